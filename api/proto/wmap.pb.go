@@ -229,16 +229,159 @@ func (x *DeviceReport) GetTimestamp() int64 {
 	return 0
 }
 
+// AlertReport carries an alert raised by an agent's sensor together with the
+// signal strength of the offending frame, so the server can correlate it.
+type AlertReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Sensor        string                 `protobuf:"bytes,2,opt,name=sensor,proto3" json:"sensor,omitempty"` // Capture interface on the agent
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Subtype       string                 `protobuf:"bytes,4,opt,name=subtype,proto3" json:"subtype,omitempty"`
+	DeviceMac     string                 `protobuf:"bytes,5,opt,name=device_mac,json=deviceMac,proto3" json:"device_mac,omitempty"`
+	TargetMac     string                 `protobuf:"bytes,6,opt,name=target_mac,json=targetMac,proto3" json:"target_mac,omitempty"`
+	Message       string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	Details       string                 `protobuf:"bytes,8,opt,name=details,proto3" json:"details,omitempty"`
+	Severity      string                 `protobuf:"bytes,9,opt,name=severity,proto3" json:"severity,omitempty"`
+	Rssi          int32                  `protobuf:"varint,10,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,11,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,12,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertReport) Reset() {
+	*x = AlertReport{}
+	mi := &file_api_proto_wmap_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertReport) ProtoMessage() {}
+
+func (x *AlertReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wmap_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertReport.ProtoReflect.Descriptor instead.
+func (*AlertReport) Descriptor() ([]byte, []int) {
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AlertReport) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AlertReport) GetSensor() string {
+	if x != nil {
+		return x.Sensor
+	}
+	return ""
+}
+
+func (x *AlertReport) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AlertReport) GetSubtype() string {
+	if x != nil {
+		return x.Subtype
+	}
+	return ""
+}
+
+func (x *AlertReport) GetDeviceMac() string {
+	if x != nil {
+		return x.DeviceMac
+	}
+	return ""
+}
+
+func (x *AlertReport) GetTargetMac() string {
+	if x != nil {
+		return x.TargetMac
+	}
+	return ""
+}
+
+func (x *AlertReport) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AlertReport) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *AlertReport) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *AlertReport) GetRssi() int32 {
+	if x != nil {
+		return x.Rssi
+	}
+	return 0
+}
+
+func (x *AlertReport) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *AlertReport) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *AlertReport) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 type ReportSummary struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	DevicesProcessed int32                  `protobuf:"varint,1,opt,name=devices_processed,json=devicesProcessed,proto3" json:"devices_processed,omitempty"`
+	AlertsProcessed  int32                  `protobuf:"varint,2,opt,name=alerts_processed,json=alertsProcessed,proto3" json:"alerts_processed,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *ReportSummary) Reset() {
 	*x = ReportSummary{}
-	mi := &file_api_proto_wmap_proto_msgTypes[1]
+	mi := &file_api_proto_wmap_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -250,7 +393,7 @@ func (x *ReportSummary) String() string {
 func (*ReportSummary) ProtoMessage() {}
 
 func (x *ReportSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_wmap_proto_msgTypes[1]
+	mi := &file_api_proto_wmap_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,7 +406,7 @@ func (x *ReportSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportSummary.ProtoReflect.Descriptor instead.
 func (*ReportSummary) Descriptor() ([]byte, []int) {
-	return file_api_proto_wmap_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ReportSummary) GetDevicesProcessed() int32 {
@@ -273,6 +416,598 @@ func (x *ReportSummary) GetDevicesProcessed() int32 {
 	return 0
 }
 
+func (x *ReportSummary) GetAlertsProcessed() int32 {
+	if x != nil {
+		return x.AlertsProcessed
+	}
+	return 0
+}
+
+// IngestRequest carries device observations exported by another tool.
+type IngestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // "wmap", "kismet" or "airodump"; detected when empty
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"` // Producing tool, defaults to the format
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestRequest) Reset() {
+	*x = IngestRequest{}
+	mi := &file_api_proto_wmap_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestRequest) ProtoMessage() {}
+
+func (x *IngestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wmap_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestRequest.ProtoReflect.Descriptor instead.
+func (*IngestRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IngestRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *IngestRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *IngestRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type IngestSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // Format the data was parsed as
+	Received      int32                  `protobuf:"varint,2,opt,name=received,proto3" json:"received,omitempty"`
+	Processed     int32                  `protobuf:"varint,3,opt,name=processed,proto3" json:"processed,omitempty"`
+	Skipped       int32                  `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Errors        []string               `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestSummary) Reset() {
+	*x = IngestSummary{}
+	mi := &file_api_proto_wmap_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestSummary) ProtoMessage() {}
+
+func (x *IngestSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wmap_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestSummary.ProtoReflect.Descriptor instead.
+func (*IngestSummary) Descriptor() ([]byte, []int) {
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *IngestSummary) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *IngestSummary) GetReceived() int32 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *IngestSummary) GetProcessed() int32 {
+	if x != nil {
+		return x.Processed
+	}
+	return 0
+}
+
+func (x *IngestSummary) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *IngestSummary) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// AgentMessage is sent by an agent on the command channel; exactly one of
+// its fields is set.
+type AgentMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hello         *AgentHello            `protobuf:"bytes,1,opt,name=hello,proto3" json:"hello,omitempty"`
+	Update        *AttackUpdate          `protobuf:"bytes,2,opt,name=update,proto3" json:"update,omitempty"`
+	ConfigAck     *ConfigAck             `protobuf:"bytes,3,opt,name=config_ack,json=configAck,proto3" json:"config_ack,omitempty"`
+	UpdateStatus  *UpdateStatus          `protobuf:"bytes,4,opt,name=update_status,json=updateStatus,proto3" json:"update_status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentMessage) Reset() {
+	*x = AgentMessage{}
+	mi := &file_api_proto_wmap_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentMessage) ProtoMessage() {}
+
+func (x *AgentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wmap_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentMessage.ProtoReflect.Descriptor instead.
+func (*AgentMessage) Descriptor() ([]byte, []int) {
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AgentMessage) GetHello() *AgentHello {
+	if x != nil {
+		return x.Hello
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetUpdate() *AttackUpdate {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetConfigAck() *ConfigAck {
+	if x != nil {
+		return x.ConfigAck
+	}
+	return nil
+}
+
+func (x *AgentMessage) GetUpdateStatus() *UpdateStatus {
+	if x != nil {
+		return x.UpdateStatus
+	}
+	return nil
+}
+
+// AgentHello identifies the agent and what it can run.
+type AgentHello struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Interfaces    []string               `protobuf:"bytes,2,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	Attacks       []string               `protobuf:"bytes,3,rep,name=attacks,proto3" json:"attacks,omitempty"`                          // Attack kinds the agent can run: "deauth", "wps"
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`                          // Agent build
+	AutoUpdate    bool                   `protobuf:"varint,5,opt,name=auto_update,json=autoUpdate,proto3" json:"auto_update,omitempty"` // Accepts "update" commands
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentHello) Reset() {
+	*x = AgentHello{}
+	mi := &file_api_proto_wmap_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentHello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentHello) ProtoMessage() {}
+
+func (x *AgentHello) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wmap_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentHello.ProtoReflect.Descriptor instead.
+func (*AgentHello) Descriptor() ([]byte, []int) {
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AgentHello) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentHello) GetInterfaces() []string {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *AgentHello) GetAttacks() []string {
+	if x != nil {
+		return x.Attacks
+	}
+	return nil
+}
+
+func (x *AgentHello) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AgentHello) GetAutoUpdate() bool {
+	if x != nil {
+		return x.AutoUpdate
+	}
+	return false
+}
+
+// AgentCommand asks an agent to start or stop an attack, or to apply a configuration.
+type AgentCommand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttackId      string                 `protobuf:"bytes,1,opt,name=attack_id,json=attackId,proto3" json:"attack_id,omitempty"` // Assigned by the server
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`                     // "start", "stop", "configure" or "update"
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`                         // Attack kind, for "start"
+	Config        []byte                 `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`                     // JSON attack configuration of the kind for "start", JSON agent configuration for "configure", JSON release for "update"
+	Revision      int64                  `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`                // Configuration revision, for "configure"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentCommand) Reset() {
+	*x = AgentCommand{}
+	mi := &file_api_proto_wmap_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentCommand) ProtoMessage() {}
+
+func (x *AgentCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wmap_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentCommand.ProtoReflect.Descriptor instead.
+func (*AgentCommand) Descriptor() ([]byte, []int) {
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AgentCommand) GetAttackId() string {
+	if x != nil {
+		return x.AttackId
+	}
+	return ""
+}
+
+func (x *AgentCommand) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AgentCommand) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AgentCommand) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *AgentCommand) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// UpdateStatus reports a failed self-update; a successful one shows as the
+// new version in the hello of the restarted agent.
+type UpdateStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"` // Version offered
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStatus) Reset() {
+	*x = UpdateStatus{}
+	mi := &file_api_proto_wmap_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStatus) ProtoMessage() {}
+
+func (x *UpdateStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wmap_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStatus.ProtoReflect.Descriptor instead.
+func (*UpdateStatus) Descriptor() ([]byte, []int) {
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateStatus) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpdateStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ConfigAck answers a "configure" command with the configuration in effect.
+type ConfigAck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int64                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`  // 0 when reporting the configuration on connect
+	Effective     []byte                 `protobuf:"bytes,2,opt,name=effective,proto3" json:"effective,omitempty"` // JSON effective configuration
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`         // Settings that could not be applied
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigAck) Reset() {
+	*x = ConfigAck{}
+	mi := &file_api_proto_wmap_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigAck) ProtoMessage() {}
+
+func (x *ConfigAck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wmap_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigAck.ProtoReflect.Descriptor instead.
+func (*ConfigAck) Descriptor() ([]byte, []int) {
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConfigAck) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *ConfigAck) GetEffective() []byte {
+	if x != nil {
+		return x.Effective
+	}
+	return nil
+}
+
+func (x *ConfigAck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// AttackUpdate reports the progress of an attack run by an agent.
+type AttackUpdate struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	AttackId               string                 `protobuf:"bytes,1,opt,name=attack_id,json=attackId,proto3" json:"attack_id,omitempty"`
+	Status                 string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // Status reported by the attack engine
+	Finished               bool                   `protobuf:"varint,3,opt,name=finished,proto3" json:"finished,omitempty"`
+	Error                  string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	PacketsSent            int32                  `protobuf:"varint,5,opt,name=packets_sent,json=packetsSent,proto3" json:"packets_sent,omitempty"`
+	HandshakeCaptured      bool                   `protobuf:"varint,6,opt,name=handshake_captured,json=handshakeCaptured,proto3" json:"handshake_captured,omitempty"`
+	DisconnectionConfirmed bool                   `protobuf:"varint,7,opt,name=disconnection_confirmed,json=disconnectionConfirmed,proto3" json:"disconnection_confirmed,omitempty"`
+	CredentialsRecovered   bool                   `protobuf:"varint,8,opt,name=credentials_recovered,json=credentialsRecovered,proto3" json:"credentials_recovered,omitempty"`
+	Timestamp              int64                  `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *AttackUpdate) Reset() {
+	*x = AttackUpdate{}
+	mi := &file_api_proto_wmap_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttackUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttackUpdate) ProtoMessage() {}
+
+func (x *AttackUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_wmap_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttackUpdate.ProtoReflect.Descriptor instead.
+func (*AttackUpdate) Descriptor() ([]byte, []int) {
+	return file_api_proto_wmap_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AttackUpdate) GetAttackId() string {
+	if x != nil {
+		return x.AttackId
+	}
+	return ""
+}
+
+func (x *AttackUpdate) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AttackUpdate) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *AttackUpdate) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AttackUpdate) GetPacketsSent() int32 {
+	if x != nil {
+		return x.PacketsSent
+	}
+	return 0
+}
+
+func (x *AttackUpdate) GetHandshakeCaptured() bool {
+	if x != nil {
+		return x.HandshakeCaptured
+	}
+	return false
+}
+
+func (x *AttackUpdate) GetDisconnectionConfirmed() bool {
+	if x != nil {
+		return x.DisconnectionConfirmed
+	}
+	return false
+}
+
+func (x *AttackUpdate) GetCredentialsRecovered() bool {
+	if x != nil {
+		return x.CredentialsRecovered
+	}
+	return false
+}
+
+func (x *AttackUpdate) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 var File_api_proto_wmap_proto protoreflect.FileDescriptor
 
 const file_api_proto_wmap_proto_rawDesc = "" +
@@ -301,11 +1036,81 @@ const file_api_proto_wmap_proto_rawDesc = "" +
 	"retryCount\x12#\n" +
 	"\rchannel_width\x18\x15 \x01(\x05R\fchannelWidth\x12\x12\n" +
 	"\x04type\x18\v \x01(\tR\x04type\x12\x1c\n" +
-	"\ttimestamp\x18\f \x01(\x03R\ttimestamp\"<\n" +
+	"\ttimestamp\x18\f \x01(\x03R\ttimestamp\"\xe8\x02\n" +
+	"\vAlertReport\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x16\n" +
+	"\x06sensor\x18\x02 \x01(\tR\x06sensor\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\asubtype\x18\x04 \x01(\tR\asubtype\x12\x1d\n" +
+	"\n" +
+	"device_mac\x18\x05 \x01(\tR\tdeviceMac\x12\x1d\n" +
+	"\n" +
+	"target_mac\x18\x06 \x01(\tR\ttargetMac\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x12\x18\n" +
+	"\adetails\x18\b \x01(\tR\adetails\x12\x1a\n" +
+	"\bseverity\x18\t \x01(\tR\bseverity\x12\x12\n" +
+	"\x04rssi\x18\n" +
+	" \x01(\x05R\x04rssi\x12\x1a\n" +
+	"\blatitude\x18\v \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\f \x01(\x01R\tlongitude\x12\x1c\n" +
+	"\ttimestamp\x18\r \x01(\x03R\ttimestamp\"g\n" +
 	"\rReportSummary\x12+\n" +
-	"\x11devices_processed\x18\x01 \x01(\x05R\x10devicesProcessed2I\n" +
+	"\x11devices_processed\x18\x01 \x01(\x05R\x10devicesProcessed\x12)\n" +
+	"\x10alerts_processed\x18\x02 \x01(\x05R\x0falertsProcessed\"S\n" +
+	"\rIngestRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\x93\x01\n" +
+	"\rIngestSummary\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x1a\n" +
+	"\breceived\x18\x02 \x01(\x05R\breceived\x12\x1c\n" +
+	"\tprocessed\x18\x03 \x01(\x05R\tprocessed\x12\x18\n" +
+	"\askipped\x18\x04 \x01(\x05R\askipped\x12\x16\n" +
+	"\x06errors\x18\x05 \x03(\tR\x06errors\"\xcb\x01\n" +
+	"\fAgentMessage\x12&\n" +
+	"\x05hello\x18\x01 \x01(\v2\x10.wmap.AgentHelloR\x05hello\x12*\n" +
+	"\x06update\x18\x02 \x01(\v2\x12.wmap.AttackUpdateR\x06update\x12.\n" +
+	"\n" +
+	"config_ack\x18\x03 \x01(\v2\x0f.wmap.ConfigAckR\tconfigAck\x127\n" +
+	"\rupdate_status\x18\x04 \x01(\v2\x12.wmap.UpdateStatusR\fupdateStatus\"\x9c\x01\n" +
+	"\n" +
+	"AgentHello\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1e\n" +
+	"\n" +
+	"interfaces\x18\x02 \x03(\tR\n" +
+	"interfaces\x12\x18\n" +
+	"\aattacks\x18\x03 \x03(\tR\aattacks\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\x1f\n" +
+	"\vauto_update\x18\x05 \x01(\bR\n" +
+	"autoUpdate\"\x8b\x01\n" +
+	"\fAgentCommand\x12\x1b\n" +
+	"\tattack_id\x18\x01 \x01(\tR\battackId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06config\x18\x04 \x01(\fR\x06config\x12\x1a\n" +
+	"\brevision\x18\x05 \x01(\x03R\brevision\">\n" +
+	"\fUpdateStatus\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"[\n" +
+	"\tConfigAck\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\x12\x1c\n" +
+	"\teffective\x18\x02 \x01(\fR\teffective\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xd3\x02\n" +
+	"\fAttackUpdate\x12\x1b\n" +
+	"\tattack_id\x18\x01 \x01(\tR\battackId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\bfinished\x18\x03 \x01(\bR\bfinished\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12!\n" +
+	"\fpackets_sent\x18\x05 \x01(\x05R\vpacketsSent\x12-\n" +
+	"\x12handshake_captured\x18\x06 \x01(\bR\x11handshakeCaptured\x127\n" +
+	"\x17disconnection_confirmed\x18\a \x01(\bR\x16disconnectionConfirmed\x123\n" +
+	"\x15credentials_recovered\x18\b \x01(\bR\x14credentialsRecovered\x12\x1c\n" +
+	"\ttimestamp\x18\t \x01(\x03R\ttimestamp2\xef\x01\n" +
 	"\vWMapService\x12:\n" +
-	"\rReportTraffic\x12\x12.wmap.DeviceReport\x1a\x13.wmap.ReportSummary(\x01B1Z/github.com/lcalzada-xor/wmap/api/grpc;wmap_grpcb\x06proto3"
+	"\rReportTraffic\x12\x12.wmap.DeviceReport\x1a\x13.wmap.ReportSummary(\x01\x128\n" +
+	"\fReportAlerts\x12\x11.wmap.AlertReport\x1a\x13.wmap.ReportSummary(\x01\x122\n" +
+	"\x06Ingest\x12\x13.wmap.IngestRequest\x1a\x13.wmap.IngestSummary\x126\n" +
+	"\bCommands\x12\x12.wmap.AgentMessage\x1a\x12.wmap.AgentCommand(\x010\x01B1Z/github.com/lcalzada-xor/wmap/api/grpc;wmap_grpcb\x06proto3"
 
 var (
 	file_api_proto_wmap_proto_rawDescOnce sync.Once
@@ -319,19 +1124,38 @@ func file_api_proto_wmap_proto_rawDescGZIP() []byte {
 	return file_api_proto_wmap_proto_rawDescData
 }
 
-var file_api_proto_wmap_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_proto_wmap_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_api_proto_wmap_proto_goTypes = []any{
 	(*DeviceReport)(nil),  // 0: wmap.DeviceReport
-	(*ReportSummary)(nil), // 1: wmap.ReportSummary
+	(*AlertReport)(nil),   // 1: wmap.AlertReport
+	(*ReportSummary)(nil), // 2: wmap.ReportSummary
+	(*IngestRequest)(nil), // 3: wmap.IngestRequest
+	(*IngestSummary)(nil), // 4: wmap.IngestSummary
+	(*AgentMessage)(nil),  // 5: wmap.AgentMessage
+	(*AgentHello)(nil),    // 6: wmap.AgentHello
+	(*AgentCommand)(nil),  // 7: wmap.AgentCommand
+	(*UpdateStatus)(nil),  // 8: wmap.UpdateStatus
+	(*ConfigAck)(nil),     // 9: wmap.ConfigAck
+	(*AttackUpdate)(nil),  // 10: wmap.AttackUpdate
 }
 var file_api_proto_wmap_proto_depIdxs = []int32{
-	0, // 0: wmap.WMapService.ReportTraffic:input_type -> wmap.DeviceReport
-	1, // 1: wmap.WMapService.ReportTraffic:output_type -> wmap.ReportSummary
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	6,  // 0: wmap.AgentMessage.hello:type_name -> wmap.AgentHello
+	10, // 1: wmap.AgentMessage.update:type_name -> wmap.AttackUpdate
+	9,  // 2: wmap.AgentMessage.config_ack:type_name -> wmap.ConfigAck
+	8,  // 3: wmap.AgentMessage.update_status:type_name -> wmap.UpdateStatus
+	0,  // 4: wmap.WMapService.ReportTraffic:input_type -> wmap.DeviceReport
+	1,  // 5: wmap.WMapService.ReportAlerts:input_type -> wmap.AlertReport
+	3,  // 6: wmap.WMapService.Ingest:input_type -> wmap.IngestRequest
+	5,  // 7: wmap.WMapService.Commands:input_type -> wmap.AgentMessage
+	2,  // 8: wmap.WMapService.ReportTraffic:output_type -> wmap.ReportSummary
+	2,  // 9: wmap.WMapService.ReportAlerts:output_type -> wmap.ReportSummary
+	4,  // 10: wmap.WMapService.Ingest:output_type -> wmap.IngestSummary
+	7,  // 11: wmap.WMapService.Commands:output_type -> wmap.AgentCommand
+	8,  // [8:12] is the sub-list for method output_type
+	4,  // [4:8] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_wmap_proto_init() }
@@ -345,7 +1169,7 @@ func file_api_proto_wmap_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_wmap_proto_rawDesc), len(file_api_proto_wmap_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
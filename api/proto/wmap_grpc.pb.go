@@ -20,6 +20,9 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	WMapService_ReportTraffic_FullMethodName = "/wmap.WMapService/ReportTraffic"
+	WMapService_ReportAlerts_FullMethodName  = "/wmap.WMapService/ReportAlerts"
+	WMapService_Ingest_FullMethodName        = "/wmap.WMapService/Ingest"
+	WMapService_Commands_FullMethodName      = "/wmap.WMapService/Commands"
 )
 
 // WMapServiceClient is the client API for WMapService service.
@@ -30,6 +33,14 @@ const (
 type WMapServiceClient interface {
 	// ReportTraffic streams captured device data from agent to server.
 	ReportTraffic(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[DeviceReport, ReportSummary], error)
+	// ReportAlerts streams locally raised alerts from agent to server.
+	ReportAlerts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AlertReport, ReportSummary], error)
+	// Ingest imports device observations produced by other tools.
+	Ingest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*IngestSummary, error)
+	// Commands opens the server to agent command channel. The agent sends a
+	// hello first and then the status of the attacks it runs; the server sends
+	// the commands for the agent.
+	Commands(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AgentMessage, AgentCommand], error)
 }
 
 type wMapServiceClient struct {
@@ -53,6 +64,42 @@ func (c *wMapServiceClient) ReportTraffic(ctx context.Context, opts ...grpc.Call
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WMapService_ReportTrafficClient = grpc.ClientStreamingClient[DeviceReport, ReportSummary]
 
+func (c *wMapServiceClient) ReportAlerts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[AlertReport, ReportSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WMapService_ServiceDesc.Streams[1], WMapService_ReportAlerts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AlertReport, ReportSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WMapService_ReportAlertsClient = grpc.ClientStreamingClient[AlertReport, ReportSummary]
+
+func (c *wMapServiceClient) Ingest(ctx context.Context, in *IngestRequest, opts ...grpc.CallOption) (*IngestSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestSummary)
+	err := c.cc.Invoke(ctx, WMapService_Ingest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *wMapServiceClient) Commands(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AgentMessage, AgentCommand], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WMapService_ServiceDesc.Streams[2], WMapService_Commands_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AgentMessage, AgentCommand]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WMapService_CommandsClient = grpc.BidiStreamingClient[AgentMessage, AgentCommand]
+
 // WMapServiceServer is the server API for WMapService service.
 // All implementations must embed UnimplementedWMapServiceServer
 // for forward compatibility.
@@ -61,6 +108,14 @@ type WMapService_ReportTrafficClient = grpc.ClientStreamingClient[DeviceReport,
 type WMapServiceServer interface {
 	// ReportTraffic streams captured device data from agent to server.
 	ReportTraffic(grpc.ClientStreamingServer[DeviceReport, ReportSummary]) error
+	// ReportAlerts streams locally raised alerts from agent to server.
+	ReportAlerts(grpc.ClientStreamingServer[AlertReport, ReportSummary]) error
+	// Ingest imports device observations produced by other tools.
+	Ingest(context.Context, *IngestRequest) (*IngestSummary, error)
+	// Commands opens the server to agent command channel. The agent sends a
+	// hello first and then the status of the attacks it runs; the server sends
+	// the commands for the agent.
+	Commands(grpc.BidiStreamingServer[AgentMessage, AgentCommand]) error
 	mustEmbedUnimplementedWMapServiceServer()
 }
 
@@ -74,6 +129,15 @@ type UnimplementedWMapServiceServer struct{}
 func (UnimplementedWMapServiceServer) ReportTraffic(grpc.ClientStreamingServer[DeviceReport, ReportSummary]) error {
 	return status.Error(codes.Unimplemented, "method ReportTraffic not implemented")
 }
+func (UnimplementedWMapServiceServer) ReportAlerts(grpc.ClientStreamingServer[AlertReport, ReportSummary]) error {
+	return status.Error(codes.Unimplemented, "method ReportAlerts not implemented")
+}
+func (UnimplementedWMapServiceServer) Ingest(context.Context, *IngestRequest) (*IngestSummary, error) {
+	return nil, status.Error(codes.Unimplemented, "method Ingest not implemented")
+}
+func (UnimplementedWMapServiceServer) Commands(grpc.BidiStreamingServer[AgentMessage, AgentCommand]) error {
+	return status.Error(codes.Unimplemented, "method Commands not implemented")
+}
 func (UnimplementedWMapServiceServer) mustEmbedUnimplementedWMapServiceServer() {}
 func (UnimplementedWMapServiceServer) testEmbeddedByValue()                     {}
 
@@ -102,19 +166,67 @@ func _WMapService_ReportTraffic_Handler(srv interface{}, stream grpc.ServerStrea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WMapService_ReportTrafficServer = grpc.ClientStreamingServer[DeviceReport, ReportSummary]
 
+func _WMapService_ReportAlerts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WMapServiceServer).ReportAlerts(&grpc.GenericServerStream[AlertReport, ReportSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WMapService_ReportAlertsServer = grpc.ClientStreamingServer[AlertReport, ReportSummary]
+
+func _WMapService_Ingest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WMapServiceServer).Ingest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WMapService_Ingest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WMapServiceServer).Ingest(ctx, req.(*IngestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WMapService_Commands_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WMapServiceServer).Commands(&grpc.GenericServerStream[AgentMessage, AgentCommand]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WMapService_CommandsServer = grpc.BidiStreamingServer[AgentMessage, AgentCommand]
+
 // WMapService_ServiceDesc is the grpc.ServiceDesc for WMapService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var WMapService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "wmap.WMapService",
 	HandlerType: (*WMapServiceServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ingest",
+			Handler:    _WMapService_Ingest_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "ReportTraffic",
 			Handler:       _WMapService_ReportTraffic_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "ReportAlerts",
+			Handler:       _WMapService_ReportAlerts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Commands",
+			Handler:       _WMapService_Commands_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "api/proto/wmap.proto",
 }
@@ -10,22 +10,82 @@ import (
 	"syscall"
 
 	wmap_grpc "github.com/lcalzada-xor/wmap/api/proto"
+	"github.com/lcalzada-xor/wmap/internal/adapters/agent"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/deauth"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/wps"
 	"github.com/lcalzada-xor/wmap/internal/adapters/fingerprint"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
 	"github.com/lcalzada-xor/wmap/internal/geo"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
+// version is set at build time with -ldflags "-X main.version=...". Releases
+// advertised by the server with another version are installed on self-update.
+var version = "dev"
+
 func main() {
 	serverAddr := flag.String("server", "localhost:9000", "WMAP Server Address")
 	iface := flag.String("i", "wlan0", "Monitor Interface")
 	lat := flag.Float64("lat", 0.0, "Latitude")
 	lng := flag.Float64("lng", 0.0, "Longitude")
+	hostname, _ := os.Hostname()
+	agentID := flag.String("id", hostname, "Agent ID, used to tell sensors apart on the server")
+	allowAttacks := flag.Bool("allow-attacks", false, "Run the deauth and WPS attacks the server commands, using the local interfaces")
+	reaverPath := flag.String("reaver-path", "reaver", "Path to the reaver binary, for commanded WPS attacks")
+	pixiewpsPath := flag.String("pixiewps-path", "pixiewps", "Path to the pixiewps binary, for commanded WPS attacks")
+	tlsCA := flag.String("tls-ca", "", "CA certificate verifying the server (default: system roots)")
+	tlsCert := flag.String("tls-cert", "", "Agent certificate for servers requiring mutual TLS; its common name must match -id")
+	tlsKey := flag.String("tls-key", "", "Private key of the agent certificate")
+	insecureConn := flag.Bool("insecure", false, "Connect without TLS (lab use only: reports travel in the clear and no API key is sent)")
+	releaseKey := flag.String("release-key", "", "Base64 Ed25519 public key of agent releases; enables self-update to the releases the server advertises")
+	profileName := flag.String("profile", envOr("WMAP_PROFILE", "full"), "Runtime profile: full, or embedded for 256 MB boards (no attack engines, smaller queues and caches)")
 	flag.Parse()
 
+	prof, err := lookupProfile(*profileName)
+	if err != nil {
+		log.Fatalf("Invalid -profile: %v", err)
+	}
+	prof.applyMemoryLimit()
+	if *allowAttacks && !prof.Attacks {
+		log.Printf("Warning: the %s profile has no attack engines, ignoring -allow-attacks", *profileName)
+		*allowAttacks = false
+	}
+
+	var updater *agent.Updater
+	if *releaseKey != "" {
+		key, err := agent.ParsePublicKey(*releaseKey)
+		if err != nil {
+			log.Fatalf("Invalid -release-key: %v", err)
+		}
+		updater = agent.NewUpdater(key, version)
+	}
+	log.Printf("wmap-agent %s (%s profile)", version, *profileName)
+
 	// 1. Connect to gRPC Server
-	conn, err := grpc.NewClient(*serverAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	var dialOpts []grpc.DialOption
+	if *insecureConn {
+		log.Printf("Warning: connecting to %s without TLS", *serverAddr)
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		creds, err := agent.TLSCredentials(*tlsCA, *tlsCert, *tlsKey)
+		if err != nil {
+			log.Fatalf("Invalid TLS configuration: %v", err)
+		}
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
+	}
+	// The API key only comes from the environment, a flag would show it in ps
+	if key := os.Getenv("WMAP_API_KEY"); key != "" {
+		if *insecureConn {
+			log.Fatalf("WMAP_API_KEY is only sent over TLS, drop -insecure")
+		}
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(agent.APIKeyCredentials(key)))
+	}
+	conn, err := grpc.NewClient(*serverAddr, dialOpts...)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -52,7 +112,7 @@ func main() {
 	// Create Manager
 	// Dwell time hardcoded/flag? currently implicit. Let's say 300ms default.
 	// Init OUI DB
-	ouiDB, _ := fingerprint.NewOUIDatabase("/data/oui.txt", 10000, nil)
+	ouiDB, _ := fingerprint.NewOUIDatabase("/data/oui.txt", prof.OUICache, nil)
 	// Create manager (using OUI DB, even if nil/empty, or use static fallback)
 	var repo fingerprint.VendorRepository = ouiDB
 	if ouiDB == nil {
@@ -60,6 +120,10 @@ func main() {
 	}
 
 	manager := sniffer.NewManager(ifaceList, 300, false, geo.NewStaticProvider(*lat, *lng), repo)
+	manager.PacketQueue = prof.PacketQueue
+	manager.Workers = prof.Workers
+	manager.Output = make(chan domain.Device, prof.DeviceBuffer)
+	manager.Alerts = make(chan domain.Alert, prof.AlertBuffer)
 	// Override output channels to ours?
 	// The manager creates its own output channels. We should use them.
 	// But wait, NewManager creates them. We can just read from manager.Output / manager.Alerts
@@ -74,15 +138,35 @@ func main() {
 		}
 	}()
 
+	// Accept commands, configuration pushes and releases from the server on
+	// their own channel; attacks only when allowed
+	exec := agent.NewExecutor(nil, nil)
+	if *allowAttacks {
+		exec = newExecutor(manager, ifaceList[0], *reaverPath, *pixiewpsPath)
+	}
+	go agent.Serve(ctx, client, agent.Agent{
+		ID:         *agentID,
+		Version:    version,
+		Interfaces: ifaceList,
+		Executor:   exec,
+		Sensor:     manager,
+		Updater:    updater,
+	})
+
 	// Use manager's channels for the loop
 	// We need to re-assign deviceChan and alertChan to point to manager's
 	// But we declared them above. Let's just alias them or use manager.Output directly in the loop.
 
 	// 3. Stream Data to Server
-	stream, err := client.ReportTraffic(ctx)
+	// The agent ID lets the server route our devices to a workspace
+	stream, err := client.ReportTraffic(metadata.AppendToOutgoingContext(ctx, domain.AgentIDMetadata, *agentID))
 	if err != nil {
 		log.Fatalf("could not create stream: %v", err)
 	}
+	alertStream, err := client.ReportAlerts(ctx)
+	if err != nil {
+		log.Fatalf("could not create alert stream: %v", err)
+	}
 
 	log.Printf("Agent started. Streaming to %s via %s", *serverAddr, *iface)
 
@@ -90,6 +174,7 @@ func main() {
 		select {
 		case <-ctx.Done():
 			stream.CloseSend()
+			alertStream.CloseSend()
 			return
 		case d := <-manager.Output:
 			// Convert Domain Device to Proto Device
@@ -117,7 +202,53 @@ func main() {
 			}
 		case a := <-manager.Alerts:
 			log.Printf("[ALERT] %s: %s -> %s (%s)", a.Type, a.DeviceMAC, a.TargetMAC, a.Subtype)
-			// TODO: Send alerts to server
+			req := &wmap_grpc.AlertReport{
+				AgentId:   *agentID,
+				Sensor:    a.Sensor,
+				Type:      string(a.Type),
+				Subtype:   a.Subtype,
+				DeviceMac: a.DeviceMAC,
+				TargetMac: a.TargetMAC,
+				Message:   a.Message,
+				Details:   a.Details,
+				Severity:  string(a.Severity),
+				Rssi:      int32(a.RSSI),
+				Latitude:  a.Latitude,
+				Longitude: a.Longitude,
+				Timestamp: a.Timestamp.Unix(),
+			}
+			if err := alertStream.Send(req); err != nil {
+				log.Printf("Failed to send alert: %v", err)
+			}
 		}
 	}
 }
+
+// newExecutor sets up the local attack engines on the manager's interfaces,
+// as the server does for its own.
+func newExecutor(manager *sniffer.SnifferManager, iface, reaverPath, pixiewpsPath string) *agent.Executor {
+	injector := manager.GetInjector(iface)
+	if injector == nil {
+		var err error
+		if injector, err = injection.NewInjector(iface); err != nil {
+			log.Printf("Warning: Failed to create injector, deauth attacks disabled: %v", err)
+		}
+	}
+
+	var deauthEngine ports.DeauthService
+	if injector != nil {
+		deauthEngine = deauth.NewDeauthEngine(injector, manager, 5)
+	}
+	wpsEngine := wps.NewWPSEngine(nil)
+	wpsEngine.SetChannelLocker(manager)
+	wpsEngine.SetToolPaths(reaverPath, pixiewpsPath)
+	return agent.NewExecutor(deauthEngine, wpsEngine)
+}
+
+// envOr returns the environment variable key, or fallback if unset.
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+)
+
+// profile sizes the agent for the board it runs on.
+type profile struct {
+	Attacks      bool  // Attack engines may be loaded
+	PacketQueue  int   // Packets buffered per interface, 0 for the sniffer default
+	Workers      int   // Packet workers per interface, 0 for one per CPU
+	DeviceBuffer int   // Devices waiting to be streamed to the server
+	AlertBuffer  int   // Alerts waiting to be streamed to the server
+	OUICache     int   // Vendor lookups kept in memory
+	MemoryLimit  int64 // Soft limit for the Go runtime in bytes, 0 for none
+}
+
+// profiles are selected with -profile or WMAP_PROFILE. The embedded profile
+// keeps 256 MB ARM boards clear of the OOM killer: no attack engines, short
+// queues that drop bursts instead of buffering them, and a soft memory limit
+// making the garbage collector work harder before the kernel steps in.
+var profiles = map[string]profile{
+	"full": {
+		Attacks:      true,
+		DeviceBuffer: 1000,
+		AlertBuffer:  100,
+		OUICache:     10000,
+	},
+	"embedded": {
+		PacketQueue:  500,
+		Workers:      1,
+		DeviceBuffer: 200,
+		AlertBuffer:  50,
+		OUICache:     500,
+		MemoryLimit:  160 << 20,
+	},
+}
+
+// lookupProfile returns the profile called name.
+func lookupProfile(name string) (profile, error) {
+	p, ok := profiles[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for n := range profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return profile{}, fmt.Errorf("unknown profile %q, expected one of %s", name, strings.Join(names, ", "))
+	}
+	return p, nil
+}
+
+// applyMemoryLimit sets the profile's soft memory limit, unless GOMEMLIMIT
+// already sets one.
+func (p profile) applyMemoryLimit() {
+	if p.MemoryLimit > 0 && os.Getenv("GOMEMLIMIT") == "" {
+		debug.SetMemoryLimit(p.MemoryLimit)
+	}
+}
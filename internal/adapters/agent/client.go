@@ -0,0 +1,192 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	wmap_grpc "github.com/lcalzada-xor/wmap/api/proto"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// RetryDelay is the wait before reopening a failed command channel.
+const RetryDelay = 5 * time.Second
+
+// Agent is the local end of the command channel.
+type Agent struct {
+	ID         string
+	Version    string
+	Interfaces []string
+	Executor   *Executor // Runs the attacks
+	Sensor     Sensor    // Configured by the server; nil when not configurable
+	Updater    *Updater  // Installs advertised releases; nil disables self-update
+}
+
+// Serve keeps the command channel to the server open, reconnecting after
+// failures, and runs the commands received until ctx is done.
+func Serve(ctx context.Context, client wmap_grpc.WMapServiceClient, a Agent) {
+	hello := &wmap_grpc.AgentHello{AgentId: a.ID, Interfaces: a.Interfaces, Version: a.Version, AutoUpdate: a.Updater != nil}
+	for _, kind := range a.Executor.Attacks() {
+		hello.Attacks = append(hello.Attacks, string(kind))
+	}
+
+	for {
+		err := serve(ctx, client, hello, a)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Command channel closed: %v; reconnecting in %s", err, RetryDelay)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(RetryDelay):
+		}
+	}
+}
+
+func serve(ctx context.Context, client wmap_grpc.WMapServiceClient, hello *wmap_grpc.AgentHello, a Agent) error {
+	stream, err := client.Commands(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&wmap_grpc.AgentMessage{Hello: hello}); err != nil {
+		return err
+	}
+	log.Printf("Command channel open, accepting %v attacks", hello.Attacks)
+	if a.Sensor != nil {
+		// Let the server know what the agent runs with before any push
+		effective := EffectiveConfig(ctx, a.Sensor, hello.Interfaces)
+		if err := stream.Send(&wmap_grpc.AgentMessage{ConfigAck: ackToProto(0, effective, nil)}); err != nil {
+			return err
+		}
+	}
+
+	// Acks and update failures are sent from this goroutine only, like the
+	// attack updates
+	acks := make(chan *wmap_grpc.ConfigAck, 1)
+	updateFailures := make(chan *wmap_grpc.UpdateStatus, 1)
+	var updating atomic.Bool
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			cmd, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			if cmd.Action == string(domain.AgentCommandConfigure) {
+				log.Printf("[COMMAND] configure revision %d", cmd.Revision)
+				select {
+				case acks <- configure(ctx, a.Sensor, hello.Interfaces, cmd):
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+			if cmd.Action == string(domain.AgentCommandUpdate) {
+				// Downloads take a while; commands keep flowing meanwhile
+				if updating.CompareAndSwap(false, true) {
+					go func() {
+						defer updating.Store(false)
+						if status := update(ctx, a.Updater, cmd); status != nil {
+							select {
+							case updateFailures <- status:
+							case <-ctx.Done():
+							}
+						}
+					}()
+				}
+				continue
+			}
+			log.Printf("[COMMAND] %s %s attack %s", cmd.Action, cmd.Kind, cmd.AttackId)
+			a.Executor.Handle(ctx, domain.AgentCommand{
+				AttackID: cmd.AttackId,
+				Action:   domain.AgentCommandAction(cmd.Action),
+				Kind:     domain.AttackKind(cmd.Kind),
+				Config:   cmd.Config,
+			})
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			stream.CloseSend()
+			return ctx.Err()
+		case err := <-recvErr:
+			return err
+		case status := <-updateFailures:
+			if err := stream.Send(&wmap_grpc.AgentMessage{UpdateStatus: status}); err != nil {
+				return err
+			}
+		case ack := <-acks:
+			if err := stream.Send(&wmap_grpc.AgentMessage{ConfigAck: ack}); err != nil {
+				return err
+			}
+		case u := <-a.Executor.Updates():
+			if err := stream.Send(&wmap_grpc.AgentMessage{Update: updateToProto(u)}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// configure applies a configure command and returns the acknowledgment.
+func configure(ctx context.Context, sensor Sensor, interfaces []string, cmd *wmap_grpc.AgentCommand) *wmap_grpc.ConfigAck {
+	if sensor == nil {
+		return &wmap_grpc.ConfigAck{Revision: cmd.Revision, Error: "remote configuration is not supported by this agent"}
+	}
+	var config domain.AgentConfig
+	if err := json.Unmarshal(cmd.Config, &config); err != nil {
+		return ackToProto(cmd.Revision, EffectiveConfig(ctx, sensor, interfaces), fmt.Errorf("invalid configuration: %w", err))
+	}
+	effective, err := ApplyConfig(ctx, sensor, interfaces, config)
+	if err != nil {
+		log.Printf("Configuration revision %d partly applied: %v", cmd.Revision, err)
+	}
+	return ackToProto(cmd.Revision, effective, err)
+}
+
+// update installs the release of an update command and restarts into it.
+// It returns the failure to report, if any.
+func update(ctx context.Context, updater *Updater, cmd *wmap_grpc.AgentCommand) *wmap_grpc.UpdateStatus {
+	var release domain.AgentUpdate
+	if err := json.Unmarshal(cmd.Config, &release); err != nil {
+		return &wmap_grpc.UpdateStatus{Error: "invalid release: " + err.Error()}
+	}
+	if updater == nil {
+		return &wmap_grpc.UpdateStatus{Version: release.Version, Error: "self-update is disabled on this agent"}
+	}
+	log.Printf("[COMMAND] update to %s from %s", release.Version, release.URL)
+	if err := updater.Apply(ctx, release); err != nil {
+		log.Printf("Update to %s failed: %v", release.Version, err)
+		return &wmap_grpc.UpdateStatus{Version: release.Version, Error: err.Error()}
+	}
+	return nil
+}
+
+func ackToProto(revision int64, effective domain.AgentEffectiveConfig, err error) *wmap_grpc.ConfigAck {
+	ack := &wmap_grpc.ConfigAck{Revision: revision}
+	ack.Effective, _ = json.Marshal(effective)
+	if err != nil {
+		ack.Error = err.Error()
+	}
+	return ack
+}
+
+func updateToProto(u domain.RemoteAttackUpdate) *wmap_grpc.AttackUpdate {
+	return &wmap_grpc.AttackUpdate{
+		AttackId:               u.AttackID,
+		Status:                 u.Status,
+		Finished:               u.Finished,
+		Error:                  u.Error,
+		PacketsSent:            int32(u.PacketsSent),
+		HandshakeCaptured:      u.HandshakeCaptured,
+		DisconnectionConfirmed: u.DisconnectionConfirmed,
+		CredentialsRecovered:   u.CredentialsRecovered,
+		Timestamp:              u.Timestamp.Unix(),
+	}
+}
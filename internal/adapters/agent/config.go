@@ -0,0 +1,81 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// Sensor is the part of the agent's sniffer the server configures,
+// implemented by the sniffer manager.
+type Sensor interface {
+	GetInterfaceChannels(ctx context.Context, iface string) ([]int, error)
+	SetInterfaceChannels(ctx context.Context, iface string, channels []int)
+	CaptureSettings() domain.CaptureSettings
+	SetDwellTime(ms int)
+	SetBPFFilter(filter string) error
+	SetDropBadFCS(drop bool)
+}
+
+// ApplyConfig applies a configuration pushed by the server to the sensor.
+// Settings that fail are reported in the error while the others still
+// apply; the returned configuration is the one in effect either way.
+func ApplyConfig(ctx context.Context, sensor Sensor, interfaces []string, config domain.AgentConfig) (domain.AgentEffectiveConfig, error) {
+	var errs []error
+	for _, iface := range sortedKeys(config.Channels) {
+		if !contains(interfaces, iface) {
+			errs = append(errs, fmt.Errorf("no interface %s", iface))
+			continue
+		}
+		sensor.SetInterfaceChannels(ctx, iface, config.Channels[iface])
+	}
+	if config.DwellMs > 0 {
+		sensor.SetDwellTime(config.DwellMs)
+	}
+	if config.BPFFilter != "" {
+		if err := sensor.SetBPFFilter(config.BPFFilter); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if config.DropBadFCS != nil {
+		sensor.SetDropBadFCS(*config.DropBadFCS)
+	}
+	return EffectiveConfig(ctx, sensor, interfaces), errors.Join(errs...)
+}
+
+// EffectiveConfig reads the configuration the sensor runs with.
+func EffectiveConfig(ctx context.Context, sensor Sensor, interfaces []string) domain.AgentEffectiveConfig {
+	effective := domain.AgentEffectiveConfig{
+		Channels:        make(map[string][]int, len(interfaces)),
+		CaptureSettings: sensor.CaptureSettings(),
+	}
+	for _, iface := range interfaces {
+		channels, err := sensor.GetInterfaceChannels(ctx, iface)
+		if err != nil {
+			continue
+		}
+		effective.Channels[iface] = channels
+	}
+	return effective
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string][]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSensor struct {
+	channels map[string][]int
+	settings domain.CaptureSettings
+}
+
+func (f *fakeSensor) GetInterfaceChannels(ctx context.Context, iface string) ([]int, error) {
+	return f.channels[iface], nil
+}
+
+func (f *fakeSensor) SetInterfaceChannels(ctx context.Context, iface string, channels []int) {
+	f.channels[iface] = channels
+}
+
+func (f *fakeSensor) CaptureSettings() domain.CaptureSettings { return f.settings }
+func (f *fakeSensor) SetDwellTime(ms int)                     { f.settings.DwellMs = ms }
+func (f *fakeSensor) SetDropBadFCS(drop bool)                 { f.settings.DropBadFCS = drop }
+
+func (f *fakeSensor) SetBPFFilter(filter string) error {
+	if filter == "bogus" {
+		return errors.New("syntax error")
+	}
+	f.settings.BPFFilter = filter
+	return nil
+}
+
+func TestApplyConfig(t *testing.T) {
+	ctx := context.Background()
+	sensor := &fakeSensor{
+		channels: map[string][]int{"wlan1": {1, 6, 11}},
+		settings: domain.CaptureSettings{DwellMs: 300, BPFFilter: "type mgt or type data"},
+	}
+	drop := true
+
+	effective, err := ApplyConfig(ctx, sensor, []string{"wlan1"}, domain.AgentConfig{
+		Channels:   map[string][]int{"wlan1": {36, 40}},
+		DwellMs:    150,
+		DropBadFCS: &drop,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{36, 40}, effective.Channels["wlan1"])
+	assert.Equal(t, 150, effective.DwellMs)
+	assert.True(t, effective.DropBadFCS)
+
+	// Failing settings are reported while the others still apply
+	effective, err = ApplyConfig(ctx, sensor, []string{"wlan1"}, domain.AgentConfig{
+		Channels:  map[string][]int{"wlan9": {1}},
+		BPFFilter: "bogus",
+		DwellMs:   250,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "wlan9")
+	assert.Contains(t, err.Error(), "syntax error")
+	assert.Equal(t, 250, effective.DwellMs)
+	assert.Equal(t, "type mgt or type data", effective.BPFFilter)
+	assert.NotContains(t, effective.Channels, "wlan9")
+}
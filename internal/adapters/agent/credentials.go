@@ -0,0 +1,58 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"google.golang.org/grpc/credentials"
+)
+
+// apiKeyCredentials sends an API key with every call to the server.
+type apiKeyCredentials struct {
+	key string
+}
+
+// APIKeyCredentials authenticates the agent to servers that require an API
+// key. The key is only sent over TLS.
+func APIKeyCredentials(key string) credentials.PerRPCCredentials {
+	return apiKeyCredentials{key: key}
+}
+
+func (c apiKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{strings.ToLower(domain.APIKeyHeader): c.key}, nil
+}
+
+func (c apiKeyCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// TLSCredentials verifies the server against caFile, or the system roots
+// when empty. With certFile and keyFile the agent presents its certificate,
+// for servers requiring mutual TLS; its common name is the agent's identity.
+func TLSCredentials(caFile, certFile, keyFile string) (credentials.TransportCredentials, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading server CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in server CA %s", caFile)
+		}
+		config.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading agent certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(config), nil
+}
@@ -0,0 +1,219 @@
+// Package agent runs on wmap-agent the attacks and configuration changes
+// commanded by the server.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// DefaultPollInterval is how often the progress of running attacks is reported.
+const DefaultPollInterval = 2 * time.Second
+
+// updateBuffer is how many updates may wait for the command channel.
+const updateBuffer = 64
+
+// Executor starts attacks on the agent's local engines and reports their
+// progress until they finish.
+type Executor struct {
+	deauth  ports.DeauthService
+	wps     ports.WPSAttackService
+	updates chan domain.RemoteAttackUpdate
+
+	// PollInterval is how often running attacks are polled for progress.
+	PollInterval time.Duration
+
+	mu      sync.Mutex
+	running map[string]localAttack // By server attack ID
+}
+
+type localAttack struct {
+	kind    domain.AttackKind
+	localID string
+}
+
+// NewExecutor creates an executor. Either engine may be nil when the agent
+// cannot run its attacks.
+func NewExecutor(deauth ports.DeauthService, wps ports.WPSAttackService) *Executor {
+	return &Executor{
+		deauth:       deauth,
+		wps:          wps,
+		updates:      make(chan domain.RemoteAttackUpdate, updateBuffer),
+		PollInterval: DefaultPollInterval,
+		running:      make(map[string]localAttack),
+	}
+}
+
+// Updates delivers the progress of the attacks for the server.
+func (e *Executor) Updates() <-chan domain.RemoteAttackUpdate {
+	return e.updates
+}
+
+// Attacks returns the attack kinds the agent can run.
+func (e *Executor) Attacks() []domain.AttackKind {
+	var kinds []domain.AttackKind
+	if e.deauth != nil {
+		kinds = append(kinds, domain.AttackKindDeauth)
+	}
+	if e.wps != nil {
+		kinds = append(kinds, domain.AttackKindWPS)
+	}
+	return kinds
+}
+
+// Handle runs a command. Failures to start are reported as a finished,
+// failed attack rather than returned, since the server waits for an update.
+func (e *Executor) Handle(ctx context.Context, cmd domain.AgentCommand) {
+	switch cmd.Action {
+	case domain.AgentCommandStart:
+		if err := e.start(ctx, cmd); err != nil {
+			e.fail(ctx, cmd.AttackID, err)
+		}
+	case domain.AgentCommandStop:
+		if err := e.stop(ctx, cmd.AttackID); err != nil {
+			e.fail(ctx, cmd.AttackID, err)
+		}
+	default:
+		e.fail(ctx, cmd.AttackID, fmt.Errorf("unknown command %q", cmd.Action))
+	}
+}
+
+func (e *Executor) start(ctx context.Context, cmd domain.AgentCommand) error {
+	var localID string
+	switch {
+	case cmd.Kind == domain.AttackKindDeauth && e.deauth != nil:
+		var config domain.DeauthAttackConfig
+		if err := json.Unmarshal(cmd.Config, &config); err != nil {
+			return fmt.Errorf("invalid deauth configuration: %w", err)
+		}
+		id, err := e.deauth.StartAttack(context.Background(), config)
+		if err != nil {
+			return err
+		}
+		localID = id
+	case cmd.Kind == domain.AttackKindWPS && e.wps != nil:
+		var config domain.WPSAttackConfig
+		if err := json.Unmarshal(cmd.Config, &config); err != nil {
+			return fmt.Errorf("invalid WPS configuration: %w", err)
+		}
+		id, err := e.wps.StartAttack(context.Background(), config)
+		if err != nil {
+			return err
+		}
+		localID = id
+	default:
+		return fmt.Errorf("%s attacks are not available on this agent", cmd.Kind)
+	}
+
+	e.mu.Lock()
+	e.running[cmd.AttackID] = localAttack{kind: cmd.Kind, localID: localID}
+	e.mu.Unlock()
+	go e.watch(ctx, cmd.AttackID)
+	return nil
+}
+
+func (e *Executor) stop(ctx context.Context, attackID string) error {
+	e.mu.Lock()
+	attack, ok := e.running[attackID]
+	e.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("attack %s is not running on this agent", attackID)
+	}
+	// The watcher reports the final status once the engine has stopped
+	if attack.kind == domain.AttackKindWPS {
+		return e.wps.StopAttack(ctx, attack.localID, false)
+	}
+	return e.deauth.StopAttack(ctx, attack.localID, false)
+}
+
+// watch reports the progress of an attack whenever it changes, until it
+// finishes or ctx is done.
+func (e *Executor) watch(ctx context.Context, attackID string) {
+	ticker := time.NewTicker(e.PollInterval)
+	defer ticker.Stop()
+
+	var last domain.RemoteAttackUpdate
+	for {
+		update := e.poll(ctx, attackID)
+		if update != last {
+			last = update
+			update.Timestamp = time.Now()
+			e.report(ctx, update)
+		}
+		if update.Finished {
+			e.mu.Lock()
+			delete(e.running, attackID)
+			e.mu.Unlock()
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reads the status of an attack from its engine. The timestamp is left
+// to the caller so unchanged polls compare equal.
+func (e *Executor) poll(ctx context.Context, attackID string) domain.RemoteAttackUpdate {
+	e.mu.Lock()
+	attack := e.running[attackID]
+	e.mu.Unlock()
+
+	update := domain.RemoteAttackUpdate{AttackID: attackID}
+	if attack.kind == domain.AttackKindWPS {
+		status, err := e.wps.GetStatus(ctx, attack.localID)
+		if err != nil {
+			// Cleaned up by the engine, so it is over
+			update.Status, update.Finished = string(domain.WPSStatusFailed), true
+			update.Error = err.Error()
+			return update
+		}
+		update.Status = string(status.Status)
+		switch status.Status {
+		case domain.WPSStatusSuccess, domain.WPSStatusFailed, domain.WPSStatusTimeout:
+			update.Finished = true
+		}
+		update.Error = status.ErrorMessage
+		update.CredentialsRecovered = status.RecoveredPIN != "" || status.RecoveredPSK != ""
+		return update
+	}
+
+	status, err := e.deauth.GetAttackStatus(ctx, attack.localID)
+	if err != nil {
+		update.Status, update.Finished = string(domain.AttackStopped), true
+		return update
+	}
+	update.Status = string(status.Status)
+	update.Finished = status.Status == domain.AttackStopped || status.Status == domain.AttackFailed
+	update.Error = status.ErrorMessage
+	update.PacketsSent = status.PacketsSent
+	update.HandshakeCaptured = status.HandshakeCaptured
+	update.DisconnectionConfirmed = status.DisconnectionConfirmed
+	return update
+}
+
+func (e *Executor) report(ctx context.Context, update domain.RemoteAttackUpdate) {
+	select {
+	case e.updates <- update:
+	case <-ctx.Done():
+	}
+}
+
+func (e *Executor) fail(ctx context.Context, attackID string, err error) {
+	e.report(ctx, domain.RemoteAttackUpdate{
+		AttackID:  attackID,
+		Status:    string(domain.AttackFailed),
+		Finished:  true,
+		Error:     err.Error(),
+		Timestamp: time.Now(),
+	})
+}
@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDeauth struct {
+	mu      sync.Mutex
+	status  domain.DeauthAttackStatus
+	started domain.DeauthAttackConfig
+	fail    error
+}
+
+func (f *fakeDeauth) StartAttack(ctx context.Context, config domain.DeauthAttackConfig) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail != nil {
+		return "", f.fail
+	}
+	f.started = config
+	f.status = domain.DeauthAttackStatus{ID: "local-1", Status: domain.AttackRunning}
+	return "local-1", nil
+}
+
+func (f *fakeDeauth) StopAttack(ctx context.Context, id string, force bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status.Status = domain.AttackStopped
+	return nil
+}
+
+func (f *fakeDeauth) GetAttackStatus(ctx context.Context, id string) (domain.DeauthAttackStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.status, nil
+}
+
+func (f *fakeDeauth) ListActiveAttacks(ctx context.Context) []domain.DeauthAttackStatus { return nil }
+func (f *fakeDeauth) SetLogger(logger func(mac, message string))                        {}
+func (f *fakeDeauth) StopAll(ctx context.Context)                                       {}
+
+func nextUpdate(t *testing.T, e *Executor) domain.RemoteAttackUpdate {
+	t.Helper()
+	select {
+	case u := <-e.Updates():
+		return u
+	case <-time.After(2 * time.Second):
+		t.Fatal("no update reported")
+		return domain.RemoteAttackUpdate{}
+	}
+}
+
+func TestExecutor_RunsAndStopsAttack(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	engine := &fakeDeauth{}
+	e := NewExecutor(engine, nil)
+	e.PollInterval = 10 * time.Millisecond
+	assert.Equal(t, []domain.AttackKind{domain.AttackKindDeauth}, e.Attacks())
+
+	config, _ := json.Marshal(domain.DeauthAttackConfig{TargetMAC: "AA:BB:CC:00:00:01", Channel: 6})
+	e.Handle(ctx, domain.AgentCommand{AttackID: "remote-1", Action: domain.AgentCommandStart, Kind: domain.AttackKindDeauth, Config: config})
+
+	u := nextUpdate(t, e)
+	assert.Equal(t, "remote-1", u.AttackID)
+	assert.Equal(t, string(domain.AttackRunning), u.Status)
+	assert.False(t, u.Finished)
+	assert.Equal(t, 6, engine.started.Channel)
+
+	e.Handle(ctx, domain.AgentCommand{AttackID: "remote-1", Action: domain.AgentCommandStop})
+	u = nextUpdate(t, e)
+	assert.Equal(t, string(domain.AttackStopped), u.Status)
+	assert.True(t, u.Finished)
+
+	// Once finished the attack is no longer known
+	e.Handle(ctx, domain.AgentCommand{AttackID: "remote-1", Action: domain.AgentCommandStop})
+	assert.True(t, nextUpdate(t, e).Finished)
+}
+
+func TestExecutor_ReportsStartFailure(t *testing.T) {
+	ctx := context.Background()
+	e := NewExecutor(&fakeDeauth{fail: errors.New("no injector")}, nil)
+
+	e.Handle(ctx, domain.AgentCommand{AttackID: "remote-1", Action: domain.AgentCommandStart, Kind: domain.AttackKindDeauth, Config: json.RawMessage(`{}`)})
+	u := nextUpdate(t, e)
+	assert.True(t, u.Finished)
+	assert.Equal(t, "no injector", u.Error)
+
+	e.Handle(ctx, domain.AgentCommand{AttackID: "remote-2", Action: domain.AgentCommandStart, Kind: domain.AttackKindWPS})
+	u = nextUpdate(t, e)
+	require.True(t, u.Finished)
+	assert.Contains(t, u.Error, "not available")
+}
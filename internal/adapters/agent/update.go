@@ -0,0 +1,147 @@
+package agent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// MaxUpdateSize bounds the binary an update downloads.
+const MaxUpdateSize = 256 << 20
+
+// Updater installs the agent releases the server advertises: it checks the
+// release signature, downloads the binary, verifies its digest, replaces the
+// running executable and restarts it.
+type Updater struct {
+	PublicKey ed25519.PublicKey // Release key; updates signed by any other are refused
+	Version   string            // Running version
+
+	// Executable is the binary replaced, the running one by default.
+	Executable string
+	// Restart runs the new binary, by default replacing the process with it.
+	Restart func(executable string) error
+	Client  *http.Client
+}
+
+// NewUpdater creates an updater of the running executable.
+func NewUpdater(publicKey ed25519.PublicKey, version string) *Updater {
+	return &Updater{
+		PublicKey: publicKey,
+		Version:   version,
+		Restart:   execSelf,
+		Client:    &http.Client{Timeout: 10 * time.Minute},
+	}
+}
+
+// ParsePublicKey decodes a base64 Ed25519 public key, as printed by
+// tools/agent_release.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("release key must be a base64 Ed25519 public key")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// Apply installs a release and restarts into it. It returns without doing
+// anything for the running version, refuses older ones, and only returns
+// after a restart if the restart failed.
+func (u *Updater) Apply(ctx context.Context, release domain.AgentUpdate) error {
+	if release.Version == u.Version {
+		return nil
+	}
+	if err := release.Validate(); err != nil {
+		return err
+	}
+	if !release.NewerThan(u.Version) {
+		return fmt.Errorf("release %s is not newer than the running %s", release.Version, u.Version)
+	}
+	signature, _ := base64.StdEncoding.DecodeString(release.Signature)
+	if !ed25519.Verify(u.PublicKey, release.SignedMessage(), signature) {
+		return fmt.Errorf("release %s is not signed by the release key", release.Version)
+	}
+
+	executable, err := u.executable()
+	if err != nil {
+		return err
+	}
+	// Next to the executable so the rename replacing it is atomic
+	tmp, err := os.CreateTemp(filepath.Dir(executable), ".wmap-agent-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = u.download(ctx, release, tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), executable); err != nil {
+		return err
+	}
+	return u.Restart(executable)
+}
+
+// download writes the release binary to f, checking its digest.
+func (u *Updater) download(ctx context.Context, release domain.AgentUpdate, f *os.File) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, release.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download of release %s failed: %s", release.Version, resp.Status)
+	}
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, hash), io.LimitReader(resp.Body, MaxUpdateSize+1))
+	if err != nil {
+		return err
+	}
+	if n > MaxUpdateSize {
+		return fmt.Errorf("release %s is larger than %d bytes", release.Version, MaxUpdateSize)
+	}
+	if digest := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(digest, release.SHA256) {
+		return fmt.Errorf("release %s digest mismatch: got %s", release.Version, digest)
+	}
+	return nil
+}
+
+func (u *Updater) executable() (string, error) {
+	if u.Executable != "" {
+		return u.Executable, nil
+	}
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(path)
+}
+
+// execSelf replaces the process with the new binary, keeping the arguments
+// and environment.
+func execSelf(executable string) error {
+	return syscall.Exec(executable, os.Args, os.Environ())
+}
@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signedRelease(t *testing.T, private ed25519.PrivateKey, version, url string, binary []byte) domain.AgentUpdate {
+	t.Helper()
+	digest := sha256.Sum256(binary)
+	release := domain.AgentUpdate{Version: version, URL: url, SHA256: hex.EncodeToString(digest[:])}
+	release.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(private, release.SignedMessage()))
+	return release
+}
+
+func TestUpdater_Apply(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	binary := []byte("#!/bin/sh\necho v2\n")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(binary)
+	}))
+	defer srv.Close()
+
+	executable := filepath.Join(t.TempDir(), "wmap-agent")
+	require.NoError(t, os.WriteFile(executable, []byte("v1"), 0755))
+	var restarted string
+	u := NewUpdater(public, "v1")
+	u.Executable = executable
+	u.Restart = func(path string) error {
+		restarted = path
+		return nil
+	}
+	ctx := context.Background()
+
+	// Signed by another key
+	err = u.Apply(ctx, signedRelease(t, otherKey, "v2", srv.URL, binary))
+	assert.ErrorContains(t, err, "not signed")
+
+	// Version swapped after signing
+	tampered := signedRelease(t, private, "v2", srv.URL, binary)
+	tampered.Version = "v3"
+	assert.ErrorContains(t, u.Apply(ctx, tampered), "not signed")
+
+	// Served binary differs from the signed digest
+	err = u.Apply(ctx, signedRelease(t, private, "v2", srv.URL, []byte("other")))
+	assert.ErrorContains(t, err, "digest mismatch")
+	data, _ := os.ReadFile(executable)
+	assert.Equal(t, "v1", string(data), "a failed update leaves the binary alone")
+	assert.Empty(t, restarted)
+
+	// The running version is not reinstalled
+	require.NoError(t, u.Apply(ctx, signedRelease(t, private, "v1", srv.URL, binary)))
+	assert.Empty(t, restarted)
+
+	// Older signed releases are not replayed
+	u.Version = "v1.5.0"
+	assert.ErrorContains(t, u.Apply(ctx, signedRelease(t, private, "v1.4.9", srv.URL, binary)), "not newer")
+	assert.ErrorContains(t, u.Apply(ctx, signedRelease(t, private, "v1.5.0-rc1", srv.URL, binary)), "not newer")
+	assert.Empty(t, restarted)
+
+	require.NoError(t, u.Apply(ctx, signedRelease(t, private, "v2", srv.URL, binary)))
+	assert.Equal(t, executable, restarted)
+	data, _ = os.ReadFile(executable)
+	assert.Equal(t, binary, data)
+	entries, _ := os.ReadDir(filepath.Dir(executable))
+	assert.Len(t, entries, 1, "no temporary files left")
+}
@@ -0,0 +1,52 @@
+package beaconspoof
+
+import (
+	"fmt"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/runner"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+type attack = runner.Snapshot[domain.BeaconSpoofConfig, domain.BeaconSpoofStatus]
+
+// BeaconSpoofEngine manages multiple concurrent beacon spoofing attacks
+type BeaconSpoofEngine struct {
+	*runner.Runner[domain.BeaconSpoofConfig, domain.BeaconSpoofStatus]
+}
+
+// NewBeaconSpoofEngine creates a new beacon spoofing engine
+func NewBeaconSpoofEngine(injector *injection.Injector, locker capture.ChannelLocker, maxConcurrent int) *BeaconSpoofEngine {
+	spec := runner.Spec[domain.BeaconSpoofConfig, domain.BeaconSpoofStatus]{
+		Kind:     domain.AttackKindBeaconSpoof,
+		Name:     "Beacon Spoof",
+		Validate: (*domain.BeaconSpoofConfig).Validate,
+		Radio: func(config *domain.BeaconSpoofConfig) (*string, int) {
+			return &config.Interface, config.Channel
+		},
+		Run: (*injection.Injector).StartBeaconSpoof,
+		Describe: func(config domain.BeaconSpoofConfig) string {
+			return fmt.Sprintf("for %q on ch %d", config.SSID, config.Channel)
+		},
+		Status: status,
+		Record: func(record *domain.AttackRecord, a attack) {
+			record.Target = a.Config.SSID
+			record.PacketsSent = a.Progress.PacketsSent
+		},
+	}
+	return &BeaconSpoofEngine{runner.New(spec, injector, locker, maxConcurrent)}
+}
+
+// status reports the state of an attack
+func status(a attack) domain.BeaconSpoofStatus {
+	return domain.BeaconSpoofStatus{
+		ID:           a.ID,
+		Config:       a.Config,
+		Status:       a.Status,
+		PacketsSent:  a.Progress.PacketsSent,
+		StartTime:    a.StartTime,
+		EndTime:      a.EndTime,
+		ErrorMessage: a.ErrorMessage,
+	}
+}
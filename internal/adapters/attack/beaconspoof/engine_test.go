@@ -0,0 +1,44 @@
+package beaconspoof
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingLocker keeps attacks queued until they are stopped
+type blockingLocker struct{}
+
+func (blockingLocker) Lock(ctx context.Context, iface string, channel int) error { return nil }
+func (blockingLocker) Unlock(ctx context.Context, iface string) error            { return nil }
+func (blockingLocker) ExecuteWithLock(ctx context.Context, iface string, channel int, action func() error) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestBeaconSpoofEngine_ForceStop(t *testing.T) {
+	engine := NewBeaconSpoofEngine(nil, blockingLocker{}, 5)
+
+	id, err := engine.StartAttack(context.Background(), domain.BeaconSpoofConfig{SSID: "Lab", Channel: 6})
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- engine.StopAttack(context.Background(), id, true) }()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("StopAttack deadlocked")
+	}
+
+	require.Eventually(t, func() bool {
+		status, err := engine.GetStatus(context.Background(), id)
+		return err == nil && status.EndTime != nil && status.Status == domain.AttackStopped
+	}, time.Second, 10*time.Millisecond)
+	status, _ := engine.GetStatus(context.Background(), id)
+	assert.Contains(t, status.ErrorMessage, "Force stopped")
+}
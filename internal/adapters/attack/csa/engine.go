@@ -0,0 +1,52 @@
+package csa
+
+import (
+	"fmt"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/runner"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+type attack = runner.Snapshot[domain.CSAAttackConfig, domain.CSAAttackStatus]
+
+// CSAEngine manages multiple concurrent CSA attacks
+type CSAEngine struct {
+	*runner.Runner[domain.CSAAttackConfig, domain.CSAAttackStatus]
+}
+
+// NewCSAEngine creates a new CSA engine
+func NewCSAEngine(injector *injection.Injector, locker capture.ChannelLocker, maxConcurrent int) *CSAEngine {
+	spec := runner.Spec[domain.CSAAttackConfig, domain.CSAAttackStatus]{
+		Kind:     domain.AttackKindCSA,
+		Name:     "CSA",
+		Validate: (*domain.CSAAttackConfig).Validate,
+		Radio: func(config *domain.CSAAttackConfig) (*string, int) {
+			return &config.Interface, config.Channel
+		},
+		Run: (*injection.Injector).StartCSAAttack,
+		Describe: func(config domain.CSAAttackConfig) string {
+			return fmt.Sprintf("against %s (ch %d -> %d, %s)", config.TargetBSSID, config.Channel, config.NewChannel, config.FrameMode)
+		},
+		Status: status,
+		Record: func(record *domain.AttackRecord, a attack) {
+			record.Target = a.Config.TargetBSSID
+			record.PacketsSent = a.Progress.PacketsSent
+		},
+	}
+	return &CSAEngine{runner.New(spec, injector, locker, maxConcurrent)}
+}
+
+// status reports the state of an attack
+func status(a attack) domain.CSAAttackStatus {
+	return domain.CSAAttackStatus{
+		ID:           a.ID,
+		Config:       a.Config,
+		Status:       a.Status,
+		PacketsSent:  a.Progress.PacketsSent,
+		StartTime:    a.StartTime,
+		EndTime:      a.EndTime,
+		ErrorMessage: a.ErrorMessage,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/runner"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/driver"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
@@ -28,9 +29,15 @@ var (
 	ErrNoInjectorAvailable  = errors.New("no injector available")
 )
 
+// ackSettleDelay is how long frames stay pending beyond the ACK window before
+// being counted as unacknowledged.
+const ackSettleDelay = 200 * time.Millisecond
+
 // effectivenessMonitor encapsulates attack effectiveness monitoring logic
 type effectivenessMonitor struct {
 	events     chan string
+	acks       chan injection.Ack
+	tracker    *injection.AckTracker
 	ctx        context.Context
 	cancel     context.CancelFunc
 	logger     func(string, string)
@@ -44,6 +51,8 @@ func newEffectivenessMonitor(ctx context.Context, controller *AttackController,
 	monitorCtx, monitorCancel := context.WithCancel(ctx)
 	return &effectivenessMonitor{
 		events:     make(chan string, 10),
+		acks:       make(chan injection.Ack, 100),
+		tracker:    injection.NewAckTracker(injection.DefaultAckWindow),
 		ctx:        monitorCtx,
 		cancel:     monitorCancel,
 		logger:     logger,
@@ -55,22 +64,52 @@ func newEffectivenessMonitor(ctx context.Context, controller *AttackController,
 
 // start begins monitoring with the given injector
 func (m *effectivenessMonitor) start(injector *injection.Injector) {
+	m.controller.mu.Lock()
+	m.controller.acks = m.tracker
+	m.controller.mu.Unlock()
+
 	go injector.StartMonitor(m.ctx, m.targetMAC, m.events)
+	go func() {
+		if err := injector.WatchAcks(m.ctx, m.acks); err != nil && m.logger != nil {
+			m.logger(fmt.Sprintf("ACK monitor unavailable for attack %s: %v", m.attackID, err), "warning")
+		}
+	}()
 	go m.processEvents()
 }
 
 // processEvents handles monitoring events
 func (m *effectivenessMonitor) processEvents() {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-m.ctx.Done():
 			return
 		case event := <-m.events:
 			m.handleEvent(event)
+		case ack := <-m.acks:
+			m.tracker.Observe(ack)
+		case now := <-ticker.C:
+			// Leave time for captured ACKs still queued in the channel
+			m.tracker.Expire(now.Add(-ackSettleDelay))
+			m.updateDelivery()
 		}
 	}
 }
 
+// updateDelivery publishes the ACK-confirmed delivery counters in the status.
+func (m *effectivenessMonitor) updateDelivery() {
+	acked, unacked := m.tracker.Counts()
+	m.controller.mu.Lock()
+	defer m.controller.mu.Unlock()
+	m.controller.Status.FramesAcked = acked
+	m.controller.Status.FramesUnacked = unacked
+	if acked+unacked > 0 {
+		m.controller.Status.DeliveryRatio = float64(acked) / float64(acked+unacked)
+	}
+}
+
 // handleEvent processes a single monitoring event
 func (m *effectivenessMonitor) handleEvent(event string) {
 	switch event {
@@ -87,6 +126,9 @@ func (m *effectivenessMonitor) handleEvent(event string) {
 		if m.logger != nil {
 			m.logger(fmt.Sprintf("Target %s sent Probe Request - CONFIRMED DISCONNECTION", m.targetMAC), "success")
 		}
+		m.controller.mu.Lock()
+		m.controller.Status.DisconnectionConfirmed = true
+		m.controller.mu.Unlock()
 
 	case "disconnected":
 		if m.logger != nil {
@@ -94,6 +136,7 @@ func (m *effectivenessMonitor) handleEvent(event string) {
 		}
 		m.controller.mu.Lock()
 		m.controller.Status.Status = domain.AttackStopped
+		m.controller.Status.DisconnectionConfirmed = true
 		m.controller.mu.Unlock()
 		m.controller.CancelFn()
 	}
@@ -102,6 +145,8 @@ func (m *effectivenessMonitor) handleEvent(event string) {
 // stop stops the monitor
 func (m *effectivenessMonitor) stop() {
 	m.cancel()
+	m.tracker.Expire(time.Now().Add(injection.DefaultAckWindow))
+	m.updateDelivery()
 }
 
 // AttackController manages the lifecycle of a single deauth attack
@@ -112,7 +157,18 @@ type AttackController struct {
 	CancelFn context.CancelFunc
 	StatusCh chan domain.DeauthAttackStatus
 	mu       sync.RWMutex
-	injector *injection.Injector // Dedicated injector for this attack (if specific interface used)
+	injector *injection.Injector   // Dedicated injector for this attack (if specific interface used)
+	acks     *injection.AckTracker // Set while the effectiveness monitor runs
+}
+
+// trackInjected registers an injected frame for ACK confirmation.
+func (c *AttackController) trackInjected(pkt []byte) {
+	c.mu.RLock()
+	tracker := c.acks
+	c.mu.RUnlock()
+	if tracker != nil {
+		tracker.TrackInjected(pkt, time.Now())
+	}
 }
 
 // DeauthEngine manages multiple concurrent deauth attacks
@@ -122,8 +178,9 @@ type DeauthEngine struct {
 	mu                sync.RWMutex
 	maxConcurrent     int
 	locker            capture.ChannelLocker
-	logger            func(string, string) // Message, Level ("info", "warning", "danger", "success")
 	monitoringEnabled bool
+
+	runner.Hooks // Logger levels: "info", "warning", "danger", "success"
 }
 
 // NewDeauthEngine creates a new deauth attack engine
@@ -140,22 +197,23 @@ func NewDeauthEngine(injector *injection.Injector, locker capture.ChannelLocker,
 	}
 }
 
-// SetLogger sets the callback for logging events
-func (e *DeauthEngine) SetLogger(logger func(string, string)) {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-	e.logger = logger
-}
-
-// log sends a message to the logger callback asynchronously
-func (e *DeauthEngine) log(message string, level string) {
-	e.mu.RLock()
-	logger := e.logger
-	e.mu.RUnlock()
-
-	if logger != nil {
-		go logger(message, level)
-	}
+// recordHistory reports the final outcome of an attack to the recorder
+func (e *DeauthEngine) recordHistory(controller *AttackController) {
+	controller.mu.RLock()
+	status := controller.Status
+	controller.mu.RUnlock()
+
+	record := domain.NewAttackRecord(domain.AttackKindDeauth, controller.ID, status.Config, status.StartTime, status.EndTime)
+	record.Target = status.Config.TargetMAC
+	record.Interface = status.Config.Interface
+	record.Channel = status.Config.Channel
+	record.Status = string(status.Status)
+	record.PacketsSent = status.PacketsSent
+	record.HandshakeCaptured = status.HandshakeCaptured
+	record.DisconnectionConfirmed = status.DisconnectionConfirmed
+	record.DeliveryRatio = status.DeliveryRatio
+	record.ErrorMessage = status.ErrorMessage
+	e.Record(record)
 }
 
 // validateConfig validates the attack configuration
@@ -188,16 +246,16 @@ func (e *DeauthEngine) prepareInjector(config *domain.DeauthAttackConfig) (*inje
 
 	// Reuse default injector if it matches the requested interface
 	if e.injector != nil && e.injector.Interface == config.Interface {
-		e.log(fmt.Sprintf("Reusing default injector for interface %s", config.Interface), "info")
+		e.Log(fmt.Sprintf("Reusing default injector for interface %s", config.Interface), "info")
 		return e.injector, nil, nil
 	}
 
 	// Set channel if specified
 	if config.Channel > 0 {
 		if err := driver.SetInterfaceChannel(config.Interface, config.Channel); err != nil {
-			e.log(fmt.Sprintf("Warning: Failed to set channel %d on %s: %v", config.Channel, config.Interface, err), "warning")
+			e.Log(fmt.Sprintf("Warning: Failed to set channel %d on %s: %v", config.Channel, config.Interface, err), "warning")
 		} else {
-			e.log(fmt.Sprintf("Set channel %d on %s", config.Channel, config.Interface), "info")
+			e.Log(fmt.Sprintf("Set channel %d on %s", config.Channel, config.Interface), "info")
 		}
 	}
 
@@ -276,7 +334,7 @@ func (e *DeauthEngine) StartAttack(ctx context.Context, config domain.DeauthAtta
 	// Start attack execution
 	go e.runAttack(attackCtx, controller, attackInjector)
 
-	e.log(fmt.Sprintf("Started attack %s: Type=%s Target=%s Interface=%s",
+	e.Log(fmt.Sprintf("Started attack %s: Type=%s Target=%s Interface=%s",
 		attackID, config.AttackType, config.TargetMAC, config.Interface), "success")
 
 	return attackID, nil
@@ -296,7 +354,7 @@ func (e *DeauthEngine) cleanupAttackResources(controller *AttackController) {
 // handleAttackPanic recovers from panics and updates attack status
 func (e *DeauthEngine) handleAttackPanic(controller *AttackController) {
 	if r := recover(); r != nil {
-		e.log(fmt.Sprintf("Attack %s CRASHED: %v", controller.ID, r), "danger")
+		e.Log(fmt.Sprintf("Attack %s CRASHED: %v", controller.ID, r), "danger")
 
 		controller.mu.Lock()
 		controller.Status.Status = domain.AttackFailed
@@ -323,7 +381,7 @@ func (e *DeauthEngine) executeAttack(ctx context.Context, controller *AttackCont
 	// Setup effectiveness monitoring
 	fmt.Printf("DEBUG: monitoringEnabled=%v\n", e.monitoringEnabled)
 	if e.monitoringEnabled {
-		monitor := newEffectivenessMonitor(ctx, controller, e.log)
+		monitor := newEffectivenessMonitor(ctx, controller, e.Log)
 		monitor.start(injector)
 		defer monitor.stop()
 	}
@@ -345,28 +403,37 @@ func (e *DeauthEngine) executeAttack(ctx context.Context, controller *AttackCont
 	controller.Status.Status = domain.AttackStopped
 	controller.mu.Unlock()
 
-	e.log(fmt.Sprintf("Attack %s: Burst finished (%d packets)", controller.ID, controller.Config.PacketCount), "success")
+	e.Log(fmt.Sprintf("Attack %s: Burst finished (%d packets)", controller.ID, controller.Config.PacketCount), "success")
 	return nil
 }
 
 // runAttack executes the attack logic with proper resource management
 func (e *DeauthEngine) runAttack(ctx context.Context, controller *AttackController, injector *injection.Injector) {
-	// Ensure cleanup and panic recovery
+	// Ensure cleanup and panic recovery, then record the outcome
+	defer e.recordHistory(controller)
 	defer e.cleanupAttackResources(controller)
 	defer e.handleAttackPanic(controller)
 
-	// Define attack action
-	action := func() error {
-		return e.executeAttack(ctx, controller, injector)
+	// Define attack action (lockCtx is cancelled if the channel lock is preempted)
+	action := func(lockCtx context.Context) error {
+		return e.executeAttack(lockCtx, controller, injector)
 	}
 
 	// Execute with or without channel lock
 	var err error
 	if e.locker != nil && controller.Config.Channel > 0 {
-		e.log(fmt.Sprintf("Channel %d locked on %s for attack", controller.Config.Channel, controller.Config.Interface), "info")
-		err = e.locker.ExecuteWithLock(ctx, controller.Config.Interface, controller.Config.Channel, action)
+		controller.mu.Lock()
+		controller.Status.Status = domain.AttackQueued
+		controller.mu.Unlock()
+		err = capture.RunLocked(ctx, e.locker, controller.Config.Interface, controller.Config.Channel, domain.LockPriorityAttack, controller.ID, func(lockCtx context.Context) error {
+			e.Log(fmt.Sprintf("Channel %d locked on %s for attack", controller.Config.Channel, controller.Config.Interface), "info")
+			return action(lockCtx)
+		})
+		if err != nil && errors.Is(err, ctx.Err()) {
+			err = nil // Stopped while queued
+		}
 	} else {
-		err = action()
+		err = action(ctx)
 	}
 
 	// Update final status
@@ -376,26 +443,36 @@ func (e *DeauthEngine) runAttack(ctx context.Context, controller *AttackControll
 // updateFinalStatus updates the attack status after completion
 func (e *DeauthEngine) updateFinalStatus(controller *AttackController, err error) {
 	controller.mu.Lock()
-	defer controller.mu.Unlock()
-
 	now := time.Now()
-
 	if err != nil {
-		e.log(fmt.Sprintf("Attack %s failed: %v", controller.ID, err), "error")
 		controller.Status.Status = domain.AttackFailed
 		controller.Status.ErrorMessage = err.Error()
 	} else {
-		if controller.Status.Status == domain.AttackRunning {
-			controller.Status.Status = domain.AttackStopped
-		}
-		e.log(fmt.Sprintf("Attack %s completed", controller.ID), "info")
+		// Also covers attacks stopped before they left the lock queue
+		controller.Status.Status = domain.AttackStopped
 	}
-
 	controller.Status.EndTime = &now
+	controller.mu.Unlock()
+
+	if err != nil {
+		e.Log(fmt.Sprintf("Attack %s failed: %v", controller.ID, err), "error")
+	} else {
+		e.Log(fmt.Sprintf("Attack %s completed", controller.ID), "info")
+	}
 }
 
 // StopAttack stops a running attack
 func (e *DeauthEngine) StopAttack(ctx context.Context, id string, force bool) error {
+	if err := e.stopAttack(id, force); err != nil {
+		return err
+	}
+
+	e.Log(fmt.Sprintf("Stopped attack %s (force=%v)", id, force), "warning")
+	return nil
+}
+
+// stopAttack cancels an attack and marks it stopped
+func (e *DeauthEngine) stopAttack(id string, force bool) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
@@ -407,7 +484,7 @@ func (e *DeauthEngine) StopAttack(ctx context.Context, id string, force bool) er
 	controller.mu.Lock()
 	defer controller.mu.Unlock()
 
-	if !force && controller.Status.Status != domain.AttackRunning && controller.Status.Status != domain.AttackPaused {
+	if !force && controller.Status.Status != domain.AttackRunning && controller.Status.Status != domain.AttackPaused && controller.Status.Status != domain.AttackQueued {
 		return fmt.Errorf("%w: %s (status: %s)", ErrAttackNotActive, id, controller.Status.Status)
 	}
 
@@ -428,8 +505,6 @@ func (e *DeauthEngine) StopAttack(ctx context.Context, id string, force bool) er
 		controller.Status.ErrorMessage = "Force stopped by user"
 	}
 
-	e.log(fmt.Sprintf("Stopped attack %s (force=%v)", id, force), "warning")
-
 	return nil
 }
 
@@ -455,7 +530,7 @@ func (e *DeauthEngine) PauseAttack(id string) error {
 	controller.CancelFn()
 	controller.Status.Status = domain.AttackPaused
 
-	e.log(fmt.Sprintf("Paused attack %s", id), "warning")
+	e.Log(fmt.Sprintf("Paused attack %s", id), "warning")
 
 	return nil
 }
@@ -532,7 +607,7 @@ func (e *DeauthEngine) CleanupFinished() int {
 	e.mu.Unlock()
 
 	if removed > 0 {
-		e.log(fmt.Sprintf("Cleaned up %d finished attacks", removed), "system")
+		e.Log(fmt.Sprintf("Cleaned up %d finished attacks", removed), "system")
 	}
 
 	return removed
@@ -589,7 +664,7 @@ func (e *DeauthEngine) runContinuousAttack(ctx context.Context, controller *Atta
 	if !config.SpoofSource && (config.AttackType == domain.DeauthTargeted || config.AttackType == domain.DeauthUnicast) {
 		sniffedSeq := injector.SniffSequenceNumber(ctx, targetMAC)
 		seq = sniffedSeq
-		e.log(fmt.Sprintf("Sniffed Sequence Number from %s: %d", targetMAC, sniffedSeq), "info")
+		e.Log(fmt.Sprintf("Sniffed Sequence Number from %s: %d", targetMAC, sniffedSeq), "info")
 	}
 
 	for {
@@ -630,7 +705,7 @@ func (e *DeauthEngine) runContinuousAttack(ctx context.Context, controller *Atta
 					pkt, err = injection.SerializeDeauthPacket(broadcast, txMAC_AP, txMAC_AP, currentReason, seq)
 				}
 				if err != nil {
-					e.log(fmt.Sprintf("Failed to serialize packet: %v", err), "warning")
+					e.Log(fmt.Sprintf("Failed to serialize packet: %v", err), "warning")
 				} else if pkt != nil {
 					pkts = append(pkts, pkt)
 				}
@@ -646,7 +721,7 @@ func (e *DeauthEngine) runContinuousAttack(ctx context.Context, controller *Atta
 						pkt, err = injection.SerializeDeauthPacket(clientMAC, txMAC_AP, txMAC_AP, currentReason, seq)
 					}
 					if err != nil {
-						e.log(fmt.Sprintf("Failed to serialize packet: %v", err), "warning")
+						e.Log(fmt.Sprintf("Failed to serialize packet: %v", err), "warning")
 					} else if pkt != nil {
 						pkts = append(pkts, pkt)
 					}
@@ -665,7 +740,7 @@ func (e *DeauthEngine) runContinuousAttack(ctx context.Context, controller *Atta
 					}
 
 					if err != nil {
-						e.log(fmt.Sprintf("Failed to serialize packet 1: %v", err), "warning")
+						e.Log(fmt.Sprintf("Failed to serialize packet 1: %v", err), "warning")
 					} else {
 						seq++ // Increment for next packet
 
@@ -682,7 +757,7 @@ func (e *DeauthEngine) runContinuousAttack(ctx context.Context, controller *Atta
 						}
 
 						if err != nil {
-							e.log(fmt.Sprintf("Failed to serialize packet 2: %v", err), "warning")
+							e.Log(fmt.Sprintf("Failed to serialize packet 2: %v", err), "warning")
 						} else {
 							if pkt1 != nil {
 								pkts = append(pkts, pkt1)
@@ -702,6 +777,7 @@ func (e *DeauthEngine) runContinuousAttack(ctx context.Context, controller *Atta
 				} else {
 					telemetry.InjectionsTotal.WithLabelValues(config.Interface, "deauth").Inc()
 					packetsSent++
+					controller.trackInjected(p)
 				}
 			}
 
@@ -764,7 +840,7 @@ func (e *DeauthEngine) runBurstAttack(ctx context.Context, controller *AttackCon
 	if !config.SpoofSource && (config.AttackType == domain.DeauthTargeted || config.AttackType == domain.DeauthUnicast) {
 		sniffedSeq := injector.SniffSequenceNumber(ctx, targetMAC)
 		seq = sniffedSeq
-		e.log(fmt.Sprintf("Sniffed Sequence Number from %s: %d", targetMAC, sniffedSeq), "info")
+		e.Log(fmt.Sprintf("Sniffed Sequence Number from %s: %d", targetMAC, sniffedSeq), "info")
 	}
 
 	for j := 0; j < count; j++ {
@@ -804,7 +880,7 @@ func (e *DeauthEngine) runBurstAttack(ctx context.Context, controller *AttackCon
 				pkt, err = injection.SerializeDeauthPacket(broadcast, txMAC_AP, txMAC_AP, currentReason, seq)
 			}
 			if err != nil {
-				e.log(fmt.Sprintf("Failed to serialize packet: %v", err), "warning")
+				e.Log(fmt.Sprintf("Failed to serialize packet: %v", err), "warning")
 			} else if pkt != nil {
 				pkts = append(pkts, pkt)
 			}
@@ -820,7 +896,7 @@ func (e *DeauthEngine) runBurstAttack(ctx context.Context, controller *AttackCon
 					pkt, err = injection.SerializeDeauthPacket(clientMAC, txMAC_AP, txMAC_AP, currentReason, seq)
 				}
 				if err != nil {
-					e.log(fmt.Sprintf("Failed to serialize packet: %v", err), "warning")
+					e.Log(fmt.Sprintf("Failed to serialize packet: %v", err), "warning")
 				} else if pkt != nil {
 					pkts = append(pkts, pkt)
 				}
@@ -838,7 +914,7 @@ func (e *DeauthEngine) runBurstAttack(ctx context.Context, controller *AttackCon
 				}
 
 				if err != nil {
-					e.log(fmt.Sprintf("Failed to serialize packet 1: %v", err), "warning")
+					e.Log(fmt.Sprintf("Failed to serialize packet 1: %v", err), "warning")
 				} else {
 					seq++
 
@@ -854,7 +930,7 @@ func (e *DeauthEngine) runBurstAttack(ctx context.Context, controller *AttackCon
 					}
 
 					if err != nil {
-						e.log(fmt.Sprintf("Failed to serialize packet 2: %v", err), "warning")
+						e.Log(fmt.Sprintf("Failed to serialize packet 2: %v", err), "warning")
 					} else {
 						if pkt1 != nil {
 							pkts = append(pkts, pkt1)
@@ -870,9 +946,10 @@ func (e *DeauthEngine) runBurstAttack(ctx context.Context, controller *AttackCon
 		for _, p := range pkts {
 			if err := injector.Inject(p); err != nil {
 				telemetry.InjectionErrors.WithLabelValues(config.Interface, "deauth").Inc()
-				e.log(fmt.Sprintf("Failed to inject packet in burst: %v", err), "warning")
+				e.Log(fmt.Sprintf("Failed to inject packet in burst: %v", err), "warning")
 			} else {
 				telemetry.InjectionsTotal.WithLabelValues(config.Interface, "deauth").Inc()
+				controller.trackInjected(p)
 			}
 		}
 
@@ -902,11 +979,11 @@ func (e *DeauthEngine) StopAll(ctx context.Context) {
 
 	for _, id := range ids {
 		if err := e.StopAttack(ctx, id, true); err != nil {
-			e.log(fmt.Sprintf("Failed to stop attack %s: %v", id, err), "error")
+			e.Log(fmt.Sprintf("Failed to stop attack %s: %v", id, err), "error")
 		}
 	}
 
-	e.log("Stopped all attacks", "system")
+	e.Log("Stopped all attacks", "system")
 }
 
 // randomMAC generates a random unicast MAC address
@@ -0,0 +1,56 @@
+package karma
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/runner"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+type attack = runner.Snapshot[domain.KarmaConfig, domain.KarmaStatus]
+
+// KarmaEngine manages multiple concurrent Karma responders
+type KarmaEngine struct {
+	*runner.Runner[domain.KarmaConfig, domain.KarmaStatus]
+}
+
+// NewKarmaEngine creates a new Karma engine
+func NewKarmaEngine(injector *injection.Injector, locker capture.ChannelLocker, maxConcurrent int) *KarmaEngine {
+	spec := runner.Spec[domain.KarmaConfig, domain.KarmaStatus]{
+		Kind:     domain.AttackKindKarma,
+		Name:     "Karma",
+		Validate: (*domain.KarmaConfig).Validate,
+		Radio: func(config *domain.KarmaConfig) (*string, int) {
+			return &config.Interface, config.Channel
+		},
+		Run: (*injection.Injector).StartKarma,
+		Describe: func(config domain.KarmaConfig) string {
+			return fmt.Sprintf("for %d SSIDs on ch %d", len(config.SSIDAllowlist), config.Channel)
+		},
+		Status: status,
+		Record: func(record *domain.AttackRecord, a attack) {
+			record.Target = strings.Join(a.Config.SSIDAllowlist, ",")
+			record.PacketsSent = a.Progress.ResponsesSent
+			record.ClientsAffected = len(a.Progress.Clients)
+		},
+	}
+	return &KarmaEngine{runner.New(spec, injector, locker, maxConcurrent)}
+}
+
+// status reports the state of a responder
+func status(a attack) domain.KarmaStatus {
+	return domain.KarmaStatus{
+		ID:            a.ID,
+		Config:        a.Config,
+		Status:        a.Status,
+		ProbesSeen:    a.Progress.ProbesSeen,
+		ResponsesSent: a.Progress.ResponsesSent,
+		Clients:       a.Progress.Clients,
+		StartTime:     a.StartTime,
+		EndTime:       a.EndTime,
+		ErrorMessage:  a.ErrorMessage,
+	}
+}
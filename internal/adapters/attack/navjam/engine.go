@@ -0,0 +1,52 @@
+package navjam
+
+import (
+	"fmt"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/runner"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+type attack = runner.Snapshot[domain.NAVJamConfig, domain.NAVJamStatus]
+
+// NAVJamEngine manages multiple concurrent NAV jamming runs
+type NAVJamEngine struct {
+	*runner.Runner[domain.NAVJamConfig, domain.NAVJamStatus]
+}
+
+// NewNAVJamEngine creates a new NAV jam engine
+func NewNAVJamEngine(injector *injection.Injector, locker capture.ChannelLocker, maxConcurrent int) *NAVJamEngine {
+	spec := runner.Spec[domain.NAVJamConfig, domain.NAVJamStatus]{
+		Kind:     domain.AttackKindNAVJam,
+		Name:     "NAV Jam",
+		Validate: (*domain.NAVJamConfig).Validate, // Also applies the safety defaults
+		Radio: func(config *domain.NAVJamConfig) (*string, int) {
+			return &config.Interface, config.Channel
+		},
+		Run: (*injection.Injector).StartNAVJam,
+		Describe: func(config domain.NAVJamConfig) string {
+			return fmt.Sprintf("on ch %d (%s, %dus x %d/s for %v)", config.Channel, config.FrameType, config.NAVMicros, config.Rate, config.Duration)
+		},
+		Status: status,
+		Record: func(record *domain.AttackRecord, a attack) {
+			record.Target = a.Config.TargetMAC
+			record.PacketsSent = a.Progress.PacketsSent
+		},
+	}
+	return &NAVJamEngine{runner.New(spec, injector, locker, maxConcurrent)}
+}
+
+// status reports the state of a run
+func status(a attack) domain.NAVJamStatus {
+	return domain.NAVJamStatus{
+		ID:           a.ID,
+		Config:       a.Config,
+		Status:       a.Status,
+		PacketsSent:  a.Progress.PacketsSent,
+		StartTime:    a.StartTime,
+		EndTime:      a.EndTime,
+		ErrorMessage: a.ErrorMessage,
+	}
+}
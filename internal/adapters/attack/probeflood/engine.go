@@ -0,0 +1,52 @@
+package probeflood
+
+import (
+	"fmt"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/runner"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+type attack = runner.Snapshot[domain.ProbeFloodAttackConfig, domain.ProbeFloodAttackStatus]
+
+// ProbeFloodEngine manages multiple concurrent probe flood attacks
+type ProbeFloodEngine struct {
+	*runner.Runner[domain.ProbeFloodAttackConfig, domain.ProbeFloodAttackStatus]
+}
+
+// NewProbeFloodEngine creates a new probe flood engine
+func NewProbeFloodEngine(injector *injection.Injector, locker capture.ChannelLocker, maxConcurrent int) *ProbeFloodEngine {
+	spec := runner.Spec[domain.ProbeFloodAttackConfig, domain.ProbeFloodAttackStatus]{
+		Kind:     domain.AttackKindProbeFlood,
+		Name:     "Probe Flood",
+		Validate: (*domain.ProbeFloodAttackConfig).Validate,
+		Radio: func(config *domain.ProbeFloodAttackConfig) (*string, int) {
+			return &config.Interface, config.Channel
+		},
+		Run: (*injection.Injector).StartProbeFlood,
+		Describe: func(config domain.ProbeFloodAttackConfig) string {
+			return fmt.Sprintf("(%d SSIDs, random=%v)", len(config.SSIDs), len(config.SSIDs) == 0)
+		},
+		Status: status,
+		Record: func(record *domain.AttackRecord, a attack) {
+			record.Target = a.Config.TargetBSSID
+			record.PacketsSent = a.Progress.PacketsSent
+		},
+	}
+	return &ProbeFloodEngine{runner.New(spec, injector, locker, maxConcurrent)}
+}
+
+// status reports the state of an attack
+func status(a attack) domain.ProbeFloodAttackStatus {
+	return domain.ProbeFloodAttackStatus{
+		ID:           a.ID,
+		Config:       a.Config,
+		Status:       a.Status,
+		PacketsSent:  a.Progress.PacketsSent,
+		StartTime:    a.StartTime,
+		EndTime:      a.EndTime,
+		ErrorMessage: a.ErrorMessage,
+	}
+}
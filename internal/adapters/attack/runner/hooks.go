@@ -0,0 +1,51 @@
+package runner
+
+import (
+	"sync"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// Hooks holds the logger and history recorder callbacks of an attack engine.
+// It has its own lock, so engines can log and record while holding theirs.
+type Hooks struct {
+	mu       sync.RWMutex
+	logger   func(string, string)
+	recorder func(domain.AttackRecord)
+}
+
+// SetLogger sets the callback for logging events
+func (h *Hooks) SetLogger(logger func(string, string)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.logger = logger
+}
+
+// SetRecorder sets the callback receiving each finished attack for the history
+func (h *Hooks) SetRecorder(recorder func(domain.AttackRecord)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.recorder = recorder
+}
+
+// Log sends a message to the logger callback asynchronously
+func (h *Hooks) Log(message string, level string) {
+	h.mu.RLock()
+	logger := h.logger
+	h.mu.RUnlock()
+
+	if logger != nil {
+		go logger(message, level)
+	}
+}
+
+// Record reports the final outcome of an attack to the recorder
+func (h *Hooks) Record(record domain.AttackRecord) {
+	h.mu.RLock()
+	recorder := h.recorder
+	h.mu.RUnlock()
+
+	if recorder != nil {
+		recorder(record)
+	}
+}
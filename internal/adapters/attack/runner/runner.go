@@ -0,0 +1,378 @@
+// Package runner holds the lifecycle shared by the attack engines that drive a
+// single injector loop: concurrency limit, injector selection, channel lock,
+// stop, status and history.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/driver"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// Common errors
+var (
+	ErrMaxConcurrentReached = errors.New("maximum concurrent attacks reached")
+	ErrAttackNotFound       = errors.New("attack not found")
+	ErrAttackNotActive      = errors.New("attack is not active")
+	ErrNoInjectorAvailable  = errors.New("no injector available")
+)
+
+// DefaultMaxConcurrent is the concurrency limit when none is given.
+const DefaultMaxConcurrent = 5
+
+// Spec describes an attack type to the Runner. C is its configuration and S
+// the status it reports.
+type Spec[C, S any] struct {
+	Kind domain.AttackKind
+	Name string // Used in log messages, e.g. "Probe Flood"
+
+	// Validate checks a configuration and may apply its defaults.
+	Validate func(config *C) error
+	// Radio returns the interface field of a configuration, defaulted to the
+	// engine's injector, and the channel to lock (0 for none).
+	Radio func(config *C) (iface *string, channel int)
+	// Run executes the attack until it is done or ctx is cancelled, sending its
+	// progress on updates.
+	Run func(injector *injection.Injector, ctx context.Context, config C, updates chan<- S) error
+	// Describe summarizes a configuration for the start log message.
+	Describe func(config C) string
+	// Status builds the status reported for an attack.
+	Status func(attack Snapshot[C, S]) S
+	// Record fills the target and counters of the history record of an attack.
+	Record func(record *domain.AttackRecord, attack Snapshot[C, S])
+}
+
+// Snapshot is the state of an attack.
+type Snapshot[C, S any] struct {
+	ID           string
+	Config       C
+	Status       domain.AttackStatus
+	StartTime    time.Time
+	EndTime      *time.Time
+	ErrorMessage string
+	Progress     S // Last update sent by Spec.Run
+}
+
+// controller manages the lifecycle of a single attack
+type controller[C, S any] struct {
+	mu       sync.RWMutex
+	state    Snapshot[C, S]
+	cancel   context.CancelFunc
+	injector *injection.Injector // Dedicated injector for this attack
+}
+
+// snapshot returns a copy of the attack state
+func (c *controller[C, S]) snapshot() Snapshot[C, S] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// closeInjectorLocked closes the dedicated injector. Caller holds c.mu.
+func (c *controller[C, S]) closeInjectorLocked() {
+	if c.injector != nil {
+		c.injector.Close()
+		c.injector = nil
+	}
+}
+
+// Runner manages multiple concurrent attacks of one kind.
+type Runner[C, S any] struct {
+	Hooks
+
+	spec          Spec[C, S]
+	injector      *injection.Injector
+	locker        capture.ChannelLocker
+	maxConcurrent int
+
+	mu            sync.RWMutex
+	activeAttacks map[string]*controller[C, S]
+}
+
+// New creates a runner for spec. The injector is used unless an attack asks
+// for another interface, the locker (optional) holds the attack's channel.
+func New[C, S any](spec Spec[C, S], injector *injection.Injector, locker capture.ChannelLocker, maxConcurrent int) *Runner[C, S] {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrent
+	}
+	return &Runner[C, S]{
+		spec:          spec,
+		injector:      injector,
+		locker:        locker,
+		maxConcurrent: maxConcurrent,
+		activeAttacks: make(map[string]*controller[C, S]),
+	}
+}
+
+// prepareInjector selects or creates an injector for the attack
+// Returns: (attackInjector, dedicatedInjector, error)
+func (r *Runner[C, S]) prepareInjector(config *C) (*injection.Injector, *injection.Injector, error) {
+	iface, channel := r.spec.Radio(config)
+
+	// Set default interface if not specified
+	if *iface == "" && r.injector != nil {
+		*iface = r.injector.Interface
+	}
+
+	// Use default injector if no specific interface requested, or if it matches
+	if *iface == "" || (r.injector != nil && r.injector.Interface == *iface) {
+		return r.injector, nil, nil
+	}
+
+	// Set channel if specified
+	if channel > 0 {
+		if err := driver.SetInterfaceChannel(*iface, channel); err != nil {
+			r.Log(fmt.Sprintf("Warning: Failed to set channel %d on %s: %v", channel, *iface, err), "warning")
+		}
+	}
+
+	// Create dedicated injector for this interface
+	inj, err := injection.NewInjector(*iface)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create injector for interface %s: %w", *iface, err)
+	}
+
+	return inj, inj, nil
+}
+
+// StartAttack validates config and starts the attack in the background.
+func (r *Runner[C, S]) StartAttack(ctx context.Context, config C) (string, error) {
+	// Cleanup finished attacks first
+	r.CleanupFinished()
+
+	if err := r.spec.Validate(&config); err != nil {
+		return "", err
+	}
+
+	r.mu.RLock()
+	active := len(r.activeAttacks)
+	r.mu.RUnlock()
+	if active >= r.maxConcurrent {
+		return "", fmt.Errorf("%w (%d)", ErrMaxConcurrentReached, r.maxConcurrent)
+	}
+
+	attackInjector, dedicatedInjector, err := r.prepareInjector(&config)
+	if err != nil {
+		return "", err
+	}
+
+	attackID := uuid.New().String()
+	attackCtx, cancel := context.WithCancel(ctx)
+	c := &controller[C, S]{
+		cancel:   cancel,
+		injector: dedicatedInjector,
+		state: Snapshot[C, S]{
+			ID:        attackID,
+			Config:    config,
+			Status:    domain.AttackPending,
+			StartTime: time.Now(),
+		},
+	}
+
+	r.mu.Lock()
+	r.activeAttacks[attackID] = c
+	r.mu.Unlock()
+
+	go r.runAttack(attackCtx, c, attackInjector)
+
+	r.Log(fmt.Sprintf("Started %s %s %s", r.spec.Name, attackID, r.spec.Describe(config)), "success")
+	return attackID, nil
+}
+
+// runAttack executes the attack with proper resource management
+func (r *Runner[C, S]) runAttack(ctx context.Context, c *controller[C, S], injector *injection.Injector) {
+	// Ensure cleanup and panic recovery, then record the outcome
+	defer r.recordHistory(c)
+	defer func() {
+		c.mu.Lock()
+		c.closeInjectorLocked()
+		c.mu.Unlock()
+	}()
+	defer r.handlePanic(c)
+
+	// lockCtx is cancelled if the channel lock is preempted
+	action := func(lockCtx context.Context) error {
+		return r.execute(lockCtx, c, injector)
+	}
+
+	var err error
+	attack := c.snapshot()
+	iface, channel := r.spec.Radio(&attack.Config)
+	if r.locker != nil && channel > 0 {
+		c.mu.Lock()
+		if c.state.Status == domain.AttackPending {
+			c.state.Status = domain.AttackQueued
+		}
+		c.mu.Unlock()
+		err = capture.RunLocked(ctx, r.locker, *iface, channel, domain.LockPriorityAttack, attack.ID, action)
+		if err != nil && errors.Is(err, ctx.Err()) {
+			err = nil // Stopped while queued
+		}
+	} else {
+		err = action(ctx)
+	}
+
+	r.finish(c, err)
+}
+
+// execute runs the attack loop, keeping the last progress update
+func (r *Runner[C, S]) execute(ctx context.Context, c *controller[C, S], injector *injection.Injector) error {
+	if injector == nil {
+		return ErrNoInjectorAvailable
+	}
+
+	c.mu.Lock()
+	c.state.Status = domain.AttackRunning
+	config := c.state.Config
+	c.mu.Unlock()
+
+	updates := make(chan S, 10)
+	consumed := make(chan struct{})
+	go func() {
+		defer close(consumed)
+		for update := range updates {
+			c.mu.Lock()
+			c.state.Progress = update
+			c.mu.Unlock()
+		}
+	}()
+
+	err := r.spec.Run(injector, ctx, config, updates)
+	close(updates)
+	<-consumed
+	return err
+}
+
+// handlePanic recovers from panics and marks the attack failed
+func (r *Runner[C, S]) handlePanic(c *controller[C, S]) {
+	if p := recover(); p != nil {
+		c.mu.Lock()
+		c.state.Status = domain.AttackFailed
+		c.state.ErrorMessage = fmt.Sprintf("panic: %v", p)
+		now := time.Now()
+		c.state.EndTime = &now
+		id := c.state.ID
+		c.mu.Unlock()
+
+		r.Log(fmt.Sprintf("%s %s panicked: %v", r.spec.Name, id, p), "danger")
+	}
+}
+
+// finish sets the final status of an attack
+func (r *Runner[C, S]) finish(c *controller[C, S], err error) {
+	c.mu.Lock()
+	now := time.Now()
+	if err != nil {
+		c.state.Status = domain.AttackFailed
+		c.state.ErrorMessage = err.Error()
+	} else {
+		// Also covers attacks stopped before they left the lock queue
+		c.state.Status = domain.AttackStopped
+	}
+	c.state.EndTime = &now
+	id := c.state.ID
+	c.mu.Unlock()
+
+	if err != nil {
+		r.Log(fmt.Sprintf("%s %s failed: %v", r.spec.Name, id, err), "error")
+	} else {
+		r.Log(fmt.Sprintf("%s %s completed", r.spec.Name, id), "info")
+	}
+}
+
+// recordHistory reports the final outcome of an attack to the recorder
+func (r *Runner[C, S]) recordHistory(c *controller[C, S]) {
+	attack := c.snapshot()
+	record := domain.NewAttackRecord(r.spec.Kind, attack.ID, attack.Config, attack.StartTime, attack.EndTime)
+	iface, channel := r.spec.Radio(&attack.Config)
+	record.Interface = *iface
+	record.Channel = channel
+	record.Status = string(attack.Status)
+	record.ErrorMessage = attack.ErrorMessage
+	r.spec.Record(&record, attack)
+	r.Record(record)
+}
+
+// StopAttack stops a running attack
+func (r *Runner[C, S]) StopAttack(ctx context.Context, id string, force bool) error {
+	r.mu.RLock()
+	c, exists := r.activeAttacks[id]
+	r.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrAttackNotFound, id)
+	}
+
+	c.mu.Lock()
+	switch c.state.Status {
+	case domain.AttackRunning, domain.AttackPaused, domain.AttackQueued, domain.AttackPending:
+	default:
+		if !force {
+			c.mu.Unlock()
+			return fmt.Errorf("%w: %s", ErrAttackNotActive, id)
+		}
+	}
+	c.cancel()
+	c.closeInjectorLocked()
+	c.state.Status = domain.AttackStopped
+	now := time.Now()
+	c.state.EndTime = &now
+	if force {
+		c.state.ErrorMessage = "Force stopped by user"
+	}
+	c.mu.Unlock()
+
+	r.Log(fmt.Sprintf("Stopped %s %s", r.spec.Name, id), "warning")
+	return nil
+}
+
+// GetStatus returns the current status of an attack
+func (r *Runner[C, S]) GetStatus(ctx context.Context, id string) (S, error) {
+	r.mu.RLock()
+	c, exists := r.activeAttacks[id]
+	r.mu.RUnlock()
+	if !exists {
+		var zero S
+		return zero, fmt.Errorf("%w: %s", ErrAttackNotFound, id)
+	}
+	return r.spec.Status(c.snapshot()), nil
+}
+
+// CleanupFinished removes finished attacks from the active list
+func (r *Runner[C, S]) CleanupFinished() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, c := range r.activeAttacks {
+		status := c.snapshot().Status
+		if status == domain.AttackStopped || status == domain.AttackFailed {
+			delete(r.activeAttacks, id)
+		}
+	}
+}
+
+// StopAll stops all active attacks
+func (r *Runner[C, S]) StopAll(ctx context.Context) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, c := range r.activeAttacks {
+		c.mu.Lock()
+		c.cancel()
+		c.closeInjectorLocked()
+		if c.state.Status == domain.AttackRunning || c.state.Status == domain.AttackQueued || c.state.Status == domain.AttackPending {
+			c.state.Status = domain.AttackStopped
+			now := time.Now()
+			c.state.EndTime = &now
+			c.state.ErrorMessage = "Service shutdown"
+		}
+		c.mu.Unlock()
+	}
+}
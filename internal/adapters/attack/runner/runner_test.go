@@ -0,0 +1,93 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Interface string
+	Target    string
+}
+
+// testSpec runs an attack that reports one update and waits to be stopped
+func testSpec() Spec[testConfig, int] {
+	return Spec[testConfig, int]{
+		Kind: domain.AttackKindProbeFlood,
+		Name: "Test",
+		Validate: func(config *testConfig) error {
+			if config.Target == "" {
+				return errors.New("target required")
+			}
+			return nil
+		},
+		Radio: func(config *testConfig) (*string, int) { return &config.Interface, 0 },
+		Run: func(injector *injection.Injector, ctx context.Context, config testConfig, updates chan<- int) error {
+			updates <- 42
+			<-ctx.Done()
+			return nil
+		},
+		Describe: func(config testConfig) string { return config.Target },
+		Status:   func(attack Snapshot[testConfig, int]) int { return attack.Progress },
+		Record: func(record *domain.AttackRecord, attack Snapshot[testConfig, int]) {
+			record.Target = attack.Config.Target
+			record.PacketsSent = attack.Progress
+		},
+	}
+}
+
+func TestRunner_Lifecycle(t *testing.T) {
+	r := New(testSpec(), &injection.Injector{Interface: "wlan0"}, nil, 1)
+	records := make(chan domain.AttackRecord, 1)
+	r.SetRecorder(func(record domain.AttackRecord) { records <- record })
+
+	_, err := r.StartAttack(context.Background(), testConfig{})
+	require.Error(t, err, "invalid configs are refused")
+
+	id, err := r.StartAttack(context.Background(), testConfig{Target: "aa:bb:cc:dd:ee:ff"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		progress, err := r.GetStatus(context.Background(), id)
+		return err == nil && progress == 42
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = r.StartAttack(context.Background(), testConfig{Target: "aa:bb:cc:dd:ee:ff"})
+	assert.ErrorIs(t, err, ErrMaxConcurrentReached)
+
+	require.NoError(t, r.StopAttack(context.Background(), id, false))
+	select {
+	case record := <-records:
+		assert.Equal(t, id, record.ID)
+		assert.Equal(t, "wlan0", record.Interface, "interface defaults to the injector's")
+		assert.Equal(t, "aa:bb:cc:dd:ee:ff", record.Target)
+		assert.Equal(t, string(domain.AttackStopped), record.Status)
+		assert.Equal(t, 42, record.PacketsSent)
+	case <-time.After(time.Second):
+		t.Fatal("finished attack was not recorded")
+	}
+
+	assert.ErrorIs(t, r.StopAttack(context.Background(), id, false), ErrAttackNotActive)
+	r.CleanupFinished()
+	_, err = r.GetStatus(context.Background(), id)
+	assert.ErrorIs(t, err, ErrAttackNotFound)
+}
+
+func TestRunner_NoInjector(t *testing.T) {
+	r := New(testSpec(), nil, nil, 1)
+
+	id, err := r.StartAttack(context.Background(), testConfig{Target: "x"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		return r.activeAttacks[id].snapshot().Status == domain.AttackFailed
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, ErrNoInjectorAvailable.Error(), r.activeAttacks[id].snapshot().ErrorMessage)
+}
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/runner"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
@@ -93,6 +94,8 @@ type WPSEngine struct {
 	mu              sync.RWMutex
 	locker          capture.ChannelLocker
 	parser          *ReaverParser
+
+	runner.Hooks // Recorder only, logs go through SetCallbacks
 }
 
 // VulnerabilityConfirmer defines the interface for confirming vulnerabilities
@@ -135,6 +138,29 @@ func (s *WPSEngine) SetCallbacks(logCb func(string, string), statusCb func(domai
 	s.statusCb = statusCb
 }
 
+// recordHistory reports the final outcome of an attack to the recorder
+func (s *WPSEngine) recordHistory(id string, config domain.WPSAttackConfig) {
+	s.mu.RLock()
+	var status domain.WPSAttackStatus
+	st, ok := s.activeAttacks[id]
+	if ok {
+		status = *st
+	}
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	record := domain.NewAttackRecord(domain.AttackKindWPS, id, config, status.StartTime, status.EndTime)
+	record.Target = config.TargetBSSID
+	record.Interface = config.Interface
+	record.Channel = config.Channel
+	record.Status = string(status.Status)
+	record.CredentialsRecovered = status.RecoveredPIN != "" || status.RecoveredPSK != ""
+	record.ErrorMessage = status.ErrorMessage
+	s.Record(record)
+}
+
 // SetToolPaths configures the paths for external tools
 func (s *WPSEngine) SetToolPaths(reaverPath, pixiewpsPath string) {
 	s.mu.Lock()
@@ -157,10 +183,10 @@ func (s *WPSEngine) SetVulnerabilityPersistence(vulnPersistence VulnerabilityCon
 // HealthCheck verifies if the necessary tools are installed
 func (s *WPSEngine) HealthCheck(ctx context.Context) error {
 	if _, err := exec.LookPath(s.reaverPath); err != nil {
-		return fmt.Errorf("%s not found (install with: sudo apt install reaver)", s.reaverPath)
+		return fmt.Errorf("%w: %s not found (install with: sudo apt install reaver)", domain.ErrToolMissing, s.reaverPath)
 	}
 	if _, err := exec.LookPath(s.pixiewpsPath); err != nil {
-		return fmt.Errorf("%s not found (install with: sudo apt install pixiewps)", s.pixiewpsPath)
+		return fmt.Errorf("%w: %s not found (install with: sudo apt install pixiewps)", domain.ErrToolMissing, s.pixiewpsPath)
 	}
 	return nil
 }
@@ -265,8 +291,10 @@ func (s *WPSEngine) buildReaverArgs(config domain.WPSAttackConfig) []string {
 
 // runAttack executes the attack logic
 func (s *WPSEngine) runAttack(ctx context.Context, id string, config domain.WPSAttackConfig) {
-	// Wrapper for execution with lock
-	action := func() error {
+	defer s.recordHistory(id, config)
+
+	// Wrapper for execution with lock (ctx is cancelled if the channel lock is preempted)
+	action := func(ctx context.Context) error {
 		defer func() {
 			s.mu.Lock()
 			if cancel, ok := s.cancelFuncs[id]; ok {
@@ -350,19 +378,25 @@ func (s *WPSEngine) runAttack(ctx context.Context, id string, config domain.WPSA
 
 	var err error
 	if s.locker != nil && config.Interface != "" {
-		err = s.locker.ExecuteWithLock(ctx, config.Interface, config.Channel, func() error {
-			if execErr := action(); execErr != nil {
+		s.updateStatus(id, domain.WPSStatusQueued, "")
+		err = capture.RunLocked(ctx, s.locker, config.Interface, config.Channel, domain.LockPriorityAttack, id, func(lockCtx context.Context) error {
+			s.updateStatus(id, domain.WPSStatusRunning, "")
+			if execErr := action(lockCtx); execErr != nil {
 				s.updateStatus(id, domain.WPSStatusFailed, execErr.Error())
 			}
 			return nil
 		})
 	} else {
-		if execErr := action(); execErr != nil {
+		if execErr := action(ctx); execErr != nil {
 			s.updateStatus(id, domain.WPSStatusFailed, execErr.Error())
 		}
 	}
 
-	if err != nil {
+	if errors.Is(err, domain.ErrLockPreempted) {
+		s.updateStatus(id, domain.WPSStatusFailed, err.Error())
+	} else if err != nil && errors.Is(err, ctx.Err()) {
+		// Stopped while queued; StopAttack already recorded the outcome
+	} else if err != nil {
 		s.updateStatus(id, domain.WPSStatusFailed, fmt.Sprintf("Failed to lock channel: %v", err))
 	}
 }
@@ -458,7 +492,7 @@ func (s *WPSEngine) StopAttack(ctx context.Context, id string, force bool) error
 	}
 
 	if status, exists := s.activeAttacks[id]; exists {
-		if status.Status == domain.WPSStatusRunning || force {
+		if status.Status == domain.WPSStatusRunning || status.Status == domain.WPSStatusQueued || force {
 			// Check if we use "stopped", it wasn't in the domain consts so fallback to Failed or add Stopped?
 			// Domain has Failed, Success, Timeout. It lacks "Stopped".
 			// Let's use Failed with message "Stopped by user" for now or add it.
@@ -495,7 +529,7 @@ func (s *WPSEngine) StopAll(ctx context.Context) {
 	for id, cancel := range s.cancelFuncs {
 		cancel()
 		if status, exists := s.activeAttacks[id]; exists {
-			if status.Status == domain.WPSStatusRunning {
+			if status.Status == domain.WPSStatusRunning || status.Status == domain.WPSStatusQueued {
 				status.Status = domain.WPSStatusFailed
 				now := time.Now()
 				status.EndTime = &now
@@ -0,0 +1,212 @@
+// Package bluetooth runs classic Bluetooth inquiry scans on a local HCI
+// controller through a raw HCI socket, so the class of device and RSSI of
+// each answer are available, which the BlueZ command line tools do not
+// report together.
+package bluetooth
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// HCI packet indicators
+const (
+	hciCommandPkt = 0x01
+	hciEventPkt   = 0x04
+)
+
+// HCI command opcodes
+const (
+	opInquiry           = 0x0401 // Link Control, Inquiry
+	opRemoteNameRequest = 0x0419 // Link Control, Remote Name Request
+	opWriteInquiryMode  = 0x0C45 // Controller & Baseband, Write Inquiry Mode
+)
+
+// HCI event codes
+const (
+	evInquiryComplete         = 0x01
+	evInquiryResult           = 0x02
+	evRemoteNameComplete      = 0x07
+	evCommandComplete         = 0x0E
+	evCommandStatus           = 0x0F
+	evInquiryResultWithRSSI   = 0x22
+	evExtendedInquiryResult   = 0x2F
+	inquiryModeExtended       = 0x02 // Results with RSSI and EIR data
+	inquiryLengthUnit         = 1280 * time.Millisecond
+	maxInquiryLength          = 0x30
+	inquiryResponseSize       = 14 // Per device, in the three result events
+	extendedInquiryDataOffset = 15 // Num_Responses and the fields before the EIR data
+)
+
+// generalInquiryLAP is the GIAC, answered by all discoverable devices.
+var generalInquiryLAP = [3]byte{0x33, 0x8B, 0x9E}
+
+// EIR data types of the device name
+const (
+	eirShortName    = 0x08
+	eirCompleteName = 0x09
+)
+
+// hciCommand encodes an HCI command packet.
+func hciCommand(opcode uint16, params ...byte) []byte {
+	packet := []byte{hciCommandPkt, 0, 0, byte(len(params))}
+	binary.LittleEndian.PutUint16(packet[1:3], opcode)
+	return append(packet, params...)
+}
+
+// inquiryCommand starts a general inquiry of about duration.
+func inquiryCommand(duration time.Duration) []byte {
+	length := int((duration + inquiryLengthUnit/2) / inquiryLengthUnit)
+	if length < 1 {
+		length = 1
+	}
+	if length > maxInquiryLength {
+		length = maxInquiryLength
+	}
+	return hciCommand(opInquiry, generalInquiryLAP[0], generalInquiryLAP[1], generalInquiryLAP[2], byte(length), 0)
+}
+
+// remoteNameCommand asks the device at address for its name.
+func remoteNameCommand(address string) ([]byte, error) {
+	bdaddr, err := parseBDAddr(address)
+	if err != nil {
+		return nil, err
+	}
+	params := append(bdaddr[:], 0x02, 0, 0, 0) // Page scan repetition mode R2, no clock offset
+	return hciCommand(opRemoteNameRequest, params...), nil
+}
+
+// hciEvent is an HCI event packet, without its indicator.
+type hciEvent struct {
+	code   byte
+	params []byte
+}
+
+// parseEvent decodes an HCI event packet read from the socket.
+func parseEvent(packet []byte) (hciEvent, bool) {
+	if len(packet) < 3 || packet[0] != hciEventPkt {
+		return hciEvent{}, false
+	}
+	length := int(packet[2])
+	if len(packet) < 3+length {
+		return hciEvent{}, false
+	}
+	return hciEvent{code: packet[1], params: packet[3 : 3+length]}, true
+}
+
+// commandStatus returns the opcode and status of a Command Status or Command
+// Complete event.
+func (e hciEvent) commandStatus() (opcode uint16, status byte, ok bool) {
+	switch {
+	case e.code == evCommandStatus && len(e.params) >= 4:
+		return binary.LittleEndian.Uint16(e.params[2:4]), e.params[0], true
+	case e.code == evCommandComplete && len(e.params) >= 4:
+		return binary.LittleEndian.Uint16(e.params[1:3]), e.params[3], true
+	}
+	return 0, 0, false
+}
+
+// inquiryResults decodes the devices of the three inquiry result events.
+// The standard result carries no RSSI.
+func (e hciEvent) inquiryResults(seen time.Time) []domain.BluetoothDevice {
+	if len(e.params) < 1 {
+		return nil
+	}
+	count := int(e.params[0])
+	var devices []domain.BluetoothDevice
+	for i := 0; i < count; i++ {
+		offset := 1 + i*inquiryResponseSize
+		if len(e.params) < offset+inquiryResponseSize {
+			break
+		}
+		r := e.params[offset : offset+inquiryResponseSize]
+		device := domain.BluetoothDevice{Address: formatBDAddr(r[0:6]), LastSeen: seen}
+		switch e.code {
+		case evInquiryResult:
+			device.Class = classOfDevice(r[9:12])
+		case evInquiryResultWithRSSI, evExtendedInquiryResult:
+			device.Class = classOfDevice(r[8:11])
+			device.RSSI = int(int8(r[13]))
+		default:
+			return nil
+		}
+		device.DeviceClass = domain.BluetoothMajorClass(device.Class)
+		if e.code == evExtendedInquiryResult {
+			if len(e.params) > extendedInquiryDataOffset {
+				device.Name = eirName(e.params[extendedInquiryDataOffset:])
+			}
+			return []domain.BluetoothDevice{device} // Always a single response
+		}
+		devices = append(devices, device)
+	}
+	return devices
+}
+
+// remoteName decodes a Remote Name Request Complete event.
+func (e hciEvent) remoteName() (address, name string, ok bool) {
+	if e.code != evRemoteNameComplete || len(e.params) < 7 || e.params[0] != 0 {
+		return "", "", false
+	}
+	return formatBDAddr(e.params[1:7]), cString(e.params[7:]), true
+}
+
+// eirName returns the device name in Extended Inquiry Response data.
+func eirName(eir []byte) string {
+	short := ""
+	for len(eir) >= 2 {
+		length := int(eir[0])
+		if length == 0 || len(eir) < 1+length {
+			break
+		}
+		data := eir[2 : 1+length]
+		switch eir[1] {
+		case eirCompleteName:
+			return cString(data)
+		case eirShortName:
+			short = cString(data)
+		}
+		eir = eir[1+length:]
+	}
+	return short
+}
+
+func classOfDevice(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
+
+// formatBDAddr formats a little endian BD_ADDR as a MAC address.
+func formatBDAddr(b []byte) string {
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", b[5], b[4], b[3], b[2], b[1], b[0])
+}
+
+// parseBDAddr encodes a MAC address as a little endian BD_ADDR.
+func parseBDAddr(address string) ([6]byte, error) {
+	var bdaddr [6]byte
+	parts := strings.Split(address, ":")
+	if len(parts) != 6 {
+		return bdaddr, fmt.Errorf("invalid Bluetooth address %q", address)
+	}
+	for i, part := range parts {
+		value, err := strconv.ParseUint(part, 16, 8)
+		if err != nil {
+			return bdaddr, fmt.Errorf("invalid Bluetooth address %q", address)
+		}
+		bdaddr[5-i] = byte(value)
+	}
+	return bdaddr, nil
+}
+
+// cString returns the UTF-8 text up to the first NUL.
+func cString(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,122 @@
+//go:build linux
+
+package bluetooth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// hciFilter is the HCI_FILTER socket option, missing from x/sys/unix.
+const hciFilter = 2
+
+// hciConn is a raw HCI socket bound to a controller.
+type hciConn struct {
+	fd int
+}
+
+func openHCI(index uint16) (*hciConn, error) {
+	fd, err := unix.Socket(unix.AF_BLUETOOTH, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.BTPROTO_HCI)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Bind(fd, &unix.SockaddrHCI{Dev: index, Channel: unix.HCI_CHANNEL_RAW}); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+
+	// struct hci_filter: packet type mask, event mask, opcode
+	filter := make([]byte, 14)
+	filter[0] = 1 << hciEventPkt
+	for _, code := range []byte{evInquiryComplete, evInquiryResult, evRemoteNameComplete, evCommandComplete, evCommandStatus, evInquiryResultWithRSSI, evExtendedInquiryResult} {
+		filter[4+code/8] |= 1 << (code % 8)
+	}
+	if err := unix.SetsockoptString(fd, unix.SOL_HCI, hciFilter, string(filter)); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	return &hciConn{fd: fd}, nil
+}
+
+func (c *hciConn) Close() error {
+	return unix.Close(c.fd)
+}
+
+// read returns the next event, or an event with code 0 if none arrived
+// before the deadline.
+func (c *hciConn) read(deadline time.Time) (hciEvent, error) {
+	wait := time.Until(deadline)
+	if wait > 500*time.Millisecond {
+		wait = 500 * time.Millisecond // Wake up to check the context
+	}
+	if wait <= 0 {
+		return hciEvent{}, nil
+	}
+	tv := unix.NsecToTimeval(wait.Nanoseconds())
+	if err := unix.SetsockoptTimeval(c.fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		return hciEvent{}, err
+	}
+	buf := make([]byte, 260)
+	n, err := unix.Read(c.fd, buf)
+	if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+		return hciEvent{}, nil
+	}
+	if err != nil {
+		return hciEvent{}, err
+	}
+	event, _ := parseEvent(buf[:n])
+	return event, nil
+}
+
+// command sends packet and waits for the status of opcode.
+func (c *hciConn) command(ctx context.Context, packet []byte, opcode uint16, timeout time.Duration) error {
+	if _, err := unix.Write(c.fd, packet); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		event, err := c.read(deadline)
+		if err != nil {
+			return err
+		}
+		if op, status, ok := event.commandStatus(); ok && op == opcode {
+			if status != 0 {
+				return fmt.Errorf("controller returned status 0x%02x", status)
+			}
+			return nil
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return fmt.Errorf("no answer from the controller")
+}
+
+// remoteName asks the device at address for its name, "" if it did not say.
+func (c *hciConn) remoteName(ctx context.Context, address string) string {
+	packet, err := remoteNameCommand(address)
+	if err != nil {
+		return ""
+	}
+	if err := c.command(ctx, packet, opRemoteNameRequest, time.Second); err != nil {
+		return ""
+	}
+	deadline := time.Now().Add(nameTimeout)
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		event, err := c.read(deadline)
+		if err != nil {
+			return ""
+		}
+		if addr, name, ok := event.remoteName(); ok && addr == address {
+			return name
+		}
+		if event.code == evRemoteNameComplete {
+			return "" // Failed for this device
+		}
+	}
+	return ""
+}
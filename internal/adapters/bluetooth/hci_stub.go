@@ -0,0 +1,26 @@
+//go:build !linux
+
+package bluetooth
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// hciConn is only supported on linux.
+type hciConn struct{}
+
+func openHCI(index uint16) (*hciConn, error) {
+	return nil, errors.New("Bluetooth inquiry only supported on linux")
+}
+
+func (c *hciConn) Close() error { return nil }
+
+func (c *hciConn) read(deadline time.Time) (hciEvent, error) { return hciEvent{}, nil }
+
+func (c *hciConn) command(ctx context.Context, packet []byte, opcode uint16, timeout time.Duration) error {
+	return errors.New("Bluetooth inquiry only supported on linux")
+}
+
+func (c *hciConn) remoteName(ctx context.Context, address string) string { return "" }
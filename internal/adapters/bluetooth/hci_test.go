@@ -0,0 +1,74 @@
+package bluetooth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInquiryCommand(t *testing.T) {
+	assert.Equal(t, []byte{0x01, 0x01, 0x04, 0x05, 0x33, 0x8B, 0x9E, 0x08, 0x00}, inquiryCommand(10*time.Second))
+	assert.Equal(t, byte(1), inquiryCommand(0)[7])
+	assert.Equal(t, byte(maxInquiryLength), inquiryCommand(time.Hour)[7])
+}
+
+func TestParseInquiryResultWithRSSI(t *testing.T) {
+	packet := []byte{hciEventPkt, evInquiryResultWithRSSI, 29, 2,
+		0x66, 0x55, 0x44, 0x33, 0x22, 0x11, 0x01, 0x00, 0x0c, 0x02, 0x5a, 0x00, 0x00, 0xC4, // -60 dBm, phone
+		0x06, 0x05, 0x04, 0x03, 0x02, 0x01, 0x01, 0x00, 0x04, 0x04, 0x24, 0x00, 0x00, 0xB0, // -80 dBm, headset
+	}
+	event, ok := parseEvent(packet)
+	require.True(t, ok)
+	devices := event.inquiryResults(time.Now())
+	require.Len(t, devices, 2)
+	assert.Equal(t, "11:22:33:44:55:66", devices[0].Address)
+	assert.Equal(t, uint32(0x5a020c), devices[0].Class)
+	assert.Equal(t, "phone", devices[0].DeviceClass)
+	assert.Equal(t, -60, devices[0].RSSI)
+	assert.Equal(t, "audio_video", devices[1].DeviceClass)
+	assert.Equal(t, -80, devices[1].RSSI)
+}
+
+func TestParseExtendedInquiryResult(t *testing.T) {
+	params := []byte{1, 0x66, 0x55, 0x44, 0x33, 0x22, 0x11, 0x01, 0x00, 0x0c, 0x01, 0x1c, 0x00, 0x00, 0xD8}
+	params = append(params, 0x04, eirShortName, 'L', 'a', 'p')
+	params = append(params, 0x07, eirCompleteName, 'L', 'a', 'p', 't', 'o', 'p')
+	params = append(params, make([]byte, 240-13)...)
+	packet := append([]byte{hciEventPkt, evExtendedInquiryResult, byte(len(params))}, params...)
+
+	event, ok := parseEvent(packet)
+	require.True(t, ok)
+	devices := event.inquiryResults(time.Now())
+	require.Len(t, devices, 1)
+	assert.Equal(t, "Laptop", devices[0].Name)
+	assert.Equal(t, "computer", devices[0].DeviceClass)
+	assert.Equal(t, -40, devices[0].RSSI)
+}
+
+func TestParseRemoteName(t *testing.T) {
+	params := append([]byte{0x00, 0x66, 0x55, 0x44, 0x33, 0x22, 0x11}, []byte("Car Kit\x00\x00")...)
+	event, ok := parseEvent(append([]byte{hciEventPkt, evRemoteNameComplete, byte(len(params))}, params...))
+	require.True(t, ok)
+	address, name, ok := event.remoteName()
+	require.True(t, ok)
+	assert.Equal(t, "11:22:33:44:55:66", address)
+	assert.Equal(t, "Car Kit", name)
+
+	cmd, err := remoteNameCommand(address)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x66, 0x55, 0x44, 0x33, 0x22, 0x11}, cmd[4:10])
+}
+
+func TestCommandStatus(t *testing.T) {
+	event, ok := parseEvent([]byte{hciEventPkt, evCommandStatus, 4, 0x0c, 0x01, 0x01, 0x04})
+	require.True(t, ok)
+	opcode, status, ok := event.commandStatus()
+	require.True(t, ok)
+	assert.Equal(t, uint16(opInquiry), opcode)
+	assert.Equal(t, byte(0x0c), status)
+
+	_, ok = parseEvent([]byte{hciEventPkt, evCommandStatus, 4, 0x00})
+	assert.False(t, ok, "truncated event")
+}
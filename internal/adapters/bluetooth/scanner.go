@@ -0,0 +1,90 @@
+package bluetooth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// nameTimeout bounds each remote name request after the inquiry.
+const nameTimeout = 5 * time.Second
+
+// Scanner runs inquiry scans on an HCI controller. The controller is put in
+// extended inquiry mode, so answers carry their RSSI and usually their name;
+// devices that did not send it are asked for it after the inquiry.
+type Scanner struct {
+	Controller   string // e.g. hci0
+	ResolveNames bool   // Ask devices without a name in their answer for it
+}
+
+// NewScanner creates a scanner on controller, such as "hci0".
+func NewScanner(controller string) *Scanner {
+	return &Scanner{Controller: controller, ResolveNames: true}
+}
+
+// device returns the controller index of the scanner.
+func (s *Scanner) device() (uint16, error) {
+	index, err := strconv.ParseUint(strings.TrimPrefix(s.Controller, "hci"), 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Bluetooth controller %q, expected hciN", s.Controller)
+	}
+	return uint16(index), nil
+}
+
+// Inquiry scans for discoverable devices for about duration, rounded to the
+// 1.28 s inquiry units. Devices answering more than once are reported once,
+// with their last RSSI.
+func (s *Scanner) Inquiry(ctx context.Context, duration time.Duration) ([]domain.BluetoothDevice, error) {
+	index, err := s.device()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := openHCI(index)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open %s: %w", s.Controller, err)
+	}
+	defer conn.Close()
+
+	// Older controllers reject the extended mode and answer without RSSI
+	_ = conn.command(ctx, hciCommand(opWriteInquiryMode, inquiryModeExtended), opWriteInquiryMode, time.Second)
+
+	if err := conn.command(ctx, inquiryCommand(duration), opInquiry, time.Second); err != nil {
+		return nil, fmt.Errorf("inquiry on %s failed: %w", s.Controller, err)
+	}
+
+	found := make(map[string]*domain.BluetoothDevice)
+	var order []string
+	deadline := time.Now().Add(duration + 5*time.Second)
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		event, err := conn.read(deadline)
+		if err != nil {
+			return nil, err
+		}
+		if event.code == evInquiryComplete {
+			break
+		}
+		for _, d := range event.inquiryResults(time.Now()) {
+			if known, ok := found[d.Address]; ok {
+				known.Merge(d)
+				continue
+			}
+			device := d
+			found[d.Address] = &device
+			order = append(order, d.Address)
+		}
+	}
+
+	devices := make([]domain.BluetoothDevice, 0, len(order))
+	for _, address := range order {
+		d := found[address]
+		if d.Name == "" && s.ResolveNames && ctx.Err() == nil {
+			d.Name = conn.remoteName(ctx, address)
+		}
+		devices = append(devices, *d)
+	}
+	return devices, ctx.Err()
+}
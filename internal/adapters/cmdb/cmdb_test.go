@@ -0,0 +1,83 @@
+package cmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCSV(t *testing.T) {
+	data := []byte("MAC Address,Owner,Asset_Tag,Criticality,Location\n" +
+		"00:11:22:33:44:55,Finance,POS-01,High,Floor 2\n" +
+		",Nobody,NO-MAC,low,\n" +
+		"AA-BB-CC-DD-EE-FF,IT,SRV-9,urgent,DC\n")
+	entries, err := ParseCSV(data)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "00:11:22:33:44:55", entries[0].MAC)
+	assert.Equal(t, domain.AssetInfo{Owner: "Finance", AssetTag: "POS-01", Criticality: domain.CriticalityHigh}, entries[0].Asset)
+	assert.Equal(t, "Floor 2", entries[0].Annotations["cmdb.location"])
+
+	// Unknown criticalities are kept as annotations only
+	assert.Empty(t, entries[1].Asset.Criticality)
+	assert.Equal(t, "urgent", entries[1].Annotations["cmdb.criticality"])
+}
+
+func TestExportSource_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "assets.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"results": [{"mac": "00:11:22:33:44:55", "owner": "Facilities", "rack": 4}]}`), 0600))
+
+	source, err := NewSource(FormatJSON, path, "")
+	require.NoError(t, err)
+	entries, err := source.FetchInventory(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Facilities", entries[0].Asset.Owner)
+	assert.Equal(t, "4", entries[0].Annotations["cmdb.rack"])
+}
+
+func TestNetBoxSource(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch {
+		case r.URL.Path == "/api/dcim/devices/":
+			w.Write([]byte(`{"next": null, "results": [
+				{"id": 1, "name": "lobby-ap", "asset_tag": "AP-0001", "tenant": {"id": 3, "name": "Facilities"},
+				 "site": {"id": 1, "name": "HQ"}, "role": {"id": 2, "name": "Access Point"},
+				 "custom_fields": {"criticality": "medium"}}]}`))
+		case r.URL.Path == "/api/dcim/interfaces/" && r.URL.Query().Get("offset") == "":
+			w.Write([]byte(`{"next": "` + srv.URL + `/api/dcim/interfaces/?limit=1000&offset=1000", "results": [
+				{"name": "wlan0", "mac_address": "00:11:22:33:44:55", "device": {"id": 1, "name": "lobby-ap"}},
+				{"name": "eth9", "mac_address": null, "device": {"id": 1, "name": "lobby-ap"}}]}`))
+		case r.URL.Path == "/api/dcim/interfaces/":
+			w.Write([]byte(`{"next": null, "results": [
+				{"name": "wlan1", "mac_address": "00:11:22:33:44:56", "device": {"id": 1, "name": "lobby-ap"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	source, err := NewSource(FormatNetBox, srv.URL+"/", "secret")
+	require.NoError(t, err)
+	entries, err := source.FetchInventory(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, domain.AssetInfo{Owner: "Facilities", AssetTag: "AP-0001", Criticality: domain.CriticalityMedium}, entries[0].Asset)
+	assert.Equal(t, "HQ", entries[0].Annotations["cmdb.site"])
+	assert.Equal(t, "Access Point", entries[0].Annotations["cmdb.role"])
+	assert.Equal(t, "wlan1", entries[1].Annotations["cmdb.interface"])
+}
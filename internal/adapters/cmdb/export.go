@@ -0,0 +1,134 @@
+package cmdb
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// AnnotationPrefix prefixes the device annotations holding inventory fields.
+const AnnotationPrefix = "cmdb."
+
+// Column names, compared case-insensitively and with spaces or dashes as
+// underscores, read into the asset; any other column becomes an annotation.
+var (
+	columnKey = strings.NewReplacer(" ", "_", "-", "_")
+
+	macColumns         = []string{"mac", "mac_address", "macaddress"}
+	ownerColumns       = []string{"owner", "tenant"}
+	assetTagColumns    = []string{"asset_tag", "asset", "asset_id"}
+	criticalityColumns = []string{"criticality"}
+)
+
+// ParseCSV parses an export with a header row naming the columns.
+func ParseCSV(data []byte) ([]domain.InventoryEntry, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV export: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	var entries []domain.InventoryEntry
+	for _, record := range records[1:] {
+		fields := make(map[string]string, len(header))
+		for i, name := range header {
+			if i < len(record) {
+				fields[name] = record[i]
+			}
+		}
+		if entry, ok := EntryFromFields(fields); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// ParseJSON parses an export holding an array of flat objects, either at the
+// top level or under "results" as REST APIs paginate.
+func ParseJSON(data []byte) ([]domain.InventoryEntry, error) {
+	var objects []map[string]interface{}
+	if err := json.Unmarshal(data, &objects); err != nil {
+		var page struct {
+			Results []map[string]interface{} `json:"results"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("invalid JSON export: %w", err)
+		}
+		objects = page.Results
+	}
+
+	var entries []domain.InventoryEntry
+	for _, object := range objects {
+		fields := make(map[string]string, len(object))
+		for k, v := range object {
+			switch v := v.(type) {
+			case string:
+				fields[k] = v
+			case float64, bool:
+				fields[k] = fmt.Sprint(v)
+			}
+		}
+		if entry, ok := EntryFromFields(fields); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// EntryFromFields builds an entry from named fields. Records without a MAC
+// are skipped, and an unknown criticality is kept only as an annotation.
+func EntryFromFields(fields map[string]string) (domain.InventoryEntry, bool) {
+	var entry domain.InventoryEntry
+	for name, value := range fields {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		key := columnKey.Replace(strings.ToLower(strings.TrimSpace(name)))
+		switch {
+		case contains(macColumns, key):
+			entry.MAC = value
+		case contains(ownerColumns, key):
+			entry.Asset.Owner = value
+		case contains(assetTagColumns, key):
+			entry.Asset.AssetTag = value
+		case contains(criticalityColumns, key):
+			entry.Asset.Criticality = domain.AssetCriticality(value)
+		default:
+			if entry.Annotations == nil {
+				entry.Annotations = make(map[string]string)
+			}
+			entry.Annotations[AnnotationPrefix+key] = value
+		}
+	}
+	if entry.MAC == "" {
+		return domain.InventoryEntry{}, false
+	}
+	if err := entry.Asset.Validate(); err != nil {
+		if entry.Annotations == nil {
+			entry.Annotations = make(map[string]string)
+		}
+		entry.Annotations[AnnotationPrefix+"criticality"] = string(entry.Asset.Criticality)
+		entry.Asset.Criticality = ""
+	}
+	return entry, true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package cmdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// maxPages bounds the pages followed per NetBox listing.
+const maxPages = 1000
+
+// NetBoxSource pulls the devices of a NetBox instance and the MACs of their
+// interfaces. The tenant is the owner, the asset tag is the device's, and
+// the criticality comes from a "criticality" custom field when defined.
+type NetBoxSource struct {
+	fetcher *fetcher
+}
+
+// Name identifies the source by the instance URL.
+func (s *NetBoxSource) Name() string { return "netbox@" + s.fetcher.name() }
+
+type netboxRef struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type netboxDevice struct {
+	ID           int                    `json:"id"`
+	Name         string                 `json:"name"`
+	AssetTag     string                 `json:"asset_tag"`
+	Tenant       *netboxRef             `json:"tenant"`
+	Site         *netboxRef             `json:"site"`
+	Role         *netboxRef             `json:"role"`
+	DeviceRole   *netboxRef             `json:"device_role"` // Before NetBox 4.0
+	CustomFields map[string]interface{} `json:"custom_fields"`
+}
+
+type netboxInterface struct {
+	Name       string     `json:"name"`
+	MACAddress string     `json:"mac_address"`
+	Device     *netboxRef `json:"device"`
+}
+
+// FetchInventory lists the interfaces with a MAC and the devices they
+// belong to.
+func (s *NetBoxSource) FetchInventory(ctx context.Context) ([]domain.InventoryEntry, error) {
+	var devices []netboxDevice
+	if err := s.list(ctx, "/api/dcim/devices/", &devices); err != nil {
+		return nil, err
+	}
+	byID := make(map[int]netboxDevice, len(devices))
+	for _, d := range devices {
+		byID[d.ID] = d
+	}
+
+	var interfaces []netboxInterface
+	if err := s.list(ctx, "/api/dcim/interfaces/", &interfaces); err != nil {
+		return nil, err
+	}
+
+	var entries []domain.InventoryEntry
+	for _, iface := range interfaces {
+		if iface.MACAddress == "" || iface.Device == nil {
+			continue
+		}
+		fields := map[string]string{
+			"mac":       iface.MACAddress,
+			"interface": iface.Name,
+			"name":      iface.Device.Name,
+		}
+		if d, ok := byID[iface.Device.ID]; ok {
+			fields["asset_tag"] = d.AssetTag
+			if d.Tenant != nil {
+				fields["owner"] = d.Tenant.Name
+			}
+			if d.Site != nil {
+				fields["site"] = d.Site.Name
+			}
+			if role := d.Role; role != nil || d.DeviceRole != nil {
+				if role == nil {
+					role = d.DeviceRole
+				}
+				fields["role"] = role.Name
+			}
+			if c, ok := d.CustomFields["criticality"].(string); ok {
+				fields["criticality"] = c
+			}
+		}
+		if entry, ok := EntryFromFields(fields); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// list follows the pages of a NetBox listing, appending the results to out.
+func (s *NetBoxSource) list(ctx context.Context, path string, out interface{}) error {
+	base, err := url.Parse(s.fetcher.location)
+	if err != nil {
+		return err
+	}
+	next := s.fetcher.location + path + "?" + url.Values{"limit": {"1000"}}.Encode()
+	var all []json.RawMessage
+	for pages := 0; next != ""; pages++ {
+		if pages == maxPages {
+			return fmt.Errorf("netbox listing %s has more than %d pages", path, maxPages)
+		}
+		data, err := s.fetcher.get(ctx, next)
+		if err != nil {
+			return err
+		}
+		var page struct {
+			Next    *string           `json:"next"`
+			Results []json.RawMessage `json:"results"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return fmt.Errorf("invalid netbox response for %s: %w", path, err)
+		}
+		all = append(all, page.Results...)
+		next = ""
+		if page.Next != nil {
+			next = *page.Next
+			// The token goes with every page: only follow links to the instance
+			if u, err := url.Parse(next); err != nil || u.Host != base.Host {
+				return fmt.Errorf("netbox listing %s links to another host: %s", path, next)
+			}
+		}
+	}
+
+	data, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,123 @@
+// Package cmdb pulls the asset list of an external inventory: a CSV or JSON
+// export, from a file or any HTTP endpoint, or a NetBox instance.
+package cmdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Source formats.
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNetBox = "netbox"
+)
+
+// maxResponseSize bounds an export or an API page.
+const maxResponseSize = 64 << 20
+
+// NewSource creates the source of an inventory. location is a file path or
+// an http(s) URL, the base URL of the instance for NetBox. The token is sent
+// to HTTP endpoints as NetBox does ("Authorization: Token ...").
+func NewSource(format, location, token string) (ports.InventorySource, error) {
+	if location == "" {
+		return nil, fmt.Errorf("inventory location is required")
+	}
+	fetcher := &fetcher{
+		location: location,
+		token:    token,
+		client:   &http.Client{Timeout: 60 * time.Second},
+	}
+	switch strings.ToLower(format) {
+	case FormatCSV, "":
+		return &ExportSource{fetcher: fetcher, parse: ParseCSV}, nil
+	case FormatJSON:
+		return &ExportSource{fetcher: fetcher, parse: ParseJSON}, nil
+	case FormatNetBox:
+		if !fetcher.isURL() {
+			return nil, fmt.Errorf("netbox location must be the URL of the instance")
+		}
+		fetcher.location = strings.TrimRight(location, "/")
+		return &NetBoxSource{fetcher: fetcher}, nil
+	default:
+		return nil, fmt.Errorf("unknown inventory format %q (csv, json or netbox)", format)
+	}
+}
+
+// ExportSource reads a CSV or JSON asset export.
+type ExportSource struct {
+	fetcher *fetcher
+	parse   func([]byte) ([]domain.InventoryEntry, error)
+}
+
+// Name identifies the source by its location.
+func (s *ExportSource) Name() string { return s.fetcher.name() }
+
+// FetchInventory reads and parses the export.
+func (s *ExportSource) FetchInventory(ctx context.Context) ([]domain.InventoryEntry, error) {
+	data, err := s.fetcher.get(ctx, s.fetcher.location)
+	if err != nil {
+		return nil, err
+	}
+	return s.parse(data)
+}
+
+// fetcher reads a file or an HTTP endpoint.
+type fetcher struct {
+	location string
+	token    string
+	client   *http.Client
+}
+
+func (f *fetcher) isURL() bool {
+	u, err := url.Parse(f.location)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// name leaves credentials in the URL out of logs and the status.
+func (f *fetcher) name() string {
+	if u, err := url.Parse(f.location); err == nil && f.isURL() {
+		return u.Scheme + "://" + u.Host + u.Path
+	}
+	return f.location
+}
+
+func (f *fetcher) get(ctx context.Context, location string) ([]byte, error) {
+	if !f.isURL() {
+		return os.ReadFile(location)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, text/csv")
+	if f.token != "" {
+		req.Header.Set("Authorization", "Token "+f.token)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", f.name(), resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxResponseSize {
+		return nil, fmt.Errorf("%s response is larger than %d bytes", f.name(), maxResponseSize)
+	}
+	return data, nil
+}
@@ -0,0 +1,76 @@
+package cracking
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Ensure compliance
+var _ ports.PSKCracker = (*AircrackCracker)(nil)
+
+// execCmd allows mocking exec.CommandContext in tests
+var execCmd = exec.CommandContext
+
+// AircrackCracker runs dictionary attacks with aircrack-ng.
+type AircrackCracker struct {
+	path string
+}
+
+// NewAircrackCracker creates a cracker using the aircrack-ng binary at path.
+func NewAircrackCracker(path string) *AircrackCracker {
+	if path == "" {
+		path = "aircrack-ng"
+	}
+	return &AircrackCracker{path: path}
+}
+
+// Crack runs the candidates against the handshake or PMKID of bssid in the
+// capture. aircrack-ng writes the key to the -l file only when it is found.
+func (c *AircrackCracker) Crack(ctx context.Context, capturePath, bssid string, candidates []string) (string, bool, error) {
+	if len(candidates) == 0 {
+		return "", false, nil
+	}
+
+	dir, err := os.MkdirTemp("", "wmap-crack-")
+	if err != nil {
+		return "", false, err
+	}
+	defer os.RemoveAll(dir)
+
+	wordlist := filepath.Join(dir, "wordlist.txt")
+	if err := os.WriteFile(wordlist, []byte(strings.Join(candidates, "\n")+"\n"), 0600); err != nil {
+		return "", false, err
+	}
+	keyFile := filepath.Join(dir, "key.txt")
+
+	var stderr bytes.Buffer
+	cmd := execCmd(ctx, c.path, "-q", "-a2", "-b", bssid, "-w", wordlist, "-l", keyFile, capturePath)
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	if key, err := os.ReadFile(keyFile); err == nil {
+		return strings.TrimSpace(string(key)), true, nil
+	}
+	if ctx.Err() != nil {
+		return "", false, ctx.Err()
+	}
+	// aircrack-ng exits non-zero when the key is not in the list; only a
+	// failure to start is an error.
+	if errors.Is(runErr, exec.ErrNotFound) {
+		return "", false, fmt.Errorf("%w: %s", domain.ErrToolMissing, c.path)
+	}
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return "", false, fmt.Errorf("aircrack-ng failed: %w", runErr)
+	}
+	return "", false, nil
+}
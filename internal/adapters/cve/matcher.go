@@ -39,7 +39,15 @@ func (m *CVEMatcherEngine) FindMatches(ctx context.Context, device domain.Device
 		}
 	}
 
-	// Strategy 3: Keyword-based (Fuzzy)
+	// Strategy 3: AP model identified from its beacons, when WPS does not name it
+	if device.Hardware != nil && device.Hardware.CPEVendor != "" && device.Hardware.CPEProduct != "" {
+		hwMatches, err := m.matchHardware(ctx, device)
+		if err == nil {
+			matches = append(matches, hwMatches...)
+		}
+	}
+
+	// Strategy 4: Keyword-based (Fuzzy)
 	keywordMatches, err := m.matchKeywords(ctx, device)
 	if err == nil {
 		matches = append(matches, keywordMatches...)
@@ -115,6 +123,31 @@ func (m *CVEMatcherEngine) matchWPS(ctx context.Context, device domain.Device) (
 	return matches, nil
 }
 
+// matchHardware performs matching based on the AP model identified from its
+// beacon fingerprint.
+func (m *CVEMatcherEngine) matchHardware(ctx context.Context, device domain.Device) ([]domain.CVEMatch, error) {
+	hw := device.Hardware
+	cves, err := m.repo.FindByVendorProduct(ctx, hw.CPEVendor, hw.CPEProduct)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []domain.CVEMatch
+	for _, cve := range cves {
+		matches = append(matches, domain.CVEMatch{
+			CVE:        cve,
+			Confidence: 0.75, // The fingerprint may be shared by related models
+			MatchType:  "fingerprint",
+			Evidence: []string{
+				"Beacon fingerprint: " + hw.Vendor + " " + hw.Model,
+				"Signature: " + hw.SignatureID,
+			},
+		})
+	}
+
+	return matches, nil
+}
+
 // matchKeywords performs fuzzy matching based on device capabilities and security features.
 func (m *CVEMatcherEngine) matchKeywords(ctx context.Context, device domain.Device) ([]domain.CVEMatch, error) {
 	keywords := extractKeywords(device)
@@ -0,0 +1,77 @@
+package fingerprint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// MinHardwareConfidence is the confidence a beacon fingerprint match needs
+// to name the AP model.
+const MinHardwareConfidence = 0.6
+
+// APHardwareStore holds the bundled beacon fingerprints of AP models.
+type APHardwareStore struct {
+	signatures []domain.APHardwareSignature
+	mu         sync.RWMutex
+}
+
+// NewAPHardwareStore creates a store of AP hardware signatures.
+func NewAPHardwareStore(sigs []domain.APHardwareSignature) *APHardwareStore {
+	return &APHardwareStore{signatures: sigs}
+}
+
+// ParseAPHardwareSignatures decodes and validates a JSON list of AP
+// hardware signatures.
+func ParseAPHardwareSignatures(data []byte) ([]domain.APHardwareSignature, error) {
+	var sigs []domain.APHardwareSignature
+	if err := json.Unmarshal(data, &sigs); err != nil {
+		return nil, err
+	}
+	for i := range sigs {
+		if err := sigs[i].Validate(); err != nil {
+			return nil, fmt.Errorf("signature %d (%s): %w", i, sigs[i].ID, err)
+		}
+	}
+	return sigs, nil
+}
+
+// ReplaceSignatures swaps the signatures, e.g. after the file changed on disk.
+func (s *APHardwareStore) ReplaceSignatures(sigs []domain.APHardwareSignature) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signatures = sigs
+}
+
+// Count returns the number of signatures held.
+func (s *APHardwareStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.signatures)
+}
+
+// MatchHardware returns the most confident signature matching the beacon
+// fingerprint of an AP, if confident enough.
+func (s *APHardwareStore) MatchHardware(ctx context.Context, device domain.Device) *domain.APHardware {
+	if device.BeaconFingerprint == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var best *domain.APHardware
+	for i := range s.signatures {
+		hw := s.signatures[i].Match(device.BeaconFingerprint)
+		if hw == nil || hw.Confidence < MinHardwareConfidence {
+			continue
+		}
+		if best == nil || hw.Confidence > best.Confidence {
+			best = hw
+		}
+	}
+	return best
+}
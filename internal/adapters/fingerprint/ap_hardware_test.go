@@ -0,0 +1,44 @@
+package fingerprint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPHardwareStore_MatchHardware(t *testing.T) {
+	sigs, err := ParseAPHardwareSignatures([]byte(`[
+		{"id": "ap_a", "vendor": "Acme", "model": "AP-1", "cpe_vendor": "acme", "cpe_product": "ap-1",
+		 "ie_order": [0, 1, 3, 5, 45, 61], "vendor_ies": ["00:10:18:02"], "ht_capabilities": 6636, "confidence": 0.9},
+		{"id": "ap_b", "vendor": "Acme", "model": "AP-2",
+		 "ie_order": [0, 1, 3, 5, 45, 61], "confidence": 0.9}
+	]`))
+	require.NoError(t, err)
+	store := NewAPHardwareStore(sigs)
+
+	device := domain.Device{BeaconFingerprint: &domain.BeaconFingerprint{
+		IEOrder:        []int{0, 1, 3, 5, 7, 45, 61, 48, 221, 221},
+		VendorIEs:      []string{"00:50:f2:02", "00:10:18:02"},
+		HTCapabilities: 6636,
+	}}
+	hw := store.MatchHardware(context.Background(), device)
+	require.NotNil(t, hw)
+	assert.Equal(t, "ap_a", hw.SignatureID)
+	assert.Equal(t, []string{"ie_order", "vendor_ies", "ht_capabilities"}, hw.MatchedBy)
+	assert.InDelta(t, 0.81, hw.Confidence, 0.001)
+
+	device.BeaconFingerprint.VendorIEs = nil
+	device.BeaconFingerprint.HTCapabilities = 0
+	assert.Nil(t, store.MatchHardware(context.Background(), device), "element order alone is too weak")
+
+	device.BeaconFingerprint = nil
+	assert.Nil(t, store.MatchHardware(context.Background(), device))
+}
+
+func TestParseAPHardwareSignatures_Invalid(t *testing.T) {
+	_, err := ParseAPHardwareSignatures([]byte(`[{"id": "x", "model": "M", "confidence": 0.5}]`))
+	assert.ErrorIs(t, err, domain.ErrNoSignatureData)
+}
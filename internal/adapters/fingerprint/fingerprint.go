@@ -3,13 +3,19 @@ package fingerprint
 import (
 	"context"
 	"net"
+	"sync"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 )
 
-// SignatureStore holds known device signatures.
+// SignatureStore holds known device signatures, along with those learned
+// from devices labeled by an analyst (see learned.go).
 type SignatureStore struct {
 	Signatures []domain.DeviceSignature
+
+	learned     []domain.DeviceSignature
+	learnedPath string
+	mu          sync.RWMutex
 }
 
 // NewSignatureStore creates a new store.
@@ -17,6 +23,14 @@ func NewSignatureStore(sigs []domain.DeviceSignature) *SignatureStore {
 	return &SignatureStore{Signatures: sigs}
 }
 
+// ReplaceSignatures swaps the bundled signatures, e.g. after the signature
+// file changed on disk. Learned signatures are kept.
+func (s *SignatureStore) ReplaceSignatures(sigs []domain.DeviceSignature) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Signatures = sigs
+}
+
 // FingerprintEngine handles device identification logic.
 type FingerprintEngine struct {
 	Store *SignatureStore
@@ -31,7 +45,15 @@ func NewFingerprintEngine(store *SignatureStore) *FingerprintEngine {
 func (s *SignatureStore) MatchSignature(ctx context.Context, device domain.Device) *domain.SignatureMatch {
 	var bestMatch *domain.SignatureMatch
 
-	for _, sig := range s.Signatures {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Learned signatures come first so they win ties against the bundled ones
+	candidates := make([]domain.DeviceSignature, 0, len(s.learned)+len(s.Signatures))
+	candidates = append(candidates, s.learned...)
+	candidates = append(candidates, s.Signatures...)
+
+	for _, sig := range candidates {
 		currentMatch := sig.CalculateMatch(&device)
 		if currentMatch == nil {
 			continue
@@ -0,0 +1,136 @@
+package fingerprint
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// learnedConfidence is the base confidence of signatures recorded from
+// analyst labels; the IE pattern alone is enough to reach a strong match.
+const learnedConfidence = 1.0
+
+// LoadLearned reads the learned signatures kept at path, which also becomes
+// the file new ones are saved to. A missing file is not an error.
+func (s *SignatureStore) LoadLearned(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.learnedPath = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var sigs []domain.DeviceSignature
+	if err := json.Unmarshal(data, &sigs); err != nil {
+		return fmt.Errorf("parse learned signatures: %w", err)
+	}
+	s.learned = sigs
+	return nil
+}
+
+// AddLearned records a learned signature, replacing one with the same ID,
+// and saves the learned signatures.
+func (s *SignatureStore) AddLearned(sig domain.DeviceSignature) error {
+	if err := sig.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replaced := false
+	for i := range s.learned {
+		if s.learned[i].ID == sig.ID {
+			sig.CreatedAt = s.learned[i].CreatedAt
+			s.learned[i] = sig
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		s.learned = append(s.learned, sig)
+	}
+	return s.saveLearned()
+}
+
+// Learned returns a copy of the learned signatures.
+func (s *SignatureStore) Learned() []domain.DeviceSignature {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]domain.DeviceSignature(nil), s.learned...)
+}
+
+// saveLearned writes the learned signatures atomically. Caller holds s.mu.
+func (s *SignatureStore) saveLearned() error {
+	if s.learnedPath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.learned, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.learnedPath), 0755); err != nil {
+		return err
+	}
+	tmp := s.learnedPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.learnedPath)
+}
+
+// LearnSignature records the IE signature of a device under the make and
+// model an analyst identified, so devices sharing it are classified alike.
+func (fe *FingerprintEngine) LearnSignature(ctx context.Context, device domain.Device, label domain.DeviceLabel) (domain.DeviceSignature, error) {
+	if err := label.Validate(); err != nil {
+		return domain.DeviceSignature{}, err
+	}
+	if len(device.IETags) == 0 {
+		return domain.DeviceSignature{}, domain.ErrNoSignatureData
+	}
+
+	now := time.Now()
+	sig := domain.DeviceSignature{
+		ID:         learnedSignatureID(device.IETags),
+		Vendor:     label.Vendor,
+		DeviceType: label.DeviceType,
+		Model:      label.Model,
+		OS:         label.OS,
+		IEPattern:  append([]int(nil), device.IETags...),
+		Confidence: learnedConfidence,
+		Sources:    []domain.MatchSource{domain.SourceIEPattern, domain.SourceLearned},
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := fe.Store.AddLearned(sig); err != nil {
+		return domain.DeviceSignature{}, err
+	}
+	return sig, nil
+}
+
+// LearnedSignatures returns the signatures learned from labeled devices.
+func (fe *FingerprintEngine) LearnedSignatures(ctx context.Context) []domain.DeviceSignature {
+	return fe.Store.Learned()
+}
+
+// learnedSignatureID derives a stable ID from an IE pattern, so relabeling a
+// device with the same signature updates the existing entry.
+func learnedSignatureID(pattern []int) string {
+	h := sha1.New()
+	for _, tag := range pattern {
+		fmt.Fprintf(h, "%d,", tag)
+	}
+	return "learned_" + hex.EncodeToString(h.Sum(nil))[:12]
+}
@@ -0,0 +1,66 @@
+package fingerprint
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+func TestFingerprintEngine_LearnSignature(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "learned_signatures.json")
+
+	bundled := []domain.DeviceSignature{{
+		ID:         "generic",
+		Vendor:     "Generic",
+		Model:      "Generic Phone",
+		IEPattern:  []int{0, 1, 50},
+		Confidence: 1.0,
+	}}
+	store := NewSignatureStore(bundled)
+	if err := store.LoadLearned(path); err != nil {
+		t.Fatalf("LoadLearned on missing file: %v", err)
+	}
+	engine := NewFingerprintEngine(store)
+
+	labeled := domain.Device{MAC: "aa:bb:cc:00:00:01", IETags: []int{0, 1, 50, 45, 221}}
+	if _, err := engine.LearnSignature(ctx, labeled, domain.DeviceLabel{Vendor: "Acme"}); !errors.Is(err, domain.ErrEmptyLabelModel) {
+		t.Errorf("expected ErrEmptyLabelModel, got %v", err)
+	}
+	if _, err := engine.LearnSignature(ctx, domain.Device{}, domain.DeviceLabel{Model: "X"}); !errors.Is(err, domain.ErrNoSignatureData) {
+		t.Errorf("expected ErrNoSignatureData, got %v", err)
+	}
+
+	sig, err := engine.LearnSignature(ctx, labeled, domain.DeviceLabel{Vendor: "Acme", Model: "Phone 1"})
+	if err != nil {
+		t.Fatalf("LearnSignature: %v", err)
+	}
+
+	// A device sharing the signature now matches the learned model, which
+	// wins the tie against the bundled signature
+	other := domain.Device{MAC: "aa:bb:cc:00:00:02", IETags: []int{0, 1, 50, 45, 221}}
+	match := store.MatchSignature(ctx, other)
+	if match == nil || match.Signature.Model != "Phone 1" {
+		t.Fatalf("expected learned match, got %+v", match)
+	}
+
+	// Relabeling the same signature updates it in place
+	if _, err := engine.LearnSignature(ctx, labeled, domain.DeviceLabel{Vendor: "Acme", Model: "Phone 1 Pro"}); err != nil {
+		t.Fatalf("relabel: %v", err)
+	}
+	if got := engine.LearnedSignatures(ctx); len(got) != 1 || got[0].ID != sig.ID || got[0].Model != "Phone 1 Pro" {
+		t.Errorf("unexpected learned signatures: %+v", got)
+	}
+
+	// Learned signatures survive a restart
+	reloaded := NewSignatureStore(bundled)
+	if err := reloaded.LoadLearned(path); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if got := reloaded.Learned(); len(got) != 1 || got[0].Model != "Phone 1 Pro" {
+		t.Errorf("unexpected reloaded signatures: %+v", got)
+	}
+}
@@ -2,6 +2,7 @@ package mapper
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/ie"
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
@@ -32,11 +33,17 @@ func NewHandlerRegistry() *HandlerRegistry {
 func (r *HandlerRegistry) registerDefaults() {
 	r.Register(&SSIDHandler{})
 	r.Register(&ChannelHandler{})
+	r.Register(&RatesHandler{id: IETagSupportedRates})
+	r.Register(&RatesHandler{id: IETagExtendedRates})
+	r.Register(&CountryHandler{})
+	r.Register(&PowerConstraintHandler{})
 	r.Register(&RSNHandler{})
 	r.Register(&MobilityHandler{})
 	r.Register(&RadioMeasurementHandler{})
 	r.Register(&HTCapabilitiesHandler{})
 	r.Register(&VHTCapabilitiesHandler{})
+	r.Register(&HTOperationHandler{})
+	r.Register(&VHTOperationHandler{})
 	r.Register(&ExtensionHandler{})
 	r.Register(&ExtendedCapabilitiesHandler{})
 	r.Register(&VendorSpecificHandler{})
@@ -80,6 +87,54 @@ func (h *ChannelHandler) Handle(val []byte, device *domain.Device) error {
 	return nil
 }
 
+// RatesHandler collects the Supported Rates and then the Extended Supported
+// Rates, which follow them in beacons.
+type RatesHandler struct {
+	id int
+}
+
+func (h *RatesHandler) ID() int { return h.id }
+func (h *RatesHandler) Handle(val []byte, device *domain.Device) error {
+	if h.id == IETagSupportedRates {
+		device.SupportedRates = nil
+	}
+	for _, r := range val {
+		device.SupportedRates = append(device.SupportedRates, int(r))
+	}
+	return nil
+}
+
+type CountryHandler struct{}
+
+func (h *CountryHandler) ID() int { return IETagCountry }
+func (h *CountryHandler) Handle(val []byte, device *domain.Device) error {
+	info, err := ie.ParseCountry(val)
+	if err != nil {
+		return err
+	}
+	device.Country = info.Code
+	if power, ok := info.MaxPowerFor(device.Channel); ok {
+		device.MaxTxPower = power
+	}
+	return nil
+}
+
+// PowerConstraintHandler lowers the regulatory maximum by the local
+// constraint. The Country element precedes it in beacons.
+type PowerConstraintHandler struct{}
+
+func (h *PowerConstraintHandler) ID() int { return IETagPowerConstraint }
+func (h *PowerConstraintHandler) Handle(val []byte, device *domain.Device) error {
+	constraint, err := ie.ParsePowerConstraint(val)
+	if err != nil {
+		return err
+	}
+	if device.MaxTxPower != 0 {
+		device.MaxTxPower -= constraint
+	}
+	return nil
+}
+
 type RSNHandler struct{}
 
 func (h *RSNHandler) ID() int { return IETagRSN }
@@ -93,6 +148,8 @@ func (h *RSNHandler) Handle(val []byte, device *domain.Device) error {
 	// Determine security type based on AKM
 	if containsString(rsn.AKMSuites, "SAE") {
 		device.Security = "WPA3"
+	} else if containsString(rsn.AKMSuites, "OWE") {
+		device.Security = domain.SecurityOWE
 	} else if containsString(rsn.AKMSuites, "PSK") {
 		device.Security = "WPA2-PSK"
 	} else if containsString(rsn.AKMSuites, "802.1X") {
@@ -161,6 +218,30 @@ func (h *VHTCapabilitiesHandler) Handle(val []byte, device *domain.Device) error
 	return nil
 }
 
+type HTOperationHandler struct{}
+
+func (h *HTOperationHandler) ID() int { return IETagHTOperation }
+func (h *HTOperationHandler) Handle(val []byte, device *domain.Device) error {
+	op, err := ie.ParseHTOperation(val)
+	if err != nil {
+		return err
+	}
+	applyChannelOperation(device, op)
+	return nil
+}
+
+type VHTOperationHandler struct{}
+
+func (h *VHTOperationHandler) ID() int { return IETagVHTOperation }
+func (h *VHTOperationHandler) Handle(val []byte, device *domain.Device) error {
+	op, err := ie.ParseVHTOperation(val)
+	if err != nil {
+		return err
+	}
+	applyChannelOperation(device, op)
+	return nil
+}
+
 type ExtensionHandler struct{}
 
 func (h *ExtensionHandler) ID() int { return IETagExtension }
@@ -173,6 +254,12 @@ func (h *ExtensionHandler) Handle(val []byte, device *domain.Device) error {
 	case ExtTagHECapabilities: // 802.11ax
 		device.Standard = "802.11ax (WiFi 6)"
 		device.IsWiFi6 = true
+	case ExtTagHEOperation:
+		op, err := ie.ParseHEOperation(val[1:])
+		if err != nil {
+			return err
+		}
+		applyChannelOperation(device, op)
 	case ExtTagEHTCapabilities: // 802.11be
 		device.Standard = "802.11be (WiFi 7)"
 		device.IsWiFi7 = true
@@ -202,6 +289,25 @@ type VendorSpecificHandler struct{}
 
 func (h *VendorSpecificHandler) ID() int { return IETagVendorSpecific }
 func (h *VendorSpecificHandler) Handle(val []byte, device *domain.Device) error {
+	if len(val) >= 3 {
+		oui := fmt.Sprintf("%02x:%02x:%02x", val[0], val[1], val[2])
+		if !containsString(device.VendorIEs, oui) {
+			device.VendorIEs = append(device.VendorIEs, oui)
+		}
+	}
+
+	// WPA (version 1), unless the RSN element already set WPA2 or later
+	if len(val) >= 4 && bytes.Equal(val[:4], VendorMicrosoftWPA) && device.RSNInfo == nil && device.Security == "OPEN" {
+		device.Security = "WPA"
+	}
+
+	// OWE transition mode, pointing at the counterpart BSS
+	if len(val) >= 4 && bytes.Equal(val[:4], VendorWFAOWE) {
+		if owe, err := ie.ParseOWETransition(val[4:]); err == nil {
+			device.OWETransition = &domain.OWETransition{BSSID: owe.BSSID, SSID: owe.SSID}
+		}
+	}
+
 	// Microsoft WPS check
 	if len(val) >= 4 && bytes.Equal(val[:4], VendorMicrosoftWPS) {
 		wpsInfo := ie.ParseWPSAttributes(val[4:])
@@ -239,6 +345,17 @@ func (h *VendorSpecificHandler) Handle(val []byte, device *domain.Device) error
 	return nil
 }
 
+// applyChannelOperation records the operating width advertised by an
+// HT/VHT/HE Operation element. The elements are cumulative (VHT extends HT),
+// so a narrower width never overrides a wider one from the same frame.
+func applyChannelOperation(device *domain.Device, op ie.ChannelOperation) {
+	if op.Width == 0 || op.Width < device.ChannelWidth {
+		return
+	}
+	device.ChannelWidth = op.Width
+	device.CenterChannel = op.CenterChannel
+}
+
 // addCapabilityIfNotExists adds a capability to the device only if it doesn't already exist
 func addCapabilityIfNotExists(device *domain.Device, capability string) {
 	if !containsString(device.Capabilities, capability) {
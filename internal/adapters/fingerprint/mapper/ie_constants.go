@@ -6,6 +6,8 @@ const (
 	IETagSupportedRates       = 1
 	IETagDSParameterSet       = 3 // Channel
 	IETagTrafficIndicationMap = 5
+	IETagCountry              = 7 // 802.11d
+	IETagPowerConstraint      = 32
 	IETagERP                  = 42
 	IETagHTCapabilities       = 45 // 802.11n
 	IETagRSN                  = 48 // WPA2/WPA3
@@ -31,6 +33,8 @@ const (
 // Vendor OUI Prefixes
 var (
 	VendorMicrosoftWPS = []byte{0x00, 0x50, 0xF2, 0x04}
+	VendorMicrosoftWPA = []byte{0x00, 0x50, 0xF2, 0x01} // Pre-RSN WPA element
+	VendorWFAOWE       = []byte{0x50, 0x6F, 0x9A, 0x1C} // OWE Transition Mode element
 	VendorApple        = []byte{0x00, 0x17, 0xF2}
 	VendorMicrosoft    = []byte{0x00, 0x50, 0xF2}
 )
@@ -75,3 +75,19 @@ func containsString(slice []string, val string) bool {
 	}
 	return false
 }
+
+// BuildBeaconFingerprint records how a beacon was built, from its elements
+// and the capability information field, to identify the AP hardware.
+func BuildBeaconFingerprint(data []byte, capabilities uint16) *domain.BeaconFingerprint {
+	fp := &domain.BeaconFingerprint{Capabilities: capabilities}
+	ie.IterateIEs(data, func(id int, val []byte) {
+		fp.IEOrder = append(fp.IEOrder, id)
+		switch {
+		case id == IETagVendorSpecific && len(val) >= 4:
+			fp.VendorIEs = append(fp.VendorIEs, fmt.Sprintf("%02x:%02x:%02x:%02x", val[0], val[1], val[2], val[3]))
+		case id == IETagHTCapabilities && len(val) >= 2:
+			fp.HTCapabilities = uint16(val[0]) | uint16(val[1])<<8
+		}
+	})
+	return fp
+}
@@ -0,0 +1,37 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBeaconFingerprint(t *testing.T) {
+	data := []byte{
+		0, 3, 'l', 'a', 'b', // SSID
+		1, 2, 0x82, 0x84, // Rates
+		3, 1, 6, // DS Parameter Set
+		45, 2, 0xec, 0x19, // HT Capabilities, truncated
+		221, 5, 0x00, 0x50, 0xf2, 0x04, 0x10, // WPS
+	}
+
+	fp := BuildBeaconFingerprint(data, 0x0431)
+	assert.Equal(t, []int{0, 1, 3, 45, 221}, fp.IEOrder)
+	assert.Equal(t, []string{"00:50:f2:04"}, fp.VendorIEs)
+	assert.Equal(t, uint16(0x19ec), fp.HTCapabilities)
+	assert.Equal(t, uint16(0x0431), fp.Capabilities)
+	assert.Equal(t, []int{0, 1, 3, 45}, fp.HardwareOrder())
+}
+
+func TestParseIEs_SupportedRates(t *testing.T) {
+	data := []byte{
+		1, 3, 0x82, 0x84, 0x0c, // Rates
+		3, 1, 6, // DS Parameter Set
+		50, 2, 0x30, 0x6c, // Extended Rates
+	}
+
+	var device domain.Device
+	ParseIEs(data, &device)
+	assert.Equal(t, []int{0x82, 0x84, 0x0c, 0x30, 0x6c}, device.SupportedRates)
+}
@@ -0,0 +1,162 @@
+package kismet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// DefaultInterval is how often the Kismet server is polled for devices.
+	DefaultInterval = 5 * time.Second
+	// maxResponseSize bounds a device poll; the first one returns every device.
+	maxResponseSize = 256 << 20
+)
+
+// requestFields limits the device records returned by Kismet to the fields
+// read by ParseDevices. Whole subtrees are requested so the keys keep their
+// nesting.
+var requestFields = []string{
+	"kismet.device.base.macaddr",
+	"kismet.device.base.phyname",
+	"kismet.device.base.type",
+	"kismet.device.base.name",
+	"kismet.device.base.commonname",
+	"kismet.device.base.manuf",
+	"kismet.device.base.channel",
+	"kismet.device.base.frequency",
+	"kismet.device.base.crypt",
+	"kismet.device.base.first_time",
+	"kismet.device.base.last_time",
+	"kismet.device.base.packets.total",
+	"kismet.device.base.datasize",
+	"kismet.device.base.signal",
+	"kismet.device.base.location",
+	"dot11.device",
+}
+
+// SinkFunc receives the devices updated on the Kismet server since the
+// previous poll.
+type SinkFunc func(ctx context.Context, devices []domain.Device)
+
+// Bridge polls a Kismet server's REST API for device updates, so any
+// hardware Kismet supports, such as SDRs or remote capture sources, acts as
+// an additional WMAP sensor.
+type Bridge struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+
+	mu     sync.Mutex
+	since  int64 // Newest last_time received, in Kismet's clock
+	failed bool  // Last poll failed, logged once until it recovers
+}
+
+// NewBridge creates a bridge to the Kismet server at baseURL, such as
+// http://localhost:2501, authenticated with an API key.
+func NewBridge(baseURL, apiKey string) *Bridge {
+	return &Bridge{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name identifies the bridge as a device source.
+func (b *Bridge) Name() string {
+	if u, err := url.Parse(b.baseURL); err == nil && u.Host != "" {
+		return "kismet@" + u.Host
+	}
+	return "kismet"
+}
+
+// Run polls the server every interval until ctx is done. The first poll
+// returns every device Kismet knows of, later ones only those updated since.
+func (b *Bridge) Run(ctx context.Context, interval time.Duration, sink SinkFunc) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		devices, err := b.Poll(ctx)
+		b.report(err)
+		if err == nil && len(devices) > 0 {
+			sink(ctx, devices)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches the devices updated since the previous poll.
+func (b *Bridge) Poll(ctx context.Context) ([]domain.Device, error) {
+	b.mu.Lock()
+	since := b.since
+	b.mu.Unlock()
+
+	fields, _ := json.Marshal(map[string]interface{}{"fields": requestFields})
+	form := url.Values{"json": {string(fields)}}
+	endpoint := fmt.Sprintf("%s/devices/last-time/%d/devices.json", b.baseURL, since)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if b.apiKey != "" {
+		req.AddCookie(&http.Cookie{Name: "KISMET", Value: b.apiKey})
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kismet returned %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := ParseDevices(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Advance using Kismet's timestamps so clock skew between hosts is harmless
+	newest := since
+	for _, d := range devices {
+		if ts := d.LastPacketTime.Unix(); !d.LastPacketTime.IsZero() && ts > newest {
+			newest = ts
+		}
+	}
+	b.mu.Lock()
+	b.since = newest
+	b.mu.Unlock()
+	return devices, nil
+}
+
+// report logs poll failures once until the server is reachable again.
+func (b *Bridge) report(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case err != nil && !b.failed:
+		log.Printf("[KISMET] Polling %s failed: %v", b.baseURL, err)
+	case err == nil && b.failed:
+		log.Printf("[KISMET] Polling %s recovered", b.baseURL)
+	}
+	b.failed = err != nil
+}
@@ -0,0 +1,58 @@
+package kismet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_Poll(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("KISMET")
+		if err != nil || cookie.Value != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		paths = append(paths, r.URL.Path)
+
+		var req struct {
+			Fields []string `json:"fields"`
+		}
+		require.NoError(t, r.ParseForm())
+		require.NoError(t, json.Unmarshal([]byte(r.PostForm.Get("json")), &req))
+		assert.Contains(t, req.Fields, "dot11.device")
+
+		w.Write([]byte(`[
+			{"kismet.device.base.macaddr": "00:11:22:33:44:55", "kismet.device.base.phyname": "IEEE802.11",
+			 "kismet.device.base.type": "Wi-Fi AP", "kismet.device.base.last_time": 1704103500,
+			 "dot11.device": {"dot11.device.last_beaconed_ssid": "Corp"}},
+			{"kismet.device.base.macaddr": "AA:BB:CC:DD:EE:FF", "kismet.device.base.phyname": "IEEE802.11",
+			 "kismet.device.base.type": "Wi-Fi Client", "kismet.device.base.last_time": 1704103400}
+		]`))
+	}))
+	defer srv.Close()
+
+	bridge := NewBridge(srv.URL+"/", "secret")
+	assert.Equal(t, "kismet@"+srv.Listener.Addr().String(), bridge.Name())
+
+	devices, err := bridge.Poll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, devices, 2)
+	assert.Equal(t, domain.DeviceTypeAP, devices[0].Type)
+	assert.Equal(t, "Corp", devices[0].SSID)
+
+	// The next poll only asks for devices updated since the newest one
+	_, err = bridge.Poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/devices/last-time/0/devices.json", "/devices/last-time/1704103500/devices.json"}, paths)
+
+	_, err = NewBridge(srv.URL, "wrong").Poll(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,209 @@
+// Package kismet reads Kismet device records: the bridge polls a Kismet
+// server as an additional sensor, and ParseDevices, also used by the ingest
+// service for uploaded Kismet JSON logs, turns records into devices.
+package kismet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// phy80211 is the Kismet PHY name of Wi-Fi devices; others, such as
+// Bluetooth, are ignored.
+const phy80211 = "IEEE802.11"
+
+// record holds the fields read from a Kismet device record, as served by
+// the devices REST endpoints or written to a .json log.
+type record struct {
+	MAC        string          `json:"kismet.device.base.macaddr"`
+	PhyName    string          `json:"kismet.device.base.phyname"`
+	Type       string          `json:"kismet.device.base.type"`
+	Name       string          `json:"kismet.device.base.name"`
+	CommonName string          `json:"kismet.device.base.commonname"`
+	Manuf      string          `json:"kismet.device.base.manuf"`
+	Channel    string          `json:"kismet.device.base.channel"`
+	Frequency  float64         `json:"kismet.device.base.frequency"` // kHz
+	Crypt      json.RawMessage `json:"kismet.device.base.crypt"`     // String in recent versions, bitmask before
+	FirstTime  int64           `json:"kismet.device.base.first_time"`
+	LastTime   int64           `json:"kismet.device.base.last_time"`
+	Packets    int             `json:"kismet.device.base.packets.total"`
+	DataSize   int64           `json:"kismet.device.base.datasize"`
+	Signal     *struct {
+		Last int `json:"kismet.common.signal.last_signal"`
+	} `json:"kismet.device.base.signal"`
+	Location *struct {
+		Avg *struct {
+			Geopoint []float64 `json:"kismet.common.location.geopoint"` // [lon, lat]
+		} `json:"kismet.common.location.avg_loc"`
+	} `json:"kismet.device.base.location"`
+	Dot11 *struct {
+		LastBeaconedSSID string          `json:"dot11.device.last_beaconed_ssid"`
+		LastBSSID        string          `json:"dot11.device.last_bssid"`
+		ProbedSSIDs      json.RawMessage `json:"dot11.device.probed_ssid_map"` // Array, or map keyed by hash before
+	} `json:"dot11.device"`
+}
+
+type probedSSID struct {
+	SSID     string `json:"dot11.probedssid.ssid"`
+	LastTime int64  `json:"dot11.probedssid.last_time"`
+}
+
+// ParseDevices decodes Kismet device JSON, an array of device records or one
+// record per line as in ekjson, into Wi-Fi devices. Records of other PHYs,
+// such as Bluetooth, are skipped.
+func ParseDevices(data []byte) ([]domain.Device, error) {
+	var records []record
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return nil, fmt.Errorf("invalid Kismet JSON: %w", err)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+		scanner.Buffer(make([]byte, 64*1024), len(trimmed)+1)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var rec record
+			if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+				return nil, fmt.Errorf("invalid Kismet JSON on line %d: %w", line, err)
+			}
+			records = append(records, rec)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	devices := make([]domain.Device, 0, len(records))
+	for _, rec := range records {
+		if rec.PhyName != "" && rec.PhyName != phy80211 {
+			continue
+		}
+		devices = append(devices, rec.device())
+	}
+	return devices, nil
+}
+
+func (k *record) device() domain.Device {
+	d := domain.Device{
+		MAC:             k.MAC,
+		Type:            deviceType(k.Type),
+		Vendor:          k.Manuf,
+		Frequency:       int(k.Frequency / 1000),
+		Security:        security(k.Crypt),
+		PacketsCount:    k.Packets,
+		DataTransmitted: k.DataSize,
+	}
+	if d.Vendor == "Unknown" {
+		d.Vendor = ""
+	}
+	d.Channel, _ = strconv.Atoi(strings.TrimSpace(k.Channel))
+	if k.FirstTime > 0 {
+		d.FirstSeen = time.Unix(k.FirstTime, 0)
+	}
+	if k.LastTime > 0 {
+		d.LastPacketTime = time.Unix(k.LastTime, 0)
+	}
+	if k.Signal != nil {
+		d.RSSI = k.Signal.Last
+	}
+	if k.Location != nil && k.Location.Avg != nil && len(k.Location.Avg.Geopoint) == 2 {
+		d.Longitude, d.Latitude = k.Location.Avg.Geopoint[0], k.Location.Avg.Geopoint[1]
+	}
+
+	if k.Dot11 == nil {
+		return d
+	}
+	if d.Type == domain.DeviceTypeAP {
+		d.SSID = k.Dot11.LastBeaconedSSID
+		return d
+	}
+	bssid := strings.ToLower(k.Dot11.LastBSSID)
+	if bssid != "" && bssid != "00:00:00:00:00:00" && bssid != strings.ToLower(k.MAC) {
+		d.ConnectedSSID = bssid
+	}
+	for _, p := range probes(k.Dot11.ProbedSSIDs) {
+		if p.SSID == "" {
+			continue
+		}
+		if d.ProbedSSIDs == nil {
+			d.ProbedSSIDs = make(map[string]time.Time)
+		}
+		seen := d.LastPacketTime
+		if p.LastTime > 0 {
+			seen = time.Unix(p.LastTime, 0)
+		}
+		d.ProbedSSIDs[p.SSID] = seen
+	}
+	return d
+}
+
+// deviceType maps a Kismet device type to a WMAP one.
+func deviceType(t string) domain.DeviceType {
+	switch t {
+	case "Wi-Fi AP":
+		return domain.DeviceTypeAP
+	case "Wi-Fi Client", "Wi-Fi Bridged", "Wi-Fi Ad-Hoc", "Wi-Fi Device":
+		return domain.DeviceTypeStation
+	default:
+		return domain.DeviceTypeUnknown
+	}
+}
+
+// security maps the Kismet encryption summary to a WMAP security label.
+// Legacy bitmasks are not decoded.
+func security(raw json.RawMessage) string {
+	var crypt string
+	if err := json.Unmarshal(raw, &crypt); err != nil {
+		return ""
+	}
+	upper := strings.ToUpper(crypt)
+	switch {
+	case upper == "" || upper == "NONE":
+		return ""
+	case upper == "OPEN":
+		return "OPEN"
+	case strings.Contains(upper, "SAE") || strings.Contains(upper, "WPA3"):
+		return "WPA3"
+	case strings.Contains(upper, "WPA2") && strings.Contains(upper, "PSK"):
+		return "WPA2-PSK"
+	case strings.Contains(upper, "WPA2") && (strings.Contains(upper, "EAP") || strings.Contains(upper, "802.1X")):
+		return "WPA2-Enterprise"
+	case strings.Contains(upper, "WPA2"):
+		return "WPA2"
+	case strings.Contains(upper, "WPA"):
+		return "WPA"
+	case strings.Contains(upper, "WEP"):
+		return "WEP"
+	}
+	return crypt
+}
+
+// probes decodes the probed SSIDs of a client, which Kismet writes as
+// an array or as a map keyed by SSID hash depending on the version.
+func probes(raw json.RawMessage) []probedSSID {
+	if len(raw) == 0 {
+		return nil
+	}
+	var list []probedSSID
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	var byHash map[string]probedSSID
+	if err := json.Unmarshal(raw, &byHash); err != nil {
+		return nil
+	}
+	for _, p := range byHash {
+		list = append(list, p)
+	}
+	return list
+}
@@ -0,0 +1,203 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// queueSize bounds the events waiting for a slow analyzer; newer events
+	// are dropped rather than stalling the capture pipeline.
+	queueSize = 1024
+	// maxLineSize bounds a single message written by an analyzer.
+	maxLineSize = 1 << 20
+	// restartDelay is the initial wait before restarting an analyzer that
+	// exited. It doubles up to maxRestartDelay while the analyzer keeps failing.
+	restartDelay    = time.Second
+	maxRestartDelay = time.Minute
+)
+
+// analyzer is an external analyzer process.
+type analyzer struct {
+	name    string
+	command string
+	args    []string
+	queue   chan []byte // Encoded messages, one per line
+	handle  func(a *analyzer, msg Message)
+
+	sent, dropped, alerts, annotations atomic.Int64
+
+	mu        sync.Mutex
+	running   bool
+	pid       int
+	startedAt time.Time
+	restarts  int
+	lastErr   string
+}
+
+func newAnalyzer(name, command string, args []string, handle func(a *analyzer, msg Message)) *analyzer {
+	return &analyzer{
+		name:    name,
+		command: command,
+		args:    args,
+		queue:   make(chan []byte, queueSize),
+		handle:  handle,
+	}
+}
+
+// send queues an encoded event for the analyzer without blocking.
+func (a *analyzer) send(line []byte) {
+	select {
+	case a.queue <- line:
+	default:
+		a.dropped.Add(1)
+	}
+}
+
+// run keeps the analyzer running until ctx is done, restarting it with a
+// backoff when it exits.
+func (a *analyzer) run(ctx context.Context) {
+	delay := restartDelay
+	for {
+		started := time.Now()
+		err := a.runOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		a.mu.Lock()
+		a.restarts++
+		if err != nil {
+			a.lastErr = err.Error()
+		}
+		a.mu.Unlock()
+		log.Printf("[PLUGIN] %s exited: %v, restarting in %s", a.name, err, delay)
+
+		if time.Since(started) > maxRestartDelay {
+			delay = restartDelay // It ran fine for a while
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRestartDelay)
+	}
+}
+
+// runOnce starts the analyzer and feeds it until it exits or ctx is done.
+func (a *analyzer) runOnce(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, a.command, a.args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+
+	a.mu.Lock()
+	a.running, a.pid, a.startedAt = true, cmd.Process.Pid, time.Now()
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.running, a.pid = false, 0
+		a.mu.Unlock()
+	}()
+	log.Printf("[PLUGIN] Started %s (pid %d)", a.name, cmd.Process.Pid)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		a.read(stdout)
+	}()
+	go func() {
+		defer wg.Done()
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			log.Printf("[PLUGIN] %s: %s", a.name, scanner.Text())
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer stdin.Close()
+		a.write(stdin, done)
+	}()
+
+	wg.Wait() // Output is closed once the process exits
+	close(done)
+	return cmd.Wait()
+}
+
+// write sends the hello message and then the queued events until done.
+func (a *analyzer) write(w io.Writer, done <-chan struct{}) {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(Message{Type: MsgHello, Version: ProtocolVersion}); err != nil {
+		return
+	}
+	for {
+		select {
+		case <-done:
+			return
+		case line := <-a.queue:
+			if _, err := w.Write(line); err != nil {
+				a.dropped.Add(1)
+				return
+			}
+			a.sent.Add(1)
+		}
+	}
+}
+
+// read handles the messages written by the analyzer.
+func (a *analyzer) read(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			log.Printf("[PLUGIN] %s: invalid message: %v", a.name, err)
+			continue
+		}
+		a.handle(a, msg)
+	}
+}
+
+// status returns a snapshot of the analyzer's state.
+func (a *analyzer) status() domain.PluginStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return domain.PluginStatus{
+		Name:        a.name,
+		Command:     a.command,
+		Running:     a.running,
+		PID:         a.pid,
+		StartedAt:   a.startedAt,
+		Restarts:    a.restarts,
+		Sent:        a.sent.Load(),
+		Dropped:     a.dropped.Load(),
+		Alerts:      a.alerts.Load(),
+		Annotations: a.annotations.Load(),
+		LastError:   a.lastErr,
+	}
+}
@@ -0,0 +1,219 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// maxPendingAlerts bounds the alerts raised by analyzers waiting to be
+// collected by the security engine.
+const maxPendingAlerts = 1000
+
+// AnnotateFunc attaches the annotations of an analyzer to a device.
+type AnnotateFunc func(ctx context.Context, mac string, annotations map[string]string) error
+
+// Manager runs the external analyzers and bridges them with the capture
+// pipeline: analyzers receive device updates and sensor frame events, and
+// can raise alerts and annotate devices.
+//
+// The manager is a security engine detector: device updates reach the
+// analyzers from Analyze, which also returns the alerts they raised since
+// the previous call, so those go through the engine's deduplication and
+// history like the built-in detections.
+type Manager struct {
+	analyzers []*analyzer
+	annotate  AnnotateFunc
+
+	pending []domain.Alert
+	mu      sync.Mutex
+}
+
+// NewManager creates a manager without analyzers.
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// unsafePerm are the permission bits letting other users replace a plugin,
+// which runs with the sensor's privileges.
+const unsafePerm = 0022
+
+// LoadDir registers every executable file in dir as an analyzer named after
+// the file. A missing directory registers nothing. A directory writable by
+// its group or others is refused, and so are such files.
+func (m *Manager) LoadDir(dir string) (int, error) {
+	dirInfo, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if dirInfo.Mode().Perm()&unsafePerm != 0 {
+		return 0, fmt.Errorf("plugin directory %s is group or world writable (%v)", dir, dirInfo.Mode().Perm())
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		if info.Mode().Perm()&unsafePerm != 0 {
+			log.Printf("Warning: skipping plugin %s: group or world writable (%v)", e.Name(), info.Mode().Perm())
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+		m.Register(name, filepath.Join(dir, e.Name()))
+		n++
+	}
+	return n, nil
+}
+
+// Register adds an analyzer run as command with args.
+func (m *Manager) Register(name, command string, args ...string) {
+	m.analyzers = append(m.analyzers, newAnalyzer(name, command, args, m.handle))
+}
+
+// SetAnnotator sets the callback attaching analyzer annotations to devices.
+func (m *Manager) SetAnnotator(annotate AnnotateFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.annotate = annotate
+}
+
+// Start runs the registered analyzers until ctx is done.
+func (m *Manager) Start(ctx context.Context) {
+	for _, a := range m.analyzers {
+		go a.run(ctx)
+	}
+}
+
+// Name implements the security engine detector.
+func (m *Manager) Name() string { return "PluginAnalyzers" }
+
+// Analyze sends a device update to the analyzers and returns the alerts they
+// raised since the previous call.
+func (m *Manager) Analyze(device *domain.Device, _ ports.DeviceRegistry) []domain.Alert {
+	if len(m.analyzers) == 0 {
+		return nil
+	}
+	m.dispatch(Message{Type: MsgDevice, Device: device})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	alerts := m.pending
+	m.pending = nil
+	return alerts
+}
+
+// DispatchFrame sends a management frame event raised by a sensor to the analyzers.
+func (m *Manager) DispatchFrame(alert domain.Alert) {
+	if len(m.analyzers) == 0 {
+		return
+	}
+	m.dispatch(Message{Type: MsgFrame, Alert: &alert})
+}
+
+// dispatch encodes a message once and queues it for every analyzer. It is
+// encoded right away, while the caller owns the data it references.
+func (m *Manager) dispatch(msg Message) {
+	line, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("[PLUGIN] Could not encode %s message: %v", msg.Type, err)
+		return
+	}
+	line = append(line, '\n')
+	for _, a := range m.analyzers {
+		a.send(line)
+	}
+}
+
+// ListPlugins returns the state of every analyzer ordered by name.
+func (m *Manager) ListPlugins(ctx context.Context) []domain.PluginStatus {
+	result := make([]domain.PluginStatus, 0, len(m.analyzers))
+	for _, a := range m.analyzers {
+		result = append(result, a.status())
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// handle processes a message written by an analyzer.
+func (m *Manager) handle(a *analyzer, msg Message) {
+	switch msg.Type {
+	case MsgAlert:
+		alert, err := pluginAlert(a.name, msg.Alert)
+		if err != nil {
+			log.Printf("[PLUGIN] %s: rejected alert: %v", a.name, err)
+			return
+		}
+		a.alerts.Add(1)
+		m.mu.Lock()
+		if len(m.pending) < maxPendingAlerts {
+			m.pending = append(m.pending, alert)
+		}
+		m.mu.Unlock()
+
+	case MsgAnnotate:
+		if msg.MAC == "" || len(msg.Annotations) == 0 {
+			log.Printf("[PLUGIN] %s: rejected annotation without MAC or annotations", a.name)
+			return
+		}
+		m.mu.Lock()
+		annotate := m.annotate
+		m.mu.Unlock()
+		if annotate == nil {
+			return
+		}
+		if err := annotate(context.Background(), strings.ToLower(msg.MAC), msg.Annotations); err != nil {
+			log.Printf("[PLUGIN] %s: could not annotate %s: %v", a.name, msg.MAC, err)
+			return
+		}
+		a.annotations.Add(1)
+
+	case MsgLog:
+		log.Printf("[PLUGIN] %s: %s", a.name, msg.Message)
+
+	default:
+		log.Printf("[PLUGIN] %s: unknown message type %q", a.name, msg.Type)
+	}
+}
+
+// pluginAlert validates an alert raised by an analyzer and fills the fields
+// the host owns.
+func pluginAlert(name string, alert *domain.Alert) (domain.Alert, error) {
+	if alert == nil || alert.Subtype == "" || alert.Message == "" {
+		return domain.Alert{}, fmt.Errorf("alert requires a subtype and a message")
+	}
+	a := *alert
+	if a.Type == "" {
+		a.Type = domain.AlertAnomaly
+	}
+	if a.Severity == "" {
+		a.Severity = domain.SeverityMedium
+	}
+	created, err := domain.NewAlert("plugin:"+name, a.Type, a.DeviceMAC, a.Message, a.Severity)
+	if err != nil {
+		return domain.Alert{}, err
+	}
+	a.ID, a.RuleID = created.ID, created.RuleID
+	if a.Timestamp.IsZero() {
+		a.Timestamp = time.Now()
+	}
+	return a, nil
+}
@@ -0,0 +1,130 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// echoAnalyzer raises an alert and annotates the device for every device update.
+const echoAnalyzer = `#!/bin/sh
+read hello
+while read line; do
+	case "$line" in
+	*'"type":"device"'*)
+		echo '{"type":"alert","alert":{"subtype":"CUSTOM","device_mac":"00:11:22:33:44:55","message":"custom detection","severity":"high"}}'
+		echo '{"type":"annotate","mac":"00:11:22:33:44:55","annotations":{"team":"seen"}}'
+		;;
+	esac
+done
+`
+
+func writeAnalyzer(t *testing.T, dir, name, script string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(script), mode))
+	return path
+}
+
+func TestManager_AlertsAndAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	writeAnalyzer(t, dir, "echo.sh", echoAnalyzer, 0755)
+
+	m := NewManager()
+	n, err := m.LoadDir(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	var mu sync.Mutex
+	annotated := make(map[string]map[string]string)
+	m.SetAnnotator(func(ctx context.Context, mac string, annotations map[string]string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		annotated[mac] = annotations
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Start(ctx)
+
+	device := &domain.Device{MAC: "00:11:22:33:44:55"}
+	var alerts []domain.Alert
+	require.Eventually(t, func() bool {
+		alerts = append(alerts, m.Analyze(device, nil)...)
+		return len(alerts) > 0
+	}, 5*time.Second, 20*time.Millisecond)
+
+	assert.Equal(t, "CUSTOM", alerts[0].Subtype)
+	assert.Equal(t, "plugin:echo", alerts[0].RuleID)
+	assert.Equal(t, domain.AlertAnomaly, alerts[0].Type)
+	assert.Equal(t, domain.SeverityHigh, alerts[0].Severity)
+	assert.NotEmpty(t, alerts[0].ID)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return annotated["00:11:22:33:44:55"]["team"] == "seen"
+	}, 5*time.Second, 20*time.Millisecond)
+
+	plugins := m.ListPlugins(ctx)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "echo", plugins[0].Name)
+	assert.True(t, plugins[0].Running)
+	assert.NotZero(t, plugins[0].Sent)
+	assert.NotZero(t, plugins[0].Alerts)
+}
+
+func TestManager_LoadDirSkipsNonExecutables(t *testing.T) {
+	dir := t.TempDir()
+	writeAnalyzer(t, dir, "README.md", "docs", 0644)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "lib"), 0755))
+
+	m := NewManager()
+	n, err := m.LoadDir(dir)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Nil(t, m.Analyze(&domain.Device{MAC: "00:11:22:33:44:55"}, nil))
+
+	n, err = m.LoadDir(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestManager_LoadDirRefusesWritableByOthers(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0755))
+	writeAnalyzer(t, dir, "echo.sh", echoAnalyzer, 0755)
+	writeAnalyzer(t, dir, "shared.sh", echoAnalyzer, 0755)
+	require.NoError(t, os.Chmod(filepath.Join(dir, "shared.sh"), 0775))
+
+	m := NewManager()
+	n, err := m.LoadDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "group-writable plugin skipped")
+
+	require.NoError(t, os.Chmod(dir, 0777))
+	_, err = NewManager().LoadDir(dir)
+	assert.Error(t, err, "world-writable directory refused")
+}
+
+func TestPluginAlert_Validation(t *testing.T) {
+	_, err := pluginAlert("p", nil)
+	assert.Error(t, err)
+	_, err = pluginAlert("p", &domain.Alert{Message: "no subtype"})
+	assert.Error(t, err)
+	_, err = pluginAlert("p", &domain.Alert{Subtype: "X", Message: "bad severity", Severity: "urgent"})
+	assert.ErrorIs(t, err, domain.ErrInvalidSeverity)
+
+	alert, err := pluginAlert("p", &domain.Alert{Subtype: "X", Message: "ok"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SeverityMedium, alert.Severity)
+	assert.False(t, alert.Timestamp.IsZero())
+}
@@ -0,0 +1,32 @@
+package plugin
+
+import "github.com/lcalzada-xor/wmap/internal/core/domain"
+
+// ProtocolVersion is sent to analyzers in the hello message.
+const ProtocolVersion = 1
+
+// Message types exchanged with analyzers. Every message is a JSON object on
+// its own line: the host writes to the analyzer's stdin and reads its stdout.
+// Anything the analyzer writes to stderr is logged.
+const (
+	// Host to analyzer
+	MsgHello  = "hello"  // {"type":"hello","version":1}
+	MsgDevice = "device" // {"type":"device","device":{...}}, the merged state of a device after each observation
+	MsgFrame  = "frame"  // {"type":"frame","alert":{...}}, a management frame event raised by a sensor (deauth, CSA, ...)
+
+	// Analyzer to host
+	MsgAlert    = "alert"    // {"type":"alert","alert":{"subtype":"...","device_mac":"...","message":"...","severity":"high"}}
+	MsgAnnotate = "annotate" // {"type":"annotate","mac":"...","annotations":{"key":"value"}}
+	MsgLog      = "log"      // {"type":"log","message":"..."}
+)
+
+// Message is a line of the analyzer protocol.
+type Message struct {
+	Type        string            `json:"type"`
+	Version     int               `json:"version,omitempty"`
+	Device      *domain.Device    `json:"device,omitempty"`
+	Alert       *domain.Alert     `json:"alert,omitempty"`
+	MAC         string            `json:"mac,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Message     string            `json:"message,omitempty"`
+}
@@ -0,0 +1,20 @@
+//go:build linux
+
+package portal
+
+import "syscall"
+
+// bindToDevice makes sockets leave through iface whatever the routing table
+// prefers, so the probe tests the network under check and not the uplink.
+func bindToDevice(iface string) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, c syscall.RawConn) error {
+		var serr error
+		err := c.Control(func(fd uintptr) {
+			serr = syscall.SetsockoptString(int(fd), syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, iface)
+		})
+		if err != nil {
+			return err
+		}
+		return serr
+	}
+}
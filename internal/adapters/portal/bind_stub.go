@@ -0,0 +1,15 @@
+//go:build !linux
+
+package portal
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// bindToDevice is only supported on linux.
+func bindToDevice(iface string) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, c syscall.RawConn) error {
+		return fmt.Errorf("binding sockets to %s only supported on linux", iface)
+	}
+}
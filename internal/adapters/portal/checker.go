@@ -0,0 +1,154 @@
+// Package portal checks what a client gets from an open network: it
+// associates a managed interface, separate from the monitor ones, obtains a
+// lease and fetches a connectivity probe through it.
+package portal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// DefaultProbeURL answers 204 No Content when reached directly; a captive
+// portal intercepts it.
+const DefaultProbeURL = "http://connectivitycheck.gstatic.com/generate_204"
+
+// execCmd allows mocking exec.CommandContext in tests
+var execCmd = exec.CommandContext
+
+// Checker runs captive portal checks on a managed interface. Checks are
+// serialized since the interface joins one network at a time.
+type Checker struct {
+	Interface string
+	ProbeURL  string
+	// DHCP obtains a lease on the interface and Release gives it back. When
+	// empty, dhclient runs with a script that leaves the host's routes and
+	// resolvers alone (see leaseScript).
+	DHCP    []string
+	Release []string
+	Timeout time.Duration // Of the association, the lease and the probe each
+
+	mu sync.Mutex
+}
+
+// NewChecker creates a checker on a managed interface using dhclient. Only
+// the interface's address is configured from the lease: its default route
+// serves the sockets bound to the interface, and its resolvers the probe.
+func NewChecker(iface string) *Checker {
+	return &Checker{
+		Interface: iface,
+		ProbeURL:  DefaultProbeURL,
+		Timeout:   20 * time.Second,
+	}
+}
+
+// CheckPortal associates with an open network, fetches the probe through it
+// and disconnects. An error means the interface could not join the network
+// or get a lease; an unreachable probe is the no_egress result.
+func (c *Checker) CheckPortal(ctx context.Context, target domain.PortalTarget) (domain.PortalCheck, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	check := domain.PortalCheck{
+		BSSID:     target.BSSID,
+		SSID:      target.SSID,
+		Interface: c.Interface,
+	}
+
+	c.run(ctx, "iw", "dev", c.Interface, "disconnect")
+	defer c.run(context.Background(), "iw", "dev", c.Interface, "disconnect")
+
+	args := []string{"dev", c.Interface, "connect", "-w", target.SSID}
+	if target.Frequency > 0 {
+		args = append(args, strconv.Itoa(target.Frequency))
+	}
+	args = append(args, target.BSSID)
+	if out, err := c.run(ctx, "iw", args...); err != nil {
+		return check, fmt.Errorf("association with %s failed: %v: %s", target.BSSID, err, out)
+	}
+
+	var nameservers []string
+	if len(c.DHCP) > 0 {
+		if out, err := c.run(ctx, c.DHCP[0], c.DHCP[1:]...); err != nil {
+			return check, fmt.Errorf("no DHCP lease from %s: %v: %s", target.BSSID, err, out)
+		}
+		if len(c.Release) > 0 {
+			defer c.run(context.Background(), c.Release[0], c.Release[1:]...)
+		}
+	} else {
+		l, release, err := c.isolatedLease(ctx)
+		if err != nil {
+			return check, fmt.Errorf("no DHCP lease from %s: %w", target.BSSID, err)
+		}
+		defer release()
+		nameservers = l.nameservers()
+	}
+
+	check.Result, check.StatusCode, check.PortalURL = c.probe(ctx, nameservers)
+	check.CheckedAt = time.Now()
+	return check, nil
+}
+
+// probe fetches the probe URL through the interface, without following
+// redirects so the portal they lead to is recorded. Names are resolved by
+// the nameservers of the lease, the host's when none are given.
+func (c *Checker) probe(ctx context.Context, nameservers []string) (domain.PortalResult, int, string) {
+	dialer := &net.Dialer{Timeout: c.Timeout, Control: bindToDevice(c.Interface)}
+	// Resolve through the network under test too: portals often hijack DNS
+	resolve := dialer.DialContext
+	if len(nameservers) > 0 {
+		resolve = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, net.JoinHostPort(nameservers[0], "53"))
+		}
+	}
+	dialer.Resolver = &net.Resolver{PreferGo: true, Dial: resolve}
+	client := &http.Client{
+		Timeout:   c.Timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext, Proxy: nil},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.ProbeURL, nil)
+	if err != nil {
+		return domain.PortalNoEgress, 0, ""
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return domain.PortalNoEgress, 0, ""
+	}
+	defer resp.Body.Close()
+	result, portalURL := Classify(resp, c.ProbeURL)
+	return result, resp.StatusCode, portalURL
+}
+
+// Classify interprets the response to the connectivity probe: only the
+// expected 204 means direct access, anything else was served by a portal.
+func Classify(resp *http.Response, probeURL string) (domain.PortalResult, string) {
+	if resp.StatusCode == http.StatusNoContent {
+		return domain.PortalOpenEgress, ""
+	}
+	if location, err := resp.Location(); err == nil {
+		return domain.PortalCaptive, location.String()
+	}
+	return domain.PortalCaptive, probeURL
+}
+
+func (c *Checker) run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+	out, err := execCmd(ctx, name, args...).CombinedOutput()
+	if errors.Is(err, exec.ErrNotFound) {
+		return out, fmt.Errorf("%w: %s", domain.ErrToolMissing, name)
+	}
+	return out, err
+}
@@ -0,0 +1,82 @@
+package portal
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	probe := DefaultProbeURL
+	redirect := &http.Response{StatusCode: http.StatusFound, Header: http.Header{"Location": {"http://portal.example/login"}}}
+
+	result, url := Classify(&http.Response{StatusCode: http.StatusNoContent}, probe)
+	assert.Equal(t, domain.PortalOpenEgress, result)
+	assert.Empty(t, url)
+
+	result, url = Classify(redirect, probe)
+	assert.Equal(t, domain.PortalCaptive, result)
+	assert.Equal(t, "http://portal.example/login", url)
+
+	// Login page served in place of the probe
+	result, url = Classify(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, probe)
+	assert.Equal(t, domain.PortalCaptive, result)
+	assert.Equal(t, probe, url)
+}
+
+func TestChecker_CheckPortal(t *testing.T) {
+	var commands []string
+	fail := ""
+	originalExecCmd := execCmd
+	execCmd = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		command := strings.Join(append([]string{name}, args...), " ")
+		commands = append(commands, command)
+		if fail != "" && strings.HasPrefix(command, fail) {
+			return exec.CommandContext(ctx, "false")
+		}
+		return exec.CommandContext(ctx, "true")
+	}
+	defer func() { execCmd = originalExecCmd }()
+
+	checker := NewChecker("wmaptest0")
+	target := domain.PortalTarget{BSSID: "00:11:22:33:44:55", SSID: "Guest WiFi", Frequency: 2437}
+
+	check, err := checker.CheckPortal(context.Background(), target)
+	require.NoError(t, err)
+	assert.Equal(t, "wmaptest0", check.Interface)
+	// The interface does not exist, so nothing is reachable through it
+	assert.Equal(t, domain.PortalNoEgress, check.Result)
+	require.Len(t, commands, 5)
+	assert.Equal(t, "iw dev wmaptest0 disconnect", commands[0])
+	assert.Equal(t, "iw dev wmaptest0 connect -w Guest WiFi 2437 00:11:22:33:44:55", commands[1])
+	assert.Regexp(t, `^dhclient -1 -sf \S+/dhclient-script -lf \S+ -pf \S+ wmaptest0$`, commands[2], "the host's routes and resolvers are left alone")
+	assert.Regexp(t, `^dhclient -r -sf \S+/dhclient-script -lf \S+ -pf \S+ wmaptest0$`, commands[3])
+	assert.Equal(t, "iw dev wmaptest0 disconnect", commands[4])
+	script := strings.Fields(commands[2])[3]
+	assert.NoFileExists(t, script, "the lease files are removed")
+
+	t.Run("Association failure", func(t *testing.T) {
+		commands, fail = nil, "iw dev wmaptest0 connect"
+		_, err := checker.CheckPortal(context.Background(), target)
+		assert.ErrorContains(t, err, "association with 00:11:22:33:44:55 failed")
+		for _, command := range commands {
+			assert.NotContains(t, command, "dhclient")
+		}
+		assert.Equal(t, "iw dev wmaptest0 disconnect", commands[len(commands)-1])
+	})
+}
+
+func TestLease_Nameservers(t *testing.T) {
+	l := &lease{dir: t.TempDir()}
+	assert.Empty(t, l.nameservers(), "none before the lease is bound")
+	require.NoError(t, os.WriteFile(filepath.Join(l.dir, "resolvers"), []byte("10.0.0.1 10.0.0.2\n"), 0600))
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, l.nameservers())
+}
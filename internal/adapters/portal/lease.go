@@ -0,0 +1,95 @@
+package portal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// routeTable is the routing table holding the default route of the network
+// under check. Only sockets bound to the interface look it up, so the
+// host's own routes are left alone.
+const routeTable = 4242
+
+// leaseScript is the dhclient hook script. It replaces dhclient-script,
+// which would install the lease's default route and resolvers on the host:
+// it only sets the interface's address, routes the interface's sockets
+// through routeTable and writes the resolvers to the file it is given.
+const leaseScript = `#!/bin/sh
+table=%d
+case "$reason" in
+BOUND|RENEW|REBIND|REBOOT)
+	ip link set dev "$interface" up
+	ip addr flush dev "$interface"
+	ip addr add "$new_ip_address/$new_subnet_mask" dev "$interface"
+	ip route flush table $table
+	for router in $new_routers; do
+		ip route add default via "$router" dev "$interface" table $table
+		break
+	done
+	ip rule del oif "$interface" table $table 2>/dev/null
+	ip rule add oif "$interface" table $table
+	echo "$new_domain_name_servers" > '%s'
+	;;
+EXPIRE|FAIL|RELEASE|STOP)
+	ip rule del oif "$interface" table $table 2>/dev/null
+	ip route flush table $table
+	ip addr flush dev "$interface"
+	;;
+esac
+exit 0
+`
+
+// lease is a DHCP lease obtained with leaseScript.
+type lease struct {
+	dir string // Holds the script, the lease and the resolvers
+}
+
+// dhclient returns the arguments running dhclient with the lease's script
+// and files, so it neither touches the host's configuration nor its leases.
+func (l *lease) dhclient(iface string, args ...string) []string {
+	args = append(args,
+		"-sf", filepath.Join(l.dir, "dhclient-script"),
+		"-lf", filepath.Join(l.dir, "dhclient.leases"),
+		"-pf", filepath.Join(l.dir, "dhclient.pid"),
+		iface)
+	return append([]string{"dhclient"}, args...)
+}
+
+// nameservers returns the resolvers of the lease, none before it is bound.
+func (l *lease) nameservers() []string {
+	data, err := os.ReadFile(filepath.Join(l.dir, "resolvers"))
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(data))
+}
+
+// isolatedLease obtains a lease on the interface without changing the
+// host's routes or resolvers. The returned release gives it back.
+func (c *Checker) isolatedLease(ctx context.Context) (*lease, func(), error) {
+	dir, err := os.MkdirTemp("", "wmap-portal-")
+	if err != nil {
+		return nil, nil, err
+	}
+	l := &lease{dir: dir}
+	script := fmt.Sprintf(leaseScript, routeTable, filepath.Join(dir, "resolvers"))
+	if err := os.WriteFile(filepath.Join(dir, "dhclient-script"), []byte(script), 0700); err != nil {
+		os.RemoveAll(dir)
+		return nil, nil, err
+	}
+
+	release := func() {
+		args := l.dhclient(c.Interface, "-r")
+		c.run(context.Background(), args[0], args[1:]...)
+		os.RemoveAll(dir)
+	}
+	args := l.dhclient(c.Interface, "-1")
+	if out, err := c.run(ctx, args[0], args[1:]...); err != nil {
+		release()
+		return nil, nil, fmt.Errorf("%v: %s", err, out)
+	}
+	return l, release, nil
+}
@@ -0,0 +1,116 @@
+// Package power reads the charge of the battery powering the sensor, from
+// the kernel's power supply class or from a command for boards whose fuel
+// gauge it does not expose, such as UPS HATs and solar charge controllers.
+package power
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/telemetry"
+)
+
+// DefaultSysfsRoot is where the kernel lists power supplies.
+const DefaultSysfsRoot = "/sys/class/power_supply"
+
+// ErrNoBattery is returned when no battery reports its charge.
+var ErrNoBattery = errors.New("no battery found")
+
+// execCmd allows mocking exec.CommandContext in tests
+var execCmd = exec.CommandContext
+
+// SysfsBattery reads the first battery of the kernel's power supply class.
+type SysfsBattery struct {
+	Root string
+}
+
+// Battery returns the charge of the first supply of type Battery.
+func (b *SysfsBattery) Battery(ctx context.Context) (domain.BatteryStatus, error) {
+	entries, err := os.ReadDir(b.Root)
+	if err != nil {
+		return domain.BatteryStatus{}, err
+	}
+	for _, entry := range entries {
+		dir := filepath.Join(b.Root, entry.Name())
+		if readAttr(dir, "type") != "Battery" {
+			continue
+		}
+		percent, err := strconv.Atoi(readAttr(dir, "capacity"))
+		if err != nil {
+			continue
+		}
+		status := readAttr(dir, "status")
+		return report(domain.BatteryStatus{
+			Percent:   percent,
+			Charging:  status == "Charging" || status == "Full",
+			Source:    entry.Name(),
+			CheckedAt: time.Now(),
+		}), nil
+	}
+	return domain.BatteryStatus{}, ErrNoBattery
+}
+
+func readAttr(dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// CommandBattery runs a command printing the charge in percent, optionally
+// followed by "charging", e.g. "87 charging".
+type CommandBattery struct {
+	Command []string
+	Timeout time.Duration
+}
+
+// Battery runs the command and parses its output.
+func (b *CommandBattery) Battery(ctx context.Context) (domain.BatteryStatus, error) {
+	if len(b.Command) == 0 {
+		return domain.BatteryStatus{}, ErrNoBattery
+	}
+	ctx, cancel := context.WithTimeout(ctx, b.Timeout)
+	defer cancel()
+	out, err := execCmd(ctx, b.Command[0], b.Command[1:]...).Output()
+	if err != nil {
+		return domain.BatteryStatus{}, fmt.Errorf("battery command failed: %w", err)
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return domain.BatteryStatus{}, fmt.Errorf("battery command printed nothing")
+	}
+	percent, err := strconv.ParseFloat(strings.TrimSuffix(fields[0], "%"), 64)
+	if err != nil || percent < 0 || percent > 100 {
+		return domain.BatteryStatus{}, fmt.Errorf("battery command printed %q, expected a percentage", fields[0])
+	}
+	return report(domain.BatteryStatus{
+		Percent:   int(percent + 0.5),
+		Charging:  len(fields) > 1 && strings.EqualFold(fields[1], "charging"),
+		Source:    filepath.Base(b.Command[0]),
+		CheckedAt: time.Now(),
+	}), nil
+}
+
+// report exports the charge as a metric.
+func report(status domain.BatteryStatus) domain.BatteryStatus {
+	telemetry.BatteryLevel.Set(float64(status.Percent))
+	return status
+}
+
+// NewBatteryMonitor reads the battery with command if set, else from sysfs.
+func NewBatteryMonitor(command string) ports.BatteryMonitor {
+	if fields := strings.Fields(command); len(fields) > 0 {
+		return &CommandBattery{Command: fields, Timeout: 10 * time.Second}
+	}
+	return &SysfsBattery{Root: DefaultSysfsRoot}
+}
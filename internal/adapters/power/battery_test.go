@@ -0,0 +1,62 @@
+package power
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSupply(t *testing.T, root, name string, attrs map[string]string) {
+	dir := filepath.Join(root, name)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	for attr, value := range attrs {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, attr), []byte(value+"\n"), 0o644))
+	}
+}
+
+func TestSysfsBattery(t *testing.T) {
+	root := t.TempDir()
+	writeSupply(t, root, "AC", map[string]string{"type": "Mains", "online": "1"})
+	writeSupply(t, root, "BAT0", map[string]string{"type": "Battery", "capacity": "64", "status": "Charging"})
+
+	status, err := (&SysfsBattery{Root: root}).Battery(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 64, status.Percent)
+	assert.True(t, status.Charging)
+	assert.Equal(t, "BAT0", status.Source)
+
+	_, err = (&SysfsBattery{Root: t.TempDir()}).Battery(context.Background())
+	assert.ErrorIs(t, err, ErrNoBattery)
+}
+
+func TestCommandBattery(t *testing.T) {
+	defer func() { execCmd = exec.CommandContext }()
+
+	for output, want := range map[string]struct {
+		percent  int
+		charging bool
+	}{
+		"87\n":           {87, false},
+		"42.6% charging": {43, true},
+	} {
+		execCmd = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+			return exec.CommandContext(ctx, "echo", "-n", output)
+		}
+		status, err := NewBatteryMonitor("ups-status --json").Battery(context.Background())
+		require.NoError(t, err, output)
+		assert.Equal(t, want.percent, status.Percent, output)
+		assert.Equal(t, want.charging, status.Charging, output)
+		assert.Equal(t, "ups-status", status.Source)
+	}
+
+	execCmd = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.CommandContext(ctx, "echo", "unknown")
+	}
+	_, err := NewBatteryMonitor("ups-status").Battery(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,244 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// actionTimeLayout formats action times; reports use UTC so the time
+// windows compare across time zones.
+const actionTimeLayout = "2006-01-02 15:04:05Z"
+
+// ExportActionsMarkdown renders an actions report as Markdown, ready to
+// paste in the rules of engagement appendix of a pentest report.
+func ExportActionsMarkdown(report *domain.ActionsReport) []byte {
+	var b bytes.Buffer
+	meta := report.Metadata
+	fmt.Fprintf(&b, "# %s\n\n", meta.Title)
+	if meta.OrganizationName != "" {
+		fmt.Fprintf(&b, "- **Organization:** %s\n", mdEscape(meta.OrganizationName))
+	}
+	if meta.WorkspaceName != "" {
+		fmt.Fprintf(&b, "- **Workspace:** %s\n", mdEscape(meta.WorkspaceName))
+	}
+	fmt.Fprintf(&b, "- **Period:** %s\n", formatPeriod(meta.ScanPeriod))
+	fmt.Fprintf(&b, "- **Generated:** %s by %s\n\n", formatActionTime(meta.GeneratedAt), mdEscape(meta.GeneratedBy))
+
+	b.WriteString("## Authorized Scope\n\n")
+	for _, line := range scopeLines(report.Scope) {
+		fmt.Fprintf(&b, "- %s\n", mdEscape(line))
+	}
+
+	s := report.Summary
+	b.WriteString("\n## Summary\n\n")
+	b.WriteString("| Attacks | Targets | Operators | Handshakes | Credentials | Disconnections | Failed |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| %d | %d | %s | %d | %d | %d | %d |\n",
+		s.Total, s.Targets, mdEscape(strings.Join(s.Operators, ", ")),
+		s.HandshakesCaptured, s.CredentialsRecovered, s.DisconnectionsObserved, s.Failed)
+
+	b.WriteString("\n## Actions\n\n")
+	if len(report.Actions) == 0 {
+		b.WriteString("No attacks were launched in this period.\n")
+		return b.Bytes()
+	}
+	b.WriteString("| # | Start | End | Duration | Operator | Attack | Target | Channel | Interface | Result |\n")
+	b.WriteString("|---|---|---|---|---|---|---|---|---|---|\n")
+	for i, a := range report.Actions {
+		fmt.Fprintf(&b, "| %d | %s | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+			i+1, formatActionTime(a.StartTime), formatActionTime(a.EndTime), formatDuration(a.DurationMs),
+			mdEscape(operatorName(a.Operator)), a.Kind, mdEscape(orDash(a.Target)), channelText(a.Channel),
+			mdEscape(orDash(a.Interface)), mdEscape(a.Outcome()))
+	}
+	return b.Bytes()
+}
+
+// ExportActionsCSV renders the actions of a report as CSV, one attack per row.
+func ExportActionsCSV(report *domain.ActionsReport) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write([]string{"id", "kind", "operator", "target", "interface", "channel", "start", "end",
+		"duration_s", "status", "packets_sent", "result", "score"})
+	for _, a := range report.Actions {
+		w.Write([]string{
+			a.ID, string(a.Kind), a.Operator, a.Target, a.Interface, strconv.Itoa(a.Channel),
+			formatActionTime(a.StartTime), formatActionTime(a.EndTime),
+			strconv.FormatInt(a.DurationMs/1000, 10), a.Status, strconv.Itoa(a.PacketsSent),
+			a.Outcome(), strconv.Itoa(a.Score),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// ExportActionsReport generates a landscape PDF listing the actions taken.
+func (e *PDFExporter) ExportActionsReport(report *domain.ActionsReport) ([]byte, error) {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	meta := report.Metadata
+
+	pdf.SetFont("Arial", "B", 20)
+	pdf.SetTextColor(0, 51, 102)
+	pdf.CellFormat(0, 12, meta.Title, "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.SetTextColor(100, 100, 100)
+	if meta.OrganizationName != "" {
+		pdf.CellFormat(0, 6, tr("Organization: "+meta.OrganizationName), "", 1, "L", false, 0, "")
+	}
+	if meta.WorkspaceName != "" {
+		pdf.CellFormat(0, 6, tr("Workspace: "+meta.WorkspaceName), "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, 6, "Period: "+formatPeriod(meta.ScanPeriod), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf("Generated: %s by %s", formatActionTime(meta.GeneratedAt), meta.GeneratedBy)), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	e.sectionTitle(pdf, "Authorized Scope")
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(60, 60, 60)
+	for _, line := range scopeLines(report.Scope) {
+		pdf.MultiCell(0, 5, tr(line), "", "L", false)
+	}
+	pdf.Ln(3)
+
+	s := report.Summary
+	e.sectionTitle(pdf, "Summary")
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(60, 60, 60)
+	pdf.MultiCell(0, 5, tr(fmt.Sprintf(
+		"%d attacks on %d targets by %s. Handshakes captured: %d. Credentials recovered: %d. Disconnections observed: %d. Failed: %d.",
+		s.Total, s.Targets, orDash(strings.Join(s.Operators, ", ")),
+		s.HandshakesCaptured, s.CredentialsRecovered, s.DisconnectionsObserved, s.Failed)), "", "L", false)
+	pdf.Ln(3)
+
+	e.sectionTitle(pdf, "Actions")
+	if len(report.Actions) == 0 {
+		pdf.SetFont("Arial", "I", 10)
+		pdf.SetTextColor(100, 100, 100)
+		pdf.CellFormat(0, 7, "No attacks were launched in this period", "", 1, "L", false, 0, "")
+	} else {
+		widths := []float64{8, 36, 36, 18, 28, 24, 34, 12, 20, 61}
+		headers := []string{"#", "Start", "End", "Duration", "Operator", "Attack", "Target", "Ch", "Interface", "Result"}
+		pdf.SetFillColor(240, 240, 240)
+		pdf.SetFont("Arial", "B", 8)
+		pdf.SetTextColor(60, 60, 60)
+		for i, h := range headers {
+			pdf.CellFormat(widths[i], 7, h, "1", 0, "L", true, 0, "")
+		}
+		pdf.Ln(-1)
+
+		pdf.SetFont("Arial", "", 8)
+		for i, a := range report.Actions {
+			row := []string{
+				strconv.Itoa(i + 1), formatActionTime(a.StartTime), formatActionTime(a.EndTime), formatDuration(a.DurationMs),
+				operatorName(a.Operator), string(a.Kind), orDash(a.Target), channelText(a.Channel), orDash(a.Interface),
+				truncate(a.Outcome(), 45),
+			}
+			for j, cell := range row {
+				pdf.CellFormat(widths[j], 6, tr(cell), "1", 0, "L", false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (e *PDFExporter) sectionTitle(pdf *gofpdf.Fpdf, title string) {
+	pdf.SetFont("Arial", "B", 13)
+	pdf.SetTextColor(0, 51, 102)
+	pdf.CellFormat(0, 9, title, "", 1, "L", false, 0, "")
+}
+
+// scopeLines describes the engagement scope attacks were checked against.
+func scopeLines(scope domain.EngagementScope) []string {
+	if scope.IsEmpty() {
+		return []string{"No scope restriction was configured"}
+	}
+	var lines []string
+	if len(scope.BSSIDs) > 0 {
+		lines = append(lines, "BSSIDs: "+strings.Join(scope.BSSIDs, ", "))
+	}
+	if len(scope.SSIDs) > 0 {
+		lines = append(lines, "SSIDs: "+strings.Join(scope.SSIDs, ", "))
+	}
+	if len(scope.MACPrefixes) > 0 {
+		lines = append(lines, "MAC prefixes: "+strings.Join(scope.MACPrefixes, ", "))
+	}
+	return lines
+}
+
+func formatPeriod(r domain.DateRange) string {
+	start, end := "beginning", "now"
+	if !r.Start.IsZero() {
+		start = r.Start.UTC().Format("2006-01-02")
+	}
+	if !r.End.IsZero() {
+		end = r.End.UTC().Format("2006-01-02")
+	}
+	return start + " to " + end
+}
+
+func formatActionTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(actionTimeLayout)
+}
+
+func formatDuration(ms int64) string {
+	if ms <= 0 {
+		return "-"
+	}
+	return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+}
+
+func operatorName(operator string) string {
+	if operator == "" {
+		return "unattributed"
+	}
+	return operator
+}
+
+func channelText(channel int) string {
+	if channel == 0 {
+		return "-"
+	}
+	return strconv.Itoa(channel)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
+
+// mdEscape keeps a value from breaking a Markdown table.
+func mdEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,89 @@
+package reporting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+func sampleActionsReport() *domain.ActionsReport {
+	start := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	return &domain.ActionsReport{
+		Metadata: domain.ReportMetadata{
+			Type:             domain.ReportTypeActions,
+			Title:            "Actions Taken",
+			GeneratedAt:      start.Add(24 * time.Hour),
+			GeneratedBy:      "alice",
+			OrganizationName: "Acme",
+			ScanPeriod:       domain.DateRange{Start: start.Add(-9 * time.Hour)},
+		},
+		Scope: domain.EngagementScope{SSIDs: []string{"Corp|Guest"}},
+		Summary: domain.ActionsSummary{
+			Total: 2, Targets: 1, Operators: []string{"alice"}, HandshakesCaptured: 1,
+		},
+		Actions: []domain.AttackRecord{
+			{
+				ID: "a1", Kind: domain.AttackKindDeauth, Operator: "alice", Target: "AA:BB:CC:00:00:01",
+				Interface: "wlan0", Channel: 6, StartTime: start, EndTime: start.Add(30 * time.Second),
+				DurationMs: 30000, HandshakeCaptured: true,
+			},
+			{
+				ID: "a2", Kind: domain.AttackKindWPS, Target: "AA:BB:CC:00:00:01",
+				StartTime: start.Add(time.Hour), Status: string(domain.AttackFailed),
+			},
+		},
+	}
+}
+
+func TestExportActionsMarkdown(t *testing.T) {
+	md := string(ExportActionsMarkdown(sampleActionsReport()))
+
+	for _, want := range []string{
+		"# Actions Taken",
+		"**Period:** 2024-03-10 to now",
+		`SSIDs: Corp\|Guest`,
+		"| 1 | 2024-03-10 09:00:00Z | 2024-03-10 09:00:30Z | 30s | alice | deauth | AA:BB:CC:00:00:01 | 6 | wlan0 | Handshake captured |",
+		"| 2 | 2024-03-10 10:00:00Z | - | - | unattributed | wps | AA:BB:CC:00:00:01 | - | - | Failed |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	empty := sampleActionsReport()
+	empty.Actions = nil
+	if !strings.Contains(string(ExportActionsMarkdown(empty)), "No attacks were launched") {
+		t.Error("Empty report should say no attacks were launched")
+	}
+}
+
+func TestExportActionsCSV(t *testing.T) {
+	data, err := ExportActionsCSV(sampleActionsReport())
+	if err != nil {
+		t.Fatalf("ExportActionsCSV() error = %v", err)
+	}
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Rows = %d, want header and 2 actions", len(rows))
+	}
+	if rows[1][2] != "alice" || rows[1][11] != "Handshake captured" {
+		t.Errorf("Row = %v", rows[1])
+	}
+}
+
+func TestPDFExporterExportActionsReport(t *testing.T) {
+	data, err := NewPDFExporter().ExportActionsReport(sampleActionsReport())
+	if err != nil {
+		t.Fatalf("ExportActionsReport() error = %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		t.Error("Output is not a PDF")
+	}
+}
@@ -33,6 +33,9 @@ func (e *PDFExporter) ExportExecutiveSummary(report *domain.ExecutiveSummary) ([
 	// Top Risks table
 	e.addTopRisks(pdf, report)
 
+	// Business assets with findings
+	e.addAssets(pdf, report)
+
 	// Recommendations
 	e.addRecommendations(pdf, report)
 
@@ -150,6 +153,13 @@ func (e *PDFExporter) addStatistics(pdf *gofpdf.Fpdf, report *domain.ExecutiveSu
 		{"Confirmed", fmt.Sprintf("%d", report.VulnStats.Confirmed), []int{0, 102, 204}},
 		{"Unconfirmed", fmt.Sprintf("%d", report.VulnStats.Unconfirmed), []int{150, 150, 150}},
 	}
+	if report.VulnStats.Suppressed > 0 {
+		stats = append(stats, struct {
+			label string
+			value string
+			color []int
+		}{"Suppressed (accepted)", fmt.Sprintf("%d", report.VulnStats.Suppressed), []int{150, 150, 150}})
+	}
 
 	// Display in 2 columns
 	colWidth := 85.0
@@ -235,6 +245,51 @@ func (e *PDFExporter) addTopRisks(pdf *gofpdf.Fpdf, report *domain.ExecutiveSumm
 	pdf.Ln(8)
 }
 
+// addAssets adds the table of business assets with active findings
+func (e *PDFExporter) addAssets(pdf *gofpdf.Fpdf, report *domain.ExecutiveSummary) {
+	if len(report.Assets) == 0 {
+		return
+	}
+
+	// Section title
+	pdf.SetFont("Arial", "B", 14)
+	pdf.SetTextColor(0, 51, 102)
+	pdf.CellFormat(0, 10, "Affected Business Assets", "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	// Table header
+	pdf.SetFillColor(240, 240, 240)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetTextColor(60, 60, 60)
+
+	pdf.CellFormat(30, 8, "Asset", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(40, 8, "Owner", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(25, 8, "Criticality", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(20, 8, "Findings", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(55, 8, "Worst Finding", "1", 1, "L", true, 0, "")
+
+	// Table rows
+	pdf.SetFont("Arial", "", 9)
+	for _, asset := range report.Assets {
+		// Fall back to the MAC for devices without an asset tag
+		name := asset.AssetTag
+		if name == "" {
+			name = asset.MAC
+		}
+		pdf.SetTextColor(60, 60, 60)
+		pdf.CellFormat(30, 7, truncate(name, 18), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 7, truncate(asset.Owner, 24), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(25, 7, string(asset.Criticality), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(20, 7, fmt.Sprintf("%d", asset.Vulnerabilities), "1", 0, "C", false, 0, "")
+
+		r, g, b := e.getSeverityColor(asset.MaxSeverity)
+		pdf.SetTextColor(r, g, b)
+		pdf.CellFormat(55, 7, truncate(fmt.Sprintf("%s (%d/10)", asset.TopFinding, asset.MaxSeverity), 34), "1", 1, "L", false, 0, "")
+	}
+
+	pdf.Ln(8)
+}
+
 // getSeverityColor returns RGB color based on severity
 func (e *PDFExporter) getSeverityColor(severity int) (r, g, b int) {
 	switch {
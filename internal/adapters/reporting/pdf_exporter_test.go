@@ -83,6 +83,9 @@ func TestPDFExporterExportExecutiveSummary(t *testing.T) {
 				RiskScore:       40.0,
 			},
 		},
+		Assets: []domain.AssetExposure{
+			{MAC: "aa:bb:cc:dd:ee:01", Owner: "Finance", AssetTag: "POS-01", Criticality: domain.CriticalityCritical, Vulnerabilities: 2, MaxSeverity: 9, TopFinding: "WPS-PIXIE"},
+		},
 		Recommendations: []domain.Recommendation{
 			{
 				Priority:    "critical",
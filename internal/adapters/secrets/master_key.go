@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrWrongMasterKey is returned when the master key does not match the one
+// that sealed the existing data.
+var ErrWrongMasterKey = errors.New("master key does not match the stored data")
+
+// keyCheckValue is sealed into the check file to recognise the master key.
+const keyCheckValue = "wmap-master-key"
+
+// scrypt parameters for passphrase-derived keys (interactive logins, 2017 guidance)
+const (
+	scryptN   = 1 << 15
+	scryptR   = 8
+	scryptP   = 1
+	saltBytes = 16
+)
+
+// DeriveKey turns a passphrase into a key. The random salt is kept at
+// saltPath and created on first use, so the same passphrase always yields
+// the same key for this installation.
+func DeriveKey(passphrase string, saltPath string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("master passphrase is empty")
+	}
+
+	salt, err := os.ReadFile(saltPath)
+	if os.IsNotExist(err) {
+		salt = make([]byte, saltBytes)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(saltPath), 0700); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(saltPath, salt, 0600); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+}
+
+// VerifyKey checks the sealer against the check file at checkPath, writing
+// it on first use. A mismatch means the data at rest was sealed with another
+// master key and could not be opened.
+func VerifyKey(sealer *Sealer, checkPath string) error {
+	sealed, err := os.ReadFile(checkPath)
+	if os.IsNotExist(err) {
+		check, err := sealer.Seal(keyCheckValue)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(checkPath), 0700); err != nil {
+			return err
+		}
+		return os.WriteFile(checkPath, []byte(check), 0600)
+	}
+	if err != nil {
+		return err
+	}
+
+	value, err := sealer.Open(string(sealed))
+	if err != nil || value != keyCheckValue {
+		return fmt.Errorf("%w (check %s)", ErrWrongMasterKey, checkPath)
+	}
+	return nil
+}
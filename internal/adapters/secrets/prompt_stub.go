@@ -0,0 +1,10 @@
+//go:build !linux
+
+package secrets
+
+import "fmt"
+
+// PromptPassphrase asks for the master passphrase on the terminal.
+func PromptPassphrase(prompt string) (string, error) {
+	return "", fmt.Errorf("passphrase prompt only supported on linux")
+}
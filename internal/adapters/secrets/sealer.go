@@ -0,0 +1,127 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Ensure compliance
+var _ ports.SecretSealer = (*Sealer)(nil)
+
+// keySize selects AES-256.
+const keySize = 32
+
+// ErrInvalidSealed is returned when a sealed secret is malformed or was sealed with another key.
+var ErrInvalidSealed = errors.New("invalid sealed secret")
+
+// Sealer encrypts secrets with AES-GCM. Sealed values are base64 encoded,
+// with the nonce prepended to the ciphertext.
+type Sealer struct {
+	aead cipher.AEAD
+	key  []byte // Master key, only used to derive SubKey
+}
+
+// NewSealer creates a sealer from a 32-byte key.
+func NewSealer(key []byte) (*Sealer, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("secret key must be %d bytes, got %d", keySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Sealer{aead: aead, key: key}, nil
+}
+
+// SubKey derives a key for purpose from the master key, so that records
+// can be authenticated without reusing the encryption key.
+func (s *Sealer) SubKey(purpose string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// LoadOrCreateKey reads the key stored at path, generating it with owner-only
+// permissions on first use.
+func LoadOrCreateKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err == nil {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("secret key %s is corrupt", path)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key = make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, key, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Seal encrypts a secret.
+func (s *Sealer) Seal(plaintext string) (string, error) {
+	sealed, err := s.SealBytes([]byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a secret produced by Seal.
+func (s *Sealer) Open(sealed string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", ErrInvalidSealed
+	}
+	plaintext, err := s.OpenBytes(data)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// SealBytes encrypts binary data, such as a capture file.
+func (s *Sealer) SealBytes(plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// OpenBytes decrypts data produced by SealBytes.
+func (s *Sealer) OpenBytes(sealed []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, ErrInvalidSealed
+	}
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidSealed
+	}
+	return plaintext, nil
+}
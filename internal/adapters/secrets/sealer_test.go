@@ -0,0 +1,100 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "secret.key")
+	key, err := LoadOrCreateKey(path)
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	again, err := LoadOrCreateKey(path)
+	require.NoError(t, err)
+	assert.Equal(t, key, again, "key is reused once created")
+
+	sealer, err := NewSealer(key)
+	require.NoError(t, err)
+
+	sealed, err := sealer.Seal("password1")
+	require.NoError(t, err)
+	assert.NotContains(t, sealed, "password1")
+
+	other, _ := sealer.Seal("password1")
+	assert.NotEqual(t, sealed, other, "nonce is random")
+
+	plain, err := sealer.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "password1", plain)
+
+	_, err = sealer.Open(sealed[:len(sealed)-4] + "AAAA")
+	assert.ErrorIs(t, err, ErrInvalidSealed)
+
+	_, err = NewSealer([]byte("short"))
+	assert.Error(t, err)
+
+	subKey := sealer.SubKey("roe")
+	assert.Len(t, subKey, keySize)
+	assert.Equal(t, subKey, sealer.SubKey("roe"))
+	assert.NotEqual(t, subKey, sealer.SubKey("other"), "one key per purpose")
+	assert.NotEqual(t, key, subKey)
+}
+
+func TestSealBytes(t *testing.T) {
+	key, err := LoadOrCreateKey(filepath.Join(t.TempDir(), "secret.key"))
+	require.NoError(t, err)
+	sealer, err := NewSealer(key)
+	require.NoError(t, err)
+
+	capture := []byte{0xd4, 0xc3, 0xb2, 0xa1, 0x02, 0x00}
+	sealed, err := sealer.SealBytes(capture)
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), string(capture))
+
+	plain, err := sealer.OpenBytes(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, capture, plain)
+
+	sealed[len(sealed)-1] ^= 0xff
+	_, err = sealer.OpenBytes(sealed)
+	assert.ErrorIs(t, err, ErrInvalidSealed)
+	_, err = sealer.OpenBytes([]byte("x"))
+	assert.ErrorIs(t, err, ErrInvalidSealed)
+}
+
+func TestMasterKey(t *testing.T) {
+	dir := t.TempDir()
+	salt := filepath.Join(dir, "master.salt")
+	check := filepath.Join(dir, "master.check")
+
+	key, err := DeriveKey("correct horse", salt)
+	require.NoError(t, err)
+	assert.Len(t, key, keySize)
+
+	again, err := DeriveKey("correct horse", salt)
+	require.NoError(t, err)
+	assert.Equal(t, key, again, "same passphrase and salt give the same key")
+
+	_, err = DeriveKey("", salt)
+	assert.Error(t, err)
+
+	sealer, err := NewSealer(key)
+	require.NoError(t, err)
+	require.NoError(t, VerifyKey(sealer, check), "first use writes the check file")
+	require.NoError(t, VerifyKey(sealer, check))
+
+	wrong, err := DeriveKey("wrong passphrase", salt)
+	require.NoError(t, err)
+	wrongSealer, err := NewSealer(wrong)
+	require.NoError(t, err)
+	assert.ErrorIs(t, VerifyKey(wrongSealer, check), ErrWrongMasterKey)
+}
@@ -0,0 +1,119 @@
+package capture
+
+import (
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// Preamble and PLCP header durations of each PHY.
+const (
+	longPreamble  = 192 * time.Microsecond // DSSS/CCK, long preamble
+	shortPreamble = 96 * time.Microsecond  // DSSS/CCK, short preamble
+	ofdmPreamble  = 20 * time.Microsecond  // 802.11a/g
+	htPreamble    = 36 * time.Microsecond  // 802.11n/ac, single stream
+)
+
+// fallbackRate is assumed when RadioTap reports no rate (Mbps).
+const fallbackRate = 6.0
+
+// htRates are the 802.11n/ac rates of MCS 0-9 for one spatial stream at
+// 20 MHz with the long guard interval (Mbps).
+var htRates = [...]float64{6.5, 13, 19.5, 26, 39, 52, 58.5, 65, 78, 86.7}
+
+// frameAirtime estimates how long a captured frame occupied the medium from
+// its length and the PHY rate reported in RadioTap. Interframe spaces and
+// acknowledgements are not counted, so the estimate is a lower bound.
+func frameAirtime(packet gopacket.Packet) time.Duration {
+	rt, ok := packet.Layer(layers.LayerTypeRadioTap).(*layers.RadioTap)
+	if !ok {
+		return 0
+	}
+	frameLen := len(packet.Data()) - int(rt.Length)
+	if frameLen <= 0 {
+		return 0
+	}
+	return radiotapAirtime(rt, frameLen)
+}
+
+// radiotapAirtime returns the airtime of a frameLen-byte frame sent with the
+// PHY parameters of rt.
+func radiotapAirtime(rt *layers.RadioTap, frameLen int) time.Duration {
+	preamble, rate := ofdmPreamble, fallbackRate
+	switch {
+	case rt.Present.VHT():
+		preamble, rate = htPreamble, vhtRate(rt.VHT)
+	case rt.Present.MCS():
+		preamble, rate = htPreamble, mcsRate(rt.MCS)
+	case rt.Present.Rate() && rt.Rate > 0:
+		rate = float64(rt.Rate) / 2
+		if isCCKRate(rt.Rate) {
+			preamble = longPreamble
+			if rt.Flags.ShortPreamble() {
+				preamble = shortPreamble
+			}
+		}
+	}
+	if rate <= 0 {
+		rate = fallbackRate
+	}
+	payload := time.Duration(float64(frameLen*8) / rate * float64(time.Microsecond))
+	return preamble + payload
+}
+
+// isCCKRate reports whether a legacy rate (500 kbps units) is a DSSS/CCK rate.
+func isCCKRate(rate layers.RadioTapRate) bool {
+	switch rate {
+	case 2, 4, 11, 22:
+		return true
+	}
+	return false
+}
+
+// mcsRate returns the 802.11n rate of an HT MCS index (Mbps).
+func mcsRate(m layers.RadioTapMCS) float64 {
+	if m.MCS > 31 {
+		return 0
+	}
+	rate := htRates[m.MCS%8] * float64(m.MCS/8+1)
+	if m.Known.Bandwidth() && m.Flags.Bandwidth() == 1 {
+		rate *= 27.0 / 13 // 108 vs 52 data subcarriers
+	}
+	if m.Known.GuardInterval() && m.Flags.ShortGI() {
+		rate *= 10.0 / 9
+	}
+	return rate
+}
+
+// vhtRate returns the 802.11ac rate of the first user's MCS and NSS (Mbps).
+func vhtRate(v layers.RadioTapVHT) float64 {
+	mcsnss := v.MCSNSS[0]
+	mcs, nss := int(mcsnss>>4), int(mcsnss&0x0f)
+	if !mcsnss.Present() || mcs >= len(htRates) {
+		return 0
+	}
+	rate := htRates[mcs] * float64(nss)
+	if v.Known.Bandwidth() {
+		rate *= vhtBandwidthFactor(v.Bandwidth & 0x1f)
+	}
+	if v.Known.GI() && v.Flags.SGI() {
+		rate *= 10.0 / 9
+	}
+	return rate
+}
+
+// vhtBandwidthFactor maps the RadioTap VHT bandwidth code to the rate
+// multiplier over 20 MHz.
+func vhtBandwidthFactor(bw uint8) float64 {
+	switch {
+	case bw == 0:
+		return 1
+	case bw <= 3:
+		return 27.0 / 13 // 40 MHz
+	case bw <= 10:
+		return 58.5 / 13 // 80 MHz
+	default:
+		return 117.0 / 13 // 160 MHz
+	}
+}
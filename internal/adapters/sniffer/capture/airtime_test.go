@@ -0,0 +1,88 @@
+package capture
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func TestRadiotapAirtime(t *testing.T) {
+	tests := []struct {
+		name string
+		rt   layers.RadioTap
+		len  int
+		want time.Duration
+	}{
+		{
+			name: "CCK long preamble",
+			rt:   layers.RadioTap{Present: layers.RadioTapPresentRate, Rate: 2}, // 1 Mbps
+			len:  100,
+			want: 192*time.Microsecond + 800*time.Microsecond,
+		},
+		{
+			name: "CCK short preamble",
+			rt:   layers.RadioTap{Present: layers.RadioTapPresentRate | layers.RadioTapPresentFlags, Rate: 22, Flags: layers.RadioTapFlagsShortPreamble}, // 11 Mbps
+			len:  1100,
+			want: 96*time.Microsecond + 800*time.Microsecond,
+		},
+		{
+			name: "OFDM",
+			rt:   layers.RadioTap{Present: layers.RadioTapPresentRate, Rate: 108}, // 54 Mbps
+			len:  540,
+			want: 20*time.Microsecond + 80*time.Microsecond,
+		},
+		{
+			name: "HT MCS 15",
+			rt:   layers.RadioTap{Present: layers.RadioTapPresentMCS, MCS: layers.RadioTapMCS{MCS: 15}}, // 130 Mbps
+			len:  1300,
+			want: 36*time.Microsecond + 80*time.Microsecond,
+		},
+		{
+			name: "VHT MCS 9 2SS 80 MHz",
+			rt: layers.RadioTap{
+				Present: layers.RadioTapPresentVHT,
+				VHT: layers.RadioTapVHT{
+					Known:     layers.RadioTapVHTKnownBandwidth,
+					Bandwidth: 4,
+					MCSNSS:    [4]layers.RadioTapVHTMCSNSS{0x92},
+				},
+			},
+			len:  7800,
+			want: 36*time.Microsecond + 80*time.Microsecond,
+		},
+		{
+			name: "no rate",
+			rt:   layers.RadioTap{},
+			len:  600,
+			want: 20*time.Microsecond + 800*time.Microsecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := radiotapAirtime(&tt.rt, tt.len)
+			if diff := got - tt.want; diff < -time.Microsecond || diff > time.Microsecond {
+				t.Errorf("radiotapAirtime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameAirtime(t *testing.T) {
+	dot11 := &layers.Dot11{Type: layers.Dot11TypeData}
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true}
+	rt := &layers.RadioTap{Present: layers.RadioTapPresentRate, Rate: 12} // 6 Mbps
+	if err := gopacket.SerializeLayers(buf, opts, rt, dot11, gopacket.Payload(make([]byte, 50))); err != nil {
+		t.Fatalf("serialize: %v", err)
+	}
+	packet := gopacket.NewPacket(buf.Bytes(), layers.LayerTypeRadioTap, gopacket.Default)
+
+	frameLen := len(buf.Bytes()) - int(packet.Layer(layers.LayerTypeRadioTap).(*layers.RadioTap).Length)
+	want := 20*time.Microsecond + time.Duration(frameLen*8)*time.Microsecond/6
+	if got := frameAirtime(packet); got-want > time.Microsecond || want-got > time.Microsecond {
+		t.Errorf("frameAirtime() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package capture
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// DefaultDedupWindow is how long a frame key is remembered across adapters.
+// Short enough that 12-bit sequence numbers do not wrap within it.
+const DefaultDedupWindow = 200 * time.Millisecond
+
+type frameKey struct {
+	src  [6]byte
+	seq  uint16
+	frag uint8
+	typ  layers.Dot11Type
+}
+
+type frameSighting struct {
+	iface string
+	at    time.Time
+}
+
+// FrameDeduplicator drops frames already received by another adapter, so a
+// frame overheard by several radios on the same host is processed once.
+// Retransmissions seen again by the same adapter are not duplicates.
+type FrameDeduplicator struct {
+	mu        sync.Mutex
+	window    time.Duration
+	seen      map[frameKey]frameSighting
+	lastPrune time.Time
+}
+
+// NewFrameDeduplicator creates a deduplicator remembering frames for window.
+func NewFrameDeduplicator(window time.Duration) *FrameDeduplicator {
+	if window <= 0 {
+		window = DefaultDedupWindow
+	}
+	return &FrameDeduplicator{
+		window:    window,
+		seen:      make(map[frameKey]frameSighting),
+		lastPrune: time.Now(),
+	}
+}
+
+// IsDuplicate reports whether packet was already received on another
+// interface within the window, keyed on (source MAC, sequence number, frame type).
+func (d *FrameDeduplicator) IsDuplicate(iface string, packet gopacket.Packet) bool {
+	dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	if !ok || len(dot11.Address2) != 6 {
+		return false
+	}
+	key := frameKey{seq: dot11.SequenceNumber, frag: uint8(dot11.FragmentNumber), typ: dot11.Type}
+	copy(key.src[:], dot11.Address2)
+	return d.observe(iface, key, packet.Metadata().Timestamp)
+}
+
+func (d *FrameDeduplicator) observe(iface string, key frameKey, at time.Time) bool {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if at.Sub(d.lastPrune) > d.window {
+		for k, s := range d.seen {
+			if at.Sub(s.at) > d.window {
+				delete(d.seen, k)
+			}
+		}
+		d.lastPrune = at
+	}
+
+	if prev, ok := d.seen[key]; ok && at.Sub(prev.at) <= d.window {
+		if prev.iface != iface {
+			return true
+		}
+	}
+	d.seen[key] = frameSighting{iface: iface, at: at}
+	return false
+}
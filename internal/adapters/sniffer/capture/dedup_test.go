@@ -0,0 +1,65 @@
+package capture
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildSeqFrame(t *testing.T, src net.HardwareAddr, seq uint16, at time.Time) gopacket.Packet {
+	t.Helper()
+	buf := gopacket.NewSerializeBuffer()
+	err := gopacket.SerializeLayers(buf, gopacket.SerializeOptions{FixLengths: true},
+		&layers.RadioTap{},
+		&layers.Dot11{
+			Type:           layers.Dot11TypeMgmtProbeReq,
+			Address1:       net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			Address2:       src,
+			Address3:       net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			SequenceNumber: seq,
+		},
+		gopacket.Payload([]byte{0x00, 0x00}),
+	)
+	if err != nil {
+		t.Fatalf("serialize: %v", err)
+	}
+	packet := gopacket.NewPacket(buf.Bytes(), layers.LayerTypeRadioTap, gopacket.Default)
+	packet.Metadata().Timestamp = at
+	return packet
+}
+
+func TestFrameDeduplicator(t *testing.T) {
+	src := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	other := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x66}
+	now := time.Now()
+
+	t.Run("Same frame on a second adapter is a duplicate", func(t *testing.T) {
+		d := NewFrameDeduplicator(100 * time.Millisecond)
+		assert.False(t, d.IsDuplicate("wlan0", buildSeqFrame(t, src, 42, now)))
+		assert.True(t, d.IsDuplicate("wlan1", buildSeqFrame(t, src, 42, now.Add(5*time.Millisecond))))
+	})
+
+	t.Run("Retransmission on the same adapter is kept", func(t *testing.T) {
+		d := NewFrameDeduplicator(100 * time.Millisecond)
+		assert.False(t, d.IsDuplicate("wlan0", buildSeqFrame(t, src, 42, now)))
+		assert.False(t, d.IsDuplicate("wlan0", buildSeqFrame(t, src, 42, now.Add(time.Millisecond))))
+	})
+
+	t.Run("Different source or sequence is not a duplicate", func(t *testing.T) {
+		d := NewFrameDeduplicator(100 * time.Millisecond)
+		assert.False(t, d.IsDuplicate("wlan0", buildSeqFrame(t, src, 42, now)))
+		assert.False(t, d.IsDuplicate("wlan1", buildSeqFrame(t, src, 43, now)))
+		assert.False(t, d.IsDuplicate("wlan1", buildSeqFrame(t, other, 42, now)))
+	})
+
+	t.Run("Sightings expire after the window", func(t *testing.T) {
+		d := NewFrameDeduplicator(100 * time.Millisecond)
+		assert.False(t, d.IsDuplicate("wlan0", buildSeqFrame(t, src, 42, now)))
+		assert.False(t, d.IsDuplicate("wlan1", buildSeqFrame(t, src, 42, now.Add(200*time.Millisecond))))
+		assert.Len(t, d.seen, 1, "expired sightings are pruned")
+	})
+}
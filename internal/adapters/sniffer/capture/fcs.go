@@ -0,0 +1,24 @@
+package capture
+
+import (
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// hasBadFCS reports whether a frame failed its frame check sequence, either as
+// flagged by the driver in RadioTap or by recomputing the CRC when the FCS is
+// included. Such frames carry corrupted addresses and create phantom devices.
+func hasBadFCS(packet gopacket.Packet) bool {
+	rt, ok := packet.Layer(layers.LayerTypeRadioTap).(*layers.RadioTap)
+	if !ok {
+		return false
+	}
+	if rt.Flags.BadFCS() {
+		return true
+	}
+	if !rt.Flags.FCS() {
+		return false
+	}
+	dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	return ok && !dot11.ChecksumValid()
+}
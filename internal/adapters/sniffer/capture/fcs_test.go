@@ -0,0 +1,67 @@
+package capture
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+func buildFCSFrame(t *testing.T, flags layers.RadioTapFlags, corrupt bool) gopacket.Packet {
+	t.Helper()
+
+	dot11 := &layers.Dot11{
+		Type:     layers.Dot11TypeMgmtProbeReq,
+		Address1: net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Address2: net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55},
+		Address3: net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+	frameBuf := gopacket.NewSerializeBuffer()
+	if err := gopacket.SerializeLayers(frameBuf, gopacket.SerializeOptions{}, dot11, gopacket.Payload([]byte{0x00, 0x00})); err != nil {
+		t.Fatalf("serialize dot11: %v", err)
+	}
+	frame := frameBuf.Bytes()
+
+	rtBuf := gopacket.NewSerializeBuffer()
+	rt := &layers.RadioTap{Present: layers.RadioTapPresentFlags, Flags: flags}
+	if err := gopacket.SerializeLayers(rtBuf, gopacket.SerializeOptions{FixLengths: true}, rt); err != nil {
+		t.Fatalf("serialize radiotap: %v", err)
+	}
+
+	data := append(append([]byte{}, rtBuf.Bytes()...), frame...)
+	if flags.FCS() {
+		fcs := make([]byte, 4)
+		binary.LittleEndian.PutUint32(fcs, crc32.ChecksumIEEE(frame))
+		if corrupt {
+			fcs[0] ^= 0xff
+		}
+		data = append(data, fcs...)
+	}
+	return gopacket.NewPacket(data, layers.LayerTypeRadioTap, gopacket.Default)
+}
+
+func TestHasBadFCS(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   layers.RadioTapFlags
+		corrupt bool
+		want    bool
+	}{
+		{"No FCS included", 0, false, false},
+		{"Valid FCS", layers.RadioTapFlagsFCS, false, false},
+		{"Corrupted FCS", layers.RadioTapFlagsFCS, true, true},
+		{"Driver flagged bad FCS", layers.RadioTapFlagsBadFCS, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packet := buildFCSFrame(t, tt.flags, tt.corrupt)
+			if got := hasBadFCS(packet); got != tt.want {
+				t.Errorf("hasBadFCS() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package capture
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// DefaultFrameHistory is how long a frame buffer keeps frames.
+	DefaultFrameHistory = 60 * time.Second
+	// DefaultFrameHistoryFrames bounds the frames a buffer holds however
+	// long the history, so busy channels cannot exhaust memory.
+	DefaultFrameHistoryFrames = 50000
+)
+
+// FrameBuffer keeps the most recent frames of an adapter in a ring, so the
+// frames of an event can be saved after it happened.
+type FrameBuffer struct {
+	mu        sync.Mutex
+	retention time.Duration
+	frames    []capturedFrame // Ring, oldest at next once full
+	next      int
+	full      bool
+}
+
+// NewFrameBuffer creates a buffer keeping the frames of the last retention,
+// at most max of them.
+func NewFrameBuffer(retention time.Duration, max int) *FrameBuffer {
+	if retention <= 0 {
+		retention = DefaultFrameHistory
+	}
+	if max <= 0 {
+		max = DefaultFrameHistoryFrames
+	}
+	return &FrameBuffer{retention: retention, frames: make([]capturedFrame, max)}
+}
+
+// Observe keeps a copy of packet, received on iface, replacing the oldest
+// frame once the buffer is full.
+func (b *FrameBuffer) Observe(iface string, linkType layers.LinkType, packet gopacket.Packet) {
+	f := capturedFrame{
+		iface:    iface,
+		linkType: linkType,
+		ci:       packet.Metadata().CaptureInfo,
+		data:     append([]byte(nil), packet.Data()...),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.frames[b.next] = f
+	b.next = (b.next + 1) % len(b.frames)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// window returns, oldest first, the frames captured between from and to
+// that are still within the retention.
+func (b *FrameBuffer) window(from, to time.Time) []capturedFrame {
+	if oldest := time.Now().Add(-b.retention); from.Before(oldest) {
+		from = oldest
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result []capturedFrame
+	n, start := b.next, 0
+	if b.full {
+		n, start = len(b.frames), b.next
+	}
+	for i := 0; i < n; i++ {
+		f := b.frames[(start+i)%len(b.frames)]
+		if f.ci.Timestamp.Before(from) || f.ci.Timestamp.After(to) {
+			continue
+		}
+		result = append(result, f)
+	}
+	return result
+}
+
+// EncodeHistory returns the frames the buffers captured between from and to
+// as pcapng, in capture order with an interface per adapter, and how many
+// there are. The data is nil without frames.
+func EncodeHistory(buffers []*FrameBuffer, from, to time.Time, comments []string) ([]byte, int, error) {
+	var frames []capturedFrame
+	for _, b := range buffers {
+		frames = append(frames, b.window(from, to)...)
+	}
+	if len(frames) == 0 {
+		return nil, 0, nil
+	}
+	sort.SliceStable(frames, func(i, j int) bool { return frames[i].ci.Timestamp.Before(frames[j].ci.Timestamp) })
+	data, err := encodeFrames(frames, comments)
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, len(frames), nil
+}
@@ -0,0 +1,62 @@
+package capture
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameBuffer(t *testing.T) {
+	src := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	now := time.Now()
+	wlan0 := NewFrameBuffer(time.Minute, 4)
+	wlan1 := NewFrameBuffer(time.Minute, 4)
+
+	data, n, err := EncodeHistory([]*FrameBuffer{wlan0, wlan1}, now.Add(-time.Minute), now, nil)
+	require.NoError(t, err)
+	assert.Nil(t, data, "empty buffers")
+	assert.Zero(t, n)
+
+	// Six frames over six seconds on wlan0: the two oldest are overwritten
+	for i := 0; i < 6; i++ {
+		packet := buildSeqFrame(t, src, uint16(i), now.Add(time.Duration(i-6)*time.Second))
+		packet.Metadata().CaptureLength = len(packet.Data())
+		packet.Metadata().Length = len(packet.Data())
+		wlan0.Observe("wlan0", layers.LinkTypeIEEE80211Radio, packet)
+	}
+	packet := buildSeqFrame(t, src, 9, now.Add(-4500*time.Millisecond))
+	packet.Metadata().CaptureLength = len(packet.Data())
+	packet.Metadata().Length = len(packet.Data())
+	wlan1.Observe("wlan1", layers.LinkTypeIEEE80211Radio, packet)
+
+	frames := wlan0.window(now.Add(-time.Minute), now)
+	require.Len(t, frames, 4)
+	assert.True(t, frames[0].ci.Timestamp.Before(frames[3].ci.Timestamp), "oldest first")
+	assert.Len(t, wlan0.window(now.Add(-2500*time.Millisecond), now), 2)
+	assert.Empty(t, wlan0.window(now.Add(-2*time.Hour), now.Add(-time.Hour)), "beyond the retention")
+
+	data, n, err = EncodeHistory([]*FrameBuffer{wlan0, wlan1}, now.Add(-time.Minute), now, []string{"alert: test"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	reader, err := pcapgo.NewNgReader(bytes.NewReader(data), pcapgo.DefaultNgReaderOptions)
+	require.NoError(t, err)
+	var last time.Time
+	read := 0
+	for {
+		_, ci, err := reader.ReadPacketData()
+		if err != nil {
+			break
+		}
+		assert.False(t, ci.Timestamp.Before(last), "frames of both adapters in capture order")
+		last = ci.Timestamp
+		read++
+	}
+	assert.Equal(t, 5, read)
+	assert.Equal(t, 2, reader.NInterfaces())
+}
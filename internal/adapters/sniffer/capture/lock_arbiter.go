@@ -0,0 +1,296 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// lockLease is one holder of the interface's channel lock. Holders on the same
+// channel share the lock; the hopper resumes when the last one is released.
+type lockLease struct {
+	id     uint64
+	req    domain.LockRequest
+	legacy bool                    // Acquired via Lock, released via Unlock
+	ctx    context.Context         // Holder's context (nil for legacy leases)
+	cancel context.CancelCauseFunc // Preempts the holder (nil for legacy leases)
+}
+
+// Lock stops channel hopping and sets a specific channel for the interface.
+// Unlike ExecuteWithLock it never waits: it fails if the interface is busy on
+// another channel with an equal or higher priority holder.
+func (s *Sniffer) Lock(ctx context.Context, iface string, channel int) error {
+	if s.Config.Interface != iface {
+		return channelSetter(iface, channel)
+	}
+
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+
+	req := domain.LockRequest{Holder: "lock", Priority: domain.LockPriorityAttack, Channel: channel, Since: time.Now()}
+	_, ok, err := s.acquireLocked(ctx, req, true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%w: locked on channel %d (ref count: %d)", domain.ErrInterfaceBusy, s.lockChannel, s.lockCount)
+	}
+	if s.lockCount > 1 {
+		log.Printf("[SNIFFER] Lock ref count incremented (count=%d) for channel %d", s.lockCount, channel)
+	}
+	return nil
+}
+
+// Unlock releases the most recent lock taken with Lock, resuming channel
+// hopping when no holder remains.
+func (s *Sniffer) Unlock(ctx context.Context, iface string) error {
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+
+	if s.Config.Interface != iface || !s.hopperPaused {
+		return nil
+	}
+
+	for i := len(s.leases) - 1; i >= 0; i-- {
+		if s.leases[i].legacy {
+			s.releaseLocked(s.leases[i])
+			break
+		}
+	}
+	if s.lockCount > 0 {
+		log.Printf("[SNIFFER] Unlock called (remaining ref count: %d)", s.lockCount)
+	}
+	return nil
+}
+
+// ExecuteWithLock runs an action while holding a channel lock at attack
+// priority, waiting in the queue while the interface is busy.
+func (s *Sniffer) ExecuteWithLock(ctx context.Context, iface string, channel int, action func() error) error {
+	return s.ExecuteWithPriority(ctx, iface, channel, domain.LockPriorityAttack, "", func(context.Context) error {
+		return action()
+	})
+}
+
+// ExecuteWithPriority runs action while holding the channel lock. If the
+// interface is locked on another channel, holders of lower priority are
+// preempted; otherwise the request waits in the (visible) queue until the
+// interface frees up or ctx is done.
+func (s *Sniffer) ExecuteWithPriority(ctx context.Context, iface string, channel int, priority domain.LockPriority, holder string, action func(ctx context.Context) error) error {
+	if s.Config.Interface != iface {
+		if err := channelSetter(iface, channel); err != nil {
+			return err
+		}
+		return action(ctx)
+	}
+
+	req := &domain.LockRequest{Holder: holder, Priority: priority, Channel: channel, Since: time.Now()}
+	var lease *lockLease
+	queued := false
+	for lease == nil {
+		s.lockMu.Lock()
+		l, ok, err := s.acquireLocked(ctx, *req, false)
+		if err != nil || ok {
+			s.removeWaiterLocked(req)
+			s.lockMu.Unlock()
+			if err != nil {
+				return err
+			}
+			lease = l
+			break
+		}
+		if !queued {
+			s.waiters = append(s.waiters, req)
+			queued = true
+			log.Printf("[SNIFFER] %s (%s) queued for channel %d on %s", holder, priority, channel, iface)
+		}
+		changed := s.changedChanLocked()
+		s.lockMu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			s.lockMu.Lock()
+			s.removeWaiterLocked(req)
+			s.lockMu.Unlock()
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+
+	leaseCtx := lease.ctx
+	err := action(leaseCtx)
+
+	s.lockMu.Lock()
+	s.releaseLocked(lease)
+	s.lockMu.Unlock()
+	lease.cancel(nil)
+
+	if cause := context.Cause(leaseCtx); errors.Is(cause, domain.ErrLockPreempted) {
+		return cause
+	}
+	return err
+}
+
+// RunLocked runs action under the channel lock, with priority arbitration when
+// the locker supports it. It returns ctx.Err() if ctx ends while queued and
+// an error wrapping domain.ErrLockPreempted if the lock was taken over.
+func RunLocked(ctx context.Context, locker ChannelLocker, iface string, channel int, priority domain.LockPriority, holder string, action func(ctx context.Context) error) error {
+	if pl, ok := locker.(PriorityLocker); ok {
+		return pl.ExecuteWithPriority(ctx, iface, channel, priority, holder, action)
+	}
+	return locker.ExecuteWithLock(ctx, iface, channel, func() error {
+		return action(ctx)
+	})
+}
+
+// acquireLocked tries to take the lock for req. It reports ok=false when the
+// caller must wait. Caller holds s.lockMu.
+func (s *Sniffer) acquireLocked(ctx context.Context, req domain.LockRequest, legacy bool) (*lockLease, bool, error) {
+	switch {
+	case !s.hopperPaused:
+		if s.higherWaiterLocked(req) {
+			return nil, false, nil
+		}
+		if err := s.pinChannelLocked(req.Channel); err != nil {
+			return nil, false, err
+		}
+	case s.lockChannel != req.Channel:
+		if req.Priority <= s.maxLeasePriorityLocked() {
+			return nil, false, nil
+		}
+		s.preemptLocked(req)
+		if err := channelSetter(s.Config.Interface, req.Channel); err != nil {
+			s.resumeHopperLocked()
+			return nil, false, err
+		}
+		s.lockChannel = req.Channel
+	}
+
+	s.leaseSeq++
+	lease := &lockLease{id: s.leaseSeq, req: req, legacy: legacy}
+	if !legacy {
+		lease.bind(ctx)
+	}
+	s.leases = append(s.leases, lease)
+	s.lockCount = len(s.leases)
+	return lease, true, nil
+}
+
+// pinChannelLocked stops the hopper and sets the channel.
+func (s *Sniffer) pinChannelLocked(channel int) error {
+	hopper := s.Hopper()
+	if hopper != nil {
+		log.Printf("[SNIFFER] Pausing hopper on %s to lock channel %d", s.Config.Interface, channel)
+		hopper.Stop()
+	}
+
+	if err := channelSetter(s.Config.Interface, channel); err != nil {
+		if hopper != nil {
+			// Hopper was stopped, need to recreate it to restart
+			s.restartHopperLocked()
+		}
+		return err
+	}
+
+	s.hopperPaused = true
+	s.lockChannel = channel
+	return nil
+}
+
+// releaseLocked drops a lease (no-op if it was already preempted).
+func (s *Sniffer) releaseLocked(lease *lockLease) {
+	for i, l := range s.leases {
+		if l == lease {
+			s.leases = append(s.leases[:i], s.leases[i+1:]...)
+			break
+		}
+	}
+	s.lockCount = len(s.leases)
+	if s.lockCount == 0 && s.hopperPaused {
+		s.resumeHopperLocked()
+	}
+	s.notifyLocked()
+}
+
+// preemptLocked cancels every current holder in favour of req.
+func (s *Sniffer) preemptLocked(req domain.LockRequest) {
+	for _, l := range s.leases {
+		log.Printf("[SNIFFER] %s (%s) preempted on %s by %s (%s)", l.req.Holder, l.req.Priority, s.Config.Interface, req.Holder, req.Priority)
+		if l.cancel != nil {
+			l.cancel(fmt.Errorf("%w: %s (%s) on channel %d", domain.ErrLockPreempted, req.Holder, req.Priority, req.Channel))
+		}
+	}
+	s.leases = nil
+	s.lockCount = 0
+}
+
+// resumeHopperLocked restarts channel hopping once the interface is free.
+func (s *Sniffer) resumeHopperLocked() {
+	log.Printf("[SNIFFER] Unlock releasing interface %s (resuming hopper)", s.Config.Interface)
+	if len(s.Config.Channels) > 0 {
+		s.restartHopperLocked()
+	}
+	s.hopperPaused = false
+	s.lockChannel = 0
+}
+
+func (s *Sniffer) restartHopperLocked() {
+	hopper := s.newHopper(s.Config.Channels)
+	s.hopper.Store(hopper)
+	go hopper.Start()
+}
+
+func (s *Sniffer) maxLeasePriorityLocked() domain.LockPriority {
+	max := domain.LockPriorityHopper
+	for _, l := range s.leases {
+		if l.req.Priority > max {
+			max = l.req.Priority
+		}
+	}
+	return max
+}
+
+// higherWaiterLocked reports whether a queued request outranks req, so a
+// freed interface goes to the most important waiter first.
+func (s *Sniffer) higherWaiterLocked(req domain.LockRequest) bool {
+	for _, w := range s.waiters {
+		if w.Priority > req.Priority {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sniffer) removeWaiterLocked(req *domain.LockRequest) {
+	for i, w := range s.waiters {
+		if w == req {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Sniffer) changedChanLocked() chan struct{} {
+	if s.lockChanged == nil {
+		s.lockChanged = make(chan struct{})
+	}
+	return s.lockChanged
+}
+
+// notifyLocked wakes every waiter so they retry.
+func (s *Sniffer) notifyLocked() {
+	if s.lockChanged != nil {
+		close(s.lockChanged)
+		s.lockChanged = nil
+	}
+}
+
+// bind derives the lease context; cancelling it preempts the holder.
+func (l *lockLease) bind(parent context.Context) {
+	ctx, cancel := context.WithCancelCause(parent)
+	l.ctx = ctx
+	l.cancel = cancel
+}
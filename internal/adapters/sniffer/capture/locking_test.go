@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/hopping"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +28,7 @@ func TestSniffer_Locking_ReferenceCounting(t *testing.T) {
 		},
 	}
 	// Init hopper
-	s.Hopper = hopping.NewHopper("wlan0", []int{1, 6, 11}, 100*time.Millisecond, nil)
+	s.SetHopper(hopping.NewHopper("wlan0", []int{1, 6, 11}, 100*time.Millisecond, nil))
 
 	// 1. Initial State
 	assert.Equal(t, 0, s.lockCount)
@@ -90,3 +91,179 @@ func TestSniffer_ExecuteWithLock(t *testing.T) {
 	assert.True(t, executed)
 	assert.Equal(t, 0, s.lockCount)
 }
+
+func TestSniffer_ManualLock(t *testing.T) {
+	originalSetter := *SetChannelSetter
+	defer func() { *SetChannelSetter = originalSetter }()
+	*SetChannelSetter = func(iface string, channel int) error {
+		return nil
+	}
+
+	s := &Sniffer{
+		Config: SnifferConfig{Interface: "wlan0"},
+	}
+	ctx := context.Background()
+
+	t.Run("Expires automatically", func(t *testing.T) {
+		state, err := s.LockFor(ctx, 6, 50*time.Millisecond)
+		assert.NoError(t, err)
+		assert.True(t, state.Locked)
+		assert.True(t, state.Manual)
+		assert.Equal(t, 6, state.Channel)
+		assert.NotNil(t, state.ExpiresAt)
+
+		assert.Eventually(t, func() bool { return !s.LockState().Locked }, time.Second, 10*time.Millisecond)
+		assert.False(t, s.LockState().Manual)
+	})
+
+	t.Run("Relocking replaces the previous manual lock", func(t *testing.T) {
+		_, err := s.LockFor(ctx, 6, time.Minute)
+		assert.NoError(t, err)
+		state, err := s.LockFor(ctx, 11, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 11, state.Channel)
+		assert.Equal(t, 1, state.Holders)
+
+		assert.True(t, s.ReleaseManualLock(ctx))
+		assert.False(t, s.LockState().Locked)
+		assert.False(t, s.ReleaseManualLock(ctx))
+	})
+
+	t.Run("Does not release locks held by other operations", func(t *testing.T) {
+		assert.NoError(t, s.Lock(ctx, "wlan0", 1))
+		_, err := s.LockFor(ctx, 1, 20*time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, s.LockState().Holders)
+
+		assert.Eventually(t, func() bool { return !s.LockState().Manual }, time.Second, 10*time.Millisecond)
+		assert.True(t, s.LockState().Locked, "attack lock still held")
+
+		_, err = s.LockFor(ctx, 6, time.Minute)
+		assert.Error(t, err, "busy on another channel")
+		assert.NoError(t, s.Unlock(ctx, "wlan0"))
+	})
+}
+
+func TestSniffer_PriorityArbitration(t *testing.T) {
+	originalSetter := *SetChannelSetter
+	defer func() { *SetChannelSetter = originalSetter }()
+	*SetChannelSetter = func(iface string, channel int) error {
+		return nil
+	}
+
+	s := &Sniffer{
+		Config: SnifferConfig{Interface: "wlan0"},
+	}
+	ctx := context.Background()
+
+	t.Run("Higher priority preempts the holder", func(t *testing.T) {
+		started := make(chan struct{})
+		done := make(chan error, 1)
+		go func() {
+			done <- s.ExecuteWithPriority(ctx, "wlan0", 6, domain.LockPriorityManual, "low", func(lockCtx context.Context) error {
+				close(started)
+				<-lockCtx.Done()
+				assert.ErrorIs(t, context.Cause(lockCtx), domain.ErrLockPreempted)
+				return lockCtx.Err()
+			})
+		}()
+		<-started
+
+		err := s.ExecuteWithPriority(ctx, "wlan0", 11, domain.LockPriorityHandshake, "high", func(lockCtx context.Context) error {
+			state := s.LockState()
+			assert.Equal(t, 11, state.Channel)
+			assert.Len(t, state.Owners, 1)
+			assert.Equal(t, "high", state.Owners[0].Holder)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.ErrorIs(t, <-done, domain.ErrLockPreempted)
+		assert.False(t, s.LockState().Locked)
+	})
+
+	t.Run("Equal priority waits in the queue", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		go func() {
+			_ = s.ExecuteWithPriority(ctx, "wlan0", 1, domain.LockPriorityAttack, "first", func(lockCtx context.Context) error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+
+		ran := make(chan struct{})
+		go func() {
+			_ = s.ExecuteWithPriority(ctx, "wlan0", 6, domain.LockPriorityAttack, "second", func(lockCtx context.Context) error {
+				close(ran)
+				return nil
+			})
+		}()
+
+		assert.Eventually(t, func() bool {
+			q := s.LockState().Queue
+			return len(q) == 1 && q[0].Holder == "second"
+		}, time.Second, 5*time.Millisecond)
+
+		close(release)
+		select {
+		case <-ran:
+		case <-time.After(time.Second):
+			t.Fatal("queued request never ran")
+		}
+		assert.Eventually(t, func() bool { return !s.LockState().Locked }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("Cancelled waiter leaves the queue", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		go func() {
+			_ = s.ExecuteWithPriority(ctx, "wlan0", 1, domain.LockPriorityHandshake, "holder", func(lockCtx context.Context) error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+		defer close(release)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 30*time.Millisecond)
+		defer cancel()
+		err := s.ExecuteWithPriority(waitCtx, "wlan0", 6, domain.LockPriorityAttack, "waiter", func(lockCtx context.Context) error {
+			t.Error("action should not run")
+			return nil
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Empty(t, s.LockState().Queue)
+	})
+}
+
+func TestSniffer_HopperSwapIsRaceFree(t *testing.T) {
+	originalSetter := *SetChannelSetter
+	defer func() { *SetChannelSetter = originalSetter }()
+	*SetChannelSetter = func(iface string, channel int) error { return nil }
+
+	s := &Sniffer{Config: SnifferConfig{Interface: "wlan0", Channels: []int{1, 6, 11}, DwellTime: 100}}
+	s.SetHopper(hopping.NewHopper("wlan0", []int{1, 6, 11}, 100*time.Millisecond, nil))
+	defer s.Close()
+
+	// The capture loop reads the hopper while locks stop and restart it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			if hopper := s.Hopper(); hopper != nil {
+				hopper.ObserveFrame(time.Millisecond)
+			}
+			s.PauseHopper(time.Millisecond)
+			_ = s.GetChannels()
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, s.Lock(context.Background(), "wlan0", 6))
+		assert.NoError(t, s.Unlock(context.Background(), "wlan0"))
+	}
+	<-done
+	assert.NotNil(t, s.Hopper(), "hopping resumes after the last unlock")
+}
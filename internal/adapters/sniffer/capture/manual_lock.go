@@ -0,0 +1,106 @@
+package capture
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// LockFor pins the interface to a channel for the given duration on behalf of
+// an analyst. A new manual lock replaces the previous one; the hopper resumes
+// automatically when it expires (unless another operation still holds the lock).
+// Manual locks rank below attacks and handshake capture, which preempt them.
+func (s *Sniffer) LockFor(ctx context.Context, channel int, duration time.Duration) (domain.ChannelLockState, error) {
+	s.ReleaseManualLock(ctx)
+
+	s.lockMu.Lock()
+	req := domain.LockRequest{Holder: "manual", Priority: domain.LockPriorityManual, Channel: channel, Since: time.Now()}
+	lease, ok, err := s.acquireLocked(context.Background(), req, false)
+	if err == nil && !ok {
+		err = fmt.Errorf("%w: locked on channel %d by a higher-priority operation", domain.ErrInterfaceBusy, s.lockChannel)
+	}
+	if err != nil {
+		s.lockMu.Unlock()
+		return s.LockState(), err
+	}
+
+	s.manualLease = lease
+	s.manualExpires = time.Now().Add(duration)
+	s.manualTimer = time.AfterFunc(duration, func() {
+		if s.releaseManualLock(lease) {
+			log.Printf("[SNIFFER] Manual lock on %s expired", s.Config.Interface)
+		}
+	})
+	s.lockMu.Unlock()
+
+	log.Printf("[SNIFFER] Manual lock on %s: channel %d for %v", s.Config.Interface, channel, duration)
+	return s.LockState(), nil
+}
+
+// ReleaseManualLock drops the analyst's lock early. It reports whether one was held.
+func (s *Sniffer) ReleaseManualLock(ctx context.Context) bool {
+	s.lockMu.Lock()
+	lease := s.manualLease
+	s.lockMu.Unlock()
+	if lease == nil {
+		return false
+	}
+	return s.releaseManualLock(lease)
+}
+
+// releaseManualLock releases lease if it is still the current manual lock, so
+// a stale expiry timer cannot release a newer one.
+func (s *Sniffer) releaseManualLock(lease *lockLease) bool {
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+
+	if s.manualLease != lease {
+		return false
+	}
+	if s.manualTimer != nil {
+		s.manualTimer.Stop()
+		s.manualTimer = nil
+	}
+	s.manualLease = nil
+	held := s.holdsLocked(lease)
+	s.releaseLocked(lease)
+	lease.cancel(nil)
+	return held
+}
+
+// holdsLocked reports whether lease is still active (not released or preempted).
+func (s *Sniffer) holdsLocked(lease *lockLease) bool {
+	for _, l := range s.leases {
+		if l == lease {
+			return true
+		}
+	}
+	return false
+}
+
+// LockState reports the current channel lock of the interface.
+func (s *Sniffer) LockState() domain.ChannelLockState {
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+
+	state := domain.ChannelLockState{
+		Locked:  s.hopperPaused,
+		Channel: s.lockChannel,
+		Holders: s.lockCount,
+		Manual:  s.manualLease != nil && s.holdsLocked(s.manualLease),
+	}
+	if state.Manual {
+		expires := s.manualExpires
+		state.ExpiresAt = &expires
+	}
+	for _, l := range s.leases {
+		state.Owners = append(state.Owners, l.req)
+	}
+	for _, w := range s.waiters {
+		state.Queue = append(state.Queue, *w)
+	}
+	return state
+}
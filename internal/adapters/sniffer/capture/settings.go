@@ -0,0 +1,93 @@
+package capture
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/gopacket/pcap"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// defaultBPFFilter excludes control frames (ACK/RTS/CTS) but keeps all
+// management (deauth/assoc) and data frames.
+const defaultBPFFilter = "type mgt or type data"
+
+// Settings returns the capture options in effect.
+func (s *Sniffer) Settings() domain.CaptureSettings {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	return domain.CaptureSettings{
+		DwellMs:    int(s.baseDwell().Milliseconds()),
+		BPFFilter:  s.filterLocked(),
+		DropBadFCS: s.dropBadFCS.Load(),
+	}
+}
+
+// SetDwellTime changes the base dwell time of the hopper, auto-tuned or not.
+func (s *Sniffer) SetDwellTime(ms int) {
+	s.dwellMs.Store(int64(ms))
+	dwell := s.baseDwell()
+	if s.dwell != nil {
+		s.dwell.SetBase(dwell)
+	}
+	if hopper := s.Hopper(); hopper != nil {
+		hopper.SetDelay(dwell)
+	}
+}
+
+// SetBPFFilter changes the capture filter, applying it to the open capture
+// if there is one. An empty filter restores the default.
+func (s *Sniffer) SetBPFFilter(filter string) error {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+
+	prev := s.bpfFilter
+	s.bpfFilter = filter
+	if s.filtered == nil {
+		return nil
+	}
+	if err := s.filtered.SetBPFFilter(s.filterLocked()); err != nil {
+		s.bpfFilter = prev
+		return fmt.Errorf("invalid capture filter %q: %w", filter, err)
+	}
+	return nil
+}
+
+// SetDropBadFCS sets whether frames failing the FCS check are discarded.
+func (s *Sniffer) SetDropBadFCS(drop bool) {
+	s.dropBadFCS.Store(drop)
+}
+
+// attachFilter applies the capture filter to handle and keeps it for later
+// changes. A nil handle detaches the closed capture.
+func (s *Sniffer) attachFilter(handle *pcap.Handle) error {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	s.filtered = handle
+	if handle == nil {
+		return nil
+	}
+	return handle.SetBPFFilter(s.filterLocked())
+}
+
+func (s *Sniffer) filterLocked() string {
+	if s.bpfFilter == "" {
+		return defaultBPFFilter
+	}
+	return s.bpfFilter
+}
+
+// baseDwell returns the configured dwell time the auto-tuning starts from.
+func (s *Sniffer) baseDwell() time.Duration {
+	dwell := time.Duration(s.dwellMs.Load()) * time.Millisecond
+	if dwell == 0 {
+		dwell = 300 * time.Millisecond
+	}
+	return dwell
+}
+
+// SetReactiveHopping sets whether handshakes hold the hopper on their
+// channel to catch the rest of the exchange.
+func (s *Sniffer) SetReactiveHopping(enabled bool) {
+	s.noReactive.Store(!enabled)
+}
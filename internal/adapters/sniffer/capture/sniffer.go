@@ -5,20 +5,20 @@ import (
 	"fmt"
 	"log"
 	"net"
-	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/pcap"
-	"github.com/google/gopacket/pcapgo"
 	"github.com/lcalzada-xor/wmap/internal/adapters/fingerprint"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/driver"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/handshake"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/hopping"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/parser"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/pcapng"
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/geo"
 	"github.com/lcalzada-xor/wmap/internal/telemetry"
@@ -30,14 +30,25 @@ var channelSetter = driver.SetInterfaceChannel
 // SnifferConfig holds configuration for the Sniffer.
 type SnifferConfig struct {
 	Interface string
-	PcapPath  string
 	Debug     bool
 	// Channels is the list of channels to hop on. If empty, hopper is disabled or default is used?
 	// Plan says we pass specific channels.
-	Channels  []int
-	DwellTime int // milliseconds
+	Channels   []int
+	DwellTime  int    // milliseconds
+	DropBadFCS bool   // Discard frames failing the FCS check instead of only counting them
+	Passive    bool   // Never open an injector on the interface
+	BPFFilter  string // Capture filter; empty for management and data frames
+	// Handshakes do not hold the hopper on their channel (power save)
+	NoReactiveHopping bool
+	// Memory footprint on small boards; zero values use the defaults
+	QueueSize int // Packets waiting for a worker, DefaultQueueSize if 0
+	Workers   int // Packet processing workers, one per CPU (at least 2) if 0
 }
 
+// DefaultQueueSize is the number of captured packets buffered for the
+// workers, enough to absorb bursts on busy channels.
+const DefaultQueueSize = 5000
+
 // ChannelLocker overrides the channel hopper to lock on a specific channel.
 type ChannelLocker interface {
 	Lock(ctx context.Context, iface string, channel int) error
@@ -45,6 +56,13 @@ type ChannelLocker interface {
 	ExecuteWithLock(ctx context.Context, iface string, channel int, action func() error) error
 }
 
+// PriorityLocker arbitrates contending channel locks by priority. The action's
+// context is cancelled with domain.ErrLockPreempted if a higher-priority
+// operation takes the interface to another channel.
+type PriorityLocker interface {
+	ExecuteWithPriority(ctx context.Context, iface string, channel int, priority domain.LockPriority, holder string, action func(ctx context.Context) error) error
+}
+
 // Sniffer handles packet capture and parsing.
 type Sniffer struct {
 	Config     SnifferConfig
@@ -52,11 +70,22 @@ type Sniffer struct {
 	Alerts     chan<- domain.Alert
 	handler    *parser.PacketHandler
 	Injector   *injection.Injector
-	Hopper     *hopping.ChannelHopper
+	hopper     atomic.Pointer[hopping.ChannelHopper] // Replaced under lockMu, loaded per frame
 	VendorRepo fingerprint.VendorRepository
-	pcapWriter *pcapgo.Writer
-	pcapFile   *os.File
-	handle     *pcap.Handle // Expose handle to get stats
+	Dedup      *FrameDeduplicator       // Shared across adapters on the same host; nil with a single adapter
+	Recorder   *pcapng.Writer           // Session recording shared across adapters; nil when disabled
+	Targets    *TargetRecorder          // Per-device recordings shared across adapters; nil when disabled
+	History    *FrameBuffer             // Recent frames of this adapter; nil when disabled
+	handle     *pcap.Handle             // Expose handle to get stats
+	dwell      *hopping.DwellController // Shared by successive hoppers so tuning survives restarts
+
+	// Settings changed at runtime (see settings.go)
+	settingsMu sync.Mutex
+	dwellMs    atomic.Int64
+	dropBadFCS atomic.Bool
+	noReactive atomic.Bool
+	bpfFilter  string
+	filtered   *pcap.Handle // Open capture handle filter changes apply to
 
 	// Capability caching
 	capabilitiesCache *domain.InterfaceCapabilities
@@ -66,18 +95,31 @@ type Sniffer struct {
 	metrics   domain.InterfaceMetrics
 	metricsMu sync.RWMutex
 
-	// Locking state
+	// Locking state (see lock_arbiter.go)
 	hopperPaused bool
 	lockMu       sync.Mutex
 	lockCount    int // Reference counting for channel locking
 	lockChannel  int // The channel currently locked
+	leases       []*lockLease
+	waiters      []*domain.LockRequest
+	lockChanged  chan struct{} // Closed and replaced whenever a lease is released
+	leaseSeq     uint64
+
+	// Manual (analyst) lock, one of the leases above
+	manualLease   *lockLease
+	manualExpires time.Time
+	manualTimer   *time.Timer
 }
 
 // New creates a new Sniffer instance.
 func New(config SnifferConfig, out chan<- domain.Device, alerts chan<- domain.Alert, loc geo.Provider, hm *handshake.HandshakeManager, repo fingerprint.VendorRepository) *Sniffer {
-	inj, err := injection.NewInjector(config.Interface)
-	if err != nil {
-		log.Printf("Warning: Failed to initialize injector: %v", err)
+	var inj *injection.Injector
+	if !config.Passive {
+		var err error
+		inj, err = injection.NewInjector(config.Interface)
+		if err != nil {
+			log.Printf("Warning: Failed to initialize injector: %v", err)
+		}
 	}
 
 	s := &Sniffer{
@@ -86,18 +128,24 @@ func New(config SnifferConfig, out chan<- domain.Device, alerts chan<- domain.Al
 		Alerts:     alerts,
 		Injector:   inj,
 		VendorRepo: repo,
+		bpfFilter:  config.BPFFilter,
 	}
+	s.dwellMs.Store(int64(config.DwellTime))
+	s.dropBadFCS.Store(config.DropBadFCS)
+	s.noReactive.Store(config.NoReactiveHopping)
 
 	// Create handler with pause callback
 	s.handler = parser.NewPacketHandler(loc, config.Debug, hm, repo, s.PauseHopper)
 
+	var pending hopping.PendingHandshakesFunc
+	if hm != nil {
+		pending = hm.PendingByChannel
+	}
+	s.dwell = hopping.NewDwellController(s.baseDwell(), pending)
+
 	// Initialize Hopper if channels are provided
 	if len(config.Channels) > 0 {
-		dwell := time.Duration(config.DwellTime) * time.Millisecond
-		if dwell == 0 {
-			dwell = 300 * time.Millisecond
-		}
-		s.Hopper = hopping.NewHopper(config.Interface, config.Channels, dwell, nil)
+		s.hopper.Store(s.newHopper(config.Channels))
 	}
 
 	return s
@@ -106,8 +154,8 @@ func New(config SnifferConfig, out chan<- domain.Device, alerts chan<- domain.Al
 // Close stops the sniffer and releases resources.
 func (s *Sniffer) Close() {
 	// Stop Hopper
-	if s.Hopper != nil {
-		s.Hopper.Stop()
+	if hopper := s.Hopper(); hopper != nil {
+		hopper.Stop()
 	}
 
 	// Close Injector
@@ -145,44 +193,40 @@ func (s *Sniffer) Start(ctx context.Context) error {
 
 	// Set filter
 	// Optimization: Exclude Control Frames (ACK/RTS/CTS) but allow ALL Mgmt (Deauth/Assoc) and Data
-	if err := handle.SetBPFFilter("type mgt or type data"); err != nil {
+	if err := s.attachFilter(handle); err != nil {
 		return err
 	}
+	defer s.attachFilter(nil)
 
-	// Initialize PCAP Writer if path is set
-	if s.Config.PcapPath != "" {
-		f, err := os.Create(s.Config.PcapPath)
+	// Record to the session capture as an interface of its own
+	recorderIf := -1
+	if s.Recorder != nil {
+		id, err := s.Recorder.AddInterface(pcapng.Interface{Name: s.Config.Interface, LinkType: handle.LinkType(), SnapLen: 2500})
 		if err != nil {
-			log.Printf("Failed to create PCAP file: %v", err)
+			log.Printf("Failed to add %s to the capture recording: %v", s.Config.Interface, err)
 		} else {
-			s.pcapFile = f
-			s.pcapWriter = pcapgo.NewWriter(f)
-			// Write file header with correct LinkType
-			if err := s.pcapWriter.WriteFileHeader(65536, handle.LinkType()); err != nil {
-				log.Printf("Failed to write PCAP header: %v", err)
-			}
-			log.Printf("Packet capture enabled. Saving to %s", s.Config.PcapPath)
+			recorderIf = id
 		}
 	}
 
-	defer func() {
-		if s.pcapFile != nil {
-			s.pcapFile.Close()
-		}
-	}()
-
 	log.Printf("Starting Enterprise Sniffer on %s...", s.Config.Interface)
 
 	// Optimization: Direct loop without intermediate channel
 	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
 
 	// Worker Pool setup
-	numWorkers := runtime.NumCPU()
-	if numWorkers < 2 {
-		numWorkers = 2
+	numWorkers := s.Config.Workers
+	if numWorkers <= 0 {
+		numWorkers = runtime.NumCPU()
+		if numWorkers < 2 {
+			numWorkers = 2
+		}
+	}
+	queueSize := s.Config.QueueSize
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
 	}
-	// Optimization: Queue Size 1000 -> 5000 to absorb bursts
-	packetChan := make(chan gopacket.Packet, 5000)
+	packetChan := make(chan gopacket.Packet, queueSize)
 	var wg sync.WaitGroup
 
 	log.Printf("Starting %d packet processing workers", numWorkers)
@@ -224,14 +268,46 @@ func (s *Sniffer) Start(ctx context.Context) error {
 			return nil
 		}
 
-		// Save to PCAP synchronously to preserve order
-		if s.pcapWriter != nil {
-			_ = s.pcapWriter.WritePacket(packet.Metadata().CaptureInfo, packet.Data())
+		// Save to the recording synchronously to preserve order
+		if recorderIf >= 0 {
+			_ = s.Recorder.WritePacket(recorderIf, packet.Metadata().CaptureInfo, packet.Data(), s.recordOptions())
+		}
+		if s.Targets != nil {
+			s.Targets.Observe(s.Config.Interface, handle.LinkType(), packet)
+		}
+		if s.History != nil {
+			s.History.Observe(s.Config.Interface, handle.LinkType(), packet)
 		}
 
 		// Metric: Packets Captured
 		telemetry.PacketsCaptured.WithLabelValues(s.Config.Interface).Inc()
 
+		// Corrupted frames (bad FCS) are kept in the PCAP but never reach the parser
+		// when filtering is enabled
+		if hasBadFCS(packet) {
+			s.metricsMu.Lock()
+			s.metrics.BadFCSFrames++
+			s.metricsMu.Unlock()
+			if s.dropBadFCS.Load() {
+				telemetry.PacketsDropped.WithLabelValues(s.Config.Interface, "bad_fcs").Inc()
+				continue
+			}
+		}
+
+		// Frames already received by another adapter are not processed again
+		if s.Dedup != nil && s.Dedup.IsDuplicate(s.Config.Interface, packet) {
+			s.metricsMu.Lock()
+			s.metrics.DuplicateFrames++
+			s.metricsMu.Unlock()
+			telemetry.PacketsDropped.WithLabelValues(s.Config.Interface, "duplicate").Inc()
+			continue
+		}
+
+		// Feed dwell auto-tuning and airtime utilization
+		if hopper := s.Hopper(); hopper != nil {
+			hopper.ObserveFrame(frameAirtime(packet))
+		}
+
 		// Non-blocking send
 		select {
 		case packetChan <- packet:
@@ -265,6 +341,9 @@ func (s *Sniffer) worker(ctx context.Context, wg *sync.WaitGroup, packets <-chan
 			telemetry.PacketsProcessed.WithLabelValues(s.Config.Interface).Inc()
 
 			if device != nil {
+				if device.Sensor == "" {
+					device.Sensor = s.Config.Interface
+				}
 				select {
 				case s.Output <- *device:
 				case <-ctx.Done():
@@ -272,6 +351,9 @@ func (s *Sniffer) worker(ctx context.Context, wg *sync.WaitGroup, packets <-chan
 				}
 			}
 			if alert != nil {
+				if alert.Sensor == "" {
+					alert.Sensor = s.Config.Interface
+				}
 				select {
 				case s.Alerts <- *alert:
 				case <-ctx.Done():
@@ -282,17 +364,32 @@ func (s *Sniffer) worker(ctx context.Context, wg *sync.WaitGroup, packets <-chan
 	}
 }
 
+// Hopper returns the running channel hopper, nil when the sniffer does not hop.
+func (s *Sniffer) Hopper() *hopping.ChannelHopper {
+	return s.hopper.Load()
+}
+
+// SetHopper replaces the channel hopper without starting it.
+func (s *Sniffer) SetHopper(hopper *hopping.ChannelHopper) {
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+	s.hopper.Store(hopper)
+}
+
 // SetChannels updates the hopper's channel list.
 func (s *Sniffer) SetChannels(channels []int) {
-	if s.Hopper != nil {
-		s.Hopper.SetChannels(channels)
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+	if hopper := s.Hopper(); hopper != nil {
+		s.Config.Channels = channels // Restored when a channel lock is released
+		hopper.SetChannels(channels)
 	}
 }
 
 // GetChannels returns the current hopper channel list.
 func (s *Sniffer) GetChannels() []int {
-	if s.Hopper != nil {
-		return s.Hopper.GetChannels()
+	if hopper := s.Hopper(); hopper != nil {
+		return hopper.GetChannels()
 	}
 	return []int{}
 }
@@ -304,8 +401,8 @@ func (s *Sniffer) GetInterfaces() []string {
 
 // GetInterfaceChannels returns the channel list for a specific interface.
 func (s *Sniffer) GetInterfaceChannels(iface string) []int {
-	if s.Config.Interface == iface && s.Hopper != nil {
-		return s.Hopper.GetChannels()
+	if hopper := s.Hopper(); s.Config.Interface == iface && hopper != nil {
+		return hopper.GetChannels()
 	}
 	return []int{}
 }
@@ -341,31 +438,52 @@ func (s *Sniffer) SetInterfaceChannels(iface string, channels []int) {
 		channels = validChannels
 	}
 
+	s.lockMu.Lock()
+	defer s.lockMu.Unlock()
+	s.Config.Channels = channels // Restored when a channel lock is released
+	hopper := s.Hopper()
+
 	// Case 1: Empty channels provided -> Stop Hopper if active
 	if len(channels) == 0 {
-		if s.Hopper != nil {
+		if hopper != nil {
 			log.Printf("Stopping hopper on %s (no channels selected)", iface)
-			s.Hopper.Stop()
-			s.Hopper = nil
+			hopper.Stop()
+			s.hopper.Store(nil)
 		}
 		return
 	}
 
 	// Case 2: Hopper exists -> Update channels
-	if s.Hopper != nil {
-		s.Hopper.SetChannels(channels)
+	if hopper != nil {
+		hopper.SetChannels(channels)
 		return
 	}
 
-	// Case 3: Hopper doesn't exist but channels provided -> Start new Hopper
+	// Case 3: Hopper doesn't exist but channels provided -> Start new Hopper,
+	// unless a channel lock holds the interface and will resume it
+	if s.hopperPaused {
+		return
+	}
 	log.Printf("Starting new hopper on %s with channels: %v", iface, channels)
-	dwell := time.Duration(s.Config.DwellTime) * time.Millisecond
-	if dwell == 0 {
-		dwell = 300 * time.Millisecond
+	s.restartHopperLocked()
+}
+
+// newHopper creates a hopper on the sniffer's interface with dwell auto-tuning.
+func (s *Sniffer) newHopper(channels []int) *hopping.ChannelHopper {
+	h := hopping.NewHopper(s.Config.Interface, channels, s.baseDwell(), nil)
+	if s.dwell != nil {
+		h.SetDwellController(s.dwell)
+	}
+	return h
+}
+
+// DwellPlan returns the per-channel dwell times currently used by the hopper.
+func (s *Sniffer) DwellPlan() []domain.ChannelDwell {
+	hopper := s.Hopper()
+	if hopper == nil {
+		return []domain.ChannelDwell{}
 	}
-	s.Hopper = hopping.NewHopper(iface, channels, dwell, nil)
-	// Start in goroutine
-	go s.Hopper.Start()
+	return hopper.DwellPlan()
 }
 
 // GetInterfaceDetails returns detailed info for this sniffer's interface.
@@ -394,6 +512,7 @@ func (s *Sniffer) GetInterfaceDetails() []domain.InterfaceInfo {
 			Capabilities:    caps,
 			CurrentChannels: s.GetChannels(),
 			Metrics:         currentMetrics,
+			Lock:            s.LockState(),
 		}}
 	}
 	s.capsCacheMu.RUnlock()
@@ -408,6 +527,7 @@ func (s *Sniffer) GetInterfaceDetails() []domain.InterfaceInfo {
 			MAC:             getMAC(s.Config.Interface),
 			CurrentChannels: s.GetChannels(),
 			Metrics:         currentMetrics,
+			Lock:            s.LockState(),
 		}}
 	}
 
@@ -432,116 +552,27 @@ func (s *Sniffer) GetInterfaceDetails() []domain.InterfaceInfo {
 		Capabilities:    caps,
 		CurrentChannels: s.GetChannels(),
 		Metrics:         currentMetrics,
+		Lock:            s.LockState(),
 	}}
 }
 
-// Lock stops channel hopping and sets a specific channel for the interface.
-func (s *Sniffer) Lock(ctx context.Context, iface string, channel int) error {
-	s.lockMu.Lock()
-	defer s.lockMu.Unlock()
-
-	if s.Config.Interface != iface {
-		return channelSetter(iface, channel)
-	}
-
-	// Reference Counting Logic
-	if s.hopperPaused {
-		// Already locked.
-		if s.lockChannel == channel {
-			// Same channel, increment ref count
-			s.lockCount++
-			log.Printf("[SNIFFER] Lock ref count incremented (count=%d) for channel %d", s.lockCount, channel)
-			return nil
-		}
-		// Different channel! Busy.
-		return fmt.Errorf("interface busy: locked on channel %d (ref count: %d)", s.lockChannel, s.lockCount)
-	}
-
-	// Not locked yet. Lock it.
-	if s.Hopper != nil {
-		log.Printf("[SNIFFER] Pausing hopper on %s to lock channel %d", iface, channel)
-		s.Hopper.Stop()
-	}
-
-	if err := channelSetter(iface, channel); err != nil {
-		// Failed to set channel, rollback (resume hopper if needed) could go here
-		// But usually we want to retry or just fail.
-		// If we resume hopper here, we must be careful.
-		if s.Hopper != nil {
-			// Hopper was stopped, need to recreate it to restart
-			dwell := time.Duration(s.Config.DwellTime) * time.Millisecond
-			if dwell == 0 {
-				dwell = 300 * time.Millisecond
-			}
-			s.Hopper = hopping.NewHopper(s.Config.Interface, s.Config.Channels, dwell, nil)
-			go s.Hopper.Start()
-		}
-		return err
-	}
-
-	s.hopperPaused = true
-	s.lockChannel = channel
-	s.lockCount = 1
-
-	return nil
-}
-
-// Unlock resumes channel hopping if it was paused.
-func (s *Sniffer) Unlock(ctx context.Context, iface string) error {
-	s.lockMu.Lock()
-	defer s.lockMu.Unlock()
-
-	if s.Config.Interface != iface {
-		return nil
-	}
-
-	if !s.hopperPaused {
-		return nil
-	}
-
-	s.lockCount--
-	if s.lockCount > 0 {
-		log.Printf("[SNIFFER] Unlock called (remaining ref count: %d)", s.lockCount)
-		return nil
-	}
-
-	// Count reached 0, fully unlock
-	log.Printf("[SNIFFER] Unlock releasing interface %s (resuming hopper)", iface)
-	if len(s.Config.Channels) > 0 {
-		dwell := time.Duration(s.Config.DwellTime) * time.Millisecond
-		if dwell == 0 {
-			dwell = 300 * time.Millisecond
-		}
-		s.Hopper = hopping.NewHopper(s.Config.Interface, s.Config.Channels, dwell, nil)
-		go s.Hopper.Start()
-	}
-
-	s.hopperPaused = false
-	s.lockChannel = 0
-	return nil
-}
-
-// ExecuteWithLock runs an action while holding a channel lock
-func (s *Sniffer) ExecuteWithLock(ctx context.Context, iface string, channel int, action func() error) error {
-	if err := s.Lock(ctx, iface, channel); err != nil {
-		return err
+// recordOptions geo-tags recorded frames with the sensor's position, when known.
+func (s *Sniffer) recordOptions() pcapng.PacketOptions {
+	if s.handler == nil || s.handler.Location == nil {
+		return pcapng.PacketOptions{}
 	}
-	defer s.Unlock(ctx, iface)
-
-	// We can check context before running action
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
+	loc := s.handler.Location.GetLocation()
+	if loc.Latitude == 0 && loc.Longitude == 0 {
+		return pcapng.PacketOptions{}
 	}
-
-	return action()
+	return pcapng.PacketOptions{GPS: &pcapng.GPS{Latitude: loc.Latitude, Longitude: loc.Longitude}}
 }
 
-// PauseHopper pauses the channel hopper for a duration.
+// PauseHopper pauses the channel hopper for a duration, unless reactive
+// hopping is disabled.
 func (s *Sniffer) PauseHopper(duration time.Duration) {
-	if s.Hopper != nil {
-		s.Hopper.Pause(duration)
+	if hopper := s.Hopper(); hopper != nil && !s.noReactive.Load() {
+		hopper.Pause(duration)
 	}
 }
 
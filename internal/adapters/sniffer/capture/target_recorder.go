@@ -0,0 +1,186 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/pcapng"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// maxTargetFrames bounds the frames kept per device recording.
+const maxTargetFrames = 20000
+
+// capturedFrame is a raw frame kept with the adapter that received it.
+type capturedFrame struct {
+	iface    string
+	linkType layers.LinkType
+	ci       gopacket.CaptureInfo
+	data     []byte
+}
+
+// targetRecording collects the frames of one device until it expires.
+type targetRecording struct {
+	reason  string
+	started time.Time
+	frames  []capturedFrame
+	dropped int
+}
+
+// TargetRecorder records the frames sent or received by selected devices,
+// on every adapter, and saves each recording as a pcapng artifact when it
+// ends. It is shared by the sniffers of a manager.
+type TargetRecorder struct {
+	mu             sync.Mutex
+	targets        map[string]*targetRecording // By lowercase MAC
+	active         atomic.Int32                // Fast path: no lookup without targets
+	store          ports.ArtifactManager
+	captureContext domain.CaptureContextFunc
+}
+
+// NewTargetRecorder creates a recorder without targets.
+func NewTargetRecorder() *TargetRecorder {
+	return &TargetRecorder{targets: make(map[string]*targetRecording)}
+}
+
+// SetStore sets the artifact store recordings are saved to. Without one,
+// recordings are discarded.
+func (r *TargetRecorder) SetStore(store ports.ArtifactManager) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.store = store
+}
+
+// SetCaptureContext sets the function returning the attribution written in
+// the recordings' comments.
+func (r *TargetRecorder) SetCaptureContext(fn domain.CaptureContextFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.captureContext = fn
+}
+
+// Record starts recording the frames of mac for duration. It returns false
+// if the device is already being recorded.
+func (r *TargetRecorder) Record(mac string, duration time.Duration, reason string) bool {
+	mac = strings.ToLower(mac)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.targets[mac]; ok {
+		return false
+	}
+	r.targets[mac] = &targetRecording{reason: reason, started: time.Now()}
+	r.active.Add(1)
+	time.AfterFunc(duration, func() { r.finish(mac) })
+	return true
+}
+
+// IsRecording reports whether mac is being recorded.
+func (r *TargetRecorder) IsRecording(mac string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.targets[strings.ToLower(mac)]
+	return ok
+}
+
+// Observe keeps packet if any of its addresses is a recorded device.
+func (r *TargetRecorder) Observe(iface string, linkType layers.LinkType, packet gopacket.Packet) {
+	if r.active.Load() == 0 {
+		return
+	}
+	dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, addr := range []net.HardwareAddr{dot11.Address1, dot11.Address2, dot11.Address3, dot11.Address4} {
+		if len(addr) != 6 {
+			continue
+		}
+		rec, ok := r.targets[addr.String()]
+		if !ok {
+			continue
+		}
+		if len(rec.frames) >= maxTargetFrames {
+			rec.dropped++
+			return
+		}
+		data := append([]byte(nil), packet.Data()...)
+		rec.frames = append(rec.frames, capturedFrame{iface: iface, linkType: linkType, ci: packet.Metadata().CaptureInfo, data: data})
+		return
+	}
+}
+
+// finish ends the recording of mac and saves it.
+func (r *TargetRecorder) finish(mac string) {
+	r.mu.Lock()
+	rec, ok := r.targets[mac]
+	delete(r.targets, mac)
+	store, captureContext := r.store, r.captureContext
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	r.active.Add(-1)
+
+	if store == nil || len(rec.frames) == 0 {
+		return
+	}
+	comments := []string{
+		"device: " + mac,
+		"reason: " + rec.reason,
+		fmt.Sprintf("recorded: %s - %s", rec.started.UTC().Format(time.RFC3339), time.Now().UTC().Format(time.RFC3339)),
+	}
+	if rec.dropped > 0 {
+		comments = append(comments, fmt.Sprintf("frames dropped over the limit: %d", rec.dropped))
+	}
+	if captureContext != nil {
+		comments = append(comments, captureContext(mac).Comments()...)
+	}
+	data, err := encodeFrames(rec.frames, comments)
+	if err != nil {
+		log.Printf("Warning: could not write the recording of %s: %v", mac, err)
+		return
+	}
+	name := fmt.Sprintf("device_%s_%d.pcapng", strings.ReplaceAll(mac, ":", ""), time.Now().Unix())
+	if _, err := store.StoreArtifact(context.Background(), domain.ArtifactPcap, name, data); err != nil {
+		log.Printf("Warning: could not save the recording of %s: %v", mac, err)
+		return
+	}
+	log.Printf("Saved %d frames of %s to %s", len(rec.frames), mac, name)
+}
+
+// encodeFrames writes frames as pcapng, with an interface per adapter.
+func encodeFrames(frames []capturedFrame, comments []string) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := pcapng.NewWriter(&buf, pcapng.Section{Application: "wmap", OS: runtime.GOOS, Comments: comments})
+	if err != nil {
+		return nil, err
+	}
+	interfaces := make(map[string]int)
+	for _, f := range frames {
+		id, ok := interfaces[f.iface]
+		if !ok {
+			if id, err = w.AddInterface(pcapng.Interface{Name: f.iface, LinkType: f.linkType, SnapLen: 2500}); err != nil {
+				return nil, err
+			}
+			interfaces[f.iface] = id
+		}
+		if err := w.WritePacket(id, f.ci, f.data, pcapng.PacketOptions{}); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,80 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingStore keeps the artifacts stored by the recorder.
+type recordingStore struct {
+	ports.ArtifactManager
+	mu     sync.Mutex
+	stored map[string][]byte
+}
+
+func (s *recordingStore) StoreArtifact(ctx context.Context, kind domain.ArtifactKind, name string, data []byte) (domain.Artifact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stored[name] = data
+	return domain.Artifact{ID: "art-1", Kind: kind, Name: name}, nil
+}
+
+func (s *recordingStore) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.stored)
+}
+
+func TestTargetRecorder(t *testing.T) {
+	drone := net.HardwareAddr{0x60, 0x60, 0x1f, 0x00, 0x00, 0x01}
+	other := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	store := &recordingStore{stored: make(map[string][]byte)}
+
+	r := NewTargetRecorder()
+	r.SetStore(store)
+
+	// Nothing is kept without targets
+	r.Observe("wlan0", layers.LinkTypeIEEE80211Radio, buildSeqFrame(t, drone, 1, time.Now()))
+
+	require.True(t, r.Record("60:60:1F:00:00:01", 50*time.Millisecond, "rule dji"))
+	assert.False(t, r.Record("60:60:1f:00:00:01", time.Minute, "rule dji"), "already recording")
+	assert.True(t, r.IsRecording("60:60:1f:00:00:01"))
+
+	for i, iface := range []string{"wlan0", "wlan1", "wlan0"} {
+		packet := buildSeqFrame(t, drone, uint16(i+2), time.Now())
+		packet.Metadata().CaptureLength = len(packet.Data())
+		packet.Metadata().Length = len(packet.Data())
+		r.Observe(iface, layers.LinkTypeIEEE80211Radio, packet)
+	}
+	r.Observe("wlan0", layers.LinkTypeIEEE80211Radio, buildSeqFrame(t, other, 9, time.Now()))
+
+	require.Eventually(t, func() bool { return store.count() == 1 }, time.Second, 10*time.Millisecond)
+	assert.False(t, r.IsRecording("60:60:1f:00:00:01"))
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, data := range store.stored {
+		reader, err := pcapgo.NewNgReader(bytes.NewReader(data), pcapgo.DefaultNgReaderOptions)
+		require.NoError(t, err)
+		frames := 0
+		for {
+			if _, _, err := reader.ReadPacketData(); err != nil {
+				break
+			}
+			frames++
+		}
+		assert.Equal(t, 3, frames)
+		assert.Equal(t, 2, reader.NInterfaces())
+	}
+}
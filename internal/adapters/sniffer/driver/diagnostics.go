@@ -0,0 +1,165 @@
+package driver
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// interferingProcesses are daemons known to retune or reconfigure wireless
+// interfaces behind the sniffer's back.
+var interferingProcesses = []string{"NetworkManager", "wpa_supplicant", "dhclient", "iwd", "avahi-daemon"}
+
+// Diagnose runs the monitor-mode preflight checks for an interface.
+func Diagnose(iface string) domain.InterfaceDiagnostics {
+	return DefaultDriver.Diagnose(iface)
+}
+
+// Diagnose checks driver, monitor mode support, interfering processes,
+// regulatory domain and supported bands, returning actionable issues.
+func (d *WirelessDriver) Diagnose(iface string) domain.InterfaceDiagnostics {
+	diag := domain.InterfaceDiagnostics{
+		Interface:            iface,
+		SupportedBands:       []domain.WiFiBand{},
+		InterferingProcesses: []string{},
+		Issues:               []domain.DiagnosticIssue{},
+	}
+	addIssue := func(severity domain.DiagnosticSeverity, check, msg, remedy string) {
+		diag.Issues = append(diag.Issues, domain.DiagnosticIssue{Severity: severity, Check: check, Message: msg, Remedy: remedy})
+	}
+
+	phy, err := d.getPhyForInterface(iface)
+	if err != nil {
+		addIssue(domain.DiagnosticError, "interface", fmt.Sprintf("%s is not a wireless interface known to iw: %v", iface, err),
+			"Check the interface name with 'iw dev' and that the adapter is plugged in")
+		return diag
+	}
+	diag.Phy = phy
+
+	// Driver
+	if out, err := d.executor.Execute("readlink", "-f", "/sys/class/net/"+iface+"/device/driver"); err == nil {
+		diag.Driver = filepath.Base(strings.TrimSpace(string(out)))
+	} else {
+		addIssue(domain.DiagnosticWarning, "driver", "Could not resolve the kernel driver", "")
+	}
+
+	// Current mode
+	if out, err := d.executor.Execute("iw", "dev", iface, "info"); err == nil {
+		diag.Mode = parseIfaceType(out)
+	}
+
+	// Monitor mode support and bands. Output iw prints in a layout we cannot
+	// parse only warns: a missing section is no proof the adapter lacks it.
+	if phyInfo, err := d.phyInfo(phy); err != nil {
+		addIssue(domain.DiagnosticWarning, "phy_info", fmt.Sprintf("Could not query %s capabilities: %v", phy, err), "")
+	} else {
+		supported, known := parseSupportsMonitor(phyInfo)
+		diag.MonitorSupported = supported
+		switch {
+		case diag.Mode == "monitor" || supported:
+		case !known:
+			addIssue(domain.DiagnosticWarning, "monitor_mode", fmt.Sprintf("Could not read the interface modes of %s", phy),
+				"Check that 'iw phy "+phy+" info' lists monitor under \"Supported interface modes\"")
+		default:
+			addIssue(domain.DiagnosticError, "monitor_mode", fmt.Sprintf("Driver %q does not advertise monitor mode", diag.Driver),
+				"Use an adapter/driver with monitor mode support")
+		}
+
+		bands, _ := parsePhyCapabilities(phyInfo)
+		if bands["2.4ghz"] {
+			diag.SupportedBands = append(diag.SupportedBands, domain.Band24GHz)
+		}
+		if bands["5ghz"] {
+			diag.SupportedBands = append(diag.SupportedBands, domain.Band5GHz)
+		}
+		switch {
+		case len(diag.SupportedBands) > 0:
+		case !bytes.Contains(phyInfo, []byte("Frequencies:")):
+			addIssue(domain.DiagnosticWarning, "bands", fmt.Sprintf("Could not read the channels of %s", phy), "")
+		default:
+			addIssue(domain.DiagnosticError, "bands", "No enabled channels reported for this interface",
+				"Check rfkill ('rfkill list') and the regulatory domain")
+		}
+	}
+
+	// Regulatory domain
+	if out, err := d.executor.Execute("iw", "reg", "get"); err == nil {
+		diag.RegulatoryDomain = parseRegDomain(out)
+	}
+	switch diag.RegulatoryDomain {
+	case "":
+		addIssue(domain.DiagnosticWarning, "regulatory", "Regulatory domain unknown", "Set it with 'iw reg set <CC>'")
+	case "00":
+		addIssue(domain.DiagnosticWarning, "regulatory", "World regulatory domain (00) restricts channels and TX power",
+			"Set your country with 'iw reg set <CC>'")
+	}
+
+	// Interfering processes
+	for _, name := range interferingProcesses {
+		if _, err := d.executor.Execute("pgrep", "-x", name); err == nil {
+			diag.InterferingProcesses = append(diag.InterferingProcesses, name)
+		}
+	}
+	if len(diag.InterferingProcesses) > 0 {
+		addIssue(domain.DiagnosticWarning, "processes",
+			fmt.Sprintf("Processes may change channel or mode: %s", strings.Join(diag.InterferingProcesses, ", ")),
+			"Stop them (e.g. 'systemctl stop NetworkManager wpa_supplicant') or mark the interface unmanaged")
+	}
+
+	return diag
+}
+
+// parseIfaceType extracts the "type" line of 'iw dev <iface> info'.
+func parseIfaceType(out []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "type ") {
+			return strings.TrimPrefix(line, "type ")
+		}
+	}
+	return ""
+}
+
+// parseSupportsMonitor looks for "* monitor" under "Supported interface modes:".
+// known is false when the output has no such section.
+func parseSupportsMonitor(out []byte) (supported, known bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	inModes := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "Supported interface modes:" {
+			inModes, known = true, true
+			continue
+		}
+		if inModes {
+			if !strings.HasPrefix(line, "*") {
+				return false, true
+			}
+			if strings.TrimSpace(strings.TrimPrefix(line, "*")) == "monitor" {
+				return true, true
+			}
+		}
+	}
+	return false, known
+}
+
+// parseRegDomain returns the first "country XX:" of 'iw reg get'.
+func parseRegDomain(out []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "country ") {
+			code := strings.TrimPrefix(line, "country ")
+			if idx := strings.Index(code, ":"); idx >= 0 {
+				code = code[:idx]
+			}
+			return code
+		}
+	}
+	return ""
+}
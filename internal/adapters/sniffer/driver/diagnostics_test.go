@@ -0,0 +1,124 @@
+package driver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeExecutor answers commands from a table keyed by the joined command line.
+type fakeExecutor map[string]string
+
+func (f fakeExecutor) Execute(name string, args ...string) ([]byte, error) {
+	out, ok := f[strings.Join(append([]string{name}, args...), " ")]
+	if !ok {
+		return nil, errors.New("exit status 1")
+	}
+	return []byte(out), nil
+}
+
+const iwDev = `phy#0
+	Interface wlan0
+		type managed
+`
+
+const iwPhyInfo = `Wiphy phy0
+	Band 1:
+		Frequencies:
+			* 2412 MHz [1] (20.0 dBm)
+			* 2437 MHz [6] (20.0 dBm)
+	Band 2:
+		Frequencies:
+			* 5180 MHz [36] (23.0 dBm)
+	Supported interface modes:
+		 * IBSS
+		 * managed
+		 * monitor
+	Supported commands:
+`
+
+func TestDiagnose(t *testing.T) {
+	t.Run("Healthy adapter", func(t *testing.T) {
+		d := &WirelessDriver{executor: fakeExecutor{
+			"iw dev": iwDev,
+			"readlink -f /sys/class/net/wlan0/device/driver": "/sys/bus/usb/drivers/rt2800usb\n",
+			"iw dev wlan0 info": "Interface wlan0\n\ttype managed\n",
+			"iw phy phy0 info":  iwPhyInfo,
+			"iw reg get":        "global\ncountry ES: DFS-ETSI\n",
+		}}
+
+		diag := d.Diagnose("wlan0")
+		assert.Equal(t, "phy0", diag.Phy)
+		assert.Equal(t, "rt2800usb", diag.Driver)
+		assert.Equal(t, "managed", diag.Mode)
+		assert.True(t, diag.MonitorSupported)
+		assert.Equal(t, "ES", diag.RegulatoryDomain)
+		assert.Equal(t, []domain.WiFiBand{domain.Band24GHz, domain.Band5GHz}, diag.SupportedBands)
+		assert.Empty(t, diag.Issues)
+		assert.True(t, diag.Ready())
+	})
+
+	t.Run("Reports blocking and advisory issues", func(t *testing.T) {
+		d := &WirelessDriver{executor: fakeExecutor{
+			"iw dev":                  iwDev,
+			"iw dev wlan0 info":       "Interface wlan0\n\ttype managed\n",
+			"iw phy phy0 info":        strings.Replace(iwPhyInfo, "\t\t * monitor\n", "", 1),
+			"iw reg get":              "global\ncountry 00: DFS-UNSET\n",
+			"pgrep -x NetworkManager": "812\n",
+		}}
+
+		diag := d.Diagnose("wlan0")
+		assert.False(t, diag.MonitorSupported)
+		assert.False(t, diag.Ready())
+		assert.Equal(t, []string{"NetworkManager"}, diag.InterferingProcesses)
+
+		checks := map[string]domain.DiagnosticSeverity{}
+		for _, issue := range diag.Issues {
+			checks[issue.Check] = issue.Severity
+		}
+		assert.Equal(t, domain.DiagnosticError, checks["monitor_mode"])
+		assert.Equal(t, domain.DiagnosticWarning, checks["regulatory"])
+		assert.Equal(t, domain.DiagnosticWarning, checks["processes"])
+		assert.Equal(t, domain.DiagnosticWarning, checks["driver"])
+	})
+
+	t.Run("Unparseable capabilities only warn", func(t *testing.T) {
+		d := &WirelessDriver{executor: fakeExecutor{
+			"iw dev":            iwDev,
+			"iw dev wlan0 info": "Interface wlan0\n\ttype managed\n",
+			"iw phy phy0 info":  "Wiphy phy0\n\tmax # scan SSIDs: 4\n",
+			"iw reg get":        "global\ncountry ES: DFS-ETSI\n",
+		}}
+
+		diag := d.Diagnose("wlan0")
+		assert.True(t, diag.Ready(), "a layout we cannot parse does not block capture")
+		checks := map[string]domain.DiagnosticSeverity{}
+		for _, issue := range diag.Issues {
+			checks[issue.Check] = issue.Severity
+		}
+		assert.Equal(t, domain.DiagnosticWarning, checks["monitor_mode"])
+		assert.Equal(t, domain.DiagnosticWarning, checks["bands"])
+	})
+
+	t.Run("Failed capability query only warns", func(t *testing.T) {
+		d := &WirelessDriver{executor: fakeExecutor{
+			"iw dev":            iwDev,
+			"iw dev wlan0 info": "Interface wlan0\n\ttype managed\n",
+			"iw reg get":        "global\ncountry ES: DFS-ETSI\n",
+		}}
+
+		diag := d.Diagnose("wlan0")
+		assert.True(t, diag.Ready())
+		assert.Equal(t, "phy_info", diag.Issues[1].Check)
+	})
+
+	t.Run("Unknown interface", func(t *testing.T) {
+		d := &WirelessDriver{executor: fakeExecutor{"iw dev": iwDev}}
+		diag := d.Diagnose("wlan9")
+		assert.False(t, diag.Ready())
+		assert.Equal(t, "interface", diag.Issues[0].Check)
+	})
+}
@@ -0,0 +1,62 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingExecutor records every command and fails the ones listed in fail.
+type recordingExecutor struct {
+	calls []string
+	fail  map[string]bool
+}
+
+func (r *recordingExecutor) Execute(name string, args ...string) ([]byte, error) {
+	cmd := strings.Join(append([]string{name}, args...), " ")
+	r.calls = append(r.calls, cmd)
+	if r.fail[cmd] {
+		return []byte("error"), assert.AnError
+	}
+	return nil, nil
+}
+
+func TestMonitorVIFName(t *testing.T) {
+	assert.Equal(t, "wlan0mon", MonitorVIFName("wlan0"))
+	assert.Len(t, MonitorVIFName("wlx00c0ca123456"), 15)
+}
+
+func TestCreateMonitorInterface(t *testing.T) {
+	t.Run("Adds the VIF without touching the parent", func(t *testing.T) {
+		exec := &recordingExecutor{fail: map[string]bool{"iw dev wlan0mon info": true}}
+		d := &WirelessDriver{executor: exec}
+
+		require.NoError(t, d.CreateMonitorInterface("wlan0", "wlan0mon"))
+		assert.Contains(t, exec.calls, "iw dev wlan0 interface add wlan0mon type monitor")
+		assert.Contains(t, exec.calls, "ip link set wlan0mon up")
+		for _, call := range exec.calls {
+			assert.NotContains(t, call, "set wlan0 ", "parent interface must not be reconfigured")
+			assert.NotContains(t, call, "systemctl")
+		}
+	})
+
+	t.Run("Replaces a leftover VIF", func(t *testing.T) {
+		exec := &recordingExecutor{}
+		d := &WirelessDriver{executor: exec}
+
+		require.NoError(t, d.CreateMonitorInterface("wlan0", "wlan0mon"))
+		assert.Equal(t, "iw dev wlan0mon del", exec.calls[2])
+	})
+
+	t.Run("Returns an error when the driver refuses", func(t *testing.T) {
+		exec := &recordingExecutor{fail: map[string]bool{
+			"iw dev wlan0mon info":                             true,
+			"iw dev wlan0 interface add wlan0mon type monitor": true,
+		}}
+		d := &WirelessDriver{executor: exec}
+
+		assert.Error(t, d.CreateMonitorInterface("wlan0", "wlan0mon"))
+	})
+}
@@ -0,0 +1,148 @@
+package driver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+var (
+	reFrequencyLine = regexp.MustCompile(`^\*\s+([0-9.]+) MHz \[([0-9]+)\]`)
+	reRadarFreq     = regexp.MustCompile(`freq[= ]([0-9]+)`)
+)
+
+// SetRegulatoryDomain sets the kernel regulatory domain (iw reg set).
+func SetRegulatoryDomain(cc string) error {
+	return DefaultDriver.SetRegulatoryDomain(cc)
+}
+
+func (d *WirelessDriver) SetRegulatoryDomain(cc string) error {
+	if !domain.IsValidRegDomain(cc) {
+		return fmt.Errorf("invalid regulatory domain: %q", cc)
+	}
+	output, err := d.executor.Execute("iw", "reg", "set", cc)
+	if err != nil {
+		return fmt.Errorf("failed to set regulatory domain %s: %v (%s)", cc, err, string(output))
+	}
+	d.flushPhyInfo()
+	return nil
+}
+
+// GetChannelRegulatory returns the regulatory flags of every channel the interface's phy knows.
+func GetChannelRegulatory(iface string) ([]domain.ChannelRegulatory, error) {
+	return DefaultDriver.GetChannelRegulatory(iface)
+}
+
+func (d *WirelessDriver) GetChannelRegulatory(iface string) ([]domain.ChannelRegulatory, error) {
+	phy, err := d.getPhyForInterface(iface)
+	if err != nil {
+		return nil, err
+	}
+	out, err := d.phyInfo(phy)
+	if err != nil {
+		return nil, err
+	}
+	return parseChannelRegulatory(out), nil
+}
+
+// parseChannelRegulatory parses the "Frequencies:" blocks of 'iw phy info'.
+// Older iw versions print "passive scanning" / "no IBSS" instead of "no IR".
+func parseChannelRegulatory(out []byte) []domain.ChannelRegulatory {
+	channels := []domain.ChannelRegulatory{}
+	seen := make(map[int]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	inFrequencies := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "Frequencies:" {
+			inFrequencies = true
+			continue
+		}
+		if !inFrequencies {
+			continue
+		}
+		if !strings.HasPrefix(line, "*") {
+			inFrequencies = false
+			continue
+		}
+
+		matches := reFrequencyLine.FindStringSubmatch(line)
+		if len(matches) < 3 {
+			continue
+		}
+		freq, _ := strconv.ParseFloat(matches[1], 64)
+		ch, _ := strconv.Atoi(matches[2])
+		if seen[ch] {
+			continue
+		}
+		seen[ch] = true
+
+		channels = append(channels, domain.ChannelRegulatory{
+			Channel:      ch,
+			FrequencyMHz: int(freq),
+			Disabled:     strings.Contains(line, "(disabled)"),
+			NoIR:         strings.Contains(line, "no IR") || strings.Contains(line, "passive scanning"),
+			DFS:          strings.Contains(line, "radar detection"),
+		})
+	}
+	return channels
+}
+
+// WatchRadarEvents follows 'iw event' and sends the channel of every radar
+// detection reported by the kernel until ctx is cancelled.
+func WatchRadarEvents(ctx context.Context, events chan<- int) error {
+	cmd := exec.CommandContext(ctx, "iw", "event")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start iw event: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if ch, ok := parseRadarEvent(scanner.Text()); ok {
+			select {
+			case events <- ch:
+			case <-ctx.Done():
+			}
+		}
+	}
+	return cmd.Wait()
+}
+
+// parseRadarEvent extracts the channel from an 'iw event' radar detection line,
+// e.g. "phy #0: radar event freq=5260 MHz (radar detected)".
+func parseRadarEvent(line string) (int, bool) {
+	if !strings.Contains(line, "radar detected") {
+		return 0, false
+	}
+	matches := reRadarFreq.FindStringSubmatch(line)
+	if len(matches) < 2 {
+		return 0, false
+	}
+	freq, _ := strconv.Atoi(matches[1])
+	ch := frequencyToChannel(freq)
+	return ch, ch > 0
+}
+
+func frequencyToChannel(freq int) int {
+	switch {
+	case freq == 2484:
+		return 14
+	case freq >= 2412 && freq < 2484:
+		return (freq - 2407) / 5
+	case freq >= 5000 && freq < 5925:
+		return (freq - 5000) / 5
+	}
+	return 0
+}
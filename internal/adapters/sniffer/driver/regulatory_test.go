@@ -0,0 +1,99 @@
+package driver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const iwPhyInfoRegulatory = `Wiphy phy0
+	Band 1:
+		Frequencies:
+			* 2412 MHz [1] (20.0 dBm)
+			* 2484 MHz [14] (disabled)
+	Band 2:
+		Frequencies:
+			* 5180.0 MHz [36] (23.0 dBm)
+			* 5260 MHz [52] (20.0 dBm) (no IR, radar detection)
+			* 5500 MHz [100] (26.0 dBm) (passive scanning, no IBSS, radar detection)
+			* 5745 MHz [149] (13.0 dBm) (no IR)
+	Supported interface modes:
+		 * monitor
+`
+
+func TestParseChannelRegulatory(t *testing.T) {
+	channels := parseChannelRegulatory([]byte(iwPhyInfoRegulatory))
+	require.Len(t, channels, 6)
+
+	byChannel := map[int]domain.ChannelRegulatory{}
+	for _, ch := range channels {
+		byChannel[ch.Channel] = ch
+	}
+
+	assert.True(t, byChannel[1].TxAllowed())
+	assert.True(t, byChannel[14].Disabled)
+	assert.Equal(t, 5180, byChannel[36].FrequencyMHz)
+	assert.True(t, byChannel[36].TxAllowed())
+	assert.True(t, byChannel[52].DFS)
+	assert.True(t, byChannel[52].NoIR)
+	assert.True(t, byChannel[100].DFS, "older iw output")
+	assert.True(t, byChannel[100].NoIR, "passive scanning means no IR")
+	assert.False(t, byChannel[149].DFS)
+	assert.False(t, byChannel[149].TxAllowed())
+}
+
+func TestParseRadarEvent(t *testing.T) {
+	ch, ok := parseRadarEvent("phy #0: radar event freq=5260 MHz (radar detected)")
+	assert.True(t, ok)
+	assert.Equal(t, 52, ch)
+
+	_, ok = parseRadarEvent("phy #0: radar event freq=5260 MHz (CAC finished)")
+	assert.False(t, ok)
+
+	_, ok = parseRadarEvent("wlan0 (phy #0): scan started")
+	assert.False(t, ok)
+}
+
+func TestSetRegulatoryDomain(t *testing.T) {
+	exec := &recordingExecutor{}
+	d := &WirelessDriver{executor: exec}
+
+	require.NoError(t, d.SetRegulatoryDomain("ES"))
+	assert.Equal(t, []string{"iw reg set ES"}, exec.calls)
+
+	assert.Error(t, d.SetRegulatoryDomain("es; reboot"))
+}
+
+// countingExecutor answers like fakeExecutor and counts the calls per command.
+type countingExecutor struct {
+	fakeExecutor
+	calls map[string]int
+}
+
+func (c *countingExecutor) Execute(name string, args ...string) ([]byte, error) {
+	c.calls[strings.Join(append([]string{name}, args...), " ")]++
+	return c.fakeExecutor.Execute(name, args...)
+}
+
+func TestGetChannelRegulatory_CachesPhyInfo(t *testing.T) {
+	exec := &countingExecutor{
+		fakeExecutor: fakeExecutor{"iw dev": iwDev, "iw phy phy0 info": iwPhyInfoRegulatory, "iw reg set ES": ""},
+		calls:        map[string]int{},
+	}
+	d := &WirelessDriver{executor: exec}
+
+	for i := 0; i < 3; i++ {
+		channels, err := d.GetChannelRegulatory("wlan0")
+		require.NoError(t, err)
+		require.Len(t, channels, 6)
+	}
+	assert.Equal(t, 1, exec.calls["iw phy phy0 info"])
+
+	require.NoError(t, d.SetRegulatoryDomain("ES"))
+	_, err := d.GetChannelRegulatory("wlan0")
+	require.NoError(t, err)
+	assert.Equal(t, 2, exec.calls["iw phy phy0 info"], "changing the regulatory domain drops the cache")
+}
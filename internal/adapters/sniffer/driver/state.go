@@ -0,0 +1,109 @@
+package driver
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// InterfaceState reads the current type, channel and link state of iface.
+func InterfaceState(iface string) (domain.InterfaceState, error) {
+	return DefaultDriver.InterfaceState(iface)
+}
+
+func (d *WirelessDriver) InterfaceState(iface string) (domain.InterfaceState, error) {
+	state := domain.InterfaceState{Name: iface}
+	out, err := d.executor.Execute("iw", "dev", iface, "info")
+	if err != nil {
+		return state, fmt.Errorf("failed to query %s: %v (%s)", iface, err, strings.TrimSpace(string(out)))
+	}
+	state.Type = parseIfaceType(out)
+	state.Channel = parseIfaceChannel(out)
+
+	if out, err := d.executor.Execute("ip", "link", "show", iface); err == nil {
+		state.Up = parseLinkUp(out)
+	}
+	return state, nil
+}
+
+// RestoreInterfaceState puts iface back in the recorded state, returning it
+// to the driver's rate control on the way.
+func RestoreInterfaceState(state domain.InterfaceState) error {
+	return DefaultDriver.RestoreInterfaceState(state)
+}
+
+func (d *WirelessDriver) RestoreInterfaceState(state domain.InterfaceState) error {
+	log.Printf("Restoring %s to %s mode...", state.Name, state.Type)
+	var errs []error
+	if err := d.runCmd("ip", "link", "set", state.Name, "down"); err != nil {
+		errs = append(errs, err)
+	}
+	_ = d.ResetBitrates(state.Name) // Not every driver supports setting them
+	if state.Type != "" {
+		if err := d.runCmd("iw", state.Name, "set", "type", state.Type); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if state.Up {
+		if err := d.runCmd("ip", "link", "set", state.Name, "up"); err != nil {
+			errs = append(errs, err)
+		}
+		// Only a monitor interface keeps a channel of its own
+		if state.Type == "monitor" && state.Channel > 0 {
+			if err := d.SetInterfaceChannel(state.Name, state.Channel); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegulatoryDomain returns the country code of the global regulatory domain.
+func RegulatoryDomain() (string, error) {
+	return DefaultDriver.RegulatoryDomain()
+}
+
+func (d *WirelessDriver) RegulatoryDomain() (string, error) {
+	out, err := d.executor.Execute("iw", "reg", "get")
+	if err != nil {
+		return "", fmt.Errorf("failed to query the regulatory domain: %v (%s)", err, strings.TrimSpace(string(out)))
+	}
+	return parseRegDomain(out), nil
+}
+
+// parseIfaceChannel extracts the channel number of 'iw dev <iface> info',
+// e.g. "channel 6 (2437 MHz), width: 20 MHz".
+func parseIfaceChannel(out []byte) int {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "channel" {
+			if channel, err := strconv.Atoi(fields[1]); err == nil {
+				return channel
+			}
+		}
+	}
+	return 0
+}
+
+// parseLinkUp reports whether the flags of 'ip link show' include UP,
+// e.g. "3: wlan0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 ...".
+func parseLinkUp(out []byte) bool {
+	start := bytes.IndexByte(out, '<')
+	end := bytes.IndexByte(out, '>')
+	if start < 0 || end < start {
+		return false
+	}
+	for _, flag := range strings.Split(string(out[start+1:end]), ",") {
+		if flag == "UP" {
+			return true
+		}
+	}
+	return false
+}
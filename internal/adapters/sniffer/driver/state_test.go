@@ -0,0 +1,60 @@
+package driver
+
+import (
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceState(t *testing.T) {
+	d := &WirelessDriver{executor: fakeExecutor{
+		"iw dev wlan0 info":  "Interface wlan0\n\tifindex 3\n\ttype managed\n\tchannel 36 (5180 MHz), width: 80 MHz, center1: 5210 MHz\n",
+		"ip link show wlan0": "3: wlan0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc noqueue state UP mode DORMANT\n",
+		"iw dev wlan1 info":  "Interface wlan1\n\ttype managed\n",
+		"ip link show wlan1": "4: wlan1: <NO-CARRIER,BROADCAST,MULTICAST> mtu 1500 qdisc noop state DOWN\n",
+	}}
+
+	state, err := d.InterfaceState("wlan0")
+	require.NoError(t, err)
+	assert.Equal(t, domain.InterfaceState{Name: "wlan0", Type: "managed", Channel: 36, Up: true}, state)
+
+	state, err = d.InterfaceState("wlan1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.InterfaceState{Name: "wlan1", Type: "managed"}, state)
+
+	_, err = d.InterfaceState("wlan9")
+	assert.Error(t, err)
+}
+
+func TestRestoreInterfaceState(t *testing.T) {
+	t.Run("Managed interface left up", func(t *testing.T) {
+		exec := &recordingExecutor{}
+		d := &WirelessDriver{executor: exec}
+
+		require.NoError(t, d.RestoreInterfaceState(domain.InterfaceState{Name: "wlan0", Type: "managed", Channel: 6, Up: true}))
+		assert.Equal(t, []string{
+			"ip link set wlan0 down",
+			"iw dev wlan0 set bitrates",
+			"iw wlan0 set type managed",
+			"ip link set wlan0 up",
+		}, exec.calls)
+	})
+
+	t.Run("Monitor interface gets its channel back", func(t *testing.T) {
+		exec := &recordingExecutor{}
+		d := &WirelessDriver{executor: exec}
+
+		require.NoError(t, d.RestoreInterfaceState(domain.InterfaceState{Name: "wlan1", Type: "monitor", Channel: 11, Up: true}))
+		assert.Contains(t, exec.calls, "iw wlan1 set channel 11")
+	})
+
+	t.Run("Down interface stays down", func(t *testing.T) {
+		exec := &recordingExecutor{fail: map[string]bool{"iw dev wlan2 set bitrates": true}}
+		d := &WirelessDriver{executor: exec}
+
+		require.NoError(t, d.RestoreInterfaceState(domain.InterfaceState{Name: "wlan2", Type: "managed"}))
+		assert.NotContains(t, exec.calls, "ip link set wlan2 up")
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -28,6 +29,19 @@ func (e *SystemCommandExecutor) Execute(name string, args ...string) ([]byte, er
 // WirelessDriver handles interaction with wireless interfaces
 type WirelessDriver struct {
 	executor CommandExecutor
+
+	phyMu    sync.Mutex
+	phyCache map[string]cachedPhyInfo // 'iw phy <phy> info' output by phy
+}
+
+// phyInfoTTL bounds how long a phy's capabilities are reused. Changing the
+// regulatory domain through the driver drops them right away.
+const phyInfoTTL = 30 * time.Second
+
+// cachedPhyInfo is the output of 'iw phy <phy> info' and when it was read.
+type cachedPhyInfo struct {
+	out    []byte
+	readAt time.Time
 }
 
 // DefaultDriver is the default instance using system commands
@@ -36,6 +50,33 @@ var DefaultDriver = &WirelessDriver{executor: &SystemCommandExecutor{}}
 // SetExecutor允许 for testing
 func SetExecutor(e CommandExecutor) {
 	DefaultDriver.executor = e
+	DefaultDriver.flushPhyInfo()
+}
+
+// phyInfo returns the output of 'iw phy <phy> info', cached per phy for
+// phyInfoTTL since every hop plan, preflight and TX check reads it.
+func (d *WirelessDriver) phyInfo(phy string) ([]byte, error) {
+	d.phyMu.Lock()
+	defer d.phyMu.Unlock()
+	if cached, ok := d.phyCache[phy]; ok && time.Since(cached.readAt) < phyInfoTTL {
+		return cached.out, nil
+	}
+	out, err := d.executor.Execute("iw", "phy", phy, "info")
+	if err != nil {
+		return nil, err
+	}
+	if d.phyCache == nil {
+		d.phyCache = make(map[string]cachedPhyInfo)
+	}
+	d.phyCache[phy] = cachedPhyInfo{out: out, readAt: time.Now()}
+	return out, nil
+}
+
+// flushPhyInfo drops the cached phy capabilities.
+func (d *WirelessDriver) flushPhyInfo() {
+	d.phyMu.Lock()
+	defer d.phyMu.Unlock()
+	d.phyCache = nil
 }
 
 // HardwareCapabilities defines what a WiFi card supports.
@@ -83,11 +124,17 @@ func (d *WirelessDriver) getPhyForInterface(iface string) (string, error) {
 }
 
 func (d *WirelessDriver) getPhyCapabilities(phy string) (map[string]bool, []int, error) {
-	out, err := d.executor.Execute("iw", "phy", phy, "info")
+	out, err := d.phyInfo(phy)
 	if err != nil {
 		return nil, nil, err
 	}
+	bands, channels := parsePhyCapabilities(out)
+	return bands, channels, nil
+}
 
+// parsePhyCapabilities returns the bands and enabled channels listed by
+// 'iw phy <phy> info'.
+func parsePhyCapabilities(out []byte) (map[string]bool, []int) {
 	bands := make(map[string]bool)
 	supportedChannels := []int{}
 
@@ -128,7 +175,7 @@ func (d *WirelessDriver) getPhyCapabilities(phy string) (map[string]bool, []int,
 		}
 	}
 
-	return bands, supportedChannels, nil
+	return bands, supportedChannels
 }
 
 // SetInterfaceChannel sets the WiFi channel for a given interface.
@@ -248,6 +295,79 @@ func (d *WirelessDriver) DisableMonitorMode(iface string) {
 	_ = d.runCmd("ip", "link", "set", iface, "up")
 }
 
+// SetLinkUp brings the interface up or down. A down interface keeps its
+// mode and powers its radio off on most drivers.
+func SetLinkUp(iface string, up bool) error {
+	return DefaultDriver.SetLinkUp(iface, up)
+}
+
+func (d *WirelessDriver) SetLinkUp(iface string, up bool) error {
+	state := "down"
+	if up {
+		state = "up"
+	}
+	return d.runCmd("ip", "link", "set", iface, state)
+}
+
+// ResetBitrates clears the fixed TX bitrates set for injection, returning the
+// interface to the driver's rate control.
+func ResetBitrates(iface string) error {
+	return DefaultDriver.ResetBitrates(iface)
+}
+
+func (d *WirelessDriver) ResetBitrates(iface string) error {
+	return d.runCmd("iw", "dev", iface, "set", "bitrates")
+}
+
+// MonitorVIFName returns the name of the monitor VIF created for iface
+// (airmon-ng style "wlan0mon", truncated to the kernel's 15-char limit).
+func MonitorVIFName(iface string) string {
+	name := iface + "mon"
+	if len(name) > 15 {
+		name = name[len(name)-15:]
+	}
+	return name
+}
+
+// CreateMonitorInterface adds a separate monitor VIF on the parent's phy,
+// leaving the parent interface (and its connectivity) untouched.
+func CreateMonitorInterface(parent, name string) error {
+	return DefaultDriver.CreateMonitorInterface(parent, name)
+}
+
+func (d *WirelessDriver) CreateMonitorInterface(parent, name string) error {
+	log.Printf("Creating monitor interface %s on %s...", name, parent)
+
+	// Remove a leftover VIF from a previous run
+	if _, err := d.executor.Execute("iw", "dev", name, "info"); err == nil {
+		d.DeleteMonitorInterface(name)
+	}
+
+	if err := d.runCmd("iw", "dev", parent, "interface", "add", name, "type", "monitor"); err != nil {
+		return fmt.Errorf("failed to add monitor interface %s: %w", name, err)
+	}
+
+	// Keep NetworkManager off the new VIF (best effort, NM may not be installed)
+	_, _ = d.executor.Execute("nmcli", "device", "set", name, "managed", "no")
+
+	if err := d.runCmd("ip", "link", "set", name, "up"); err != nil {
+		d.DeleteMonitorInterface(name)
+		return fmt.Errorf("failed to bring up %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteMonitorInterface removes a monitor VIF created by CreateMonitorInterface.
+func DeleteMonitorInterface(name string) {
+	DefaultDriver.DeleteMonitorInterface(name)
+}
+
+func (d *WirelessDriver) DeleteMonitorInterface(name string) {
+	log.Printf("Removing monitor interface %s...", name)
+	_ = d.runCmd("ip", "link", "set", name, "down")
+	_ = d.runCmd("iw", "dev", name, "del")
+}
+
 func (d *WirelessDriver) runCmd(name string, args ...string) error {
 	output, err := d.executor.Execute(name, args...)
 	if err != nil {
@@ -1,6 +1,7 @@
 package handshake
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"net"
@@ -14,6 +15,8 @@ import (
 	"github.com/google/gopacket/layers"
 	"github.com/google/gopacket/pcapgo"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/ie"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/pcapng"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,14 +34,83 @@ func TestPMKIDCapture(t *testing.T) {
 
 	hm.SavePMKID(createPMKIDPacket(bssid, "aa:bb:cc:dd:ee:ff"), bssid, ssid)
 
-	// Filename: BSSID_ESSID_PMKID.pcap
-	expectedFilename := fmt.Sprintf("%s_%s_PMKID.pcap", sanitizeFilename(bssid), sanitizeFilename(ssid))
+	// Filename: BSSID_ESSID_PMKID.pcapng
+	expectedFilename := fmt.Sprintf("%s_%s_PMKID.pcapng", sanitizeFilename(bssid), sanitizeFilename(ssid))
 	path := filepath.Join(tmpDir, expectedFilename)
 
 	// Verify file existence
 	assert.FileExists(t, path)
 }
 
+// prefixSealer marks sealed data with a prefix; enough to tell sealed files apart.
+type prefixSealer struct{}
+
+func (prefixSealer) Seal(plaintext string) (string, error) { return "sealed:" + plaintext, nil }
+func (prefixSealer) Open(sealed string) (string, error)    { return sealed[len("sealed:"):], nil }
+func (prefixSealer) SealBytes(plaintext []byte) ([]byte, error) {
+	return append([]byte("sealed:"), plaintext...), nil
+}
+func (prefixSealer) OpenBytes(sealed []byte) ([]byte, error) { return sealed[len("sealed:"):], nil }
+
+func TestSealedCaptures(t *testing.T) {
+	tmpDir := t.TempDir()
+	hm := NewHandshakeManager(tmpDir)
+	bssid, ssid := "00:11:22:33:44:98", "SealedNet"
+	plainPath := filepath.Join(tmpDir, fmt.Sprintf("%s_%s_PMKID.pcapng", sanitizeFilename(bssid), sanitizeFilename(ssid)))
+
+	// A capture saved before encryption was enabled
+	hm.SavePMKID(createPMKIDPacket(bssid, "aa:bb:cc:dd:ee:ff"), bssid, ssid)
+	require.FileExists(t, plainPath)
+
+	var saved []string
+	hm.SetOnSaved(func(path string) { saved = append(saved, path) })
+	hm.SetSealer(prefixSealer{})
+
+	n, err := hm.SealExisting()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NoFileExists(t, plainPath)
+	assert.Equal(t, []string{plainPath + ".enc"}, saved)
+
+	data, err := os.ReadFile(plainPath + ".enc")
+	require.NoError(t, err)
+	assert.Equal(t, "sealed:", string(data[:7]))
+
+	// The sealed file still holds a valid pcapng
+	r, err := pcapgo.NewNgReader(bytes.NewReader(data[7:]), pcapgo.DefaultNgReaderOptions)
+	require.NoError(t, err)
+	assert.Equal(t, layers.LinkTypeIEEE80211Radio, r.LinkType())
+
+	// New captures are written sealed only
+	hm.SavePMKID(createPMKIDPacket(bssid, "aa:bb:cc:dd:ee:ff"), bssid, ssid)
+	assert.NoFileExists(t, plainPath)
+	assert.Len(t, saved, 2)
+}
+
+func TestCaptureContextComments(t *testing.T) {
+	tmpDir := t.TempDir()
+	hm := NewHandshakeManager(tmpDir)
+	defer hm.Close()
+	bssid, ssid := "00:11:22:33:44:97", "AttributedNet"
+
+	var asked []string
+	hm.SetCaptureContext(func(b string) domain.CaptureContext {
+		asked = append(asked, b)
+		return domain.CaptureContext{Workspace: "office", Operator: "alice", AttackID: "deauth-1"}
+	})
+	hm.SavePMKID(createPMKIDPacket(bssid, "aa:bb:cc:dd:ee:ff"), bssid, ssid)
+	assert.Equal(t, []string{bssid}, asked)
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, fmt.Sprintf("%s_%s_PMKID.pcapng", sanitizeFilename(bssid), ssid)))
+	require.NoError(t, err)
+	n, err := pcapng.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	_, err = n.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "wmap", n.Metadata().Application)
+	assert.Equal(t, []string{"bssid: " + bssid, "PMKID", "workspace: office", "operator: alice", "attack: deauth-1"}, n.Metadata().Comments)
+}
+
 func TestPCAPGeneration_Exhaustive(t *testing.T) {
 	tmpDir := t.TempDir()
 	hm := NewHandshakeManager(tmpDir)
@@ -73,8 +145,8 @@ func TestPCAPGeneration_Exhaustive(t *testing.T) {
 	time.Sleep(500 * time.Millisecond)
 
 	// 3. Verify File Content
-	// Expected filename: BSSID_ESSID_STA.pcap
-	expectedFilename := fmt.Sprintf("%s_%s_%s.pcap", sanitizeFilename(bssid), sanitizeFilename(ssid), sanitizeFilename(sta))
+	// Expected filename: BSSID_ESSID_STA.pcapng
+	expectedFilename := fmt.Sprintf("%s_%s_%s.pcapng", sanitizeFilename(bssid), sanitizeFilename(ssid), sanitizeFilename(sta))
 	fullPath := filepath.Join(tmpDir, expectedFilename)
 
 	// Check file exists
@@ -87,7 +159,7 @@ func TestPCAPGeneration_Exhaustive(t *testing.T) {
 	require.NoError(t, err)
 	defer f.Close()
 
-	reader, err := pcapgo.NewReader(f)
+	reader, err := pcapgo.NewNgReader(f, pcapgo.DefaultNgReaderOptions)
 	require.NoError(t, err)
 
 	// We expect 3 packets: Beacon, M1, M2
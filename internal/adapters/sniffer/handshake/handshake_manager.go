@@ -6,13 +6,17 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
-	"github.com/google/gopacket/pcapgo"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/ie"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/pcapng"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
 )
 
 const (
@@ -20,6 +24,15 @@ const (
 	incompleteSessionTimeout = 60 * time.Second
 	cleanupInterval          = 1 * time.Minute
 	maxFramesPerSession      = 20
+
+	// captureExt is the extension of saved captures. Files from before the
+	// move to pcapng keep the legacy .pcap extension.
+	captureExt       = ".pcapng"
+	legacyCaptureExt = ".pcap"
+
+	// SampleDir is the subdirectory of sample frames kept as evidence of a
+	// finding. They are no handshakes, so they stay out of the capture list.
+	SampleDir = "samples"
 )
 
 // HandshakeManager handles the capture and storage of WPA/WPA2 handshakes.
@@ -29,8 +42,13 @@ type HandshakeManager struct {
 	bssidToEssid  map[string]string          // Kept as map protected by RWMutex for now, but usage optimized
 	bssidToBeacon map[string]gopacket.Packet // BSSID -> Beacon Packet (Cache)
 	sessions      map[string]*HandshakeSession
+	pmkids        map[string]bool // BSSIDs with a saved PMKID
+	imported      map[string]bool // BSSIDs with an imported handshake hash
 	saveQueue     chan *HandshakeSession
 	stopChan      chan struct{}
+	onSaved       func(path string)         // Called after a capture file is written
+	sealer        ports.SecretSealer        // Encrypts capture files at rest when set
+	captureCtx    domain.CaptureContextFunc // Attribution written in capture comments
 }
 
 // HandshakeSession represents a capture session for a specific BSSID+Station pair.
@@ -38,6 +56,7 @@ type HandshakeSession struct {
 	BSSID      string
 	StationMAC string
 	ESSID      string
+	Channel    int // From RadioTap, 0 if unknown
 	Frames     []gopacket.Packet
 	Beacon     gopacket.Packet // Best beacon frame, required for aircrack-ng ESSID detection
 	LastUpdate time.Time
@@ -50,6 +69,16 @@ type HandshakeSession struct {
 	Anonce           []byte
 }
 
+// DefaultDir returns the XDG-compliant directory where captures are saved.
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		log.Printf("Warning: Could not resolve home directory, using fallback path: %v", err)
+		home = "."
+	}
+	return filepath.Join(home, ".local", "share", "wmap", "handshakes")
+}
+
 // NewHandshakeManager creates a new manager.
 func NewHandshakeManager(baseDir string) *HandshakeManager {
 	// Ensure directory exists
@@ -62,6 +91,8 @@ func NewHandshakeManager(baseDir string) *HandshakeManager {
 		bssidToEssid:  make(map[string]string),
 		bssidToBeacon: make(map[string]gopacket.Packet),
 		sessions:      make(map[string]*HandshakeSession),
+		pmkids:        make(map[string]bool),
+		imported:      make(map[string]bool),
 		saveQueue:     make(chan *HandshakeSession, 100),
 		stopChan:      make(chan struct{}),
 	}
@@ -74,6 +105,96 @@ func NewHandshakeManager(baseDir string) *HandshakeManager {
 	return hm
 }
 
+// Dir returns the directory where captures are saved.
+func (hm *HandshakeManager) Dir() string {
+	return hm.baseDir
+}
+
+// SetOnSaved sets a callback receiving the path of every capture file written.
+func (hm *HandshakeManager) SetOnSaved(callback func(path string)) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.onSaved = callback
+}
+
+// SetCaptureContext sets the function returning the workspace, operator and
+// attack recorded in the comments of the captures of a BSSID.
+func (hm *HandshakeManager) SetCaptureContext(fn domain.CaptureContextFunc) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.captureCtx = fn
+}
+
+// SetSealer encrypts capture files at rest: they are written as
+// NAME.pcapng.enc and only decrypted by their consumers.
+func (hm *HandshakeManager) SetSealer(sealer ports.SecretSealer) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+	hm.sealer = sealer
+}
+
+// SealExisting encrypts the plaintext captures left in the directory, e.g.
+// from before encryption was enabled. It returns how many were encrypted.
+func (hm *HandshakeManager) SealExisting() (int, error) {
+	hm.mu.RLock()
+	sealer := hm.sealer
+	hm.mu.RUnlock()
+	if sealer == nil {
+		return 0, nil
+	}
+
+	entries, err := os.ReadDir(hm.baseDir)
+	if err != nil {
+		return 0, err
+	}
+	sealed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !isCaptureFile(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(hm.baseDir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return sealed, err
+		}
+		if err := hm.writeCapture(path, data); err != nil {
+			return sealed, err
+		}
+		sealed++
+	}
+	return sealed, nil
+}
+
+// writeCapture stores a capture file, encrypted if a sealer is set, and
+// notifies the saved callback with the path actually written.
+func (hm *HandshakeManager) writeCapture(path string, data []byte) error {
+	hm.mu.RLock()
+	sealer, callback := hm.sealer, hm.onSaved
+	hm.mu.RUnlock()
+
+	if sealer != nil {
+		sealed, err := sealer.SealBytes(data)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path+domain.SealedFileSuffix, sealed, 0600); err != nil {
+			return err
+		}
+		// Never leave a plaintext copy next to the sealed one
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		path += domain.SealedFileSuffix
+	} else if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+
+	if callback != nil {
+		callback(path)
+	}
+	return nil
+}
+
 // Close stops background routines.
 func (hm *HandshakeManager) Close() {
 	close(hm.stopChan)
@@ -185,31 +306,8 @@ func (hm *HandshakeManager) handleEAPOL(packet gopacket.Packet, dot11 *layers.Do
 	// Address2 = Transmitter (SA)
 	// Address3 = BSSID (usually)
 
-	// Determine addresses based on DS flags
-	var bssid, stationMac string
-	toDS := dot11.Flags.ToDS()
-	fromDS := dot11.Flags.FromDS()
-
-	if !toDS && !fromDS {
-		// AdHoc / Mgmt
-		bssid = dot11.Address3.String()
-		if dot11.Address2.String() == bssid {
-			stationMac = dot11.Address1.String()
-		} else {
-			stationMac = dot11.Address2.String()
-		}
-	} else if !toDS && fromDS {
-		// AP -> Station (Downlink)
-		// RA=Addr1(Station), TA=Addr2(BSSID), SA=Addr3(Src)
-		bssid = dot11.Address2.String()
-		stationMac = dot11.Address1.String()
-	} else if toDS && !fromDS {
-		// Station -> AP (Uplink)
-		// RA=Addr1(BSSID), TA=Addr2(Station), DA=Addr3(Dst)
-		bssid = dot11.Address1.String()
-		stationMac = dot11.Address2.String()
-	} else {
-		// WDS or unknown - skip
+	bssid, stationMac, ok := eapolAddresses(dot11)
+	if !ok {
 		return false
 	}
 
@@ -236,6 +334,7 @@ func (hm *HandshakeManager) handleEAPOL(packet gopacket.Packet, dot11 *layers.Do
 			StationMAC: stationMac,
 			ESSID:      essid,
 			Beacon:     beacon, // Seed with cached beacon
+			Channel:    radioTapChannel(packet),
 			Frames:     make([]gopacket.Packet, 0),
 			Captured:   make(map[uint8]bool),
 		}
@@ -396,6 +495,38 @@ func (hm *HandshakeManager) handleEAPOL(packet gopacket.Packet, dot11 *layers.Do
 	return false
 }
 
+// eapolAddresses returns the BSSID and station of an EAPOL frame based on
+// its DS flags. WDS frames are not handshakes with a station.
+func eapolAddresses(dot11 *layers.Dot11) (bssid, station string, ok bool) {
+	toDS := dot11.Flags.ToDS()
+	fromDS := dot11.Flags.FromDS()
+
+	switch {
+	case !toDS && !fromDS:
+		// AdHoc / Mgmt
+		bssid = dot11.Address3.String()
+		if dot11.Address2.String() == bssid {
+			station = dot11.Address1.String()
+		} else {
+			station = dot11.Address2.String()
+		}
+	case !toDS && fromDS:
+		// AP -> Station (Downlink)
+		// RA=Addr1(Station), TA=Addr2(BSSID), SA=Addr3(Src)
+		bssid = dot11.Address2.String()
+		station = dot11.Address1.String()
+	case toDS && !fromDS:
+		// Station -> AP (Uplink)
+		// RA=Addr1(BSSID), TA=Addr2(Station), DA=Addr3(Dst)
+		bssid = dot11.Address1.String()
+		station = dot11.Address2.String()
+	default:
+		// WDS or unknown - skip
+		return "", "", false
+	}
+	return bssid, station, true
+}
+
 // RegisterNetwork manually registers an ESSID for a BSSID (useful for testing or seeding)
 func (hm *HandshakeManager) RegisterNetwork(bssid, essid string) {
 	hm.mu.Lock()
@@ -404,46 +535,44 @@ func (hm *HandshakeManager) RegisterNetwork(bssid, essid string) {
 }
 
 func (hm *HandshakeManager) saveSession(session *HandshakeSession) {
-	// Filename: BSSID_ESSID_StationMAC.pcap (Sanitized)
+	// Filename: BSSID_ESSID_StationMAC.pcapng (Sanitized)
 	// This ensures unique files per client (Solution 1)
 	essidClean := sanitizeFilename(session.ESSID)
 	bssidClean := sanitizeFilename(session.BSSID)
 	staClean := sanitizeFilename(session.StationMAC)
 
-	filename := fmt.Sprintf("%s_%s_%s.pcap", bssidClean, essidClean, staClean)
+	filename := fmt.Sprintf("%s_%s_%s%s", bssidClean, essidClean, staClean, captureExt)
 	path := filepath.Join(hm.baseDir, filename)
 
 	log.Printf("DEBUG: Attempting to save session to %s", path)
 
-	f, err := os.Create(path)
+	var buf bytes.Buffer
+	w, ifID, err := hm.newCapture(&buf, session.BSSID, "station: "+session.StationMAC)
 	if err != nil {
-		log.Printf("Error creating pcap file %s: %v", path, err)
+		log.Printf("Error starting capture %s: %v", path, err)
 		return
 	}
-	defer f.Close()
-
-	w := pcapgo.NewWriter(f)
-	// LinkType 127 is DLT_IEEE802_11_RADIO (Radiotap)
-	// Or 105 for IEEE802_11. Most gopacket captures include Radiotap layer.
-	// Let's assume Radiotap presence.
-	w.WriteFileHeader(65536, layers.LinkTypeIEEE80211Radio)
 
 	// Write Beacon First (Critical for aircrack-ng)
 	if session.Beacon != nil {
-		if err := w.WritePacket(session.Beacon.Metadata().CaptureInfo, session.Beacon.Data()); err != nil {
-			log.Printf("Error writing beacon to pcap: %v", err)
+		if err := writeFrame(w, ifID, session.Beacon); err != nil {
+			log.Printf("Error writing beacon to pcapng: %v", err)
 		}
 	}
 
 	for _, pkt := range session.Frames {
-		if err := w.WritePacket(pkt.Metadata().CaptureInfo, pkt.Data()); err != nil {
-			log.Printf("Error writing packet to pcap: %v", err)
+		if err := writeFrame(w, ifID, pkt); err != nil {
+			log.Printf("Error writing packet to pcapng: %v", err)
 		}
 	}
+	if err := hm.writeCapture(path, buf.Bytes()); err != nil {
+		log.Printf("Error saving pcapng file %s: %v", path, err)
+		return
+	}
 	log.Printf("DEBUG: Successfully saved session to %s", path)
 }
 
-// SavePMKID saves a single packet containing a PMKID to a pcap file.
+// SavePMKID saves a single packet containing a PMKID to a pcapng file.
 func (hm *HandshakeManager) SavePMKID(packet gopacket.Packet, bssid, essid string) {
 	// Ensure we have a valid ESSID for filename
 	if essid == "" {
@@ -456,23 +585,20 @@ func (hm *HandshakeManager) SavePMKID(packet gopacket.Packet, bssid, essid strin
 		hm.mu.RUnlock()
 	}
 
-	// Filename: BSSID_ESSID_PMKID.pcap
+	// Filename: BSSID_ESSID_PMKID.pcapng
 	essidClean := sanitizeFilename(essid)
 	bssidClean := sanitizeFilename(bssid)
-	filename := fmt.Sprintf("%s_%s_PMKID.pcap", bssidClean, essidClean)
+	filename := fmt.Sprintf("%s_%s_PMKID%s", bssidClean, essidClean, captureExt)
 	path := filepath.Join(hm.baseDir, filename)
 
 	// Check if already exists to avoid spamming I/O?
 	// For now, overwrite or skip. Let's overwrite to ensure latest capture.
-	f, err := os.Create(path)
+	var buf bytes.Buffer
+	w, ifID, err := hm.newCapture(&buf, bssid, "PMKID")
 	if err != nil {
-		log.Printf("Error creating PMKID pcap file %s: %v", path, err)
+		log.Printf("Error starting PMKID capture %s: %v", path, err)
 		return
 	}
-	defer f.Close()
-
-	w := pcapgo.NewWriter(f)
-	w.WriteFileHeader(65536, layers.LinkTypeIEEE80211Radio)
 
 	// Try to find a beacon to include
 	hm.mu.RLock()
@@ -480,17 +606,88 @@ func (hm *HandshakeManager) SavePMKID(packet gopacket.Packet, bssid, essid strin
 	hm.mu.RUnlock()
 
 	if beacon != nil {
-		w.WritePacket(beacon.Metadata().CaptureInfo, beacon.Data())
+		writeFrame(w, ifID, beacon)
 	}
 
-	w.WritePacket(packet.Metadata().CaptureInfo, packet.Data())
+	writeFrame(w, ifID, packet)
+	if err := hm.writeCapture(path, buf.Bytes()); err != nil {
+		log.Printf("Error saving PMKID pcapng file %s: %v", path, err)
+		return
+	}
+	hm.mu.Lock()
+	hm.pmkids[bssid] = true
+	hm.mu.Unlock()
 	log.Printf("Saved PMKID capture: %s", filename)
 }
 
+// SaveSample saves frames of a BSSID kept as evidence of a finding, such as
+// weak nonces, under SampleDir. It returns the path written.
+func (hm *HandshakeManager) SaveSample(bssid, finding string, packets ...gopacket.Packet) (string, error) {
+	dir := filepath.Join(hm.baseDir, SampleDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	filename := fmt.Sprintf("%s_%s_%d%s", sanitizeFilename(bssid), sanitizeFilename(finding), time.Now().UnixNano(), captureExt)
+	path := filepath.Join(dir, filename)
+
+	var buf bytes.Buffer
+	w, ifID, err := hm.newCapture(&buf, bssid, finding)
+	if err != nil {
+		return "", err
+	}
+	for _, packet := range packets {
+		if err := writeFrame(w, ifID, packet); err != nil {
+			return "", err
+		}
+	}
+	if err := hm.writeCapture(path, buf.Bytes()); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// newCapture starts a pcapng capture of a BSSID's frames. The section
+// comments record what was captured and the capture context.
+func (hm *HandshakeManager) newCapture(buf *bytes.Buffer, bssid, description string) (*pcapng.Writer, int, error) {
+	hm.mu.RLock()
+	captureCtx := hm.captureCtx
+	hm.mu.RUnlock()
+
+	comments := []string{"bssid: " + bssid, description}
+	if captureCtx != nil {
+		comments = append(comments, captureCtx(bssid).Comments()...)
+	}
+	w, err := pcapng.NewWriter(buf, pcapng.Section{Application: "wmap", OS: runtime.GOOS, Comments: comments})
+	if err != nil {
+		return nil, 0, err
+	}
+	// Most gopacket captures include the Radiotap layer
+	ifID, err := w.AddInterface(pcapng.Interface{LinkType: layers.LinkTypeIEEE80211Radio})
+	return w, ifID, err
+}
+
+func writeFrame(w *pcapng.Writer, ifID int, packet gopacket.Packet) error {
+	data := packet.Data()
+	ci := packet.Metadata().CaptureInfo
+	ci.CaptureLength = len(data)
+	if ci.Length < len(data) {
+		ci.Length = len(data)
+	}
+	return w.WritePacket(ifID, ci, data, pcapng.PacketOptions{})
+}
+
+// isCaptureFile reports whether name is a plaintext capture, pcapng or legacy pcap.
+func isCaptureFile(name string) bool {
+	return strings.HasSuffix(name, captureExt) || strings.HasSuffix(name, legacyCaptureExt)
+}
+
 // HasHandshake returns true if a handshake has been captured for the given BSSID.
 func (hm *HandshakeManager) HasHandshake(bssid string) bool {
 	hm.mu.RLock()
 	defer hm.mu.RUnlock()
+	if hm.imported[bssid] {
+		return true
+	}
 	// Check if any session with this BSSID has captured M2 and (M1 or M3)
 	for _, session := range hm.sessions {
 		if session.BSSID == bssid && session.Captured[2] && (session.Captured[1] || session.Captured[3]) {
@@ -500,8 +697,53 @@ func (hm *HandshakeManager) HasHandshake(bssid string) bool {
 	return false
 }
 
+// HasPMKID returns true if a PMKID has been saved for the given BSSID.
+func (hm *HandshakeManager) HasPMKID(bssid string) bool {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+	return hm.pmkids[bssid]
+}
+
+// PendingByChannel counts recently active sessions that still lack a crackable
+// handshake, keyed by channel. The hopper dwells longer on those channels.
+func (hm *HandshakeManager) PendingByChannel() map[int]int {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	pending := make(map[int]int)
+	now := time.Now()
+	for _, session := range hm.sessions {
+		if session.Channel == 0 || now.Sub(session.LastUpdate) > incompleteSessionTimeout {
+			continue
+		}
+		if session.Captured[2] && (session.Captured[1] || session.Captured[3]) {
+			continue
+		}
+		pending[session.Channel]++
+	}
+	return pending
+}
+
 // Helpers
 
+// radioTapChannel returns the channel the frame was received on, or 0.
+func radioTapChannel(packet gopacket.Packet) int {
+	rt, ok := packet.Layer(layers.LayerTypeRadioTap).(*layers.RadioTap)
+	if !ok {
+		return 0
+	}
+	freq := int(rt.ChannelFrequency)
+	switch {
+	case freq == 2484:
+		return 14
+	case freq >= 2412 && freq < 2484:
+		return (freq - 2407) / 5
+	case freq >= 5170 && freq <= 5825:
+		return (freq - 5000) / 5
+	}
+	return 0
+}
+
 func getSSIDFromPacket(packet gopacket.Packet) string {
 	if beacon := packet.Layer(layers.LayerTypeDot11MgmtBeacon); beacon != nil {
 		// Optimization: Try to parse generic payload first (faster)
@@ -0,0 +1,141 @@
+package handshake
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// hashExt is the extension of saved hashcat 22000 hash files, also
+	// written as .hc22000 by hcxpcapngtool.
+	hashExt   = ".22000"
+	hcHashExt = ".hc22000"
+
+	// hashcat 22000 hash types
+	hashTypePMKID  = "01"
+	hashTypeEAPOL  = "02"
+	hashMICSize    = 16 // PMKID or MIC
+	maxHashLineLen = 4096
+)
+
+// hashLine is a hashcat 22000 line:
+// WPA*TYPE*PMKID/MIC*MACAP*MACCLIENT*ESSID*ANONCE*EAPOL*MESSAGEPAIR
+type hashLine struct {
+	hashType string
+	bssid    string
+	station  string
+	essid    string
+}
+
+// parseHashLine decodes the addresses and ESSID of a hashcat 22000 line.
+func parseHashLine(line string) (hashLine, error) {
+	fields := strings.Split(line, "*")
+	if len(fields) != 9 || fields[0] != "WPA" {
+		return hashLine{}, fmt.Errorf("not a 22000 hash")
+	}
+	h := hashLine{hashType: fields[1]}
+	if h.hashType != hashTypePMKID && h.hashType != hashTypeEAPOL {
+		return hashLine{}, fmt.Errorf("unknown hash type %q", h.hashType)
+	}
+	if mic, err := hex.DecodeString(fields[2]); err != nil || len(mic) != hashMICSize {
+		return hashLine{}, fmt.Errorf("invalid hash")
+	}
+	ap, err := hex.DecodeString(fields[3])
+	if err != nil || len(ap) != 6 {
+		return hashLine{}, fmt.Errorf("invalid AP address %q", fields[3])
+	}
+	sta, err := hex.DecodeString(fields[4])
+	if err != nil || len(sta) != 6 {
+		return hashLine{}, fmt.Errorf("invalid client address %q", fields[4])
+	}
+	essid, err := hex.DecodeString(fields[5])
+	if err != nil || len(essid) == 0 || len(essid) > 32 {
+		return hashLine{}, fmt.Errorf("invalid ESSID %q", fields[5])
+	}
+	if h.hashType == hashTypeEAPOL && (fields[6] == "" || fields[7] == "") {
+		return hashLine{}, fmt.Errorf("EAPOL hash without nonce or frame")
+	}
+	h.bssid, h.station, h.essid = formatMAC(ap), formatMAC(sta), string(essid)
+	return h, nil
+}
+
+// isHashFile reports whether name is a hashcat 22000 hash file.
+func isHashFile(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == hashExt || ext == hcHashExt
+}
+
+// ImportHashes saves the hashcat 22000 hashes of a hash file, as written by
+// hcxpcapngtool, next to the captures: PMKID hashes mark their BSSID like a
+// saved PMKID and EAPOL hashes like a captured handshake. Lines that are no
+// 22000 hash are skipped.
+func (hm *HandshakeManager) ImportHashes(ctx context.Context, name string, r io.Reader) (domain.CaptureImport, error) {
+	result := domain.CaptureImport{File: name}
+	handshakes := make(map[string]bool)
+	pmkids := make(map[string]bool)
+	captures := make(map[string]*domain.CapturedNetwork)
+	var saved bytes.Buffer
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, maxHashLineLen), maxHashLineLen)
+	for scanner.Scan() && ctx.Err() == nil {
+		line := strings.TrimSpace(scanner.Text())
+		h, err := parseHashLine(line)
+		if err != nil {
+			continue
+		}
+		result.Packets++
+		saved.WriteString(line + "\n")
+		hm.RegisterNetwork(h.bssid, h.essid)
+
+		capture := captured(captures, h.bssid, time.Time{}) // Hash files carry no timestamps
+		if h.hashType == hashTypePMKID {
+			pmkids[h.bssid] = true
+			capture.PMKID = true
+		} else {
+			handshakes[pairKey(h.bssid, h.station)] = true
+			capture.Handshake = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result, fmt.Errorf("reading hashes: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if result.Packets == 0 {
+		return result, fmt.Errorf("no 22000 hashes in file")
+	}
+
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+	path := filepath.Join(hm.baseDir, sanitizeFilename(stem)+hashExt)
+	if err := hm.writeCapture(path, saved.Bytes()); err != nil {
+		return result, fmt.Errorf("saving hashes: %w", err)
+	}
+
+	hm.mu.Lock()
+	for bssid := range pmkids {
+		hm.pmkids[bssid] = true
+	}
+	for _, capture := range captures {
+		if capture.Handshake {
+			hm.imported[capture.BSSID] = true
+		}
+	}
+	hm.mu.Unlock()
+
+	result.Networks = len(captures)
+	result.Handshakes = len(handshakes)
+	result.PMKIDs = len(pmkids)
+	result.Captures = hm.capturedNetworks(captures)
+	return result, nil
+}
@@ -0,0 +1,191 @@
+package handshake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/ie"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/pcapng"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// maxImportComments bounds the comments listed in an import result.
+const maxImportComments = 50
+
+// ImportPcapng replays a pcapng capture recorded by another tool, such as
+// hcxdumptool, through the manager: beacons name the networks, EAPOL frames
+// build handshake sessions saved like live ones, and PMKIDs in M1 messages
+// are saved on their own. Captures are written asynchronously, like those
+// of the live capture. Legacy pcap files, as written by airodump-ng, are
+// read too.
+func (hm *HandshakeManager) ImportPcapng(ctx context.Context, name string, r io.Reader) (domain.CaptureImport, error) {
+	result := domain.CaptureImport{File: name}
+	reader, err := pcapng.NewReader(r)
+	if err != nil {
+		return result, err
+	}
+	handshakes := make(map[string]bool)
+	pmkids := make(map[string]bool)
+	networks := make(map[string]bool)
+	captures := make(map[string]*domain.CapturedNetwork)
+
+	for ctx.Err() == nil {
+		ng, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if result.Packets == 0 {
+				return result, err
+			}
+			break // Keep what was read from a truncated capture
+		}
+		result.Packets++
+		for _, c := range ng.Comments {
+			if len(result.Comments) < maxImportComments {
+				result.Comments = appendUnique(result.Comments, c)
+			}
+		}
+
+		data, ok := radiotapFrame(ng.LinkType, ng.Data)
+		if !ok {
+			continue
+		}
+		packet := gopacket.NewPacket(data, layers.LayerTypeRadioTap, gopacket.Default)
+		ts := ng.Timestamp
+		if ts.IsZero() {
+			ts = time.Now()
+		}
+		packet.Metadata().CaptureInfo = gopacket.CaptureInfo{
+			Timestamp:     ts,
+			CaptureLength: len(data),
+			Length:        len(data) - len(ng.Data) + ng.Length,
+		}
+
+		dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+		if !ok {
+			continue
+		}
+		if dot11.Type == layers.Dot11TypeMgmtBeacon {
+			if essid := getSSIDFromPacket(packet); essid != "" && essid != "<HIDDEN>" {
+				networks[dot11.Address3.String()] = true
+			}
+		}
+
+		if hm.ProcessFrame(packet) {
+			handshakes[pairKey(dot11.Address1.String(), dot11.Address2.String())] = true
+			if bssid, _, ok := eapolAddresses(dot11); ok {
+				captured(captures, bssid, ng.Timestamp).Handshake = true
+			}
+		}
+		if bssid, ok := pmkidBSSID(packet, dot11); ok {
+			hm.SavePMKID(packet, bssid, "")
+			pmkids[bssid] = true
+			captured(captures, bssid, ng.Timestamp).PMKID = true
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if result.Packets == 0 {
+		return result, fmt.Errorf("no packets in capture")
+	}
+
+	meta := reader.Metadata()
+	result.Application, result.Hardware, result.OS = meta.Application, meta.Hardware, meta.OS
+	result.ToolMACs = meta.ToolMACs
+	for _, c := range meta.Comments {
+		if len(result.Comments) < maxImportComments {
+			result.Comments = appendUnique(result.Comments, c)
+		}
+	}
+	result.Networks = len(networks)
+	result.Handshakes = len(handshakes)
+	result.PMKIDs = len(pmkids)
+	result.Captures = hm.capturedNetworks(captures)
+	return result, nil
+}
+
+// captured returns the capture of a BSSID, added on its first frame, and
+// moves its last seen time forward to ts.
+func captured(captures map[string]*domain.CapturedNetwork, bssid string, ts time.Time) *domain.CapturedNetwork {
+	capture, ok := captures[bssid]
+	if !ok {
+		capture = &domain.CapturedNetwork{BSSID: bssid}
+		captures[bssid] = capture
+	}
+	if ts.After(capture.LastSeen) {
+		capture.LastSeen = ts
+	}
+	return capture
+}
+
+// capturedNetworks lists the captures by BSSID, named from the beacons seen
+// so far.
+func (hm *HandshakeManager) capturedNetworks(captures map[string]*domain.CapturedNetwork) []domain.CapturedNetwork {
+	hm.mu.RLock()
+	defer hm.mu.RUnlock()
+
+	list := make([]domain.CapturedNetwork, 0, len(captures))
+	for bssid, capture := range captures {
+		capture.SSID = hm.bssidToEssid[bssid]
+		list = append(list, *capture)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].BSSID < list[j].BSSID })
+	return list
+}
+
+// pairKey identifies a station session whatever the direction of the frame.
+func pairKey(a, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + "_" + b
+}
+
+// pmkidBSSID returns the BSSID of an EAPOL M1 carrying a PMKID.
+func pmkidBSSID(packet gopacket.Packet, dot11 *layers.Dot11) (string, bool) {
+	frame, err := ParseEAPOLKey(packet)
+	if err != nil || frame.DetermineMessageNumber() != 1 || len(frame.KeyData) == 0 {
+		return "", false
+	}
+	if !ie.ParsePMKID(frame.KeyData) {
+		return "", false
+	}
+	return dot11.Address2.String(), true // Sent by the AP
+}
+
+// radiotapFrame returns a frame with a radiotap header, adding an empty one
+// to raw 802.11 frames so captures are saved with a single link type.
+func radiotapFrame(linkType layers.LinkType, data []byte) ([]byte, bool) {
+	switch linkType {
+	case layers.LinkTypeIEEE80211Radio:
+		return data, true
+	case layers.LinkTypeIEEE802_11:
+		frame := make([]byte, 8+len(data))
+		frame[2] = 8 // Header length, no fields present
+		copy(frame[8:], data)
+		return frame, true
+	default:
+		return nil, false
+	}
+}
+
+func formatMAC(b []byte) string {
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", b[0], b[1], b[2], b[3], b[4], b[5])
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,177 @@
+package handshake
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/pcapng"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCapture builds a pcapng file of raw 802.11 frames a second apart, commented with comments[i] when given.
+func writeCapture(t *testing.T, section pcapng.Section, start time.Time, frames [][]byte, comments ...[]string) []byte {
+	var buf bytes.Buffer
+	w, err := pcapng.NewWriter(&buf, section)
+	require.NoError(t, err)
+	ifID, err := w.AddInterface(pcapng.Interface{LinkType: layers.LinkTypeIEEE802_11})
+	require.NoError(t, err)
+	for i, frame := range frames {
+		var opts pcapng.PacketOptions
+		if i < len(comments) {
+			opts.Comments = comments[i]
+		}
+		ci := gopacket.CaptureInfo{Timestamp: start.Add(time.Duration(i) * time.Second), CaptureLength: len(frame), Length: len(frame)}
+		require.NoError(t, w.WritePacket(ifID, ci, frame, opts))
+	}
+	return buf.Bytes()
+}
+
+// createPMKIDM1 builds an M1 carrying a PMKID KDE in its key data.
+func createPMKIDM1(bssid, sta string) []byte {
+	apMac, _ := parseMACAddr(bssid)
+	staMac, _ := parseMACAddr(sta)
+	dot11 := &layers.Dot11{Type: layers.Dot11TypeData, Address1: staMac, Address2: apMac, Address3: apMac}
+	llc := &layers.LLC{DSAP: 0xaa, SSAP: 0xaa, Control: 0x03}
+	snap := &layers.SNAP{OrganizationalCode: []byte{0, 0, 0}, Type: layers.EthernetTypeEAPOL}
+
+	kde := append([]byte{0xdd, 0x14, 0x00, 0x0f, 0xac, 0x04}, bytes.Repeat([]byte{0xab}, 16)...)
+	payload := make([]byte, 95, 95+len(kde))
+	payload[0] = 2
+	binary.BigEndian.PutUint16(payload[1:3], 0x008a) // Ack | Pairwise | AES
+	binary.BigEndian.PutUint64(payload[5:13], 1)
+	copy(payload[13:45], bytes.Repeat([]byte{0x11}, 32))
+	binary.BigEndian.PutUint16(payload[93:95], uint16(len(kde)))
+	payload = append(payload, kde...)
+	eapol := &layers.EAPOL{Version: 2, Type: layers.EAPOLTypeKey, Length: uint16(len(payload))}
+
+	buf := gopacket.NewSerializeBuffer()
+	gopacket.SerializeLayers(buf, gopacket.SerializeOptions{}, dot11, llc, snap, eapol, gopacket.Payload(payload))
+	return buf.Bytes()
+}
+
+func TestImportPcapng_Hcxdumptool(t *testing.T) {
+	bssid, sta := "00:11:22:33:44:55", "aa:bb:cc:dd:ee:ff"
+	data := writeCapture(t,
+		pcapng.Section{Application: "hcxdumptool 6.3.4", OS: "Linux", Comments: []string{"field survey"}},
+		time.Now(),
+		[][]byte{
+			createManualBeacon(bssid, "FieldNet").Data(),
+			createPMKIDM1(bssid, sta),
+			makeEAPOL(packetParams{SRC: sta, DST: bssid, BSSID: bssid, MsgNum: 2, ReplayCounter: 1}).Data(),
+		},
+		nil, []string{"PMKID"},
+	)
+
+	dir := t.TempDir()
+	hm := NewHandshakeManager(dir)
+	defer hm.Close()
+
+	result, err := hm.ImportPcapng(context.Background(), "field.pcapng", bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "field.pcapng", result.File)
+	assert.Equal(t, "hcxdumptool 6.3.4", result.Application)
+	assert.Equal(t, "Linux", result.OS)
+	assert.Equal(t, []string{"PMKID", "field survey"}, result.Comments)
+	assert.Equal(t, 3, result.Packets)
+	assert.Equal(t, 1, result.Networks)
+	assert.Equal(t, 1, result.PMKIDs)
+	assert.Equal(t, 1, result.Handshakes)
+	assert.True(t, hm.HasHandshake(bssid))
+
+	assert.FileExists(t, filepath.Join(dir, fmt.Sprintf("%s_FieldNet_PMKID.pcapng", sanitizeFilename(bssid))))
+	handshakeFile := filepath.Join(dir, fmt.Sprintf("%s_FieldNet_%s.pcapng", sanitizeFilename(bssid), sanitizeFilename(sta)))
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(handshakeFile)
+		return err == nil
+	}, 2*time.Second, 20*time.Millisecond, "sessions are saved asynchronously")
+}
+
+func TestImportPcapng_Invalid(t *testing.T) {
+	hm := NewHandshakeManager(t.TempDir())
+	defer hm.Close()
+
+	_, err := hm.ImportPcapng(context.Background(), "legacy.pcap", bytes.NewReader([]byte("\xd4\xc3\xb2\xa1 not pcapng")))
+	assert.Error(t, err)
+
+	empty := writeCapture(t, pcapng.Section{}, time.Now(), nil)
+	_, err = hm.ImportPcapng(context.Background(), "empty.pcapng", bytes.NewReader(empty))
+	assert.Error(t, err)
+}
+
+func TestImportPcapng_LegacyPcap(t *testing.T) {
+	bssid, sta := "00:11:22:33:44:55", "aa:bb:cc:dd:ee:ff"
+	var buf bytes.Buffer
+	w := pcapgo.NewWriter(&buf)
+	require.NoError(t, w.WriteFileHeader(65535, layers.LinkTypeIEEE802_11))
+	seen := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, packet := range []gopacket.Packet{
+		createManualBeacon(bssid, "Airodump"),
+		makeEAPOL(packetParams{SRC: bssid, DST: sta, BSSID: bssid, MsgNum: 1, ReplayCounter: 1}),
+		makeEAPOL(packetParams{SRC: sta, DST: bssid, BSSID: bssid, MsgNum: 2, ReplayCounter: 1}),
+	} {
+		data := packet.Data()
+		ci := gopacket.CaptureInfo{Timestamp: seen.Add(time.Duration(i) * time.Second), CaptureLength: len(data), Length: len(data)}
+		require.NoError(t, w.WritePacket(ci, data))
+	}
+
+	hm := NewHandshakeManager(t.TempDir())
+	defer hm.Close()
+
+	result, err := hm.ImportPcapng(context.Background(), "airodump-01.cap", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Packets)
+	assert.Equal(t, 1, result.Handshakes)
+	assert.Empty(t, result.Application, "legacy pcap carries no metadata")
+	require.Len(t, result.Captures, 1)
+	assert.Equal(t, domain.CapturedNetwork{BSSID: bssid, SSID: "Airodump", Handshake: true, LastSeen: seen.Add(2 * time.Second)}, result.Captures[0])
+	assert.True(t, hm.HasHandshake(bssid))
+}
+
+func TestImportHashes(t *testing.T) {
+	hashes := strings.Join([]string{
+		"WPA*01*4d4fe7aac3a2cecab195321ceb99a7d0*fc690c158264*f4747f87f9f4*686173686361742d6573736964***01",
+		"WPA*02*024022795224bffca545276c3762686f*6466b38ec3fc*225edc49b7aa*54502d4c494e4b5f484153484341545f54455354*10e3be3b005a629e89de088d6a2fdc489db83ad4764f2d186b9cde15446e972e*0103007502010a0000000000000000000148ce2ccba9c1fda130ff2fbbfb4fd3b063d1a93920b0f7df54a5cbf787b16171000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000001630140100000fac040100000fac040100000fac028000*a2",
+		"WPA*02*024022795224bffca545276c3762686f*6466b38ec3fc*225edc49b7aa*54502d4c494e4b5f484153484341545f54455354*10e3be3b005a629e89de088d6a2fdc489db83ad4764f2d186b9cde15446e972e*0103007502010a*00",
+		"# not a hash",
+		"WPA*02*short*6466b38ec3fc*225edc49b7aa*54502d4c*aa*bb*00",
+	}, "\n")
+
+	dir := t.TempDir()
+	hm := NewHandshakeManager(dir)
+	defer hm.Close()
+	var saved []string
+	hm.SetOnSaved(func(path string) { saved = append(saved, path) })
+
+	result, err := hm.ImportHashes(context.Background(), "survey.hc22000", strings.NewReader(hashes))
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Packets)
+	assert.Equal(t, 2, result.Networks)
+	assert.Equal(t, 1, result.Handshakes, "one station session")
+	assert.Equal(t, 1, result.PMKIDs)
+	assert.Equal(t, []domain.CapturedNetwork{
+		{BSSID: "64:66:b3:8e:c3:fc", SSID: "TP-LINK_HASHCAT_TEST", Handshake: true},
+		{BSSID: "fc:69:0c:15:82:64", SSID: "hashcat-essid", PMKID: true},
+	}, result.Captures)
+	assert.True(t, hm.HasHandshake("64:66:b3:8e:c3:fc"))
+	assert.True(t, hm.HasPMKID("fc:69:0c:15:82:64"))
+
+	require.Equal(t, []string{filepath.Join(dir, "survey.22000")}, saved, "saved to the catalog")
+	data, err := os.ReadFile(saved[0])
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(data), "\n"), "only the hashes are kept")
+
+	_, err = hm.ImportHashes(context.Background(), "empty.22000", strings.NewReader("no hashes here\n"))
+	assert.Error(t, err)
+}
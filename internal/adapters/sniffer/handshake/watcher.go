@@ -0,0 +1,174 @@
+package handshake
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// DropDir is the default subdirectory of the capture directory watched
+	// for captures added by other tools.
+	DropDir = "incoming"
+	// ImportedDir is the subdirectory of the drop folder imported files are
+	// moved to.
+	ImportedDir = "imported"
+	// DefaultWatchInterval is how often the drop folder is scanned.
+	DefaultWatchInterval = 5 * time.Second
+)
+
+// fileState identifies a version of a dropped file.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// Watcher imports the captures and hash files other tools, such as
+// hcxdumptool or airodump-ng, drop in a folder. A file is imported once it
+// stopped growing between two scans, then moved to ImportedDir.
+type Watcher struct {
+	hm       *HandshakeManager
+	dir      string
+	onImport func(ctx context.Context, result domain.CaptureImport) // Optional, set when imports are registered
+
+	mu      sync.Mutex
+	pending map[string]fileState // Files seen at the previous scan
+	failed  map[string]fileState // Files not retried until they change
+}
+
+// NewWatcher creates a watcher importing the files dropped in dir into hm.
+func NewWatcher(hm *HandshakeManager, dir string) *Watcher {
+	if err := os.MkdirAll(filepath.Join(dir, ImportedDir), 0755); err != nil {
+		log.Printf("ERROR: Could not create capture drop folder: %v", err)
+	}
+	return &Watcher{
+		hm:      hm,
+		dir:     dir,
+		pending: make(map[string]fileState),
+		failed:  make(map[string]fileState),
+	}
+}
+
+// Dir returns the watched folder.
+func (w *Watcher) Dir() string {
+	return w.dir
+}
+
+// SetOnImport sets a callback receiving the result of every imported file.
+func (w *Watcher) SetOnImport(callback func(ctx context.Context, result domain.CaptureImport)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onImport = callback
+}
+
+// Run scans the folder every interval until ctx is done.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		w.Scan(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan imports the files unchanged since the previous scan and returns
+// their results.
+func (w *Watcher) Scan(ctx context.Context) []domain.CaptureImport {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		log.Printf("Warning: could not scan capture drop folder: %v", err)
+		return nil
+	}
+
+	var results []domain.CaptureImport
+	pending := make(map[string]fileState)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !isDroppedCapture(name) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		state := fileState{size: info.Size(), modTime: info.ModTime()}
+		if failed, ok := w.failed[name]; ok && failed == state {
+			continue
+		}
+		delete(w.failed, name)
+		if prev, ok := w.pending[name]; !ok || prev != state {
+			pending[name] = state // Possibly still being written
+			continue
+		}
+
+		result, err := w.importFile(ctx, name, state.modTime)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			log.Printf("Warning: could not import dropped capture %s: %v", name, err)
+			w.failed[name] = state
+			continue
+		}
+		if err := os.Rename(filepath.Join(w.dir, name), filepath.Join(w.dir, ImportedDir, name)); err != nil {
+			log.Printf("Warning: could not move imported capture %s: %v", name, err)
+			w.failed[name] = state
+		}
+		log.Printf("Imported dropped capture %s: %d handshakes, %d PMKIDs", name, result.Handshakes, result.PMKIDs)
+		if w.onImport != nil {
+			w.onImport(ctx, result)
+		}
+		results = append(results, result)
+	}
+	w.pending = pending
+	return results
+}
+
+// importFile imports a dropped file. Captures without timestamps are dated
+// by the file's modification time.
+func (w *Watcher) importFile(ctx context.Context, name string, modTime time.Time) (domain.CaptureImport, error) {
+	f, err := os.Open(filepath.Join(w.dir, name))
+	if err != nil {
+		return domain.CaptureImport{}, err
+	}
+	defer f.Close()
+
+	importer := w.hm.ImportPcapng
+	if isHashFile(name) {
+		importer = w.hm.ImportHashes
+	}
+	result, err := importer(ctx, name, f)
+	if err != nil {
+		return result, err
+	}
+	for i := range result.Captures {
+		if result.Captures[i].LastSeen.IsZero() {
+			result.Captures[i].LastSeen = modTime
+		}
+	}
+	return result, nil
+}
+
+// isDroppedCapture reports whether name is a file the watcher imports.
+func isDroppedCapture(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case captureExt, legacyCaptureExt, ".cap":
+		return true
+	default:
+		return isHashFile(name)
+	}
+}
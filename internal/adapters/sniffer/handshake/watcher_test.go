@@ -0,0 +1,51 @@
+package handshake
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_Scan(t *testing.T) {
+	hm := NewHandshakeManager(t.TempDir())
+	defer hm.Close()
+	drop := filepath.Join(hm.Dir(), DropDir)
+	watcher := NewWatcher(hm, drop)
+	var imported []domain.CaptureImport
+	watcher.SetOnImport(func(ctx context.Context, result domain.CaptureImport) {
+		imported = append(imported, result)
+	})
+	ctx := context.Background()
+
+	hash := "WPA*01*4d4fe7aac3a2cecab195321ceb99a7d0*fc690c158264*f4747f87f9f4*686173686361742d6573736964***01\n"
+	modTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(drop, "field.hc22000")
+	require.NoError(t, os.WriteFile(path, []byte(hash), 0644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	require.NoError(t, os.WriteFile(filepath.Join(drop, "broken.pcapng"), []byte("garbage"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(drop, "notes.txt"), []byte("ignored"), 0644))
+
+	assert.Empty(t, watcher.Scan(ctx), "files are imported once they stop changing")
+
+	results := watcher.Scan(ctx)
+	require.Len(t, results, 1)
+	assert.Equal(t, imported, results)
+	require.Len(t, results[0].Captures, 1)
+	assert.Equal(t, "hashcat-essid", results[0].Captures[0].SSID)
+	assert.True(t, results[0].Captures[0].LastSeen.Equal(modTime), "dated by the file")
+	assert.True(t, hm.HasPMKID("fc:69:0c:15:82:64"))
+
+	assert.NoFileExists(t, path)
+	assert.FileExists(t, filepath.Join(drop, ImportedDir, "field.hc22000"))
+	assert.FileExists(t, filepath.Join(drop, "broken.pcapng"), "failed imports stay in place")
+	assert.FileExists(t, filepath.Join(hm.Dir(), "field.22000"))
+
+	assert.Empty(t, watcher.Scan(ctx), "failed imports are not retried until they change")
+	assert.Len(t, imported, 1)
+}
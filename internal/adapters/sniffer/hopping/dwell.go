@@ -0,0 +1,155 @@
+package hopping
+
+import (
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// dwellSmoothing is the EWMA weight given to the latest dwell sample.
+	dwellSmoothing = 0.3
+	// minDwellFactor and maxDwellFactor bound the tuned dwell relative to the base dwell.
+	minDwellFactor = 0.5
+	maxDwellFactor = 4.0
+)
+
+// PendingHandshakesFunc reports incomplete handshake sessions per channel.
+type PendingHandshakesFunc func() map[int]int
+
+// DwellController tunes per-channel dwell time from recent activity: busy
+// channels get longer dwell, quiet ones shorter, and channels with outstanding
+// handshake sessions get the maximum so the remaining EAPOL messages are not missed.
+type DwellController struct {
+	mu      sync.Mutex
+	base    time.Duration
+	rates   map[int]float64       // Smoothed frames/s per channel
+	counts  map[int]int           // Frames seen during the current dwell
+	busy    map[int]time.Duration // Airtime of the frames seen during the current dwell
+	usage   map[int]float64       // Smoothed fraction of airtime in use per channel
+	pending PendingHandshakesFunc
+}
+
+// NewDwellController creates a controller around the configured base dwell.
+// pending may be nil.
+func NewDwellController(base time.Duration, pending PendingHandshakesFunc) *DwellController {
+	return &DwellController{
+		base:    base,
+		rates:   make(map[int]float64),
+		counts:  make(map[int]int),
+		busy:    make(map[int]time.Duration),
+		usage:   make(map[int]float64),
+		pending: pending,
+	}
+}
+
+// SetBase changes the base dwell the tuned dwell is relative to.
+func (c *DwellController) SetBase(base time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.base = base
+}
+
+// ObserveFrame counts a frame captured while tuned to channel.
+func (c *DwellController) ObserveFrame(channel int) {
+	c.mu.Lock()
+	c.counts[channel]++
+	c.mu.Unlock()
+}
+
+// ObserveAirtime adds the estimated airtime of a frame captured while tuned
+// to channel.
+func (c *DwellController) ObserveAirtime(channel int, airtime time.Duration) {
+	c.mu.Lock()
+	c.busy[channel] += airtime
+	c.mu.Unlock()
+}
+
+// EndDwell closes a dwell period on channel and folds its frame rate and
+// airtime utilization into the averages.
+func (c *DwellController) EndDwell(channel int, elapsed time.Duration) {
+	if elapsed <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rate := float64(c.counts[channel]) / elapsed.Seconds()
+	delete(c.counts, channel)
+	if prev, ok := c.rates[channel]; ok {
+		rate = dwellSmoothing*rate + (1-dwellSmoothing)*prev
+	}
+	c.rates[channel] = rate
+
+	usage := float64(c.busy[channel]) / float64(elapsed)
+	delete(c.busy, channel)
+	if usage > 1 {
+		usage = 1
+	}
+	if prev, ok := c.usage[channel]; ok {
+		usage = dwellSmoothing*usage + (1-dwellSmoothing)*prev
+	}
+	c.usage[channel] = usage
+}
+
+// Dwell returns how long the hopper should stay on channel.
+func (c *DwellController) Dwell(channel int) time.Duration {
+	var pending map[int]int
+	if c.pending != nil {
+		pending = c.pending()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.dwellLocked(channel, pending)
+}
+
+func (c *DwellController) dwellLocked(channel int, pending map[int]int) time.Duration {
+	if pending[channel] > 0 {
+		return time.Duration(float64(c.base) * maxDwellFactor)
+	}
+
+	rate, ok := c.rates[channel]
+	if !ok || len(c.rates) == 0 {
+		return c.base
+	}
+	var total float64
+	for _, r := range c.rates {
+		total += r
+	}
+	mean := total / float64(len(c.rates))
+	if mean == 0 {
+		return c.base
+	}
+
+	factor := rate / mean
+	if factor < minDwellFactor {
+		factor = minDwellFactor
+	}
+	if factor > maxDwellFactor {
+		factor = maxDwellFactor
+	}
+	return time.Duration(float64(c.base) * factor)
+}
+
+// Plan returns the current dwell decision for each channel.
+func (c *DwellController) Plan(channels []int) []domain.ChannelDwell {
+	var pending map[int]int
+	if c.pending != nil {
+		pending = c.pending()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	plan := make([]domain.ChannelDwell, 0, len(channels))
+	for _, ch := range channels {
+		plan = append(plan, domain.ChannelDwell{
+			Channel:           ch,
+			DwellMs:           c.dwellLocked(ch, pending).Milliseconds(),
+			FrameRate:         c.rates[ch],
+			Utilization:       c.usage[ch] * 100,
+			PendingHandshakes: pending[ch],
+		})
+	}
+	return plan
+}
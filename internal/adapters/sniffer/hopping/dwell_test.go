@@ -0,0 +1,105 @@
+package hopping
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDwellController_AdjustsToActivity(t *testing.T) {
+	base := 100 * time.Millisecond
+	c := NewDwellController(base, nil)
+
+	// No history yet: base dwell
+	if got := c.Dwell(1); got != base {
+		t.Fatalf("Dwell(1) without history = %v, want %v", got, base)
+	}
+
+	// Channel 1 busy, channel 6 average, channel 11 silent
+	for i := 0; i < 90; i++ {
+		c.ObserveFrame(1)
+	}
+	for i := 0; i < 30; i++ {
+		c.ObserveFrame(6)
+	}
+	c.EndDwell(1, time.Second)
+	c.EndDwell(6, time.Second)
+	c.EndDwell(11, time.Second)
+
+	busy, avg, quiet := c.Dwell(1), c.Dwell(6), c.Dwell(11)
+	if !(busy > avg && avg > quiet) {
+		t.Errorf("expected busy > average > quiet, got %v, %v, %v", busy, avg, quiet)
+	}
+	if quiet != time.Duration(float64(base)*minDwellFactor) {
+		t.Errorf("quiet channel dwell = %v, want floor %v", quiet, time.Duration(float64(base)*minDwellFactor))
+	}
+	if busy > time.Duration(float64(base)*maxDwellFactor) {
+		t.Errorf("busy channel dwell %v exceeds ceiling", busy)
+	}
+}
+
+func TestDwellController_PendingHandshakesGetMaxDwell(t *testing.T) {
+	base := 100 * time.Millisecond
+	c := NewDwellController(base, func() map[int]int { return map[int]int{11: 2} })
+
+	c.ObserveFrame(1)
+	c.EndDwell(1, time.Second)
+	c.EndDwell(11, time.Second) // Quiet, but a handshake is in progress
+
+	if got, want := c.Dwell(11), time.Duration(float64(base)*maxDwellFactor); got != want {
+		t.Errorf("Dwell(11) = %v, want %v", got, want)
+	}
+
+	plan := c.Plan([]int{1, 11})
+	if len(plan) != 2 {
+		t.Fatalf("expected 2 plan entries, got %d", len(plan))
+	}
+	if plan[1].PendingHandshakes != 2 || plan[1].DwellMs != 400 {
+		t.Errorf("unexpected plan entry for channel 11: %+v", plan[1])
+	}
+}
+
+func TestHopper_UsesTunedDwell(t *testing.T) {
+	mock := &MockSwitcher{}
+	h := NewHopper("wlan0", []int{1, 6}, 20*time.Millisecond, mock)
+	c := NewDwellController(20*time.Millisecond, func() map[int]int { return map[int]int{1: 1} })
+	h.SetDwellController(c)
+
+	go h.Start()
+	time.Sleep(100 * time.Millisecond)
+	h.Stop()
+
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	// Channel 1 dwells 80ms, channel 6 20ms: at most a couple of hops in 100ms
+	if len(mock.calls) > 4 {
+		t.Errorf("expected tuned dwell to slow hopping, got %d hops: %v", len(mock.calls), mock.calls)
+	}
+}
+
+func TestDwellController_AirtimeUtilization(t *testing.T) {
+	c := NewDwellController(100*time.Millisecond, nil)
+
+	// 250ms of frames during a one second dwell
+	for i := 0; i < 10; i++ {
+		c.ObserveFrame(1)
+		c.ObserveAirtime(1, 25*time.Millisecond)
+	}
+	c.EndDwell(1, time.Second)
+	c.EndDwell(6, time.Second)
+
+	plan := c.Plan([]int{1, 6})
+	if got := plan[0].Utilization; got < 24.9 || got > 25.1 {
+		t.Errorf("channel 1 utilization = %.2f%%, want 25%%", got)
+	}
+	if plan[1].Utilization != 0 {
+		t.Errorf("channel 6 utilization = %.2f%%, want 0", plan[1].Utilization)
+	}
+
+	// Saturated dwell: utilization is capped and smoothed
+	c.ObserveAirtime(1, 2*time.Second)
+	c.EndDwell(1, time.Second)
+	want := (dwellSmoothing*1 + (1-dwellSmoothing)*0.25) * 100
+	if got := c.Plan([]int{1})[0].Utilization; got < want-0.1 || got > want+0.1 {
+		t.Errorf("smoothed utilization = %.2f%%, want %.2f%%", got, want)
+	}
+}
@@ -3,7 +3,10 @@ package hopping
 import (
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
 )
 
 // ChannelHopper handles switching WiFi channels.
@@ -19,6 +22,11 @@ type ChannelHopper struct {
 	currentIndex int // For Round Robin
 	errorCount   int
 	state        AtomicState
+
+	// Dwell auto-tuning (optional)
+	dwell      *DwellController
+	current    atomic.Int32 // Channel the hopper last tuned to, 0 when locked
+	dwellStart time.Time
 }
 
 // NewHopper creates a new ChannelHopper.
@@ -56,6 +64,74 @@ func (h *ChannelHopper) GetChannels() []int {
 	return result
 }
 
+// SetDwellController enables per-channel dwell auto-tuning. Without one the
+// hopper uses the static Delay for every channel.
+func (h *ChannelHopper) SetDwellController(c *DwellController) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.dwell = c
+}
+
+// ObserveFrame attributes a captured frame and its estimated airtime to the
+// channel the hopper is on.
+func (h *ChannelHopper) ObserveFrame(airtime time.Duration) {
+	ch := int(h.current.Load())
+	if ch == 0 {
+		return
+	}
+	h.mu.RLock()
+	c := h.dwell
+	h.mu.RUnlock()
+	if c != nil {
+		c.ObserveFrame(ch)
+		c.ObserveAirtime(ch, airtime)
+	}
+}
+
+// DwellPlan returns the dwell time the hopper will use on each channel.
+func (h *ChannelHopper) DwellPlan() []domain.ChannelDwell {
+	h.mu.RLock()
+	c, delay := h.dwell, h.Delay
+	channels := make([]int, len(h.Channels))
+	copy(channels, h.Channels)
+	h.mu.RUnlock()
+
+	if c != nil {
+		return c.Plan(channels)
+	}
+	plan := make([]domain.ChannelDwell, 0, len(channels))
+	for _, ch := range channels {
+		plan = append(plan, domain.ChannelDwell{Channel: ch, DwellMs: delay.Milliseconds()})
+	}
+	return plan
+}
+
+// SetDelay changes the dwell time used without auto-tuning.
+func (h *ChannelHopper) SetDelay(delay time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Delay = delay
+}
+
+// nextDelay returns the dwell for the channel just tuned to.
+func (h *ChannelHopper) nextDelay(channel int) time.Duration {
+	h.mu.RLock()
+	c, delay := h.dwell, h.Delay
+	h.mu.RUnlock()
+	if c == nil || channel == 0 {
+		return delay
+	}
+	return c.Dwell(channel)
+}
+
+// endDwellLocked closes the dwell period of the current channel. Caller holds h.mu.
+func (h *ChannelHopper) endDwellLocked() {
+	prev := int(h.current.Swap(0))
+	if h.dwell != nil && prev != 0 {
+		h.dwell.EndDwell(prev, time.Since(h.dwellStart))
+	}
+}
+
 // GetState returns the current state of the hopper.
 func (h *ChannelHopper) GetState() HopperState {
 	return h.state.Get()
@@ -89,7 +165,9 @@ func (h *ChannelHopper) Start() {
 	defer ticker.Stop()
 
 	// Initial hop if we can
-	h.hop()
+	if ch := h.hop(); ch != 0 {
+		ticker.Reset(h.nextDelay(ch))
+	}
 
 	for {
 		select {
@@ -105,7 +183,7 @@ func (h *ChannelHopper) Start() {
 				case <-time.After(d):
 					log.Printf("Hopper on %s RESUMING", h.Interface)
 					h.state.Set(StateHopping)
-					ticker.Reset(h.Delay)
+					ticker.Reset(h.nextDelay(int(h.current.Load())))
 				case <-h.stopChan:
 					return
 				}
@@ -113,7 +191,9 @@ func (h *ChannelHopper) Start() {
 		case <-ticker.C:
 			// Only hop if we are in Hopping state
 			if h.state.Get() == StateHopping {
-				h.hop()
+				if ch := h.hop(); ch != 0 {
+					ticker.Reset(h.nextDelay(ch))
+				}
 			}
 		}
 	}
@@ -138,6 +218,7 @@ func (h *ChannelHopper) Lock(channel int) error {
 
 	// Update state
 	h.state.Set(StateLocked)
+	h.endDwellLocked()
 
 	// Force switch
 	if err := h.switcher.SetChannel(h.Interface, channel); err != nil {
@@ -160,7 +241,8 @@ func (h *ChannelHopper) Unlock() {
 	}
 }
 
-func (h *ChannelHopper) hop() {
+// hop switches to the next channel and returns it, or 0 if no switch happened.
+func (h *ChannelHopper) hop() int {
 	// Synchronization:
 	// We hold the lock to check state AND switch channel to prevent race with Lock()
 	h.mu.Lock()
@@ -168,11 +250,11 @@ func (h *ChannelHopper) hop() {
 
 	// Double check state inside lock
 	if h.state.Get() != StateHopping {
-		return
+		return 0
 	}
 
 	if len(h.Channels) == 0 {
-		return
+		return 0
 	}
 
 	// Round Robin logic
@@ -188,6 +270,7 @@ func (h *ChannelHopper) hop() {
 	}
 
 	// Perform Switch
+	h.endDwellLocked()
 	start := time.Now()
 	if err := h.switcher.SetChannel(h.Interface, ch); err != nil {
 		h.errorCount++
@@ -210,5 +293,10 @@ func (h *ChannelHopper) hop() {
 
 		// Optional: Track hop duration logic if needed
 		_ = time.Since(start)
+
+		h.current.Store(int32(ch))
+		h.dwellStart = time.Now()
+		return ch
 	}
+	return 0
 }
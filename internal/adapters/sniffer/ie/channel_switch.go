@@ -0,0 +1,23 @@
+package ie
+
+// TagChannelSwitch is the Channel Switch Announcement element (802.11h).
+const TagChannelSwitch = 37
+
+// ChannelSwitch is an announced move of a BSS to another channel.
+type ChannelSwitch struct {
+	Mode       int // 1 if stations must stop transmitting until the switch
+	NewChannel int
+	Count      int // Beacon intervals left before the switch
+}
+
+// ParseChannelSwitch parses the Channel Switch Announcement element (Tag 37).
+func ParseChannelSwitch(data []byte) (ChannelSwitch, error) {
+	if len(data) < 3 {
+		return ChannelSwitch{}, ErrMalformedIE
+	}
+	return ChannelSwitch{
+		Mode:       int(data[0]),
+		NewChannel: int(data[1]),
+		Count:      int(data[2]),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package ie
+
+// Regulatory IE Tags
+const (
+	TagCountry         = 7
+	TagPowerConstraint = 32
+)
+
+// firstOperatingExtension is the lowest first-channel value of an operating
+// extension triplet (802.11d); those carry operating classes, not channels.
+const firstOperatingExtension = 201
+
+// CountryInfo holds the regulatory information of a Country element.
+type CountryInfo struct {
+	Code        string // ISO 3166-1 alpha-2 country code
+	Environment byte   // ' ' any, 'O' outdoor, 'I' indoor, 'X' non-country entity
+	Channels    []CountryChannels
+}
+
+// CountryChannels is a subband triplet of the Country element.
+type CountryChannels struct {
+	FirstChannel int
+	NumChannels  int
+	MaxPower     int // Maximum transmit power in dBm
+}
+
+// Contains reports whether the subband covers the channel. Channels are
+// consecutive in 2.4 GHz and 4 numbers apart in 5 GHz.
+func (c CountryChannels) Contains(channel int) bool {
+	step := 1
+	if c.FirstChannel > 14 {
+		step = 4
+	}
+	last := c.FirstChannel + (c.NumChannels-1)*step
+	return channel >= c.FirstChannel && channel <= last && (channel-c.FirstChannel)%step == 0
+}
+
+// MaxPowerFor returns the maximum transmit power advertised for a channel.
+func (c CountryInfo) MaxPowerFor(channel int) (int, bool) {
+	for _, sub := range c.Channels {
+		if sub.Contains(channel) {
+			return sub.MaxPower, true
+		}
+	}
+	return 0, false
+}
+
+// ParseCountry parses the Country element (Tag 7).
+func ParseCountry(data []byte) (CountryInfo, error) {
+	if len(data) < 3 {
+		return CountryInfo{}, ErrMalformedIE
+	}
+
+	info := CountryInfo{
+		Code:        safeString(data[0:2]),
+		Environment: data[2],
+	}
+	// Subband triplets follow; an odd trailing byte is padding
+	for offset := 3; offset+3 <= len(data); offset += 3 {
+		first := int(data[offset])
+		if first >= firstOperatingExtension {
+			continue
+		}
+		info.Channels = append(info.Channels, CountryChannels{
+			FirstChannel: first,
+			NumChannels:  int(data[offset+1]),
+			MaxPower:     int(int8(data[offset+2])),
+		})
+	}
+	return info, nil
+}
+
+// ParsePowerConstraint parses the Power Constraint element (Tag 32) and
+// returns the local power constraint in dB, to be subtracted from the
+// regulatory maximum of the operating channel.
+func ParsePowerConstraint(data []byte) (int, error) {
+	if len(data) < 1 {
+		return 0, ErrMalformedIE
+	}
+	return int(data[0]), nil
+}
@@ -0,0 +1,49 @@
+package ie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCountry(t *testing.T) {
+	data := []byte{
+		'E', 'S', ' ',
+		1, 13, 20, // 2.4 GHz: channels 1-13 at 20 dBm
+		36, 4, 23, // 5 GHz: channels 36-48 at 23 dBm
+		201, 1, 0, // Operating extension, ignored
+		0, // Padding
+	}
+
+	info, err := ParseCountry(data)
+	require.NoError(t, err)
+	assert.Equal(t, "ES", info.Code)
+	assert.Equal(t, byte(' '), info.Environment)
+	require.Len(t, info.Channels, 2)
+
+	power, ok := info.MaxPowerFor(11)
+	assert.True(t, ok)
+	assert.Equal(t, 20, power)
+
+	power, ok = info.MaxPowerFor(44)
+	assert.True(t, ok)
+	assert.Equal(t, 23, power)
+
+	_, ok = info.MaxPowerFor(42) // Between 5 GHz channels
+	assert.False(t, ok)
+	_, ok = info.MaxPowerFor(149)
+	assert.False(t, ok)
+
+	_, err = ParseCountry([]byte{'E', 'S'})
+	assert.ErrorIs(t, err, ErrMalformedIE)
+}
+
+func TestParsePowerConstraint(t *testing.T) {
+	constraint, err := ParsePowerConstraint([]byte{3})
+	require.NoError(t, err)
+	assert.Equal(t, 3, constraint)
+
+	_, err = ParsePowerConstraint(nil)
+	assert.ErrorIs(t, err, ErrMalformedIE)
+}
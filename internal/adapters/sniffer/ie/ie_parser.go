@@ -8,8 +8,10 @@ import (
 // Common IE Tags
 const (
 	TagSSID           = 0
+	TagSupportedRates = 1
 	TagDSParameterSet = 3
 	TagRSN            = 48
+	TagExtendedRates  = 50
 	TagVendorSpecific = 221 // 0xDD
 )
 
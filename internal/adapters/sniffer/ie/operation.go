@@ -0,0 +1,123 @@
+package ie
+
+// Operation IE Tags
+const (
+	TagHTOperation  = 61
+	TagVHTOperation = 192
+	TagExtension    = 255
+
+	// ExtTagHEOperation is the Element ID Extension of the HE Operation element
+	ExtTagHEOperation = 36
+)
+
+// ChannelOperation describes the channel a BSS operates on, as advertised in
+// its HT/VHT/HE Operation elements.
+type ChannelOperation struct {
+	PrimaryChannel int // Primary 20 MHz channel (0 if not advertised)
+	Width          int // Operating width in MHz: 20, 40, 80 or 160 (0 if not advertised)
+	CenterChannel  int // Center channel of the whole operating width
+}
+
+// ParseHTOperation parses the HT Operation element (Tag 61).
+func ParseHTOperation(data []byte) (ChannelOperation, error) {
+	if len(data) < 2 {
+		return ChannelOperation{}, ErrMalformedIE
+	}
+
+	primary := int(data[0])
+	op := ChannelOperation{PrimaryChannel: primary, Width: 20, CenterChannel: primary}
+
+	// HT Operation Information, octet 1: bits 0-1 secondary channel offset,
+	// bit 2 STA channel width (any width allowed)
+	if data[1]&0x04 == 0 {
+		return op, nil
+	}
+	switch data[1] & 0x03 {
+	case 1: // Secondary channel above
+		op.Width, op.CenterChannel = 40, primary+2
+	case 3: // Secondary channel below
+		op.Width, op.CenterChannel = 40, primary-2
+	}
+	return op, nil
+}
+
+// ParseVHTOperation parses the VHT Operation element (Tag 192). A zero Width
+// means the BSS operates at 20/40 MHz and the HT Operation element applies.
+func ParseVHTOperation(data []byte) (ChannelOperation, error) {
+	if len(data) < 3 {
+		return ChannelOperation{}, ErrMalformedIE
+	}
+
+	switch data[0] {
+	case 1: // 80, 160 or 80+80 MHz, told apart by the segment indices
+		return vhtWidth(int(data[1]), int(data[2])), nil
+	case 2: // 160 MHz (deprecated signalling)
+		return ChannelOperation{Width: 160, CenterChannel: int(data[1])}, nil
+	case 3: // 80+80 MHz (deprecated signalling)
+		return ChannelOperation{Width: 160, CenterChannel: int(data[1])}, nil
+	}
+	return ChannelOperation{}, nil
+}
+
+// ParseHEOperation parses the body of the HE Operation element (Tag 255,
+// extension 36), without the extension ID. Only the 6 GHz Operation
+// Information carries a width; below 6 GHz the HT/VHT elements apply and a
+// zero Width is returned.
+func ParseHEOperation(data []byte) (ChannelOperation, error) {
+	// HE Operation Parameters (3) + BSS Color (1) + Basic HE-MCS And NSS Set (2)
+	if len(data) < 6 {
+		return ChannelOperation{}, ErrMalformedIE
+	}
+
+	params := int(data[0]) | int(data[1])<<8 | int(data[2])<<16
+	offset := 6
+	if params&(1<<14) != 0 { // VHT Operation Information Present
+		offset += 3
+	}
+	if params&(1<<15) != 0 { // Co-Hosted BSS
+		offset++
+	}
+	if params&(1<<17) == 0 { // 6 GHz Operation Information Present
+		return ChannelOperation{}, nil
+	}
+
+	// Primary Channel (1), Control (1), CCFS0 (1), CCFS1 (1), Minimum Rate (1)
+	if len(data) < offset+5 {
+		return ChannelOperation{}, ErrMalformedIE
+	}
+	info := data[offset:]
+	primary := int(info[0])
+
+	var op ChannelOperation
+	switch info[1] & 0x03 {
+	case 0:
+		op = ChannelOperation{Width: 20, CenterChannel: int(info[2])}
+	case 1:
+		op = ChannelOperation{Width: 40, CenterChannel: int(info[2])}
+	case 2:
+		op = ChannelOperation{Width: 80, CenterChannel: int(info[2])}
+	case 3:
+		op = vhtWidth(int(info[2]), int(info[3]))
+		op.Width = 160 // Segment 1 may be left zero when the width is explicit
+	}
+	op.PrimaryChannel = primary
+	return op, nil
+}
+
+// vhtWidth resolves the width from Channel Center Frequency Segments 0 and 1.
+// A contiguous 160 MHz BSS puts its center in segment 1, eight channels away
+// from the 80 MHz center of segment 0; 80+80 segments are further apart.
+func vhtWidth(ccfs0, ccfs1 int) ChannelOperation {
+	if ccfs1 == 0 {
+		return ChannelOperation{Width: 80, CenterChannel: ccfs0}
+	}
+	diff := ccfs1 - ccfs0
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff == 8 {
+		return ChannelOperation{Width: 160, CenterChannel: ccfs1}
+	}
+	// 80+80: report the primary segment
+	return ChannelOperation{Width: 160, CenterChannel: ccfs0}
+}
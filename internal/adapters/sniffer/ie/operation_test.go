@@ -0,0 +1,92 @@
+package ie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHTOperation(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected ChannelOperation
+	}{
+		{"20 MHz", []byte{6, 0x00, 0, 0, 0}, ChannelOperation{PrimaryChannel: 6, Width: 20, CenterChannel: 6}},
+		{"40 MHz above", []byte{36, 0x05, 0, 0, 0}, ChannelOperation{PrimaryChannel: 36, Width: 40, CenterChannel: 38}},
+		{"40 MHz below", []byte{11, 0x07, 0, 0, 0}, ChannelOperation{PrimaryChannel: 11, Width: 40, CenterChannel: 9}},
+		{"Offset without width", []byte{1, 0x01, 0, 0, 0}, ChannelOperation{PrimaryChannel: 1, Width: 20, CenterChannel: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := ParseHTOperation(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, op)
+		})
+	}
+
+	_, err := ParseHTOperation([]byte{6})
+	assert.ErrorIs(t, err, ErrMalformedIE)
+}
+
+func TestParseVHTOperation(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		expected ChannelOperation
+	}{
+		{"20/40 MHz", []byte{0, 0, 0, 0, 0}, ChannelOperation{}},
+		{"80 MHz", []byte{1, 42, 0, 0, 0}, ChannelOperation{Width: 80, CenterChannel: 42}},
+		{"160 MHz", []byte{1, 42, 50, 0, 0}, ChannelOperation{Width: 160, CenterChannel: 50}},
+		{"80+80 MHz", []byte{1, 42, 155, 0, 0}, ChannelOperation{Width: 160, CenterChannel: 42}},
+		{"Deprecated 160 MHz", []byte{2, 50, 0, 0, 0}, ChannelOperation{Width: 160, CenterChannel: 50}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, err := ParseVHTOperation(tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, op)
+		})
+	}
+
+	_, err := ParseVHTOperation([]byte{1, 42})
+	assert.ErrorIs(t, err, ErrMalformedIE)
+}
+
+func TestParseHEOperation(t *testing.T) {
+	t.Run("Below 6 GHz", func(t *testing.T) {
+		op, err := ParseHEOperation([]byte{0x00, 0x00, 0x00, 0x01, 0xfc, 0xff})
+		require.NoError(t, err)
+		assert.Equal(t, ChannelOperation{}, op)
+	})
+
+	t.Run("6 GHz 160 MHz", func(t *testing.T) {
+		data := []byte{
+			0x00, 0x00, 0x02, // 6 GHz Operation Information Present
+			0x01, 0xfc, 0xff,
+			37, 0x03, 39, 47, 0x00, // Primary, Control (160), CCFS0, CCFS1, Min Rate
+		}
+		op, err := ParseHEOperation(data)
+		require.NoError(t, err)
+		assert.Equal(t, ChannelOperation{PrimaryChannel: 37, Width: 160, CenterChannel: 47}, op)
+	})
+
+	t.Run("6 GHz after optional fields", func(t *testing.T) {
+		data := []byte{
+			0x00, 0xc0, 0x02, // VHT Info, Co-Hosted BSS and 6 GHz Info present
+			0x01, 0xfc, 0xff,
+			0, 0, 0, // VHT Operation Information
+			0,                   // Max Co-Hosted BSSID Indicator
+			5, 0x02, 7, 0, 0x00, // Primary, Control (80), CCFS0, CCFS1, Min Rate
+		}
+		op, err := ParseHEOperation(data)
+		require.NoError(t, err)
+		assert.Equal(t, ChannelOperation{PrimaryChannel: 5, Width: 80, CenterChannel: 7}, op)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		_, err := ParseHEOperation([]byte{0x00, 0x00, 0x02, 0x01, 0xfc, 0xff, 37})
+		assert.ErrorIs(t, err, ErrMalformedIE)
+	})
+}
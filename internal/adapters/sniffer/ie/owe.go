@@ -0,0 +1,24 @@
+package ie
+
+import "net"
+
+// OWETransitionInfo is the OWE Transition Mode element (Wi-Fi Alliance
+// vendor element 50:6F:9A type 0x1C), which pairs an open BSS with the
+// hidden OWE BSS serving the same network, each pointing at the other.
+type OWETransitionInfo struct {
+	BSSID string // The counterpart BSS
+	SSID  string
+}
+
+// ParseOWETransition parses an OWE Transition Mode element after its OUI
+// and type. Structure: BSSID (6) | SSID length (1) | SSID | optional
+// operating class and channel.
+func ParseOWETransition(data []byte) (OWETransitionInfo, error) {
+	if len(data) < 7 || len(data) < 7+int(data[6]) {
+		return OWETransitionInfo{}, ErrMalformedIE
+	}
+	return OWETransitionInfo{
+		BSSID: net.HardwareAddr(data[:6]).String(),
+		SSID:  string(data[7 : 7+int(data[6])]),
+	}, nil
+}
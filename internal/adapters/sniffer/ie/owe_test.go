@@ -0,0 +1,20 @@
+package ie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOWETransition(t *testing.T) {
+	data := []byte{0x02, 0x11, 0x22, 0x33, 0x44, 0x55, 4, 'o', 'w', 'e', '1', 81, 6}
+
+	info, err := ParseOWETransition(data)
+	require.NoError(t, err)
+	assert.Equal(t, "02:11:22:33:44:55", info.BSSID)
+	assert.Equal(t, "owe1", info.SSID)
+
+	_, err = ParseOWETransition(data[:9]) // SSID cut short
+	assert.ErrorIs(t, err, ErrMalformedIE)
+}
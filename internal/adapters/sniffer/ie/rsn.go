@@ -139,3 +139,75 @@ func parseRSNCapabilities(caps uint16) RSNCapabilities {
 		PeerKeyEnabled:   (caps & 0x0200) != 0,
 	}
 }
+
+// BuildRSN serializes an RSN Information Element body (without tag/length).
+// It is the inverse of ParseRSN for the well-known suites; unknown names are skipped.
+func BuildRSN(rsn RSNInfo) []byte {
+	version := rsn.Version
+	if version == 0 {
+		version = 1
+	}
+
+	buf := binary.LittleEndian.AppendUint16(nil, version)
+
+	group, ok := cipherSuiteType(rsn.GroupCipher)
+	if !ok {
+		group = 4 // CCMP
+	}
+	buf = append(buf, 0x00, 0x0f, 0xac, group)
+
+	buf = appendSuites(buf, rsn.PairwiseCiphers, cipherSuiteType)
+	buf = appendSuites(buf, rsn.AKMSuites, akmSuiteType)
+
+	var caps uint16
+	if rsn.Capabilities.PreAuth {
+		caps |= 0x0001
+	}
+	if rsn.Capabilities.NoPairwise {
+		caps |= 0x0002
+	}
+	caps |= uint16(rsn.Capabilities.PTKSAReplayCount&0x03) << 2
+	caps |= uint16(rsn.Capabilities.GTKSAReplayCount&0x03) << 4
+	if rsn.Capabilities.MFPRequired {
+		caps |= 0x0040
+	}
+	if rsn.Capabilities.MFPCapable {
+		caps |= 0x0080
+	}
+	if rsn.Capabilities.PeerKeyEnabled {
+		caps |= 0x0200
+	}
+	return binary.LittleEndian.AppendUint16(buf, caps)
+}
+
+func appendSuites(buf []byte, names []string, lookup func(string) (byte, bool)) []byte {
+	var types []byte
+	for _, name := range names {
+		if t, ok := lookup(name); ok {
+			types = append(types, t)
+		}
+	}
+	buf = binary.LittleEndian.AppendUint16(buf, uint16(len(types)))
+	for _, t := range types {
+		buf = append(buf, 0x00, 0x0f, 0xac, t)
+	}
+	return buf
+}
+
+func cipherSuiteType(name string) (byte, bool) {
+	for t := byte(0); t <= 10; t++ {
+		if parseCipherSuite([]byte{0x00, 0x0f, 0xac, t}) == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+func akmSuiteType(name string) (byte, bool) {
+	for t := byte(0); t <= 18; t++ {
+		if parseAKMSuite([]byte{0x00, 0x0f, 0xac, t}) == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
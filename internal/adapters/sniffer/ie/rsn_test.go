@@ -0,0 +1,39 @@
+package ie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRSN_RoundTrip(t *testing.T) {
+	in := RSNInfo{
+		Version:         1,
+		GroupCipher:     "CCMP",
+		PairwiseCiphers: []string{"CCMP", "GCMP-256"},
+		AKMSuites:       []string{"SAE", "FT-SAE"},
+		Capabilities: RSNCapabilities{
+			MFPRequired:      true,
+			MFPCapable:       true,
+			PTKSAReplayCount: 2,
+		},
+	}
+
+	out, err := ParseRSN(BuildRSN(in))
+	require.NoError(t, err)
+	assert.Equal(t, in, *out)
+}
+
+func TestBuildRSN_WPA2PSK(t *testing.T) {
+	data := BuildRSN(RSNInfo{GroupCipher: "CCMP", PairwiseCiphers: []string{"CCMP"}, AKMSuites: []string{"PSK"}})
+
+	expected := []byte{
+		0x01, 0x00, // Version 1
+		0x00, 0x0f, 0xac, 0x04, // Group: CCMP
+		0x01, 0x00, 0x00, 0x0f, 0xac, 0x04, // Pairwise: CCMP
+		0x01, 0x00, 0x00, 0x0f, 0xac, 0x02, // AKM: PSK
+		0x00, 0x00, // Capabilities
+	}
+	assert.Equal(t, expected, data)
+}
@@ -0,0 +1,171 @@
+package injection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket/pcap"
+)
+
+// DefaultAckWindow is how long after injection an ACK still counts as
+// confirming delivery. ACKs follow after a SIFS, the slack covers driver
+// queueing and capture timestamp jitter.
+const DefaultAckWindow = 20 * time.Millisecond
+
+// Ack is an 802.11 ACK frame seen on air.
+type Ack struct {
+	Receiver net.HardwareAddr
+	At       time.Time
+}
+
+// WatchAcks captures ACK frames on the injector's interface and sends them to
+// acks until ctx is cancelled. It uses its own pcap handle.
+func (i *Injector) WatchAcks(ctx context.Context, acks chan<- Ack) error {
+	handle, err := pcap.OpenLive(i.Interface, 256, true, 100*time.Millisecond)
+	if err != nil {
+		return fmt.Errorf("failed to open capture on %s: %w", i.Interface, err)
+	}
+	defer handle.Close()
+
+	if err := handle.SetBPFFilter("type ctl subtype ack"); err != nil {
+		return fmt.Errorf("failed to set BPF filter: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		data, ci, err := handle.ReadPacketData()
+		if err == pcap.NextErrorTimeoutExpired {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		ra, ok := ackReceiver(data)
+		if !ok {
+			continue
+		}
+		select {
+		case acks <- Ack{Receiver: ra, At: ci.Timestamp}:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// ackReceiver returns the receiver address of a RadioTap-framed ACK. The raw
+// bytes are parsed directly since gopacket mangles short control frames.
+func ackReceiver(data []byte) (net.HardwareAddr, bool) {
+	if len(data) < 4 {
+		return nil, false
+	}
+	rtLen := int(binary.LittleEndian.Uint16(data[2:4]))
+	if len(data) < rtLen+10 || data[rtLen] != 0xd4 {
+		return nil, false
+	}
+	frame := data[rtLen:]
+	return net.HardwareAddr(append([]byte{}, frame[4:10]...)), true
+}
+
+// dot11Addresses returns the receiver and transmitter of a RadioTap-framed
+// management or data frame.
+func dot11Addresses(pkt []byte) (ra, ta net.HardwareAddr, ok bool) {
+	if len(pkt) < 4 {
+		return nil, nil, false
+	}
+	rtLen := int(binary.LittleEndian.Uint16(pkt[2:4]))
+	if len(pkt) < rtLen+16 {
+		return nil, nil, false
+	}
+	frame := pkt[rtLen:]
+	return net.HardwareAddr(frame[4:10]), net.HardwareAddr(frame[10:16]), true
+}
+
+type pendingFrame struct {
+	ta net.HardwareAddr
+	at time.Time
+}
+
+// AckTracker matches injected unicast frames with the ACKs the receiver
+// sends back to the (spoofed) transmitter address, yielding how many frames
+// actually reached their target.
+type AckTracker struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending []pendingFrame
+	acked   int
+	unacked int
+}
+
+// NewAckTracker creates a tracker confirming delivery within window.
+func NewAckTracker(window time.Duration) *AckTracker {
+	if window <= 0 {
+		window = DefaultAckWindow
+	}
+	return &AckTracker{window: window}
+}
+
+// TrackInjected records a frame injected at the given time. Group-addressed
+// frames are never acknowledged and are ignored.
+func (t *AckTracker) TrackInjected(pkt []byte, at time.Time) {
+	ra, ta, ok := dot11Addresses(pkt)
+	if !ok || ra[0]&0x01 != 0 {
+		return
+	}
+	t.mu.Lock()
+	t.pending = append(t.pending, pendingFrame{ta: append(net.HardwareAddr{}, ta...), at: at})
+	t.mu.Unlock()
+}
+
+// Observe matches an ACK against the oldest pending frame sent by its receiver.
+// Returns whether the ACK confirmed a frame.
+func (t *AckTracker) Observe(ack Ack) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for idx, p := range t.pending {
+		if !bytes.Equal(p.ta, ack.Receiver) {
+			continue
+		}
+		delay := ack.At.Sub(p.at)
+		if delay < -t.window || delay > t.window {
+			continue
+		}
+		t.pending = append(t.pending[:idx], t.pending[idx+1:]...)
+		t.acked++
+		return true
+	}
+	return false
+}
+
+// Expire counts frames still unacknowledged after the window as lost.
+func (t *AckTracker) Expire(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	kept := t.pending[:0]
+	for _, p := range t.pending {
+		if now.Sub(p.at) > t.window {
+			t.unacked++
+		} else {
+			kept = append(kept, p)
+		}
+	}
+	t.pending = kept
+}
+
+// Counts returns the delivered and unacknowledged frame totals.
+func (t *AckTracker) Counts() (acked, unacked int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.acked, t.unacked
+}
@@ -0,0 +1,58 @@
+package injection
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAckReceiver(t *testing.T) {
+	ra := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	// 8-byte RadioTap header + ACK (FC, duration, RA)
+	ack := append([]byte{0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0xd4, 0x00, 0x00, 0x00}, ra...)
+
+	got, ok := ackReceiver(ack)
+	require.True(t, ok)
+	assert.Equal(t, ra, got)
+
+	cts := append([]byte{}, ack...)
+	cts[8] = 0xc4
+	_, ok = ackReceiver(cts)
+	assert.False(t, ok, "CTS is not an ACK")
+
+	_, ok = ackReceiver(ack[:12])
+	assert.False(t, ok, "truncated frame")
+}
+
+func TestAckTracker(t *testing.T) {
+	client := net.HardwareAddr{0x00, 0xaa, 0xbb, 0xcc, 0xdd, 0xee}
+	spoofed := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	broadcast := net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+	unicast, err := SerializeDeauthPacket(client, spoofed, spoofed, 7, 1)
+	require.NoError(t, err)
+	group, err := SerializeDeauthPacket(broadcast, spoofed, spoofed, 7, 2)
+	require.NoError(t, err)
+
+	tracker := NewAckTracker(20 * time.Millisecond)
+	start := time.Now()
+
+	tracker.TrackInjected(unicast, start)
+	tracker.TrackInjected(unicast, start.Add(100*time.Millisecond))
+	tracker.TrackInjected(group, start) // Never acknowledged, not tracked
+
+	// ACK for another transmitter does not count
+	assert.False(t, tracker.Observe(Ack{Receiver: client, At: start.Add(time.Millisecond)}))
+	// ACK to the spoofed source right after the first frame confirms it
+	assert.True(t, tracker.Observe(Ack{Receiver: spoofed, At: start.Add(time.Millisecond)}))
+	// Late ACK outside the window does not confirm the second frame
+	assert.False(t, tracker.Observe(Ack{Receiver: spoofed, At: start.Add(200 * time.Millisecond)}))
+
+	tracker.Expire(start.Add(time.Second))
+	acked, unacked := tracker.Counts()
+	assert.Equal(t, 1, acked)
+	assert.Equal(t, 1, unacked)
+}
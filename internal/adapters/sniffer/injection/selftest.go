@@ -0,0 +1,143 @@
+package injection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/telemetry"
+)
+
+// RunInjectionTest checks whether frames injected on the interface actually
+// reach the air, in the spirit of "aireplay-ng -9": it broadcasts probe requests
+// from a throwaway MAC and counts the probe responses addressed back to it.
+// The caller is responsible for locking the channel if needed.
+func (i *Injector) RunInjectionTest(ctx context.Context, config domain.InjectionTestConfig) (domain.InjectionTestResult, error) {
+	handle, err := pcap.OpenLive(i.Interface, 65536, true, pcap.BlockForever)
+	if err != nil {
+		return domain.InjectionTestResult{}, fmt.Errorf("failed to open capture on %s: %w", i.Interface, err)
+	}
+	defer handle.Close()
+
+	if err := handle.SetBPFFilter("type mgt subtype probe-resp"); err != nil {
+		return domain.InjectionTestResult{}, fmt.Errorf("failed to set BPF filter: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	packets := gopacket.NewPacketSource(handle, handle.LinkType()).Packets()
+	return i.runInjectionTest(ctx, config, randomMAC(), packets), nil
+}
+
+// runInjectionTest spreads the probes over the first half of the test window
+// and keeps listening for late responses until ctx is done.
+func (i *Injector) runInjectionTest(ctx context.Context, config domain.InjectionTestConfig, src net.HardwareAddr, packets <-chan gopacket.Packet) domain.InjectionTestResult {
+	start := time.Now()
+	result := domain.InjectionTestResult{
+		Interface: i.Interface,
+		Channel:   config.Channel,
+		SourceMAC: src.String(),
+	}
+
+	broadcast := net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	interval := config.Timeout / 2 / time.Duration(config.Probes)
+	if interval <= 0 {
+		interval = time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	aps := make(map[string]*domain.InjectionTestAP)
+
+	send := func() {
+		pkt, err := SerializeProbeRequestFrom(src, broadcast, "", i.nextSeq())
+		if err == nil {
+			err = i.Inject(pkt)
+		}
+		if err != nil {
+			result.SendErrors++
+			telemetry.InjectionErrors.WithLabelValues(i.Interface, "injection_test").Inc()
+			return
+		}
+		result.ProbesSent++
+		telemetry.InjectionsTotal.WithLabelValues(i.Interface, "injection_test").Inc()
+	}
+	send()
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-ticker.C:
+			if result.ProbesSent+result.SendErrors < config.Probes {
+				send()
+			}
+		case packet, ok := <-packets:
+			if !ok {
+				packets = nil // Keep sending until the deadline
+				continue
+			}
+			dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+			if !ok || dot11.Type != layers.Dot11TypeMgmtProbeResp || !bytes.Equal(dot11.Address1, src) {
+				continue
+			}
+
+			result.Responses++
+			bssid := dot11.Address3.String()
+			ap, exists := aps[bssid]
+			if !exists {
+				ap = &domain.InjectionTestAP{BSSID: bssid, SSID: probeRespSSID(dot11.Payload)}
+				aps[bssid] = ap
+			}
+			ap.Responses++
+			if rt, ok := packet.Layer(layers.LayerTypeRadioTap).(*layers.RadioTap); ok {
+				ap.RSSI = int(rt.DBMAntennaSignal)
+			}
+		}
+	}
+
+	result.Duration = time.Since(start)
+	for _, ap := range aps {
+		if result.ProbesSent > 0 {
+			ap.SuccessRate = float64(ap.Responses) / float64(result.ProbesSent)
+			if ap.SuccessRate > 1 {
+				ap.SuccessRate = 1
+			}
+		}
+		if ap.SuccessRate > result.SuccessRate {
+			result.SuccessRate = ap.SuccessRate
+		}
+		result.APs = append(result.APs, *ap)
+	}
+	sort.Slice(result.APs, func(a, b int) bool { return result.APs[a].Responses > result.APs[b].Responses })
+
+	result.InjectionWorks = result.Responses > 0
+	switch {
+	case result.ProbesSent == 0:
+		result.Message = "No frames could be injected; check driver and monitor mode support"
+	case result.InjectionWorks:
+		result.Message = fmt.Sprintf("Injection is working! %d AP(s) answered", len(result.APs))
+	default:
+		result.Message = "No probe responses received; injection may not work or no AP is in range on this channel"
+	}
+
+	return result
+}
+
+// probeRespSSID extracts the SSID from a probe response body (after the
+// 12-byte fixed fields).
+func probeRespSSID(body []byte) string {
+	if len(body) < 12 {
+		return ""
+	}
+	return probeSSID(body[12:])
+}
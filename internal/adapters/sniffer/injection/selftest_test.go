@@ -0,0 +1,60 @@
+package injection
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunInjectionTest(t *testing.T) {
+	src, _ := net.ParseMAC("02:11:22:33:44:55")
+	other, _ := net.ParseMAC("02:99:99:99:99:99")
+	bssid, _ := net.ParseMAC("00:11:22:33:44:01")
+
+	config := domain.InjectionTestConfig{Probes: 4, Timeout: 200 * time.Millisecond}
+
+	t.Run("Counts responses addressed to the test MAC", func(t *testing.T) {
+		mock := NewMockInjector()
+		inj := &Injector{Interface: "wlan0"}
+		inj.SetMechanismForTest(mock)
+
+		packets := make(chan gopacket.Packet, 4)
+		for _, dst := range []net.HardwareAddr{src, src, other} {
+			resp, err := SerializeProbeResponse(dst, bssid, "LabAP", 6, 1)
+			require.NoError(t, err)
+			packets <- decode(t, resp)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+		defer cancel()
+		result := inj.runInjectionTest(ctx, config, src, packets)
+
+		assert.Equal(t, 4, result.ProbesSent)
+		assert.Len(t, mock.GetPackets(), 4)
+		assert.True(t, result.InjectionWorks)
+		assert.Equal(t, 2, result.Responses)
+		require.Len(t, result.APs, 1)
+		assert.Equal(t, bssid.String(), result.APs[0].BSSID)
+		assert.Equal(t, "LabAP", result.APs[0].SSID)
+		assert.InDelta(t, 0.5, result.SuccessRate, 0.001)
+	})
+
+	t.Run("Reports failure without responses", func(t *testing.T) {
+		inj := &Injector{Interface: "wlan0"}
+		inj.SetMechanismForTest(NewMockInjector())
+
+		ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+		defer cancel()
+		result := inj.runInjectionTest(ctx, config, src, make(chan gopacket.Packet))
+
+		assert.False(t, result.InjectionWorks)
+		assert.Zero(t, result.SuccessRate)
+		assert.Empty(t, result.APs)
+	})
+}
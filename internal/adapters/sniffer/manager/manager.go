@@ -5,18 +5,43 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/adapters/fingerprint"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/driver"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/handshake"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/pcapng"
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
 	"github.com/lcalzada-xor/wmap/internal/geo"
 )
 
+// Optional sniffer capabilities discovered by the network service via type
+// assertion. A signature drifting from its port would silently disable the
+// feature, so each one is checked at compile time.
+var (
+	_ ports.Sniffer               = (*SnifferManager)(nil)
+	_ ports.DeviceRecorder        = (*SnifferManager)(nil)
+	_ ports.PriorityChannelLocker = (*SnifferManager)(nil)
+	_ ports.InjectionTester       = (*SnifferManager)(nil)
+	_ ports.InterfaceDiagnoser    = (*SnifferManager)(nil)
+	_ ports.RegulatoryProvider    = (*SnifferManager)(nil)
+	_ ports.ManualChannelLocker   = (*SnifferManager)(nil)
+	_ ports.DwellPlanner          = (*SnifferManager)(nil)
+	_ ports.ReactiveHopper        = (*SnifferManager)(nil)
+	_ ports.DynamicSniffer        = (*SnifferManager)(nil)
+	_ ports.BitrateRestorer       = (*SnifferManager)(nil)
+	_ ports.FrameHistory          = (*SnifferManager)(nil)
+)
+
 // SnifferStatus tracks the operational status of a sniffer instance.
 type SnifferStatus struct {
 	Interface string
@@ -31,30 +56,63 @@ type SnifferManager struct {
 	Output     chan domain.Device
 	Alerts     chan domain.Alert
 	// Config
-	DwellTime int
-	Debug     bool
-	Loc       geo.Provider
+	DwellTime  int
+	DropBadFCS bool
+	BPFFilter  string // Capture filter; empty for the default
+	Passive    bool   // Never open injectors (WIDS sensor deployments)
+	Debug      bool
+	Loc        geo.Provider
+
+	// Interfaces dedicated to injection or AP duties do not hop
+	Roles domain.InterfaceRoles
+	// How the channel pool is split among the hopping interfaces; empty for
+	// domain.HopPlanBand
+	HopPlan     domain.HopPlan
+	channelPool []int // Set by SetChannels, nil for defaultChannels
+
+	// Handshakes do not hold the hoppers on their channel (power save)
+	NoReactiveHopping bool
+	// Per sniffer packet queue and workers; zero values use the defaults
+	PacketQueue int
+	Workers     int
+
+	// Session recording: every adapter's frames in one pcapng file, each
+	// adapter as an interface. Empty to disable.
+	PcapPath       string
+	CaptureContext domain.CaptureContextFunc // Attribution written in the recording's comments
+	recording      *os.File
+	recorder       *pcapng.Writer
+
+	// Retroactive capture: the raw frames of the last FrameHistory are kept
+	// in memory per adapter. Zero disables.
+	FrameHistory time.Duration
+	histories    map[string]*capture.FrameBuffer // By interface
 	// Status tracking
 	statuses map[string]*SnifferStatus
 	mu       sync.RWMutex
 
+	// Regulatory: channels closed to TX after a radar detection
+	radarHits map[int]time.Time
+
+	// Running capture, for interfaces added and removed at runtime
+	runCtx      context.Context
+	runRecorder *pcapng.Writer
+	runs        map[string]*snifferRun // By interface, until its sniffer stops
+	runWG       sync.WaitGroup
+
 	// Shared components
 	HandshakeManager *handshake.HandshakeManager
+	Dedup            *capture.FrameDeduplicator // Cross-adapter duplicate frame filter
+	Targets          *capture.TargetRecorder    // Recordings of single devices
 	VendorRepo       fingerprint.VendorRepository
 }
 
 // NewManager creates a manager for the given interfaces.
 func NewManager(interfaces []string, dwell int, debug bool, loc geo.Provider, repo fingerprint.VendorRepository) *SnifferManager {
-	// Use XDG-compliant path for handshakes
-	home, err := os.UserHomeDir()
-	if err != nil {
-		log.Printf("Warning: Could not resolve home directory, using fallback path: %v", err)
-		home = "."
-	}
-	handshakeDir := filepath.Join(home, ".local", "share", "wmap", "handshakes")
+	handshakeDir := handshake.DefaultDir()
 
 	return &SnifferManager{
-		Interfaces: interfaces,
+		Interfaces: slices.Clone(interfaces), // Changed by AddInterface and RemoveInterface
 		DwellTime:  dwell,
 		Debug:      debug,
 		Loc:        loc,
@@ -62,113 +120,301 @@ func NewManager(interfaces []string, dwell int, debug bool, loc geo.Provider, re
 		Output:     make(chan domain.Device, 1000), // Aggregated output
 		Alerts:     make(chan domain.Alert, 100),   // Aggregated alerts
 		statuses:   make(map[string]*SnifferStatus),
+		radarHits:  make(map[int]time.Time),
 		// Initialize shared HandshakeManager
 		HandshakeManager: handshake.NewHandshakeManager(handshakeDir),
+		Targets:          capture.NewTargetRecorder(),
+		FrameHistory:     capture.DefaultFrameHistory,
+		histories:        make(map[string]*capture.FrameBuffer),
 	}
 }
 
+// defaultChannels is the channel pool partitioned among the interfaces
+// (2.4GHz + limited 5GHz for now).
+// TODO: Make this configurable or dynamic based on hardware capabilities
+var defaultChannels = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 36, 40, 44, 48, 149, 153, 157, 161}
+
+// snifferRun is a sniffer started by the manager, stopped on its own when
+// its interface is removed.
+type snifferRun struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
 // Start initializes internal sniffers, partitions channels, and starts them.
+// It returns once every sniffer, including those added while running, stopped.
 func (m *SnifferManager) Start(ctx context.Context) error {
 	if len(m.Interfaces) == 0 {
 		return nil
 	}
 
-	// 1. Define Channel Pool (2.4GHz + limited 5GHz for now)
-	// TODO: Make this configurable or dynamic based on hardware capabilities
-	allChannels := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 36, 40, 44, 48, 149, 153, 157, 161}
-
-	// 2. Load Config from Disk (Phase 3 Persistence)
+	// 1. Load Config from Disk (Phase 3 Persistence)
 	savedConfig, err := m.loadChannelConfig()
 	if err != nil && !os.IsNotExist(err) {
 		log.Printf("Warning: Failed to load channel config: %v", err)
 	}
 
-	// 2b. Partition Channels (Default fallback)
-	partitioned := partitionChannels(allChannels, len(m.Interfaces))
+	// 2. Partition Channels (Default fallback) among the hopping interfaces
+	var hopping []string
+	for _, iface := range m.Interfaces {
+		if m.Roles.Hops(iface) {
+			hopping = append(hopping, iface)
+		}
+	}
+	m.mu.RLock()
+	partitioned := partitionChannels(m.poolLocked(), len(hopping), m.HopPlan)
+	m.mu.RUnlock()
+
+	// Adapters on overlapping channels overhear the same frames. The
+	// deduplicator is shared from the start even with a single adapter, as
+	// adapters added while capturing must be deduplicated against it.
+	if m.Dedup == nil {
+		m.Dedup = capture.NewFrameDeduplicator(capture.DefaultDedupWindow)
+	}
+
+	// Track DFS radar detections for the TX guard
+	go m.watchRadar(ctx)
 
-	var wg sync.WaitGroup
+	recorder := m.startRecording()
 
 	// 3. Create and Start Sniffers
-	for i, iface := range m.Interfaces {
+	m.mu.Lock()
+	m.runCtx = ctx
+	m.runRecorder = recorder
+	m.runs = make(map[string]*snifferRun)
+	for _, iface := range m.Interfaces {
+		// Dedicated injection and AP interfaces stay on their channel
+		if !m.Roles.Hops(iface) {
+			log.Printf("%s dedicated to %s, not hopping", iface, m.Roles[iface])
+			m.launchLocked(iface, nil)
+			continue
+		}
+
 		// Determine channels: Saved Config -> Partitioned Default
 		var channels []int
 		if saved, ok := savedConfig[iface]; ok {
 			channels = saved
 			log.Printf("Loaded saved configuration for %s: %v", iface, channels)
 		} else {
-			channels = partitioned[i]
+			channels = partitioned[slices.Index(hopping, iface)]
 			log.Printf("Assigning default channels to %s: %v", iface, channels)
 		}
+		m.launchLocked(iface, m.filterDisabledChannels(iface, channels))
+	}
+	m.mu.Unlock()
 
-		cfg := capture.SnifferConfig{
-			Interface: iface,
-			Debug:     m.Debug,
-			Channels:  channels,
-			DwellTime: m.DwellTime,
+	// Wait for all to finish (when ctx is cancelled). Interfaces added in
+	// the meantime are waited for too.
+	for {
+		m.runWG.Wait()
+		m.mu.Lock()
+		if len(m.runs) == 0 {
+			break
 		}
+		m.mu.Unlock()
+	}
 
-		// Create Sniffer
-		// Note: We need to bridge the individual Output channels to the manager's aggregated Output
-		// Or we can pass the manager's channel directly IF it was send-only, but Sniffer expects chan<-
-		// Yes, we can pass m.Output directly.
-		sniff := capture.New(cfg, m.Output, m.Alerts, m.Loc, m.HandshakeManager, m.VendorRepo)
-		m.Sniffers = append(m.Sniffers, sniff)
+	// Release hoppers and injectors so the manager can be started again
+	for _, s := range m.Sniffers {
+		s.Close()
+	}
+	m.Sniffers = nil
+	m.runCtx = nil
+	m.runRecorder = nil
+	m.mu.Unlock()
+	return nil
+}
 
-		wg.Add(1)
-		go func(s *capture.Sniffer, ifaceName string) {
-			defer wg.Done()
+// launchLocked creates the sniffer of iface and runs it until the manager's
+// context is done or the interface is removed. Called with mu held.
+func (m *SnifferManager) launchLocked(iface string, channels []int) *capture.Sniffer {
+	cfg := capture.SnifferConfig{
+		Interface:  iface,
+		Debug:      m.Debug,
+		Channels:   channels,
+		DwellTime:  m.DwellTime,
+		DropBadFCS: m.DropBadFCS,
+		BPFFilter:  m.BPFFilter,
+		Passive:    m.Passive,
+
+		NoReactiveHopping: m.NoReactiveHopping,
+		QueueSize:         m.PacketQueue,
+		Workers:           m.Workers,
+	}
+
+	// Create Sniffer
+	// Sniffers write to the manager's aggregated Output and Alerts directly
+	sniff := capture.New(cfg, m.Output, m.Alerts, m.Loc, m.HandshakeManager, m.VendorRepo)
+	sniff.Dedup = m.Dedup
+	sniff.Recorder = m.runRecorder
+	sniff.Targets = m.Targets
+	sniff.History = m.historyLocked(iface)
+	m.Sniffers = append(m.Sniffers, sniff)
+
+	// Initialize status tracking
+	status := &SnifferStatus{
+		Interface: iface,
+		Status:    "starting",
+	}
+	m.statuses[iface] = status
+
+	ctx, cancel := context.WithCancel(m.runCtx)
+	run := &snifferRun{cancel: cancel, done: make(chan struct{})}
+	m.runs[iface] = run
+	m.runWG.Add(1)
 
-			// Initialize status tracking
-			status := &SnifferStatus{
-				Interface: ifaceName,
-				Status:    "starting",
+	go func() {
+		defer m.runWG.Done()
+		defer close(run.done)
+		defer func() {
+			m.mu.Lock()
+			if m.runs[iface] == run {
+				delete(m.runs, iface)
 			}
+			m.mu.Unlock()
+			cancel()
+		}()
+
+		// Start Hopper if exists
+		if hopper := sniff.Hopper(); hopper != nil {
+			go hopper.Start()
+		}
+
+		if err := sniff.Start(ctx); err != nil {
+			// Update status
 			m.mu.Lock()
-			m.statuses[ifaceName] = status
+			status.Status = "failed"
+			status.Error = err
 			m.mu.Unlock()
 
-			// Start Hopper if exists
-			if s.Hopper != nil {
-				go s.Hopper.Start()
+			log.Printf("CRITICAL: Sniffer %s failed: %v", iface, err)
+
+			// Send alert to frontend
+			select {
+			case m.Alerts <- domain.Alert{
+				Type:    "system",
+				Message: fmt.Sprintf("Interface %s failed to start: %v", iface, err),
+			}:
+			default:
+				// Alert channel full, log only
+				log.Printf("Failed to send alert for interface %s failure", iface)
 			}
+		} else {
+			// Sniffer stopped gracefully
+			m.mu.Lock()
+			status.Status = "stopped"
+			m.mu.Unlock()
+			log.Printf("Sniffer %s stopped gracefully", iface)
+		}
+	}()
+	return sniff
+}
 
-			if err := s.Start(ctx); err != nil {
-				// Update status
-				m.mu.Lock()
-				status.Status = "failed"
-				status.Error = err
-				m.mu.Unlock()
-
-				log.Printf("CRITICAL: Sniffer %s failed: %v", ifaceName, err)
-
-				// Send alert to frontend
-				select {
-				case m.Alerts <- domain.Alert{
-					Type:    "system",
-					Message: fmt.Sprintf("Interface %s failed to start: %v", ifaceName, err),
-				}:
-				default:
-					// Alert channel full, log only
-					log.Printf("Failed to send alert for interface %s failure", ifaceName)
-				}
-			} else {
-				// Sniffer stopped gracefully
-				m.mu.Lock()
-				status.Status = "stopped"
-				m.mu.Unlock()
-				log.Printf("Sniffer %s stopped gracefully", ifaceName)
-			}
-		}(sniff, iface)
+// AddInterface starts capturing on iface, already in monitor mode, and
+// rebalances the channels of the interfaces without a saved channel list.
+// Before Start, the interface is only added to the list.
+func (m *SnifferManager) AddInterface(ctx context.Context, iface string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.Interfaces {
+		if existing == iface {
+			return fmt.Errorf("%w: %s", domain.ErrInterfaceInUse, iface)
+		}
+	}
+	m.Interfaces = append(m.Interfaces, iface)
+	if m.runCtx == nil || m.runCtx.Err() != nil {
+		return nil
+	}
+
+	m.launchLocked(iface, nil)
+	m.rebalanceLocked()
+	log.Printf("Capture interface %s added", iface)
+	return nil
+}
+
+// RemoveInterface stops capturing on iface and gives its channels to the
+// remaining interfaces. The last interface cannot be removed.
+func (m *SnifferManager) RemoveInterface(ctx context.Context, iface string) error {
+	m.mu.Lock()
+	index := -1
+	for i, existing := range m.Interfaces {
+		if existing == iface {
+			index = i
+		}
+	}
+	if index < 0 {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %s", domain.ErrInterfaceNotFound, iface)
+	}
+	if len(m.Interfaces) == 1 {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %s", domain.ErrLastInterface, iface)
+	}
+	run := m.runs[iface]
+	m.mu.Unlock()
+
+	if run != nil {
+		run.cancel()
+		select {
+		case <-run.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 
-	// Wait for all to finish (when ctx is cancelled)
-	wg.Wait()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Interfaces = slices.DeleteFunc(m.Interfaces, func(name string) bool { return name == iface })
+	m.Sniffers = slices.DeleteFunc(m.Sniffers, func(s *capture.Sniffer) bool {
+		if s.Config.Interface != iface {
+			return false
+		}
+		s.Close()
+		return true
+	})
+	delete(m.statuses, iface)
+	delete(m.histories, iface)
+	m.rebalanceLocked()
+	log.Printf("Capture interface %s removed", iface)
 	return nil
 }
 
-// partitionChannels divides channels by frequency band for optimal hardware utilization.
-// This reduces channel hopping latency by avoiding unnecessary frequency band switches.
-func partitionChannels(channels []int, n int) [][]int {
+// rebalanceLocked partitions the channel pool again among the hopping
+// interfaces without a saved channel list. Called with mu held.
+func (m *SnifferManager) rebalanceLocked() {
+	savedConfig, _ := m.loadChannelConfig()
+	var free []*capture.Sniffer
+	for _, s := range m.Sniffers {
+		if _, ok := savedConfig[s.Config.Interface]; !ok && m.Roles.Hops(s.Config.Interface) {
+			free = append(free, s)
+		}
+	}
+	for i, channels := range partitionChannels(m.poolLocked(), len(free), m.HopPlan) {
+		channels = m.filterDisabledChannels(free[i].Config.Interface, channels)
+		free[i].SetChannels(channels)
+		log.Printf("Rebalanced %s to channels %v", free[i].Config.Interface, channels)
+	}
+}
+
+// poolLocked returns the channel pool partitioned among the hopping
+// interfaces. Called with mu held.
+func (m *SnifferManager) poolLocked() []int {
+	if len(m.channelPool) > 0 {
+		return m.channelPool
+	}
+	return defaultChannels
+}
+
+// partitionChannels splits channels among n interfaces following plan, so
+// they cover different channels at the same time.
+//
+// With HopPlanBand interfaces are dedicated to a band, in proportion to its
+// channels, which avoids band switches on each hop: with two interfaces one
+// hops 2.4GHz and the other 5GHz. Interfaces sharing a band alternate its
+// channels. With HopPlanInterleave every interface alternates the channels
+// of both bands.
+func partitionChannels(channels []int, n int, plan domain.HopPlan) [][]int {
 	if n <= 0 {
 		return nil
 	}
@@ -186,30 +432,39 @@ func partitionChannels(channels []int, n int) [][]int {
 	}
 
 	result := make([][]int, n)
+	for i := range result {
+		result[i] = []int{}
+	}
 
-	// Strategy: Assign complete bands to interfaces when possible
-	// This minimizes hardware reconfiguration overhead
-	if n == 1 {
-		// Single interface gets all channels
-		result[0] = append(band24, band5...)
-	} else if n == 2 {
-		// Optimal case: One interface per band
-		result[0] = band24
-		result[1] = band5
-		log.Printf("Channel partitioning: Interface 0 → 2.4GHz (%d channels), Interface 1 → 5GHz (%d channels)",
-			len(band24), len(band5))
-	} else {
-		// Multiple interfaces: Distribute bands using round-robin within each band
-		// This keeps interfaces focused on specific frequency ranges
-		for i, ch := range band24 {
+	if n == 1 || plan == domain.HopPlanInterleave {
+		for i, ch := range append(band24, band5...) {
 			result[i%n] = append(result[i%n], ch)
 		}
-		for i, ch := range band5 {
-			result[i%n] = append(result[i%n], ch)
+		if n > 1 {
+			log.Printf("Channel partitioning: Interleaved %d channels across %d interfaces", len(channels), n)
 		}
-		log.Printf("Channel partitioning: Distributed %d channels across %d interfaces",
-			len(channels), n)
+		return result
+	}
+
+	// Bands in proportion to their channels, at least one interface each.
+	// An empty band leaves every interface to the other one.
+	n24 := n
+	switch {
+	case len(band24) == 0:
+		n24 = 0
+	case len(band5) > 0:
+		n24 = int(math.Round(float64(n*len(band24)) / float64(len(band24)+len(band5))))
+		n24 = max(min(n24, n-1), 1)
+	}
+	for i, ch := range band24 {
+		result[i%n24] = append(result[i%n24], ch)
+	}
+	for i, ch := range band5 {
+		idx := n24 + i%(n-n24)
+		result[idx] = append(result[idx], ch)
 	}
+	log.Printf("Channel partitioning: %d interfaces on 2.4GHz (%d channels), %d on 5GHz (%d channels)",
+		n24, len(band24), n-n24, len(band5))
 
 	return result
 }
@@ -218,21 +473,76 @@ func partitionChannels(channels []int, n int) [][]int {
 func (m *SnifferManager) GetChannels(ctx context.Context) []int {
 	var all []int
 	for _, s := range m.Sniffers {
-		if s.Hopper != nil {
-			all = append(all, s.Hopper.GetChannels()...)
+		if hopper := s.Hopper(); hopper != nil {
+			all = append(all, hopper.GetChannels()...)
 		}
 	}
 	return all
 }
 
-// SetChannels updates the channels... effectively redistributing them?
-// For now, this is complex to implement dynamically for all sniffers.
-// Let's implement a dummy one or a simple one to satisfy interface if needed.
-// The port probably requires it.
+// SetChannels replaces the channel pool and partitions it again among the
+// hopping interfaces without a saved channel list. An empty list restores
+// the default pool.
 func (m *SnifferManager) SetChannels(ctx context.Context, channels []int) {
-	// Re-partitioning at runtime is tricky because sniffers are running.
-	// For now, let's just log a warning or partial implementation.
-	log.Printf("Warning: SetChannels not fully implemented for SnifferManager yet")
+	pool := make([]int, 0, len(channels))
+	for _, ch := range channels {
+		if ch > 0 && !slices.Contains(pool, ch) {
+			pool = append(pool, ch)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.channelPool = pool
+	m.rebalanceLocked()
+}
+
+// CaptureSettings returns the capture options in effect on the sniffers.
+func (m *SnifferManager) CaptureSettings() domain.CaptureSettings {
+	if len(m.Sniffers) > 0 {
+		return m.Sniffers[0].Settings()
+	}
+	return domain.CaptureSettings{DwellMs: m.DwellTime, BPFFilter: m.BPFFilter, DropBadFCS: m.DropBadFCS}
+}
+
+// SetDwellTime changes the base dwell time of every sniffer.
+func (m *SnifferManager) SetDwellTime(ms int) {
+	m.DwellTime = ms
+	for _, s := range m.Sniffers {
+		s.SetDwellTime(ms)
+	}
+}
+
+// SetBPFFilter changes the capture filter of every sniffer. The filter is
+// checked on the first one so an invalid filter changes none.
+func (m *SnifferManager) SetBPFFilter(filter string) error {
+	for i, s := range m.Sniffers {
+		if err := s.SetBPFFilter(filter); err != nil {
+			for _, prev := range m.Sniffers[:i] {
+				prev.SetBPFFilter(m.BPFFilter)
+			}
+			return err
+		}
+	}
+	m.BPFFilter = filter
+	return nil
+}
+
+// SetDropBadFCS sets whether every sniffer discards frames failing the FCS check.
+func (m *SnifferManager) SetDropBadFCS(drop bool) {
+	m.DropBadFCS = drop
+	for _, s := range m.Sniffers {
+		s.SetDropBadFCS(drop)
+	}
+}
+
+// SetReactiveHopping sets whether handshakes hold the hoppers of every
+// sniffer on their channel.
+func (m *SnifferManager) SetReactiveHopping(enabled bool) {
+	m.NoReactiveHopping = !enabled
+	for _, s := range m.Sniffers {
+		s.SetReactiveHopping(enabled)
+	}
 }
 
 // Scan performs an active scan by broadcasting probe requests.
@@ -247,6 +557,40 @@ func (m *SnifferManager) Scan(ctx context.Context, target string) error {
 	return nil
 }
 
+// RecordDevice records the frames of mac on every adapter for duration and
+// saves them as a pcap artifact.
+func (m *SnifferManager) RecordDevice(ctx context.Context, mac string, duration time.Duration, reason string) bool {
+	return m.Targets.Record(mac, duration, reason)
+}
+
+// CaptureWindow returns the frames captured between from and to on ifaces,
+// every adapter when empty, as long as they are still in their history.
+func (m *SnifferManager) CaptureWindow(ctx context.Context, ifaces []string, from, to time.Time, comments []string) ([]byte, int, error) {
+	m.mu.RLock()
+	var buffers []*capture.FrameBuffer
+	for iface, buffer := range m.histories {
+		if len(ifaces) == 0 || slices.Contains(ifaces, iface) {
+			buffers = append(buffers, buffer)
+		}
+	}
+	m.mu.RUnlock()
+	return capture.EncodeHistory(buffers, from, to, comments)
+}
+
+// historyLocked returns the frame history of iface, created on first use, or
+// nil when retroactive capture is disabled. Called with mu held.
+func (m *SnifferManager) historyLocked(iface string) *capture.FrameBuffer {
+	if m.FrameHistory <= 0 {
+		return nil
+	}
+	buffer, ok := m.histories[iface]
+	if !ok {
+		buffer = capture.NewFrameBuffer(m.FrameHistory, capture.DefaultFrameHistoryFrames)
+		m.histories[iface] = buffer
+	}
+	return buffer
+}
+
 // GetInterfaces returns the list of managed interfaces.
 func (m *SnifferManager) GetInterfaces(ctx context.Context) ([]string, error) {
 	return m.Interfaces, nil
@@ -255,8 +599,8 @@ func (m *SnifferManager) GetInterfaces(ctx context.Context) ([]string, error) {
 // GetInterfaceChannels returns the channel list for a specific interface.
 func (m *SnifferManager) GetInterfaceChannels(ctx context.Context, iface string) ([]int, error) {
 	for _, s := range m.Sniffers {
-		if s.Config.Interface == iface && s.Hopper != nil {
-			return s.Hopper.GetChannels(), nil
+		if hopper := s.Hopper(); s.Config.Interface == iface && hopper != nil {
+			return hopper.GetChannels(), nil
 		}
 	}
 	return []int{}, nil
@@ -386,6 +730,37 @@ func (m *SnifferManager) ExecuteWithLock(ctx context.Context, iface string, chan
 	return fmt.Errorf("interface %s not found in manager", iface)
 }
 
+// LockChannelFor pins an interface to a channel for a limited time (manual lock).
+func (m *SnifferManager) LockChannelFor(ctx context.Context, iface string, channel int, duration time.Duration) (domain.ChannelLockState, error) {
+	for _, s := range m.Sniffers {
+		if s.Config.Interface == iface {
+			return s.LockFor(ctx, channel, duration)
+		}
+	}
+	return domain.ChannelLockState{}, fmt.Errorf("interface %s not found in manager", iface)
+}
+
+// ReleaseChannelLock drops the manual lock of an interface.
+func (m *SnifferManager) ReleaseChannelLock(ctx context.Context, iface string) error {
+	for _, s := range m.Sniffers {
+		if s.Config.Interface == iface {
+			s.ReleaseManualLock(ctx)
+			return nil
+		}
+	}
+	return fmt.Errorf("interface %s not found in manager", iface)
+}
+
+// ExecuteWithPriority delegates priority-arbitrated locking to the appropriate sniffer.
+func (m *SnifferManager) ExecuteWithPriority(ctx context.Context, iface string, channel int, priority domain.LockPriority, holder string, action func(ctx context.Context) error) error {
+	for _, s := range m.Sniffers {
+		if s.Config.Interface == iface {
+			return s.ExecuteWithPriority(ctx, iface, channel, priority, holder, action)
+		}
+	}
+	return fmt.Errorf("interface %s not found in manager", iface)
+}
+
 // GetInjector returns the injector for a specific interface if managed.
 func (m *SnifferManager) GetInjector(iface string) *injection.Injector {
 	for _, s := range m.Sniffers {
@@ -396,6 +771,190 @@ func (m *SnifferManager) GetInjector(iface string) *injection.Injector {
 	return nil
 }
 
+// DwellPlan returns the auto-tuned per-channel dwell times of the interface's hopper.
+func (m *SnifferManager) DwellPlan(ctx context.Context, iface string) ([]domain.ChannelDwell, error) {
+	for _, s := range m.Sniffers {
+		if s.Config.Interface == iface {
+			return s.DwellPlan(), nil
+		}
+	}
+	return nil, fmt.Errorf("interface %s not found in manager", iface)
+}
+
+// ChannelRegulatory returns the regulatory flags of the interface's channels,
+// including channels in a radar non-occupancy period.
+func (m *SnifferManager) ChannelRegulatory(ctx context.Context, iface string) ([]domain.ChannelRegulatory, error) {
+	channels, err := driver.GetChannelRegulatory(iface)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for i := range channels {
+		if hit, ok := m.radarHits[channels[i].Channel]; ok && time.Since(hit) < domain.RadarNonOccupancyPeriod {
+			channels[i].RadarDetected = true
+		}
+	}
+	return channels, nil
+}
+
+// RestoreBitrates returns iface to the driver's rate control after an attack
+// engine fixed its bitrates for injection.
+func (m *SnifferManager) RestoreBitrates(ctx context.Context, iface string) error {
+	return driver.ResetBitrates(iface)
+}
+
+// filterDisabledChannels drops channels the regulatory domain disables on iface.
+// Listening on DFS/no-IR channels is legal, so those stay in the hop list.
+func (m *SnifferManager) filterDisabledChannels(iface string, channels []int) []int {
+	regulatory, err := driver.GetChannelRegulatory(iface)
+	if err != nil || len(regulatory) == 0 {
+		return channels
+	}
+
+	enabled := make(map[int]bool, len(regulatory))
+	for _, r := range regulatory {
+		enabled[r.Channel] = !r.Disabled
+	}
+
+	filtered := make([]int, 0, len(channels))
+	for _, ch := range channels {
+		if enabled[ch] {
+			filtered = append(filtered, ch)
+		} else {
+			log.Printf("Skipping channel %d on %s: not enabled by the regulatory domain or hardware", ch, iface)
+		}
+	}
+	if len(filtered) > 0 || len(channels) == 0 {
+		return filtered
+	}
+
+	// An empty list would leave the sniffer without a channel to hop, fall
+	// back to every channel the interface may use
+	for _, r := range regulatory {
+		if !r.Disabled {
+			filtered = append(filtered, r.Channel)
+		}
+	}
+	if len(filtered) == 0 {
+		log.Printf("Warning: no channel of %v is enabled on %s, keeping them", channels, iface)
+		return channels
+	}
+	log.Printf("Warning: no channel of %v is enabled on %s, hopping its enabled channels instead", channels, iface)
+	return filtered
+}
+
+// watchRadar follows kernel radar events until ctx is cancelled.
+func (m *SnifferManager) watchRadar(ctx context.Context) {
+	events := make(chan int, 8)
+	go func() {
+		if err := driver.WatchRadarEvents(ctx, events); err != nil && ctx.Err() == nil && m.Debug {
+			log.Printf("Radar event watcher stopped: %v", err)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ch := <-events:
+			m.recordRadar(ch)
+		}
+	}
+}
+
+// recordRadar closes a channel to TX for the non-occupancy period and raises an alert.
+func (m *SnifferManager) recordRadar(channel int) {
+	m.mu.Lock()
+	m.radarHits[channel] = time.Now()
+	m.mu.Unlock()
+
+	log.Printf("Radar detected on channel %d: TX blocked for %v", channel, domain.RadarNonOccupancyPeriod)
+	select {
+	case m.Alerts <- domain.Alert{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "RADAR_DETECTED",
+		Timestamp: time.Now().UTC(),
+		Message:   fmt.Sprintf("Radar detected on channel %d; active operations blocked for %v", channel, domain.RadarNonOccupancyPeriod),
+		Severity:  domain.SeverityMedium,
+	}:
+	default:
+	}
+}
+
+// DiagnoseInterfaces runs the monitor-mode preflight checks on the given
+// interfaces, or on all managed interfaces when none are given.
+func (m *SnifferManager) DiagnoseInterfaces(ctx context.Context, ifaces []string) ([]domain.InterfaceDiagnostics, error) {
+	if len(ifaces) == 0 {
+		ifaces = m.Interfaces
+	}
+	results := make([]domain.InterfaceDiagnostics, 0, len(ifaces))
+	for _, iface := range ifaces {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		results = append(results, driver.Diagnose(iface))
+	}
+	return results, nil
+}
+
+// RunInjectionTest runs an injection self-test on iface, locking the channel
+// for the duration of the test when one is requested.
+func (m *SnifferManager) RunInjectionTest(ctx context.Context, iface string, config domain.InjectionTestConfig) (domain.InjectionTestResult, error) {
+	injector := m.GetInjector(iface)
+	if injector == nil {
+		return domain.InjectionTestResult{}, fmt.Errorf("no injector available for interface %s", iface)
+	}
+
+	if config.Channel == 0 {
+		return injector.RunInjectionTest(ctx, config)
+	}
+
+	var result domain.InjectionTestResult
+	err := m.ExecuteWithLock(ctx, iface, config.Channel, func() error {
+		var err error
+		result, err = injector.RunInjectionTest(ctx, config)
+		return err
+	})
+	return result, err
+}
+
+// startRecording creates the session recording, if enabled. Failures are
+// logged: capture continues without a recording.
+func (m *SnifferManager) startRecording() *pcapng.Writer {
+	if m.PcapPath == "" {
+		return nil
+	}
+	m.mu.RLock()
+	recorder := m.recorder
+	m.mu.RUnlock()
+	if recorder != nil {
+		return recorder // Restarted: keep appending to the same recording
+	}
+	f, err := os.Create(m.PcapPath)
+	if err != nil {
+		log.Printf("Failed to create capture recording: %v", err)
+		return nil
+	}
+	section := pcapng.Section{Application: "wmap", OS: runtime.GOOS}
+	if m.CaptureContext != nil {
+		section.Comments = m.CaptureContext("").Comments()
+	}
+	w, err := pcapng.NewWriter(f, section)
+	if err != nil {
+		log.Printf("Failed to write capture recording header: %v", err)
+		f.Close()
+		return nil
+	}
+	m.mu.Lock()
+	m.recording = f
+	m.recorder = w
+	m.mu.Unlock()
+	log.Printf("Packet capture enabled. Saving to %s", m.PcapPath)
+	return w
+}
+
 // Close releases all resources managed by the manager.
 func (m *SnifferManager) Close() error {
 	m.mu.Lock()
@@ -409,5 +968,10 @@ func (m *SnifferManager) Close() error {
 	for _, s := range m.Sniffers {
 		s.Close()
 	}
+	if m.recording != nil {
+		m.recording.Close()
+		m.recording = nil
+		m.recorder = nil
+	}
 	return nil
 }
@@ -17,14 +17,8 @@ func TestManagerChannels(t *testing.T) {
 
 	// Create dummy sniffers with hoppers
 	// Note: We don't start them, so no exec.Command calls happen
-	s0 := &capture.Sniffer{
-		Config: capture.SnifferConfig{Interface: "wlan0"},
-		Hopper: &hopping.ChannelHopper{Channels: []int{1, 6}},
-	}
-	s1 := &capture.Sniffer{
-		Config: capture.SnifferConfig{Interface: "wlan1"},
-		Hopper: &hopping.ChannelHopper{Channels: []int{36, 40}},
-	}
+	s0 := hoppingSniffer("wlan0", &hopping.ChannelHopper{Channels: []int{1, 6}})
+	s1 := hoppingSniffer("wlan1", &hopping.ChannelHopper{Channels: []int{36, 40}})
 	m.Sniffers = []*capture.Sniffer{s0, s1}
 
 	// Test GetInterfaces
@@ -1,8 +1,14 @@
 package manager
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/hopping"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
 )
 
 func TestPartitionChannels(t *testing.T) {
@@ -10,18 +16,15 @@ func TestPartitionChannels(t *testing.T) {
 		name     string
 		channels []int
 		n        int
+		plan     domain.HopPlan
 		want     [][]int
 	}{
 		{
 			name:     "2 interfaces, 4 channels",
 			channels: []int{1, 2, 3, 4},
 			n:        2,
-			// Correct.
-			// New logic attempts to split by band (2.4 vs 5).
-			// Since all are 2.4, they might all go to one interface if we reserve the other for 5GHz?
-			// The current implementation does: result[0] = band24, result[1] = band5.
-			// So [[1 2 3 4] []] is correct behavior for the current implementation.
-			want: [][]int{{1, 2, 3, 4}, {}},
+			// No 5GHz channel: both interfaces share 2.4GHz instead of one idling
+			want: [][]int{{1, 3}, {2, 4}},
 		},
 		{
 			name:     "2 interfaces, Mixed Bands",
@@ -36,6 +39,25 @@ func TestPartitionChannels(t *testing.T) {
 			n:        3,
 			want:     [][]int{{1, 4}, {2, 5}, {3}},
 		},
+		{
+			name:     "3 interfaces, bands in proportion",
+			channels: []int{1, 2, 3, 4, 5, 6, 36, 40},
+			n:        3,
+			want:     [][]int{{1, 3, 5}, {2, 4, 6}, {36, 40}},
+		},
+		{
+			name:     "2 interfaces, only 5GHz",
+			channels: []int{36, 40, 44},
+			n:        2,
+			want:     [][]int{{36, 44}, {40}},
+		},
+		{
+			name:     "2 interfaces, interleaved",
+			channels: []int{1, 6, 11, 36, 40},
+			n:        2,
+			plan:     domain.HopPlanInterleave,
+			want:     [][]int{{1, 11, 40}, {6, 36}},
+		},
 		{
 			name:     "1 interface",
 			channels: []int{1, 2, 3},
@@ -52,10 +74,92 @@ func TestPartitionChannels(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := partitionChannels(tt.channels, tt.n)
+			got := partitionChannels(tt.channels, tt.n, tt.plan)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("partitionChannels() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+// hoppingSniffer returns a sniffer on iface hopping with hopper, not started.
+func hoppingSniffer(iface string, hopper *hopping.ChannelHopper) *capture.Sniffer {
+	s := &capture.Sniffer{Config: capture.SnifferConfig{Interface: iface}}
+	s.SetHopper(hopper)
+	return s
+}
+
+func TestAddRemoveInterface(t *testing.T) {
+	ctx := context.Background()
+	hopper := &hopping.ChannelHopper{Channels: []int{1, 6}}
+	m := &SnifferManager{
+		Interfaces: []string{"mon5"},
+		Sniffers: []*capture.Sniffer{
+			hoppingSniffer("mon5", hopper),
+		},
+		statuses: make(map[string]*SnifferStatus),
+	}
+
+	// Not capturing yet: the interface only joins the list
+	if err := m.AddInterface(ctx, "mon6"); err != nil {
+		t.Fatalf("AddInterface failed: %v", err)
+	}
+	if !reflect.DeepEqual(m.Interfaces, []string{"mon5", "mon6"}) {
+		t.Errorf("Interfaces = %v", m.Interfaces)
+	}
+	if err := m.AddInterface(ctx, "mon6"); !errors.Is(err, domain.ErrInterfaceInUse) {
+		t.Errorf("duplicate AddInterface error = %v, want ErrInterfaceInUse", err)
+	}
+	if err := m.RemoveInterface(ctx, "mon7"); !errors.Is(err, domain.ErrInterfaceNotFound) {
+		t.Errorf("RemoveInterface of unknown error = %v, want ErrInterfaceNotFound", err)
+	}
+
+	// The remaining interface takes over the whole channel pool
+	if err := m.RemoveInterface(ctx, "mon6"); err != nil {
+		t.Fatalf("RemoveInterface failed: %v", err)
+	}
+	if !reflect.DeepEqual(m.Interfaces, []string{"mon5"}) {
+		t.Errorf("Interfaces = %v", m.Interfaces)
+	}
+	if got := hopper.GetChannels(); !reflect.DeepEqual(got, defaultChannels) {
+		t.Errorf("rebalanced channels = %v, want %v", got, defaultChannels)
+	}
+
+	if err := m.RemoveInterface(ctx, "mon5"); !errors.Is(err, domain.ErrLastInterface) {
+		t.Errorf("RemoveInterface of the last error = %v, want ErrLastInterface", err)
+	}
+}
+
+func TestSetChannels(t *testing.T) {
+	h5, h6, injector := &hopping.ChannelHopper{}, &hopping.ChannelHopper{}, &hopping.ChannelHopper{Channels: []int{6}}
+	m := &SnifferManager{
+		Interfaces: []string{"mon5", "mon6", "mon7"},
+		Roles:      domain.InterfaceRoles{"mon7": domain.InterfaceRoleInjection},
+		Sniffers: []*capture.Sniffer{
+			hoppingSniffer("mon5", h5),
+			hoppingSniffer("mon6", h6),
+			hoppingSniffer("mon7", injector),
+		},
+	}
+
+	// The hopping interfaces split the plan, duplicates dropped
+	m.SetChannels(context.Background(), []int{1, 6, 11, 36, 149, 6})
+	if got := h5.GetChannels(); !reflect.DeepEqual(got, []int{1, 6, 11}) {
+		t.Errorf("mon5 channels = %v, want 2.4GHz", got)
+	}
+	if got := h6.GetChannels(); !reflect.DeepEqual(got, []int{36, 149}) {
+		t.Errorf("mon6 channels = %v, want 5GHz", got)
+	}
+	if got := injector.GetChannels(); !reflect.DeepEqual(got, []int{6}) {
+		t.Errorf("injection interface channels = %v, want unchanged", got)
+	}
+
+	m.HopPlan = domain.HopPlanInterleave
+	m.SetChannels(context.Background(), nil)
+	if got := len(h5.GetChannels()) + len(h6.GetChannels()); got != len(defaultChannels) {
+		t.Errorf("default pool split into %d channels, want %d", got, len(defaultChannels))
+	}
+	if got := h5.GetChannels(); got[0] != 1 || got[1] != 3 {
+		t.Errorf("mon5 channels = %v, want alternate channels", got)
+	}
+}
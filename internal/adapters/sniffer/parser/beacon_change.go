@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/ie"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// maxBeaconProfiles bounds the tracker, the APs heard least recently
+	// being dropped first.
+	maxBeaconProfiles = 4096
+)
+
+// beaconProfile is the configuration an AP announces in its beacons.
+type beaconProfile struct {
+	interval int
+	rates    string
+	rsn      string
+	channel  int // 0 if the beacon carries none
+}
+
+// beaconChangeTracker remembers the beacon configuration of each BSSID. APs
+// rarely change it; a BSSID suddenly announcing another interval, rate set,
+// RSN configuration or channel was reconfigured, or is being spoofed.
+type beaconChangeTracker struct {
+	mu       sync.Mutex
+	profiles *lru[string, beaconProfile] // BSSID
+}
+
+func newBeaconChangeTracker() *beaconChangeTracker {
+	return &beaconChangeTracker{profiles: newLRU[string, beaconProfile](maxBeaconProfiles)}
+}
+
+// observe records the profile of a beacon and returns how it differs from
+// the last one of the same BSSID.
+func (t *beaconChangeTracker) observe(bssid string, p beaconProfile) []domain.ConfigChange {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last, ok := t.profiles.get(bssid)
+	if p.channel == 0 {
+		p.channel = last.channel // Keep the last channel announced
+	}
+	t.profiles.put(bssid, p)
+	if !ok {
+		return nil
+	}
+
+	var changes []domain.ConfigChange
+	if last.interval != p.interval {
+		changes = append(changes, domain.ConfigChange{Field: "beacon_interval",
+			Before: strconv.Itoa(last.interval), After: strconv.Itoa(p.interval)})
+	}
+	if last.rates != p.rates {
+		changes = append(changes, domain.ConfigChange{Field: "supported_rates", Before: last.rates, After: p.rates})
+	}
+	if last.rsn != p.rsn {
+		changes = append(changes, domain.ConfigChange{Field: "rsn", Before: last.rsn, After: p.rsn})
+	}
+	if last.channel != 0 && last.channel != p.channel {
+		changes = append(changes, domain.ConfigChange{Field: "channel",
+			Before: strconv.Itoa(last.channel), After: strconv.Itoa(p.channel)})
+	}
+	return changes
+}
+
+// detectBeaconChange compares a beacon, once its elements are parsed into
+// device, with the previous one of the AP and alerts on any difference.
+func (h *PacketHandler) detectBeaconChange(dot11 *layers.Dot11, device *domain.Device) *domain.Alert {
+	if dot11.Type != layers.Dot11TypeMgmtBeacon || device == nil {
+		return nil
+	}
+	payload := dot11.LayerPayload()
+	if len(payload) < 12 {
+		return nil
+	}
+	ies := payload[12:] // After timestamp, interval and capabilities
+
+	changes := h.beacons.observe(device.MAC, beaconProfile{
+		interval: device.BeaconInterval,
+		rates:    describeRates(ies),
+		rsn:      describeRSN(device),
+		channel:  beaconChannel(ies),
+	})
+	if len(changes) == 0 {
+		return nil
+	}
+
+	fields := make([]string, len(changes))
+	details := make([]string, len(changes))
+	for i, c := range changes {
+		fields[i] = c.Field
+		details[i] = fmt.Sprintf("%s: %s -> %s", c.Field, c.Before, c.After)
+	}
+	return &domain.Alert{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "BEACON_CHANGED",
+		Severity:  domain.SeverityMedium,
+		DeviceMAC: device.MAC,
+		BSSID:     dot11.Address3.String(),
+		Timestamp: time.Now(),
+		Message:   fmt.Sprintf("AP changed its beacon (%s): reconfigured or spoofed", strings.Join(fields, ", ")),
+		Details:   strings.Join(details, "; "),
+		Changes:   changes,
+		RSSI:      device.RSSI,
+		Latitude:  device.Latitude,
+		Longitude: device.Longitude,
+	}
+}
+
+// describeRates lists the supported and extended rates of a beacon in Mbps,
+// basic rates marked with an asterisk.
+func describeRates(ies []byte) string {
+	var rates []string
+	for _, tag := range []int{ie.TagSupportedRates, ie.TagExtendedRates} {
+		for _, r := range ie.FindIE(ies, tag) {
+			rate := strconv.FormatFloat(float64(r&0x7f)/2, 'f', -1, 64)
+			if r&0x80 != 0 {
+				rate += "*"
+			}
+			rates = append(rates, rate)
+		}
+	}
+	return strings.Join(rates, " ")
+}
+
+// describeRSN summarizes the security of a parsed beacon.
+func describeRSN(device *domain.Device) string {
+	rsn := device.RSNInfo
+	if rsn == nil {
+		return device.Security
+	}
+	pmf := "off"
+	if rsn.Capabilities.MFPRequired {
+		pmf = "required"
+	} else if rsn.Capabilities.MFPCapable {
+		pmf = "capable"
+	}
+	return fmt.Sprintf("%s group=%s pairwise=%s akm=%s pmf=%s", device.Security, rsn.GroupCipher,
+		strings.Join(rsn.PairwiseCiphers, "+"), strings.Join(rsn.AKMSuites, "+"), pmf)
+}
+
+// beaconChannel returns the channel a beacon announces, from the DS
+// Parameter Set or else the HT Operation element, 0 if neither is present.
+func beaconChannel(ies []byte) int {
+	if ds := ie.FindIE(ies, ie.TagDSParameterSet); len(ds) > 0 {
+		return int(ds[0])
+	}
+	if ht := ie.FindIE(ies, ie.TagHTOperation); len(ht) > 0 {
+		return int(ht[0])
+	}
+	return 0
+}
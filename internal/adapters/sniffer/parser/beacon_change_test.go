@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// beaconWithIEs assembles a beacon of the test AP carrying the given elements.
+func beaconWithIEs(ies ...byte) *layers.Dot11 {
+	frame := []byte{0x80, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	frame = append(frame, testAP...)
+	frame = append(frame, testAP...)
+	frame = append(frame, 0, 0)
+	frame = append(frame, make([]byte, 8)...)         // Timestamp
+	frame = append(frame, 0x64, 0, 0x01, 0)           // Interval, capabilities
+	frame = append(append(frame, ies...), 0, 0, 0, 0) // FCS
+	packet := gopacket.NewPacket(frame, layers.LayerTypeDot11, gopacket.Default)
+	return packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+}
+
+func TestBeaconChangeTracker(t *testing.T) {
+	tracker := newBeaconChangeTracker()
+	base := beaconProfile{interval: 100, rates: "1* 2* 5.5 11", rsn: "OPEN", channel: 6}
+
+	assert.Empty(t, tracker.observe(testAP.String(), base), "first beacon")
+	assert.Empty(t, tracker.observe(testAP.String(), base), "same configuration")
+
+	noChannel := base
+	noChannel.channel = 0
+	assert.Empty(t, tracker.observe(testAP.String(), noChannel), "channel unknown")
+
+	changed := base
+	changed.interval = 200
+	changed.channel = 11
+	changes := tracker.observe(testAP.String(), changed)
+	assert.Equal(t, []domain.ConfigChange{
+		{Field: "beacon_interval", Before: "100", After: "200"},
+		{Field: "channel", Before: "6", After: "11"},
+	}, changes, "channel compared against the last beacon announcing one")
+}
+
+func TestDetectBeaconChange(t *testing.T) {
+	h := NewPacketHandler(nil, false, nil, nil, nil)
+	device := &domain.Device{MAC: testAP.String(), BeaconInterval: 100, Security: domain.SecurityOpen}
+
+	rates := []byte{1, 4, 0x82, 0x84, 0x0b, 0x16}
+	ds := []byte{3, 1, 6}
+	assert.Nil(t, h.detectBeaconChange(beaconWithIEs(append(rates, ds...)...), device))
+
+	alert := h.detectBeaconChange(beaconWithIEs(append([]byte{1, 2, 0x82, 0x84}, ds...)...), device)
+	require.NotNil(t, alert)
+	assert.Equal(t, "BEACON_CHANGED", alert.Subtype)
+	assert.Equal(t, []domain.ConfigChange{
+		{Field: "supported_rates", Before: "1* 2* 5.5 11", After: "1* 2*"},
+	}, alert.Changes)
+}
@@ -2,6 +2,9 @@ package parser
 
 import (
 	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/google/gopacket"
@@ -58,13 +61,6 @@ func (h *PacketHandler) handleHandshakeCapture(packet gopacket.Packet) (bool, *d
 				return true, alert
 			}
 		}
-
-		// Passive M1 Analysis (Nonce Randomness)
-		if isEAPOLKey(packet) {
-			if alert := h.analyzeM1(packet); alert != nil {
-				return true, alert
-			}
-		}
 	}
 	return false, nil
 }
@@ -130,85 +126,47 @@ func (h *PacketHandler) detectPMKID(packet gopacket.Packet) *domain.Vulnerabilit
 	return nil
 }
 
-func (h *PacketHandler) analyzeM1(packet gopacket.Packet) *domain.Alert {
-	eapolLayer := packet.Layer(layers.LayerTypeEAPOL)
-	if eapolLayer == nil {
-		return nil
-	}
-
-	eapol, ok := eapolLayer.(*layers.EAPOL)
-	if !ok || eapol.Type != layers.EAPOLTypeKey {
-		return nil
-	}
-
-	payload := eapol.LayerPayload()
-	// EAPOL Key Frame Check
-	// Descriptor Type (1 byte) | Key Info (2) | Key Len (2) | Replay Counter (8) | Key Nonce (32)
-	// Offset for Nonce: 1 + 2 + 2 + 8 = 13
-	if len(payload) < 13+32 {
-		return nil
-	}
-
-	// Verify it's M1 (Key Ack set, Key MIC NOT set)
-	// Key Info is at offset 1 (2 bytes). Big Endian.
-	// Bit 7: Key Ack (check if set). Bit 8: Key MIC (check if NOT set).
-	// 0x0080 = Key Ack. 0x0100 = Key MIC. (Depends on endianness in packet vs parsing)
-	// Actually G1/G2 bits differ. Assuming 802.11 endianness.
-	// Let's rely on FromDS check for AP direction + having Nonce.
-
-	dot11Layer := packet.Layer(layers.LayerTypeDot11)
-	if dot11Layer == nil {
-		return nil
-	}
-	dot11 := dot11Layer.(*layers.Dot11)
-
-	if !dot11.Flags.FromDS() {
-		return nil // Client -> AP
+// analyzeNonces checks handshake nonces for the marks of a broken random
+// number generator. The device returned carries the evidence, with sample
+// captures of the frames when handshakes are saved.
+func (h *PacketHandler) analyzeNonces(packet gopacket.Packet) (*domain.Device, *domain.Alert) {
+	finding := h.nonces.observe(packet, time.Now())
+	if finding == nil {
+		return nil, nil
 	}
 
-	nonce := payload[13 : 13+32]
-
-	// 1. Zero Nonce Check
-	allZero := true
-	for _, b := range nonce {
-		if b != 0 {
-			allZero = false
-			break
+	evidence := []string{finding.evidence}
+	if h.HandshakeManager != nil {
+		if path, err := h.HandshakeManager.SaveSample(finding.mac, "BAD-RNG", finding.samples...); err == nil {
+			evidence = append(evidence, "Sample capture: "+filepath.Base(path))
+		} else {
+			log.Printf("Error saving weak nonce sample of %s: %v", finding.mac, err)
 		}
 	}
 
-	if allZero {
-		return &domain.Alert{
-			Type:      domain.AlertAnomaly,
-			Subtype:   "WEAK_CRYPTO_ZERO_NONCE",
-			Severity:  domain.SeverityCritical,
-			Message:   "Critical Crypto Flaw: AP generating Zero Nonce in Handshake",
-			Details:   "BSSID: " + dot11.Address3.String(),
-			DeviceMAC: dot11.Address3.String(),
-			Timestamp: time.Now(),
-		}
+	device := &domain.Device{
+		MAC:            finding.mac,
+		Type:           domain.DeviceTypeStation,
+		LastPacketTime: time.Now(),
+		LastSeen:       time.Now(),
+		WeakNonces:     &domain.WeakNonces{Zero: finding.kind == nonceZero, Evidence: evidence},
 	}
-
-	// 2. Repeating Pattern Check (e.g., all 0xAA)
-	first := nonce[0]
-	allSame := true
-	for _, b := range nonce {
-		if b != first {
-			allSame = false
-			break
-		}
+	alert := &domain.Alert{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "WEAK_CRYPTO_BAD_RNG",
+		Severity:  domain.SeverityHigh,
+		Message:   "Weak RNG Detected: " + finding.evidence,
+		Details:   strings.Join(evidence, "; "),
+		DeviceMAC: finding.mac,
+		Timestamp: time.Now(),
 	}
-	if allSame {
-		return &domain.Alert{
-			Type:      domain.AlertAnomaly,
-			Subtype:   "WEAK_CRYPTO_BAD_RNG",
-			Severity:  domain.SeverityHigh,
-			Message:   "Weak RNG Detected: Nonce contains repeating bytes",
-			Details:   fmt.Sprintf("Pattern: %02x, BSSID: %s", first, dot11.Address3.String()),
-			DeviceMAC: dot11.Address3.String(),
-			Timestamp: time.Now(),
-		}
+	if finding.ap {
+		device.Type = domain.DeviceTypeAP
 	}
-
-	return nil
+	if finding.kind == nonceZero {
+		alert.Subtype = "WEAK_CRYPTO_ZERO_NONCE"
+		alert.Severity = domain.SeverityCritical
+		alert.Message = "Critical Crypto Flaw: " + finding.evidence
+	}
+	return device, alert
 }
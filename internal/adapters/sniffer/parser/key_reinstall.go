@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/handshake"
+)
+
+const (
+	// maxKeySessions bounds the tracker, the least recently seen sessions
+	// being dropped first.
+	maxKeySessions = 4096
+	// pnRestartCeiling is the highest packet number a freshly installed key
+	// is expected to be back at. Lower ones after a retransmission are a
+	// restart, higher ones are frames sent out of order.
+	pnRestartCeiling = 64
+	// groupRetryWindow tells a retransmitted group message 1 from a rekey,
+	// which comes minutes after the previous one.
+	groupRetryWindow = 5 * time.Second
+	// maxKeyEvidence bounds the EAPOL frames kept as evidence per session.
+	maxKeyEvidence = 8
+)
+
+// keySession is the key exchange between an AP and one of its clients.
+type keySession struct {
+	anonce   []byte
+	m3       uint64 // Replay counter of the last message 3
+	group    uint64 // Replay counter of the last group message 1
+	groupAt  time.Time
+	armed    bool   // A message was retransmitted: a vulnerable client reinstalls its key
+	highest  uint64 // Highest CCMP packet number the client sent since the key was installed
+	evidence []string
+	reported bool
+}
+
+// keyReinstallTracker spots key reinstallations (KRACK) passively. When an
+// AP retransmits message 3 of the 4-way handshake, or message 1 of a group
+// key handshake, a vulnerable client installs the same key again and resets
+// its packet number, reusing CCMP nonces. The evidence is the retransmitted
+// EAPOL frames and the first data frame whose packet number went back.
+type keyReinstallTracker struct {
+	mu       sync.Mutex
+	sessions *lru[string, *keySession] // "AP|client"
+}
+
+func newKeyReinstallTracker() *keyReinstallTracker {
+	return &keyReinstallTracker{sessions: newLRU[string, *keySession](maxKeySessions)}
+}
+
+// observeKey records the EAPOL key frames an AP sends a client.
+func (t *keyReinstallTracker) observeKey(packet gopacket.Packet, now time.Time) {
+	dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	if !ok || dot11.Flags.ToDS() || !dot11.Flags.FromDS() {
+		return // Only AP -> client messages are retransmitted
+	}
+	frame, err := handshake.ParseEAPOLKey(packet)
+	if err != nil || !frame.HasAck {
+		return
+	}
+	key := dot11.Address2.String() + "|" + dot11.Address1.String()
+	stamp := now.Format(time.RFC3339Nano)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, _ := t.sessions.get(key)
+	if frame.IsPairwise && !frame.HasMIC {
+		// Message 1 starts a new handshake, and so a new key
+		s = &keySession{anonce: append([]byte(nil), frame.Nonce...)}
+		t.sessions.put(key, s)
+	}
+	if s == nil {
+		return // Joined mid-handshake
+	}
+
+	switch {
+	case frame.IsPairwise && frame.HasMIC:
+		if s.m3 != 0 && frame.ReplayCounter > s.m3 && string(frame.Nonce) == string(s.anonce) {
+			s.armed = true
+			s.addEvidence(fmt.Sprintf("%s message 3 retransmitted: replay counter %d after %d, ANonce %x", stamp, frame.ReplayCounter, s.m3, frame.Nonce[:8]))
+		} else if s.m3 == 0 {
+			s.addEvidence(fmt.Sprintf("%s message 3: replay counter %d, ANonce %x", stamp, frame.ReplayCounter, frame.Nonce[:8]))
+		}
+		s.m3 = frame.ReplayCounter
+	case !frame.IsPairwise:
+		if s.group != 0 && frame.ReplayCounter > s.group && now.Sub(s.groupAt) < groupRetryWindow {
+			s.armed = true
+			s.addEvidence(fmt.Sprintf("%s group message 1 retransmitted: replay counter %d after %d", stamp, frame.ReplayCounter, s.group))
+		}
+		s.group, s.groupAt = frame.ReplayCounter, now
+	}
+}
+
+// observeData checks the packet number of a protected data frame a client
+// sends its AP, and returns the evidence of a key reinstallation the first
+// time the number restarts after a retransmission.
+func (t *keyReinstallTracker) observeData(dot11 *layers.Dot11, now time.Time) []string {
+	if !dot11.Flags.WEP() || dot11.Flags.Retry() {
+		return nil // Retries legitimately repeat their packet number
+	}
+	pn, ok := ccmpPacketNumber(dot11.LayerPayload())
+	if !ok {
+		return nil
+	}
+	key := dot11.Address1.String() + "|" + dot11.Address2.String()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, _ := t.sessions.get(key)
+	if s == nil {
+		return nil
+	}
+	if s.armed && !s.reported && pn <= s.highest && pn < pnRestartCeiling {
+		s.reported = true
+		return append(append([]string(nil), s.evidence...),
+			fmt.Sprintf("%s data frame reused packet number %d, %d already sent with the key (seq %d)", now.Format(time.RFC3339Nano), pn, s.highest, dot11.SequenceNumber))
+	}
+	if pn > s.highest {
+		s.highest = pn
+	}
+	return nil
+}
+
+func (s *keySession) addEvidence(line string) {
+	if len(s.evidence) < maxKeyEvidence {
+		s.evidence = append(s.evidence, line)
+	}
+}
+
+// ccmpPacketNumber reads the 48-bit packet number of a CCMP header. WEP
+// frames have no extended IV, and TKIP ones a WEP seed where CCMP has a
+// reserved zero byte, so neither is read.
+func ccmpPacketNumber(payload []byte) (uint64, bool) {
+	if len(payload) < 8 || payload[3]&0x20 == 0 || payload[2] != 0 {
+		return 0, false
+	}
+	var pn [8]byte
+	pn[0], pn[1] = payload[0], payload[1]
+	copy(pn[2:6], payload[4:8])
+	return binary.LittleEndian.Uint64(pn[:]), true
+}
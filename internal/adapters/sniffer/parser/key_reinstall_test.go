@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAP     = net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	testClient = net.HardwareAddr{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+)
+
+// dataFrame assembles a data frame between the test AP and client, with a
+// blank FCS.
+func dataFrame(client net.HardwareAddr, fromAP bool, flags byte, body []byte) gopacket.Packet {
+	frame := []byte{0x08, 0x01 | flags, 0, 0}
+	if fromAP {
+		frame[1] = 0x02 | flags
+		frame = append(frame, client...)
+		frame = append(frame, testAP...)
+	} else {
+		frame = append(frame, testAP...)
+		frame = append(frame, client...)
+	}
+	frame = append(frame, testAP...)
+	frame = append(append(frame, 0, 0), body...)
+	frame = append(frame, 0, 0, 0, 0)
+	return gopacket.NewPacket(frame, layers.LayerTypeDot11, gopacket.Default)
+}
+
+// eapolKey assembles an EAPOL key frame between the test AP and client.
+func eapolKey(client net.HardwareAddr, fromAP bool, keyInfo uint16, replay uint64, nonce []byte, keyData int) gopacket.Packet {
+	key := make([]byte, 95+keyData)
+	key[0] = 2
+	binary.BigEndian.PutUint16(key[1:3], keyInfo)
+	binary.BigEndian.PutUint64(key[5:13], replay)
+	copy(key[13:45], nonce)
+	binary.BigEndian.PutUint16(key[93:95], uint16(keyData))
+
+	length := len(key)
+	body := []byte{0xaa, 0xaa, 0x03, 0, 0, 0, 0x88, 0x8e, 2, 3, byte(length >> 8), byte(length)}
+	return dataFrame(client, fromAP, 0, append(body, key...))
+}
+
+// keyFrame assembles an EAPOL key frame the AP sends the test client.
+func keyFrame(keyInfo uint16, replay uint64, nonce byte) gopacket.Packet {
+	return eapolKey(testClient, true, keyInfo, replay, bytes.Repeat([]byte{nonce}, 32), 0)
+}
+
+// protectedFrame assembles a CCMP protected data frame the client sends.
+func protectedFrame(pn uint64, retry bool) *layers.Dot11 {
+	flags := byte(0x40)
+	if retry {
+		flags |= 0x08
+	}
+	header := []byte{byte(pn), byte(pn >> 8), 0, 0x20, byte(pn >> 16), byte(pn >> 24), byte(pn >> 32), byte(pn >> 40)}
+	packet := dataFrame(testClient, false, flags, append(header, make([]byte, 16)...))
+	return packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+}
+
+const (
+	msg1      = 0x008a // Pairwise, Ack
+	msg2      = 0x010a // Pairwise, MIC
+	msg3      = 0x01ca // Pairwise, Ack, MIC, Install
+	groupMsg1 = 0x0382 // Ack, MIC, Secure
+)
+
+func TestKeyReinstallTracker(t *testing.T) {
+	now := time.Now()
+
+	t.Run("packet number restarts after message 3 retransmission", func(t *testing.T) {
+		tracker := newKeyReinstallTracker()
+		tracker.observeKey(keyFrame(msg1, 1, 0x42), now)
+		tracker.observeKey(keyFrame(msg3, 2, 0x42), now)
+		for pn := uint64(1); pn <= 5; pn++ {
+			assert.Nil(t, tracker.observeData(protectedFrame(pn, false), now))
+		}
+		tracker.observeKey(keyFrame(msg3, 3, 0x42), now)
+		assert.Nil(t, tracker.observeData(protectedFrame(5, true), now), "retries repeat their packet number")
+
+		evidence := tracker.observeData(protectedFrame(1, false), now)
+		require.Len(t, evidence, 3)
+		assert.Contains(t, evidence[1], "message 3 retransmitted: replay counter 3 after 2")
+		assert.Contains(t, evidence[2], "reused packet number 1, 5 already sent")
+		assert.Nil(t, tracker.observeData(protectedFrame(1, false), now), "reported once")
+	})
+
+	t.Run("patched client keeps counting", func(t *testing.T) {
+		tracker := newKeyReinstallTracker()
+		tracker.observeKey(keyFrame(msg1, 1, 0x42), now)
+		tracker.observeKey(keyFrame(msg3, 2, 0x42), now)
+		tracker.observeData(protectedFrame(5, false), now)
+		tracker.observeKey(keyFrame(msg3, 3, 0x42), now)
+		assert.Nil(t, tracker.observeData(protectedFrame(6, false), now))
+	})
+
+	t.Run("new handshake resets the key", func(t *testing.T) {
+		tracker := newKeyReinstallTracker()
+		tracker.observeKey(keyFrame(msg1, 1, 0x42), now)
+		tracker.observeKey(keyFrame(msg3, 2, 0x42), now)
+		tracker.observeData(protectedFrame(5, false), now)
+		tracker.observeKey(keyFrame(msg1, 3, 0x43), now)
+		tracker.observeKey(keyFrame(msg3, 4, 0x43), now)
+		assert.Nil(t, tracker.observeData(protectedFrame(1, false), now))
+	})
+
+	t.Run("group key retransmission", func(t *testing.T) {
+		tracker := newKeyReinstallTracker()
+		tracker.observeKey(keyFrame(msg1, 1, 0x42), now)
+		tracker.observeKey(keyFrame(msg3, 2, 0x42), now)
+		tracker.observeData(protectedFrame(9, false), now)
+		tracker.observeKey(keyFrame(groupMsg1, 3, 0), now.Add(time.Hour))
+		assert.Nil(t, tracker.observeData(protectedFrame(10, false), now), "a rekey is not a retransmission")
+		tracker.observeKey(keyFrame(groupMsg1, 4, 0), now.Add(time.Hour+time.Second))
+
+		evidence := tracker.observeData(protectedFrame(1, false), now)
+		require.NotEmpty(t, evidence)
+		assert.Contains(t, evidence[len(evidence)-2], "group message 1 retransmitted")
+	})
+}
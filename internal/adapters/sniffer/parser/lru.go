@@ -0,0 +1,58 @@
+package parser
+
+import "container/list"
+
+// lru is a map bounded to capacity entries, evicting the least recently
+// used one when full. The trackers keep their state per transmitter in one,
+// so that frames from spoofed addresses cannot grow it without limit. It is
+// not safe for concurrent use; the trackers hold their own lock.
+type lru[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List // Most recently used first
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRU[K comparable, V any](capacity int) *lru[K, V] {
+	return &lru[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the value of key and marks it used.
+func (c *lru[K, V]) get(key K) (V, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[K, V]).value, true
+}
+
+// put sets the value of key and marks it used, evicting the least recently
+// used entry if the map is over capacity.
+func (c *lru[K, V]) put(key K, value V) {
+	if elem, ok := c.items[key]; ok {
+		c.order.MoveToFront(elem)
+		elem.Value.(*lruEntry[K, V]).value = value
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key, value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// len returns the number of entries.
+func (c *lru[K, V]) len() int {
+	return c.order.Len()
+}
@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	c := newLRU[string, int](2)
+	c.put("a", 1)
+	c.put("b", 2)
+
+	v, ok := c.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	c.put("c", 3)
+	assert.Equal(t, 2, c.len())
+	_, ok = c.get("b")
+	assert.False(t, ok, "least recently used entry evicted")
+
+	c.put("a", 10)
+	v, _ = c.get("a")
+	assert.Equal(t, 10, v, "update in place")
+	assert.Equal(t, 2, c.len())
+
+	_, ok = c.get("missing")
+	assert.False(t, ok)
+}
+
+func TestNonceTracker_Bounded(t *testing.T) {
+	tracker := newNonceTracker()
+	for i := 0; i < 2*maxNonces; i++ {
+		tracker.reported.put(string(rune(i)), true)
+		tracker.anonces.put(string(rune(i)), "nonce")
+	}
+	assert.Equal(t, maxNonces, tracker.reported.len())
+	assert.Equal(t, maxNonces, tracker.anonces.len())
+}
@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/handshake"
+)
+
+const (
+	// nonceStaleAfter is how long a nonce is remembered to spot its reuse.
+	nonceStaleAfter = time.Hour
+	// maxNonces bounds each map of the tracker, the least recently seen
+	// entries being dropped first.
+	maxNonces = 8192
+)
+
+// Nonce anomalies, reported once per transmitter and kind.
+const (
+	nonceZero    = "zero"    // All-zero nonce
+	noncePattern = "pattern" // A single byte repeated
+	nonceReused  = "reused"  // Same nonce in two handshakes
+)
+
+// nonceUse is the first handshake a nonce was seen in.
+type nonceUse struct {
+	session string // Peer for ANonces, ANonce answered for SNonces
+	sample  gopacket.Packet
+	seen    time.Time
+}
+
+// nonceFinding is a weak nonce a device sent in a handshake.
+type nonceFinding struct {
+	mac      string
+	ap       bool
+	kind     string
+	evidence string
+	samples  []gopacket.Packet
+}
+
+// nonceTracker checks the nonces of 4-way handshakes for the marks of a
+// broken random number generator: all-zero or patterned values, and values
+// repeated across handshakes. An AP must draw a fresh ANonce for every
+// handshake, and a client a fresh SNonce for every ANonce it answers.
+type nonceTracker struct {
+	mu       sync.Mutex
+	nonces   *lru[string, nonceUse] // "transmitter|nonce"
+	anonces  *lru[string, string]   // "AP|client" -> last ANonce, what an SNonce answers
+	reported *lru[string, bool]     // "transmitter|kind"
+}
+
+func newNonceTracker() *nonceTracker {
+	return &nonceTracker{
+		nonces:   newLRU[string, nonceUse](maxNonces),
+		anonces:  newLRU[string, string](maxNonces),
+		reported: newLRU[string, bool](maxNonces),
+	}
+}
+
+// observe checks the nonce of message 1 (ANonce) or message 2 (SNonce).
+func (t *nonceTracker) observe(packet gopacket.Packet, now time.Time) *nonceFinding {
+	dot11, ok := packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+	if !ok {
+		return nil
+	}
+	frame, err := handshake.ParseEAPOLKey(packet)
+	if err != nil {
+		return nil
+	}
+
+	var finding nonceFinding
+	var pair string
+	switch frame.DetermineMessageNumber() {
+	case 1:
+		if !dot11.Flags.FromDS() || dot11.Flags.ToDS() {
+			return nil
+		}
+		finding.mac, finding.ap = dot11.Address2.String(), true
+		pair = finding.mac + "|" + dot11.Address1.String()
+	case 2:
+		if !dot11.Flags.ToDS() || dot11.Flags.FromDS() {
+			return nil
+		}
+		finding.mac = dot11.Address2.String()
+		pair = dot11.Address1.String() + "|" + finding.mac
+	default:
+		return nil
+	}
+	nonce := hex.EncodeToString(frame.Nonce)
+	peer := dot11.Address1.String()
+	name, msg := "SNonce", 2
+	if finding.ap {
+		name, msg = "ANonce", 1
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// A client answers the ANonce of the handshake it is in
+	session, _ := t.anonces.get(pair)
+	if finding.ap {
+		session = peer
+		t.anonces.put(pair, nonce)
+	}
+
+	switch {
+	case isZeroNonce(frame.Nonce):
+		finding.kind = nonceZero
+		finding.evidence = fmt.Sprintf("all-zero %s in message %d to %s", name, msg, peer)
+		finding.samples = []gopacket.Packet{packet}
+	case isPatternedNonce(frame.Nonce):
+		finding.kind = noncePattern
+		finding.evidence = fmt.Sprintf("%s of repeated %02x bytes in message %d to %s", name, frame.Nonce[0], msg, peer)
+		finding.samples = []gopacket.Packet{packet}
+	default:
+		key := finding.mac + "|" + nonce
+		first, seen := t.nonces.get(key)
+		if !seen || now.Sub(first.seen) >= nonceStaleAfter {
+			t.nonces.put(key, nonceUse{session: session, sample: packet, seen: now})
+			return nil
+		}
+		if first.session == session {
+			return nil // Retransmission within the same handshake
+		}
+		finding.kind = nonceReused
+		finding.evidence = fmt.Sprintf("%s %s reused in two handshakes, %s apart", name, nonce, now.Sub(first.seen).Round(time.Second))
+		finding.samples = []gopacket.Packet{first.sample, packet}
+	}
+
+	reported := finding.mac + "|" + finding.kind
+	if _, done := t.reported.get(reported); done {
+		return nil
+	}
+	t.reported.put(reported, true)
+	return &finding
+}
+
+func isZeroNonce(nonce []byte) bool {
+	for _, b := range nonce {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isPatternedNonce(nonce []byte) bool {
+	for _, b := range nonce {
+		if b != nonce[0] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/handshake"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// randomNonce returns a nonce that is neither zero nor patterned.
+func randomNonce(seed byte) []byte {
+	nonce := make([]byte, 32)
+	for i := range nonce {
+		nonce[i] = seed + byte(i)
+	}
+	return nonce
+}
+
+func TestNonceTracker(t *testing.T) {
+	now := time.Now()
+	other := net.HardwareAddr{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0x01}
+
+	t.Run("zero ANonce", func(t *testing.T) {
+		tracker := newNonceTracker()
+		finding := tracker.observe(eapolKey(testClient, true, msg1, 1, make([]byte, 32), 0), now)
+		require.NotNil(t, finding)
+		assert.True(t, finding.ap)
+		assert.Equal(t, testAP.String(), finding.mac)
+		assert.Equal(t, nonceZero, finding.kind)
+		assert.Nil(t, tracker.observe(eapolKey(other, true, msg1, 1, make([]byte, 32), 0), now), "reported once")
+	})
+
+	t.Run("patterned SNonce", func(t *testing.T) {
+		tracker := newNonceTracker()
+		finding := tracker.observe(eapolKey(testClient, false, msg2, 1, bytes.Repeat([]byte{0xaa}, 32), 22), now)
+		require.NotNil(t, finding)
+		assert.False(t, finding.ap)
+		assert.Equal(t, testClient.String(), finding.mac)
+		assert.Equal(t, noncePattern, finding.kind)
+	})
+
+	t.Run("ANonce reused for another client", func(t *testing.T) {
+		tracker := newNonceTracker()
+		assert.Nil(t, tracker.observe(eapolKey(testClient, true, msg1, 1, randomNonce(1), 0), now))
+		assert.Nil(t, tracker.observe(eapolKey(testClient, true, msg1, 2, randomNonce(1), 0), now), "retransmitted message 1")
+
+		finding := tracker.observe(eapolKey(other, true, msg1, 1, randomNonce(1), 0), now.Add(time.Minute))
+		require.NotNil(t, finding)
+		assert.Equal(t, nonceReused, finding.kind)
+		assert.Contains(t, finding.evidence, "reused in two handshakes, 1m0s apart")
+		assert.Len(t, finding.samples, 2)
+	})
+
+	t.Run("SNonce reused for another ANonce", func(t *testing.T) {
+		tracker := newNonceTracker()
+		tracker.observe(eapolKey(testClient, true, msg1, 1, randomNonce(1), 0), now)
+		assert.Nil(t, tracker.observe(eapolKey(testClient, false, msg2, 1, randomNonce(100), 22), now))
+		assert.Nil(t, tracker.observe(eapolKey(testClient, false, msg2, 2, randomNonce(100), 22), now), "retransmitted message 2")
+
+		tracker.observe(eapolKey(testClient, true, msg1, 3, randomNonce(50), 0), now)
+		finding := tracker.observe(eapolKey(testClient, false, msg2, 3, randomNonce(100), 22), now)
+		require.NotNil(t, finding)
+		assert.Equal(t, nonceReused, finding.kind)
+		assert.Equal(t, testClient.String(), finding.mac)
+	})
+}
+
+func TestAnalyzeNonces_SavesSample(t *testing.T) {
+	dir := t.TempDir()
+	h := NewPacketHandler(nil, false, handshake.NewHandshakeManager(dir), nil, nil)
+	defer h.HandshakeManager.Close()
+
+	device, alert := h.analyzeNonces(eapolKey(testClient, true, msg1, 1, make([]byte, 32), 0))
+	require.NotNil(t, device)
+	require.NotNil(t, alert)
+	assert.Equal(t, domain.DeviceTypeAP, device.Type)
+	assert.True(t, device.WeakNonces.Zero)
+	assert.Equal(t, "WEAK_CRYPTO_ZERO_NONCE", alert.Subtype)
+
+	samples, err := os.ReadDir(filepath.Join(dir, handshake.SampleDir))
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Contains(t, device.WeakNonces.Evidence, "Sample capture: "+samples[0].Name())
+}
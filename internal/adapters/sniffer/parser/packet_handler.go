@@ -12,6 +12,7 @@ import (
 	"github.com/lcalzada-xor/wmap/internal/adapters/fingerprint"
 	"github.com/lcalzada-xor/wmap/internal/adapters/fingerprint/mapper"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/handshake"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/ie"
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/geo"
 )
@@ -27,10 +28,35 @@ type PacketHandler struct {
 
 	// Optimization: Throttle cache (Sharded)
 	throttleCache *ShardedCache
+
+	// Beacon sequence numbers, to spot spoofed management frames
+	sequences *sequenceTracker
+
+	// EAPOL retransmissions and packet numbers, to spot key reinstallations
+	keys *keyReinstallTracker
+
+	// Handshake nonces, to spot broken random number generators
+	nonces *nonceTracker
+
+	// IVs of WEP encrypted frames, to tell how soon a WEP key falls
+	wep *wepTracker
+
+	// Beacon timers, to estimate AP uptime and spot reboots
+	uptime *uptimeTracker
+
+	// Beacon configuration per BSSID, to spot reconfigured or spoofed APs
+	beacons *beaconChangeTracker
+
+	// Sequence spaces per transmitter, to spot spoofed MAC addresses
+	interleave *interleaveTracker
 }
 
 const shardCount = 32
 
+// capabilityPrivacy is the Privacy bit of the capability information of
+// beacons and probe responses.
+const capabilityPrivacy = 0x0010
+
 type ShardedCache struct {
 	shards [shardCount]shard
 }
@@ -104,6 +130,13 @@ func NewPacketHandler(loc geo.Provider, debug bool, hm *handshake.HandshakeManag
 		VendorRepo:        repo,
 		PauseCallback:     pauseFunc,
 		throttleCache:     newShardedCache(),
+		sequences:         newSequenceTracker(),
+		keys:              newKeyReinstallTracker(),
+		nonces:            newNonceTracker(),
+		wep:               newWEPTracker(),
+		uptime:            newUptimeTracker(),
+		beacons:           newBeaconChangeTracker(),
+		interleave:        newInterleaveTracker(),
 	}
 }
 
@@ -120,8 +153,17 @@ func (h *PacketHandler) HandlePacket(packet gopacket.Packet) (dev *domain.Device
 	}()
 
 	// 1. Handshake & Passive Vulnerability Detection
-	if stop, alert := h.handleHandshakeCapture(packet); stop || alert != nil {
-		return nil, alert
+	var weakNonces *domain.Device
+	var nonceAlert *domain.Alert
+	if isEAPOLKey(packet) {
+		h.keys.observeKey(packet, time.Now())
+		weakNonces, nonceAlert = h.analyzeNonces(packet)
+	}
+	if stop, alert := h.handleHandshakeCapture(packet); stop || alert != nil || weakNonces != nil {
+		if alert == nil {
+			alert = nonceAlert
+		}
+		return weakNonces, alert
 	}
 
 	dot11Layer := packet.Layer(layers.LayerTypeDot11)
@@ -133,17 +175,27 @@ func (h *PacketHandler) HandlePacket(packet gopacket.Packet) (dev *domain.Device
 		return nil, nil
 	}
 
-	// 2. Throttling
+	// 2. Sequence tracking, ahead of throttling so no beacon is missed
+	seqGap := h.sequences.observe(dot11, time.Now())
+	h.wep.observe(dot11)
+	h.uptime.observe(dot11, time.Now())
+	if alert := h.detectInterleavedSequences(dot11); alert != nil {
+		setFrameContext(alert, dot11, packet, seqGap)
+		return nil, alert
+	}
+
+	// 3. Throttling
 	if h.shouldThrottlePacket(dot11, packet) {
 		return nil, nil
 	}
 
-	// 3. Basic RF Info
+	// 4. Basic RF Info
 	rssi, freq, channelWidth := extractBasicDeviceInfo(packet)
 
 	// Initialize basic Device struct
 	device := &domain.Device{
 		RSSI:           rssi,
+		ChainRSSI:      extractChainSignals(packet.Data()),
 		Frequency:      freq,
 		Channel:        frequencyToChannel(freq), // Derive channel from frequency
 		ChannelWidth:   channelWidth,
@@ -153,16 +205,33 @@ func (h *PacketHandler) HandlePacket(packet gopacket.Packet) (dev *domain.Device
 		LastSeen:       time.Now(),
 	}
 
-	// 4. Threat Detection (Deauth/Disassoc)
+	// 5. Threat Detection (Deauth/Disassoc)
 	if threatDev, threatAlert := h.detectThreats(dot11, packet, device); threatAlert != nil {
+		setFrameContext(threatAlert, dot11, packet, seqGap)
 		return threatDev, threatAlert
 	}
 
-	// 5. Dispatch based on frame type
+	// 6. Dispatch based on frame type
 	mainType := dot11.Type.MainType()
 	if mainType == layers.Dot11TypeMgmt {
-		return h.handleMgmtFrame(packet, dot11, device), nil
+		// Channel switch announcements still update the AP
+		alert := h.detectChannelSwitch(dot11, packet, device)
+		if alert != nil {
+			setFrameContext(alert, dot11, packet, seqGap)
+		}
+		// Reboots are still recorded in the uptime when a switch takes the alert
+		if rebootAlert := h.reportUptime(dot11, device); alert == nil {
+			alert = rebootAlert
+		}
+		mgmtDevice := h.handleMgmtFrame(packet, dot11, device)
+		if changeAlert := h.detectBeaconChange(dot11, mgmtDevice); alert == nil {
+			alert = changeAlert
+		}
+		return mgmtDevice, alert
 	} else if mainType == layers.Dot11TypeData {
+		if ap, alert := h.reportWEP(dot11, device); ap != nil {
+			return ap, alert
+		}
 		return h.handleDataFrame(packet, dot11, device), nil
 	}
 
@@ -181,7 +250,8 @@ func (h *PacketHandler) shouldThrottlePacket(dot11 *layers.Dot11, packet gopacke
 		dot11.Type == layers.Dot11TypeMgmtReassociationReq ||
 		dot11.Type == layers.Dot11TypeMgmtAuthentication ||
 		dot11.Type.MainType() == layers.Dot11TypeData ||
-		isEAPOLKey(packet)
+		isEAPOLKey(packet) ||
+		channelSwitchIE(dot11, packet) != nil
 
 	if !isCritical {
 		if h.throttleCache.shouldThrottle(sourceMAC, 500*time.Millisecond) {
@@ -221,11 +291,39 @@ func (h *PacketHandler) detectThreats(dot11 *layers.Dot11, packet gopacket.Packe
 		Timestamp: time.Now(),
 		Message:   "Deauthentication/Disassociation Frame Detected",
 		Details:   "BSSID: " + dot11.Address3.String(),
+		RSSI:      device.RSSI,
+		Latitude:  device.Latitude,
+		Longitude: device.Longitude,
 	}
 	if dot11.Address1.String() == "ff:ff:ff:ff:ff:ff" {
 		alert.Subtype = "BROADCAST_DEAUTH"
 	}
 
+	// Check Reason Code
+	var reasonCode layers.Dot11Reason
+	foundReason := false
+
+	if dot11.Type == layers.Dot11TypeMgmtDeauthentication {
+		if layer := packet.Layer(layers.LayerTypeDot11MgmtDeauthentication); layer != nil {
+			if deauth, ok := layer.(*layers.Dot11MgmtDeauthentication); ok {
+				reasonCode = deauth.Reason
+				foundReason = true
+			}
+		}
+	} else if dot11.Type == layers.Dot11TypeMgmtDisassociation {
+		if layer := packet.Layer(layers.LayerTypeDot11MgmtDisassociation); layer != nil {
+			if disassoc, ok := layer.(*layers.Dot11MgmtDisassociation); ok {
+				reasonCode = disassoc.Reason
+				foundReason = true
+			}
+		}
+	}
+
+	if foundReason {
+		alert.ReasonCode = int(reasonCode)
+		alert.Details += fmt.Sprintf(", Reason: %d", reasonCode)
+	}
+
 	// Logic: Identify who is disconnecting
 	// Addr1: Dest, Addr2: Source, Addr3: BSSID
 	isAPKicking := dot11.Address2.String() == dot11.Address3.String()
@@ -252,37 +350,76 @@ func (h *PacketHandler) detectThreats(dot11 *layers.Dot11, packet gopacket.Packe
 	device.Vendor = h.getVendor(device.MAC) // Ensure vendor is set
 
 	// Auth Failure Diagnostics
-	// Check Reason Code
-	var reasonCode layers.Dot11Reason
-	foundReason := false
+	// Reason 2: Previous authentication no longer valid
+	// Reason 15: 4-Way Handshake timeout
+	// Reason 23: IEEE 802.1X authentication failed
+	if foundReason && (reasonCode == 2 || reasonCode == 15 || reasonCode == 23) {
+		device.ConnectionError = "auth_failed"
+	}
 
-	if dot11.Type == layers.Dot11TypeMgmtDeauthentication {
-		if layer := packet.Layer(layers.LayerTypeDot11MgmtDeauthentication); layer != nil {
-			if deauth, ok := layer.(*layers.Dot11MgmtDeauthentication); ok {
-				reasonCode = deauth.Reason
-				foundReason = true
-			}
-		}
-	} else if dot11.Type == layers.Dot11TypeMgmtDisassociation {
-		if layer := packet.Layer(layers.LayerTypeDot11MgmtDisassociation); layer != nil {
-			if disassoc, ok := layer.(*layers.Dot11MgmtDisassociation); ok {
-				reasonCode = disassoc.Reason
-				foundReason = true
-			}
-		}
+	return device, alert
+}
+
+// detectChannelSwitch raises an alert for Channel Switch Announcements, sent
+// either as Spectrum Management action frames or in beacons and probe
+// responses. Forged announcements move clients off their AP.
+func (h *PacketHandler) detectChannelSwitch(dot11 *layers.Dot11, packet gopacket.Packet, device *domain.Device) *domain.Alert {
+	data := channelSwitchIE(dot11, packet)
+	if data == nil {
+		return nil
+	}
+	csa, err := ie.ParseChannelSwitch(data)
+	if err != nil {
+		return nil
 	}
 
-	if foundReason {
-		alert.Details += fmt.Sprintf(", Reason: %d", reasonCode)
-		// Reason 2: Previous authentication no longer valid
-		// Reason 15: 4-Way Handshake timeout
-		// Reason 23: IEEE 802.1X authentication failed
-		if reasonCode == 2 || reasonCode == 15 || reasonCode == 23 {
-			device.ConnectionError = "auth_failed"
+	return &domain.Alert{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "CSA_DETECTED",
+		DeviceMAC: dot11.Address2.String(), // Transmitter
+		TargetMAC: dot11.Address1.String(),
+		Timestamp: time.Now(),
+		Message:   "Channel Switch Announcement Detected",
+		Details: fmt.Sprintf("BSSID: %s, New channel: %d, Count: %d, Mode: %d",
+			dot11.Address3.String(), csa.NewChannel, csa.Count, csa.Mode),
+		RSSI:      device.RSSI,
+		Latitude:  device.Latitude,
+		Longitude: device.Longitude,
+	}
+}
+
+// channelSwitchIE returns the Channel Switch Announcement element of a frame,
+// or nil if it carries none.
+func channelSwitchIE(dot11 *layers.Dot11, packet gopacket.Packet) []byte {
+	switch dot11.Type {
+	case layers.Dot11TypeMgmtAction:
+		// Category 0 (Spectrum Management), Action 4 (Channel Switch Announcement)
+		payload := dot11.LayerPayload()
+		if len(payload) < 2 || payload[0] != 0 || payload[1] != 4 {
+			return nil
 		}
+		return ie.FindIE(payload[2:], ie.TagChannelSwitch)
+	case layers.Dot11TypeMgmtBeacon, layers.Dot11TypeMgmtProbeResp:
+		// Fixed parameters: Timestamp(8) + Interval(2) + Capabilities(2)
+		payload := dot11.LayerPayload()
+		if len(payload) < 12 {
+			return nil
+		}
+		return ie.FindIE(payload[12:], ie.TagChannelSwitch)
 	}
+	return nil
+}
 
-	return device, alert
+// setFrameContext records the offending frame on an alert for attacker
+// analysis and evidence capture.
+func setFrameContext(alert *domain.Alert, dot11 *layers.Dot11, packet gopacket.Packet, seqGap int) {
+	alert.BSSID = dot11.Address3.String()
+	alert.Sequence = int(dot11.SequenceNumber)
+	alert.SeqGap = seqGap
+	alert.Frame = append([]byte(nil), packet.Data()...)
+	if ts := packet.Metadata().Timestamp; !ts.IsZero() {
+		alert.Timestamp = ts
+	}
 }
 
 func (h *PacketHandler) handleMgmtFrame(packet gopacket.Packet, dot11 *layers.Dot11, device *domain.Device) *domain.Device {
@@ -291,10 +428,15 @@ func (h *PacketHandler) handleMgmtFrame(packet gopacket.Packet, dot11 *layers.Do
 	device.Vendor = h.getVendor(device.MAC)
 	device.PacketsCount = 1
 	device.DataTransmitted = int64(len(packet.Data()))
+	if dot11.Flags.Retry() {
+		device.RetryCount = 1
+	}
 
 	var ieData []byte
 	isBeacon := false
 	isProbe := false
+	privacy := false // Capability of APs encrypting their traffic
+	var capabilities uint16
 
 	// Check Frame Type based on Dot11 header first (safer than checking for layer existence)
 	if dot11.Type == layers.Dot11TypeMgmtBeacon {
@@ -303,6 +445,11 @@ func (h *PacketHandler) handleMgmtFrame(packet gopacket.Packet, dot11 *layers.Do
 		device.Capabilities = append(device.Capabilities, "Beacon")
 		if beacon := packet.Layer(layers.LayerTypeDot11MgmtBeacon); beacon != nil {
 			ieData = beacon.LayerPayload()
+			if b, ok := beacon.(*layers.Dot11MgmtBeacon); ok {
+				device.BeaconInterval = int(b.Interval)
+				capabilities = b.Flags
+				privacy = b.Flags&capabilityPrivacy != 0
+			}
 		}
 	} else if dot11.Type == layers.Dot11TypeMgmtProbeReq {
 		isProbe = true
@@ -317,6 +464,9 @@ func (h *PacketHandler) handleMgmtFrame(packet gopacket.Packet, dot11 *layers.Do
 		device.Capabilities = append(device.Capabilities, "ProbeResp")
 		if resp := packet.Layer(layers.LayerTypeDot11MgmtProbeResp); resp != nil {
 			ieData = resp.LayerPayload()
+			if r, ok := resp.(*layers.Dot11MgmtProbeResp); ok {
+				privacy = r.Flags&capabilityPrivacy != 0
+			}
 		}
 	} else if dot11.Type == layers.Dot11TypeMgmtAssociationReq || dot11.Type == layers.Dot11TypeMgmtReassociationReq {
 		// Client -> AP (Requesting connection)
@@ -431,6 +581,13 @@ func (h *PacketHandler) handleMgmtFrame(packet gopacket.Packet, dot11 *layers.Do
 	}
 
 	mapper.ParseIEs(ieData, device)
+	if privacy && device.Security == domain.SecurityOpen {
+		// Encrypted without an RSN or WPA element: only WEP is left
+		device.Security = domain.SecurityWEP
+	}
+	if isBeacon {
+		device.BeaconFingerprint = mapper.BuildBeaconFingerprint(ieData, capabilities)
+	}
 
 	// Randomized MAC Check & Fingerprinting
 	h.FingerprintEngine.AnalyzeRandomization(dot11.Address2, device)
@@ -490,6 +647,7 @@ func (h *PacketHandler) handleDataFrame(packet gopacket.Packet, dot11 *layers.Do
 			device.ConnectionState = domain.StateHandshake
 		} else {
 			device.ConnectionState = domain.StateConnected
+			device.KeyReinstallation = h.keys.observeData(dot11, time.Now())
 		}
 
 		device.DataTransmitted = payloadLen
@@ -519,8 +677,9 @@ func (h *PacketHandler) handleDataFrame(packet gopacket.Packet, dot11 *layers.Do
 
 		device.DataReceived = payloadLen
 		device.PacketsCount = 1
-		// Retries here are usually AP retrying sending to STA.
-		// We might not attribute this to the STA's "bad behavior" but it reflects link quality.
+		// Retries here are the AP retrying to reach the STA. Not the STA's
+		// "bad behavior", but they reflect the quality of its link.
+		device.RetryCount = retryVal
 		h.FingerprintEngine.AnalyzeRandomization(dot11.Address1, device)
 		return device
 	}
@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"encoding/binary"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// RadioTap presence bits used when walking extended bitmaps.
+const (
+	rtBitDBMAntennaSignal = 5
+	rtBitAntenna          = 11
+	rtBitRadiotapNS       = 29
+	rtBitVendorNS         = 30
+	rtBitExt              = 31
+)
+
+// radiotapFieldLayout gives the alignment and size of the standard RadioTap
+// fields up to HE-MU-other-user, indexed by presence bit. Walking stops at
+// the first field not described here since its size is unknown.
+var radiotapFieldLayout = [...]struct{ align, size int }{
+	{8, 8},  // 0 TSFT
+	{1, 1},  // 1 Flags
+	{1, 1},  // 2 Rate
+	{2, 4},  // 3 Channel
+	{2, 2},  // 4 FHSS
+	{1, 1},  // 5 dBm antenna signal
+	{1, 1},  // 6 dBm antenna noise
+	{2, 2},  // 7 Lock quality
+	{2, 2},  // 8 TX attenuation
+	{2, 2},  // 9 dB TX attenuation
+	{1, 1},  // 10 dBm TX power
+	{1, 1},  // 11 Antenna
+	{1, 1},  // 12 dB antenna signal
+	{1, 1},  // 13 dB antenna noise
+	{2, 2},  // 14 RX flags
+	{2, 2},  // 15 TX flags
+	{1, 1},  // 16 RTS retries
+	{1, 1},  // 17 Data retries
+	{4, 8},  // 18 XChannel
+	{1, 3},  // 19 MCS
+	{4, 8},  // 20 A-MPDU status
+	{2, 12}, // 21 VHT
+	{8, 12}, // 22 Timestamp
+	{2, 12}, // 23 HE
+	{2, 12}, // 24 HE-MU
+	{2, 6},  // 25 HE-MU-other-user
+}
+
+// rtNamespace holds the per-antenna fields found in one RadioTap namespace.
+type rtNamespace struct {
+	antenna, signal       int
+	hasAntenna, hasSignal bool
+}
+
+// extractChainSignals parses the per-antenna signal of multi-chain adapters.
+// Drivers such as mac80211 report the combined signal in the first RadioTap
+// namespace and one extra namespace (antenna index + dBm signal) per RX chain,
+// which gopacket ignores. Returns nil when no per-antenna data is present.
+func extractChainSignals(data []byte) []domain.ChainSignal {
+	if len(data) < 8 {
+		return nil
+	}
+	length := int(binary.LittleEndian.Uint16(data[2:4]))
+	if length > len(data) {
+		return nil
+	}
+	data = data[:length]
+
+	// Collect the presence bitmaps
+	var bitmaps []uint32
+	pos := 4
+	for {
+		if pos+4 > len(data) {
+			return nil
+		}
+		word := binary.LittleEndian.Uint32(data[pos : pos+4])
+		bitmaps = append(bitmaps, word)
+		pos += 4
+		if word&(1<<rtBitExt) == 0 {
+			break
+		}
+	}
+
+	var namespaces []rtNamespace
+	current := rtNamespace{}
+	inRadiotap := true
+	vendorPending := false // Vendor data is laid out once per namespace, not per bitmap
+	offset := pos
+
+walk:
+	for _, word := range bitmaps {
+		if inRadiotap {
+			for bit := 0; bit < rtBitRadiotapNS; bit++ {
+				if word&(1<<bit) == 0 {
+					continue
+				}
+				if bit >= len(radiotapFieldLayout) {
+					break walk // Unknown field size: nothing after it can be located
+				}
+				layout := radiotapFieldLayout[bit]
+				offset += (layout.align - offset%layout.align) % layout.align
+				if offset+layout.size > len(data) {
+					break walk
+				}
+				switch bit {
+				case rtBitDBMAntennaSignal:
+					current.signal = int(int8(data[offset]))
+					current.hasSignal = true
+				case rtBitAntenna:
+					current.antenna = int(data[offset])
+					current.hasAntenna = true
+				}
+				offset += layout.size
+			}
+		} else if vendorPending {
+			// Vendor namespace: OUI(3) + sub-namespace(1) + skip length(2), then data
+			vendorPending = false
+			offset += offset % 2
+			if offset+6 > len(data) {
+				break walk
+			}
+			offset += 6 + int(binary.LittleEndian.Uint16(data[offset+4:offset+6]))
+		}
+
+		// Namespace of the next bitmap
+		if word&(1<<rtBitRadiotapNS|1<<rtBitVendorNS) != 0 {
+			if inRadiotap {
+				namespaces = append(namespaces, current)
+			}
+			current = rtNamespace{}
+			inRadiotap = word&(1<<rtBitRadiotapNS) != 0
+			vendorPending = !inRadiotap
+		}
+	}
+	if inRadiotap {
+		namespaces = append(namespaces, current)
+	}
+
+	return chainSignals(namespaces)
+}
+
+// chainSignals keeps the namespaces carrying a per-antenna signal. The first
+// namespace is the combined signal unless it is the only one and names its antenna.
+func chainSignals(namespaces []rtNamespace) []domain.ChainSignal {
+	var chains []domain.ChainSignal
+	for i, ns := range namespaces {
+		if !ns.hasSignal || (i == 0 && (len(namespaces) > 1 || !ns.hasAntenna)) {
+			continue
+		}
+		antenna := ns.antenna
+		if !ns.hasAntenna {
+			antenna = i - 1
+		}
+		chains = append(chains, domain.ChainSignal{Antenna: antenna, RSSI: ns.signal})
+	}
+	return chains
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// radiotapHeader assembles a RadioTap header from presence bitmaps and field bytes.
+func radiotapHeader(bitmaps []uint32, fields []byte) []byte {
+	hdr := make([]byte, 4+4*len(bitmaps))
+	for i, b := range bitmaps {
+		binary.LittleEndian.PutUint32(hdr[4+4*i:], b)
+	}
+	hdr = append(hdr, fields...)
+	binary.LittleEndian.PutUint16(hdr[2:4], uint16(len(hdr)))
+	return hdr
+}
+
+func TestExtractChainSignals(t *testing.T) {
+	const (
+		flags   = 1 << 1
+		channel = 1 << 3
+		signal  = 1 << rtBitDBMAntennaSignal
+		antenna = 1 << rtBitAntenna
+		rtNS    = 1 << rtBitRadiotapNS
+		vendNS  = 1 << rtBitVendorNS
+		ext     = 1 << rtBitExt
+	)
+	dbm := func(v int8) byte { return byte(v) }
+
+	t.Run("mac80211 two chains", func(t *testing.T) {
+		// Header is 16 bytes: flags@16, pad@17, channel@18..21, signal@22, chain0@23..24, chain1@25..26
+		data := radiotapHeader(
+			[]uint32{flags | channel | signal | rtNS | ext, signal | antenna | rtNS | ext, signal | antenna},
+			[]byte{0x10, 0x00, 0x6c, 0x09, 0xa0, 0x00, dbm(-40), dbm(-42), 0, dbm(-47), 1},
+		)
+		assert.Equal(t, []domain.ChainSignal{{Antenna: 0, RSSI: -42}, {Antenna: 1, RSSI: -47}}, extractChainSignals(data))
+	})
+
+	t.Run("single namespace with antenna", func(t *testing.T) {
+		data := radiotapHeader([]uint32{signal | antenna}, []byte{dbm(-60), 2})
+		assert.Equal(t, []domain.ChainSignal{{Antenna: 2, RSSI: -60}}, extractChainSignals(data))
+	})
+
+	t.Run("combined signal only", func(t *testing.T) {
+		data := radiotapHeader([]uint32{flags | signal}, []byte{0x00, dbm(-55)})
+		assert.Nil(t, extractChainSignals(data))
+	})
+
+	t.Run("vendor namespace is skipped", func(t *testing.T) {
+		// Header is 16 bytes: signal@16, pad@17, vendor hdr@18..23 (skip 2), vendor data@24..25, chain@26..27
+		data := radiotapHeader(
+			[]uint32{signal | vendNS | ext, rtNS | ext, signal | antenna},
+			[]byte{dbm(-50), 0, 0x00, 0x11, 0x22, 0x01, 0x02, 0x00, 0xaa, 0xbb, dbm(-53), 3},
+		)
+		assert.Equal(t, []domain.ChainSignal{{Antenna: 3, RSSI: -53}}, extractChainSignals(data))
+	})
+
+	t.Run("truncated header", func(t *testing.T) {
+		assert.Nil(t, extractChainSignals([]byte{0, 0, 8}))
+	})
+}
@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// maxSeqStep is the largest forward jump that continues a sequence
+	// space; larger jumps, or going backwards, belong to another one.
+	maxSeqStep = 128
+	// interleaveSwitches is how many alternations between two sequence
+	// spaces raise an alert.
+	interleaveSwitches = 6
+	// minSpaceFrames is the frames each space needs before it counts.
+	minSpaceFrames = 3
+	// interleaveWindow bounds how long alternations accumulate.
+	interleaveWindow = time.Minute
+	// interleaveStaleAfter is how long a transmitter is remembered without frames.
+	interleaveStaleAfter = 10 * time.Second
+	// interleaveCooldown is the minimum time between alerts per transmitter.
+	interleaveCooldown = 5 * time.Minute
+	// maxInterleaveTracked bounds the tracker, the transmitters heard least
+	// recently being dropped first.
+	maxInterleaveTracked = 4096
+)
+
+// seqSpace is a run of sequence numbers counting up from one source.
+type seqSpace struct {
+	last   uint16
+	frames int
+}
+
+// seqSpaces are the sequence spaces heard from one transmitter.
+type seqSpaces struct {
+	spaces   [2]seqSpace
+	used     int // Spaces in use
+	current  int // Space of the last frame
+	switches int // Alternations between spaces since windowAt
+	windowAt time.Time
+	seen     time.Time
+	alerted  time.Time
+}
+
+// interleaveTracker follows the sequence numbers of management frames per
+// transmitter. A device numbers its frames from a single counter, so frames
+// of one MAC alternating between two unrelated sequence spaces come from two
+// radios: the device and someone spoofing it. Beacons are tracked apart,
+// since many chipsets number them in firmware with a counter of their own.
+type interleaveTracker struct {
+	mu sync.Mutex
+	tx *lru[string, *seqSpaces] // Transmitter and frame class
+}
+
+func newInterleaveTracker() *interleaveTracker {
+	return &interleaveTracker{tx: newLRU[string, *seqSpaces](maxInterleaveTracked)}
+}
+
+// observe records the sequence number of a management frame and returns
+// the two spaces when they have interleaved enough to alert.
+func (t *interleaveTracker) observe(dot11 *layers.Dot11, now time.Time) (*seqSpaces, bool) {
+	if dot11.Type.MainType() != layers.Dot11TypeMgmt || dot11.Flags.Retry() {
+		return nil, false
+	}
+	key := dot11.Address2.String()
+	if dot11.Type == layers.Dot11TypeMgmtBeacon {
+		key += "/beacon"
+	}
+	seq := dot11.SequenceNumber
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, _ := t.tx.get(key)
+	if s == nil || now.Sub(s.seen) >= interleaveStaleAfter {
+		alerted := time.Time{}
+		if s != nil {
+			alerted = s.alerted
+		}
+		s = &seqSpaces{used: 1, windowAt: now, alerted: alerted}
+		s.spaces[0] = seqSpace{last: seq, frames: 1}
+		s.seen = now
+		t.tx.put(key, s)
+		return nil, false
+	}
+	s.seen = now
+
+	if now.Sub(s.windowAt) >= interleaveWindow {
+		s.switches = 0
+		s.windowAt = now
+	}
+
+	matched := -1
+	for i := 0; i < s.used; i++ {
+		step := (seq - s.spaces[i].last + seqModulo) % seqModulo
+		if step == 0 {
+			return nil, false // Repeated frame
+		}
+		if step <= maxSeqStep {
+			matched = i
+			break
+		}
+	}
+
+	switch {
+	case matched >= 0:
+		s.spaces[matched].last = seq
+		s.spaces[matched].frames++
+		if matched != s.current {
+			s.switches++
+			s.current = matched
+		}
+	case s.used < len(s.spaces):
+		// A second space, or the counter jumped; alternation tells them apart
+		s.spaces[s.used] = seqSpace{last: seq, frames: 1}
+		s.current = s.used
+		s.used++
+	default:
+		// Neither space continues: the older one is replaced
+		s.spaces[1-s.current] = seqSpace{last: seq, frames: 1}
+		s.current = 1 - s.current
+		s.switches = 0
+	}
+
+	if s.switches < interleaveSwitches || s.spaces[0].frames < minSpaceFrames || s.spaces[1].frames < minSpaceFrames {
+		return nil, false
+	}
+	if !s.alerted.IsZero() && now.Sub(s.alerted) < interleaveCooldown {
+		return nil, false
+	}
+	s.alerted = now
+	snapshot := *s
+	s.switches = 0
+	return &snapshot, true
+}
+
+// detectInterleavedSequences alerts when the management frames of a
+// transmitter alternate between two sequence spaces.
+func (h *PacketHandler) detectInterleavedSequences(dot11 *layers.Dot11) *domain.Alert {
+	spaces, ok := h.interleave.observe(dot11, time.Now())
+	if !ok {
+		return nil
+	}
+
+	frames := "management frames"
+	if dot11.Type == layers.Dot11TypeMgmtBeacon {
+		frames = "beacons"
+	}
+	return &domain.Alert{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "SEQ_INTERLEAVED",
+		Severity:  domain.SeverityHigh,
+		DeviceMAC: dot11.Address2.String(),
+		TargetMAC: dot11.Address1.String(),
+		Timestamp: time.Now(),
+		Message:   "MAC address spoofed: two interleaved sequence number spaces",
+		Details: fmt.Sprintf("%s of %s alternate %d times between sequence numbers near %d (%d frames) and %d (%d frames)",
+			frames, dot11.Address2, spaces.switches, spaces.spaces[0].last, spaces.spaces[0].frames,
+			spaces.spaces[1].last, spaces.spaces[1].frames),
+	}
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seqFrame assembles a management frame of the test AP with the given frame
+// control type byte and sequence number.
+func seqFrame(frameType byte, seq uint16) *layers.Dot11 {
+	frame := []byte{frameType, 0, 0, 0}
+	frame = append(frame, testClient...)
+	frame = append(frame, testAP...)
+	frame = append(frame, testAP...)
+	frame = append(frame, byte(seq<<4), byte(seq>>4))
+	frame = append(frame, 7, 0, 0, 0, 0, 0) // Reason code, FCS
+	packet := gopacket.NewPacket(frame, layers.LayerTypeDot11, gopacket.Default)
+	return packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+}
+
+const deauthType = 0xc0
+
+func TestInterleaveTracker(t *testing.T) {
+	now := time.Now()
+
+	t.Run("single counter, with a jump", func(t *testing.T) {
+		tracker := newInterleaveTracker()
+		for i, seq := range []uint16{10, 11, 12, 2000, 2001, 2002, 2003, 2004, 2005, 2006, 2007} {
+			_, ok := tracker.observe(seqFrame(deauthType, seq), now.Add(time.Duration(i)*time.Millisecond))
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("two interleaved counters", func(t *testing.T) {
+		tracker := newInterleaveTracker()
+		var alerted *seqSpaces
+		for i := uint16(0); i < 8; i++ {
+			for j, seq := range []uint16{100 + i, 3000 + i} {
+				at := now.Add(time.Duration(2*int(i)+j) * time.Millisecond)
+				if spaces, ok := tracker.observe(seqFrame(deauthType, seq), at); ok {
+					require.Nil(t, alerted, "alerted once")
+					alerted = spaces
+				}
+			}
+		}
+		require.NotNil(t, alerted)
+		assert.GreaterOrEqual(t, alerted.switches, interleaveSwitches)
+	})
+
+	t.Run("beacons counted apart", func(t *testing.T) {
+		tracker := newInterleaveTracker()
+		for i := uint16(0); i < 8; i++ {
+			_, ok := tracker.observe(seqFrame(0x80, 100+i), now)
+			assert.False(t, ok)
+			_, ok = tracker.observe(seqFrame(deauthType, 3000+i), now)
+			assert.False(t, ok)
+		}
+	})
+}
+
+func TestDetectInterleavedSequences(t *testing.T) {
+	h := NewPacketHandler(nil, false, nil, nil, nil)
+	for i := uint16(0); i < 8; i++ {
+		assert.Nil(t, h.detectInterleavedSequences(seqFrame(deauthType, 100+i)))
+		if alert := h.detectInterleavedSequences(seqFrame(deauthType, 3000+i)); alert != nil {
+			assert.Equal(t, "SEQ_INTERLEAVED", alert.Subtype)
+			assert.Equal(t, testAP.String(), alert.DeviceMAC)
+			return
+		}
+	}
+	t.Fatal("no alert")
+}
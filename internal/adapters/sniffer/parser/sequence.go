@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/gopacket/layers"
+)
+
+const (
+	// seqModulo is the range of the 12-bit 802.11 sequence number.
+	seqModulo = 4096
+	// seqStaleAfter is how long a beacon sequence number stays a valid reference.
+	seqStaleAfter = 2 * time.Second
+	// maxSeqSamples bounds the tracker before stale entries are dropped.
+	maxSeqSamples = 4096
+)
+
+// seqSample is the last sequence number an AP used in its own frames.
+type seqSample struct {
+	seq  uint16
+	seen time.Time
+}
+
+// sequenceTracker remembers the sequence numbers APs put in their beacons and
+// probe responses. A deauthentication or channel switch claiming to come from
+// an AP with a sequence number far from its recent beacons was most likely
+// injected by someone else.
+type sequenceTracker struct {
+	mu      sync.Mutex
+	samples map[string]seqSample
+}
+
+func newSequenceTracker() *sequenceTracker {
+	return &sequenceTracker{samples: make(map[string]seqSample)}
+}
+
+// observe records the sequence number of AP frames and returns the distance
+// of the frame from the transmitter's last beacon, 0 if unknown.
+func (t *sequenceTracker) observe(dot11 *layers.Dot11, now time.Time) int {
+	switch dot11.Type {
+	case layers.Dot11TypeMgmtBeacon, layers.Dot11TypeMgmtProbeResp,
+		layers.Dot11TypeMgmtDeauthentication, layers.Dot11TypeMgmtDisassociation, layers.Dot11TypeMgmtAction:
+	default:
+		return 0
+	}
+	ta := dot11.Address2.String()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	gap := 0
+	if last, ok := t.samples[ta]; ok && now.Sub(last.seen) < seqStaleAfter {
+		gap = int((dot11.SequenceNumber - last.seq + seqModulo) % seqModulo)
+	}
+	if dot11.Type == layers.Dot11TypeMgmtBeacon || dot11.Type == layers.Dot11TypeMgmtProbeResp {
+		t.samples[ta] = seqSample{seq: dot11.SequenceNumber, seen: now}
+		if len(t.samples) > maxSeqSamples {
+			t.prune(now)
+		}
+	}
+	return gap
+}
+
+// prune drops stale samples. Caller holds t.mu.
+func (t *sequenceTracker) prune(now time.Time) {
+	for ta, s := range t.samples {
+		if now.Sub(s.seen) >= seqStaleAfter {
+			delete(t.samples, ta)
+		}
+	}
+}
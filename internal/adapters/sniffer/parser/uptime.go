@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// rebootSlack is how much later the boot time implied by a beacon may
+	// fall before the timer is taken as reset; it absorbs clock drift and
+	// capture latency.
+	rebootSlack = 5 * time.Second
+	// maxReboots bounds the reboots remembered per AP.
+	maxReboots = 16
+	// maxUptimeAPs bounds the tracker, the APs heard least recently being
+	// dropped first.
+	maxUptimeAPs = 4096
+)
+
+// apClock is the TSF timer last heard from an AP.
+type apClock struct {
+	uptime   domain.APUptime
+	rebooted bool // A reset not yet reported
+}
+
+// uptimeTracker follows the TSF timers of APs, set to zero on power on, to
+// estimate their uptime and spot reboots during the assessment: a timer
+// implying a later boot time than before was reset.
+type uptimeTracker struct {
+	mu  sync.Mutex
+	aps *lru[string, *apClock] // BSSID
+}
+
+func newUptimeTracker() *uptimeTracker {
+	return &uptimeTracker{aps: newLRU[string, *apClock](maxUptimeAPs)}
+}
+
+// observe records the timer of a beacon or probe response.
+func (t *uptimeTracker) observe(dot11 *layers.Dot11, now time.Time) {
+	if dot11.Type != layers.Dot11TypeMgmtBeacon && dot11.Type != layers.Dot11TypeMgmtProbeResp {
+		return
+	}
+	payload := dot11.LayerPayload()
+	if len(payload) < 8 {
+		return
+	}
+	tsf := binary.LittleEndian.Uint64(payload[:8])
+	uptime := time.Duration(tsf) * time.Microsecond
+	if uptime < 0 {
+		return // Past what time.Duration holds, surely garbage
+	}
+	bootedAt := now.Add(-uptime)
+	bssid := dot11.Address2.String()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c, ok := t.aps.get(bssid)
+	if !ok {
+		c = &apClock{}
+		t.aps.put(bssid, c)
+	} else if bootedAt.Sub(c.uptime.BootedAt) > rebootSlack {
+		c.uptime.Reboots = append(c.uptime.Reboots, bootedAt)
+		if len(c.uptime.Reboots) > maxReboots {
+			c.uptime.Reboots = c.uptime.Reboots[1:]
+		}
+		c.rebooted = true
+	}
+	c.uptime.TSF = tsf
+	c.uptime.UptimeSeconds = int64(uptime / time.Second)
+	c.uptime.BootedAt = bootedAt
+}
+
+// report returns the uptime of bssid and whether it rebooted since the last
+// report.
+func (t *uptimeTracker) report(bssid string) (*domain.APUptime, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c, ok := t.aps.get(bssid)
+	if !ok {
+		return nil, false
+	}
+	uptime := c.uptime
+	uptime.Reboots = append([]time.Time(nil), c.uptime.Reboots...)
+	rebooted := c.rebooted
+	c.rebooted = false
+	return &uptime, rebooted
+}
+
+// reportUptime sets the uptime of the AP sending a beacon or probe response,
+// and alerts when its timer was reset since it was last reported.
+func (h *PacketHandler) reportUptime(dot11 *layers.Dot11, device *domain.Device) *domain.Alert {
+	if dot11.Type != layers.Dot11TypeMgmtBeacon && dot11.Type != layers.Dot11TypeMgmtProbeResp {
+		return nil
+	}
+	uptime, rebooted := h.uptime.report(dot11.Address2.String())
+	if uptime == nil {
+		return nil
+	}
+	device.Uptime = uptime
+	if !rebooted {
+		return nil
+	}
+
+	return &domain.Alert{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "AP_REBOOTED",
+		Severity:  domain.SeverityLow,
+		DeviceMAC: dot11.Address2.String(),
+		Timestamp: time.Now(),
+		Message:   "AP rebooted during assessment",
+		Details: fmt.Sprintf("Beacon timer reset, up %s since %s (%d reboots heard)",
+			time.Duration(uptime.UptimeSeconds)*time.Second, uptime.BootedAt.Format(time.RFC3339), len(uptime.Reboots)),
+		RSSI:      device.RSSI,
+		Latitude:  device.Latitude,
+		Longitude: device.Longitude,
+	}
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// beaconFrame assembles a beacon of the test AP with the given TSF timer.
+func beaconFrame(tsf time.Duration) *layers.Dot11 {
+	frame := []byte{0x80, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	frame = append(frame, testAP...)
+	frame = append(frame, testAP...)
+	frame = append(frame, 0, 0)
+	frame = binary.LittleEndian.AppendUint64(frame, uint64(tsf/time.Microsecond))
+	frame = append(frame, 0x64, 0, 0x01, 0, 0, 0, 0, 0, 0, 0) // Interval, capabilities, empty SSID, FCS
+	packet := gopacket.NewPacket(frame, layers.LayerTypeDot11, gopacket.Default)
+	return packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+}
+
+func TestUptimeTracker(t *testing.T) {
+	tracker := newUptimeTracker()
+	start := time.Now()
+
+	tracker.observe(beaconFrame(time.Hour), start)
+	uptime, rebooted := tracker.report(testAP.String())
+	require.NotNil(t, uptime)
+	assert.False(t, rebooted)
+	assert.Equal(t, int64(3600), uptime.UptimeSeconds)
+	assert.WithinDuration(t, start.Add(-time.Hour), uptime.BootedAt, time.Millisecond)
+
+	// Beacons a minute apart, the timer advancing alike
+	tracker.observe(beaconFrame(time.Hour+time.Minute), start.Add(time.Minute))
+	_, rebooted = tracker.report(testAP.String())
+	assert.False(t, rebooted, "drift within slack")
+
+	// Back from a reboot two minutes later
+	tracker.observe(beaconFrame(30*time.Second), start.Add(3*time.Minute))
+	uptime, rebooted = tracker.report(testAP.String())
+	assert.True(t, rebooted)
+	assert.Equal(t, int64(30), uptime.UptimeSeconds)
+	require.Len(t, uptime.Reboots, 1)
+	assert.WithinDuration(t, start.Add(150*time.Second), uptime.Reboots[0], time.Millisecond)
+
+	_, rebooted = tracker.report(testAP.String())
+	assert.False(t, rebooted, "reported once")
+}
+
+func TestReportUptime(t *testing.T) {
+	h := NewPacketHandler(nil, false, nil, nil, nil)
+	device := &domain.Device{}
+
+	h.uptime.observe(beaconFrame(time.Hour), time.Now().Add(-time.Minute))
+	assert.Nil(t, h.reportUptime(beaconFrame(time.Hour), device))
+	require.NotNil(t, device.Uptime)
+
+	h.uptime.observe(beaconFrame(time.Second), time.Now())
+	alert := h.reportUptime(beaconFrame(time.Second), device)
+	require.NotNil(t, alert)
+	assert.Equal(t, "AP_REBOOTED", alert.Subtype)
+	assert.Equal(t, testAP.String(), alert.DeviceMAC)
+	assert.Len(t, device.Uptime.Reboots, 1)
+}
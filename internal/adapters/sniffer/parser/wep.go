@@ -0,0 +1,193 @@
+package parser
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// maxWEPAPs bounds the APs whose IVs are counted, each taking 64 KiB.
+	maxWEPAPs = 16
+	// wepSampleBits sets the share of the IV space whose IVs are kept, one
+	// in 2^wepSampleBits: the unique IVs are estimated from that sample, as
+	// a bitset of every IV would take 2 MiB per AP. The estimate is within
+	// a few percent past a thousand IVs, whatever order the IVs come in.
+	wepSampleBits = 5
+	// wepReportEvery is how many new unique IVs an AP collects between updates.
+	wepReportEvery = 1000
+)
+
+// wepIVThresholds are the unique IV counts that raise an alert when reached.
+var wepIVThresholds = []int{domain.WEPIVsPossible, domain.WEPIVsLikely, domain.WEPIVsCertain}
+
+// wepCounter counts the IVs of one WEP AP.
+type wepCounter struct {
+	sampled  []uint64 // Bitset of the sampled IVs, by their rank in the sample
+	weak     []uint64 // Bitset of the 13*256 weak IVs
+	stats    domain.WEPIVStats
+	reported int // UniqueIVs at the last report
+}
+
+func newWEPCounter() *wepCounter {
+	return &wepCounter{
+		sampled: make([]uint64, 1<<(24-wepSampleBits)/64),
+		weak:    make([]uint64, (13*256+63)/64),
+	}
+}
+
+// wepSample returns the rank of an IV in the sample, false if it is not
+// sampled. The IVs are sampled by a multiplicative hash, so sequential and
+// random IVs alike spread over the sample. Multiplying by an odd number is a
+// bijection of the 24-bit IVs, so the hash itself ranks the sampled ones.
+func wepSample(iv uint32) (uint32, bool) {
+	h := iv * 0x9e3779 & (1<<24 - 1) // 2^24 divided by the golden ratio
+	if h >= 1<<(24-wepSampleBits) {
+		return 0, false
+	}
+	return h, true
+}
+
+// setBit sets a bit of a bitset, reporting whether it was clear.
+func setBit(bits []uint64, i uint32) bool {
+	word, bit := i/64, uint64(1)<<(i%64)
+	if bits[word]&bit != 0 {
+		return false
+	}
+	bits[word] |= bit
+	return true
+}
+
+// wepReport is an update on the IVs of a WEP AP.
+type wepReport struct {
+	stats     domain.WEPIVStats
+	threshold int // Unique IV threshold reached since the last report, 0 if none
+}
+
+// wepTracker counts the IVs of WEP encrypted data frames per AP. Every
+// unique IV is a keystream sample for the statistical attacks on RC4, so
+// their count tells how close the key is to being recovered.
+type wepTracker struct {
+	mu  sync.Mutex
+	aps map[string]*wepCounter // BSSID
+}
+
+func newWEPTracker() *wepTracker {
+	return &wepTracker{aps: make(map[string]*wepCounter)}
+}
+
+// observe counts the IV of a WEP encrypted data frame.
+func (t *wepTracker) observe(dot11 *layers.Dot11) {
+	iv, ok := wepIV(dot11)
+	if !ok {
+		return
+	}
+	bssid := frameBSSID(dot11)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := t.aps[bssid]
+	if c == nil {
+		if len(t.aps) >= maxWEPAPs {
+			return
+		}
+		c = newWEPCounter()
+		t.aps[bssid] = c
+	}
+
+	c.stats.Frames++
+	if rank, ok := wepSample(iv); ok && setBit(c.sampled, rank) {
+		c.stats.UniqueIVs += 1 << wepSampleBits
+	}
+	if isWeakIV(iv) && setBit(c.weak, (iv>>16-3)<<8|iv&0xff) {
+		c.stats.WeakIVs++
+	}
+}
+
+// report returns the IV counts of bssid once enough new IVs have been
+// collected since the last report.
+func (t *wepTracker) report(bssid string) *wepReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := t.aps[bssid]
+	if c == nil || c.stats.UniqueIVs-c.reported < wepReportEvery {
+		return nil
+	}
+	r := &wepReport{stats: c.stats}
+	for _, threshold := range wepIVThresholds {
+		if c.reported < threshold && c.stats.UniqueIVs >= threshold {
+			r.threshold = threshold
+		}
+	}
+	c.reported = c.stats.UniqueIVs
+	return r
+}
+
+// wepIV reads the 24-bit IV of a WEP encrypted data frame. TKIP and CCMP
+// frames set the extended IV bit and are skipped.
+func wepIV(dot11 *layers.Dot11) (uint32, bool) {
+	if dot11.Type.MainType() != layers.Dot11TypeData || !dot11.Flags.WEP() {
+		return 0, false
+	}
+	payload := dot11.LayerPayload()
+	if len(payload) < 8 || payload[3]&0x3f != 0 {
+		return 0, false // Extended IV or reserved bits set
+	}
+	return uint32(payload[0])<<16 | uint32(payload[1])<<8 | uint32(payload[2]), true
+}
+
+// isWeakIV tells the IVs of the FMS attack, (B+3, 0xff, x), which leak key
+// byte B.
+func isWeakIV(iv uint32) bool {
+	first, second := iv>>16, iv>>8&0xff
+	return first >= 3 && first < 16 && second == 0xff
+}
+
+// frameBSSID returns the BSSID of a data frame from its DS bits.
+func frameBSSID(dot11 *layers.Dot11) string {
+	switch {
+	case dot11.Flags.ToDS() && !dot11.Flags.FromDS():
+		return dot11.Address1.String()
+	case dot11.Flags.FromDS() && !dot11.Flags.ToDS():
+		return dot11.Address2.String()
+	}
+	return dot11.Address3.String()
+}
+
+// reportWEP updates the AP sending a WEP data frame with its IV counts, and
+// alerts when enough IVs have been collected to recover its key. Only frames
+// the AP sends are used, so that the signal is its own.
+func (h *PacketHandler) reportWEP(dot11 *layers.Dot11, device *domain.Device) (*domain.Device, *domain.Alert) {
+	if !dot11.Flags.FromDS() || dot11.Flags.ToDS() {
+		return nil, nil
+	}
+	r := h.wep.report(dot11.Address2.String())
+	if r == nil {
+		return nil, nil
+	}
+
+	device.MAC = dot11.Address2.String()
+	device.Type = domain.DeviceTypeAP
+	device.Vendor = h.getVendor(device.MAC)
+	device.Security = domain.SecurityWEP
+	device.WEPIVs = &r.stats
+	if r.threshold == 0 {
+		return device, nil
+	}
+
+	crackability := r.stats.Crackability()
+	return device, &domain.Alert{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "WEP_CRACKABLE",
+		Severity:  domain.SeverityHigh,
+		Message:   fmt.Sprintf("WEP key recovery %s: %d unique IVs captured", crackability, r.stats.UniqueIVs),
+		Details:   fmt.Sprintf("%d encrypted frames, %d unique IVs, %d weak IVs", r.stats.Frames, r.stats.UniqueIVs, r.stats.WeakIVs),
+		DeviceMAC: device.MAC,
+		Timestamp: time.Now(),
+	}
+}
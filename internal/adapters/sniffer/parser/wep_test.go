@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/geo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wepFrame assembles a WEP encrypted data frame with the given IV.
+func wepFrame(iv uint32, fromAP bool) *layers.Dot11 {
+	body := append([]byte{byte(iv >> 16), byte(iv >> 8), byte(iv), 0}, make([]byte, 16)...)
+	packet := dataFrame(testClient, fromAP, 0x40, body)
+	return packet.Layer(layers.LayerTypeDot11).(*layers.Dot11)
+}
+
+func TestWEPTracker(t *testing.T) {
+	t.Run("counts unique and weak IVs per AP", func(t *testing.T) {
+		tracker := newWEPTracker()
+		tracker.observe(wepFrame(0x03ff01, false))
+		tracker.observe(wepFrame(0x03ff01, true))
+		tracker.observe(wepFrame(0x000001, true))
+
+		c := tracker.aps[testAP.String()]
+		require.NotNil(t, c)
+		assert.Equal(t, 3, c.stats.Frames)
+		assert.Equal(t, 1, c.stats.WeakIVs, "weak IVs are counted once")
+	})
+
+	t.Run("estimates unique IVs from a sample", func(t *testing.T) {
+		for name, next := range map[string]func(uint32) uint32{
+			"sequential": func(i uint32) uint32 { return i },
+			"random":     func(i uint32) uint32 { return i * 2654435761 & 0xffffff },
+		} {
+			tracker := newWEPTracker()
+			for i := uint32(0); i < domain.WEPIVsLikely; i++ {
+				tracker.observe(wepFrame(next(i), true))
+				tracker.observe(wepFrame(next(i), true))
+			}
+			c := tracker.aps[testAP.String()]
+			assert.InEpsilon(t, domain.WEPIVsLikely, c.stats.UniqueIVs, 0.05, name)
+			assert.Equal(t, 2*domain.WEPIVsLikely, c.stats.Frames, name)
+		}
+	})
+
+	t.Run("skips CCMP frames", func(t *testing.T) {
+		tracker := newWEPTracker()
+		tracker.observe(protectedFrame(1, false))
+		assert.Empty(t, tracker.aps)
+	})
+
+	t.Run("reports every batch of IVs and alerts on thresholds", func(t *testing.T) {
+		tracker := newWEPTracker()
+		for iv := uint32(0); iv < wepReportEvery/2; iv++ {
+			tracker.observe(wepFrame(iv, true))
+		}
+		assert.Nil(t, tracker.report(testAP.String()))
+
+		for iv := uint32(wepReportEvery / 2); iv < 2*wepReportEvery; iv++ {
+			tracker.observe(wepFrame(iv, true))
+		}
+		r := tracker.report(testAP.String())
+		require.NotNil(t, r)
+		assert.InEpsilon(t, 2*wepReportEvery, r.stats.UniqueIVs, 0.05)
+		assert.Zero(t, r.threshold)
+		assert.Nil(t, tracker.report(testAP.String()), "reported once per batch")
+
+		for iv := uint32(1 << 16); iv < 1<<16+domain.WEPIVsPossible; iv++ {
+			tracker.observe(wepFrame(iv, false))
+		}
+		r = tracker.report(testAP.String())
+		require.NotNil(t, r)
+		assert.Equal(t, domain.WEPIVsPossible, r.threshold)
+		assert.Equal(t, domain.WEPCrackPossible, r.stats.Crackability())
+	})
+}
+
+func TestReportWEP(t *testing.T) {
+	h := NewPacketHandler(geo.NewStaticProvider(0, 0), false, nil, nil, nil)
+	for iv := uint32(0); iv < domain.WEPIVsPossible+wepReportEvery; iv++ {
+		h.wep.observe(wepFrame(iv, false))
+	}
+
+	ap, alert := h.reportWEP(wepFrame(0, false), &domain.Device{})
+	assert.Nil(t, ap, "only frames the AP sends carry its signal")
+
+	ap, alert = h.reportWEP(wepFrame(0, true), &domain.Device{})
+	require.NotNil(t, ap)
+	assert.Equal(t, testAP.String(), ap.MAC)
+	assert.Equal(t, domain.SecurityWEP, ap.Security)
+	assert.InEpsilon(t, domain.WEPIVsPossible+wepReportEvery, ap.WEPIVs.UniqueIVs, 0.05)
+	require.NotNil(t, alert)
+	assert.Equal(t, "WEP_CRACKABLE", alert.Subtype)
+}
+
+func TestWEPStatsCrackability(t *testing.T) {
+	assert.Equal(t, domain.WEPCrackInsufficient, domain.WEPIVStats{UniqueIVs: 100}.Crackability())
+	assert.Equal(t, domain.WEPCrackLikely, domain.WEPIVStats{UniqueIVs: domain.WEPIVsLikely}.Crackability())
+	assert.Equal(t, domain.WEPCrackCertain, domain.WEPIVStats{UniqueIVs: 90000}.Crackability())
+}
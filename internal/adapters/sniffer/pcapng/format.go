@@ -0,0 +1,62 @@
+package pcapng
+
+import "math"
+
+// Block types
+const (
+	blockSection      = 0x0A0D0D0A
+	blockInterface    = 0x00000001
+	blockSimple       = 0x00000003
+	blockEnhanced     = 0x00000006
+	blockCustom       = 0x00000BAD
+	blockCustomNoCopy = 0x40000BAD
+	byteOrderMagic    = 0x1A2B3C4D
+	maxBlockSize      = 16 << 20
+)
+
+// Legacy pcap magic numbers, read little endian
+const (
+	pcapMagicMicros        = 0xa1b2c3d4
+	pcapMagicNanos         = 0xa1b23c4d
+	pcapMagicMicrosSwapped = 0xd4c3b2a1
+	pcapMagicNanosSwapped  = 0x4d3cb2a1
+)
+
+// Option codes
+const (
+	optEnd           = 0
+	optComment       = 1
+	optSHBHardware   = 2
+	optSHBOS         = 3
+	optSHBUserAppl   = 4
+	optIfName        = 2
+	optIfDesc        = 3
+	optIfTsresol     = 9
+	optCustomStr     = 2988
+	optCustomBin     = 2989
+	optCustomStrNoCp = 19372
+	optCustomBinNoCp = 19373
+	maxOptionSize    = math.MaxUint16
+)
+
+// GPS options follow Kismet's layout, which Wireshark decodes: its private
+// enterprise number, then a versioned header and a bitmask of the fields
+// present, each a fixed point unsigned value.
+const (
+	kismetPEN   = 55922
+	gpsMagic    = 0x47
+	gpsVersion  = 1
+	gpsFieldLon = 0x2
+	gpsFieldLat = 0x4
+	gpsFieldAlt = 0x8
+)
+
+// hcxdumptool records the addresses it uses for its own attack frames,
+// along with their replay counter and nonces, in custom options after a 32
+// byte magic.
+const (
+	hcxMagicSize    = 32
+	hcxOptMACOrig   = 0xf29a
+	hcxOptMACAP     = 0xf29b
+	hcxOptMACClient = 0xf29e
+)
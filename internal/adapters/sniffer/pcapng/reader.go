@@ -0,0 +1,337 @@
+package pcapng
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+// Packet is a captured frame with its comments.
+type Packet struct {
+	LinkType  layers.LinkType
+	Timestamp time.Time // Zero for simple packet blocks
+	Data      []byte
+	Length    int // Original length of the frame
+	Comments  []string
+}
+
+// Metadata is the information recorded by the capture tool.
+type Metadata struct {
+	Application string
+	Hardware    string
+	OS          string
+	Comments    []string
+	ToolMACs    []string // Addresses hcxdumptool used for its own frames
+}
+
+// option is a decoded option.
+type option struct {
+	code  uint16
+	value []byte
+}
+
+// readerInterface is an interface described in the current section.
+type readerInterface struct {
+	linkType layers.LinkType
+	tsUnit   time.Duration // Zero when finer than a nanosecond
+	tsDiv    float64       // Ticks per second when tsUnit is zero
+}
+
+// Reader reads the frames of a pcapng file, or of a legacy pcap file as
+// written by airodump-ng and older tools. gopacket's NgReader is not used
+// for pcapng: it skips packet comments and custom blocks and options, which
+// carry the capture context and the addresses hcxdumptool attacked from.
+type Reader struct {
+	r          io.Reader
+	pcap       *pcapgo.Reader // Set for legacy pcap files
+	order      binary.ByteOrder
+	inSection  bool
+	interfaces []readerInterface
+	meta       Metadata
+}
+
+// NewReader reads r as pcapng or, from its magic, as legacy pcap.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+	if err != nil {
+		return nil, fmt.Errorf("not a capture file")
+	}
+	switch binary.LittleEndian.Uint32(magic) {
+	case pcapMagicMicros, pcapMagicNanos, pcapMagicMicrosSwapped, pcapMagicNanosSwapped:
+		pr, err := pcapgo.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pcap file: %w", err)
+		}
+		return &Reader{pcap: pr}, nil
+	default:
+		return &Reader{r: br, order: binary.LittleEndian}, nil
+	}
+}
+
+// Metadata returns the information read so far from the section headers
+// and custom blocks. Legacy pcap files carry none.
+func (n *Reader) Metadata() Metadata {
+	return n.meta
+}
+
+// Next returns the next captured frame, or io.EOF at the end of the file.
+func (n *Reader) Next() (*Packet, error) {
+	if n.pcap != nil {
+		return n.nextPcap()
+	}
+	for {
+		blockType, body, err := n.readBlock()
+		if err != nil {
+			return nil, err
+		}
+		switch blockType {
+		case blockSection:
+			if err := n.readSection(body); err != nil {
+				return nil, err
+			}
+		case blockInterface:
+			n.readInterface(body)
+		case blockEnhanced:
+			if pkt := n.readEnhanced(body); pkt != nil {
+				return pkt, nil
+			}
+		case blockSimple:
+			if pkt := n.readSimple(body); pkt != nil {
+				return pkt, nil
+			}
+		case blockCustom, blockCustomNoCopy:
+			n.readCustom(body)
+		}
+	}
+}
+
+func (n *Reader) nextPcap() (*Packet, error) {
+	data, ci, err := n.pcap.ReadPacketData()
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("truncated pcap record")
+		}
+		return nil, err
+	}
+	return &Packet{LinkType: n.pcap.LinkType(), Timestamp: ci.Timestamp, Data: data, Length: ci.Length}, nil
+}
+
+// readBlock reads a whole block and returns its type and body. The byte
+// order is set by every section header.
+func (n *Reader) readBlock() (uint32, []byte, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(n.r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, fmt.Errorf("truncated pcapng block header")
+		}
+		return 0, nil, err
+	}
+
+	blockType := binary.LittleEndian.Uint32(header[0:4]) // Palindromic for section headers
+	if blockType == blockSection {
+		var bom [4]byte
+		if _, err := io.ReadFull(n.r, bom[:]); err != nil {
+			return 0, nil, fmt.Errorf("truncated section header")
+		}
+		switch {
+		case binary.LittleEndian.Uint32(bom[:]) == byteOrderMagic:
+			n.order = binary.LittleEndian
+		case binary.BigEndian.Uint32(bom[:]) == byteOrderMagic:
+			n.order = binary.BigEndian
+		default:
+			return 0, nil, fmt.Errorf("not a pcapng file")
+		}
+		length := n.order.Uint32(header[4:8])
+		if length < 16 || length > maxBlockSize {
+			return 0, nil, fmt.Errorf("invalid section header length %d", length)
+		}
+		body := make([]byte, length-12)
+		if _, err := io.ReadFull(n.r, body); err != nil {
+			return 0, nil, fmt.Errorf("truncated section header")
+		}
+		return blockType, append(bom[:], body[:len(body)-4]...), nil
+	}
+	if !n.inSection {
+		return 0, nil, fmt.Errorf("not a pcapng file")
+	}
+
+	blockType = n.order.Uint32(header[0:4])
+	length := n.order.Uint32(header[4:8])
+	if length < 12 || length%4 != 0 || length > maxBlockSize {
+		return 0, nil, fmt.Errorf("invalid pcapng block length %d", length)
+	}
+	body := make([]byte, length-8)
+	if _, err := io.ReadFull(n.r, body); err != nil {
+		return 0, nil, fmt.Errorf("truncated pcapng block")
+	}
+	return blockType, body[:len(body)-4], nil
+}
+
+// readSection starts a new section: interfaces are numbered per section.
+func (n *Reader) readSection(body []byte) error {
+	if len(body) < 16 {
+		return fmt.Errorf("invalid section header")
+	}
+	if major := n.order.Uint16(body[4:6]); major != 1 {
+		return fmt.Errorf("unsupported pcapng version %d", major)
+	}
+	n.inSection = true
+	n.interfaces = nil
+	for _, opt := range n.readOptions(body[16:]) {
+		switch opt.code {
+		case optComment:
+			n.meta.Comments = append(n.meta.Comments, opt.text())
+		case optSHBHardware:
+			n.meta.Hardware = opt.text()
+		case optSHBOS:
+			n.meta.OS = opt.text()
+		case optSHBUserAppl:
+			n.meta.Application = opt.text()
+		case optCustomStr, optCustomStrNoCp, optCustomBin, optCustomBinNoCp:
+			n.readCustom(opt.value)
+		}
+	}
+	return nil
+}
+
+func (n *Reader) readInterface(body []byte) {
+	if len(body) < 8 {
+		return
+	}
+	intf := readerInterface{linkType: layers.LinkType(n.order.Uint16(body[0:2])), tsUnit: time.Microsecond}
+	for _, opt := range n.readOptions(body[8:]) {
+		if opt.code != optIfTsresol || len(opt.value) < 1 {
+			continue
+		}
+		v := opt.value[0]
+		var perSecond float64
+		if v&0x80 == 0 {
+			perSecond = math.Pow10(int(v))
+		} else {
+			perSecond = math.Pow(2, float64(v&0x7f))
+		}
+		intf.tsUnit = time.Duration(float64(time.Second) / perSecond)
+		if intf.tsUnit == 0 {
+			intf.tsDiv = perSecond
+		}
+	}
+	n.interfaces = append(n.interfaces, intf)
+}
+
+func (n *Reader) readEnhanced(body []byte) *Packet {
+	if len(body) < 20 {
+		return nil
+	}
+	ifID := int(n.order.Uint32(body[0:4]))
+	if ifID >= len(n.interfaces) {
+		return nil
+	}
+	ts := uint64(n.order.Uint32(body[4:8]))<<32 | uint64(n.order.Uint32(body[8:12]))
+	capLen := int(n.order.Uint32(body[12:16]))
+	origLen := int(n.order.Uint32(body[16:20]))
+	padded := (capLen + 3) &^ 3
+	if capLen < 0 || capLen > len(body)-20 || padded > len(body)-20 {
+		return nil
+	}
+
+	intf := n.interfaces[ifID]
+	pkt := &Packet{
+		LinkType:  intf.linkType,
+		Timestamp: intf.time(ts),
+		Data:      body[20 : 20+capLen],
+		Length:    origLen,
+	}
+	for _, opt := range n.readOptions(body[20+padded:]) {
+		if opt.code == optComment {
+			pkt.Comments = append(pkt.Comments, opt.text())
+		}
+	}
+	return pkt
+}
+
+func (n *Reader) readSimple(body []byte) *Packet {
+	if len(body) < 4 || len(n.interfaces) == 0 {
+		return nil
+	}
+	origLen := int(n.order.Uint32(body[0:4]))
+	data := body[4:]
+	if origLen < len(data) {
+		data = data[:origLen] // Drop the padding
+	}
+	return &Packet{LinkType: n.interfaces[0].linkType, Data: data, Length: origLen}
+}
+
+// readCustom reads a custom block or option: a private enterprise number
+// followed by data. hcxdumptool's data is its magic and nested options.
+func (n *Reader) readCustom(data []byte) {
+	if len(data) < 4+hcxMagicSize {
+		return
+	}
+	for _, opt := range n.readOptions(data[4+hcxMagicSize:]) {
+		switch opt.code {
+		case hcxOptMACOrig, hcxOptMACAP, hcxOptMACClient:
+			if len(opt.value) >= 6 {
+				n.meta.ToolMACs = appendUnique(n.meta.ToolMACs, formatMAC(opt.value[:6]))
+			}
+		}
+	}
+}
+
+// readOptions decodes the options of a block. Values are padded to 32 bits.
+func (n *Reader) readOptions(data []byte) []option {
+	var opts []option
+	for len(data) >= 4 {
+		code := n.order.Uint16(data[0:2])
+		length := int(n.order.Uint16(data[2:4]))
+		if code == optEnd {
+			break
+		}
+		padded := (length + 3) &^ 3
+		if 4+length > len(data) {
+			break
+		}
+		opts = append(opts, option{code: code, value: data[4 : 4+length]})
+		if 4+padded > len(data) {
+			break
+		}
+		data = data[4+padded:]
+	}
+	return opts
+}
+
+// text returns a string option without the terminators some tools write.
+func (o option) text() string {
+	return string(bytes.TrimRight(o.value, "\x00"))
+}
+
+// time converts a timestamp in the interface's resolution.
+func (i readerInterface) time(ts uint64) time.Time {
+	if i.tsUnit > 0 {
+		return time.Unix(0, 0).Add(time.Duration(ts) * i.tsUnit)
+	}
+	sec := float64(ts) / i.tsDiv
+	whole := math.Floor(sec)
+	return time.Unix(int64(whole), int64((sec-whole)*1e9))
+}
+
+func formatMAC(b []byte) string {
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", b[0], b[1], b[2], b[3], b[4], b[5])
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,191 @@
+package pcapng
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ngWriter builds little endian pcapng files with options Writer does not
+// write, such as hcxdumptool's custom options.
+type ngWriter struct {
+	buf bytes.Buffer
+}
+
+func ngOpt(code uint16, value []byte) []byte {
+	opt := make([]byte, 4, 4+len(value)+3)
+	binary.LittleEndian.PutUint16(opt[0:2], code)
+	binary.LittleEndian.PutUint16(opt[2:4], uint16(len(value)))
+	opt = append(opt, value...)
+	for len(opt)%4 != 0 {
+		opt = append(opt, 0)
+	}
+	return opt
+}
+
+func (w *ngWriter) block(blockType uint32, body []byte, opts ...[]byte) {
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+	for _, o := range opts {
+		body = append(body, o...)
+	}
+	if len(opts) > 0 {
+		body = append(body, ngOpt(optEnd, nil)...)
+	}
+	length := uint32(12 + len(body))
+	binary.Write(&w.buf, binary.LittleEndian, uint32(blockType))
+	binary.Write(&w.buf, binary.LittleEndian, length)
+	w.buf.Write(body)
+	binary.Write(&w.buf, binary.LittleEndian, length)
+}
+
+func (w *ngWriter) section(opts ...[]byte) {
+	body := make([]byte, 16)
+	binary.LittleEndian.PutUint32(body[0:4], byteOrderMagic)
+	binary.LittleEndian.PutUint16(body[4:6], 1)
+	binary.LittleEndian.PutUint64(body[8:16], ^uint64(0))
+	w.block(blockSection, body, opts...)
+}
+
+func (w *ngWriter) iface(linkType layers.LinkType, opts ...[]byte) {
+	body := make([]byte, 8)
+	binary.LittleEndian.PutUint16(body[0:2], uint16(linkType))
+	binary.LittleEndian.PutUint32(body[4:8], 65535)
+	w.block(blockInterface, body, opts...)
+}
+
+func (w *ngWriter) packet(ts uint64, data []byte, opts ...[]byte) {
+	body := make([]byte, 20, 20+len(data))
+	binary.LittleEndian.PutUint32(body[4:8], uint32(ts>>32))
+	binary.LittleEndian.PutUint32(body[8:12], uint32(ts))
+	binary.LittleEndian.PutUint32(body[12:16], uint32(len(data)))
+	binary.LittleEndian.PutUint32(body[16:20], uint32(len(data)))
+	w.block(blockEnhanced, append(body, data...), opts...)
+}
+
+func TestReader_Hcxdumptool(t *testing.T) {
+	toolAP := []byte{0x02, 0x12, 0x34, 0x56, 0x78, 0x00}
+	custom := append([]byte{0, 0, 0x2a, 0xce}, bytes.Repeat([]byte{0x2a}, hcxMagicSize)...)
+	custom = append(custom, ngOpt(hcxOptMACAP, toolAP)...)
+	custom = append(custom, ngOpt(optEnd, nil)...)
+
+	var w ngWriter
+	w.section(
+		ngOpt(optSHBUserAppl, []byte("hcxdumptool 6.3.4\x00")),
+		ngOpt(optSHBOS, []byte("Linux")),
+		ngOpt(optComment, []byte("field survey")),
+		ngOpt(optCustomBin, custom),
+	)
+	w.iface(layers.LinkTypeIEEE802_11, ngOpt(optIfTsresol, []byte{9}))
+	w.packet(1_700_000_000_123_456_789, []byte{1, 2, 3}, ngOpt(optComment, []byte("PMKID")))
+
+	r, err := NewReader(&w.buf)
+	require.NoError(t, err)
+	pkt, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, layers.LinkTypeIEEE802_11, pkt.LinkType)
+	assert.Equal(t, []byte{1, 2, 3}, pkt.Data)
+	assert.Equal(t, 3, pkt.Length)
+	assert.Equal(t, []string{"PMKID"}, pkt.Comments)
+	assert.Equal(t, time.Unix(1_700_000_000, 123_456_789), pkt.Timestamp, "nanosecond resolution")
+	_, err = r.Next()
+	assert.ErrorIs(t, err, io.EOF)
+
+	assert.Equal(t, Metadata{
+		Application: "hcxdumptool 6.3.4",
+		OS:          "Linux",
+		Comments:    []string{"field survey"},
+		ToolMACs:    []string{"02:12:34:56:78:00"},
+	}, r.Metadata())
+}
+
+func TestReader_WriterRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Section{Application: "wmap", Comments: []string{"workspace: office"}})
+	require.NoError(t, err)
+	ifID, err := w.AddInterface(Interface{LinkType: layers.LinkTypeIEEE80211Radio})
+	require.NoError(t, err)
+	ts := time.Date(2024, 1, 1, 10, 0, 0, 123456000, time.UTC)
+	frame := []byte{1, 2, 3, 4, 5}
+	ci := gopacket.CaptureInfo{Timestamp: ts, CaptureLength: len(frame), Length: 10}
+	require.NoError(t, w.WritePacket(ifID, ci, frame, PacketOptions{Comments: []string{"attack: deauth-1"}}))
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+	pkt, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, frame, pkt.Data)
+	assert.Equal(t, 10, pkt.Length)
+	assert.True(t, ts.Equal(pkt.Timestamp))
+	assert.Equal(t, []string{"attack: deauth-1"}, pkt.Comments)
+	assert.Equal(t, "wmap", r.Metadata().Application)
+	assert.Equal(t, []string{"workspace: office"}, r.Metadata().Comments)
+}
+
+func TestReader_LegacyPcap(t *testing.T) {
+	var buf bytes.Buffer
+	w := pcapgo.NewWriter(&buf)
+	require.NoError(t, w.WriteFileHeader(65535, layers.LinkTypeIEEE802_11))
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, w.WritePacket(gopacket.CaptureInfo{Timestamp: ts, CaptureLength: 2, Length: 2}, []byte{1, 2}))
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+	pkt, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, layers.LinkTypeIEEE802_11, pkt.LinkType)
+	assert.True(t, ts.Equal(pkt.Timestamp))
+	_, err = r.Next()
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, Metadata{}, r.Metadata(), "legacy pcap carries no metadata")
+}
+
+func TestReader_Invalid(t *testing.T) {
+	_, err := NewReader(bytes.NewReader(nil))
+	assert.Error(t, err)
+
+	r, err := NewReader(bytes.NewReader([]byte("not a capture file")))
+	require.NoError(t, err)
+	_, err = r.Next()
+	assert.Error(t, err)
+
+	var w ngWriter
+	w.section()
+	w.block(blockEnhanced, make([]byte, 4)) // Too short, and no interface
+	r, err = NewReader(&w.buf)
+	require.NoError(t, err)
+	_, err = r.Next()
+	assert.ErrorIs(t, err, io.EOF, "malformed blocks are skipped")
+}
+
+// FuzzReader checks that arbitrary input never panics the reader.
+func FuzzReader(f *testing.F) {
+	var w ngWriter
+	w.section(ngOpt(optComment, []byte("seed")))
+	w.iface(layers.LinkTypeIEEE80211Radio, ngOpt(optIfTsresol, []byte{0x86}))
+	w.packet(1, []byte{0, 0, 8, 0, 0, 0, 0, 0}, ngOpt(optComment, []byte("frame")))
+	f.Add(w.buf.Bytes())
+	f.Add([]byte{0xd4, 0xc3, 0xb2, 0xa1, 2, 0, 4, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r, err := NewReader(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		for i := 0; i < 1000; i++ {
+			if _, err := r.Next(); err != nil {
+				break
+			}
+		}
+		_ = r.Metadata()
+	})
+}
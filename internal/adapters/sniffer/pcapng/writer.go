@@ -0,0 +1,214 @@
+// Package pcapng reads and writes capture files in the pcapng format, which
+// unlike legacy pcap can describe the capturing interfaces and carry
+// comments and custom options with every frame.
+package pcapng
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+)
+
+// defaultSnap is the snapshot length of interfaces that do not set one.
+const defaultSnap = 65535
+
+// ErrUnknownInterface is returned for packets of an interface that was not added.
+var ErrUnknownInterface = errors.New("unknown pcapng interface")
+
+// Section describes the recording in the section header block.
+type Section struct {
+	Application string
+	Hardware    string
+	OS          string
+	Comments    []string
+}
+
+// Interface describes a capturing interface. Packets refer to it by the
+// index AddInterface returns.
+type Interface struct {
+	Name        string
+	Description string
+	LinkType    layers.LinkType
+	SnapLen     uint32 // 0 for 65535
+}
+
+// GPS is the position of the sensor when a frame was received.
+type GPS struct {
+	Latitude  float64
+	Longitude float64
+	Altitude  float64 // Meters, omitted when zero
+}
+
+// PacketOptions are written with a packet.
+type PacketOptions struct {
+	Comments []string
+	GPS      *GPS // Nil for frames without a position
+}
+
+// Writer writes a pcapng section. It is safe for concurrent use, so
+// several sniffers can record to one file, each as its own interface.
+type Writer struct {
+	mu         sync.Mutex
+	w          io.Writer
+	interfaces int
+}
+
+// NewWriter writes the section header to w and returns a writer for its
+// interfaces and packets. Timestamps are recorded in microseconds.
+func NewWriter(w io.Writer, section Section) (*Writer, error) {
+	body := make([]byte, 16)
+	binary.LittleEndian.PutUint32(body[0:4], byteOrderMagic)
+	binary.LittleEndian.PutUint16(body[4:6], 1)
+	binary.LittleEndian.PutUint64(body[8:16], math.MaxUint64) // Length not specified
+
+	var opts options
+	opts.text(optSHBHardware, section.Hardware)
+	opts.text(optSHBOS, section.OS)
+	opts.text(optSHBUserAppl, section.Application)
+	opts.comments(section.Comments)
+
+	nw := &Writer{w: w}
+	if err := nw.block(blockSection, body, opts); err != nil {
+		return nil, err
+	}
+	return nw, nil
+}
+
+// AddInterface writes an interface description block and returns the
+// interface's index.
+func (w *Writer) AddInterface(intf Interface) (int, error) {
+	snapLen := intf.SnapLen
+	if snapLen == 0 {
+		snapLen = defaultSnap
+	}
+	body := make([]byte, 8)
+	binary.LittleEndian.PutUint16(body[0:2], uint16(intf.LinkType))
+	binary.LittleEndian.PutUint32(body[4:8], snapLen)
+
+	var opts options
+	opts.text(optIfName, intf.Name)
+	opts.text(optIfDesc, intf.Description)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.block(blockInterface, body, opts); err != nil {
+		return 0, err
+	}
+	w.interfaces++
+	return w.interfaces - 1, nil
+}
+
+// WritePacket writes a frame received on an interface as an enhanced
+// packet block.
+func (w *Writer) WritePacket(ifID int, ci gopacket.CaptureInfo, data []byte, po PacketOptions) error {
+	if ci.CaptureLength != len(data) {
+		return fmt.Errorf("capture length %d does not match data length %d", ci.CaptureLength, len(data))
+	}
+	length := ci.Length
+	if length < len(data) {
+		length = len(data)
+	}
+
+	body := make([]byte, 20, 20+len(data)+3)
+	ts := uint64(ci.Timestamp.UnixMicro())
+	binary.LittleEndian.PutUint32(body[0:4], uint32(ifID))
+	binary.LittleEndian.PutUint32(body[4:8], uint32(ts>>32))
+	binary.LittleEndian.PutUint32(body[8:12], uint32(ts))
+	binary.LittleEndian.PutUint32(body[12:16], uint32(len(data)))
+	binary.LittleEndian.PutUint32(body[16:20], uint32(length))
+	body = append(body, data...)
+
+	var opts options
+	opts.comments(po.Comments)
+	if po.GPS != nil {
+		opts.add(optCustomBin, gpsOption(*po.GPS))
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if ifID < 0 || ifID >= w.interfaces {
+		return fmt.Errorf("%w: %d", ErrUnknownInterface, ifID)
+	}
+	return w.block(blockEnhanced, body, opts)
+}
+
+// block writes a block: the body is padded to 32 bits and followed by the
+// options, and the total length is repeated at the end.
+func (w *Writer) block(blockType uint32, body []byte, opts options) error {
+	for len(body)%4 != 0 {
+		body = append(body, 0)
+	}
+	if len(opts) > 0 {
+		body = append(body, opts...)
+		body = binary.LittleEndian.AppendUint32(body, optEnd)
+	}
+	length := uint32(12 + len(body))
+
+	buf := make([]byte, 0, length)
+	buf = binary.LittleEndian.AppendUint32(buf, blockType)
+	buf = binary.LittleEndian.AppendUint32(buf, length)
+	buf = append(buf, body...)
+	buf = binary.LittleEndian.AppendUint32(buf, length)
+	_, err := w.w.Write(buf)
+	return err
+}
+
+// options accumulates encoded options, each padded to 32 bits.
+type options []byte
+
+func (o *options) add(code uint16, value []byte) {
+	if len(value) > maxOptionSize {
+		value = value[:maxOptionSize]
+	}
+	*o = binary.LittleEndian.AppendUint16(*o, code)
+	*o = binary.LittleEndian.AppendUint16(*o, uint16(len(value)))
+	*o = append(*o, value...)
+	for len(*o)%4 != 0 {
+		*o = append(*o, 0)
+	}
+}
+
+func (o *options) text(code uint16, s string) {
+	if s != "" {
+		o.add(code, []byte(s))
+	}
+}
+
+func (o *options) comments(comments []string) {
+	for _, c := range comments {
+		o.text(optComment, c)
+	}
+}
+
+// gpsOption encodes a position as a Kismet GPS custom option.
+func gpsOption(g GPS) []byte {
+	fields := uint32(gpsFieldLon | gpsFieldLat)
+	values := binary.LittleEndian.AppendUint32(nil, fixed3_7(g.Longitude))
+	values = binary.LittleEndian.AppendUint32(values, fixed3_7(g.Latitude))
+	if g.Altitude != 0 {
+		fields |= gpsFieldAlt
+		values = binary.LittleEndian.AppendUint32(values, fixed6_4(g.Altitude))
+	}
+
+	opt := binary.LittleEndian.AppendUint32(nil, kismetPEN)
+	opt = append(opt, gpsMagic, gpsVersion)
+	opt = binary.LittleEndian.AppendUint16(opt, uint16(len(values)))
+	opt = binary.LittleEndian.AppendUint32(opt, fields)
+	return append(opt, values...)
+}
+
+// fixed3_7 encodes a coordinate offset by 180 degrees with 7 decimals.
+func fixed3_7(v float64) uint32 {
+	return uint32(math.Round((v + 180) * 1e7))
+}
+
+// fixed6_4 encodes an altitude offset by 180000 meters with 4 decimals.
+func fixed6_4(v float64) uint32 {
+	return uint32(math.Round((v + 180000) * 1e4))
+}
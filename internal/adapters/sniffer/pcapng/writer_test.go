@@ -0,0 +1,85 @@
+package pcapng
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Section{Application: "wmap", OS: "linux", Comments: []string{"workspace: office"}})
+	require.NoError(t, err)
+
+	radio, err := w.AddInterface(Interface{Name: "wlan0", Description: "monitor", LinkType: layers.LinkTypeIEEE80211Radio})
+	require.NoError(t, err)
+	raw, err := w.AddInterface(Interface{Name: "wlan1", LinkType: layers.LinkTypeIEEE802_11})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, []int{radio, raw})
+
+	ts := time.Date(2024, 1, 1, 10, 0, 0, 123456000, time.UTC)
+	frame := []byte{1, 2, 3, 4, 5}
+	ci := gopacket.CaptureInfo{Timestamp: ts, CaptureLength: len(frame), Length: len(frame)}
+	require.NoError(t, w.WritePacket(radio, ci, frame, PacketOptions{
+		Comments: []string{"attack: deauth-1"},
+		GPS:      &GPS{Latitude: 40.4, Longitude: -3.7, Altitude: 650},
+	}))
+	assert.Error(t, w.WritePacket(raw, ci, frame[:3], PacketOptions{}), "capture length is checked")
+	ci.CaptureLength = 3
+	require.NoError(t, w.WritePacket(raw, ci, frame[:3], PacketOptions{}))
+	assert.ErrorIs(t, w.WritePacket(2, ci, frame[:3], PacketOptions{}), ErrUnknownInterface)
+
+	assert.Contains(t, buf.String(), "workspace: office")
+	assert.Contains(t, buf.String(), "attack: deauth-1")
+
+	opts := pcapgo.DefaultNgReaderOptions
+	opts.WantMixedLinkType = true
+	r, err := pcapgo.NewNgReader(bytes.NewReader(buf.Bytes()), opts)
+	require.NoError(t, err)
+	data, got, err := r.ReadPacketData()
+	require.NoError(t, err)
+	assert.Equal(t, frame, data)
+	assert.True(t, ts.Equal(got.Timestamp))
+	assert.Equal(t, 0, got.InterfaceIndex)
+
+	data, got, err = r.ReadPacketData()
+	require.NoError(t, err)
+	assert.Equal(t, frame[:3], data)
+	assert.Equal(t, 1, got.InterfaceIndex)
+	_, _, err = r.ReadPacketData()
+	assert.ErrorIs(t, err, io.EOF)
+
+	require.Equal(t, 2, r.NInterfaces())
+	intf, err := r.Interface(0)
+	require.NoError(t, err)
+	assert.Equal(t, "wlan0", intf.Name)
+	assert.Equal(t, "monitor", intf.Description)
+	assert.Equal(t, layers.LinkTypeIEEE80211Radio, intf.LinkType)
+	intf, err = r.Interface(1)
+	require.NoError(t, err)
+	assert.Equal(t, layers.LinkTypeIEEE802_11, intf.LinkType)
+}
+
+func TestGPSOption(t *testing.T) {
+	opt := gpsOption(GPS{Latitude: 40.4168, Longitude: -3.7038})
+	require.Len(t, opt, 4+8+8)
+	assert.Equal(t, uint32(kismetPEN), binary.LittleEndian.Uint32(opt[0:4]))
+	assert.Equal(t, []byte{gpsMagic, gpsVersion}, opt[4:6])
+	assert.Equal(t, uint16(8), binary.LittleEndian.Uint16(opt[6:8]))
+	assert.Equal(t, uint32(gpsFieldLon|gpsFieldLat), binary.LittleEndian.Uint32(opt[8:12]))
+	assert.Equal(t, uint32(1762962000), binary.LittleEndian.Uint32(opt[12:16]))
+	assert.Equal(t, uint32(2204168000), binary.LittleEndian.Uint32(opt[16:20]))
+
+	opt = gpsOption(GPS{Latitude: 1, Longitude: 1, Altitude: 650.5})
+	require.Len(t, opt, 4+8+12)
+	assert.Equal(t, uint32(gpsFieldLon|gpsFieldLat|gpsFieldAlt), binary.LittleEndian.Uint32(opt[8:12]))
+	assert.Equal(t, uint32(1806505000), binary.LittleEndian.Uint32(opt[20:24]))
+}
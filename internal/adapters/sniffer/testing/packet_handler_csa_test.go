@@ -0,0 +1,55 @@
+package sniffer
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildMgmtFrame(t *testing.T, typ layers.Dot11Type, src, dst string, seq uint16, payload []byte) gopacket.Packet {
+	t.Helper()
+	srcMAC, _ := net.ParseMAC(src)
+	dstMAC, _ := net.ParseMAC(dst)
+	dot11 := &layers.Dot11{
+		Type:           typ,
+		Address1:       dstMAC,
+		Address2:       srcMAC,
+		Address3:       srcMAC,
+		SequenceNumber: seq,
+	}
+	buf := gopacket.NewSerializeBuffer()
+	require.NoError(t, gopacket.SerializeLayers(buf, gopacket.SerializeOptions{}, dot11, gopacket.Payload(payload)))
+	data := append(buf.Bytes(), 0xDE, 0xAD, 0xBE, 0xEF) // Dummy FCS
+	return gopacket.NewPacket(data, layers.LayerTypeDot11, gopacket.Default)
+}
+
+func TestHandlePacket_ChannelSwitchSpoofed(t *testing.T) {
+	handler := parser.NewPacketHandler(MockGeo{}, false, nil, nil, nil)
+	ap := "00:11:22:33:44:55"
+
+	// Beacon from the real AP sets the sequence reference
+	beacon := append(make([]byte, 12), 0, 2, 'H', 'Q')
+	_, alert := handler.HandlePacket(buildMgmtFrame(t, layers.Dot11TypeMgmtBeacon, ap, "ff:ff:ff:ff:ff:ff", 100, beacon))
+	assert.Nil(t, alert)
+
+	// Forged CSA action frame: Spectrum Management, Channel Switch to 13
+	csa := []byte{0, 4, 37, 3, 1, 13, 3}
+	_, alert = handler.HandlePacket(buildMgmtFrame(t, layers.Dot11TypeMgmtAction, ap, "ff:ff:ff:ff:ff:ff", 3000, csa))
+	require.NotNil(t, alert)
+	assert.Equal(t, "CSA_DETECTED", alert.Subtype)
+	assert.Equal(t, ap, alert.BSSID)
+	assert.Equal(t, 3000, alert.Sequence)
+	assert.Equal(t, 2900, alert.SeqGap)
+	assert.NotEmpty(t, alert.Frame)
+	assert.Contains(t, alert.Details, "New channel: 13")
+
+	// Deauth following the AP's beacons closely
+	_, alert = handler.HandlePacket(buildMgmtFrame(t, layers.Dot11TypeMgmtDeauthentication, ap, "aa:bb:cc:dd:ee:ff", 102, []byte{0x07, 0x00}))
+	require.NotNil(t, alert)
+	assert.Equal(t, 2, alert.SeqGap)
+}
@@ -0,0 +1,483 @@
+package sso
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Ensure compliance
+var _ ports.Directory = (*LDAPDirectory)(nil)
+
+// ErrLDAPInvalidCredentials is returned when the directory refuses the bind.
+var ErrLDAPInvalidCredentials = errors.New("ldap: invalid credentials")
+
+// LDAP protocol operations (RFC 4511) and result codes used by the client.
+const (
+	ldapBindRequest     = 0x60
+	ldapBindResponse    = 0x61
+	ldapUnbindRequest   = 0x42
+	ldapSearchRequest   = 0x63
+	ldapSearchEntry     = 0x64
+	ldapSearchDone      = 0x65
+	ldapExtendedRequest = 0x77
+	ldapExtendedResp    = 0x78
+
+	ldapSuccess            = 0
+	ldapInvalidCredentials = 49
+
+	startTLSOID = "1.3.6.1.4.1.1466.20037"
+)
+
+// LDAPConfig configures authentication by simple bind.
+type LDAPConfig struct {
+	URL        string // ldaps://host[:636], or ldap://host[:389] upgraded with StartTLS
+	UserDN     string // DN template of users, e.g. uid=%s,ou=people,dc=example,dc=com
+	GroupAttr  string // Attribute of user entries listing their groups (default memberOf)
+	ServerName string // Name in the server certificate, when it differs from the URL host
+}
+
+// LDAPDirectory authenticates users by binding as them, then reads the
+// groups from their own entry. Passwords never cross the network in clear:
+// plain LDAP is upgraded with StartTLS, except to the loopback interface.
+type LDAPDirectory struct {
+	cfg     LDAPConfig
+	addr    string
+	tls     bool // Connect with TLS (ldaps)
+	timeout time.Duration
+}
+
+// NewLDAPDirectory checks cfg and returns a directory for it.
+func NewLDAPDirectory(cfg LDAPConfig) (*LDAPDirectory, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("ldap: invalid URL %q", cfg.URL)
+	}
+	if strings.Count(cfg.UserDN, "%s") != 1 {
+		return nil, fmt.Errorf("ldap: user DN template must contain one %%s")
+	}
+	if cfg.GroupAttr == "" {
+		cfg.GroupAttr = "memberOf"
+	}
+	if cfg.ServerName == "" {
+		cfg.ServerName = u.Hostname()
+	}
+
+	d := &LDAPDirectory{cfg: cfg, timeout: 10 * time.Second}
+	port := u.Port()
+	switch u.Scheme {
+	case "ldaps":
+		d.tls = true
+		if port == "" {
+			port = "636"
+		}
+	case "ldap":
+		if port == "" {
+			port = "389"
+		}
+	default:
+		return nil, fmt.Errorf("ldap: unsupported scheme %q", u.Scheme)
+	}
+	d.addr = net.JoinHostPort(u.Hostname(), port)
+	return d, nil
+}
+
+// Authenticate binds as username with password. Groups are reported both as
+// DNs and as the value of their first RDN, e.g. "cn=wmap-admins,ou=groups"
+// and "wmap-admins".
+func (d *LDAPDirectory) Authenticate(ctx context.Context, username, password string) (*domain.ExternalIdentity, error) {
+	// An empty password would make an unauthenticated bind, which succeeds
+	if username == "" || password == "" {
+		return nil, ErrLDAPInvalidCredentials
+	}
+
+	conn, err := d.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.close()
+
+	dn := fmt.Sprintf(d.cfg.UserDN, escapeDN(username))
+	if err := conn.bind(dn, password); err != nil {
+		return nil, err
+	}
+	values, err := conn.readAttribute(dn, d.cfg.GroupAttr)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &domain.ExternalIdentity{Source: domain.AuthSourceLDAP, Username: username}
+	for _, group := range values {
+		identity.Groups = append(identity.Groups, group)
+		if name := firstRDNValue(group); name != "" && name != group {
+			identity.Groups = append(identity.Groups, name)
+		}
+	}
+	return identity, nil
+}
+
+// ldapConn is a connection to the directory carrying one request at a time.
+type ldapConn struct {
+	conn   net.Conn
+	r      *bufio.Reader
+	nextID int
+}
+
+func (d *LDAPDirectory) dial(ctx context.Context) (*ldapConn, error) {
+	dialer := &net.Dialer{Timeout: d.timeout}
+	raw, err := dialer.DialContext(ctx, "tcp", d.addr)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: %w", err)
+	}
+	raw.SetDeadline(time.Now().Add(d.timeout))
+
+	tlsConfig := &tls.Config{ServerName: d.cfg.ServerName, MinVersion: tls.VersionTLS12}
+	if d.tls {
+		raw = tls.Client(raw, tlsConfig)
+	}
+	c := &ldapConn{conn: raw, r: bufio.NewReader(raw)}
+	if d.tls {
+		return c, nil
+	}
+
+	host, _, _ := net.SplitHostPort(d.addr)
+	if isLoopback(host) {
+		return c, nil
+	}
+	if err := c.startTLS(tlsConfig); err != nil {
+		c.conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *ldapConn) close() {
+	c.send(berTLV(ldapUnbindRequest, nil))
+	c.conn.Close()
+}
+
+func (c *ldapConn) startTLS(config *tls.Config) error {
+	resp, err := c.request(berTLV(ldapExtendedRequest, berTLV(0x80, []byte(startTLSOID))), ldapExtendedResp)
+	if err != nil {
+		return err
+	}
+	if err := resultError(resp, "StartTLS"); err != nil {
+		return err
+	}
+	tlsConn := tls.Client(c.conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		return fmt.Errorf("ldap: StartTLS: %w", err)
+	}
+	c.conn = tlsConn
+	c.r = bufio.NewReader(tlsConn)
+	return nil
+}
+
+func (c *ldapConn) bind(dn, password string) error {
+	op := berTLV(ldapBindRequest, concat(
+		berInt(3),
+		berTLV(0x04, []byte(dn)),
+		berTLV(0x80, []byte(password)), // Simple authentication
+	))
+	resp, err := c.request(op, ldapBindResponse)
+	if err != nil {
+		return err
+	}
+	if code, _ := resultCode(resp); code == ldapInvalidCredentials {
+		return ErrLDAPInvalidCredentials
+	}
+	return resultError(resp, "bind")
+}
+
+// readAttribute returns the values of attr in the entry dn.
+func (c *ldapConn) readAttribute(dn, attr string) ([]string, error) {
+	op := berTLV(ldapSearchRequest, concat(
+		berTLV(0x04, []byte(dn)),
+		berTLV(0x0a, []byte{0}), // Scope: base object
+		berTLV(0x0a, []byte{0}), // Never dereference aliases
+		berInt(1),               // Size limit
+		berInt(10),              // Time limit, in seconds
+		berTLV(0x01, []byte{0}), // Types only: false
+		berTLV(0x87, []byte("objectClass")),
+		berTLV(0x30, berTLV(0x04, []byte(attr))),
+	))
+	id, err := c.send(op)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for {
+		tag, resp, err := c.receive(id)
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case ldapSearchEntry:
+			values = append(values, entryValues(resp, attr)...)
+		case ldapSearchDone:
+			return values, resultError(resp, "search")
+		}
+	}
+}
+
+// request sends op and returns the content of the response, which must have
+// the given tag.
+func (c *ldapConn) request(op []byte, want byte) ([]byte, error) {
+	id, err := c.send(op)
+	if err != nil {
+		return nil, err
+	}
+	tag, resp, err := c.receive(id)
+	if err != nil {
+		return nil, err
+	}
+	if tag != want {
+		return nil, fmt.Errorf("ldap: unexpected response 0x%02x", tag)
+	}
+	return resp, nil
+}
+
+func (c *ldapConn) send(op []byte) (int, error) {
+	c.nextID++
+	msg := berTLV(0x30, concat(berInt(c.nextID), op))
+	if _, err := c.conn.Write(msg); err != nil {
+		return 0, fmt.Errorf("ldap: %w", err)
+	}
+	return c.nextID, nil
+}
+
+// receive reads the next message for id, returning its operation.
+func (c *ldapConn) receive(id int) (byte, []byte, error) {
+	for {
+		tag, msg, err := readTLV(c.r)
+		if err != nil {
+			return 0, nil, fmt.Errorf("ldap: %w", err)
+		}
+		if tag != 0x30 {
+			return 0, nil, fmt.Errorf("ldap: malformed message")
+		}
+		fields, err := parseTLVs(msg)
+		if err != nil || len(fields) < 2 {
+			return 0, nil, fmt.Errorf("ldap: malformed message")
+		}
+		if int(decodeInt(fields[0].value)) != id {
+			continue // Unsolicited notification
+		}
+		return fields[1].tag, fields[1].value, nil
+	}
+}
+
+// resultCode returns the code and diagnostic message of an LDAPResult.
+func resultCode(resp []byte) (int, string) {
+	fields, err := parseTLVs(resp)
+	if err != nil || len(fields) < 3 {
+		return -1, "malformed result"
+	}
+	return int(decodeInt(fields[0].value)), string(fields[2].value)
+}
+
+func resultError(resp []byte, op string) error {
+	code, msg := resultCode(resp)
+	if code == ldapSuccess {
+		return nil
+	}
+	return fmt.Errorf("ldap: %s failed with result %d: %s", op, code, msg)
+}
+
+// entryValues returns the values of attr in a SearchResultEntry.
+func entryValues(entry []byte, attr string) []string {
+	fields, err := parseTLVs(entry)
+	if err != nil || len(fields) < 2 {
+		return nil
+	}
+	attrs, err := parseTLVs(fields[1].value)
+	if err != nil {
+		return nil
+	}
+	var values []string
+	for _, a := range attrs {
+		parts, err := parseTLVs(a.value)
+		if err != nil || len(parts) < 2 || !strings.EqualFold(string(parts[0].value), attr) {
+			continue
+		}
+		vals, err := parseTLVs(parts[1].value)
+		if err != nil {
+			continue
+		}
+		for _, v := range vals {
+			values = append(values, string(v.value))
+		}
+	}
+	return values
+}
+
+// escapeDN escapes an attribute value for a DN (RFC 4514).
+func escapeDN(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case strings.ContainsRune(`,+"\<>;=`, r),
+			(r == ' ' || r == '#') && i == 0,
+			r == ' ' && i == len(s)-1:
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == 0:
+			b.WriteString(`\00`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// firstRDNValue returns "wmap-admins" for "cn=wmap-admins,ou=groups,...".
+func firstRDNValue(dn string) string {
+	rdn := dn
+	for i := 0; i < len(dn); i++ {
+		if dn[i] == '\\' {
+			i++
+		} else if dn[i] == ',' {
+			rdn = dn[:i]
+			break
+		}
+	}
+	if eq := strings.IndexByte(rdn, '='); eq >= 0 {
+		return strings.TrimSpace(rdn[eq+1:])
+	}
+	return ""
+}
+
+// BER encoding, limited to the definite lengths and types LDAP uses. The
+// client needs simple bind, StartTLS and one base object search, so it
+// speaks the few messages of RFC 4511 itself rather than adding an LDAP
+// library and its ASN.1 stack to the module. Every length read from the
+// directory is checked against the data available and maxMessageSize, and
+// the decoder is fuzzed (FuzzParseTLVs, FuzzReadTLV).
+
+type tlv struct {
+	tag   byte
+	value []byte
+}
+
+// maxMessageSize bounds the messages read from the directory.
+const maxMessageSize = 1 << 20
+
+func berTLV(tag byte, value []byte) []byte {
+	n := len(value)
+	var length []byte
+	switch {
+	case n < 0x80:
+		length = []byte{byte(n)}
+	case n < 0x100:
+		length = []byte{0x81, byte(n)}
+	case n < 0x10000:
+		length = []byte{0x82, byte(n >> 8), byte(n)}
+	default:
+		length = []byte{0x83, byte(n >> 16), byte(n >> 8), byte(n)}
+	}
+	return concat([]byte{tag}, length, value)
+}
+
+// berInt encodes a non-negative INTEGER.
+func berInt(v int) []byte {
+	b := []byte{byte(v)}
+	for v >>= 8; v > 0; v >>= 8 {
+		b = append([]byte{byte(v)}, b...)
+	}
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return berTLV(0x02, b)
+}
+
+func decodeInt(b []byte) int64 {
+	var v int64
+	for i, c := range b {
+		if i == 0 && c&0x80 != 0 {
+			v = -1
+		}
+		v = v<<8 | int64(c)
+	}
+	return v
+}
+
+func concat(parts ...[]byte) []byte {
+	var out []byte
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func readTLV(r io.Reader) (byte, []byte, error) {
+	head := make([]byte, 2)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return 0, nil, err
+	}
+	n := int(head[1])
+	if n&0x80 != 0 {
+		size := n & 0x7f
+		if size == 0 || size > 3 {
+			return 0, nil, fmt.Errorf("unsupported length encoding")
+		}
+		b := make([]byte, size)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return 0, nil, err
+		}
+		n = 0
+		for _, c := range b {
+			n = n<<8 | int(c)
+		}
+	}
+	if n > maxMessageSize {
+		return 0, nil, fmt.Errorf("message of %d bytes too large", n)
+	}
+	value := make([]byte, n)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return 0, nil, err
+	}
+	return head[0], value, nil
+}
+
+// parseTLVs splits the content of a constructed value into its elements,
+// which share its memory.
+func parseTLVs(b []byte) ([]tlv, error) {
+	var out []tlv
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		tag, n, head := b[0], int(b[1]), 2
+		if n&0x80 != 0 {
+			size := n & 0x7f
+			if size == 0 || size > 3 {
+				return nil, fmt.Errorf("unsupported length encoding")
+			}
+			if len(b) < 2+size {
+				return nil, io.ErrUnexpectedEOF
+			}
+			n = 0
+			for _, c := range b[2 : 2+size] {
+				n = n<<8 | int(c)
+			}
+			head += size
+		}
+		if n > len(b)-head {
+			return nil, io.ErrUnexpectedEOF
+		}
+		out = append(out, tlv{tag: tag, value: b[head : head+n]})
+		b = b[head+n:]
+	}
+	return out, nil
+}
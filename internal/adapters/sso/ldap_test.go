@@ -0,0 +1,143 @@
+package sso
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLDAPServer accepts binds of dn with password, and serves the memberOf
+// attribute of dn.
+func fakeLDAPServer(t *testing.T, dn, password string, groups []string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	result := func(op byte, code int) []byte {
+		return berTLV(op, concat(berTLV(0x0a, []byte{byte(code)}), berTLV(0x04, nil), berTLV(0x04, nil)))
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					_, msg, err := readTLV(r)
+					if err != nil {
+						return
+					}
+					fields, _ := parseTLVs(msg)
+					id := berInt(int(decodeInt(fields[0].value)))
+					reply := func(op []byte) { conn.Write(berTLV(0x30, concat(id, op))) }
+
+					op := fields[1]
+					args, _ := parseTLVs(op.value)
+					switch op.tag {
+					case ldapBindRequest:
+						code := ldapInvalidCredentials
+						if string(args[1].value) == dn && string(args[2].value) == password {
+							code = ldapSuccess
+						}
+						reply(result(ldapBindResponse, code))
+					case ldapSearchRequest:
+						var values []byte
+						for _, g := range groups {
+							values = append(values, berTLV(0x04, []byte(g))...)
+						}
+						attr := berTLV(0x30, concat(berTLV(0x04, []byte("memberOf")), berTLV(0x31, values)))
+						reply(berTLV(ldapSearchEntry, concat(berTLV(0x04, args[0].value), berTLV(0x30, attr))))
+						reply(result(ldapSearchDone, ldapSuccess))
+					case ldapUnbindRequest:
+						return
+					}
+				}
+			}(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestLDAPDirectory(t *testing.T) {
+	addr := fakeLDAPServer(t, `uid=alice\,x,ou=people,dc=example,dc=com`, "S3cret", []string{
+		"cn=wmap-ops,ou=groups,dc=example,dc=com",
+		"cn=staff,ou=groups,dc=example,dc=com",
+	})
+	d, err := NewLDAPDirectory(LDAPConfig{URL: "ldap://" + addr, UserDN: "uid=%s,ou=people,dc=example,dc=com"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	identity, err := d.Authenticate(ctx, "alice,x", "S3cret")
+	require.NoError(t, err)
+	assert.Equal(t, "alice,x", identity.Username)
+	assert.Contains(t, identity.Groups, "wmap-ops")
+	assert.Contains(t, identity.Groups, "cn=staff,ou=groups,dc=example,dc=com")
+
+	_, err = d.Authenticate(ctx, "alice,x", "wrong")
+	assert.ErrorIs(t, err, ErrLDAPInvalidCredentials)
+
+	// An empty password would be an unauthenticated bind
+	_, err = d.Authenticate(ctx, "alice,x", "")
+	assert.ErrorIs(t, err, ErrLDAPInvalidCredentials)
+}
+
+func TestEscapeDN(t *testing.T) {
+	assert.Equal(t, `a\,b\=c`, escapeDN("a,b=c"))
+	assert.Equal(t, `\#admin\ `, escapeDN("#admin "))
+	assert.Equal(t, "wmap-admins", firstRDNValue(`cn=wmap-admins,ou=groups`))
+	assert.Equal(t, `a\,b`, firstRDNValue(`cn=a\,b,ou=groups`))
+}
+
+func TestParseTLVs(t *testing.T) {
+	long := bytes.Repeat([]byte{'x'}, 300)
+	fields, err := parseTLVs(concat(berTLV(0x04, []byte("a")), berTLV(0x04, long), berInt(5)))
+	require.NoError(t, err)
+	require.Len(t, fields, 3)
+	assert.Equal(t, "a", string(fields[0].value))
+	assert.Equal(t, long, fields[1].value)
+	assert.Equal(t, int64(5), decodeInt(fields[2].value))
+
+	_, err = parseTLVs([]byte{0x04, 0x83, 0xff, 0xff, 0xff})
+	assert.Error(t, err, "lengths past the data are refused")
+	_, err = parseTLVs([]byte{0x04, 0x84, 0, 0, 0, 1, 'x'})
+	assert.Error(t, err)
+}
+
+// FuzzParseTLVs feeds arbitrary LDAP results and search entries to the
+// decoder.
+func FuzzParseTLVs(f *testing.F) {
+	f.Add(concat(berTLV(0x0a, []byte{0}), berTLV(0x04, nil), berTLV(0x04, []byte("ok"))))
+	attr := berTLV(0x30, concat(berTLV(0x04, []byte("memberOf")), berTLV(0x31, berTLV(0x04, []byte("cn=a")))))
+	f.Add(concat(berTLV(0x04, []byte("uid=alice")), berTLV(0x30, attr)))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if fields, err := parseTLVs(data); err == nil {
+			for _, field := range fields {
+				parseTLVs(field.value)
+			}
+		}
+		resultCode(data)
+		entryValues(data, "memberOf")
+	})
+}
+
+// FuzzReadTLV feeds arbitrary messages from the directory to the client.
+func FuzzReadTLV(f *testing.F) {
+	f.Add(berTLV(0x30, concat(berInt(1), berTLV(ldapBindResponse, concat(berTLV(0x0a, []byte{0}), berTLV(0x04, nil), berTLV(0x04, nil))))))
+	f.Add([]byte{0x30, 0x83, 0x10, 0, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c := &ldapConn{r: bufio.NewReader(bytes.NewReader(data))}
+		if _, resp, err := c.receive(1); err == nil {
+			resultCode(resp)
+		}
+	})
+}
@@ -0,0 +1,276 @@
+// Package sso authenticates users against enterprise identity providers:
+// OpenID Connect providers and LDAP directories.
+package sso
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Ensure compliance
+var _ ports.IdentityProvider = (*OIDCProvider)(nil)
+
+// OIDCConfig configures an OpenID Connect client registered with the
+// provider as a confidential client.
+type OIDCConfig struct {
+	Issuer       string // Provider URL, e.g. https://login.example.com/realms/corp
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // The callback route, e.g. https://wmap.example.com/api/sso/callback
+	GroupsClaim  string // ID token claim listing the user's groups (default "groups")
+}
+
+// OIDCProvider signs users in with the authorization code flow. The ID token
+// comes straight from the token endpoint over TLS, which authenticates it
+// (OpenID Connect Core 3.1.3.7), so its signature is not checked; its
+// issuer, audience, expiry and nonce are.
+type OIDCProvider struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	metadata *oidcMetadata // Discovered at first use, so a provider down at start is retried
+}
+
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+// NewOIDCProvider checks cfg and returns a provider for it. Plain HTTP is
+// only accepted for a provider on the loopback interface.
+func NewOIDCProvider(cfg OIDCConfig) (*OIDCProvider, error) {
+	if cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, fmt.Errorf("oidc: client ID and redirect URL are required")
+	}
+	if err := checkSecureURL(cfg.Issuer); err != nil {
+		return nil, fmt.Errorf("oidc: issuer: %w", err)
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	return &OIDCProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+// AuthCodeURL returns the provider's login URL for state and nonce.
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	params := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.cfg.ClientID},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"scope":         {"openid profile email"},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + params.Encode(), nil
+}
+
+// Exchange redeems code at the token endpoint and returns the identity in
+// the ID token.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, nonce string) (*domain.ExternalIdentity, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {p.cfg.RedirectURL},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+		Error   string `json:"error"`
+	}
+	if err := p.do(req, &tokens); err != nil {
+		return nil, fmt.Errorf("oidc: token request: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("oidc: token response without an ID token")
+	}
+
+	claims, err := p.verifyIDToken(tokens.IDToken, nonce, meta.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	return p.identity(claims)
+}
+
+// verifyIDToken decodes the claims of the ID token and checks they were
+// issued by issuer for this client and login.
+func (p *OIDCProvider) verifyIDToken(token, nonce, issuer string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("oidc: malformed ID token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("oidc: malformed ID token: %w", err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("oidc: malformed ID token: %w", err)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != issuer {
+		return nil, fmt.Errorf("oidc: ID token issued by %q, want %q", iss, issuer)
+	}
+	audience := stringList(claims["aud"])
+	if !contains(audience, p.cfg.ClientID) {
+		return nil, fmt.Errorf("oidc: ID token not issued for this client")
+	}
+	if azp, ok := claims["azp"].(string); ok && azp != p.cfg.ClientID {
+		return nil, fmt.Errorf("oidc: ID token authorized for another client")
+	}
+	exp, _ := claims["exp"].(float64)
+	if time.Now().After(time.Unix(int64(exp), 0)) {
+		return nil, fmt.Errorf("oidc: ID token expired")
+	}
+	if n, _ := claims["nonce"].(string); n == "" || n != nonce {
+		return nil, fmt.Errorf("oidc: ID token nonce mismatch")
+	}
+	return claims, nil
+}
+
+// identity names the user by preferred_username, else email, else subject.
+func (p *OIDCProvider) identity(claims map[string]interface{}) (*domain.ExternalIdentity, error) {
+	var username string
+	for _, claim := range []string{"preferred_username", "email", "sub"} {
+		if v, ok := claims[claim].(string); ok && v != "" {
+			username = v
+			break
+		}
+	}
+	if username == "" {
+		return nil, fmt.Errorf("oidc: ID token without a username")
+	}
+	return &domain.ExternalIdentity{
+		Source:   domain.AuthSourceOIDC,
+		Username: username,
+		Groups:   stringList(claims[p.cfg.GroupsClaim]),
+	}, nil
+}
+
+// discover fetches the provider metadata, once it succeeds.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	var meta oidcMetadata
+	if err := p.do(req, &meta); err != nil {
+		return nil, fmt.Errorf("oidc: discovery: %w", err)
+	}
+	if strings.TrimSuffix(meta.Issuer, "/") != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc: discovery: issuer %q does not match %q", meta.Issuer, p.cfg.Issuer)
+	}
+	for _, endpoint := range []string{meta.AuthorizationEndpoint, meta.TokenEndpoint} {
+		if err := checkSecureURL(endpoint); err != nil {
+			return nil, fmt.Errorf("oidc: discovery: %w", err)
+		}
+	}
+	p.metadata = &meta
+	return p.metadata, nil
+}
+
+func (p *OIDCProvider) do(req *http.Request, v interface{}) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
+
+// checkSecureURL accepts HTTPS URLs, and HTTP ones on the loopback interface.
+func checkSecureURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("invalid URL %q", raw)
+	}
+	if u.Scheme == "https" || (u.Scheme == "http" && isLoopback(u.Hostname())) {
+		return nil
+	}
+	return fmt.Errorf("%q must use https", raw)
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// stringList returns a claim holding a string or a list of strings.
+func stringList(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package sso
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIssuer serves discovery and a token endpoint issuing claims.
+func fakeIssuer(t *testing.T, claims map[string]interface{}) *httptest.Server {
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 srv.URL,
+			"authorization_endpoint": srv.URL + "/authorize",
+			"token_endpoint":         srv.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if id != "wmap" || secret != "s3cret" || r.FormValue("code") != "good-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		payload, _ := json.Marshal(claims)
+		json.NewEncoder(w).Encode(map[string]string{
+			"id_token": "e30." + base64.RawURLEncoding.EncodeToString(payload) + ".sig",
+		})
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOIDCProvider(t *testing.T) {
+	claims := map[string]interface{}{
+		"aud":                "wmap",
+		"exp":                float64(time.Now().Add(time.Minute).Unix()),
+		"nonce":              "n-1",
+		"preferred_username": "alice",
+		"roles":              []string{"wmap-ops", "staff"},
+	}
+	srv := fakeIssuer(t, claims)
+	claims["iss"] = srv.URL
+
+	p, err := NewOIDCProvider(OIDCConfig{
+		Issuer:       srv.URL,
+		ClientID:     "wmap",
+		ClientSecret: "s3cret",
+		RedirectURL:  "https://wmap.example.com/api/sso/callback",
+		GroupsClaim:  "roles",
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("login URL", func(t *testing.T) {
+		raw, err := p.AuthCodeURL(ctx, "s-1", "n-1")
+		require.NoError(t, err)
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		assert.Equal(t, "/authorize", u.Path)
+		assert.Equal(t, "s-1", u.Query().Get("state"))
+		assert.Equal(t, "n-1", u.Query().Get("nonce"))
+		assert.Equal(t, "code", u.Query().Get("response_type"))
+	})
+
+	t.Run("identity from ID token", func(t *testing.T) {
+		identity, err := p.Exchange(ctx, "good-code", "n-1")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", identity.Username)
+		assert.Equal(t, []string{"wmap-ops", "staff"}, identity.Groups)
+	})
+
+	t.Run("rejected tokens", func(t *testing.T) {
+		_, err := p.Exchange(ctx, "bad-code", "n-1")
+		assert.ErrorContains(t, err, "invalid_grant")
+
+		_, err = p.Exchange(ctx, "good-code", "replayed")
+		assert.ErrorContains(t, err, "nonce")
+
+		claims["aud"] = []string{"other-client"}
+		_, err = p.Exchange(ctx, "good-code", "n-1")
+		assert.ErrorContains(t, err, "not issued for this client")
+		claims["aud"] = "wmap"
+
+		claims["exp"] = float64(time.Now().Add(-time.Minute).Unix())
+		_, err = p.Exchange(ctx, "good-code", "n-1")
+		assert.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("plain HTTP only on loopback", func(t *testing.T) {
+		_, err := NewOIDCProvider(OIDCConfig{Issuer: "http://login.example.com", ClientID: "wmap", RedirectURL: "https://wmap/cb"})
+		assert.ErrorContains(t, err, "https")
+	})
+}
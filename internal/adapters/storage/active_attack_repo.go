@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.ActiveAttackRepository = (*SQLiteAdapter)(nil)
+
+// SaveActiveAttack stores a running attack, replacing any earlier entry with
+// the same ID.
+func (a *SQLiteAdapter) SaveActiveAttack(ctx context.Context, attack domain.ActiveAttack) error {
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&attack).Error
+}
+
+// DeleteActiveAttack forgets a running attack once it has finished.
+func (a *SQLiteAdapter) DeleteActiveAttack(ctx context.Context, id string) error {
+	return a.db.WithContext(ctx).Delete(&domain.ActiveAttack{}, "id = ?", id).Error
+}
+
+// ListActiveAttacks returns the attacks left running, oldest first.
+func (a *SQLiteAdapter) ListActiveAttacks(ctx context.Context) ([]domain.ActiveAttack, error) {
+	var attacks []domain.ActiveAttack
+	if err := a.db.WithContext(ctx).Order("started_at asc").Find(&attacks).Error; err != nil {
+		return nil, err
+	}
+	return attacks, nil
+}
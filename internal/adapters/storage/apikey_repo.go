@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.APIKeyRepository = (*SQLiteAdapter)(nil)
+
+// SaveAPIKey creates or updates an API key.
+func (a *SQLiteAdapter) SaveAPIKey(ctx context.Context, key domain.APIKey) error {
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&key).Error
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of its secret.
+func (a *SQLiteAdapter) GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	if err := a.db.WithContext(ctx).Where("hash = ?", hash).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListAPIKeys returns the API keys of a user, or all of them when userID is
+// empty, newest first.
+func (a *SQLiteAdapter) ListAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	query := a.db.WithContext(ctx).Order("created_at desc")
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	var keys []domain.APIKey
+	if err := query.Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// DeleteAPIKey revokes an API key.
+func (a *SQLiteAdapter) DeleteAPIKey(ctx context.Context, id string) error {
+	result := a.db.WithContext(ctx).Delete(&domain.APIKey{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
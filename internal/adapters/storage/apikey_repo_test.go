@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeys(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	require.NoError(t, adapter.db.AutoMigrate(&domain.APIKey{}))
+	ctx := context.Background()
+
+	ci := domain.APIKey{ID: "k1", Name: "ci", Hash: "h1", Scope: domain.APIKeyScopeRead, UserID: "u1", CreatedAt: time.Now()}
+	other := domain.APIKey{ID: "k2", Name: "nightly", Hash: "h2", Scope: domain.APIKeyScopeAttack, UserID: "u2", CreatedAt: time.Now().Add(time.Second)}
+	require.NoError(t, adapter.SaveAPIKey(ctx, ci))
+	require.NoError(t, adapter.SaveAPIKey(ctx, other))
+
+	key, err := adapter.GetAPIKeyByHash(ctx, "h1")
+	require.NoError(t, err)
+	assert.Equal(t, "ci", key.Name)
+	_, err = adapter.GetAPIKeyByHash(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+
+	keys, err := adapter.ListAPIKeys(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	keys, err = adapter.ListAPIKeys(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "k2", keys[0].ID)
+
+	require.NoError(t, adapter.DeleteAPIKey(ctx, "k1"))
+	assert.ErrorIs(t, adapter.DeleteAPIKey(ctx, "k1"), domain.ErrAPIKeyNotFound)
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.ArtifactRepository = (*SQLiteAdapter)(nil)
+
+// SaveArtifact registers or updates an artifact.
+func (a *SQLiteAdapter) SaveArtifact(ctx context.Context, artifact domain.Artifact) error {
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&artifact).Error
+}
+
+// GetArtifact returns an artifact by ID.
+func (a *SQLiteAdapter) GetArtifact(ctx context.Context, id string) (*domain.Artifact, error) {
+	var artifact domain.Artifact
+	if err := a.db.WithContext(ctx).Where("id = ?", id).First(&artifact).Error; err != nil {
+		return nil, err
+	}
+	return &artifact, nil
+}
+
+// ListArtifacts returns the artifacts matching the filter, most recent first.
+func (a *SQLiteAdapter) ListArtifacts(ctx context.Context, filter domain.ArtifactFilter) ([]domain.Artifact, error) {
+	query := a.db.WithContext(ctx).Order("created_at desc")
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var artifacts []domain.Artifact
+	if err := query.Find(&artifacts).Error; err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// DeleteArtifact removes an artifact from the registry.
+func (a *SQLiteAdapter) DeleteArtifact(ctx context.Context, id string) error {
+	return a.db.WithContext(ctx).Where("id = ?", id).Delete(&domain.Artifact{}).Error
+}
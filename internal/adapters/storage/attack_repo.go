@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.AttackHistoryRepository = (*SQLiteAdapter)(nil)
+
+// SaveAttackRecord stores the outcome of a finished attack, replacing any
+// earlier record with the same ID.
+func (a *SQLiteAdapter) SaveAttackRecord(ctx context.Context, record domain.AttackRecord) error {
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&record).Error
+}
+
+// ListAttackRecords returns the most recent attacks first.
+func (a *SQLiteAdapter) ListAttackRecords(ctx context.Context, limit int) ([]domain.AttackRecord, error) {
+	var records []domain.AttackRecord
+	if err := a.db.WithContext(ctx).Order("start_time desc").Limit(limit).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
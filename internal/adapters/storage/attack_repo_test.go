@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttackRecords(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	require.NoError(t, adapter.db.AutoMigrate(&domain.AttackRecord{}))
+	ctx := context.Background()
+
+	now := time.Now()
+	older := domain.AttackRecord{ID: "a1", Kind: domain.AttackKindDeauth, Target: "00:11:22:33:44:55", StartTime: now.Add(-time.Hour), Status: "stopped"}
+	newer := domain.AttackRecord{ID: "a2", Kind: domain.AttackKindWPS, Target: "66:77:88:99:AA:BB", StartTime: now, CredentialsRecovered: true, Score: 100}
+	require.NoError(t, adapter.SaveAttackRecord(ctx, older))
+	require.NoError(t, adapter.SaveAttackRecord(ctx, newer))
+
+	// Saving again replaces the record
+	older.HandshakeCaptured = true
+	older.Score = 100
+	require.NoError(t, adapter.SaveAttackRecord(ctx, older))
+
+	records, err := adapter.ListAttackRecords(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "a2", records[0].ID)
+	assert.True(t, records[0].CredentialsRecovered)
+	assert.Equal(t, "a1", records[1].ID)
+	assert.True(t, records[1].HandshakeCaptured)
+	assert.Equal(t, 100, records[1].Score)
+
+	records, err = adapter.ListAttackRecords(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
+
+func TestActiveAttacks(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	require.NoError(t, adapter.db.AutoMigrate(&domain.ActiveAttack{}))
+	ctx := context.Background()
+
+	first := domain.NewActiveAttack(domain.AttackKindDeauth, "a1", "00:11:22:33:44:55", "wlan0", 6, domain.DeauthAttackConfig{})
+	second := domain.NewActiveAttack(domain.AttackKindWPS, "a2", "66:77:88:99:AA:BB", "wlan1", 11, domain.WPSAttackConfig{})
+	second.StartedAt = first.StartedAt.Add(time.Second)
+	require.NoError(t, adapter.SaveActiveAttack(ctx, first))
+	require.NoError(t, adapter.SaveActiveAttack(ctx, second))
+
+	attacks, err := adapter.ListActiveAttacks(ctx)
+	require.NoError(t, err)
+	require.Len(t, attacks, 2)
+	assert.Equal(t, "a1", attacks[0].ID)
+	assert.Equal(t, "wlan0", attacks[0].Interface)
+
+	require.NoError(t, adapter.DeleteActiveAttack(ctx, "a1"))
+	attacks, err = adapter.ListActiveAttacks(ctx)
+	require.NoError(t, err)
+	require.Len(t, attacks, 1)
+	assert.Equal(t, "a2", attacks[0].ID)
+}
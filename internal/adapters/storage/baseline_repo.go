@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.BaselineRepository = (*SQLiteAdapter)(nil)
+
+// baselineRowID is the single row holding the workspace baseline configuration.
+const baselineRowID = 1
+
+// BaselineModel is the GORM model for the baseline monitoring configuration.
+type BaselineModel struct {
+	ID               uint `gorm:"primaryKey"`
+	Enabled          bool
+	LearningPeriod   int64 // Nanoseconds
+	StartedAt        time.Time
+	IgnoreRandomized bool
+	APsOnly          bool
+}
+
+// GetBaseline returns the baseline configuration of the workspace, disabled if none was set.
+func (a *SQLiteAdapter) GetBaseline(ctx context.Context) (domain.BaselineConfig, error) {
+	var model BaselineModel
+	err := a.db.WithContext(ctx).First(&model, baselineRowID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.BaselineConfig{}, nil
+	}
+	if err != nil {
+		return domain.BaselineConfig{}, err
+	}
+	return domain.BaselineConfig{
+		Enabled:          model.Enabled,
+		LearningPeriod:   time.Duration(model.LearningPeriod),
+		StartedAt:        model.StartedAt,
+		IgnoreRandomized: model.IgnoreRandomized,
+		APsOnly:          model.APsOnly,
+	}, nil
+}
+
+// SaveBaseline replaces the baseline configuration of the workspace.
+func (a *SQLiteAdapter) SaveBaseline(ctx context.Context, config domain.BaselineConfig) error {
+	model := BaselineModel{
+		ID:               baselineRowID,
+		Enabled:          config.Enabled,
+		LearningPeriod:   int64(config.LearningPeriod),
+		StartedAt:        config.StartedAt,
+		IgnoreRandomized: config.IgnoreRandomized,
+		APsOnly:          config.APsOnly,
+	}
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&model).Error
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.BluetoothRepository = (*SQLiteAdapter)(nil)
+
+// BluetoothModel is the GORM model for classic Bluetooth devices.
+type BluetoothModel struct {
+	Address     string `gorm:"primaryKey"`
+	Name        string
+	Class       uint32
+	DeviceClass string
+	RSSI        int
+	Vendor      string
+	FirstSeen   time.Time
+	LastSeen    time.Time `gorm:"index"`
+	Inquiries   int
+	RelatedMACs string // JSON encoded []string
+}
+
+// SaveBluetoothDevices inserts or updates Bluetooth devices.
+func (a *SQLiteAdapter) SaveBluetoothDevices(ctx context.Context, devices []domain.BluetoothDevice) error {
+	if len(devices) == 0 {
+		return nil
+	}
+	models := make([]BluetoothModel, 0, len(devices))
+	for _, d := range devices {
+		related, err := json.Marshal(d.RelatedMACs)
+		if err != nil {
+			return err
+		}
+		models = append(models, BluetoothModel{
+			Address:     d.Address,
+			Name:        d.Name,
+			Class:       d.Class,
+			DeviceClass: d.DeviceClass,
+			RSSI:        d.RSSI,
+			Vendor:      d.Vendor,
+			FirstSeen:   d.FirstSeen,
+			LastSeen:    d.LastSeen,
+			Inquiries:   d.Inquiries,
+			RelatedMACs: string(related),
+		})
+	}
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&models).Error
+}
+
+// GetBluetoothDevices returns the stored Bluetooth devices, most recently
+// seen first.
+func (a *SQLiteAdapter) GetBluetoothDevices(ctx context.Context) ([]domain.BluetoothDevice, error) {
+	var models []BluetoothModel
+	if err := a.db.WithContext(ctx).Order("last_seen desc").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	devices := make([]domain.BluetoothDevice, 0, len(models))
+	for _, m := range models {
+		d := domain.BluetoothDevice{
+			Address:     m.Address,
+			Name:        m.Name,
+			Class:       m.Class,
+			DeviceClass: m.DeviceClass,
+			RSSI:        m.RSSI,
+			Vendor:      m.Vendor,
+			FirstSeen:   m.FirstSeen,
+			LastSeen:    m.LastSeen,
+			Inquiries:   m.Inquiries,
+		}
+		if m.RelatedMACs != "" {
+			_ = json.Unmarshal([]byte(m.RelatedMACs), &d.RelatedMACs)
+		}
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
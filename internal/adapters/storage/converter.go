@@ -10,8 +10,15 @@ import (
 // toDomain converts a database model to a domain entity.
 func toDomain(m DeviceModel) *domain.Device {
 	probes := make(map[string]time.Time)
+	var counts map[string]int
 	for _, p := range m.ProbedSSIDs {
 		probes[p.SSID] = p.LastSeen
+		if p.Count > 0 {
+			if counts == nil {
+				counts = make(map[string]int)
+			}
+			counts[p.SSID] = p.Count
+		}
 	}
 
 	dev := &domain.Device{
@@ -26,12 +33,16 @@ func toDomain(m DeviceModel) *domain.Device {
 		Standard:         m.Standard,
 		Frequency:        m.Frequency,
 		ChannelWidth:     m.ChannelWidth,
+		CenterChannel:    m.CenterChannel,
+		Country:          m.Country,
+		MaxTxPower:       m.MaxTxPower,
 		WPSInfo:          m.WPSInfo,
 		Latitude:         m.Latitude,
 		Longitude:        m.Longitude,
 		LastPacketTime:   m.LastPacketTime,
 		FirstSeen:        m.FirstSeen,
 		LastSeen:         m.LastSeen,
+		Sensor:           m.Sensor,
 		ConnectedSSID:    m.ConnectedSSID,
 		Model:            m.Model,
 		OS:               m.OS,
@@ -47,6 +58,7 @@ func toDomain(m DeviceModel) *domain.Device {
 		PacketsCount:     m.PacketsCount,
 		RetryCount:       m.RetryCount,
 		ProbedSSIDs:      probes,
+		ProbeCounts:      counts,
 		ConnectionState:  domain.ConnectionState(m.ConnectionState),
 		ConnectionTarget: m.ConnectionTarget,
 		ConnectionError:  m.ConnectionError,
@@ -66,6 +78,26 @@ func toDomain(m DeviceModel) *domain.Device {
 		LastUpdated:    m.LastSeen,
 	}
 
+	if m.Annotations != "" {
+		_ = json.Unmarshal([]byte(m.Annotations), &dev.Annotations)
+	}
+
+	asset := domain.AssetInfo{
+		Owner:       m.AssetOwner,
+		AssetTag:    m.AssetTag,
+		Criticality: domain.AssetCriticality(m.AssetCriticality),
+	}
+	if !asset.IsEmpty() {
+		dev.Asset = &asset
+	}
+
+	if m.Portal != "" {
+		var portal domain.PortalCheck
+		if json.Unmarshal([]byte(m.Portal), &portal) == nil {
+			dev.Portal = &portal
+		}
+	}
+
 	return dev
 }
 
@@ -83,12 +115,16 @@ func toModel(d domain.Device) DeviceModel {
 		Standard:         d.Standard,
 		Frequency:        d.Frequency,
 		ChannelWidth:     d.ChannelWidth,
+		CenterChannel:    d.CenterChannel,
+		Country:          d.Country,
+		MaxTxPower:       d.MaxTxPower,
 		WPSInfo:          d.WPSInfo,
 		Latitude:         d.Latitude,
 		Longitude:        d.Longitude,
 		LastPacketTime:   d.LastPacketTime,
 		FirstSeen:        d.FirstSeen,
 		LastSeen:         d.LastSeen,
+		Sensor:           d.Sensor,
 		ConnectedSSID:    d.ConnectedSSID,
 		Model:            d.Model,
 		OS:               d.OS,
@@ -118,5 +154,21 @@ func toModel(d domain.Device) DeviceModel {
 		}
 	}
 
+	if len(d.Annotations) > 0 {
+		aBytes, _ := json.Marshal(d.Annotations)
+		model.Annotations = string(aBytes)
+	}
+
+	if d.Asset != nil {
+		model.AssetOwner = d.Asset.Owner
+		model.AssetTag = d.Asset.AssetTag
+		model.AssetCriticality = string(d.Asset.Criticality)
+	}
+
+	if d.Portal != nil {
+		pBytes, _ := json.Marshal(d.Portal)
+		model.Portal = string(pBytes)
+	}
+
 	return model
 }
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.CredentialRepository = (*SQLiteAdapter)(nil)
+
+// SaveCredential stores a recovered secret, replacing any earlier one for the same network.
+func (a *SQLiteAdapter) SaveCredential(ctx context.Context, credential domain.RecoveredCredential) error {
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&credential).Error
+}
+
+// GetCredential returns the secret recovered for a network, or nil if there is none.
+func (a *SQLiteAdapter) GetCredential(ctx context.Context, bssid string) (*domain.RecoveredCredential, error) {
+	var credential domain.RecoveredCredential
+	err := a.db.WithContext(ctx).Where("id = ?", bssid).First(&credential).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
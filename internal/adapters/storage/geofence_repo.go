@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.GeofenceRepository = (*SQLiteAdapter)(nil)
+
+// GeofenceModel is the GORM model for a protected zone.
+type GeofenceModel struct {
+	ID           string `gorm:"primaryKey"`
+	Name         string
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters float64
+	TrackedMACs  string // JSON encoded []string
+	AlertOnNewAP bool
+	Enabled      bool
+	CreatedAt    time.Time
+}
+
+// ListGeofences returns the protected zones ordered by creation time.
+func (a *SQLiteAdapter) ListGeofences(ctx context.Context) ([]domain.Geofence, error) {
+	var models []GeofenceModel
+	if err := a.db.WithContext(ctx).Order("created_at").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	result := make([]domain.Geofence, len(models))
+	for i, m := range models {
+		result[i] = domain.Geofence{
+			ID:           m.ID,
+			Name:         m.Name,
+			Latitude:     m.Latitude,
+			Longitude:    m.Longitude,
+			RadiusMeters: m.RadiusMeters,
+			AlertOnNewAP: m.AlertOnNewAP,
+			Enabled:      m.Enabled,
+			CreatedAt:    m.CreatedAt,
+		}
+		if m.TrackedMACs != "" {
+			if err := json.Unmarshal([]byte(m.TrackedMACs), &result[i].TrackedMACs); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// SaveGeofence adds or replaces a protected zone.
+func (a *SQLiteAdapter) SaveGeofence(ctx context.Context, zone domain.Geofence) error {
+	tracked, err := json.Marshal(zone.TrackedMACs)
+	if err != nil {
+		return err
+	}
+	model := GeofenceModel{
+		ID:           zone.ID,
+		Name:         zone.Name,
+		Latitude:     zone.Latitude,
+		Longitude:    zone.Longitude,
+		RadiusMeters: zone.RadiusMeters,
+		TrackedMACs:  string(tracked),
+		AlertOnNewAP: zone.AlertOnNewAP,
+		Enabled:      zone.Enabled,
+		CreatedAt:    zone.CreatedAt,
+	}
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&model).Error
+}
+
+// DeleteGeofence removes a protected zone. Deleting an unknown zone is not an error.
+func (a *SQLiteAdapter) DeleteGeofence(ctx context.Context, id string) error {
+	return a.db.WithContext(ctx).Delete(&GeofenceModel{}, "id = ?", id).Error
+}
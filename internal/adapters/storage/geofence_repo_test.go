@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeofences(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	require.NoError(t, adapter.db.AutoMigrate(&GeofenceModel{}))
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	hq := domain.Geofence{ID: "hq", Name: "HQ", Latitude: 40.4, Longitude: -3.7, RadiusMeters: 200, TrackedMACs: []string{"AA:BB:CC:DD:EE:FF"}, Enabled: true, CreatedAt: now}
+	require.NoError(t, adapter.SaveGeofence(ctx, domain.Geofence{ID: "lab", Name: "Lab", RadiusMeters: 50, CreatedAt: now.Add(time.Minute)}))
+	require.NoError(t, adapter.SaveGeofence(ctx, hq))
+
+	list, err := adapter.ListGeofences(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "hq", list[0].ID, "oldest first")
+	assert.Equal(t, []string{"AA:BB:CC:DD:EE:FF"}, list[0].TrackedMACs)
+	assert.True(t, list[0].Enabled)
+
+	require.NoError(t, adapter.DeleteGeofence(ctx, "hq"))
+	require.NoError(t, adapter.DeleteGeofence(ctx, "hq"))
+	list, err = adapter.ListGeofences(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Ensure compliance
+var _ ports.HookRepository = (*SQLiteAdapter)(nil)
+
+// HookModel is the GORM model for a scripting hook.
+type HookModel struct {
+	ID        string `gorm:"primaryKey"`
+	Name      string
+	Condition string
+	Actions   string // JSON encoded []domain.HookAction
+	Enabled   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ListHooks returns the scripting hooks of the workspace ordered by creation.
+func (a *SQLiteAdapter) ListHooks(ctx context.Context) ([]domain.ScriptHook, error) {
+	var models []HookModel
+	if err := a.db.WithContext(ctx).Order("created_at").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	hooks := make([]domain.ScriptHook, 0, len(models))
+	for _, m := range models {
+		hook := domain.ScriptHook{
+			ID:        m.ID,
+			Name:      m.Name,
+			Condition: m.Condition,
+			Enabled:   m.Enabled,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		}
+		_ = json.Unmarshal([]byte(m.Actions), &hook.Actions)
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
+// SaveHook creates or replaces a scripting hook.
+func (a *SQLiteAdapter) SaveHook(ctx context.Context, hook domain.ScriptHook) error {
+	actions, err := json.Marshal(hook.Actions)
+	if err != nil {
+		return err
+	}
+	model := HookModel{
+		ID:        hook.ID,
+		Name:      hook.Name,
+		Condition: hook.Condition,
+		Actions:   string(actions),
+		Enabled:   hook.Enabled,
+		CreatedAt: hook.CreatedAt,
+		UpdatedAt: hook.UpdatedAt,
+	}
+	return a.db.WithContext(ctx).Save(&model).Error
+}
+
+// DeleteHook removes a scripting hook.
+func (a *SQLiteAdapter) DeleteHook(ctx context.Context, id string) error {
+	result := a.db.WithContext(ctx).Delete(&HookModel{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrHookNotFound
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.JobRepository = (*SQLiteAdapter)(nil)
+
+// SaveJob stores the current state of a job.
+func (a *SQLiteAdapter) SaveJob(ctx context.Context, job domain.Job) error {
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&job).Error
+}
+
+// GetJob returns a job by ID.
+func (a *SQLiteAdapter) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	var job domain.Job
+	if err := a.db.WithContext(ctx).Where("id = ?", id).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListJobs returns the jobs matching the filter, most recent first.
+func (a *SQLiteAdapter) ListJobs(ctx context.Context, filter domain.JobFilter) ([]domain.Job, error) {
+	query := a.db.WithContext(ctx).Order("created_at desc")
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var jobs []domain.Job
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.ProtectedBSSIDRepository = (*SQLiteAdapter)(nil)
+
+// ProtectedBSSIDModel is the GORM model for a protected BSSID.
+type ProtectedBSSIDModel struct {
+	BSSID           string `gorm:"primaryKey;column:bssid"`
+	Label           string
+	CaptureEvidence bool
+	CreatedAt       time.Time
+}
+
+// ListProtectedBSSIDs returns the protected BSSIDs of the workspace ordered by creation time.
+func (a *SQLiteAdapter) ListProtectedBSSIDs(ctx context.Context) ([]domain.ProtectedBSSID, error) {
+	var models []ProtectedBSSIDModel
+	if err := a.db.WithContext(ctx).Order("created_at").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	result := make([]domain.ProtectedBSSID, len(models))
+	for i, m := range models {
+		result[i] = domain.ProtectedBSSID{
+			BSSID:           m.BSSID,
+			Label:           m.Label,
+			CaptureEvidence: m.CaptureEvidence,
+			CreatedAt:       m.CreatedAt,
+		}
+	}
+	return result, nil
+}
+
+// SaveProtectedBSSID adds or replaces a protected BSSID.
+func (a *SQLiteAdapter) SaveProtectedBSSID(ctx context.Context, protected domain.ProtectedBSSID) error {
+	model := ProtectedBSSIDModel{
+		BSSID:           protected.BSSID,
+		Label:           protected.Label,
+		CaptureEvidence: protected.CaptureEvidence,
+		CreatedAt:       protected.CreatedAt,
+	}
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&model).Error
+}
+
+// DeleteProtectedBSSID stops protecting a BSSID. Deleting an unknown BSSID is not an error.
+func (a *SQLiteAdapter) DeleteProtectedBSSID(ctx context.Context, bssid string) error {
+	return a.db.WithContext(ctx).Delete(&ProtectedBSSIDModel{}, "bssid = ?", bssid).Error
+}
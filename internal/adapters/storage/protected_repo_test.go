@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtectedBSSIDs(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	require.NoError(t, adapter.db.AutoMigrate(&ProtectedBSSIDModel{}))
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, adapter.SaveProtectedBSSID(ctx, domain.ProtectedBSSID{BSSID: "00:11:22:33:44:66", CreatedAt: now.Add(time.Minute)}))
+	require.NoError(t, adapter.SaveProtectedBSSID(ctx, domain.ProtectedBSSID{BSSID: "00:11:22:33:44:55", Label: "HQ", CreatedAt: now}))
+	require.NoError(t, adapter.SaveProtectedBSSID(ctx, domain.ProtectedBSSID{BSSID: "00:11:22:33:44:55", Label: "HQ", CaptureEvidence: true, CreatedAt: now}))
+
+	list, err := adapter.ListProtectedBSSIDs(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "00:11:22:33:44:55", list[0].BSSID, "oldest first")
+	assert.True(t, list[0].CaptureEvidence, "saving again replaces")
+
+	require.NoError(t, adapter.DeleteProtectedBSSID(ctx, "00:11:22:33:44:55"))
+	require.NoError(t, adapter.DeleteProtectedBSSID(ctx, "00:11:22:33:44:55"))
+	list, err = adapter.ListProtectedBSSIDs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
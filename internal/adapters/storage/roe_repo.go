@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm"
+)
+
+// Ensure compliance
+var _ ports.ROERepository = (*SQLiteAdapter)(nil)
+
+// SaveROE creates a rules of engagement record, or updates it if it has an ID.
+func (a *SQLiteAdapter) SaveROE(ctx context.Context, roe *domain.RulesOfEngagement) error {
+	return a.db.WithContext(ctx).Save(roe).Error
+}
+
+// LatestROE returns the most recently signed record, nil if none was.
+func (a *SQLiteAdapter) LatestROE(ctx context.Context) (*domain.RulesOfEngagement, error) {
+	var roe domain.RulesOfEngagement
+	err := a.db.WithContext(ctx).Order("id desc").First(&roe).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &roe, nil
+}
+
+// ListROE returns every record, newest first.
+func (a *SQLiteAdapter) ListROE(ctx context.Context) ([]domain.RulesOfEngagement, error) {
+	var records []domain.RulesOfEngagement
+	if err := a.db.WithContext(ctx).Order("id desc").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.ScheduleRepository = (*SQLiteAdapter)(nil)
+
+// scheduleRowID is the single row holding the monitoring schedule.
+const scheduleRowID = 1
+
+// ScheduleModel is the GORM model for the monitoring schedule.
+type ScheduleModel struct {
+	ID       uint `gorm:"primaryKey"`
+	Enabled  bool
+	Timezone string
+	Windows  string // JSON encoded []domain.MonitoringWindow
+}
+
+// GetSchedule returns the monitoring schedule, disabled if none was set.
+func (a *SQLiteAdapter) GetSchedule(ctx context.Context) (domain.MonitoringSchedule, error) {
+	var model ScheduleModel
+	err := a.db.WithContext(ctx).First(&model, scheduleRowID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.MonitoringSchedule{}, nil
+	}
+	if err != nil {
+		return domain.MonitoringSchedule{}, err
+	}
+	schedule := domain.MonitoringSchedule{Enabled: model.Enabled, Timezone: model.Timezone}
+	if model.Windows != "" {
+		if err := json.Unmarshal([]byte(model.Windows), &schedule.Windows); err != nil {
+			return domain.MonitoringSchedule{}, err
+		}
+	}
+	return schedule, nil
+}
+
+// SaveSchedule replaces the monitoring schedule.
+func (a *SQLiteAdapter) SaveSchedule(ctx context.Context, schedule domain.MonitoringSchedule) error {
+	windows, err := json.Marshal(schedule.Windows)
+	if err != nil {
+		return err
+	}
+	model := ScheduleModel{
+		ID:       scheduleRowID,
+		Enabled:  schedule.Enabled,
+		Timezone: schedule.Timezone,
+		Windows:  string(windows),
+	}
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&model).Error
+}
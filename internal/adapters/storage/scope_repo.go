@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.ScopeRepository = (*SQLiteAdapter)(nil)
+
+// scopeRowID is the single row holding the workspace scope.
+const scopeRowID = 1
+
+// ScopeModel is the GORM model for the engagement scope. Lists are stored as JSON.
+type ScopeModel struct {
+	ID          uint `gorm:"primaryKey"`
+	BSSIDs      string
+	SSIDs       string
+	MACPrefixes string
+}
+
+// GetScope returns the engagement scope of the workspace, empty if none was set.
+func (a *SQLiteAdapter) GetScope(ctx context.Context) (domain.EngagementScope, error) {
+	var model ScopeModel
+	err := a.db.WithContext(ctx).First(&model, scopeRowID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.EngagementScope{}, nil
+	}
+	if err != nil {
+		return domain.EngagementScope{}, err
+	}
+
+	var scope domain.EngagementScope
+	for _, field := range []struct {
+		raw string
+		dst *[]string
+	}{
+		{model.BSSIDs, &scope.BSSIDs},
+		{model.SSIDs, &scope.SSIDs},
+		{model.MACPrefixes, &scope.MACPrefixes},
+	} {
+		if field.raw == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(field.raw), field.dst); err != nil {
+			return domain.EngagementScope{}, err
+		}
+	}
+	return scope, nil
+}
+
+// SaveScope replaces the engagement scope of the workspace.
+func (a *SQLiteAdapter) SaveScope(ctx context.Context, scope domain.EngagementScope) error {
+	bssids, _ := json.Marshal(scope.BSSIDs)
+	ssids, _ := json.Marshal(scope.SSIDs)
+	prefixes, _ := json.Marshal(scope.MACPrefixes)
+
+	model := ScopeModel{
+		ID:          scopeRowID,
+		BSSIDs:      string(bssids),
+		SSIDs:       string(ssids),
+		MACPrefixes: string(prefixes),
+	}
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&model).Error
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.SessionRepository = (*SQLiteAdapter)(nil)
+
+// SaveSession creates or updates a session.
+func (a *SQLiteAdapter) SaveSession(ctx context.Context, session domain.Session) error {
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&session).Error
+}
+
+// GetSessionByHash retrieves a session by the hash of its token.
+func (a *SQLiteAdapter) GetSessionByHash(ctx context.Context, hash string) (*domain.Session, error) {
+	var session domain.Session
+	if err := a.db.WithContext(ctx).Where("token_hash = ?", hash).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListSessions returns the sessions of a user, or all of them when userID is
+// empty, newest first.
+func (a *SQLiteAdapter) ListSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	query := a.db.WithContext(ctx).Order("created_at desc")
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	var sessions []domain.Session
+	if err := query.Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// DeleteSession revokes a session.
+func (a *SQLiteAdapter) DeleteSession(ctx context.Context, id string) error {
+	result := a.db.WithContext(ctx).Delete(&domain.Session{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSessionNotFound
+	}
+	return nil
+}
+
+// DeleteUserSessions revokes every session of a user.
+func (a *SQLiteAdapter) DeleteUserSessions(ctx context.Context, userID string) (int, error) {
+	result := a.db.WithContext(ctx).Delete(&domain.Session{}, "user_id = ?", userID)
+	return int(result.RowsAffected), result.Error
+}
+
+// DeleteExpiredSessions removes the sessions expired at now.
+func (a *SQLiteAdapter) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	return a.db.WithContext(ctx).Delete(&domain.Session{}, "expires_at < ?", now).Error
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessions(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	require.NoError(t, adapter.db.AutoMigrate(&domain.Session{}))
+	ctx := context.Background()
+	now := time.Now()
+
+	laptop := domain.Session{ID: "s1", TokenHash: "h1", UserID: "u1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	phone := domain.Session{ID: "s2", TokenHash: "h2", UserID: "u1", CreatedAt: now.Add(time.Second), ExpiresAt: now.Add(time.Hour)}
+	stale := domain.Session{ID: "s3", TokenHash: "h3", UserID: "u2", CreatedAt: now, ExpiresAt: now.Add(-time.Hour)}
+	for _, s := range []domain.Session{laptop, phone, stale} {
+		require.NoError(t, adapter.SaveSession(ctx, s))
+	}
+
+	session, err := adapter.GetSessionByHash(ctx, "h1")
+	require.NoError(t, err)
+	assert.Equal(t, "s1", session.ID)
+	_, err = adapter.GetSessionByHash(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+
+	sessions, err := adapter.ListSessions(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "s2", sessions[0].ID)
+
+	require.NoError(t, adapter.DeleteExpiredSessions(ctx, now))
+	sessions, err = adapter.ListSessions(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, sessions, 2)
+
+	require.NoError(t, adapter.DeleteSession(ctx, "s1"))
+	assert.ErrorIs(t, adapter.DeleteSession(ctx, "s1"), domain.ErrSessionNotFound)
+	n, err := adapter.DeleteUserSessions(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
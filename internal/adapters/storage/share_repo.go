@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.ShareLinkRepository = (*SQLiteAdapter)(nil)
+
+// SaveShareLink creates or updates a share link.
+func (a *SQLiteAdapter) SaveShareLink(ctx context.Context, link domain.ShareLink) error {
+	return a.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&link).Error
+}
+
+// GetShareLinkByHash retrieves a share link by the hash of its token.
+func (a *SQLiteAdapter) GetShareLinkByHash(ctx context.Context, hash string) (*domain.ShareLink, error) {
+	var link domain.ShareLink
+	if err := a.db.WithContext(ctx).Where("hash = ?", hash).First(&link).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrShareNotFound
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+// ListShareLinks returns the share links of a workspace, or all of them when
+// workspace is empty, newest first.
+func (a *SQLiteAdapter) ListShareLinks(ctx context.Context, workspace string) ([]domain.ShareLink, error) {
+	query := a.db.WithContext(ctx).Order("created_at desc")
+	if workspace != "" {
+		query = query.Where("workspace = ?", workspace)
+	}
+	var links []domain.ShareLink
+	if err := query.Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// DeleteShareLink revokes a share link.
+func (a *SQLiteAdapter) DeleteShareLink(ctx context.Context, id string) error {
+	result := a.db.WithContext(ctx).Delete(&domain.ShareLink{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrShareNotFound
+	}
+	return nil
+}
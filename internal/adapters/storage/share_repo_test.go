@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLinks(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	require.NoError(t, adapter.db.AutoMigrate(&domain.ShareLink{}))
+	ctx := context.Background()
+
+	now := time.Now()
+	customer := domain.ShareLink{ID: "s1", Workspace: "site-a", Hash: "h1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	other := domain.ShareLink{ID: "s2", Workspace: "site-b", Hash: "h2", CreatedAt: now.Add(time.Second), ExpiresAt: now.Add(time.Hour)}
+	require.NoError(t, adapter.SaveShareLink(ctx, customer))
+	require.NoError(t, adapter.SaveShareLink(ctx, other))
+
+	link, err := adapter.GetShareLinkByHash(ctx, "h1")
+	require.NoError(t, err)
+	assert.Equal(t, "site-a", link.Workspace)
+	_, err = adapter.GetShareLinkByHash(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrShareNotFound)
+
+	links, err := adapter.ListShareLinks(ctx, "site-a")
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	links, err = adapter.ListShareLinks(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, "s2", links[0].ID)
+
+	require.NoError(t, adapter.DeleteShareLink(ctx, "s1"))
+	assert.ErrorIs(t, adapter.DeleteShareLink(ctx, "s1"), domain.ErrShareNotFound)
+}
@@ -33,12 +33,16 @@ type DeviceModel struct {
 	Standard       string // 802.11ax (WiFi 6), etc.
 	Frequency      int    // 2412, 5180, etc.
 	ChannelWidth   int    // 20, 40, 80, 160 MHz
+	CenterChannel  int
+	Country        string // Advertised regulatory country
+	MaxTxPower     int    // dBm
 	WPSInfo        string // Configured, Unconfigured
 	Latitude       float64
 	Longitude      float64
 	LastPacketTime time.Time
 	FirstSeen      time.Time
 	LastSeen       time.Time
+	Sensor         string // Interface or agent that last heard it
 	ConnectedSSID  string
 	Model          string
 	OS             string
@@ -67,17 +71,28 @@ type DeviceModel struct {
 	ConnectionTarget string
 	ConnectionError  string
 
+	Annotations string // JSON encoded map[string]string
+
+	// Business asset
+	AssetOwner       string
+	AssetTag         string
+	AssetCriticality string
+
+	Portal string // JSON encoded domain.PortalCheck
+
 	// ProbedSSIDs is a many-to-many or one-to-many relationship,
 	// but for simplicity in SQLite we can store it in a separate table.
 	ProbedSSIDs []ProbeModel `gorm:"foreignKey:DeviceMAC"`
 }
 
-// ProbeModel stores SSIDs probed by a device.
+// ProbeModel stores SSIDs probed by a device, with how many probe requests
+// were heard for each.
 type ProbeModel struct {
 	ID        uint   `gorm:"primaryKey"`
 	DeviceMAC string `gorm:"index"`
 	SSID      string `gorm:"column:ssid"`
 	LastSeen  time.Time
+	Count     int
 }
 
 // VulnerabilityModel is the GORM model for vulnerabilities
@@ -94,6 +109,26 @@ type VulnerabilityModel struct {
 	Notes           string
 	Evidence        string // JSON encoded
 	Description     string
+	Workspace       string `gorm:"index"` // Open workspace the finding was routed to
+
+	// Suppression, kept apart from detections so they never overwrite it
+	SuppressedAt         *time.Time `gorm:"index"`
+	SuppressedBy         string
+	SuppressionReason    string
+	SuppressionExpiresAt *time.Time
+}
+
+// suppression returns the finding's suppression, nil when it has none.
+func (m VulnerabilityModel) suppression() *domain.VulnerabilitySuppression {
+	if m.SuppressedAt == nil {
+		return nil
+	}
+	return &domain.VulnerabilitySuppression{
+		Justification: m.SuppressionReason,
+		SuppressedBy:  m.SuppressedBy,
+		SuppressedAt:  *m.SuppressedAt,
+		ExpiresAt:     m.SuppressionExpiresAt,
+	}
 }
 
 // NewSQLiteAdapter initializes the database and migrates schema.
@@ -106,7 +141,7 @@ func NewSQLiteAdapter(path string) (*SQLiteAdapter, error) {
 	}
 
 	// Auto Migrate
-	if err := db.AutoMigrate(&DeviceModel{}, &ProbeModel{}, &domain.User{}, &domain.AuditLog{}, &VulnerabilityModel{}); err != nil {
+	if err := db.AutoMigrate(&DeviceModel{}, &ProbeModel{}, &domain.User{}, &domain.AuditLog{}, &VulnerabilityModel{}, &domain.AttackRecord{}, &domain.ActiveAttack{}, &domain.APIKey{}, &domain.ShareLink{}, &domain.Session{}, &ScopeModel{}, &domain.RulesOfEngagement{}, &BaselineModel{}, &ProtectedBSSIDModel{}, &GeofenceModel{}, &WorkspaceSettingsModel{}, &ScheduleModel{}, &BluetoothModel{}, &HookModel{}, &domain.RecoveredCredential{}, &domain.Job{}, &domain.Artifact{}); err != nil {
 		return nil, err
 	}
 
@@ -164,6 +199,7 @@ func (a *SQLiteAdapter) SaveDevice(ctx context.Context, d domain.Device) error {
 					DeviceMAC: d.MAC,
 					SSID:      ssid,
 					LastSeen:  ts,
+					Count:     d.ProbeCounts[ssid],
 				}
 				if err := a.db.WithContext(ctx).Create(&probe).Error; err != nil {
 					log.Printf("Failed to save probe: %v", err)
@@ -172,6 +208,7 @@ func (a *SQLiteAdapter) SaveDevice(ctx context.Context, d domain.Device) error {
 		} else {
 			// Update existing timestamp
 			probe.LastSeen = ts
+			probe.Count = d.ProbeCounts[ssid]
 			a.db.WithContext(ctx).Save(&probe)
 		}
 	}
@@ -179,6 +216,51 @@ func (a *SQLiteAdapter) SaveDevice(ctx context.Context, d domain.Device) error {
 	return nil
 }
 
+// saveProbes upserts the probed SSIDs of devices, counts included, looking
+// the stored ones up in a single query.
+func saveProbes(tx *gorm.DB, devices []domain.Device) error {
+	macs := make([]string, 0, len(devices))
+	for _, d := range devices {
+		if len(d.ProbedSSIDs) > 0 {
+			macs = append(macs, d.MAC)
+		}
+	}
+	if len(macs) == 0 {
+		return nil
+	}
+
+	var stored []ProbeModel
+	if err := tx.Where("device_mac IN ?", macs).Find(&stored).Error; err != nil {
+		return err
+	}
+	existing := make(map[[2]string]ProbeModel, len(stored))
+	for _, p := range stored {
+		existing[[2]string{p.DeviceMAC, p.SSID}] = p
+	}
+
+	var created []ProbeModel
+	for _, d := range devices {
+		for ssid, ts := range d.ProbedSSIDs {
+			probe, ok := existing[[2]string{d.MAC, ssid}]
+			if !ok {
+				created = append(created, ProbeModel{DeviceMAC: d.MAC, SSID: ssid, LastSeen: ts, Count: d.ProbeCounts[ssid]})
+				continue
+			}
+			if probe.LastSeen.Equal(ts) && probe.Count == d.ProbeCounts[ssid] {
+				continue
+			}
+			probe.LastSeen, probe.Count = ts, d.ProbeCounts[ssid]
+			if err := tx.Save(&probe).Error; err != nil {
+				return err
+			}
+		}
+	}
+	if len(created) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(created, 100).Error
+}
+
 // SaveDevicesBatch saves multiple devices in a single transaction.
 func (a *SQLiteAdapter) SaveDevicesBatch(ctx context.Context, devices []domain.Device) error {
 	if len(devices) == 0 {
@@ -191,9 +273,12 @@ func (a *SQLiteAdapter) SaveDevicesBatch(ctx context.Context, devices []domain.D
 	}
 
 	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		return tx.Clauses(clause.OnConflict{
+		if err := tx.Clauses(clause.OnConflict{
 			UpdateAll: true,
-		}).CreateInBatches(models, 100).Error
+		}).CreateInBatches(models, 100).Error; err != nil {
+			return err
+		}
+		return saveProbes(tx, devices)
 	})
 }
 
@@ -206,6 +291,39 @@ func (a *SQLiteAdapter) GetDevice(ctx context.Context, mac string) (*domain.Devi
 	return toDomain(model), nil
 }
 
+// DeleteDevice removes a device with its probes and vulnerabilities, and
+// clears the connections of stored devices to it.
+func (a *SQLiteAdapter) DeleteDevice(ctx context.Context, mac string) error {
+	found := false
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("mac = ?", mac).Delete(&DeviceModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+		found = result.RowsAffected > 0
+		if err := tx.Where("device_mac = ?", mac).Delete(&ProbeModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("device_mac = ?", mac).Delete(&VulnerabilityModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&DeviceModel{}).Where(&DeviceModel{ConnectedSSID: mac}).Update("ConnectedSSID", "").Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&DeviceModel{}).Where(&DeviceModel{ConnectionTarget: mac}).Updates(map[string]interface{}{
+			"ConnectionTarget": "",
+			"ConnectionState":  string(domain.StateDisconnected),
+		}).Error; err != nil {
+			return err
+		}
+		return nil
+	})
+	if err == nil && !found {
+		return domain.ErrDeviceNotFound
+	}
+	return err
+}
+
 // GetAllDevices retrieves all devices.
 func (a *SQLiteAdapter) GetAllDevices(ctx context.Context) ([]domain.Device, error) {
 	var models []DeviceModel
@@ -291,6 +409,7 @@ func (a *SQLiteAdapter) SaveVulnerability(ctx context.Context, record domain.Vul
 		Notes:           record.Notes,
 		Description:     record.Description,
 		Evidence:        string(evidenceBytes),
+		Workspace:       record.Workspace,
 	}
 
 	// Using Upsert logic
@@ -314,6 +433,17 @@ func (a *SQLiteAdapter) GetVulnerabilities(ctx context.Context, filter domain.Vu
 	if filter.MinSeverity > 0 {
 		query = query.Where("severity >= ?", filter.MinSeverity)
 	}
+	if filter.Workspace != "" {
+		query = query.Where("workspace = ?", filter.Workspace)
+	}
+	if filter.Suppressed != nil {
+		now := time.Now().UTC()
+		if *filter.Suppressed {
+			query = query.Where("suppressed_at IS NOT NULL AND (suppression_expires_at IS NULL OR suppression_expires_at > ?)", now)
+		} else {
+			query = query.Where("suppressed_at IS NULL OR suppression_expires_at <= ?", now)
+		}
+	}
 
 	if err := query.Find(&models).Error; err != nil {
 		return nil, err
@@ -334,6 +464,8 @@ func (a *SQLiteAdapter) GetVulnerabilities(ctx context.Context, filter domain.Vu
 			Notes:           m.Notes,
 			Description:     m.Description,
 			Evidence:        []string{}, // Unmarshal if needed
+			Suppression:     m.suppression(),
+			Workspace:       m.Workspace,
 		}
 		if m.Evidence != "" {
 			json.Unmarshal([]byte(m.Evidence), &records[i].Evidence)
@@ -362,6 +494,8 @@ func (a *SQLiteAdapter) GetVulnerability(ctx context.Context, id string) (*domai
 		Notes:           m.Notes,
 		Description:     m.Description,
 		Evidence:        []string{},
+		Suppression:     m.suppression(),
+		Workspace:       m.Workspace,
 	}
 	if m.Evidence != "" {
 		json.Unmarshal([]byte(m.Evidence), &record.Evidence)
@@ -379,6 +513,35 @@ func (a *SQLiteAdapter) UpdateVulnerabilityStatus(ctx context.Context, id string
 	return a.db.WithContext(ctx).Model(&VulnerabilityModel{}).Where("id = ?", id).Updates(updates).Error
 }
 
+// SetVulnerabilitySuppression suppresses a vulnerability, or lifts its
+// suppression when nil.
+func (a *SQLiteAdapter) SetVulnerabilitySuppression(ctx context.Context, id string, suppression *domain.VulnerabilitySuppression) error {
+	updates := map[string]interface{}{
+		"suppressed_at":          nil,
+		"suppressed_by":          "",
+		"suppression_reason":     "",
+		"suppression_expires_at": nil,
+	}
+	if suppression != nil {
+		at := suppression.SuppressedAt.UTC()
+		updates["suppressed_at"] = &at
+		updates["suppressed_by"] = suppression.SuppressedBy
+		updates["suppression_reason"] = suppression.Justification
+		if suppression.ExpiresAt != nil {
+			expires := suppression.ExpiresAt.UTC()
+			updates["suppression_expires_at"] = &expires
+		}
+	}
+	result := a.db.WithContext(ctx).Model(&VulnerabilityModel{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrVulnerabilityNotFound
+	}
+	return nil
+}
+
 func (a *SQLiteAdapter) Close() error {
 	sqlDB, err := a.db.DB()
 	if err != nil {
@@ -117,6 +117,32 @@ func TestProbedSSIDs_Persistence(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestSaveDevicesBatch_ProbeCounts(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	ctx := context.Background()
+	t0 := time.Now().Truncate(time.Second)
+
+	dev := domain.Device{
+		MAC:         "BB:BB:BB:BB:BB:BB",
+		ProbedSSIDs: map[string]time.Time{"HomeWiFi": t0},
+		ProbeCounts: map[string]int{"HomeWiFi": 1},
+	}
+	require.NoError(t, adapter.SaveDevicesBatch(ctx, []domain.Device{dev}))
+
+	dev.MergeProbes(map[string]time.Time{"HomeWiFi": t0.Add(time.Minute), "Cafe": t0.Add(time.Minute)}, nil)
+	require.NoError(t, adapter.SaveDevicesBatch(ctx, []domain.Device{dev}))
+
+	stored, err := adapter.GetDevice(ctx, dev.MAC)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"HomeWiFi": 2, "Cafe": 1}, stored.ProbeCounts)
+	assert.True(t, stored.ProbedSSIDs["HomeWiFi"].Equal(t0.Add(time.Minute)))
+
+	// Upserted, not duplicated
+	var rows int64
+	adapter.db.Model(&ProbeModel{}).Where("device_mac = ?", dev.MAC).Count(&rows)
+	assert.Equal(t, int64(2), rows)
+}
+
 func TestVulnerability_Persistence(t *testing.T) {
 	adapter := setupInMemoryDB(t)
 
@@ -149,3 +175,85 @@ func TestVulnerability_Persistence(t *testing.T) {
 	assert.Equal(t, "False positive test", stored2.Notes)
 	assert.False(t, stored2.StatusChangedAt.IsZero(), "StatusChangedAt should be set")
 }
+
+func TestVulnerability_Suppression(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	for _, id := range []string{"guest-open", "corp-wps", "lab-wep"} {
+		require.NoError(t, adapter.SaveVulnerability(ctx, domain.VulnerabilityRecord{
+			ID: id, DeviceMAC: "AA:BB:CC:DD:EE:FF", Name: id, Severity: domain.VulnSeverityCritical,
+			Status: domain.VulnStatusActive, FirstSeen: now, LastSeen: now,
+		}))
+	}
+	expired := now.Add(-time.Hour)
+	require.NoError(t, adapter.SetVulnerabilitySuppression(ctx, "guest-open", &domain.VulnerabilitySuppression{
+		Justification: "Guest SSID is open by design", SuppressedBy: "admin", SuppressedAt: now,
+	}))
+	require.NoError(t, adapter.SetVulnerabilitySuppression(ctx, "lab-wep", &domain.VulnerabilitySuppression{
+		Justification: "Lab network", SuppressedBy: "admin", SuppressedAt: now.Add(-2 * time.Hour), ExpiresAt: &expired,
+	}))
+	assert.ErrorIs(t, adapter.SetVulnerabilitySuppression(ctx, "missing", nil), domain.ErrVulnerabilityNotFound)
+
+	// Detections do not lift the suppression
+	require.NoError(t, adapter.SaveVulnerability(ctx, domain.VulnerabilityRecord{
+		ID: "guest-open", DeviceMAC: "AA:BB:CC:DD:EE:FF", Name: "guest-open", Severity: domain.VulnSeverityCritical,
+		Status: domain.VulnStatusActive, FirstSeen: now, LastSeen: now.Add(time.Minute),
+	}))
+	stored, err := adapter.GetVulnerability(ctx, "guest-open")
+	require.NoError(t, err)
+	require.NotNil(t, stored.Suppression)
+	assert.Equal(t, "Guest SSID is open by design", stored.Suppression.Justification)
+	assert.Equal(t, "admin", stored.Suppression.SuppressedBy)
+	assert.True(t, stored.IsSuppressed())
+
+	yes, no := true, false
+	suppressed, err := adapter.GetVulnerabilities(ctx, domain.VulnerabilityFilter{Suppressed: &yes})
+	require.NoError(t, err)
+	require.Len(t, suppressed, 1)
+	assert.Equal(t, "guest-open", suppressed[0].ID)
+
+	reported, err := adapter.GetVulnerabilities(ctx, domain.VulnerabilityFilter{Suppressed: &no})
+	require.NoError(t, err)
+	assert.Len(t, reported, 2, "expired suppressions no longer hide the finding")
+
+	require.NoError(t, adapter.SetVulnerabilitySuppression(ctx, "guest-open", nil))
+	stored, err = adapter.GetVulnerability(ctx, "guest-open")
+	require.NoError(t, err)
+	assert.Nil(t, stored.Suppression)
+}
+
+func TestDeleteDevice(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	ctx := context.Background()
+
+	ap := domain.Device{MAC: "AA:AA:AA:AA:AA:AA", Type: "ap"}
+	sta := domain.Device{
+		MAC:              "11:11:11:11:11:11",
+		ConnectedSSID:    ap.MAC,
+		ConnectionTarget: ap.MAC,
+		ConnectionState:  domain.StateConnected,
+		ProbedSSIDs:      map[string]time.Time{"Corp": time.Now()},
+	}
+	require.NoError(t, adapter.SaveDevice(ctx, ap))
+	require.NoError(t, adapter.SaveDevice(ctx, sta))
+
+	require.NoError(t, adapter.DeleteDevice(ctx, ap.MAC))
+	_, err := adapter.GetDevice(ctx, ap.MAC)
+	assert.Error(t, err)
+
+	stored, err := adapter.GetDevice(ctx, sta.MAC)
+	require.NoError(t, err)
+	assert.Empty(t, stored.ConnectedSSID)
+	assert.Empty(t, stored.ConnectionTarget)
+	assert.Equal(t, domain.StateDisconnected, stored.ConnectionState)
+
+	// Probes go with the device
+	require.NoError(t, adapter.DeleteDevice(ctx, sta.MAC))
+	var probes int64
+	adapter.db.Model(&ProbeModel{}).Where("device_mac = ?", sta.MAC).Count(&probes)
+	assert.Zero(t, probes)
+
+	assert.ErrorIs(t, adapter.DeleteDevice(ctx, sta.MAC), domain.ErrDeviceNotFound)
+}
@@ -22,7 +22,7 @@ func (a *SQLiteAdapter) GetByUsername(ctx context.Context, username string) (*do
 	var user domain.User
 	if err := a.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -34,7 +34,7 @@ func (a *SQLiteAdapter) GetByID(ctx context.Context, id string) (*domain.User, e
 	var user domain.User
 	if err := a.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, domain.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -49,3 +49,15 @@ func (a *SQLiteAdapter) List(ctx context.Context) ([]domain.User, error) {
 	}
 	return users, nil
 }
+
+// Delete removes a user by ID.
+func (a *SQLiteAdapter) Delete(ctx context.Context, id string) error {
+	result := a.db.WithContext(ctx).Delete(&domain.User{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ensure compliance
+var _ ports.WorkspaceSettingsRepository = (*SQLiteAdapter)(nil)
+
+// settingsRowID is the single row holding the workspace settings.
+const settingsRowID = 1
+
+// WorkspaceSettingsModel is the GORM model for the workspace settings not
+// kept in a table of their own. Rules and routing are stored as JSON.
+type WorkspaceSettingsModel struct {
+	ID              uint  `gorm:"primaryKey"`
+	DeviceRetention int64 // Nanoseconds
+	AlertRules      string
+	Notifications   string
+	UpdatedAt       time.Time
+}
+
+// GetWorkspaceSettings returns the settings of the workspace, with its scope
+// and baseline configuration.
+func (a *SQLiteAdapter) GetWorkspaceSettings(ctx context.Context) (domain.WorkspaceSettings, error) {
+	var settings domain.WorkspaceSettings
+	var model WorkspaceSettingsModel
+	err := a.db.WithContext(ctx).First(&model, settingsRowID).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.WorkspaceSettings{}, err
+	}
+	if err == nil {
+		settings.DeviceRetention = time.Duration(model.DeviceRetention)
+		settings.UpdatedAt = model.UpdatedAt
+		if model.AlertRules != "" {
+			if err := json.Unmarshal([]byte(model.AlertRules), &settings.AlertRules); err != nil {
+				return domain.WorkspaceSettings{}, err
+			}
+		}
+		if model.Notifications != "" {
+			if err := json.Unmarshal([]byte(model.Notifications), &settings.Notifications); err != nil {
+				return domain.WorkspaceSettings{}, err
+			}
+		}
+	}
+
+	if settings.Scope, err = a.GetScope(ctx); err != nil {
+		return domain.WorkspaceSettings{}, err
+	}
+	if settings.Baseline, err = a.GetBaseline(ctx); err != nil {
+		return domain.WorkspaceSettings{}, err
+	}
+	return settings, nil
+}
+
+// SaveWorkspaceSettings replaces the settings of the workspace, its scope and
+// baseline configuration included, in one transaction.
+func (a *SQLiteAdapter) SaveWorkspaceSettings(ctx context.Context, settings domain.WorkspaceSettings) error {
+	rules, err := json.Marshal(settings.AlertRules)
+	if err != nil {
+		return err
+	}
+	notifications, err := json.Marshal(settings.Notifications)
+	if err != nil {
+		return err
+	}
+
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txAdapter := &SQLiteAdapter{db: tx}
+		if err := txAdapter.SaveScope(ctx, settings.Scope); err != nil {
+			return err
+		}
+		if err := txAdapter.SaveBaseline(ctx, settings.Baseline); err != nil {
+			return err
+		}
+		model := WorkspaceSettingsModel{
+			ID:              settingsRowID,
+			DeviceRetention: int64(settings.DeviceRetention),
+			AlertRules:      string(rules),
+			Notifications:   string(notifications),
+			UpdatedAt:       settings.UpdatedAt,
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&model).Error
+	})
+}
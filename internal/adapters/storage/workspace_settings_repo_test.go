@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceSettings(t *testing.T) {
+	adapter := setupInMemoryDB(t)
+	require.NoError(t, adapter.db.AutoMigrate(&WorkspaceSettingsModel{}, &ScopeModel{}, &BaselineModel{}))
+	ctx := context.Background()
+
+	empty, err := adapter.GetWorkspaceSettings(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, empty.DeviceRetention)
+	assert.Empty(t, empty.AlertRules)
+
+	settings := domain.WorkspaceSettings{
+		DeviceRetention: 2 * time.Hour,
+		Scope:           domain.EngagementScope{SSIDs: []string{"Corp"}},
+		AlertRules:      []domain.AlertRule{{ID: "r1", Type: domain.AlertSSID, Value: "Lab", Enabled: true}},
+		Baseline:        domain.BaselineConfig{Enabled: true, LearningPeriod: time.Hour, StartedAt: time.Now().UTC().Truncate(time.Second)},
+		Notifications:   domain.NotificationRouting{MinSeverity: domain.SeverityHigh, Muted: []string{"AP_REBOOTED"}},
+		UpdatedAt:       time.Now().UTC().Truncate(time.Second),
+	}
+	require.NoError(t, adapter.SaveWorkspaceSettings(ctx, settings))
+
+	got, err := adapter.GetWorkspaceSettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, settings.DeviceRetention, got.DeviceRetention)
+	assert.Equal(t, settings.AlertRules, got.AlertRules)
+	assert.Equal(t, settings.Notifications, got.Notifications)
+	assert.Equal(t, []string{"Corp"}, got.Scope.SSIDs)
+	assert.True(t, got.Baseline.Enabled)
+	assert.True(t, settings.Baseline.StartedAt.Equal(got.Baseline.StartedAt))
+
+	// Scope and baseline are shared with their own endpoints
+	scope, err := adapter.GetScope(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Corp"}, scope.SSIDs)
+}
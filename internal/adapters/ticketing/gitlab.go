@@ -0,0 +1,109 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// markdownEscaper keeps values, such as SSIDs, from being read as markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`, "~", `\~`,
+)
+
+// GitLabTracker files issues in a GitLab project through the REST API v4.
+type GitLabTracker struct {
+	client  *client
+	project string
+}
+
+// Name identifies the tracker by the project and instance.
+func (t *GitLabTracker) Name() string { return "gitlab:" + t.project + "@" + t.client.host() }
+
+// path returns the API path of the project's issues.
+func (t *GitLabTracker) path() string {
+	return "/api/v4/projects/" + url.PathEscape(t.project) + "/issues"
+}
+
+type gitlabIssue struct {
+	IID       int       `json:"iid"`
+	WebURL    string    `json:"web_url"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FindIssue lists the project's issues, open or closed, labeled with the
+// fingerprint.
+func (t *GitLabTracker) FindIssue(ctx context.Context, fingerprint string) (domain.Ticket, bool, error) {
+	query := url.Values{"labels": {domain.TicketLabel(fingerprint)}, "state": {"all"}, "per_page": {"1"}}
+	var issues []gitlabIssue
+	if err := t.client.do(ctx, http.MethodGet, t.path()+"?"+query.Encode(), nil, &issues); err != nil {
+		return domain.Ticket{}, false, err
+	}
+	if len(issues) == 0 {
+		return domain.Ticket{}, false, nil
+	}
+	ticket := t.ticket(issues[0], fingerprint)
+	ticket.Existing = true
+	return ticket, true, nil
+}
+
+// CreateIssue opens an issue.
+func (t *GitLabTracker) CreateIssue(ctx context.Context, draft domain.IssueDraft) (domain.Ticket, error) {
+	body := map[string]string{
+		"title":       draft.Title,
+		"description": renderMarkdown(draft),
+		"labels":      strings.Join(draft.Labels, ","),
+	}
+	var created gitlabIssue
+	if err := t.client.do(ctx, http.MethodPost, t.path(), body, &created); err != nil {
+		return domain.Ticket{}, err
+	}
+	return t.ticket(created, draft.Fingerprint), nil
+}
+
+func (t *GitLabTracker) ticket(issue gitlabIssue, fingerprint string) domain.Ticket {
+	return domain.Ticket{
+		Tracker:     domain.TrackerGitLab,
+		Key:         fmt.Sprintf("#%d", issue.IID),
+		URL:         issue.WebURL,
+		Fingerprint: fingerprint,
+		Title:       issue.Title,
+		CreatedAt:   issue.CreatedAt,
+	}
+}
+
+// renderMarkdown writes an issue in GitLab flavored markdown.
+func renderMarkdown(draft domain.IssueDraft) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n**Severity:** %s\n", markdownEscaper.Replace(draft.Summary), draft.Severity)
+	if len(draft.Fields) > 0 {
+		b.WriteString("\n### Details\n\n| Field | Value |\n|---|---|\n")
+		for _, f := range draft.Fields {
+			fmt.Fprintf(&b, "| %s | %s |\n", f.Name, markdownEscaper.Replace(f.Value))
+		}
+	}
+	if len(draft.Evidence) > 0 {
+		b.WriteString("\n### Evidence\n\n")
+		for _, e := range draft.Evidence {
+			fmt.Fprintf(&b, "- %s\n", markdownEscaper.Replace(e))
+		}
+	}
+	if rec := draft.Remediation; rec.Title != "" {
+		fmt.Fprintf(&b, "\n### Remediation: %s\n\n%s\n\n", markdownEscaper.Replace(rec.Title), markdownEscaper.Replace(rec.Description))
+		for i, action := range rec.Actions {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, markdownEscaper.Replace(action))
+		}
+		if rec.EstimatedEffort != "" {
+			fmt.Fprintf(&b, "\n_Estimated effort: %s_\n", markdownEscaper.Replace(rec.EstimatedEffort))
+		}
+	}
+	fmt.Fprintf(&b, "\n---\n%s\n", footer(draft))
+	return b.String()
+}
@@ -0,0 +1,122 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// jiraTime is the layout of the timestamps of the Jira REST API.
+const jiraTime = "2006-01-02T15:04:05.000-0700"
+
+// jiraEscaper keeps values, such as SSIDs, from being read as wiki markup.
+var jiraEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "{", `\{`, "}", `\}`, "[", `\[`, "]", `\]`,
+	"|", `\|`, "-", `\-`, "+", `\+`, "^", `\^`, "~", `\~`, "#", `\#`, "!", `\!`,
+)
+
+// JiraTracker files issues in a Jira project through the REST API v2,
+// available on both Jira Cloud and Data Center.
+type JiraTracker struct {
+	client    *client
+	project   string
+	issueType string
+}
+
+// Name identifies the tracker by the project and instance.
+func (t *JiraTracker) Name() string { return "jira:" + t.project + "@" + t.client.host() }
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Created string `json:"created"`
+	} `json:"fields"`
+}
+
+// FindIssue searches the project for the issue labeled with the fingerprint.
+func (t *JiraTracker) FindIssue(ctx context.Context, fingerprint string) (domain.Ticket, bool, error) {
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s"`, t.project, domain.TicketLabel(fingerprint))
+	query := url.Values{"jql": {jql}, "maxResults": {"1"}, "fields": {"summary,created"}}
+	var result struct {
+		Issues []jiraIssue `json:"issues"`
+	}
+	if err := t.client.do(ctx, http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &result); err != nil {
+		return domain.Ticket{}, false, err
+	}
+	if len(result.Issues) == 0 {
+		return domain.Ticket{}, false, nil
+	}
+	issue := result.Issues[0]
+	created, _ := time.Parse(jiraTime, issue.Fields.Created)
+	return domain.Ticket{
+		Tracker:     domain.TrackerJira,
+		Key:         issue.Key,
+		URL:         t.client.base + "/browse/" + issue.Key,
+		Fingerprint: fingerprint,
+		Title:       issue.Fields.Summary,
+		CreatedAt:   created,
+		Existing:    true,
+	}, true, nil
+}
+
+// CreateIssue opens an issue of the configured type.
+func (t *JiraTracker) CreateIssue(ctx context.Context, draft domain.IssueDraft) (domain.Ticket, error) {
+	body := map[string]interface{}{
+		"fields": map[string]interface{}{
+			"project":     map[string]string{"key": t.project},
+			"issuetype":   map[string]string{"name": t.issueType},
+			"summary":     draft.Title,
+			"description": renderJira(draft),
+			"labels":      draft.Labels,
+		},
+	}
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := t.client.do(ctx, http.MethodPost, "/rest/api/2/issue", body, &created); err != nil {
+		return domain.Ticket{}, err
+	}
+	return domain.Ticket{
+		Tracker:     domain.TrackerJira,
+		Key:         created.Key,
+		URL:         t.client.base + "/browse/" + created.Key,
+		Fingerprint: draft.Fingerprint,
+		Title:       draft.Title,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// renderJira writes an issue in Jira wiki markup.
+func renderJira(draft domain.IssueDraft) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n*Severity:* %s\n", jiraEscaper.Replace(draft.Summary), draft.Severity)
+	if len(draft.Fields) > 0 {
+		b.WriteString("\nh3. Details\n||Field||Value||\n")
+		for _, f := range draft.Fields {
+			fmt.Fprintf(&b, "|%s|%s|\n", f.Name, jiraEscaper.Replace(f.Value))
+		}
+	}
+	if len(draft.Evidence) > 0 {
+		b.WriteString("\nh3. Evidence\n")
+		for _, e := range draft.Evidence {
+			fmt.Fprintf(&b, "* %s\n", jiraEscaper.Replace(e))
+		}
+	}
+	if rec := draft.Remediation; rec.Title != "" {
+		fmt.Fprintf(&b, "\nh3. Remediation: %s\n%s\n", jiraEscaper.Replace(rec.Title), jiraEscaper.Replace(rec.Description))
+		for _, action := range rec.Actions {
+			fmt.Fprintf(&b, "# %s\n", jiraEscaper.Replace(action))
+		}
+		if rec.EstimatedEffort != "" {
+			fmt.Fprintf(&b, "\n_Estimated effort: %s_\n", jiraEscaper.Replace(rec.EstimatedEffort))
+		}
+	}
+	fmt.Fprintf(&b, "\n----\n%s\n", footer(draft))
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package ticketing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDraft() domain.IssueDraft {
+	return domain.IssueDraft{
+		Title:    "[WMAP] CRITICAL: WPS-PIXIE on 00:11:22:33:44:55",
+		Summary:  "WPS PIN recoverable offline",
+		Severity: "critical",
+		Fields:   []domain.IssueField{{Name: "SSID", Value: "*free_wifi*"}},
+		Evidence: []string{"WPS 2.0 enabled"},
+		Remediation: domain.Recommendation{
+			Title:   "Disable WPS on All Access Points",
+			Actions: []string{"Disable WPS"},
+		},
+		Fingerprint: "0123456789abcdef",
+		Labels:      []string{"wmap", "wmap-vulnerability", "wmap-0123456789abcdef"},
+	}
+}
+
+func TestNewTracker(t *testing.T) {
+	_, err := NewTracker(Config{Tracker: "jira", URL: "example.com", Project: "SEC", Token: "t"})
+	assert.Error(t, err, "URL without scheme")
+	_, err = NewTracker(Config{Tracker: "jira", URL: "https://example.com", Token: "t"})
+	assert.Error(t, err, "no project")
+	_, err = NewTracker(Config{Tracker: "redmine", URL: "https://example.com", Project: "SEC", Token: "t"})
+	assert.Error(t, err)
+
+	tracker, err := NewTracker(Config{Tracker: "GitLab", URL: "https://gitlab.example.com/", Project: "sec/wifi", Token: "t"})
+	require.NoError(t, err)
+	assert.Equal(t, "gitlab:sec/wifi@gitlab.example.com", tracker.Name())
+}
+
+func TestJiraTracker(t *testing.T) {
+	var created map[string]map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "bot@example.com" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/search":
+			if r.URL.Query().Get("jql") == `project = "SEC" AND labels = "wmap-aaaa"` {
+				w.Write([]byte(`{"issues": [{"key": "SEC-7", "fields": {"summary": "Old", "created": "2024-03-01T10:00:00.000+0000"}}]}`))
+				return
+			}
+			w.Write([]byte(`{"issues": []}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "10001", "key": "SEC-42"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	tracker, err := NewTracker(Config{Tracker: "jira", URL: srv.URL, Project: "SEC", User: "bot@example.com", Token: "secret"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	ticket, found, err := tracker.FindIssue(ctx, "aaaa")
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, "SEC-7", ticket.Key)
+	assert.Equal(t, srv.URL+"/browse/SEC-7", ticket.URL)
+	assert.True(t, ticket.Existing)
+	assert.Equal(t, 2024, ticket.CreatedAt.Year())
+
+	_, found, err = tracker.FindIssue(ctx, "0123456789abcdef")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	ticket, err = tracker.CreateIssue(ctx, testDraft())
+	require.NoError(t, err)
+	assert.Equal(t, "SEC-42", ticket.Key)
+	fields := created["fields"]
+	assert.Equal(t, map[string]interface{}{"name": "Bug"}, fields["issuetype"])
+	assert.Contains(t, fields["labels"], "wmap-0123456789abcdef")
+	description := fields["description"].(string)
+	assert.Contains(t, description, `|SSID|\*free\_wifi\*|`, "values are escaped")
+	assert.Contains(t, description, "h3. Remediation: Disable WPS on All Access Points")
+	assert.Contains(t, description, "# Disable WPS")
+}
+
+func TestGitLabTracker(t *testing.T) {
+	var created map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.EscapedPath() != "/api/v4/projects/sec%2Fwifi/issues" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "all", r.URL.Query().Get("state"), "closed issues count as filed")
+			w.Write([]byte(`[]`))
+		case http.MethodPost:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"iid": 12, "web_url": "https://gitlab.example.com/sec/wifi/-/issues/12", "title": "t", "created_at": "2024-03-01T10:00:00Z"}`))
+		}
+	}))
+	defer srv.Close()
+
+	tracker, err := NewTracker(Config{Tracker: "gitlab", URL: srv.URL, Project: "sec/wifi", Token: "secret"})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, found, err := tracker.FindIssue(ctx, "0123456789abcdef")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	ticket, err := tracker.CreateIssue(ctx, testDraft())
+	require.NoError(t, err)
+	assert.Equal(t, "#12", ticket.Key)
+	assert.Equal(t, "https://gitlab.example.com/sec/wifi/-/issues/12", ticket.URL)
+	assert.Equal(t, "wmap,wmap-vulnerability,wmap-0123456789abcdef", created["labels"])
+	assert.Contains(t, created["description"], `| SSID | \*free\_wifi\* |`)
+	assert.Contains(t, created["description"], "1. Disable WPS")
+
+	bad, err := NewTracker(Config{Tracker: "gitlab", URL: srv.URL, Project: "sec/wifi", Token: "wrong"})
+	require.NoError(t, err)
+	_, _, err = bad.FindIssue(ctx, "0123456789abcdef")
+	assert.ErrorContains(t, err, "401")
+}
@@ -0,0 +1,131 @@
+// Package ticketing files findings as issues in Jira or GitLab.
+package ticketing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// maxResponseSize bounds a tracker response.
+const maxResponseSize = 4 << 20
+
+// Config locates the project issues are filed in.
+type Config struct {
+	Tracker   string // jira or gitlab
+	URL       string // Base URL of the instance
+	Project   string // Jira project key, or GitLab project ID or path
+	User      string // Jira Cloud account email, empty for a personal access token
+	Token     string // API token
+	IssueType string // Jira issue type, "Bug" when empty
+}
+
+// NewTracker creates the tracker of a project.
+func NewTracker(cfg Config) (ports.IssueTracker, error) {
+	base, err := url.Parse(strings.TrimRight(cfg.URL, "/"))
+	if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+		return nil, fmt.Errorf("tracker URL must be the http(s) URL of the instance")
+	}
+	if cfg.Project == "" {
+		return nil, fmt.Errorf("tracker project is required")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("tracker token is required")
+	}
+	c := &client{
+		base: base.String(),
+		http: &http.Client{Timeout: 30 * time.Second},
+	}
+	switch strings.ToLower(cfg.Tracker) {
+	case domain.TrackerJira:
+		if cfg.User != "" {
+			c.auth = func(req *http.Request) { req.SetBasicAuth(cfg.User, cfg.Token) }
+		} else {
+			c.auth = func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+cfg.Token) }
+		}
+		issueType := cfg.IssueType
+		if issueType == "" {
+			issueType = "Bug"
+		}
+		return &JiraTracker{client: c, project: cfg.Project, issueType: issueType}, nil
+	case domain.TrackerGitLab:
+		c.auth = func(req *http.Request) { req.Header.Set("PRIVATE-TOKEN", cfg.Token) }
+		return &GitLabTracker{client: c, project: cfg.Project}, nil
+	default:
+		return nil, fmt.Errorf("unknown tracker %q (jira or gitlab)", cfg.Tracker)
+	}
+}
+
+// client calls the REST API of a tracker.
+type client struct {
+	base string
+	http *http.Client
+	auth func(*http.Request)
+}
+
+// host leaves the path and credentials out of names and errors.
+func (c *client) host() string {
+	if u, err := url.Parse(c.base); err == nil {
+		return u.Host
+	}
+	return c.base
+}
+
+// do sends body as JSON to path and decodes the response into out.
+func (c *client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.auth(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := strings.TrimSpace(string(data))
+		if len(msg) > 200 {
+			msg = msg[:200]
+		}
+		return fmt.Errorf("%s %s returned %s: %s", method, c.host(), resp.Status, msg)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", c.host(), err)
+	}
+	return nil
+}
+
+// footer closes every issue, naming the fingerprint the issue is found by.
+func footer(draft domain.IssueDraft) string {
+	return "Filed by WMAP. Finding fingerprint: " + draft.Fingerprint
+}
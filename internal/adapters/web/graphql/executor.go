@@ -0,0 +1,351 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxDepth bounds how deeply selections nest, so computed fields
+	// pointing back at their parents cannot be queried without end.
+	maxDepth = 12
+	// maxNodes bounds the values a response holds, so that nesting lists
+	// within maxDepth cannot multiply the work of a query.
+	maxNodes = 10000
+)
+
+// Args are the arguments of a field, variables substituted: strings,
+// numbers (int64 or float64), booleans, lists and objects.
+type Args map[string]any
+
+// String returns a string argument, empty when absent.
+func (a Args) String(name string) string {
+	switch v := a[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Int returns an integer argument, def when absent.
+func (a Args) Int(name string, def int) (int, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return def, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("argument %q must be an integer", name)
+}
+
+// Resolver resolves a root query field.
+type Resolver func(ctx context.Context, args Args) (any, error)
+
+// FieldResolver resolves a computed field of an object, parent being the
+// object value.
+type FieldResolver func(ctx context.Context, parent any, args Args) (any, error)
+
+// Schema maps the root query fields to their resolvers. The fields of the
+// objects they return are those of their JSON encoding, plus the computed
+// fields registered per type.
+type Schema struct {
+	query  map[string]Resolver
+	fields map[reflect.Type]map[string]FieldResolver
+}
+
+// NewSchema returns an empty schema.
+func NewSchema() *Schema {
+	return &Schema{
+		query:  make(map[string]Resolver),
+		fields: make(map[reflect.Type]map[string]FieldResolver),
+	}
+}
+
+// Query registers a root query field.
+func (s *Schema) Query(name string, resolve Resolver) {
+	s.query[name] = resolve
+}
+
+// Field registers a computed field on the type of object, e.g. domain.Device{}.
+func (s *Schema) Field(object any, name string, resolve FieldResolver) {
+	t := reflect.TypeOf(object)
+	if s.fields[t] == nil {
+		s.fields[t] = make(map[string]FieldResolver)
+	}
+	s.fields[t][name] = resolve
+}
+
+// Error is a query error, located by the response path of the failed field.
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// Response is the result of a query. Data is nil when the query could not
+// be executed at all.
+type Response struct {
+	Data   map[string]any `json:"data,omitempty"`
+	Errors []Error        `json:"errors,omitempty"`
+}
+
+// Execute parses and runs a query. Fields that fail resolve to null and are
+// reported in the errors, next to the data of the others.
+func (s *Schema) Execute(ctx context.Context, source string, variables map[string]any) Response {
+	query, err := Parse(source)
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error()}}}
+	}
+
+	vars := make(map[string]any, len(query.Variables))
+	for _, v := range query.Variables {
+		if value, ok := variables[v.Name]; ok {
+			vars[v.Name] = value
+		} else if v.Default != nil {
+			vars[v.Name], _ = resolveValue(*v.Default, nil)
+		}
+	}
+
+	e := &execution{schema: s, vars: vars}
+	data := make(map[string]any, len(query.Selections))
+	for _, field := range query.Selections {
+		path := []any{field.Alias}
+		if field.Name == "__typename" {
+			data[field.Alias] = "Query"
+			continue
+		}
+		if !e.spend(path) {
+			data[field.Alias] = nil
+			continue
+		}
+		resolve, ok := s.query[field.Name]
+		if !ok {
+			e.fail(path, "Cannot query field %q on type \"Query\"", field.Name)
+			data[field.Alias] = nil
+			continue
+		}
+		args, err := e.arguments(field)
+		if err != nil {
+			e.fail(path, "%v", err)
+			data[field.Alias] = nil
+			continue
+		}
+		value, err := resolve(ctx, args)
+		if err != nil {
+			e.fail(path, "%v", err)
+			data[field.Alias] = nil
+			continue
+		}
+		data[field.Alias] = e.project(ctx, reflect.ValueOf(value), field, path)
+	}
+	return Response{Data: data, Errors: e.errors}
+}
+
+type execution struct {
+	schema *Schema
+	vars   map[string]any
+	errors []Error
+	nodes  int // Values resolved so far
+}
+
+// spend counts a value of the response against maxNodes, reporting the
+// query once when it runs over. Values past the budget resolve to null.
+func (e *execution) spend(path []any) bool {
+	e.nodes++
+	if e.nodes == maxNodes+1 {
+		e.fail(path, "query exceeds the budget of %d values", maxNodes)
+	}
+	return e.nodes <= maxNodes
+}
+
+func (e *execution) fail(path []any, format string, args ...any) {
+	e.errors = append(e.errors, Error{Message: fmt.Sprintf(format, args...), Path: append([]any(nil), path...)})
+}
+
+// arguments resolves the arguments of a field.
+func (e *execution) arguments(field *Field) (Args, error) {
+	args := make(Args, len(field.Arguments))
+	for name, value := range field.Arguments {
+		v, err := resolveValue(value, e.vars)
+		if err != nil {
+			return nil, err
+		}
+		args[name] = v
+	}
+	return args, nil
+}
+
+// resolveValue turns a parsed value into a Go value, substituting variables.
+func resolveValue(value Value, vars map[string]any) (any, error) {
+	if value.Variable != "" {
+		v, ok := vars[value.Variable]
+		if !ok {
+			return nil, fmt.Errorf("variable $%s is not defined", value.Variable)
+		}
+		return v, nil
+	}
+	switch literal := value.Literal.(type) {
+	case []Value:
+		list := make([]any, len(literal))
+		for i, item := range literal {
+			v, err := resolveValue(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = v
+		}
+		return list, nil
+	case map[string]Value:
+		object := make(map[string]any, len(literal))
+		for key, item := range literal {
+			v, err := resolveValue(item, vars)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = v
+		}
+		return object, nil
+	}
+	return value.Literal, nil
+}
+
+// project keeps the selected fields of a resolved value. Leaf fields are
+// returned as they are, to be encoded like in the REST API.
+func (e *execution) project(ctx context.Context, v reflect.Value, field *Field, path []any) any {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	if field.Selections == nil {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		list := make([]any, v.Len())
+		for i := range list {
+			if !e.spend(append(path, i)) {
+				break
+			}
+			list[i] = e.project(ctx, v.Index(i), field, append(path, i))
+		}
+		return list
+	case reflect.Struct, reflect.Map:
+		if v.Kind() == reflect.Map && v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		object := make(map[string]any, len(field.Selections))
+		for _, sub := range field.Selections {
+			subPath := append(path, sub.Alias)
+			if !e.spend(subPath) {
+				object[sub.Alias] = nil
+				continue
+			}
+			value, ok := e.resolveField(ctx, v, sub, subPath)
+			if !ok {
+				object[sub.Alias] = nil
+				continue
+			}
+			object[sub.Alias] = e.project(ctx, value, sub, subPath)
+		}
+		return object
+	}
+	e.fail(path, "Field %q of type %s has no subfields", field.Name, typeName(v.Type()))
+	return nil
+}
+
+// resolveField returns the value of a field of an object: a computed field,
+// a key of a map or a field of a struct by its JSON name.
+func (e *execution) resolveField(ctx context.Context, object reflect.Value, field *Field, path []any) (reflect.Value, bool) {
+	t := object.Type()
+	if field.Name == "__typename" {
+		return reflect.ValueOf(typeName(t)), true
+	}
+
+	if resolve, ok := e.schema.fields[t][field.Name]; ok {
+		args, err := e.arguments(field)
+		if err != nil {
+			e.fail(path, "%v", err)
+			return reflect.Value{}, false
+		}
+		value, err := resolve(ctx, object.Interface(), args)
+		if err != nil {
+			e.fail(path, "%v", err)
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(value), true
+	}
+	if len(field.Arguments) > 0 {
+		e.fail(path, "Field %q of type %s takes no arguments", field.Name, typeName(t))
+		return reflect.Value{}, false
+	}
+
+	if object.Kind() == reflect.Map {
+		value := object.MapIndex(reflect.ValueOf(field.Name).Convert(t.Key()))
+		return value, true // Missing keys are null
+	}
+	index, ok := jsonFields(t)[field.Name]
+	if !ok {
+		e.fail(path, "Cannot query field %q on type %s", field.Name, typeName(t))
+		return reflect.Value{}, false
+	}
+	value, err := object.FieldByIndexErr(index)
+	if err != nil {
+		return reflect.Value{}, true // Through a nil embedded pointer: null
+	}
+	return value, true
+}
+
+// typeName names a type in errors and __typename.
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+var fieldCache sync.Map // reflect.Type -> map[string][]int
+
+// jsonFields maps the JSON names of the fields of a struct type, promoted
+// fields of embedded structs included, to their index.
+func jsonFields(t reflect.Type) map[string][]int {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+	fields := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(t) {
+		tag := f.Tag.Get("json")
+		if tag == "-" || !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			continue // Its fields are promoted
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, taken := fields[name]; !taken {
+			fields[name] = f.Index
+		}
+	}
+	fieldCache.Store(t, fields)
+	return fields
+}
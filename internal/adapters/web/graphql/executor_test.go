@@ -0,0 +1,162 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRadio struct {
+	Channel int `json:"channel"`
+}
+
+type testNode struct {
+	testRadio
+	MAC     string            `json:"mac"`
+	Vendor  string            `json:"vendor,omitempty"`
+	Secret  string            `json:"-"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	Clients []*testNode       `json:"clients,omitempty"`
+}
+
+func testSchema() *Schema {
+	nodes := []testNode{
+		{MAC: "aa", Vendor: "Acme", testRadio: testRadio{Channel: 6}, Tags: map[string]string{"site": "hq"},
+			Clients: []*testNode{{MAC: "cc"}}},
+		{MAC: "bb", Vendor: "Other", testRadio: testRadio{Channel: 11}},
+	}
+	s := NewSchema()
+	s.Query("nodes", func(_ context.Context, args Args) (any, error) {
+		limit, err := args.Int("limit", len(nodes))
+		if err != nil {
+			return nil, err
+		}
+		return nodes[:min(limit, len(nodes))], nil
+	})
+	s.Query("node", func(_ context.Context, args Args) (any, error) {
+		for i := range nodes {
+			if nodes[i].MAC == args.String("mac") {
+				return &nodes[i], nil
+			}
+		}
+		return nil, nil
+	})
+	s.Query("broken", func(context.Context, Args) (any, error) {
+		return nil, errors.New("backend down")
+	})
+	s.Field(testNode{}, "label", func(_ context.Context, parent any, args Args) (any, error) {
+		return args.String("prefix") + parent.(testNode).MAC, nil
+	})
+	return s
+}
+
+func encode(t *testing.T, r Response) string {
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestExecute_Selections(t *testing.T) {
+	r := testSchema().Execute(context.Background(), `
+		# Aliases, nesting and promoted fields
+		{
+			first: nodes(limit: 1) { mac channel tags { site } clients { mac } }
+			nodes { mac vendor }
+		}`, nil)
+	assert.JSONEq(t, `{"data":{
+		"first":[{"mac":"aa","channel":6,"tags":{"site":"hq"},"clients":[{"mac":"cc"}]}],
+		"nodes":[{"mac":"aa","vendor":"Acme"},{"mac":"bb","vendor":"Other"}]
+	}}`, encode(t, r))
+}
+
+func TestExecute_VariablesAndComputedFields(t *testing.T) {
+	r := testSchema().Execute(context.Background(), `
+		query One($mac: String!, $prefix: String = "node-") {
+			node(mac: $mac) { __typename label(prefix: $prefix) }
+			missing: node(mac: "zz") { mac }
+		}`, map[string]any{"mac": "bb"})
+	assert.JSONEq(t, `{"data":{"node":{"__typename":"testNode","label":"node-bb"},"missing":null}}`, encode(t, r))
+}
+
+func TestExecute_Errors(t *testing.T) {
+	s := testSchema()
+
+	r := s.Execute(context.Background(), `{ nodes { mac secret } broken { mac } }`, nil)
+	require.Len(t, r.Errors, 3)
+	assert.Equal(t, `Cannot query field "secret" on type testNode`, r.Errors[0].Message)
+	assert.Equal(t, []any{"nodes", 0, "secret"}, r.Errors[0].Path)
+	assert.Equal(t, "backend down", r.Errors[2].Message)
+	assert.Nil(t, r.Data["broken"])
+
+	r = s.Execute(context.Background(), `{ nodes { mac { x } } }`, nil)
+	require.NotEmpty(t, r.Errors)
+	assert.Contains(t, r.Errors[0].Message, "has no subfields")
+
+	r = s.Execute(context.Background(), `{ nodes(limit: $n) { mac } }`, nil)
+	require.Len(t, r.Errors, 1)
+	assert.Contains(t, r.Errors[0].Message, "$n is not defined")
+
+	r = s.Execute(context.Background(), `{ nodes(limit: "ten") { mac } }`, nil)
+	require.Len(t, r.Errors, 1)
+	assert.Contains(t, r.Errors[0].Message, "must be an integer")
+}
+
+func TestExecute_RequestErrors(t *testing.T) {
+	s := testSchema()
+	for name, query := range map[string]string{
+		"mutation":   `mutation { nodes { mac } }`,
+		"fragment":   `{ nodes { ...f } }`,
+		"syntax":     `{ nodes { mac }`,
+		"two ops":    `{ nodes { mac } } { nodes { mac } }`,
+		"bad string": `{ node(mac: "aa) { mac } }`,
+		"too deep":   `{ a { b { c { d { e { f { g { h { i { j { k { l { m } } } } } } } } } } } } }`,
+		"deep value": `{ nodes(l: [[[[[[[[[[[[[1]]]]]]]]]]]]]) { mac } }`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := s.Execute(context.Background(), query, nil)
+			assert.Nil(t, r.Data)
+			assert.Len(t, r.Errors, 1)
+		})
+	}
+}
+
+func TestExecute_Budget(t *testing.T) {
+	s := testSchema()
+	many := make([]testNode, maxNodes)
+	s.Query("many", func(context.Context, Args) (any, error) { return many, nil })
+
+	r := s.Execute(context.Background(), `{ many { mac vendor } }`, nil)
+	require.Len(t, r.Errors, 1)
+	assert.Contains(t, r.Errors[0].Message, "budget")
+	list := r.Data["many"].([]any)
+	assert.NotNil(t, list[0])
+	assert.Nil(t, list[len(list)-1], "values past the budget are null")
+}
+
+func TestParse_Values(t *testing.T) {
+	q, err := Parse(`query { f(s: "a\"bé", i: -3, x: 1.5e2, b: true, n: null, e: HIGH, l: [1, 2], o: {k: "v"}) }`)
+	require.NoError(t, err)
+	args, err := (&execution{}).arguments(q.Selections[0])
+	require.NoError(t, err)
+	assert.Equal(t, Args{
+		"s": "a\"bé", "i": int64(-3), "x": 150.0, "b": true, "n": nil, "e": "HIGH",
+		"l": []any{int64(1), int64(2)}, "o": map[string]any{"k": "v"},
+	}, args)
+}
+
+// FuzzExecute checks that no query panics the parser or the executor.
+func FuzzExecute(f *testing.F) {
+	f.Add(`{ nodes(limit: 1) { mac channel tags { site } clients { mac } } }`)
+	f.Add(`query One($mac: String!, $prefix: String = "node-") { node(mac: $mac) { __typename label(prefix: $prefix) } }`)
+	f.Add(`query { f(s: "a\"bé", i: -3, x: 1.5e2, b: true, n: null, e: HIGH, l: [1, 2], o: {k: "v"}) }`)
+	f.Add(`query Q($l: [[Int!]]) { nodes { ...f } }`)
+
+	s := testSchema()
+	f.Fuzz(func(t *testing.T, query string) {
+		s.Execute(context.Background(), query, map[string]any{"mac": "aa"})
+	})
+}
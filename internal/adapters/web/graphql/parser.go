@@ -0,0 +1,502 @@
+// Package graphql executes read-only GraphQL queries over the domain model.
+// It implements the subset dashboards need: a query operation with
+// variables, aliases, arguments and nested selections. Mutations,
+// subscriptions, fragments and introspection are not supported.
+//
+// That subset is small enough that a hand-written parser and executor cost
+// less than a GraphQL library and its schema definitions, which would
+// duplicate the JSON encoding of the domain types. The parser bounds how
+// deeply queries nest and is fuzzed (FuzzExecute).
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Field is a field of a selection set.
+type Field struct {
+	Alias      string // Name in the response, the field name if not aliased
+	Name       string
+	Arguments  map[string]Value
+	Selections []*Field // Nil for leaf fields
+}
+
+// Value is an argument value: a literal, a list, an object or a variable.
+type Value struct {
+	Variable string // Set when the value is a $variable
+	Literal  any    // string, int64, float64, bool, nil, []Value or map[string]Value
+}
+
+// Variable is a variable defined by the operation.
+type Variable struct {
+	Name    string
+	Default *Value
+}
+
+// Query is a parsed query operation.
+type Query struct {
+	Name       string
+	Variables  []Variable
+	Selections []*Field
+}
+
+// Parse parses a document holding a single query operation. Selections and
+// values nesting deeper than maxDepth are refused.
+func Parse(source string) (*Query, error) {
+	p := &parser{lex: lexer{src: source}}
+	p.next()
+	q, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorf("unexpected %q after the operation, only one query is supported", p.tok.text)
+	}
+	return q, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokInt
+	tokFloat
+	tokString
+	tokPunct
+	tokSpread
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+// next returns the next token, skipping whitespace, commas and comments.
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		case c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			l.pos++
+		default:
+			return l.scan()
+		}
+	}
+	return token{kind: tokEOF, pos: l.pos}, nil
+}
+
+func (l *lexer) scan() (token, error) {
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokSpread, text: "...", pos: start}, nil
+	case strings.ContainsRune("{}()[]:$!=@", rune(c)):
+		l.pos++
+		return token{kind: tokPunct, text: string(c), pos: start}, nil
+	case c == '"':
+		return l.scanString()
+	case c == '-' || isDigit(c):
+		return l.scanNumber()
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokName, text: l.src[start:l.pos], pos: start}, nil
+	}
+	return token{}, fmt.Errorf("syntax error at %d: unexpected character %q", start, c)
+}
+
+func (l *lexer) scanNumber() (token, error) {
+	start := l.pos
+	kind := tokInt
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case isDigit(c):
+		case c == '.' || c == 'e' || c == 'E' || ((c == '+' || c == '-') && kind == tokFloat):
+			kind = tokFloat
+		default:
+			return token{kind: kind, text: l.src[start:l.pos], pos: start}, nil
+		}
+		l.pos++
+	}
+	return token{kind: kind, text: l.src[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) scanString() (token, error) {
+	start := l.pos
+	l.pos++ // Opening quote
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return token{kind: tokString, text: b.String(), pos: start}, nil
+		case '\n':
+			return token{}, fmt.Errorf("syntax error at %d: unterminated string", start)
+		case '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, fmt.Errorf("syntax error at %d: unterminated string", start)
+			}
+			l.pos++
+			switch e := l.src[l.pos]; e {
+			case '"', '\\', '/':
+				b.WriteByte(e)
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'u':
+				if l.pos+4 >= len(l.src) {
+					return token{}, fmt.Errorf("syntax error at %d: invalid unicode escape", l.pos)
+				}
+				r, err := strconv.ParseUint(l.src[l.pos+1:l.pos+5], 16, 32)
+				if err != nil {
+					return token{}, fmt.Errorf("syntax error at %d: invalid unicode escape", l.pos)
+				}
+				b.WriteRune(rune(r))
+				l.pos += 4
+			default:
+				return token{}, fmt.Errorf("syntax error at %d: invalid escape \\%c", l.pos, e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+		l.pos++
+	}
+	return token{}, fmt.Errorf("syntax error at %d: unterminated string", start)
+}
+
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+type parser struct {
+	lex   lexer
+	tok   token
+	err   error
+	depth int
+}
+
+// next advances to the next token. A lexing error ends the input.
+func (p *parser) next() {
+	if p.err != nil {
+		return
+	}
+	tok, err := p.lex.next()
+	if err != nil {
+		p.err = err
+		tok = token{kind: tokEOF, pos: p.lex.pos}
+	}
+	p.tok = tok
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	if p.err != nil {
+		return p.err
+	}
+	return fmt.Errorf("syntax error at %d: %s", p.tok.pos, fmt.Sprintf(format, args...))
+}
+
+// enter descends into a nested selection set or value, refusing more than
+// maxDepth. Each enter is paired with a leave.
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxDepth {
+		return fmt.Errorf("query nests deeper than %d levels", maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() { p.depth-- }
+
+func (p *parser) is(text string) bool {
+	return (p.tok.kind == tokPunct || p.tok.kind == tokName) && p.tok.text == text
+}
+
+func (p *parser) expect(text string) error {
+	if p.tok.kind != tokPunct || p.tok.text != text {
+		return p.errorf("expected %q, found %q", text, p.tok.text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokName {
+		return "", p.errorf("expected a name, found %q", p.tok.text)
+	}
+	name := p.tok.text
+	p.next()
+	return name, nil
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	q := &Query{}
+	if p.tok.kind == tokName {
+		switch p.tok.text {
+		case "query":
+			p.next()
+		case "mutation", "subscription":
+			return nil, fmt.Errorf("%s operations are not supported", p.tok.text)
+		case "fragment":
+			return nil, fmt.Errorf("fragments are not supported")
+		default:
+			return nil, p.errorf("unexpected %q", p.tok.text)
+		}
+		if p.tok.kind == tokName {
+			q.Name = p.tok.text
+			p.next()
+		}
+		if p.is("(") {
+			vars, err := p.parseVariables()
+			if err != nil {
+				return nil, err
+			}
+			q.Variables = vars
+		}
+		if p.is("@") {
+			return nil, fmt.Errorf("directives are not supported")
+		}
+	}
+
+	selections, err := p.parseSelections()
+	if err != nil {
+		return nil, err
+	}
+	q.Selections = selections
+	return q, p.err
+}
+
+func (p *parser) parseVariables() ([]Variable, error) {
+	p.next() // (
+	var vars []Variable
+	for !p.is(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if err := p.skipType(); err != nil {
+			return nil, err
+		}
+		v := Variable{Name: name}
+		if p.is("=") {
+			p.next()
+			def, err := p.parseValue(true)
+			if err != nil {
+				return nil, err
+			}
+			v.Default = &def
+		}
+		vars = append(vars, v)
+	}
+	p.next() // )
+	return vars, nil
+}
+
+// skipType skips a variable type: values are checked by the resolvers.
+func (p *parser) skipType() error {
+	if err := p.enter(); err != nil {
+		return err
+	}
+	defer p.leave()
+	if p.is("[") {
+		p.next()
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.name(); err != nil {
+		return err
+	}
+	if p.is("!") {
+		p.next()
+	}
+	return nil
+}
+
+func (p *parser) parseSelections() ([]*Field, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var fields []*Field
+	for !p.is("}") {
+		if p.tok.kind == tokEOF {
+			return nil, p.errorf("unterminated selection set")
+		}
+		if p.tok.kind == tokSpread {
+			return nil, fmt.Errorf("fragments are not supported")
+		}
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, p.errorf("empty selection set")
+	}
+	p.next() // }
+	return fields, nil
+}
+
+func (p *parser) parseField() (*Field, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	field := &Field{Alias: name, Name: name}
+	if p.is(":") {
+		p.next()
+		if field.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if p.is("(") {
+		p.next()
+		field.Arguments = make(map[string]Value)
+		for !p.is(")") {
+			arg, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			value, err := p.parseValue(false)
+			if err != nil {
+				return nil, err
+			}
+			field.Arguments[arg] = value
+		}
+		p.next() // )
+	}
+	if p.is("@") {
+		return nil, fmt.Errorf("directives are not supported")
+	}
+	if p.is("{") {
+		if field.Selections, err = p.parseSelections(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+// parseValue parses an argument value. Constant values, such as variable
+// defaults, cannot refer to variables.
+func (p *parser) parseValue(constant bool) (Value, error) {
+	if err := p.enter(); err != nil {
+		return Value{}, err
+	}
+	defer p.leave()
+	tok := p.tok
+	switch tok.kind {
+	case tokPunct:
+		switch tok.text {
+		case "$":
+			if constant {
+				return Value{}, p.errorf("variables are not allowed here")
+			}
+			p.next()
+			name, err := p.name()
+			return Value{Variable: name}, err
+		case "[":
+			p.next()
+			list := []Value{}
+			for !p.is("]") {
+				if p.tok.kind == tokEOF {
+					return Value{}, p.errorf("unterminated list")
+				}
+				item, err := p.parseValue(constant)
+				if err != nil {
+					return Value{}, err
+				}
+				list = append(list, item)
+			}
+			p.next()
+			return Value{Literal: list}, nil
+		case "{":
+			p.next()
+			object := map[string]Value{}
+			for !p.is("}") {
+				key, err := p.name()
+				if err != nil {
+					return Value{}, err
+				}
+				if err := p.expect(":"); err != nil {
+					return Value{}, err
+				}
+				if object[key], err = p.parseValue(constant); err != nil {
+					return Value{}, err
+				}
+			}
+			p.next()
+			return Value{Literal: object}, nil
+		}
+	case tokInt:
+		p.next()
+		n, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return Value{}, fmt.Errorf("syntax error at %d: invalid integer %s", tok.pos, tok.text)
+		}
+		return Value{Literal: n}, nil
+	case tokFloat:
+		p.next()
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return Value{}, fmt.Errorf("syntax error at %d: invalid number %s", tok.pos, tok.text)
+		}
+		return Value{Literal: f}, nil
+	case tokString:
+		p.next()
+		return Value{Literal: tok.text}, nil
+	case tokName:
+		p.next()
+		switch tok.text {
+		case "true":
+			return Value{Literal: true}, nil
+		case "false":
+			return Value{Literal: false}, nil
+		case "null":
+			return Value{}, nil
+		}
+		return Value{Literal: tok.text}, nil // Enum values are passed as strings
+	}
+	return Value{}, p.errorf("expected a value, found %q", tok.text)
+}
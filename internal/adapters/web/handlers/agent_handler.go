@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// AgentHandler starts and stops attacks on remote agents and configures them
+type AgentHandler struct {
+	Agents ports.AgentController
+}
+
+// NewAgentHandler creates a new AgentHandler
+func NewAgentHandler(agents ports.AgentController) *AgentHandler {
+	return &AgentHandler{
+		Agents: agents,
+	}
+}
+
+// HandleListAgents returns the agents connected to the command channel, with
+// the configuration pushed to each and the one it runs with, and the agent
+// release advertised for self-update
+func (h *AgentHandler) HandleListAgents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"agents":  h.Agents.ListAgents(),
+		"release": h.Agents.Release(),
+	})
+}
+
+// HandleListAttacks returns the remote attacks and their progress
+func (h *AgentHandler) HandleListAttacks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"attacks": h.Agents.ListAttacks(),
+	})
+}
+
+// HandleStartAttack sends an attack to an agent. The config is the one of
+// the local start endpoint of the attack kind.
+func (h *AgentHandler) HandleStartAttack(w http.ResponseWriter, r *http.Request) {
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var req struct {
+		AgentID string            `json:"agent_id"`
+		Kind    domain.AttackKind `json:"kind"`
+		Config  json.RawMessage   `json:"config"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.AgentID == "" || req.Kind == "" {
+		http.Error(w, "agent_id and kind are required", http.StatusBadRequest)
+		return
+	}
+	perm := domain.AttackPermission(req.Kind)
+	if user, ok := domain.UserFromContext(r.Context()); ok && !user.Can(perm) {
+		http.Error(w, "Forbidden: requires permission "+string(perm), http.StatusForbidden)
+		return
+	}
+
+	attack, err := h.Agents.StartAttack(r.Context(), req.AgentID, req.Kind, req.Config)
+	if err != nil {
+		writeError(w, "Failed to start remote attack", err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(attack)
+}
+
+// HandleStopAttack asks the agent running an attack to stop it
+func (h *AgentHandler) HandleStopAttack(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "Attack ID required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Agents.StopAttack(r.Context(), id); err != nil {
+		code := http.StatusNotFound
+		if errors.Is(err, domain.ErrAgentNotConnected) {
+			code = http.StatusConflict
+		}
+		http.Error(w, "Failed to stop remote attack: "+err.Error(), code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "stopping"})
+}
+
+// HandlePushConfig sends channel plans and capture options to an agent. The
+// agent applies them asynchronously; GET /api/agents shows its answer.
+func (h *AgentHandler) HandlePushConfig(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "Agent ID required", http.StatusBadRequest)
+		return
+	}
+
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var config domain.AgentConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.Agents.PushConfig(r.Context(), id, config)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, domain.ErrAgentNotConnected) {
+			code = http.StatusNotFound
+		}
+		http.Error(w, "Failed to configure agent: "+err.Error(), code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(status)
+}
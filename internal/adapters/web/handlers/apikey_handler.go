@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// APIKeyHandler manages the API keys of automation clients
+type APIKeyHandler struct {
+	Manager ports.APIKeyManager
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(manager ports.APIKeyManager) *APIKeyHandler {
+	return &APIKeyHandler{
+		Manager: manager,
+	}
+}
+
+// CreateAPIKeyRequest describes a key to issue
+type CreateAPIKeyRequest struct {
+	Name      string             `json:"name"`
+	Scope     domain.APIKeyScope `json:"scope"`
+	ExpiresAt *time.Time         `json:"expires_at,omitempty"`
+}
+
+// HandleList returns the keys of the current user, or every key for admins
+func (h *APIKeyHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.Manager.ListAPIKeys(r.Context())
+	if err != nil {
+		writeError(w, "Failed to list API keys", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": keys,
+	})
+}
+
+// HandleCreate issues a key. The secret is only returned in this response
+func (h *APIKeyHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var req CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	key, secret, err := h.Manager.CreateAPIKey(r.Context(), req.Name, req.Scope, req.ExpiresAt)
+	if err != nil {
+		writeError(w, "Failed to create API key", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"key":    key,
+		"secret": secret,
+	})
+}
+
+// HandleRevoke deletes a key by ID
+func (h *APIKeyHandler) HandleRevoke(w http.ResponseWriter, r *http.Request) {
+	if err := h.Manager.RevokeAPIKey(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, "Failed to revoke API key", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "revoked"})
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/artifacts"
+)
+
+// ArtifactHandler exposes the workspace artifact store
+type ArtifactHandler struct {
+	Manager ports.ArtifactManager
+}
+
+// NewArtifactHandler creates a new ArtifactHandler
+func NewArtifactHandler(manager ports.ArtifactManager) *ArtifactHandler {
+	return &ArtifactHandler{
+		Manager: manager,
+	}
+}
+
+// HandleList returns artifacts, optionally filtered by ?kind= and ?limit=
+func (h *ArtifactHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	filter := domain.ArtifactFilter{Kind: domain.ArtifactKind(r.URL.Query().Get("kind"))}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = n
+	}
+
+	list, err := h.Manager.ListArtifacts(r.Context(), filter)
+	if err != nil {
+		http.Error(w, "Failed to list artifacts: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if list == nil {
+		list = []domain.Artifact{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// HandleGet returns the metadata of an artifact
+func (h *ArtifactHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	artifact, err := h.Manager.GetArtifact(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(artifact)
+}
+
+// HandleDelete removes an artifact
+func (h *ArtifactHandler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := h.Manager.DeleteArtifact(r.Context(), r.PathValue("id")); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleCreateLink returns a time-limited download URL. The optional body
+// {"ttl_seconds": N} sets its lifetime.
+func (h *ArtifactHandler) HandleCreateLink(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var req struct {
+		TTLSeconds int64 `json:"ttl_seconds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	link, err := h.Manager.CreateDownloadLink(r.Context(), r.PathValue("id"), time.Duration(req.TTLSeconds)*time.Second)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(link)
+}
+
+// HandleDownload serves an artifact for a valid download token. It needs no
+// session: the token is the credential, so the CLI can fetch links directly.
+func (h *ArtifactHandler) HandleDownload(w http.ResponseWriter, r *http.Request) {
+	artifact, err := h.Manager.ResolveDownload(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	f, err := h.Manager.OpenArtifact(r.Context(), artifact)
+	if err != nil {
+		http.Error(w, "Artifact file is no longer available", http.StatusGone)
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", artifact.MimeType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", artifact.Name))
+	w.Header().Set("Cache-Control", "no-store")
+	http.ServeContent(w, r, artifact.Name, artifact.CreatedAt, f)
+}
+
+func (h *ArtifactHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, artifacts.ErrArtifactNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, artifacts.ErrInvalidToken), errors.Is(err, artifacts.ErrLinkExpired):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, artifacts.ErrInvalidTTL):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "Artifact request failed: "+err.Error(), http.StatusInternalServerError)
+	}
+}
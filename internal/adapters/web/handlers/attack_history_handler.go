@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// AttackHistoryHandler serves the persisted outcome of finished attacks
+type AttackHistoryHandler struct {
+	Service ports.NetworkService
+}
+
+// NewAttackHistoryHandler creates a new AttackHistoryHandler
+func NewAttackHistoryHandler(service ports.NetworkService) *AttackHistoryHandler {
+	return &AttackHistoryHandler{
+		Service: service,
+	}
+}
+
+// HandleList returns finished attacks with their effectiveness score, newest first
+func (h *AttackHistoryHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	limit := 100
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	records, err := h.Service.GetAttackHistory(r.Context(), limit)
+	if err != nil {
+		http.Error(w, "Failed to load attack history: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
@@ -33,7 +33,7 @@ func (h *AuthFloodHandler) HandleStart(w http.ResponseWriter, r *http.Request) {
 
 	id, err := h.Service.StartAuthFloodAttack(r.Context(), config)
 	if err != nil {
-		http.Error(w, "Failed to start attack: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, "Failed to start attack", err, http.StatusInternalServerError)
 		return
 	}
 
@@ -57,7 +57,7 @@ func (h *AuthFloodHandler) HandleStop(w http.ResponseWriter, r *http.Request) {
 	force := r.URL.Query().Get("force") == "true"
 
 	if err := h.Service.StopAuthFloodAttack(r.Context(), attackID, force); err != nil {
-		http.Error(w, "Failed to stop attack: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, "Failed to stop attack", err, http.StatusInternalServerError)
 		return
 	}
 
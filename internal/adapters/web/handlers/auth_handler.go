@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/adapters/web/middleware"
@@ -32,6 +35,7 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Username string `json:"username"`
 		Password string `json:"password"`
+		OTP      string `json:"otp"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -39,15 +43,50 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.Service.Login(r.Context(), domain.Credentials{
+	ctx := domain.ContextWithClientIP(r.Context(), middleware.ClientIP(r))
+	ctx = domain.ContextWithUserAgent(ctx, r.UserAgent())
+	token, err := h.Service.Login(ctx, domain.Credentials{
 		Username: req.Username,
 		Password: req.Password,
+		OTP:      req.OTP,
 	})
 	if err != nil {
+		if errors.Is(err, domain.ErrTOTPRequired) {
+			writeError(w, "Login incomplete", err, http.StatusUnauthorized)
+			return
+		}
+		var locked *domain.LoginLockedError
+		if errors.As(err, &locked) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(time.Until(locked.Until).Seconds())+1))
+			writeError(w, "Login refused", err, http.StatusTooManyRequests)
+			return
+		}
 		http.Error(w, "Invalid credentials", http.StatusUnauthorized)
 		return
 	}
 
+	setSessionCookie(w, token)
+
+	// Tell the client when the password must be changed or two-factor
+	// authentication enrolled before anything else
+	mustChange, mustEnroll := false, false
+	if user, err := h.Service.ValidateToken(ctx, token); err == nil {
+		mustChange = user.MustChangePassword
+		if tfa, ok := h.Service.(ports.TwoFactorManager); ok {
+			mustEnroll = tfa.EnrollmentRequired(user)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"token":                token,
+		"must_change_password": mustChange,
+		"must_enroll_totp":     mustEnroll,
+	})
+}
+
+// setSessionCookie stores the session token in the browser
+func setSessionCookie(w http.ResponseWriter, token string) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     "auth_token",
 		Value:    token,
@@ -56,13 +95,17 @@ func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
 		Path:     "/",
 		SameSite: http.SameSiteStrictMode,
 	})
-
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"token": token})
 }
 
 // HandleLogout handles user logout
 func (h *AuthHandler) HandleLogout(w http.ResponseWriter, r *http.Request) {
+	// End the session server side too, so a copied token stops working
+	if cookie, err := r.Cookie("auth_token"); err == nil && cookie.Value != "" {
+		h.Service.Logout(r.Context(), cookie.Value)
+	} else if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		h.Service.Logout(r.Context(), token)
+	}
+
 	http.SetCookie(w, &http.Cookie{
 		Name:     "auth_token",
 		Value:    "",
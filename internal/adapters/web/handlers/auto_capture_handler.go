@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/network"
+)
+
+// AutoCaptureHandler runs the supervised handshake capture over the APs in scope
+type AutoCaptureHandler struct {
+	Capturer ports.AutoCapturer
+}
+
+// NewAutoCaptureHandler creates a new AutoCaptureHandler
+func NewAutoCaptureHandler(capturer ports.AutoCapturer) *AutoCaptureHandler {
+	return &AutoCaptureHandler{
+		Capturer: capturer,
+	}
+}
+
+// AutoCaptureRequest tunes a run. Zero values use the defaults
+type AutoCaptureRequest struct {
+	BurstPackets int    `json:"burst_packets"`
+	WaitSeconds  int    `json:"wait_seconds"`
+	MaxTargets   int    `json:"max_targets"`
+	MinRSSI      int    `json:"min_rssi"`
+	Interface    string `json:"interface"`
+}
+
+// HandleStart starts a run in the background
+func (h *AutoCaptureHandler) HandleStart(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var req AutoCaptureRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	err := h.Capturer.StartAutoCapture(r.Context(), domain.AutoCaptureConfig{
+		BurstPackets: req.BurstPackets,
+		Wait:         time.Duration(req.WaitSeconds) * time.Second,
+		MaxTargets:   req.MaxTargets,
+		MinRSSI:      req.MinRSSI,
+		Interface:    req.Interface,
+	})
+	if err != nil {
+		if errors.Is(err, network.ErrAutoCaptureRunning) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeError(w, "Failed to start auto-capture", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(h.Capturer.GetAutoCaptureStatus(r.Context()))
+}
+
+// HandleStop ends the current run
+func (h *AutoCaptureHandler) HandleStop(w http.ResponseWriter, r *http.Request) {
+	if err := h.Capturer.StopAutoCapture(r.Context()); err != nil {
+		if errors.Is(err, network.ErrAutoCaptureIdle) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		writeError(w, "Failed to stop auto-capture", err, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleStatus returns the progress and summary of the current or last run
+func (h *AutoCaptureHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Capturer.GetAutoCaptureStatus(r.Context()))
+}
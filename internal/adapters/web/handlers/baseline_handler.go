@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// BaselineHandler configures new-device monitoring for the current workspace
+type BaselineHandler struct {
+	Manager ports.BaselineManager
+}
+
+// NewBaselineHandler creates a new BaselineHandler
+func NewBaselineHandler(manager ports.BaselineManager) *BaselineHandler {
+	return &BaselineHandler{
+		Manager: manager,
+	}
+}
+
+// HandleGet returns the baseline configuration and whether it is still learning
+func (h *BaselineHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	config, err := h.Manager.GetBaseline(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to load baseline: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.writeConfig(w, config)
+}
+
+// HandleSet replaces the baseline configuration
+func (h *BaselineHandler) HandleSet(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var config domain.BaselineConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	stored, err := h.Manager.SetBaseline(r.Context(), config)
+	if errors.Is(err, domain.ErrInvalidLearningPeriod) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to save baseline: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.writeConfig(w, stored)
+}
+
+func (h *BaselineHandler) writeConfig(w http.ResponseWriter, config domain.BaselineConfig) {
+	resp := map[string]interface{}{
+		"baseline": config,
+		"learning": config.IsLearning(time.Now()),
+	}
+	if config.Enabled {
+		resp["learned_at"] = config.LearnedAt()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// BluetoothHandler exposes the classic Bluetooth devices found by inquiry
+type BluetoothHandler struct {
+	Service ports.BluetoothService
+}
+
+// NewBluetoothHandler creates a new BluetoothHandler
+func NewBluetoothHandler(service ports.BluetoothService) *BluetoothHandler {
+	return &BluetoothHandler{
+		Service: service,
+	}
+}
+
+// HandleList returns the Bluetooth devices with their related Wi-Fi devices
+func (h *BluetoothHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Service.BluetoothDevices(r.Context()))
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// maxCaptureImportSize bounds an uploaded capture file.
+const maxCaptureImportSize = 512 << 20
+
+// CaptureImportHandler imports pcapng captures and hash files recorded by other tools
+type CaptureImportHandler struct {
+	Importer ports.CaptureImporter
+}
+
+// NewCaptureImportHandler creates a new CaptureImportHandler
+func NewCaptureImportHandler(importer ports.CaptureImporter) *CaptureImportHandler {
+	return &CaptureImportHandler{
+		Importer: importer,
+	}
+}
+
+// HandleImport saves the handshakes and PMKIDs of a pcapng or pcap capture, or
+// of a hashcat 22000 hash file, sent as the "file" field of a multipart form
+// or as the raw body with ?name=
+func (h *CaptureImportHandler) HandleImport(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxCaptureImportSize)
+
+	var body io.Reader = r.Body
+	name := r.URL.Query().Get("name")
+	if file, header, err := r.FormFile("file"); err == nil {
+		defer file.Close()
+		body, name = file, header.Filename
+	} else if err != http.ErrNotMultipart {
+		http.Error(w, "Invalid upload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if name == "" {
+		name = "upload.pcapng"
+	}
+
+	importer := h.Importer.ImportPcapng
+	if ext := strings.ToLower(filepath.Ext(name)); ext == ".22000" || ext == ".hc22000" {
+		importer = h.Importer.ImportHashes
+	}
+	result, err := importer(r.Context(), filepath.Base(name), body)
+	if err != nil {
+		http.Error(w, "Import failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// CSAHandler handles standalone Channel Switch Announcement attacks
+type CSAHandler struct {
+	Service ports.NetworkService
+}
+
+// NewCSAHandler creates a new CSAHandler
+func NewCSAHandler(service ports.NetworkService) *CSAHandler {
+	return &CSAHandler{
+		Service: service,
+	}
+}
+
+// HandleStart triggers a new CSA attack
+func (h *CSAHandler) HandleStart(w http.ResponseWriter, r *http.Request) {
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var config domain.CSAAttackConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := config.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, err := h.Service.StartCSAAttack(r.Context(), config)
+	if err != nil {
+		writeError(w, "Failed to start attack", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]string{"id": id, "status": "started"})
+}
+
+// HandleStop stops an ongoing attack
+func (h *CSAHandler) HandleStop(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	attackID := r.URL.Query().Get("id")
+	if attackID == "" {
+		http.Error(w, "attack id is required", http.StatusBadRequest)
+		return
+	}
+
+	force := r.URL.Query().Get("force") == "true"
+
+	if err := h.Service.StopCSAAttack(r.Context(), attackID, force); err != nil {
+		writeError(w, "Failed to stop attack", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "stopped"})
+}
+
+// HandleStatus returns the status of an attack
+func (h *CSAHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "ID required", http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.Service.GetCSAStatus(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Attack not found: "+err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(status)
+}
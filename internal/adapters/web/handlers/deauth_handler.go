@@ -104,7 +104,7 @@ func (h *DeauthHandler) HandleStart(w http.ResponseWriter, r *http.Request) {
 	attackID, err := h.Service.StartDeauthAttack(r.Context(), config)
 	if err != nil {
 		log.Printf("[DEAUTH API] Failed to start attack: %v", err)
-		http.Error(w, "Failed to start attack: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, "Failed to start attack", err, http.StatusInternalServerError)
 		return
 	}
 
@@ -136,7 +136,7 @@ func (h *DeauthHandler) HandleStop(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.Service.StopDeauthAttack(r.Context(), attackID, force); err != nil {
 		log.Printf("[DEAUTH API] Failed to stop attack %s: %v", attackID, err)
-		http.Error(w, "Failed to stop attack: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, "Failed to stop attack", err, http.StatusInternalServerError)
 		return
 	}
 
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// DeauthReasonHandler reports the reason codes of the deauthentication and
+// disassociation frames seen per BSSID, with the distributions typical of
+// attack tools flagged
+type DeauthReasonHandler struct {
+	Analytics ports.DeauthReasonAnalytics
+}
+
+// NewDeauthReasonHandler creates a new DeauthReasonHandler
+func NewDeauthReasonHandler(analytics ports.DeauthReasonAnalytics) *DeauthReasonHandler {
+	return &DeauthReasonHandler{
+		Analytics: analytics,
+	}
+}
+
+// HandleList returns the reason code distribution of every BSSID, or of the
+// one given as ?bssid=
+func (h *DeauthReasonHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"bssids": h.Analytics.DeauthReasonStats(r.Context(), r.URL.Query().Get("bssid")),
+	})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// DeviceHandler manages individual devices
+type DeviceHandler struct {
+	Devices ports.DeviceForgetter
+	Assets  ports.DeviceAssetEditor
+}
+
+// NewDeviceHandler creates a new DeviceHandler
+func NewDeviceHandler(devices ports.DeviceForgetter, assets ports.DeviceAssetEditor) *DeviceHandler {
+	return &DeviceHandler{
+		Devices: devices,
+		Assets:  assets,
+	}
+}
+
+// HandleSetAsset records the owner, asset tag and criticality of a device
+func (h *DeviceHandler) HandleSetAsset(w http.ResponseWriter, r *http.Request) {
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var asset domain.AssetInfo
+	if err := json.NewDecoder(r.Body).Decode(&asset); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	device, err := h.Assets.SetDeviceAsset(r.Context(), r.PathValue("mac"), asset)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrDeviceNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidCriticality):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Failed to set asset: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(device)
+}
+
+// HandleForget removes a device everywhere it is kept and reports from where
+func (h *DeviceHandler) HandleForget(w http.ResponseWriter, r *http.Request) {
+	report, err := h.Devices.ForgetDevice(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		if errors.Is(err, domain.ErrDeviceNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to forget device: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// errorStatus is the HTTP status reported for each domain error code
+var errorStatus = map[domain.ErrorCode]int{
+	domain.CodeInvalidRequest:    http.StatusBadRequest,
+	domain.CodeNotFound:          http.StatusNotFound,
+	domain.CodeConflict:          http.StatusConflict,
+	domain.CodeOutOfScope:        http.StatusForbidden,
+	domain.CodeROERequired:       http.StatusForbidden,
+	domain.CodeTxNotPermitted:    http.StatusForbidden,
+	domain.CodeInterfaceBusy:     http.StatusConflict,
+	domain.CodeLockPreempted:     http.StatusConflict,
+	domain.CodePMFProtected:      http.StatusUnprocessableEntity,
+	domain.CodeToolMissing:       http.StatusServiceUnavailable,
+	domain.CodeAgentNotConnected: http.StatusNotFound,
+	domain.CodeUnsupported:       http.StatusNotImplemented,
+	domain.CodeRateLimited:       http.StatusTooManyRequests,
+	domain.CodePasswordChange:    http.StatusForbidden,
+	domain.CodeTOTPRequired:      http.StatusUnauthorized,
+	domain.CodeTOTPEnrollment:    http.StatusForbidden,
+}
+
+// ErrorResponse is the JSON body of a failed API request
+type ErrorResponse struct {
+	Error string           `json:"error"`
+	Code  domain.ErrorCode `json:"code"`
+}
+
+// writeError reports err with the status and code of its domain error. Errors
+// outside the taxonomy are reported with the fallback status, as invalid
+// requests below 500 and internal errors otherwise
+func writeError(w http.ResponseWriter, message string, err error, fallback int) {
+	code := domain.ErrorCodeOf(err)
+	status, ok := errorStatus[code]
+	if !ok {
+		status = fallback
+		if fallback < http.StatusInternalServerError {
+			code = domain.CodeInvalidRequest
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: message + ": " + err.Error(), Code: code})
+}
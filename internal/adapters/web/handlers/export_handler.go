@@ -38,6 +38,17 @@ func (h *ExportHandler) HandleExport(w http.ResponseWriter, r *http.Request) {
 		dataType = "devices"
 	}
 
+	// Topology renderings of the AP/client graph, for reports and slides
+	if format == "dot" || format == "cytoscape" || format == "cytoscape-json" {
+		graphData, err := h.Service.GetGraph(r.Context())
+		if err != nil {
+			http.Error(w, "Failed to get graph data: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		h.exportGraph(w, graphData, format)
+		return
+	}
+
 	// Handle alerts export
 	if dataType == "alerts" {
 		alerts, err := h.Service.GetAlerts(r.Context())
@@ -94,6 +105,23 @@ func (h *ExportHandler) exportDevices(w http.ResponseWriter, devices []domain.De
 	}
 }
 
+func (h *ExportHandler) exportGraph(w http.ResponseWriter, graph domain.GraphData, format string) {
+	switch format {
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		w.Header().Set("Content-Disposition", "attachment; filename=wmap_graph.dot")
+		if err := export.ExportGraphDOT(w, graph); err != nil {
+			log.Printf("DOT export error: %v", err)
+		}
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", "attachment; filename=wmap_graph.cyjs")
+		if err := export.ExportGraphCytoscape(w, graph); err != nil {
+			log.Printf("Cytoscape export error: %v", err)
+		}
+	}
+}
+
 func (h *ExportHandler) exportAlerts(w http.ResponseWriter, alerts []domain.Alert, format string) {
 	switch format {
 	case "csv":
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// GeofenceHandler manages protected zones for perimeter alerting
+type GeofenceHandler struct {
+	Manager ports.GeofenceManager
+}
+
+// NewGeofenceHandler creates a new GeofenceHandler
+func NewGeofenceHandler(manager ports.GeofenceManager) *GeofenceHandler {
+	return &GeofenceHandler{
+		Manager: manager,
+	}
+}
+
+// HandleList returns all configured zones
+func (h *GeofenceHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"geofences": h.Manager.GetGeofences(r.Context()),
+	})
+}
+
+// HandleCreate registers a new zone
+func (h *GeofenceHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var zone domain.Geofence
+	if err := json.NewDecoder(r.Body).Decode(&zone); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.Manager.AddGeofence(r.Context(), zone)
+	if err != nil {
+		http.Error(w, "Invalid geofence: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// HandleDelete removes a zone by ID
+func (h *GeofenceHandler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "ID required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Manager.RemoveGeofence(r.Context(), id); err != nil {
+		http.Error(w, "Failed to delete geofence: "+err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/web/graphql"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// maxGraphQLList caps the items of each list a query returns, whatever limit
+// it asks for.
+const maxGraphQLList = 500
+
+// GraphQLHandler answers read-only GraphQL queries over devices, alerts,
+// attacks, vulnerabilities and the relationships between devices, so
+// dashboards fetch the fields they need in one request. Object fields are
+// named as in the REST API
+type GraphQLHandler struct {
+	Registry ports.DeviceRegistry
+	Service  ports.NetworkService
+	Vulns    ports.VulnerabilityReader // Optional, vulnerabilities resolve to an error without it
+	schema   *graphql.Schema
+}
+
+// GraphQLRequest is the body of a GraphQL POST request
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler
+func NewGraphQLHandler(registry ports.DeviceRegistry, service ports.NetworkService, vulns ports.VulnerabilityReader) *GraphQLHandler {
+	h := &GraphQLHandler{
+		Registry: registry,
+		Service:  service,
+		Vulns:    vulns,
+	}
+	h.schema = h.buildSchema()
+	return h
+}
+
+// HandleQuery runs a query given in a JSON body or, for GET requests, in the
+// query and variables parameters
+func (h *GraphQLHandler) HandleQuery(w http.ResponseWriter, r *http.Request) {
+	var req GraphQLRequest
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		if raw := r.URL.Query().Get("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				http.Error(w, "Invalid variables", http.StatusBadRequest)
+				return
+			}
+		}
+	} else {
+		r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		http.Error(w, "Missing query", http.StatusBadRequest)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), graphQLCacheKey{}, &graphQLCache{})
+	resp := h.schema.Execute(ctx, req.Query, req.Variables)
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Data == nil {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// graphQLCache holds the alerts and the device graph for the time of a
+// request, so that fields resolved for every device of a list do not
+// rebuild them each time.
+type graphQLCache struct {
+	alertsOnce sync.Once
+	alerts     []domain.Alert
+	alertsErr  error
+
+	graphOnce sync.Once
+	graph     domain.GraphData
+	graphErr  error
+}
+
+type graphQLCacheKey struct{}
+
+// listLimit returns the limit argument, capped to maxGraphQLList, which is
+// also the default.
+func listLimit(args graphql.Args) (int, error) {
+	limit, err := args.Int("limit", maxGraphQLList)
+	if err != nil {
+		return 0, err
+	}
+	if limit <= 0 || limit > maxGraphQLList {
+		limit = maxGraphQLList
+	}
+	return limit, nil
+}
+
+// buildSchema declares the root queries and the relationship fields of
+// devices and edges. Lists hold at most maxGraphQLList items.
+func (h *GraphQLHandler) buildSchema() *graphql.Schema {
+	s := graphql.NewSchema()
+
+	// devices(type, ssid, security, limit): most recently seen first
+	s.Query("devices", func(ctx context.Context, args graphql.Args) (any, error) {
+		limit, err := listLimit(args)
+		if err != nil {
+			return nil, err
+		}
+		devices := []domain.Device{}
+		for _, d := range h.Registry.GetAllDevices(ctx) {
+			if t := args.String("type"); t != "" && string(d.Type) != t {
+				continue
+			}
+			if ssid := args.String("ssid"); ssid != "" && d.SSID != ssid {
+				continue
+			}
+			if sec := args.String("security"); sec != "" && !strings.EqualFold(d.Security, sec) {
+				continue
+			}
+			devices = append(devices, d)
+		}
+		sort.Slice(devices, func(i, j int) bool { return devices[i].LastSeen.After(devices[j].LastSeen) })
+		if len(devices) > limit {
+			devices = devices[:limit]
+		}
+		return devices, nil
+	})
+
+	// device(mac)
+	s.Query("device", func(ctx context.Context, args graphql.Args) (any, error) {
+		return h.device(ctx, args.String("mac")), nil
+	})
+
+	// alerts(severity, device, limit): the most recent ones
+	s.Query("alerts", func(ctx context.Context, args graphql.Args) (any, error) {
+		limit, err := listLimit(args)
+		if err != nil {
+			return nil, err
+		}
+		return h.alerts(ctx, args.String("device"), args.String("severity"), limit)
+	})
+
+	// attacks(limit): finished attacks, newest first
+	s.Query("attacks", func(ctx context.Context, args graphql.Args) (any, error) {
+		limit, err := listLimit(args)
+		if err != nil {
+			return nil, err
+		}
+		return h.Service.GetAttackHistory(ctx, limit)
+	})
+
+	// vulnerabilities(device, status, minSeverity, limit)
+	s.Query("vulnerabilities", func(ctx context.Context, args graphql.Args) (any, error) {
+		minSeverity, err := args.Int("minSeverity", 0)
+		if err != nil {
+			return nil, err
+		}
+		limit, err := listLimit(args)
+		if err != nil {
+			return nil, err
+		}
+		return h.vulnerabilities(args.String("device"), args.String("status"), minSeverity, limit)
+	})
+
+	// relationships(mac, type, limit): edges of the device graph
+	s.Query("relationships", func(ctx context.Context, args graphql.Args) (any, error) {
+		limit, err := listLimit(args)
+		if err != nil {
+			return nil, err
+		}
+		return h.relationships(ctx, args.String("mac"), args.String("type"), limit)
+	})
+
+	s.Field(domain.Device{}, "alerts", func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+		limit, err := listLimit(args)
+		if err != nil {
+			return nil, err
+		}
+		return h.alerts(ctx, parent.(domain.Device).MAC, args.String("severity"), limit)
+	})
+	s.Field(domain.Device{}, "vulnerability_records", func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+		limit, err := listLimit(args)
+		if err != nil {
+			return nil, err
+		}
+		return h.vulnerabilities(parent.(domain.Device).MAC, args.String("status"), 0, limit)
+	})
+	s.Field(domain.Device{}, "relationships", func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+		limit, err := listLimit(args)
+		if err != nil {
+			return nil, err
+		}
+		return h.relationships(ctx, parent.(domain.Device).MAC, args.String("type"), limit)
+	})
+	s.Field(domain.GraphEdge{}, "from_device", func(ctx context.Context, parent any, _ graphql.Args) (any, error) {
+		return h.device(ctx, parent.(domain.GraphEdge).From), nil
+	})
+	s.Field(domain.GraphEdge{}, "to_device", func(ctx context.Context, parent any, _ graphql.Args) (any, error) {
+		return h.device(ctx, parent.(domain.GraphEdge).To), nil
+	})
+	return s
+}
+
+// device returns a device by MAC, in either case, nil for graph nodes that
+// are not devices
+func (h *GraphQLHandler) device(ctx context.Context, mac string) *domain.Device {
+	device, found := h.Registry.GetDevice(ctx, mac)
+	if !found {
+		device, found = h.Registry.GetDevice(ctx, strings.ToLower(mac))
+	}
+	if !found {
+		return nil
+	}
+	return &device
+}
+
+// alerts returns the last limit alerts of a device and severity, all when
+// empty
+func (h *GraphQLHandler) alerts(ctx context.Context, mac, severity string, limit int) ([]domain.Alert, error) {
+	all, err := h.allAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	alerts := []domain.Alert{}
+	for _, a := range all {
+		if mac != "" && !strings.EqualFold(a.DeviceMAC, mac) && !strings.EqualFold(a.TargetMAC, mac) {
+			continue
+		}
+		if severity != "" && !strings.EqualFold(string(a.Severity), severity) {
+			continue
+		}
+		alerts = append(alerts, a)
+	}
+	if len(alerts) > limit {
+		alerts = alerts[len(alerts)-limit:]
+	}
+	return alerts, nil
+}
+
+// allAlerts returns the alerts, read once per request
+func (h *GraphQLHandler) allAlerts(ctx context.Context) ([]domain.Alert, error) {
+	cache, ok := ctx.Value(graphQLCacheKey{}).(*graphQLCache)
+	if !ok {
+		return h.Service.GetAlerts(ctx)
+	}
+	cache.alertsOnce.Do(func() { cache.alerts, cache.alertsErr = h.Service.GetAlerts(ctx) })
+	return cache.alerts, cache.alertsErr
+}
+
+// vulnerabilities returns the first limit stored findings of a device, all
+// when empty
+func (h *GraphQLHandler) vulnerabilities(mac, status string, minSeverity, limit int) ([]domain.VulnerabilityRecord, error) {
+	if h.Vulns == nil {
+		return nil, errors.New("vulnerability store not available")
+	}
+	filter := domain.VulnerabilityFilter{DeviceMAC: mac, MinSeverity: minSeverity}
+	if status != "" {
+		s := domain.VulnerabilityStatus(status)
+		filter.Status = &s
+	}
+	vulns, err := h.Vulns.GetVulnerabilities(filter)
+	if len(vulns) > limit {
+		vulns = vulns[:limit]
+	}
+	return vulns, err
+}
+
+// relationships returns the first limit edges touching a device and of a
+// type, all when empty
+func (h *GraphQLHandler) relationships(ctx context.Context, mac, edgeType string, limit int) ([]domain.GraphEdge, error) {
+	graph, err := h.graph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	edges := []domain.GraphEdge{}
+	for _, e := range graph.Edges {
+		if mac != "" && !strings.EqualFold(e.From, mac) && !strings.EqualFold(e.To, mac) {
+			continue
+		}
+		if edgeType != "" && string(e.Type) != edgeType {
+			continue
+		}
+		edges = append(edges, e)
+		if len(edges) == limit {
+			break
+		}
+	}
+	return edges, nil
+}
+
+// graph returns the device graph, built once per request
+func (h *GraphQLHandler) graph(ctx context.Context) (domain.GraphData, error) {
+	cache, ok := ctx.Value(graphQLCacheKey{}).(*graphQLCache)
+	if !ok {
+		return h.Service.GetGraph(ctx)
+	}
+	cache.graphOnce.Do(func() { cache.graph, cache.graphErr = h.Service.GetGraph(ctx) })
+	return cache.graph, cache.graphErr
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// HookHandler manages the scripting hooks of the current workspace
+type HookHandler struct {
+	Manager ports.HookManager
+}
+
+// NewHookHandler creates a new HookHandler
+func NewHookHandler(manager ports.HookManager) *HookHandler {
+	return &HookHandler{
+		Manager: manager,
+	}
+}
+
+// HandleList returns the hooks of the workspace
+func (h *HookHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	hooks, err := h.Manager.ListHooks(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to load hooks: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"hooks": hooks,
+	})
+}
+
+// HandleCreate stores a new hook
+func (h *HookHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	hook, ok := decodeHook(w, r)
+	if !ok {
+		return
+	}
+
+	created, err := h.Manager.CreateHook(r.Context(), hook)
+	if err != nil {
+		http.Error(w, "Failed to create hook: "+err.Error(), hookErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// HandleUpdate replaces a hook
+func (h *HookHandler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
+	hook, ok := decodeHook(w, r)
+	if !ok {
+		return
+	}
+
+	updated, err := h.Manager.UpdateHook(r.Context(), r.PathValue("id"), hook)
+	if err != nil {
+		http.Error(w, "Failed to update hook: "+err.Error(), hookErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// HandleDelete removes a hook by ID
+func (h *HookHandler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := h.Manager.DeleteHook(r.Context(), r.PathValue("id")); err != nil {
+		http.Error(w, "Failed to delete hook: "+err.Error(), hookErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+func decodeHook(w http.ResponseWriter, r *http.Request) (domain.ScriptHook, bool) {
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var hook domain.ScriptHook
+	if err := json.NewDecoder(r.Body).Decode(&hook); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return domain.ScriptHook{}, false
+	}
+	return hook, true
+}
+
+// hookErrorStatus maps hook errors to HTTP status codes.
+func hookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrHookNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidHookName),
+		errors.Is(err, domain.ErrEmptyHookCondition),
+		errors.Is(err, domain.ErrEmptyHookActions),
+		errors.Is(err, domain.ErrInvalidHookAction),
+		errors.Is(err, domain.ErrInvalidHookSetField),
+		errors.Is(err, domain.ErrEmptyHookActionValue),
+		errors.Is(err, domain.ErrInvalidHookScript),
+		errors.Is(err, domain.ErrInvalidSeverity):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// maxIngestSize bounds an imported file; Kismet device dumps are large.
+const maxIngestSize = 64 << 20
+
+// IngestHandler imports device observations from other tools
+type IngestHandler struct {
+	Ingester ports.DeviceIngester
+}
+
+// NewIngestHandler creates a new IngestHandler
+func NewIngestHandler(ingester ports.DeviceIngester) *IngestHandler {
+	return &IngestHandler{
+		Ingester: ingester,
+	}
+}
+
+// HandleIngest merges the device observations in the request body. The
+// format (wmap, kismet or airodump) is detected unless given in ?format=,
+// and ?source= names the producing tool.
+func (h *IngestHandler) HandleIngest(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxIngestSize)
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	format := domain.IngestFormat(r.URL.Query().Get("format"))
+	result, err := h.Ingester.Ingest(r.Context(), format, r.URL.Query().Get("source"), data)
+	if err != nil {
+		// Observations are merged one by one, so errors come from the data
+		http.Error(w, "Ingestion failed: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// InterfaceHandler adds and removes capture interfaces without restarting
+type InterfaceHandler struct {
+	Interfaces ports.CaptureInterfaceManager
+}
+
+// NewInterfaceHandler creates a new InterfaceHandler
+func NewInterfaceHandler(interfaces ports.CaptureInterfaceManager) *InterfaceHandler {
+	return &InterfaceHandler{
+		Interfaces: interfaces,
+	}
+}
+
+// HandleAdd puts an interface in monitor mode and starts capturing on it
+// Path: /api/interfaces/{iface}/capture
+func (h *InterfaceHandler) HandleAdd(w http.ResponseWriter, r *http.Request) {
+	name, err := h.Interfaces.AddCaptureInterface(r.Context(), r.PathValue("iface"))
+	if err != nil {
+		writeError(w, "Failed to add interface", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{"interface": name})
+}
+
+// HandleRemove stops capturing on an interface and gives it back to the system
+// Path: /api/interfaces/{iface}/capture
+func (h *InterfaceHandler) HandleRemove(w http.ResponseWriter, r *http.Request) {
+	if err := h.Interfaces.RemoveCaptureInterface(r.Context(), r.PathValue("iface")); err != nil {
+		writeError(w, "Failed to remove interface", err, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// InventoryHandler exposes the synchronization with the asset inventory
+type InventoryHandler struct {
+	Inventory ports.InventoryManager
+}
+
+// NewInventoryHandler creates a new InventoryHandler
+func NewInventoryHandler(inventory ports.InventoryManager) *InventoryHandler {
+	return &InventoryHandler{
+		Inventory: inventory,
+	}
+}
+
+// HandleStatus returns the state of the last synchronization
+func (h *InventoryHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Inventory.Status())
+}
+
+// HandleSync synchronizes the inventory now
+func (h *InventoryHandler) HandleSync(w http.ResponseWriter, r *http.Request) {
+	status, err := h.Inventory.Sync(r.Context())
+	if err != nil {
+		http.Error(w, "Inventory synchronization failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
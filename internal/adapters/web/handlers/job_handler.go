@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/jobs"
+)
+
+// JobHandler exposes the background job queue
+type JobHandler struct {
+	Manager ports.JobManager
+}
+
+// NewJobHandler creates a new JobHandler
+func NewJobHandler(manager ports.JobManager) *JobHandler {
+	return &JobHandler{
+		Manager: manager,
+	}
+}
+
+type enqueueJobRequest struct {
+	Kind    string          `json:"kind"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// HandleList returns jobs, optionally filtered by ?status=a,b, ?kind= and ?limit=
+func (h *JobHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.JobFilter{Kind: query.Get("kind")}
+	if statuses := query.Get("status"); statuses != "" {
+		for _, status := range strings.Split(statuses, ",") {
+			filter.Statuses = append(filter.Statuses, domain.JobStatus(strings.TrimSpace(status)))
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = n
+	}
+
+	list, err := h.Manager.ListJobs(r.Context(), filter)
+	if err != nil {
+		http.Error(w, "Failed to list jobs: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if list == nil {
+		list = []domain.Job{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// HandleGet returns a single job
+func (h *JobHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	job, err := h.Manager.GetJob(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// HandleEnqueue queues a new job
+func (h *JobHandler) HandleEnqueue(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var req enqueueJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Kind == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.Manager.EnqueueJob(r.Context(), req.Kind, string(req.Payload))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+// HandleCancel stops a queued or running job
+func (h *JobHandler) HandleCancel(w http.ResponseWriter, r *http.Request) {
+	if err := h.Manager.CancelJob(r.Context(), r.PathValue("id")); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *JobHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, jobs.ErrJobNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, jobs.ErrJobFinished):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, jobs.ErrUnknownKind):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, jobs.ErrQueueFull), errors.Is(err, jobs.ErrQueueStopped):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		http.Error(w, "Job request failed: "+err.Error(), http.StatusInternalServerError)
+	}
+}
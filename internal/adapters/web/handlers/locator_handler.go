@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// LocatorHandler handles the "hot/cold" device locator mode
+type LocatorHandler struct {
+	Service ports.NetworkService
+}
+
+// NewLocatorHandler creates a new LocatorHandler
+func NewLocatorHandler(service ports.NetworkService) *LocatorHandler {
+	return &LocatorHandler{
+		Service: service,
+	}
+}
+
+// HandleStart locks the radio on the target's channel and starts streaming readings
+func (h *LocatorHandler) HandleStart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var req struct {
+		TargetMAC      string  `json:"target_mac"`
+		Interface      string  `json:"interface"`
+		Channel        int     `json:"channel"`
+		Smoothing      float64 `json:"smoothing"`
+		MaxDurationSec int     `json:"max_duration_sec"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	config := domain.LocatorConfig{
+		TargetMAC:   req.TargetMAC,
+		Interface:   req.Interface,
+		Channel:     req.Channel,
+		Smoothing:   req.Smoothing,
+		MaxDuration: time.Duration(req.MaxDurationSec) * time.Second,
+	}
+	if err := config.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.Service.StartLocator(r.Context(), config)
+	if err != nil {
+		log.Printf("[LOCATOR API] Failed to start: %v", err)
+		writeError(w, "Failed to start locator", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
+
+// HandleStop ends the locator session and releases the channel lock
+func (h *LocatorHandler) HandleStop(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.Service.StopLocator(r.Context()); err != nil {
+		http.Error(w, "Failed to stop locator: "+err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "stopped"})
+}
+
+// HandleStatus returns the current locator session
+func (h *LocatorHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := h.Service.GetLocatorStatus(r.Context())
+	if err != nil {
+		http.Error(w, "Locator not active", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(session)
+}
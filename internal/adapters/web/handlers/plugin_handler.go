@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// PluginHandler exposes the external analyzers
+type PluginHandler struct {
+	Manager ports.PluginManager
+}
+
+// NewPluginHandler creates a new PluginHandler
+func NewPluginHandler(manager ports.PluginManager) *PluginHandler {
+	return &PluginHandler{
+		Manager: manager,
+	}
+}
+
+// HandleList returns the state of every analyzer
+func (h *PluginHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"plugins": h.Manager.ListPlugins(r.Context()),
+	})
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// PortalHandler runs captive portal checks of open networks
+type PortalHandler struct {
+	Service ports.PortalCheckService
+}
+
+// NewPortalHandler creates a new PortalHandler
+func NewPortalHandler(service ports.PortalCheckService) *PortalHandler {
+	return &PortalHandler{
+		Service: service,
+	}
+}
+
+// HandleCheck associates with an open AP and reports what a client gets
+func (h *PortalHandler) HandleCheck(w http.ResponseWriter, r *http.Request) {
+	check, err := h.Service.CheckCaptivePortal(r.Context(), r.PathValue("mac"))
+	if err != nil {
+		writeError(w, "Captive portal check failed", err, http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(check)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// PowerHandler manages the power save mode of battery powered sensors
+type PowerHandler struct {
+	Power ports.PowerManager
+}
+
+// NewPowerHandler creates a new PowerHandler
+func NewPowerHandler(power ports.PowerManager) *PowerHandler {
+	return &PowerHandler{
+		Power: power,
+	}
+}
+
+// HandleGet returns the power save settings, radio state and battery charge
+func (h *PowerHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Power.PowerStatus())
+}
+
+// HandleSet enables, disables or changes the power save duty cycle
+func (h *PowerHandler) HandleSet(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+	var config domain.PowerSaveConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.Power.SetPowerSave(r.Context(), config)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidDutyCycle) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to set power save: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
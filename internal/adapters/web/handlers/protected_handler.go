@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// ProtectedBSSIDHandler manages the APs defended against deauthentication and
+// channel switch attacks
+type ProtectedBSSIDHandler struct {
+	Manager ports.ProtectedBSSIDManager
+}
+
+// NewProtectedBSSIDHandler creates a new ProtectedBSSIDHandler
+func NewProtectedBSSIDHandler(manager ports.ProtectedBSSIDManager) *ProtectedBSSIDHandler {
+	return &ProtectedBSSIDHandler{
+		Manager: manager,
+	}
+}
+
+// HandleList returns all protected BSSIDs
+func (h *ProtectedBSSIDHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"protected": h.Manager.GetProtectedBSSIDs(r.Context()),
+	})
+}
+
+// HandleCreate protects a BSSID
+func (h *ProtectedBSSIDHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var protected domain.ProtectedBSSID
+	if err := json.NewDecoder(r.Body).Decode(&protected); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.Manager.AddProtectedBSSID(r.Context(), protected)
+	if err != nil {
+		http.Error(w, "Invalid protected BSSID: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// HandleDelete stops protecting a BSSID
+func (h *ProtectedBSSIDHandler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	bssid := r.PathValue("bssid")
+	if bssid == "" {
+		http.Error(w, "BSSID required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Manager.RemoveProtectedBSSID(r.Context(), bssid); err != nil {
+		http.Error(w, "Failed to remove protected BSSID: "+err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/security"
+)
+
+// PSKAuditHandler runs the weak-PSK audit over captured handshakes
+type PSKAuditHandler struct {
+	Auditor ports.PSKAuditor
+}
+
+// NewPSKAuditHandler creates a new PSKAuditHandler
+func NewPSKAuditHandler(auditor ports.PSKAuditor) *PSKAuditHandler {
+	return &PSKAuditHandler{
+		Auditor: auditor,
+	}
+}
+
+// HandleStart starts an audit in the background
+func (h *PSKAuditHandler) HandleStart(w http.ResponseWriter, r *http.Request) {
+	if err := h.Auditor.StartPSKAudit(r.Context()); err != nil {
+		if errors.Is(err, security.ErrPSKAuditRunning) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, "Failed to start PSK audit: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(h.Auditor.GetPSKAuditStatus(r.Context()))
+}
+
+// HandleStatus returns the progress of the current or last audit
+func (h *PSKAuditHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Auditor.GetPSKAuditStatus(r.Context()))
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// RecentCaptureHandler saves the frames the sensor captured before the
+// request, so an event seen on the map can be kept after it happened
+type RecentCaptureHandler struct {
+	Saver ports.RecentFrameSaver
+}
+
+// NewRecentCaptureHandler creates a new RecentCaptureHandler
+func NewRecentCaptureHandler(saver ports.RecentFrameSaver) *RecentCaptureHandler {
+	return &RecentCaptureHandler{
+		Saver: saver,
+	}
+}
+
+// HandleSave stores the last {"seconds": N} of frames, 30 by default, of
+// {"interface": name} or every adapter, and returns the pcap artifact
+func (h *RecentCaptureHandler) HandleSave(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var req domain.RecentCaptureRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	if err := req.Validate(); err != nil {
+		writeError(w, "Invalid capture window", err, http.StatusBadRequest)
+		return
+	}
+
+	artifact, err := h.Saver.SaveRecentFrames(r.Context(), req.Interface, req.Window())
+	if err != nil {
+		writeError(w, "Failed to save recent frames", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(artifact)
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// ReloadHandler reloads signatures and alert rules without a restart
+type ReloadHandler struct {
+	Reloader ports.DataReloader
+}
+
+// NewReloadHandler creates a new ReloadHandler
+func NewReloadHandler(reloader ports.DataReloader) *ReloadHandler {
+	return &ReloadHandler{
+		Reloader: reloader,
+	}
+}
+
+// HandleReload reloads every data file and reports the outcome of each
+func (h *ReloadHandler) HandleReload(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"results": h.Reloader.Reload(r.Context()),
+	})
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"log"
 	"net/http"
 	"sort"
 	"time"
@@ -25,6 +27,10 @@ type ReportHandler struct {
 	// New Phase 2 fields
 	ExecutiveGenerator *reportingService.ExecutiveReportGenerator
 	PDFExporter        *reporting.PDFExporter
+	// ActionsGenerator compiles the attacks launched. Optional.
+	ActionsGenerator *reportingService.ActionsReportGenerator
+	// Artifacts keeps a copy of every generated report. Optional.
+	Artifacts ports.ArtifactManager
 }
 
 // NewReportHandler creates a new ReportHandler
@@ -103,18 +109,24 @@ func (h *ReportHandler) HandleGenerateReport(w http.ResponseWriter, r *http.Requ
 		stats.SecurityBreakdown[sec]++
 
 		// Channel Stats
-		if node.Channel > 0 {
-			stats.ChannelUsage[node.Channel]++
+		for _, ch := range domain.OccupiedChannels(node.Channel, node.ChannelWidth, node.CenterChannel) {
+			stats.ChannelUsage[ch]++
 		}
 
 		// Convert GraphNode -> Device (Simplified)
+		var link *domain.LinkStats
+		if node.LinkQuality != "" {
+			link = &domain.LinkStats{RetryRate: node.RetryRate, Quality: node.LinkQuality}
+		}
 		devices = append(devices, domain.Device{
-			MAC:      node.MAC,
-			Type:     domain.DeviceType(node.Group),
-			Vendor:   node.Vendor,
-			SSID:     node.Label, // Graph uses Label for SSID usually, or ID
-			RSSI:     node.RSSI,
-			Security: node.Security,
+			MAC:         node.MAC,
+			Type:        domain.DeviceType(node.Group),
+			Vendor:      node.Vendor,
+			SSID:        node.Label, // Graph uses Label for SSID usually, or ID
+			RSSI:        node.RSSI,
+			Security:    node.Security,
+			ClientCount: node.ClientCount,
+			Link:        link,
 		})
 	}
 
@@ -158,15 +170,32 @@ func (h *ReportHandler) HandleGenerateReport(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// 6. Serve Response
+	// 6. Render and Serve Response
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
 	filename := fmt.Sprintf("wmap_report_%s.html", time.Now().Format("20060102_150405"))
+	h.storeArtifact(w, r, filename, buf.Bytes())
 	w.Header().Set("Content-Type", "text/html")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+	w.Write(buf.Bytes())
+}
 
-	if err := tmpl.Execute(w, data); err != nil {
-		// If we already wrote header, this might be messy, but acceptable for now
+// storeArtifact keeps a copy of a generated report and returns its ID in the
+// X-Artifact-ID header. Failures are logged; the report is still served.
+func (h *ReportHandler) storeArtifact(w http.ResponseWriter, r *http.Request, filename string, data []byte) {
+	if h.Artifacts == nil {
 		return
 	}
+	artifact, err := h.Artifacts.StoreArtifact(r.Context(), domain.ArtifactReport, filename, data)
+	if err != nil {
+		log.Printf("Warning: could not store report %s: %v", filename, err)
+		return
+	}
+	w.Header().Set("X-Artifact-ID", artifact.ID)
 }
 
 // ============================================================================
@@ -250,6 +279,7 @@ func (h *ReportHandler) HandleGenerateExecutiveSummary(w http.ResponseWriter, r
 			filename = fmt.Sprintf("wmap-executive-summary-%s.pdf", req.OrgName)
 		}
 
+		h.storeArtifact(w, r, filename, data)
 		w.Header().Set("Content-Type", "application/pdf")
 		w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 		w.Write(data)
@@ -262,3 +292,101 @@ func (h *ReportHandler) HandleGenerateExecutiveSummary(w http.ResponseWriter, r
 		http.Error(w, "Unsupported format: "+req.Format, http.StatusBadRequest)
 	}
 }
+
+// HandleGenerateActionsReport generates the actions taken report: every attack
+// launched in the period, who launched it, its target, time window and result.
+func (h *ReportHandler) HandleGenerateActionsReport(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		StartDate string `json:"start_date"` // YYYY-MM-DD format
+		EndDate   string `json:"end_date"`   // YYYY-MM-DD format, inclusive
+		OrgName   string `json:"org_name"`
+		Format    string `json:"format"` // pdf, markdown, csv, json
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Without dates the report covers the whole engagement
+	var dateRange domain.DateRange
+	if req.StartDate != "" {
+		start, err := time.Parse("2006-01-02", req.StartDate)
+		if err != nil {
+			http.Error(w, "Invalid start_date format (use YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		dateRange.Start = start
+	}
+	if req.EndDate != "" {
+		end, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			http.Error(w, "Invalid end_date format (use YYYY-MM-DD)", http.StatusBadRequest)
+			return
+		}
+		dateRange.End = end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	if req.Format == "" {
+		req.Format = "pdf"
+	}
+
+	if h.ActionsGenerator == nil {
+		http.Error(w, "Actions report generator not initialized", http.StatusInternalServerError)
+		return
+	}
+
+	username := "Unknown"
+	if user, ok := r.Context().Value(middleware.UserContextKey).(*domain.User); ok && user != nil {
+		username = user.Username
+	}
+	workspaceName := ""
+	if h.WorkspaceManager != nil {
+		workspaceName = h.WorkspaceManager.GetCurrentWorkspace()
+	}
+
+	report, err := h.ActionsGenerator.Generate(r.Context(), dateRange, req.OrgName, workspaceName, username)
+	if err != nil {
+		http.Error(w, "Failed to generate report: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var data []byte
+	var contentType, ext string
+	switch req.Format {
+	case "pdf":
+		if h.PDFExporter == nil {
+			http.Error(w, "PDF exporter not initialized", http.StatusInternalServerError)
+			return
+		}
+		data, err = h.PDFExporter.ExportActionsReport(report)
+		contentType, ext = "application/pdf", "pdf"
+	case "markdown":
+		data = reporting.ExportActionsMarkdown(report)
+		contentType, ext = "text/markdown; charset=utf-8", "md"
+	case "csv":
+		data, err = reporting.ExportActionsCSV(report)
+		contentType, ext = "text/csv", "csv"
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(report)
+		return
+	default:
+		http.Error(w, "Unsupported format: "+req.Format, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to export report: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	filename := "wmap-actions-taken." + ext
+	if req.OrgName != "" {
+		filename = fmt.Sprintf("wmap-actions-taken-%s.%s", req.OrgName, ext)
+	}
+
+	h.storeArtifact(w, r, filename, data)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Write(data)
+}
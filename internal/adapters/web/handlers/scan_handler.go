@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
 )
 
@@ -60,10 +63,22 @@ func (h *ScanHandler) HandleChannels(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
+		resp := map[string]interface{}{
 			"channels": channels,
-		})
+		}
+		if iface != "" {
+			// Annotate with regulatory flags (DFS, no-IR, disabled)
+			if regulatory, err := h.Service.GetChannelRegulatory(ctx, iface); err == nil {
+				resp["regulatory"] = regulatory
+			}
+			// Current auto-tuned dwell per channel
+			if plan, err := h.Service.GetDwellPlan(ctx, iface); err == nil {
+				resp["dwell_plan"] = plan
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
 	case http.MethodPost:
 		// Update channel list
 		var req struct {
@@ -114,6 +129,140 @@ func (h *ScanHandler) HandleListInterfaces(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+// HandleChannelLock pins an interface to a channel (POST) or releases the lock (DELETE)
+// Path: /api/interfaces/{iface}/lock
+// Body (POST): {"channel": 6, "duration_seconds": 60}
+func (h *ScanHandler) HandleChannelLock(w http.ResponseWriter, r *http.Request) {
+	iface := r.PathValue("iface")
+	if !domain.IsValidInterface(iface) {
+		http.Error(w, "Invalid interface", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		var req struct {
+			Channel         int `json:"channel"`
+			DurationSeconds int `json:"duration_seconds"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Channel < 1 || req.Channel > 165 {
+			http.Error(w, "Invalid channel", http.StatusBadRequest)
+			return
+		}
+		duration := time.Duration(req.DurationSeconds) * time.Second
+		if duration <= 0 || duration > domain.MaxManualLockDuration {
+			http.Error(w, "duration_seconds must be between 1 and "+strconv.Itoa(int(domain.MaxManualLockDuration.Seconds())), http.StatusBadRequest)
+			return
+		}
+
+		state, err := h.Service.LockInterfaceChannel(r.Context(), iface, req.Channel, duration)
+		if err != nil {
+			http.Error(w, "Failed to lock channel: "+err.Error(), http.StatusConflict)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(state)
+	case http.MethodDelete:
+		if err := h.Service.UnlockInterfaceChannel(r.Context(), iface); err != nil {
+			http.Error(w, "Failed to unlock channel: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"unlocked"}`))
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleDiagnostics runs monitor-mode preflight checks
+// Query Params: interface (repeatable, defaults to all configured interfaces)
+func (h *ScanHandler) HandleDiagnostics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ifaces := r.URL.Query()["interface"]
+	for _, iface := range ifaces {
+		if !domain.IsValidInterface(iface) {
+			http.Error(w, "Invalid interface: "+iface, http.StatusBadRequest)
+			return
+		}
+	}
+
+	diagnostics, err := h.Service.DiagnoseInterfaces(r.Context(), ifaces)
+	if err != nil {
+		http.Error(w, "Diagnostics failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"interfaces": diagnostics,
+	})
+}
+
+// HandleInjectionTest verifies that frames can be injected on an interface
+// Path: /api/interfaces/{iface}/injection-test
+// Query Params: channel, probes, timeout (Go duration)
+func (h *ScanHandler) HandleInjectionTest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	iface := r.PathValue("iface")
+	if !domain.IsValidInterface(iface) {
+		http.Error(w, "Invalid interface", http.StatusBadRequest)
+		return
+	}
+
+	var config domain.InjectionTestConfig
+	q := r.URL.Query()
+	if v := q.Get("channel"); v != "" {
+		ch, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Invalid channel", http.StatusBadRequest)
+			return
+		}
+		config.Channel = ch
+	}
+	if v := q.Get("probes"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Invalid probes", http.StatusBadRequest)
+			return
+		}
+		config.Probes = n
+	}
+	if v := q.Get("timeout"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			http.Error(w, "Invalid timeout", http.StatusBadRequest)
+			return
+		}
+		config.Timeout = d
+	}
+	if err := config.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.Service.RunInjectionTest(r.Context(), iface, config)
+	if err != nil {
+		writeError(w, "Injection test failed", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
 // HandleGetStats returns system intelligence stats
 func (h *ScanHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -130,3 +279,45 @@ func (h *ScanHandler) HandleGetStats(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(stats)
 }
+
+// HandleGetHeatmap returns geo-binned signal strength per SSID
+// Query Params: cell_size (meters), ssid, since (RFC3339)
+func (h *ScanHandler) HandleGetHeatmap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := domain.HeatmapQuery{
+		SSID: r.URL.Query().Get("ssid"),
+	}
+	if v := r.URL.Query().Get("cell_size"); v != "" {
+		size, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			http.Error(w, "Invalid cell_size", http.StatusBadRequest)
+			return
+		}
+		query.CellSizeMeters = size
+	}
+	if v := r.URL.Query().Get("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, "Invalid since (expected RFC3339)", http.StatusBadRequest)
+			return
+		}
+		query.Since = since
+	}
+	if err := query.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	heatmap, err := h.Service.GetHeatmap(r.Context(), query)
+	if err != nil {
+		http.Error(w, "Failed to build heatmap: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(heatmap)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// ScheduleHandler manages the monitoring windows of the sensor
+type ScheduleHandler struct {
+	Scheduler ports.MonitoringScheduler
+}
+
+// NewScheduleHandler creates a new ScheduleHandler
+func NewScheduleHandler(scheduler ports.MonitoringScheduler) *ScheduleHandler {
+	return &ScheduleHandler{
+		Scheduler: scheduler,
+	}
+}
+
+// HandleGet returns the schedule and whether capture is running
+func (h *ScheduleHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Scheduler.Status())
+}
+
+// HandleSet replaces the schedule, pausing or resuming capture right away
+func (h *ScheduleHandler) HandleSet(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+	var schedule domain.MonitoringSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.Scheduler.SetSchedule(r.Context(), schedule)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSchedule) || errors.Is(err, domain.ErrEmptySchedule) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to save schedule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
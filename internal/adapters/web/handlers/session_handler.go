@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// SessionHandler lists and revokes login sessions
+type SessionHandler struct {
+	Manager ports.SessionManager
+}
+
+// NewSessionHandler creates a new SessionHandler
+func NewSessionHandler(manager ports.SessionManager) *SessionHandler {
+	return &SessionHandler{
+		Manager: manager,
+	}
+}
+
+// HandleList returns the active sessions of the user in the path, or of the
+// current user
+func (h *SessionHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.Manager.ListSessions(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, "Failed to list sessions", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"sessions": sessions,
+	})
+}
+
+// HandleRevoke ends one session
+func (h *SessionHandler) HandleRevoke(w http.ResponseWriter, r *http.Request) {
+	if err := h.Manager.RevokeSession(r.Context(), r.PathValue("session")); err != nil {
+		writeError(w, "Failed to revoke session", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "revoked"})
+}
+
+// HandleRevokeAll ends every session of the user in the path, or of the
+// current user, including the one making the request
+func (h *SessionHandler) HandleRevokeAll(w http.ResponseWriter, r *http.Request) {
+	n, err := h.Manager.RevokeUserSessions(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, "Failed to revoke sessions", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"revoked": n})
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/workspace"
+)
+
+// defaultShareTTL is how long a share link lasts when no TTL is requested
+const defaultShareTTL = 7 * 24 * time.Hour
+
+// ShareHandler issues read-only workspace links and serves the views they
+// grant: devices, alerts and the report summary
+type ShareHandler struct {
+	Shares           ports.ShareLinkManager
+	Service          ports.NetworkService
+	WorkspaceManager *workspace.WorkspaceManager
+}
+
+// NewShareHandler creates a new ShareHandler
+func NewShareHandler(shares ports.ShareLinkManager, service ports.NetworkService, workspaceManager *workspace.WorkspaceManager) *ShareHandler {
+	return &ShareHandler{
+		Shares:           shares,
+		Service:          service,
+		WorkspaceManager: workspaceManager,
+	}
+}
+
+// CreateShareRequest describes a link to issue. The TTL defaults to a week
+type CreateShareRequest struct {
+	Label      string `json:"label"`
+	TTLSeconds int64  `json:"ttl_seconds"`
+}
+
+// HandleCreate issues a link to a workspace. The token is only returned in
+// this response
+func (h *ShareHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var req CreateShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	ttl := time.Duration(req.TTLSeconds) * time.Second
+	if ttl == 0 {
+		ttl = defaultShareTTL
+	}
+
+	name := r.PathValue("id")
+	workspaces, err := h.WorkspaceManager.ListWorkspaces()
+	if err != nil {
+		writeError(w, "Failed to list workspaces", err, http.StatusInternalServerError)
+		return
+	}
+	if !slices.Contains(workspaces, name) {
+		http.Error(w, workspace.ErrWorkspaceNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	link, token, err := h.Shares.CreateShareLink(r.Context(), name, req.Label, time.Now().Add(ttl))
+	if err != nil {
+		writeError(w, "Failed to create share link", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"link":  link,
+		"token": token,
+		"url":   "/share.html?token=" + token,
+	})
+}
+
+// HandleList returns the links issued for a workspace
+func (h *ShareHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	links, err := h.Shares.ListShareLinks(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, "Failed to list share links", err, http.StatusInternalServerError)
+		return
+	}
+	if links == nil {
+		links = []domain.ShareLink{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"links": links,
+	})
+}
+
+// HandleRevoke deletes a link by ID
+func (h *ShareHandler) HandleRevoke(w http.ResponseWriter, r *http.Request) {
+	if err := h.Shares.RevokeShareLink(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, "Failed to revoke share link", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "revoked"})
+}
+
+// HandleDevices returns the devices of the shared workspace. It needs no
+// session: the token is the credential
+func (h *ShareHandler) HandleDevices(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.resolve(w, r)
+	if !ok {
+		return
+	}
+	devices, err := h.WorkspaceManager.Devices(r.Context(), link.Workspace)
+	if err != nil {
+		http.Error(w, "Failed to load devices", http.StatusInternalServerError)
+		return
+	}
+	if devices == nil {
+		devices = []domain.Device{}
+	}
+	writeShared(w, map[string]interface{}{"devices": devices})
+}
+
+// HandleAlerts returns the alerts raised in the shared workspace, none
+// unless it is active or open
+func (h *ShareHandler) HandleAlerts(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.resolve(w, r)
+	if !ok {
+		return
+	}
+	writeShared(w, map[string]interface{}{"alerts": h.alerts(r, link)})
+}
+
+// HandleReport returns the report summary of the shared workspace
+func (h *ShareHandler) HandleReport(w http.ResponseWriter, r *http.Request) {
+	link, ok := h.resolve(w, r)
+	if !ok {
+		return
+	}
+	devices, err := h.WorkspaceManager.Devices(r.Context(), link.Workspace)
+	if err != nil {
+		http.Error(w, "Failed to load devices", http.StatusInternalServerError)
+		return
+	}
+	writeShared(w, domain.NewShareSummary(*link, devices, h.alerts(r, link)))
+}
+
+// resolve returns the link granted by the token of the request, or reports
+// it invalid. Unknown and expired tokens are told apart only in the message
+func (h *ShareHandler) resolve(w http.ResponseWriter, r *http.Request) (*domain.ShareLink, bool) {
+	link, err := h.Shares.ResolveShareLink(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		if errors.Is(err, domain.ErrShareNotFound) || errors.Is(err, domain.ErrShareExpired) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+		} else {
+			http.Error(w, "Share link request failed", http.StatusInternalServerError)
+		}
+		return nil, false
+	}
+	return link, true
+}
+
+// alerts returns the alerts heard by the sensors of the shared workspace
+func (h *ShareHandler) alerts(r *http.Request, link *domain.ShareLink) []domain.Alert {
+	alerts := []domain.Alert{}
+	all, err := h.Service.GetAlerts(r.Context())
+	if err != nil {
+		return alerts
+	}
+	for _, a := range all {
+		if h.WorkspaceManager.WorkspaceOf(a.Sensor) == link.Workspace {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+// writeShared encodes a shared view, never cached by the browser
+func writeShared(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// SignatureHandler lets analysts label devices to grow the signature library
+type SignatureHandler struct {
+	Labeler ports.DeviceLabeler
+}
+
+// NewSignatureHandler creates a new SignatureHandler
+func NewSignatureHandler(labeler ports.DeviceLabeler) *SignatureHandler {
+	return &SignatureHandler{
+		Labeler: labeler,
+	}
+}
+
+// HandleLabel sets the true make and model of a device and learns its signature
+func (h *SignatureHandler) HandleLabel(w http.ResponseWriter, r *http.Request) {
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var label domain.DeviceLabel
+	if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	sig, err := h.Labeler.LabelDevice(r.Context(), r.PathValue("mac"), label)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sig)
+}
+
+// HandleListLearned returns the signatures learned from labeled devices
+func (h *SignatureHandler) HandleListLearned(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"signatures": h.Labeler.GetLearnedSignatures(r.Context()),
+	})
+}
+
+func (h *SignatureHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrDeviceNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, domain.ErrEmptyLabelModel), errors.Is(err, domain.ErrNoSignatureData):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, "Labeling failed: "+err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/web/middleware"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// ssoStateCookie binds the provider's callback to the browser that started
+// the login, against login CSRF
+const ssoStateCookie = "sso_state"
+
+// SSOHandler signs users in through an OpenID Connect provider
+type SSOHandler struct {
+	Provider ports.IdentityProvider
+	Service  ports.ExternalLogin
+}
+
+// NewSSOHandler creates a new SSOHandler
+func NewSSOHandler(provider ports.IdentityProvider, service ports.ExternalLogin) *SSOHandler {
+	return &SSOHandler{
+		Provider: provider,
+		Service:  service,
+	}
+}
+
+// HandleStatus tells the login page single sign-on is available
+func (h *SSOHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{"enabled": true})
+}
+
+// HandleLogin redirects the browser to the provider's login page
+func (h *SSOHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	state, nonce := randomToken(), randomToken()
+	target, err := h.Provider.AuthCodeURL(r.Context(), state, nonce)
+	if err != nil {
+		log.Printf("SSO login unavailable: %v", err)
+		ssoFailed(w, r, "identity provider unavailable")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookie,
+		Value:    state + "." + nonce,
+		MaxAge:   600,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		Path:     "/api/sso",
+		SameSite: http.SameSiteLaxMode, // Sent on the provider's redirect back
+	})
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
+// HandleCallback completes the login the provider redirected back from
+func (h *SSOHandler) HandleCallback(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{Name: ssoStateCookie, Value: "", MaxAge: -1, Path: "/api/sso"})
+
+	query := r.URL.Query()
+	if e := query.Get("error"); e != "" {
+		log.Printf("SSO login refused by provider: %s %s", e, query.Get("error_description"))
+		ssoFailed(w, r, "login refused by identity provider")
+		return
+	}
+	cookie, err := r.Cookie(ssoStateCookie)
+	if err != nil {
+		ssoFailed(w, r, "login expired, try again")
+		return
+	}
+	state, nonce, _ := strings.Cut(cookie.Value, ".")
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(query.Get("state"))) != 1 {
+		ssoFailed(w, r, "login expired, try again")
+		return
+	}
+
+	ctx := domain.ContextWithClientIP(r.Context(), middleware.ClientIP(r))
+	ctx = domain.ContextWithUserAgent(ctx, r.UserAgent())
+	identity, err := h.Provider.Exchange(ctx, query.Get("code"), nonce)
+	if err != nil {
+		log.Printf("SSO code exchange failed: %v", err)
+		ssoFailed(w, r, "identity provider error")
+		return
+	}
+	token, err := h.Service.LoginExternal(ctx, *identity)
+	if err != nil {
+		log.Printf("SSO login of %q refused: %v", identity.Username, err)
+		ssoFailed(w, r, "access denied")
+		return
+	}
+
+	setSessionCookie(w, token)
+	// A redirect would carry on the cross-site navigation from the provider,
+	// on which the SameSite=Strict session cookie is not sent
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(`<!DOCTYPE html><meta http-equiv="refresh" content="0;url=/"><a href="/">Continue</a>`))
+}
+
+// ssoFailed sends the browser back to the login page with reason
+func ssoFailed(w http.ResponseWriter, r *http.Request, reason string) {
+	http.Redirect(w, r, "/login.html?sso_error="+url.QueryEscape(reason), http.StatusFound)
+}
+
+func randomToken() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
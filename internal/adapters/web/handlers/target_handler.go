@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// TargetHandler serves the access points in scope ranked by attack value
+type TargetHandler struct {
+	Prioritizer ports.TargetPrioritizer
+}
+
+// NewTargetHandler creates a new TargetHandler
+func NewTargetHandler(prioritizer ports.TargetPrioritizer) *TargetHandler {
+	return &TargetHandler{
+		Prioritizer: prioritizer,
+	}
+}
+
+// HandleList returns the scored targets, best first, optionally capped by ?limit=
+func (h *TargetHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	targets, err := h.Prioritizer.PrioritizeTargets(r.Context(), limit)
+	if err != nil {
+		writeError(w, "Failed to prioritize targets", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(targets)
+}
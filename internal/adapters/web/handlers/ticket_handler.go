@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// TicketHandler exposes the issues filed in the issue tracker for critical
+// findings
+type TicketHandler struct {
+	Tickets ports.TicketManager
+}
+
+// NewTicketHandler creates a new TicketHandler
+func NewTicketHandler(tickets ports.TicketManager) *TicketHandler {
+	return &TicketHandler{
+		Tickets: tickets,
+	}
+}
+
+// HandleStatus returns the issues filed and the last filing error
+func (h *TicketHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Tickets.Status())
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// TwoFactorHandler enrolls users in TOTP two-factor authentication
+type TwoFactorHandler struct {
+	Manager ports.TwoFactorManager
+}
+
+// NewTwoFactorHandler creates a new TwoFactorHandler
+func NewTwoFactorHandler(manager ports.TwoFactorManager) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		Manager: manager,
+	}
+}
+
+// TOTPCodeRequest carries a TOTP or recovery code
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// HandleEnroll generates a secret for the current user, to add to an
+// authenticator app and confirm with a code
+func (h *TwoFactorHandler) HandleEnroll(w http.ResponseWriter, r *http.Request) {
+	secret, url, err := h.Manager.BeginTOTPEnrollment(r.Context())
+	if err != nil {
+		writeError(w, "Failed to start enrollment", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"secret":      secret,
+		"otpauth_url": url,
+	})
+}
+
+// HandleConfirm enables two-factor authentication and returns the recovery
+// codes, which are not shown again
+func (h *TwoFactorHandler) HandleConfirm(w http.ResponseWriter, r *http.Request) {
+	var req TOTPCodeRequest
+	if !decodeUserRequest(w, r, &req) {
+		return
+	}
+
+	codes, err := h.Manager.ConfirmTOTPEnrollment(r.Context(), req.Code)
+	if err != nil {
+		writeError(w, "Failed to confirm enrollment", err, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"recovery_codes": codes,
+	})
+}
+
+// HandleDisable turns two-factor authentication off for the current user
+func (h *TwoFactorHandler) HandleDisable(w http.ResponseWriter, r *http.Request) {
+	var req TOTPCodeRequest
+	if !decodeUserRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.Manager.DisableTOTP(r.Context(), req.Code); err != nil {
+		writeError(w, "Failed to disable two-factor authentication", err, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "disabled"})
+}
+
+// HandleReset turns two-factor authentication off for a user who lost their
+// authenticator
+func (h *TwoFactorHandler) HandleReset(w http.ResponseWriter, r *http.Request) {
+	if err := h.Manager.ResetTOTP(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, "Failed to reset two-factor authentication", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "reset"})
+}
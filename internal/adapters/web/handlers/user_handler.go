@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// UserHandler administers user accounts
+type UserHandler struct {
+	Manager ports.UserManager
+}
+
+// NewUserHandler creates a new UserHandler
+func NewUserHandler(manager ports.UserManager) *UserHandler {
+	return &UserHandler{
+		Manager: manager,
+	}
+}
+
+// CreateUserRequest describes a new account
+type CreateUserRequest struct {
+	Username    string              `json:"username"`
+	Role        domain.Role         `json:"role"`
+	Password    string              `json:"password"`
+	Permissions []domain.Permission `json:"permissions,omitempty"` // Narrow the role; omitted for everything it allows
+}
+
+// UpdateUserRequest changes the role, permissions or enabled state of an
+// account. Omitted fields are left unchanged
+type UpdateUserRequest struct {
+	Role        *domain.Role         `json:"role,omitempty"`
+	Disabled    *bool                `json:"disabled,omitempty"`
+	Permissions *[]domain.Permission `json:"permissions,omitempty"`
+}
+
+// PasswordRequest sets a password. Current is only needed to change one's own
+type PasswordRequest struct {
+	Current  string `json:"current_password,omitempty"`
+	Password string `json:"new_password"`
+}
+
+// HandleList returns every account
+func (h *UserHandler) HandleList(w http.ResponseWriter, r *http.Request) {
+	users, err := h.Manager.ListUsers(r.Context())
+	if err != nil {
+		writeError(w, "Failed to list users", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"users": users,
+	})
+}
+
+// HandlePermissions lists the permissions an account can be restricted to
+func (h *UserHandler) HandlePermissions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"permissions": domain.Permissions(),
+	})
+}
+
+// HandleCreate provisions an account
+func (h *UserHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
+	var req CreateUserRequest
+	if !decodeUserRequest(w, r, &req) {
+		return
+	}
+
+	user := domain.User{Username: req.Username, Role: req.Role, Permissions: req.Permissions}
+	if err := h.Manager.CreateUser(r.Context(), user, req.Password); err != nil {
+		writeError(w, "Failed to create user", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]string{"status": "created"})
+}
+
+// HandleUpdate changes the role, permissions or enabled state of an account
+func (h *UserHandler) HandleUpdate(w http.ResponseWriter, r *http.Request) {
+	var req UpdateUserRequest
+	if !decodeUserRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.Manager.UpdateUser(r.Context(), r.PathValue("id"), domain.UserUpdate{
+		Role:        req.Role,
+		Disabled:    req.Disabled,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		writeError(w, "Failed to update user", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+// HandleDelete removes an account
+func (h *UserHandler) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if err := h.Manager.DeleteUser(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, "Failed to delete user", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+// HandleResetPassword sets a temporary password, to be changed at the next login
+func (h *UserHandler) HandleResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req PasswordRequest
+	if !decodeUserRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.Manager.ResetPassword(r.Context(), r.PathValue("id"), req.Password); err != nil {
+		writeError(w, "Failed to reset password", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "reset"})
+}
+
+// HandleChangePassword replaces the password of the current user
+func (h *UserHandler) HandleChangePassword(w http.ResponseWriter, r *http.Request) {
+	var req PasswordRequest
+	if !decodeUserRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.Manager.ChangePassword(r.Context(), req.Current, req.Password); err != nil {
+		writeError(w, "Failed to change password", err, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "changed"})
+}
+
+func decodeUserRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	// Limit request body to 1MB
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
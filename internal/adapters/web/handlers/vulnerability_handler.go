@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/services/security"
@@ -20,11 +21,14 @@ func NewVulnerabilityHandler(service *security.VulnerabilityPersistenceService)
 }
 
 // GetVulnerabilities returns a list of vulnerabilities.
-// Query Params: device_mac, status (active, ignored, fixed), min_severity
+// Query Params: device_mac, status (active, ignored, fixed), min_severity,
+// suppressed (true, false)
 func (h *VulnerabilityHandler) GetVulnerabilities(w http.ResponseWriter, r *http.Request) {
 	mac := r.URL.Query().Get("device_mac")
 	statusStr := r.URL.Query().Get("status")
 	severityStr := r.URL.Query().Get("min_severity")
+	suppressedStr := r.URL.Query().Get("suppressed")
+	workspace := r.URL.Query().Get("workspace")
 
 	var status *domain.VulnerabilityStatus
 	if statusStr != "" {
@@ -37,10 +41,22 @@ func (h *VulnerabilityHandler) GetVulnerabilities(w http.ResponseWriter, r *http
 		minSeverity, _ = strconv.Atoi(severityStr)
 	}
 
+	var suppressed *bool
+	if suppressedStr != "" {
+		b, err := strconv.ParseBool(suppressedStr)
+		if err != nil {
+			http.Error(w, "Invalid suppressed", http.StatusBadRequest)
+			return
+		}
+		suppressed = &b
+	}
+
 	filter := domain.VulnerabilityFilter{
 		DeviceMAC:   mac,
 		Status:      status,
 		MinSeverity: minSeverity,
+		Suppressed:  suppressed,
+		Workspace:   workspace,
 	}
 
 	vulns, err := h.service.GetVulnerabilities(filter)
@@ -92,6 +108,53 @@ func (h *VulnerabilityHandler) UpdateStatus(w http.ResponseWriter, r *http.Reque
 	w.WriteHeader(http.StatusOK)
 }
 
+// Suppress accepts a vulnerability as a known risk or false positive, leaving
+// it out of reports. The justification is required and audited
+// PUT /api/vulnerabilities/{id}/suppression
+// Body: { "justification": "Guest SSID is open by design", "expires_at": "2025-01-01T00:00:00Z" }
+func (h *VulnerabilityHandler) Suppress(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "ID required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Justification string     `json:"justification"`
+		ExpiresAt     *time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+
+	vuln, err := h.service.Suppress(r.Context(), id, req.Justification, req.ExpiresAt)
+	if err != nil {
+		writeError(w, "Failed to suppress vulnerability", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(vuln)
+}
+
+// Unsuppress lifts the suppression of a vulnerability, which is reported again
+// DELETE /api/vulnerabilities/{id}/suppression
+func (h *VulnerabilityHandler) Unsuppress(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "ID required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Unsuppress(r.Context(), id); err != nil {
+		writeError(w, "Failed to lift suppression", err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // GetVulnerability returns a single vulnerability by ID
 func (h *VulnerabilityHandler) GetVulnerability(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
@@ -120,6 +183,26 @@ func (h *VulnerabilityHandler) GetVulnerability(w http.ResponseWriter, r *http.R
 	http.Error(w, "Vulnerability not found", http.StatusNotFound)
 }
 
+// RevealVulnerability returns a vulnerability with its sealed credentials decrypted.
+// POST /api/vulnerabilities/{id}/reveal
+func (h *VulnerabilityHandler) RevealVulnerability(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		http.Error(w, "ID required", http.StatusBadRequest)
+		return
+	}
+
+	vuln, err := h.service.RevealVulnerability(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Vulnerability not found or could not be decrypted", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(vuln)
+}
+
 // GetVulnerabilityStats returns statistics about vulnerabilities
 func (h *VulnerabilityHandler) GetVulnerabilityStats(w http.ResponseWriter, r *http.Request) {
 	filter := domain.VulnerabilityFilter{}
@@ -141,6 +224,7 @@ func (h *VulnerabilityHandler) GetVulnerabilityStats(w http.ResponseWriter, r *h
 		ActiveCount   int            `json:"active_count"`
 		IgnoredCount  int            `json:"ignored_count"`
 		FixedCount    int            `json:"fixed_count"`
+		Suppressed    int            `json:"suppressed_count"`
 	}{
 		Total:      len(vulns),
 		BySeverity: make(map[string]int),
@@ -165,6 +249,10 @@ func (h *VulnerabilityHandler) GetVulnerabilityStats(w http.ResponseWriter, r *h
 			stats.LowCount++
 		}
 
+		if vuln.IsSuppressed() {
+			stats.Suppressed++
+		}
+
 		// By status
 		stats.ByStatus[string(vuln.Status)]++
 		switch vuln.Status {
@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
 	"github.com/lcalzada-xor/wmap/internal/core/services/workspace"
 )
@@ -123,3 +126,213 @@ func (h *WorkspaceHandler) HandleDeleteWorkspace(w http.ResponseWriter, r *http.
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(`{"status":"deleted"}`))
 }
+
+// HandleGetScope returns the engagement scope of the current workspace
+func (h *WorkspaceHandler) HandleGetScope(w http.ResponseWriter, r *http.Request) {
+	scope, err := h.Service.GetScope(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to load scope: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scope)
+}
+
+// HandleSetScope replaces the engagement scope of the current workspace
+func (h *WorkspaceHandler) HandleSetScope(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var scope domain.EngagementScope
+	if err := json.NewDecoder(r.Body).Decode(&scope); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := scope.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.SetScope(r.Context(), scope); err != nil {
+		http.Error(w, "Failed to save scope: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(scope)
+}
+
+// SignROERequest is the acknowledgment an operator signs for the current
+// scope. Start defaults to now
+type SignROERequest struct {
+	Acknowledgment string    `json:"acknowledgment"`
+	Start          time.Time `json:"start,omitempty"`
+	End            time.Time `json:"end"`
+}
+
+// HandleGetROE returns the rules of engagement of the current workspace, the
+// record in force first
+func (h *WorkspaceHandler) HandleGetROE(w http.ResponseWriter, r *http.Request) {
+	records, err := h.Service.ListROE(r.Context())
+	if err != nil {
+		writeError(w, "Failed to load rules of engagement", err, http.StatusInternalServerError)
+		return
+	}
+	var current *domain.RulesOfEngagement
+	if len(records) > 0 && records[0].InForce(time.Now()) {
+		current = &records[0]
+	}
+	if records == nil {
+		records = []domain.RulesOfEngagement{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"current": current,
+		"records": records,
+	})
+}
+
+// HandleSignROE records the acknowledgment of the requesting operator for the
+// current engagement scope
+func (h *WorkspaceHandler) HandleSignROE(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var req SignROERequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if req.Start.IsZero() {
+		req.Start = time.Now()
+	}
+	roe, err := h.Service.SignROE(r.Context(), domain.RulesOfEngagement{
+		Acknowledgment: req.Acknowledgment,
+		Start:          req.Start,
+		End:            req.End,
+	})
+	if err != nil {
+		writeError(w, "Failed to sign rules of engagement", err, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(roe)
+}
+
+// HandleRevokeROE withdraws the rules of engagement in force
+func (h *WorkspaceHandler) HandleRevokeROE(w http.ResponseWriter, r *http.Request) {
+	if err := h.Service.RevokeROE(r.Context()); err != nil {
+		writeError(w, "Failed to revoke rules of engagement", err, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleGetSettings returns the settings stored in a workspace
+func (h *WorkspaceHandler) HandleGetSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.WorkspaceManager.GetSettings(r.Context(), r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Failed to load settings: "+err.Error(), settingsErrorStatus(err))
+		return
+	}
+	if settings.AlertRules == nil {
+		settings.AlertRules = []domain.AlertRule{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
+
+// HandleSetSettings replaces the settings of a workspace, applied right away
+// if it is the active one
+func (h *WorkspaceHandler) HandleSetSettings(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var settings domain.WorkspaceSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := settings.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	saved, err := h.WorkspaceManager.SaveSettings(r.Context(), r.PathValue("id"), settings)
+	if err != nil {
+		http.Error(w, "Failed to save settings: "+err.Error(), settingsErrorStatus(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(saved)
+}
+
+// settingsErrorStatus is the HTTP status of a failed settings operation
+func settingsErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, workspace.ErrWorkspaceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, workspace.ErrInvalidWorkspaceName):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// HandleListOpen returns the active workspace and the others open alongside it
+func (h *WorkspaceHandler) HandleListOpen(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"active": h.WorkspaceManager.GetCurrentWorkspace(),
+		"open":   h.WorkspaceManager.OpenWorkspaces(),
+	})
+}
+
+// HandleOpenWorkspace opens a workspace alongside the active one, creating it
+// if needed, so routed devices are recorded in it
+func (h *WorkspaceHandler) HandleOpenWorkspace(w http.ResponseWriter, r *http.Request) {
+	if err := h.WorkspaceManager.OpenWorkspace(r.PathValue("id")); err != nil {
+		http.Error(w, "Failed to open workspace: "+err.Error(), settingsErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"opened"}`))
+}
+
+// HandleCloseWorkspace closes a workspace opened alongside the active one
+func (h *WorkspaceHandler) HandleCloseWorkspace(w http.ResponseWriter, r *http.Request) {
+	if err := h.WorkspaceManager.CloseWorkspace(r.PathValue("id")); err != nil {
+		http.Error(w, "Failed to close workspace: "+err.Error(), settingsErrorStatus(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"closed"}`))
+}
+
+// HandleGetRoutes returns the rules routing devices of agents and interfaces
+// to workspaces
+func (h *WorkspaceHandler) HandleGetRoutes(w http.ResponseWriter, r *http.Request) {
+	routes := h.WorkspaceManager.Routes()
+	if routes == nil {
+		routes = []domain.WorkspaceRoute{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(routes)
+}
+
+// HandleSetRoutes replaces the routing rules, the first matching rule winning
+func (h *WorkspaceHandler) HandleSetRoutes(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 1048576)
+
+	var routes []domain.WorkspaceRoute
+	if err := json.NewDecoder(r.Body).Decode(&routes); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	for _, route := range routes {
+		if err := route.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := h.WorkspaceManager.SetRoutes(routes); err != nil {
+		http.Error(w, "Failed to save routes: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(routes)
+}
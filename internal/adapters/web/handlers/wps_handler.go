@@ -48,7 +48,7 @@ func (h *WPSHandler) HandleStart(w http.ResponseWriter, r *http.Request) {
 
 	id, err := h.Service.StartWPSAttack(r.Context(), config)
 	if err != nil {
-		http.Error(w, "Failed to start attack: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, "Failed to start attack", err, http.StatusInternalServerError)
 		return
 	}
 
@@ -63,7 +63,7 @@ func (h *WPSHandler) HandleStop(w http.ResponseWriter, r *http.Request) {
 	force := r.URL.Query().Get("force") == "true"
 
 	if err := h.Service.StopWPSAttack(r.Context(), id, force); err != nil {
-		http.Error(w, "Failed to stop attack: "+err.Error(), http.StatusInternalServerError)
+		writeError(w, "Failed to stop attack", err, http.StatusInternalServerError)
 		return
 	}
 
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// ZigbeeHandler exposes the 802.15.4 networks and devices seen by the sniffer dongle
+type ZigbeeHandler struct {
+	Registry ports.ZigbeeRegistry
+}
+
+// NewZigbeeHandler creates a new ZigbeeHandler
+func NewZigbeeHandler(registry ports.ZigbeeRegistry) *ZigbeeHandler {
+	return &ZigbeeHandler{
+		Registry: registry,
+	}
+}
+
+// HandleSurvey returns the Zigbee networks and their devices
+func (h *ZigbeeHandler) HandleSurvey(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.Registry.ZigbeeSurvey())
+}
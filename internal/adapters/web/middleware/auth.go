@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 
@@ -21,6 +22,22 @@ func AuthMiddleware(authService ports.AuthService) func(http.Handler) http.Handl
 			// Ideally this is handled by router groups, but here we do simple path check if needed
 			// or apply middleware selectively.
 
+			// Automation clients send an API key instead of a session token
+			if key := r.Header.Get(domain.APIKeyHeader); key != "" {
+				validator, ok := authService.(ports.APIKeyValidator)
+				if !ok {
+					http.Error(w, "Unauthorized: API keys not available", http.StatusUnauthorized)
+					return
+				}
+				user, err := validator.ValidateAPIKey(r.Context(), key)
+				if err != nil {
+					http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+					return
+				}
+				next.ServeHTTP(w, withUser(r, user))
+				return
+			}
+
 			// Get token from cookie
 			cookie, err := r.Cookie("auth_token")
 			var token string
@@ -41,7 +58,7 @@ func AuthMiddleware(authService ports.AuthService) func(http.Handler) http.Handl
 				return
 			}
 
-			// Validate Token
+			// Validate Token, which fails once the session is revoked
 			user, err := authService.ValidateToken(r.Context(), token)
 			if err != nil {
 				// Clear cookie if invalid
@@ -55,13 +72,54 @@ func AuthMiddleware(authService ports.AuthService) func(http.Handler) http.Handl
 				return
 			}
 
-			// Add user to context
-			ctx := context.WithValue(r.Context(), UserContextKey, user)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			// Until a required password change or two-factor enrollment, the
+			// session only serves the endpoints needed to make it
+			if err := pendingAction(authService, user); err != nil && !pendingActionPaths[r.URL.Path] {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": err.Error(),
+					"code":  string(domain.ErrorCodeOf(err)),
+				})
+				return
+			}
+
+			r = r.WithContext(domain.ContextWithSessionToken(r.Context(), token))
+			next.ServeHTTP(w, withUser(r, user))
 		})
 	}
 }
 
+// pendingActionPaths are served to sessions of users who must change their
+// password or enroll in two-factor authentication.
+var pendingActionPaths = map[string]bool{
+	"/api/me":          true,
+	"/api/password":    true,
+	"/api/logout":      true,
+	"/api/2fa/enroll":  true,
+	"/api/2fa/confirm": true,
+}
+
+// pendingAction returns the error of a step the user must take before using
+// the API, or nil.
+func pendingAction(authService ports.AuthService, user *domain.User) error {
+	if user.MustChangePassword {
+		return domain.ErrPasswordChange
+	}
+	if tfa, ok := authService.(ports.TwoFactorManager); ok && tfa.EnrollmentRequired(user) {
+		return domain.ErrTOTPEnrollment
+	}
+	return nil
+}
+
+// withUser adds the authenticated user to the request context.
+func withUser(r *http.Request, user *domain.User) *http.Request {
+	ctx := context.WithValue(r.Context(), UserContextKey, user)
+	ctx = domain.ContextWithUser(ctx, user)
+	ctx = domain.ContextWithClientIP(ctx, ClientIP(r))
+	return r.WithContext(ctx)
+}
+
 // RoleMiddleware checks if the user has the required role.
 func RoleMiddleware(requiredRole domain.Role) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -73,7 +131,7 @@ func RoleMiddleware(requiredRole domain.Role) func(http.Handler) http.Handler {
 			}
 
 			// Simple hierarchy: Admin > Operator > Viewer
-			if !hasPermission(user.Role, requiredRole) {
+			if !user.Role.Allows(requiredRole) {
 				http.Error(w, "Forbidden", http.StatusForbidden)
 				return
 			}
@@ -83,15 +141,21 @@ func RoleMiddleware(requiredRole domain.Role) func(http.Handler) http.Handler {
 	}
 }
 
-func hasPermission(userRole, requiredRole domain.Role) bool {
-	if userRole == domain.RoleAdmin {
-		return true
-	}
-	if userRole == domain.RoleOperator {
-		return requiredRole != domain.RoleAdmin
-	}
-	if userRole == domain.RoleViewer {
-		return requiredRole == domain.RoleViewer
+// PermissionMiddleware checks if the user's permission list grants perm,
+// on top of the role checked by RoleMiddleware.
+func PermissionMiddleware(perm domain.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := r.Context().Value(UserContextKey).(*domain.User)
+			if !ok || user == nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if !user.Can(perm) {
+				http.Error(w, "Forbidden: requires permission "+string(perm), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
 	}
-	return false
 }
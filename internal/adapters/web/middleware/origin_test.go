@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCSRFMiddleware(t *testing.T) {
+	handler := CSRFMiddleware([]string{"https://dash.example.com"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name    string
+		method  string
+		origin  string
+		referer string
+		want    int
+	}{
+		{"same origin", http.MethodPost, "http://wmap.local:8080", "", http.StatusOK},
+		{"allowed origin", http.MethodDelete, "https://dash.example.com", "", http.StatusOK},
+		{"foreign origin", http.MethodPost, "https://evil.example.com", "", http.StatusForbidden},
+		{"foreign referer", http.MethodPut, "", "https://evil.example.com/page", http.StatusForbidden},
+		{"non-browser client", http.MethodPost, "", "", http.StatusOK},
+		{"safe method", http.MethodGet, "https://evil.example.com", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://wmap.local:8080/api/deauth/start", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.referer != "" {
+				req.Header.Set("Referer", tt.referer)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != tt.want {
+				t.Errorf("status = %d, want %d", rr.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	handler := CORSMiddleware([]string{"https://dash.example.com/"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "http://wmap.local:8080/api/devices", nil)
+	req.Header.Set("Origin", "https://dash.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNoContent || rr.Header().Get("Access-Control-Allow-Origin") != "https://dash.example.com" {
+		t.Errorf("preflight from allowed origin: status %d, headers %v", rr.Code, rr.Header())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "http://wmap.local:8080/api/devices", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("foreign origin got CORS headers: %v", rr.Header())
+	}
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import "net/http"
+
+// PassiveModeMiddleware rejects active (transmitting) operations when the
+// instance runs as a passive sensor. It is a no-op when passive is false.
+func PassiveModeMiddleware(passive bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !passive {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Active operations are disabled in passive mode", http.StatusForbidden)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPassiveModeMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	for _, tt := range []struct {
+		passive bool
+		want    int
+	}{
+		{false, http.StatusAccepted},
+		{true, http.StatusForbidden},
+	} {
+		rec := httptest.NewRecorder()
+		PassiveModeMiddleware(tt.passive)(next).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/deauth/start", nil))
+		if rec.Code != tt.want {
+			t.Errorf("passive=%v: status = %d, want %d", tt.passive, rec.Code, tt.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -89,7 +90,7 @@ func (rl *rateLimiter) Allow(ip string) bool {
 func RateLimitMiddleware(limiter *rateLimiter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ip := r.RemoteAddr
+			ip := ClientIP(r)
 
 			if !limiter.Allow(ip) {
 				http.Error(w, "Rate limit exceeded. Please try again later.", http.StatusTooManyRequests)
@@ -100,3 +101,14 @@ func RateLimitMiddleware(limiter *rateLimiter) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// ClientIP returns the address of the client, without the port so that
+// limits hold across connections. Forwarding headers are not trusted: they
+// are set by the client itself unless a proxy overwrites them.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
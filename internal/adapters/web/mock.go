@@ -18,6 +18,11 @@ func (m *MockNetworkService) ProcessDevice(ctx context.Context, device domain.De
 	return args.Error(0)
 }
 
+func (m *MockNetworkService) ReportAlert(ctx context.Context, alert domain.Alert) error {
+	args := m.Called(ctx, alert)
+	return args.Error(0)
+}
+
 func (m *MockNetworkService) GetGraph(ctx context.Context) (domain.GraphData, error) {
 	args := m.Called(ctx)
 	return args.Get(0).(domain.GraphData), args.Error(1)
@@ -77,6 +82,36 @@ func (m *MockNetworkService) GetInterfaces(ctx context.Context) ([]string, error
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockNetworkService) RunInjectionTest(ctx context.Context, iface string, config domain.InjectionTestConfig) (domain.InjectionTestResult, error) {
+	args := m.Called(ctx, iface, config)
+	return args.Get(0).(domain.InjectionTestResult), args.Error(1)
+}
+
+func (m *MockNetworkService) DiagnoseInterfaces(ctx context.Context, ifaces []string) ([]domain.InterfaceDiagnostics, error) {
+	args := m.Called(ctx, ifaces)
+	return args.Get(0).([]domain.InterfaceDiagnostics), args.Error(1)
+}
+
+func (m *MockNetworkService) GetChannelRegulatory(ctx context.Context, iface string) ([]domain.ChannelRegulatory, error) {
+	args := m.Called(ctx, iface)
+	return args.Get(0).([]domain.ChannelRegulatory), args.Error(1)
+}
+
+func (m *MockNetworkService) GetDwellPlan(ctx context.Context, iface string) ([]domain.ChannelDwell, error) {
+	args := m.Called(ctx, iface)
+	return args.Get(0).([]domain.ChannelDwell), args.Error(1)
+}
+
+func (m *MockNetworkService) LockInterfaceChannel(ctx context.Context, iface string, channel int, duration time.Duration) (domain.ChannelLockState, error) {
+	args := m.Called(ctx, iface, channel, duration)
+	return args.Get(0).(domain.ChannelLockState), args.Error(1)
+}
+
+func (m *MockNetworkService) UnlockInterfaceChannel(ctx context.Context, iface string) error {
+	args := m.Called(ctx, iface)
+	return args.Error(0)
+}
+
 func (m *MockNetworkService) GetInterfaceDetails(ctx context.Context) ([]domain.InterfaceInfo, error) {
 	args := m.Called(ctx)
 	return args.Get(0).([]domain.InterfaceInfo), args.Error(1)
@@ -123,6 +158,11 @@ func (m *MockNetworkService) GetSystemStats(ctx context.Context) (domain.SystemS
 	return args.Get(0).(domain.SystemStats), args.Error(1)
 }
 
+func (m *MockNetworkService) GetHeatmap(ctx context.Context, query domain.HeatmapQuery) (domain.Heatmap, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(domain.Heatmap), args.Error(1)
+}
+
 // Auth Flood Mock Methods
 func (m *MockNetworkService) StartAuthFloodAttack(ctx context.Context, config domain.AuthFloodAttackConfig) (string, error) {
 	args := m.Called(ctx, config)
@@ -139,6 +179,143 @@ func (m *MockNetworkService) GetAuthFloodStatus(ctx context.Context, id string)
 	return args.Get(0).(domain.AuthFloodAttackStatus), args.Error(1)
 }
 
+// Probe Flood Mock Methods
+func (m *MockNetworkService) StartProbeFloodAttack(ctx context.Context, config domain.ProbeFloodAttackConfig) (string, error) {
+	args := m.Called(ctx, config)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockNetworkService) StopProbeFloodAttack(ctx context.Context, id string, force bool) error {
+	args := m.Called(ctx, id, force)
+	return args.Error(0)
+}
+
+func (m *MockNetworkService) GetProbeFloodStatus(ctx context.Context, id string) (domain.ProbeFloodAttackStatus, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.ProbeFloodAttackStatus), args.Error(1)
+}
+
+// CSA Mock Methods
+func (m *MockNetworkService) StartCSAAttack(ctx context.Context, config domain.CSAAttackConfig) (string, error) {
+	args := m.Called(ctx, config)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockNetworkService) StopCSAAttack(ctx context.Context, id string, force bool) error {
+	args := m.Called(ctx, id, force)
+	return args.Error(0)
+}
+
+func (m *MockNetworkService) GetCSAStatus(ctx context.Context, id string) (domain.CSAAttackStatus, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.CSAAttackStatus), args.Error(1)
+}
+
+// Beacon Spoof Mock Methods
+func (m *MockNetworkService) StartBeaconSpoof(ctx context.Context, config domain.BeaconSpoofConfig) (string, error) {
+	args := m.Called(ctx, config)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockNetworkService) StopBeaconSpoof(ctx context.Context, id string, force bool) error {
+	args := m.Called(ctx, id, force)
+	return args.Error(0)
+}
+
+func (m *MockNetworkService) GetBeaconSpoofStatus(ctx context.Context, id string) (domain.BeaconSpoofStatus, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.BeaconSpoofStatus), args.Error(1)
+}
+
+// Karma Mock Methods
+func (m *MockNetworkService) StartKarma(ctx context.Context, config domain.KarmaConfig) (string, error) {
+	args := m.Called(ctx, config)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockNetworkService) StopKarma(ctx context.Context, id string, force bool) error {
+	args := m.Called(ctx, id, force)
+	return args.Error(0)
+}
+
+func (m *MockNetworkService) GetKarmaStatus(ctx context.Context, id string) (domain.KarmaStatus, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.KarmaStatus), args.Error(1)
+}
+
+// NAV Jam Mock Methods
+func (m *MockNetworkService) StartNAVJam(ctx context.Context, config domain.NAVJamConfig) (string, error) {
+	args := m.Called(ctx, config)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockNetworkService) StopNAVJam(ctx context.Context, id string, force bool) error {
+	args := m.Called(ctx, id, force)
+	return args.Error(0)
+}
+
+func (m *MockNetworkService) GetNAVJamStatus(ctx context.Context, id string) (domain.NAVJamStatus, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.NAVJamStatus), args.Error(1)
+}
+
+func (m *MockNetworkService) GetScope(ctx context.Context) (domain.EngagementScope, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(domain.EngagementScope), args.Error(1)
+}
+
+func (m *MockNetworkService) SetScope(ctx context.Context, scope domain.EngagementScope) error {
+	args := m.Called(ctx, scope)
+	return args.Error(0)
+}
+
+func (m *MockNetworkService) GetROE(ctx context.Context) (*domain.RulesOfEngagement, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RulesOfEngagement), args.Error(1)
+}
+
+func (m *MockNetworkService) ListROE(ctx context.Context) ([]domain.RulesOfEngagement, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.RulesOfEngagement), args.Error(1)
+}
+
+func (m *MockNetworkService) SignROE(ctx context.Context, roe domain.RulesOfEngagement) (domain.RulesOfEngagement, error) {
+	args := m.Called(ctx, roe)
+	return args.Get(0).(domain.RulesOfEngagement), args.Error(1)
+}
+
+func (m *MockNetworkService) RevokeROE(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockNetworkService) GetAttackHistory(ctx context.Context, limit int) ([]domain.AttackRecord, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AttackRecord), args.Error(1)
+}
+
+// Device Locator Mock Methods
+func (m *MockNetworkService) StartLocator(ctx context.Context, config domain.LocatorConfig) (domain.LocatorSession, error) {
+	args := m.Called(ctx, config)
+	return args.Get(0).(domain.LocatorSession), args.Error(1)
+}
+
+func (m *MockNetworkService) StopLocator(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockNetworkService) GetLocatorStatus(ctx context.Context) (domain.LocatorSession, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(domain.LocatorSession), args.Error(1)
+}
+
 func (m *MockNetworkService) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -164,6 +341,11 @@ func (m *MockDeviceRegistry) GetAllDevices(ctx context.Context) []domain.Device
 	return args.Get(0).([]domain.Device)
 }
 
+func (m *MockDeviceRegistry) RemoveDevice(ctx context.Context, mac string) (bool, int) {
+	args := m.Called(ctx, mac)
+	return args.Bool(0), args.Int(1)
+}
+
 func (m *MockDeviceRegistry) PruneOldDevices(ctx context.Context, ttl time.Duration) int {
 	args := m.Called(ctx, ttl)
 	return args.Int(0)
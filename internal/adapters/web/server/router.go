@@ -30,9 +30,11 @@ func SetupRoutes(s *Server) http.Handler {
 		return auth(h)
 	}
 
-	// WebSocket endpoint (protected)
-	// Now handled by WSManager via Server
-	mux.Handle("/ws", protect(s.WSManager.HandleWebSocket))
+	// WebSocket endpoint, authenticated by WSManager itself: clients that
+	// cannot send the cookie or a header give their token in the first message
+	mux.HandleFunc("/ws", s.WSManager.HandleWebSocket)
+	// Server-sent events mirroring /ws, for proxies that block WebSockets
+	mux.Handle("GET /api/events", protect(s.WSManager.HandleSSE))
 
 	// RBAC Middleware Helper (Operator Level)
 	requireOperator := middleware.RoleMiddleware(domain.RoleOperator)
@@ -40,40 +42,78 @@ func SetupRoutes(s *Server) http.Handler {
 		return auth(requireOperator(h))
 	}
 
+	// RBAC Middleware Helper (Admin Level)
+	requireAdmin := middleware.RoleMiddleware(domain.RoleAdmin)
+	protectAdmin := func(h http.HandlerFunc) http.Handler {
+		return auth(requireAdmin(h))
+	}
+
+	// Permission lists narrow what the role allows. Stopping an attack needs
+	// no permission: it is never disruptive
+	permit := func(perm domain.Permission, h http.HandlerFunc) http.HandlerFunc {
+		return middleware.PermissionMiddleware(perm)(h).ServeHTTP
+	}
+
+	// Active operations (transmitting) are refused outright in passive mode
+	passive := middleware.PassiveModeMiddleware(s.Passive)
+	protectTx := func(h http.HandlerFunc) http.Handler {
+		return passive(protectOp(h))
+	}
+
 	mux.Handle("/api/me", protect(s.AuthHandler.HandleMe))
-	mux.Handle("/api/scan", protect(s.ScanHandler.HandleScan))
+	mux.Handle("/api/scan", passive(protect(permit(domain.PermScan, s.ScanHandler.HandleScan))))
 	mux.Handle("/api/export", protect(s.ExportHandler.HandleExport))
 	mux.Handle("/api/config", protect(s.ConfigHandler.HandleGetConfig))
-	mux.Handle("/api/config/persistence", protect(s.ConfigHandler.HandleTogglePersistence))
+	mux.Handle("/api/config/persistence", protect(permit(domain.PermConfig, s.ConfigHandler.HandleTogglePersistence)))
 	mux.Handle("/api/stats", protect(s.ScanHandler.HandleGetStats))
+	mux.Handle("/api/heatmap", protect(s.ScanHandler.HandleGetHeatmap))
 
 	// Reports (Restricted to Operator/Admin)
-	mux.Handle("/api/reports/download", protectOp(s.ReportHandler.HandleGenerateReport))
+	mux.Handle("/api/reports/download", protectOp(permit(domain.PermReport, s.ReportHandler.HandleGenerateReport)))
 
 	// Audit Logs
 	mux.Handle("/api/audit-logs", protect(s.AuditHandler.HandleGetLogs))
 
 	// Workspace API
-	mux.Handle("/api/workspaces/clear", protect(s.WorkspaceHandler.HandleClear))
+	mux.Handle("/api/workspaces/clear", protect(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleClear)))
 	mux.Handle("/api/workspaces", protect(s.WorkspaceHandler.HandleListWorkspaces))
-	mux.Handle("/api/workspaces/new", protect(s.WorkspaceHandler.HandleCreateWorkspace))
-	mux.Handle("/api/workspaces/load", protect(s.WorkspaceHandler.HandleLoadWorkspace))
+	mux.Handle("/api/workspaces/new", protect(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleCreateWorkspace)))
+	mux.Handle("/api/workspaces/load", protect(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleLoadWorkspace)))
 	mux.Handle("/api/workspace/status", protect(s.WorkspaceHandler.HandleStatus))
-	mux.Handle("/api/workspaces/delete", protect(s.WorkspaceHandler.HandleDeleteWorkspace))
+	mux.Handle("/api/workspaces/delete", protect(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleDeleteWorkspace)))
+	mux.Handle("GET /api/workspaces/{id}/settings", protect(s.WorkspaceHandler.HandleGetSettings))
+	mux.Handle("PUT /api/workspaces/{id}/settings", protectOp(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleSetSettings)))
+	mux.Handle("GET /api/workspaces/open", protect(s.WorkspaceHandler.HandleListOpen))
+	mux.Handle("POST /api/workspaces/{id}/open", protectOp(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleOpenWorkspace)))
+	mux.Handle("POST /api/workspaces/{id}/close", protectOp(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleCloseWorkspace)))
+	mux.Handle("GET /api/workspaces/routes", protect(s.WorkspaceHandler.HandleGetRoutes))
+	mux.Handle("PUT /api/workspaces/routes", protectOp(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleSetRoutes)))
+	mux.Handle("GET /api/workspace/scope", protect(s.WorkspaceHandler.HandleGetScope))
+	mux.Handle("PUT /api/workspace/scope", protectOp(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleSetScope)))
+	mux.Handle("GET /api/workspace/roe", protect(s.WorkspaceHandler.HandleGetROE))
+	mux.Handle("POST /api/workspace/roe", protectOp(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleSignROE)))
+	mux.Handle("DELETE /api/workspace/roe", protectOp(permit(domain.PermWorkspace, s.WorkspaceHandler.HandleRevokeROE)))
 
 	mux.Handle("/api/channels", protect(s.ScanHandler.HandleChannels))
 	mux.Handle("/api/interfaces", protect(s.ScanHandler.HandleListInterfaces))
+	mux.Handle("/api/interfaces/diagnostics", protect(s.ScanHandler.HandleDiagnostics))
+	mux.Handle("/api/interfaces/{iface}/lock", protect(permit(domain.PermScan, s.ScanHandler.HandleChannelLock)))
+	mux.Handle("/api/interfaces/{iface}/injection-test", protectTx(permit(domain.PermScan, s.ScanHandler.HandleInjectionTest)))
+	if s.InterfaceHandler != nil {
+		mux.Handle("POST /api/interfaces/{iface}/capture", protectOp(permit(domain.PermScan, s.InterfaceHandler.HandleAdd)))
+		mux.Handle("DELETE /api/interfaces/{iface}/capture", protectOp(permit(domain.PermScan, s.InterfaceHandler.HandleRemove)))
+	}
 
 	// Deauth Attack endpoints
-	mux.Handle("/api/deauth/start", middleware.RateLimitMiddleware(deauthLimiter)(protectOp(s.DeauthHandler.HandleStart)))
-	mux.Handle("/api/deauth/stop", middleware.RateLimitMiddleware(deauthLimiter)(protectOp(s.DeauthHandler.HandleStop)))
-	mux.Handle("/api/deauth/status", protect(s.DeauthHandler.HandleStatus))
-	mux.Handle("/api/deauth/list", protect(s.DeauthHandler.HandleList))
+	mux.Handle("/api/deauth/start", middleware.RateLimitMiddleware(deauthLimiter)(protectTx(permit(domain.AttackPermission(domain.AttackKindDeauth), s.DeauthHandler.HandleStart))))
+	mux.Handle("/api/deauth/stop", middleware.RateLimitMiddleware(deauthLimiter)(protectTx(s.DeauthHandler.HandleStop)))
+	mux.Handle("/api/deauth/status", passive(protect(s.DeauthHandler.HandleStatus)))
+	mux.Handle("/api/deauth/list", passive(protect(s.DeauthHandler.HandleList)))
 
 	// WPS Attack Endpoints
-	mux.Handle("/api/wps/start", protectOp(s.WPSHandler.HandleStart))
-	mux.Handle("/api/wps/stop/{id}", protectOp(s.WPSHandler.HandleStop))
-	mux.Handle("/api/wps/status/{id}", protect(s.WPSHandler.HandleStatus))
+	mux.Handle("/api/wps/start", protectTx(permit(domain.AttackPermission(domain.AttackKindWPS), s.WPSHandler.HandleStart)))
+	mux.Handle("/api/wps/stop/{id}", protectTx(s.WPSHandler.HandleStop))
+	mux.Handle("/api/wps/status/{id}", passive(protect(s.WPSHandler.HandleStatus)))
 
 	// Metrics endpoint (protected - requires authentication)
 	mux.Handle("/metrics", protect(func(w http.ResponseWriter, r *http.Request) {
@@ -81,21 +121,243 @@ func SetupRoutes(s *Server) http.Handler {
 	}))
 
 	// Auth Flood Attack (New)
-	mux.Handle("/api/attack/auth-flood/start", protectOp(s.AuthFloodHandler.HandleStart))
-	mux.Handle("/api/attack/auth-flood/stop", protectOp(s.AuthFloodHandler.HandleStop))
-	mux.Handle("/api/attack/auth-flood/status", protect(s.AuthFloodHandler.HandleStatus))
+	mux.Handle("/api/attack/auth-flood/start", protectTx(permit(domain.AttackPermission(domain.AttackKindAuthFlood), s.AuthFloodHandler.HandleStart)))
+	mux.Handle("/api/attack/auth-flood/stop", protectTx(s.AuthFloodHandler.HandleStop))
+	mux.Handle("/api/attack/auth-flood/status", passive(protect(s.AuthFloodHandler.HandleStatus)))
+
+	// Probe Request Flood (SSID spam, WIDS testing)
+	mux.Handle("/api/attack/probe-flood/start", protectTx(permit(domain.AttackPermission(domain.AttackKindProbeFlood), s.ProbeFloodHandler.HandleStart)))
+	mux.Handle("/api/attack/probe-flood/stop", protectTx(s.ProbeFloodHandler.HandleStop))
+	mux.Handle("/api/attack/probe-flood/status", passive(protect(s.ProbeFloodHandler.HandleStatus)))
+
+	// Channel Switch Announcement (standalone)
+	mux.Handle("/api/attack/csa/start", protectTx(permit(domain.AttackPermission(domain.AttackKindCSA), s.CSAHandler.HandleStart)))
+	mux.Handle("/api/attack/csa/stop", protectTx(s.CSAHandler.HandleStop))
+	mux.Handle("/api/attack/csa/status", passive(protect(s.CSAHandler.HandleStatus)))
+
+	// Beacon Spoofing (clone or template)
+	mux.Handle("/api/attack/beacon/start", protectTx(permit(domain.AttackPermission(domain.AttackKindBeaconSpoof), s.BeaconHandler.HandleStart)))
+	mux.Handle("/api/attack/beacon/stop", protectTx(s.BeaconHandler.HandleStop))
+	mux.Handle("/api/attack/beacon/status", passive(protect(s.BeaconHandler.HandleStatus)))
+
+	// Karma-lite probe responder (auto-join risk demonstration)
+	mux.Handle("/api/attack/karma/start", protectTx(permit(domain.AttackPermission(domain.AttackKindKarma), s.KarmaHandler.HandleStart)))
+	mux.Handle("/api/attack/karma/stop", protectTx(s.KarmaHandler.HandleStop))
+	mux.Handle("/api/attack/karma/status", passive(protect(s.KarmaHandler.HandleStatus)))
+
+	// RTS/CTS virtual jamming (rate and duration capped)
+	mux.Handle("/api/attack/nav-jam/start", protectTx(permit(domain.AttackPermission(domain.AttackKindNAVJam), s.NAVJamHandler.HandleStart)))
+	mux.Handle("/api/attack/nav-jam/stop", protectTx(s.NAVJamHandler.HandleStop))
+	mux.Handle("/api/attack/nav-jam/status", passive(protect(s.NAVJamHandler.HandleStatus)))
+
+	// Finished attacks with effectiveness score
+	mux.Handle("/api/attacks/history", protect(s.HistoryHandler.HandleList))
+
+	// Device Locator ("hot/cold" tracking, readings streamed over /ws)
+	mux.Handle("/api/locator/start", protectOp(permit(domain.PermScan, s.LocatorHandler.HandleStart)))
+	mux.Handle("/api/locator/stop", protectOp(permit(domain.PermScan, s.LocatorHandler.HandleStop)))
+	mux.Handle("/api/locator/status", protect(s.LocatorHandler.HandleStatus))
 
 	// Vulnerability Management API
 	mux.Handle("GET /api/vulnerabilities", protect(http.HandlerFunc(s.VulnHandler.GetVulnerabilities)))
 	mux.Handle("GET /api/vulnerabilities/stats", protect(http.HandlerFunc(s.VulnHandler.GetVulnerabilityStats)))
 	mux.Handle("GET /api/vulnerabilities/{id}", protect(http.HandlerFunc(s.VulnHandler.GetVulnerability)))
 	mux.Handle("PUT /api/vulnerabilities/{id}/status", protect(http.HandlerFunc(s.VulnHandler.UpdateStatus)))
+	mux.Handle("PUT /api/vulnerabilities/{id}/suppression", protectOp(permit(domain.PermReport, s.VulnHandler.Suppress)))
+	mux.Handle("DELETE /api/vulnerabilities/{id}/suppression", protectOp(permit(domain.PermReport, s.VulnHandler.Unsuppress)))
+	mux.Handle("POST /api/vulnerabilities/{id}/reveal", protectOp(s.VulnHandler.RevealVulnerability))
 
 	// Reporting API (Phase 2)
-	mux.Handle("POST /api/reports/executive", protect(http.HandlerFunc(s.ReportHandler.HandleGenerateExecutiveSummary)))
+	mux.Handle("POST /api/reports/executive", protect(permit(domain.PermReport, s.ReportHandler.HandleGenerateExecutiveSummary)))
+	if s.ReportHandler.ActionsGenerator != nil {
+		mux.Handle("POST /api/reports/actions", protect(permit(domain.PermReport, s.ReportHandler.HandleGenerateActionsReport)))
+	}
+
+	// Geofencing (optional)
+	if s.GeofenceHandler != nil {
+		mux.Handle("GET /api/geofences", protect(s.GeofenceHandler.HandleList))
+		mux.Handle("POST /api/geofences", protectOp(permit(domain.PermDevice, s.GeofenceHandler.HandleCreate)))
+		mux.Handle("DELETE /api/geofences/{id}", protectOp(permit(domain.PermDevice, s.GeofenceHandler.HandleDelete)))
+	}
+
+	if s.ProtectedHandler != nil {
+		mux.Handle("GET /api/protected-bssids", protect(s.ProtectedHandler.HandleList))
+		mux.Handle("POST /api/protected-bssids", protectOp(permit(domain.PermDevice, s.ProtectedHandler.HandleCreate)))
+		mux.Handle("DELETE /api/protected-bssids/{bssid}", protectOp(permit(domain.PermDevice, s.ProtectedHandler.HandleDelete)))
+	}
+
+	if s.DeauthReasonHandler != nil {
+		mux.Handle("GET /api/analytics/deauth-reasons", protect(s.DeauthReasonHandler.HandleList))
+	}
+
+	if s.SignatureHandler != nil {
+		mux.Handle("POST /api/devices/{mac}/label", protectOp(permit(domain.PermDevice, s.SignatureHandler.HandleLabel)))
+		mux.Handle("GET /api/signatures/learned", protect(s.SignatureHandler.HandleListLearned))
+	}
+
+	if s.DeviceHandler != nil {
+		mux.Handle("DELETE /api/devices/{mac}", protectOp(permit(domain.PermDevice, s.DeviceHandler.HandleForget)))
+		mux.Handle("PUT /api/devices/{mac}/asset", protectOp(permit(domain.PermDevice, s.DeviceHandler.HandleSetAsset)))
+	}
+
+	if s.PortalHandler != nil {
+		mux.Handle("POST /api/devices/{mac}/portal-check", protectTx(permit(domain.AttackPermission(domain.AttackKindPortal), s.PortalHandler.HandleCheck)))
+	}
+
+	if s.ScheduleHandler != nil {
+		mux.Handle("GET /api/schedule", protect(s.ScheduleHandler.HandleGet))
+		mux.Handle("PUT /api/schedule", protectOp(permit(domain.PermConfig, s.ScheduleHandler.HandleSet)))
+	}
+	if s.PowerHandler != nil {
+		mux.Handle("GET /api/power", protect(s.PowerHandler.HandleGet))
+		mux.Handle("PUT /api/power", protectOp(permit(domain.PermConfig, s.PowerHandler.HandleSet)))
+	}
+	if s.InventoryHandler != nil {
+		mux.Handle("GET /api/inventory", protect(s.InventoryHandler.HandleStatus))
+		mux.Handle("POST /api/inventory/sync", protectOp(permit(domain.PermDevice, s.InventoryHandler.HandleSync)))
+	}
+	if s.TicketHandler != nil {
+		mux.Handle("GET /api/tickets", protect(s.TicketHandler.HandleStatus))
+	}
+
+	if s.BluetoothHandler != nil {
+		mux.Handle("GET /api/bluetooth/devices", protect(s.BluetoothHandler.HandleList))
+	}
+
+	if s.ZigbeeHandler != nil {
+		mux.Handle("GET /api/zigbee", protect(s.ZigbeeHandler.HandleSurvey))
+	}
+
+	if s.ReloadHandler != nil {
+		mux.Handle("POST /api/reload", protectOp(permit(domain.PermConfig, s.ReloadHandler.HandleReload)))
+	}
+
+	if s.PluginHandler != nil {
+		mux.Handle("GET /api/plugins", protect(s.PluginHandler.HandleList))
+	}
+
+	if s.HookHandler != nil {
+		mux.Handle("GET /api/hooks", protect(s.HookHandler.HandleList))
+		mux.Handle("POST /api/hooks", protectOp(permit(domain.PermConfig, s.HookHandler.HandleCreate)))
+		mux.Handle("PUT /api/hooks/{id}", protectOp(permit(domain.PermConfig, s.HookHandler.HandleUpdate)))
+		mux.Handle("DELETE /api/hooks/{id}", protectOp(permit(domain.PermConfig, s.HookHandler.HandleDelete)))
+	}
+
+	if s.IngestHandler != nil {
+		mux.Handle("POST /api/ingest", protectOp(permit(domain.PermDevice, s.IngestHandler.HandleIngest)))
+	}
+
+	if s.CaptureImportHandler != nil {
+		mux.Handle("POST /api/captures/import", protectOp(permit(domain.PermDevice, s.CaptureImportHandler.HandleImport)))
+	}
+	if s.RecentCaptureHandler != nil {
+		mux.Handle("POST /api/captures/recent", protectOp(permit(domain.PermScan, s.RecentCaptureHandler.HandleSave)))
+	}
+
+	if s.BaselineHandler != nil {
+		mux.Handle("GET /api/baseline", protect(s.BaselineHandler.HandleGet))
+		mux.Handle("PUT /api/baseline", protectOp(permit(domain.PermWorkspace, s.BaselineHandler.HandleSet)))
+	}
+
+	if s.PSKAuditHandler != nil {
+		mux.Handle("GET /api/audit/psk", protect(s.PSKAuditHandler.HandleStatus))
+		mux.Handle("POST /api/audit/psk", protectOp(s.PSKAuditHandler.HandleStart))
+	}
+
+	if s.AutoCaptureHandler != nil {
+		mux.Handle("GET /api/auto-capture", protect(s.AutoCaptureHandler.HandleStatus))
+		mux.Handle("POST /api/auto-capture", protectTx(permit(domain.AttackPermission(domain.AttackKindDeauth), s.AutoCaptureHandler.HandleStart)))
+		mux.Handle("DELETE /api/auto-capture", protectOp(s.AutoCaptureHandler.HandleStop))
+	}
+	if s.TargetHandler != nil {
+		mux.Handle("GET /api/targets", protect(s.TargetHandler.HandleList))
+	}
+
+	if s.JobHandler != nil {
+		mux.Handle("GET /api/jobs", protect(s.JobHandler.HandleList))
+		mux.Handle("POST /api/jobs", protectOp(s.JobHandler.HandleEnqueue))
+		mux.Handle("GET /api/jobs/{id}", protect(s.JobHandler.HandleGet))
+		mux.Handle("POST /api/jobs/{id}/cancel", protectOp(s.JobHandler.HandleCancel))
+	}
+
+	if s.ArtifactHandler != nil {
+		mux.Handle("GET /api/artifacts", protect(s.ArtifactHandler.HandleList))
+		mux.Handle("GET /api/artifacts/{id}", protect(s.ArtifactHandler.HandleGet))
+		mux.Handle("DELETE /api/artifacts/{id}", protectOp(permit(domain.PermReport, s.ArtifactHandler.HandleDelete)))
+		mux.Handle("POST /api/artifacts/{id}/link", protectOp(permit(domain.PermReport, s.ArtifactHandler.HandleCreateLink)))
+		// Public: the signed, expiring token authorizes the download
+		mux.HandleFunc("GET /api/artifacts/download", s.ArtifactHandler.HandleDownload)
+	}
+
+	if s.APIKeyHandler != nil {
+		mux.Handle("GET /api/apikeys", protect(s.APIKeyHandler.HandleList))
+		mux.Handle("POST /api/apikeys", protect(s.APIKeyHandler.HandleCreate))
+		mux.Handle("DELETE /api/apikeys/{id}", protect(s.APIKeyHandler.HandleRevoke))
+	}
+
+	if s.GraphQLHandler != nil {
+		mux.Handle("GET /api/graphql", protect(s.GraphQLHandler.HandleQuery))
+		mux.Handle("POST /api/graphql", protect(s.GraphQLHandler.HandleQuery))
+	}
+
+	if s.ShareHandler != nil {
+		mux.Handle("GET /api/workspaces/{id}/shares", protect(permit(domain.PermWorkspace, s.ShareHandler.HandleList)))
+		mux.Handle("POST /api/workspaces/{id}/shares", protectOp(permit(domain.PermWorkspace, s.ShareHandler.HandleCreate)))
+		mux.Handle("DELETE /api/shares/{id}", protectOp(permit(domain.PermWorkspace, s.ShareHandler.HandleRevoke)))
+		// Public: the share token authorizes the read-only views
+		shareLimiter := middleware.NewRateLimiter(60, 1*time.Minute)
+		shared := middleware.RateLimitMiddleware(shareLimiter)
+		mux.Handle("GET /api/share/devices", shared(http.HandlerFunc(s.ShareHandler.HandleDevices)))
+		mux.Handle("GET /api/share/alerts", shared(http.HandlerFunc(s.ShareHandler.HandleAlerts)))
+		mux.Handle("GET /api/share/report", shared(http.HandlerFunc(s.ShareHandler.HandleReport)))
+	}
+
+	if s.UserHandler != nil {
+		mux.Handle("GET /api/users", protectAdmin(s.UserHandler.HandleList))
+		mux.Handle("GET /api/permissions", protectAdmin(s.UserHandler.HandlePermissions))
+		mux.Handle("POST /api/users", protectAdmin(s.UserHandler.HandleCreate))
+		mux.Handle("PATCH /api/users/{id}", protectAdmin(s.UserHandler.HandleUpdate))
+		mux.Handle("DELETE /api/users/{id}", protectAdmin(s.UserHandler.HandleDelete))
+		mux.Handle("POST /api/users/{id}/reset-password", protectAdmin(s.UserHandler.HandleResetPassword))
+		// Every user changes their own password, also while a change is required
+		mux.Handle("POST /api/password", protect(s.UserHandler.HandleChangePassword))
+	}
+
+	if s.TwoFactorHandler != nil {
+		mux.Handle("POST /api/2fa/enroll", protect(s.TwoFactorHandler.HandleEnroll))
+		mux.Handle("POST /api/2fa/confirm", protect(s.TwoFactorHandler.HandleConfirm))
+		mux.Handle("POST /api/2fa/disable", protect(s.TwoFactorHandler.HandleDisable))
+		mux.Handle("POST /api/users/{id}/reset-2fa", protectAdmin(s.TwoFactorHandler.HandleReset))
+	}
+
+	if s.SessionHandler != nil {
+		mux.Handle("GET /api/sessions", protect(s.SessionHandler.HandleList))
+		mux.Handle("DELETE /api/sessions", protect(s.SessionHandler.HandleRevokeAll))
+		mux.Handle("DELETE /api/sessions/{session}", protect(s.SessionHandler.HandleRevoke))
+		mux.Handle("GET /api/users/{id}/sessions", protectAdmin(s.SessionHandler.HandleList))
+		mux.Handle("DELETE /api/users/{id}/sessions", protectAdmin(s.SessionHandler.HandleRevokeAll))
+	}
+
+	if s.SSOHandler != nil {
+		// Public: these start and complete the login
+		mux.HandleFunc("GET /api/sso", s.SSOHandler.HandleStatus)
+		mux.Handle("GET /api/sso/login", middleware.RateLimitMiddleware(loginLimiter)(http.HandlerFunc(s.SSOHandler.HandleLogin)))
+		mux.Handle("GET /api/sso/callback", middleware.RateLimitMiddleware(loginLimiter)(http.HandlerFunc(s.SSOHandler.HandleCallback)))
+	}
+
+	if s.AgentHandler != nil {
+		mux.Handle("GET /api/agents", protect(s.AgentHandler.HandleListAgents))
+		mux.Handle("GET /api/agents/attacks", protect(s.AgentHandler.HandleListAttacks))
+		mux.Handle("POST /api/agents/attacks", protectTx(s.AgentHandler.HandleStartAttack))
+		mux.Handle("POST /api/agents/attacks/{id}/stop", protectTx(s.AgentHandler.HandleStopAttack))
+		mux.Handle("PUT /api/agents/{id}/config", protectOp(permit(domain.PermConfig, s.AgentHandler.HandlePushConfig)))
+	}
 
 	// Capture/Handshake Management
 	mux.Handle("/api/captures/open-folder", protect(http.HandlerFunc(s.CaptureHandler.HandleOpenHandshakeFolder)))
 
-	return mux
+	// Cross-origin browser requests are only served for the allowed origins
+	cors := middleware.CORSMiddleware(s.AllowedOrigins)
+	csrf := middleware.CSRFMiddleware(s.AllowedOrigins)
+	return cors(csrf(mux))
 }
@@ -4,7 +4,7 @@ import (
 	"context"
 	"log"
 	"net/http"
-
+	"sync/atomic"
 	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/adapters/reporting"
@@ -21,6 +21,8 @@ import (
 // Server handles HTTP and WebSocket connections.
 type Server struct {
 	Addr             string
+	Passive          bool     // Refuse active (transmitting) endpoints with 403
+	AllowedOrigins   []string // Origins besides the server's own allowed to use the API from a browser
 	Service          ports.NetworkService
 	WorkspaceManager *workspace.WorkspaceManager
 	AuthService      ports.AuthService
@@ -28,18 +30,59 @@ type Server struct {
 	WSManager        *web.WSManager
 	WPSHandler       *handlers.WPSHandler
 
-	DeauthHandler    *handlers.DeauthHandler
-	AuthFloodHandler *handlers.AuthFloodHandler
-	AuditHandler     *handlers.AuditHandler
-	ReportHandler    *handlers.ReportHandler
-	AuthHandler      *handlers.AuthHandler
-	ScanHandler      *handlers.ScanHandler
-	ConfigHandler    *handlers.ConfigHandler
-	WorkspaceHandler *handlers.WorkspaceHandler
-	ExportHandler    *handlers.ExportHandler
-	VulnHandler      *handlers.VulnerabilityHandler
-	CaptureHandler   *handlers.CaptureHandler
-	srv              *http.Server
+	DeauthHandler        *handlers.DeauthHandler
+	AuthFloodHandler     *handlers.AuthFloodHandler
+	ProbeFloodHandler    *handlers.ProbeFloodHandler
+	CSAHandler           *handlers.CSAHandler
+	BeaconHandler        *handlers.BeaconSpoofHandler
+	KarmaHandler         *handlers.KarmaHandler
+	NAVJamHandler        *handlers.NAVJamHandler
+	HistoryHandler       *handlers.AttackHistoryHandler
+	AuditHandler         *handlers.AuditHandler
+	ReportHandler        *handlers.ReportHandler
+	AuthHandler          *handlers.AuthHandler
+	ScanHandler          *handlers.ScanHandler
+	ConfigHandler        *handlers.ConfigHandler
+	WorkspaceHandler     *handlers.WorkspaceHandler
+	ExportHandler        *handlers.ExportHandler
+	VulnHandler          *handlers.VulnerabilityHandler
+	CaptureHandler       *handlers.CaptureHandler
+	LocatorHandler       *handlers.LocatorHandler
+	GeofenceHandler      *handlers.GeofenceHandler       // Optional, set when a geofence manager is available
+	BaselineHandler      *handlers.BaselineHandler       // Optional, set when a baseline manager is available
+	PSKAuditHandler      *handlers.PSKAuditHandler       // Optional, set when the cracking tools are configured
+	AutoCaptureHandler   *handlers.AutoCaptureHandler    // Optional, set when handshakes are captured
+	TargetHandler        *handlers.TargetHandler         // Optional, set when targets can be prioritized
+	JobHandler           *handlers.JobHandler            // Optional, set when the job queue is available
+	ArtifactHandler      *handlers.ArtifactHandler       // Optional, set when the artifact store is available
+	RecentCaptureHandler *handlers.RecentCaptureHandler  // Optional, set when adapters keep a frame history
+	ProtectedHandler     *handlers.ProtectedBSSIDHandler // Optional, set when a protected BSSID manager is available
+	DeauthReasonHandler  *handlers.DeauthReasonHandler   // Optional, set when deauth reason codes are aggregated
+	SignatureHandler     *handlers.SignatureHandler      // Optional, set when signature learning is available
+	DeviceHandler        *handlers.DeviceHandler         // Optional, set when devices can be managed
+	InventoryHandler     *handlers.InventoryHandler      // Optional, set when an asset inventory is synchronized
+	TicketHandler        *handlers.TicketHandler         // Optional, set when findings are filed in an issue tracker
+	BluetoothHandler     *handlers.BluetoothHandler      // Optional, set when a Bluetooth controller runs inquiry scans
+	ZigbeeHandler        *handlers.ZigbeeHandler         // Optional, set when an 802.15.4 sniffer dongle is attached
+	ScheduleHandler      *handlers.ScheduleHandler       // Optional, set when capture can be scheduled
+	PowerHandler         *handlers.PowerHandler          // Optional, set when capture can be duty cycled
+	InterfaceHandler     *handlers.InterfaceHandler      // Optional, set when capture interfaces can change at runtime
+	PortalHandler        *handlers.PortalHandler         // Optional, set when a managed interface checks captive portals
+	ReloadHandler        *handlers.ReloadHandler         // Optional, set when data files can be reloaded
+	PluginHandler        *handlers.PluginHandler         // Optional, set when external analyzers are loaded
+	HookHandler          *handlers.HookHandler           // Optional, set when scripting hooks are available
+	IngestHandler        *handlers.IngestHandler         // Optional, set when observations from other tools can be imported
+	CaptureImportHandler *handlers.CaptureImportHandler  // Optional, set when the handshake manager is available
+	AgentHandler         *handlers.AgentHandler          // Optional, set when agents can be commanded
+	APIKeyHandler        *handlers.APIKeyHandler         // Optional, set when API keys are stored
+	ShareHandler         *handlers.ShareHandler          // Optional, set when share links are stored
+	GraphQLHandler       *handlers.GraphQLHandler        // Optional, set when dashboards can query the registry
+	UserHandler          *handlers.UserHandler           // Optional, set when user accounts can be administered
+	TwoFactorHandler     *handlers.TwoFactorHandler      // Optional, set when users can enroll in two-factor authentication
+	SSOHandler           *handlers.SSOHandler            // Optional, set when an OpenID Connect provider is configured
+	SessionHandler       *handlers.SessionHandler        // Optional, set when sessions can be listed and revoked
+	srv                  *http.Server
+	routing              atomic.Pointer[domain.NotificationRouting] // Alerts pushed to clients, all when nil
 }
 
 // NewServer creates a new web server.
@@ -55,25 +98,33 @@ func NewServer(addr string, service ports.NetworkService, workspaceManager *work
 		AuthService:      authService,
 		AuditService:     auditService,
 
-		WSManager:        web.NewWSManager(service),
-		WPSHandler:       handlers.NewWPSHandler(service),
-		DeauthHandler:    handlers.NewDeauthHandler(service),
-		AuthFloodHandler: handlers.NewAuthFloodHandler(service),
-		AuditHandler:     handlers.NewAuditHandler(auditService),
-		ReportHandler:    reportHandler,
-		AuthHandler:      handlers.NewAuthHandler(authService),
-		ScanHandler:      handlers.NewScanHandler(service),
-		ConfigHandler:    handlers.NewConfigHandler(service),
-		WorkspaceHandler: handlers.NewWorkspaceHandler(service, workspaceManager),
-		ExportHandler:    handlers.NewExportHandler(service),
-		VulnHandler:      handlers.NewVulnerabilityHandler(vulnService),
-		CaptureHandler:   handlers.NewCaptureHandler(),
+		WSManager:         web.NewWSManager(service, authService),
+		WPSHandler:        handlers.NewWPSHandler(service),
+		DeauthHandler:     handlers.NewDeauthHandler(service),
+		AuthFloodHandler:  handlers.NewAuthFloodHandler(service),
+		ProbeFloodHandler: handlers.NewProbeFloodHandler(service),
+		CSAHandler:        handlers.NewCSAHandler(service),
+		BeaconHandler:     handlers.NewBeaconSpoofHandler(service),
+		KarmaHandler:      handlers.NewKarmaHandler(service),
+		NAVJamHandler:     handlers.NewNAVJamHandler(service),
+		HistoryHandler:    handlers.NewAttackHistoryHandler(service),
+		AuditHandler:      handlers.NewAuditHandler(auditService),
+		ReportHandler:     reportHandler,
+		AuthHandler:       handlers.NewAuthHandler(authService),
+		ScanHandler:       handlers.NewScanHandler(service),
+		ConfigHandler:     handlers.NewConfigHandler(service),
+		WorkspaceHandler:  handlers.NewWorkspaceHandler(service, workspaceManager),
+		ExportHandler:     handlers.NewExportHandler(service),
+		VulnHandler:       handlers.NewVulnerabilityHandler(vulnService),
+		CaptureHandler:    handlers.NewCaptureHandler(),
+		LocatorHandler:    handlers.NewLocatorHandler(service),
 	}
 }
 
 // Run starts the server and the broadcaster.
 func (s *Server) Run(ctx context.Context) error {
 	// Start WS Manager
+	s.WSManager.AllowedOrigins = s.AllowedOrigins
 	s.WSManager.Start(ctx)
 
 	// Setup Routes
@@ -112,7 +163,16 @@ func (s *Server) BroadcastLog(message string, level string) {
 	s.WSManager.BroadcastLog(message, level)
 }
 
-// BroadcastAlert sends an alert object to all connected clients
+// BroadcastAlert sends an alert object to all connected clients, unless the
+// notification routing of the workspace holds it back
 func (s *Server) BroadcastAlert(alert domain.Alert) {
+	if routing := s.routing.Load(); routing != nil && !routing.Routes(alert) {
+		return
+	}
 	s.WSManager.BroadcastAlert(alert)
 }
+
+// SetAlertRouting sets which alerts are pushed to connected clients
+func (s *Server) SetAlertRouting(routing domain.NotificationRouting) {
+	s.routing.Store(&routing)
+}
@@ -1,6 +1,114 @@
 // Running immediately as script is deferred/at end of body
 const form = document.getElementById('login-form');
 const errorMsg = document.getElementById('error-msg');
+const otpGroup = document.getElementById('otp-group');
+const newPasswordGroup = document.getElementById('new-password-group');
+const totpSetup = document.getElementById('totp-setup');
+
+// Login steps: credentials (and a TOTP code when enrolled), then the password
+// change and two-factor enrollment the server may require before the session
+// is usable
+let stage = 'login';
+let mustEnroll = false;
+
+async function postJSON(url, payload) {
+    const response = await fetch(url, {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify(payload)
+    });
+    const body = await response.json().catch(() => ({}));
+    return { response, body };
+}
+
+function show(message) {
+    errorMsg.innerText = message;
+    errorMsg.style.opacity = '1';
+}
+
+async function startEnrollment() {
+    const { response, body } = await postJSON('/api/2fa/enroll', {});
+    if (!response.ok) {
+        throw new Error((body.error || 'ENROLLMENT FAILED').toUpperCase());
+    }
+    stage = 'enroll';
+    newPasswordGroup.hidden = true;
+    otpGroup.hidden = false;
+    document.getElementById('otp').value = '';
+    document.getElementById('totp-secret').innerText = body.secret;
+    document.getElementById('totp-link').href = body.otpauth_url;
+    totpSetup.hidden = false;
+    show('TWO-FACTOR AUTHENTICATION REQUIRED FOR ADMINS');
+}
+
+function continueSession() {
+    if (mustEnroll) {
+        return startEnrollment();
+    }
+    window.location.href = '/';
+}
+
+const steps = {
+    async login(username, password) {
+        const otp = document.getElementById('otp').value;
+        const { response, body } = await postJSON('/api/login', { username, password, otp });
+
+        if (response.ok) {
+            mustEnroll = body.must_enroll_totp;
+            if (body.must_change_password) {
+                stage = 'password';
+                otpGroup.hidden = true;
+                newPasswordGroup.hidden = false;
+                document.getElementById('new-password').required = true;
+                throw new Error('PASSWORD CHANGE REQUIRED: CHOOSE A NEW ACCESS KEY');
+            }
+            return continueSession();
+        }
+        if (body.code === 'totp_required') {
+            otpGroup.hidden = false;
+            document.getElementById('otp').focus();
+            throw new Error('ENTER THE CODE FROM YOUR AUTHENTICATOR OR A RECOVERY CODE');
+        }
+        if (response.status === 429) {
+            throw new Error('ACCESS DENIED: TOO MANY ATTEMPTS, TRY AGAIN LATER');
+        }
+        throw new Error('ACCESS DENIED: INVALID CREDENTIALS');
+    },
+
+    async password(username, password) {
+        const next = document.getElementById('new-password').value;
+        const { response, body } = await postJSON('/api/password', { current_password: password, new_password: next });
+        if (!response.ok) {
+            throw new Error((body.error || 'PASSWORD CHANGE FAILED').toUpperCase());
+        }
+        return continueSession();
+    },
+
+    async enroll() {
+        const code = document.getElementById('otp').value;
+        const { response, body } = await postJSON('/api/2fa/confirm', { code });
+        if (!response.ok) {
+            throw new Error((body.error || 'INVALID CODE').toUpperCase());
+        }
+        stage = 'recovery';
+        otpGroup.hidden = true;
+        document.getElementById('totp-link').hidden = true;
+        totpSetup.querySelector('p').innerText = 'Store these recovery codes safely. Each works once if you lose your authenticator.';
+        document.getElementById('totp-secret').innerText = body.recovery_codes.join('\n');
+        show('');
+    },
+
+    async recovery() {
+        window.location.href = '/';
+    }
+};
+
+const buttonLabels = {
+    login: 'Initialize Link',
+    password: 'Update Access Key',
+    enroll: 'Verify Code',
+    recovery: 'Continue'
+};
 
 if (form) {
     form.addEventListener('submit', async (e) => {
@@ -14,26 +122,27 @@ if (form) {
         errorMsg.style.opacity = '0';
 
         try {
-            const response = await fetch('/api/login', {
-                method: 'POST',
-                headers: { 'Content-Type': 'application/json' },
-                body: JSON.stringify({ username, password })
-            });
-
-            if (response.ok) {
-                window.location.href = '/';
-            } else {
-                throw new Error('Invalid credentials');
-            }
+            await steps[stage](username, password);
         } catch (err) {
-            errorMsg.innerText = "ACCESS DENIED: INVALID CREDENTIALS";
-            errorMsg.style.opacity = '1';
-            btn.innerText = "Initialize Link";
-            btn.disabled = false;
+            show(err.message);
         }
+        btn.innerText = buttonLabels[stage];
+        btn.disabled = false;
     });
 }
 
+// Single sign-on, when an identity provider is configured
+fetch('/api/sso').then(response => {
+    if (response.ok) {
+        document.getElementById('sso-login').hidden = false;
+    }
+}).catch(() => {});
+
+const ssoError = new URLSearchParams(window.location.search).get('sso_error');
+if (ssoError) {
+    show('SSO FAILED: ' + ssoError.toUpperCase());
+}
+
 // Logout Helper (exposed globally if this script is included in main app)
 window.logout = async () => {
     try {
@@ -21,8 +21,8 @@ export const API = {
             }
 
             if (res.status === 403) {
-                const text = await res.text();
-                throw Object.assign(new Error(text || 'Forbidden'), { status: 403 });
+                const { message, code } = await this.readError(res);
+                throw Object.assign(new Error(message || 'Forbidden'), { status: 403, code });
             }
 
             if (res.status === 429) {
@@ -30,10 +30,10 @@ export const API = {
             }
 
             if (!res.ok) {
-                const text = await res.text();
+                const { message, code } = await this.readError(res);
                 throw Object.assign(
-                    new Error(text || `Request failed: ${res.statusText}`),
-                    { status: res.status }
+                    new Error(message || `Request failed: ${res.statusText}`),
+                    { status: res.status, code }
                 );
             }
 
@@ -47,6 +47,23 @@ export const API = {
         }
     },
 
+    /**
+     * Reads the message and machine-readable code of a failed response.
+     * Errors from the domain come as JSON {error, code}; others as plain text.
+     */
+    async readError(res) {
+        const text = await res.text();
+        if ((res.headers.get('Content-Type') || '').includes('application/json')) {
+            try {
+                const body = JSON.parse(text);
+                return { message: body.error || text, code: body.code };
+            } catch (e) {
+                // Fall through to the raw text
+            }
+        }
+        return { message: text.trim(), code: undefined };
+    },
+
     async get(endpoint) {
         return this.request(endpoint);
     },
@@ -161,6 +178,16 @@ export const API = {
         });
     },
 
+    // Targets in scope, ranked by attack value
+    async getTargets(limit = 0) {
+        return this.get(limit > 0 ? `/api/targets?limit=${limit}` : '/api/targets');
+    },
+
+    // Signature learning
+    async labelDevice(mac, label) {
+        return this.post(`/api/devices/${encodeURIComponent(mac)}/label`, label);
+    },
+
     // Captures
     async openHandshakeFolder(mac) {
         return this.post('/api/captures/open-folder', { mac });
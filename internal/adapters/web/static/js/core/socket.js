@@ -1,6 +1,8 @@
 /**
  * WMAP WebSocket Client
- * Handles real-time data updates.
+ * Handles real-time data updates. Falls back to server-sent events
+ * (/api/events) when the WebSocket cannot be opened, e.g. behind proxies
+ * that block the upgrade.
  */
 
 import { Actions } from './store/actions.js';
@@ -9,6 +11,8 @@ import { Store } from './store/store.js';
 export class SocketClient {
     constructor() {
         this.socket = null;
+        this.events = null;
+        this.everOpened = false;
         this.reconnectAttempts = 0;
         this.maxReconnectAttempts = 5;
     }
@@ -21,6 +25,7 @@ export class SocketClient {
 
         this.socket.onopen = () => {
             console.log("WebSocket connected");
+            this.everOpened = true;
             this.reconnectAttempts = 0;
             Store.dispatch(Actions.SOCKET_CONNECTED);
         };
@@ -37,6 +42,10 @@ export class SocketClient {
         this.socket.onclose = () => {
             console.log("WebSocket disconnected");
             Store.dispatch(Actions.SOCKET_DISCONNECTED);
+            if (!this.everOpened && typeof EventSource !== 'undefined') {
+                this.connectEvents();
+                return;
+            }
             this.handleReconnect();
         };
 
@@ -46,6 +55,33 @@ export class SocketClient {
         };
     }
 
+    // connectEvents streams the same messages over server-sent events. The
+    // browser reconnects the stream by itself.
+    connectEvents() {
+        console.log("WebSocket unavailable, falling back to server-sent events");
+        Store.dispatch(Actions.SOCKET_CONNECTING);
+
+        this.events = new EventSource('/api/events');
+
+        this.events.onopen = () => {
+            console.log("Event stream connected");
+            Store.dispatch(Actions.SOCKET_CONNECTED);
+        };
+
+        this.events.onmessage = (event) => {
+            try {
+                this.handleMessage(JSON.parse(event.data));
+            } catch (e) {
+                console.error("Failed to parse event stream message", e);
+            }
+        };
+
+        this.events.onerror = (error) => {
+            console.error("Event stream error", error);
+            Store.dispatch(Actions.SOCKET_DISCONNECTED);
+        };
+    }
+
     handleMessage(msg) {
         // Dispatch specific actions based on message type
         // This replaces the switch statement in main.js
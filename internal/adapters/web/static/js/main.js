@@ -330,6 +330,10 @@ class App {
             Notifications.show(`${icon} ${alert.message}`, severity);
             this.console.log(`[ALERT] ${alert.message}`, severity);
         }
+
+        if (alert.evidence_id) {
+            this.console.log(`[EVIDENCE] Frames saved as artifact ${alert.evidence_id}`, "info");
+        }
     }
 
     async loadUser() {
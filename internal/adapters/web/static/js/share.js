@@ -0,0 +1,67 @@
+// Read-only view of a shared workspace. The token in the link is the only
+// credential; it is sent back on every request and never stored.
+const token = new URLSearchParams(window.location.search).get('token') || '';
+const status = document.getElementById('share-status');
+
+async function fetchShared(view) {
+    const response = await fetch(`/api/share/${view}?token=${encodeURIComponent(token)}`);
+    if (!response.ok) {
+        throw new Error(response.status === 403 ? 'This link is invalid or has expired.' : 'Could not load the assessment.');
+    }
+    return response.json();
+}
+
+// Captured values (SSIDs, vendors) are set as text, never as markup
+function row(cells) {
+    const tr = document.createElement('tr');
+    for (const value of cells) {
+        const td = document.createElement('td');
+        td.textContent = value ?? '';
+        tr.appendChild(td);
+    }
+    return tr;
+}
+
+function stat(label, value) {
+    const div = document.createElement('div');
+    div.className = 'share-stat glass';
+    const strong = document.createElement('strong');
+    strong.textContent = value;
+    div.append(strong, ' ' + label);
+    return div;
+}
+
+function formatTime(value) {
+    return value ? new Date(value).toLocaleString() : '';
+}
+
+async function refresh() {
+    try {
+        const [report, alerts, devices] = await Promise.all([
+            fetchShared('report'), fetchShared('alerts'), fetchShared('devices')
+        ]);
+
+        document.getElementById('share-workspace').textContent = report.workspace;
+        status.textContent = `Read-only view, updated ${formatTime(report.generated_at)}. Link expires ${formatTime(report.expires_at)}.`;
+
+        const summary = document.getElementById('share-summary');
+        summary.replaceChildren(
+            stat('access points', report.ap_count),
+            stat('clients', report.client_count),
+            stat('alerts', alerts.alerts.length),
+            ...Object.entries(report.vulnerabilities).map(([severity, count]) => stat(`${severity} findings`, count))
+        );
+
+        document.getElementById('share-alerts').replaceChildren(...alerts.alerts
+            .slice().reverse()
+            .map(a => row([formatTime(a.timestamp), a.severity, a.device_mac, a.message])));
+        document.getElementById('share-devices').replaceChildren(...devices.devices
+            .map(d => row([d.mac, d.type, d.ssid, d.vendor, d.security, formatTime(d.last_seen)])));
+    } catch (err) {
+        status.textContent = err.message;
+        clearInterval(timer);
+    }
+}
+
+const timer = setInterval(refresh, 30000);
+refresh();
@@ -81,6 +81,18 @@ export class ContextMenu {
                     });
                 });
                 break;
+            case 'label':
+                Modals.prompt("Label Make / Model (e.g. Apple / iPhone 15)", (val) => {
+                    if (!val) return;
+                    const [first, ...rest] = val.split('/').map(s => s.trim());
+                    const label = rest.length ? { vendor: first, model: rest.join(' / ') } : { model: first };
+                    API.labelDevice(node.id, label).then(() => {
+                        Notifications.show(`Labeled as ${val}, signature learned`, "success");
+                    }).catch(err => {
+                        Notifications.show(`Labeling failed: ${err.message}`, "danger");
+                    });
+                });
+                break;
             case 'copy':
                 navigator.clipboard.writeText(node.id).then(() => {
                     Notifications.show("MAC Address Copied", "success");
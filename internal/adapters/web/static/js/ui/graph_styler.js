@@ -118,6 +118,10 @@ export const GraphStyler = {
         } else if (e.type === 'inferred') {
             color = 'rgba(50, 215, 75, 0.5)';
             width = 2;
+        } else if (e.type === 'probed_for') {
+            // PNL exposure: thicker for SSIDs probed for more often
+            color = 'rgba(175, 82, 222, 0.5)';
+            width = 1 + Math.min(Math.log2(e.count || 1), 4);
         }
 
         // Use backend provided color if available
@@ -133,6 +137,7 @@ export const GraphStyler = {
         if (!edgeLabel) {
             if (e.type === 'connection') edgeLabel = '✓';
             else if (e.type === 'probe') edgeLabel = '?';
+            else if (e.type === 'probed_for') edgeLabel = e.count > 1 ? `?×${e.count}` : '?';
             else if (e.type === 'correlation') edgeLabel = '≈';
         }
 
@@ -142,6 +147,7 @@ export const GraphStyler = {
             to: e.to,
             dashes: e.dashed || false,
             label: edgeLabel,
+            title: e.type === 'probed_for' && e.last_seen ? `Probed ${e.count}× · last ${new Date(e.last_seen).toLocaleString()}` : undefined,
             width: width,
             color: { color: color, highlight: color },
             font: { size: 10, align: 'middle', color: '#888' }
@@ -294,6 +294,8 @@ export const HUDTemplates = {
 
         const tx = formatters.formatBytes(node.data_tx || 0);
         const rx = formatters.formatBytes(node.data_rx || 0);
+        const txRate = formatters.formatBytes(Math.round(node.tx_rate || 0)) + '/s';
+        const rxRate = formatters.formatBytes(Math.round(node.rx_rate || 0)) + '/s';
         const packets = node.packets || 0;
 
         const seenFirst = formatters.timeAgo(node.first_seen);
@@ -325,6 +327,11 @@ export const HUDTemplates = {
                     <span class="label" style="margin-left: 0;">Network</span>
                     <span class="value">${ssid}</span>
                 </div>
+                ${node.group === NodeGroups.AP ? `
+                <div class="summary-row">
+                    <span class="label" style="margin-left: 0;">Clients</span>
+                    <span class="value">${node.clientCount || 0} associated${node.probingClientCount ? ` · ${node.probingClientCount} probing` : ''}</span>
+                </div>` : ''}
                  <div class="summary-row">
                     <span class="label" style="margin-left: 0;">Security</span>
                     <span class="value">
@@ -364,16 +371,21 @@ export const HUDTemplates = {
                 <div class="section-title">TRAFFIC STATS</div>
                  <div class="summary-row">
                      <span class="label" style="margin-left:0">TX Data</span>
-                     <span class="value">${tx}</span>
+                     <span class="value">${tx} <span style="opacity:0.6">(${txRate})</span></span>
                 </div>
                  <div class="summary-row">
                      <span class="label" style="margin-left:0">RX Data</span>
-                     <span class="value">${rx}</span>
+                     <span class="value">${rx} <span style="opacity:0.6">(${rxRate})</span></span>
                 </div>
                  <div class="summary-row">
                      <span class="label" style="margin-left:0">Packets</span>
                      <span class="value">${packets}</span>
                 </div>
+                ${node.link_quality ? `
+                 <div class="summary-row">
+                     <span class="label" style="margin-left:0">${node.group === NodeGroups.AP ? 'Cell Quality' : 'Link Quality'}</span>
+                     <span class="value" style="${node.link_quality === 'poor' ? 'color:var(--danger-color)' : ''}">${node.link_quality} (${Math.round((node.retry_rate || 0) * 100)}% retries)</span>
+                </div>` : ''}
             </div>
 
              <div class="sidebar-section">
@@ -405,6 +405,7 @@ const SecurityReportHTML = `
                         <th>Vendor</th>
                         <th>Signal</th>
                         <th>Security</th>
+                        <th>Link</th>
                         <th>SSID / Notes</th>
                     </tr>
                 </thead>
@@ -427,7 +428,13 @@ const SecurityReportHTML = `
                             {{else if eq .Security "WEP"}}<span class="badge high">WEP</span>
                             {{else}}<span style="color: var(--text-secondary);">{{.Security}}</span>{{end}}
                         </td>
-                        <td>{{if .SSID}}<strong>{{.SSID}}</strong>{{end}}</td>
+                        <td>
+                            {{if .Link}}{{if eq .Link.Quality "poor"}}<span class="badge high">POOR</span>
+                            {{else}}<span style="color: var(--text-secondary);">{{.Link.Quality}}</span>{{end}}
+                            <span style="color: #64748b; font-size: 11px;">({{printf "%.0f" .Link.RetryPercent}}% retries)</span>
+                            {{else}}<span style="color: var(--text-secondary);">-</span>{{end}}
+                        </td>
+                        <td>{{if .SSID}}<strong>{{.SSID}}</strong>{{end}}{{if eq .Type "ap"}} <span style="color: var(--text-secondary);">({{.ClientCount}} clients)</span>{{end}}</td>
                     </tr>
                     {{end}}
                 </tbody>
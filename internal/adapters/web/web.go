@@ -10,6 +10,6 @@ import (
 type WSManager = websocket.WSManager
 
 // NewWSManager creates a new WSManager
-func NewWSManager(service ports.NetworkService) *WSManager {
-	return websocket.NewWSManager(service)
+func NewWSManager(service ports.NetworkService, auth ports.AuthService) *WSManager {
+	return websocket.NewWSManager(service, auth)
 }
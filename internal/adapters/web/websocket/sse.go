@@ -0,0 +1,87 @@
+package web
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// sseHeartbeat is how often an idle event stream gets a comment, so proxies
+// do not time it out.
+const sseHeartbeat = 15 * time.Second
+
+// sseBuffer is how many messages a slow event stream may fall behind before
+// messages are dropped for it.
+const sseBuffer = 64
+
+// HandleSSE streams the broadcasts as server-sent events, for clients behind
+// proxies that block WebSockets. Each event carries the same {type, payload}
+// message as the WebSocket. The route authenticates the request, so scripts
+// may also use an API key.
+func (m *WSManager) HandleSSE(w http.ResponseWriter, r *http.Request) {
+	user, ok := domain.UserFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	stream := make(chan []byte, sseBuffer)
+	m.mu.Lock()
+	m.streams[stream] = user
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.streams, stream)
+		m.mu.Unlock()
+		log.Printf("Event stream closed: user=%s", user.Username)
+	}()
+	log.Printf("Event stream opened: user=%s, role=%s", user.Username, user.Role)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Keep nginx from buffering the stream
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("retry: 2000\n\n"))
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-stream:
+			if _, err := w.Write(append(append([]byte("data: "), data...), '\n', '\n')); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := w.Write([]byte(": ping\n\n")); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// publish queues a message on the event streams of the users allowed to get
+// it. Streams too far behind miss it rather than holding up the broadcast.
+// The caller holds m.mu.
+func (m *WSManager) publish(data []byte, required domain.Role, restricted bool) {
+	for stream, user := range m.streams {
+		if restricted && !user.Role.Allows(required) {
+			continue
+		}
+		select {
+		case stream <- data:
+		default:
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package web
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openStream opens an event stream as user, as the router would after
+// authenticating the request, and returns its reader.
+func openStream(t *testing.T, m *WSManager, user *domain.User) *bufio.Reader {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.HandleSSE(w, r.WithContext(domain.ContextWithUser(r.Context(), user)))
+	}))
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewReader(resp.Body)
+}
+
+// waitStreams waits for the manager to register n event streams.
+func waitStreams(t *testing.T, m *WSManager, n int) {
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return len(m.streams) == n
+	}, time.Second, 10*time.Millisecond)
+}
+
+// readEvent returns the message of the next data event.
+func readEvent(t *testing.T, r *bufio.Reader) WSMessage {
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			var msg WSMessage
+			require.NoError(t, json.Unmarshal([]byte(data), &msg))
+			return msg
+		}
+	}
+}
+
+func TestHandleSSE_RequiresUser(t *testing.T) {
+	m, _ := newTestServer(t)
+	rec := httptest.NewRecorder()
+	m.HandleSSE(rec, httptest.NewRequest(http.MethodGet, "/api/events", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestHandleSSE_MirrorsBroadcasts(t *testing.T) {
+	m, _ := newTestServer(t)
+	operator := openStream(t, m, &domain.User{Username: "alice", Role: domain.RoleOperator})
+	viewer := openStream(t, m, &domain.User{Username: "bob", Role: domain.RoleViewer})
+	waitStreams(t, m, 2)
+
+	m.BroadcastWPSStatus(domain.WPSAttackStatus{ID: "wps-1", RecoveredPSK: "hunter22"})
+	m.BroadcastAlert(domain.Alert{ID: "alert-1"})
+
+	assert.Equal(t, "wps.status", readEvent(t, operator).Type)
+	msg := readEvent(t, operator)
+	assert.Equal(t, "alert", msg.Type)
+	assert.Equal(t, "alert-1", msg.Payload.(map[string]any)["id"])
+
+	// The viewer gets the alert but never the recovered key
+	assert.Equal(t, "alert", readEvent(t, viewer).Type)
+}
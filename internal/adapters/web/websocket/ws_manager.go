@@ -3,8 +3,10 @@ package web
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,33 +16,16 @@ import (
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		origin := r.Header.Get("Origin")
+// authTimeout is how long a client connecting without a token has to send
+// its auth message.
+const authTimeout = 10 * time.Second
 
-		// Allow same-origin (no Origin header)
-		if origin == "" {
-			return true
-		}
-
-		// Allowed origins
-		allowedOrigins := []string{
-			"http://localhost:8080",
-			"http://127.0.0.1:8080",
-			"http://[::1]:8080",
-		}
-
-		for _, allowed := range allowedOrigins {
-			if origin == allowed {
-				return true
-			}
-		}
-
-		log.Printf("WebSocket: Rejected origin: %s", origin)
-		return false
-	},
+// messageRoles is the least role receiving each message type. Other types go
+// to every authenticated client.
+var messageRoles = map[string]domain.Role{
+	"log":        domain.RoleOperator, // Attack engine logs, may carry recovered keys
+	"wps.log":    domain.RoleOperator,
+	"wps.status": domain.RoleOperator, // Recovered PIN and PSK
 }
 
 type WSMessage struct {
@@ -49,15 +34,20 @@ type WSMessage struct {
 }
 
 type WSManager struct {
-	Service ports.NetworkService
-	Clients map[*websocket.Conn]*domain.User
-	mu      sync.Mutex
+	Service        ports.NetworkService
+	Auth           ports.AuthService
+	AllowedOrigins []string // Origins besides the server's own allowed to connect from a browser
+	Clients        map[*websocket.Conn]*domain.User
+	streams        map[chan []byte]*domain.User // Server-sent event subscribers
+	mu             sync.Mutex
 }
 
-func NewWSManager(service ports.NetworkService) *WSManager {
+func NewWSManager(service ports.NetworkService, auth ports.AuthService) *WSManager {
 	return &WSManager{
 		Service: service,
+		Auth:    auth,
 		Clients: make(map[*websocket.Conn]*domain.User),
+		streams: make(map[chan []byte]*domain.User),
 	}
 }
 
@@ -65,20 +55,55 @@ func (m *WSManager) Start(ctx context.Context) {
 	go m.processAndBroadcast(ctx)
 }
 
+// checkOrigin refuses browsers connecting from other origins than the
+// server's own and the allowed ones, so that other sites cannot open a
+// socket with the session cookie. Clients without an Origin are not browsers.
+func (m *WSManager) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || middleware.OriginAllowed(r, origin, m.AllowedOrigins) {
+		return true
+	}
+	log.Printf("WebSocket: Rejected origin: %s", origin)
+	return false
+}
+
+// HandleWebSocket authenticates the client and subscribes it to broadcasts.
+// The session token comes from the auth cookie, a Bearer header or the token
+// query parameter; without one, the first message must be
+// {"type": "auth", "payload": {"token": "..."}}.
 func (m *WSManager) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
-	// Extract user from context (set by AuthMiddleware)
-	user, ok := r.Context().Value(middleware.UserContextKey).(*domain.User)
-	if !ok || user == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
-		return
+	var user *domain.User
+	if token := requestToken(r); token != "" {
+		validated, err := m.validateToken(r.Context(), token)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		user = validated
 	}
 
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     m.checkOrigin,
+	}
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Println("Upgrade error:", err)
 		return
 	}
 
+	if user == nil {
+		if user, err = m.authenticate(r.Context(), conn); err != nil {
+			log.Printf("WebSocket: authentication failed from %s: %v", r.RemoteAddr, err)
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "authentication required"),
+				time.Now().Add(time.Second))
+			conn.Close()
+			return
+		}
+	}
+
 	m.mu.Lock()
 	m.Clients[conn] = user
 	m.mu.Unlock()
@@ -103,6 +128,63 @@ func (m *WSManager) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	}()
 }
 
+// requestToken returns the session token sent with the upgrade request.
+func requestToken(r *http.Request) string {
+	if cookie, err := r.Cookie("auth_token"); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		return strings.TrimPrefix(header, "Bearer ")
+	}
+	return r.URL.Query().Get("token")
+}
+
+// authenticate validates the token of the client's first message, which must
+// arrive within authTimeout.
+func (m *WSManager) authenticate(ctx context.Context, conn *websocket.Conn) (*domain.User, error) {
+	conn.SetReadDeadline(time.Now().Add(authTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	var msg struct {
+		Type    string `json:"type"`
+		Payload struct {
+			Token string `json:"token"`
+		} `json:"payload"`
+	}
+	if err := conn.ReadJSON(&msg); err != nil {
+		return nil, err
+	}
+	if msg.Type != "auth" || msg.Payload.Token == "" {
+		return nil, fmt.Errorf("expected an auth message, got %q", msg.Type)
+	}
+	user, err := m.validateToken(ctx, msg.Payload.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	if err := conn.WriteJSON(WSMessage{Type: "auth", Payload: map[string]string{"username": user.Username, "role": string(user.Role)}}); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// validateToken returns the user of a session, refusing users who must
+// change their password or enroll in two-factor authentication first.
+func (m *WSManager) validateToken(ctx context.Context, token string) (*domain.User, error) {
+	user, err := m.Auth.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if user.MustChangePassword {
+		return nil, domain.ErrPasswordChange
+	}
+	if tfa, ok := m.Auth.(ports.TwoFactorManager); ok && tfa.EnrollmentRequired(user) {
+		return nil, domain.ErrTOTPEnrollment
+	}
+	return user, nil
+}
+
 func (m *WSManager) processAndBroadcast(ctx context.Context) {
 	ticker := time.NewTicker(2 * time.Second) // "Sweep" every 2 seconds
 	defer ticker.Stop()
@@ -179,6 +261,24 @@ func (m *WSManager) BroadcastWPSStatus(status domain.WPSAttackStatus) {
 	m.broadcastMessage(msg)
 }
 
+// BroadcastLocatorReading sends a device locator update to all connected clients
+func (m *WSManager) BroadcastLocatorReading(reading domain.LocatorReading) {
+	msg := WSMessage{
+		Type:    "locator:update",
+		Payload: reading,
+	}
+	m.broadcastMessage(msg)
+}
+
+// BroadcastJob sends a background job state or progress update to all connected clients
+func (m *WSManager) BroadcastJob(job domain.Job) {
+	msg := WSMessage{
+		Type:    "job:update",
+		Payload: job,
+	}
+	m.broadcastMessage(msg)
+}
+
 // NotifyNewVulnerability broadcasts a new vulnerability detection.
 func (m *WSManager) NotifyNewVulnerability(ctx context.Context, vuln domain.VulnerabilityRecord) {
 	msg := WSMessage{
@@ -204,13 +304,19 @@ func (m *WSManager) broadcastMessage(msg WSMessage) {
 		return
 	}
 
+	required, restricted := messageRoles[msg.Type]
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	for conn := range m.Clients {
+	for conn, user := range m.Clients {
+		if restricted && !user.Role.Allows(required) {
+			continue
+		}
 		conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
 		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
 			conn.Close()
 			delete(m.Clients, conn)
 		}
 	}
+	m.publish(data, required, restricted)
 }
@@ -0,0 +1,118 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenAuth knows a fixed set of session tokens.
+type tokenAuth map[string]*domain.User
+
+func (a tokenAuth) Login(ctx context.Context, creds domain.Credentials) (string, error) {
+	return "", errors.New("not supported")
+}
+
+func (a tokenAuth) ValidateToken(ctx context.Context, token string) (*domain.User, error) {
+	if user, ok := a[token]; ok {
+		return user, nil
+	}
+	return nil, errors.New("invalid token")
+}
+
+func (a tokenAuth) Logout(ctx context.Context, token string) error { return nil }
+
+func (a tokenAuth) CreateUser(ctx context.Context, user domain.User, password string) error {
+	return nil
+}
+
+func newTestServer(t *testing.T) (*WSManager, string) {
+	m := NewWSManager(nil, tokenAuth{
+		"op-token":     {Username: "alice", Role: domain.RoleOperator},
+		"viewer-token": {Username: "bob", Role: domain.RoleViewer},
+	})
+	srv := httptest.NewServer(http.HandlerFunc(m.HandleWebSocket))
+	t.Cleanup(srv.Close)
+	return m, "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// waitClients waits for the manager to register n clients.
+func waitClients(t *testing.T, m *WSManager, n int) {
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return len(m.Clients) == n
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHandleWebSocket_Authentication(t *testing.T) {
+	m, url := newTestServer(t)
+
+	t.Run("Rejects an invalid query token", func(t *testing.T) {
+		_, resp, err := websocket.DefaultDialer.Dial(url+"?token=bogus", nil)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Accepts a query token", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(url+"?token=op-token", nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		waitClients(t, m, 1)
+	})
+
+	t.Run("Accepts a token in the first message", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteJSON(map[string]any{"type": "auth", "payload": map[string]string{"token": "viewer-token"}}))
+		var ack WSMessage
+		require.NoError(t, conn.ReadJSON(&ack))
+		assert.Equal(t, "auth", ack.Type)
+	})
+
+	t.Run("Closes the connection on a bad first message", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteJSON(map[string]any{"type": "auth", "payload": map[string]string{"token": "bogus"}}))
+		_, _, err = conn.ReadMessage()
+		assert.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), "got %v", err)
+	})
+}
+
+func TestBroadcast_FiltersByRole(t *testing.T) {
+	m, url := newTestServer(t)
+
+	operator, _, err := websocket.DefaultDialer.Dial(url+"?token=op-token", nil)
+	require.NoError(t, err)
+	defer operator.Close()
+	viewer, _, err := websocket.DefaultDialer.Dial(url+"?token=viewer-token", nil)
+	require.NoError(t, err)
+	defer viewer.Close()
+	waitClients(t, m, 2)
+
+	m.BroadcastWPSStatus(domain.WPSAttackStatus{ID: "wps-1", RecoveredPSK: "hunter22"})
+	m.BroadcastAlert(domain.Alert{ID: "alert-1"})
+
+	var msg WSMessage
+	require.NoError(t, operator.ReadJSON(&msg))
+	assert.Equal(t, "wps.status", msg.Type)
+	require.NoError(t, operator.ReadJSON(&msg))
+	assert.Equal(t, "alert", msg.Type)
+
+	// The viewer gets the alert but never the recovered key
+	require.NoError(t, viewer.ReadJSON(&msg))
+	assert.Equal(t, "alert", msg.Type)
+}
@@ -0,0 +1,158 @@
+package zigbee
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// ErrShortFrame is returned for a frame truncated before its addressing fields.
+var ErrShortFrame = errors.New("802.15.4 frame too short")
+
+// Addressing modes of the frame control field
+const (
+	addrModeNone     = 0
+	addrModeShort    = 2
+	addrModeExtended = 3
+)
+
+// macCmdDataRequest is the MAC command polling a parent for pending data.
+const macCmdDataRequest = 0x04
+
+// zigbeeProtocolID starts the beacon payload of Zigbee networks.
+const zigbeeProtocolID = 0x00
+
+// ParseFrame decodes the MAC header of an IEEE 802.15.4 frame, without its
+// FCS, and the Zigbee payload of beacons.
+func ParseFrame(data []byte) (domain.ZigbeeFrame, error) {
+	var frame domain.ZigbeeFrame
+	if len(data) < 3 {
+		return frame, ErrShortFrame
+	}
+	fc := binary.LittleEndian.Uint16(data[0:2])
+	switch fc & 0x07 {
+	case 0:
+		frame.Type = domain.ZigbeeFrameBeacon
+	case 1:
+		frame.Type = domain.ZigbeeFrameData
+	case 2:
+		frame.Type = domain.ZigbeeFrameAck
+	case 3:
+		frame.Type = domain.ZigbeeFrameCommand
+	default:
+		frame.Type = domain.ZigbeeFrameOther
+	}
+	frame.Encrypted = fc&(1<<3) != 0
+	panCompression := fc&(1<<6) != 0
+	dstMode := int(fc>>10) & 0x03
+	srcMode := int(fc>>14) & 0x03
+	frame.Sequence = int(data[2])
+
+	rest := data[3:]
+	var err error
+	if dstMode != addrModeNone {
+		if len(rest) < 2 {
+			return frame, ErrShortFrame
+		}
+		frame.DstPAN = binary.LittleEndian.Uint16(rest)
+		rest = rest[2:]
+		if frame.DstAddr, rest, err = readAddress(rest, dstMode); err != nil {
+			return frame, err
+		}
+	}
+	if srcMode != addrModeNone {
+		if panCompression && dstMode != addrModeNone {
+			frame.SrcPAN = frame.DstPAN
+		} else {
+			if len(rest) < 2 {
+				return frame, ErrShortFrame
+			}
+			frame.SrcPAN = binary.LittleEndian.Uint16(rest)
+			rest = rest[2:]
+		}
+		if frame.SrcAddr, rest, err = readAddress(rest, srcMode); err != nil {
+			return frame, err
+		}
+	}
+
+	// Secured payloads cannot be read without the network key
+	if frame.Encrypted {
+		return frame, nil
+	}
+	switch frame.Type {
+	case domain.ZigbeeFrameCommand:
+		frame.DataRequest = len(rest) > 0 && rest[0] == macCmdDataRequest
+	case domain.ZigbeeFrameBeacon:
+		frame.Beacon = parseBeacon(rest)
+	}
+	return frame, nil
+}
+
+// readAddress reads a short or extended address, both little endian.
+func readAddress(data []byte, mode int) (string, []byte, error) {
+	switch mode {
+	case addrModeShort:
+		if len(data) < 2 {
+			return "", nil, ErrShortFrame
+		}
+		return fmt.Sprintf("0x%04x", binary.LittleEndian.Uint16(data)), data[2:], nil
+	case addrModeExtended:
+		if len(data) < 8 {
+			return "", nil, ErrShortFrame
+		}
+		return formatExtended(data[:8]), data[8:], nil
+	}
+	return "", nil, fmt.Errorf("reserved 802.15.4 addressing mode %d", mode)
+}
+
+// formatExtended formats a little endian EUI-64 most significant byte first.
+func formatExtended(b []byte) string {
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x:%02x:%02x", b[7], b[6], b[5], b[4], b[3], b[2], b[1], b[0])
+}
+
+// parseBeacon decodes the superframe specification of a beacon, skipping
+// the GTS and pending address fields, and the Zigbee beacon payload.
+func parseBeacon(data []byte) *domain.ZigbeeBeacon {
+	if len(data) < 4 {
+		return nil
+	}
+	superframe := binary.LittleEndian.Uint16(data)
+	beacon := &domain.ZigbeeBeacon{
+		PANCoordinator: superframe&(1<<14) != 0,
+		PermitJoining:  superframe&(1<<15) != 0,
+	}
+	rest := data[2:]
+
+	gtsCount := int(rest[0] & 0x07)
+	rest = rest[1:]
+	if gtsCount > 0 {
+		skip := 1 + 3*gtsCount // Directions and descriptors
+		if len(rest) < skip {
+			return beacon
+		}
+		rest = rest[skip:]
+	}
+	if len(rest) < 1 {
+		return beacon
+	}
+	pending := rest[0]
+	rest = rest[1:]
+	skip := 2*int(pending&0x07) + 8*int((pending>>4)&0x07)
+	if len(rest) < skip {
+		return beacon
+	}
+	rest = rest[skip:]
+
+	// Zigbee payload: protocol ID, stack profile and version, capacities,
+	// then the extended PAN ID
+	if len(rest) < 11 || rest[0] != zigbeeProtocolID {
+		return beacon
+	}
+	beacon.StackProfile = int(rest[1] & 0x0F)
+	beacon.RouterCapacity = rest[2]&(1<<2) != 0
+	beacon.EndDeviceCapacity = rest[2]&(1<<7) != 0
+	beacon.ExtendedPANID = formatExtended(rest[3:11])
+	return beacon
+}
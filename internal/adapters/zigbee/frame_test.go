@@ -0,0 +1,102 @@
+package zigbee
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Zigbee PRO beacon of PAN 0x1a62 from its coordinator, permitting joins
+var beaconFrame = []byte{
+	0x00, 0x80, 0x42, // Beacon, short source, seq 0x42
+	0x62, 0x1a, 0x00, 0x00, // PAN, source 0x0000
+	0xff, 0xcf, // Superframe: PAN coordinator, association permit
+	0x00, 0x00, // No GTS, no pending addresses
+	0x00, 0x22, 0x84, // Zigbee, PRO, router and end device capacity
+	0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, // Extended PAN ID
+	0xff, 0xff, 0xff, 0x00,
+}
+
+func TestParseFrame_Beacon(t *testing.T) {
+	frame, err := ParseFrame(beaconFrame)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ZigbeeFrameBeacon, frame.Type)
+	assert.Equal(t, 0x42, frame.Sequence)
+	assert.Equal(t, uint16(0x1a62), frame.SrcPAN)
+	assert.Equal(t, "0x0000", frame.SrcAddr)
+	require.NotNil(t, frame.Beacon)
+	assert.True(t, frame.Beacon.PANCoordinator)
+	assert.True(t, frame.Beacon.PermitJoining)
+	assert.Equal(t, 2, frame.Beacon.StackProfile)
+	assert.True(t, frame.Beacon.RouterCapacity)
+	assert.True(t, frame.Beacon.EndDeviceCapacity)
+	assert.Equal(t, "01:02:03:04:05:06:07:08", frame.Beacon.ExtendedPANID)
+}
+
+func TestParseFrame_DataRequest(t *testing.T) {
+	// Command, PAN compression, short destination, extended source
+	data := []byte{0x63, 0xc8, 0x10, 0x62, 0x1a, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x4b, 0x12, 0x00, 0x04}
+	frame, err := ParseFrame(data)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ZigbeeFrameCommand, frame.Type)
+	assert.True(t, frame.DataRequest)
+	assert.Equal(t, uint16(0x1a62), frame.SrcPAN, "compressed source PAN")
+	assert.Equal(t, "0x0000", frame.DstAddr)
+	assert.Equal(t, "00:12:4b:05:04:03:02:01", frame.SrcAddr)
+}
+
+func TestParseFrame_Truncated(t *testing.T) {
+	_, err := ParseFrame([]byte{0x41, 0x88, 0x01, 0x62})
+	assert.ErrorIs(t, err, ErrShortFrame)
+}
+
+func TestReadNRF(t *testing.T) {
+	input := "channel 15\r\nreceive\r\n" +
+		fmt.Sprintf("received: %s0000 power: -47 lqi: 180 time: 1234\r\n", hex.EncodeToString(beaconFrame)) +
+		"received: zz power: -47 lqi: 180 time: 1235\r\n"
+	var frames []domain.ZigbeeFrame
+	require.NoError(t, ReadNRF(bytes.NewBufferString(input), 15, func(f domain.ZigbeeFrame) { frames = append(frames, f) }))
+	require.Len(t, frames, 1)
+	assert.Equal(t, 15, frames[0].Channel)
+	assert.Equal(t, -47, frames[0].RSSI)
+	assert.Equal(t, 180, frames[0].LQI)
+	assert.NotNil(t, frames[0].Beacon)
+}
+
+func TestReadCC2531(t *testing.T) {
+	packet := func(fcsOK bool) []byte {
+		status := byte(0x6c)
+		if fcsOK {
+			status |= 0x80
+		}
+		psdu := append(append([]byte{}, beaconFrame...), 0x10, status) // Raw RSSI 16
+		data := append([]byte{0x01, 0x00, 0x00, 0x00, byte(len(psdu))}, psdu...)
+		return append([]byte{0x00, byte(len(data)), 0x00}, data...)
+	}
+	var stream bytes.Buffer
+	stream.Write([]byte{0x01, 0x01, 0x00, 0x00}) // Heartbeat
+	stream.Write(packet(false))
+	stream.Write(packet(true))
+
+	var frames []domain.ZigbeeFrame
+	require.NoError(t, ReadCC2531(&stream, 11, func(f domain.ZigbeeFrame) { frames = append(frames, f) }))
+	require.Len(t, frames, 1, "bad FCS skipped")
+	assert.Equal(t, 16-cc2531RSSIOffset, frames[0].RSSI)
+	assert.Equal(t, 0x6c, frames[0].LQI)
+	assert.Equal(t, "0x0000", frames[0].SrcAddr)
+}
+
+func TestNewSniffer(t *testing.T) {
+	_, err := NewSniffer("/dev/ttyACM0", "usb", 11)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+	_, err = NewSniffer("/dev/ttyACM0", FormatNRF, 27)
+	assert.Error(t, err)
+	s, err := NewSniffer("/dev/ttyACM0", FormatCC2531, 0)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultChannel, s.Channel)
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package zigbee
+
+import (
+	"errors"
+	"io"
+)
+
+// openSerial is only supported on linux.
+func openSerial(device string, baud int) (io.ReadWriteCloser, error) {
+	return nil, errors.New("Zigbee sniffers only supported on linux")
+}
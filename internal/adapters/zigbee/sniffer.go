@@ -0,0 +1,164 @@
+// Package zigbee captures IEEE 802.15.4 (Zigbee) traffic from sniffer
+// dongles attached over a serial port: Nordic nRF52840 boards running the
+// nRF 802.15.4 sniffer firmware, and CC2531 style sticks forwarding TI
+// packet sniffer frames.
+package zigbee
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// Serial protocols of the supported dongles
+const (
+	FormatNRF    = "nrf"    // Text lines of the nRF 802.15.4 sniffer firmware
+	FormatCC2531 = "cc2531" // TI packet sniffer frames
+)
+
+// DefaultChannel is the first 2.4 GHz 802.15.4 channel, where most Zigbee
+// coordinators start their network.
+const DefaultChannel = 11
+
+// cc2531RSSIOffset converts the raw CC2531 RSSI to dBm.
+const cc2531RSSIOffset = 73
+
+// ErrUnknownFormat is returned for a serial protocol that is not supported.
+var ErrUnknownFormat = errors.New("unknown Zigbee sniffer format, expected nrf or cc2531")
+
+// Sniffer reads 802.15.4 frames from a dongle on a serial port. It captures
+// on a single channel; run one dongle per channel to survey several.
+type Sniffer struct {
+	Device  string // Serial port, e.g. /dev/ttyACM0
+	Format  string
+	Channel int // 11-26
+	Baud    int // Ignored by USB CDC dongles
+}
+
+// NewSniffer creates a sniffer on device speaking format, capturing on channel.
+func NewSniffer(device, format string, channel int) (*Sniffer, error) {
+	if format != FormatNRF && format != FormatCC2531 {
+		return nil, ErrUnknownFormat
+	}
+	if channel == 0 {
+		channel = DefaultChannel
+	}
+	if channel < 11 || channel > 26 {
+		return nil, fmt.Errorf("invalid 802.15.4 channel %d, expected 11-26", channel)
+	}
+	return &Sniffer{Device: device, Format: format, Channel: channel, Baud: 115200}, nil
+}
+
+// Run opens the serial port and calls handle with each frame until ctx is
+// done or the port fails.
+func (s *Sniffer) Run(ctx context.Context, handle func(domain.ZigbeeFrame)) error {
+	port, err := openSerial(s.Device, s.Baud)
+	if err != nil {
+		return fmt.Errorf("cannot open Zigbee sniffer %s: %w", s.Device, err)
+	}
+	go func() {
+		<-ctx.Done()
+		port.Close() // Unblocks the read
+	}()
+
+	if s.Format == FormatNRF {
+		// Restart reception on the configured channel
+		if _, err := fmt.Fprintf(port, "sleep\r\nchannel %d\r\nreceive\r\n", s.Channel); err != nil {
+			port.Close()
+			return err
+		}
+		err = ReadNRF(port, s.Channel, handle)
+	} else {
+		err = ReadCC2531(port, s.Channel, handle)
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// nrfLine matches a frame reported by the nRF 802.15.4 sniffer firmware.
+var nrfLine = regexp.MustCompile(`received: ([0-9a-fA-F]+) power: (-?\d+) lqi: (\d+) time: (-?\d+)`)
+
+// ReadNRF reads the text output of the nRF 802.15.4 sniffer firmware until
+// r ends. Frames include their FCS, which is dropped.
+func ReadNRF(r io.Reader, channel int, handle func(domain.ZigbeeFrame)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m := nrfLine.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue // Command echoes and prompts
+		}
+		data, err := hex.DecodeString(m[1])
+		if err != nil || len(data) < 2 {
+			continue
+		}
+		frame, err := ParseFrame(data[:len(data)-2])
+		if err != nil {
+			continue
+		}
+		frame.Channel = channel
+		frame.RSSI, _ = strconv.Atoi(m[2])
+		frame.LQI, _ = strconv.Atoi(m[3])
+		frame.Timestamp = time.Now()
+		handle(frame)
+	}
+	return scanner.Err()
+}
+
+// ReadCC2531 reads TI packet sniffer frames until r ends: a type byte, the
+// little endian length and the data, made of a timestamp, the 802.15.4
+// frame length and the frame, whose FCS is replaced by the RSSI and a byte
+// holding the FCS status and the correlation (LQI). Frames failing their
+// FCS and other frame types are skipped.
+func ReadCC2531(r io.Reader, channel int, handle func(domain.ZigbeeFrame)) error {
+	reader := bufio.NewReader(r)
+	header := make([]byte, 3)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+		length := int(binary.LittleEndian.Uint16(header[1:3]))
+		data := make([]byte, length)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+		if header[0] != 0x00 || length < 8 {
+			continue // Heartbeats and other frame types
+		}
+
+		frameLen := int(data[4])
+		if frameLen < 2 || len(data) < 5+frameLen {
+			continue
+		}
+		psdu := data[5 : 5+frameLen]
+		status := psdu[frameLen-1]
+		if status&0x80 == 0 {
+			continue // Bad FCS
+		}
+		frame, err := ParseFrame(psdu[:frameLen-2])
+		if err != nil {
+			continue
+		}
+		frame.Channel = channel
+		frame.RSSI = int(int8(psdu[frameLen-2])) - cc2531RSSIOffset
+		frame.LQI = int(status & 0x7F)
+		frame.Timestamp = time.Now()
+		handle(frame)
+	}
+}
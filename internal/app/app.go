@@ -10,34 +10,67 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/lcalzada-xor/wmap/internal/adapters/attack/authflood"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/beaconspoof"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/csa"
 	"github.com/lcalzada-xor/wmap/internal/adapters/attack/deauth"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/karma"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/navjam"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/probeflood"
 	"github.com/lcalzada-xor/wmap/internal/adapters/attack/wps"
+	"github.com/lcalzada-xor/wmap/internal/adapters/bluetooth"
+	"github.com/lcalzada-xor/wmap/internal/adapters/cmdb"
+	"github.com/lcalzada-xor/wmap/internal/adapters/cracking"
 	"github.com/lcalzada-xor/wmap/internal/adapters/cve"
 	"github.com/lcalzada-xor/wmap/internal/adapters/fingerprint"
+	"github.com/lcalzada-xor/wmap/internal/adapters/kismet"
+	"github.com/lcalzada-xor/wmap/internal/adapters/plugin"
+	"github.com/lcalzada-xor/wmap/internal/adapters/portal"
+	"github.com/lcalzada-xor/wmap/internal/adapters/power"
 	"github.com/lcalzada-xor/wmap/internal/adapters/reporting"
+	"github.com/lcalzada-xor/wmap/internal/adapters/secrets"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/capture"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/driver"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/handshake"
 	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/injection"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sso"
 	"github.com/lcalzada-xor/wmap/internal/adapters/storage"
+	"github.com/lcalzada-xor/wmap/internal/adapters/ticketing"
+	"github.com/lcalzada-xor/wmap/internal/adapters/web/handlers"
 	webserver "github.com/lcalzada-xor/wmap/internal/adapters/web/server"
+	zigbeeSniffer "github.com/lcalzada-xor/wmap/internal/adapters/zigbee"
 	"github.com/lcalzada-xor/wmap/internal/config"
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/agents"
+	"github.com/lcalzada-xor/wmap/internal/core/services/artifacts"
 	"github.com/lcalzada-xor/wmap/internal/core/services/audit"
 	"github.com/lcalzada-xor/wmap/internal/core/services/auth"
+	bluetoothService "github.com/lcalzada-xor/wmap/internal/core/services/bluetooth"
 	grpcserver "github.com/lcalzada-xor/wmap/internal/core/services/grpc"
+	"github.com/lcalzada-xor/wmap/internal/core/services/ingest"
+	"github.com/lcalzada-xor/wmap/internal/core/services/inventory"
+	"github.com/lcalzada-xor/wmap/internal/core/services/jobs"
 	"github.com/lcalzada-xor/wmap/internal/core/services/network"
 	"github.com/lcalzada-xor/wmap/internal/core/services/persistence"
+	powerService "github.com/lcalzada-xor/wmap/internal/core/services/power"
 	"github.com/lcalzada-xor/wmap/internal/core/services/registry"
+	"github.com/lcalzada-xor/wmap/internal/core/services/reload"
 	reportingService "github.com/lcalzada-xor/wmap/internal/core/services/reporting"
+	"github.com/lcalzada-xor/wmap/internal/core/services/schedule"
+	"github.com/lcalzada-xor/wmap/internal/core/services/scripting"
 	"github.com/lcalzada-xor/wmap/internal/core/services/security"
+	ticketingService "github.com/lcalzada-xor/wmap/internal/core/services/ticketing"
 	"github.com/lcalzada-xor/wmap/internal/core/services/workspace"
+	"github.com/lcalzada-xor/wmap/internal/core/services/zigbee"
 	"github.com/lcalzada-xor/wmap/internal/geo"
 	"github.com/lcalzada-xor/wmap/internal/telemetry"
 )
@@ -46,6 +79,14 @@ import (
 const (
 	DefaultOUIDBPath      = "data/oui/ieee_oui.db"
 	DefaultSignaturesPath = "data/signatures.json"
+	// DefaultLearnedSignaturesPath holds the signatures learned from devices
+	// labeled by analysts, which augment the bundled ones.
+	DefaultLearnedSignaturesPath = "data/learned_signatures.json"
+	// DefaultNetworkStatePath holds the host's wireless configuration before
+	// capture, removed once restored on shutdown.
+	DefaultNetworkStatePath = "data/network_state.json"
+	// DefaultAPFingerprintsPath holds the beacon fingerprints of AP models.
+	DefaultAPFingerprintsPath = "data/ap_fingerprints.json"
 )
 
 // Application holds the core components of the application.
@@ -62,6 +103,22 @@ type Application struct {
 	AuthService        *auth.AuthService
 	AuditService       *audit.AuditService
 	PersistenceManager *persistence.PersistenceManager
+	SecurityEngine     *security.SecurityEngine
+	JobQueue           *jobs.Queue
+	ArtifactStore      *artifacts.Store
+	Reloader           *reload.Service
+	Plugins            *plugin.Manager // Nil when no external analyzer is installed
+	Hooks              *scripting.HookEngine
+	Ingester           *ingest.Service
+	Kismet             *kismet.Bridge            // Nil unless a Kismet server is configured
+	CaptureWatcher     *handshake.Watcher        // Nil in mock mode
+	Inventory          *inventory.Service        // Nil unless an asset inventory is configured
+	Tickets            *ticketingService.Service // Nil unless an issue tracker is configured
+	Bluetooth          *bluetoothService.Service // Nil unless a Bluetooth controller is configured
+	Zigbee             *zigbee.Registry          // Nil unless an 802.15.4 sniffer dongle is configured
+	Scheduler          *schedule.Service         // Monitoring windows pausing and resuming capture
+	Power              *powerService.Service     // Power save duty cycles and battery readings
+	Agents             *agents.Hub               // Command channels of remote agents
 	VendorRepo         fingerprint.VendorRepository
 	MockIntegration    interface{}
 
@@ -70,7 +127,28 @@ type Application struct {
 	sourceAlertChan  <-chan domain.Alert
 
 	// Internal State
+	sealer            *secrets.Sealer              // Master key: credentials and captures at rest
+	oidc              *sso.OIDCProvider            // Single sign-on, nil when not configured
+	signatures        *fingerprint.SignatureStore  // Bundled and learned device signatures
+	apHardware        *fingerprint.APHardwareStore // Beacon fingerprints of AP models
+	roles             domain.InterfaceRoles        // By capture interface name
 	monitorInterfaces []string
+	monitorVIFs       []string                // Created by us, deleted on shutdown
+	vifParents        []string                // Interfaces the monitor VIFs are created on
+	servicesStopped   bool                    // Network services stopped to enable monitor mode
+	network           *domain.NetworkSnapshot // Host configuration before capture, nil in mock mode
+
+	// Running capture, stopped and restarted by the monitoring schedule
+	captureMu     sync.Mutex
+	captureCtx    context.Context // Run's context, parent of every capture
+	captureErrs   chan<- error
+	captureCancel context.CancelFunc // Nil while capture is paused
+	captureDone   chan struct{}
+	radiosAsleep  bool // Capture interfaces down between power save cycles
+	// Capturing interfaces, the monitor VIFs in their place, including those
+	// added at runtime; guarded by captureMu. Config.Interfaces keeps the
+	// configured ones and is not changed after start.
+	captureIfaces []string
 }
 
 // New creates a new Application instance and bootstraps its components.
@@ -95,18 +173,25 @@ func (app *Application) bootstrap() error {
 	if err != nil {
 		return err
 	}
+	if err := app.initEncryption(); err != nil {
+		return err
+	}
 
 	if err := app.initExternalData(); err != nil {
 		log.Printf("Warning: hardware/device data initialization incomplete: %v", err)
 	}
 
 	// 2. Network Driver Setup
+	if err := app.initRoles(); err != nil {
+		return err
+	}
 	if err := app.initNetworkDriver(); err != nil {
 		return err
 	}
 
 	// 3. Domain Services
 	sigMatcher := app.loadSignatures()
+	app.signatures = sigMatcher
 
 	// Load vendor database for configuration vulnerability detection
 	vendorDB, err := security.LoadVendorDatabase("configs/vendor_defaults.json")
@@ -120,14 +205,24 @@ func (app *Application) bootstrap() error {
 	// TODO: Technical debt - concrete implementations don't fully match ports interfaces
 	// (missing context.Context parameters). Using type assertions as a temporary bridge.
 	vulnStore := security.NewVulnerabilityPersistenceService(interface{}(systemStore).(ports.Storage))
+	vulnStore.SetSealer(app.sealer)
+	if n, err := vulnStore.SealStoredCredentials(context.Background()); err != nil {
+		log.Printf("Warning: could not encrypt stored credentials: %v", err)
+	} else if n > 0 {
+		log.Printf("Encrypted credentials of %d vulnerability records", n)
+	}
 	devRegistry := registry.NewDeviceRegistry(interface{}(sigMatcher).(ports.SignatureMatcher), vulnStore)
+	app.apHardware = app.loadAPFingerprints()
+	devRegistry.SetHardwareMatcher(app.apHardware)
 
 	// Inject vendor database into vulnerability detector
 	if devRegistry.VulnDetector != nil {
 		devRegistry.VulnDetector.SetVendorDatabase(vendorDB)
+		devRegistry.VulnDetector.SetRegulatoryDomain(app.Config.RegDomain)
 	}
 
 	// Initialize CVE infrastructure
+	var cveEnricher *security.CVEEnricher
 	cveRepo, err := cve.NewSQLiteRepository("data/cve.db")
 	if err != nil {
 		log.Printf("Warning: Failed to initialize CVE repository: %v", err)
@@ -138,18 +233,48 @@ func (app *Application) bootstrap() error {
 		if devRegistry.VulnDetector != nil {
 			devRegistry.VulnDetector.SetCVEMatcher(cveMatcher)
 		}
+		// Alerts carry the top CVEs of their device to the notification sinks
+		cveEnricher = security.NewCVEEnricher(interface{}(devRegistry).(ports.DeviceRegistry), cveRepo)
 	}
 
 	securityEngine := security.NewSecurityEngine(interface{}(devRegistry).(ports.DeviceRegistry))
+	app.SecurityEngine = securityEngine
+	if cveEnricher != nil {
+		securityEngine.SetAlertEnricher(cveEnricher)
+	}
 
 	app.PersistenceManager = persistence.NewPersistenceManager(interface{}(systemStore).(ports.Storage), 10000)
+	securityEngine.SetBaselineStore(app.PersistenceManager)
+	// Zones are of the sensor's location, whichever workspace is open
+	if err := securityEngine.SetGeofenceStore(systemStore); err != nil {
+		return err
+	}
+	securityEngine.SetTrustedSSIDs(context.Background(), app.Config.TrustedSSIDs)
+	app.initReload(securityEngine)
+	app.initPlugins(securityEngine)
+
+	// Scripting hooks run last, after the analyzers, on the device of the active workspace
+	app.Hooks = scripting.NewHookEngine()
+	app.Hooks.SetStore(app.PersistenceManager)
+	securityEngine.AddDetector(app.Hooks)
 
 	if err := app.initWorkspace(devRegistry); err != nil {
 		return err
 	}
+	vulnStore.SetRouter(app.WorkspaceManager)
 
 	app.AuditService = audit.NewAuditService(interface{}(systemStore).(ports.AuditRepository))
+	vulnStore.SetAuditService(app.AuditService)
 	app.AuthService = auth.NewAuthService(interface{}(systemStore).(ports.UserRepository))
+	app.AuthService.SetAuditService(app.AuditService)
+	app.AuthService.SetSealer(app.sealer)
+	app.AuthService.RequireAdminTOTP(app.Config.Require2FA)
+	app.AuthService.SetAPIKeyStore(interface{}(systemStore).(ports.APIKeyRepository))
+	app.AuthService.SetShareStore(interface{}(systemStore).(ports.ShareLinkRepository))
+	app.AuthService.SetSessionStore(interface{}(systemStore).(ports.SessionRepository))
+	if err := app.initSSO(); err != nil {
+		return err
+	}
 
 	if err := app.ensureDefaultAdmin(systemStore); err != nil {
 		log.Printf("Warning: could not ensure default admin: %v", err)
@@ -159,9 +284,26 @@ func (app *Application) bootstrap() error {
 	if err := app.initNetworking(devRegistry, securityEngine); err != nil {
 		return err
 	}
+	app.NetworkService.SetWorkspaceRouter(app.WorkspaceManager)
+	// Running attacks are tracked in the system store, whichever workspace is open
+	app.NetworkService.SetActiveAttackStore(interface{}(systemStore).(ports.ActiveAttackRepository))
+	// Rules of engagement are signed per workspace, and required to start attacks
+	app.NetworkService.SetROEStore(app.PersistenceManager, app.sealer.SubKey("wmap-roe"))
+	if cveEnricher != nil {
+		app.NetworkService.SetAlertEnricher(cveEnricher)
+	}
 
 	// 5. Servers & Integration
 	app.initServers(systemStore, vulnStore, devRegistry)
+	if err := app.initGRPC(); err != nil {
+		return err
+	}
+	if err := app.initSchedule(systemStore); err != nil {
+		return err
+	}
+	if err := app.initPower(); err != nil {
+		return err
+	}
 
 	if app.Config.MockMode {
 		app.MockIntegration = "mock_enabled"
@@ -183,6 +325,43 @@ func (app *Application) initStorage() (*storage.SQLiteAdapter, error) {
 	return store, nil
 }
 
+// initEncryption loads the master key sealing credentials and captures at
+// rest: from the configured key file, from a passphrase (environment or
+// prompt), or else from a key generated next to the database.
+func (app *Application) initEncryption() error {
+	dataDir := filepath.Dir(app.Config.DBPath)
+
+	var key []byte
+	var err error
+	switch {
+	case app.Config.MasterKeyFile != "":
+		key, err = secrets.LoadOrCreateKey(app.Config.MasterKeyFile)
+	case app.Config.MasterPassphrase != "" || app.Config.PromptMasterKey:
+		passphrase := app.Config.MasterPassphrase
+		if passphrase == "" {
+			if passphrase, err = secrets.PromptPassphrase("Master passphrase: "); err != nil {
+				return fmt.Errorf("failed to read master passphrase: %w", err)
+			}
+		}
+		key, err = secrets.DeriveKey(passphrase, filepath.Join(dataDir, "master.salt"))
+	default:
+		key, err = secrets.LoadOrCreateKey(filepath.Join(dataDir, "secret.key"))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load master key: %w", err)
+	}
+
+	sealer, err := secrets.NewSealer(key)
+	if err != nil {
+		return fmt.Errorf("failed to load master key: %w", err)
+	}
+	if err := secrets.VerifyKey(sealer, filepath.Join(dataDir, "master.check")); err != nil {
+		return err
+	}
+	app.sealer = sealer
+	return nil
+}
+
 func (app *Application) initExternalData() error {
 	// Initialize OUI Database with Caching
 	ouiDB, err := fingerprint.NewOUIDatabase(DefaultOUIDBPath, 10000, nil)
@@ -217,7 +396,41 @@ func (app *Application) initExternalData() error {
 	return nil
 }
 
+// lookupVendor returns the vendor of the OUI of mac, or "" if unknown.
+func (app *Application) lookupVendor(ctx context.Context, mac string) string {
+	addr, err := fingerprint.ParseMAC(mac)
+	if err != nil || app.VendorRepo == nil {
+		return ""
+	}
+	vendor, err := app.VendorRepo.LookupVendor(ctx, addr)
+	if err != nil {
+		return ""
+	}
+	return vendor
+}
+
+// initRoles parses the interface roles. With monitor VIFs, roles given for
+// an interface apply to its VIF.
+func (app *Application) initRoles() error {
+	roles, err := domain.ParseInterfaceRoles(app.Config.Roles)
+	if err != nil {
+		return fmt.Errorf("interface roles: %w", err)
+	}
+	app.roles = make(domain.InterfaceRoles, len(roles))
+	for iface, role := range roles {
+		if !slices.Contains(app.Config.Interfaces, iface) {
+			log.Printf("Warning: role %s given to %s, which is not a capture interface", role, iface)
+		}
+		if app.Config.MonitorVIF {
+			iface = driver.MonitorVIFName(iface)
+		}
+		app.roles[iface] = role
+	}
+	return nil
+}
+
 func (app *Application) initNetworkDriver() error {
+	app.captureIfaces = slices.Clone(app.Config.Interfaces)
 	if app.Config.MockMode {
 		log.Println("Skipping network driver initialization (Mock Mode)")
 		return nil
@@ -227,12 +440,48 @@ func (app *Application) initNetworkDriver() error {
 		return fmt.Errorf("no network interfaces configured")
 	}
 
+	// Monitor VIFs leave their parents untouched, only the regulatory domain changes
+	app.recoverNetworkState()
+	if app.Config.MonitorVIF {
+		app.recordNetworkState(nil)
+	} else {
+		app.recordNetworkState(app.Config.Interfaces)
+	}
+
+	if app.Config.RegDomain != "" {
+		if err := driver.SetRegulatoryDomain(app.Config.RegDomain); err != nil {
+			return err
+		}
+		log.Printf("Regulatory domain set to %s", app.Config.RegDomain)
+	}
+
+	// Preflight: fail early with actionable errors instead of mid-run. Only
+	// blocking issues fail, checks whose output could not be read just warn.
+	for _, iface := range app.Config.Interfaces {
+		for _, issue := range driver.Diagnose(iface).Issues {
+			if issue.Severity == domain.DiagnosticError {
+				return fmt.Errorf("preflight failed on %s: %s (%s)", iface, issue.Message, issue.Remedy)
+			}
+			log.Printf("Preflight warning on %s: %s. %s", iface, issue.Message, issue.Remedy)
+		}
+	}
+
+	if app.Config.MonitorVIF {
+		return app.initMonitorVIFs(app.Config.Interfaces)
+	}
+	return app.enableMonitorMode()
+}
+
+// enableMonitorMode stops the network services that would reclaim the
+// configured interfaces and switches them to monitor mode.
+func (app *Application) enableMonitorMode() error {
 	log.Println("Stopping conflicting network services...")
 	if err := driver.KillConflictingProcesses(); err != nil {
 		log.Printf("Warning: Failed to stop conflicting processes: %v", err)
 	}
+	app.servicesStopped = true
 
-	for _, iface := range app.Config.Interfaces {
+	for _, iface := range app.captureIfaces {
 		if err := driver.EnableMonitorMode(iface); err != nil {
 			return fmt.Errorf("failed to enable monitor mode on %s: %v", iface, err)
 		}
@@ -243,21 +492,120 @@ func (app *Application) initNetworkDriver() error {
 	return nil
 }
 
-func (app *Application) loadSignatures() *fingerprint.SignatureStore {
-	sigData, err := os.ReadFile(DefaultSignaturesPath)
-	if err != nil {
-		log.Printf("Warning: Could not load signatures: %v", err)
-		return nil
+// initMonitorVIFs creates a monitor VIF per configured interface and captures
+// on it instead, so the host keeps its normal connectivity. Network services are
+// left running; note that channel hopping is limited while the parent interface
+// is associated to an AP.
+func (app *Application) initMonitorVIFs(parents []string) error {
+	app.vifParents = parents
+	vifs := make([]string, 0, len(parents))
+	for _, iface := range parents {
+		vif := driver.MonitorVIFName(iface)
+		if err := driver.CreateMonitorInterface(iface, vif); err != nil {
+			for _, created := range app.monitorVIFs {
+				driver.DeleteMonitorInterface(created)
+			}
+			app.monitorVIFs = nil
+			return fmt.Errorf("failed to create monitor interface for %s: %v", iface, err)
+		}
+		app.monitorVIFs = append(app.monitorVIFs, vif)
+		vifs = append(vifs, vif)
 	}
+	app.captureIfaces = vifs
+
+	time.Sleep(2 * time.Second) // Settle time
+	return nil
+}
 
+func (app *Application) loadSignatures() *fingerprint.SignatureStore {
 	var sigs []domain.DeviceSignature
-	if err := json.Unmarshal(sigData, &sigs); err != nil {
+	if sigData, err := os.ReadFile(DefaultSignaturesPath); err != nil {
+		log.Printf("Warning: Could not load signatures: %v", err)
+	} else if err := json.Unmarshal(sigData, &sigs); err != nil {
 		log.Printf("Error parsing signatures: %v", err)
-		return nil
+		sigs = nil
 	}
 
-	log.Printf("Loaded %d device signatures", len(sigs))
-	return fingerprint.NewSignatureStore(sigs)
+	store := fingerprint.NewSignatureStore(sigs)
+	if err := store.LoadLearned(DefaultLearnedSignaturesPath); err != nil {
+		log.Printf("Warning: Could not load learned signatures: %v", err)
+	}
+	log.Printf("Loaded %d device signatures (%d learned)", len(sigs), len(store.Learned()))
+	return store
+}
+
+func (app *Application) loadAPFingerprints() *fingerprint.APHardwareStore {
+	var sigs []domain.APHardwareSignature
+	if data, err := os.ReadFile(DefaultAPFingerprintsPath); err != nil {
+		log.Printf("Warning: Could not load AP fingerprints: %v", err)
+	} else if sigs, err = fingerprint.ParseAPHardwareSignatures(data); err != nil {
+		log.Printf("Error parsing AP fingerprints: %v", err)
+		sigs = nil
+	}
+
+	log.Printf("Loaded %d AP hardware fingerprints", len(sigs))
+	return fingerprint.NewAPHardwareStore(sigs)
+}
+
+// initReload loads the alert rules file and registers it, along with the
+// bundled signatures and AP fingerprints, for reloading into the running engines.
+func (app *Application) initReload(sec *security.SecurityEngine) {
+	applySignatures := func(data []byte) (int, error) {
+		var sigs []domain.DeviceSignature
+		if err := json.Unmarshal(data, &sigs); err != nil {
+			return 0, fmt.Errorf("parse signatures: %w", err)
+		}
+		app.signatures.ReplaceSignatures(sigs)
+		return len(sigs), nil
+	}
+	applyAPFingerprints := func(data []byte) (int, error) {
+		sigs, err := fingerprint.ParseAPHardwareSignatures(data)
+		if err != nil {
+			return 0, fmt.Errorf("parse AP fingerprints: %w", err)
+		}
+		app.apHardware.ReplaceSignatures(sigs)
+		return len(sigs), nil
+	}
+	applyRules := func(data []byte) (int, error) {
+		var rules []domain.AlertRule
+		if err := json.Unmarshal(data, &rules); err != nil {
+			return 0, fmt.Errorf("parse alert rules: %w", err)
+		}
+		return len(rules), sec.SetFileRules(rules)
+	}
+
+	if data, err := os.ReadFile(app.Config.RulesPath); err == nil {
+		if n, err := applyRules(data); err != nil {
+			log.Printf("Warning: Could not load alert rules: %v", err)
+		} else {
+			log.Printf("Loaded %d alert rules from %s", n, app.Config.RulesPath)
+		}
+	}
+
+	app.Reloader = reload.NewService()
+	app.Reloader.Register("signatures", DefaultSignaturesPath, applySignatures)
+	app.Reloader.Register("AP fingerprints", DefaultAPFingerprintsPath, applyAPFingerprints)
+	app.Reloader.Register("alert rules", app.Config.RulesPath, applyRules)
+}
+
+// initPlugins registers the external analyzers found in the plugin directory
+// as a detector of the security engine. Plugins are off without a directory.
+func (app *Application) initPlugins(sec *security.SecurityEngine) {
+	if app.Config.PluginDir == "" {
+		return
+	}
+	manager := plugin.NewManager()
+	n, err := manager.LoadDir(app.Config.PluginDir)
+	if err != nil {
+		log.Printf("Warning: Could not load analyzer plugins: %v", err)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	sec.AddDetector(manager)
+	app.Plugins = manager
+	log.Printf("Loaded %d analyzer plugins from %s", n, app.Config.PluginDir)
 }
 
 func (app *Application) initWorkspace(reg *registry.DeviceRegistry) error {
@@ -269,13 +617,67 @@ func (app *Application) initWorkspace(reg *registry.DeviceRegistry) error {
 	return nil
 }
 
+// applyWorkspaceSettings puts the settings of the active workspace into
+// effect. Its scope and baseline need nothing: they are read from the
+// workspace storage when used.
+func (app *Application) applyWorkspaceSettings(ctx context.Context, settings domain.WorkspaceSettings) {
+	if err := app.SecurityEngine.SetWorkspaceRules(settings.AlertRules); err != nil {
+		log.Printf("Warning: Could not apply workspace alert rules: %v", err)
+	}
+	app.NetworkService.SetDeviceRetention(settings.DeviceRetention)
+	app.WebServer.SetAlertRouting(settings.Notifications)
+}
+
+// initSSO sets up the identity providers users without a local account
+// authenticate with. A misconfiguration stops the start rather than locking
+// those users out unnoticed.
+func (app *Application) initSSO() error {
+	cfg := app.Config
+	if cfg.OIDCIssuer == "" && cfg.LDAPURL == "" {
+		return nil
+	}
+	roles, err := domain.ParseGroupRoles(cfg.GroupRoles)
+	if err != nil {
+		return fmt.Errorf("invalid SSO group roles: %w", err)
+	}
+	if len(roles) == 0 {
+		log.Printf("Warning: no SSO group roles set, every OIDC and LDAP login will be refused")
+	}
+	app.AuthService.SetGroupRoles(roles)
+
+	if cfg.OIDCIssuer != "" {
+		provider, err := sso.NewOIDCProvider(sso.OIDCConfig{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCSecret,
+			RedirectURL:  cfg.OIDCRedirect,
+			GroupsClaim:  cfg.OIDCGroups,
+		})
+		if err != nil {
+			return err
+		}
+		app.oidc = provider
+		log.Printf("Single sign-on through %s", cfg.OIDCIssuer)
+	}
+	if cfg.LDAPURL != "" {
+		directory, err := sso.NewLDAPDirectory(sso.LDAPConfig{
+			URL:       cfg.LDAPURL,
+			UserDN:    cfg.LDAPUserDN,
+			GroupAttr: cfg.LDAPGroups,
+		})
+		if err != nil {
+			return err
+		}
+		app.AuthService.SetDirectory(directory)
+		log.Printf("Password logins without a local account checked against %s", cfg.LDAPURL)
+	}
+	return nil
+}
+
 func (app *Application) ensureDefaultAdmin(store *storage.SQLiteAdapter) error {
 	if _, err := store.GetByUsername(context.Background(), "admin"); err != nil {
 		log.Println("Provisioning default admin user...")
-		return app.AuthService.CreateUser(context.Background(), domain.User{
-			Username: "admin",
-			Role:     domain.RoleAdmin,
-		}, "changeit")
+		return app.AuthService.ProvisionDefaultAdmin(context.Background(), "admin", "changeit")
 	}
 	return nil
 }
@@ -292,7 +694,19 @@ func (app *Application) initNetworking(reg *registry.DeviceRegistry, sec *securi
 		app.sourceDeviceChan = deviceChan
 		app.sourceAlertChan = alertChan
 	} else {
-		manager := sniffer.NewManager(app.Config.Interfaces, app.Config.DwellTime, app.Config.Debug, locProvider, app.VendorRepo)
+		hopPlan, err := domain.ParseHopPlan(app.Config.HopPlan)
+		if err != nil {
+			return err
+		}
+		manager := sniffer.NewManager(slices.Clone(app.captureIfaces), app.Config.DwellTime, app.Config.Debug, locProvider, app.VendorRepo)
+		manager.DropBadFCS = app.Config.DropBadFCS
+		manager.Passive = app.Config.Passive
+		manager.PcapPath = app.Config.PcapPath
+		manager.FrameHistory = app.Config.FrameHistory
+		manager.Roles = app.roles
+		manager.HopPlan = hopPlan
+		manager.CaptureContext = app.captureContext
+		manager.HandshakeManager.SetCaptureContext(app.captureContext)
 		// Cast to interface to satisfy ports.Sniffer
 		app.SnifferRunner = interface{}(manager).(ports.Sniffer)
 		app.sourceDeviceChan = manager.Output
@@ -305,28 +719,25 @@ func (app *Application) initNetworking(reg *registry.DeviceRegistry, sec *securi
 }
 
 func (app *Application) configureEngines(reg *registry.DeviceRegistry) {
-	var locker capture.ChannelLocker
-	if manager, ok := app.SnifferRunner.(*sniffer.SnifferManager); ok {
-		locker = manager
-	}
-
-	var defaultIface string
-	if len(app.Config.Interfaces) > 0 {
-		defaultIface = app.Config.Interfaces[0]
+	if app.Config.Passive {
+		log.Println("Passive mode: injection and attack engines disabled")
+		return
 	}
 
-	var injector *injection.Injector
+	var locker capture.ChannelLocker
 	if manager, ok := app.SnifferRunner.(*sniffer.SnifferManager); ok {
-		injector = manager.GetInjector(defaultIface)
+		locker = manager
 	}
 
-	if injector == nil && defaultIface != "" {
-		var err error
-		injector, err = injection.NewInjector(defaultIface)
-		if err != nil {
-			log.Printf("Warning: Failed to create injector: %v", err)
-		}
+	// Attacks transmit from the injection interface, rogue APs from the AP one
+	injectIface := app.roles.Pick(domain.InterfaceRoleInjection, app.captureIfaces)
+	apIface := app.roles.Pick(domain.InterfaceRoleAP, app.captureIfaces)
+	injector := app.injectorFor(injectIface)
+	apInjector := injector
+	if apIface != injectIface {
+		apInjector = app.injectorFor(apIface)
 	}
+	app.NetworkService.SetInterfaceRoles(app.roles)
 
 	// Setup Engines
 	app.NetworkService.SetDeauthEngine(interface{}(deauth.NewDeauthEngine(injector, locker, 5)).(ports.DeauthService))
@@ -349,6 +760,180 @@ func (app *Application) configureEngines(reg *registry.DeviceRegistry) {
 		})
 	}
 	app.NetworkService.SetAuthFloodEngine(afEngine)
+
+	pfEngine := probeflood.NewProbeFloodEngine(injector, locker, 5)
+	if app.Config.Debug {
+		pfEngine.SetLogger(func(msg, level string) {
+			slog.Info("PROBE-FLOOD", "level", level, "msg", msg)
+		})
+	}
+	app.NetworkService.SetProbeFloodEngine(pfEngine)
+
+	csaEngine := csa.NewCSAEngine(injector, locker, 5)
+	if app.Config.Debug {
+		csaEngine.SetLogger(func(msg, level string) {
+			slog.Info("CSA", "level", level, "msg", msg)
+		})
+	}
+	app.NetworkService.SetCSAEngine(csaEngine)
+
+	beaconEngine := beaconspoof.NewBeaconSpoofEngine(apInjector, locker, 5)
+	if app.Config.Debug {
+		beaconEngine.SetLogger(func(msg, level string) {
+			slog.Info("BEACON-SPOOF", "level", level, "msg", msg)
+		})
+	}
+	app.NetworkService.SetBeaconSpoofEngine(beaconEngine)
+
+	karmaEngine := karma.NewKarmaEngine(apInjector, locker, 1) // One responder at a time
+	if app.Config.Debug {
+		karmaEngine.SetLogger(func(msg, level string) {
+			slog.Info("KARMA", "level", level, "msg", msg)
+		})
+	}
+	app.NetworkService.SetKarmaEngine(karmaEngine)
+
+	navJamEngine := navjam.NewNAVJamEngine(injector, locker, 1) // Never stack jammers
+	if app.Config.Debug {
+		navJamEngine.SetLogger(func(msg, level string) {
+			slog.Info("NAV-JAM", "level", level, "msg", msg)
+		})
+	}
+	app.NetworkService.SetNAVJamEngine(navJamEngine)
+}
+
+// injectorFor returns the injector the sniffer of iface opened, or a new one.
+func (app *Application) injectorFor(iface string) *injection.Injector {
+	if iface == "" {
+		return nil
+	}
+	if manager, ok := app.SnifferRunner.(*sniffer.SnifferManager); ok {
+		if injector := manager.GetInjector(iface); injector != nil {
+			return injector
+		}
+	}
+	injector, err := injection.NewInjector(iface)
+	if err != nil {
+		log.Printf("Warning: Failed to create injector on %s: %v", iface, err)
+		return nil
+	}
+	return injector
+}
+
+// newPSKAudit sets up the weak-PSK audit over captured handshakes. Recovered
+// keys are sealed with the master key.
+func (app *Application) newPSKAudit(systemStore *storage.SQLiteAdapter, vulnStore *security.VulnerabilityPersistenceService) *security.PSKAuditService {
+	handshakeDir := handshake.DefaultDir()
+	if manager, ok := app.SnifferRunner.(*sniffer.SnifferManager); ok && manager.HandshakeManager != nil {
+		handshakeDir = manager.HandshakeManager.Dir()
+	}
+
+	return security.NewPSKAuditService(
+		handshakeDir,
+		cracking.NewAircrackCracker(app.Config.AircrackPath),
+		app.sealer,
+		interface{}(systemStore).(ports.CredentialRepository),
+		vulnStore,
+	)
+}
+
+// newCaptureWatcher imports the captures and hashes other tools drop in the
+// drop folder, registering their networks as captured in the registry.
+func (app *Application) newCaptureWatcher(hm *handshake.HandshakeManager) *handshake.Watcher {
+	dir := app.Config.DropDir
+	if dir == "" {
+		dir = filepath.Join(hm.Dir(), handshake.DropDir)
+	}
+	watcher := handshake.NewWatcher(hm, dir)
+	watcher.SetOnImport(func(ctx context.Context, result domain.CaptureImport) {
+		app.Ingester.IngestDevices(ctx, "capture-import", result.Devices())
+	})
+	return watcher
+}
+
+// initTicketing files critical vulnerabilities and alerts in the configured
+// issue tracker.
+func (app *Application) initTicketing(devRegistry *registry.DeviceRegistry, vulnStore *security.VulnerabilityPersistenceService) {
+	tracker, err := ticketing.NewTracker(ticketing.Config{
+		Tracker:   app.Config.Tracker,
+		URL:       app.Config.TrackerURL,
+		Project:   app.Config.TrackerProj,
+		User:      app.Config.TrackerUser,
+		Token:     app.Config.TrackerToken,
+		IssueType: app.Config.TrackerType,
+	})
+	if err != nil {
+		log.Printf("Warning: issue tracker disabled: %v", err)
+		return
+	}
+	minSeverity, err := domain.ParseAlertSeverity(app.Config.TrackerMin)
+	if err != nil {
+		log.Printf("Warning: issue tracker disabled: %v %q", err, app.Config.TrackerMin)
+		return
+	}
+	app.Tickets = ticketingService.NewService(tracker, devRegistry)
+	app.Tickets.SetMinSeverity(minSeverity)
+	app.NetworkService.SetTicketer(app.Tickets)
+	vulnStore.AddNotifier(app.Tickets)
+	app.WebServer.TicketHandler = handlers.NewTicketHandler(app.Tickets)
+	log.Printf("Filing %s findings in %s", minSeverity, tracker.Name())
+}
+
+// newArtifactStore sets up the artifact registry of the active workspace.
+// Managed files and the download link key live next to the database.
+func (app *Application) newArtifactStore() *artifacts.Store {
+	dataDir := filepath.Dir(app.Config.DBPath)
+	key, err := secrets.LoadOrCreateKey(filepath.Join(dataDir, "artifact-links.key"))
+	if err != nil {
+		log.Printf("Warning: artifact store disabled, could not load link key: %v", err)
+		return nil
+	}
+	store := artifacts.NewStore(app.PersistenceManager, filepath.Join(dataDir, "artifacts"), key, app.Config.ArtifactRetention)
+	store.SetSealer(app.sealer)
+
+	// Register handshake captures and evidence samples as they are written
+	if manager, ok := app.SnifferRunner.(*sniffer.SnifferManager); ok && manager.HandshakeManager != nil {
+		manager.HandshakeManager.SetOnSaved(func(path string) {
+			kind := domain.ArtifactHandshake
+			if filepath.Base(filepath.Dir(path)) == handshake.SampleDir {
+				kind = domain.ArtifactPcap
+			}
+			if _, err := store.RegisterArtifact(context.Background(), kind, path); err != nil {
+				log.Printf("Warning: could not register %s artifact %s: %v", kind, path, err)
+			}
+		})
+	}
+	return store
+}
+
+// captureContext attributes the frames captured on a BSSID to the workspace
+// its sensor is routed to and to the running attack on the BSSID, if any.
+func (app *Application) captureContext(bssid string) domain.CaptureContext {
+	var cc domain.CaptureContext
+	if app.WorkspaceManager != nil {
+		cc.Workspace = app.WorkspaceManager.WorkspaceOfDevice(context.Background(), bssid)
+	}
+	if app.NetworkService != nil && bssid != "" {
+		if id, operator, ok := app.NetworkService.AttackOn(bssid); ok {
+			cc.AttackID, cc.Operator = id, operator
+		}
+	}
+	return cc
+}
+
+// initCaptureEncryption seals new handshake captures, and those left in
+// plaintext, when enabled.
+func (app *Application) initCaptureEncryption() {
+	manager, ok := app.SnifferRunner.(*sniffer.SnifferManager)
+	if !app.Config.EncryptCaptures || !ok || manager.HandshakeManager == nil {
+		return
+	}
+	manager.HandshakeManager.SetSealer(app.sealer)
+	if n, err := manager.HandshakeManager.SealExisting(); err != nil {
+		log.Printf("Warning: could not encrypt existing handshake captures: %v", err)
+	} else if n > 0 {
+		log.Printf("Encrypted %d existing handshake captures", n)
+	}
 }
 
 func (app *Application) initServers(systemStore *storage.SQLiteAdapter, vulnStore *security.VulnerabilityPersistenceService, devRegistry *registry.DeviceRegistry) {
@@ -370,6 +955,107 @@ func (app *Application) initServers(systemStore *storage.SQLiteAdapter, vulnStor
 		pdfExporter,
 	)
 
+	app.WebServer.Passive = app.Config.Passive
+	app.WebServer.AllowedOrigins = app.Config.Origins
+	app.WebServer.ReportHandler.ActionsGenerator = reportingService.NewActionsReportGenerator(app.PersistenceManager, app.PersistenceManager)
+	app.WebServer.GeofenceHandler = handlers.NewGeofenceHandler(interface{}(app.SecurityEngine).(ports.GeofenceManager))
+	app.WebServer.BaselineHandler = handlers.NewBaselineHandler(interface{}(app.SecurityEngine).(ports.BaselineManager))
+	app.WebServer.ProtectedHandler = handlers.NewProtectedBSSIDHandler(interface{}(app.NetworkService).(ports.ProtectedBSSIDManager))
+	app.WebServer.DeauthReasonHandler = handlers.NewDeauthReasonHandler(interface{}(app.NetworkService).(ports.DeauthReasonAnalytics))
+	app.NetworkService.SetSignatureLearner(fingerprint.NewFingerprintEngine(app.signatures))
+	app.WebServer.SignatureHandler = handlers.NewSignatureHandler(interface{}(app.NetworkService).(ports.DeviceLabeler))
+	app.WebServer.DeviceHandler = handlers.NewDeviceHandler(interface{}(app.NetworkService).(ports.DeviceForgetter), interface{}(app.NetworkService).(ports.DeviceAssetEditor))
+	app.WebServer.TargetHandler = handlers.NewTargetHandler(interface{}(app.NetworkService).(ports.TargetPrioritizer))
+	app.WebServer.ReloadHandler = handlers.NewReloadHandler(app.Reloader)
+	app.WebServer.HookHandler = handlers.NewHookHandler(app.Hooks)
+	app.WebServer.GraphQLHandler = handlers.NewGraphQLHandler(interface{}(devRegistry).(ports.DeviceRegistry), interface{}(app.NetworkService).(ports.NetworkService), vulnStore)
+	app.Ingester = ingest.NewService(app.NetworkService)
+	app.WebServer.IngestHandler = handlers.NewIngestHandler(app.Ingester)
+	if manager, ok := app.SnifferRunner.(*sniffer.SnifferManager); ok && manager.HandshakeManager != nil {
+		app.WebServer.CaptureImportHandler = handlers.NewCaptureImportHandler(manager.HandshakeManager)
+		app.CaptureWatcher = app.newCaptureWatcher(manager.HandshakeManager)
+	}
+	if app.Config.KismetURL != "" {
+		app.Kismet = kismet.NewBridge(app.Config.KismetURL, app.Config.KismetAPIKey)
+	}
+	if app.Config.PortalIface != "" {
+		if app.Config.Passive {
+			log.Println("Passive mode: captive portal checks disabled")
+		} else {
+			app.NetworkService.SetPortalChecker(portal.NewChecker(app.Config.PortalIface))
+			app.WebServer.PortalHandler = handlers.NewPortalHandler(app.NetworkService)
+		}
+	}
+	if _, ok := app.SnifferRunner.(ports.DynamicSniffer); ok {
+		app.WebServer.InterfaceHandler = handlers.NewInterfaceHandler(app)
+	}
+	if app.Config.BTController != "" {
+		if app.Config.Passive {
+			log.Println("Passive mode: Bluetooth inquiry disabled")
+		} else {
+			app.Bluetooth = bluetoothService.NewService(
+				bluetooth.NewScanner(app.Config.BTController),
+				interface{}(systemStore).(ports.BluetoothRepository),
+				interface{}(devRegistry).(ports.DeviceRegistry),
+				app.lookupVendor,
+			)
+			if err := app.Bluetooth.Load(context.Background()); err != nil {
+				log.Printf("Warning: could not load Bluetooth devices: %v", err)
+			}
+			app.WebServer.BluetoothHandler = handlers.NewBluetoothHandler(app.Bluetooth)
+		}
+	}
+	if app.Config.ZigbeePort != "" {
+		if dongle, err := zigbeeSniffer.NewSniffer(app.Config.ZigbeePort, app.Config.ZigbeeFormat, app.Config.ZigbeeChan); err != nil {
+			log.Printf("Warning: Zigbee capture disabled: %v", err)
+		} else {
+			app.Zigbee = zigbee.NewRegistry(dongle)
+			app.WebServer.ZigbeeHandler = handlers.NewZigbeeHandler(app.Zigbee)
+		}
+	}
+	if app.Config.CMDBSource != "" {
+		if source, err := cmdb.NewSource(app.Config.CMDBFormat, app.Config.CMDBSource, app.Config.CMDBToken); err != nil {
+			log.Printf("Warning: asset inventory disabled: %v", err)
+		} else {
+			app.Inventory = inventory.NewService(source)
+			app.NetworkService.SetInventory(app.Inventory)
+			app.SecurityEngine.SetInventory(app.Inventory)
+			app.WebServer.InventoryHandler = handlers.NewInventoryHandler(app.Inventory)
+		}
+	}
+	if app.Config.Tracker != "" {
+		app.initTicketing(devRegistry, vulnStore)
+	}
+	if app.Plugins != nil {
+		app.Plugins.SetAnnotator(app.NetworkService.AnnotateDevice)
+		app.WebServer.PluginHandler = handlers.NewPluginHandler(app.Plugins)
+	}
+	if app.ArtifactStore = app.newArtifactStore(); app.ArtifactStore != nil {
+		app.WebServer.ArtifactHandler = handlers.NewArtifactHandler(app.ArtifactStore)
+		app.WebServer.ReportHandler.Artifacts = app.ArtifactStore
+		app.NetworkService.SetEvidenceStore(app.ArtifactStore)
+		if manager, ok := app.SnifferRunner.(*sniffer.SnifferManager); ok {
+			manager.Targets.SetStore(app.ArtifactStore)
+			manager.Targets.SetCaptureContext(app.captureContext)
+			if manager.FrameHistory > 0 {
+				app.WebServer.RecentCaptureHandler = handlers.NewRecentCaptureHandler(app.NetworkService)
+			}
+		}
+	}
+	app.NetworkService.SetCaptureContext(app.captureContext)
+	app.initCaptureEncryption()
+	app.JobQueue = jobs.NewQueue(interface{}(systemStore).(ports.JobRepository), jobs.DefaultWorkers)
+	app.WebServer.JobHandler = handlers.NewJobHandler(app.JobQueue)
+	pskAudit := app.newPSKAudit(systemStore, vulnStore)
+	app.WebServer.PSKAuditHandler = handlers.NewPSKAuditHandler(pskAudit)
+	if manager, ok := app.SnifferRunner.(*sniffer.SnifferManager); ok && manager.HandshakeManager != nil {
+		autoCapture := network.NewAutoCaptureService(app.NetworkService, manager.HandshakeManager)
+		app.WebServer.AutoCaptureHandler = handlers.NewAutoCaptureHandler(autoCapture)
+	}
+	app.JobQueue.Register("psk_audit", func(ctx context.Context, job domain.Job, progress jobs.ProgressFunc) error {
+		return pskAudit.RunPSKAudit(ctx, progress)
+	})
+
 	if app.WebServer.WSManager != nil {
 		vulnStore.SetNotifier(interface{}(app.WebServer.WSManager).(ports.VulnerabilityNotifier))
 
@@ -378,6 +1064,18 @@ func (app *Application) initServers(systemStore *storage.SQLiteAdapter, vulnStor
 			app.WebServer.BroadcastLog(msg, level)
 		})
 
+		// Stream device locator readings to WS
+		app.NetworkService.SetLocatorPublisher(app.WebServer.WSManager.BroadcastLocatorReading)
+
+		// Raise sensor alerts (deauth correlated across sensors) to WS
+		app.NetworkService.SetAlertPublisher(app.WebServer.BroadcastAlert)
+
+		// Apply the settings of each workspace as it becomes active
+		app.WorkspaceManager.SetSettingsApplier(app.applyWorkspaceSettings)
+
+		// Stream background job progress to WS
+		app.JobQueue.SetNotifier(app.WebServer.WSManager.BroadcastJob)
+
 		// Bridge WPS callbacks - need to store concrete type for this
 		// TODO: Add SetCallbacks to ports.WPSAttackService interface
 		if wpsIface := app.NetworkService.GetWPSEngine(); wpsIface != nil {
@@ -391,7 +1089,41 @@ func (app *Application) initServers(systemStore *storage.SQLiteAdapter, vulnStor
 		}
 	}
 
-	app.GrpcServer = grpcserver.NewGrpcServer(interface{}(app.NetworkService).(ports.NetworkService))
+	app.Agents = agents.NewHub(interface{}(devRegistry).(ports.DeviceRegistry), app.NetworkService, app.PersistenceManager, app.AuditService)
+	app.Agents.SetLogger(app.WebServer.BroadcastLog)
+	if app.Config.AgentRelease != "" {
+		if release, err := agents.LoadRelease(app.Config.AgentRelease); err != nil {
+			log.Printf("Warning: agent self-update disabled: %v", err)
+		} else {
+			app.Agents.SetRelease(release)
+			log.Printf("Advertising agent release %s", release.Version)
+		}
+	}
+	app.WebServer.AgentHandler = handlers.NewAgentHandler(app.Agents)
+	app.WebServer.APIKeyHandler = handlers.NewAPIKeyHandler(app.AuthService)
+	app.WebServer.ShareHandler = handlers.NewShareHandler(app.AuthService, app.NetworkService, app.WorkspaceManager)
+	app.WebServer.UserHandler = handlers.NewUserHandler(app.AuthService)
+	app.WebServer.TwoFactorHandler = handlers.NewTwoFactorHandler(app.AuthService)
+	app.WebServer.SessionHandler = handlers.NewSessionHandler(app.AuthService)
+	if app.oidc != nil {
+		app.WebServer.SSOHandler = handlers.NewSSOHandler(app.oidc, app.AuthService)
+	}
+}
+
+// initGRPC creates the gRPC server agents report to, over TLS when a
+// certificate is configured.
+func (app *Application) initGRPC() error {
+	var creds credentials.TransportCredentials
+	if app.Config.GRPCCert != "" {
+		var err error
+		if creds, err = grpcserver.ServerCredentials(app.Config.GRPCCert, app.Config.GRPCKey, app.Config.GRPCClientCA); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("Warning: gRPC server without TLS; agents connect only with -insecure (set -grpc-tls-cert)")
+	}
+	app.GrpcServer = grpcserver.NewGrpcServer(interface{}(app.NetworkService).(ports.NetworkService), app.Ingester, app.Agents, app.AuthService, app.Config.GRPCAPIKey, creds)
+	return nil
 }
 
 // Run starts the application components and manages their execution lifecycle.
@@ -401,6 +1133,51 @@ func (app *Application) Run(ctx context.Context) error {
 	// 1. Auxiliary Loops
 	app.NetworkService.StartCleanupLoop(ctx, 10*time.Minute, 1*time.Minute)
 	app.PersistenceManager.Start(ctx)
+	if err := app.JobQueue.Start(ctx); err != nil {
+		log.Printf("Warning: background jobs disabled: %v", err)
+	}
+	if app.ArtifactStore != nil {
+		app.ArtifactStore.Start(ctx)
+	}
+	if app.Config.ReloadInterval > 0 {
+		app.Reloader.Watch(ctx, app.Config.ReloadInterval)
+	}
+	if app.Plugins != nil {
+		app.Plugins.Start(ctx)
+	}
+	if app.Kismet != nil {
+		interval := app.Config.KismetInterval
+		if interval <= 0 {
+			interval = kismet.DefaultInterval
+		}
+		go app.Kismet.Run(ctx, interval, func(ctx context.Context, devices []domain.Device) {
+			app.Ingester.IngestDevices(ctx, app.Kismet.Name(), devices)
+		})
+	}
+
+	if app.Inventory != nil {
+		interval := app.Config.CMDBInterval
+		if interval <= 0 {
+			interval = inventory.DefaultInterval
+		}
+		go app.Inventory.Run(ctx, interval)
+	}
+
+	if app.Bluetooth != nil {
+		interval := app.Config.BTInterval
+		if interval <= 0 {
+			interval = bluetoothService.DefaultInterval
+		}
+		go app.Bluetooth.Run(ctx, interval)
+	}
+
+	if app.Zigbee != nil {
+		go app.Zigbee.Run(ctx)
+	}
+
+	if app.CaptureWatcher != nil {
+		go app.CaptureWatcher.Run(ctx, handshake.DefaultWatchInterval)
+	}
 
 	// 2. Background Processing
 	go app.runAlertPump(ctx)
@@ -436,9 +1213,10 @@ func (app *Application) Run(ctx context.Context) error {
 
 	go func() {
 		time.Sleep(1 * time.Second) // Wait for servers to bind
-		if err := app.SnifferRunner.Start(ctx); err != nil {
-			errChan <- fmt.Errorf("sniffer error: %w", err)
-		}
+		app.startCapture(ctx, errChan)
+		app.NetworkService.RecoverAttacks(ctx)
+		go app.Power.Run(ctx)
+		app.Scheduler.Run(ctx, schedule.DefaultInterval)
 	}()
 
 	slog.Info("WMAP Ready. Press Ctrl+C to terminate.")
@@ -471,7 +1249,10 @@ func (app *Application) runAlertPump(ctx context.Context) {
 			return
 		case a := <-app.sourceAlertChan:
 			slog.Info("Alert", "type", a.Type, "msg", a.Message)
-			app.WebServer.BroadcastAlert(a)
+			_ = app.NetworkService.ReportAlert(ctx, a)
+			if app.Plugins != nil {
+				app.Plugins.DispatchFrame(a)
+			}
 		}
 	}
 }
@@ -503,6 +1284,13 @@ func (app *Application) cleanup() error {
 		app.SnifferRunner.Close()
 	}
 
+	// The capture file is complete once the sniffer is closed
+	if app.ArtifactStore != nil && app.Config.PcapPath != "" {
+		if _, err := app.ArtifactStore.RegisterArtifact(context.Background(), domain.ArtifactPcap, app.Config.PcapPath); err != nil {
+			log.Printf("Warning: could not register capture artifact: %v", err)
+		}
+	}
+
 	// NetworkService.Close() was already called in Run() to stop attacks
 	// No need to call it again here
 
@@ -515,12 +1303,218 @@ func (app *Application) RestoreNetwork() {
 		return
 	}
 
-	log.Println("Restoring networking infrastructure...")
-	if err := driver.RestoreNetworkServices(); err != nil {
-		log.Printf("Error restoring system services: %v", err)
+	app.captureMu.Lock()
+	defer app.captureMu.Unlock()
+	app.releaseInterfaces()
+	if app.network != nil {
+		restoreRegulatoryDomain(app.network.RegulatoryDomain)
+		os.Remove(DefaultNetworkStatePath)
+	}
+}
+
+// releaseInterfaces deletes the monitor VIFs, or puts the interfaces back in
+// managed mode and restarts the network services stopped for capture.
+func (app *Application) releaseInterfaces() {
+	for _, vif := range app.monitorVIFs {
+		driver.DeleteMonitorInterface(vif)
+	}
+	app.monitorVIFs = nil
+	if app.Config.MonitorVIF {
+		return // Services and interface modes were never touched
+	}
+
+	if app.servicesStopped {
+		log.Println("Restoring networking infrastructure...")
+		if err := driver.RestoreNetworkServices(); err != nil {
+			log.Printf("Error restoring system services: %v", err)
+		}
+		app.servicesStopped = false
 	}
 
 	for _, iface := range app.monitorInterfaces {
-		driver.DisableMonitorMode(iface)
+		app.restoreInterface(iface)
+	}
+	app.monitorInterfaces = nil
+}
+
+// initSchedule restores the monitoring schedule. One given on the command
+// line takes precedence over the saved one.
+func (app *Application) initSchedule(systemStore *storage.SQLiteAdapter) error {
+	app.Scheduler = schedule.NewService(app, interface{}(systemStore).(ports.ScheduleRepository), app.AuditService)
+	app.WebServer.ScheduleHandler = handlers.NewScheduleHandler(app.Scheduler)
+
+	if app.Config.Schedule == "" {
+		if err := app.Scheduler.Load(context.Background()); err != nil {
+			log.Printf("Warning: could not load the monitoring schedule: %v", err)
+		}
+		return nil
+	}
+	sched, err := domain.ParseMonitoringSchedule(app.Config.Schedule)
+	if err != nil {
+		return fmt.Errorf("monitoring schedule: %w", err)
+	}
+	return app.Scheduler.Override(sched)
+}
+
+// initPower sets up the power save mode, enabled by a duty cycle given on the
+// command line.
+func (app *Application) initPower() error {
+	hopper, _ := app.SnifferRunner.(ports.ReactiveHopper)
+	app.Power = powerService.NewService(app, hopper, app.PersistenceManager, power.NewBatteryMonitor(app.Config.BatteryCmd), app.AuditService)
+	app.WebServer.PowerHandler = handlers.NewPowerHandler(app.Power)
+
+	if app.Config.PowerSave == "" {
+		return nil
+	}
+	on, off, err := domain.ParseDutyCycle(app.Config.PowerSave)
+	if err != nil {
+		return fmt.Errorf("power save: %w", err)
+	}
+	return app.Power.Configure(domain.PowerSaveConfig{
+		Enabled:    true,
+		On:         on,
+		Off:        off,
+		LowBattery: domain.DefaultLowBattery,
+	})
+}
+
+// startCapture runs the sniffer until ctx is done or capture is paused.
+func (app *Application) startCapture(ctx context.Context, errChan chan<- error) {
+	app.captureMu.Lock()
+	defer app.captureMu.Unlock()
+	app.captureCtx, app.captureErrs = ctx, errChan
+	app.runSniffer()
+}
+
+// runSniffer starts the sniffer in the background. Called with captureMu held.
+func (app *Application) runSniffer() {
+	ctx, cancel := context.WithCancel(app.captureCtx)
+	done := make(chan struct{})
+	app.captureCancel, app.captureDone = cancel, done
+
+	go func() {
+		defer close(done)
+		if err := app.SnifferRunner.Start(ctx); err != nil {
+			select {
+			case app.captureErrs <- fmt.Errorf("sniffer error: %w", err):
+			default:
+				log.Printf("Sniffer error: %v", err)
+			}
+		}
+	}()
+}
+
+// PauseCapture stops the sniffer and any running attack, and gives the
+// interfaces back to the system in managed mode.
+func (app *Application) PauseCapture(ctx context.Context) error {
+	// Attacks are stopped before taking captureMu: stopping waits on the
+	// engines, which must not block interface changes meanwhile
+	app.NetworkService.StopAttacks(ctx)
+
+	app.captureMu.Lock()
+	defer app.captureMu.Unlock()
+
+	if app.captureCancel != nil {
+		app.captureCancel()
+		select {
+		case <-app.captureDone:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		app.captureCancel = nil
 	}
+	app.radiosAsleep = false
+	if !app.Config.MockMode {
+		app.releaseInterfaces()
+	}
+	return nil
+}
+
+// ResumeCapture puts the interfaces back in monitor mode and restarts the sniffer.
+func (app *Application) ResumeCapture(ctx context.Context) error {
+	app.captureMu.Lock()
+	defer app.captureMu.Unlock()
+
+	if app.captureCancel != nil {
+		return nil
+	}
+	if app.captureCtx == nil || app.captureCtx.Err() != nil {
+		return fmt.Errorf("capture is not running")
+	}
+	if !app.Config.MockMode {
+		var err error
+		if app.Config.MonitorVIF {
+			err = app.initMonitorVIFs(app.vifParents)
+		} else {
+			err = app.enableMonitorMode()
+		}
+		if err != nil {
+			app.releaseInterfaces()
+			return err
+		}
+	}
+	app.runSniffer()
+	return nil
+}
+
+// SleepRadios stops any running attack, then the sniffer, and puts the
+// capture interfaces down, keeping their monitor mode, until WakeRadios. The
+// sniffer and interfaces are left alone while capture is paused.
+func (app *Application) SleepRadios(ctx context.Context) error {
+	// As in PauseCapture, attacks are stopped outside captureMu
+	app.NetworkService.StopAttacks(ctx)
+
+	app.captureMu.Lock()
+	defer app.captureMu.Unlock()
+
+	if app.captureCancel == nil {
+		return nil
+	}
+	app.captureCancel()
+	select {
+	case <-app.captureDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	app.captureCancel = nil
+	app.radiosAsleep = true
+	return app.setCaptureLinks(false)
+}
+
+// WakeRadios puts the capture interfaces back up and restarts the sniffer
+// after SleepRadios.
+func (app *Application) WakeRadios(ctx context.Context) error {
+	app.captureMu.Lock()
+	defer app.captureMu.Unlock()
+
+	if !app.radiosAsleep {
+		return nil
+	}
+	if app.captureCtx == nil || app.captureCtx.Err() != nil {
+		return fmt.Errorf("capture is not running")
+	}
+	if err := app.setCaptureLinks(true); err != nil {
+		return err
+	}
+	app.radiosAsleep = false
+	app.runSniffer()
+	return nil
+}
+
+// setCaptureLinks puts the capture interfaces up or down. Called with
+// captureMu held.
+func (app *Application) setCaptureLinks(up bool) error {
+	if app.Config.MockMode {
+		return nil
+	}
+	ifaces := app.monitorInterfaces
+	if app.Config.MonitorVIF {
+		ifaces = app.monitorVIFs
+	}
+	for _, iface := range ifaces {
+		if err := driver.SetLinkUp(iface, up); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/driver"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+var _ ports.CaptureInterfaceManager = (*Application)(nil)
+
+// AddCaptureInterface puts iface in monitor mode, or creates a monitor VIF
+// on it, and starts capturing on it without restarting the others. While
+// capture is paused the interface only joins the configuration.
+func (app *Application) AddCaptureInterface(ctx context.Context, iface string) (string, error) {
+	if !domain.IsValidInterface(iface) {
+		return "", domain.ErrInvalidInterfaceName
+	}
+	dynamic, ok := app.SnifferRunner.(ports.DynamicSniffer)
+	if !ok {
+		return "", fmt.Errorf("capture interfaces cannot be changed at runtime in this mode")
+	}
+
+	app.captureMu.Lock()
+	defer app.captureMu.Unlock()
+
+	name := iface
+	if app.Config.MonitorVIF {
+		name = driver.MonitorVIFName(iface)
+	}
+	if slices.Contains(app.captureIfaces, name) {
+		return "", fmt.Errorf("%w: %s", domain.ErrInterfaceInUse, name)
+	}
+
+	running := app.captureCancel != nil || app.radiosAsleep
+	if running {
+		for _, issue := range driver.Diagnose(iface).Issues {
+			if issue.Severity == domain.DiagnosticError {
+				return "", fmt.Errorf("preflight failed on %s: %s (%s)", iface, issue.Message, issue.Remedy)
+			}
+		}
+		if err := app.claimInterface(iface, name); err != nil {
+			return "", err
+		}
+	}
+	if err := dynamic.AddInterface(ctx, name); err != nil {
+		if running {
+			app.releaseInterface(name)
+		}
+		return "", err
+	}
+
+	app.captureIfaces = append(app.captureIfaces, name)
+	if app.Config.MonitorVIF {
+		app.vifParents = append(app.vifParents, iface)
+	}
+	app.AuditService.Log(ctx, domain.ActionConfigChange, name, "capture interface added")
+	return name, nil
+}
+
+// RemoveCaptureInterface stops capturing on iface and gives it back to the
+// system: its monitor VIF is deleted, or it returns to managed mode.
+func (app *Application) RemoveCaptureInterface(ctx context.Context, iface string) error {
+	dynamic, ok := app.SnifferRunner.(ports.DynamicSniffer)
+	if !ok {
+		return fmt.Errorf("capture interfaces cannot be changed at runtime in this mode")
+	}
+
+	app.captureMu.Lock()
+	defer app.captureMu.Unlock()
+
+	index := slices.Index(app.captureIfaces, iface)
+	if index < 0 {
+		return fmt.Errorf("%w: %s", domain.ErrInterfaceNotFound, iface)
+	}
+	if err := dynamic.RemoveInterface(ctx, iface); err != nil {
+		return err
+	}
+	if app.captureCancel != nil || app.radiosAsleep {
+		app.releaseInterface(iface)
+	}
+
+	app.captureIfaces = slices.Delete(app.captureIfaces, index, index+1)
+	if app.Config.MonitorVIF && index < len(app.vifParents) {
+		app.vifParents = slices.Delete(app.vifParents, index, index+1)
+	}
+	app.AuditService.Log(ctx, domain.ActionConfigChange, iface, "capture interface removed")
+	return nil
+}
+
+// claimInterface readies iface for capture as name. Called with captureMu held.
+func (app *Application) claimInterface(iface, name string) error {
+	if app.Config.MockMode {
+		return nil
+	}
+	if app.Config.MonitorVIF {
+		if err := driver.CreateMonitorInterface(iface, name); err != nil {
+			return fmt.Errorf("failed to create monitor interface for %s: %v", iface, err)
+		}
+		app.monitorVIFs = append(app.monitorVIFs, name)
+		return nil
+	}
+	app.rememberInterface(iface)
+	if err := driver.EnableMonitorMode(iface); err != nil {
+		return fmt.Errorf("failed to enable monitor mode on %s: %v", iface, err)
+	}
+	app.monitorInterfaces = append(app.monitorInterfaces, iface)
+	log.Printf("Monitor mode enabled on %s", iface)
+	return nil
+}
+
+// releaseInterface undoes claimInterface. Called with captureMu held.
+func (app *Application) releaseInterface(name string) {
+	if app.Config.MockMode {
+		return
+	}
+	if app.Config.MonitorVIF {
+		driver.DeleteMonitorInterface(name)
+		app.monitorVIFs = slices.DeleteFunc(app.monitorVIFs, func(vif string) bool { return vif == name })
+		return
+	}
+	app.restoreInterface(name)
+	app.monitorInterfaces = slices.DeleteFunc(app.monitorInterfaces, func(i string) bool { return i == name })
+}
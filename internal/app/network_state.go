@@ -0,0 +1,115 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/driver"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// recoverNetworkState restores the host configuration left behind by a run
+// that crashed, or panicked in a goroutine, before it could restore it.
+func (app *Application) recoverNetworkState() {
+	data, err := os.ReadFile(DefaultNetworkStatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		log.Printf("Warning: could not read the saved network state: %v", err)
+		return
+	}
+	var snapshot domain.NetworkSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		log.Printf("Warning: discarding unreadable network state %s: %v", DefaultNetworkStatePath, err)
+		os.Remove(DefaultNetworkStatePath)
+		return
+	}
+
+	log.Println("Restoring interfaces left configured by a previous run...")
+	for _, state := range snapshot.Interfaces {
+		if err := driver.RestoreInterfaceState(state); err != nil {
+			log.Printf("Warning: could not restore %s: %v", state.Name, err)
+		}
+	}
+	restoreRegulatoryDomain(snapshot.RegulatoryDomain)
+	os.Remove(DefaultNetworkStatePath)
+}
+
+// recordNetworkState records the regulatory domain and the state of ifaces
+// before capture reconfigures them, and saves it to survive a crash.
+func (app *Application) recordNetworkState(ifaces []string) {
+	snapshot := &domain.NetworkSnapshot{}
+	if reg, err := driver.RegulatoryDomain(); err == nil {
+		snapshot.RegulatoryDomain = reg
+	}
+	app.network = snapshot
+	for _, iface := range ifaces {
+		app.rememberInterface(iface)
+	}
+	app.saveNetworkState()
+}
+
+// rememberInterface records the state of iface, unless already known, before
+// it is put in monitor mode.
+func (app *Application) rememberInterface(iface string) {
+	if app.network == nil {
+		return
+	}
+	if _, ok := app.network.Interface(iface); ok {
+		return
+	}
+	state, err := driver.InterfaceState(iface)
+	if err != nil {
+		log.Printf("Warning: could not record the state of %s: %v", iface, err)
+		return
+	}
+	app.network.Interfaces = append(app.network.Interfaces, state)
+	app.saveNetworkState()
+}
+
+// restoreInterface puts iface back in its recorded state, or in managed mode
+// when it was never recorded.
+func (app *Application) restoreInterface(iface string) {
+	if app.network != nil {
+		if state, ok := app.network.Interface(iface); ok {
+			if err := driver.RestoreInterfaceState(state); err != nil {
+				log.Printf("Error restoring %s: %v", iface, err)
+			}
+			return
+		}
+	}
+	driver.DisableMonitorMode(iface)
+}
+
+// restoreRegulatoryDomain sets back the regulatory domain when it was
+// changed from original.
+func restoreRegulatoryDomain(original string) {
+	if original == "" {
+		return
+	}
+	if current, err := driver.RegulatoryDomain(); err == nil && current == original {
+		return
+	}
+	if err := driver.SetRegulatoryDomain(original); err != nil {
+		log.Printf("Error restoring regulatory domain %s: %v", original, err)
+		return
+	}
+	log.Printf("Regulatory domain restored to %s", original)
+}
+
+// saveNetworkState persists the recorded state until RestoreNetwork.
+func (app *Application) saveNetworkState() {
+	data, err := json.MarshalIndent(app.network, "", "  ")
+	if err == nil {
+		if err = os.MkdirAll(filepath.Dir(DefaultNetworkStatePath), 0755); err == nil {
+			err = os.WriteFile(DefaultNetworkStatePath, data, 0600)
+		}
+	}
+	if err != nil {
+		log.Printf("Warning: could not save the network state: %v", err)
+	}
+}
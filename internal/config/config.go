@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds all application configuration.
@@ -16,14 +17,76 @@ type Config struct {
 	Latitude     float64
 	Longitude    float64
 	MockMode     bool
+	MonitorVIF   bool   // Capture on a separate monitor VIF instead of switching the interface mode
+	Roles        string // Interface roles, e.g. "wlan0=capture,wlan1=injection,wlan2=ap" (empty: the first interface also attacks)
+	HopPlan      string // How hopping interfaces split the channels: band or interleave
+	RegDomain    string // ISO country code applied with 'iw reg set' (empty keeps the system setting)
+	PortalIface  string // Managed interface associating with open networks for captive portal checks (empty disables)
 	DBPath       string
 	PcapPath     string
+	DropDir      string // Folder watched for captures and 22000 hashes added by other tools (empty uses the capture directory's "incoming")
 	GRPCPort     int
+	GRPCAPIKey   bool   // Refuse gRPC clients and agents without a valid API key or client certificate
+	GRPCCert     string // TLS certificate of the gRPC server (empty serves plaintext)
+	GRPCKey      string
+	GRPCClientCA string // CA signing agent certificates; set, agents must use mutual TLS
 	Debug        bool
-	DwellTime    int // in milliseconds
+	DwellTime    int      // in milliseconds
+	DropBadFCS   bool     // Drop frames with a bad FCS instead of parsing them
+	Passive      bool     // Pure sensor: no injector, no attack engines, active endpoints refused
+	TrustedSSIDs []string // Legitimate networks that lookalike SSIDs are compared against
+	Origins      []string // Other origins allowed to call the API from a browser, e.g. "https://dash.example.com"
+	Require2FA   bool     // Admins must enroll in TOTP two-factor authentication before using the API
+	GroupRoles   string   // Identity provider groups mapped to roles, e.g. "wmap-admins=admin,wmap-ops=operator"
+	OIDCIssuer   string   // OpenID Connect provider for single sign-on (empty disables)
+	OIDCClientID string
+	OIDCSecret   string // Only from the environment, never a flag (visible in ps)
+	OIDCRedirect string // Callback URL registered with the provider, ending in /api/sso/callback
+	OIDCGroups   string // ID token claim listing the user's groups
+	LDAPURL      string // Directory authenticating users without a local account (empty disables)
+	LDAPUserDN   string // DN template of users, e.g. "uid=%s,ou=people,dc=example,dc=com"
+	LDAPGroups   string // Attribute of user entries listing their groups
 	ReaverPath   string
 	PixiewpsPath string
+	AircrackPath string
 	WorkspaceDir string
+	RulesPath    string // JSON file of alert rules, reloaded when it changes
+	PluginDir    string // Executables run as external analyzers
+	KismetURL    string // Kismet server polled as an additional sensor (empty disables)
+	KismetAPIKey string // Only from the environment, never a flag (visible in ps)
+	AgentRelease string // Signed agent release advertised for self-update (empty disables)
+	CMDBSource   string // Asset inventory file or URL synchronized as the trusted inventory (empty disables)
+	CMDBFormat   string // csv, json or netbox
+	CMDBToken    string // Only from the environment, never a flag (visible in ps)
+	Tracker      string // Issue tracker critical findings are filed in: jira or gitlab (empty disables)
+	TrackerURL   string // Base URL of the tracker instance
+	TrackerProj  string // Jira project key, or GitLab project ID or path
+	TrackerUser  string // Jira Cloud account email, empty for a personal access token
+	TrackerToken string // Only from the environment, never a flag (visible in ps)
+	TrackerType  string // Jira issue type
+	TrackerMin   string // Least severe finding filed
+	Schedule     string // Monitoring windows, e.g. "mon-fri 08:00-20:00" (empty uses the saved schedule)
+	BTController string // HCI controller running classic Bluetooth inquiry scans, e.g. hci0 (empty disables)
+	ZigbeePort   string // Serial port of an 802.15.4 sniffer dongle (empty disables)
+	ZigbeeFormat string // nrf or cc2531
+	ZigbeeChan   int    // 802.15.4 channel, 11-26
+	PowerSave    string // Capture duty cycle of battery powered sensors, e.g. "20s/40s" (empty disables)
+	BatteryCmd   string // Command printing the battery charge, for fuel gauges not in sysfs
+
+	ReloadInterval    time.Duration // How often signature and rule files are checked for changes (0 disables)
+	KismetInterval    time.Duration // How often the Kismet server is polled for device updates
+	CMDBInterval      time.Duration // How often the asset inventory is synchronized
+	BTInterval        time.Duration // How often a Bluetooth inquiry scan runs
+	ArtifactRetention time.Duration // How long reports and captures stay in the artifact store (0 keeps them)
+	FrameHistory      time.Duration // Raw frames kept in memory per adapter for retroactive capture (0 disables)
+
+	// Encryption at rest. The master key comes from MasterKeyFile, else from
+	// MasterPassphrase or a prompt at start; without either a generated key
+	// file next to the database is used.
+	MasterKeyFile    string
+	MasterPassphrase string // Only from the environment, never a flag (visible in ps)
+	PromptMasterKey  bool
+	EncryptCaptures  bool // Store handshake captures encrypted
 }
 
 // Load parses command line flags and environment variables to populate Config.
@@ -37,9 +100,58 @@ func Load() *Config {
 	cfg.Latitude = getEnvFloat("WMAP_LAT", 40.4168)
 	cfg.Longitude = getEnvFloat("WMAP_LNG", -3.7038)
 	cfg.MockMode = getEnvBool("WMAP_MOCK", false)
+	cfg.MonitorVIF = getEnvBool("WMAP_MONITOR_VIF", false)
+	cfg.Roles = getEnv("WMAP_ROLES", "")
+	cfg.HopPlan = getEnv("WMAP_HOP_PLAN", "band")
+	cfg.RegDomain = getEnv("WMAP_REGDOMAIN", "")
+	cfg.PortalIface = getEnv("WMAP_PORTAL_IFACE", "")
 	cfg.DBPath = getEnv("WMAP_DB", getDefaultDBPath())
 	cfg.WorkspaceDir = getEnv("WMAP_WORKSPACE_DIR", getDefaultWorkspaceDir())
+	cfg.RulesPath = getEnv("WMAP_RULES", "data/alert_rules.json")
+	cfg.PluginDir = getEnv("WMAP_PLUGIN_DIR", "")
+	cfg.DropDir = getEnv("WMAP_DROP_DIR", "")
+	cfg.KismetURL = getEnv("WMAP_KISMET_URL", "")
+	cfg.KismetAPIKey = getEnv("WMAP_KISMET_APIKEY", "")
+	cfg.AgentRelease = getEnv("WMAP_AGENT_RELEASE", "")
+	cfg.CMDBSource = getEnv("WMAP_CMDB", "")
+	cfg.CMDBFormat = getEnv("WMAP_CMDB_FORMAT", "csv")
+	cfg.CMDBToken = getEnv("WMAP_CMDB_TOKEN", "")
+	cfg.Tracker = getEnv("WMAP_TRACKER", "")
+	cfg.TrackerURL = getEnv("WMAP_TRACKER_URL", "")
+	cfg.TrackerProj = getEnv("WMAP_TRACKER_PROJECT", "")
+	cfg.TrackerUser = getEnv("WMAP_TRACKER_USER", "")
+	cfg.TrackerToken = getEnv("WMAP_TRACKER_TOKEN", "")
+	cfg.TrackerType = getEnv("WMAP_TRACKER_ISSUE_TYPE", "Bug")
+	cfg.TrackerMin = getEnv("WMAP_TRACKER_MIN_SEVERITY", "critical")
+	cfg.Schedule = getEnv("WMAP_SCHEDULE", "")
+	cfg.BTController = getEnv("WMAP_BT", "")
+	cfg.ZigbeePort = getEnv("WMAP_ZIGBEE", "")
+	cfg.ZigbeeFormat = getEnv("WMAP_ZIGBEE_FORMAT", "nrf")
+	cfg.PowerSave = getEnv("WMAP_POWER_SAVE", "")
+	cfg.BatteryCmd = getEnv("WMAP_BATTERY_CMD", "")
 	cfg.GRPCPort = int(getEnvFloat("WMAP_GRPC", 9000))
+	cfg.GRPCAPIKey = getEnvBool("WMAP_GRPC_REQUIRE_KEY", true)
+	cfg.GRPCCert = getEnv("WMAP_GRPC_TLS_CERT", "")
+	cfg.GRPCKey = getEnv("WMAP_GRPC_TLS_KEY", "")
+	cfg.GRPCClientCA = getEnv("WMAP_GRPC_CLIENT_CA", "")
+	cfg.DropBadFCS = getEnvBool("WMAP_DROP_BAD_FCS", true)
+	cfg.Passive = getEnvBool("WMAP_PASSIVE", false)
+	trustedStr := getEnv("WMAP_TRUSTED_SSIDS", "")
+	originsStr := getEnv("WMAP_ALLOWED_ORIGINS", "")
+	cfg.Require2FA = getEnvBool("WMAP_REQUIRE_2FA", false)
+	cfg.GroupRoles = getEnv("WMAP_SSO_GROUP_ROLES", "")
+	cfg.OIDCIssuer = getEnv("WMAP_OIDC_ISSUER", "")
+	cfg.OIDCClientID = getEnv("WMAP_OIDC_CLIENT_ID", "")
+	cfg.OIDCSecret = getEnv("WMAP_OIDC_CLIENT_SECRET", "")
+	cfg.OIDCRedirect = getEnv("WMAP_OIDC_REDIRECT_URL", "")
+	cfg.OIDCGroups = getEnv("WMAP_OIDC_GROUPS_CLAIM", "groups")
+	cfg.LDAPURL = getEnv("WMAP_LDAP_URL", "")
+	cfg.LDAPUserDN = getEnv("WMAP_LDAP_USER_DN", "")
+	cfg.LDAPGroups = getEnv("WMAP_LDAP_GROUP_ATTR", "memberOf")
+	cfg.MasterKeyFile = getEnv("WMAP_MASTER_KEY_FILE", "")
+	cfg.MasterPassphrase = getEnv("WMAP_MASTER_KEY", "")
+	cfg.EncryptCaptures = getEnvBool("WMAP_ENCRYPT_CAPTURES", false)
+	cfg.FrameHistory = getEnvDuration("WMAP_FRAME_HISTORY", 0)
 
 	// Command Line Flags (Override Env)
 	flag.StringVar(&ifaceStr, "i", ifaceStr, "Network interface(s) in monitor mode (comma separated)")
@@ -47,24 +159,79 @@ func Load() *Config {
 	flag.Float64Var(&cfg.Latitude, "lat", cfg.Latitude, "Static Latitude")
 	flag.Float64Var(&cfg.Longitude, "lng", cfg.Longitude, "Static Longitude")
 	flag.BoolVar(&cfg.MockMode, "mock", cfg.MockMode, "Run in mock mode (simulation)")
+	flag.BoolVar(&cfg.MonitorVIF, "monitor-vif", cfg.MonitorVIF, "Create a monitor VIF (e.g. wlan0mon) and keep the interface's connectivity")
+	flag.StringVar(&cfg.Roles, "roles", cfg.Roles, "Dedicate interfaces to capture (hopping), injection (attacks) or ap (rogue AP), e.g. wlan0=capture,wlan1=injection,wlan2=ap")
+	flag.StringVar(&cfg.HopPlan, "hop-plan", cfg.HopPlan, "How several capture interfaces split the channels: band (one per band, e.g. 2.4GHz and 5GHz) or interleave (alternate channels of both bands)")
+	flag.StringVar(&cfg.RegDomain, "reg", cfg.RegDomain, "Regulatory domain country code (e.g. ES, US)")
+	flag.StringVar(&cfg.PortalIface, "portal-iface", cfg.PortalIface, "Managed (not monitor) interface used to check open networks for captive portals")
 	flag.StringVar(&cfg.DBPath, "db", cfg.DBPath, "Path to SQLite database")
-	flag.StringVar(&cfg.PcapPath, "pcap", "", "Path to save PCAP file (empty to disable)")
+	flag.StringVar(&cfg.PcapPath, "pcap", "", "Path to save a pcapng recording of every adapter (empty to disable)")
+	flag.StringVar(&cfg.DropDir, "drop-dir", cfg.DropDir, "Folder watched for pcapng, pcap and hashcat 22000 files added by other tools (default: incoming in the handshake directory)")
 	flag.IntVar(&cfg.GRPCPort, "grpc", cfg.GRPCPort, "gRPC Server Port")
+	flag.BoolVar(&cfg.GRPCAPIKey, "grpc-require-key", cfg.GRPCAPIKey, "Require an API key, or a client certificate, from gRPC clients and agents (agent commands always require one)")
+	flag.StringVar(&cfg.GRPCCert, "grpc-tls-cert", cfg.GRPCCert, "TLS certificate of the gRPC server (empty serves plaintext, which agents refuse unless run with -insecure)")
+	flag.StringVar(&cfg.GRPCKey, "grpc-tls-key", cfg.GRPCKey, "TLS private key of the gRPC server")
+	flag.StringVar(&cfg.GRPCClientCA, "grpc-client-ca", cfg.GRPCClientCA, "CA of agent certificates: agents must authenticate with mutual TLS and are identified by their certificate's common name")
 	flag.BoolVar(&cfg.Debug, "debug", false, "Enable verbose debug logging")
 	flag.IntVar(&cfg.DwellTime, "dwell", 300, "Channel dwell time in milliseconds")
+	flag.BoolVar(&cfg.DropBadFCS, "drop-bad-fcs", cfg.DropBadFCS, "Drop frames with a bad FCS (when false they are only counted)")
+	flag.BoolVar(&cfg.Passive, "passive", cfg.Passive, "Passive sensor mode: never transmit (no injection or attack engines)")
+	flag.StringVar(&trustedStr, "trusted-ssids", trustedStr, "Trusted SSIDs to detect lookalike networks against (comma separated)")
+	flag.BoolVar(&cfg.Require2FA, "require-2fa", cfg.Require2FA, "Require admins to use TOTP two-factor authentication")
+	flag.StringVar(&cfg.GroupRoles, "sso-group-roles", cfg.GroupRoles, "Roles of OIDC and LDAP users by group, e.g. wmap-admins=admin,wmap-ops=operator (users in no listed group are refused)")
+	flag.StringVar(&cfg.OIDCIssuer, "oidc-issuer", cfg.OIDCIssuer, "OpenID Connect issuer URL for single sign-on (client secret in WMAP_OIDC_CLIENT_SECRET)")
+	flag.StringVar(&cfg.OIDCClientID, "oidc-client-id", cfg.OIDCClientID, "OpenID Connect client ID")
+	flag.StringVar(&cfg.OIDCRedirect, "oidc-redirect-url", cfg.OIDCRedirect, "OpenID Connect redirect URL, e.g. https://wmap.example.com/api/sso/callback")
+	flag.StringVar(&cfg.OIDCGroups, "oidc-groups-claim", cfg.OIDCGroups, "ID token claim listing the user's groups")
+	flag.StringVar(&cfg.LDAPURL, "ldap", cfg.LDAPURL, "LDAP directory authenticating users without a local account, e.g. ldaps://ldap.example.com")
+	flag.StringVar(&cfg.LDAPUserDN, "ldap-user-dn", cfg.LDAPUserDN, "DN template of LDAP users, e.g. uid=%s,ou=people,dc=example,dc=com")
+	flag.StringVar(&cfg.LDAPGroups, "ldap-group-attr", cfg.LDAPGroups, "Attribute of LDAP user entries listing their groups")
+	flag.StringVar(&originsStr, "allowed-origins", originsStr, "Origins besides the server's own allowed to use the API and WebSocket from a browser (comma separated)")
 	flag.StringVar(&cfg.ReaverPath, "reaver-path", "reaver", "Path to reaver binary")
 	flag.StringVar(&cfg.PixiewpsPath, "pixiewps-path", "pixiewps", "Path to pixiewps binary")
+	flag.StringVar(&cfg.AircrackPath, "aircrack-path", "aircrack-ng", "Path to aircrack-ng binary (PSK audit)")
 	flag.StringVar(&cfg.WorkspaceDir, "workspace-dir", cfg.WorkspaceDir, "Path to workspace directory")
+	flag.StringVar(&cfg.RulesPath, "rules", cfg.RulesPath, "Path to the JSON file of alert rules")
+	flag.StringVar(&cfg.PluginDir, "plugins", cfg.PluginDir, "Directory of external analyzer executables, none loaded if empty (see internal/adapters/plugin)")
+	flag.StringVar(&cfg.KismetURL, "kismet", cfg.KismetURL, "Kismet server URL to use as an additional sensor, e.g. http://localhost:2501 (API key in WMAP_KISMET_APIKEY)")
+	flag.StringVar(&cfg.AgentRelease, "agent-release", cfg.AgentRelease, "JSON release file (see tools/agent_release) advertised to agents for self-update")
+	flag.StringVar(&cfg.CMDBSource, "cmdb", cfg.CMDBSource, "Asset inventory (file path or URL, NetBox base URL) mapping MACs to owners and asset tags (token in WMAP_CMDB_TOKEN)")
+	flag.StringVar(&cfg.CMDBFormat, "cmdb-format", cfg.CMDBFormat, "Asset inventory format: csv, json or netbox")
+	flag.StringVar(&cfg.Tracker, "tracker", cfg.Tracker, "Issue tracker critical vulnerabilities and alerts are filed in: jira or gitlab (token in WMAP_TRACKER_TOKEN)")
+	flag.StringVar(&cfg.TrackerURL, "tracker-url", cfg.TrackerURL, "Base URL of the Jira or GitLab instance, e.g. https://example.atlassian.net")
+	flag.StringVar(&cfg.TrackerProj, "tracker-project", cfg.TrackerProj, "Jira project key, or GitLab project ID or path (group/project)")
+	flag.StringVar(&cfg.TrackerUser, "tracker-user", cfg.TrackerUser, "Jira Cloud account email the API token belongs to (empty sends the token as a personal access token)")
+	flag.StringVar(&cfg.TrackerType, "tracker-issue-type", cfg.TrackerType, "Jira issue type of the issues filed")
+	flag.StringVar(&cfg.TrackerMin, "tracker-min-severity", cfg.TrackerMin, "Least severe finding filed in the issue tracker: critical, high, medium, low or info")
+	flag.StringVar(&cfg.Schedule, "schedule", cfg.Schedule, "Capture only during these windows, e.g. \"mon-fri 08:00-20:00, sat 09:00-13:00\" (sensor local time)")
+	flag.StringVar(&cfg.BTController, "bt", cfg.BTController, "Bluetooth controller (e.g. hci0) running periodic classic inquiry scans")
+	flag.StringVar(&cfg.ZigbeePort, "zigbee", cfg.ZigbeePort, "Serial port of an 802.15.4/Zigbee sniffer dongle, e.g. /dev/ttyACM0")
+	flag.StringVar(&cfg.ZigbeeFormat, "zigbee-format", cfg.ZigbeeFormat, "Zigbee sniffer protocol: nrf (nRF 802.15.4 sniffer firmware) or cc2531 (TI packet sniffer frames)")
+	flag.IntVar(&cfg.ZigbeeChan, "zigbee-channel", 11, "802.15.4 channel captured by the Zigbee sniffer (11-26)")
+	flag.StringVar(&cfg.PowerSave, "power-save", cfg.PowerSave, "Power save duty cycle for battery powered sensors, capture on/radios off, e.g. 20s/40s (0s captures continuously)")
+	flag.StringVar(&cfg.BatteryCmd, "battery-cmd", cfg.BatteryCmd, "Command printing the battery charge in percent, optionally followed by \"charging\" (default reads /sys/class/power_supply)")
+	flag.DurationVar(&cfg.BTInterval, "bt-interval", 2*time.Minute, "Interval between Bluetooth inquiry scans")
+	flag.DurationVar(&cfg.CMDBInterval, "cmdb-interval", time.Hour, "Interval to synchronize the asset inventory")
+	flag.DurationVar(&cfg.KismetInterval, "kismet-interval", 5*time.Second, "Interval to poll the Kismet server for device updates")
+	flag.DurationVar(&cfg.ReloadInterval, "reload-interval", 5*time.Second, "Interval to check signature and rule files for changes (0 disables)")
+	flag.StringVar(&cfg.MasterKeyFile, "master-key-file", cfg.MasterKeyFile, "Path to the 32-byte master key encrypting credentials and captures at rest")
+	flag.BoolVar(&cfg.PromptMasterKey, "prompt-master-key", false, "Prompt for the master passphrase at start")
+	flag.BoolVar(&cfg.EncryptCaptures, "encrypt-captures", cfg.EncryptCaptures, "Encrypt handshake captures at rest")
+	flag.DurationVar(&cfg.ArtifactRetention, "artifact-retention", 30*24*time.Hour, "Retention of stored artifacts (0 keeps them forever)")
+	flag.DurationVar(&cfg.FrameHistory, "frame-history", cfg.FrameHistory, "Raw frames kept in memory per adapter, saved as evidence of alerts or on demand, e.g. 30s (0 disables: frames hold the traffic of third parties)")
 
 	flag.Parse()
 
-	// Parse interfaces
-	cfg.Interfaces = parseInterfaces(ifaceStr)
+	// Parse comma separated lists
+	cfg.Interfaces = parseList(ifaceStr)
+	cfg.TrustedSSIDs = parseList(trustedStr)
+	cfg.Origins = parseList(originsStr)
+	cfg.RegDomain = strings.ToUpper(strings.TrimSpace(cfg.RegDomain))
 
 	return cfg
 }
 
-func parseInterfaces(s string) []string {
+func parseList(s string) []string {
 	var ifaces []string
 	if s == "" {
 		return ifaces
@@ -104,6 +271,15 @@ func getEnvBool(key string, fallback bool) bool {
 	return fallback
 }
 
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value, ok := os.LookupEnv(key); ok {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
 // getDefaultDBPath returns the default database path in user's home directory.
 // Creates the directory if it doesn't exist.
 func getDefaultDBPath() string {
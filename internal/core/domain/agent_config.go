@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// CaptureSettings are the capture options of a sensor that can be changed
+// while it runs.
+type CaptureSettings struct {
+	DwellMs    int    `json:"dwell_ms"`
+	BPFFilter  string `json:"bpf_filter"`
+	DropBadFCS bool   `json:"drop_bad_fcs"`
+}
+
+// AgentConfig is a configuration pushed to an agent. Zero fields leave the
+// agent's setting unchanged.
+type AgentConfig struct {
+	Channels   map[string][]int `json:"channels,omitempty"` // Channel plan by interface
+	DwellMs    int              `json:"dwell_ms,omitempty"`
+	BPFFilter  string           `json:"bpf_filter,omitempty"`
+	DropBadFCS *bool            `json:"drop_bad_fcs,omitempty"`
+}
+
+// IsEmpty reports whether the configuration changes nothing.
+func (c AgentConfig) IsEmpty() bool {
+	return len(c.Channels) == 0 && c.DwellMs == 0 && c.BPFFilter == "" && c.DropBadFCS == nil
+}
+
+// Validate checks the values without knowing the agent's hardware.
+func (c AgentConfig) Validate() error {
+	if c.DwellMs < 0 {
+		return fmt.Errorf("dwell time must not be negative")
+	}
+	for iface, channels := range c.Channels {
+		for _, ch := range channels {
+			if ch < 1 || ch > 165 {
+				return fmt.Errorf("invalid channel %d for %s", ch, iface)
+			}
+		}
+	}
+	return nil
+}
+
+// Merge returns the configuration with the fields set in next applied on top,
+// so successive pushes add up to the agent's desired configuration.
+func (c AgentConfig) Merge(next AgentConfig) AgentConfig {
+	merged := c
+	if len(next.Channels) > 0 {
+		merged.Channels = make(map[string][]int, len(c.Channels)+len(next.Channels))
+		for iface, channels := range c.Channels {
+			merged.Channels[iface] = channels
+		}
+		for iface, channels := range next.Channels {
+			merged.Channels[iface] = channels
+		}
+	}
+	if next.DwellMs != 0 {
+		merged.DwellMs = next.DwellMs
+	}
+	if next.BPFFilter != "" {
+		merged.BPFFilter = next.BPFFilter
+	}
+	if next.DropBadFCS != nil {
+		merged.DropBadFCS = next.DropBadFCS
+	}
+	return merged
+}
+
+// AgentEffectiveConfig is the configuration an agent runs with.
+type AgentEffectiveConfig struct {
+	Channels map[string][]int `json:"channels"` // By interface
+	CaptureSettings
+}
+
+// AgentConfigAck is an agent's answer to a configuration push. Revision 0
+// reports the configuration the agent runs with when it connects.
+type AgentConfigAck struct {
+	Revision  int64                `json:"revision"`
+	Effective AgentEffectiveConfig `json:"effective"`
+	Error     string               `json:"error,omitempty"` // Settings that could not be applied
+}
+
+// AgentConfigStatus tracks the configuration of an agent: what was pushed,
+// what the agent acknowledged and what it actually runs with.
+type AgentConfigStatus struct {
+	Revision        int64                 `json:"revision"` // Latest pushed, 0 if none
+	Desired         *AgentConfig          `json:"desired,omitempty"`
+	AppliedRevision int64                 `json:"applied_revision"`
+	Effective       *AgentEffectiveConfig `json:"effective,omitempty"`
+	Error           string                `json:"error,omitempty"`
+	AppliedAt       time.Time             `json:"applied_at,omitempty"`
+}
+
+// Pending reports whether the latest push has not been acknowledged yet.
+func (s AgentConfigStatus) Pending() bool {
+	return s.Revision > s.AppliedRevision
+}
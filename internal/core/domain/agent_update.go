@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AgentUpdate advertises an agent release. Agents only install it when
+// Signature is a valid Ed25519 signature of SignedMessage by the release key
+// they are configured with, the binary downloaded from URL matches SHA256,
+// and Version is newer than theirs, so old signed releases cannot be
+// replayed to downgrade them.
+type AgentUpdate struct {
+	Version   string `json:"version"`
+	URL       string `json:"url"`
+	SHA256    string `json:"sha256"`    // Hex digest of the binary
+	Signature string `json:"signature"` // Base64
+}
+
+// SignedMessage returns what the release key signs: the version and the
+// digest, so neither can be swapped without invalidating the signature.
+func (u AgentUpdate) SignedMessage() []byte {
+	return []byte("wmap-agent " + u.Version + " " + u.SHA256)
+}
+
+// Validate checks the advertisement is complete and well formed.
+func (u AgentUpdate) Validate() error {
+	if u.Version == "" {
+		return fmt.Errorf("release version is required")
+	}
+	if _, ok := parseVersion(u.Version); !ok {
+		return fmt.Errorf("release version must be a semantic version, e.g. v1.2.3")
+	}
+	parsed, err := url.Parse(u.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("release URL must be an http(s) URL")
+	}
+	if digest, err := hex.DecodeString(u.SHA256); err != nil || len(digest) != 32 {
+		return fmt.Errorf("release sha256 must be a hex SHA-256 digest")
+	}
+	if _, err := base64.StdEncoding.DecodeString(u.Signature); err != nil || u.Signature == "" {
+		return fmt.Errorf("release signature must be base64")
+	}
+	return nil
+}
+
+// NewerThan reports whether the release is a later version than running.
+// Running versions that are not semantic versions, such as "dev" builds,
+// take any release.
+func (u AgentUpdate) NewerThan(running string) bool {
+	release, ok := parseVersion(u.Version)
+	if !ok {
+		return false
+	}
+	current, ok := parseVersion(running)
+	if !ok {
+		return true
+	}
+	return release.compare(current) > 0
+}
+
+// version is a semantic version; missing minor and patch numbers are zero.
+type version struct {
+	numbers    [3]int
+	prerelease string // Sorts before the release it precedes
+}
+
+// parseVersion parses "v1.2.3", "1.2" or "v2-rc1"; build metadata after a
+// "+" is ignored.
+func parseVersion(s string) (version, bool) {
+	var v version
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, v.prerelease, _ = strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.numbers[i] = n
+	}
+	return v, true
+}
+
+func (v version) compare(o version) int {
+	for i := range v.numbers {
+		if v.numbers[i] != o.numbers[i] {
+			if v.numbers[i] < o.numbers[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == o.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case o.prerelease == "":
+		return -1
+	}
+	return strings.Compare(v.prerelease, o.prerelease)
+}
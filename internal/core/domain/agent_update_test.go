@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgentUpdate_NewerThan(t *testing.T) {
+	tests := []struct {
+		release, running string
+		newer            bool
+	}{
+		{"v2", "v1", true},
+		{"v1.10.0", "v1.9.3", true},
+		{"v1.2.0", "v1.2.0-rc1", true},
+		{"v1.2.0-rc2", "v1.2.0-rc1", true},
+		{"v1.2.0+build5", "v1.1.0", true},
+		{"v1.0.0", "dev", true},
+		{"v1", "v1.0.0", false},
+		{"v1.4.9", "v1.5.0", false},
+		{"v1.2.0-rc1", "v1.2.0", false},
+		{"latest", "v1.0.0", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.newer, AgentUpdate{Version: tt.release}.NewerThan(tt.running), "%s over %s", tt.release, tt.running)
+	}
+}
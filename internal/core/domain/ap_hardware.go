@@ -0,0 +1,119 @@
+package domain
+
+import "slices"
+
+// configurableIEs are elements an AP adds or drops with its configuration,
+// not its firmware: Country, Power Constraint, RSN, Mobility Domain and
+// vendor elements, the latter compared on their own. They are left out of
+// the element order of hardware fingerprints.
+var configurableIEs = map[int]bool{7: true, 32: true, 48: true, 54: true, 221: true}
+
+// BeaconFingerprint is how the firmware of an AP builds its beacons, which
+// identifies the hardware when WPS does not name it.
+type BeaconFingerprint struct {
+	IEOrder        []int    `json:"ie_order"`                  // Element IDs in order
+	VendorIEs      []string `json:"vendor_ies,omitempty"`      // OUI and type, e.g. "00:50:f2:04"
+	Capabilities   uint16   `json:"capabilities"`              // Capability information field
+	HTCapabilities uint16   `json:"ht_capabilities,omitempty"` // HT capability information, 0 if not HT
+}
+
+// HardwareOrder returns the element order without configurable elements.
+func (f *BeaconFingerprint) HardwareOrder() []int {
+	order := make([]int, 0, len(f.IEOrder))
+	for _, id := range f.IEOrder {
+		if !configurableIEs[id] {
+			order = append(order, id)
+		}
+	}
+	return order
+}
+
+// APHardwareSignature is the beacon fingerprint of an AP model in the
+// bundled database.
+type APHardwareSignature struct {
+	ID             string   `json:"id"`
+	Vendor         string   `json:"vendor"`
+	Model          string   `json:"model"`
+	CPEVendor      string   `json:"cpe_vendor,omitempty"` // Vendor and product as named in CVE records
+	CPEProduct     string   `json:"cpe_product,omitempty"`
+	IEOrder        []int    `json:"ie_order"` // Hardware order, without configurable elements
+	VendorIEs      []string `json:"vendor_ies,omitempty"`
+	Capabilities   uint16   `json:"capabilities,omitempty"`    // 0 matches any
+	HTCapabilities uint16   `json:"ht_capabilities,omitempty"` // 0 matches any
+	Confidence     float64  `json:"confidence"`                // Base confidence (0.0 - 1.0)
+}
+
+// APHardware is the AP model identified from its beacons.
+type APHardware struct {
+	SignatureID string   `json:"signature_id"`
+	Vendor      string   `json:"vendor"`
+	Model       string   `json:"model"`
+	CPEVendor   string   `json:"cpe_vendor,omitempty"`
+	CPEProduct  string   `json:"cpe_product,omitempty"`
+	Confidence  float64  `json:"confidence"`
+	MatchedBy   []string `json:"matched_by"` // Fingerprint parts that matched
+}
+
+// Validate checks the signature names a model and an element order.
+func (s *APHardwareSignature) Validate() error {
+	if s.ID == "" {
+		return ErrEmptySignatureID
+	}
+	if s.Model == "" {
+		return ErrEmptyLabelModel
+	}
+	if s.Confidence < 0 || s.Confidence > 1 {
+		return ErrInvalidConfidence
+	}
+	if len(s.IEOrder) == 0 {
+		return ErrNoSignatureData
+	}
+	return nil
+}
+
+// Match scores a beacon fingerprint against the signature. The element
+// order must match; vendor elements and capability bits, where the
+// signature gives them, add to the score, so an order alone makes a weak
+// match. It returns nil without a match.
+func (s *APHardwareSignature) Match(f *BeaconFingerprint) *APHardware {
+	if f == nil || !slices.Equal(s.IEOrder, f.HardwareOrder()) {
+		return nil
+	}
+
+	const orderWeight, vendorWeight, htWeight, capWeight = 0.5, 0.25, 0.15, 0.1
+	hw := &APHardware{
+		SignatureID: s.ID,
+		Vendor:      s.Vendor,
+		Model:       s.Model,
+		CPEVendor:   s.CPEVendor,
+		CPEProduct:  s.CPEProduct,
+		MatchedBy:   []string{"ie_order"},
+	}
+	score := orderWeight
+
+	if len(s.VendorIEs) > 0 && containsAll(f.VendorIEs, s.VendorIEs) {
+		score += vendorWeight
+		hw.MatchedBy = append(hw.MatchedBy, "vendor_ies")
+	}
+	if s.HTCapabilities != 0 && s.HTCapabilities == f.HTCapabilities {
+		score += htWeight
+		hw.MatchedBy = append(hw.MatchedBy, "ht_capabilities")
+	}
+	if s.Capabilities != 0 && s.Capabilities == f.Capabilities {
+		score += capWeight
+		hw.MatchedBy = append(hw.MatchedBy, "capabilities")
+	}
+
+	hw.Confidence = score * s.Confidence
+	return hw
+}
+
+// containsAll reports whether every item of want is in have.
+func containsAll(have, want []string) bool {
+	for _, w := range want {
+		if !slices.Contains(have, w) {
+			return false
+		}
+	}
+	return true
+}
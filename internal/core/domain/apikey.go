@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// APIKeyHeader carries an API key on REST requests. gRPC clients send it as
+// metadata under the lowercase name.
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyScope limits what an automation client may do with an API key.
+type APIKeyScope string
+
+const (
+	APIKeyScopeRead   APIKeyScope = "read"
+	APIKeyScopeAttack APIKeyScope = "attack"
+	APIKeyScopeAdmin  APIKeyScope = "admin"
+)
+
+var (
+	ErrInvalidAPIKeyScope = errors.New("invalid API key scope")
+	ErrEmptyAPIKeyName    = errors.New("API key name cannot be empty")
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrAPIKeyExpired      = errors.New("API key expired")
+)
+
+// Role returns the role a request authenticated by a key of this scope acts
+// with: read keys are viewers, attack keys operators and admin keys admins.
+func (s APIKeyScope) Role() Role {
+	switch s {
+	case APIKeyScopeRead:
+		return RoleViewer
+	case APIKeyScopeAttack:
+		return RoleOperator
+	case APIKeyScopeAdmin:
+		return RoleAdmin
+	}
+	return ""
+}
+
+// IsValid checks if the scope is a recognized API key scope.
+func (s APIKeyScope) IsValid() bool {
+	return s.Role() != ""
+}
+
+// APIKey is a long-lived credential for scripts and CI jobs, which cannot go
+// through the interactive login. Only a hash of the secret is stored; the
+// secret itself is shown once, when the key is created.
+type APIKey struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Prefix    string      `json:"prefix"` // Start of the secret, to tell keys apart in lists
+	Hash      string      `json:"-" gorm:"uniqueIndex"`
+	Scope     APIKeyScope `json:"scope"`
+	UserID    string      `json:"user_id"` // Owner, whose role bounds the scope
+	CreatedAt time.Time   `json:"created_at"`
+	LastUsed  time.Time   `json:"last_used"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+}
+
+// Validate ensures the key is named and has a known scope.
+func (k *APIKey) Validate() error {
+	if k.Name == "" {
+		return ErrEmptyAPIKeyName
+	}
+	if !k.Scope.IsValid() {
+		return ErrInvalidAPIKeyScope
+	}
+	return nil
+}
+
+// Expired reports whether the key has an expiry date before now.
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && now.After(*k.ExpiresAt)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// ArtifactKind classifies files produced by wmap.
+type ArtifactKind string
+
+const (
+	ArtifactReport    ArtifactKind = "report"
+	ArtifactHandshake ArtifactKind = "handshake"
+	ArtifactPcap      ArtifactKind = "pcap"
+	ArtifactExport    ArtifactKind = "export"
+)
+
+// Artifact is a file registered in the workspace artifact store.
+type Artifact struct {
+	ID        string       `json:"id"`
+	Kind      ArtifactKind `json:"kind"`
+	Name      string       `json:"name"` // Download file name
+	Path      string       `json:"-"`
+	MimeType  string       `json:"mime_type"`
+	Size      int64        `json:"size"`
+	SHA256    string       `json:"sha256,omitempty"`
+	Managed   bool         `json:"managed"` // File owned by the store and deleted with the artifact
+	Sealed    bool         `json:"sealed"`  // File encrypted at rest, decrypted on download
+	CreatedAt time.Time    `json:"created_at"`
+	ExpiresAt time.Time    `json:"expires_at,omitempty"` // Zero keeps the artifact forever
+}
+
+// IsExpired reports whether the retention period of the artifact is over.
+func (a Artifact) IsExpired(now time.Time) bool {
+	return !a.ExpiresAt.IsZero() && now.After(a.ExpiresAt)
+}
+
+// ArtifactFilter selects artifacts to list.
+type ArtifactFilter struct {
+	Kind  ArtifactKind
+	Limit int
+}
+
+// ArtifactLink is a tokenized, time-limited download URL of an artifact.
+type ArtifactLink struct {
+	URL       string    `json:"url"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
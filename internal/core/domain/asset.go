@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// AssetCriticality ranks how much a business asset matters.
+type AssetCriticality string
+
+const (
+	CriticalityLow      AssetCriticality = "low"
+	CriticalityMedium   AssetCriticality = "medium"
+	CriticalityHigh     AssetCriticality = "high"
+	CriticalityCritical AssetCriticality = "critical"
+)
+
+// ErrInvalidCriticality is returned for a criticality outside the known levels.
+var ErrInvalidCriticality = errors.New("criticality must be low, medium, high or critical")
+
+// Rank orders criticalities, higher first; unset ranks lowest.
+func (c AssetCriticality) Rank() int {
+	switch c {
+	case CriticalityCritical:
+		return 4
+	case CriticalityHigh:
+		return 3
+	case CriticalityMedium:
+		return 2
+	case CriticalityLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AssetInfo ties a device to the business asset it is, as recorded by an
+// analyst, so findings can be reported against owners rather than MACs.
+type AssetInfo struct {
+	Owner       string           `json:"owner,omitempty"`
+	AssetTag    string           `json:"asset_tag,omitempty"`
+	Criticality AssetCriticality `json:"criticality,omitempty"`
+}
+
+// Validate normalizes the fields and checks the criticality.
+func (a *AssetInfo) Validate() error {
+	a.Owner = strings.TrimSpace(a.Owner)
+	a.AssetTag = strings.TrimSpace(a.AssetTag)
+	a.Criticality = AssetCriticality(strings.ToLower(strings.TrimSpace(string(a.Criticality))))
+	if a.Criticality != "" && a.Criticality.Rank() == 0 {
+		return ErrInvalidCriticality
+	}
+	return nil
+}
+
+// IsEmpty reports whether no field is set.
+func (a AssetInfo) IsEmpty() bool {
+	return a.Owner == "" && a.AssetTag == "" && a.Criticality == ""
+}
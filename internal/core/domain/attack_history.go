@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AttackKind identifies the engine that ran an attack.
+type AttackKind string
+
+const (
+	AttackKindDeauth      AttackKind = "deauth"
+	AttackKindWPS         AttackKind = "wps"
+	AttackKindAuthFlood   AttackKind = "auth_flood"
+	AttackKindProbeFlood  AttackKind = "probe_flood"
+	AttackKindCSA         AttackKind = "csa"
+	AttackKindBeaconSpoof AttackKind = "beacon_spoof"
+	AttackKindKarma       AttackKind = "karma"
+	AttackKindNAVJam      AttackKind = "nav_jam"
+	AttackKindPortal      AttackKind = "portal_check"
+)
+
+// AttackRecord is the persisted outcome of a finished attack. Engines drop
+// their in-memory status once the attack is cleaned up; the record keeps the
+// result in the workspace.
+type AttackRecord struct {
+	ID        string     `json:"id"`
+	Kind      AttackKind `json:"kind"`
+	Target    string     `json:"target"`
+	Operator  string     `json:"operator,omitempty"` // User who launched it, empty if unknown
+	Interface string     `json:"interface"`
+	Channel   int        `json:"channel"`
+	Config    string     `json:"config"` // JSON-encoded engine configuration
+	Status    string     `json:"status"` // Final engine status (stopped, failed, success...)
+
+	StartTime  time.Time `json:"start_time"`
+	EndTime    time.Time `json:"end_time"`
+	DurationMs int64     `json:"duration_ms"`
+
+	// Outcome
+	PacketsSent            int     `json:"packets_sent"`
+	HandshakeCaptured      bool    `json:"handshake_captured"`
+	DisconnectionConfirmed bool    `json:"disconnection_confirmed"`
+	CredentialsRecovered   bool    `json:"credentials_recovered"`
+	ClientsAffected        int     `json:"clients_affected"`
+	DeliveryRatio          float64 `json:"delivery_ratio"` // ACK-confirmed share of unicast frames, 0 if unknown
+	ErrorMessage           string  `json:"error_message,omitempty"`
+
+	Score int `json:"score"` // Effectiveness, 0-100
+}
+
+// NewAttackRecord builds the common part of a record for a finished attack.
+// A nil end time means the attack ended now.
+func NewAttackRecord(kind AttackKind, id string, config any, start time.Time, end *time.Time) AttackRecord {
+	record := AttackRecord{
+		ID:        id,
+		Kind:      kind,
+		StartTime: start,
+		EndTime:   time.Now(),
+	}
+	if end != nil {
+		record.EndTime = *end
+	}
+	if !start.IsZero() && record.EndTime.After(start) {
+		record.DurationMs = record.EndTime.Sub(start).Milliseconds()
+	}
+	if data, err := json.Marshal(config); err == nil {
+		record.Config = string(data)
+	}
+	return record
+}
+
+// Outcome summarises the result of the attack in a few words.
+func (r AttackRecord) Outcome() string {
+	switch {
+	case r.CredentialsRecovered:
+		return "Credentials recovered"
+	case r.HandshakeCaptured:
+		return "Handshake captured"
+	case r.DisconnectionConfirmed:
+		return "Disconnection confirmed"
+	case r.ClientsAffected > 0:
+		return fmt.Sprintf("%d clients affected", r.ClientsAffected)
+	case r.Status == string(AttackFailed) && r.ErrorMessage != "":
+		return "Failed: " + r.ErrorMessage
+	case r.Status == string(AttackFailed):
+		return "Failed"
+	default:
+		return "No confirmed effect"
+	}
+}
+
+// EffectivenessScore rates the attack from 0 to 100. Reaching the attack's
+// objective (handshake, credentials) scores full marks, an observed effect on
+// the target scores high, and frames sent without confirmed effect score low,
+// weighted by the delivery ratio when it is known.
+func (r AttackRecord) EffectivenessScore() int {
+	switch {
+	case r.HandshakeCaptured || r.CredentialsRecovered:
+		return 100
+	case r.DisconnectionConfirmed:
+		return 80
+	case r.ClientsAffected > 0:
+		return min(60+10*r.ClientsAffected, 100)
+	}
+
+	if r.PacketsSent == 0 {
+		return 0
+	}
+	score := 10 + int(r.DeliveryRatio*40)
+	if r.Status == string(AttackFailed) {
+		score /= 2
+	}
+	return score
+}
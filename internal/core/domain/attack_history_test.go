@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAttackRecord(t *testing.T) {
+	start := time.Now().Add(-90 * time.Second)
+	end := start.Add(90 * time.Second)
+	config := DeauthAttackConfig{TargetMAC: "00:11:22:33:44:55", Channel: 6}
+
+	r := NewAttackRecord(AttackKindDeauth, "id-1", config, start, &end)
+	if r.DurationMs != 90000 {
+		t.Errorf("DurationMs = %d, want 90000", r.DurationMs)
+	}
+	if !strings.Contains(r.Config, `"target_mac":"00:11:22:33:44:55"`) {
+		t.Errorf("config not encoded: %s", r.Config)
+	}
+}
+
+func TestAttackRecord_EffectivenessScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		record AttackRecord
+		want   int
+	}{
+		{"handshake captured", AttackRecord{PacketsSent: 50, HandshakeCaptured: true}, 100},
+		{"credentials recovered", AttackRecord{CredentialsRecovered: true}, 100},
+		{"disconnection confirmed", AttackRecord{PacketsSent: 50, DisconnectionConfirmed: true}, 80},
+		{"karma clients", AttackRecord{PacketsSent: 10, ClientsAffected: 2}, 80},
+		{"nothing sent", AttackRecord{Status: string(AttackFailed)}, 0},
+		{"sent, delivery unknown", AttackRecord{PacketsSent: 100}, 10},
+		{"sent, half acked", AttackRecord{PacketsSent: 100, DeliveryRatio: 0.5}, 30},
+		{"failed midway", AttackRecord{PacketsSent: 100, DeliveryRatio: 0.5, Status: string(AttackFailed)}, 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.record.EffectivenessScore(); got != tt.want {
+				t.Errorf("EffectivenessScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttackRecord_Outcome(t *testing.T) {
+	tests := []struct {
+		record AttackRecord
+		want   string
+	}{
+		{AttackRecord{HandshakeCaptured: true, CredentialsRecovered: true}, "Credentials recovered"},
+		{AttackRecord{HandshakeCaptured: true}, "Handshake captured"},
+		{AttackRecord{ClientsAffected: 3}, "3 clients affected"},
+		{AttackRecord{Status: string(AttackFailed), ErrorMessage: "injection failed"}, "Failed: injection failed"},
+		{AttackRecord{PacketsSent: 100}, "No confirmed effect"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.record.Outcome(); got != tt.want {
+			t.Errorf("Outcome() = %q, want %q", got, tt.want)
+		}
+	}
+}
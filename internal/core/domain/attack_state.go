@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AttackStatusAborted is the final status of an attack cut short by a server
+// restart and not resumed.
+const AttackStatusAborted = "aborted"
+
+// ActiveAttack describes a running attack, persisted while it runs so that it
+// can be resumed or marked aborted if the server stops before the engine
+// records it as finished.
+type ActiveAttack struct {
+	ID        string     `json:"id"`
+	Kind      AttackKind `json:"kind"`
+	Target    string     `json:"target"`
+	Operator  string     `json:"operator,omitempty"`
+	Interface string     `json:"interface"`
+	Channel   int        `json:"channel"`
+	Config    string     `json:"config"` // JSON-encoded engine configuration
+	StartedAt time.Time  `json:"started_at"`
+}
+
+// NewActiveAttack describes an attack launched now with the given engine
+// configuration.
+func NewActiveAttack(kind AttackKind, id, target, iface string, channel int, config any) ActiveAttack {
+	attack := ActiveAttack{
+		ID:        id,
+		Kind:      kind,
+		Target:    target,
+		Interface: iface,
+		Channel:   channel,
+		StartedAt: time.Now(),
+	}
+	if data, err := json.Marshal(config); err == nil {
+		attack.Config = string(data)
+	}
+	return attack
+}
+
+// Continuous reports whether the attack runs until stopped, with no packet or
+// time budget, so that restarting it after a restart is equivalent to having
+// never been interrupted. Attacks with a budget, and engines with their own
+// progress (WPS, Karma, NAV jamming), are not resumable.
+func (a ActiveAttack) Continuous() bool {
+	switch a.Kind {
+	case AttackKindDeauth, AttackKindAuthFlood, AttackKindProbeFlood, AttackKindCSA, AttackKindBeaconSpoof:
+	default:
+		return false
+	}
+	var budget struct {
+		PacketCount int           `json:"packet_count"`
+		Duration    time.Duration `json:"duration"`
+	}
+	if err := json.Unmarshal([]byte(a.Config), &budget); err != nil {
+		return false
+	}
+	return budget.PacketCount == 0 && budget.Duration == 0
+}
+
+// Aborted returns the history record of the attack, interrupted at end.
+func (a ActiveAttack) Aborted(end time.Time, reason string) AttackRecord {
+	record := AttackRecord{
+		ID:           a.ID,
+		Kind:         a.Kind,
+		Target:       a.Target,
+		Operator:     a.Operator,
+		Interface:    a.Interface,
+		Channel:      a.Channel,
+		Config:       a.Config,
+		Status:       AttackStatusAborted,
+		StartTime:    a.StartedAt,
+		EndTime:      end,
+		ErrorMessage: reason,
+	}
+	if end.After(a.StartedAt) {
+		record.DurationMs = end.Sub(a.StartedAt).Milliseconds()
+	}
+	return record
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActiveAttack_Continuous(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   AttackKind
+		config any
+		want   bool
+	}{
+		{"endless deauth", AttackKindDeauth, DeauthAttackConfig{PacketCount: 0}, true},
+		{"deauth burst", AttackKindDeauth, DeauthAttackConfig{PacketCount: 64}, false},
+		{"endless probe flood", AttackKindProbeFlood, ProbeFloodAttackConfig{}, true},
+		{"timed probe flood", AttackKindProbeFlood, ProbeFloodAttackConfig{Duration: time.Minute}, false},
+		{"wps", AttackKindWPS, WPSAttackConfig{}, false},
+		{"karma", AttackKindKarma, KarmaConfig{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewActiveAttack(tt.kind, "id-1", "00:11:22:33:44:55", "wlan0", 6, tt.config)
+			if got := a.Continuous(); got != tt.want {
+				t.Errorf("Continuous() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActiveAttack_Aborted(t *testing.T) {
+	a := NewActiveAttack(AttackKindDeauth, "id-1", "00:11:22:33:44:55", "wlan0", 6, DeauthAttackConfig{PacketCount: 64})
+	a.Operator = "alice"
+	end := a.StartedAt.Add(30 * time.Second)
+
+	r := a.Aborted(end, "server restarted")
+	if r.Status != AttackStatusAborted || r.Operator != "alice" || r.Interface != "wlan0" {
+		t.Errorf("unexpected record: %+v", r)
+	}
+	if r.DurationMs != 30000 {
+		t.Errorf("DurationMs = %d, want 30000", r.DurationMs)
+	}
+	if r.EffectivenessScore() != 0 {
+		t.Errorf("aborted attack scored %d", r.EffectivenessScore())
+	}
+}
@@ -18,6 +18,25 @@ const (
 	ActionConfigChange AuditAction = "CONFIG_CHANGE"
 	ActionWorkspace    AuditAction = "WORKSPACE_OP"
 	ActionInfo         AuditAction = "INFO"
+	ActionScopeDenied  AuditAction = "SCOPE_DENIED"
+	ActionDeviceForget AuditAction = "DEVICE_FORGOTTEN"
+	ActionDeviceAsset  AuditAction = "DEVICE_ASSET_SET"
+	ActionAPIKeyCreate AuditAction = "API_KEY_CREATED"
+	ActionAPIKeyRevoke AuditAction = "API_KEY_REVOKED"
+	ActionLoginFailed  AuditAction = "LOGIN_FAILED"
+	ActionLoginLocked  AuditAction = "LOGIN_LOCKED"
+	ActionUserCreate   AuditAction = "USER_CREATED"
+	ActionUserUpdate   AuditAction = "USER_UPDATED"
+	ActionUserDelete   AuditAction = "USER_DELETED"
+	ActionPasswordSet  AuditAction = "PASSWORD_CHANGED"
+	ActionTOTPChange   AuditAction = "TOTP_CHANGED"
+	ActionSessionEnd   AuditAction = "SESSION_REVOKED"
+	ActionROESigned    AuditAction = "ROE_SIGNED"
+	ActionROERevoked   AuditAction = "ROE_REVOKED"
+	ActionShareCreate  AuditAction = "SHARE_CREATED"
+	ActionShareRevoke  AuditAction = "SHARE_REVOKED"
+	ActionVulnSuppress AuditAction = "VULN_SUPPRESSED"
+	ActionVulnRestore  AuditAction = "VULN_UNSUPPRESSED"
 )
 
 // Domain Errors
@@ -66,7 +85,12 @@ func NewAuditLog(userID, username string, action AuditAction, target, details, i
 func isValidAction(action AuditAction) bool {
 	switch action {
 	case ActionLogin, ActionLogout, ActionScan, ActionDeauthStart,
-		ActionDeauthStop, ActionConfigChange, ActionWorkspace, ActionInfo:
+		ActionDeauthStop, ActionConfigChange, ActionWorkspace, ActionInfo,
+		ActionScopeDenied, ActionDeviceForget, ActionDeviceAsset,
+		ActionAPIKeyCreate, ActionAPIKeyRevoke, ActionLoginFailed, ActionLoginLocked,
+		ActionUserCreate, ActionUserUpdate, ActionUserDelete, ActionPasswordSet,
+		ActionTOTPChange, ActionSessionEnd, ActionROESigned, ActionROERevoked,
+		ActionShareCreate, ActionShareRevoke, ActionVulnSuppress, ActionVulnRestore:
 		return true
 	}
 	return false
@@ -16,6 +16,13 @@ const (
 	AuthFloodTypeAssociation AuthFloodType = "assoc"
 )
 
+const (
+	// DefaultAssocIEPadding is the amount of vendor IE data appended to association requests.
+	DefaultAssocIEPadding = 512
+	// MaxAssocIEPadding keeps association requests below the 802.11 management MMPDU limit.
+	MaxAssocIEPadding = 2048
+)
+
 // AuthFloodAttackConfig defines the domain rules and parameters for an authentication flood attack.
 type AuthFloodAttackConfig struct {
 	// Infrastructure
@@ -32,6 +39,7 @@ type AuthFloodAttackConfig struct {
 	TargetSSID     string        `json:"target_ssid"`      // Required for Assoc
 	UseRandomMAC   bool          `json:"use_random_mac"`   // True for random source MAC
 	FixedSourceMAC string        `json:"fixed_source_mac"` // Used if UseRandomMAC is false
+	IEPaddingBytes int           `json:"ie_padding_bytes"` // Oversized vendor IEs for Assoc (0 = default)
 }
 
 // NewAuthFloodDefaultConfig returns a configuration with sane defaults for a standard flood.
@@ -59,6 +67,14 @@ func (c *AuthFloodAttackConfig) Validate() error {
 		return errors.New("target SSID is mandatory for association flood attacks")
 	}
 
+	if c.IEPaddingBytes < 0 || c.IEPaddingBytes > MaxAssocIEPadding {
+		return fmt.Errorf("IE padding must be between 0 and %d bytes", MaxAssocIEPadding)
+	}
+
+	if c.AttackType == AuthFloodTypeAssociation && c.IEPaddingBytes == 0 {
+		c.IEPaddingBytes = DefaultAssocIEPadding
+	}
+
 	if !c.UseRandomMAC && c.FixedSourceMAC != "" && !IsValidMAC(c.FixedSourceMAC) {
 		return fmt.Errorf("invalid source MAC: %s", c.FixedSourceMAC)
 	}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Auto-capture defaults, used for zero config values.
+const (
+	DefaultAutoCaptureBurst = 16               // Deauthentication frames per target
+	DefaultAutoCaptureWait  = 20 * time.Second // Time to wait for material after the burst
+	MaxAutoCaptureWait      = 5 * time.Minute
+)
+
+// ErrInvalidAutoCapture is returned for auto-capture settings out of range.
+var ErrInvalidAutoCapture = errors.New("invalid auto-capture config")
+
+// AutoCaptureConfig tunes a supervised handshake capture run over the
+// WPA-PSK access points in scope. APs with material already are skipped.
+type AutoCaptureConfig struct {
+	BurstPackets int           `json:"burst_packets"`       // Deauthentication frames sent per target
+	Wait         time.Duration `json:"wait"`                // Per target, for a handshake or PMKID
+	MaxTargets   int           `json:"max_targets"`         // 0 for every eligible AP
+	MinRSSI      int           `json:"min_rssi,omitempty"`  // Skip APs heard weaker, e.g. -80
+	Interface    string        `json:"interface,omitempty"` // Injection interface, picked by role if empty
+}
+
+// WithDefaults returns the config with zero values replaced by defaults.
+func (c AutoCaptureConfig) WithDefaults() AutoCaptureConfig {
+	if c.BurstPackets == 0 {
+		c.BurstPackets = DefaultAutoCaptureBurst
+	}
+	if c.Wait == 0 {
+		c.Wait = DefaultAutoCaptureWait
+	}
+	return c
+}
+
+// Validate checks the settings are in range.
+func (c AutoCaptureConfig) Validate() error {
+	if c.BurstPackets < 1 || c.BurstPackets > 1000 {
+		return fmt.Errorf("%w: burst_packets must be between 1 and 1000", ErrInvalidAutoCapture)
+	}
+	if c.Wait < time.Second || c.Wait > MaxAutoCaptureWait {
+		return fmt.Errorf("%w: wait must be between 1s and %v", ErrInvalidAutoCapture, MaxAutoCaptureWait)
+	}
+	if c.MaxTargets < 0 {
+		return fmt.Errorf("%w: max_targets must not be negative", ErrInvalidAutoCapture)
+	}
+	if c.Interface != "" && !IsValidInterface(c.Interface) {
+		return ErrInvalidInterfaceName
+	}
+	return nil
+}
+
+// AutoCaptureOutcome is what a target yielded.
+type AutoCaptureOutcome string
+
+const (
+	CaptureHandshake AutoCaptureOutcome = "handshake" // Crackable 4-way handshake
+	CapturePMKID     AutoCaptureOutcome = "pmkid"
+	CaptureNothing   AutoCaptureOutcome = "nothing"
+	CaptureSkipped   AutoCaptureOutcome = "skipped" // Refused before the burst, see Detail
+	CaptureFailed    AutoCaptureOutcome = "failed"
+)
+
+// Yielded reports whether the outcome is crackable material.
+func (o AutoCaptureOutcome) Yielded() bool {
+	return o == CaptureHandshake || o == CapturePMKID
+}
+
+// AutoCaptureResult is the outcome of one target of a run.
+type AutoCaptureResult struct {
+	BSSID    string             `json:"bssid"`
+	SSID     string             `json:"ssid,omitempty"`
+	Channel  int                `json:"channel"`
+	Outcome  AutoCaptureOutcome `json:"outcome"`
+	Detail   string             `json:"detail,omitempty"`
+	Duration time.Duration      `json:"duration"`
+}
+
+// AutoCaptureStatus reports the progress of the current or last run, and
+// which networks yielded crackable material.
+type AutoCaptureStatus struct {
+	Running    bool                `json:"running"`
+	StartedAt  time.Time           `json:"started_at,omitempty"`
+	FinishedAt time.Time           `json:"finished_at,omitempty"`
+	Operator   string              `json:"operator,omitempty"`
+	Config     AutoCaptureConfig   `json:"config"`
+	Targets    int                 `json:"targets"`           // Eligible APs selected
+	Current    string              `json:"current,omitempty"` // BSSID being worked on
+	Results    []AutoCaptureResult `json:"results"`
+	Yielded    int                 `json:"yielded"` // Targets with a handshake or PMKID
+	Error      string              `json:"error,omitempty"`
+}
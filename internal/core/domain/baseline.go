@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidLearningPeriod is returned when an enabled baseline has no learning period.
+var ErrInvalidLearningPeriod = errors.New("baseline learning period must be positive")
+
+// BaselineConfig controls continuous monitoring of a workspace: devices seen
+// during the learning period form the baseline, and any device first seen
+// afterwards raises a NEW_DEVICE alert.
+type BaselineConfig struct {
+	Enabled          bool          `json:"enabled"`
+	LearningPeriod   time.Duration `json:"learning_period"`
+	StartedAt        time.Time     `json:"started_at"`        // Start of the learning period
+	IgnoreRandomized bool          `json:"ignore_randomized"` // Skip clients using randomized MACs
+	APsOnly          bool          `json:"aps_only"`          // Only alert on new access points
+}
+
+// Validate performs internal consistency checks on the configuration.
+func (c BaselineConfig) Validate() error {
+	if c.Enabled && c.LearningPeriod <= 0 {
+		return ErrInvalidLearningPeriod
+	}
+	return nil
+}
+
+// LearnedAt returns the end of the learning period.
+func (c BaselineConfig) LearnedAt() time.Time {
+	return c.StartedAt.Add(c.LearningPeriod)
+}
+
+// IsLearning reports whether the baseline is still being learned at t.
+func (c BaselineConfig) IsLearning(t time.Time) bool {
+	return c.Enabled && t.Before(c.LearnedAt())
+}
+
+// IsNewDevice reports whether the device was first seen after the learning
+// period and passes the sensitivity filters.
+func (c BaselineConfig) IsNewDevice(device Device) bool {
+	if !c.Enabled || c.StartedAt.IsZero() || device.FirstSeen.IsZero() {
+		return false
+	}
+	if !device.FirstSeen.After(c.LearnedAt()) {
+		return false
+	}
+	if c.APsOnly && !device.IsAP() {
+		return false
+	}
+	if c.IgnoreRandomized && device.IsRandomized {
+		return false
+	}
+	return true
+}
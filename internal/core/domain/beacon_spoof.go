@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Beacon security templates
+const (
+	BeaconSecurityOpen = "open"
+	BeaconSecurityWPA2 = "wpa2"
+	BeaconSecurityWPA3 = "wpa3"
+)
+
+// DefaultBeaconInterval is the standard beacon interval in Time Units (1 TU = 1024us).
+const DefaultBeaconInterval = 100
+
+// maxBeaconRates is the number of rates the Supported (8) and Extended
+// Supported Rates (255) elements can carry.
+const maxBeaconRates = 8 + 255
+
+// BeaconIEOverride replaces, adds or removes an Information Element of the spoofed beacon.
+type BeaconIEOverride struct {
+	ID     uint8  `json:"id"`
+	Data   string `json:"data,omitempty"` // Hex-encoded element body
+	Remove bool   `json:"remove,omitempty"`
+}
+
+// Bytes decodes the hex body of the override.
+func (o BeaconIEOverride) Bytes() ([]byte, error) {
+	return hex.DecodeString(strings.ReplaceAll(o.Data, ":", ""))
+}
+
+// BeaconSpoofConfig defines a beacon transmitter that either clones an observed AP
+// (CloneBSSID) or crafts a network from the template fields below.
+type BeaconSpoofConfig struct {
+	// Source
+	CloneBSSID string `json:"clone_bssid,omitempty"` // AP from the registry to clone
+	BSSID      string `json:"bssid,omitempty"`       // Transmitted BSSID (defaults to CloneBSSID, random otherwise)
+
+	// Template (overrides cloned values when set)
+	SSID           string   `json:"ssid,omitempty"`
+	Channel        int      `json:"channel,omitempty"`
+	Security       string   `json:"security,omitempty"`        // "open", "wpa2" or "wpa3"
+	RSN            *RSNInfo `json:"rsn,omitempty"`             // Exact RSN element, takes precedence over Security
+	BeaconInterval int      `json:"beacon_interval,omitempty"` // In TU (0 = 100)
+	Rates          []int    `json:"rates,omitempty"`           // Rate codes in 500 kbps units, 0x80 marks basic rates (802.11g set if empty)
+
+	IEOverrides []BeaconIEOverride `json:"ie_overrides,omitempty"`
+
+	// Infrastructure
+	Interface string `json:"interface,omitempty"` // Optional, auto-selected if empty
+
+	// Flow Control
+	PacketCount    int           `json:"packet_count"`    // 0 for continuous
+	PacketInterval time.Duration `json:"packet_interval"` // Defaults to the beacon interval
+	Duration       time.Duration `json:"duration"`        // 0 for no time limit
+}
+
+// Validate ensures the configuration adheres to business and protocol rules.
+func (c *BeaconSpoofConfig) Validate() error {
+	if c.CloneBSSID != "" && !IsValidMAC(c.CloneBSSID) {
+		return fmt.Errorf("invalid clone BSSID: %s", c.CloneBSSID)
+	}
+
+	if c.BSSID != "" && !IsValidMAC(c.BSSID) {
+		return fmt.Errorf("invalid BSSID: %s", c.BSSID)
+	}
+
+	if c.CloneBSSID == "" && c.SSID == "" {
+		return errors.New("either a clone BSSID or a template SSID is required")
+	}
+
+	if len(c.SSID) > 32 {
+		return fmt.Errorf("SSID too long: %q", c.SSID)
+	}
+
+	if c.Channel < 0 || c.Channel > 165 {
+		return fmt.Errorf("invalid WiFi channel: %d", c.Channel)
+	}
+
+	switch c.Security {
+	case "", BeaconSecurityOpen, BeaconSecurityWPA2, BeaconSecurityWPA3:
+	default:
+		return fmt.Errorf("unknown security template: %s", c.Security)
+	}
+
+	if c.BeaconInterval < 0 || c.BeaconInterval > 65535 {
+		return fmt.Errorf("invalid beacon interval: %d", c.BeaconInterval)
+	}
+
+	if len(c.Rates) > maxBeaconRates {
+		return fmt.Errorf("too many rates: %d (max %d)", len(c.Rates), maxBeaconRates)
+	}
+	for _, r := range c.Rates {
+		if r <= 0 || r > 0xff || r&0x7f == 0 {
+			return fmt.Errorf("invalid rate code: %d", r)
+		}
+	}
+
+	for _, o := range c.IEOverrides {
+		data, err := o.Bytes()
+		if err != nil {
+			return fmt.Errorf("invalid data for IE %d: %w", o.ID, err)
+		}
+		if len(data) > 255 {
+			return fmt.Errorf("IE %d exceeds 255 bytes", o.ID)
+		}
+	}
+
+	if c.Interface != "" && !IsValidInterface(c.Interface) {
+		return fmt.Errorf("invalid interface name: %s", c.Interface)
+	}
+
+	if c.PacketCount < 0 {
+		return errors.New("packet count cannot be negative")
+	}
+
+	if c.PacketInterval < 0 {
+		return errors.New("packet interval cannot be negative")
+	}
+
+	if c.Duration < 0 {
+		return errors.New("duration cannot be negative")
+	}
+
+	return nil
+}
+
+// BeaconSpoofStatus encapsulates the runtime state of a beacon spoofing attack.
+type BeaconSpoofStatus struct {
+	ID           string            `json:"id"`
+	Config       BeaconSpoofConfig `json:"config"`
+	Status       AttackStatus      `json:"status"`
+	PacketsSent  int               `json:"packets_sent"`
+	StartTime    time.Time         `json:"start_time"`
+	EndTime      *time.Time        `json:"end_time,omitempty"`
+	ErrorMessage string            `json:"error_message,omitempty"`
+}
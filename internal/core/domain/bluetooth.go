@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"net"
+	"time"
+)
+
+// BluetoothMACProximity is how far apart, in the device part of the address,
+// a Bluetooth and a Wi-Fi address can be for both to be taken as the same
+// combo chip. Vendors assign them consecutively, usually one or two apart.
+const BluetoothMACProximity = 8
+
+// BluetoothDevice is a classic (BR/EDR) Bluetooth device answering inquiry
+// scans.
+type BluetoothDevice struct {
+	Address     string    `json:"address"`
+	Name        string    `json:"name,omitempty"`
+	Class       uint32    `json:"class"`        // Class of Device, 24 bits
+	DeviceClass string    `json:"device_class"` // Major class, e.g. "phone"
+	RSSI        int       `json:"rssi"`         // dBm, 0 when the controller did not report it
+	Vendor      string    `json:"vendor,omitempty"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+	Inquiries   int       `json:"inquiries"` // Scans the device answered
+	// RelatedMACs are Wi-Fi devices in the same vendor OUI block, likely
+	// the Wi-Fi side of the same chip, closest first.
+	RelatedMACs []string `json:"related_macs,omitempty"`
+}
+
+// bluetoothMajorClasses names the major device classes of the Class of Device.
+var bluetoothMajorClasses = map[uint32]string{
+	0x00: "miscellaneous",
+	0x01: "computer",
+	0x02: "phone",
+	0x03: "network",
+	0x04: "audio_video",
+	0x05: "peripheral",
+	0x06: "imaging",
+	0x07: "wearable",
+	0x08: "toy",
+	0x09: "health",
+	0x1F: "uncategorized",
+}
+
+// BluetoothMajorClass returns the name of the major device class of a Class
+// of Device.
+func BluetoothMajorClass(class uint32) string {
+	if name, ok := bluetoothMajorClasses[(class>>8)&0x1F]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Merge updates the device with a newer inquiry result of the same address.
+func (d *BluetoothDevice) Merge(seen BluetoothDevice) {
+	if seen.Name != "" {
+		d.Name = seen.Name
+	}
+	if seen.Class != 0 {
+		d.Class = seen.Class
+		d.DeviceClass = seen.DeviceClass
+	}
+	if seen.RSSI != 0 {
+		d.RSSI = seen.RSSI
+	}
+	if seen.Vendor != "" {
+		d.Vendor = seen.Vendor
+	}
+	if seen.LastSeen.After(d.LastSeen) {
+		d.LastSeen = seen.LastSeen
+	}
+	d.Inquiries++
+}
+
+// BluetoothMACDistance returns how far apart the device parts of a Bluetooth
+// and a Wi-Fi address are, and false if they are not in the same OUI block.
+// Randomized Wi-Fi addresses never match.
+func BluetoothMACDistance(btAddr, wifiMAC string) (int, bool) {
+	bt, err := net.ParseMAC(btAddr)
+	if err != nil || len(bt) != 6 {
+		return 0, false
+	}
+	wifi, err := net.ParseMAC(wifiMAC)
+	if err != nil || len(wifi) != 6 || wifi[0]&0x02 != 0 { // Locally administered
+		return 0, false
+	}
+	if bt[0] != wifi[0] || bt[1] != wifi[1] || bt[2] != wifi[2] {
+		return 0, false
+	}
+	nic := func(mac net.HardwareAddr) int { return int(mac[3])<<16 | int(mac[4])<<8 | int(mac[5]) }
+	distance := nic(bt) - nic(wifi)
+	if distance < 0 {
+		distance = -distance
+	}
+	return distance, true
+}
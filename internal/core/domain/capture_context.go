@@ -0,0 +1,28 @@
+package domain
+
+// CaptureContext describes the circumstances of a recording. It is written
+// as comments in the capture files so they remain attributable once shared.
+type CaptureContext struct {
+	Workspace string `json:"workspace,omitempty"`
+	Operator  string `json:"operator,omitempty"` // User who launched the attack
+	AttackID  string `json:"attack_id,omitempty"`
+}
+
+// Comments returns the set fields as "key: value" capture comments.
+func (c CaptureContext) Comments() []string {
+	var comments []string
+	if c.Workspace != "" {
+		comments = append(comments, "workspace: "+c.Workspace)
+	}
+	if c.Operator != "" {
+		comments = append(comments, "operator: "+c.Operator)
+	}
+	if c.AttackID != "" {
+		comments = append(comments, "attack: "+c.AttackID)
+	}
+	return comments
+}
+
+// CaptureContextFunc returns the context of the frames captured on a BSSID,
+// or of a whole recording when the BSSID is empty.
+type CaptureContextFunc func(bssid string) CaptureContext
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// CaptureImport reports the handshakes and PMKIDs found in a capture file
+// recorded by another tool, such as hcxdumptool.
+type CaptureImport struct {
+	File        string   `json:"file"`
+	Application string   `json:"application,omitempty"` // Recording tool, from the file's metadata
+	Hardware    string   `json:"hardware,omitempty"`
+	OS          string   `json:"os,omitempty"`
+	Comments    []string `json:"comments,omitempty"`  // Section and packet comments
+	ToolMACs    []string `json:"tool_macs,omitempty"` // Addresses the tool used for its own frames
+	Packets     int      `json:"packets"`             // Frames, or hash lines of a hash file
+	Networks    int      `json:"networks"`            // BSSIDs with a known ESSID
+	Handshakes  int      `json:"handshakes"`          // Station sessions saved with a crackable handshake
+	PMKIDs      int      `json:"pmkids"`              // BSSIDs with a saved PMKID
+
+	// Captures lists the networks with a handshake or PMKID, by BSSID
+	Captures []CapturedNetwork `json:"captures,omitempty"`
+}
+
+// CapturedNetwork is a network whose handshake or PMKID was imported.
+type CapturedNetwork struct {
+	BSSID     string    `json:"bssid"`
+	SSID      string    `json:"ssid,omitempty"`
+	Handshake bool      `json:"handshake,omitempty"`
+	PMKID     bool      `json:"pmkid,omitempty"`
+	LastSeen  time.Time `json:"last_seen,omitempty"` // Of its last frame, zero when the file has no timestamps
+}
+
+// Devices returns the captured networks as access point observations, so
+// the registry marks them as having a handshake.
+func (c CaptureImport) Devices() []Device {
+	devices := make([]Device, 0, len(c.Captures))
+	for _, n := range c.Captures {
+		devices = append(devices, Device{
+			MAC:            n.BSSID,
+			Type:           DeviceTypeAP,
+			SSID:           n.SSID,
+			HasHandshake:   true,
+			LastPacketTime: n.LastSeen,
+		})
+	}
+	return devices
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CSAFrameMode selects the frame carrying the Channel Switch Announcement element.
+type CSAFrameMode string
+
+const (
+	// CSAModeAction sends Spectrum Management action frames (compact, unicast friendly).
+	CSAModeAction CSAFrameMode = "action"
+	// CSAModeBeacon sends spoofed beacons of the target AP with the CSA element appended.
+	CSAModeBeacon CSAFrameMode = "beacon"
+)
+
+// CSAAttackConfig defines a standalone Channel Switch Announcement attack.
+// Spoofed CSAs push clients off the AP's channel and are honoured by many
+// clients even when PMF is enabled, since beacons are not protected.
+type CSAAttackConfig struct {
+	// Infrastructure
+	TargetBSSID string `json:"target_bssid"`
+	TargetMAC   string `json:"target_mac,omitempty"`  // Client to target; broadcast if empty
+	TargetSSID  string `json:"target_ssid,omitempty"` // Used in beacon mode, auto-detected if empty
+	Interface   string `json:"interface,omitempty"`   // Optional, auto-selected if empty
+	Channel     int    `json:"channel,omitempty"`     // AP's current channel, auto-detected if 0
+
+	// Announcement
+	NewChannel  int          `json:"new_channel"`  // Channel announced to clients
+	SwitchCount int          `json:"switch_count"` // Beacon intervals until the switch (0 = immediate)
+	FrameMode   CSAFrameMode `json:"frame_mode"`   // "action" (default) or "beacon"
+
+	// Flow Control
+	PacketCount    int           `json:"packet_count"`    // 0 for continuous
+	PacketInterval time.Duration `json:"packet_interval"` // Time between packets
+}
+
+// Validate ensures the configuration adheres to business and protocol rules.
+func (c *CSAAttackConfig) Validate() error {
+	if !IsValidMAC(c.TargetBSSID) {
+		return fmt.Errorf("invalid target BSSID: %s", c.TargetBSSID)
+	}
+
+	if c.TargetMAC != "" && !IsValidMAC(c.TargetMAC) {
+		return fmt.Errorf("invalid target MAC: %s", c.TargetMAC)
+	}
+
+	if c.Interface != "" && !IsValidInterface(c.Interface) {
+		return fmt.Errorf("invalid interface name: %s", c.Interface)
+	}
+
+	if c.Channel < 0 || c.Channel > 165 {
+		return fmt.Errorf("invalid WiFi channel: %d", c.Channel)
+	}
+
+	if c.NewChannel < 1 || c.NewChannel > 165 {
+		return fmt.Errorf("invalid announced channel: %d", c.NewChannel)
+	}
+
+	if c.Channel != 0 && c.NewChannel == c.Channel {
+		return errors.New("announced channel must differ from the current channel")
+	}
+
+	if c.SwitchCount < 0 || c.SwitchCount > 255 {
+		return fmt.Errorf("switch count must be between 0 and 255: %d", c.SwitchCount)
+	}
+
+	switch c.FrameMode {
+	case "":
+		c.FrameMode = CSAModeAction
+	case CSAModeAction, CSAModeBeacon:
+	default:
+		return fmt.Errorf("unknown CSA frame mode: %s", c.FrameMode)
+	}
+
+	if len(c.TargetSSID) > 32 {
+		return fmt.Errorf("SSID too long: %q", c.TargetSSID)
+	}
+
+	if c.PacketCount < 0 {
+		return errors.New("packet count cannot be negative")
+	}
+
+	if c.PacketInterval < 0 {
+		return errors.New("packet interval cannot be negative")
+	}
+
+	return nil
+}
+
+// CSAAttackStatus encapsulates the runtime state of a CSA attack.
+type CSAAttackStatus struct {
+	ID           string          `json:"id"`
+	Config       CSAAttackConfig `json:"config"`
+	Status       AttackStatus    `json:"status"`
+	PacketsSent  int             `json:"packets_sent"`
+	StartTime    time.Time       `json:"start_time"`
+	EndTime      *time.Time      `json:"end_time,omitempty"`
+	ErrorMessage string          `json:"error_message,omitempty"`
+}
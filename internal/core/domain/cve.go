@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // CVERecord represents a Common Vulnerabilities and Exposures entry
 // from the National Vulnerability Database (NVD) or similar sources.
@@ -33,7 +36,7 @@ type CVERecord struct {
 type CVEMatch struct {
 	CVE        CVERecord `json:"cve"`
 	Confidence float64   `json:"confidence"` // 0.0-1.0
-	MatchType  string    `json:"match_type"` // "exact", "wps", "keyword"
+	MatchType  string    `json:"match_type"` // "exact", "wps", "fingerprint", "keyword"
 	Evidence   []string  `json:"evidence"`   // What triggered the match
 }
 
@@ -43,3 +46,22 @@ type CVESyncStatus struct {
 	RecordCount  int       `json:"record_count"`
 	ErrorMessage string    `json:"error_message,omitempty"`
 }
+
+// MaxAlertCVEs bounds the CVEs an alert carries.
+const MaxAlertCVEs = 3
+
+// AlertCVE is a CVE mapped to the device raising an alert, so notifications
+// carry what to patch.
+type AlertCVE struct {
+	ID         string  `json:"id"`             // e.g., "CVE-2020-3111"
+	CVSS       float64 `json:"cvss,omitempty"` // 0 when the CVE is not in the database
+	CVSSVector string  `json:"cvss_vector,omitempty"`
+}
+
+// String renders the CVE for notifications, e.g. "CVE-2020-3111 (CVSS 9.8)".
+func (c AlertCVE) String() string {
+	if c.CVSS == 0 {
+		return c.ID
+	}
+	return fmt.Sprintf("%s (CVSS %.1f)", c.ID, c.CVSS)
+}
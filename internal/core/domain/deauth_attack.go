@@ -24,6 +24,7 @@ type AttackStatus string
 
 const (
 	AttackPending AttackStatus = "pending"
+	AttackQueued  AttackStatus = "queued" // Waiting for the channel lock
 	AttackRunning AttackStatus = "running"
 	AttackPaused  AttackStatus = "paused"
 	AttackStopped AttackStatus = "stopped"
@@ -107,6 +108,14 @@ type DeauthAttackStatus struct {
 	EndTime           *time.Time         `json:"end_time,omitempty"`
 	ErrorMessage      string             `json:"error_message,omitempty"`
 	HandshakeCaptured bool               `json:"handshake_captured"`
+
+	// DisconnectionConfirmed is set once the monitor sees the target probing or going silent
+	DisconnectionConfirmed bool `json:"disconnection_confirmed"`
+
+	// Delivery confirmation of unicast frames (ACKs sent back to the spoofed source)
+	FramesAcked   int     `json:"frames_acked"`
+	FramesUnacked int     `json:"frames_unacked"`
+	DeliveryRatio float64 `json:"delivery_ratio"` // acked / (acked + unacked)
 }
 
 // NewDeauthAttack initializes a new deauth attack entity with valid configuration.
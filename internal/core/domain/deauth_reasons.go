@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// deauthReasonNames are the 802.11 reason codes of deauthentication and
+// disassociation frames.
+var deauthReasonNames = map[int]string{
+	1:  "Unspecified reason",
+	2:  "Previous authentication no longer valid",
+	3:  "Station is leaving",
+	4:  "Disassociated due to inactivity",
+	5:  "AP unable to handle all associated stations",
+	6:  "Class 2 frame received from nonauthenticated station",
+	7:  "Class 3 frame received from nonassociated station",
+	8:  "Station is leaving the BSS",
+	9:  "Station requesting association is not authenticated",
+	10: "Power capability unacceptable",
+	11: "Supported channels unacceptable",
+	13: "Invalid element",
+	14: "MIC failure",
+	15: "4-way handshake timeout",
+	16: "Group key handshake timeout",
+	17: "Element in 4-way handshake differs",
+	18: "Invalid group cipher",
+	19: "Invalid pairwise cipher",
+	20: "Invalid AKMP",
+	21: "Unsupported RSNE version",
+	22: "Invalid RSNE capabilities",
+	23: "IEEE 802.1X authentication failed",
+	24: "Cipher suite rejected",
+	34: "Excessive frame losses",
+}
+
+// DeauthReasonName returns the meaning of a deauthentication reason code.
+func DeauthReasonName(code int) string {
+	if name, ok := deauthReasonNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("Reason %d", code)
+}
+
+// DeauthReasonCount is how many frames carried a reason code.
+type DeauthReasonCount struct {
+	Code  int    `json:"code"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// DeauthReasonSource is the reason code distribution of one transmitter.
+type DeauthReasonSource struct {
+	MAC     string              `json:"mac"`
+	Frames  int                 `json:"frames"`
+	Reasons []DeauthReasonCount `json:"reasons"`
+}
+
+// DeauthReasonStats aggregates the reason codes of the deauthentication and
+// disassociation frames seen in a BSS. Anomalies explains the distributions
+// typical of attack tools, such as one source sending nothing but reason 7.
+type DeauthReasonStats struct {
+	BSSID     string               `json:"bssid"`
+	Frames    int                  `json:"frames"`
+	Reasons   []DeauthReasonCount  `json:"reasons"`
+	Sources   []DeauthReasonSource `json:"sources"`
+	FirstSeen time.Time            `json:"first_seen"`
+	LastSeen  time.Time            `json:"last_seen"`
+	Anomalies []string             `json:"anomalies,omitempty"`
+}
@@ -36,16 +36,21 @@ type Device struct {
 	IsRandomized bool       `json:"is_randomized"`
 
 	// --- RF & Radio State ---
-	RSSI           int       `json:"rssi"`
-	Channel        int       `json:"channel,omitempty"`
-	Frequency      int       `json:"freq,omitempty"`
-	ChannelWidth   int       `json:"bw,omitempty"`
-	Standard       string    `json:"standard,omitempty"` // e.g. "802.11ax"
-	IsWiFi6        bool      `json:"is_wifi6"`
-	IsWiFi7        bool      `json:"is_wifi7"`
-	LastPacketTime time.Time `json:"last_packet_time"`
-	FirstSeen      time.Time `json:"first_seen"`
-	LastSeen       time.Time `json:"last_seen"`
+	RSSI           int           `json:"rssi"`
+	ChainRSSI      []ChainSignal `json:"chain_rssi,omitempty"` // Per-antenna signal on multi-chain adapters
+	Channel        int           `json:"channel,omitempty"`
+	Frequency      int           `json:"freq,omitempty"`
+	ChannelWidth   int           `json:"bw,omitempty"`             // Operating width in MHz (20, 40, 80, 160)
+	CenterChannel  int           `json:"center_channel,omitempty"` // Center of the operating width
+	Country        string        `json:"country,omitempty"`        // Regulatory country advertised in beacons
+	MaxTxPower     int           `json:"max_tx_power,omitempty"`   // Advertised max TX power in dBm, after the local constraint
+	Standard       string        `json:"standard,omitempty"`       // e.g. "802.11ax"
+	IsWiFi6        bool          `json:"is_wifi6"`
+	IsWiFi7        bool          `json:"is_wifi7"`
+	LastPacketTime time.Time     `json:"last_packet_time"`
+	FirstSeen      time.Time     `json:"first_seen"`
+	LastSeen       time.Time     `json:"last_seen"`
+	Sensor         string        `json:"sensor,omitempty"` // Interface that first heard it, or "agent/interface" for remote sensors
 
 	// --- Network Protocol & Security ---
 	SSID           string          `json:"ssid,omitempty"` // Beacon SSID (AP) or last probed (Sta)
@@ -55,13 +60,24 @@ type Device struct {
 	WPSInfo        string          `json:"wps_info,omitempty"`
 	RSNInfo        *RSNInfo        `json:"rsn_info,omitempty"`
 	WPSDetails     *WPSDetails     `json:"wps_details,omitempty"`
+	WEPIVs         *WEPIVStats     `json:"wep_ivs,omitempty"` // IVs heard, for WEP APs
+	Uptime         *APUptime       `json:"uptime,omitempty"`  // From beacon timestamps, for APs
 	MobilityDomain *MobilityDomain `json:"mobility_domain,omitempty"`
+	OWETransition  *OWETransition  `json:"owe_transition,omitempty"`
+	SupportedRates []int           `json:"supported_rates,omitempty"` // Rate codes of the Supported and Extended Rates elements
+
+	// Beacon construction, and the AP model it identifies
+	BeaconFingerprint *BeaconFingerprint `json:"beacon_fingerprint,omitempty"`
+	Hardware          *APHardware        `json:"hardware,omitempty"`
 
 	// --- Traffic Analytics ---
-	DataTransmitted int64 `json:"data_tx"`
-	DataReceived    int64 `json:"data_rx"`
-	PacketsCount    int   `json:"packets"`
-	RetryCount      int   `json:"retries"`
+	DataTransmitted int64      `json:"data_tx"`
+	DataReceived    int64      `json:"data_rx"`
+	PacketsCount    int        `json:"packets"`
+	RetryCount      int        `json:"retries"`
+	TxRate          float64    `json:"tx_rate"`        // Bytes/sec over the last minute
+	RxRate          float64    `json:"rx_rate"`        // Bytes/sec over the last minute
+	Link            *LinkStats `json:"link,omitempty"` // Retries over the last minute
 
 	// --- Geospatial ---
 	Latitude  float64 `json:"lat"`
@@ -73,7 +89,9 @@ type Device struct {
 	ConnectionError  string               `json:"connection_error,omitempty"`
 	HasHandshake     bool                 `json:"has_handshake,omitempty"`
 	ProbedSSIDs      map[string]time.Time `json:"probed_ssids,omitempty"`
+	ProbeCounts      map[string]int       `json:"probe_counts,omitempty"` // Probe requests heard per SSID
 	ConnectedSSID    string               `json:"connected_ssid,omitempty"`
+	ClientCount      int                  `json:"client_count,omitempty"` // Unique associated clients of an AP, filled in reports
 
 	ObservedSSIDs []string `json:"observed_ssids,omitempty"`
 	// Protocol Flags (802.11k/v/r)
@@ -82,19 +100,30 @@ type Device struct {
 	Has11r bool `json:"has11r,omitempty"`
 
 	// Handshake Details
-	LastANonce string `json:"last_anonce,omitempty"` // Hex string of last AP Nonce seen
+	LastANonce        string      `json:"last_anonce,omitempty"`        // Hex string of last AP Nonce seen
+	KeyReinstallation []string    `json:"key_reinstallation,omitempty"` // Frames showing the client reinstalled its key (KRACK)
+	WeakNonces        *WeakNonces `json:"weak_nonces,omitempty"`        // Handshake nonces betraying a broken RNG
 
 	// --- Advanced Fingerprinting ---
-	IEFingerprint    string  `json:"ie_fingerprint,omitempty"`
-	IETags           []int   `json:"ie_tags,omitempty"`
-	Signature        string  `json:"signature,omitempty"`
-	ProbeHash        string  `json:"probe_hash,omitempty"`
-	ManufacturerRaw  string  `json:"manuf_raw,omitempty"`
-	VendorConfidence float32 `json:"vendor_confidence,omitempty"`
+	IEFingerprint    string   `json:"ie_fingerprint,omitempty"`
+	IETags           []int    `json:"ie_tags,omitempty"`
+	Signature        string   `json:"signature,omitempty"`
+	ProbeHash        string   `json:"probe_hash,omitempty"`
+	ManufacturerRaw  string   `json:"manuf_raw,omitempty"`
+	VendorConfidence float32  `json:"vendor_confidence,omitempty"`
+	VendorIEs        []string `json:"vendor_ies,omitempty"`      // OUIs of the vendor specific IEs, e.g. "fa:0b:bc"
+	BeaconInterval   int      `json:"beacon_interval,omitempty"` // In TU, as advertised in beacons
 
 	// --- Domain Relations ---
 	Behavioral      *BehavioralProfile `json:"behavioral,omitempty"`
 	Vulnerabilities []VulnerabilityTag `json:"vulnerabilities,omitempty"`
+
+	// Annotations are key/value findings attached by external analyzers
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Asset is the business asset the device was recorded as by an analyst
+	Asset *AssetInfo `json:"asset,omitempty"`
+	// Portal is the last captive portal check of an open network
+	Portal *PortalCheck `json:"portal,omitempty"`
 }
 
 // RSNInfo contains parsed RSN IE details
@@ -117,6 +146,13 @@ type RSNCapabilities struct {
 	PeerKeyEnabled   bool  `json:"peer_key_enabled"`
 }
 
+// WeakNonces is the evidence of a broken random number generator in the
+// handshakes of a device: zero, patterned or reused nonces.
+type WeakNonces struct {
+	Zero     bool     `json:"zero"`     // An all-zero nonce was sent
+	Evidence []string `json:"evidence"` // Nonces and sample captures
+}
+
 // MobilityDomain contains 802.11r FT details
 type MobilityDomain struct {
 	MDID        uint16 `json:"mdid"`
@@ -124,6 +160,13 @@ type MobilityDomain struct {
 	ResourceReq bool   `json:"resource_req"`
 }
 
+// OWETransition is the counterpart an AP in OWE transition mode points at:
+// the open BSS of an OWE AP, or the OWE BSS of an open one.
+type OWETransition struct {
+	BSSID string `json:"bssid"`
+	SSID  string `json:"ssid"`
+}
+
 // WPSDetails contains detailed WPS information
 type WPSDetails struct {
 	State         string   `json:"state"` // "Configured", "Unconfigured"
@@ -164,6 +207,54 @@ func (d *Device) UpdateTraffic(tx, rx int64, packets int) {
 	d.PacketsCount += packets
 }
 
+// MergeProbes records the probe requests of an observation of the device.
+// Each SSID probed later than last known adds the requests the observation
+// counted, one when it did not count them; observations repeating a known
+// probe, such as polled device state, add none.
+func (d *Device) MergeProbes(probes map[string]time.Time, counts map[string]int) {
+	if len(probes) == 0 {
+		return
+	}
+	if d.ProbedSSIDs == nil {
+		d.ProbedSSIDs = make(map[string]time.Time)
+	}
+	if d.ProbeCounts == nil {
+		d.ProbeCounts = make(map[string]int)
+	}
+	for ssid, ts := range probes {
+		if last, known := d.ProbedSSIDs[ssid]; !known || ts.After(last) {
+			d.ProbeCounts[ssid] += max(counts[ssid], 1)
+		}
+		d.ProbedSSIDs[ssid] = ts
+	}
+}
+
+// OccupiedChannels returns the 20 MHz channels covered by the device's
+// operating width.
+func (d *Device) OccupiedChannels() []int {
+	return OccupiedChannels(d.Channel, d.ChannelWidth, d.CenterChannel)
+}
+
+// OccupiedChannels returns the 20 MHz channels a BSS on the given primary
+// channel covers. Channel numbers are 5 MHz apart, so the 20 MHz sub-channels
+// of a wider BSS are 4 numbers apart around its center. Without a known width
+// only the primary channel is returned.
+func OccupiedChannels(channel, width, center int) []int {
+	if channel <= 0 {
+		return nil
+	}
+	if width <= 20 || center <= 0 {
+		return []int{channel}
+	}
+	n := width / 20
+	first := center - 2*(n-1)
+	channels := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		channels = append(channels, first+4*i)
+	}
+	return channels
+}
+
 // HasVulnerability checks if a specific vulnerability has been detected.
 func (d *Device) HasVulnerability(name string) bool {
 	for _, v := range d.Vulnerabilities {
@@ -173,3 +264,34 @@ func (d *Device) HasVulnerability(name string) bool {
 	}
 	return false
 }
+
+// pskAKMs are the AKM suites whose handshakes can be cracked offline.
+var pskAKMs = map[string]bool{"PSK": true, "FT-PSK": true, "PSK-SHA256": true}
+
+// UsesPSK returns true if the AP offers a pre-shared key AKM. SAE is not one.
+func (d *Device) UsesPSK() bool {
+	if d.RSNInfo == nil {
+		return false
+	}
+	for _, akm := range d.RSNInfo.AKMSuites {
+		if pskAKMs[akm] {
+			return true
+		}
+	}
+	return false
+}
+
+// ChainSignal is the signal received on one antenna (RX chain) of the capture adapter.
+type ChainSignal struct {
+	Antenna int `json:"antenna"`
+	RSSI    int `json:"rssi"`
+}
+
+// DeviceForgetReport tells where a forgotten device was removed from.
+type DeviceForgetReport struct {
+	MAC          string `json:"mac"`
+	Registry     bool   `json:"registry"`     // Was in memory, and so in the graph
+	Storage      bool   `json:"storage"`      // Was saved in the workspace
+	Observations int    `json:"observations"` // RSSI samples dropped from the heatmap history
+	Unlinked     int    `json:"unlinked"`     // Devices whose connection to it was cleared
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOccupiedChannels(t *testing.T) {
+	tests := []struct {
+		name   string
+		device Device
+		want   []int
+	}{
+		{"unknown channel", Device{}, nil},
+		{"unknown width", Device{Channel: 6}, []int{6}},
+		{"20 MHz", Device{Channel: 36, ChannelWidth: 20, CenterChannel: 36}, []int{36}},
+		{"40 MHz 2.4 GHz", Device{Channel: 6, ChannelWidth: 40, CenterChannel: 8}, []int{6, 10}},
+		{"80 MHz", Device{Channel: 44, ChannelWidth: 80, CenterChannel: 42}, []int{36, 40, 44, 48}},
+		{"160 MHz", Device{Channel: 36, ChannelWidth: 160, CenterChannel: 50}, []int{36, 40, 44, 48, 52, 56, 60, 64}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.device.OccupiedChannels(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OccupiedChannels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeProbes(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var d Device
+
+	d.MergeProbes(map[string]time.Time{"home": t0, "cafe": t0}, nil)
+	d.MergeProbes(map[string]time.Time{"home": t0.Add(time.Second)}, nil)
+	d.MergeProbes(map[string]time.Time{"home": t0.Add(time.Second)}, nil) // Repeated state
+	d.MergeProbes(map[string]time.Time{"cafe": t0.Add(time.Minute)}, map[string]int{"cafe": 5})
+
+	want := map[string]int{"home": 2, "cafe": 6}
+	if !reflect.DeepEqual(d.ProbeCounts, want) {
+		t.Errorf("ProbeCounts = %v, want %v", d.ProbeCounts, want)
+	}
+	if got := d.ProbedSSIDs["cafe"]; !got.Equal(t0.Add(time.Minute)) {
+		t.Errorf("ProbedSSIDs[cafe] = %v, want the latest probe", got)
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+// DiagnosticSeverity grades a preflight finding.
+type DiagnosticSeverity string
+
+const (
+	// DiagnosticError blocks monitor-mode capture on the interface.
+	DiagnosticError DiagnosticSeverity = "error"
+	// DiagnosticWarning degrades capture or injection but does not block it.
+	DiagnosticWarning DiagnosticSeverity = "warning"
+)
+
+// DiagnosticIssue is a single actionable finding of a preflight check.
+type DiagnosticIssue struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Check    string             `json:"check"` // e.g. "monitor_mode", "regulatory"
+	Message  string             `json:"message"`
+	Remedy   string             `json:"remedy,omitempty"`
+}
+
+// InterfaceDiagnostics is the preflight report for a wireless interface.
+type InterfaceDiagnostics struct {
+	Interface            string            `json:"interface"`
+	Phy                  string            `json:"phy"`
+	Driver               string            `json:"driver"`
+	Mode                 string            `json:"mode"` // Current iw type (managed, monitor, ...)
+	MonitorSupported     bool              `json:"monitor_supported"`
+	RegulatoryDomain     string            `json:"regulatory_domain"`
+	SupportedBands       []WiFiBand        `json:"supported_bands"`
+	InterferingProcesses []string          `json:"interfering_processes"`
+	Issues               []DiagnosticIssue `json:"issues"`
+}
+
+// Ready reports whether no blocking issue was found.
+func (d InterfaceDiagnostics) Ready() bool {
+	for _, issue := range d.Issues {
+		if issue.Severity == DiagnosticError {
+			return false
+		}
+	}
+	return true
+}
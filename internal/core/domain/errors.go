@@ -0,0 +1,111 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrInterfaceBusy is returned when an interface is locked on another
+	// channel by an operation that cannot be preempted.
+	ErrInterfaceBusy = errors.New("interface busy")
+	// ErrPMFProtected is returned when an attack relies on unprotected
+	// management frames against a target that requires 802.11w (PMF).
+	ErrPMFProtected = errors.New("target requires protected management frames")
+	// ErrToolMissing is returned when an external tool an engine runs is not
+	// installed.
+	ErrToolMissing = errors.New("required tool not installed")
+)
+
+// ErrorCode identifies a class of errors for API clients, which can react to
+// it without parsing messages. Codes are stable; messages are not.
+type ErrorCode string
+
+const (
+	CodeInvalidRequest    ErrorCode = "invalid_request"
+	CodeNotFound          ErrorCode = "not_found"
+	CodeConflict          ErrorCode = "conflict"
+	CodeOutOfScope        ErrorCode = "out_of_scope"
+	CodeROERequired       ErrorCode = "roe_required"
+	CodeTxNotPermitted    ErrorCode = "tx_not_permitted"
+	CodeInterfaceBusy     ErrorCode = "interface_busy"
+	CodeLockPreempted     ErrorCode = "lock_preempted"
+	CodePMFProtected      ErrorCode = "pmf_protected"
+	CodeToolMissing       ErrorCode = "tool_missing"
+	CodeAgentNotConnected ErrorCode = "agent_not_connected"
+	CodeUnsupported       ErrorCode = "unsupported"
+	CodeRateLimited       ErrorCode = "rate_limited"
+	CodePasswordChange    ErrorCode = "password_change_required"
+	CodeTOTPRequired      ErrorCode = "totp_required"
+	CodeTOTPEnrollment    ErrorCode = "totp_enrollment_required"
+	CodeInternal          ErrorCode = "internal"
+)
+
+// errorCodes classifies the domain errors, in the order they are matched.
+var errorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	{ErrOutOfScope, CodeOutOfScope},
+	{ErrNoROE, CodeROERequired},
+	{ErrInvalidROE, CodeInvalidRequest},
+	{ErrInvalidAutoCapture, CodeInvalidRequest},
+	{ErrInvalidRecentCapture, CodeInvalidRequest},
+	{ErrTxNotPermitted, CodeTxNotPermitted},
+	{ErrPMFProtected, CodePMFProtected},
+	{ErrToolMissing, CodeToolMissing},
+	{ErrLockPreempted, CodeLockPreempted},
+	{ErrInterfaceBusy, CodeInterfaceBusy},
+	{ErrInterfaceInUse, CodeInterfaceBusy},
+	{ErrAgentNotConnected, CodeAgentNotConnected},
+	{ErrInjectionTestUnsupported, CodeUnsupported},
+	{ErrFrameHistoryUnavailable, CodeUnsupported},
+	{ErrLoginLocked, CodeRateLimited},
+	{ErrPasswordChange, CodePasswordChange},
+	{ErrTOTPRequired, CodeTOTPRequired},
+	{ErrTOTPEnrollment, CodeTOTPEnrollment},
+	{ErrTOTPNotEnrolled, CodeConflict},
+	{ErrInvalidTOTP, CodeInvalidRequest},
+	{ErrDeviceNotFound, CodeNotFound},
+	{ErrInterfaceNotFound, CodeNotFound},
+	{ErrHookNotFound, CodeNotFound},
+	{ErrProtectedBSSIDNotFound, CodeNotFound},
+	{ErrAPIKeyNotFound, CodeNotFound},
+	{ErrShareNotFound, CodeNotFound},
+	{ErrUserNotFound, CodeNotFound},
+	{ErrSessionNotFound, CodeNotFound},
+	{ErrNoRecentFrames, CodeNotFound},
+	{ErrVulnerabilityNotFound, CodeNotFound},
+	{ErrLastInterface, CodeConflict},
+	{ErrLocatorActive, CodeConflict},
+	{ErrLocatorNotActive, CodeConflict},
+	{ErrUserExists, CodeConflict},
+	{ErrLastAdmin, CodeConflict},
+	{ErrExternalAccount, CodeConflict},
+	{ErrInvalidInterfaceName, CodeInvalidRequest},
+	{ErrInvalidMAC, CodeInvalidRequest},
+	{ErrUnsupportedBand, CodeInvalidRequest},
+	{ErrInvalidInterfaceRole, CodeInvalidRequest},
+	{ErrInvalidDutyCycle, CodeInvalidRequest},
+	{ErrWPSInvalidConfig, CodeInvalidRequest},
+	{ErrNotOpenNetwork, CodeInvalidRequest},
+	{ErrInvalidAPIKeyScope, CodeInvalidRequest},
+	{ErrEmptyAPIKeyName, CodeInvalidRequest},
+	{ErrEmptyShareWorkspace, CodeInvalidRequest},
+	{ErrInvalidShareExpiry, CodeInvalidRequest},
+	{ErrInvalidPassword, CodeInvalidRequest},
+	{ErrInvalidPermission, CodeInvalidRequest},
+	{ErrInvalidRole, CodeInvalidRequest},
+	{ErrEmptyUsername, CodeInvalidRequest},
+	{ErrEmptyJustification, CodeInvalidRequest},
+	{ErrInvalidJustification, CodeInvalidRequest},
+	{ErrInvalidSuppressExpiry, CodeInvalidRequest},
+}
+
+// ErrorCodeOf returns the code of the first domain error found in err's
+// chain, or CodeInternal when err is not a known domain error.
+func ErrorCodeOf(err error) ErrorCode {
+	for _, known := range errorCodes {
+		if errors.Is(err, known.err) {
+			return known.code
+		}
+	}
+	return CodeInternal
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ErrorCode
+	}{
+		{"out of scope", fmt.Errorf("00:11:22:33:44:55: %w", ErrOutOfScope), CodeOutOfScope},
+		{"busy", fmt.Errorf("%w: locked on channel 6", ErrInterfaceBusy), CodeInterfaceBusy},
+		{"pmf", fmt.Errorf("wrapped: %w", fmt.Errorf("AP: %w", ErrPMFProtected)), CodePMFProtected},
+		{"tool", fmt.Errorf("reaver: %w", ErrToolMissing), CodeToolMissing},
+		{"not found", ErrDeviceNotFound, CodeNotFound},
+		{"unknown", errors.New("boom"), CodeInternal},
+		{"nil", nil, CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorCodeOf(tt.err); got != tt.want {
+				t.Errorf("ErrorCodeOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
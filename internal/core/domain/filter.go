@@ -12,6 +12,7 @@ const (
 	SecurityWPA2 = "WPA2"
 	SecurityWEP  = "WEP"
 	SecurityOpen = "OPEN"
+	SecurityOWE  = "OWE" // Opportunistic Wireless Encryption, unauthenticated
 )
 
 // Domain Errors for filtering
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// Domain Errors for Geofencing
+var (
+	ErrInvalidGeofenceName   = errors.New("geofence name cannot be empty")
+	ErrInvalidGeofenceCenter = errors.New("geofence center coordinates out of range")
+	ErrInvalidGeofenceRadius = errors.New("geofence radius must be positive")
+)
+
+// earthRadiusMeters is the mean Earth radius used for great-circle distances.
+const earthRadiusMeters = 6371000.0
+
+// Geofence defines a circular protected zone used for perimeter monitoring.
+type Geofence struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Latitude     float64   `json:"lat"`
+	Longitude    float64   `json:"lng"`
+	RadiusMeters float64   `json:"radius_m"`
+	TrackedMACs  []string  `json:"tracked_macs,omitempty"` // Devices that raise an alert whenever seen inside the zone
+	AlertOnNewAP bool      `json:"alert_on_new_ap"`        // Raise an alert for APs first seen after the zone was created
+	Enabled      bool      `json:"enabled"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Validate performs internal consistency checks on the zone definition.
+func (g *Geofence) Validate() error {
+	if strings.TrimSpace(g.Name) == "" {
+		return ErrInvalidGeofenceName
+	}
+	if g.Latitude < -90 || g.Latitude > 90 || g.Longitude < -180 || g.Longitude > 180 {
+		return ErrInvalidGeofenceCenter
+	}
+	if g.RadiusMeters <= 0 {
+		return ErrInvalidGeofenceRadius
+	}
+	for _, mac := range g.TrackedMACs {
+		if !IsValidMAC(mac) {
+			return ErrInvalidMAC
+		}
+	}
+	return nil
+}
+
+// Contains reports whether the given coordinates fall inside the zone.
+func (g *Geofence) Contains(lat, lng float64) bool {
+	return DistanceMeters(g.Latitude, g.Longitude, lat, lng) <= g.RadiusMeters
+}
+
+// IsTracked reports whether the MAC address is on the zone's watch list.
+func (g *Geofence) IsTracked(mac string) bool {
+	for _, m := range g.TrackedMACs {
+		if strings.EqualFold(m, mac) {
+			return true
+		}
+	}
+	return false
+}
+
+// DistanceMeters returns the great-circle distance between two coordinates (haversine).
+func DistanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
@@ -23,6 +23,8 @@ type GraphNode struct {
 	// State and Metadata
 	Title           string             `json:"title,omitempty"` // Tooltip/Popup content
 	IsStale         bool               `json:"is_stale,omitempty"`
+	ClientCount     int                `json:"clientCount,omitempty"`        // Unique associated clients (APs)
+	ProbingClients  int                `json:"probingClientCount,omitempty"` // Unique clients probing for the SSID (APs)
 	Vulnerabilities []VulnerabilityTag `json:"vulnerabilities,omitempty"`
 }
 
@@ -39,29 +41,34 @@ type NodeIdentity struct {
 
 // RadioDetails encapsulates WiFi physical and link layer attributes.
 type RadioDetails struct {
-	SSID         string   `json:"ssid,omitempty"`
-	Channel      int      `json:"channel,omitempty"`
-	ChannelWidth int      `json:"bw"`
-	Frequency    int      `json:"frequency,omitempty"`
-	RSSI         int      `json:"rssi,omitempty"`
-	Security     string   `json:"security,omitempty"`
-	Standard     string   `json:"standard,omitempty"`
-	Capabilities []string `json:"capabilities,omitempty"`
-	IsWiFi6      bool     `json:"is_wifi6,omitempty"`
-	IsWiFi7      bool     `json:"is_wifi7,omitempty"`
-	IsRandomized bool     `json:"is_randomized,omitempty"`
-	HasHandshake bool     `json:"has_handshake,omitempty"`
-	ProbedSSIDs  []string `json:"probedSSIDs,omitempty"`
-	IETags       []int    `json:"ieTags,omitempty"`
-	WPSInfo      string   `json:"wps_info,omitempty"` // "Configured", "Unconfigured" or empty
+	SSID          string   `json:"ssid,omitempty"`
+	Channel       int      `json:"channel,omitempty"`
+	ChannelWidth  int      `json:"bw"`
+	CenterChannel int      `json:"center_channel,omitempty"`
+	Frequency     int      `json:"frequency,omitempty"`
+	RSSI          int      `json:"rssi,omitempty"`
+	Security      string   `json:"security,omitempty"`
+	Standard      string   `json:"standard,omitempty"`
+	Capabilities  []string `json:"capabilities,omitempty"`
+	IsWiFi6       bool     `json:"is_wifi6,omitempty"`
+	IsWiFi7       bool     `json:"is_wifi7,omitempty"`
+	IsRandomized  bool     `json:"is_randomized,omitempty"`
+	HasHandshake  bool     `json:"has_handshake,omitempty"`
+	ProbedSSIDs   []string `json:"probedSSIDs,omitempty"`
+	IETags        []int    `json:"ieTags,omitempty"`
+	WPSInfo       string   `json:"wps_info,omitempty"` // "Configured", "Unconfigured" or empty
 }
 
 // TrafficStats captures data transmission metrics.
 type TrafficStats struct {
-	DataTransmitted int64 `json:"data_tx"`
-	DataReceived    int64 `json:"data_rx"`
-	PacketsCount    int   `json:"packets"`
-	RetryCount      int   `json:"retries"`
+	DataTransmitted int64       `json:"data_tx"`
+	DataReceived    int64       `json:"data_rx"`
+	PacketsCount    int         `json:"packets"`
+	RetryCount      int         `json:"retries"`
+	TxRate          float64     `json:"tx_rate"`                // Bytes/sec over the last minute
+	RxRate          float64     `json:"rx_rate"`                // Bytes/sec over the last minute
+	RetryRate       float64     `json:"retry_rate,omitempty"`   // Over the last minute; for APs, of the whole cell
+	LinkQuality     LinkQuality `json:"link_quality,omitempty"` // Grade of RetryRate
 }
 
 // NodeBehavioralData encapsulates higher-level analysis results.
@@ -81,6 +88,7 @@ const (
 	TypeConnection  EdgeType = "connection"
 	TypeProbe       EdgeType = "probe"
 	TypeCorrelation EdgeType = "correlation"
+	TypeProbedFor   EdgeType = "probed_for" // Client to an SSID of its preferred network list
 )
 
 // GraphEdge represents a connection between two nodes.
@@ -91,6 +99,10 @@ type GraphEdge struct {
 	Type   EdgeType `json:"type,omitempty"`
 	Label  string   `json:"label,omitempty"` // For display
 	Color  string   `json:"color,omitempty"` // Hex or rgba for dynamic override
+
+	// Probed-for edges: probe requests heard and when the last one was
+	Count    int        `json:"count,omitempty"`
+	LastSeen *time.Time `json:"last_seen,omitempty"`
 }
 
 // GraphData allows sending the whole graph state to the frontend.
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Domain Errors for Heatmaps
+var (
+	ErrInvalidCellSize = errors.New("heatmap cell size must be between 1 and 10000 meters")
+)
+
+// DefaultHeatmapCellSize is the grid resolution used when none is requested.
+const DefaultHeatmapCellSize = 25.0
+
+// SignalObservation is a single geo-tagged RSSI sample of an Access Point.
+type SignalObservation struct {
+	SSID      string    `json:"ssid"`
+	BSSID     string    `json:"bssid"`
+	RSSI      int       `json:"rssi"`
+	Latitude  float64   `json:"lat"`
+	Longitude float64   `json:"lng"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// HeatmapQuery defines the aggregation parameters for a heatmap request.
+type HeatmapQuery struct {
+	CellSizeMeters float64   `json:"cell_size_m"`
+	SSID           string    `json:"ssid,omitempty"`  // Empty = all SSIDs
+	Since          time.Time `json:"since,omitempty"` // Zero = whole session
+}
+
+// Validate checks the query bounds, applying the default cell size if unset.
+func (q *HeatmapQuery) Validate() error {
+	if q.CellSizeMeters == 0 {
+		q.CellSizeMeters = DefaultHeatmapCellSize
+	}
+	if q.CellSizeMeters < 1 || q.CellSizeMeters > 10000 {
+		return ErrInvalidCellSize
+	}
+	return nil
+}
+
+// HeatmapCell holds the aggregated signal of one SSID within one grid cell.
+type HeatmapCell struct {
+	Row       int     `json:"row"`
+	Col       int     `json:"col"`
+	Latitude  float64 `json:"lat"` // Cell center
+	Longitude float64 `json:"lng"` // Cell center
+	SSID      string  `json:"ssid"`
+	MaxRSSI   int     `json:"max_rssi"`
+	AvgRSSI   float64 `json:"avg_rssi"`
+	Samples   int     `json:"samples"`
+}
+
+// Heatmap is the grid projection of signal observations.
+type Heatmap struct {
+	CellSizeMeters float64       `json:"cell_size_m"`
+	Observations   int           `json:"observations"`
+	Cells          []HeatmapCell `json:"cells"`
+}
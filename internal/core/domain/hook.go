@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Domain Errors for Scripting Hooks
+var (
+	ErrInvalidHookName      = errors.New("hook name cannot be empty")
+	ErrEmptyHookCondition   = errors.New("hook condition cannot be empty")
+	ErrEmptyHookActions     = errors.New("hook requires at least one action")
+	ErrInvalidHookAction    = errors.New("invalid hook action")
+	ErrInvalidHookSetField  = errors.New("hook can only set vendor, model or os")
+	ErrHookNotFound         = errors.New("hook not found")
+	ErrEmptyHookActionValue = errors.New("hook action requires a value")
+	ErrInvalidHookScript    = errors.New("invalid hook expression")
+)
+
+// HookActionType defines what a hook does when its condition matches.
+type HookActionType string
+
+const (
+	HookAlert    HookActionType = "alert"    // Raise an alert with Value as the message
+	HookAnnotate HookActionType = "annotate" // Set annotation Key to Value
+	HookSet      HookActionType = "set"      // Set device field Key to Value
+)
+
+// HookSettableFields are the device fields a hook can overwrite.
+var HookSettableFields = []string{"vendor", "model", "os"}
+
+// HookAction is an effect of a hook. Value is a template whose ${expression}
+// placeholders are evaluated against the device.
+type HookAction struct {
+	Type     HookActionType `json:"type"`
+	Key      string         `json:"key,omitempty"`      // Annotation key or device field
+	Value    string         `json:"value"`              // Alert message, annotation or field value
+	Severity AlertSeverity  `json:"severity,omitempty"` // Alerts only, medium by default
+	Subtype  string         `json:"subtype,omitempty"`  // Alerts only, SCRIPT_HOOK by default
+}
+
+// ScriptHook is a user-defined condition evaluated on every device update
+// of a workspace, with the actions run when it matches.
+type ScriptHook struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Condition string       `json:"condition"` // Expression, e.g. `vendor == "Hikvision" && !randomized`
+	Actions   []HookAction `json:"actions"`
+	Enabled   bool         `json:"enabled"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
+// Validate performs internal consistency checks on the hook. Expressions are
+// compiled by the scripting engine.
+func (h *ScriptHook) Validate() error {
+	if strings.TrimSpace(h.Name) == "" {
+		return ErrInvalidHookName
+	}
+	if strings.TrimSpace(h.Condition) == "" {
+		return ErrEmptyHookCondition
+	}
+	if len(h.Actions) == 0 {
+		return ErrEmptyHookActions
+	}
+	for _, a := range h.Actions {
+		if err := a.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks that the action is complete.
+func (a *HookAction) Validate() error {
+	if strings.TrimSpace(a.Value) == "" {
+		return ErrEmptyHookActionValue
+	}
+	switch a.Type {
+	case HookAlert:
+		if a.Severity != "" && !isValidSeverity(a.Severity) {
+			return ErrInvalidSeverity
+		}
+	case HookAnnotate:
+		if strings.TrimSpace(a.Key) == "" {
+			return ErrInvalidHookAction
+		}
+	case HookSet:
+		for _, f := range HookSettableFields {
+			if a.Key == f {
+				return nil
+			}
+		}
+		return ErrInvalidHookSetField
+	default:
+		return ErrInvalidHookAction
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidHopPlan is returned for an unknown channel hopping plan.
+var ErrInvalidHopPlan = errors.New("invalid hop plan")
+
+// HopPlan is how the channel pool is split among the hopping interfaces, so
+// that they cover different channels at the same time instead of each
+// hopping the whole pool.
+type HopPlan string
+
+const (
+	HopPlanBand       HopPlan = "band"       // Interfaces dedicated to a band, e.g. one on 2.4GHz and one on 5GHz
+	HopPlanInterleave HopPlan = "interleave" // Every interface hops alternate channels of both bands
+)
+
+// ParseHopPlan reads a hop plan, HopPlanBand when empty.
+func ParseHopPlan(s string) (HopPlan, error) {
+	switch plan := HopPlan(strings.ToLower(strings.TrimSpace(s))); plan {
+	case "":
+		return HopPlanBand, nil
+	case HopPlanBand, HopPlanInterleave:
+		return plan, nil
+	default:
+		return "", fmt.Errorf("%w: %q, expected band or interleave", ErrInvalidHopPlan, s)
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "errors"
+
+// Domain Errors for Ingestion
+var (
+	ErrUnknownIngestFormat = errors.New("unknown ingest format")
+	ErrEmptyIngest         = errors.New("no device observations to ingest")
+)
+
+// IngestFormat identifies the format of device observations imported from
+// another tool.
+type IngestFormat string
+
+const (
+	IngestFormatAuto     IngestFormat = ""         // Detected from the content
+	IngestFormatWMAP     IngestFormat = "wmap"     // WMAP device JSON, as exported
+	IngestFormatKismet   IngestFormat = "kismet"   // Kismet device JSON
+	IngestFormatAirodump IngestFormat = "airodump" // airodump-ng CSV
+)
+
+// IngestResult reports the outcome of importing device observations.
+type IngestResult struct {
+	Format    IngestFormat `json:"format"`
+	Source    string       `json:"source"`
+	Received  int          `json:"received"`         // Observations found in the data
+	Processed int          `json:"processed"`        // Observations merged into the registry
+	Skipped   int          `json:"skipped"`          // Observations without a valid MAC or failed to merge
+	Errors    []string     `json:"errors,omitempty"` // First errors, for troubleshooting
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Injection self-test limits (aireplay-ng -9 style).
+const (
+	DefaultInjectionTestProbes = 30
+	MaxInjectionTestProbes     = 200
+	DefaultInjectionTestWait   = 5 * time.Second
+	MaxInjectionTestWait       = 30 * time.Second
+)
+
+// InjectionTestConfig controls an injection capability self-test.
+type InjectionTestConfig struct {
+	Channel int           `json:"channel"` // Channel to lock during the test (0 = keep hopping)
+	Probes  int           `json:"probes"`  // Broadcast probe requests to send (0 = default)
+	Timeout time.Duration `json:"timeout"` // Total test time (0 = default)
+}
+
+// Validate ensures the configuration is within limits, applying defaults.
+func (c *InjectionTestConfig) Validate() error {
+	if c.Channel < 0 || c.Channel > 165 {
+		return fmt.Errorf("invalid WiFi channel: %d", c.Channel)
+	}
+
+	if c.Probes < 0 || c.Probes > MaxInjectionTestProbes {
+		return fmt.Errorf("probes must be between 0 and %d", MaxInjectionTestProbes)
+	}
+	if c.Probes == 0 {
+		c.Probes = DefaultInjectionTestProbes
+	}
+
+	if c.Timeout < 0 || c.Timeout > MaxInjectionTestWait {
+		return fmt.Errorf("timeout must be between 0 and %v", MaxInjectionTestWait)
+	}
+	if c.Timeout == 0 {
+		c.Timeout = DefaultInjectionTestWait
+	}
+
+	return nil
+}
+
+// ErrInjectionTestUnsupported is returned when the active sniffer cannot inject.
+var ErrInjectionTestUnsupported = errors.New("injection test not supported by the active sniffer")
+
+// InjectionTestAP reports how a single AP answered the test probes.
+type InjectionTestAP struct {
+	BSSID       string  `json:"bssid"`
+	SSID        string  `json:"ssid"`
+	Responses   int     `json:"responses"`
+	SuccessRate float64 `json:"success_rate"` // Responses / probes sent, capped at 1
+	RSSI        int     `json:"rssi"`         // Last seen signal (dBm)
+}
+
+// InjectionTestResult summarizes an injection capability self-test.
+type InjectionTestResult struct {
+	Interface      string            `json:"interface"`
+	Channel        int               `json:"channel"`
+	SourceMAC      string            `json:"source_mac"`
+	ProbesSent     int               `json:"probes_sent"`
+	SendErrors     int               `json:"send_errors"`
+	Responses      int               `json:"responses"`
+	InjectionWorks bool              `json:"injection_works"`
+	SuccessRate    float64           `json:"success_rate"` // Best per-AP success rate
+	APs            []InjectionTestAP `json:"aps"`
+	Duration       time.Duration     `json:"duration"`
+	Message        string            `json:"message"`
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"errors"
+	"time"
 )
 
 // WiFiBand represents a typed string for frequency bands.
@@ -18,6 +19,9 @@ var (
 	ErrInvalidInterfaceName = errors.New("invalid interface name")
 	ErrInvalidMAC           = errors.New("invalid MAC address")
 	ErrUnsupportedBand      = errors.New("unsupported wifi band")
+	ErrInterfaceInUse       = errors.New("interface already captures")
+	ErrInterfaceNotFound    = errors.New("interface does not capture")
+	ErrLastInterface        = errors.New("cannot remove the last capture interface")
 )
 
 // InterfaceCapabilities helps the UI know what an interface supports.
@@ -33,6 +37,70 @@ type InterfaceInfo struct {
 	Capabilities    InterfaceCapabilities `json:"capabilities"`
 	CurrentChannels []int                 `json:"current_channels"`
 	Metrics         InterfaceMetrics      `json:"metrics"`
+	Lock            ChannelLockState      `json:"lock"`
+}
+
+// MaxManualLockDuration caps how long an analyst can pin an interface to a channel.
+const MaxManualLockDuration = time.Hour
+
+// LockPriority orders contending channel lock holders. A request on a different
+// channel preempts holders of strictly lower priority and waits otherwise.
+type LockPriority int
+
+const (
+	LockPriorityHopper    LockPriority = iota // Implicit: the hopper yields to any lock
+	LockPriorityManual                        // Analyst pin from the UI
+	LockPriorityAttack                        // Active attacks
+	LockPriorityHandshake                     // Handshake capture
+)
+
+func (p LockPriority) String() string {
+	switch p {
+	case LockPriorityManual:
+		return "manual"
+	case LockPriorityAttack:
+		return "attack"
+	case LockPriorityHandshake:
+		return "handshake"
+	}
+	return "hopper"
+}
+
+// MarshalText encodes the priority by name.
+func (p LockPriority) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// ErrLockPreempted is the cause reported to an operation whose channel lock was
+// taken over by a higher-priority one.
+var ErrLockPreempted = errors.New("channel lock preempted by a higher-priority operation")
+
+// LockRequest identifies a channel lock holder or waiter.
+type LockRequest struct {
+	Holder   string       `json:"holder"` // e.g. attack ID
+	Priority LockPriority `json:"priority"`
+	Channel  int          `json:"channel"`
+	Since    time.Time    `json:"since"`
+}
+
+// ChannelLockState describes whether an interface is pinned to a channel and by whom.
+type ChannelLockState struct {
+	Locked    bool          `json:"locked"`
+	Channel   int           `json:"channel,omitempty"`
+	Holders   int           `json:"holders,omitempty"`    // Operations sharing the lock
+	Manual    bool          `json:"manual"`               // Held by an analyst through the API
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"` // Manual lock expiry
+	Owners    []LockRequest `json:"owners,omitempty"`
+	Queue     []LockRequest `json:"queue,omitempty"` // Operations waiting for the interface
+}
+
+// ChannelDwell is the hopper's current dwell-time decision for a channel.
+type ChannelDwell struct {
+	Channel           int     `json:"channel"`
+	DwellMs           int64   `json:"dwell_ms"`
+	FrameRate         float64 `json:"frame_rate"`         // Smoothed frames per second while tuned to the channel
+	PendingHandshakes int     `json:"pending_handshakes"` // Incomplete handshake sessions seen on the channel
+	Utilization       float64 `json:"utilization"`        // Estimated percentage of airtime in use, from captured frames
 }
 
 // InterfaceMetrics holds packet capture statistics.
@@ -42,6 +110,8 @@ type InterfaceMetrics struct {
 	AppPacketsDropped int64 `json:"app_packets_dropped"` // Buffer full
 	PacketsIfDropped  int64 `json:"packets_if_dropped"`  // Interface drops
 	ErrorCount        int64 `json:"error_count"`         // Processing errors
+	BadFCSFrames      int64 `json:"bad_fcs_frames"`      // Frames failing the FCS check (dropped or not)
+	DuplicateFrames   int64 `json:"duplicate_frames"`    // Frames already received by another adapter
 }
 
 // NewInterfaceInfo is the factory for creating valid InterfaceInfo entities.
@@ -75,6 +145,8 @@ func (m *InterfaceMetrics) ResetMetrics() {
 	m.AppPacketsDropped = 0
 	m.PacketsIfDropped = 0
 	m.ErrorCount = 0
+	m.BadFCSFrames = 0
+	m.DuplicateFrames = 0
 }
 
 // AddMetrics increments metrics from another source (e.g., from a capture session).
@@ -84,4 +156,6 @@ func (m *InterfaceMetrics) AddMetrics(other InterfaceMetrics) {
 	m.AppPacketsDropped += other.AppPacketsDropped
 	m.PacketsIfDropped += other.PacketsIfDropped
 	m.ErrorCount += other.ErrorCount
+	m.BadFCSFrames += other.BadFCSFrames
+	m.DuplicateFrames += other.DuplicateFrames
 }
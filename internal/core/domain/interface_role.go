@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidInterfaceRole is returned for a role map that cannot be parsed.
+var ErrInvalidInterfaceRole = errors.New("invalid interface role")
+
+// InterfaceRole is the duty an adapter is dedicated to.
+type InterfaceRole string
+
+const (
+	InterfaceRoleCapture   InterfaceRole = "capture"   // Hops channels to capture
+	InterfaceRoleInjection InterfaceRole = "injection" // Stays on its channel, free for attacks
+	InterfaceRoleAP        InterfaceRole = "ap"        // Hosts rogue AP responders and beacons
+)
+
+// InterfaceRoles maps interfaces to their role. Interfaces without one
+// capture; with no injection interface, attacks use the first interface,
+// and with no AP interface, the injection one.
+type InterfaceRoles map[string]InterfaceRole
+
+// ParseInterfaceRoles reads roles written as "wlan0=capture,wlan1=injection,wlan2=ap".
+func ParseInterfaceRoles(spec string) (InterfaceRoles, error) {
+	roles := make(InterfaceRoles)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		iface, role, ok := strings.Cut(entry, "=")
+		iface, role = strings.TrimSpace(iface), strings.ToLower(strings.TrimSpace(role))
+		if !ok || !IsValidInterface(iface) {
+			return nil, fmt.Errorf("%w: %q, expected interface=role", ErrInvalidInterfaceRole, entry)
+		}
+		switch InterfaceRole(role) {
+		case InterfaceRoleCapture, InterfaceRoleInjection, InterfaceRoleAP:
+			roles[iface] = InterfaceRole(role)
+		default:
+			return nil, fmt.Errorf("%w: %q, expected capture, injection or ap", ErrInvalidInterfaceRole, role)
+		}
+	}
+	return roles, nil
+}
+
+// Hops reports whether iface takes part in channel hopping.
+func (r InterfaceRoles) Hops(iface string) bool {
+	role, ok := r[iface]
+	return !ok || role == InterfaceRoleCapture
+}
+
+// Interface returns the first of available assigned role, or "" if none.
+// The AP role falls back to the injection interface.
+func (r InterfaceRoles) Interface(role InterfaceRole, available []string) string {
+	for _, iface := range available {
+		if r[iface] == role {
+			return iface
+		}
+	}
+	if role == InterfaceRoleAP {
+		return r.Interface(InterfaceRoleInjection, available)
+	}
+	return ""
+}
+
+// Pick returns the interface assigned role, else the first of available.
+func (r InterfaceRoles) Pick(role InterfaceRole, available []string) string {
+	if iface := r.Interface(role, available); iface != "" {
+		return iface
+	}
+	if len(available) > 0 {
+		return available[0]
+	}
+	return ""
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterfaceRoles(t *testing.T) {
+	roles, err := ParseInterfaceRoles("wlan0=capture, wlan1=Injection,wlan2=ap")
+	require.NoError(t, err)
+	assert.Equal(t, InterfaceRoles{"wlan0": InterfaceRoleCapture, "wlan1": InterfaceRoleInjection, "wlan2": InterfaceRoleAP}, roles)
+
+	for _, spec := range []string{"wlan0", "wlan0=monitor", "bad;name=capture"} {
+		_, err := ParseInterfaceRoles(spec)
+		assert.True(t, errors.Is(err, ErrInvalidInterfaceRole), spec)
+	}
+}
+
+func TestInterfaceRoles_Resolve(t *testing.T) {
+	available := []string{"wlan0", "wlan1", "wlan2"}
+	roles := InterfaceRoles{"wlan1": InterfaceRoleInjection}
+
+	assert.True(t, roles.Hops("wlan0"))
+	assert.False(t, roles.Hops("wlan1"))
+
+	assert.Equal(t, "wlan1", roles.Pick(InterfaceRoleInjection, available))
+	assert.Equal(t, "wlan1", roles.Pick(InterfaceRoleAP, available), "AP falls back to injection")
+	assert.Equal(t, "wlan0", InterfaceRoles(nil).Pick(InterfaceRoleInjection, available))
+	assert.Equal(t, "", roles.Pick(InterfaceRoleInjection, nil))
+
+	roles["wlan2"] = InterfaceRoleAP
+	assert.Equal(t, "wlan2", roles.Pick(InterfaceRoleAP, available))
+	assert.Equal(t, "", roles.Interface(InterfaceRoleAP, []string{"wlan0"}))
+}
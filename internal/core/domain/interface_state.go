@@ -0,0 +1,28 @@
+package domain
+
+// InterfaceState is the configuration of a wireless interface before wmap
+// reconfigured it for capture, restored when wmap gives it back.
+type InterfaceState struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`              // managed, monitor, AP...
+	Channel int    `json:"channel,omitempty"` // 0 if not tuned
+	Up      bool   `json:"up"`
+}
+
+// NetworkSnapshot is the host's wireless configuration when wmap started. It
+// is persisted, so that a run that crashed before restoring it can be undone
+// by the next one.
+type NetworkSnapshot struct {
+	RegulatoryDomain string           `json:"regulatory_domain,omitempty"`
+	Interfaces       []InterfaceState `json:"interfaces"`
+}
+
+// Interface returns the recorded state of the named interface.
+func (s NetworkSnapshot) Interface(name string) (InterfaceState, bool) {
+	for _, state := range s.Interfaces {
+		if state.Name == name {
+			return state, true
+		}
+	}
+	return InterfaceState{}, false
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// InventoryEntry is a device as listed by an external asset inventory, such
+// as a CMDB: the business asset it is, plus any other fields the inventory
+// keeps, attached to the device as annotations.
+type InventoryEntry struct {
+	MAC         string            `json:"mac"`
+	Asset       AssetInfo         `json:"asset"`
+	Annotations map[string]string `json:"annotations,omitempty"` // Keyed "cmdb.<field>"
+}
+
+// InventoryStatus describes the last synchronization with the inventory.
+type InventoryStatus struct {
+	Source   string    `json:"source"`
+	Entries  int       `json:"entries"`
+	LastSync time.Time `json:"last_sync,omitempty"` // Last successful synchronization
+	Error    string    `json:"error,omitempty"`     // Of the last attempt, the previous entries are kept
+}
+
+// Inventory indexes inventory entries by MAC, whatever the separators and
+// case the inventory writes them with.
+type Inventory map[string]InventoryEntry
+
+// NewInventory indexes entries; later entries for a MAC replace earlier ones.
+func NewInventory(entries []InventoryEntry) Inventory {
+	inv := make(Inventory, len(entries))
+	for _, e := range entries {
+		if key := normalizeMAC(e.MAC); key != "" {
+			inv[key] = e
+		}
+	}
+	return inv
+}
+
+// Lookup returns the entry listing mac.
+func (inv Inventory) Lookup(mac string) (InventoryEntry, bool) {
+	e, ok := inv[normalizeMAC(mac)]
+	return e, ok
+}
+
+// Apply sets the asset and annotations of the entry on a device, reporting
+// whether anything changed. The annotations map is copied, not updated in
+// place, since device snapshots share it.
+func (e InventoryEntry) Apply(device *Device) bool {
+	changed := false
+	if !e.Asset.IsEmpty() && (device.Asset == nil || *device.Asset != e.Asset) {
+		asset := e.Asset
+		device.Asset = &asset
+		changed = true
+	}
+
+	stale := false
+	for k, v := range e.Annotations {
+		if device.Annotations[k] != v {
+			stale = true
+			break
+		}
+	}
+	if stale {
+		annotations := make(map[string]string, len(device.Annotations)+len(e.Annotations))
+		for k, v := range device.Annotations {
+			annotations[k] = v
+		}
+		for k, v := range e.Annotations {
+			annotations[k] = v
+		}
+		device.Annotations = annotations
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// JobStatus is the lifecycle state of a background job.
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// Job is a long-running task executed by the background job queue.
+type Job struct {
+	ID          string    `json:"id"`
+	Kind        string    `json:"kind"` // Selects the handler, e.g. "psk_audit"
+	Status      JobStatus `json:"status"`
+	Progress    float64   `json:"progress"` // 0-100
+	Message     string    `json:"message,omitempty"`
+	Payload     string    `json:"payload,omitempty"` // JSON-encoded handler input
+	Error       string    `json:"error,omitempty"`
+	Attempts    int       `json:"attempts"`
+	MaxAttempts int       `json:"max_attempts"`
+	CreatedAt   time.Time `json:"created_at"`
+	StartedAt   time.Time `json:"started_at,omitempty"`
+	FinishedAt  time.Time `json:"finished_at,omitempty"`
+}
+
+// IsFinished reports whether the job reached a final state.
+func (j Job) IsFinished() bool {
+	switch j.Status {
+	case JobSucceeded, JobFailed, JobCanceled:
+		return true
+	}
+	return false
+}
+
+// JobFilter selects jobs to list. Empty statuses match every job.
+type JobFilter struct {
+	Statuses []JobStatus
+	Kind     string
+	Limit    int
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultKarmaDuration bounds a responder session when no duration is given.
+	DefaultKarmaDuration = 10 * time.Minute
+	// MaxKarmaDuration is the hard cap for a responder session.
+	MaxKarmaDuration = time.Hour
+)
+
+// KarmaConfig defines a contained "Karma-lite" responder: it only answers probe
+// requests for SSIDs in the allowlist and never completes an association.
+type KarmaConfig struct {
+	SSIDAllowlist []string      `json:"ssid_allowlist"`
+	BSSID         string        `json:"bssid,omitempty"`     // Responder BSSID (random if empty)
+	Interface     string        `json:"interface,omitempty"` // Optional, auto-selected if empty
+	Channel       int           `json:"channel"`
+	Duration      time.Duration `json:"duration"` // 0 = DefaultKarmaDuration
+}
+
+// Validate ensures the configuration adheres to business and protocol rules.
+func (c *KarmaConfig) Validate() error {
+	if len(c.SSIDAllowlist) == 0 {
+		return errors.New("SSID allowlist cannot be empty")
+	}
+	for _, ssid := range c.SSIDAllowlist {
+		if ssid == "" || len(ssid) > 32 {
+			return fmt.Errorf("invalid SSID in allowlist: %q", ssid)
+		}
+	}
+
+	if c.BSSID != "" && !IsValidMAC(c.BSSID) {
+		return fmt.Errorf("invalid BSSID: %s", c.BSSID)
+	}
+
+	if c.Interface != "" && !IsValidInterface(c.Interface) {
+		return fmt.Errorf("invalid interface name: %s", c.Interface)
+	}
+
+	if c.Channel < 1 || c.Channel > 165 {
+		return fmt.Errorf("invalid WiFi channel: %d", c.Channel)
+	}
+
+	if c.Duration < 0 || c.Duration > MaxKarmaDuration {
+		return fmt.Errorf("duration must be between 0 and %v", MaxKarmaDuration)
+	}
+	if c.Duration == 0 {
+		c.Duration = DefaultKarmaDuration
+	}
+
+	return nil
+}
+
+// KarmaClient summarizes how a client reacted to the spoofed probe responses.
+type KarmaClient struct {
+	MAC            string    `json:"mac"`
+	SSIDs          []string  `json:"ssids"` // Allowlisted SSIDs the client probed for
+	ProbesAnswered int       `json:"probes_answered"`
+	AuthAttempts   int       `json:"auth_attempts"`
+	AssocAttempts  int       `json:"assoc_attempts"` // Client tried to join: auto-join risk
+	FirstSeen      time.Time `json:"first_seen"`
+	LastSeen       time.Time `json:"last_seen"`
+}
+
+// KarmaStatus encapsulates the runtime state of a responder session.
+type KarmaStatus struct {
+	ID            string        `json:"id"`
+	Config        KarmaConfig   `json:"config"`
+	Status        AttackStatus  `json:"status"`
+	ProbesSeen    int           `json:"probes_seen"`
+	ResponsesSent int           `json:"responses_sent"`
+	Clients       []KarmaClient `json:"clients"`
+	StartTime     time.Time     `json:"start_time"`
+	EndTime       *time.Time    `json:"end_time,omitempty"`
+	ErrorMessage  string        `json:"error_message,omitempty"`
+}
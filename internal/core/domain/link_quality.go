@@ -0,0 +1,57 @@
+package domain
+
+// LinkQuality grades a link or cell by its frame retry ratio.
+type LinkQuality string
+
+const (
+	LinkExcellent LinkQuality = "excellent"
+	LinkGood      LinkQuality = "good"
+	LinkFair      LinkQuality = "fair"
+	LinkPoor      LinkQuality = "poor"
+)
+
+// MinLinkQualityFrames is the number of frames needed before a link is
+// graded; a handful of retries on an idle link says little.
+const MinLinkQualityFrames = 20
+
+// LinkStats summarizes the frame retries of a device over the last minute.
+type LinkStats struct {
+	Frames    int         `json:"frames"`
+	Retries   int         `json:"retries"`
+	RetryRate float64     `json:"retry_rate"`        // Retried/total frames
+	Quality   LinkQuality `json:"quality,omitempty"` // Empty until enough frames are seen
+}
+
+// NewLinkStats computes the retry ratio and quality grade of a frame count.
+func NewLinkStats(frames, retries int) LinkStats {
+	stats := LinkStats{Frames: frames, Retries: retries}
+	if frames == 0 {
+		return stats
+	}
+	stats.RetryRate = float64(retries) / float64(frames)
+	if frames < MinLinkQualityFrames {
+		return stats
+	}
+
+	switch {
+	case stats.RetryRate < 0.05:
+		stats.Quality = LinkExcellent
+	case stats.RetryRate < 0.10:
+		stats.Quality = LinkGood
+	case stats.RetryRate < 0.20:
+		stats.Quality = LinkFair
+	default:
+		stats.Quality = LinkPoor
+	}
+	return stats
+}
+
+// Add combines the frames of two links, e.g. an AP and its clients into a cell.
+func (s LinkStats) Add(other LinkStats) LinkStats {
+	return NewLinkStats(s.Frames+other.Frames, s.Retries+other.Retries)
+}
+
+// RetryPercent returns the retry ratio as a percentage.
+func (s LinkStats) RetryPercent() float64 {
+	return s.RetryRate * 100
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestNewLinkStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		frames  int
+		retries int
+		want    LinkQuality
+	}{
+		{"no frames", 0, 0, ""},
+		{"too few frames", 10, 5, ""},
+		{"excellent", 100, 2, LinkExcellent},
+		{"good", 100, 7, LinkGood},
+		{"fair", 100, 15, LinkFair},
+		{"poor", 100, 40, LinkPoor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLinkStats(tt.frames, tt.retries).Quality; got != tt.want {
+				t.Errorf("NewLinkStats(%d, %d).Quality = %q, want %q", tt.frames, tt.retries, got, tt.want)
+			}
+		})
+	}
+
+	cell := NewLinkStats(10, 0).Add(NewLinkStats(10, 5))
+	if cell.Frames != 20 || cell.RetryRate != 0.25 || cell.Quality != LinkPoor {
+		t.Errorf("Add() = %+v, want 20 frames at 25%% (poor)", cell)
+	}
+}
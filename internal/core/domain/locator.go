@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Domain Errors for the device locator
+var (
+	ErrLocatorActive    = errors.New("a locator session is already active")
+	ErrLocatorNotActive = errors.New("no locator session is active")
+)
+
+// LocatorTrend indicates whether the operator is getting closer to the target.
+type LocatorTrend string
+
+const (
+	TrendApproaching LocatorTrend = "approaching"
+	TrendReceding    LocatorTrend = "receding"
+	TrendStable      LocatorTrend = "stable"
+	TrendUnknown     LocatorTrend = "unknown"
+)
+
+// LocatorConfig defines the parameters of a "hot/cold" tracking session.
+type LocatorConfig struct {
+	// TargetMAC is the device to track down.
+	TargetMAC string `json:"target_mac"`
+
+	// Interface is the monitor interface to lock (auto-detected if empty).
+	Interface string `json:"interface,omitempty"`
+
+	// Channel to lock on (auto-detected from the registry if 0).
+	Channel int `json:"channel"`
+
+	// Smoothing is the EMA factor applied to raw RSSI (0 < x <= 1, lower = smoother).
+	Smoothing float64 `json:"smoothing"`
+
+	// MaxDuration releases the channel lock automatically (0 = default of 30 minutes).
+	MaxDuration time.Duration `json:"max_duration"`
+}
+
+// Validate evaluates the configuration, applying defaults for optional fields.
+func (c *LocatorConfig) Validate() error {
+	if !IsValidMAC(c.TargetMAC) {
+		return fmt.Errorf("invalid target MAC: %s", c.TargetMAC)
+	}
+	if c.Channel < 0 || c.Channel > 165 {
+		return fmt.Errorf("invalid WiFi channel: %d", c.Channel)
+	}
+	if c.Interface != "" && !IsValidInterface(c.Interface) {
+		return fmt.Errorf("invalid interface name: %s", c.Interface)
+	}
+	if c.Smoothing == 0 {
+		c.Smoothing = 0.3
+	}
+	if c.Smoothing < 0 || c.Smoothing > 1 {
+		return fmt.Errorf("smoothing must be in (0, 1]: %v", c.Smoothing)
+	}
+	if c.MaxDuration < 0 {
+		return errors.New("max duration cannot be negative")
+	}
+	if c.MaxDuration == 0 {
+		c.MaxDuration = 30 * time.Minute
+	}
+	return nil
+}
+
+// LocatorReading is a single smoothed RSSI update streamed to the operator.
+type LocatorReading struct {
+	TargetMAC    string       `json:"target_mac"`
+	RSSI         int          `json:"rssi"`
+	SmoothedRSSI float64      `json:"smoothed_rssi"`
+	Delta        float64      `json:"delta"` // Change of the smoothed value over the trend window
+	Trend        LocatorTrend `json:"trend"`
+	Channel      int          `json:"channel"`
+	Timestamp    time.Time    `json:"timestamp"`
+}
+
+// LocatorSession describes the state of the current tracking session.
+type LocatorSession struct {
+	ID          string          `json:"id"`
+	Config      LocatorConfig   `json:"config"`
+	Active      bool            `json:"active"`
+	StartTime   time.Time       `json:"start_time"`
+	EndTime     *time.Time      `json:"end_time,omitempty"`
+	Samples     int             `json:"samples"`
+	LastReading *LocatorReading `json:"last_reading,omitempty"`
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NAVJamFrame selects the control frame used to reserve airtime.
+type NAVJamFrame string
+
+const (
+	// NAVJamCTS sends CTS-to-self frames (no response expected).
+	NAVJamCTS NAVJamFrame = "cts"
+	// NAVJamRTS sends RTS frames to a receiver, which may answer with a CTS extending the reservation.
+	NAVJamRTS NAVJamFrame = "rts"
+)
+
+// Safety limits for virtual jamming. They are enforced both on validation and
+// by the injector, so the medium is never reserved indefinitely.
+const (
+	MaxNAVJamRate       = 50               // Frames per second
+	DefaultNAVJamRate   = 10               // Frames per second
+	MaxNAVDuration      = 32767            // Microseconds (15-bit Duration/ID field)
+	DefaultNAVDuration  = 32000            // Microseconds
+	MaxNAVJamDuration   = 2 * time.Minute  // Per attack
+	DefaultNAVJamLength = 30 * time.Second // Per attack
+)
+
+// NAVJamConfig defines an RTS/CTS virtual jamming run against a channel.
+type NAVJamConfig struct {
+	Interface string      `json:"interface,omitempty"` // Optional, auto-selected if empty
+	Channel   int         `json:"channel"`
+	FrameType NAVJamFrame `json:"frame_type"`           // "cts" (default) or "rts"
+	TargetMAC string      `json:"target_mac,omitempty"` // RTS receiver / CTS RA (own random MAC if empty)
+
+	NAVMicros int           `json:"nav_us"`   // Duration/ID value per frame (0 = default)
+	Rate      int           `json:"rate"`     // Frames per second (0 = default)
+	Duration  time.Duration `json:"duration"` // Total run time (0 = default)
+}
+
+// Validate ensures the configuration stays within the safety limits, applying defaults.
+func (c *NAVJamConfig) Validate() error {
+	if c.Interface != "" && !IsValidInterface(c.Interface) {
+		return fmt.Errorf("invalid interface name: %s", c.Interface)
+	}
+
+	if c.Channel < 1 || c.Channel > 165 {
+		return fmt.Errorf("invalid WiFi channel: %d", c.Channel)
+	}
+
+	switch c.FrameType {
+	case "":
+		c.FrameType = NAVJamCTS
+	case NAVJamCTS, NAVJamRTS:
+	default:
+		return fmt.Errorf("unknown NAV jam frame type: %s", c.FrameType)
+	}
+
+	if c.TargetMAC != "" && !IsValidMAC(c.TargetMAC) {
+		return fmt.Errorf("invalid target MAC: %s", c.TargetMAC)
+	}
+	if c.FrameType == NAVJamRTS && c.TargetMAC == "" {
+		return errors.New("target MAC is required for RTS jamming")
+	}
+
+	if c.NAVMicros < 0 || c.NAVMicros > MaxNAVDuration {
+		return fmt.Errorf("NAV duration must be between 0 and %dus", MaxNAVDuration)
+	}
+	if c.NAVMicros == 0 {
+		c.NAVMicros = DefaultNAVDuration
+	}
+
+	if c.Rate < 0 || c.Rate > MaxNAVJamRate {
+		return fmt.Errorf("rate must be between 0 and %d frames/s", MaxNAVJamRate)
+	}
+	if c.Rate == 0 {
+		c.Rate = DefaultNAVJamRate
+	}
+
+	if c.Duration < 0 || c.Duration > MaxNAVJamDuration {
+		return fmt.Errorf("duration must be between 0 and %v", MaxNAVJamDuration)
+	}
+	if c.Duration == 0 {
+		c.Duration = DefaultNAVJamLength
+	}
+
+	return nil
+}
+
+// NAVJamStatus encapsulates the runtime state of a virtual jamming run.
+type NAVJamStatus struct {
+	ID           string       `json:"id"`
+	Config       NAVJamConfig `json:"config"`
+	Status       AttackStatus `json:"status"`
+	PacketsSent  int          `json:"packets_sent"`
+	StartTime    time.Time    `json:"start_time"`
+	EndTime      *time.Time   `json:"end_time,omitempty"`
+	ErrorMessage string       `json:"error_message,omitempty"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MinPasswordLength is the shortest password accepted for an account.
+const MinPasswordLength = 12
+
+// ValidatePassword checks password against the password policy: at least
+// MinPasswordLength characters from at least three of lowercase, uppercase,
+// digits and symbols, and not containing the username.
+func ValidatePassword(password, username string) error {
+	if len([]rune(password)) < MinPasswordLength {
+		return fmt.Errorf("%w: at least %d characters", ErrInvalidPassword, MinPasswordLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+	if classes < 3 {
+		return fmt.Errorf("%w: use three of lowercase, uppercase, digits and symbols", ErrInvalidPassword)
+	}
+
+	if len(username) >= 3 && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		return fmt.Errorf("%w: must not contain the username", ErrInvalidPassword)
+	}
+	return nil
+}
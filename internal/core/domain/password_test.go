@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		password string
+		ok       bool
+	}{
+		{"changeit", false},
+		{"alllowercaseletters", false},
+		{"Admin-Passw0rd-2024", false}, // Contains the username
+		{"Correct-Horse-Battery", true},
+		{"correct horse battery 9", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidatePassword(tt.password, "admin")
+		if (err == nil) != tt.ok {
+			t.Errorf("ValidatePassword(%q) = %v, want ok %t", tt.password, err, tt.ok)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidPassword) {
+			t.Errorf("ValidatePassword(%q) = %v, not ErrInvalidPassword", tt.password, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Permission names a class of operations. Roles decide the operations a
+// user may run at all; a permission list narrows them, so that, say, a
+// junior analyst with the operator role can run recon and reports without
+// being able to trigger disruptive attacks.
+type Permission string
+
+const (
+	PermScan      Permission = "scan:control"     // Scans, channel locks, capture interfaces and retroactive captures
+	PermWorkspace Permission = "workspace:manage" // Creating, loading and clearing workspaces, scope and baseline
+	PermReport    Permission = "report:generate"
+	PermDevice    Permission = "device:manage" // Labels, assets, geofences, protected BSSIDs and imports
+	PermConfig    Permission = "config:manage" // Schedule, power, hooks, reloads and agent configuration
+)
+
+var ErrInvalidPermission = errors.New("invalid permission")
+
+// attackKinds are the attacks with a permission each.
+var attackKinds = []AttackKind{
+	AttackKindDeauth, AttackKindWPS, AttackKindAuthFlood, AttackKindProbeFlood,
+	AttackKindCSA, AttackKindBeaconSpoof, AttackKindKarma, AttackKindNAVJam,
+	AttackKindPortal,
+}
+
+// AttackPermission returns the permission to launch attacks of kind, e.g.
+// "attack:deauth".
+func AttackPermission(kind AttackKind) Permission {
+	return Permission("attack:" + kind)
+}
+
+// Permissions lists every permission, for clients building a picker.
+func Permissions() []Permission {
+	perms := []Permission{PermScan, PermWorkspace, PermReport, PermDevice, PermConfig}
+	for _, kind := range attackKinds {
+		perms = append(perms, AttackPermission(kind))
+	}
+	return perms
+}
+
+// IsValid checks if the permission is a recognized one.
+func (p Permission) IsValid() bool {
+	for _, known := range Permissions() {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePermissions checks every permission of a list.
+func ValidatePermissions(perms []Permission) error {
+	for _, p := range perms {
+		if !p.IsValid() {
+			return fmt.Errorf("%w: %q", ErrInvalidPermission, p)
+		}
+	}
+	return nil
+}
+
+// Can reports whether the permission list of the user grants p. Users
+// without a list may do everything their role allows.
+func (u *User) Can(p Permission) bool {
+	if len(u.Permissions) == 0 {
+		return true
+	}
+	for _, granted := range u.Permissions {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUserCan(t *testing.T) {
+	unrestricted := User{Role: RoleOperator}
+	if !unrestricted.Can(AttackPermission(AttackKindDeauth)) {
+		t.Error("user without a permission list should be allowed what the role allows")
+	}
+
+	junior := User{Role: RoleOperator, Permissions: []Permission{PermScan, PermReport}}
+	if !junior.Can(PermReport) {
+		t.Error("granted permission refused")
+	}
+	if junior.Can(AttackPermission(AttackKindDeauth)) {
+		t.Error("attack allowed without its permission")
+	}
+}
+
+func TestValidatePermissions(t *testing.T) {
+	if err := ValidatePermissions([]Permission{PermScan, "attack:deauth", "attack:portal_check"}); err != nil {
+		t.Errorf("valid permissions refused: %v", err)
+	}
+	for _, p := range []Permission{"attack:", "attack:nuke", "scan"} {
+		if err := ValidatePermissions([]Permission{p}); !errors.Is(err, ErrInvalidPermission) {
+			t.Errorf("ValidatePermissions(%q) = %v, want ErrInvalidPermission", p, err)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// PluginStatus describes an external analyzer and what it has done so far.
+type PluginStatus struct {
+	Name        string    `json:"name"`
+	Command     string    `json:"command"`
+	Running     bool      `json:"running"`
+	PID         int       `json:"pid,omitempty"`
+	StartedAt   time.Time `json:"started_at,omitempty"`
+	Restarts    int       `json:"restarts"`
+	Sent        int64     `json:"sent"`        // Events delivered to the analyzer
+	Dropped     int64     `json:"dropped"`     // Events dropped because the analyzer lagged behind
+	Alerts      int64     `json:"alerts"`      // Alerts raised by the analyzer
+	Annotations int64     `json:"annotations"` // Device annotations made by the analyzer
+	LastError   string    `json:"last_error,omitempty"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrNotOpenNetwork is returned for a captive portal check of a network that
+// requires credentials to associate.
+var ErrNotOpenNetwork = errors.New("captive portal checks only apply to open networks")
+
+// PortalResult is what a client associating with an open network gets.
+type PortalResult string
+
+const (
+	PortalOpenEgress PortalResult = "open_egress"    // Internet access without any login
+	PortalCaptive    PortalResult = "captive_portal" // Web requests intercepted by a login page
+	PortalNoEgress   PortalResult = "no_egress"      // Associated but nothing reachable
+)
+
+// PortalTarget is the open network a captive portal check associates with.
+type PortalTarget struct {
+	BSSID     string
+	SSID      string
+	Frequency int // MHz, 0 lets the interface scan for it
+}
+
+// PortalCheck records an active captive portal check of an open network.
+type PortalCheck struct {
+	BSSID      string       `json:"bssid"`
+	SSID       string       `json:"ssid"`
+	Interface  string       `json:"interface"`
+	Result     PortalResult `json:"result"`
+	PortalURL  string       `json:"portal_url,omitempty"`  // Login page requests were sent to
+	StatusCode int          `json:"status_code,omitempty"` // Of the connectivity probe
+	CheckedAt  time.Time    `json:"checked_at"`
+}
+
+// IsOpenNetwork reports whether associating with the device needs no credentials.
+func (d Device) IsOpenNetwork() bool {
+	security := strings.ToUpper(d.Security)
+	return d.IsAP() && (d.Security == "" || security == "OPEN" || security == "NONE")
+}
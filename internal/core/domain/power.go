@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Power save errors
+var ErrInvalidDutyCycle = errors.New("invalid duty cycle")
+
+// Power save defaults for battery and solar powered sensors
+const (
+	DefaultPowerSaveFlush = time.Minute
+	DefaultLowBattery     = 20 // Percent
+)
+
+// PowerSaveConfig trades capture coverage for battery life: capture runs
+// On, then the radios sleep for Off, handshake captures no longer hold the
+// hopper on a channel, and devices are written to storage less often.
+type PowerSaveConfig struct {
+	Enabled       bool          `json:"enabled"`
+	On            time.Duration `json:"on"`             // Capturing part of a cycle
+	Off           time.Duration `json:"off"`            // Sleeping part of a cycle; 0 captures continuously
+	FlushInterval time.Duration `json:"flush_interval"` // Between persistence flushes
+	LowBattery    int           `json:"low_battery"`    // Percent reported as low, 0 disables
+}
+
+// Validate checks the cycle and fills the defaults.
+func (c *PowerSaveConfig) Validate() error {
+	if c.On < 0 || c.Off < 0 || c.FlushInterval < 0 || c.LowBattery < 0 || c.LowBattery > 100 {
+		return ErrInvalidDutyCycle
+	}
+	if c.Off > 0 && c.On < time.Second {
+		return fmt.Errorf("%w: capture must run at least a second per cycle", ErrInvalidDutyCycle)
+	}
+	if c.FlushInterval == 0 {
+		c.FlushInterval = DefaultPowerSaveFlush
+	}
+	return nil
+}
+
+// Cycling reports whether the radios sleep part of the time.
+func (c PowerSaveConfig) Cycling() bool {
+	return c.Enabled && c.Off > 0
+}
+
+// IsOn reports whether capture runs at t. Cycles are aligned on the Unix
+// epoch, so sensors sharing a cycle sleep and wake together.
+func (c PowerSaveConfig) IsOn(t time.Time) bool {
+	if !c.Cycling() {
+		return true
+	}
+	return time.Duration(t.UnixNano())%(c.On+c.Off) < c.On
+}
+
+// NextChange returns when capture next starts or stops after t.
+func (c PowerSaveConfig) NextChange(t time.Time) time.Time {
+	if !c.Cycling() {
+		return time.Time{}
+	}
+	period := c.On + c.Off
+	phase := time.Duration(t.UnixNano()) % period
+	if phase < c.On {
+		return t.Add(c.On - phase)
+	}
+	return t.Add(period - phase)
+}
+
+// ParseDutyCycle reads a cycle written as "on/off", e.g. "20s/40s".
+func ParseDutyCycle(spec string) (on, off time.Duration, err error) {
+	onSpec, offSpec, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %q, expected on/off such as 20s/40s", ErrInvalidDutyCycle, spec)
+	}
+	if on, err = time.ParseDuration(strings.TrimSpace(onSpec)); err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalidDutyCycle, err)
+	}
+	if off, err = time.ParseDuration(strings.TrimSpace(offSpec)); err != nil {
+		return 0, 0, fmt.Errorf("%w: %v", ErrInvalidDutyCycle, err)
+	}
+	return on, off, nil
+}
+
+// BatteryStatus is the charge of the sensor's battery.
+type BatteryStatus struct {
+	Percent   int       `json:"percent"`
+	Charging  bool      `json:"charging"`
+	Source    string    `json:"source"` // Power supply or command reporting it
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// PowerStatus is the power save configuration and state of the sensor.
+type PowerStatus struct {
+	PowerSave PowerSaveConfig `json:"power_save"`
+	Sleeping  bool            `json:"sleeping"` // Radios off for the current cycle
+	Battery   *BatteryStatus  `json:"battery,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
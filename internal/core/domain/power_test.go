@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDutyCycle(t *testing.T) {
+	on, off, err := ParseDutyCycle("20s/40s")
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Second, on)
+	assert.Equal(t, 40*time.Second, off)
+
+	for _, spec := range []string{"20s", "20s/", "x/40s"} {
+		_, _, err := ParseDutyCycle(spec)
+		assert.True(t, errors.Is(err, ErrInvalidDutyCycle), spec)
+	}
+}
+
+func TestPowerSaveConfig_Cycle(t *testing.T) {
+	cfg := PowerSaveConfig{Enabled: true, On: 20 * time.Second, Off: 40 * time.Second}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, DefaultPowerSaveFlush, cfg.FlushInterval)
+
+	start := time.Unix(600, 0) // Multiple of the minute long cycle
+	assert.True(t, cfg.IsOn(start))
+	assert.True(t, cfg.IsOn(start.Add(19*time.Second)))
+	assert.False(t, cfg.IsOn(start.Add(20*time.Second)))
+	assert.False(t, cfg.IsOn(start.Add(59*time.Second)))
+
+	assert.Equal(t, start.Add(20*time.Second), cfg.NextChange(start.Add(5*time.Second)))
+	assert.Equal(t, start.Add(time.Minute), cfg.NextChange(start.Add(30*time.Second)))
+
+	cfg.Enabled = false
+	assert.True(t, cfg.IsOn(start.Add(30*time.Second)))
+	assert.True(t, cfg.NextChange(start).IsZero())
+}
+
+func TestPowerSaveConfig_Validate(t *testing.T) {
+	assert.Error(t, (&PowerSaveConfig{Enabled: true, On: 0, Off: time.Minute}).Validate())
+	assert.Error(t, (&PowerSaveConfig{LowBattery: 101}).Validate())
+	assert.NoError(t, (&PowerSaveConfig{Enabled: true}).Validate()) // Continuous capture
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultProbeFloodSSIDLength is the length of generated SSIDs when none are configured.
+	DefaultProbeFloodSSIDLength = 8
+	// MaxProbeFloodSSIDs bounds the configured SSID list.
+	MaxProbeFloodSSIDs = 1024
+	// MinProbeFloodInterval protects the medium (and the adapter) from unbounded floods.
+	MinProbeFloodInterval = time.Millisecond
+)
+
+// ProbeFloodAttackConfig defines the parameters of a probe request flood (MDK "p" mode).
+// It broadcasts Probe Requests for configured or random SSIDs, typically to exercise WIDS detection.
+type ProbeFloodAttackConfig struct {
+	// Infrastructure
+	Interface   string `json:"interface,omitempty"`    // Optional, auto-selected if empty
+	Channel     int    `json:"channel,omitempty"`      // Optional, will switch if provided
+	TargetBSSID string `json:"target_bssid,omitempty"` // Optional directed probes (broadcast if empty)
+
+	// SSID Strategy
+	SSIDs      []string `json:"ssids"`       // Cycled in order; random SSIDs are used if empty
+	SSIDLength int      `json:"ssid_length"` // Length of random SSIDs (0 = default)
+
+	// Source Spoofing
+	UseRandomMAC   bool   `json:"use_random_mac"`   // True for a new random source MAC per frame
+	FixedSourceMAC string `json:"fixed_source_mac"` // Used if UseRandomMAC is false
+
+	// Flow Control / Stop Condition
+	PacketCount    int           `json:"packet_count"`    // 0 for continuous
+	PacketInterval time.Duration `json:"packet_interval"` // Time between packets
+	Duration       time.Duration `json:"duration"`        // 0 for no time limit
+}
+
+// Validate ensures the configuration adheres to business and protocol rules.
+func (c *ProbeFloodAttackConfig) Validate() error {
+	if c.Interface != "" && !IsValidInterface(c.Interface) {
+		return fmt.Errorf("invalid interface name: %s", c.Interface)
+	}
+
+	if c.TargetBSSID != "" && !IsValidMAC(c.TargetBSSID) {
+		return fmt.Errorf("invalid target BSSID: %s", c.TargetBSSID)
+	}
+
+	if len(c.SSIDs) > MaxProbeFloodSSIDs {
+		return fmt.Errorf("too many SSIDs: %d (max %d)", len(c.SSIDs), MaxProbeFloodSSIDs)
+	}
+	for _, ssid := range c.SSIDs {
+		if len(ssid) > 32 {
+			return fmt.Errorf("SSID too long: %q", ssid)
+		}
+	}
+
+	if c.SSIDLength < 0 || c.SSIDLength > 32 {
+		return fmt.Errorf("random SSID length must be between 0 and 32: %d", c.SSIDLength)
+	}
+
+	if !c.UseRandomMAC && c.FixedSourceMAC != "" && !IsValidMAC(c.FixedSourceMAC) {
+		return fmt.Errorf("invalid source MAC: %s", c.FixedSourceMAC)
+	}
+
+	if c.PacketCount < 0 {
+		return errors.New("packet count cannot be negative")
+	}
+
+	if c.PacketInterval < 0 {
+		return errors.New("packet interval cannot be negative")
+	}
+
+	if c.PacketInterval > 0 && c.PacketInterval < MinProbeFloodInterval {
+		return fmt.Errorf("packet interval must be at least %v", MinProbeFloodInterval)
+	}
+
+	if c.Duration < 0 {
+		return errors.New("duration cannot be negative")
+	}
+
+	return nil
+}
+
+// ProbeFloodAttackStatus encapsulates the runtime state of a probe flood.
+type ProbeFloodAttackStatus struct {
+	ID           string                 `json:"id"`
+	Config       ProbeFloodAttackConfig `json:"config"`
+	Status       AttackStatus           `json:"status"`
+	PacketsSent  int                    `json:"packets_sent"`
+	StartTime    time.Time              `json:"start_time"`
+	EndTime      *time.Time             `json:"end_time,omitempty"`
+	ErrorMessage string                 `json:"error_message,omitempty"`
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrProtectedBSSIDNotFound is returned when a BSSID is not on the protected list.
+var ErrProtectedBSSIDNotFound = errors.New("protected BSSID not found")
+
+// ProtectedBSSID is an AP defended by the sensor: deauthentication,
+// disassociation and channel switch frames targeting it raise high-severity
+// alerts.
+type ProtectedBSSID struct {
+	BSSID           string    `json:"bssid"`
+	Label           string    `json:"label,omitempty"`
+	CaptureEvidence bool      `json:"capture_evidence"` // Save the offending frames as a pcap artifact
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Validate checks the BSSID and normalizes it to lower case.
+func (p *ProtectedBSSID) Validate() error {
+	if !IsValidMAC(p.BSSID) {
+		return ErrInvalidMAC
+	}
+	p.BSSID = strings.ToLower(p.BSSID)
+	return nil
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// VulnWeakPSK names the vulnerability raised when a network's PSK is recovered
+// from a captured handshake with a weak-password list.
+const VulnWeakPSK = "WEAK-PSK"
+
+// PSKAuditStatus reports the progress of the weak-PSK audit over captured handshakes.
+type PSKAuditStatus struct {
+	Running    bool      `json:"running"`
+	StartedAt  time.Time `json:"started_at,omitempty"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+	Captures   int       `json:"captures"`  // Capture files found
+	Audited    int       `json:"audited"`   // Captures run against the wordlist
+	Recovered  []string  `json:"recovered"` // BSSIDs whose PSK was recovered
+	Errors     []string  `json:"errors,omitempty"`
+}
+
+// RecoveredCredential is a secret recovered for a network. The secret is only
+// kept sealed; SealedSecret is never the plaintext.
+type RecoveredCredential struct {
+	ID           string    `json:"id"` // BSSID
+	BSSID        string    `json:"bssid"`
+	ESSID        string    `json:"essid"`
+	Source       string    `json:"source"` // e.g. "psk_audit"
+	SealedSecret string    `json:"-"`
+	RecoveredAt  time.Time `json:"recovered_at"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Retroactive capture limits. The frames actually available also depend on
+// the history the sensor keeps per adapter.
+const (
+	DefaultRecentCapture = 30 * time.Second
+	MaxRecentCapture     = 10 * time.Minute
+)
+
+var (
+	// ErrInvalidRecentCapture is returned for a retroactive capture window out of range.
+	ErrInvalidRecentCapture = errors.New("invalid recent capture window")
+	// ErrFrameHistoryUnavailable is returned when the sensor keeps no frame history.
+	ErrFrameHistoryUnavailable = errors.New("frame history not available")
+	// ErrNoRecentFrames is returned when no frame was captured in the window.
+	ErrNoRecentFrames = errors.New("no frames captured in the window")
+)
+
+// RecentCaptureRequest saves the frames captured during the last Seconds, on
+// an interface or on every adapter when Interface is empty.
+type RecentCaptureRequest struct {
+	Seconds   int    `json:"seconds"`
+	Interface string `json:"interface,omitempty"`
+}
+
+// Window returns how far back the capture goes, the default for zero.
+func (r RecentCaptureRequest) Window() time.Duration {
+	if r.Seconds == 0 {
+		return DefaultRecentCapture
+	}
+	return time.Duration(r.Seconds) * time.Second
+}
+
+// Validate checks the window is in range.
+func (r RecentCaptureRequest) Validate() error {
+	if r.Seconds < 0 || r.Window() > MaxRecentCapture {
+		return fmt.Errorf("%w: seconds must be between 1 and %d", ErrInvalidRecentCapture, int(MaxRecentCapture.Seconds()))
+	}
+	return nil
+}
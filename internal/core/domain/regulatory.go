@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// RadarNonOccupancyPeriod is how long a channel stays closed to transmission
+// after a radar detection (ETSI/FCC non-occupancy period).
+const RadarNonOccupancyPeriod = 30 * time.Minute
+
+// ErrTxNotPermitted is returned when an active operation targets a channel the
+// regulatory domain does not allow us to initiate transmissions on.
+var ErrTxNotPermitted = errors.New("transmission not permitted on this channel by the regulatory domain")
+
+var regDomainRegex = regexp.MustCompile(`^([A-Z]{2}|00)$`)
+
+// IsValidRegDomain validates an ISO 3166-1 alpha-2 country code (or "00" for world).
+func IsValidRegDomain(cc string) bool {
+	return regDomainRegex.MatchString(cc)
+}
+
+// ChannelRegulatory describes the regulatory flags of a channel on an interface.
+type ChannelRegulatory struct {
+	Channel       int  `json:"channel"`
+	FrequencyMHz  int  `json:"frequency_mhz"`
+	Disabled      bool `json:"disabled"`
+	NoIR          bool `json:"no_ir"`          // No initiating radiation (passive only)
+	DFS           bool `json:"dfs"`            // Radar detection required before transmitting
+	RadarDetected bool `json:"radar_detected"` // Radar seen within the non-occupancy period
+}
+
+// TxAllowed reports whether we may initiate transmissions (injection) on the channel.
+// Listening is always allowed on enabled channels.
+func (c ChannelRegulatory) TxAllowed() bool {
+	return !c.Disabled && !c.NoIR && !c.DFS && !c.RadarDetected
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// ReloadResult reports the outcome of reloading one data file into the
+// running services.
+type ReloadResult struct {
+	Source     string    `json:"source"`
+	Path       string    `json:"path"`
+	Loaded     int       `json:"loaded"` // Entries loaded from the file
+	Error      string    `json:"error,omitempty"`
+	ReloadedAt time.Time `json:"reloaded_at"`
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrAgentNotConnected is returned for commands to an agent without an open
+// command channel.
+var ErrAgentNotConnected = errors.New("agent is not connected")
+
+// AgentInfo describes an agent connected to the command channel.
+type AgentInfo struct {
+	ID          string       `json:"id"`
+	Interfaces  []string     `json:"interfaces"`
+	Attacks     []AttackKind `json:"attacks"` // Kinds the agent can run
+	ConnectedAt time.Time    `json:"connected_at"`
+	Version     string       `json:"version,omitempty"`
+	AutoUpdate  bool         `json:"auto_update"`            // Installs the releases the server advertises
+	UpdateError string       `json:"update_error,omitempty"` // Last failed update
+
+	Config *AgentConfigStatus `json:"config,omitempty"` // Set by the hub when listing
+}
+
+// Supports reports whether the agent can run attacks of the kind.
+func (a AgentInfo) Supports(kind AttackKind) bool {
+	for _, k := range a.Attacks {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// AgentCommandAction is what an agent command asks for.
+type AgentCommandAction string
+
+const (
+	AgentCommandStart     AgentCommandAction = "start"
+	AgentCommandStop      AgentCommandAction = "stop"
+	AgentCommandConfigure AgentCommandAction = "configure" // Config holds an AgentConfig
+	AgentCommandUpdate    AgentCommandAction = "update"    // Config holds an AgentUpdate
+)
+
+// AgentCommand asks an agent to start or stop an attack, or to apply a
+// configuration. For attacks Config holds the JSON configuration of the
+// kind's engine (DeauthAttackConfig, WPSAttackConfig).
+type AgentCommand struct {
+	AttackID string             `json:"attack_id"`
+	Action   AgentCommandAction `json:"action"`
+	Kind     AttackKind         `json:"kind,omitempty"`
+	Config   json.RawMessage    `json:"config,omitempty"`
+	Revision int64              `json:"revision,omitempty"` // Of a configure command
+}
+
+// RemoteAttackUpdate is the progress of an attack reported by the agent
+// running it.
+type RemoteAttackUpdate struct {
+	AttackID               string    `json:"attack_id"`
+	Status                 string    `json:"status"` // Engine status on the agent
+	Finished               bool      `json:"finished"`
+	Error                  string    `json:"error,omitempty"`
+	PacketsSent            int       `json:"packets_sent"`
+	HandshakeCaptured      bool      `json:"handshake_captured"`
+	DisconnectionConfirmed bool      `json:"disconnection_confirmed"`
+	CredentialsRecovered   bool      `json:"credentials_recovered"`
+	Timestamp              time.Time `json:"timestamp"`
+}
+
+// RemoteAttack is an attack the server commanded an agent to run.
+type RemoteAttack struct {
+	ID       string          `json:"id"`
+	AgentID  string          `json:"agent_id"`
+	Kind     AttackKind      `json:"kind"`
+	Target   string          `json:"target"`
+	Operator string          `json:"operator,omitempty"`
+	Config   json.RawMessage `json:"config"`
+
+	Status                 string `json:"status"`
+	Finished               bool   `json:"finished"`
+	Error                  string `json:"error,omitempty"`
+	PacketsSent            int    `json:"packets_sent"`
+	HandshakeCaptured      bool   `json:"handshake_captured"`
+	DisconnectionConfirmed bool   `json:"disconnection_confirmed"`
+	CredentialsRecovered   bool   `json:"credentials_recovered"`
+
+	StartTime time.Time `json:"start_time"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Apply merges an update reported by the agent.
+func (a *RemoteAttack) Apply(u RemoteAttackUpdate) {
+	a.Status = u.Status
+	a.Finished = u.Finished
+	a.Error = u.Error
+	a.PacketsSent = u.PacketsSent
+	a.HandshakeCaptured = a.HandshakeCaptured || u.HandshakeCaptured
+	a.DisconnectionConfirmed = a.DisconnectionConfirmed || u.DisconnectionConfirmed
+	a.CredentialsRecovered = a.CredentialsRecovered || u.CredentialsRecovered
+	a.UpdatedAt = u.Timestamp
+	if a.UpdatedAt.IsZero() {
+		a.UpdatedAt = time.Now()
+	}
+}
+
+// Record builds the history record of a finished remote attack. The
+// interface is qualified with the agent, as alert sensors are.
+func (a RemoteAttack) Record() AttackRecord {
+	end := a.UpdatedAt
+	record := NewAttackRecord(a.Kind, a.ID, a.Config, a.StartTime, &end)
+	record.Target = a.Target
+	record.Operator = a.Operator
+	record.Interface = a.AgentID
+	record.Status = a.Status
+	record.ErrorMessage = a.Error
+	record.PacketsSent = a.PacketsSent
+	record.HandshakeCaptured = a.HandshakeCaptured
+	record.DisconnectionConfirmed = a.DisconnectionConfirmed
+	record.CredentialsRecovered = a.CredentialsRecovered
+
+	var common struct {
+		Interface string `json:"interface"`
+		Channel   int    `json:"channel"`
+	}
+	if json.Unmarshal(a.Config, &common) == nil {
+		if common.Interface != "" {
+			record.Interface += "/" + common.Interface
+		}
+		record.Channel = common.Channel
+	}
+	return record
+}
@@ -99,9 +99,9 @@ func (r *ReportData) RecomputeStats() {
 		}
 		stats.SecurityBreakdown[sec]++
 
-		// 4. Radio Channel usage
-		if d.Channel > 0 {
-			stats.ChannelUsage[d.Channel]++
+		// 4. Radio Channel usage (wide BSSs load every sub-channel they span)
+		for _, ch := range d.OccupiedChannels() {
+			stats.ChannelUsage[ch]++
 		}
 
 		// 5. Vulnerability check
@@ -155,6 +155,7 @@ const (
 	ReportTypeTechnical  ReportType = "technical"
 	ReportTypeCompliance ReportType = "compliance"
 	ReportTypeTrend      ReportType = "trend"
+	ReportTypeActions    ReportType = "actions"
 )
 
 // ReportFormat represents the export format for reports
@@ -196,6 +197,41 @@ type ExecutiveSummary struct {
 	VulnStats       VulnerabilityStats `json:"vulnerability_stats"`
 	TopRisks        []RiskItem         `json:"top_risks"`
 	Recommendations []Recommendation   `json:"recommendations"`
+	Assets          []AssetExposure    `json:"assets,omitempty"` // Most critical first
+}
+
+// AssetExposure ties the active findings on a device to its business asset.
+type AssetExposure struct {
+	MAC             string           `json:"mac"`
+	Owner           string           `json:"owner,omitempty"`
+	AssetTag        string           `json:"asset_tag,omitempty"`
+	Criticality     AssetCriticality `json:"criticality,omitempty"`
+	Vulnerabilities int              `json:"vulnerabilities"`
+	MaxSeverity     int              `json:"max_severity"`
+	TopFinding      string           `json:"top_finding"`
+}
+
+// ActionsReport lists the attacks launched during an engagement, for the
+// rules of engagement appendix of a penetration test report.
+type ActionsReport struct {
+	Metadata ReportMetadata  `json:"metadata"`
+	Scope    EngagementScope `json:"scope"`
+	Summary  ActionsSummary  `json:"summary"`
+	Actions  []AttackRecord  `json:"actions"` // Oldest first
+}
+
+// ActionsSummary aggregates the attacks of an actions report.
+type ActionsSummary struct {
+	Total                  int                `json:"total"`
+	ByKind                 map[AttackKind]int `json:"by_kind"`
+	Operators              []string           `json:"operators"`
+	Targets                int                `json:"targets"` // Distinct targets
+	FirstAction            time.Time          `json:"first_action,omitempty"`
+	LastAction             time.Time          `json:"last_action,omitempty"`
+	HandshakesCaptured     int                `json:"handshakes_captured"`
+	CredentialsRecovered   int                `json:"credentials_recovered"`
+	DisconnectionsObserved int                `json:"disconnections_observed"`
+	Failed                 int                `json:"failed"`
 }
 
 // VulnerabilityStats provides statistical breakdown of vulnerabilities
@@ -210,6 +246,7 @@ type VulnerabilityStats struct {
 	ByStatus    map[string]int `json:"by_status"`
 	Confirmed   int            `json:"confirmed"`
 	Unconfirmed int            `json:"unconfirmed"`
+	Suppressed  int            `json:"suppressed"` // Accepted risks left out of the other counts
 }
 
 // RiskItem represents a prioritized security risk
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNoROE is returned when an attack is started in a workspace without
+	// rules of engagement in force.
+	ErrNoROE = errors.New("no rules of engagement in force")
+	// ErrInvalidROE is returned for rules of engagement that cannot be signed.
+	ErrInvalidROE = errors.New("invalid rules of engagement")
+)
+
+// RulesOfEngagement is the signed record authorizing active operations in a
+// workspace: who acknowledged what, over which targets and for how long.
+// Records are kept for the engagement file; the latest one is in force.
+type RulesOfEngagement struct {
+	ID             uint            `json:"id" gorm:"primaryKey"`
+	Operator       string          `json:"operator"`                     // Username of the signer
+	Scope          EngagementScope `json:"scope" gorm:"serializer:json"` // Workspace scope when signed
+	Start          time.Time       `json:"start"`                        // Window attacks are authorized in, up to End
+	End            time.Time       `json:"end"`
+	Acknowledgment string          `json:"acknowledgment"` // Text the operator agreed to
+	SignedAt       time.Time       `json:"signed_at"`
+	Digest         string          `json:"digest"`  // HMAC-SHA256 of the fields above
+	Revoked        bool            `json:"revoked"` // Withdrawn before its window ended
+}
+
+// Validate checks that the record can be signed.
+func (r RulesOfEngagement) Validate() error {
+	if strings.TrimSpace(r.Acknowledgment) == "" {
+		return fmt.Errorf("%w: acknowledgment text is required", ErrInvalidROE)
+	}
+	if r.Start.IsZero() || r.End.IsZero() || !r.End.After(r.Start) {
+		return fmt.Errorf("%w: window must end after it starts", ErrInvalidROE)
+	}
+	return r.Scope.Validate()
+}
+
+// Seal computes the digest binding the operator, scope, window and
+// acknowledgment of the record. It is keyed, so that whoever can write the
+// database cannot sign records of their own.
+func (r RulesOfEngagement) Seal(key []byte) string {
+	content, _ := json.Marshal(struct {
+		Operator       string          `json:"operator"`
+		Scope          EngagementScope `json:"scope"`
+		Start          int64           `json:"start"`
+		End            int64           `json:"end"`
+		Acknowledgment string          `json:"acknowledgment"`
+		SignedAt       int64           `json:"signed_at"`
+	}{r.Operator, r.Scope, r.Start.Unix(), r.End.Unix(), r.Acknowledgment, r.SignedAt.Unix()})
+	mac := hmac.New(sha256.New, key)
+	mac.Write(content)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Intact reports whether the record matches its digest under key.
+func (r RulesOfEngagement) Intact(key []byte) bool {
+	return r.Digest != "" && hmac.Equal([]byte(r.Digest), []byte(r.Seal(key)))
+}
+
+// InForce reports whether the record authorizes attacks at now.
+func (r RulesOfEngagement) InForce(now time.Time) bool {
+	return !r.Revoked && !now.Before(r.Start) && now.Before(r.End)
+}
+
+// Covers reports whether the record was signed for scope, so that widening
+// the scope of the workspace requires signing again.
+func (r RulesOfEngagement) Covers(scope EngagementScope) bool {
+	signed, _ := json.Marshal(r.Scope)
+	current, _ := json.Marshal(scope)
+	return string(signed) == string(current)
+}
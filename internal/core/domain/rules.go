@@ -9,9 +9,12 @@ import (
 
 // Domain Errors for Alerting
 var (
-	ErrInvalidRuleType = errors.New("invalid alert rule type")
-	ErrEmptyRuleValue  = errors.New("alert rule value cannot be empty")
-	ErrInvalidSeverity = errors.New("invalid alert severity level")
+	ErrInvalidRuleType   = errors.New("invalid alert rule type")
+	ErrEmptyRuleValue    = errors.New("alert rule value cannot be empty")
+	ErrInvalidSeverity   = errors.New("invalid alert severity level")
+	ErrInvalidRuleAction = errors.New("invalid alert rule action")
+	ErrInvalidOUI        = errors.New("OUI must be a MAC prefix such as 60:60:1F")
+	ErrEmptyRuleTag      = errors.New("alert rule tag action requires a tag")
 )
 
 // AlertType defines the category of an alert.
@@ -22,7 +25,8 @@ const (
 	AlertMAC     AlertType = "MAC_MATCH"
 	AlertVendor  AlertType = "VENDOR_MATCH"
 	AlertProbe   AlertType = "PROBE_MATCH"
-	AlertAnomaly AlertType = "ANOMALY" // e.g. Deauth Flood, Rogue AP
+	AlertOUI     AlertType = "OUI_MATCH" // MAC prefix of a vendor, e.g. every DJI device
+	AlertAnomaly AlertType = "ANOMALY"   // e.g. Deauth Flood, Rogue AP
 )
 
 // AlertSeverity represents the criticality of a security event.
@@ -36,13 +40,35 @@ const (
 	SeverityInfo     AlertSeverity = "info"
 )
 
-// AlertRule defines the criteria used by the engine to trigger alerts.
+// RuleAction is what a rule does with a matching device.
+type RuleAction string
+
+const (
+	RuleActionAlert  RuleAction = "alert"  // Raise an alert
+	RuleActionTag    RuleAction = "tag"    // Annotate the device with the rule's tag
+	RuleActionRecord RuleAction = "record" // Record the device's frames to a pcap artifact
+)
+
+// DefaultRecordDuration is how long a record action captures a device's frames.
+const DefaultRecordDuration = 5 * time.Minute
+
+// TagAnnotationPrefix prefixes the device annotations set by tag actions.
+const TagAnnotationPrefix = "tag."
+
+// AlertRule defines the criteria used by the engine to trigger alerts. Rules
+// with actions beyond alerting act as policies, e.g. "any DJI OUI device:
+// critical alert, tag as drone and record its frames".
 type AlertRule struct {
 	ID      string    `json:"id"`
 	Type    AlertType `json:"type"`
 	Value   string    `json:"value"` // The value to match (e.g., "HiddenLab", "AA:BB:CC...")
 	Exact   bool      `json:"exact"` // If true, performs a literal match; otherwise, partial (case-insensitive)
 	Enabled bool      `json:"enabled"`
+
+	Severity       AlertSeverity `json:"severity,omitempty"`        // Of the raised alert; high when empty
+	Actions        []RuleAction  `json:"actions,omitempty"`         // Alert only when empty
+	Tag            string        `json:"tag,omitempty"`             // Set by the tag action
+	RecordDuration time.Duration `json:"record_duration,omitempty"` // Of the record action; DefaultRecordDuration when zero
 }
 
 // Validate performs internal consistency checks on the rule.
@@ -53,10 +79,85 @@ func (r *AlertRule) Validate() error {
 
 	switch r.Type {
 	case AlertSSID, AlertMAC, AlertVendor, AlertProbe, AlertAnomaly:
-		return nil
+	case AlertOUI:
+		if !isMACPrefix(r.Value) {
+			return ErrInvalidOUI
+		}
 	default:
 		return ErrInvalidRuleType
 	}
+
+	if r.Severity != "" && !isValidSeverity(r.Severity) {
+		return ErrInvalidSeverity
+	}
+	for _, action := range r.Actions {
+		switch action {
+		case RuleActionAlert, RuleActionRecord:
+		case RuleActionTag:
+			if strings.TrimSpace(r.Tag) == "" {
+				return ErrEmptyRuleTag
+			}
+		default:
+			return fmt.Errorf("%w: %q", ErrInvalidRuleAction, action)
+		}
+	}
+	return nil
+}
+
+// HasAction reports whether the rule performs action. Rules without
+// actions only alert.
+func (r AlertRule) HasAction(action RuleAction) bool {
+	if len(r.Actions) == 0 {
+		return action == RuleActionAlert
+	}
+	for _, a := range r.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// AlertSeverity returns the severity of the alerts raised by the rule.
+func (r AlertRule) AlertSeverity() AlertSeverity {
+	if r.Severity == "" {
+		return SeverityHigh
+	}
+	return r.Severity
+}
+
+// RecordFor returns how long the record action captures a device.
+func (r AlertRule) RecordFor() time.Duration {
+	if r.RecordDuration <= 0 {
+		return DefaultRecordDuration
+	}
+	return r.RecordDuration
+}
+
+// MatchesOUI reports whether mac starts with the rule's MAC prefix. Any
+// separator or none is accepted on both sides.
+func (r AlertRule) MatchesOUI(mac string) bool {
+	prefix := macHex(r.Value)
+	return prefix != "" && strings.HasPrefix(macHex(mac), prefix)
+}
+
+// macHex strips the separators of a MAC address or prefix and lowercases it.
+func macHex(value string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", "-", "", ".", "").Replace(strings.TrimSpace(value)))
+}
+
+// isMACPrefix reports whether value is 3 to 6 hex octets.
+func isMACPrefix(value string) bool {
+	hex := macHex(value)
+	if len(hex) < 6 || len(hex) > 12 || len(hex)%2 != 0 {
+		return false
+	}
+	for _, c := range hex {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
 }
 
 // Matches evaluates if a given input string satisfies the rule's criteria.
@@ -87,6 +188,53 @@ type Alert struct {
 	Message   string        `json:"message"`
 	Details   string        `json:"details,omitempty"`
 	Severity  AlertSeverity `json:"severity"`
+	// Confidence of detectors that weigh several indicators, from 0 to 1
+	Confidence float64 `json:"confidence,omitempty"`
+
+	// Sensor context of the frame that raised the alert, if any
+	Sensor    string  `json:"sensor,omitempty"`    // Interface, or "agent/interface" for remote sensors
+	Workspace string  `json:"workspace,omitempty"` // Workspace the sensor is routed to
+	RSSI      int     `json:"rssi,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+
+	// Observations lists every sensor that saw the event when the alert was
+	// correlated across sensors.
+	Observations []SensorObservation `json:"observations,omitempty"`
+
+	// Offending frame, for alerts raised by a single management frame
+	BSSID      string `json:"bssid,omitempty"`
+	Sequence   int    `json:"sequence,omitempty"`    // 802.11 sequence number
+	SeqGap     int    `json:"seq_gap,omitempty"`     // Distance from the transmitter's last beacon sequence number, 0 if unknown
+	ReasonCode int    `json:"reason_code,omitempty"` // Reason of deauthentication and disassociation frames
+	Frame      []byte `json:"-"`                     // Raw frame including RadioTap, kept for evidence capture
+
+	// EvidenceID is the artifact holding the captured offending frames.
+	EvidenceID string `json:"evidence_id,omitempty"`
+
+	// Changes lists the configuration that changed, for alerts raised by
+	// an AP announcing a different one.
+	Changes []ConfigChange `json:"changes,omitempty"`
+
+	// CVEs mapped to the device raising the alert, highest CVSS first
+	CVEs []AlertCVE `json:"cves,omitempty"`
+}
+
+// ConfigChange is a setting an AP advertised differently than before.
+type ConfigChange struct {
+	Field  string `json:"field"` // e.g. "beacon_interval", "rsn"
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// SensorObservation summarizes what a single sensor saw of a correlated event.
+type SensorObservation struct {
+	Sensor    string  `json:"sensor"`
+	RSSI      int     `json:"rssi"`   // Strongest signal seen
+	Frames    int     `json:"frames"` // Offending frames seen
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+	DistanceM float64 `json:"distance_m"` // Estimated distance to the emitter
 }
 
 // NewAlert creates a new Alert instance while ensuring the severity domain invariant.
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Monitoring schedule errors
+var (
+	ErrInvalidSchedule = errors.New("invalid monitoring schedule")
+	ErrEmptySchedule   = errors.New("an enabled monitoring schedule needs at least one window")
+)
+
+// weekdayNames are the day names accepted in monitoring windows.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// MonitoringWindow is a daily period during which capture is allowed, such as
+// 08:00-20:00. A window ending before it starts runs overnight into the next
+// day; "24:00" ends it at midnight.
+type MonitoringWindow struct {
+	Days  []string `json:"days,omitempty"` // mon..sun, the day the window starts; empty means every day
+	Start string   `json:"start"`          // HH:MM
+	End   string   `json:"end"`            // HH:MM
+}
+
+// MonitoringSchedule restricts capture to site monitoring hours. Outside its
+// windows the sensor pauses capture and gives the interfaces back to the
+// system in managed mode. A disabled schedule captures all the time.
+type MonitoringSchedule struct {
+	Enabled  bool               `json:"enabled"`
+	Timezone string             `json:"timezone,omitempty"` // IANA name; the sensor's local time when empty
+	Windows  []MonitoringWindow `json:"windows"`
+}
+
+// MonitoringStatus is the state of the scheduled capture.
+type MonitoringStatus struct {
+	Schedule  MonitoringSchedule `json:"schedule"`
+	Capturing bool               `json:"capturing"`
+	Since     time.Time          `json:"since"` // Last change of Capturing
+	Error     string             `json:"error,omitempty"`
+}
+
+// Validate checks the windows and the timezone and normalizes day names.
+func (s *MonitoringSchedule) Validate() error {
+	if s.Enabled && len(s.Windows) == 0 {
+		return ErrEmptySchedule
+	}
+	if _, err := s.location(); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, s.Timezone)
+	}
+	for i := range s.Windows {
+		w := &s.Windows[i]
+		start, err := parseClock(w.Start)
+		if err != nil || start >= 24*60 {
+			return fmt.Errorf("%w: bad start time %q", ErrInvalidSchedule, w.Start)
+		}
+		end, err := parseClock(w.End)
+		if err != nil {
+			return fmt.Errorf("%w: bad end time %q", ErrInvalidSchedule, w.End)
+		}
+		if start == end {
+			return fmt.Errorf("%w: window %s-%s is empty", ErrInvalidSchedule, w.Start, w.End)
+		}
+		for j, day := range w.Days {
+			day = strings.ToLower(strings.TrimSpace(day))
+			if _, ok := weekdayNames[day]; !ok {
+				return fmt.Errorf("%w: unknown day %q", ErrInvalidSchedule, w.Days[j])
+			}
+			w.Days[j] = day
+		}
+	}
+	return nil
+}
+
+// IsActive reports whether capture is allowed at t.
+func (s MonitoringSchedule) IsActive(t time.Time) bool {
+	if !s.Enabled {
+		return true
+	}
+	if loc, err := s.location(); err == nil {
+		t = t.In(loc)
+	}
+	minute := t.Hour()*60 + t.Minute()
+	for _, w := range s.Windows {
+		if w.contains(t.Weekday(), minute) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s MonitoringSchedule) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// contains reports whether the window covers minute of day.
+func (w MonitoringWindow) contains(day time.Weekday, minute int) bool {
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return false
+	}
+	if start < end {
+		return w.onDay(day) && minute >= start && minute < end
+	}
+	// Overnight: the evening belongs to the start day, the morning to the next
+	if minute >= start {
+		return w.onDay(day)
+	}
+	return minute < end && w.onDay((day+6)%7)
+}
+
+func (w MonitoringWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if weekdayNames[strings.ToLower(name)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock returns the minutes since midnight of an HH:MM time.
+func parseClock(value string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil {
+		return 0, err
+	}
+	if hour < 0 || minute < 0 || minute > 59 || hour > 24 || (hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("out of range")
+	}
+	return hour*60 + minute, nil
+}
+
+// ParseMonitoringSchedule reads a schedule written as comma separated
+// windows, each an optional day or day range followed by a time range:
+// "mon-fri 08:00-20:00, sat 09:00-13:00". An empty spec disables it.
+func ParseMonitoringSchedule(spec string) (MonitoringSchedule, error) {
+	var schedule MonitoringSchedule
+	for _, part := range strings.Split(spec, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 2 {
+			return MonitoringSchedule{}, fmt.Errorf("%w: %q", ErrInvalidSchedule, strings.TrimSpace(part))
+		}
+
+		var window MonitoringWindow
+		if len(fields) == 2 {
+			days, err := parseDayRange(fields[0])
+			if err != nil {
+				return MonitoringSchedule{}, err
+			}
+			window.Days = days
+		}
+		start, end, ok := strings.Cut(fields[len(fields)-1], "-")
+		if !ok {
+			return MonitoringSchedule{}, fmt.Errorf("%w: bad time range %q", ErrInvalidSchedule, fields[len(fields)-1])
+		}
+		window.Start, window.End = start, end
+		schedule.Windows = append(schedule.Windows, window)
+	}
+	schedule.Enabled = len(schedule.Windows) > 0
+	return schedule, schedule.Validate()
+}
+
+// parseDayRange expands "mon-fri" (wrapping past Sunday) or a single day.
+func parseDayRange(value string) ([]string, error) {
+	from, to, isRange := strings.Cut(strings.ToLower(value), "-")
+	if !isRange {
+		to = from
+	}
+	first, ok := weekdayNames[from]
+	last, ok2 := weekdayNames[to]
+	if !ok || !ok2 {
+		return nil, fmt.Errorf("%w: unknown days %q", ErrInvalidSchedule, value)
+	}
+	var days []string
+	for day := first; ; day = (day + 1) % 7 {
+		days = append(days, strings.ToLower(day.String()[:3]))
+		if day == last {
+			return days, nil
+		}
+	}
+}
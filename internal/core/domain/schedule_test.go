@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMonitoringSchedule(t *testing.T) {
+	schedule, err := ParseMonitoringSchedule("mon-fri 08:00-20:00, sat 22:00-02:00")
+	require.NoError(t, err)
+	require.True(t, schedule.Enabled)
+	require.Len(t, schedule.Windows, 2)
+	assert.Equal(t, []string{"mon", "tue", "wed", "thu", "fri"}, schedule.Windows[0].Days)
+
+	disabled, err := ParseMonitoringSchedule("")
+	require.NoError(t, err)
+	assert.False(t, disabled.Enabled)
+
+	wrapped, err := ParseMonitoringSchedule("fri-mon 00:00-24:00")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fri", "sat", "sun", "mon"}, wrapped.Windows[0].Days)
+
+	for _, bad := range []string{"mon-fri 08:00", "funday 08:00-20:00", "08:00-08:00", "mon 25:00-26:00", "mon tue 08:00-09:00"} {
+		_, err := ParseMonitoringSchedule(bad)
+		assert.ErrorIs(t, err, ErrInvalidSchedule, bad)
+	}
+}
+
+func TestMonitoringSchedule_IsActive(t *testing.T) {
+	schedule, err := ParseMonitoringSchedule("mon-fri 08:00-20:00, sat 22:00-02:00")
+	require.NoError(t, err)
+	schedule.Timezone = "UTC"
+
+	at := func(day, clock string) time.Time {
+		ts, err := time.Parse("2006-01-02 15:04", day+" "+clock)
+		require.NoError(t, err)
+		return ts
+	}
+	// 2026-10-12 is a Monday
+	assert.True(t, schedule.IsActive(at("2026-10-12", "08:00")))
+	assert.True(t, schedule.IsActive(at("2026-10-16", "19:59")))
+	assert.False(t, schedule.IsActive(at("2026-10-16", "20:00")))
+	assert.False(t, schedule.IsActive(at("2026-10-13", "07:59")))
+
+	// Overnight window belongs to Saturday and runs into Sunday
+	assert.True(t, schedule.IsActive(at("2026-10-17", "23:30")))
+	assert.True(t, schedule.IsActive(at("2026-10-18", "01:59")))
+	assert.False(t, schedule.IsActive(at("2026-10-18", "02:00")))
+	assert.False(t, schedule.IsActive(at("2026-10-17", "01:00")))
+
+	// Disabled schedules never pause capture
+	assert.True(t, MonitoringSchedule{}.IsActive(at("2026-10-18", "12:00")))
+
+	invalid := MonitoringSchedule{Enabled: true, Timezone: "Mars/Olympus", Windows: schedule.Windows}
+	assert.ErrorIs(t, invalid.Validate(), ErrInvalidSchedule)
+	assert.ErrorIs(t, (&MonitoringSchedule{Enabled: true}).Validate(), ErrEmptySchedule)
+}
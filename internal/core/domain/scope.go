@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ErrOutOfScope is returned when an active operation targets a device outside
+// the engagement scope of the current workspace.
+var ErrOutOfScope = errors.New("target is outside the engagement scope")
+
+// EngagementScope lists the targets a workspace is authorized to attack.
+// An empty scope places no restriction.
+type EngagementScope struct {
+	BSSIDs      []string `json:"bssids"`       // Exact AP/station addresses
+	SSIDs       []string `json:"ssids"`        // Networks, matched against the target's SSID
+	MACPrefixes []string `json:"mac_prefixes"` // OUI or longer prefixes, e.g. "00:11:22"
+}
+
+// IsEmpty reports whether the scope places no restriction.
+func (s EngagementScope) IsEmpty() bool {
+	return len(s.BSSIDs) == 0 && len(s.SSIDs) == 0 && len(s.MACPrefixes) == 0
+}
+
+// Validate checks that every address and prefix is well formed.
+func (s EngagementScope) Validate() error {
+	for _, bssid := range s.BSSIDs {
+		if _, err := net.ParseMAC(bssid); err != nil {
+			return fmt.Errorf("invalid BSSID %q: %w", bssid, err)
+		}
+	}
+	for _, ssid := range s.SSIDs {
+		if ssid == "" || len(ssid) > 32 {
+			return fmt.Errorf("invalid SSID %q", ssid)
+		}
+	}
+	for _, prefix := range s.MACPrefixes {
+		hex := normalizeMAC(prefix)
+		if hex == "" || len(hex) > 12 || strings.Trim(hex, "0123456789ABCDEF") != "" {
+			return fmt.Errorf("invalid MAC prefix %q", prefix)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether a target with the given address and SSID (may be
+// empty if unknown) is in scope.
+func (s EngagementScope) Allows(mac, ssid string) bool {
+	if s.IsEmpty() {
+		return true
+	}
+
+	target := normalizeMAC(mac)
+	if target != "" {
+		for _, bssid := range s.BSSIDs {
+			if normalizeMAC(bssid) == target {
+				return true
+			}
+		}
+		for _, prefix := range s.MACPrefixes {
+			if p := normalizeMAC(prefix); p != "" && strings.HasPrefix(target, p) {
+				return true
+			}
+		}
+	}
+	if ssid != "" {
+		for _, allowed := range s.SSIDs {
+			if allowed == ssid {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeMAC strips separators and upper-cases an address or prefix.
+func normalizeMAC(mac string) string {
+	r := strings.NewReplacer(":", "", "-", "", ".", "")
+	return strings.ToUpper(r.Replace(strings.TrimSpace(mac)))
+}
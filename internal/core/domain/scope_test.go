@@ -0,0 +1,47 @@
+package domain
+
+import "testing"
+
+func TestEngagementScope_Allows(t *testing.T) {
+	scope := EngagementScope{
+		BSSIDs:      []string{"00:11:22:33:44:55"},
+		SSIDs:       []string{"CorpNet"},
+		MACPrefixes: []string{"AA-BB-CC"},
+	}
+
+	tests := []struct {
+		name string
+		mac  string
+		ssid string
+		want bool
+	}{
+		{"exact BSSID, dash separated", "00-11-22-33-44-55", "", true},
+		{"prefix", "aa:bb:cc:01:02:03", "", true},
+		{"SSID", "66:77:88:99:AA:BB", "CorpNet", true},
+		{"unknown target", "66:77:88:99:AA:BB", "Guest", false},
+		{"no address or SSID", "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scope.Allows(tt.mac, tt.ssid); got != tt.want {
+				t.Errorf("Allows(%q, %q) = %v, want %v", tt.mac, tt.ssid, got, tt.want)
+			}
+		})
+	}
+
+	if !(EngagementScope{}).Allows("66:77:88:99:AA:BB", "") {
+		t.Error("empty scope should allow every target")
+	}
+}
+
+func TestEngagementScope_Validate(t *testing.T) {
+	if err := (EngagementScope{BSSIDs: []string{"not-a-mac"}}).Validate(); err == nil {
+		t.Error("expected error for invalid BSSID")
+	}
+	if err := (EngagementScope{MACPrefixes: []string{"ZZ:11"}}).Validate(); err == nil {
+		t.Error("expected error for invalid prefix")
+	}
+	if err := (EngagementScope{BSSIDs: []string{"00:11:22:33:44:55"}, MACPrefixes: []string{"00:11:22"}}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
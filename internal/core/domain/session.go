@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// Session is a login, identified by a token the browser or API client
+// holds. Only a hash of the token is stored, so a leaked store cannot be
+// used to hijack sessions.
+type Session struct {
+	ID        string    `json:"id"`
+	TokenHash string    `json:"-" gorm:"uniqueIndex"`
+	UserID    string    `json:"user_id" gorm:"index"`
+	ClientIP  string    `json:"client_ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+	LastSeen  time.Time `json:"last_seen"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Current   bool      `json:"current,omitempty" gorm:"-"` // Set in listings for the session making the request
+}
+
+// Expired reports whether the session is no longer valid at now.
+func (s *Session) Expired(now time.Time) bool {
+	return now.After(s.ExpiresAt)
+}
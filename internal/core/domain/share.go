@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxShareLifetime bounds how long a share link stays valid.
+const MaxShareLifetime = 30 * 24 * time.Hour
+
+var (
+	ErrEmptyShareWorkspace = errors.New("share link must name a workspace")
+	ErrInvalidShareExpiry  = errors.New("share link must expire within 30 days")
+	ErrShareNotFound       = errors.New("share link not found")
+	ErrShareExpired        = errors.New("share link expired")
+)
+
+// ShareLink grants read-only access to the devices, alerts and report
+// summary of one workspace, so a customer contact can follow an assessment
+// without an account. Like API keys, only a hash of the token is stored; the
+// token is shown once, when the link is created.
+type ShareLink struct {
+	ID        string    `json:"id"`
+	Workspace string    `json:"workspace"`
+	Label     string    `json:"label,omitempty"` // Who the link was given to
+	Prefix    string    `json:"prefix"`          // Start of the token, to tell links apart in lists
+	Hash      string    `json:"-" gorm:"uniqueIndex"`
+	CreatedBy string    `json:"created_by"` // Username of the operator who shared
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	LastUsed  time.Time `json:"last_used"`
+}
+
+// Validate checks the link names a workspace and expires, within
+// MaxShareLifetime of its creation.
+func (l *ShareLink) Validate() error {
+	if l.Workspace == "" {
+		return ErrEmptyShareWorkspace
+	}
+	if !l.ExpiresAt.After(l.CreatedAt) || l.ExpiresAt.Sub(l.CreatedAt) > MaxShareLifetime {
+		return ErrInvalidShareExpiry
+	}
+	return nil
+}
+
+// Expired reports whether the link expired before now.
+func (l *ShareLink) Expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// ShareSummary is the report view of a shared workspace: how far the
+// assessment got, without the details reserved to operators.
+type ShareSummary struct {
+	Workspace         string                `json:"workspace"`
+	ExpiresAt         time.Time             `json:"expires_at"`
+	GeneratedAt       time.Time             `json:"generated_at"`
+	APCount           int                   `json:"ap_count"`
+	ClientCount       int                   `json:"client_count"`
+	SecurityBreakdown map[string]int        `json:"security_breakdown"`
+	AlertsBySeverity  map[AlertSeverity]int `json:"alerts_by_severity"`
+	Vulnerabilities   map[string]int        `json:"vulnerabilities"` // Findings by severity name
+}
+
+// NewShareSummary summarizes the devices and alerts of a shared workspace.
+func NewShareSummary(link ShareLink, devices []Device, alerts []Alert) ShareSummary {
+	summary := ShareSummary{
+		Workspace:         link.Workspace,
+		ExpiresAt:         link.ExpiresAt,
+		GeneratedAt:       time.Now(),
+		SecurityBreakdown: make(map[string]int),
+		AlertsBySeverity:  make(map[AlertSeverity]int),
+		Vulnerabilities:   make(map[string]int),
+	}
+	for _, d := range devices {
+		if d.IsAP() {
+			summary.APCount++
+			security := d.Security
+			if security == "" {
+				security = SecurityOpen
+			}
+			summary.SecurityBreakdown[security]++
+		} else {
+			summary.ClientCount++
+		}
+		for _, v := range d.Vulnerabilities {
+			summary.Vulnerabilities[v.Severity.String()]++
+		}
+	}
+	for _, a := range alerts {
+		summary.AlertsBySeverity[a.Severity]++
+	}
+	return summary
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareLink_Validate(t *testing.T) {
+	now := time.Now()
+	link := ShareLink{Workspace: "site-a", CreatedAt: now, ExpiresAt: now.Add(24 * time.Hour)}
+	assert.NoError(t, link.Validate())
+
+	link.Workspace = ""
+	assert.ErrorIs(t, link.Validate(), ErrEmptyShareWorkspace)
+
+	link.Workspace = "site-a"
+	link.ExpiresAt = now
+	assert.ErrorIs(t, link.Validate(), ErrInvalidShareExpiry)
+	link.ExpiresAt = now.Add(MaxShareLifetime + time.Hour)
+	assert.ErrorIs(t, link.Validate(), ErrInvalidShareExpiry)
+
+	link.ExpiresAt = now.Add(time.Hour)
+	assert.False(t, link.Expired(now))
+	assert.True(t, link.Expired(now.Add(time.Hour)))
+}
+
+func TestNewShareSummary(t *testing.T) {
+	link := ShareLink{Workspace: "site-a", ExpiresAt: time.Now().Add(time.Hour)}
+	devices := []Device{
+		{MAC: "00:11:22:33:44:01", Type: DeviceTypeAP, Security: "WPA2",
+			Vulnerabilities: []VulnerabilityTag{{Name: "WPS-PIXIE", Severity: VulnSeverityHigh}}},
+		{MAC: "00:11:22:33:44:02", Type: DeviceTypeAP},
+		{MAC: "00:11:22:33:44:03", Type: DeviceTypeStation},
+	}
+	alerts := []Alert{{Severity: SeverityHigh}, {Severity: SeverityHigh}, {Severity: SeverityLow}}
+
+	summary := NewShareSummary(link, devices, alerts)
+	assert.Equal(t, "site-a", summary.Workspace)
+	assert.Equal(t, 2, summary.APCount)
+	assert.Equal(t, 1, summary.ClientCount)
+	assert.Equal(t, map[string]int{"WPA2": 1, SecurityOpen: 1}, summary.SecurityBreakdown)
+	assert.Equal(t, map[AlertSeverity]int{SeverityHigh: 2, SeverityLow: 1}, summary.AlertsBySeverity)
+	assert.Equal(t, map[string]int{"HIGH": 1}, summary.Vulnerabilities)
+}
@@ -28,6 +28,7 @@ const (
 	SourceIEPattern MatchSource = "IE_Pattern"
 	SourceVendorIE  MatchSource = "Vendor_IE"
 	SourceOUI       MatchSource = "OUI"
+	SourceLearned   MatchSource = "Learned" // Recorded from a device labeled by an analyst
 )
 
 // --- Domain Entities ---
@@ -62,8 +63,30 @@ type SignatureMatch struct {
 var (
 	ErrInvalidConfidence = errors.New("confidence must be between 0.0 and 1.0")
 	ErrEmptySignatureID  = errors.New("signature ID cannot be empty")
+	ErrEmptyLabelModel   = errors.New("device label model cannot be empty")
+	ErrNoSignatureData   = errors.New("device has no IE signature to learn from")
+	ErrDeviceNotFound    = errors.New("device not found")
 )
 
+// DeviceLabel is the true make and model of a device, as identified by an analyst.
+type DeviceLabel struct {
+	Vendor     string         `json:"vendor"`
+	Model      string         `json:"model"`
+	OS         string         `json:"os,omitempty"`
+	DeviceType DeviceCategory `json:"device_type,omitempty"`
+}
+
+// Validate checks that the label names a model.
+func (l *DeviceLabel) Validate() error {
+	l.Vendor = strings.TrimSpace(l.Vendor)
+	l.Model = strings.TrimSpace(l.Model)
+	l.OS = strings.TrimSpace(l.OS)
+	if l.Model == "" {
+		return ErrEmptyLabelModel
+	}
+	return nil
+}
+
 // Validate checks if the signature data is consistent and valid.
 func (s *DeviceSignature) Validate() error {
 	if s.ID == "" {
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// homoglyphs maps characters commonly used to imitate an SSID to the letter
+// they resemble: leetspeak digits and symbols, and Cyrillic/Greek lookalikes.
+var homoglyphs = map[rune]rune{
+	'0': 'o', '1': 'l', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l',
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'х': 'x', 'у': 'y', 'і': 'i', 'ј': 'j', 'ѕ': 's',
+	'α': 'a', 'ο': 'o', 'ν': 'v', 'ι': 'i', 'κ': 'k', 'τ': 't',
+}
+
+// NormalizeSSID folds case, surrounding whitespace and homoglyphs so that
+// lookalike SSIDs compare equal.
+func NormalizeSSID(ssid string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(ssid)) {
+		if mapped, ok := homoglyphs[r]; ok {
+			r = mapped
+		}
+		// Treat 'l' and 'i' as one glyph, as in "PayPaI"
+		if r == 'i' {
+			r = 'l'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SSIDSimilarity scores how alike two SSIDs look, from 0 (unrelated) to 1
+// (indistinguishable once homoglyphs are folded), using the Levenshtein
+// distance of the normalized names.
+func SSIDSimilarity(a, b string) float64 {
+	na, nb := NormalizeSSID(a), NormalizeSSID(b)
+	longest := max(utf8.RuneCountInString(na), utf8.RuneCountInString(nb))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(na, nb))/float64(longest)
+}
+
+// levenshtein returns the edit distance between two strings, in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
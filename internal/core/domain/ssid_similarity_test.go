@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestSSIDSimilarity(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		atLeast float64
+		below   float64
+	}{
+		{"digit for letter", "C0rpWiFi", "CorpWiFi", 1, 2},
+		{"l for i", "CorpWlFi", "CorpWiFi", 1, 2},
+		{"cyrillic o", "Cоrp", "Corp", 1, 2},
+		{"case and whitespace", "corpwifi ", "CorpWiFi", 1, 2},
+		{"one extra letter", "CorpWiFii", "CorpWiFi", 0.85, 1},
+		{"unrelated", "Starbucks", "CorpWiFi", 0, 0.3},
+		{"empty", "", "", 0, 0.01},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SSIDSimilarity(tt.a, tt.b)
+			if got < tt.atLeast || got >= tt.below {
+				t.Errorf("SSIDSimilarity(%q, %q) = %.2f, want [%.2f, %.2f)", tt.a, tt.b, got, tt.atLeast, tt.below)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sources of user accounts. Local accounts have a password stored by WMAP;
+// the others are provisioned at their first login through an identity
+// provider, which keeps the credentials and decides the role.
+const (
+	AuthSourceLocal = ""
+	AuthSourceOIDC  = "oidc"
+	AuthSourceLDAP  = "ldap"
+)
+
+var (
+	// ErrNoMappedGroup is returned for external users in none of the groups
+	// mapped to a role.
+	ErrNoMappedGroup = errors.New("no group of the user is mapped to a role")
+	// ErrExternalAccount is returned for operations on the credentials of
+	// accounts managed by an identity provider.
+	ErrExternalAccount = errors.New("account managed by an identity provider")
+)
+
+// ExternalIdentity is a user authenticated by an identity provider, with the
+// groups the provider reports for them.
+type ExternalIdentity struct {
+	Source   string
+	Username string
+	Groups   []string
+}
+
+// GroupRoles maps identity provider groups, compared case insensitively, to
+// WMAP roles.
+type GroupRoles map[string]Role
+
+// ParseGroupRoles parses a comma separated list of group=role pairs, e.g.
+// "wmap-admins=admin,wmap-ops=operator". Groups are names, such as the CN of
+// LDAP groups, not DNs.
+func ParseGroupRoles(s string) (GroupRoles, error) {
+	roles := make(GroupRoles)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		group, role, ok := strings.Cut(pair, "=")
+		group = strings.TrimSpace(group)
+		if !ok || group == "" {
+			return nil, fmt.Errorf("invalid group mapping %q, want group=role", pair)
+		}
+		r := Role(strings.TrimSpace(role))
+		if !r.IsValid() {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRole, r)
+		}
+		roles[strings.ToLower(group)] = r
+	}
+	return roles, nil
+}
+
+// Role returns the most privileged role mapped to one of groups.
+func (g GroupRoles) Role(groups []string) (Role, error) {
+	var best Role
+	for _, group := range groups {
+		role, ok := g[strings.ToLower(group)]
+		if ok && (best == "" || role.Allows(best)) {
+			best = role
+		}
+	}
+	if best == "" {
+		return "", ErrNoMappedGroup
+	}
+	return best, nil
+}
+
+// External reports whether the account is managed by an identity provider.
+func (u *User) External() bool {
+	return u.Source != AuthSourceLocal
+}
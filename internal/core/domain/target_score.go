@@ -0,0 +1,54 @@
+package domain
+
+import "fmt"
+
+// Target score weights. The score estimates how likely an AP is to yield
+// something: a WPS PIN, or a crackable handshake that needs clients to
+// deauthenticate and frames that are not protected.
+const (
+	scoreWPS        = 30
+	scorePSK        = 25
+	scoreNoPMF      = 15
+	scorePerClient  = 5
+	scoreMaxClients = 20
+	scoreMaxSignal  = 10 // At -30 dBm or better, none at -90 dBm
+)
+
+// TargetScore ranks an access point by attack value.
+type TargetScore struct {
+	BSSID   string   `json:"bssid"`
+	SSID    string   `json:"ssid,omitempty"`
+	Channel int      `json:"channel"`
+	RSSI    int      `json:"rssi"`
+	Clients int      `json:"clients"` // Active associated clients
+	Score   int      `json:"score"`   // 0-100
+	Reasons []string `json:"reasons"` // What the score is made of
+}
+
+// ScoreTarget scores ap with its count of active clients.
+func ScoreTarget(ap Device, clients int) TargetScore {
+	t := TargetScore{BSSID: ap.MAC, SSID: ap.SSID, Channel: ap.Channel, RSSI: ap.RSSI, Clients: clients, Reasons: []string{}}
+	add := func(points int, reason string) {
+		t.Score += points
+		t.Reasons = append(t.Reasons, reason)
+	}
+
+	if ap.WPSDetails != nil && !ap.WPSDetails.Locked || ap.WPSDetails == nil && ap.WPSInfo != "" {
+		add(scoreWPS, "WPS enabled")
+	}
+	if ap.UsesPSK() {
+		add(scorePSK, "WPA-PSK")
+	}
+	if ap.RSNInfo != nil && !ap.RSNInfo.Capabilities.MFPRequired {
+		add(scoreNoPMF, "no PMF")
+	}
+	if clients > 0 {
+		add(min(clients*scorePerClient, scoreMaxClients), fmt.Sprintf("%d active clients", clients))
+	}
+	if ap.RSSI < 0 {
+		if points := min(max((ap.RSSI+90)*scoreMaxSignal/60, 0), scoreMaxSignal); points > 0 {
+			add(points, fmt.Sprintf("signal %d dBm", ap.RSSI))
+		}
+	}
+	return t
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreTarget(t *testing.T) {
+	psk := &RSNInfo{AKMSuites: []string{"PSK"}}
+	pmf := &RSNInfo{AKMSuites: []string{"SAE"}, Capabilities: RSNCapabilities{MFPRequired: true}}
+
+	tests := []struct {
+		name    string
+		ap      Device
+		clients int
+		want    int
+	}{
+		{"open and far", Device{RSSI: -95}, 0, 0},
+		{"psk without pmf", Device{RSSI: -90, RSNInfo: psk}, 0, scorePSK + scoreNoPMF},
+		{"wps", Device{RSSI: -90, WPSInfo: "Configured"}, 0, scoreWPS},
+		{"locked wps", Device{RSSI: -90, WPSDetails: &WPSDetails{Locked: true}}, 0, 0},
+		{"wpa3 with pmf", Device{RSSI: -90, RSNInfo: pmf}, 0, 0},
+		{"clients capped", Device{RSSI: -90}, 10, scoreMaxClients},
+		{"every factor", Device{RSSI: -20, RSNInfo: psk, WPSInfo: "Configured"}, 4, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ScoreTarget(tt.ap, tt.clients).Score)
+		})
+	}
+}
+
+func TestScoreTarget_Reasons(t *testing.T) {
+	ap := Device{MAC: "aa:bb:cc:00:00:01", RSSI: -60, RSNInfo: &RSNInfo{AKMSuites: []string{"FT-PSK"}}}
+	score := ScoreTarget(ap, 2)
+
+	assert.Equal(t, "aa:bb:cc:00:00:01", score.BSSID)
+	assert.Equal(t, []string{"WPA-PSK", "no PMF", "2 active clients", "signal -60 dBm"}, score.Reasons)
+	assert.Equal(t, scorePSK+scoreNoPMF+2*scorePerClient+5, score.Score)
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Issue trackers findings can be filed in.
+const (
+	TrackerJira   = "jira"
+	TrackerGitLab = "gitlab"
+)
+
+// Kinds of findings filed as issues.
+const (
+	FindingAlert         = "alert"
+	FindingVulnerability = "vulnerability"
+)
+
+// TicketLabelPrefix starts the label carrying a finding's fingerprint, which
+// is how trackers are searched for the issue already filed for it.
+const TicketLabelPrefix = "wmap-"
+
+// IssueField is a labeled detail of the finding, such as the device's vendor.
+type IssueField struct {
+	Name  string
+	Value string
+}
+
+// IssueDraft is the tracker-neutral content of an issue. Trackers render it
+// in their own markup.
+type IssueDraft struct {
+	Title       string
+	Summary     string
+	Severity    string // critical, high, medium, low or info
+	Fields      []IssueField
+	Evidence    []string
+	Remediation Recommendation
+	Fingerprint string
+	Labels      []string
+}
+
+// Ticket is an issue filed for a finding.
+type Ticket struct {
+	Tracker     string    `json:"tracker"`
+	Key         string    `json:"key"` // e.g. "SEC-42" or "#42"
+	URL         string    `json:"url,omitempty"`
+	Fingerprint string    `json:"fingerprint"`
+	Title       string    `json:"title,omitempty"`
+	Finding     string    `json:"finding,omitempty"` // alert or vulnerability
+	DeviceMAC   string    `json:"device_mac,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	Existing    bool      `json:"existing,omitempty"` // Filed before, found in the tracker
+}
+
+// TicketStatus lists the issues filed and the last filing error.
+type TicketStatus struct {
+	Tracker     string        `json:"tracker"`
+	MinSeverity AlertSeverity `json:"min_severity"`
+	Tickets     []Ticket      `json:"tickets"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// FindingFingerprint identifies a finding across restarts so it is filed
+// once: the kind, the device (empty for alerts, filed per type) and what
+// was found on it.
+func FindingFingerprint(kind, mac, name string) string {
+	raw := kind + "|" + strings.ToLower(mac) + "|" + strings.ToUpper(name)
+	hash := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(hash[:8])
+}
+
+// TicketLabel returns the tracker label carrying a fingerprint.
+func TicketLabel(fingerprint string) string {
+	return TicketLabelPrefix + fingerprint
+}
+
+// VulnerabilityAlertSeverity maps a vulnerability score to the alert scale.
+func VulnerabilityAlertSeverity(s Severity) AlertSeverity {
+	return AlertSeverity(strings.ToLower(s.String()))
+}
+
+// ParseAlertSeverity validates a severity level given by an operator.
+func ParseAlertSeverity(s string) (AlertSeverity, error) {
+	severity := AlertSeverity(strings.ToLower(strings.TrimSpace(s)))
+	if !isValidSeverity(severity) {
+		return "", ErrInvalidSeverity
+	}
+	return severity, nil
+}
+
+// SeverityAtLeast reports whether s is min or more severe.
+func SeverityAtLeast(s, min AlertSeverity) bool {
+	return severityRank(s) >= severityRank(min)
+}
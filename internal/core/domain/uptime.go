@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// APUptime is the uptime of an AP estimated from the TSF timer of its
+// beacons, which counts microseconds from power on and restarts on reboot.
+type APUptime struct {
+	TSF           uint64    `json:"tsf"`            // Timer of the last beacon, in microseconds
+	UptimeSeconds int64     `json:"uptime_seconds"` // At the last beacon
+	BootedAt      time.Time `json:"booted_at"`      // Estimated power on time
+	// Reboots are the timer resets heard, most recent last
+	Reboots []time.Time `json:"reboots,omitempty"`
+}
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -18,8 +20,37 @@ var (
 	ErrInvalidRole     = errors.New("invalid user role")
 	ErrEmptyUsername   = errors.New("username cannot be empty")
 	ErrInvalidPassword = errors.New("password does not meet security requirements")
+	ErrLoginLocked     = errors.New("too many failed logins")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrUserExists      = errors.New("username already taken")
+	ErrLastAdmin       = errors.New("at least one enabled admin is required")
+	// ErrPasswordChange is returned for requests of users who must change
+	// their password before doing anything else.
+	ErrPasswordChange = errors.New("password change required")
+	// ErrTOTPRequired is returned by logins of users with two-factor
+	// authentication that carry no code.
+	ErrTOTPRequired = errors.New("two-factor code required")
+	// ErrTOTPEnrollment is returned for requests of admins who must enroll
+	// in two-factor authentication before doing anything else.
+	ErrTOTPEnrollment  = errors.New("two-factor enrollment required")
+	ErrInvalidTOTP     = errors.New("invalid two-factor code")
+	ErrTOTPNotEnrolled = errors.New("two-factor authentication not enrolled")
 )
 
+// LoginLockedError is returned while logins are refused for an account or a
+// client after repeated failures.
+type LoginLockedError struct {
+	Until time.Time
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%v, try again after %s", ErrLoginLocked, e.Until.Format(time.RFC3339))
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrLoginLocked
+}
+
 // IsValid checks if the role is a recognized system role.
 func (r Role) IsValid() bool {
 	switch r {
@@ -29,15 +60,46 @@ func (r Role) IsValid() bool {
 	return false
 }
 
+// Allows reports whether the role grants the permissions of required, in the
+// hierarchy Admin > Operator > Viewer.
+func (r Role) Allows(required Role) bool {
+	switch r {
+	case RoleAdmin:
+		return true
+	case RoleOperator:
+		return required != RoleAdmin
+	case RoleViewer:
+		return required == RoleViewer
+	}
+	return false
+}
+
 // User represents an authenticated user in the system.
 // This is a pure domain entity, decoupled from infrastructure (DB tags).
 type User struct {
-	ID           string    `json:"id"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"-"` // Never expose hash in JSON
-	Role         Role      `json:"role"`
-	CreatedAt    time.Time `json:"created_at"`
-	LastLogin    time.Time `json:"last_login"`
+	ID                 string       `json:"id"`
+	Username           string       `json:"username"`
+	PasswordHash       string       `json:"-"` // Never expose hash in JSON
+	Role               Role         `json:"role"`
+	Source             string       `json:"source,omitempty"`                             // AuthSource* constant, empty for local accounts
+	Permissions        []Permission `json:"permissions,omitempty" gorm:"serializer:json"` // Narrow the role when set
+	Disabled           bool         `json:"disabled"`
+	MustChangePassword bool         `json:"must_change_password"` // Set for default and reset credentials
+	PasswordChangedAt  time.Time    `json:"password_changed_at"`
+	TOTPEnabled        bool         `json:"totp_enabled"`
+	TOTPSecret         string       `json:"-"` // Sealed when encryption at rest is available; set but not enabled while enrolling
+	TOTPLastStep       int64        `json:"-"` // Last time step used, so codes cannot be replayed
+	RecoveryCodes      string       `json:"-"` // Comma separated hashes of the unused recovery codes
+	CreatedAt          time.Time    `json:"created_at"`
+	LastLogin          time.Time    `json:"last_login"`
+}
+
+// UserUpdate holds the account settings an admin changes; nil leaves a
+// setting unchanged. An empty permission list lifts the restrictions.
+type UserUpdate struct {
+	Role        *Role
+	Disabled    *bool
+	Permissions *[]Permission
 }
 
 // NewUser creates a new validated user instance.
@@ -75,7 +137,63 @@ func (u *User) Validate() error {
 	if !u.Role.IsValid() {
 		return ErrInvalidRole
 	}
-	return nil
+	return ValidatePermissions(u.Permissions)
+}
+
+type userContextKey struct{}
+
+// ContextWithUser returns a context carrying the authenticated user, so the
+// core services can attribute the actions taken on their behalf.
+func ContextWithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the user set by ContextWithUser.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(*User)
+	return user, ok && user != nil
+}
+
+type clientIPContextKey struct{}
+
+// ContextWithClientIP returns a context carrying the address of the client a
+// request comes from, for rate limiting and auditing.
+func ContextWithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey{}, ip)
+}
+
+// ClientIPFromContext returns the address set by ContextWithClientIP.
+func ClientIPFromContext(ctx context.Context) (string, bool) {
+	ip, ok := ctx.Value(clientIPContextKey{}).(string)
+	return ip, ok && ip != ""
+}
+
+type userAgentContextKey struct{}
+
+// ContextWithUserAgent returns a context carrying the User-Agent of the
+// client a request comes from, to tell its sessions apart.
+func ContextWithUserAgent(ctx context.Context, agent string) context.Context {
+	return context.WithValue(ctx, userAgentContextKey{}, agent)
+}
+
+// UserAgentFromContext returns the User-Agent set by ContextWithUserAgent.
+func UserAgentFromContext(ctx context.Context) string {
+	agent, _ := ctx.Value(userAgentContextKey{}).(string)
+	return agent
+}
+
+type sessionContextKey struct{}
+
+// ContextWithSessionToken returns a context carrying the token of the
+// session a request is authenticated by, so listings can tell it apart.
+func ContextWithSessionToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, token)
+}
+
+// SessionTokenFromContext returns the token set by ContextWithSessionToken.
+func SessionTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(sessionContextKey{}).(string)
+	return token
 }
 
 // --- DTOs / Request Objects ---
@@ -86,4 +204,5 @@ func (u *User) Validate() error {
 type Credentials struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	OTP      string `json:"otp,omitempty"` // TOTP or recovery code, for users with two-factor authentication
 }
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -66,18 +67,20 @@ type VulnerabilityTag struct {
 
 // VulnerabilityRecord represents a persistent vulnerability entry for a device
 type VulnerabilityRecord struct {
-	ID              string              `json:"id"`
-	DeviceMAC       string              `json:"device_mac"`
-	Name            string              `json:"name"`
-	Severity        Severity            `json:"severity"`
-	Confidence      Confidence          `json:"confidence"`
-	Description     string              `json:"description"`
-	Evidence        []string            `json:"evidence"`
-	Status          VulnerabilityStatus `json:"status"`
-	StatusChangedAt time.Time           `json:"status_changed_at"`
-	FirstSeen       time.Time           `json:"first_seen"`
-	LastSeen        time.Time           `json:"last_seen"`
-	Notes           string              `json:"notes"`
+	ID              string                    `json:"id"`
+	DeviceMAC       string                    `json:"device_mac"`
+	Name            string                    `json:"name"`
+	Severity        Severity                  `json:"severity"`
+	Confidence      Confidence                `json:"confidence"`
+	Description     string                    `json:"description"`
+	Evidence        []string                  `json:"evidence"`
+	Status          VulnerabilityStatus       `json:"status"`
+	StatusChangedAt time.Time                 `json:"status_changed_at"`
+	FirstSeen       time.Time                 `json:"first_seen"`
+	LastSeen        time.Time                 `json:"last_seen"`
+	Notes           string                    `json:"notes"`
+	Suppression     *VulnerabilitySuppression `json:"suppression,omitempty"`
+	Workspace       string                    `json:"workspace,omitempty"` // Open workspace the sensor was routed to, empty for the active one
 }
 
 // NewVulnerabilityRecord initializes a record from a detection tag
@@ -148,6 +151,8 @@ type VulnerabilityFilter struct {
 	Status      *VulnerabilityStatus
 	MinSeverity int
 	DeviceMAC   string
+	Suppressed  *bool  // Only the suppressed findings when true, only the others when false
+	Workspace   string // Only the findings routed to this open workspace
 }
 
 // ConfirmWithEvidence updates the vulnerability record with confirmation details
@@ -157,8 +162,7 @@ func (v *VulnerabilityRecord) ConfirmWithEvidence(confirmation VulnerabilityConf
 
 	// Add confirmation evidence
 	for key, value := range confirmation.Evidence {
-		evidenceStr := fmt.Sprintf("%s: %s (confirmed by %s)", key, value, confirmation.ConfirmedBy)
-		v.Evidence = append(v.Evidence, evidenceStr)
+		v.Evidence = append(v.Evidence, FormatConfirmationEvidence(key, value, confirmation.ConfirmedBy))
 	}
 
 	// Update notes with confirmation details
@@ -167,3 +171,39 @@ func (v *VulnerabilityRecord) ConfirmWithEvidence(confirmation VulnerabilityConf
 	}
 	v.Notes += fmt.Sprintf("Confirmed via %s at %s", confirmation.ConfirmedBy, confirmation.ConfirmedAt.Format(time.RFC3339))
 }
+
+// SealedEvidencePrefix marks an evidence value that is encrypted at rest.
+const SealedEvidencePrefix = "sealed:"
+
+// SealedFileSuffix is appended to the name of files encrypted at rest.
+const SealedFileSuffix = ".enc"
+
+// credentialEvidenceKeys are the confirmation evidence keys holding credentials.
+var credentialEvidenceKeys = map[string]bool{
+	"pin":        true,
+	"psk":        true,
+	"password":   true,
+	"passphrase": true,
+}
+
+// IsCredentialEvidence reports whether a confirmation evidence key holds a credential.
+func IsCredentialEvidence(key string) bool {
+	return credentialEvidenceKeys[strings.ToLower(key)]
+}
+
+// FormatConfirmationEvidence renders a confirmation evidence entry.
+func FormatConfirmationEvidence(key, value string, source ConfirmationSource) string {
+	return fmt.Sprintf("%s: %s (confirmed by %s)", key, value, source)
+}
+
+// ParseConfirmationEvidence splits an entry written by FormatConfirmationEvidence.
+func ParseConfirmationEvidence(entry string) (key, value string, source ConfirmationSource, ok bool) {
+	const marker = " (confirmed by "
+	key, rest, found := strings.Cut(entry, ": ")
+	end := strings.LastIndex(rest, marker)
+	if !found || end < 0 || !strings.HasSuffix(rest, ")") {
+		return "", "", "", false
+	}
+	source = ConfirmationSource(rest[end+len(marker) : len(rest)-1])
+	return key, rest[:end], source, true
+}
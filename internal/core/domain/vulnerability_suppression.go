@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaxSuppressionJustification bounds the justification of a suppression.
+const MaxSuppressionJustification = 1000
+
+var (
+	ErrEmptyJustification    = errors.New("suppression requires a justification")
+	ErrInvalidJustification  = errors.New("suppression justification too long")
+	ErrInvalidSuppressExpiry = errors.New("suppression must expire in the future")
+	ErrVulnerabilityNotFound = errors.New("vulnerability not found")
+)
+
+// VulnerabilitySuppression records a finding accepted as a known risk or
+// false positive, such as an intentionally open guest network. Suppressed
+// findings stay stored and queryable but are left out of reports.
+type VulnerabilitySuppression struct {
+	Justification string     `json:"justification"`
+	SuppressedBy  string     `json:"suppressed_by"`
+	SuppressedAt  time.Time  `json:"suppressed_at"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"` // Reviewed again after, nil for never
+}
+
+// Validate trims the justification and checks it and the expiry.
+func (s *VulnerabilitySuppression) Validate(now time.Time) error {
+	s.Justification = strings.TrimSpace(s.Justification)
+	if s.Justification == "" {
+		return ErrEmptyJustification
+	}
+	if len(s.Justification) > MaxSuppressionJustification {
+		return ErrInvalidJustification
+	}
+	if s.ExpiresAt != nil && !s.ExpiresAt.After(now) {
+		return ErrInvalidSuppressExpiry
+	}
+	return nil
+}
+
+// ActiveAt reports whether the suppression is in effect at t.
+func (s *VulnerabilitySuppression) ActiveAt(t time.Time) bool {
+	return s != nil && (s.ExpiresAt == nil || s.ExpiresAt.After(t))
+}
+
+// IsSuppressed reports whether the finding is suppressed now. Expired
+// suppressions no longer hide it.
+func (v *VulnerabilityRecord) IsSuppressed() bool {
+	return v.Suppression.ActiveAt(time.Now())
+}
@@ -0,0 +1,39 @@
+package domain
+
+// Unique IVs after which a WEP key is likely recovered by the PTW attack:
+// about half of 104-bit keys fall at 40000, nearly all at 85000. 40-bit
+// keys need about half as many.
+const (
+	WEPIVsPossible = 20000
+	WEPIVsLikely   = 40000
+	WEPIVsCertain  = 85000
+)
+
+// WEP crackability estimates.
+const (
+	WEPCrackInsufficient = "insufficient"
+	WEPCrackPossible     = "possible" // 40-bit keys
+	WEPCrackLikely       = "likely"
+	WEPCrackCertain      = "near-certain"
+)
+
+// WEPIVStats counts the initialization vectors of the frames a WEP AP and
+// its clients were heard encrypting, which tell how soon the key falls.
+type WEPIVStats struct {
+	Frames    int `json:"frames"`     // Encrypted data frames
+	UniqueIVs int `json:"unique_ivs"` // Distinct 24-bit IVs among them, estimated from a sample
+	WeakIVs   int `json:"weak_ivs"`   // FMS weak IVs, of the form (B+3, 0xff, x)
+}
+
+// Crackability estimates the chance of recovering the key from the IVs seen.
+func (s WEPIVStats) Crackability() string {
+	switch {
+	case s.UniqueIVs >= WEPIVsCertain:
+		return WEPCrackCertain
+	case s.UniqueIVs >= WEPIVsLikely:
+		return WEPCrackLikely
+	case s.UniqueIVs >= WEPIVsPossible:
+		return WEPCrackPossible
+	}
+	return WEPCrackInsufficient
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// AgentIDMetadata is the gRPC metadata key agents send their ID under when
+// streaming device reports.
+const AgentIDMetadata = "x-wmap-agent"
+
+// Domain Errors for Workspace Routing
+var (
+	ErrEmptyRouteWorkspace = errors.New("workspace route requires a workspace")
+	ErrEmptyRouteSensor    = errors.New("workspace route requires an agent or interface")
+)
+
+// WorkspaceRoute sends the devices heard by a sensor to an open workspace,
+// so that several workspaces are fed at once, e.g. one per site.
+type WorkspaceRoute struct {
+	Agent     string `json:"agent,omitempty"`     // Agent ID; empty for the local interfaces
+	Interface string `json:"interface,omitempty"` // Capture interface; empty for all of the agent
+	Workspace string `json:"workspace"`
+}
+
+// Validate checks the route names a sensor and a workspace.
+func (r WorkspaceRoute) Validate() error {
+	if strings.TrimSpace(r.Workspace) == "" {
+		return ErrEmptyRouteWorkspace
+	}
+	if r.Agent == "" && r.Interface == "" {
+		return ErrEmptyRouteSensor
+	}
+	return nil
+}
+
+// Matches reports whether a device heard by sensor follows the route.
+func (r WorkspaceRoute) Matches(sensor string) bool {
+	agent, iface := SplitSensor(sensor)
+	if agent != r.Agent {
+		return false
+	}
+	return r.Interface == "" || r.Interface == iface
+}
+
+// RouteWorkspace returns the workspace of the first route matching sensor,
+// empty if none does.
+func RouteWorkspace(routes []WorkspaceRoute, sensor string) string {
+	if sensor == "" {
+		return ""
+	}
+	for _, r := range routes {
+		if r.Matches(sensor) {
+			return r.Workspace
+		}
+	}
+	return ""
+}
+
+// SplitSensor splits a sensor, an interface or "agent/interface" for remote
+// sensors, into its agent, empty if local, and interface.
+func SplitSensor(sensor string) (agent, iface string) {
+	if i := strings.Index(sensor, "/"); i >= 0 {
+		return sensor[:i], sensor[i+1:]
+	}
+	return "", sensor
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceRoute_Validate(t *testing.T) {
+	assert.NoError(t, WorkspaceRoute{Interface: "wlan1", Workspace: "lab"}.Validate())
+	assert.ErrorIs(t, WorkspaceRoute{Agent: "site-a"}.Validate(), ErrEmptyRouteWorkspace)
+	assert.ErrorIs(t, WorkspaceRoute{Workspace: "lab"}.Validate(), ErrEmptyRouteSensor)
+}
+
+func TestRouteWorkspace(t *testing.T) {
+	routes := []WorkspaceRoute{
+		{Agent: "site-a", Interface: "wlan1", Workspace: "site-a-5ghz"},
+		{Agent: "site-a", Workspace: "site-a"},
+		{Interface: "wlan1", Workspace: "lab"},
+	}
+
+	assert.Equal(t, "site-a-5ghz", RouteWorkspace(routes, "site-a/wlan1"))
+	assert.Equal(t, "site-a", RouteWorkspace(routes, "site-a/wlan0"))
+	assert.Equal(t, "site-a", RouteWorkspace(routes, "site-a/"), "agents not naming the interface")
+	assert.Equal(t, "lab", RouteWorkspace(routes, "wlan1"))
+	assert.Empty(t, RouteWorkspace(routes, "wlan0"))
+	assert.Empty(t, RouteWorkspace(routes, "site-b/wlan1"))
+	assert.Empty(t, RouteWorkspace(routes, ""))
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ErrInvalidRetention is returned when a workspace retention is negative.
+var ErrInvalidRetention = errors.New("retention cannot be negative")
+
+// WorkspaceSettings is the configuration a workspace carries in its own
+// database, applied when it is the active one. Zero values fall back to the
+// global configuration.
+type WorkspaceSettings struct {
+	DeviceRetention time.Duration       `json:"device_retention"` // Devices unseen for longer leave the live view (0: global default)
+	Scope           EngagementScope     `json:"scope"`
+	AlertRules      []AlertRule         `json:"alert_rules"` // Evaluated along with the rules file
+	Baseline        BaselineConfig      `json:"baseline"`
+	Notifications   NotificationRouting `json:"notifications"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+// Validate performs internal consistency checks on every section.
+func (s *WorkspaceSettings) Validate() error {
+	if s.DeviceRetention < 0 {
+		return ErrInvalidRetention
+	}
+	if err := s.Scope.Validate(); err != nil {
+		return err
+	}
+	for i := range s.AlertRules {
+		if err := s.AlertRules[i].Validate(); err != nil {
+			return fmt.Errorf("rule %d (%s): %w", i, s.AlertRules[i].ID, err)
+		}
+	}
+	if err := s.Baseline.Validate(); err != nil {
+		return err
+	}
+	return s.Notifications.Validate()
+}
+
+// NotificationRouting decides which alerts of a workspace are pushed to the
+// live notifiers. Alerts it holds back are still stored.
+type NotificationRouting struct {
+	MinSeverity AlertSeverity `json:"min_severity,omitempty"` // Lower severities are not pushed; all when empty
+	Muted       []string      `json:"muted,omitempty"`        // Alert subtypes never pushed, e.g. "AP_REBOOTED"
+}
+
+// Validate checks the minimum severity is a known level.
+func (n NotificationRouting) Validate() error {
+	if n.MinSeverity != "" && !isValidSeverity(n.MinSeverity) {
+		return ErrInvalidSeverity
+	}
+	return nil
+}
+
+// Routes reports whether an alert is pushed to the live notifiers.
+func (n NotificationRouting) Routes(alert Alert) bool {
+	if alert.Subtype != "" && slices.Contains(n.Muted, alert.Subtype) {
+		return false
+	}
+	if n.MinSeverity == "" {
+		return true
+	}
+	return severityRank(alert.Severity) >= severityRank(n.MinSeverity)
+}
+
+// severityRank orders alert severities, unknown ones lowest.
+func severityRank(s AlertSeverity) int {
+	switch s {
+	case SeverityCritical:
+		return 4
+	case SeverityHigh:
+		return 3
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspaceSettings_Validate(t *testing.T) {
+	valid := WorkspaceSettings{
+		DeviceRetention: time.Hour,
+		Scope:           EngagementScope{BSSIDs: []string{"00:11:22:33:44:55"}},
+		AlertRules:      []AlertRule{{ID: "r1", Type: AlertSSID, Value: "Lab", Enabled: true}},
+		Notifications:   NotificationRouting{MinSeverity: SeverityMedium},
+	}
+	assert.NoError(t, valid.Validate())
+
+	s := valid
+	s.DeviceRetention = -time.Second
+	assert.ErrorIs(t, s.Validate(), ErrInvalidRetention)
+
+	s = valid
+	s.AlertRules = []AlertRule{{ID: "r2", Type: AlertSSID}}
+	assert.ErrorIs(t, s.Validate(), ErrEmptyRuleValue)
+
+	s = valid
+	s.Baseline = BaselineConfig{Enabled: true}
+	assert.ErrorIs(t, s.Validate(), ErrInvalidLearningPeriod)
+
+	s = valid
+	s.Notifications.MinSeverity = "urgent"
+	assert.ErrorIs(t, s.Validate(), ErrInvalidSeverity)
+}
+
+func TestNotificationRouting_Routes(t *testing.T) {
+	assert.True(t, NotificationRouting{}.Routes(Alert{Severity: SeverityInfo}))
+
+	routing := NotificationRouting{MinSeverity: SeverityHigh, Muted: []string{"AP_REBOOTED"}}
+	assert.True(t, routing.Routes(Alert{Severity: SeverityCritical, Subtype: "DEAUTH_FLOOD"}))
+	assert.True(t, routing.Routes(Alert{Severity: SeverityHigh}))
+	assert.False(t, routing.Routes(Alert{Severity: SeverityMedium}))
+	assert.False(t, routing.Routes(Alert{Severity: SeverityCritical, Subtype: "AP_REBOOTED"}))
+}
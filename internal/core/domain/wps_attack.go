@@ -10,6 +10,7 @@ type WPSStatus string
 
 const (
 	WPSStatusPending        WPSStatus = "pending"
+	WPSStatusQueued         WPSStatus = "queued" // Waiting for the channel lock
 	WPSStatusRunning        WPSStatus = "running"
 	WPSStatusAssociating    WPSStatus = "associating"
 	WPSStatusExchangingKeys WPSStatus = "exchanging_keys"
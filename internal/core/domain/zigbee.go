@@ -0,0 +1,94 @@
+package domain
+
+import "time"
+
+// ZigbeeFrameType is the frame type of an IEEE 802.15.4 MAC frame.
+type ZigbeeFrameType string
+
+const (
+	ZigbeeFrameBeacon  ZigbeeFrameType = "beacon"
+	ZigbeeFrameData    ZigbeeFrameType = "data"
+	ZigbeeFrameAck     ZigbeeFrameType = "ack"
+	ZigbeeFrameCommand ZigbeeFrameType = "command"
+	ZigbeeFrameOther   ZigbeeFrameType = "other"
+)
+
+// ZigbeeRole is the part a node plays in its network.
+type ZigbeeRole string
+
+const (
+	ZigbeeRoleUnknown     ZigbeeRole = "unknown"
+	ZigbeeRoleCoordinator ZigbeeRole = "coordinator"
+	ZigbeeRoleRouter      ZigbeeRole = "router"
+	ZigbeeRoleEndDevice   ZigbeeRole = "end_device"
+)
+
+// ZigbeeCoordinatorAddress is the short address of every Zigbee coordinator.
+const ZigbeeCoordinatorAddress = "0x0000"
+
+// ZigbeeBroadcastAddress is the short broadcast address.
+const ZigbeeBroadcastAddress = "0xffff"
+
+// ZigbeeFrame is an IEEE 802.15.4 frame captured by a sniffer dongle.
+// Short addresses are written as "0x1a2b", extended ones as colon
+// separated hex, most significant byte first.
+type ZigbeeFrame struct {
+	Channel   int
+	RSSI      int // dBm
+	LQI       int
+	Timestamp time.Time
+
+	Type        ZigbeeFrameType
+	Sequence    int
+	Encrypted   bool // MAC layer security
+	DataRequest bool // MAC data request command, polled by sleeping end devices
+	SrcPAN      uint16
+	DstPAN      uint16
+	SrcAddr     string
+	DstAddr     string
+	Beacon      *ZigbeeBeacon // Set on beacon frames
+}
+
+// ZigbeeBeacon is the superframe specification and Zigbee payload of a beacon.
+type ZigbeeBeacon struct {
+	PANCoordinator    bool
+	PermitJoining     bool
+	StackProfile      int    // 1 Zigbee, 2 Zigbee PRO; 0 if not a Zigbee beacon
+	ExtendedPANID     string // Empty if not a Zigbee beacon
+	RouterCapacity    bool
+	EndDeviceCapacity bool
+}
+
+// ZigbeeDevice is an 802.15.4 node seen by the sniffer.
+type ZigbeeDevice struct {
+	Address   string     `json:"address"`
+	PANID     uint16     `json:"pan_id"`
+	Role      ZigbeeRole `json:"role"`
+	Channel   int        `json:"channel"`
+	RSSI      int        `json:"rssi"`
+	LQI       int        `json:"lqi"`
+	Frames    int        `json:"frames"`
+	Encrypted bool       `json:"encrypted"` // Sent MAC secured frames
+	FirstSeen time.Time  `json:"first_seen"`
+	LastSeen  time.Time  `json:"last_seen"`
+}
+
+// ZigbeeNetwork is a personal area network seen by the sniffer.
+type ZigbeeNetwork struct {
+	PANID         uint16    `json:"pan_id"`
+	ExtendedPANID string    `json:"extended_pan_id,omitempty"`
+	Channel       int       `json:"channel"`
+	Coordinator   string    `json:"coordinator,omitempty"`
+	StackProfile  int       `json:"stack_profile,omitempty"`
+	PermitJoining bool      `json:"permit_joining"` // A beacon accepted new devices since the coordinator last refused them
+	Devices       int       `json:"devices"`
+	FirstSeen     time.Time `json:"first_seen"`
+	LastSeen      time.Time `json:"last_seen"`
+}
+
+// ZigbeeSurvey is what the 802.15.4 sniffer found, kept apart from the Wi-Fi
+// devices.
+type ZigbeeSurvey struct {
+	Networks []ZigbeeNetwork `json:"networks"`
+	Devices  []ZigbeeDevice  `json:"devices"`
+}
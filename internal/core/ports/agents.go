@@ -0,0 +1,55 @@
+package ports
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// AgentController commands connected agents to run attacks with their own
+// interfaces.
+type AgentController interface {
+	// ListAgents returns the agents with an open command channel.
+	ListAgents() []domain.AgentInfo
+
+	// StartAttack asks an agent to start an attack. config is the JSON
+	// configuration of the kind's engine.
+	StartAttack(ctx context.Context, agentID string, kind domain.AttackKind, config json.RawMessage) (domain.RemoteAttack, error)
+
+	// StopAttack asks the agent running an attack to stop it.
+	StopAttack(ctx context.Context, id string) error
+
+	// ListAttacks returns the remote attacks, most recent first.
+	ListAttacks() []domain.RemoteAttack
+
+	// PushConfig sends channel plans and capture options to an agent. The
+	// agent acknowledges the returned revision once applied.
+	PushConfig(ctx context.Context, agentID string, config domain.AgentConfig) (domain.AgentConfigStatus, error)
+
+	// Release returns the agent release advertised to agents accepting
+	// updates, nil if there is none.
+	Release() *domain.AgentUpdate
+}
+
+// AgentSessions is the server end of the agents' command channels.
+type AgentSessions interface {
+	// Connect registers an agent. Its commands are delivered on the returned
+	// channel, which is closed when the agent connects again; disconnect
+	// must be called once the channel is no longer read.
+	Connect(info domain.AgentInfo) (commands <-chan domain.AgentCommand, disconnect func())
+
+	// Update records the progress an agent reports for an attack.
+	Update(agentID string, update domain.RemoteAttackUpdate)
+
+	// ConfigApplied records the configuration an agent reports in effect.
+	ConfigApplied(agentID string, ack domain.AgentConfigAck)
+
+	// UpdateFailed records that an agent could not install a release.
+	UpdateFailed(agentID, version, reason string)
+}
+
+// ScopeGuard refuses attacks on targets outside the engagement scope.
+type ScopeGuard interface {
+	CheckScope(ctx context.Context, kind domain.AttackKind, target string) error
+}
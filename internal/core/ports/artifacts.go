@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// ArtifactManager keeps track of the files produced in a workspace (reports,
+// handshakes, pcaps) and hands out time-limited download links for them.
+type ArtifactManager interface {
+	// StoreArtifact writes data into the artifact store and registers it.
+	StoreArtifact(ctx context.Context, kind domain.ArtifactKind, name string, data []byte) (domain.Artifact, error)
+
+	// RegisterArtifact registers an existing file without taking ownership of it.
+	RegisterArtifact(ctx context.Context, kind domain.ArtifactKind, path string) (domain.Artifact, error)
+
+	// GetArtifact returns an artifact by ID.
+	GetArtifact(ctx context.Context, id string) (domain.Artifact, error)
+
+	// ListArtifacts returns artifacts, most recent first.
+	ListArtifacts(ctx context.Context, filter domain.ArtifactFilter) ([]domain.Artifact, error)
+
+	// DeleteArtifact unregisters an artifact, deleting its file if the store owns it.
+	DeleteArtifact(ctx context.Context, id string) error
+
+	// OpenArtifact opens the content of an artifact, decrypting sealed files.
+	OpenArtifact(ctx context.Context, artifact domain.Artifact) (io.ReadSeekCloser, error)
+
+	// CreateDownloadLink returns a tokenized URL valid for ttl.
+	CreateDownloadLink(ctx context.Context, id string, ttl time.Duration) (domain.ArtifactLink, error)
+
+	// ResolveDownload validates a download token and returns its artifact.
+	ResolveDownload(ctx context.Context, token string) (domain.Artifact, error)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 )
@@ -34,4 +35,152 @@ type UserRepository interface {
 
 	// List returns all registered users.
 	List(ctx context.Context) ([]domain.User, error)
+
+	// Delete removes a user by their internal UUID.
+	Delete(ctx context.Context, id string) error
+}
+
+// UserManager administers user accounts. Every method but ChangePassword is
+// meant for admins; ChangePassword acts on the user in the context.
+type UserManager interface {
+	ListUsers(ctx context.Context) ([]domain.User, error)
+	// CreateUser provisions a user, whose password must satisfy the policy.
+	CreateUser(ctx context.Context, user domain.User, password string) error
+	// UpdateUser changes the role and permissions of a user and enables or
+	// disables the account.
+	UpdateUser(ctx context.Context, id string, update domain.UserUpdate) (*domain.User, error)
+	DeleteUser(ctx context.Context, id string) error
+	// ResetPassword sets a temporary password, which the user must change at
+	// the next login, and ends the user's sessions.
+	ResetPassword(ctx context.Context, id, password string) error
+	// ChangePassword replaces the password of the user in the context.
+	ChangePassword(ctx context.Context, current, password string) error
+}
+
+// SessionRepository provides access to the sessions of logged in users.
+type SessionRepository interface {
+	SaveSession(ctx context.Context, session domain.Session) error
+	// GetSessionByHash retrieves the session whose token hashes to hash.
+	GetSessionByHash(ctx context.Context, hash string) (*domain.Session, error)
+	// ListSessions returns the sessions of a user, or every session when
+	// userID is empty.
+	ListSessions(ctx context.Context, userID string) ([]domain.Session, error)
+	DeleteSession(ctx context.Context, id string) error
+	// DeleteUserSessions removes the sessions of a user, returning how many.
+	DeleteUserSessions(ctx context.Context, userID string) (int, error)
+	// DeleteExpiredSessions removes the sessions expired at now.
+	DeleteExpiredSessions(ctx context.Context, now time.Time) error
+}
+
+// SessionManager lists and revokes sessions. Users manage their own
+// sessions; admins those of any user.
+type SessionManager interface {
+	// ListSessions returns the active sessions of a user, the one in the
+	// context when userID is empty.
+	ListSessions(ctx context.Context, userID string) ([]domain.Session, error)
+	RevokeSession(ctx context.Context, id string) error
+	// RevokeUserSessions ends every session of a user, the one in the
+	// context when userID is empty, and returns how many ended.
+	RevokeUserSessions(ctx context.Context, userID string) (int, error)
+}
+
+// APIKeyRepository provides access to stored API keys.
+type APIKeyRepository interface {
+	SaveAPIKey(ctx context.Context, key domain.APIKey) error
+	// GetAPIKeyByHash retrieves the key whose secret hashes to hash.
+	GetAPIKeyByHash(ctx context.Context, hash string) (*domain.APIKey, error)
+	// ListAPIKeys returns the keys of a user, or every key when userID is empty.
+	ListAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id string) error
+}
+
+// APIKeyValidator authenticates automation clients by API key.
+type APIKeyValidator interface {
+	// ValidateAPIKey returns the owner of the key, acting with the role of
+	// the key's scope.
+	ValidateAPIKey(ctx context.Context, key string) (*domain.User, error)
+}
+
+// APIKeyManager issues and revokes API keys on behalf of the user in the
+// context.
+type APIKeyManager interface {
+	// CreateAPIKey returns the new key and its secret, which is not stored
+	// and cannot be retrieved again.
+	CreateAPIKey(ctx context.Context, name string, scope domain.APIKeyScope, expiresAt *time.Time) (domain.APIKey, string, error)
+	// ListAPIKeys returns the user's keys, or every key for admins.
+	ListAPIKeys(ctx context.Context) ([]domain.APIKey, error)
+	// RevokeAPIKey deletes a key of the user, or any key for admins.
+	RevokeAPIKey(ctx context.Context, id string) error
+}
+
+// ShareLinkRepository provides access to stored workspace share links.
+type ShareLinkRepository interface {
+	SaveShareLink(ctx context.Context, link domain.ShareLink) error
+	// GetShareLinkByHash retrieves the link whose token hashes to hash.
+	GetShareLinkByHash(ctx context.Context, hash string) (*domain.ShareLink, error)
+	// ListShareLinks returns the links of a workspace, or every link when
+	// workspace is empty.
+	ListShareLinks(ctx context.Context, workspace string) ([]domain.ShareLink, error)
+	DeleteShareLink(ctx context.Context, id string) error
+}
+
+// ShareLinkManager issues read-only links to a workspace for people without
+// an account, and resolves their tokens.
+type ShareLinkManager interface {
+	// CreateShareLink returns the new link and its token, which is not
+	// stored and cannot be retrieved again.
+	CreateShareLink(ctx context.Context, workspace, label string, expiresAt time.Time) (domain.ShareLink, string, error)
+	ListShareLinks(ctx context.Context, workspace string) ([]domain.ShareLink, error)
+	RevokeShareLink(ctx context.Context, id string) error
+	// ResolveShareLink returns the link a token grants, failing once it
+	// expired or was revoked.
+	ResolveShareLink(ctx context.Context, token string) (*domain.ShareLink, error)
+}
+
+// TwoFactorManager enrolls the user in the context in TOTP two-factor
+// authentication.
+type TwoFactorManager interface {
+	// BeginTOTPEnrollment generates a secret for the user, returned with
+	// the otpauth:// URL authenticator apps import. It is not enforced
+	// until confirmed.
+	BeginTOTPEnrollment(ctx context.Context) (secret, url string, err error)
+	// ConfirmTOTPEnrollment enables the pending secret once code proves the
+	// authenticator has it, and returns single-use recovery codes.
+	ConfirmTOTPEnrollment(ctx context.Context, code string) ([]string, error)
+	// DisableTOTP turns two-factor authentication off, given a current code
+	// or a recovery code.
+	DisableTOTP(ctx context.Context, code string) error
+	// ResetTOTP turns two-factor authentication off for another user who
+	// lost their authenticator and recovery codes. Meant for admins.
+	ResetTOTP(ctx context.Context, id string) error
+	// EnrollmentRequired reports whether user must enroll before using the
+	// API, when two-factor authentication is enforced for admins.
+	EnrollmentRequired(user *domain.User) bool
+}
+
+// ExternalLogin signs in users authenticated by an identity provider, whose
+// groups decide their role.
+type ExternalLogin interface {
+	// LoginExternal provisions or updates the account of identity and
+	// returns a session token.
+	LoginExternal(ctx context.Context, identity domain.ExternalIdentity) (token string, err error)
+}
+
+// IdentityProvider authenticates users with the OpenID Connect authorization
+// code flow.
+type IdentityProvider interface {
+	// AuthCodeURL returns the URL of the provider's login page, which
+	// redirects back with a code and state.
+	AuthCodeURL(ctx context.Context, state, nonce string) (string, error)
+	// Exchange redeems code for the identity of the user, checking the ID
+	// token was issued for this client and nonce.
+	Exchange(ctx context.Context, code, nonce string) (*domain.ExternalIdentity, error)
+}
+
+// Directory authenticates users by password against an external directory,
+// such as LDAP.
+type Directory interface {
+	// Authenticate checks the password of username and returns their
+	// identity with the groups they belong to.
+	Authenticate(ctx context.Context, username, password string) (*domain.ExternalIdentity, error)
 }
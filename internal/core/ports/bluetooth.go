@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// BluetoothScanner runs classic Bluetooth inquiry scans.
+type BluetoothScanner interface {
+	// Inquiry scans for discoverable devices for about duration.
+	Inquiry(ctx context.Context, duration time.Duration) ([]domain.BluetoothDevice, error)
+}
+
+// BluetoothService keeps the classic Bluetooth devices found by periodic
+// inquiry scans.
+type BluetoothService interface {
+	// BluetoothDevices returns the devices found, most recently seen first,
+	// correlated with the Wi-Fi devices of the same chip.
+	BluetoothDevices(ctx context.Context) []domain.BluetoothDevice
+}
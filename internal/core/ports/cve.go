@@ -33,3 +33,8 @@ type CVEMatcher interface {
 	// FindMatches returns all CVE matches for a given device
 	FindMatches(ctx context.Context, device domain.Device) ([]domain.CVEMatch, error)
 }
+
+// AlertEnricher adds the CVEs of the device raising an alert to it.
+type AlertEnricher interface {
+	EnrichAlert(ctx context.Context, alert *domain.Alert)
+}
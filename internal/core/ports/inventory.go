@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// InventorySource fetches the asset list of an external inventory, such as a
+// CMDB export or a NetBox instance.
+type InventorySource interface {
+	// Name identifies the source, e.g. for the inventory status.
+	Name() string
+
+	// FetchInventory returns every device the inventory lists.
+	FetchInventory(ctx context.Context) ([]domain.InventoryEntry, error)
+}
+
+// AssetInventory resolves devices to the entries of the trusted inventory.
+type AssetInventory interface {
+	// Lookup returns the entry listing a MAC.
+	Lookup(mac string) (domain.InventoryEntry, bool)
+}
+
+// InventoryManager synchronizes the trusted inventory with its source.
+type InventoryManager interface {
+	// Status describes the last synchronization.
+	Status() domain.InventoryStatus
+
+	// Sync fetches the inventory now.
+	Sync(ctx context.Context) (domain.InventoryStatus, error)
+}
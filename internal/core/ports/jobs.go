@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// JobManager runs long-running tasks (cracking, report generation, database
+// updates) in the background and reports their progress.
+type JobManager interface {
+	// EnqueueJob queues a job of a registered kind. Payload is the JSON input of the handler.
+	EnqueueJob(ctx context.Context, kind string, payload string) (domain.Job, error)
+
+	// GetJob returns a job by ID.
+	GetJob(ctx context.Context, id string) (domain.Job, error)
+
+	// ListJobs returns jobs, most recent first.
+	ListJobs(ctx context.Context, filter domain.JobFilter) ([]domain.Job, error)
+
+	// CancelJob stops a queued or running job.
+	CancelJob(ctx context.Context, id string) error
+}
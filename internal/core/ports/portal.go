@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// PortalChecker associates with an open network to see what a client gets.
+type PortalChecker interface {
+	// CheckPortal joins the network, probes for Internet access and leaves.
+	CheckPortal(ctx context.Context, target domain.PortalTarget) (domain.PortalCheck, error)
+}
+
+// PortalCheckService runs captive portal checks of discovered open networks.
+type PortalCheckService interface {
+	// CheckCaptivePortal checks the open network of an AP and records the result on it.
+	CheckCaptivePortal(ctx context.Context, bssid string) (domain.PortalCheck, error)
+}
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 )
@@ -32,6 +33,22 @@ type Sniffer interface {
 	Close() error
 }
 
+// DynamicSniffer adds and removes capture interfaces while capturing. The
+// interfaces must already be in monitor mode.
+type DynamicSniffer interface {
+	AddInterface(ctx context.Context, iface string) error
+	RemoveInterface(ctx context.Context, iface string) error
+}
+
+// CaptureInterfaceManager adds and removes capture interfaces at runtime,
+// switching them in and out of monitor mode.
+type CaptureInterfaceManager interface {
+	// AddCaptureInterface returns the name capture runs on, a monitor VIF
+	// of iface in VIF mode.
+	AddCaptureInterface(ctx context.Context, iface string) (string, error)
+	RemoveCaptureInterface(ctx context.Context, iface string) error
+}
+
 // ChannelLocking defines the capability to lock a radio interface to a specific channel.
 type ChannelLocking interface {
 	Lock(ctx context.Context, iface string, channel int) error
@@ -39,6 +56,69 @@ type ChannelLocking interface {
 	ExecuteWithLock(ctx context.Context, iface string, channel int, action func() error) error
 }
 
+// PriorityChannelLocker is implemented by sniffers arbitrating contending
+// channel locks by priority. The action's context is cancelled with
+// domain.ErrLockPreempted if a higher-priority holder takes the interface to
+// another channel.
+type PriorityChannelLocker interface {
+	ExecuteWithPriority(ctx context.Context, iface string, channel int, priority domain.LockPriority, holder string, action func(ctx context.Context) error) error
+}
+
+// InjectionTester is implemented by sniffers able to verify frame injection on an interface.
+type InjectionTester interface {
+	RunInjectionTest(ctx context.Context, iface string, config domain.InjectionTestConfig) (domain.InjectionTestResult, error)
+}
+
+// InterfaceDiagnoser is implemented by sniffers able to run monitor-mode preflight checks.
+type InterfaceDiagnoser interface {
+	DiagnoseInterfaces(ctx context.Context, ifaces []string) ([]domain.InterfaceDiagnostics, error)
+}
+
+// RegulatoryProvider is implemented by sniffers that know the regulatory flags of their channels.
+type RegulatoryProvider interface {
+	ChannelRegulatory(ctx context.Context, iface string) ([]domain.ChannelRegulatory, error)
+}
+
+// BitrateRestorer is implemented by sniffers that can undo the fixed bitrates
+// attack engines set on an interface for injection.
+type BitrateRestorer interface {
+	RestoreBitrates(ctx context.Context, iface string) error
+}
+
+// DwellPlanner is implemented by sniffers that tune channel dwell time per channel.
+type DwellPlanner interface {
+	DwellPlan(ctx context.Context, iface string) ([]domain.ChannelDwell, error)
+}
+
+// ManualChannelLocker is implemented by sniffers that support time-limited analyst channel locks.
+type ManualChannelLocker interface {
+	LockChannelFor(ctx context.Context, iface string, channel int, duration time.Duration) (domain.ChannelLockState, error)
+	ReleaseChannelLock(ctx context.Context, iface string) error
+}
+
+// DeviceRecorder is implemented by sniffers able to record the frames of a
+// single device to a capture artifact.
+type DeviceRecorder interface {
+	// RecordDevice records mac for duration. It returns false if the device
+	// is already being recorded.
+	RecordDevice(ctx context.Context, mac string, duration time.Duration, reason string) bool
+}
+
+// FrameHistory is implemented by sniffers keeping the recent frames of each
+// adapter in memory.
+type FrameHistory interface {
+	// CaptureWindow returns the frames captured between from and to on
+	// ifaces, every adapter when empty, as pcapng, and how many there are.
+	// The data is nil without frames.
+	CaptureWindow(ctx context.Context, ifaces []string, from, to time.Time, comments []string) ([]byte, int, error)
+}
+
+// RecentFrameSaver saves the frames the sensor captured before the request,
+// for events that already happened.
+type RecentFrameSaver interface {
+	SaveRecentFrames(ctx context.Context, iface string, window time.Duration) (domain.Artifact, error)
+}
+
 // NetworkScanner manages the higher-level scanning logic and hardware orchestration.
 type NetworkScanner interface {
 	TriggerScan(ctx context.Context) error
@@ -48,6 +128,12 @@ type NetworkScanner interface {
 	GetChannels(ctx context.Context) ([]int, error)
 	SetInterfaceChannels(ctx context.Context, iface string, channels []int) error
 	GetInterfaceChannels(ctx context.Context, iface string) ([]int, error)
+	RunInjectionTest(ctx context.Context, iface string, config domain.InjectionTestConfig) (domain.InjectionTestResult, error)
+	DiagnoseInterfaces(ctx context.Context, ifaces []string) ([]domain.InterfaceDiagnostics, error)
+	GetChannelRegulatory(ctx context.Context, iface string) ([]domain.ChannelRegulatory, error)
+	GetDwellPlan(ctx context.Context, iface string) ([]domain.ChannelDwell, error)
+	LockInterfaceChannel(ctx context.Context, iface string, channel int, duration time.Duration) (domain.ChannelLockState, error)
+	UnlockInterfaceChannel(ctx context.Context, iface string) error
 }
 
 // AttackManager coordinates the lifecycle of various security assessments.
@@ -67,6 +153,63 @@ type AttackManager interface {
 	StartAuthFloodAttack(ctx context.Context, config domain.AuthFloodAttackConfig) (string, error)
 	StopAuthFloodAttack(ctx context.Context, id string, force bool) error
 	GetAuthFloodStatus(ctx context.Context, id string) (domain.AuthFloodAttackStatus, error)
+
+	// Probe Flood Attacks
+	StartProbeFloodAttack(ctx context.Context, config domain.ProbeFloodAttackConfig) (string, error)
+	StopProbeFloodAttack(ctx context.Context, id string, force bool) error
+	GetProbeFloodStatus(ctx context.Context, id string) (domain.ProbeFloodAttackStatus, error)
+
+	// Channel Switch Announcement Attacks
+	StartCSAAttack(ctx context.Context, config domain.CSAAttackConfig) (string, error)
+	StopCSAAttack(ctx context.Context, id string, force bool) error
+	GetCSAStatus(ctx context.Context, id string) (domain.CSAAttackStatus, error)
+
+	// Beacon Spoofing
+	StartBeaconSpoof(ctx context.Context, config domain.BeaconSpoofConfig) (string, error)
+	StopBeaconSpoof(ctx context.Context, id string, force bool) error
+	GetBeaconSpoofStatus(ctx context.Context, id string) (domain.BeaconSpoofStatus, error)
+
+	// Karma-lite Probe Responder
+	StartKarma(ctx context.Context, config domain.KarmaConfig) (string, error)
+	StopKarma(ctx context.Context, id string, force bool) error
+	GetKarmaStatus(ctx context.Context, id string) (domain.KarmaStatus, error)
+
+	// RTS/CTS Virtual Jamming
+	StartNAVJam(ctx context.Context, config domain.NAVJamConfig) (string, error)
+	StopNAVJam(ctx context.Context, id string, force bool) error
+	GetNAVJamStatus(ctx context.Context, id string) (domain.NAVJamStatus, error)
+
+	// History of finished attacks
+	GetAttackHistory(ctx context.Context, limit int) ([]domain.AttackRecord, error)
+}
+
+// AttackRecorder is implemented by attack engines that report each finished
+// attack so it can be persisted to the attack history.
+type AttackRecorder interface {
+	SetRecorder(recorder func(domain.AttackRecord))
+}
+
+// ScopeManager maintains the engagement scope: the targets a workspace is
+// authorized to attack.
+type ScopeManager interface {
+	GetScope(ctx context.Context) (domain.EngagementScope, error)
+	SetScope(ctx context.Context, scope domain.EngagementScope) error
+}
+
+// ROEManager keeps the rules of engagement: the signed record an attack
+// cannot be started without.
+type ROEManager interface {
+	GetROE(ctx context.Context) (*domain.RulesOfEngagement, error)
+	ListROE(ctx context.Context) ([]domain.RulesOfEngagement, error)
+	SignROE(ctx context.Context, roe domain.RulesOfEngagement) (domain.RulesOfEngagement, error)
+	RevokeROE(ctx context.Context) error
+}
+
+// DeviceLocator tracks the signal of a single device to physically locate it.
+type DeviceLocator interface {
+	StartLocator(ctx context.Context, config domain.LocatorConfig) (domain.LocatorSession, error)
+	StopLocator(ctx context.Context) error
+	GetLocatorStatus(ctx context.Context) (domain.LocatorSession, error)
 }
 
 // IntelligenceService provides access to processed domain data and system state.
@@ -74,6 +217,7 @@ type IntelligenceService interface {
 	GetGraph(ctx context.Context) (domain.GraphData, error)
 	GetAlerts(ctx context.Context) ([]domain.Alert, error)
 	GetSystemStats(ctx context.Context) (domain.SystemStats, error)
+	GetHeatmap(ctx context.Context, query domain.HeatmapQuery) (domain.Heatmap, error)
 	AddRule(ctx context.Context, rule domain.AlertRule) error
 }
 
@@ -82,9 +226,13 @@ type IntelligenceService interface {
 type NetworkService interface {
 	NetworkScanner
 	AttackManager
+	ScopeManager
+	ROEManager
 	IntelligenceService
+	DeviceLocator
 
 	ProcessDevice(ctx context.Context, device domain.Device) error
+	ReportAlert(ctx context.Context, alert domain.Alert) error
 	SetPersistenceEnabled(enabled bool)
 	IsPersistenceEnabled() bool
 	ResetWorkspace(ctx context.Context) error
@@ -92,3 +240,10 @@ type NetworkService interface {
 	// Close performs a graceful shutdown of all underlying services.
 	Close() error
 }
+
+// DeviceIngester imports device observations produced by other tools.
+type DeviceIngester interface {
+	// Ingest parses data in format, detected when empty, and merges every
+	// observation into the registry. Source names the producing tool.
+	Ingest(ctx context.Context, format domain.IngestFormat, source string, data []byte) (domain.IngestResult, error)
+}
@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// RadioSleeper switches the capture radios off between duty cycles, more
+// cheaply than pausing capture: interfaces keep their monitor mode.
+type RadioSleeper interface {
+	SleepRadios(ctx context.Context) error
+	WakeRadios(ctx context.Context) error
+}
+
+// ReactiveHopper is a sniffer that holds its hopper on a channel when it
+// sees a handshake.
+type ReactiveHopper interface {
+	SetReactiveHopping(enabled bool)
+}
+
+// FlushThrottler writes to storage in batches at an adjustable interval.
+type FlushThrottler interface {
+	SetFlushInterval(interval time.Duration)
+}
+
+// BatteryMonitor reports the charge of the sensor's battery.
+type BatteryMonitor interface {
+	Battery(ctx context.Context) (domain.BatteryStatus, error)
+}
+
+// PowerManager manages the power save mode of battery powered sensors.
+type PowerManager interface {
+	PowerStatus() domain.PowerStatus
+	SetPowerSave(ctx context.Context, config domain.PowerSaveConfig) (domain.PowerStatus, error)
+}
@@ -33,6 +33,11 @@ type DeviceRegistry interface {
 	GetSSIDs(ctx context.Context) map[string]bool
 	GetSSIDSecurity(ctx context.Context, ssid string) (security string, found bool)
 
+	// RemoveDevice drops a device and clears the connections of other
+	// devices to it. It returns whether the device was known and how many
+	// devices were unlinked.
+	RemoveDevice(ctx context.Context, mac string) (found bool, unlinked int)
+
 	// Clear resets the registry state.
 	Clear(ctx context.Context)
 }
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// CaptureController stops and restarts the capture of the sensor.
+type CaptureController interface {
+	// PauseCapture stops the sniffers and gives the interfaces back to the system.
+	PauseCapture(ctx context.Context) error
+	// ResumeCapture puts the interfaces back in monitor mode and restarts the sniffers.
+	ResumeCapture(ctx context.Context) error
+}
+
+// MonitoringScheduler restricts capture to the windows of a monitoring schedule.
+type MonitoringScheduler interface {
+	Status() domain.MonitoringStatus
+	// SetSchedule replaces the schedule, applying it right away.
+	SetSchedule(ctx context.Context, schedule domain.MonitoringSchedule) (domain.MonitoringStatus, error)
+}
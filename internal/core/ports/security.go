@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"io"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 )
@@ -18,6 +19,23 @@ type SecurityEngine interface {
 	GetAlerts(ctx context.Context) []domain.Alert
 }
 
+// RuleMatcher is implemented by security engines able to tell which alert
+// rules a device matches, so their policy actions can be carried out.
+type RuleMatcher interface {
+	MatchRules(device domain.Device) []domain.AlertRule
+}
+
+// EvidenceLinker is implemented by security engines able to report the alerts
+// they raise and to link evidence captured afterwards to them.
+type EvidenceLinker interface {
+	// OnAlert sets the hook called with each new alert, outside the engine lock.
+	OnAlert(hook func(domain.Alert))
+
+	// AttachEvidence links an artifact to a stored alert. It returns false if
+	// the alert is no longer kept.
+	AttachEvidence(alertID, evidenceID string) bool
+}
+
 // VulnerabilityNotifier handles the real-time dissemination of security findings.
 type VulnerabilityNotifier interface {
 	// NotifyNewVulnerability emits a notification for a newly discovered weakness.
@@ -26,3 +44,140 @@ type VulnerabilityNotifier interface {
 	// NotifyVulnerabilityConfirmed emits a notification when a vulnerability is confirmed via active validation.
 	NotifyVulnerabilityConfirmed(ctx context.Context, vuln domain.VulnerabilityRecord)
 }
+
+// VulnerabilityReader queries the stored security findings.
+type VulnerabilityReader interface {
+	GetVulnerabilities(filter domain.VulnerabilityFilter) ([]domain.VulnerabilityRecord, error)
+}
+
+// BaselineManager configures new-device monitoring for the current workspace.
+type BaselineManager interface {
+	// GetBaseline returns the baseline configuration.
+	GetBaseline(ctx context.Context) (domain.BaselineConfig, error)
+
+	// SetBaseline validates and stores the configuration, starting the learning
+	// period when the baseline is enabled. It returns the stored copy.
+	SetBaseline(ctx context.Context, config domain.BaselineConfig) (domain.BaselineConfig, error)
+}
+
+// PSKCracker recovers a WPA PSK from a capture file using a list of candidates.
+type PSKCracker interface {
+	// Crack returns the PSK and true if one of the candidates matches the
+	// handshake or PMKID of bssid in the capture.
+	Crack(ctx context.Context, capturePath, bssid string, candidates []string) (string, bool, error)
+}
+
+// SecretSealer encrypts secrets and files before they are stored.
+type SecretSealer interface {
+	Seal(plaintext string) (string, error)
+	Open(sealed string) (string, error)
+	SealBytes(plaintext []byte) ([]byte, error)
+	OpenBytes(sealed []byte) ([]byte, error)
+}
+
+// PSKAuditor runs the weak-PSK audit against captured handshakes.
+type PSKAuditor interface {
+	// StartPSKAudit starts an audit in the background.
+	StartPSKAudit(ctx context.Context) error
+
+	// GetPSKAuditStatus returns the progress of the current or last audit.
+	GetPSKAuditStatus(ctx context.Context) domain.PSKAuditStatus
+}
+
+// CaptureMaterial reports the crackable material captured for access points.
+type CaptureMaterial interface {
+	HasHandshake(bssid string) bool
+	HasPMKID(bssid string) bool
+}
+
+// AutoCapturer cycles through the WPA-PSK access points in scope, sending a
+// short deauthentication burst to each and waiting for a handshake or PMKID.
+type AutoCapturer interface {
+	// StartAutoCapture starts a run in the background.
+	StartAutoCapture(ctx context.Context, config domain.AutoCaptureConfig) error
+
+	// StopAutoCapture ends the current run. A burst already started completes.
+	StopAutoCapture(ctx context.Context) error
+
+	// GetAutoCaptureStatus returns the progress and results of the current or last run.
+	GetAutoCaptureStatus(ctx context.Context) domain.AutoCaptureStatus
+}
+
+// TargetPrioritizer ranks the access points in scope by attack value.
+type TargetPrioritizer interface {
+	// PrioritizeTargets returns the scored APs, best first. A limit of 0
+	// returns them all.
+	PrioritizeTargets(ctx context.Context, limit int) ([]domain.TargetScore, error)
+}
+
+// CaptureImporter imports captures recorded by other tools, such as
+// hcxdumptool, as handshake and PMKID captures.
+type CaptureImporter interface {
+	// ImportPcapng saves the handshakes and PMKIDs found in a pcapng file.
+	ImportPcapng(ctx context.Context, name string, r io.Reader) (domain.CaptureImport, error)
+
+	// ImportHashes saves the hashcat 22000 hashes of handshakes and PMKIDs
+	// found in a hash file, as written by hcxpcapngtool.
+	ImportHashes(ctx context.Context, name string, r io.Reader) (domain.CaptureImport, error)
+}
+
+// GeofenceManager manages the protected zones used for perimeter alerting.
+type GeofenceManager interface {
+	// AddGeofence validates and registers a new protected zone.
+	AddGeofence(ctx context.Context, zone domain.Geofence) (domain.Geofence, error)
+
+	// RemoveGeofence deletes a protected zone by ID.
+	RemoveGeofence(ctx context.Context, id string) error
+
+	// GetGeofences returns all registered zones.
+	GetGeofences(ctx context.Context) []domain.Geofence
+}
+
+// DeauthReasonAnalytics aggregates the reason codes of the deauthentication
+// and disassociation frames seen per BSSID.
+type DeauthReasonAnalytics interface {
+	// DeauthReasonStats returns the distribution of every BSSID, or of
+	// bssid when not empty.
+	DeauthReasonStats(ctx context.Context, bssid string) []domain.DeauthReasonStats
+}
+
+// ProtectedBSSIDManager manages the APs defended against deauthentication and
+// channel switch attacks.
+type ProtectedBSSIDManager interface {
+	// AddProtectedBSSID validates and protects a BSSID.
+	AddProtectedBSSID(ctx context.Context, protected domain.ProtectedBSSID) (domain.ProtectedBSSID, error)
+
+	// RemoveProtectedBSSID stops protecting a BSSID.
+	RemoveProtectedBSSID(ctx context.Context, bssid string) error
+
+	// GetProtectedBSSIDs returns all protected BSSIDs.
+	GetProtectedBSSIDs(ctx context.Context) []domain.ProtectedBSSID
+}
+
+// DataReloader reloads data files, such as device signatures and alert
+// rules, into the running services.
+type DataReloader interface {
+	// Reload applies every data file and reports the outcome of each.
+	Reload(ctx context.Context) []domain.ReloadResult
+}
+
+// PluginManager exposes the external analyzers extending the detections.
+type PluginManager interface {
+	// ListPlugins returns the state of every analyzer.
+	ListPlugins(ctx context.Context) []domain.PluginStatus
+}
+
+// HookManager manages the scripting hooks of the current workspace.
+type HookManager interface {
+	// ListHooks returns the hooks of the workspace.
+	ListHooks(ctx context.Context) ([]domain.ScriptHook, error)
+
+	// CreateHook validates, compiles and stores a new hook.
+	CreateHook(ctx context.Context, hook domain.ScriptHook) (domain.ScriptHook, error)
+
+	// UpdateHook replaces an existing hook.
+	UpdateHook(ctx context.Context, id string, hook domain.ScriptHook) (domain.ScriptHook, error)
+
+	// DeleteHook removes a hook by ID.
+	DeleteHook(ctx context.Context, id string) error
+}
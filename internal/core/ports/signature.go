@@ -11,3 +11,43 @@ type SignatureMatcher interface {
 	// MatchSignature compares device data against a library of known fingerprints.
 	MatchSignature(ctx context.Context, device domain.Device) *domain.SignatureMatch
 }
+
+// APHardwareMatcher identifies the make and model of APs from how their
+// beacons are built.
+type APHardwareMatcher interface {
+	// MatchHardware returns the best match for the beacon fingerprint of an
+	// AP, or nil if none is good enough.
+	MatchHardware(ctx context.Context, device domain.Device) *domain.APHardware
+}
+
+// SignatureLearner grows the signature library from devices labeled by an analyst.
+type SignatureLearner interface {
+	// LearnSignature records the signature of a device under its true make and model.
+	LearnSignature(ctx context.Context, device domain.Device, label domain.DeviceLabel) (domain.DeviceSignature, error)
+
+	// LearnedSignatures returns the signatures learned so far.
+	LearnedSignatures(ctx context.Context) []domain.DeviceSignature
+}
+
+// DeviceLabeler lets an analyst label the true make and model of a device.
+type DeviceLabeler interface {
+	// LabelDevice applies the label to a device and learns its signature.
+	LabelDevice(ctx context.Context, mac string, label domain.DeviceLabel) (domain.DeviceSignature, error)
+
+	// GetLearnedSignatures returns the signatures learned from labeled devices.
+	GetLearnedSignatures(ctx context.Context) []domain.DeviceSignature
+}
+
+// DeviceAssetEditor records which business asset a device is.
+type DeviceAssetEditor interface {
+	// SetDeviceAsset sets the owner, asset tag and criticality of a device.
+	SetDeviceAsset(ctx context.Context, mac string, asset domain.AssetInfo) (domain.Device, error)
+}
+
+// DeviceForgetter removes every trace of a device, e.g. test pollution or a
+// data-removal request.
+type DeviceForgetter interface {
+	// ForgetDevice removes the device from the registry, the workspace
+	// storage and the signal history.
+	ForgetDevice(ctx context.Context, mac string) (domain.DeviceForgetReport, error)
+}
@@ -25,6 +25,107 @@ type VulnerabilityRepository interface {
 	GetVulnerabilities(ctx context.Context, filter domain.VulnerabilityFilter) ([]domain.VulnerabilityRecord, error)
 	GetVulnerability(ctx context.Context, id string) (*domain.VulnerabilityRecord, error)
 	UpdateVulnerabilityStatus(ctx context.Context, id string, status domain.VulnerabilityStatus, notes string) error
+	// SetVulnerabilitySuppression suppresses a finding, or lifts its
+	// suppression when nil. Detections do not overwrite it.
+	SetVulnerabilitySuppression(ctx context.Context, id string, suppression *domain.VulnerabilitySuppression) error
+}
+
+// AttackHistoryRepository handles persistence for finished attack outcomes.
+type AttackHistoryRepository interface {
+	SaveAttackRecord(ctx context.Context, record domain.AttackRecord) error
+	ListAttackRecords(ctx context.Context, limit int) ([]domain.AttackRecord, error)
+}
+
+// ActiveAttackRepository persists the attacks running right now, so that they
+// can be recovered if the server stops before they finish.
+type ActiveAttackRepository interface {
+	SaveActiveAttack(ctx context.Context, attack domain.ActiveAttack) error
+	DeleteActiveAttack(ctx context.Context, id string) error
+	ListActiveAttacks(ctx context.Context) ([]domain.ActiveAttack, error)
+}
+
+// ScopeRepository persists the engagement scope of a workspace.
+type ScopeRepository interface {
+	GetScope(ctx context.Context) (domain.EngagementScope, error)
+	SaveScope(ctx context.Context, scope domain.EngagementScope) error
+}
+
+// ROERepository persists the rules of engagement signed for a workspace.
+type ROERepository interface {
+	SaveROE(ctx context.Context, roe *domain.RulesOfEngagement) error
+	// LatestROE returns the most recently signed record, nil if none was.
+	LatestROE(ctx context.Context) (*domain.RulesOfEngagement, error)
+	ListROE(ctx context.Context) ([]domain.RulesOfEngagement, error)
+}
+
+// CredentialRepository persists secrets recovered for networks, sealed.
+type CredentialRepository interface {
+	SaveCredential(ctx context.Context, credential domain.RecoveredCredential) error
+	GetCredential(ctx context.Context, bssid string) (*domain.RecoveredCredential, error)
+}
+
+// JobRepository persists background jobs so they survive restarts.
+type JobRepository interface {
+	SaveJob(ctx context.Context, job domain.Job) error
+	GetJob(ctx context.Context, id string) (*domain.Job, error)
+	ListJobs(ctx context.Context, filter domain.JobFilter) ([]domain.Job, error)
+}
+
+// ArtifactRepository persists the artifact registry of a workspace.
+type ArtifactRepository interface {
+	SaveArtifact(ctx context.Context, artifact domain.Artifact) error
+	GetArtifact(ctx context.Context, id string) (*domain.Artifact, error)
+	ListArtifacts(ctx context.Context, filter domain.ArtifactFilter) ([]domain.Artifact, error)
+	DeleteArtifact(ctx context.Context, id string) error
+}
+
+// BaselineRepository persists the baseline monitoring configuration of a workspace.
+type BaselineRepository interface {
+	GetBaseline(ctx context.Context) (domain.BaselineConfig, error)
+	SaveBaseline(ctx context.Context, config domain.BaselineConfig) error
+}
+
+// ProtectedBSSIDRepository persists the BSSIDs a workspace defends against
+// deauthentication and channel switch attacks.
+type ProtectedBSSIDRepository interface {
+	ListProtectedBSSIDs(ctx context.Context) ([]domain.ProtectedBSSID, error)
+	SaveProtectedBSSID(ctx context.Context, protected domain.ProtectedBSSID) error
+	DeleteProtectedBSSID(ctx context.Context, bssid string) error
+}
+
+// GeofenceRepository persists the protected zones of perimeter alerting.
+type GeofenceRepository interface {
+	ListGeofences(ctx context.Context) ([]domain.Geofence, error)
+	SaveGeofence(ctx context.Context, zone domain.Geofence) error
+	DeleteGeofence(ctx context.Context, id string) error
+}
+
+// WorkspaceSettingsRepository persists the settings of a workspace. Its scope
+// and baseline are those of ScopeRepository and BaselineRepository.
+type WorkspaceSettingsRepository interface {
+	GetWorkspaceSettings(ctx context.Context) (domain.WorkspaceSettings, error)
+	SaveWorkspaceSettings(ctx context.Context, settings domain.WorkspaceSettings) error
+}
+
+// ScheduleRepository persists the monitoring schedule of the sensor.
+type ScheduleRepository interface {
+	GetSchedule(ctx context.Context) (domain.MonitoringSchedule, error)
+	SaveSchedule(ctx context.Context, schedule domain.MonitoringSchedule) error
+}
+
+// HookRepository persists the scripting hooks of a workspace.
+type HookRepository interface {
+	ListHooks(ctx context.Context) ([]domain.ScriptHook, error)
+	SaveHook(ctx context.Context, hook domain.ScriptHook) error
+	DeleteHook(ctx context.Context, id string) error
+}
+
+// DeviceEraser deletes a device from a workspace with what was recorded
+// about it: probes and vulnerabilities. Attack history is kept as the audit
+// trail of what was done.
+type DeviceEraser interface {
+	// DeleteDevice returns domain.ErrDeviceNotFound if the device was not stored.
+	DeleteDevice(ctx context.Context, mac string) error
 }
 
 // Storage provides a unified interface for the persistence layer.
@@ -37,3 +138,21 @@ type Storage interface {
 	// Close ensures all underlying database connections are properly terminated.
 	Close() error
 }
+
+// WorkspaceRouter finds the open workspace the devices of a sensor are
+// persisted to.
+type WorkspaceRouter interface {
+	// Route returns the workspace receiving the devices heard by sensor and
+	// its storage, or an empty name for the active workspace.
+	Route(sensor string) (workspace string, store Storage)
+	// WorkspaceOf returns the name of the workspace recording what sensor
+	// hears: the open workspace it is routed to, else the active one.
+	WorkspaceOf(sensor string) string
+}
+
+// BluetoothRepository persists the classic Bluetooth devices found by
+// inquiry scans.
+type BluetoothRepository interface {
+	SaveBluetoothDevices(ctx context.Context, devices []domain.BluetoothDevice) error
+	GetBluetoothDevices(ctx context.Context) ([]domain.BluetoothDevice, error)
+}
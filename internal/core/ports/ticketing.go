@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// IssueTracker files findings as issues in an external tracker, such as
+// Jira or GitLab.
+type IssueTracker interface {
+	// Name identifies the tracker and project, e.g. for the ticket status.
+	Name() string
+
+	// FindIssue returns the issue labeled with a finding's fingerprint, open
+	// or closed, if one was filed.
+	FindIssue(ctx context.Context, fingerprint string) (domain.Ticket, bool, error)
+
+	// CreateIssue opens an issue and returns it.
+	CreateIssue(ctx context.Context, draft domain.IssueDraft) (domain.Ticket, error)
+}
+
+// FindingTicketer opens an issue for each severe finding, once.
+type FindingTicketer interface {
+	// FileAlert opens an issue for the alert unless it is below the
+	// threshold or one was already filed for it.
+	FileAlert(ctx context.Context, alert domain.Alert)
+}
+
+// TicketManager reports the issues filed for findings.
+type TicketManager interface {
+	// Status lists the issues filed and the last error.
+	Status() domain.TicketStatus
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// ZigbeeSniffer captures IEEE 802.15.4 frames from a sniffer dongle.
+type ZigbeeSniffer interface {
+	// Run calls handle with each frame until ctx is done or the dongle fails.
+	Run(ctx context.Context, handle func(domain.ZigbeeFrame)) error
+}
+
+// ZigbeeRegistry keeps the 802.15.4 networks and devices seen, separate from
+// the Wi-Fi device registry.
+type ZigbeeRegistry interface {
+	ZigbeeSurvey() domain.ZigbeeSurvey
+}
@@ -0,0 +1,149 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// PushConfig sends a configuration to an agent. It is merged with the ones
+// pushed before, and the agent gets the whole result under a new revision so
+// a lost or repeated push leaves it in the same state.
+func (h *Hub) PushConfig(ctx context.Context, agentID string, config domain.AgentConfig) (domain.AgentConfigStatus, error) {
+	if config.IsEmpty() {
+		return domain.AgentConfigStatus{}, fmt.Errorf("configuration changes nothing")
+	}
+	if err := config.Validate(); err != nil {
+		return domain.AgentConfigStatus{}, err
+	}
+
+	h.mu.Lock()
+	s, ok := h.sessions[agentID]
+	if !ok {
+		h.mu.Unlock()
+		return domain.AgentConfigStatus{}, fmt.Errorf("%s: %w", agentID, domain.ErrAgentNotConnected)
+	}
+	for iface := range config.Channels {
+		if !hasInterface(s.info, iface) {
+			h.mu.Unlock()
+			return domain.AgentConfigStatus{}, fmt.Errorf("agent %s has no interface %s", agentID, iface)
+		}
+	}
+
+	status, ok := h.configs[agentID]
+	if !ok {
+		status = &domain.AgentConfigStatus{}
+	}
+	var desired domain.AgentConfig
+	if status.Desired != nil {
+		desired = *status.Desired
+	}
+	desired = desired.Merge(config)
+	data, err := json.Marshal(desired)
+	if err != nil {
+		h.mu.Unlock()
+		return domain.AgentConfigStatus{}, err
+	}
+
+	revision := h.configSeq + 1
+	cmd := domain.AgentCommand{Action: domain.AgentCommandConfigure, Config: data, Revision: revision}
+	if err := h.send(agentID, cmd); err != nil {
+		h.mu.Unlock()
+		return domain.AgentConfigStatus{}, err
+	}
+	h.configSeq = revision
+	status.Desired = &desired
+	status.Revision = revision
+	h.configs[agentID] = status
+	snapshot := *status
+	h.mu.Unlock()
+
+	if h.audit != nil {
+		h.audit.Log(ctx, domain.ActionConfigChange, agentID, fmt.Sprintf("Configuration revision %d pushed to agent: %s", revision, describeConfig(config)))
+	}
+	return snapshot, nil
+}
+
+// resendConfig sends the desired configuration to a reconnected agent. The
+// caller holds h.mu.
+func (h *Hub) resendConfig(agentID string) {
+	status, ok := h.configs[agentID]
+	if !ok || status.Desired == nil {
+		return
+	}
+	data, err := json.Marshal(status.Desired)
+	if err != nil {
+		return
+	}
+	cmd := domain.AgentCommand{Action: domain.AgentCommandConfigure, Config: data, Revision: status.Revision}
+	if err := h.send(agentID, cmd); err != nil {
+		log.Printf("Warning: could not resend configuration to agent %s: %v", agentID, err)
+	}
+}
+
+// ConfigApplied records an agent's answer to a configuration push, or the
+// configuration it reports on connecting (revision 0). Answers to revisions
+// older than the one last acknowledged are ignored.
+func (h *Hub) ConfigApplied(agentID string, ack domain.AgentConfigAck) {
+	h.mu.Lock()
+	status, ok := h.configs[agentID]
+	if !ok {
+		status = &domain.AgentConfigStatus{}
+		h.configs[agentID] = status
+	}
+	if ack.Revision != 0 && ack.Revision < status.AppliedRevision {
+		h.mu.Unlock()
+		return
+	}
+	effective := ack.Effective
+	status.Effective = &effective
+	status.AppliedAt = time.Now()
+	if ack.Revision != 0 {
+		status.AppliedRevision = ack.Revision
+		status.Error = ack.Error
+	}
+	h.mu.Unlock()
+
+	if ack.Revision == 0 {
+		return
+	}
+	if ack.Error != "" {
+		h.log(fmt.Sprintf("Agent %s applied configuration revision %d with errors: %s", agentID, ack.Revision, ack.Error), "warning")
+		return
+	}
+	h.log(fmt.Sprintf("Agent %s applied configuration revision %d", agentID, ack.Revision), "info")
+}
+
+func hasInterface(info domain.AgentInfo, iface string) bool {
+	for _, i := range info.Interfaces {
+		if i == iface {
+			return true
+		}
+	}
+	return false
+}
+
+// describeConfig summarizes the settings a push changes for the audit log.
+func describeConfig(c domain.AgentConfig) string {
+	var parts []string
+	for iface, channels := range c.Channels {
+		parts = append(parts, fmt.Sprintf("%s channels %v", iface, channels))
+	}
+	sort.Strings(parts)
+	if c.DwellMs != 0 {
+		parts = append(parts, fmt.Sprintf("dwell %dms", c.DwellMs))
+	}
+	if c.BPFFilter != "" {
+		parts = append(parts, fmt.Sprintf("filter %q", c.BPFFilter))
+	}
+	if c.DropBadFCS != nil {
+		parts = append(parts, fmt.Sprintf("drop bad FCS %t", *c.DropBadFCS))
+	}
+	return strings.Join(parts, ", ")
+}
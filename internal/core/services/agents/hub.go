@@ -0,0 +1,380 @@
+// Package agents lets the server command the attacks of remote agents. Each
+// agent keeps a command channel open; attacks started through the hub go
+// through the same scope checks, audit and history as local ones, but run on
+// the agent's own interfaces.
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// commandBuffer is how many commands may wait for an agent before new ones
+// are refused.
+const commandBuffer = 16
+
+// maxFinishedAttacks bounds the finished attacks kept in memory; they are
+// in the attack history once finished.
+const maxFinishedAttacks = 200
+
+type session struct {
+	info     domain.AgentInfo
+	commands chan domain.AgentCommand
+}
+
+// Hub tracks the connected agents and the attacks they run.
+type Hub struct {
+	registry ports.DeviceRegistry
+	guard    ports.ScopeGuard
+	history  ports.AttackHistoryRepository
+	audit    ports.AuditService
+	logger   func(message, level string)
+
+	mu        sync.Mutex
+	sessions  map[string]*session
+	attacks   map[string]*domain.RemoteAttack
+	configs   map[string]*domain.AgentConfigStatus // By agent ID, kept across reconnects
+	configSeq int64
+	release   *domain.AgentUpdate // Advertised to agents accepting updates
+}
+
+// NewHub creates a hub. The registry fills in the channel of targets, the
+// guard enforces the engagement scope and finished attacks are saved to the
+// history; all of them are optional.
+func NewHub(registry ports.DeviceRegistry, guard ports.ScopeGuard, history ports.AttackHistoryRepository, audit ports.AuditService) *Hub {
+	return &Hub{
+		registry: registry,
+		guard:    guard,
+		history:  history,
+		audit:    audit,
+		sessions: make(map[string]*session),
+		attacks:  make(map[string]*domain.RemoteAttack),
+		configs:  make(map[string]*domain.AgentConfigStatus),
+	}
+}
+
+// SetLogger sets where the progress of remote attacks is reported.
+func (h *Hub) SetLogger(logger func(message, level string)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.logger = logger
+}
+
+// Connect registers an agent, replacing a previous connection with the same ID.
+// The configuration pushed to the agent before is sent again, and the
+// advertised release if the agent runs another version.
+func (h *Hub) Connect(info domain.AgentInfo) (<-chan domain.AgentCommand, func()) {
+	if info.ConnectedAt.IsZero() {
+		info.ConnectedAt = time.Now()
+	}
+	s := &session{info: info, commands: make(chan domain.AgentCommand, commandBuffer)}
+
+	h.mu.Lock()
+	if old, ok := h.sessions[info.ID]; ok {
+		close(old.commands)
+	}
+	h.sessions[info.ID] = s
+	h.resendConfig(info.ID)
+	h.offerRelease(s)
+	h.mu.Unlock()
+	h.log(fmt.Sprintf("Agent %s connected (%d interfaces)", info.ID, len(info.Interfaces)), "info")
+
+	var once sync.Once
+	return s.commands, func() {
+		once.Do(func() { h.disconnect(s) })
+	}
+}
+
+// disconnect removes a session unless a newer connection replaced it, and
+// fails the attacks the agent can no longer report on.
+func (h *Hub) disconnect(s *session) {
+	h.mu.Lock()
+	if h.sessions[s.info.ID] != s {
+		h.mu.Unlock()
+		return
+	}
+	delete(h.sessions, s.info.ID)
+	close(s.commands)
+	if status, ok := h.configs[s.info.ID]; ok {
+		// Unknown until the agent acknowledges it again
+		status.AppliedRevision = 0
+	}
+
+	var lost []domain.RemoteAttack
+	for _, a := range h.attacks {
+		if a.AgentID == s.info.ID && !a.Finished {
+			a.Apply(domain.RemoteAttackUpdate{
+				Status:      string(domain.AttackFailed),
+				Finished:    true,
+				Error:       "agent disconnected",
+				PacketsSent: a.PacketsSent,
+			})
+			lost = append(lost, *a)
+		}
+	}
+	h.mu.Unlock()
+
+	h.log(fmt.Sprintf("Agent %s disconnected", s.info.ID), "warning")
+	for _, a := range lost {
+		h.finish(a)
+	}
+}
+
+// ListAgents returns the connected agents sorted by ID, with the status of
+// their configuration.
+func (h *Hub) ListAgents() []domain.AgentInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	agents := make([]domain.AgentInfo, 0, len(h.sessions))
+	for id, s := range h.sessions {
+		info := s.info
+		if status, ok := h.configs[id]; ok {
+			snapshot := *status
+			info.Config = &snapshot
+		}
+		agents = append(agents, info)
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].ID < agents[j].ID })
+	return agents
+}
+
+// StartAttack checks an attack against the engagement scope and sends it to
+// the agent. The attack is pending until the agent reports on it.
+func (h *Hub) StartAttack(ctx context.Context, agentID string, kind domain.AttackKind, config json.RawMessage) (domain.RemoteAttack, error) {
+	h.mu.Lock()
+	s, ok := h.sessions[agentID]
+	h.mu.Unlock()
+	if !ok {
+		return domain.RemoteAttack{}, fmt.Errorf("%s: %w", agentID, domain.ErrAgentNotConnected)
+	}
+	if !s.info.Supports(kind) {
+		return domain.RemoteAttack{}, fmt.Errorf("agent %s cannot run %s attacks", agentID, kind)
+	}
+
+	target, config, err := h.prepare(ctx, s.info, kind, config)
+	if err != nil {
+		return domain.RemoteAttack{}, err
+	}
+	if h.guard != nil {
+		if err := h.guard.CheckScope(ctx, kind, target); err != nil {
+			return domain.RemoteAttack{}, err
+		}
+	}
+
+	attack := domain.RemoteAttack{
+		ID:        uuid.New().String(),
+		AgentID:   agentID,
+		Kind:      kind,
+		Target:    target,
+		Config:    config,
+		Status:    string(domain.AttackPending),
+		StartTime: time.Now(),
+	}
+	attack.UpdatedAt = attack.StartTime
+	if user, ok := domain.UserFromContext(ctx); ok {
+		attack.Operator = user.Username
+	}
+
+	cmd := domain.AgentCommand{AttackID: attack.ID, Action: domain.AgentCommandStart, Kind: kind, Config: config}
+	h.mu.Lock()
+	if err := h.send(agentID, cmd); err != nil {
+		h.mu.Unlock()
+		return domain.RemoteAttack{}, err
+	}
+	h.attacks[attack.ID] = &attack
+	h.mu.Unlock()
+
+	if h.audit != nil {
+		action := domain.ActionInfo
+		if kind == domain.AttackKindDeauth {
+			action = domain.ActionDeauthStart
+		}
+		h.audit.Log(ctx, action, target, fmt.Sprintf("Remote %s attack sent to agent %s", kind, agentID))
+	}
+	return attack, nil
+}
+
+// prepare decodes and completes the configuration of an attack: a missing
+// interface is the agent's first one and a missing channel the one the
+// target was last seen on. It returns the target and the configuration to send.
+func (h *Hub) prepare(ctx context.Context, agent domain.AgentInfo, kind domain.AttackKind, raw json.RawMessage) (string, json.RawMessage, error) {
+	defaultIface := ""
+	if len(agent.Interfaces) > 0 {
+		defaultIface = agent.Interfaces[0]
+	}
+
+	var target string
+	var config interface{ Validate() error }
+	switch kind {
+	case domain.AttackKindDeauth:
+		var c domain.DeauthAttackConfig
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return "", nil, fmt.Errorf("invalid deauth configuration: %w", err)
+		}
+		if c.Interface == "" {
+			c.Interface = defaultIface
+		}
+		if c.Channel == 0 {
+			c.Channel = h.channelOf(ctx, c.TargetMAC)
+		}
+		target, config = c.TargetMAC, &c
+	case domain.AttackKindWPS:
+		var c domain.WPSAttackConfig
+		if err := json.Unmarshal(raw, &c); err != nil {
+			return "", nil, fmt.Errorf("invalid WPS configuration: %w", err)
+		}
+		if c.Interface == "" {
+			c.Interface = defaultIface
+		}
+		if c.Channel == 0 {
+			c.Channel = h.channelOf(ctx, c.TargetBSSID)
+		}
+		if c.TimeoutSeconds == 0 {
+			c.TimeoutSeconds = domain.NewWPSAttackConfig("", "", 0).TimeoutSeconds
+		}
+		target, config = c.TargetBSSID, &c
+	default:
+		return "", nil, fmt.Errorf("remote %s attacks are not supported", kind)
+	}
+
+	if err := config.Validate(); err != nil {
+		return "", nil, err
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", nil, err
+	}
+	return target, data, nil
+}
+
+func (h *Hub) channelOf(ctx context.Context, mac string) int {
+	if h.registry == nil {
+		return 0
+	}
+	if device, ok := h.registry.GetDevice(ctx, mac); ok {
+		return device.Channel
+	}
+	return 0
+}
+
+// StopAttack asks the agent running an attack to stop it.
+func (h *Hub) StopAttack(ctx context.Context, id string) error {
+	h.mu.Lock()
+	attack, ok := h.attacks[id]
+	if !ok {
+		h.mu.Unlock()
+		return fmt.Errorf("remote attack %s not found", id)
+	}
+	if attack.Finished {
+		h.mu.Unlock()
+		return nil
+	}
+	agentID, target := attack.AgentID, attack.Target
+	err := h.send(agentID, domain.AgentCommand{AttackID: id, Action: domain.AgentCommandStop})
+	h.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if h.audit != nil {
+		h.audit.Log(ctx, domain.ActionDeauthStop, target, fmt.Sprintf("Remote attack %s stopped on agent %s", id, agentID))
+	}
+	return nil
+}
+
+// send queues a command for an agent. The caller holds h.mu.
+func (h *Hub) send(agentID string, cmd domain.AgentCommand) error {
+	s, ok := h.sessions[agentID]
+	if !ok {
+		return fmt.Errorf("%s: %w", agentID, domain.ErrAgentNotConnected)
+	}
+	select {
+	case s.commands <- cmd:
+		return nil
+	default:
+		return fmt.Errorf("agent %s is not keeping up with commands", agentID)
+	}
+}
+
+// Update records the progress an agent reports. Updates for attacks of other
+// agents are ignored.
+func (h *Hub) Update(agentID string, update domain.RemoteAttackUpdate) {
+	h.mu.Lock()
+	attack, ok := h.attacks[update.AttackID]
+	if !ok || attack.AgentID != agentID || attack.Finished {
+		h.mu.Unlock()
+		return
+	}
+	changed := attack.Status != update.Status || update.Finished
+	attack.Apply(update)
+	snapshot := *attack
+	h.mu.Unlock()
+
+	if changed {
+		msg := fmt.Sprintf("Remote %s on %s via %s: %s", snapshot.Kind, snapshot.Target, agentID, snapshot.Status)
+		level := "info"
+		if snapshot.Error != "" {
+			msg += " (" + snapshot.Error + ")"
+			level = "error"
+		}
+		h.log(msg, level)
+	}
+	if snapshot.Finished {
+		h.finish(snapshot)
+	}
+}
+
+// finish saves a finished attack to the history and prunes old ones.
+func (h *Hub) finish(attack domain.RemoteAttack) {
+	if h.history != nil {
+		if err := h.history.SaveAttackRecord(context.Background(), attack.Record()); err != nil {
+			log.Printf("Warning: could not record remote attack %s: %v", attack.ID, err)
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var finished []*domain.RemoteAttack
+	for _, a := range h.attacks {
+		if a.Finished {
+			finished = append(finished, a)
+		}
+	}
+	if len(finished) <= maxFinishedAttacks {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].UpdatedAt.Before(finished[j].UpdatedAt) })
+	for _, a := range finished[:len(finished)-maxFinishedAttacks] {
+		delete(h.attacks, a.ID)
+	}
+}
+
+// ListAttacks returns the remote attacks, most recent first.
+func (h *Hub) ListAttacks() []domain.RemoteAttack {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	attacks := make([]domain.RemoteAttack, 0, len(h.attacks))
+	for _, a := range h.attacks {
+		attacks = append(attacks, *a)
+	}
+	sort.Slice(attacks, func(i, j int) bool { return attacks[i].StartTime.After(attacks[j].StartTime) })
+	return attacks
+}
+
+func (h *Hub) log(message, level string) {
+	h.mu.Lock()
+	logger := h.logger
+	h.mu.Unlock()
+	if logger != nil {
+		logger(message, level)
+	}
+}
@@ -0,0 +1,200 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGuard struct{ denied string }
+
+func (g fakeGuard) CheckScope(ctx context.Context, kind domain.AttackKind, target string) error {
+	if target == g.denied {
+		return domain.ErrOutOfScope
+	}
+	return nil
+}
+
+type fakeHistory struct{ records []domain.AttackRecord }
+
+func (h *fakeHistory) SaveAttackRecord(ctx context.Context, record domain.AttackRecord) error {
+	h.records = append(h.records, record)
+	return nil
+}
+
+func (h *fakeHistory) ListAttackRecords(ctx context.Context, limit int) ([]domain.AttackRecord, error) {
+	return h.records, nil
+}
+
+func deauthConfig(target string) json.RawMessage {
+	data, _ := json.Marshal(domain.DeauthAttackConfig{TargetMAC: target, AttackType: domain.DeauthBroadcast, Channel: 6})
+	return data
+}
+
+func TestHub_StartAttack(t *testing.T) {
+	history := &fakeHistory{}
+	hub := NewHub(nil, fakeGuard{denied: "AA:BB:CC:00:00:09"}, history, nil)
+	ctx := domain.ContextWithUser(context.Background(), &domain.User{Username: "alice"})
+
+	_, err := hub.StartAttack(ctx, "pi-1", domain.AttackKindDeauth, deauthConfig("AA:BB:CC:00:00:01"))
+	assert.ErrorIs(t, err, domain.ErrAgentNotConnected)
+
+	commands, disconnect := hub.Connect(domain.AgentInfo{ID: "pi-1", Interfaces: []string{"wlan1"}, Attacks: []domain.AttackKind{domain.AttackKindDeauth}})
+	defer disconnect()
+	require.Len(t, hub.ListAgents(), 1)
+
+	_, err = hub.StartAttack(ctx, "pi-1", domain.AttackKindWPS, nil)
+	assert.Error(t, err, "agent does not run WPS attacks")
+	_, err = hub.StartAttack(ctx, "pi-1", domain.AttackKindDeauth, deauthConfig("AA:BB:CC:00:00:09"))
+	assert.ErrorIs(t, err, domain.ErrOutOfScope)
+
+	attack, err := hub.StartAttack(ctx, "pi-1", domain.AttackKindDeauth, deauthConfig("AA:BB:CC:00:00:01"))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", attack.Operator)
+	assert.Equal(t, string(domain.AttackPending), attack.Status)
+
+	cmd := <-commands
+	assert.Equal(t, domain.AgentCommandStart, cmd.Action)
+	assert.Equal(t, attack.ID, cmd.AttackID)
+	var sent domain.DeauthAttackConfig
+	require.NoError(t, json.Unmarshal(cmd.Config, &sent))
+	assert.Equal(t, "wlan1", sent.Interface, "defaults to the agent's first interface")
+
+	hub.Update("pi-2", domain.RemoteAttackUpdate{AttackID: attack.ID, Status: "running"})
+	assert.Equal(t, string(domain.AttackPending), hub.ListAttacks()[0].Status, "updates of other agents are ignored")
+
+	hub.Update("pi-1", domain.RemoteAttackUpdate{AttackID: attack.ID, Status: "running", PacketsSent: 10})
+	require.NoError(t, hub.StopAttack(ctx, attack.ID))
+	assert.Equal(t, domain.AgentCommandStop, (<-commands).Action)
+
+	hub.Update("pi-1", domain.RemoteAttackUpdate{AttackID: attack.ID, Status: "stopped", Finished: true, PacketsSent: 64, DisconnectionConfirmed: true})
+	got := hub.ListAttacks()[0]
+	assert.True(t, got.Finished)
+	assert.Equal(t, 64, got.PacketsSent)
+
+	require.Len(t, history.records, 1)
+	record := history.records[0]
+	assert.Equal(t, domain.AttackKindDeauth, record.Kind)
+	assert.Equal(t, "alice", record.Operator)
+	assert.Equal(t, "pi-1/wlan1", record.Interface)
+	assert.Equal(t, 6, record.Channel)
+	assert.True(t, record.DisconnectionConfirmed)
+}
+
+func TestHub_Disconnect(t *testing.T) {
+	history := &fakeHistory{}
+	hub := NewHub(nil, nil, history, nil)
+	info := domain.AgentInfo{ID: "pi-1", Attacks: []domain.AttackKind{domain.AttackKindDeauth}}
+
+	old, disconnectOld := hub.Connect(info)
+	commands, disconnect := hub.Connect(info)
+	_, open := <-old
+	assert.False(t, open, "a new connection closes the previous one")
+	disconnectOld()
+	require.Len(t, hub.ListAgents(), 1, "the stale disconnect keeps the new session")
+
+	attack, err := hub.StartAttack(context.Background(), "pi-1", domain.AttackKindDeauth, deauthConfig("AA:BB:CC:00:00:01"))
+	require.NoError(t, err)
+	<-commands
+
+	disconnect()
+	assert.Empty(t, hub.ListAgents())
+	got := hub.ListAttacks()[0]
+	assert.Equal(t, attack.ID, got.ID)
+	assert.True(t, got.Finished)
+	assert.Equal(t, "agent disconnected", got.Error)
+	require.Len(t, history.records, 1)
+
+	assert.Error(t, hub.StopAttack(context.Background(), "missing"))
+}
+
+func TestHub_PushConfig(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+	ctx := context.Background()
+	drop := true
+
+	_, err := hub.PushConfig(ctx, "pi-1", domain.AgentConfig{DwellMs: 200})
+	assert.ErrorIs(t, err, domain.ErrAgentNotConnected)
+
+	info := domain.AgentInfo{ID: "pi-1", Interfaces: []string{"wlan1"}}
+	commands, disconnect := hub.Connect(info)
+	_, err = hub.PushConfig(ctx, "pi-1", domain.AgentConfig{})
+	assert.Error(t, err, "empty configuration")
+	_, err = hub.PushConfig(ctx, "pi-1", domain.AgentConfig{Channels: map[string][]int{"wlan9": {1}}})
+	assert.Error(t, err, "unknown interface")
+	_, err = hub.PushConfig(ctx, "pi-1", domain.AgentConfig{Channels: map[string][]int{"wlan1": {0}}})
+	assert.Error(t, err, "invalid channel")
+
+	first, err := hub.PushConfig(ctx, "pi-1", domain.AgentConfig{Channels: map[string][]int{"wlan1": {1, 6, 11}}})
+	require.NoError(t, err)
+	<-commands
+	second, err := hub.PushConfig(ctx, "pi-1", domain.AgentConfig{DwellMs: 200, DropBadFCS: &drop})
+	require.NoError(t, err)
+	assert.Greater(t, second.Revision, first.Revision)
+	assert.True(t, second.Pending())
+
+	cmd := <-commands
+	assert.Equal(t, domain.AgentCommandConfigure, cmd.Action)
+	assert.Equal(t, second.Revision, cmd.Revision)
+	var sent domain.AgentConfig
+	require.NoError(t, json.Unmarshal(cmd.Config, &sent))
+	assert.Equal(t, []int{1, 6, 11}, sent.Channels["wlan1"], "pushes add up")
+	assert.Equal(t, 200, sent.DwellMs)
+
+	effective := domain.AgentEffectiveConfig{Channels: map[string][]int{"wlan1": {1, 6, 11}}, CaptureSettings: domain.CaptureSettings{DwellMs: 200, DropBadFCS: true}}
+	hub.ConfigApplied("pi-1", domain.AgentConfigAck{Revision: second.Revision, Effective: effective})
+	hub.ConfigApplied("pi-1", domain.AgentConfigAck{Revision: first.Revision, Error: "stale"})
+	status := hub.ListAgents()[0].Config
+	require.NotNil(t, status)
+	assert.False(t, status.Pending())
+	assert.Empty(t, status.Error, "older acknowledgments are ignored")
+	assert.Equal(t, 200, status.Effective.DwellMs)
+
+	// The desired configuration is sent again on reconnecting
+	disconnect()
+	commands, disconnect = hub.Connect(info)
+	defer disconnect()
+	cmd = <-commands
+	assert.Equal(t, domain.AgentCommandConfigure, cmd.Action)
+	assert.Equal(t, second.Revision, cmd.Revision)
+	assert.True(t, hub.ListAgents()[0].Config.Pending())
+}
+
+func TestHub_OffersRelease(t *testing.T) {
+	hub := NewHub(nil, nil, nil, nil)
+	release := &domain.AgentUpdate{Version: "v2", URL: "https://example.com/wmap-agent", SHA256: strings.Repeat("ab", 32), Signature: "c2ln"}
+
+	current, disconnectCurrent := hub.Connect(domain.AgentInfo{ID: "pi-1", Version: "v2", AutoUpdate: true})
+	defer disconnectCurrent()
+	pinned, disconnectPinned := hub.Connect(domain.AgentInfo{ID: "pi-2", Version: "v1"})
+	defer disconnectPinned()
+	outdated, disconnectOutdated := hub.Connect(domain.AgentInfo{ID: "pi-3", Version: "v1", AutoUpdate: true})
+	defer disconnectOutdated()
+
+	hub.SetRelease(release)
+	assert.Equal(t, "v2", hub.Release().Version)
+	assert.Empty(t, current, "already on the release")
+	assert.Empty(t, pinned, "does not accept updates")
+	cmd := <-outdated
+	assert.Equal(t, domain.AgentCommandUpdate, cmd.Action)
+	var offered domain.AgentUpdate
+	require.NoError(t, json.Unmarshal(cmd.Config, &offered))
+	assert.Equal(t, *release, offered)
+
+	hub.UpdateFailed("pi-3", "v2", "digest mismatch")
+	for _, agent := range hub.ListAgents() {
+		if agent.ID == "pi-3" {
+			assert.Equal(t, "v2: digest mismatch", agent.UpdateError)
+		}
+	}
+
+	// Offered again on reconnecting
+	again, disconnect := hub.Connect(domain.AgentInfo{ID: "pi-3", Version: "v1", AutoUpdate: true})
+	defer disconnect()
+	assert.Equal(t, domain.AgentCommandUpdate, (<-again).Action)
+}
@@ -0,0 +1,79 @@
+package agents
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// LoadRelease reads a release advertisement, as written by
+// tools/agent_release, from a JSON file.
+func LoadRelease(path string) (*domain.AgentUpdate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var release domain.AgentUpdate
+	if err := json.Unmarshal(data, &release); err != nil {
+		return nil, fmt.Errorf("invalid release file %s: %w", path, err)
+	}
+	if err := release.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid release file %s: %w", path, err)
+	}
+	return &release, nil
+}
+
+// SetRelease sets the agent release advertised to agents that accept updates,
+// offering it right away to the connected ones on another version. nil stops
+// advertising.
+func (h *Hub) SetRelease(release *domain.AgentUpdate) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.release = release
+	for _, s := range h.sessions {
+		h.offerRelease(s)
+	}
+}
+
+// Release returns the advertised agent release, nil if there is none.
+func (h *Hub) Release() *domain.AgentUpdate {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.release == nil {
+		return nil
+	}
+	release := *h.release
+	return &release
+}
+
+// offerRelease sends the advertised release to an agent that accepts updates
+// and runs an older version. The caller holds h.mu.
+func (h *Hub) offerRelease(s *session) {
+	if h.release == nil || !s.info.AutoUpdate || !h.release.NewerThan(s.info.Version) {
+		return
+	}
+	data, err := json.Marshal(h.release)
+	if err != nil {
+		return
+	}
+	cmd := domain.AgentCommand{Action: domain.AgentCommandUpdate, Config: data}
+	if err := h.send(s.info.ID, cmd); err != nil {
+		log.Printf("Warning: could not offer release %s to agent %s: %v", h.release.Version, s.info.ID, err)
+		return
+	}
+	log.Printf("Offered release %s to agent %s (running %s)", h.release.Version, s.info.ID, s.info.Version)
+}
+
+// UpdateFailed records that an agent could not install a release. It is
+// offered again when the agent reconnects.
+func (h *Hub) UpdateFailed(agentID, version, reason string) {
+	h.mu.Lock()
+	if s, ok := h.sessions[agentID]; ok {
+		s.info.UpdateError = fmt.Sprintf("%s: %s", version, reason)
+	}
+	h.mu.Unlock()
+	h.log(fmt.Sprintf("Agent %s could not update to %s: %s", agentID, version, reason), "warning")
+}
@@ -0,0 +1,337 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Domain errors of the artifact store
+var (
+	ErrArtifactNotFound = errors.New("artifact not found")
+	ErrInvalidName      = errors.New("invalid artifact name")
+	ErrInvalidToken     = errors.New("invalid download token")
+	ErrLinkExpired      = errors.New("download link expired")
+	ErrInvalidTTL       = errors.New("invalid link lifetime")
+	ErrSealed           = errors.New("artifact is encrypted and no key is loaded")
+)
+
+const (
+	// DefaultLinkTTL is the lifetime of a download link when none is given.
+	DefaultLinkTTL = 15 * time.Minute
+	// MaxLinkTTL bounds the lifetime of a download link.
+	MaxLinkTTL = 7 * 24 * time.Hour
+	// DownloadPath is the endpoint serving tokenized downloads.
+	DownloadPath = "/api/artifacts/download"
+
+	// purgeInterval is how often expired artifacts are removed.
+	purgeInterval = time.Hour
+)
+
+// Ensure compliance
+var _ ports.ArtifactManager = (*Store)(nil)
+
+// Store registers the files produced in the active workspace and signs
+// time-limited download links for them. Links are stateless: the token
+// carries the artifact ID and expiry, authenticated with an HMAC.
+type Store struct {
+	repo      ports.ArtifactRepository
+	dir       string
+	linkKey   []byte
+	retention time.Duration
+	sealer    ports.SecretSealer // Opens files sealed at rest
+}
+
+// NewStore creates an artifact store writing managed files into dir. Artifacts
+// expire after retention; zero keeps them until deleted.
+func NewStore(repo ports.ArtifactRepository, dir string, linkKey []byte, retention time.Duration) *Store {
+	return &Store{
+		repo:      repo,
+		dir:       dir,
+		linkKey:   linkKey,
+		retention: retention,
+	}
+}
+
+// SetSealer sets the sealer used to decrypt files registered with the
+// sealed suffix, such as encrypted handshake captures.
+func (s *Store) SetSealer(sealer ports.SecretSealer) {
+	s.sealer = sealer
+}
+
+// Start purges expired artifacts now and then periodically until ctx ends.
+func (s *Store) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(purgeInterval)
+		defer ticker.Stop()
+		for {
+			if n, err := s.Purge(ctx); err != nil {
+				log.Printf("[ARTIFACTS] Purge failed: %v", err)
+			} else if n > 0 {
+				log.Printf("[ARTIFACTS] Purged %d expired artifacts", n)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// StoreArtifact writes data into the store and registers it.
+func (s *Store) StoreArtifact(ctx context.Context, kind domain.ArtifactKind, name string, data []byte) (domain.Artifact, error) {
+	name = filepath.Base(name)
+	if name == "." || name == string(filepath.Separator) {
+		return domain.Artifact{}, ErrInvalidName
+	}
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return domain.Artifact{}, fmt.Errorf("create artifact dir: %w", err)
+	}
+
+	id := uuid.New().String()
+	path := filepath.Join(s.dir, id+"_"+name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return domain.Artifact{}, fmt.Errorf("write artifact: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	artifact := s.newArtifact(id, kind, name, path, int64(len(data)), hex.EncodeToString(sum[:]))
+	artifact.Managed = true
+	if err := s.repo.SaveArtifact(ctx, artifact); err != nil {
+		os.Remove(path)
+		return domain.Artifact{}, fmt.Errorf("register artifact: %w", err)
+	}
+	return artifact, nil
+}
+
+// RegisterArtifact registers an existing file. Registering the same path
+// again refreshes its size and checksum, so files rewritten in place (e.g.
+// handshake captures) keep a single entry.
+func (s *Store) RegisterArtifact(ctx context.Context, kind domain.ArtifactKind, path string) (domain.Artifact, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return domain.Artifact{}, err
+	}
+	size, sum, err := hashFile(path)
+	if err != nil {
+		return domain.Artifact{}, err
+	}
+
+	id := uuid.NewSHA1(uuid.NameSpaceURL, []byte("file://"+path)).String()
+	name := filepath.Base(path)
+	sealed := strings.HasSuffix(name, domain.SealedFileSuffix)
+	artifact := s.newArtifact(id, kind, strings.TrimSuffix(name, domain.SealedFileSuffix), path, size, sum)
+	artifact.Sealed = sealed
+	if err := s.repo.SaveArtifact(ctx, artifact); err != nil {
+		return domain.Artifact{}, fmt.Errorf("register artifact: %w", err)
+	}
+	return artifact, nil
+}
+
+// GetArtifact returns an unexpired artifact by ID.
+func (s *Store) GetArtifact(ctx context.Context, id string) (domain.Artifact, error) {
+	artifact, err := s.repo.GetArtifact(ctx, id)
+	if err != nil || artifact == nil || artifact.IsExpired(time.Now()) {
+		return domain.Artifact{}, ErrArtifactNotFound
+	}
+	return *artifact, nil
+}
+
+// ListArtifacts returns the unexpired artifacts, most recent first.
+func (s *Store) ListArtifacts(ctx context.Context, filter domain.ArtifactFilter) ([]domain.Artifact, error) {
+	list, err := s.repo.ListArtifacts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	live := list[:0]
+	for _, artifact := range list {
+		if !artifact.IsExpired(now) {
+			live = append(live, artifact)
+		}
+	}
+	return live, nil
+}
+
+// OpenArtifact opens the content of an artifact. Sealed files are decrypted
+// in memory; the plaintext never touches the disk.
+func (s *Store) OpenArtifact(ctx context.Context, artifact domain.Artifact) (io.ReadSeekCloser, error) {
+	if !artifact.Sealed {
+		return os.Open(artifact.Path)
+	}
+	if s.sealer == nil {
+		return nil, ErrSealed
+	}
+	data, err := os.ReadFile(artifact.Path)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := s.sealer.OpenBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt artifact: %w", err)
+	}
+	return nopCloser{bytes.NewReader(plain)}, nil
+}
+
+// nopCloser adds a no-op Close to an in-memory reader.
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+// DeleteArtifact unregisters an artifact, deleting its file if the store owns it.
+func (s *Store) DeleteArtifact(ctx context.Context, id string) error {
+	artifact, err := s.repo.GetArtifact(ctx, id)
+	if err != nil || artifact == nil {
+		return ErrArtifactNotFound
+	}
+	return s.remove(ctx, *artifact)
+}
+
+// Purge removes expired artifacts and returns how many were removed.
+func (s *Store) Purge(ctx context.Context) (int, error) {
+	list, err := s.repo.ListArtifacts(ctx, domain.ArtifactFilter{})
+	if err != nil {
+		return 0, err
+	}
+
+	now, purged := time.Now(), 0
+	for _, artifact := range list {
+		if !artifact.IsExpired(now) {
+			continue
+		}
+		if err := s.remove(ctx, artifact); err != nil {
+			log.Printf("[ARTIFACTS] Could not purge %s: %v", artifact.ID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// CreateDownloadLink returns a URL downloading the artifact until ttl elapses.
+func (s *Store) CreateDownloadLink(ctx context.Context, id string, ttl time.Duration) (domain.ArtifactLink, error) {
+	if ttl == 0 {
+		ttl = DefaultLinkTTL
+	}
+	if ttl < 0 || ttl > MaxLinkTTL {
+		return domain.ArtifactLink{}, ErrInvalidTTL
+	}
+	if _, err := s.GetArtifact(ctx, id); err != nil {
+		return domain.ArtifactLink{}, err
+	}
+
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	payload := id + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	token := payload + "." + s.sign(payload)
+	return domain.ArtifactLink{
+		URL:       DownloadPath + "?token=" + url.QueryEscape(token),
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ResolveDownload validates a download token and returns its artifact.
+func (s *Store) ResolveDownload(ctx context.Context, token string) (domain.Artifact, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return domain.Artifact{}, ErrInvalidToken
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(payload))) {
+		return domain.Artifact{}, ErrInvalidToken
+	}
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return domain.Artifact{}, ErrInvalidToken
+	}
+	if time.Now().After(time.Unix(expiry, 0)) {
+		return domain.Artifact{}, ErrLinkExpired
+	}
+	return s.GetArtifact(ctx, parts[0])
+}
+
+func (s *Store) newArtifact(id string, kind domain.ArtifactKind, name, path string, size int64, sum string) domain.Artifact {
+	now := time.Now()
+	artifact := domain.Artifact{
+		ID:        id,
+		Kind:      kind,
+		Name:      name,
+		Path:      path,
+		MimeType:  mimeType(name),
+		Size:      size,
+		SHA256:    sum,
+		CreatedAt: now,
+	}
+	if s.retention > 0 {
+		artifact.ExpiresAt = now.Add(s.retention)
+	}
+	return artifact
+}
+
+func (s *Store) remove(ctx context.Context, artifact domain.Artifact) error {
+	if err := s.repo.DeleteArtifact(ctx, artifact.ID); err != nil {
+		return err
+	}
+	if artifact.Managed {
+		if err := os.Remove(artifact.Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("delete artifact file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.linkKey)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func hashFile(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func mimeType(name string) string {
+	ext := strings.ToLower(filepath.Ext(name))
+	switch ext {
+	case ".pcap", ".pcapng", ".cap":
+		return "application/vnd.tcpdump.pcap"
+	}
+	if t := mime.TypeByExtension(ext); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
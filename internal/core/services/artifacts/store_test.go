@@ -0,0 +1,180 @@
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryArtifactRepo struct {
+	artifacts map[string]domain.Artifact
+}
+
+func (r *memoryArtifactRepo) SaveArtifact(ctx context.Context, artifact domain.Artifact) error {
+	r.artifacts[artifact.ID] = artifact
+	return nil
+}
+
+func (r *memoryArtifactRepo) GetArtifact(ctx context.Context, id string) (*domain.Artifact, error) {
+	artifact, ok := r.artifacts[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &artifact, nil
+}
+
+func (r *memoryArtifactRepo) ListArtifacts(ctx context.Context, filter domain.ArtifactFilter) ([]domain.Artifact, error) {
+	var list []domain.Artifact
+	for _, artifact := range r.artifacts {
+		if filter.Kind == "" || artifact.Kind == filter.Kind {
+			list = append(list, artifact)
+		}
+	}
+	return list, nil
+}
+
+func (r *memoryArtifactRepo) DeleteArtifact(ctx context.Context, id string) error {
+	delete(r.artifacts, id)
+	return nil
+}
+
+func newTestStore(t *testing.T, retention time.Duration) (*Store, *memoryArtifactRepo) {
+	repo := &memoryArtifactRepo{artifacts: make(map[string]domain.Artifact)}
+	return NewStore(repo, filepath.Join(t.TempDir(), "artifacts"), []byte("test-link-key"), retention), repo
+}
+
+func TestStoreArtifact(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestStore(t, time.Hour)
+
+	artifact, err := store.StoreArtifact(ctx, domain.ArtifactReport, "../../report.html", []byte("<html></html>"))
+	require.NoError(t, err)
+	assert.Equal(t, "report.html", artifact.Name)
+	assert.Equal(t, int64(13), artifact.Size)
+	assert.True(t, strings.HasPrefix(artifact.MimeType, "text/html"))
+	assert.True(t, artifact.Managed)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), artifact.ExpiresAt, time.Second)
+
+	data, err := os.ReadFile(artifact.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "<html></html>", string(data))
+
+	require.NoError(t, store.DeleteArtifact(ctx, artifact.ID))
+	_, err = os.Stat(artifact.Path)
+	assert.True(t, os.IsNotExist(err))
+	assert.ErrorIs(t, store.DeleteArtifact(ctx, artifact.ID), ErrArtifactNotFound)
+}
+
+func TestRegisterArtifact(t *testing.T) {
+	ctx := context.Background()
+	store, repo := newTestStore(t, 0)
+
+	path := filepath.Join(t.TempDir(), "00_11_22_33_44_55_Corp_PMKID.pcap")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0600))
+	first, err := store.RegisterArtifact(ctx, domain.ArtifactHandshake, path)
+	require.NoError(t, err)
+	assert.False(t, first.Managed)
+	assert.True(t, first.ExpiresAt.IsZero())
+	assert.Equal(t, "application/vnd.tcpdump.pcap", first.MimeType)
+
+	// Rewriting the capture updates the same entry
+	require.NoError(t, os.WriteFile(path, []byte("second capture"), 0600))
+	second, err := store.RegisterArtifact(ctx, domain.ArtifactHandshake, path)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.NotEqual(t, first.SHA256, second.SHA256)
+	assert.Len(t, repo.artifacts, 1)
+
+	// Unmanaged files are left on disk
+	require.NoError(t, store.DeleteArtifact(ctx, second.ID))
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+
+	_, err = store.RegisterArtifact(ctx, domain.ArtifactPcap, filepath.Join(t.TempDir(), "missing.pcap"))
+	assert.Error(t, err)
+}
+
+func TestDownloadLinks(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestStore(t, 0)
+
+	artifact, err := store.StoreArtifact(ctx, domain.ArtifactExport, "devices.csv", []byte("mac\n"))
+	require.NoError(t, err)
+
+	link, err := store.CreateDownloadLink(ctx, artifact.ID, 0)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(DefaultLinkTTL), link.ExpiresAt, time.Second)
+
+	parsed, err := url.Parse(link.URL)
+	require.NoError(t, err)
+	assert.Equal(t, DownloadPath, parsed.Path)
+	assert.Equal(t, link.Token, parsed.Query().Get("token"))
+
+	resolved, err := store.ResolveDownload(ctx, link.Token)
+	require.NoError(t, err)
+	assert.Equal(t, artifact.ID, resolved.ID)
+
+	t.Run("Tampered token", func(t *testing.T) {
+		parts := strings.Split(link.Token, ".")
+		forged := parts[0] + "." + "9999999999" + "." + parts[2]
+		_, err := store.ResolveDownload(ctx, forged)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		_, err = store.ResolveDownload(ctx, "garbage")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Other key", func(t *testing.T) {
+		other := NewStore(store.repo, store.dir, []byte("other-key"), 0)
+		_, err := other.ResolveDownload(ctx, link.Token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Expired link", func(t *testing.T) {
+		payload := artifact.ID + ".1"
+		_, err := store.ResolveDownload(ctx, payload+"."+store.sign(payload))
+		assert.ErrorIs(t, err, ErrLinkExpired)
+	})
+
+	t.Run("Invalid TTL", func(t *testing.T) {
+		_, err := store.CreateDownloadLink(ctx, artifact.ID, MaxLinkTTL+time.Second)
+		assert.ErrorIs(t, err, ErrInvalidTTL)
+		_, err = store.CreateDownloadLink(ctx, "missing", time.Minute)
+		assert.ErrorIs(t, err, ErrArtifactNotFound)
+	})
+}
+
+func TestPurge(t *testing.T) {
+	ctx := context.Background()
+	store, repo := newTestStore(t, time.Hour)
+
+	kept, err := store.StoreArtifact(ctx, domain.ArtifactReport, "kept.pdf", []byte("pdf"))
+	require.NoError(t, err)
+	expired, err := store.StoreArtifact(ctx, domain.ArtifactReport, "old.pdf", []byte("pdf"))
+	require.NoError(t, err)
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	repo.artifacts[expired.ID] = expired
+
+	// Expired artifacts are hidden before they are purged
+	list, err := store.ListArtifacts(ctx, domain.ArtifactFilter{})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, kept.ID, list[0].ID)
+	_, err = store.GetArtifact(ctx, expired.ID)
+	assert.ErrorIs(t, err, ErrArtifactNotFound)
+
+	purged, err := store.Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.NotContains(t, repo.artifacts, expired.ID)
+	_, err = os.Stat(expired.Path)
+	assert.True(t, os.IsNotExist(err))
+}
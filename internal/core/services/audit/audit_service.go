@@ -24,7 +24,10 @@ func (s *AuditService) Log(ctx context.Context, action domain.AuditAction, targe
 	username := "system"
 
 	// Try to extract user from context if we set it up properly in domain
-	if u, ok := ctx.Value("audit_user").(domain.User); ok {
+	if u, ok := domain.UserFromContext(ctx); ok {
+		userID = u.ID
+		username = u.Username
+	} else if u, ok := ctx.Value("audit_user").(domain.User); ok {
 		userID = u.ID
 		username = u.Username
 	} else if uPtr, ok := ctx.Value("audit_user").(*domain.User); ok {
@@ -32,8 +35,10 @@ func (s *AuditService) Log(ctx context.Context, action domain.AuditAction, targe
 		username = uPtr.Username
 	}
 
+	ip, _ := domain.ClientIPFromContext(ctx)
+
 	// Use Domain Factory to ensure business rules
-	entry, err := domain.NewAuditLog(userID, username, action, target, details, "") // IP extraction could be added here
+	entry, err := domain.NewAuditLog(userID, username, action, target, details, ip)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// apiKeyPrefix starts every API key secret, so that keys leaked in logs or
+// repositories are easy to recognize.
+const apiKeyPrefix = "wmap_"
+
+// lastUsedInterval limits how often the last use of a key or session is
+// written back.
+const lastUsedInterval = time.Minute
+
+var ErrAPIKeysUnavailable = errors.New("API keys not available")
+
+// Ensure compliance
+var (
+	_ ports.APIKeyValidator = (*AuthService)(nil)
+	_ ports.APIKeyManager   = (*AuthService)(nil)
+)
+
+// SetAPIKeyStore enables API keys, stored in keys.
+func (s *AuthService) SetAPIKeyStore(keys ports.APIKeyRepository) {
+	s.keys = keys
+}
+
+// CreateAPIKey issues a key owned by the user in the context. The scope may
+// not exceed the owner's role.
+func (s *AuthService) CreateAPIKey(ctx context.Context, name string, scope domain.APIKeyScope, expiresAt *time.Time) (domain.APIKey, string, error) {
+	if s.keys == nil {
+		return domain.APIKey{}, "", ErrAPIKeysUnavailable
+	}
+	owner, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return domain.APIKey{}, "", ErrInvalidSession
+	}
+
+	key := domain.APIKey{
+		ID:        uuid.New().String(),
+		Name:      strings.TrimSpace(name),
+		Scope:     scope,
+		UserID:    owner.ID,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt,
+	}
+	if err := key.Validate(); err != nil {
+		return domain.APIKey{}, "", err
+	}
+	if !owner.Role.Allows(scope.Role()) {
+		return domain.APIKey{}, "", fmt.Errorf("%w: %s keys require the %s role", domain.ErrInvalidAPIKeyScope, scope, scope.Role())
+	}
+
+	secret, err := generateAPIKey()
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+	key.Prefix = secret[:len(apiKeyPrefix)+6]
+	key.Hash = hashToken(secret)
+
+	if err := s.keys.SaveAPIKey(ctx, key); err != nil {
+		return domain.APIKey{}, "", fmt.Errorf("failed to save API key: %w", err)
+	}
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionAPIKeyCreate, key.Name, fmt.Sprintf("API key %s (%s scope) issued", key.Prefix, key.Scope))
+	}
+	return key, secret, nil
+}
+
+// ListAPIKeys returns the keys of the user in the context, or every key for
+// admins.
+func (s *AuthService) ListAPIKeys(ctx context.Context) ([]domain.APIKey, error) {
+	if s.keys == nil {
+		return nil, ErrAPIKeysUnavailable
+	}
+	owner, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return nil, ErrInvalidSession
+	}
+	if owner.IsAdmin() {
+		return s.keys.ListAPIKeys(ctx, "")
+	}
+	return s.keys.ListAPIKeys(ctx, owner.ID)
+}
+
+// RevokeAPIKey deletes a key of the user in the context. Admins may revoke
+// any key.
+func (s *AuthService) RevokeAPIKey(ctx context.Context, id string) error {
+	keys, err := s.ListAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if key.ID != id {
+			continue
+		}
+		if err := s.keys.DeleteAPIKey(ctx, id); err != nil {
+			return err
+		}
+		if s.audit != nil {
+			s.audit.Log(ctx, domain.ActionAPIKeyRevoke, key.Name, fmt.Sprintf("API key %s revoked", key.Prefix))
+		}
+		return nil
+	}
+	return domain.ErrAPIKeyNotFound
+}
+
+// ValidateAPIKey returns the owner of the key, with the role of the key's
+// scope. A key never grants more than its owner currently has, so keys of a
+// demoted user lose the permissions the user lost.
+func (s *AuthService) ValidateAPIKey(ctx context.Context, secret string) (*domain.User, error) {
+	if s.keys == nil || !strings.HasPrefix(secret, apiKeyPrefix) {
+		return nil, ErrInvalidCredentials
+	}
+	key, err := s.keys.GetAPIKeyByHash(ctx, hashToken(secret))
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	now := time.Now().UTC()
+	if key.Expired(now) {
+		return nil, domain.ErrAPIKeyExpired
+	}
+
+	owner, err := s.repo.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	if owner.Disabled {
+		return nil, ErrInvalidCredentials
+	}
+	user := *owner
+	if role := key.Scope.Role(); user.Role.Allows(role) {
+		user.Role = role
+	}
+
+	if now.Sub(key.LastUsed) > lastUsedInterval {
+		key.LastUsed = now
+		if err := s.keys.SaveAPIKey(ctx, *key); err != nil {
+			log.Printf("Failed to record the use of API key %s: %v", key.ID, err)
+		}
+	}
+	return &user, nil
+}
+
+// generateAPIKey returns a new secret: the key prefix and 32 random bytes.
+func generateAPIKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken hashes a secret for storage. The secrets are random, so a fast
+// hash is enough and lets keys be looked up by hash.
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryKeyStore implements ports.APIKeyRepository in memory.
+type memoryKeyStore struct {
+	keys map[string]domain.APIKey
+}
+
+func (m *memoryKeyStore) SaveAPIKey(_ context.Context, key domain.APIKey) error {
+	m.keys[key.ID] = key
+	return nil
+}
+
+func (m *memoryKeyStore) GetAPIKeyByHash(_ context.Context, hash string) (*domain.APIKey, error) {
+	for _, key := range m.keys {
+		if key.Hash == hash {
+			return &key, nil
+		}
+	}
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+func (m *memoryKeyStore) ListAPIKeys(_ context.Context, userID string) ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	for _, key := range m.keys {
+		if userID == "" || key.UserID == userID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *memoryKeyStore) DeleteAPIKey(_ context.Context, id string) error {
+	delete(m.keys, id)
+	return nil
+}
+
+func TestAuthService_APIKeys(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	svc := NewAuthService(mockRepo)
+	store := &memoryKeyStore{keys: map[string]domain.APIKey{}}
+	svc.SetAPIKeyStore(store)
+
+	operator := &domain.User{ID: "u-1", Username: "ci", Role: domain.RoleOperator}
+	mockRepo.On("GetByID", context.Background(), "u-1").Return(operator, nil)
+	ctx := domain.ContextWithUser(context.Background(), operator)
+
+	t.Run("scope bounded by role", func(t *testing.T) {
+		_, _, err := svc.CreateAPIKey(ctx, "escalate", domain.APIKeyScopeAdmin, nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKeyScope)
+	})
+
+	t.Run("read key acts as viewer", func(t *testing.T) {
+		key, secret, err := svc.CreateAPIKey(ctx, "dashboards", domain.APIKeyScopeRead, nil)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(secret, key.Prefix))
+		assert.NotContains(t, key.Hash, secret)
+
+		user, err := svc.ValidateAPIKey(context.Background(), secret)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleViewer, user.Role)
+		assert.Equal(t, "ci", user.Username)
+	})
+
+	t.Run("expired and revoked keys", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+		_, secret, err := svc.CreateAPIKey(ctx, "old", domain.APIKeyScopeAttack, &past)
+		require.NoError(t, err)
+		_, err = svc.ValidateAPIKey(context.Background(), secret)
+		assert.ErrorIs(t, err, domain.ErrAPIKeyExpired)
+
+		key, secret, err := svc.CreateAPIKey(ctx, "nightly", domain.APIKeyScopeAttack, nil)
+		require.NoError(t, err)
+		require.NoError(t, svc.RevokeAPIKey(ctx, key.ID))
+		_, err = svc.ValidateAPIKey(context.Background(), secret)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("cannot revoke keys of others", func(t *testing.T) {
+		store.keys["other"] = domain.APIKey{ID: "other", Name: "admin", UserID: "u-2", Scope: domain.APIKeyScopeAdmin}
+		assert.ErrorIs(t, svc.RevokeAPIKey(ctx, "other"), domain.ErrAPIKeyNotFound)
+	})
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sync"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,73 +15,111 @@ import (
 
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
-	ErrUserNotFound       = errors.New("user not found")
+	ErrUserNotFound       = domain.ErrUserNotFound
 	ErrTokenExpired       = errors.New("token expired")
-	ErrRateLimitExceeded  = errors.New("rate limit exceeded")
 	ErrInvalidSession     = errors.New("invalid session")
 )
 
-// Session represents an active user session.
-type Session struct {
-	UserID    string
-	Role      domain.Role
-	ExpiresAt time.Time
-}
-
 // AuthService implements ports.AuthService.
 // It coordinates credentials validation and session management.
 type AuthService struct {
-	repo          ports.UserRepository
-	sessions      map[string]Session
-	loginAttempts map[string]int
-	mu            sync.RWMutex
-	sessionTTL    time.Duration
+	repo        ports.UserRepository
+	keys        ports.APIKeyRepository    // Optional, set when API keys are enabled
+	shares      ports.ShareLinkRepository // Optional, set when share links are enabled
+	audit       ports.AuditService        // Optional, records logins and account changes
+	sealer      ports.SecretSealer        // Optional, encrypts TOTP secrets at rest
+	directory   ports.Directory           // Optional, authenticates users without a local account
+	groupRoles  domain.GroupRoles         // Roles of external users by group
+	requireTOTP bool                      // Admins must enroll in two-factor authentication
+	sessions    ports.SessionRepository   // In memory until SetSessionStore
+	guard       *loginGuard
+	sessionTTL  time.Duration
 }
 
 // NewAuthService creates a new authentication service instance.
 func NewAuthService(repo ports.UserRepository) *AuthService {
 	return &AuthService{
-		repo:          repo,
-		sessions:      make(map[string]Session),
-		loginAttempts: make(map[string]int),
-		sessionTTL:    24 * time.Hour,
+		repo:       repo,
+		sessions:   newMemorySessionStore(),
+		guard:      newLoginGuard(),
+		sessionTTL: 24 * time.Hour,
 	}
 }
 
-// Login validates user credentials and returns a session token.
+// SetAuditService records logins, lockouts and API key changes in audit.
+func (s *AuthService) SetAuditService(audit ports.AuditService) {
+	s.audit = audit
+}
+
+// Login validates user credentials and returns a session token. Accounts and
+// client addresses with repeated failures are locked out for a while, and
+// every failure and lockout is audited.
 func (s *AuthService) Login(ctx context.Context, creds domain.Credentials) (string, error) {
-	if err := s.checkRateLimit(creds.Username); err != nil {
-		return "", err
+	accountKey := "account:" + creds.Username
+	keys := []string{accountKey}
+	clientKey := ""
+	if ip, ok := domain.ClientIPFromContext(ctx); ok {
+		clientKey = "client:" + ip
+		keys = append(keys, clientKey)
+	}
+	if until := s.guard.lockedUntil(keys...); !until.IsZero() {
+		return "", &domain.LoginLockedError{Until: until}
 	}
 
 	user, err := s.repo.GetByUsername(ctx, creds.Username)
+	switch {
+	case s.directory != nil && (err != nil || user.Source == domain.AuthSourceLDAP):
+		user, err = s.directoryLogin(ctx, creds)
+	case err == nil && user.External():
+		err = domain.ErrExternalAccount
+	case err == nil:
+		err = s.verifyPassword(user.PasswordHash, creds.Password)
+	}
+	if err == nil && user.Disabled {
+		err = errors.New("account disabled")
+	}
+	if err == nil && user.TOTPEnabled {
+		// The password was right: ask for the second factor without
+		// counting a failure, then check it like the password
+		if creds.OTP == "" {
+			return "", domain.ErrTOTPRequired
+		}
+		err = s.checkTOTP(user, creds.OTP, true)
+	}
 	if err != nil {
-		s.incrementAttempts(creds.Username)
+		s.loginFailed(ctx, creds.Username, accountKey, clientKey)
 		return "", ErrInvalidCredentials // Generic error to avoid enumeration
 	}
 
-	if err := s.verifyPassword(user.PasswordHash, creds.Password); err != nil {
-		s.incrementAttempts(creds.Username)
-		return "", ErrInvalidCredentials
-	}
+	s.guard.reset(accountKey)
 
-	s.resetAttempts(creds.Username)
+	// Passwords set before the policy, such as the default admin password,
+	// must be replaced before the account can be used
+	if !user.External() && domain.ValidatePassword(creds.Password, user.Username) != nil {
+		user.MustChangePassword = true
+	}
+	user.UpdateLastLogin()
+	if err := s.repo.Save(ctx, *user); err != nil {
+		return "", fmt.Errorf("failed to save user: %w", err)
+	}
+	if s.audit != nil {
+		s.audit.Log(domain.ContextWithUser(ctx, user), domain.ActionLogin, user.Username, "Login succeeded")
+	}
 
-	return s.createSession(user)
+	return s.createSession(ctx, user)
 }
 
 // ValidateToken verifies a session token and returns the associated user.
+// Revoked sessions are no longer found.
 func (s *AuthService) ValidateToken(ctx context.Context, token string) (*domain.User, error) {
-	s.mu.RLock()
-	session, ok := s.sessions[token]
-	s.mu.RUnlock()
-
-	if !ok {
+	session, err := s.sessions.GetSessionByHash(ctx, hashToken(token))
+	if err != nil {
 		return nil, ErrInvalidSession
 	}
 
-	if time.Now().After(session.ExpiresAt) {
-		s.Logout(ctx, token)
+	now := time.Now()
+	if session.Expired(now) {
+		s.sessions.DeleteSession(ctx, session.ID)
 		return nil, ErrTokenExpired
 	}
 
@@ -89,20 +127,59 @@ func (s *AuthService) ValidateToken(ctx context.Context, token string) (*domain.
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve user: %w", err)
 	}
+	if user.Disabled {
+		s.sessions.DeleteSession(ctx, session.ID)
+		return nil, ErrInvalidSession
+	}
 
+	if now.Sub(session.LastSeen) > lastUsedInterval {
+		session.LastSeen = now
+		s.sessions.SaveSession(ctx, *session)
+	}
 	return user, nil
 }
 
 // Logout invalidates a session token.
 func (s *AuthService) Logout(ctx context.Context, token string) error {
-	s.mu.Lock()
-	delete(s.sessions, token)
-	s.mu.Unlock()
-	return nil
+	session, err := s.sessions.GetSessionByHash(ctx, hashToken(token))
+	if err != nil {
+		return nil
+	}
+	return s.sessions.DeleteSession(ctx, session.ID)
 }
 
-// CreateUser provision a new user with a hashed password.
-func (s *AuthService) CreateUser(ctx context.Context, user domain.User, password string) error {
+// ProvisionDefaultAdmin creates an admin account with a well-known
+// password, which must be changed at the first login.
+func (s *AuthService) ProvisionDefaultAdmin(ctx context.Context, username, password string) error {
+	return s.saveNewUser(ctx, domain.User{
+		Username:           username,
+		Role:               domain.RoleAdmin,
+		MustChangePassword: true,
+	}, password)
+}
+
+// Private helpers
+
+// loginFailed counts a failed login against the account and the client, and
+// audits it along with any lockout it starts.
+func (s *AuthService) loginFailed(ctx context.Context, username, accountKey, clientKey string) {
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionLoginFailed, username, "Invalid credentials")
+	}
+	if until, locked := s.guard.fail(accountKey, maxAccountFailures); locked && s.audit != nil {
+		s.audit.Log(ctx, domain.ActionLoginLocked, username, fmt.Sprintf("Account locked until %s after %d failed logins", until.Format(time.RFC3339), maxAccountFailures))
+	}
+	if clientKey == "" {
+		return
+	}
+	if until, locked := s.guard.fail(clientKey, maxClientFailures); locked && s.audit != nil {
+		ip, _ := domain.ClientIPFromContext(ctx)
+		s.audit.Log(ctx, domain.ActionLoginLocked, ip, fmt.Sprintf("Client locked until %s after %d failed logins", until.Format(time.RFC3339), maxClientFailures))
+	}
+}
+
+// saveNewUser stores user with a hash of password.
+func (s *AuthService) saveNewUser(ctx context.Context, user domain.User, password string) error {
 	hash, err := s.hashPassword(password)
 	if err != nil {
 		return err
@@ -110,6 +187,7 @@ func (s *AuthService) CreateUser(ctx context.Context, user domain.User, password
 
 	user.PasswordHash = hash
 	user.CreatedAt = time.Now()
+	user.PasswordChangedAt = user.CreatedAt
 
 	if user.ID == "" {
 		user.ID = uuid.New().String()
@@ -118,27 +196,11 @@ func (s *AuthService) CreateUser(ctx context.Context, user domain.User, password
 	return s.repo.Save(ctx, user)
 }
 
-// Private helpers
-
-func (s *AuthService) checkRateLimit(username string) error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	if s.loginAttempts[username] >= 5 {
-		return ErrRateLimitExceeded
+// dropSessions ends every session of a user.
+func (s *AuthService) dropSessions(userID string) {
+	if _, err := s.sessions.DeleteUserSessions(context.Background(), userID); err != nil {
+		log.Printf("Failed to end the sessions of user %s: %v", userID, err)
 	}
-	return nil
-}
-
-func (s *AuthService) incrementAttempts(username string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.loginAttempts[username]++
-}
-
-func (s *AuthService) resetAttempts(username string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	delete(s.loginAttempts, username)
 }
 
 func (s *AuthService) verifyPassword(hash, password string) error {
@@ -153,16 +215,29 @@ func (s *AuthService) hashPassword(password string) (string, error) {
 	return string(hash), nil
 }
 
-func (s *AuthService) createSession(user *domain.User) (string, error) {
+// createSession stores a new session of user, with the client it logged in
+// from, and returns its token.
+func (s *AuthService) createSession(ctx context.Context, user *domain.User) (string, error) {
 	token := uuid.New().String()
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	now := time.Now()
+	ip, _ := domain.ClientIPFromContext(ctx)
 
-	s.sessions[token] = Session{
+	// Logins are rare enough to prune the expired sessions
+	if err := s.sessions.DeleteExpiredSessions(ctx, now); err != nil {
+		log.Printf("Failed to delete expired sessions: %v", err)
+	}
+	err := s.sessions.SaveSession(ctx, domain.Session{
+		ID:        uuid.New().String(),
+		TokenHash: hashToken(token),
 		UserID:    user.ID,
-		Role:      user.Role,
-		ExpiresAt: time.Now().Add(s.sessionTTL),
+		ClientIP:  ip,
+		UserAgent: domain.UserAgentFromContext(ctx),
+		CreatedAt: now,
+		LastSeen:  now,
+		ExpiresAt: now.Add(s.sessionTTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to save session: %w", err)
 	}
-
 	return token, nil
 }
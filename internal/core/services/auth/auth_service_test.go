@@ -45,6 +45,11 @@ func (m *MockUserRepository) List(ctx context.Context) ([]domain.User, error) {
 	return args.Get(0).([]domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func TestAuthService_Login(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	svc := NewAuthService(mockRepo)
@@ -62,10 +67,12 @@ func TestAuthService_Login(t *testing.T) {
 
 	// 1. Success
 	mockRepo.On("GetByUsername", ctx, "admin").Return(user, nil)
+	mockRepo.On("Save", ctx, mock.Anything).Return(nil)
 
 	token, err := svc.Login(ctx, domain.Credentials{Username: "admin", Password: "secret123"})
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
+	assert.True(t, user.MustChangePassword, "a password below the policy must be changed")
 
 	// 2. Wrong Password
 	mockRepo.On("GetByUsername", ctx, "admin_fail").Return(user, nil)
@@ -91,6 +98,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	user := &domain.User{ID: "u-1", Username: "user", PasswordHash: string(hashed)}
 
 	mockRepo.On("GetByUsername", ctx, "user").Return(user, nil)
+	mockRepo.On("Save", ctx, mock.Anything).Return(nil)
 
 	token, _ := svc.Login(ctx, domain.Credentials{Username: "user", Password: "pass"})
 
@@ -120,7 +128,9 @@ func TestAuthService_CreateUser(t *testing.T) {
 		return u.Username == "newuser" && len(u.PasswordHash) > 0 && u.ID != ""
 	})).Return(nil)
 
-	err := svc.CreateUser(ctx, newUser, "password")
+	mockRepo.On("GetByUsername", ctx, "newuser").Return(nil, domain.ErrUserNotFound)
+
+	err := svc.CreateUser(ctx, newUser, "Corr3ct-Horse-Battery")
 	assert.NoError(t, err)
 
 	mockRepo.AssertExpectations(t)
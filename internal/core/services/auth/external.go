@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Ensure compliance
+var _ ports.ExternalLogin = (*AuthService)(nil)
+
+// SetGroupRoles maps the groups reported by identity providers to roles.
+// External users in none of the groups cannot log in.
+func (s *AuthService) SetGroupRoles(roles domain.GroupRoles) {
+	s.groupRoles = roles
+}
+
+// SetDirectory authenticates password logins of users without a local
+// account against directory.
+func (s *AuthService) SetDirectory(directory ports.Directory) {
+	s.directory = directory
+}
+
+// LoginExternal returns a session token for a user authenticated by an
+// identity provider, which is trusted for the second factor too.
+func (s *AuthService) LoginExternal(ctx context.Context, identity domain.ExternalIdentity) (string, error) {
+	user, err := s.provisionExternal(ctx, identity)
+	if err == nil && user.Disabled {
+		err = errors.New("account disabled")
+	}
+	if err != nil {
+		if s.audit != nil {
+			s.audit.Log(ctx, domain.ActionLoginFailed, identity.Username, fmt.Sprintf("%s login refused: %v", identity.Source, err))
+		}
+		return "", err
+	}
+
+	user.UpdateLastLogin()
+	if err := s.repo.Save(ctx, *user); err != nil {
+		return "", fmt.Errorf("failed to save user: %w", err)
+	}
+	if s.audit != nil {
+		s.audit.Log(domain.ContextWithUser(ctx, user), domain.ActionLogin, user.Username, "Login succeeded through "+identity.Source)
+	}
+	return s.createSession(ctx, user)
+}
+
+// directoryLogin checks creds against the directory and returns the account
+// of the user.
+func (s *AuthService) directoryLogin(ctx context.Context, creds domain.Credentials) (*domain.User, error) {
+	identity, err := s.directory.Authenticate(ctx, creds.Username, creds.Password)
+	if err != nil {
+		log.Printf("Directory login of %q failed: %v", creds.Username, err)
+		return nil, err
+	}
+	return s.provisionExternal(ctx, *identity)
+}
+
+// provisionExternal returns the account of identity, created at the first
+// login. The role follows the groups at every login, so users removed from
+// the mapped groups lose access. Local accounts are never taken over.
+func (s *AuthService) provisionExternal(ctx context.Context, identity domain.ExternalIdentity) (*domain.User, error) {
+	role, roleErr := s.groupRoles.Role(identity.Groups)
+
+	user, err := s.repo.GetByUsername(ctx, identity.Username)
+	if err != nil {
+		if roleErr != nil {
+			return nil, roleErr
+		}
+		user = &domain.User{
+			ID:        uuid.New().String(),
+			Username:  identity.Username,
+			Role:      role,
+			Source:    identity.Source,
+			CreatedAt: time.Now(),
+		}
+		if err := user.Validate(); err != nil {
+			return nil, err
+		}
+		if s.audit != nil {
+			s.audit.Log(ctx, domain.ActionUserCreate, user.Username, fmt.Sprintf("User provisioned by %s with role %s", identity.Source, role))
+		}
+		return user, nil
+	}
+
+	if user.Source != identity.Source {
+		return nil, fmt.Errorf("%w: %s is not a %s account", domain.ErrUserExists, user.Username, identity.Source)
+	}
+	if roleErr != nil {
+		return nil, roleErr
+	}
+	if user.Role != role {
+		if s.audit != nil {
+			s.audit.Log(ctx, domain.ActionUserUpdate, user.Username, fmt.Sprintf("Role %s from %s groups, was %s", role, identity.Source, user.Role))
+		}
+		user.Role = role
+	}
+	return user, nil
+}
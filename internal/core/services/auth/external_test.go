@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDirectory knows one password per user.
+type fakeDirectory map[string]struct {
+	password string
+	groups   []string
+}
+
+func (d fakeDirectory) Authenticate(ctx context.Context, username, password string) (*domain.ExternalIdentity, error) {
+	entry, ok := d[username]
+	if !ok || entry.password != password {
+		return nil, errors.New("invalid credentials")
+	}
+	return &domain.ExternalIdentity{Source: domain.AuthSourceLDAP, Username: username, Groups: entry.groups}, nil
+}
+
+func TestAuthService_ExternalLogin(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	svc := NewAuthService(mockRepo)
+	ctx := context.Background()
+
+	roles, err := domain.ParseGroupRoles("wmap-admins=admin, WMAP-Ops=operator")
+	require.NoError(t, err)
+	svc.SetGroupRoles(roles)
+	svc.SetDirectory(fakeDirectory{
+		"alice":   {"S3cret", []string{"wmap-ops"}},
+		"mallory": {"S3cret", []string{"staff"}},
+		"root":    {"S3cret", []string{"wmap-admins"}},
+	})
+
+	local := &domain.User{ID: "a-1", Username: "root", Role: domain.RoleAdmin}
+	sso := &domain.User{ID: "o-1", Username: "bob", Role: domain.RoleViewer, Source: domain.AuthSourceOIDC}
+	mockRepo.On("GetByUsername", mock.Anything, "root").Return(local, nil)
+	mockRepo.On("GetByUsername", mock.Anything, "bob").Return(sso, nil)
+	mockRepo.On("GetByID", mock.Anything, "o-1").Return(sso, nil)
+	mockRepo.On("GetByUsername", mock.Anything, mock.Anything).Return(nil, domain.ErrUserNotFound)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	t.Run("directory user provisioned with mapped role", func(t *testing.T) {
+		_, err := svc.Login(ctx, domain.Credentials{Username: "alice", Password: "S3cret"})
+		require.NoError(t, err)
+
+		saved := mockRepo.Calls[len(mockRepo.Calls)-1].Arguments.Get(1).(domain.User)
+		assert.Equal(t, domain.RoleOperator, saved.Role)
+		assert.Equal(t, domain.AuthSourceLDAP, saved.Source)
+		assert.False(t, saved.MustChangePassword)
+	})
+
+	t.Run("users in no mapped group refused", func(t *testing.T) {
+		_, err := svc.Login(ctx, domain.Credentials{Username: "mallory", Password: "S3cret"})
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("local accounts not taken over", func(t *testing.T) {
+		// The directory is not asked for existing local accounts
+		_, err := svc.Login(ctx, domain.Credentials{Username: "root", Password: "S3cret"})
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+		_, err = svc.LoginExternal(ctx, domain.ExternalIdentity{Source: domain.AuthSourceOIDC, Username: "root", Groups: []string{"wmap-ops"}})
+		assert.ErrorIs(t, err, domain.ErrUserExists)
+	})
+
+	t.Run("provider role follows groups", func(t *testing.T) {
+		token, err := svc.LoginExternal(ctx, domain.ExternalIdentity{Source: domain.AuthSourceOIDC, Username: "bob", Groups: []string{"wmap-ops"}})
+		require.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, domain.RoleOperator, sso.Role)
+
+		_, err = svc.LoginExternal(ctx, domain.ExternalIdentity{Source: domain.AuthSourceOIDC, Username: "bob"})
+		assert.ErrorIs(t, err, domain.ErrNoMappedGroup)
+	})
+
+	t.Run("provider accounts have no local password", func(t *testing.T) {
+		_, err := svc.Login(ctx, domain.Credentials{Username: "bob", Password: ""})
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+		assert.ErrorIs(t, svc.ChangePassword(domain.ContextWithUser(ctx, sso), "", "New-Passw0rd-789"), domain.ErrExternalAccount)
+	})
+}
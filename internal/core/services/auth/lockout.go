@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// Failed logins are counted per account and per client address. Clients get
+// a larger allowance, since assessment teams often share one address behind
+// NAT.
+const (
+	maxAccountFailures = 5
+	maxClientFailures  = 20
+	failureWindow      = 15 * time.Minute
+	lockoutDuration    = 5 * time.Minute
+	maxLockoutDuration = 4 * time.Hour
+	maxTrackedLogins   = 10000 // Stale, then least recently failed, entries are dropped past this many
+)
+
+// loginFailures counts the recent failed logins of an account or client.
+type loginFailures struct {
+	count       int
+	first       time.Time
+	last        time.Time // Of the latest failure
+	lockedUntil time.Time
+	lockouts    int // Consecutive lockouts, each twice as long as the last
+}
+
+// loginGuard locks accounts and clients out after repeated failed logins.
+type loginGuard struct {
+	mu       sync.Mutex
+	failures map[string]*loginFailures
+	now      func() time.Time
+}
+
+func newLoginGuard() *loginGuard {
+	return &loginGuard{
+		failures: make(map[string]*loginFailures),
+		now:      time.Now,
+	}
+}
+
+// lockedUntil returns the latest end of the lockouts of keys, or the zero
+// time when none is locked out.
+func (g *loginGuard) lockedUntil(keys ...string) time.Time {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var until time.Time
+	now := g.now()
+	for _, key := range keys {
+		if f, ok := g.failures[key]; ok && f.lockedUntil.After(now) && f.lockedUntil.After(until) {
+			until = f.lockedUntil
+		}
+	}
+	return until
+}
+
+// fail records a failed login for key, allowed max failures in the window.
+// It returns the end of the lockout when this failure starts one.
+func (g *loginGuard) fail(key string, max int) (time.Time, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	f, ok := g.failures[key]
+	if !ok {
+		if len(g.failures) >= maxTrackedLogins {
+			g.cleanupLocked(now)
+		}
+		// A spray of distinct usernames within the window leaves nothing
+		// stale: the least recently failed entry not locked out makes room
+		if len(g.failures) >= maxTrackedLogins && !g.evictLocked(now) {
+			return time.Time{}, false // Every entry is locked out, they are kept
+		}
+		f = &loginFailures{}
+		g.failures[key] = f
+	}
+	f.last = now
+	if now.Sub(f.first) > failureWindow {
+		f.count, f.first = 0, now
+	}
+	f.count++
+	if f.count < max {
+		return time.Time{}, false
+	}
+
+	lockout := lockoutDuration << f.lockouts
+	if lockout > maxLockoutDuration || lockout <= 0 {
+		lockout = maxLockoutDuration
+	}
+	f.lockouts++
+	f.count, f.first = 0, now
+	f.lockedUntil = now.Add(lockout)
+	return f.lockedUntil, true
+}
+
+// reset forgets the failures of key after a successful login.
+func (g *loginGuard) reset(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.failures, key)
+}
+
+// cleanupLocked forgets keys with no recent failure and no lockout in force
+// for long enough that the next one would not escalate.
+func (g *loginGuard) cleanupLocked(now time.Time) {
+	for key, f := range g.failures {
+		if now.Sub(f.first) > failureWindow && now.After(f.lockedUntil.Add(maxLockoutDuration)) {
+			delete(g.failures, key)
+		}
+	}
+}
+
+// evictLocked forgets the least recently failed key with no lockout in
+// force. It returns false when every key is locked out.
+func (g *loginGuard) evictLocked(now time.Time) bool {
+	var oldest string
+	var oldestAt time.Time
+	for key, f := range g.failures {
+		if f.lockedUntil.After(now) {
+			continue
+		}
+		if oldest == "" || f.last.Before(oldestAt) {
+			oldest, oldestAt = key, f.last
+		}
+	}
+	if oldest == "" {
+		return false
+	}
+	delete(g.failures, oldest)
+	return true
+}
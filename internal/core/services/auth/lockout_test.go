@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthService_LoginLockout(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	svc := NewAuthService(mockRepo)
+	now := time.Now()
+	svc.guard.now = func() time.Time { return now }
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("changeit"), bcrypt.MinCost)
+	admin := &domain.User{ID: "u-1", Username: "admin", PasswordHash: string(hashed), Role: domain.RoleAdmin}
+	mockRepo.On("GetByUsername", mock.Anything, "admin").Return(admin, nil)
+	mockRepo.On("GetByUsername", mock.Anything, mock.Anything).Return(nil, errors.New("user not found"))
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	ctx := domain.ContextWithClientIP(context.Background(), "10.0.0.5")
+	login := func(ctx context.Context, username, password string) error {
+		_, err := svc.Login(ctx, domain.Credentials{Username: username, Password: password})
+		return err
+	}
+
+	t.Run("account locked after repeated failures", func(t *testing.T) {
+		for i := 0; i < maxAccountFailures; i++ {
+			assert.ErrorIs(t, login(ctx, "admin", "wrong"), ErrInvalidCredentials)
+		}
+
+		// Even the right password is refused until the lockout ends
+		err := login(ctx, "admin", "changeit")
+		var locked *domain.LoginLockedError
+		assert.ErrorAs(t, err, &locked)
+		assert.Equal(t, now.Add(lockoutDuration), locked.Until)
+
+		now = now.Add(lockoutDuration + time.Second)
+		assert.NoError(t, login(ctx, "admin", "changeit"))
+	})
+
+	t.Run("lockouts escalate", func(t *testing.T) {
+		for round := 0; round < 2; round++ {
+			for i := 0; i < maxAccountFailures; i++ {
+				login(ctx, "admin", "wrong")
+			}
+			until := svc.guard.lockedUntil("account:admin")
+			assert.Equal(t, now.Add(lockoutDuration<<round), until)
+			now = until.Add(time.Second)
+		}
+	})
+
+	t.Run("client locked across accounts", func(t *testing.T) {
+		scanner := domain.ContextWithClientIP(context.Background(), "10.0.0.66")
+		for i := 0; i < maxClientFailures; i++ {
+			login(scanner, fmt.Sprintf("user%d", i), "guess")
+		}
+		assert.ErrorIs(t, login(scanner, "admin", "changeit"), domain.ErrLoginLocked)
+
+		// Other clients are not affected
+		assert.NoError(t, login(ctx, "admin", "changeit"))
+	})
+}
+
+func TestLoginGuard_Bounded(t *testing.T) {
+	g := newLoginGuard()
+	now := time.Now()
+	g.now = func() time.Time { return now }
+
+	// The client being sprayed from gets locked out and must stay so
+	for i := 0; i < maxClientFailures; i++ {
+		g.fail("client:10.0.0.66", maxClientFailures)
+	}
+	until := g.lockedUntil("client:10.0.0.66")
+	assert.True(t, until.After(now))
+
+	for i := 0; i < maxTrackedLogins+100; i++ {
+		now = now.Add(time.Millisecond)
+		g.fail(fmt.Sprintf("account:user%d", i), maxAccountFailures)
+	}
+	assert.Len(t, g.failures, maxTrackedLogins)
+	assert.Equal(t, until, g.lockedUntil("client:10.0.0.66"))
+	assert.Contains(t, g.failures, fmt.Sprintf("account:user%d", maxTrackedLogins+99))
+	assert.NotContains(t, g.failures, "account:user0")
+}
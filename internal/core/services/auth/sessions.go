@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Ensure compliance
+var (
+	_ ports.SessionManager    = (*AuthService)(nil)
+	_ ports.SessionRepository = (*memorySessionStore)(nil)
+)
+
+// SetSessionStore keeps sessions in store, so they survive restarts and are
+// visible to every instance sharing it. Sessions already issued are lost.
+func (s *AuthService) SetSessionStore(store ports.SessionRepository) {
+	s.sessions = store
+}
+
+// ListSessions returns the active sessions of a user, newest first, marking
+// the one the request comes from.
+func (s *AuthService) ListSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	userID, err := s.sessionOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessions.ListSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	current := hashToken(domain.SessionTokenFromContext(ctx))
+	active := make([]domain.Session, 0, len(sessions))
+	for _, session := range sessions {
+		if session.Expired(now) {
+			continue
+		}
+		session.Current = session.TokenHash == current
+		active = append(active, session)
+	}
+	return active, nil
+}
+
+// RevokeSession ends a session of the user in the context or, for admins,
+// of any user.
+func (s *AuthService) RevokeSession(ctx context.Context, id string) error {
+	caller, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return ErrInvalidSession
+	}
+	// Sessions of other users are reported missing to non-admins
+	owner := caller.ID
+	if caller.IsAdmin() {
+		owner = ""
+	}
+	sessions, err := s.sessions.ListSessions(ctx, owner)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.ID != id {
+			continue
+		}
+		if err := s.sessions.DeleteSession(ctx, id); err != nil {
+			return err
+		}
+		s.auditSessionEnd(ctx, session.UserID, fmt.Sprintf("Session %s from %s revoked", id, session.ClientIP))
+		return nil
+	}
+	return domain.ErrSessionNotFound
+}
+
+// RevokeUserSessions ends every session of a user, logging them out
+// everywhere, and returns how many ended.
+func (s *AuthService) RevokeUserSessions(ctx context.Context, userID string) (int, error) {
+	userID, err := s.sessionOwner(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := s.sessions.DeleteUserSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	s.auditSessionEnd(ctx, userID, fmt.Sprintf("%d sessions revoked", n))
+	return n, nil
+}
+
+// sessionOwner returns the user whose sessions a request is about: the
+// caller when userID is empty, else userID if the caller may manage them.
+func (s *AuthService) sessionOwner(ctx context.Context, userID string) (string, error) {
+	caller, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return "", ErrInvalidSession
+	}
+	if userID == "" || userID == caller.ID {
+		return caller.ID, nil
+	}
+	if !caller.IsAdmin() {
+		return "", domain.ErrUserNotFound
+	}
+	if _, err := s.repo.GetByID(ctx, userID); err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+func (s *AuthService) auditSessionEnd(ctx context.Context, userID, details string) {
+	if s.audit == nil {
+		return
+	}
+	target := userID
+	if user, err := s.repo.GetByID(ctx, userID); err == nil {
+		target = user.Username
+	}
+	s.audit.Log(ctx, domain.ActionSessionEnd, target, details)
+}
+
+// memorySessionStore keeps sessions in memory, for deployments and tests
+// without a session store.
+type memorySessionStore struct {
+	mu       sync.RWMutex
+	sessions map[string]domain.Session // By token hash
+}
+
+func newMemorySessionStore() *memorySessionStore {
+	return &memorySessionStore{sessions: make(map[string]domain.Session)}
+}
+
+func (m *memorySessionStore) SaveSession(ctx context.Context, session domain.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (m *memorySessionStore) GetSessionByHash(ctx context.Context, hash string) (*domain.Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	session, ok := m.sessions[hash]
+	if !ok {
+		return nil, domain.ErrSessionNotFound
+	}
+	return &session, nil
+}
+
+func (m *memorySessionStore) ListSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var sessions []domain.Session
+	for _, session := range m.sessions {
+		if userID == "" || session.UserID == userID {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt.After(sessions[j].CreatedAt) })
+	return sessions, nil
+}
+
+func (m *memorySessionStore) DeleteSession(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, session := range m.sessions {
+		if session.ID == id {
+			delete(m.sessions, hash)
+			return nil
+		}
+	}
+	return domain.ErrSessionNotFound
+}
+
+func (m *memorySessionStore) DeleteUserSessions(ctx context.Context, userID string) (int, error) {
+	return m.deleteWhere(func(session domain.Session) bool { return session.UserID == userID }), nil
+}
+
+func (m *memorySessionStore) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	m.deleteWhere(func(session domain.Session) bool { return session.Expired(now) })
+	return nil
+}
+
+func (m *memorySessionStore) deleteWhere(match func(domain.Session) bool) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for hash, session := range m.sessions {
+		if match(session) {
+			delete(m.sessions, hash)
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthService_Sessions(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	svc := NewAuthService(mockRepo)
+	ctx := domain.ContextWithClientIP(context.Background(), "10.0.0.5")
+
+	admin := &domain.User{ID: "a-1", Username: "root", Role: domain.RoleAdmin}
+	analyst := &domain.User{ID: "u-1", Username: "analyst", Role: domain.RoleViewer}
+	other := &domain.User{ID: "u-2", Username: "intern", Role: domain.RoleViewer}
+	for _, u := range []*domain.User{admin, analyst, other} {
+		mockRepo.On("GetByID", mock.Anything, u.ID).Return(u, nil)
+	}
+
+	laptop, err := svc.createSession(domain.ContextWithUserAgent(ctx, "Firefox"), analyst)
+	require.NoError(t, err)
+	_, err = svc.createSession(ctx, analyst)
+	require.NoError(t, err)
+
+	self := domain.ContextWithSessionToken(domain.ContextWithUser(ctx, analyst), laptop)
+	sessions, err := svc.ListSessions(self, "")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	var current domain.Session
+	for _, s := range sessions {
+		if s.Current {
+			current = s
+		}
+	}
+	assert.Equal(t, "Firefox", current.UserAgent)
+	assert.Equal(t, "10.0.0.5", current.ClientIP)
+
+	t.Run("other users cannot see or revoke", func(t *testing.T) {
+		intern := domain.ContextWithUser(ctx, other)
+		_, err := svc.ListSessions(intern, "u-1")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.ErrorIs(t, svc.RevokeSession(intern, current.ID), domain.ErrSessionNotFound)
+	})
+
+	t.Run("revoked sessions refused", func(t *testing.T) {
+		require.NoError(t, svc.RevokeSession(domain.ContextWithUser(ctx, admin), current.ID))
+		_, err := svc.ValidateToken(ctx, laptop)
+		assert.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("revoke all", func(t *testing.T) {
+		n, err := svc.RevokeUserSessions(domain.ContextWithUser(ctx, admin), "u-1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		sessions, err := svc.ListSessions(self, "")
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+	})
+}
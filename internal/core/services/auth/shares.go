@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// shareTokenPrefix starts every share link token, told apart from API keys.
+const shareTokenPrefix = "wshare_"
+
+var ErrSharesUnavailable = errors.New("share links not available")
+
+// Ensure compliance
+var _ ports.ShareLinkManager = (*AuthService)(nil)
+
+// SetShareStore enables share links, stored in shares.
+func (s *AuthService) SetShareStore(shares ports.ShareLinkRepository) {
+	s.shares = shares
+}
+
+// CreateShareLink issues a read-only link to workspace on behalf of the user
+// in the context.
+func (s *AuthService) CreateShareLink(ctx context.Context, workspace, label string, expiresAt time.Time) (domain.ShareLink, string, error) {
+	if s.shares == nil {
+		return domain.ShareLink{}, "", ErrSharesUnavailable
+	}
+	owner, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return domain.ShareLink{}, "", ErrInvalidSession
+	}
+
+	link := domain.ShareLink{
+		ID:        uuid.New().String(),
+		Workspace: workspace,
+		Label:     strings.TrimSpace(label),
+		CreatedBy: owner.Username,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt.UTC(),
+	}
+	if err := link.Validate(); err != nil {
+		return domain.ShareLink{}, "", err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return domain.ShareLink{}, "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	token := shareTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	link.Prefix = token[:len(shareTokenPrefix)+6]
+	link.Hash = hashToken(token)
+
+	if err := s.shares.SaveShareLink(ctx, link); err != nil {
+		return domain.ShareLink{}, "", fmt.Errorf("failed to save share link: %w", err)
+	}
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionShareCreate, workspace,
+			fmt.Sprintf("Share link %s issued until %s", link.Prefix, link.ExpiresAt.Format(time.RFC3339)))
+	}
+	return link, token, nil
+}
+
+// ListShareLinks returns the links to workspace, or every link when empty.
+func (s *AuthService) ListShareLinks(ctx context.Context, workspace string) ([]domain.ShareLink, error) {
+	if s.shares == nil {
+		return nil, ErrSharesUnavailable
+	}
+	return s.shares.ListShareLinks(ctx, workspace)
+}
+
+// RevokeShareLink deletes a link, which stops working right away.
+func (s *AuthService) RevokeShareLink(ctx context.Context, id string) error {
+	links, err := s.ListShareLinks(ctx, "")
+	if err != nil {
+		return err
+	}
+	for _, link := range links {
+		if link.ID != id {
+			continue
+		}
+		if err := s.shares.DeleteShareLink(ctx, id); err != nil {
+			return err
+		}
+		if s.audit != nil {
+			s.audit.Log(ctx, domain.ActionShareRevoke, link.Workspace, fmt.Sprintf("Share link %s revoked", link.Prefix))
+		}
+		return nil
+	}
+	return domain.ErrShareNotFound
+}
+
+// ResolveShareLink returns the link a token grants.
+func (s *AuthService) ResolveShareLink(ctx context.Context, token string) (*domain.ShareLink, error) {
+	if s.shares == nil || !strings.HasPrefix(token, shareTokenPrefix) {
+		return nil, domain.ErrShareNotFound
+	}
+	link, err := s.shares.GetShareLinkByHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if link.Expired(now) {
+		return nil, domain.ErrShareExpired
+	}
+
+	if now.Sub(link.LastUsed) > lastUsedInterval {
+		link.LastUsed = now
+		s.shares.SaveShareLink(ctx, *link)
+	}
+	return link, nil
+}
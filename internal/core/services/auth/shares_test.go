@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryShareStore implements ports.ShareLinkRepository in memory.
+type memoryShareStore struct {
+	links map[string]domain.ShareLink
+}
+
+func (m *memoryShareStore) SaveShareLink(_ context.Context, link domain.ShareLink) error {
+	m.links[link.ID] = link
+	return nil
+}
+
+func (m *memoryShareStore) GetShareLinkByHash(_ context.Context, hash string) (*domain.ShareLink, error) {
+	for _, link := range m.links {
+		if link.Hash == hash {
+			return &link, nil
+		}
+	}
+	return nil, domain.ErrShareNotFound
+}
+
+func (m *memoryShareStore) ListShareLinks(_ context.Context, workspace string) ([]domain.ShareLink, error) {
+	var links []domain.ShareLink
+	for _, link := range m.links {
+		if workspace == "" || link.Workspace == workspace {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func (m *memoryShareStore) DeleteShareLink(_ context.Context, id string) error {
+	delete(m.links, id)
+	return nil
+}
+
+func TestAuthService_ShareLinks(t *testing.T) {
+	svc := NewAuthService(new(MockUserRepository))
+	store := &memoryShareStore{links: map[string]domain.ShareLink{}}
+	svc.SetShareStore(store)
+
+	operator := &domain.User{ID: "u-1", Username: "alice", Role: domain.RoleOperator}
+	ctx := domain.ContextWithUser(context.Background(), operator)
+
+	t.Run("token resolves to the workspace", func(t *testing.T) {
+		link, token, err := svc.CreateShareLink(ctx, "site-a", " ACME contact ", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(token, link.Prefix))
+		assert.NotContains(t, link.Hash, token)
+		assert.Equal(t, "ACME contact", link.Label)
+		assert.Equal(t, "alice", link.CreatedBy)
+
+		resolved, err := svc.ResolveShareLink(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, "site-a", resolved.Workspace)
+		assert.False(t, store.links[link.ID].LastUsed.IsZero())
+	})
+
+	t.Run("API keys are not share tokens", func(t *testing.T) {
+		_, err := svc.ResolveShareLink(context.Background(), "wmap_secret")
+		assert.ErrorIs(t, err, domain.ErrShareNotFound)
+	})
+
+	t.Run("expiry is bounded", func(t *testing.T) {
+		_, _, err := svc.CreateShareLink(ctx, "site-a", "", time.Now().Add(-time.Minute))
+		assert.ErrorIs(t, err, domain.ErrInvalidShareExpiry)
+		_, _, err = svc.CreateShareLink(ctx, "site-a", "", time.Now().Add(2*domain.MaxShareLifetime))
+		assert.ErrorIs(t, err, domain.ErrInvalidShareExpiry)
+	})
+
+	t.Run("expired and revoked links", func(t *testing.T) {
+		link, token, err := svc.CreateShareLink(ctx, "site-a", "", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		expired := store.links[link.ID]
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		store.links[link.ID] = expired
+		_, err = svc.ResolveShareLink(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrShareExpired)
+
+		link, token, err = svc.CreateShareLink(ctx, "site-b", "", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.NoError(t, svc.RevokeShareLink(ctx, link.ID))
+		_, err = svc.ResolveShareLink(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrShareNotFound)
+		assert.ErrorIs(t, svc.RevokeShareLink(ctx, link.ID), domain.ErrShareNotFound)
+	})
+}
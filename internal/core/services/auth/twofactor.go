@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// recoveryCodeCount is how many recovery codes an enrollment issues.
+const recoveryCodeCount = 10
+
+// Ensure compliance
+var _ ports.TwoFactorManager = (*AuthService)(nil)
+
+// SetSealer enables encryption at rest of the TOTP secrets.
+func (s *AuthService) SetSealer(sealer ports.SecretSealer) {
+	s.sealer = sealer
+}
+
+// RequireAdminTOTP makes two-factor authentication mandatory for admins,
+// who can launch attacks. Admins not enrolled yet are limited to enrolling.
+func (s *AuthService) RequireAdminTOTP(required bool) {
+	s.requireTOTP = required
+}
+
+// EnrollmentRequired reports whether user must enroll in two-factor
+// authentication before using the API. OpenID Connect providers are trusted
+// for the second factor.
+func (s *AuthService) EnrollmentRequired(user *domain.User) bool {
+	return s.requireTOTP && user.IsAdmin() && !user.TOTPEnabled && user.Source != domain.AuthSourceOIDC
+}
+
+// BeginTOTPEnrollment generates a TOTP secret for the user in the context.
+// Enrolling again replaces a pending secret; an enabled one must be disabled
+// first.
+func (s *AuthService) BeginTOTPEnrollment(ctx context.Context) (string, string, error) {
+	user, err := s.contextUser(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if user.TOTPEnabled {
+		return "", "", fmt.Errorf("%w: disable it before enrolling again", domain.ErrInvalidTOTP)
+	}
+	if user.Source == domain.AuthSourceOIDC {
+		return "", "", domain.ErrExternalAccount
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+	sealed, err := s.sealTOTPSecret(secret)
+	if err != nil {
+		return "", "", err
+	}
+	user.TOTPSecret = sealed
+	user.TOTPLastStep = 0
+	if err := s.repo.Save(ctx, *user); err != nil {
+		return "", "", fmt.Errorf("failed to save user: %w", err)
+	}
+	return secret, totpURL(user.Username, secret), nil
+}
+
+// ConfirmTOTPEnrollment enables the pending secret of the user in the
+// context, once code shows the authenticator has it. It returns recovery
+// codes, which are only stored hashed.
+func (s *AuthService) ConfirmTOTPEnrollment(ctx context.Context, code string) ([]string, error) {
+	user, err := s.contextUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, fmt.Errorf("%w: already enabled", domain.ErrInvalidTOTP)
+	}
+	if user.TOTPSecret == "" {
+		return nil, domain.ErrTOTPNotEnrolled
+	}
+	if err := s.checkTOTP(user, code, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPEnabled = true
+	user.RecoveryCodes = strings.Join(hashes, ",")
+	if err := s.repo.Save(ctx, *user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionTOTPChange, user.Username, "Two-factor authentication enabled")
+	}
+	return codes, nil
+}
+
+// DisableTOTP turns two-factor authentication off for the user in the
+// context, given a current code or a recovery code. Admins cannot while it
+// is enforced.
+func (s *AuthService) DisableTOTP(ctx context.Context, code string) error {
+	user, err := s.contextUser(ctx)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return domain.ErrTOTPNotEnrolled
+	}
+	if s.requireTOTP && user.IsAdmin() {
+		return fmt.Errorf("%w: enforced for admins", domain.ErrInvalidTOTP)
+	}
+	if err := s.checkTOTP(user, code, true); err != nil {
+		return err
+	}
+	return s.clearTOTP(ctx, user, "Two-factor authentication disabled")
+}
+
+// ResetTOTP turns two-factor authentication off for the user with the given
+// ID, who lost their authenticator and recovery codes.
+func (s *AuthService) ResetTOTP(ctx context.Context, id string) error {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled && user.TOTPSecret == "" {
+		return domain.ErrTOTPNotEnrolled
+	}
+	if err := s.clearTOTP(ctx, user, "Two-factor authentication reset"); err != nil {
+		return err
+	}
+	s.dropSessions(id)
+	return nil
+}
+
+// checkTOTP verifies code, a TOTP code or, if recovery is set and the code
+// is one, an unused recovery code, which is consumed. The user is saved with
+// the code marked as used.
+func (s *AuthService) checkTOTP(user *domain.User, code string, recovery bool) error {
+	secret, err := s.openTOTPSecret(user.TOTPSecret)
+	if err != nil {
+		return err
+	}
+
+	if step, ok := verifyTOTP(secret, code, time.Now(), user.TOTPLastStep); ok {
+		user.TOTPLastStep = step
+	} else if !recovery || !consumeRecoveryCode(user, code) {
+		return domain.ErrInvalidTOTP
+	}
+	return s.repo.Save(context.Background(), *user)
+}
+
+func (s *AuthService) clearTOTP(ctx context.Context, user *domain.User, details string) error {
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	user.RecoveryCodes = ""
+	if err := s.repo.Save(ctx, *user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionTOTPChange, user.Username, details)
+	}
+	return nil
+}
+
+// contextUser reloads the user in the context from the repository.
+func (s *AuthService) contextUser(ctx context.Context) (*domain.User, error) {
+	caller, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return nil, ErrInvalidSession
+	}
+	return s.repo.GetByID(ctx, caller.ID)
+}
+
+func (s *AuthService) sealTOTPSecret(secret string) (string, error) {
+	if s.sealer == nil {
+		return secret, nil
+	}
+	sealed, err := s.sealer.Seal(secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to seal TOTP secret: %w", err)
+	}
+	return sealed, nil
+}
+
+func (s *AuthService) openTOTPSecret(stored string) (string, error) {
+	if s.sealer == nil {
+		return stored, nil
+	}
+	secret, err := s.sealer.Open(stored)
+	if err != nil {
+		return "", fmt.Errorf("failed to open TOTP secret: %w", err)
+	}
+	return secret, nil
+}
+
+// generateRecoveryCodes returns new recovery codes, like "k3j9x-2mq8p", and
+// their hashes.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		buf := make([]byte, 7)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+		}
+		raw := strings.ToLower(totpEncoding.EncodeToString(buf))[:10]
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashToken(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// consumeRecoveryCode removes code from the unused recovery codes of user,
+// reporting whether it was one of them.
+func consumeRecoveryCode(user *domain.User, code string) bool {
+	hash := hashToken(strings.ToLower(strings.TrimSpace(code)))
+	hashes := strings.Split(user.RecoveryCodes, ",")
+	for i, h := range hashes {
+		if h != "" && h == hash {
+			user.RecoveryCodes = strings.Join(append(hashes[:i], hashes[i+1:]...), ",")
+			return true
+		}
+	}
+	return false
+}
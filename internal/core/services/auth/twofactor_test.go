@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestTOTPCode_RFC6238(t *testing.T) {
+	// Test vectors of RFC 6238, appendix B (SHA-1), truncated to 6 digits
+	key := []byte("12345678901234567890")
+	tests := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range tests {
+		if got := totpCode(key, unix/totpPeriod); got != want {
+			t.Errorf("totpCode(%d) = %s, want %s", unix, got, want)
+		}
+	}
+}
+
+func TestVerifyTOTP_Replay(t *testing.T) {
+	secret, err := generateTOTPSecret()
+	require.NoError(t, err)
+	key, _ := totpEncoding.DecodeString(secret)
+	now := time.Now()
+	code := totpCode(key, now.Unix()/totpPeriod)
+
+	step, ok := verifyTOTP(secret, code, now, 0)
+	require.True(t, ok)
+	_, ok = verifyTOTP(secret, code, now, step)
+	assert.False(t, ok, "a code must not be accepted twice")
+}
+
+// FuzzVerifyTOTP checks that no secret or code panics the verification, and
+// that a code is accepted only when it is the one of its step.
+func FuzzVerifyTOTP(f *testing.F) {
+	f.Add("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", "287082", int64(59))
+	f.Add("gezdgnbv", " 28 70 82", int64(0))
+	f.Add("not base32!", "", int64(-1))
+
+	f.Fuzz(func(t *testing.T, secret, code string, unix int64) {
+		now := time.Unix(unix, 0)
+		step, ok := verifyTOTP(secret, code, now, 0)
+		if !ok {
+			return
+		}
+		key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+		require.NoError(t, err)
+		assert.Equal(t, totpCode(key, step), strings.ReplaceAll(code, " ", ""))
+	})
+}
+
+func TestAuthService_TwoFactor(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	svc := NewAuthService(mockRepo)
+	svc.RequireAdminTOTP(true)
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("Adm1n-Passphrase"), bcrypt.MinCost)
+	admin := &domain.User{ID: "a-1", Username: "root", Role: domain.RoleAdmin, PasswordHash: string(hashed)}
+	mockRepo.On("GetByID", mock.Anything, "a-1").Return(admin, nil)
+	mockRepo.On("GetByUsername", mock.Anything, "root").Return(admin, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	ctx := domain.ContextWithUser(context.Background(), admin)
+
+	assert.True(t, svc.EnrollmentRequired(admin))
+
+	secret, url, err := svc.BeginTOTPEnrollment(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, url, "secret="+secret)
+	key, _ := totpEncoding.DecodeString(secret)
+	code := func() string { return totpCode(key, time.Now().Unix()/totpPeriod) }
+
+	_, err = svc.ConfirmTOTPEnrollment(ctx, "000000")
+	assert.ErrorIs(t, err, domain.ErrInvalidTOTP)
+	recovery, err := svc.ConfirmTOTPEnrollment(ctx, code())
+	require.NoError(t, err)
+	assert.Len(t, recovery, recoveryCodeCount)
+	assert.False(t, svc.EnrollmentRequired(admin))
+
+	login := func(otp string) error {
+		_, err := svc.Login(context.Background(), domain.Credentials{Username: "root", Password: "Adm1n-Passphrase", OTP: otp})
+		return err
+	}
+	assert.ErrorIs(t, login(""), domain.ErrTOTPRequired)
+	// The code used to confirm cannot be replayed
+	assert.ErrorIs(t, login(code()), ErrInvalidCredentials)
+	require.NoError(t, login(recovery[0]))
+	assert.ErrorIs(t, login(recovery[0]), ErrInvalidCredentials, "recovery codes are single use")
+
+	// Enforced for admins: only a reset by another admin turns it off
+	assert.ErrorIs(t, svc.DisableTOTP(ctx, recovery[1]), domain.ErrInvalidTOTP)
+	require.NoError(t, svc.ResetTOTP(ctx, "a-1"))
+	assert.True(t, svc.EnrollmentRequired(admin))
+}
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Ensure compliance
+var _ ports.UserManager = (*AuthService)(nil)
+
+// ListUsers returns every user account.
+func (s *AuthService) ListUsers(ctx context.Context) ([]domain.User, error) {
+	return s.repo.List(ctx)
+}
+
+// CreateUser provisions a new user. The username must be free and the
+// password must satisfy the password policy.
+func (s *AuthService) CreateUser(ctx context.Context, user domain.User, password string) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+	if err := domain.ValidatePassword(password, user.Username); err != nil {
+		return err
+	}
+	if _, err := s.repo.GetByUsername(ctx, user.Username); err == nil {
+		return domain.ErrUserExists
+	}
+
+	if err := s.saveNewUser(ctx, user, password); err != nil {
+		return err
+	}
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionUserCreate, user.Username, fmt.Sprintf("User created with role %s, permissions %v", user.Role, user.Permissions))
+	}
+	return nil
+}
+
+// UpdateUser changes the role and permissions of a user and enables or
+// disables the account. Disabling a user ends their sessions. The last
+// enabled admin can be neither demoted nor disabled.
+func (s *AuthService) UpdateUser(ctx context.Context, id string, update domain.UserUpdate) (*domain.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *user
+	if update.Role != nil {
+		updated.Role = *update.Role
+	}
+	if update.Disabled != nil {
+		updated.Disabled = *update.Disabled
+	}
+	if update.Permissions != nil {
+		updated.Permissions = *update.Permissions
+	}
+	if err := updated.Validate(); err != nil {
+		return nil, err
+	}
+	if activeAdmin(*user) && !activeAdmin(updated) {
+		if err := s.ensureOtherAdmin(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Save(ctx, updated); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+	if updated.Disabled {
+		s.dropSessions(id)
+	}
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionUserUpdate, updated.Username, fmt.Sprintf("Role %s, disabled %t, permissions %v", updated.Role, updated.Disabled, updated.Permissions))
+	}
+	return &updated, nil
+}
+
+// DeleteUser removes a user, with their sessions and API keys. The last
+// enabled admin cannot be deleted.
+func (s *AuthService) DeleteUser(ctx context.Context, id string) error {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if activeAdmin(*user) {
+		if err := s.ensureOtherAdmin(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.dropSessions(id)
+	if s.keys != nil {
+		keys, err := s.keys.ListAPIKeys(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to list API keys of deleted user: %w", err)
+		}
+		for _, key := range keys {
+			s.keys.DeleteAPIKey(ctx, key.ID)
+		}
+	}
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionUserDelete, user.Username, "User deleted")
+	}
+	return nil
+}
+
+// ResetPassword sets a temporary password for a user, who must change it at
+// the next login. The user's current sessions end. Accounts of identity
+// providers have no local password.
+func (s *AuthService) ResetPassword(ctx context.Context, id, password string) error {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user.External() {
+		return domain.ErrExternalAccount
+	}
+	if err := domain.ValidatePassword(password, user.Username); err != nil {
+		return err
+	}
+	if err := s.setPassword(ctx, user, password, true); err != nil {
+		return err
+	}
+	s.dropSessions(id)
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionPasswordSet, user.Username, "Password reset, change required at next login")
+	}
+	return nil
+}
+
+// ChangePassword replaces the password of the user in the context, after
+// checking the current one.
+func (s *AuthService) ChangePassword(ctx context.Context, current, password string) error {
+	caller, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return ErrInvalidSession
+	}
+	user, err := s.repo.GetByID(ctx, caller.ID)
+	if err != nil {
+		return err
+	}
+	if user.External() {
+		return domain.ErrExternalAccount
+	}
+	if err := s.verifyPassword(user.PasswordHash, current); err != nil {
+		return ErrInvalidCredentials
+	}
+	if current == password {
+		return fmt.Errorf("%w: must differ from the current password", domain.ErrInvalidPassword)
+	}
+	if err := domain.ValidatePassword(password, user.Username); err != nil {
+		return err
+	}
+	if err := s.setPassword(ctx, user, password, false); err != nil {
+		return err
+	}
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionPasswordSet, user.Username, "Password changed")
+	}
+	return nil
+}
+
+// setPassword stores a new password for user.
+func (s *AuthService) setPassword(ctx context.Context, user *domain.User, password string, mustChange bool) error {
+	hash, err := s.hashPassword(password)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+	user.PasswordChangedAt = time.Now().UTC()
+	user.MustChangePassword = mustChange
+	if err := s.repo.Save(ctx, *user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+	return nil
+}
+
+// ensureOtherAdmin returns ErrLastAdmin unless an enabled admin other than
+// the user with the given ID exists.
+func (s *AuthService) ensureOtherAdmin(ctx context.Context, id string) error {
+	users, err := s.repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+	for _, u := range users {
+		if u.ID != id && activeAdmin(u) {
+			return nil
+		}
+	}
+	return domain.ErrLastAdmin
+}
+
+func activeAdmin(u domain.User) bool {
+	return u.IsAdmin() && !u.Disabled
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestAuthService_UserManagement(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	svc := NewAuthService(mockRepo)
+	ctx := context.Background()
+
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("Old-Passw0rd-123"), bcrypt.MinCost)
+	admin := domain.User{ID: "a-1", Username: "root", Role: domain.RoleAdmin}
+	analyst := domain.User{ID: "u-1", Username: "analyst", Role: domain.RoleViewer, PasswordHash: string(hashed)}
+	mockRepo.On("GetByID", ctx, "a-1").Return(&admin, nil)
+	mockRepo.On("GetByID", mock.Anything, "u-1").Return(&analyst, nil)
+	mockRepo.On("List", ctx).Return([]domain.User{admin, analyst}, nil)
+	mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	t.Run("password policy", func(t *testing.T) {
+		err := svc.CreateUser(ctx, domain.User{Username: "new", Role: domain.RoleViewer}, "short")
+		assert.ErrorIs(t, err, domain.ErrInvalidPassword)
+	})
+
+	t.Run("last admin kept", func(t *testing.T) {
+		role := domain.RoleOperator
+		_, err := svc.UpdateUser(ctx, "a-1", domain.UserUpdate{Role: &role})
+		assert.ErrorIs(t, err, domain.ErrLastAdmin)
+
+		disabled := true
+		_, err = svc.UpdateUser(ctx, "a-1", domain.UserUpdate{Disabled: &disabled})
+		assert.ErrorIs(t, err, domain.ErrLastAdmin)
+	})
+
+	t.Run("permissions validated", func(t *testing.T) {
+		perms := []domain.Permission{"attack:nuke"}
+		_, err := svc.UpdateUser(ctx, "u-1", domain.UserUpdate{Permissions: &perms})
+		assert.ErrorIs(t, err, domain.ErrInvalidPermission)
+
+		perms = []domain.Permission{domain.PermScan, domain.PermReport}
+		updated, err := svc.UpdateUser(ctx, "u-1", domain.UserUpdate{Permissions: &perms})
+		require.NoError(t, err)
+		assert.False(t, updated.Can(domain.AttackPermission(domain.AttackKindDeauth)))
+	})
+
+	t.Run("disabling ends sessions", func(t *testing.T) {
+		token, err := svc.createSession(ctx, &analyst)
+		require.NoError(t, err)
+
+		disabled := true
+		updated, err := svc.UpdateUser(ctx, "u-1", domain.UserUpdate{Disabled: &disabled})
+		require.NoError(t, err)
+		assert.True(t, updated.Disabled)
+
+		_, err = svc.ValidateToken(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidSession)
+	})
+
+	t.Run("reset forces a change", func(t *testing.T) {
+		require.NoError(t, svc.ResetPassword(ctx, "u-1", "Temp-Passw0rd-456"))
+		assert.True(t, analyst.MustChangePassword)
+
+		self := domain.ContextWithUser(ctx, &analyst)
+		assert.ErrorIs(t, svc.ChangePassword(self, "wrong", "New-Passw0rd-789"), ErrInvalidCredentials)
+		require.NoError(t, svc.ChangePassword(self, "Temp-Passw0rd-456", "New-Passw0rd-789"))
+		assert.False(t, analyst.MustChangePassword)
+	})
+}
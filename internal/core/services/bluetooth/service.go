@@ -0,0 +1,209 @@
+package bluetooth
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+const (
+	// DefaultInterval is the time between the start of two inquiry scans.
+	DefaultInterval = 2 * time.Minute
+	// DefaultInquiryDuration is how long each inquiry listens for answers.
+	DefaultInquiryDuration = 10 * time.Second
+)
+
+// VendorLookup returns the vendor of a MAC address, or "" if unknown.
+type VendorLookup func(ctx context.Context, mac string) string
+
+// Service complements Wi-Fi capture with periodic classic Bluetooth inquiry
+// scans. Devices found are kept across scans, stored, and correlated with
+// the Wi-Fi devices of the same combo chip.
+type Service struct {
+	scanner  ports.BluetoothScanner
+	store    ports.BluetoothRepository // Optional, keeps devices across restarts
+	registry ports.DeviceRegistry      // Optional, Wi-Fi devices to correlate with
+	vendors  VendorLookup              // Optional
+
+	InquiryDuration time.Duration
+
+	mu      sync.RWMutex
+	devices map[string]*domain.BluetoothDevice // By lowercase address
+}
+
+// NewService creates a service scanning with scanner.
+func NewService(scanner ports.BluetoothScanner, store ports.BluetoothRepository, registry ports.DeviceRegistry, vendors VendorLookup) *Service {
+	return &Service{
+		scanner:         scanner,
+		store:           store,
+		registry:        registry,
+		vendors:         vendors,
+		InquiryDuration: DefaultInquiryDuration,
+		devices:         make(map[string]*domain.BluetoothDevice),
+	}
+}
+
+// Load restores the stored devices.
+func (s *Service) Load(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+	devices, err := s.store.GetBluetoothDevices(ctx)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range devices {
+		d := devices[i]
+		s.devices[strings.ToLower(d.Address)] = &d
+	}
+	return nil
+}
+
+// Scan runs one inquiry and records the devices that answered.
+func (s *Service) Scan(ctx context.Context) ([]domain.BluetoothDevice, error) {
+	found, err := s.scanner.Inquiry(ctx, s.InquiryDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range found {
+		d := &found[i]
+		d.Address = strings.ToLower(d.Address)
+		if d.LastSeen.IsZero() {
+			d.LastSeen = now
+		}
+		if d.DeviceClass == "" {
+			d.DeviceClass = domain.BluetoothMajorClass(d.Class)
+		}
+		if d.Vendor == "" && s.vendors != nil {
+			d.Vendor = s.vendors(ctx, d.Address)
+		}
+	}
+	wifi := s.wifiDevices(ctx)
+
+	s.mu.Lock()
+	updated := make([]domain.BluetoothDevice, 0, len(found))
+	for _, seen := range found {
+		known, ok := s.devices[seen.Address]
+		if !ok {
+			seen.FirstSeen = seen.LastSeen
+			seen.Inquiries = 1
+			known = &seen
+			s.devices[seen.Address] = known
+		} else {
+			known.Merge(seen)
+		}
+		known.RelatedMACs = relatedMACs(known.Address, wifi)
+		updated = append(updated, *known)
+	}
+	s.mu.Unlock()
+
+	if s.store != nil && len(updated) > 0 {
+		if err := s.store.SaveBluetoothDevices(ctx, updated); err != nil {
+			log.Printf("[BLUETOOTH] Failed to save devices: %v", err)
+		}
+	}
+	return updated, nil
+}
+
+// BluetoothDevices returns the devices found, most recently seen first.
+// Correlations are refreshed, since the Wi-Fi side may show up later.
+func (s *Service) BluetoothDevices(ctx context.Context) []domain.BluetoothDevice {
+	wifi := s.wifiDevices(ctx)
+
+	s.mu.RLock()
+	devices := make([]domain.BluetoothDevice, 0, len(s.devices))
+	for _, d := range s.devices {
+		device := *d
+		device.RelatedMACs = relatedMACs(device.Address, wifi)
+		devices = append(devices, device)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(devices, func(i, j int) bool {
+		if !devices[i].LastSeen.Equal(devices[j].LastSeen) {
+			return devices[i].LastSeen.After(devices[j].LastSeen)
+		}
+		return devices[i].Address < devices[j].Address
+	})
+	return devices
+}
+
+// Run scans now and then every interval until ctx is done. A failed scan,
+// such as with the controller down, is logged once until scans work again.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	failing := false
+	for {
+		devices, err := s.Scan(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			if !failing {
+				log.Printf("[BLUETOOTH] Inquiry failed: %v", err)
+			}
+			failing = true
+		case err == nil:
+			if failing {
+				log.Printf("[BLUETOOTH] Inquiry working again")
+			}
+			failing = false
+			if len(devices) > 0 {
+				log.Printf("[BLUETOOTH] Inquiry found %d devices", len(devices))
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) wifiDevices(ctx context.Context) []string {
+	if s.registry == nil {
+		return nil
+	}
+	devices := s.registry.GetAllDevices(ctx)
+	macs := make([]string, 0, len(devices))
+	for _, d := range devices {
+		macs = append(macs, d.MAC)
+	}
+	return macs
+}
+
+// relatedMACs returns the Wi-Fi addresses close enough to address to be the
+// same chip, closest first.
+func relatedMACs(address string, wifi []string) []string {
+	type candidate struct {
+		mac      string
+		distance int
+	}
+	var candidates []candidate
+	for _, mac := range wifi {
+		distance, ok := domain.BluetoothMACDistance(address, mac)
+		if ok && distance <= domain.BluetoothMACProximity {
+			candidates = append(candidates, candidate{strings.ToLower(mac), distance})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].mac < candidates[j].mac
+	})
+	var macs []string
+	for _, c := range candidates {
+		macs = append(macs, c.mac)
+	}
+	return macs
+}
@@ -0,0 +1,81 @@
+package bluetooth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeScanner struct {
+	results [][]domain.BluetoothDevice
+}
+
+func (f *fakeScanner) Inquiry(ctx context.Context, duration time.Duration) ([]domain.BluetoothDevice, error) {
+	result := f.results[0]
+	f.results = f.results[1:]
+	return result, nil
+}
+
+type fakeStore struct {
+	saved map[string]domain.BluetoothDevice
+}
+
+func (f *fakeStore) SaveBluetoothDevices(ctx context.Context, devices []domain.BluetoothDevice) error {
+	for _, d := range devices {
+		f.saved[d.Address] = d
+	}
+	return nil
+}
+
+func (f *fakeStore) GetBluetoothDevices(ctx context.Context) ([]domain.BluetoothDevice, error) {
+	return nil, nil
+}
+
+type fakeRegistry struct {
+	ports.DeviceRegistry
+	devices []domain.Device
+}
+
+func (f *fakeRegistry) GetAllDevices(ctx context.Context) []domain.Device {
+	return f.devices
+}
+
+func TestService_ScanMergesAndCorrelates(t *testing.T) {
+	scanner := &fakeScanner{results: [][]domain.BluetoothDevice{
+		{{Address: "AC:DE:48:00:11:23", Class: 0x5a020c, RSSI: -60}},
+		{{Address: "ac:de:48:00:11:23", Name: "Pixel", RSSI: -52}},
+	}}
+	store := &fakeStore{saved: make(map[string]domain.BluetoothDevice)}
+	registry := &fakeRegistry{devices: []domain.Device{
+		{MAC: "ac:de:48:00:11:22"}, // Wi-Fi side of the same chip
+		{MAC: "ac:de:48:00:11:20"},
+		{MAC: "ac:de:48:99:00:00"}, // Same vendor, unrelated
+		{MAC: "ae:de:48:00:11:22"}, // Randomized
+	}}
+	vendors := func(ctx context.Context, mac string) string { return "Google" }
+	s := NewService(scanner, store, registry, vendors)
+
+	devices, err := s.Scan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "phone", devices[0].DeviceClass)
+	assert.Equal(t, "Google", devices[0].Vendor)
+	assert.Equal(t, []string{"ac:de:48:00:11:22", "ac:de:48:00:11:20"}, devices[0].RelatedMACs)
+	firstSeen := devices[0].FirstSeen
+
+	_, err = s.Scan(context.Background())
+	require.NoError(t, err)
+	all := s.BluetoothDevices(context.Background())
+	require.Len(t, all, 1)
+	assert.Equal(t, "Pixel", all[0].Name)
+	assert.Equal(t, -52, all[0].RSSI)
+	assert.Equal(t, uint32(0x5a020c), all[0].Class, "class kept from the first answer")
+	assert.Equal(t, 2, all[0].Inquiries)
+	assert.Equal(t, firstSeen, all[0].FirstSeen)
+	assert.Equal(t, "Pixel", store.saved["ac:de:48:00:11:23"].Name)
+}
@@ -0,0 +1,280 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// Security tiers used to style access points, weakest first.
+const (
+	tierOpen    = "open"
+	tierWEP     = "wep"
+	tierWPA     = "wpa"
+	tierWPA2    = "wpa2"
+	tierWPA3    = "wpa3"
+	tierUnknown = "unknown"
+)
+
+// tierColors are the fill and border colors of each security tier.
+var tierColors = map[string][2]string{
+	tierOpen:    {"#ff3b30", "#b3261e"},
+	tierWEP:     {"#ff6b3d", "#b34a2a"},
+	tierWPA:     {"#ff9500", "#b36800"},
+	tierWPA2:    {"#ffcc00", "#b38f00"},
+	tierWPA3:    {"#34c759", "#248a3d"},
+	tierUnknown: {"#c7c7cc", "#8e8e93"},
+}
+
+// stationColors and networkColors are the fill and border colors of client
+// and SSID nodes, which are not styled by security.
+var (
+	stationColors = [2]string{"#5ac8fa", "#007aff"}
+	networkColors = [2]string{"#e5e5ea", "#8e8e93"}
+)
+
+// SecurityTier classifies the security of an access point, mixed modes by
+// their weakest member: a WPA2/WPA3 transition network is WPA2.
+func SecurityTier(security string) string {
+	s := strings.ToUpper(security)
+	switch {
+	case s == "" || s == domain.SecurityOpen || s == "NONE":
+		return tierOpen
+	case strings.Contains(s, domain.SecurityWEP):
+		return tierWEP
+	case strings.Contains(s, domain.SecurityWPA2):
+		return tierWPA2
+	case strings.Contains(s, domain.SecurityWPA3), strings.Contains(s, domain.SecurityOWE):
+		return tierWPA3
+	case strings.Contains(s, "WPA"):
+		return tierWPA
+	}
+	return tierUnknown
+}
+
+// nodeColors returns the fill and border colors of a node.
+func nodeColors(node domain.GraphNode) [2]string {
+	switch node.Group {
+	case domain.GroupAP:
+		return tierColors[SecurityTier(node.Security)]
+	case domain.GroupNetwork:
+		return networkColors
+	}
+	return stationColors
+}
+
+// nodeLabel names a node by its label, adding the SSID and security of
+// access points.
+func nodeLabel(node domain.GraphNode) string {
+	label := node.Label
+	if label == "" {
+		label = node.ID
+	}
+	if node.Group != domain.GroupAP {
+		return label
+	}
+	security := node.Security
+	if security == "" {
+		security = domain.SecurityOpen
+	}
+	if node.SSID != "" && node.SSID != label {
+		return fmt.Sprintf("%s\n%s (%s)", label, node.SSID, security)
+	}
+	return fmt.Sprintf("%s\n%s", label, security)
+}
+
+// sortedGraph returns the nodes and edges of a graph in a stable order, so
+// that exports of the same graph are identical.
+func sortedGraph(graph domain.GraphData) ([]domain.GraphNode, []domain.GraphEdge) {
+	nodes := append([]domain.GraphNode(nil), graph.Nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	edges := append([]domain.GraphEdge(nil), graph.Edges...)
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Type < edges[j].Type
+	})
+	return nodes, edges
+}
+
+// ExportGraphDOT writes the graph in Graphviz DOT. Access points are boxes
+// colored by security tier, clients ellipses and SSIDs diamonds; probe edges
+// are dashed, labeled with their count, and correlation edges dotted.
+func ExportGraphDOT(w io.Writer, graph domain.GraphData) error {
+	nodes, edges := sortedGraph(graph)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "graph wmap {")
+	fmt.Fprintln(bw, `	graph [layout=neato, overlap=false, splines=true, fontname="Helvetica"];`)
+	fmt.Fprintln(bw, `	node [style=filled, fontname="Helvetica", fontsize=10];`)
+	fmt.Fprintln(bw, `	edge [color="#8e8e93"];`)
+	for _, node := range nodes {
+		shape := "ellipse"
+		switch node.Group {
+		case domain.GroupAP:
+			shape = "box"
+		case domain.GroupNetwork:
+			shape = "diamond"
+		}
+		colors := nodeColors(node)
+		fmt.Fprintf(bw, "\t%s [label=%s, shape=%s, fillcolor=%s, color=%s];\n",
+			dotQuote(node.ID), dotQuote(nodeLabel(node)), shape, dotQuote(colors[0]), dotQuote(colors[1]))
+	}
+	for _, edge := range edges {
+		var attrs []string
+		switch {
+		case edge.Type == domain.TypeCorrelation:
+			attrs = append(attrs, "style=dotted")
+		case edge.Type == domain.TypeProbe || edge.Type == domain.TypeProbedFor || edge.Dashed:
+			attrs = append(attrs, "style=dashed")
+		}
+		if edge.Label != "" {
+			attrs = append(attrs, "label="+dotQuote(edge.Label))
+		} else if edge.Count > 0 {
+			attrs = append(attrs, fmt.Sprintf("label=\"%dx\"", edge.Count))
+		}
+		if edge.Color != "" {
+			attrs = append(attrs, "color="+dotQuote(edge.Color))
+		}
+		fmt.Fprintf(bw, "\t%s -- %s", dotQuote(edge.From), dotQuote(edge.To))
+		if len(attrs) > 0 {
+			fmt.Fprintf(bw, " [%s]", strings.Join(attrs, ", "))
+		}
+		fmt.Fprintln(bw, ";")
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotQuote quotes a DOT identifier, keeping line breaks as \n.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\r", "")
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+// CytoscapeElement is a node or an edge of a Cytoscape.js graph.
+type CytoscapeElement struct {
+	Data    map[string]interface{} `json:"data"`
+	Classes string                 `json:"classes,omitempty"`
+}
+
+// CytoscapeStyle styles the elements matched by a selector.
+type CytoscapeStyle struct {
+	Selector string            `json:"selector"`
+	Style    map[string]string `json:"style"`
+}
+
+// CytoscapeGraph is a graph in the JSON accepted by cy.json() and the
+// Cytoscape desktop app, styles included.
+type CytoscapeGraph struct {
+	Elements struct {
+		Nodes []CytoscapeElement `json:"nodes"`
+		Edges []CytoscapeElement `json:"edges"`
+	} `json:"elements"`
+	Style []CytoscapeStyle `json:"style"`
+}
+
+// NewCytoscapeGraph converts the graph. Nodes get their group as class and,
+// for access points, their security tier; edges get their type.
+func NewCytoscapeGraph(graph domain.GraphData) CytoscapeGraph {
+	nodes, edges := sortedGraph(graph)
+
+	var g CytoscapeGraph
+	g.Elements.Nodes = make([]CytoscapeElement, 0, len(nodes))
+	for _, node := range nodes {
+		data := map[string]interface{}{
+			"id":    node.ID,
+			"label": nodeLabel(node),
+			"group": string(node.Group),
+		}
+		classes := []string{string(node.Group)}
+		if node.MAC != "" {
+			data["mac"] = node.MAC
+		}
+		if node.Vendor != "" {
+			data["vendor"] = node.Vendor
+		}
+		if node.SSID != "" {
+			data["ssid"] = node.SSID
+		}
+		if node.Channel != 0 {
+			data["channel"] = node.Channel
+		}
+		if node.Group == domain.GroupAP {
+			data["security"] = node.Security
+			classes = append(classes, SecurityTier(node.Security))
+		}
+		g.Elements.Nodes = append(g.Elements.Nodes, CytoscapeElement{Data: data, Classes: strings.Join(classes, " ")})
+	}
+
+	g.Elements.Edges = make([]CytoscapeElement, 0, len(edges))
+	for i, edge := range edges {
+		edgeType := edge.Type
+		if edgeType == "" {
+			edgeType = domain.TypeConnection
+		}
+		data := map[string]interface{}{
+			"id":     fmt.Sprintf("e%d", i),
+			"source": edge.From,
+			"target": edge.To,
+			"type":   string(edgeType),
+		}
+		if edge.Label != "" {
+			data["label"] = edge.Label
+		}
+		if edge.Count > 0 {
+			data["count"] = edge.Count
+		}
+		if edge.LastSeen != nil {
+			data["last_seen"] = edge.LastSeen
+		}
+		g.Elements.Edges = append(g.Elements.Edges, CytoscapeElement{Data: data, Classes: string(edgeType)})
+	}
+
+	g.Style = cytoscapeStyles()
+	return g
+}
+
+// cytoscapeStyles mirrors the DOT styling.
+func cytoscapeStyles() []CytoscapeStyle {
+	styles := []CytoscapeStyle{
+		{Selector: "node", Style: map[string]string{
+			"label": "data(label)", "text-wrap": "wrap", "font-size": "10px",
+			"text-valign": "bottom", "border-width": "2px",
+			"background-color": stationColors[0], "border-color": stationColors[1],
+		}},
+		{Selector: "node.ap", Style: map[string]string{"shape": "round-rectangle"}},
+		{Selector: "node.network", Style: map[string]string{
+			"shape": "diamond", "background-color": networkColors[0], "border-color": networkColors[1],
+		}},
+		{Selector: "edge", Style: map[string]string{"width": "1.5px", "line-color": "#8e8e93", "curve-style": "bezier"}},
+		{Selector: "edge.probe", Style: map[string]string{"line-style": "dashed"}},
+		{Selector: "edge.probed_for", Style: map[string]string{"line-style": "dashed", "line-color": "#af52de"}},
+		{Selector: "edge.correlation", Style: map[string]string{"line-style": "dotted"}},
+	}
+	for _, tier := range []string{tierOpen, tierWEP, tierWPA, tierWPA2, tierWPA3, tierUnknown} {
+		styles = append(styles, CytoscapeStyle{
+			Selector: "node.ap." + tier,
+			Style:    map[string]string{"background-color": tierColors[tier][0], "border-color": tierColors[tier][1]},
+		})
+	}
+	return styles
+}
+
+// ExportGraphCytoscape writes the graph as Cytoscape JSON.
+func ExportGraphCytoscape(w io.Writer, graph domain.GraphData) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(NewCytoscapeGraph(graph))
+}
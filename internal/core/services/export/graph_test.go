@@ -0,0 +1,80 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph() domain.GraphData {
+	ap := domain.GraphNode{}
+	ap.ID, ap.Label, ap.Group, ap.MAC = "00:11:22:33:44:55", `Lab "AP"`, domain.GroupAP, "00:11:22:33:44:55"
+	ap.SSID, ap.Security = "corp", "WPA2/WPA3"
+	station := domain.GraphNode{}
+	station.ID, station.Label, station.Group = "aa:bb:cc:dd:ee:ff", "phone", domain.GroupStation
+	network := domain.GraphNode{}
+	network.ID, network.Label, network.Group = "ssid:guest", "guest", domain.GroupNetwork
+
+	return domain.GraphData{
+		Nodes: []domain.GraphNode{station, network, ap},
+		Edges: []domain.GraphEdge{
+			{From: "aa:bb:cc:dd:ee:ff", To: "ssid:guest", Type: domain.TypeProbedFor, Dashed: true, Count: 3},
+			{From: "00:11:22:33:44:55", To: "aa:bb:cc:dd:ee:ff", Type: domain.TypeConnection},
+		},
+	}
+}
+
+func TestSecurityTier(t *testing.T) {
+	for security, tier := range map[string]string{
+		"":          tierOpen,
+		"OPEN":      tierOpen,
+		"WEP":       tierWEP,
+		"WPA":       tierWPA,
+		"WPA2-PSK":  tierWPA2,
+		"WPA2/WPA3": tierWPA2,
+		"wpa3-sae":  tierWPA3,
+		"OWE":       tierWPA3,
+		"802.1X?":   tierUnknown,
+	} {
+		assert.Equal(t, tier, SecurityTier(security), security)
+	}
+}
+
+func TestExportGraphDOT(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportGraphDOT(&buf, testGraph()))
+	dot := buf.String()
+
+	assert.Contains(t, dot, "graph wmap {")
+	assert.Contains(t, dot, `"00:11:22:33:44:55" [label="Lab \"AP\"\ncorp (WPA2/WPA3)", shape=box, fillcolor="#ffcc00"`)
+	assert.Contains(t, dot, `"ssid:guest" [label="guest", shape=diamond`)
+	assert.Contains(t, dot, `"00:11:22:33:44:55" -- "aa:bb:cc:dd:ee:ff";`)
+	assert.Contains(t, dot, `"aa:bb:cc:dd:ee:ff" -- "ssid:guest" [style=dashed, label="3x"];`)
+
+	// Exports of the same graph are identical
+	var again bytes.Buffer
+	require.NoError(t, ExportGraphDOT(&again, testGraph()))
+	assert.Equal(t, dot, again.String())
+}
+
+func TestExportGraphCytoscape(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportGraphCytoscape(&buf, testGraph()))
+
+	var g CytoscapeGraph
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &g))
+	require.Len(t, g.Elements.Nodes, 3)
+	assert.Equal(t, "00:11:22:33:44:55", g.Elements.Nodes[0].Data["id"])
+	assert.Equal(t, "ap wpa2", g.Elements.Nodes[0].Classes)
+	assert.Equal(t, "station", g.Elements.Nodes[1].Classes)
+
+	require.Len(t, g.Elements.Edges, 2)
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", g.Elements.Edges[1].Data["source"])
+	assert.Equal(t, "probed_for", g.Elements.Edges[1].Classes)
+	assert.EqualValues(t, 3, g.Elements.Edges[1].Data["count"])
+	assert.NotEmpty(t, g.Style)
+}
@@ -0,0 +1,96 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	wmap_grpc "github.com/lcalzada-xor/wmap/api/proto"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyMetadata is the metadata key clients send their API key under.
+var apiKeyMetadata = strings.ToLower(domain.APIKeyHeader)
+
+// methodRoles is the role an API key must act with to call each method.
+// Every method feeds data or takes commands, so read keys may call none;
+// methods missing here are refused.
+var methodRoles = map[string]domain.Role{
+	wmap_grpc.WMapService_ReportTraffic_FullMethodName: domain.RoleOperator,
+	wmap_grpc.WMapService_ReportAlerts_FullMethodName:  domain.RoleOperator,
+	wmap_grpc.WMapService_Ingest_FullMethodName:        domain.RoleOperator,
+	wmap_grpc.WMapService_Commands_FullMethodName:      domain.RoleOperator,
+}
+
+// keylessMethods may be called without an API key when keys are optional.
+// The command channel never is: it hands out attacks.
+var keylessMethods = map[string]bool{
+	wmap_grpc.WMapService_ReportTraffic_FullMethodName: true,
+	wmap_grpc.WMapService_ReportAlerts_FullMethodName:  true,
+	wmap_grpc.WMapService_Ingest_FullMethodName:        true,
+}
+
+// apiKeyAuth authenticates gRPC clients by API key. A key that is sent must
+// be valid and of a scope allowing the method. Agents authenticated by a
+// client certificate need no key; other calls without one are refused when
+// required is set, and for the command channel in any case.
+type apiKeyAuth struct {
+	keys     ports.APIKeyValidator
+	required bool
+}
+
+// authenticate returns ctx carrying the owner of the call's API key.
+func (a apiKeyAuth) authenticate(ctx context.Context, method string) (context.Context, error) {
+	var key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(apiKeyMetadata); len(values) > 0 {
+			key = values[0]
+		}
+	}
+	if key == "" {
+		if hasClientCert(ctx) || (!a.required && keylessMethods[method]) {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "API key required")
+	}
+	user, err := a.keys.ValidateAPIKey(ctx, key)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	required, ok := methodRoles[method]
+	if !ok || !user.Role.Allows(required) {
+		return nil, status.Errorf(codes.PermissionDenied, "API key scope does not allow %s", method)
+	}
+	return domain.ContextWithUser(ctx, user), nil
+}
+
+func (a apiKeyAuth) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a apiKeyAuth) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream replaces the context of a stream with the
+// authenticated one.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	wmap_grpc "github.com/lcalzada-xor/wmap/api/proto"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// scopedKeys validates keys named after their scope.
+type scopedKeys struct{}
+
+func (scopedKeys) ValidateAPIKey(_ context.Context, key string) (*domain.User, error) {
+	scope := domain.APIKeyScope(key)
+	if !scope.IsValid() {
+		return nil, errors.New("invalid API key")
+	}
+	return &domain.User{Username: "automation", Role: scope.Role()}, nil
+}
+
+func withKey(ctx context.Context, key string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(apiKeyMetadata, key))
+}
+
+func TestAPIKeyAuth_Scopes(t *testing.T) {
+	auth := apiKeyAuth{keys: scopedKeys{}}
+	methods := []string{
+		wmap_grpc.WMapService_ReportTraffic_FullMethodName,
+		wmap_grpc.WMapService_ReportAlerts_FullMethodName,
+		wmap_grpc.WMapService_Ingest_FullMethodName,
+		wmap_grpc.WMapService_Commands_FullMethodName,
+	}
+	for _, method := range methods {
+		_, err := auth.authenticate(withKey(context.Background(), "read"), method)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), "read keys may not call %s", method)
+
+		ctx, err := auth.authenticate(withKey(context.Background(), "attack"), method)
+		require.NoError(t, err, method)
+		user, ok := domain.UserFromContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, domain.RoleOperator, user.Role)
+	}
+
+	_, err := auth.authenticate(withKey(context.Background(), "bogus"), wmap_grpc.WMapService_Ingest_FullMethodName)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = auth.authenticate(withKey(context.Background(), "admin"), "/wmap.WMapService/Unknown")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "unlisted methods are refused")
+}
+
+func TestAPIKeyAuth_WithoutKey(t *testing.T) {
+	optional := apiKeyAuth{keys: scopedKeys{}}
+	_, err := optional.authenticate(context.Background(), wmap_grpc.WMapService_ReportTraffic_FullMethodName)
+	assert.NoError(t, err, "reports may omit the key when keys are optional")
+	_, err = optional.authenticate(context.Background(), wmap_grpc.WMapService_Commands_FullMethodName)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "the command channel always needs a key")
+
+	required := apiKeyAuth{keys: scopedKeys{}, required: true}
+	_, err = required.authenticate(context.Background(), wmap_grpc.WMapService_ReportTraffic_FullMethodName)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = required.authenticate(certPeer("agent-1"), wmap_grpc.WMapService_Commands_FullMethodName)
+	assert.NoError(t, err, "a client certificate authenticates the agent")
+}
@@ -1,28 +1,67 @@
 package grpc
 
 import (
+	"context"
+	"encoding/json"
 	"io"
+	"log"
 	"time"
 
 	wmap_grpc "github.com/lcalzada-xor/wmap/api/proto"
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // GrpcServer implements wmap.WMapServiceServer
 type GrpcServer struct {
 	wmap_grpc.UnimplementedWMapServiceServer
-	service ports.NetworkService
+	service  ports.NetworkService
+	ingester ports.DeviceIngester
+	agents   ports.AgentSessions
 }
 
-func NewGrpcServer(svc ports.NetworkService) *grpc.Server {
-	s := grpc.NewServer()
-	wmap_grpc.RegisterWMapServiceServer(s, &GrpcServer{service: svc})
+// NewGrpcServer creates the gRPC server. The ingester and agent sessions
+// are optional; the matching methods are unavailable without them. With keys
+// set, clients authenticate with an API key or a client certificate, always
+// required for the command channel and, with requireKey, for every method.
+// Without creds the server accepts plaintext connections.
+func NewGrpcServer(svc ports.NetworkService, ingester ports.DeviceIngester, agents ports.AgentSessions, keys ports.APIKeyValidator, requireKey bool, creds credentials.TransportCredentials) *grpc.Server {
+	var opts []grpc.ServerOption
+	if creds != nil {
+		opts = append(opts, grpc.Creds(creds))
+	}
+	if keys != nil {
+		auth := apiKeyAuth{keys: keys, required: requireKey}
+		opts = append(opts, grpc.UnaryInterceptor(auth.unary), grpc.StreamInterceptor(auth.stream))
+	}
+	s := grpc.NewServer(opts...)
+	wmap_grpc.RegisterWMapServiceServer(s, &GrpcServer{service: svc, ingester: ingester, agents: agents})
 	return s
 }
 
 func (s *GrpcServer) ReportTraffic(stream wmap_grpc.WMapService_ReportTrafficServer) error {
+	// Device reports carry no sensor; the agent names itself in the stream
+	// metadata so its devices can be routed to a workspace.
+	var claimed string
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		if ids := md.Get(domain.AgentIDMetadata); len(ids) > 0 {
+			claimed = ids[0]
+		}
+	}
+	agentID, err := agentIdentity(stream.Context(), claimed)
+	if err != nil {
+		return err
+	}
+	var sensor string
+	if agentID != "" {
+		sensor = agentID + "/"
+	}
+
 	for {
 		report, err := stream.Recv()
 		if err == io.EOF {
@@ -64,6 +103,7 @@ func (s *GrpcServer) ReportTraffic(stream wmap_grpc.WMapService_ReportTrafficSer
 			Standard:       report.Standard,
 			Model:          report.Model,
 			Frequency:      int(report.Frequency),
+			Sensor:         sensor,
 
 			// Analytics
 			DataTransmitted: report.DataTransmitted,
@@ -87,3 +127,172 @@ func (s *GrpcServer) ReportTraffic(stream wmap_grpc.WMapService_ReportTrafficSer
 		_ = s.service.ProcessDevice(stream.Context(), device)
 	}
 }
+
+func (s *GrpcServer) ReportAlerts(stream wmap_grpc.WMapService_ReportAlertsServer) error {
+	var processed int32
+	for {
+		report, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&wmap_grpc.ReportSummary{
+				AlertsProcessed: processed,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		ts := time.Unix(report.Timestamp, 0)
+		if report.Timestamp == 0 {
+			ts = time.Now()
+		}
+
+		// Qualify the sensor with the agent so interfaces of different agents
+		// are told apart when correlating.
+		agentID, err := agentIdentity(stream.Context(), report.AgentId)
+		if err != nil {
+			return err
+		}
+		sensor := report.Sensor
+		if agentID != "" {
+			sensor = agentID + "/" + report.Sensor
+		}
+
+		alert := domain.Alert{
+			Type:      domain.AlertType(report.Type),
+			Subtype:   report.Subtype,
+			DeviceMAC: report.DeviceMac,
+			TargetMAC: report.TargetMac,
+			Timestamp: ts,
+			Message:   report.Message,
+			Details:   report.Details,
+			Severity:  domain.AlertSeverity(report.Severity),
+			Sensor:    sensor,
+			RSSI:      int(report.Rssi),
+			Latitude:  report.Latitude,
+			Longitude: report.Longitude,
+		}
+
+		if err := s.service.ReportAlert(stream.Context(), alert); err == nil {
+			processed++
+		}
+	}
+}
+
+func (s *GrpcServer) Ingest(ctx context.Context, req *wmap_grpc.IngestRequest) (*wmap_grpc.IngestSummary, error) {
+	if s.ingester == nil {
+		return nil, status.Error(codes.Unimplemented, "ingestion not available")
+	}
+	result, err := s.ingester.Ingest(ctx, domain.IngestFormat(req.Format), req.Source, req.Data)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &wmap_grpc.IngestSummary{
+		Format:    string(result.Format),
+		Received:  int32(result.Received),
+		Processed: int32(result.Processed),
+		Skipped:   int32(result.Skipped),
+		Errors:    result.Errors,
+	}, nil
+}
+
+// Commands serves an agent's command channel: commands for the agent are sent
+// as the hub queues them while the agent reports the progress of its attacks
+// and the configuration it runs with.
+func (s *GrpcServer) Commands(stream wmap_grpc.WMapService_CommandsServer) error {
+	if s.agents == nil {
+		return status.Error(codes.Unimplemented, "remote commands not available")
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	hello := first.GetHello()
+	if hello == nil {
+		return status.Error(codes.InvalidArgument, "the first message must be a hello with the agent ID")
+	}
+	agentID, err := agentIdentity(stream.Context(), hello.AgentId)
+	if err != nil {
+		return err
+	}
+	if agentID == "" {
+		return status.Error(codes.InvalidArgument, "the first message must be a hello with the agent ID")
+	}
+
+	info := domain.AgentInfo{ID: agentID, Interfaces: hello.Interfaces, Version: hello.Version, AutoUpdate: hello.AutoUpdate}
+	for _, kind := range hello.Attacks {
+		info.Attacks = append(info.Attacks, domain.AttackKind(kind))
+	}
+	commands, disconnect := s.agents.Connect(info)
+	defer disconnect()
+
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			if u := msg.GetUpdate(); u != nil {
+				s.agents.Update(info.ID, updateFromProto(u))
+			}
+			if ack := msg.GetConfigAck(); ack != nil {
+				s.agents.ConfigApplied(info.ID, ackFromProto(ack))
+			}
+			if u := msg.GetUpdateStatus(); u != nil && u.Error != "" {
+				s.agents.UpdateFailed(info.ID, u.Version, u.Error)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case cmd, ok := <-commands:
+			if !ok {
+				return status.Error(codes.Aborted, "replaced by a newer connection of the agent")
+			}
+			if err := stream.Send(&wmap_grpc.AgentCommand{
+				AttackId: cmd.AttackID,
+				Action:   string(cmd.Action),
+				Kind:     string(cmd.Kind),
+				Config:   cmd.Config,
+				Revision: cmd.Revision,
+			}); err != nil {
+				return err
+			}
+		case err := <-recvErr:
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func updateFromProto(u *wmap_grpc.AttackUpdate) domain.RemoteAttackUpdate {
+	ts := time.Unix(u.Timestamp, 0)
+	if u.Timestamp == 0 {
+		ts = time.Now()
+	}
+	return domain.RemoteAttackUpdate{
+		AttackID:               u.AttackId,
+		Status:                 u.Status,
+		Finished:               u.Finished,
+		Error:                  u.Error,
+		PacketsSent:            int(u.PacketsSent),
+		HandshakeCaptured:      u.HandshakeCaptured,
+		DisconnectionConfirmed: u.DisconnectionConfirmed,
+		CredentialsRecovered:   u.CredentialsRecovered,
+		Timestamp:              ts,
+	}
+}
+
+func ackFromProto(a *wmap_grpc.ConfigAck) domain.AgentConfigAck {
+	ack := domain.AgentConfigAck{Revision: a.Revision, Error: a.Error}
+	if err := json.Unmarshal(a.Effective, &ack.Effective); err != nil && len(a.Effective) > 0 {
+		log.Printf("Warning: invalid effective configuration from agent: %v", err)
+	}
+	return ack
+}
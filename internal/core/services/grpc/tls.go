@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ServerCredentials loads the server's TLS certificate. With clientCAFile
+// set, agents must present a certificate signed by that CA (mutual TLS) and
+// are identified by its common name.
+func ServerCredentials(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading gRPC certificate: %w", err)
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading gRPC client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in gRPC client CA %s", clientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(config), nil
+}
+
+// agentIdentity returns the ID an agent reports under. Agents authenticated
+// by a client certificate are named by its common name, and may not claim
+// another ID; otherwise the claimed ID is trusted.
+func agentIdentity(ctx context.Context, claimed string) (string, error) {
+	cert := clientCert(ctx)
+	if cert == nil {
+		return claimed, nil
+	}
+	name := cert.Subject.CommonName
+	if name == "" {
+		return "", status.Error(codes.Unauthenticated, "client certificate has no common name")
+	}
+	if claimed != "" && claimed != name {
+		return "", status.Errorf(codes.PermissionDenied, "agent %q may not report as %q", name, claimed)
+	}
+	return name, nil
+}
+
+// clientCert returns the verified client certificate of the call, nil when
+// the client presented none.
+func clientCert(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return info.State.VerifiedChains[0][0]
+}
+
+// hasClientCert reports whether the client authenticated with a certificate.
+func hasClientCert(ctx context.Context) bool {
+	return clientCert(ctx) != nil
+}
@@ -0,0 +1,44 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// certPeer returns ctx for a connection authenticated by a client
+// certificate named cn.
+func certPeer(cn string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	info := credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+}
+
+func TestAgentIdentity(t *testing.T) {
+	id, err := agentIdentity(context.Background(), "claimed")
+	require.NoError(t, err)
+	assert.Equal(t, "claimed", id, "without a client certificate the claim is trusted")
+
+	id, err = agentIdentity(certPeer("agent-1"), "")
+	require.NoError(t, err)
+	assert.Equal(t, "agent-1", id, "named by the certificate")
+
+	id, err = agentIdentity(certPeer("agent-1"), "agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, "agent-1", id)
+
+	_, err = agentIdentity(certPeer("agent-1"), "agent-2")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "certificates may not report as another agent")
+
+	_, err = agentIdentity(certPeer(""), "agent-1")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,164 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// airodumpTimeLayout is the layout of the first and last seen columns, in
+// the local time of the capture host.
+const airodumpTimeLayout = "2006-01-02 15:04:05"
+
+// Column counts of the two sections of an airodump-ng CSV file.
+const (
+	airodumpAPColumns      = 15 // BSSID .. Key
+	airodumpStationColumns = 7  // Station MAC .. Probed ESSIDs
+)
+
+// parseAirodump decodes an airodump-ng CSV file: an access point section
+// followed by a station section, each introduced by its header line.
+//
+// Lines are split by hand rather than with encoding/csv, since airodump does
+// not quote its fields and SSIDs may contain commas.
+func parseAirodump(data []byte) ([]domain.Device, error) {
+	var devices []domain.Device
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case strings.HasPrefix(line, "BSSID,"):
+			section = "ap"
+			continue
+		case strings.HasPrefix(line, "Station MAC,"):
+			section = "station"
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		switch section {
+		case "ap":
+			if d, ok := airodumpAP(fields); ok {
+				devices = append(devices, d)
+			}
+		case "station":
+			if d, ok := airodumpStation(fields); ok {
+				devices = append(devices, d)
+			}
+		}
+	}
+	return devices, scanner.Err()
+}
+
+// airodumpAP decodes a line of the access point section.
+func airodumpAP(fields []string) (domain.Device, bool) {
+	if len(fields) < airodumpAPColumns {
+		return domain.Device{}, false
+	}
+	d := domain.Device{
+		MAC:            fields[0],
+		Type:           domain.DeviceTypeAP,
+		FirstSeen:      airodumpTime(fields[1]),
+		LastPacketTime: airodumpTime(fields[2]),
+		Security:       airodumpSecurity(fields[5], fields[7]),
+		Crypto:         fields[6],
+		RSSI:           airodumpPower(fields[8]),
+		SSID:           airodumpESSID(fields),
+	}
+	d.Channel, _ = strconv.Atoi(fields[3])
+	d.PacketsCount, _ = strconv.Atoi(fields[9])
+	return d, true
+}
+
+// airodumpStation decodes a line of the station section.
+func airodumpStation(fields []string) (domain.Device, bool) {
+	if len(fields) < airodumpStationColumns-1 {
+		return domain.Device{}, false
+	}
+	d := domain.Device{
+		MAC:            fields[0],
+		Type:           domain.DeviceTypeStation,
+		FirstSeen:      airodumpTime(fields[1]),
+		LastPacketTime: airodumpTime(fields[2]),
+		RSSI:           airodumpPower(fields[3]),
+	}
+	d.PacketsCount, _ = strconv.Atoi(fields[4])
+	if bssid := strings.ToLower(fields[5]); strings.Count(bssid, ":") == 5 {
+		d.ConnectedSSID = bssid
+	}
+	for _, ssid := range fields[6:] {
+		if ssid == "" {
+			continue
+		}
+		if d.ProbedSSIDs == nil {
+			d.ProbedSSIDs = make(map[string]time.Time)
+		}
+		d.ProbedSSIDs[ssid] = d.LastPacketTime
+	}
+	return d, true
+}
+
+// airodumpESSID rebuilds the SSID of an access point line, which spans
+// several fields when it contains commas. The ID-length column gives its
+// actual length.
+func airodumpESSID(fields []string) string {
+	ssid := strings.Join(fields[13:len(fields)-1], ",")
+	if n, err := strconv.Atoi(fields[12]); err == nil && n == 0 {
+		return "" // Hidden
+	}
+	if strings.HasPrefix(ssid, `\x00`) {
+		return "" // Hidden, with the length kept
+	}
+	return ssid
+}
+
+// airodumpSecurity maps the privacy and authentication columns to a WMAP
+// security label.
+func airodumpSecurity(privacy, auth string) string {
+	switch {
+	case privacy == "OPN":
+		return "OPEN"
+	case strings.Contains(privacy, "WEP"):
+		return "WEP"
+	case strings.Contains(auth, "SAE") || strings.Contains(privacy, "WPA3"):
+		return "WPA3"
+	case strings.Contains(privacy, "WPA2") && auth == "PSK":
+		return "WPA2-PSK"
+	case strings.Contains(privacy, "WPA2") && auth == "MGT":
+		return "WPA2-Enterprise"
+	case strings.Contains(privacy, "WPA2"):
+		return "WPA2"
+	case strings.Contains(privacy, "WPA"):
+		return "WPA"
+	}
+	return privacy
+}
+
+// airodumpPower parses a power column; airodump writes -1 when the driver
+// does not report signal.
+func airodumpPower(s string) int {
+	p, err := strconv.Atoi(s)
+	if err != nil || p == -1 {
+		return 0
+	}
+	return p
+}
+
+func airodumpTime(s string) time.Time {
+	t, err := time.ParseInLocation(airodumpTimeLayout, s, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
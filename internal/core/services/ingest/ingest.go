@@ -0,0 +1,144 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/adapters/kismet"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// SourceAnnotation is the device annotation naming the tool a device was
+	// last imported from.
+	SourceAnnotation = "ingest.source"
+	// maxReportedErrors bounds the errors listed in a result.
+	maxReportedErrors = 10
+)
+
+// DeviceProcessor merges a device observation into the registry, running
+// the same analysis as captured traffic.
+type DeviceProcessor interface {
+	ProcessDevice(ctx context.Context, device domain.Device) error
+}
+
+// Service imports device observations produced by other tools, such as
+// Kismet or airodump-ng, so they are correlated with the live capture.
+type Service struct {
+	processor DeviceProcessor
+}
+
+// NewService creates an ingestion service feeding processor.
+func NewService(processor DeviceProcessor) *Service {
+	return &Service{processor: processor}
+}
+
+// Ingest parses data in format, detected from the content when empty, and
+// merges every observation. Source names the producing tool and defaults to
+// the format.
+func (s *Service) Ingest(ctx context.Context, format domain.IngestFormat, source string, data []byte) (domain.IngestResult, error) {
+	if format == domain.IngestFormatAuto {
+		format = Detect(data)
+	}
+	devices, err := Parse(format, data)
+	if err != nil {
+		return domain.IngestResult{}, err
+	}
+	if len(devices) == 0 {
+		return domain.IngestResult{}, domain.ErrEmptyIngest
+	}
+	if source == "" {
+		source = string(format)
+	}
+	result := s.IngestDevices(ctx, source, devices)
+	result.Format = format
+	return result, nil
+}
+
+// IngestDevices merges observations already decoded by an adapter, such as
+// a bridge to another tool's sensors.
+func (s *Service) IngestDevices(ctx context.Context, source string, devices []domain.Device) domain.IngestResult {
+	result := domain.IngestResult{Source: source, Received: len(devices)}
+	now := time.Now()
+	for _, d := range devices {
+		if err := normalize(&d, source, now); err != nil {
+			skip(&result, err)
+			continue
+		}
+		if err := s.processor.ProcessDevice(ctx, d); err != nil {
+			skip(&result, fmt.Errorf("%s: %w", d.MAC, err))
+			continue
+		}
+		result.Processed++
+	}
+	return result
+}
+
+// Detect guesses the format of data: airodump CSV starts with its AP section
+// header and Kismet JSON uses dotted field names.
+func Detect(data []byte) domain.IngestFormat {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("BSSID,")) || bytes.HasPrefix(trimmed, []byte("Station MAC,")) {
+		return domain.IngestFormatAirodump
+	}
+	if bytes.Contains(trimmed, []byte(`"kismet.device.base.`)) {
+		return domain.IngestFormatKismet
+	}
+	return domain.IngestFormatWMAP
+}
+
+// Parse decodes the device observations in data.
+func Parse(format domain.IngestFormat, data []byte) ([]domain.Device, error) {
+	switch format {
+	case domain.IngestFormatWMAP:
+		return parseWMAP(data)
+	case domain.IngestFormatKismet:
+		return kismet.ParseDevices(data)
+	case domain.IngestFormatAirodump:
+		return parseAirodump(data)
+	default:
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnknownIngestFormat, format)
+	}
+}
+
+// normalize validates an observation and fills what the registry expects
+// from captured devices.
+func normalize(d *domain.Device, source string, now time.Time) error {
+	hw, err := net.ParseMAC(strings.TrimSpace(d.MAC))
+	if err != nil || len(hw) != 6 {
+		return fmt.Errorf("invalid MAC %q", d.MAC)
+	}
+	d.MAC = hw.String()
+	if d.Type == "" {
+		d.Type = domain.DeviceTypeUnknown
+	}
+	if d.LastPacketTime.IsZero() {
+		d.LastPacketTime = d.LastSeen
+	}
+	if d.LastPacketTime.IsZero() || d.LastPacketTime.After(now) {
+		d.LastPacketTime = now
+	}
+	if hw[0]&0x02 != 0 {
+		d.IsRandomized = true // Locally administered
+	}
+
+	annotations := make(map[string]string, len(d.Annotations)+1)
+	for k, v := range d.Annotations {
+		annotations[k] = v
+	}
+	annotations[SourceAnnotation] = source
+	d.Annotations = annotations
+	return nil
+}
+
+// skip records an observation that could not be merged.
+func skip(result *domain.IngestResult, err error) {
+	result.Skipped++
+	if len(result.Errors) < maxReportedErrors {
+		result.Errors = append(result.Errors, err.Error())
+	}
+}
@@ -0,0 +1,183 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingProcessor struct {
+	devices []domain.Device
+}
+
+func (p *recordingProcessor) ProcessDevice(ctx context.Context, d domain.Device) error {
+	if d.MAC == "de:ad:be:ef:00:01" {
+		return errors.New("rejected")
+	}
+	p.devices = append(p.devices, d)
+	return nil
+}
+
+const airodumpCSV = "\r\n" +
+	"BSSID, First time seen, Last time seen, channel, Speed, Privacy, Cipher, Authentication, Power, # beacons, # IV, LAN IP, ID-length, ESSID, Key\r\n" +
+	"00:11:22:33:44:55, 2024-01-01 10:00:00, 2024-01-01 10:05:00,  6,  54, WPA2, CCMP, PSK, -45,      120,        0,   0.  0.  0.  0,   9, Cafe,Shop, \r\n" +
+	"00:11:22:33:44:66, 2024-01-01 10:00:00, 2024-01-01 10:05:00, 36, 866, OPN, , , -1,       10,        0,   0.  0.  0.  0,   0, , \r\n" +
+	"\r\n" +
+	"Station MAC, First time seen, Last time seen, Power, # packets, BSSID, Probed ESSIDs\r\n" +
+	"AA:BB:CC:DD:EE:FF, 2024-01-01 10:01:00, 2024-01-01 10:04:00, -60,       30, 00:11:22:33:44:55, Home,Office\r\n" +
+	"11:22:33:44:55:66, 2024-01-01 10:01:00, 2024-01-01 10:04:00, -70,        3, (not associated) ,\r\n"
+
+const kismetJSON = `[
+ {
+  "kismet.device.base.macaddr": "00:11:22:33:44:55",
+  "kismet.device.base.phyname": "IEEE802.11",
+  "kismet.device.base.type": "Wi-Fi AP",
+  "kismet.device.base.manuf": "Cisco",
+  "kismet.device.base.channel": "11",
+  "kismet.device.base.frequency": 2462000,
+  "kismet.device.base.crypt": "WPA2-PSK AES-CCMP",
+  "kismet.device.base.first_time": 1704103200,
+  "kismet.device.base.last_time": 1704103500,
+  "kismet.device.base.packets.total": 42,
+  "kismet.device.base.signal": {"kismet.common.signal.last_signal": -52},
+  "kismet.device.base.location": {"kismet.common.location.avg_loc": {"kismet.common.location.geopoint": [-3.7, 40.4]}},
+  "dot11.device": {"dot11.device.last_beaconed_ssid": "Corp"}
+ },
+ {
+  "kismet.device.base.macaddr": "AA:BB:CC:DD:EE:FF",
+  "kismet.device.base.phyname": "IEEE802.11",
+  "kismet.device.base.type": "Wi-Fi Client",
+  "kismet.device.base.manuf": "Unknown",
+  "kismet.device.base.last_time": 1704103500,
+  "dot11.device": {
+   "dot11.device.last_bssid": "00:11:22:33:44:55",
+   "dot11.device.probed_ssid_map": [{"dot11.probedssid.ssid": "Home", "dot11.probedssid.last_time": 1704103400}]
+  }
+ },
+ {
+  "kismet.device.base.macaddr": "CC:CC:CC:CC:CC:CC",
+  "kismet.device.base.phyname": "Bluetooth",
+  "kismet.device.base.type": "BR/EDR"
+ }
+]`
+
+func TestDetect(t *testing.T) {
+	assert.Equal(t, domain.IngestFormatAirodump, Detect([]byte(airodumpCSV)))
+	assert.Equal(t, domain.IngestFormatKismet, Detect([]byte(kismetJSON)))
+	assert.Equal(t, domain.IngestFormatWMAP, Detect([]byte(`[{"mac":"00:11:22:33:44:55"}]`)))
+}
+
+func TestParseAirodump(t *testing.T) {
+	devices, err := Parse(domain.IngestFormatAirodump, []byte(airodumpCSV))
+	require.NoError(t, err)
+	require.Len(t, devices, 4)
+
+	ap := devices[0]
+	assert.Equal(t, domain.DeviceTypeAP, ap.Type)
+	assert.Equal(t, "Cafe,Shop", ap.SSID, "SSID with a comma")
+	assert.Equal(t, 6, ap.Channel)
+	assert.Equal(t, "WPA2-PSK", ap.Security)
+	assert.Equal(t, -45, ap.RSSI)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 5, 0, 0, time.Local), ap.LastPacketTime)
+
+	hidden := devices[1]
+	assert.Empty(t, hidden.SSID)
+	assert.Equal(t, "OPEN", hidden.Security)
+	assert.Zero(t, hidden.RSSI, "-1 means no signal report")
+
+	sta := devices[2]
+	assert.Equal(t, domain.DeviceTypeStation, sta.Type)
+	assert.Equal(t, "00:11:22:33:44:55", sta.ConnectedSSID)
+	assert.Contains(t, sta.ProbedSSIDs, "Home")
+	assert.Contains(t, sta.ProbedSSIDs, "Office")
+
+	assert.Empty(t, devices[3].ConnectedSSID, "not associated")
+	assert.Empty(t, devices[3].ProbedSSIDs)
+}
+
+func TestParseKismet(t *testing.T) {
+	devices, err := Parse(domain.IngestFormatKismet, []byte(kismetJSON))
+	require.NoError(t, err)
+	require.Len(t, devices, 2, "non Wi-Fi devices are ignored")
+
+	ap := devices[0]
+	assert.Equal(t, domain.DeviceTypeAP, ap.Type)
+	assert.Equal(t, "Corp", ap.SSID)
+	assert.Equal(t, "Cisco", ap.Vendor)
+	assert.Equal(t, 11, ap.Channel)
+	assert.Equal(t, 2462, ap.Frequency)
+	assert.Equal(t, "WPA2-PSK", ap.Security)
+	assert.Equal(t, -52, ap.RSSI)
+	assert.Equal(t, 40.4, ap.Latitude)
+	assert.Equal(t, -3.7, ap.Longitude)
+	assert.Equal(t, time.Unix(1704103500, 0), ap.LastPacketTime)
+
+	sta := devices[1]
+	assert.Equal(t, domain.DeviceTypeStation, sta.Type)
+	assert.Empty(t, sta.Vendor)
+	assert.Equal(t, "00:11:22:33:44:55", sta.ConnectedSSID)
+	assert.Equal(t, time.Unix(1704103400, 0), sta.ProbedSSIDs["Home"])
+
+	// One record per line, as in ekjson
+	lines := `{"kismet.device.base.macaddr": "00:11:22:33:44:55", "kismet.device.base.type": "Wi-Fi AP"}
+{"kismet.device.base.macaddr": "AA:BB:CC:DD:EE:FF", "kismet.device.base.type": "Wi-Fi Client"}`
+	devices, err = Parse(domain.IngestFormatKismet, []byte(lines))
+	require.NoError(t, err)
+	assert.Len(t, devices, 2)
+}
+
+func TestParseWMAP(t *testing.T) {
+	devices, err := Parse(domain.IngestFormatWMAP, []byte(`[{"mac":"00:11:22:33:44:55","type":"ap","ssid":"Corp"}]`))
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "Corp", devices[0].SSID)
+
+	devices, err = Parse(domain.IngestFormatWMAP, []byte(`{"devices":[{"mac":"00:11:22:33:44:55"},{"mac":"aa:bb:cc:dd:ee:ff"}]}`))
+	require.NoError(t, err)
+	assert.Len(t, devices, 2)
+
+	devices, err = Parse(domain.IngestFormatWMAP, []byte(`{"mac":"00:11:22:33:44:55"}`))
+	require.NoError(t, err)
+	assert.Len(t, devices, 1)
+
+	_, err = Parse(domain.IngestFormatWMAP, []byte(`{"mac":`))
+	assert.Error(t, err)
+	_, err = Parse("pcap", nil)
+	assert.ErrorIs(t, err, domain.ErrUnknownIngestFormat)
+}
+
+func TestService_Ingest(t *testing.T) {
+	processor := &recordingProcessor{}
+	svc := NewService(processor)
+
+	data := `[
+		{"mac":"00:11:22:33:44:55","type":"ap","last_packet_time":"2024-01-01T10:00:00Z"},
+		{"mac":"DA:BB:CC:DD:EE:FF","type":"station"},
+		{"mac":"not-a-mac"},
+		{"mac":"de:ad:be:ef:00:01"}
+	]`
+	result, err := svc.Ingest(context.Background(), domain.IngestFormatAuto, "", []byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, domain.IngestFormatWMAP, result.Format)
+	assert.Equal(t, "wmap", result.Source)
+	assert.Equal(t, 4, result.Received)
+	assert.Equal(t, 2, result.Processed)
+	assert.Equal(t, 2, result.Skipped)
+	assert.Len(t, result.Errors, 2)
+
+	require.Len(t, processor.devices, 2)
+	ap, sta := processor.devices[0], processor.devices[1]
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), ap.LastPacketTime.UTC())
+	assert.Equal(t, "wmap", ap.Annotations[SourceAnnotation])
+	assert.Equal(t, "da:bb:cc:dd:ee:ff", sta.MAC, "MAC is normalized")
+	assert.True(t, sta.IsRandomized, "locally administered MAC")
+	assert.False(t, sta.LastPacketTime.IsZero(), "missing timestamp defaults to now")
+
+	_, err = svc.Ingest(context.Background(), domain.IngestFormatKismet, "kismet-1", []byte(`[]`))
+	assert.ErrorIs(t, err, domain.ErrEmptyIngest)
+}
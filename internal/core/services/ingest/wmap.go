@@ -0,0 +1,41 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// parseWMAP decodes WMAP device JSON: an array as written by the JSON export,
+// an object with a "devices" array, or a single device.
+func parseWMAP(data []byte) ([]domain.Device, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var devices []domain.Device
+		if err := json.Unmarshal(trimmed, &devices); err != nil {
+			return nil, fmt.Errorf("invalid WMAP JSON: %w", err)
+		}
+		return devices, nil
+	}
+
+	var wrapped struct {
+		Devices []domain.Device `json:"devices"`
+	}
+	if err := json.Unmarshal(trimmed, &wrapped); err != nil {
+		return nil, fmt.Errorf("invalid WMAP JSON: %w", err)
+	}
+	if wrapped.Devices != nil {
+		return wrapped.Devices, nil
+	}
+
+	var device domain.Device
+	if err := json.Unmarshal(trimmed, &device); err != nil {
+		return nil, fmt.Errorf("invalid WMAP JSON: %w", err)
+	}
+	if device.MAC == "" {
+		return nil, nil
+	}
+	return []domain.Device{device}, nil
+}
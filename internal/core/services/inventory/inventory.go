@@ -0,0 +1,89 @@
+package inventory
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// DefaultInterval is how often the inventory is synchronized.
+const DefaultInterval = time.Hour
+
+// Service keeps the trusted inventory pulled from an external source, such
+// as a CMDB. Devices it lists are tagged with their business asset and are
+// known devices for the baseline. The source is authoritative: its entries
+// replace assets set by hand on the devices it lists.
+type Service struct {
+	source ports.InventorySource
+
+	mu        sync.RWMutex
+	inventory domain.Inventory
+	status    domain.InventoryStatus
+}
+
+// NewService creates an empty inventory synchronized from source.
+func NewService(source ports.InventorySource) *Service {
+	return &Service{
+		source:    source,
+		inventory: domain.Inventory{},
+		status:    domain.InventoryStatus{Source: source.Name()},
+	}
+}
+
+// Lookup returns the entry listing mac.
+func (s *Service) Lookup(mac string) (domain.InventoryEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.inventory.Lookup(mac)
+}
+
+// Status describes the last synchronization.
+func (s *Service) Status() domain.InventoryStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// Sync fetches the inventory. On failure the previous entries are kept.
+func (s *Service) Sync(ctx context.Context) (domain.InventoryStatus, error) {
+	entries, err := s.source.FetchInventory(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.status.Error = err.Error()
+		return s.status, err
+	}
+	s.inventory = domain.NewInventory(entries)
+	s.status.Entries = len(s.inventory)
+	s.status.LastSync = time.Now()
+	s.status.Error = ""
+	return s.status, nil
+}
+
+// Run synchronizes now and then every interval until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	failed := false
+	for {
+		status, err := s.Sync(ctx)
+		switch {
+		case err != nil && !failed:
+			log.Printf("[CMDB] Synchronizing %s failed: %v", status.Source, err)
+		case err == nil:
+			log.Printf("[CMDB] Synchronized %d assets from %s", status.Entries, status.Source)
+		}
+		failed = err != nil
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,403 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// Domain errors of the job queue
+var (
+	ErrUnknownKind  = errors.New("unknown job kind")
+	ErrJobNotFound  = errors.New("job not found")
+	ErrJobFinished  = errors.New("job already finished")
+	ErrQueueFull    = errors.New("job queue is full")
+	ErrQueueStopped = errors.New("job queue is not running")
+)
+
+const (
+	// DefaultWorkers is the number of jobs run concurrently.
+	DefaultWorkers = 2
+	// DefaultMaxAttempts is how many times a failing job is tried.
+	DefaultMaxAttempts = 3
+	// DefaultRetryDelay is the wait before the first retry; it doubles on each attempt.
+	DefaultRetryDelay = 5 * time.Second
+
+	// queueCapacity bounds the number of queued jobs.
+	queueCapacity = 1024
+	// progressPersistInterval throttles storage writes of progress updates.
+	progressPersistInterval = time.Second
+)
+
+// ProgressFunc reports the progress of a job, from 0 to 100.
+type ProgressFunc func(percent float64, message string)
+
+// Handler executes a job. It must return promptly once ctx is canceled.
+type Handler func(ctx context.Context, job domain.Job, progress ProgressFunc) error
+
+// Ensure compliance
+var _ ports.JobManager = (*Queue)(nil)
+
+// Queue is a persistent background job queue served by a pool of workers.
+// Jobs are stored on every state change so unfinished ones are resumed after
+// a restart; failed attempts are retried with exponential backoff.
+type Queue struct {
+	store      ports.JobRepository
+	workers    int
+	retryDelay time.Duration
+	notifier   func(domain.Job)
+
+	handlers  map[string]Handler
+	active    map[string]*domain.Job // Unfinished jobs
+	cancels   map[string]context.CancelFunc
+	persisted map[string]time.Time // Last progress write per job
+	pending   chan string
+	started   bool
+	mu        sync.Mutex
+}
+
+// NewQueue creates a queue persisting jobs in store.
+func NewQueue(store ports.JobRepository, workers int) *Queue {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	return &Queue{
+		store:      store,
+		workers:    workers,
+		retryDelay: DefaultRetryDelay,
+		handlers:   make(map[string]Handler),
+		active:     make(map[string]*domain.Job),
+		cancels:    make(map[string]context.CancelFunc),
+		persisted:  make(map[string]time.Time),
+		pending:    make(chan string, queueCapacity),
+	}
+}
+
+// Register sets the handler for a job kind. Must be called before Start.
+func (q *Queue) Register(kind string, handler Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[kind] = handler
+}
+
+// SetNotifier sets the callback receiving every job update (e.g. over WebSocket).
+func (q *Queue) SetNotifier(notifier func(domain.Job)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.notifier = notifier
+}
+
+// SetRetryDelay sets the wait before the first retry.
+func (q *Queue) SetRetryDelay(delay time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.retryDelay = delay
+}
+
+// Start resumes unfinished jobs from storage and starts the workers. Jobs
+// that were running when the process stopped are queued again.
+func (q *Queue) Start(ctx context.Context) error {
+	unfinished, err := q.store.ListJobs(ctx, domain.JobFilter{
+		Statuses: []domain.JobStatus{domain.JobQueued, domain.JobRunning},
+	})
+	if err != nil {
+		return fmt.Errorf("load unfinished jobs: %w", err)
+	}
+
+	q.mu.Lock()
+	q.started = true
+	q.mu.Unlock()
+
+	// Oldest first
+	for i := len(unfinished) - 1; i >= 0; i-- {
+		job := unfinished[i]
+		job.Status = domain.JobQueued
+		job.Message = "Resumed after restart"
+		q.mu.Lock()
+		q.active[job.ID] = &job
+		q.mu.Unlock()
+		q.save(job)
+		if err := q.push(job.ID); err != nil {
+			log.Printf("[JOBS] Could not resume job %s: %v", job.ID, err)
+		}
+	}
+
+	for i := 0; i < q.workers; i++ {
+		go q.worker(ctx)
+	}
+	return nil
+}
+
+// EnqueueJob queues a job of a registered kind.
+func (q *Queue) EnqueueJob(ctx context.Context, kind string, payload string) (domain.Job, error) {
+	q.mu.Lock()
+	_, ok := q.handlers[kind]
+	started := q.started
+	q.mu.Unlock()
+	if !ok {
+		return domain.Job{}, fmt.Errorf("%w: %s", ErrUnknownKind, kind)
+	}
+	if !started {
+		return domain.Job{}, ErrQueueStopped
+	}
+
+	job := domain.Job{
+		ID:          uuid.New().String(),
+		Kind:        kind,
+		Status:      domain.JobQueued,
+		Payload:     payload,
+		MaxAttempts: DefaultMaxAttempts,
+		CreatedAt:   time.Now(),
+	}
+	if err := q.store.SaveJob(ctx, job); err != nil {
+		return domain.Job{}, fmt.Errorf("save job: %w", err)
+	}
+
+	// Workers own the active copy; job stays the caller's snapshot
+	active := job
+	q.mu.Lock()
+	q.active[job.ID] = &active
+	q.mu.Unlock()
+
+	q.notify(job)
+	if err := q.push(job.ID); err != nil {
+		q.finish(job.ID, domain.JobFailed, err.Error())
+		return domain.Job{}, err
+	}
+	return job, nil
+}
+
+// GetJob returns a job by ID.
+func (q *Queue) GetJob(ctx context.Context, id string) (domain.Job, error) {
+	q.mu.Lock()
+	if job, ok := q.active[id]; ok {
+		defer q.mu.Unlock()
+		return *job, nil
+	}
+	q.mu.Unlock()
+
+	job, err := q.store.GetJob(ctx, id)
+	if err != nil || job == nil {
+		return domain.Job{}, ErrJobNotFound
+	}
+	return *job, nil
+}
+
+// ListJobs returns jobs, most recent first.
+func (q *Queue) ListJobs(ctx context.Context, filter domain.JobFilter) ([]domain.Job, error) {
+	jobs, err := q.store.ListJobs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage only holds throttled progress; overlay the live state
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i := range jobs {
+		if live, ok := q.active[jobs[i].ID]; ok {
+			jobs[i] = *live
+		}
+	}
+	return jobs, nil
+}
+
+// CancelJob stops a queued or running job.
+func (q *Queue) CancelJob(ctx context.Context, id string) error {
+	q.mu.Lock()
+	job, ok := q.active[id]
+	if !ok {
+		q.mu.Unlock()
+		if _, err := q.store.GetJob(ctx, id); err != nil {
+			return ErrJobNotFound
+		}
+		return ErrJobFinished
+	}
+	cancel, running := q.cancels[id]
+	status := job.Status
+	q.mu.Unlock()
+
+	if running {
+		// The worker records the cancellation once the handler returns
+		cancel()
+		return nil
+	}
+	if status == domain.JobQueued {
+		q.finish(id, domain.JobCanceled, "")
+	}
+	return nil
+}
+
+func (q *Queue) push(id string) error {
+	select {
+	case q.pending <- id:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+func (q *Queue) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case id := <-q.pending:
+			q.run(ctx, id)
+		}
+	}
+}
+
+func (q *Queue) run(parent context.Context, id string) {
+	q.mu.Lock()
+	job, ok := q.active[id]
+	if !ok || job.Status != domain.JobQueued {
+		// Canceled while queued
+		q.mu.Unlock()
+		return
+	}
+	handler, ok := q.handlers[job.Kind]
+	if !ok {
+		// Resumed job whose kind is no longer registered
+		q.mu.Unlock()
+		q.finish(id, domain.JobFailed, ErrUnknownKind.Error())
+		return
+	}
+	ctx, cancel := context.WithCancel(parent)
+	q.cancels[id] = cancel
+
+	job.Status = domain.JobRunning
+	job.Attempts++
+	job.StartedAt = time.Now()
+	job.Error = ""
+	snapshot := *job
+	q.mu.Unlock()
+	defer cancel()
+
+	q.save(snapshot)
+	q.notify(snapshot)
+
+	err := q.invoke(ctx, handler, snapshot)
+
+	q.mu.Lock()
+	delete(q.cancels, id)
+	attempts, maxAttempts := job.Attempts, job.MaxAttempts
+	retryDelay := q.retryDelay
+	q.mu.Unlock()
+
+	switch {
+	case err == nil:
+		q.finish(id, domain.JobSucceeded, "")
+	case ctx.Err() != nil && parent.Err() == nil:
+		q.finish(id, domain.JobCanceled, "")
+	case parent.Err() != nil:
+		// Shutting down: leave the job running in storage so it is resumed
+	case attempts < maxAttempts:
+		q.retry(id, err, retryDelay<<(attempts-1))
+	default:
+		q.finish(id, domain.JobFailed, err.Error())
+	}
+}
+
+// invoke runs the handler, turning a panic into a job failure.
+func (q *Queue) invoke(ctx context.Context, handler Handler, job domain.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return handler(ctx, job, func(percent float64, message string) {
+		q.progress(job.ID, percent, message)
+	})
+}
+
+func (q *Queue) progress(id string, percent float64, message string) {
+	q.mu.Lock()
+	job, ok := q.active[id]
+	if !ok {
+		q.mu.Unlock()
+		return
+	}
+	job.Progress = min(max(percent, 0), 100)
+	if message != "" {
+		job.Message = message
+	}
+	snapshot := *job
+	persist := time.Since(q.persisted[id]) >= progressPersistInterval
+	if persist {
+		q.persisted[id] = time.Now()
+	}
+	q.mu.Unlock()
+
+	if persist {
+		q.save(snapshot)
+	}
+	q.notify(snapshot)
+}
+
+func (q *Queue) retry(id string, cause error, delay time.Duration) {
+	q.mu.Lock()
+	job, ok := q.active[id]
+	if !ok {
+		q.mu.Unlock()
+		return
+	}
+	job.Status = domain.JobQueued
+	job.Error = cause.Error()
+	job.Message = fmt.Sprintf("Retrying in %s", delay)
+	snapshot := *job
+	q.mu.Unlock()
+
+	q.save(snapshot)
+	q.notify(snapshot)
+
+	time.AfterFunc(delay, func() {
+		if err := q.push(id); err != nil {
+			q.finish(id, domain.JobFailed, err.Error())
+		}
+	})
+}
+
+// finish records a final state and forgets the job.
+func (q *Queue) finish(id string, status domain.JobStatus, errMsg string) {
+	q.mu.Lock()
+	job, ok := q.active[id]
+	if !ok {
+		q.mu.Unlock()
+		return
+	}
+	job.Status = status
+	job.Error = errMsg
+	job.FinishedAt = time.Now()
+	if status == domain.JobSucceeded {
+		job.Progress = 100
+	}
+	snapshot := *job
+	delete(q.active, id)
+	delete(q.persisted, id)
+	q.mu.Unlock()
+
+	q.save(snapshot)
+	q.notify(snapshot)
+}
+
+func (q *Queue) save(job domain.Job) {
+	if err := q.store.SaveJob(context.Background(), job); err != nil {
+		log.Printf("[JOBS] Failed to persist job %s: %v", job.ID, err)
+	}
+}
+
+func (q *Queue) notify(job domain.Job) {
+	q.mu.Lock()
+	notifier := q.notifier
+	q.mu.Unlock()
+	if notifier != nil {
+		notifier(job)
+	}
+}
@@ -0,0 +1,201 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryJobStore struct {
+	jobs map[string]domain.Job
+	mu   sync.Mutex
+}
+
+func newMemoryJobStore() *memoryJobStore {
+	return &memoryJobStore{jobs: make(map[string]domain.Job)}
+}
+
+func (s *memoryJobStore) SaveJob(ctx context.Context, job domain.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func (s *memoryJobStore) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &job, nil
+}
+
+func (s *memoryJobStore) ListJobs(ctx context.Context, filter domain.JobFilter) ([]domain.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var list []domain.Job
+	for _, job := range s.jobs {
+		if len(filter.Statuses) > 0 {
+			match := false
+			for _, status := range filter.Statuses {
+				match = match || job.Status == status
+			}
+			if !match {
+				continue
+			}
+		}
+		list = append(list, job)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list, nil
+}
+
+func waitForStatus(t *testing.T, q *Queue, id string, status domain.JobStatus) domain.Job {
+	t.Helper()
+	var job domain.Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = q.GetJob(context.Background(), id)
+		return err == nil && job.Status == status
+	}, 2*time.Second, 5*time.Millisecond)
+	return job
+}
+
+func TestQueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newMemoryJobStore()
+	q := NewQueue(store, 2)
+	q.SetRetryDelay(time.Millisecond)
+
+	var updates []domain.Job
+	var updatesMu sync.Mutex
+	q.SetNotifier(func(job domain.Job) {
+		updatesMu.Lock()
+		defer updatesMu.Unlock()
+		updates = append(updates, job)
+	})
+
+	q.Register("ok", func(ctx context.Context, job domain.Job, progress ProgressFunc) error {
+		progress(50, "halfway")
+		return nil
+	})
+	failures := 0
+	q.Register("flaky", func(ctx context.Context, job domain.Job, progress ProgressFunc) error {
+		failures++
+		if failures < 2 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	q.Register("broken", func(ctx context.Context, job domain.Job, progress ProgressFunc) error {
+		return errors.New("always fails")
+	})
+	q.Register("block", func(ctx context.Context, job domain.Job, progress ProgressFunc) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	_, err := q.EnqueueJob(ctx, "ok", "")
+	assert.ErrorIs(t, err, ErrQueueStopped)
+
+	require.NoError(t, q.Start(ctx))
+
+	t.Run("Unknown kind", func(t *testing.T) {
+		_, err := q.EnqueueJob(ctx, "nope", "")
+		assert.ErrorIs(t, err, ErrUnknownKind)
+	})
+
+	t.Run("Success reports progress", func(t *testing.T) {
+		job, err := q.EnqueueJob(ctx, "ok", `{"a":1}`)
+		require.NoError(t, err)
+		done := waitForStatus(t, q, job.ID, domain.JobSucceeded)
+		assert.Equal(t, 100.0, done.Progress)
+		assert.Equal(t, 1, done.Attempts)
+		assert.Equal(t, `{"a":1}`, done.Payload)
+
+		updatesMu.Lock()
+		defer updatesMu.Unlock()
+		var sawProgress bool
+		for _, update := range updates {
+			sawProgress = sawProgress || (update.ID == job.ID && update.Message == "halfway")
+		}
+		assert.True(t, sawProgress)
+	})
+
+	t.Run("Retries transient failures", func(t *testing.T) {
+		job, err := q.EnqueueJob(ctx, "flaky", "")
+		require.NoError(t, err)
+		done := waitForStatus(t, q, job.ID, domain.JobSucceeded)
+		assert.Equal(t, 2, done.Attempts)
+	})
+
+	t.Run("Fails after max attempts", func(t *testing.T) {
+		job, err := q.EnqueueJob(ctx, "broken", "")
+		require.NoError(t, err)
+		done := waitForStatus(t, q, job.ID, domain.JobFailed)
+		assert.Equal(t, DefaultMaxAttempts, done.Attempts)
+		assert.Equal(t, "always fails", done.Error)
+	})
+
+	t.Run("Cancel running job", func(t *testing.T) {
+		job, err := q.EnqueueJob(ctx, "block", "")
+		require.NoError(t, err)
+		waitForStatus(t, q, job.ID, domain.JobRunning)
+
+		require.NoError(t, q.CancelJob(ctx, job.ID))
+		done := waitForStatus(t, q, job.ID, domain.JobCanceled)
+		assert.False(t, done.FinishedAt.IsZero())
+		assert.ErrorIs(t, q.CancelJob(ctx, job.ID), ErrJobFinished)
+		assert.ErrorIs(t, q.CancelJob(ctx, "missing"), ErrJobNotFound)
+	})
+
+	t.Run("List filters by status", func(t *testing.T) {
+		failed, err := q.ListJobs(ctx, domain.JobFilter{Statuses: []domain.JobStatus{domain.JobFailed}})
+		require.NoError(t, err)
+		assert.Len(t, failed, 1)
+	})
+}
+
+func TestQueueResumesUnfinishedJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := newMemoryJobStore()
+	interrupted := domain.Job{
+		ID:          "interrupted",
+		Kind:        "ok",
+		Status:      domain.JobRunning,
+		Attempts:    1,
+		MaxAttempts: DefaultMaxAttempts,
+		CreatedAt:   time.Now().Add(-time.Minute),
+	}
+	require.NoError(t, store.SaveJob(ctx, interrupted))
+
+	q := NewQueue(store, 1)
+	ran := make(chan string, 1)
+	q.Register("ok", func(ctx context.Context, job domain.Job, progress ProgressFunc) error {
+		ran <- job.ID
+		return nil
+	})
+	require.NoError(t, q.Start(ctx))
+
+	select {
+	case id := <-ran:
+		assert.Equal(t, interrupted.ID, id)
+	case <-time.After(2 * time.Second):
+		t.Fatal("interrupted job was not resumed")
+	}
+	done := waitForStatus(t, q, interrupted.ID, domain.JobSucceeded)
+	assert.Equal(t, 2, done.Attempts)
+}
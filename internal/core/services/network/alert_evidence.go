@@ -0,0 +1,166 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// DefaultEvidenceLead is how long before an alert its evidence capture starts.
+const DefaultEvidenceLead = 10 * time.Second
+
+// evidenceSubtypes are the alerts whose frames are saved as evidence.
+var evidenceSubtypes = map[string]bool{
+	"DEAUTH_FLOOD":       true,
+	"EVIL_TWIN_DETECTED": true,
+	"WPA_HANDSHAKE":      true,
+}
+
+// AlertEvidence saves the frames around deauth floods, evil twins and
+// captured handshakes as a pcapng artifact, taken from the recent frames the
+// sniffer keeps in memory. The capture spans from lead before the alert to
+// when it is raised: deauth incidents are raised once their correlation
+// window has elapsed, so it covers the whole burst. The recent frames can
+// also be saved on demand, for events no alert caught.
+type AlertEvidence struct {
+	lead       time.Duration
+	history    ports.FrameHistory
+	store      ports.ArtifactManager
+	captureCtx domain.CaptureContextFunc
+	mu         sync.Mutex
+}
+
+// NewAlertEvidence creates a collector capturing from lead before each alert.
+func NewAlertEvidence(lead time.Duration) *AlertEvidence {
+	if lead <= 0 {
+		lead = DefaultEvidenceLead
+	}
+	return &AlertEvidence{lead: lead}
+}
+
+// SetHistory sets the sniffer frames are taken from. Without one, nothing
+// is captured.
+func (e *AlertEvidence) SetHistory(history ports.FrameHistory) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.history = history
+}
+
+// SetStore sets the artifact store evidence is saved to. Without one,
+// nothing is captured.
+func (e *AlertEvidence) SetStore(store ports.ArtifactManager) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.store = store
+}
+
+// SetCaptureContext sets the function returning the attribution written in
+// the captures' comments.
+func (e *AlertEvidence) SetCaptureContext(fn domain.CaptureContextFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.captureCtx = fn
+}
+
+// Wants reports whether evidence is captured for alert.
+func (e *AlertEvidence) Wants(alert domain.Alert) bool {
+	return alert.EvidenceID == "" && evidenceSubtypes[alert.Subtype]
+}
+
+// Capture saves the frames around alert and returns the ID of the artifact,
+// empty when the alert needs no evidence or no frame was kept.
+func (e *AlertEvidence) Capture(ctx context.Context, alert domain.Alert) (string, error) {
+	if !e.Wants(alert) {
+		return "", nil
+	}
+	e.mu.Lock()
+	history, store, captureCtx := e.history, e.store, e.captureCtx
+	e.mu.Unlock()
+	if history == nil || store == nil {
+		return "", nil
+	}
+
+	at := alert.Timestamp
+	if at.IsZero() {
+		at = time.Now()
+	}
+	from, to := at.Add(-e.lead), time.Now()
+	comments := []string{
+		"alert: " + alert.Subtype,
+		alert.Message,
+		fmt.Sprintf("window: %s - %s", from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339)),
+	}
+	if alert.DeviceMAC != "" {
+		comments = append(comments, "device: "+alert.DeviceMAC)
+	}
+	if captureCtx != nil {
+		comments = append(comments, captureCtx(alert.DeviceMAC).Comments()...)
+	}
+
+	data, frames, err := history.CaptureWindow(ctx, nil, from, to, comments)
+	if err != nil || frames == 0 {
+		return "", err
+	}
+	name := fmt.Sprintf("alert_%s_%s_%d.pcapng", strings.ToLower(alert.Subtype),
+		strings.ReplaceAll(strings.ToLower(alert.DeviceMAC), ":", ""), time.Now().Unix())
+	artifact, err := store.StoreArtifact(ctx, domain.ArtifactPcap, name, data)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Saved %d frames as evidence of %s alert to %s", frames, alert.Subtype, name)
+	return artifact.ID, nil
+}
+
+// SaveRecent saves the frames captured during the last window on iface,
+// every adapter when empty, as a pcapng artifact. The caller is recorded as
+// its operator.
+func (e *AlertEvidence) SaveRecent(ctx context.Context, iface string, window time.Duration) (domain.Artifact, error) {
+	e.mu.Lock()
+	history, store, captureCtx := e.history, e.store, e.captureCtx
+	e.mu.Unlock()
+	if history == nil || store == nil {
+		return domain.Artifact{}, domain.ErrFrameHistoryUnavailable
+	}
+
+	to := time.Now()
+	from := to.Add(-window)
+	comments := []string{fmt.Sprintf("window: %s - %s", from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))}
+	var ifaces []string
+	if iface != "" {
+		ifaces = []string{iface}
+		comments = append(comments, "interface: "+iface)
+	}
+	var attribution domain.CaptureContext
+	if captureCtx != nil {
+		attribution = captureCtx("")
+	}
+	if user, ok := domain.UserFromContext(ctx); ok {
+		attribution.Operator = user.Username
+	}
+	comments = append(comments, attribution.Comments()...)
+
+	data, frames, err := history.CaptureWindow(ctx, ifaces, from, to, comments)
+	if err != nil {
+		return domain.Artifact{}, err
+	}
+	if frames == 0 {
+		return domain.Artifact{}, domain.ErrNoRecentFrames
+	}
+	scope := "all"
+	if iface != "" {
+		scope = iface
+	}
+	name := fmt.Sprintf("recent_%s_%ds_%d.pcapng", scope, int(window.Seconds()), to.Unix())
+	artifact, err := store.StoreArtifact(ctx, domain.ArtifactPcap, name, data)
+	if err != nil {
+		return domain.Artifact{}, err
+	}
+	log.Printf("Saved the last %v of frames (%d) to %s", window, frames, name)
+	return artifact, nil
+}
@@ -0,0 +1,178 @@
+package network
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/registry"
+	"github.com/lcalzada-xor/wmap/internal/core/services/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// frameHistory is a sniffer keeping frames, recording the windows asked for.
+type frameHistory struct {
+	ports.Sniffer
+	frames   int
+	ifaces   []string
+	from, to time.Time
+	comments []string
+}
+
+func (h *frameHistory) CaptureWindow(ctx context.Context, ifaces []string, from, to time.Time, comments []string) ([]byte, int, error) {
+	h.ifaces, h.from, h.to, h.comments = ifaces, from, to, comments
+	if h.frames == 0 {
+		return nil, 0, nil
+	}
+	return []byte("pcapng"), h.frames, nil
+}
+
+func TestAlertEvidence_Capture(t *testing.T) {
+	history := &frameHistory{frames: 12}
+	store := &evidenceStore{stored: make(map[string][]byte)}
+	e := NewAlertEvidence(5 * time.Second)
+	at := time.Now().Add(-2 * time.Second)
+	flood := domain.Alert{Subtype: "DEAUTH_FLOOD", DeviceMAC: "00:11:22:33:44:55", Timestamp: at, Message: "Deauth flood"}
+
+	// Nothing is captured without a history and a store
+	id, err := e.Capture(context.Background(), flood)
+	require.NoError(t, err)
+	assert.Empty(t, id)
+
+	e.SetHistory(history)
+	e.SetStore(store)
+	e.SetCaptureContext(func(mac string) domain.CaptureContext {
+		return domain.CaptureContext{Workspace: "office"}
+	})
+
+	id, err = e.Capture(context.Background(), domain.Alert{Subtype: "HIGH_RETRY_RATE", Timestamp: at})
+	require.NoError(t, err)
+	assert.Empty(t, id, "not worth evidence")
+
+	id, err = e.Capture(context.Background(), flood)
+	require.NoError(t, err)
+	assert.Equal(t, "art-1", id)
+	assert.Equal(t, at.Add(-5*time.Second), history.from)
+	assert.True(t, history.to.After(at))
+	assert.Contains(t, history.comments, "alert: DEAUTH_FLOOD")
+	assert.Contains(t, history.comments, "device: 00:11:22:33:44:55")
+	require.Len(t, store.stored, 1)
+	for name := range store.stored {
+		assert.Contains(t, name, "alert_deauth_flood_001122334455_")
+	}
+
+	// Alerts that already carry evidence are left alone
+	flood.EvidenceID = "art-0"
+	assert.False(t, e.Wants(flood))
+
+	// Without frames in the window there is nothing to link
+	history.frames = 0
+	id, err = e.Capture(context.Background(), domain.Alert{Subtype: "WPA_HANDSHAKE", Timestamp: at})
+	require.NoError(t, err)
+	assert.Empty(t, id)
+}
+
+func TestAlertEvidence_SaveRecent(t *testing.T) {
+	history := &frameHistory{frames: 40}
+	store := &evidenceStore{stored: make(map[string][]byte)}
+	e := NewAlertEvidence(DefaultEvidenceLead)
+
+	_, err := e.SaveRecent(context.Background(), "wlan0", 30*time.Second)
+	assert.ErrorIs(t, err, domain.ErrFrameHistoryUnavailable)
+
+	e.SetHistory(history)
+	e.SetStore(store)
+	ctx := domain.ContextWithUser(context.Background(), &domain.User{Username: "alice"})
+	artifact, err := e.SaveRecent(ctx, "wlan0", 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "art-1", artifact.ID)
+	assert.Equal(t, []string{"wlan0"}, history.ifaces)
+	assert.WithinDuration(t, history.to.Add(-30*time.Second), history.from, 0)
+	assert.Contains(t, history.comments, "operator: alice")
+	require.Len(t, store.stored, 1)
+	for name := range store.stored {
+		assert.Contains(t, name, "recent_wlan0_30s_")
+	}
+
+	// Every adapter when no interface is given
+	_, err = e.SaveRecent(context.Background(), "", time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, history.ifaces)
+
+	history.frames = 0
+	_, err = e.SaveRecent(context.Background(), "", time.Minute)
+	assert.ErrorIs(t, err, domain.ErrNoRecentFrames)
+}
+
+func TestNetworkService_LinksAlertEvidence(t *testing.T) {
+	reg := registry.NewDeviceRegistry(nil, nil)
+	sec := security.NewSecurityEngine(reg)
+	history := &frameHistory{frames: 3}
+	store := &evidenceStore{stored: make(map[string][]byte)}
+	svc := NewNetworkService(reg, sec, nil, history, nil)
+	svc.SetEvidenceStore(store)
+
+	// Sensor alerts are published with their evidence
+	published := make(chan domain.Alert, 1)
+	svc.SetAlertPublisher(func(a domain.Alert) { published <- a })
+	require.NoError(t, svc.ReportAlert(context.Background(), domain.Alert{Subtype: "WPA_HANDSHAKE", DeviceMAC: "00:11:22:33:44:55", Timestamp: time.Now()}))
+	select {
+	case a := <-published:
+		assert.Equal(t, "art-1", a.EvidenceID)
+	case <-time.After(time.Second):
+		t.Fatal("alert not published")
+	}
+
+	// Engine alerts get it linked once saved
+	sec.AddDetector(evilTwinDetector{})
+	sec.Analyze(context.Background(), domain.Device{MAC: "aa:bb:cc:dd:ee:ff"})
+	require.Eventually(t, func() bool {
+		alerts, _ := svc.GetAlerts(context.Background())
+		for _, a := range alerts {
+			if a.Subtype == "EVIL_TWIN_DETECTED" {
+				return a.EvidenceID == "art-1"
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+}
+
+// evilTwinDetector raises an evil twin alert for every device.
+type evilTwinDetector struct{}
+
+func (evilTwinDetector) Name() string { return "evil_twin_test" }
+
+func (evilTwinDetector) Analyze(device *domain.Device, registry ports.DeviceRegistry) []domain.Alert {
+	return []domain.Alert{{Type: domain.AlertAnomaly, Subtype: "EVIL_TWIN_DETECTED", DeviceMAC: device.MAC, Timestamp: time.Now()}}
+}
+
+// agentRouter routes the sensors of one agent to a workspace
+type agentRouter struct{}
+
+func (agentRouter) Route(sensor string) (string, ports.Storage) { return "", nil }
+func (agentRouter) WorkspaceOf(sensor string) string {
+	if strings.HasPrefix(sensor, "agent-b/") {
+		return "site-b"
+	}
+	return "site-a"
+}
+
+func TestNetworkService_RoutesAlerts(t *testing.T) {
+	reg := registry.NewDeviceRegistry(nil, nil)
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), nil, nil, nil)
+	svc.SetWorkspaceRouter(agentRouter{})
+
+	published := make(chan domain.Alert, 1)
+	svc.SetAlertPublisher(func(a domain.Alert) { published <- a })
+	require.NoError(t, svc.ReportAlert(context.Background(), domain.Alert{Subtype: "DEAUTH_FLOOD", Sensor: "agent-b/wlan1", Timestamp: time.Now()}))
+	select {
+	case a := <-published:
+		assert.Equal(t, "site-b", a.Workspace)
+	case <-time.After(time.Second):
+		t.Fatal("alert not published")
+	}
+}
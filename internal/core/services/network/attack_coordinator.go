@@ -2,10 +2,19 @@ package network
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"log"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/adapters/attack/authflood"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/beaconspoof"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/csa"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/karma"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/navjam"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/probeflood"
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
 	"go.opentelemetry.io/otel"
@@ -14,12 +23,37 @@ import (
 
 // AttackCoordinator manages all active network attacks.
 type AttackCoordinator struct {
-	registry        ports.DeviceRegistry
-	sniffer         ports.Sniffer
-	audit           ports.AuditService
-	deauthEngine    ports.DeauthService
-	wpsEngine       ports.WPSAttackService
-	authFloodEngine *authflood.AuthFloodEngine
+	registry         ports.DeviceRegistry
+	sniffer          ports.Sniffer
+	audit            ports.AuditService
+	deauthEngine     ports.DeauthService
+	wpsEngine        ports.WPSAttackService
+	authFloodEngine  *authflood.AuthFloodEngine
+	probeFloodEngine *probeflood.ProbeFloodEngine
+	csaEngine        *csa.CSAEngine
+	beaconEngine     *beaconspoof.BeaconSpoofEngine
+	karmaEngine      *karma.KarmaEngine
+	navJamEngine     *navjam.NAVJamEngine
+	history          ports.AttackHistoryRepository
+	active           ports.ActiveAttackRepository
+	scope            ports.ScopeRepository
+	roe              ports.ROERepository
+	roeKey           []byte                // Keys the digest of rules of engagement
+	roles            domain.InterfaceRoles // Interfaces dedicated to injection and AP duties
+
+	launchMu sync.Mutex
+	launches map[string]attackLaunch // Running attacks by ID, until recorded
+
+	roeMu     sync.Mutex
+	roeExpiry *time.Timer // Fires when the rules of engagement attacks run under end
+}
+
+// attackLaunch is who started a running attack, and on what.
+type attackLaunch struct {
+	target   string
+	operator string
+	iface    string
+	started  time.Time
 }
 
 // NewAttackCoordinator creates a new attack coordinator.
@@ -32,22 +66,499 @@ func NewAttackCoordinator(
 		registry: registry,
 		sniffer:  sniffer,
 		audit:    audit,
+		launches: make(map[string]attackLaunch),
 	}
 }
 
 // SetDeauthEngine sets the deauth engine.
 func (c *AttackCoordinator) SetDeauthEngine(engine ports.DeauthService) {
 	c.deauthEngine = engine
+	if recorder, ok := engine.(ports.AttackRecorder); ok {
+		recorder.SetRecorder(c.recordAttack)
+	}
 }
 
 // SetWPSEngine sets the WPS engine.
 func (c *AttackCoordinator) SetWPSEngine(engine ports.WPSAttackService) {
 	c.wpsEngine = engine
+	if recorder, ok := engine.(ports.AttackRecorder); ok {
+		recorder.SetRecorder(c.recordAttack)
+	}
 }
 
 // SetAuthFloodEngine sets the Auth Flood engine.
 func (c *AttackCoordinator) SetAuthFloodEngine(engine *authflood.AuthFloodEngine) {
 	c.authFloodEngine = engine
+	engine.SetRecorder(c.recordAttack)
+}
+
+// SetProbeFloodEngine sets the Probe Flood engine.
+func (c *AttackCoordinator) SetProbeFloodEngine(engine *probeflood.ProbeFloodEngine) {
+	c.probeFloodEngine = engine
+	engine.SetRecorder(c.recordAttack)
+}
+
+// SetCSAEngine sets the Channel Switch Announcement engine.
+func (c *AttackCoordinator) SetCSAEngine(engine *csa.CSAEngine) {
+	c.csaEngine = engine
+	engine.SetRecorder(c.recordAttack)
+}
+
+// SetBeaconSpoofEngine sets the beacon spoofing engine.
+func (c *AttackCoordinator) SetBeaconSpoofEngine(engine *beaconspoof.BeaconSpoofEngine) {
+	c.beaconEngine = engine
+	engine.SetRecorder(c.recordAttack)
+}
+
+// SetKarmaEngine sets the Karma-lite responder engine.
+func (c *AttackCoordinator) SetKarmaEngine(engine *karma.KarmaEngine) {
+	c.karmaEngine = engine
+	engine.SetRecorder(c.recordAttack)
+}
+
+// SetNAVJamEngine sets the RTS/CTS virtual jamming engine.
+func (c *AttackCoordinator) SetNAVJamEngine(engine *navjam.NAVJamEngine) {
+	c.navJamEngine = engine
+	engine.SetRecorder(c.recordAttack)
+}
+
+// SetInterfaceRoles sets the interfaces attacks run on when none is given.
+func (c *AttackCoordinator) SetInterfaceRoles(roles domain.InterfaceRoles) {
+	c.roles = roles
+}
+
+// SetHistoryStore sets where finished attacks are recorded.
+func (c *AttackCoordinator) SetHistoryStore(store ports.AttackHistoryRepository) {
+	c.history = store
+}
+
+// SetActiveStore sets where running attacks are kept until they finish, to
+// recover them after a restart.
+func (c *AttackCoordinator) SetActiveStore(store ports.ActiveAttackRepository) {
+	c.active = store
+}
+
+// SetScopeStore sets where the engagement scope of the workspace is kept.
+func (c *AttackCoordinator) SetScopeStore(store ports.ScopeRepository) {
+	c.scope = store
+}
+
+// GetScope returns the engagement scope of the current workspace.
+func (c *AttackCoordinator) GetScope(ctx context.Context) (domain.EngagementScope, error) {
+	if c.scope == nil {
+		return domain.EngagementScope{}, nil
+	}
+	return c.scope.GetScope(ctx)
+}
+
+// SetScope replaces the engagement scope of the current workspace.
+func (c *AttackCoordinator) SetScope(ctx context.Context, scope domain.EngagementScope) error {
+	if c.scope == nil {
+		return fmt.Errorf("engagement scope storage not available")
+	}
+	if err := scope.Validate(); err != nil {
+		return err
+	}
+	if err := c.scope.SaveScope(ctx, scope); err != nil {
+		return err
+	}
+	if c.audit != nil {
+		c.audit.Log(ctx, domain.ActionConfigChange, "scope", fmt.Sprintf("Engagement scope set (%d BSSIDs, %d SSIDs, %d prefixes)", len(scope.BSSIDs), len(scope.SSIDs), len(scope.MACPrefixes)))
+	}
+	return nil
+}
+
+// SetROEStore sets where the rules of engagement of the workspace are kept,
+// and the key sealing them. Once set, no attack starts without rules of
+// engagement in force.
+func (c *AttackCoordinator) SetROEStore(store ports.ROERepository, key []byte) {
+	c.roe = store
+	c.roeKey = key
+}
+
+// GetROE returns the rules of engagement last signed for the workspace, nil
+// if none were.
+func (c *AttackCoordinator) GetROE(ctx context.Context) (*domain.RulesOfEngagement, error) {
+	if c.roe == nil {
+		return nil, nil
+	}
+	return c.roe.LatestROE(ctx)
+}
+
+// ListROE returns every rules of engagement record of the workspace.
+func (c *AttackCoordinator) ListROE(ctx context.Context) ([]domain.RulesOfEngagement, error) {
+	if c.roe == nil {
+		return nil, nil
+	}
+	return c.roe.ListROE(ctx)
+}
+
+// SignROE records the acknowledgment of the user in ctx for the current
+// engagement scope and window of roe, superseding earlier records.
+func (c *AttackCoordinator) SignROE(ctx context.Context, roe domain.RulesOfEngagement) (domain.RulesOfEngagement, error) {
+	if c.roe == nil {
+		return roe, fmt.Errorf("rules of engagement storage not available")
+	}
+	user, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return roe, fmt.Errorf("%w: must be signed by an authenticated operator", domain.ErrInvalidROE)
+	}
+	scope, err := c.GetScope(ctx)
+	if err != nil {
+		return roe, fmt.Errorf("engagement scope unavailable: %w", err)
+	}
+
+	roe.ID = 0
+	roe.Operator = user.Username
+	roe.Scope = scope
+	roe.SignedAt = time.Now()
+	roe.Revoked = false
+	if err := roe.Validate(); err != nil {
+		return roe, err
+	}
+	roe.Digest = roe.Seal(c.roeKey)
+	if err := c.roe.SaveROE(ctx, &roe); err != nil {
+		return roe, err
+	}
+
+	if c.audit != nil {
+		c.audit.Log(ctx, domain.ActionROESigned, fmt.Sprintf("roe-%d", roe.ID), fmt.Sprintf("Rules of engagement signed for %s to %s (digest %s)", roe.Start.Format(time.RFC3339), roe.End.Format(time.RFC3339), roe.Digest))
+	}
+	return roe, nil
+}
+
+// RevokeROE withdraws the rules of engagement in force and stops the running
+// attacks; attacks are refused until new ones are signed.
+func (c *AttackCoordinator) RevokeROE(ctx context.Context) error {
+	roe, err := c.GetROE(ctx)
+	if err != nil {
+		return err
+	}
+	if roe == nil || roe.Revoked {
+		return domain.ErrNoROE
+	}
+	roe.Revoked = true
+	if err := c.roe.SaveROE(ctx, roe); err != nil {
+		return err
+	}
+	if c.audit != nil {
+		c.audit.Log(ctx, domain.ActionROERevoked, fmt.Sprintf("roe-%d", roe.ID), "Rules of engagement revoked")
+	}
+	c.StopAll(ctx)
+	return nil
+}
+
+// watchROE arms the stop of the running attacks at end, when the rules of
+// engagement they were started under stop being in force.
+func (c *AttackCoordinator) watchROE(end time.Time) {
+	c.roeMu.Lock()
+	defer c.roeMu.Unlock()
+	if c.roeExpiry != nil {
+		c.roeExpiry.Stop()
+	}
+	c.roeExpiry = time.AfterFunc(time.Until(end), c.expireROE)
+}
+
+// expireROE stops the running attacks unless rules of engagement were
+// renewed since they were started. Rules that cannot be loaded stop them.
+func (c *AttackCoordinator) expireROE() {
+	ctx := context.Background()
+	roe, err := c.roe.LatestROE(ctx)
+	if err == nil && roe != nil && roe.Intact(c.roeKey) && roe.InForce(time.Now()) {
+		c.watchROE(roe.End)
+		return
+	}
+
+	log.Printf("Rules of engagement no longer in force, stopping attacks")
+	if c.audit != nil {
+		c.audit.Log(ctx, domain.ActionInfo, "roe", "Attacks stopped: rules of engagement window ended")
+	}
+	c.StopAll(ctx)
+}
+
+// checkROE refuses attacks unless intact rules of engagement, signed for
+// the current scope, are in force, and audits the refusal. Rules that cannot
+// be loaded block the attack.
+func (c *AttackCoordinator) checkROE(ctx context.Context, kind domain.AttackKind) error {
+	if c.roe == nil {
+		return nil
+	}
+	roe, err := c.roe.LatestROE(ctx)
+	if err != nil {
+		return fmt.Errorf("rules of engagement unavailable: %w", err)
+	}
+
+	var reason string
+	switch {
+	case roe == nil:
+		reason = "none signed"
+	case !roe.Intact(c.roeKey):
+		reason = fmt.Sprintf("record %d does not match its digest", roe.ID)
+	case !roe.InForce(time.Now()):
+		reason = fmt.Sprintf("record %d is revoked or outside its window", roe.ID)
+	default:
+		scope, err := c.GetScope(ctx)
+		if err != nil {
+			return fmt.Errorf("engagement scope unavailable: %w", err)
+		}
+		if roe.Covers(scope) {
+			c.watchROE(roe.End)
+			return nil
+		}
+		reason = fmt.Sprintf("engagement scope changed since record %d was signed", roe.ID)
+	}
+
+	if c.audit != nil {
+		c.audit.Log(ctx, domain.ActionScopeDenied, string(kind), fmt.Sprintf("Refused %s attack: %s", kind, reason))
+	}
+	return fmt.Errorf("%s: %w", reason, domain.ErrNoROE)
+}
+
+// checkScope refuses attacks on targets outside the engagement scope and
+// audits the refusal. ssid may be empty, in which case the SSID known for the
+// target in the registry is used. A scope that cannot be loaded blocks the attack.
+func (c *AttackCoordinator) checkScope(ctx context.Context, kind domain.AttackKind, target, ssid string) error {
+	if c.scope == nil {
+		return nil
+	}
+	scope, err := c.scope.GetScope(ctx)
+	if err != nil {
+		return fmt.Errorf("engagement scope unavailable: %w", err)
+	}
+	if scope.IsEmpty() {
+		return nil
+	}
+
+	if ssid == "" && target != "" {
+		if device, ok := c.registry.GetDevice(ctx, target); ok {
+			ssid = device.SSID
+		}
+	}
+	if scope.Allows(target, ssid) {
+		return nil
+	}
+
+	if c.audit != nil {
+		c.audit.Log(ctx, domain.ActionScopeDenied, target, fmt.Sprintf("Refused %s attack: target outside engagement scope (SSID: %q)", kind, ssid))
+	}
+	return fmt.Errorf("%s: %w", target, domain.ErrOutOfScope)
+}
+
+// trackLaunch remembers the user who started an attack until the engine
+// records it as finished, and persists it to survive a restart meanwhile.
+func (c *AttackCoordinator) trackLaunch(ctx context.Context, attack domain.ActiveAttack) {
+	if user, ok := domain.UserFromContext(ctx); ok {
+		attack.Operator = user.Username
+	}
+	c.launchMu.Lock()
+	c.launches[attack.ID] = attackLaunch{
+		target:   strings.ToLower(attack.Target),
+		operator: attack.Operator,
+		iface:    attack.Interface,
+		started:  attack.StartedAt,
+	}
+	c.launchMu.Unlock()
+
+	if c.active == nil {
+		return
+	}
+	if err := c.active.SaveActiveAttack(context.Background(), attack); err != nil {
+		fmt.Printf("[DB-ERR] Failed to save active attack %s: %v\n", attack.ID, err)
+	}
+}
+
+// AttackOn returns the ID and operator of the most recent running attack on
+// target, to attribute the frames captured meanwhile.
+func (c *AttackCoordinator) AttackOn(target string) (id, operator string, ok bool) {
+	target = strings.ToLower(target)
+	c.launchMu.Lock()
+	defer c.launchMu.Unlock()
+	var latest time.Time
+	for launchID, launch := range c.launches {
+		if launch.target == target && launch.started.After(latest) {
+			id, operator, ok = launchID, launch.operator, true
+			latest = launch.started
+		}
+	}
+	return id, operator, ok
+}
+
+// recordAttack scores a finished attack, attributes it to the user who
+// launched it and persists it to the attack history. The interface goes back
+// to its default bitrates once no other attack runs on it.
+func (c *AttackCoordinator) recordAttack(record domain.AttackRecord) {
+	var idle string
+	c.launchMu.Lock()
+	if launch, ok := c.launches[record.ID]; ok {
+		record.Operator = launch.operator
+		delete(c.launches, record.ID)
+		if !c.interfaceBusyLocked(launch.iface) {
+			idle = launch.iface
+		}
+	}
+	c.launchMu.Unlock()
+
+	c.restoreBitrates(context.Background(), idle)
+	if c.active != nil {
+		if err := c.active.DeleteActiveAttack(context.Background(), record.ID); err != nil {
+			fmt.Printf("[DB-ERR] Failed to clear active attack %s: %v\n", record.ID, err)
+		}
+	}
+
+	if c.history == nil {
+		return
+	}
+	record.Score = record.EffectivenessScore()
+	if err := c.history.SaveAttackRecord(context.Background(), record); err != nil {
+		fmt.Printf("[DB-ERR] Failed to save attack record %s: %v\n", record.ID, err)
+	}
+}
+
+// interfaceBusyLocked reports whether a running attack transmits on iface.
+func (c *AttackCoordinator) interfaceBusyLocked(iface string) bool {
+	for _, launch := range c.launches {
+		if launch.iface == iface {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreBitrates undoes the fixed bitrates the engines set for injection,
+// when the sniffer supports it.
+func (c *AttackCoordinator) restoreBitrates(ctx context.Context, iface string) {
+	restorer, ok := c.sniffer.(ports.BitrateRestorer)
+	if !ok || iface == "" {
+		return
+	}
+	if err := restorer.RestoreBitrates(ctx, iface); err != nil {
+		log.Printf("[ATTACK] Failed to restore bitrates on %s: %v", iface, err)
+	}
+}
+
+// RecoverAttacks deals with the attacks left running when the server last
+// stopped. Continuous attacks are started again with their configuration on
+// behalf of their operator; the others, and those that fail to restart, are
+// recorded as aborted. Their interfaces get their default bitrates back.
+func (c *AttackCoordinator) RecoverAttacks(ctx context.Context) {
+	if c.active == nil {
+		return
+	}
+	attacks, err := c.active.ListActiveAttacks(ctx)
+	if err != nil {
+		log.Printf("[ATTACK] Failed to list attacks left running: %v", err)
+		return
+	}
+
+	for _, attack := range attacks {
+		if err := c.active.DeleteActiveAttack(ctx, attack.ID); err != nil {
+			log.Printf("[ATTACK] Failed to clear active attack %s: %v", attack.ID, err)
+		}
+		c.restoreBitrates(ctx, attack.Interface)
+
+		opCtx := ctx
+		if attack.Operator != "" {
+			opCtx = domain.ContextWithUser(ctx, &domain.User{Username: attack.Operator})
+		}
+
+		reason := "server restarted"
+		if attack.Continuous() {
+			id, err := c.resumeAttack(opCtx, attack)
+			if err == nil {
+				log.Printf("[ATTACK] Resumed %s attack %s on %s as %s", attack.Kind, attack.ID, attack.Target, id)
+				if c.audit != nil {
+					c.audit.Log(opCtx, domain.ActionInfo, attack.Target, fmt.Sprintf("Resumed %s attack %s after restart as %s", attack.Kind, attack.ID, id))
+				}
+				continue
+			}
+			reason = fmt.Sprintf("server restarted, resume failed: %v", err)
+		}
+
+		log.Printf("[ATTACK] Aborted %s attack %s on %s: %s", attack.Kind, attack.ID, attack.Target, reason)
+		if c.history != nil {
+			if err := c.history.SaveAttackRecord(ctx, attack.Aborted(time.Now(), reason)); err != nil {
+				log.Printf("[ATTACK] Failed to save aborted attack %s: %v", attack.ID, err)
+			}
+		}
+		if c.audit != nil {
+			c.audit.Log(opCtx, domain.ActionDeauthStop, attack.Target, fmt.Sprintf("Aborted %s attack %s: %s", attack.Kind, attack.ID, reason))
+		}
+	}
+}
+
+// resumeAttack starts an interrupted continuous attack again with its
+// original configuration, through the usual scope and regulatory checks.
+func (c *AttackCoordinator) resumeAttack(ctx context.Context, attack domain.ActiveAttack) (string, error) {
+	switch attack.Kind {
+	case domain.AttackKindDeauth:
+		var config domain.DeauthAttackConfig
+		if err := json.Unmarshal([]byte(attack.Config), &config); err != nil {
+			return "", err
+		}
+		return c.StartDeauthAttack(ctx, config)
+	case domain.AttackKindAuthFlood:
+		var config domain.AuthFloodAttackConfig
+		if err := json.Unmarshal([]byte(attack.Config), &config); err != nil {
+			return "", err
+		}
+		return c.StartAuthFloodAttack(ctx, config)
+	case domain.AttackKindProbeFlood:
+		var config domain.ProbeFloodAttackConfig
+		if err := json.Unmarshal([]byte(attack.Config), &config); err != nil {
+			return "", err
+		}
+		return c.StartProbeFloodAttack(ctx, config)
+	case domain.AttackKindCSA:
+		var config domain.CSAAttackConfig
+		if err := json.Unmarshal([]byte(attack.Config), &config); err != nil {
+			return "", err
+		}
+		return c.StartCSAAttack(ctx, config)
+	case domain.AttackKindBeaconSpoof:
+		var config domain.BeaconSpoofConfig
+		if err := json.Unmarshal([]byte(attack.Config), &config); err != nil {
+			return "", err
+		}
+		return c.StartBeaconSpoof(ctx, config)
+	}
+	return "", fmt.Errorf("%s attacks cannot be resumed", attack.Kind)
+}
+
+// GetAttackHistory returns the most recent finished attacks, newest first.
+func (c *AttackCoordinator) GetAttackHistory(ctx context.Context, limit int) ([]domain.AttackRecord, error) {
+	if c.history == nil {
+		return nil, fmt.Errorf("attack history not available")
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	return c.history.ListAttackRecords(ctx, limit)
+}
+
+// checkTxAllowed refuses active operations on channels where the regulatory
+// domain forbids initiating transmissions (DFS, no-IR, disabled or radar seen).
+// Channels the interface does not list, or whose flags cannot be read, are
+// refused too. It is a no-op when no channel is given or the sniffer has no
+// regulatory info.
+func (c *AttackCoordinator) checkTxAllowed(ctx context.Context, iface string, channel int) error {
+	provider, ok := c.sniffer.(ports.RegulatoryProvider)
+	if !ok || iface == "" || channel == 0 {
+		return nil
+	}
+	// Fail closed: a channel whose flags are unknown may be DFS or no-IR
+	channels, err := provider.ChannelRegulatory(ctx, iface)
+	if err != nil {
+		return fmt.Errorf("channel %d: reading regulatory flags of %s: %v: %w", channel, iface, err, domain.ErrTxNotPermitted)
+	}
+	for _, ch := range channels {
+		if ch.Channel == channel {
+			if !ch.TxAllowed() {
+				return fmt.Errorf("channel %d: %w", channel, domain.ErrTxNotPermitted)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("channel %d: not supported by %s: %w", channel, iface, domain.ErrTxNotPermitted)
 }
 
 // StartDeauthAttack initiates a deauth attack with smart defaults.
@@ -61,6 +572,22 @@ func (c *AttackCoordinator) StartDeauthAttack(ctx context.Context, config domain
 	if c.deauthEngine == nil {
 		return "", fmt.Errorf("deauth engine not initialized")
 	}
+	if err := c.checkROE(ctx, domain.AttackKindDeauth); err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+
+	if err := c.checkScope(ctx, domain.AttackKindDeauth, config.TargetMAC, ""); err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+
+	// Spoofed deauthentication frames are dropped by APs that require 802.11w
+	if device, exists := c.registry.GetDevice(ctx, config.TargetMAC); exists && device.RSNInfo != nil && device.RSNInfo.Capabilities.MFPRequired {
+		err := fmt.Errorf("%s: %w", config.TargetMAC, domain.ErrPMFProtected)
+		span.RecordError(err)
+		return "", err
+	}
 
 	// Channel Auto-detection (use request context for synchronous lookup)
 	if config.Channel == 0 {
@@ -76,7 +603,9 @@ func (c *AttackCoordinator) StartDeauthAttack(ctx context.Context, config domain
 	if config.Interface == "" {
 		if c.sniffer != nil {
 			interfaces, _ := c.sniffer.GetInterfaces(ctx)
-			if len(interfaces) > 0 {
+			if iface := c.roles.Interface(domain.InterfaceRoleInjection, interfaces); iface != "" {
+				config.Interface = iface
+			} else if len(interfaces) > 0 {
 				found := false
 				for _, iface := range interfaces {
 					chans, _ := c.sniffer.GetInterfaceChannels(ctx, iface)
@@ -124,9 +653,17 @@ func (c *AttackCoordinator) StartDeauthAttack(ctx context.Context, config domain
 		}
 	}
 
+	if err := c.checkTxAllowed(ctx, config.Interface, config.Channel); err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+
 	// Use background context for long-running attack execution
 	// This prevents the attack from being canceled when the HTTP request completes
 	id, err := c.deauthEngine.StartAttack(context.Background(), config)
+	if err == nil {
+		c.trackLaunch(ctx, domain.NewActiveAttack(domain.AttackKindDeauth, id, config.TargetMAC, config.Interface, config.Channel, config))
+	}
 	if err == nil && c.audit != nil {
 		c.audit.Log(ctx, domain.ActionDeauthStart, config.TargetMAC, fmt.Sprintf("Type: %s, Ch: %d", config.AttackType, config.Channel))
 	} else if err != nil {
@@ -172,9 +709,15 @@ func (c *AttackCoordinator) StartWPSAttack(ctx context.Context, config domain.WP
 	if c.wpsEngine == nil {
 		return "", fmt.Errorf("WPS engine not initialized")
 	}
+	if err := c.checkROE(ctx, domain.AttackKindWPS); err != nil {
+		return "", err
+	}
 	if config.TargetBSSID == "" {
 		return "", fmt.Errorf("target BSSID is required")
 	}
+	if err := c.checkScope(ctx, domain.AttackKindWPS, config.TargetBSSID, ""); err != nil {
+		return "", err
+	}
 
 	// Auto-detect channel (use request context for synchronous lookup)
 	if config.Channel == 0 {
@@ -191,7 +734,7 @@ func (c *AttackCoordinator) StartWPSAttack(ctx context.Context, config domain.WP
 		if c.sniffer != nil {
 			interfaces, _ := c.sniffer.GetInterfaces(ctx)
 			if len(interfaces) > 0 {
-				config.Interface = interfaces[0]
+				config.Interface = c.roles.Pick(domain.InterfaceRoleInjection, interfaces)
 			} else {
 				return "", fmt.Errorf("no interfaces available")
 			}
@@ -200,8 +743,16 @@ func (c *AttackCoordinator) StartWPSAttack(ctx context.Context, config domain.WP
 		}
 	}
 
+	if err := c.checkTxAllowed(ctx, config.Interface, config.Channel); err != nil {
+		return "", err
+	}
+
 	// Use background context for long-running attack execution
-	return c.wpsEngine.StartAttack(context.Background(), config)
+	id, err := c.wpsEngine.StartAttack(context.Background(), config)
+	if err == nil {
+		c.trackLaunch(ctx, domain.NewActiveAttack(domain.AttackKindWPS, id, config.TargetBSSID, config.Interface, config.Channel, config))
+	}
+	return id, err
 }
 
 // StopWPSAttack stops a WPS attack.
@@ -225,6 +776,12 @@ func (c *AttackCoordinator) StartAuthFloodAttack(ctx context.Context, config dom
 	if c.authFloodEngine == nil {
 		return "", fmt.Errorf("auth flood engine not initialized")
 	}
+	if err := c.checkROE(ctx, domain.AttackKindAuthFlood); err != nil {
+		return "", err
+	}
+	if err := c.checkScope(ctx, domain.AttackKindAuthFlood, config.TargetBSSID, config.TargetSSID); err != nil {
+		return "", err
+	}
 
 	// Auto-detect channel (use request context for synchronous lookup)
 	if config.Channel == 0 && config.TargetBSSID != "" {
@@ -238,14 +795,25 @@ func (c *AttackCoordinator) StartAuthFloodAttack(ctx context.Context, config dom
 	if config.Interface == "" && c.sniffer != nil {
 		interfaces, _ := c.sniffer.GetInterfaces(ctx)
 		if len(interfaces) > 0 {
-			config.Interface = interfaces[0]
+			config.Interface = c.roles.Pick(domain.InterfaceRoleInjection, interfaces)
 		}
 	}
 
+	if err := c.checkTxAllowed(ctx, config.Interface, config.Channel); err != nil {
+		return "", err
+	}
+
 	// Use background context for long-running attack execution
 	id, err := c.authFloodEngine.StartAttack(context.Background(), config)
+	if err == nil {
+		c.trackLaunch(ctx, domain.NewActiveAttack(domain.AttackKindAuthFlood, id, config.TargetBSSID, config.Interface, config.Channel, config))
+	}
 	if err == nil && c.audit != nil {
-		c.audit.Log(ctx, domain.ActionDeauthStart, config.TargetBSSID, "Started Auth Flood")
+		msg := "Started Auth Flood"
+		if config.AttackType == domain.AuthFloodTypeAssociation {
+			msg = fmt.Sprintf("Started Association Flood (SSID: %s)", config.TargetSSID)
+		}
+		c.audit.Log(ctx, domain.ActionDeauthStart, config.TargetBSSID, msg)
 	}
 	return id, err
 }
@@ -266,6 +834,301 @@ func (c *AttackCoordinator) GetAuthFloodStatus(ctx context.Context, id string) (
 	return c.authFloodEngine.GetStatus(ctx, id)
 }
 
+// StartProbeFloodAttack initiates a Probe Request flood.
+func (c *AttackCoordinator) StartProbeFloodAttack(ctx context.Context, config domain.ProbeFloodAttackConfig) (string, error) {
+	if c.probeFloodEngine == nil {
+		return "", fmt.Errorf("probe flood engine not initialized")
+	}
+	if err := c.checkROE(ctx, domain.AttackKindProbeFlood); err != nil {
+		return "", err
+	}
+
+	// Auto-detect interface (use request context for synchronous lookup)
+	if config.Interface == "" && c.sniffer != nil {
+		interfaces, _ := c.sniffer.GetInterfaces(ctx)
+		if len(interfaces) > 0 {
+			config.Interface = c.roles.Pick(domain.InterfaceRoleInjection, interfaces)
+		}
+	}
+
+	if err := c.checkTxAllowed(ctx, config.Interface, config.Channel); err != nil {
+		return "", err
+	}
+
+	// Use background context for long-running attack execution
+	id, err := c.probeFloodEngine.StartAttack(context.Background(), config)
+	if err == nil {
+		c.trackLaunch(ctx, domain.NewActiveAttack(domain.AttackKindProbeFlood, id, config.TargetBSSID, config.Interface, config.Channel, config))
+	}
+	if err == nil && c.audit != nil {
+		target := config.TargetBSSID
+		if target == "" {
+			target = "broadcast"
+		}
+		c.audit.Log(ctx, domain.ActionDeauthStart, target, fmt.Sprintf("Started Probe Flood (%d SSIDs)", len(config.SSIDs)))
+	}
+	return id, err
+}
+
+// StopProbeFloodAttack stops a Probe Request flood.
+func (c *AttackCoordinator) StopProbeFloodAttack(ctx context.Context, id string, force bool) error {
+	if c.probeFloodEngine == nil {
+		return fmt.Errorf("probe flood engine not initialized")
+	}
+	return c.probeFloodEngine.StopAttack(ctx, id, force)
+}
+
+// GetProbeFloodStatus returns status of a Probe Request flood.
+func (c *AttackCoordinator) GetProbeFloodStatus(ctx context.Context, id string) (domain.ProbeFloodAttackStatus, error) {
+	if c.probeFloodEngine == nil {
+		return domain.ProbeFloodAttackStatus{}, fmt.Errorf("probe flood engine not initialized")
+	}
+	return c.probeFloodEngine.GetStatus(ctx, id)
+}
+
+// StartCSAAttack initiates a standalone Channel Switch Announcement attack.
+func (c *AttackCoordinator) StartCSAAttack(ctx context.Context, config domain.CSAAttackConfig) (string, error) {
+	if c.csaEngine == nil {
+		return "", fmt.Errorf("CSA engine not initialized")
+	}
+	if err := c.checkROE(ctx, domain.AttackKindCSA); err != nil {
+		return "", err
+	}
+
+	// Auto-detect channel and SSID (use request context for synchronous lookup)
+	if config.Channel == 0 || (config.FrameMode == domain.CSAModeBeacon && config.TargetSSID == "") {
+		device, exists := c.registry.GetDevice(ctx, config.TargetBSSID)
+		if exists {
+			if config.Channel == 0 && device.Channel > 0 {
+				config.Channel = device.Channel
+			}
+			if config.TargetSSID == "" {
+				config.TargetSSID = device.SSID
+			}
+		}
+	}
+	if config.Channel == 0 {
+		return "", fmt.Errorf("channel is 0 and could not be detected for %s", config.TargetBSSID)
+	}
+
+	// Auto-detect interface (use request context for synchronous lookup)
+	if config.Interface == "" && c.sniffer != nil {
+		interfaces, _ := c.sniffer.GetInterfaces(ctx)
+		if len(interfaces) > 0 {
+			config.Interface = c.roles.Pick(domain.InterfaceRoleInjection, interfaces)
+		}
+	}
+
+	if err := c.checkTxAllowed(ctx, config.Interface, config.Channel); err != nil {
+		return "", err
+	}
+
+	// Use background context for long-running attack execution
+	id, err := c.csaEngine.StartAttack(context.Background(), config)
+	if err == nil {
+		c.trackLaunch(ctx, domain.NewActiveAttack(domain.AttackKindCSA, id, config.TargetBSSID, config.Interface, config.Channel, config))
+	}
+	if err == nil && c.audit != nil {
+		c.audit.Log(ctx, domain.ActionDeauthStart, config.TargetBSSID, fmt.Sprintf("Started CSA attack (ch %d -> %d, %s)", config.Channel, config.NewChannel, config.FrameMode))
+	}
+	return id, err
+}
+
+// StopCSAAttack stops a CSA attack.
+func (c *AttackCoordinator) StopCSAAttack(ctx context.Context, id string, force bool) error {
+	if c.csaEngine == nil {
+		return fmt.Errorf("CSA engine not initialized")
+	}
+	return c.csaEngine.StopAttack(ctx, id, force)
+}
+
+// GetCSAStatus returns status of a CSA attack.
+func (c *AttackCoordinator) GetCSAStatus(ctx context.Context, id string) (domain.CSAAttackStatus, error) {
+	if c.csaEngine == nil {
+		return domain.CSAAttackStatus{}, fmt.Errorf("CSA engine not initialized")
+	}
+	return c.csaEngine.GetStatus(ctx, id)
+}
+
+// StartBeaconSpoof starts transmitting a spoofed beacon, cloning the target AP when requested.
+func (c *AttackCoordinator) StartBeaconSpoof(ctx context.Context, config domain.BeaconSpoofConfig) (string, error) {
+	if c.beaconEngine == nil {
+		return "", fmt.Errorf("beacon spoof engine not initialized")
+	}
+	if err := c.checkROE(ctx, domain.AttackKindBeaconSpoof); err != nil {
+		return "", err
+	}
+
+	// Clone the observed AP; explicit template fields take precedence
+	if config.CloneBSSID != "" {
+		device, exists := c.registry.GetDevice(ctx, config.CloneBSSID)
+		if !exists {
+			return "", fmt.Errorf("AP to clone not found: %s", config.CloneBSSID)
+		}
+		if config.BSSID == "" {
+			config.BSSID = device.MAC
+		}
+		if config.SSID == "" {
+			config.SSID = device.SSID
+		}
+		if config.Channel == 0 {
+			config.Channel = device.Channel
+		}
+		if len(config.Rates) == 0 {
+			config.Rates = device.SupportedRates
+		}
+		if config.Security == "" && config.RSN == nil {
+			if device.RSNInfo != nil {
+				rsn := *device.RSNInfo
+				config.RSN = &rsn
+			} else if strings.Contains(strings.ToUpper(device.Security), "WPA") {
+				config.Security = domain.BeaconSecurityWPA2
+			}
+		}
+	}
+	if config.Channel == 0 {
+		return "", fmt.Errorf("channel is required to transmit beacons")
+	}
+
+	// Auto-detect interface (use request context for synchronous lookup)
+	if config.Interface == "" && c.sniffer != nil {
+		interfaces, _ := c.sniffer.GetInterfaces(ctx)
+		if len(interfaces) > 0 {
+			config.Interface = c.roles.Pick(domain.InterfaceRoleAP, interfaces)
+		}
+	}
+
+	if err := c.checkTxAllowed(ctx, config.Interface, config.Channel); err != nil {
+		return "", err
+	}
+
+	// Use background context for long-running attack execution
+	id, err := c.beaconEngine.StartAttack(context.Background(), config)
+	if err == nil {
+		c.trackLaunch(ctx, domain.NewActiveAttack(domain.AttackKindBeaconSpoof, id, config.BSSID, config.Interface, config.Channel, config))
+	}
+	if err == nil && c.audit != nil {
+		target := config.BSSID
+		if target == "" {
+			target = config.SSID
+		}
+		c.audit.Log(ctx, domain.ActionDeauthStart, target, fmt.Sprintf("Started Beacon Spoof (SSID: %s, ch %d)", config.SSID, config.Channel))
+	}
+	return id, err
+}
+
+// StopBeaconSpoof stops a beacon spoofing attack.
+func (c *AttackCoordinator) StopBeaconSpoof(ctx context.Context, id string, force bool) error {
+	if c.beaconEngine == nil {
+		return fmt.Errorf("beacon spoof engine not initialized")
+	}
+	return c.beaconEngine.StopAttack(ctx, id, force)
+}
+
+// GetBeaconSpoofStatus returns status of a beacon spoofing attack.
+func (c *AttackCoordinator) GetBeaconSpoofStatus(ctx context.Context, id string) (domain.BeaconSpoofStatus, error) {
+	if c.beaconEngine == nil {
+		return domain.BeaconSpoofStatus{}, fmt.Errorf("beacon spoof engine not initialized")
+	}
+	return c.beaconEngine.GetStatus(ctx, id)
+}
+
+// StartKarma starts a Karma-lite probe responder for an SSID allowlist.
+func (c *AttackCoordinator) StartKarma(ctx context.Context, config domain.KarmaConfig) (string, error) {
+	if c.karmaEngine == nil {
+		return "", fmt.Errorf("karma engine not initialized")
+	}
+	if err := c.checkROE(ctx, domain.AttackKindKarma); err != nil {
+		return "", err
+	}
+
+	// Auto-detect interface (use request context for synchronous lookup)
+	if config.Interface == "" && c.sniffer != nil {
+		interfaces, _ := c.sniffer.GetInterfaces(ctx)
+		if len(interfaces) > 0 {
+			config.Interface = c.roles.Pick(domain.InterfaceRoleAP, interfaces)
+		}
+	}
+
+	if err := c.checkTxAllowed(ctx, config.Interface, config.Channel); err != nil {
+		return "", err
+	}
+
+	// Use background context for long-running attack execution
+	id, err := c.karmaEngine.StartAttack(context.Background(), config)
+	if err == nil {
+		c.trackLaunch(ctx, domain.NewActiveAttack(domain.AttackKindKarma, id, config.BSSID, config.Interface, config.Channel, config))
+	}
+	if err == nil && c.audit != nil {
+		c.audit.Log(ctx, domain.ActionDeauthStart, strings.Join(config.SSIDAllowlist, ","), fmt.Sprintf("Started Karma responder on ch %d", config.Channel))
+	}
+	return id, err
+}
+
+// StopKarma stops a Karma-lite responder.
+func (c *AttackCoordinator) StopKarma(ctx context.Context, id string, force bool) error {
+	if c.karmaEngine == nil {
+		return fmt.Errorf("karma engine not initialized")
+	}
+	return c.karmaEngine.StopAttack(ctx, id, force)
+}
+
+// GetKarmaStatus returns the status and observed clients of a Karma-lite responder.
+func (c *AttackCoordinator) GetKarmaStatus(ctx context.Context, id string) (domain.KarmaStatus, error) {
+	if c.karmaEngine == nil {
+		return domain.KarmaStatus{}, fmt.Errorf("karma engine not initialized")
+	}
+	return c.karmaEngine.GetStatus(ctx, id)
+}
+
+// StartNAVJam starts an RTS/CTS virtual jamming run on a channel.
+func (c *AttackCoordinator) StartNAVJam(ctx context.Context, config domain.NAVJamConfig) (string, error) {
+	if c.navJamEngine == nil {
+		return "", fmt.Errorf("NAV jam engine not initialized")
+	}
+	if err := c.checkROE(ctx, domain.AttackKindNAVJam); err != nil {
+		return "", err
+	}
+
+	// Auto-detect interface (use request context for synchronous lookup)
+	if config.Interface == "" && c.sniffer != nil {
+		interfaces, _ := c.sniffer.GetInterfaces(ctx)
+		if len(interfaces) > 0 {
+			config.Interface = c.roles.Pick(domain.InterfaceRoleInjection, interfaces)
+		}
+	}
+
+	if err := c.checkTxAllowed(ctx, config.Interface, config.Channel); err != nil {
+		return "", err
+	}
+
+	// Use background context for long-running attack execution
+	id, err := c.navJamEngine.StartAttack(context.Background(), config)
+	if err == nil {
+		c.trackLaunch(ctx, domain.NewActiveAttack(domain.AttackKindNAVJam, id, config.TargetMAC, config.Interface, config.Channel, config))
+	}
+	if err == nil && c.audit != nil {
+		c.audit.Log(ctx, domain.ActionDeauthStart, fmt.Sprintf("channel %d", config.Channel), fmt.Sprintf("Started NAV jamming (%s)", config.FrameType))
+	}
+	return id, err
+}
+
+// StopNAVJam stops a virtual jamming run.
+func (c *AttackCoordinator) StopNAVJam(ctx context.Context, id string, force bool) error {
+	if c.navJamEngine == nil {
+		return fmt.Errorf("NAV jam engine not initialized")
+	}
+	return c.navJamEngine.StopAttack(ctx, id, force)
+}
+
+// GetNAVJamStatus returns status of a virtual jamming run.
+func (c *AttackCoordinator) GetNAVJamStatus(ctx context.Context, id string) (domain.NAVJamStatus, error) {
+	if c.navJamEngine == nil {
+		return domain.NAVJamStatus{}, fmt.Errorf("NAV jam engine not initialized")
+	}
+	return c.navJamEngine.GetStatus(ctx, id)
+}
+
 // StopAll stops all active attacks.
 func (c *AttackCoordinator) StopAll(ctx context.Context) {
 	if c.deauthEngine != nil {
@@ -277,4 +1140,19 @@ func (c *AttackCoordinator) StopAll(ctx context.Context) {
 	if c.authFloodEngine != nil {
 		c.authFloodEngine.StopAll(ctx)
 	}
+	if c.probeFloodEngine != nil {
+		c.probeFloodEngine.StopAll(ctx)
+	}
+	if c.csaEngine != nil {
+		c.csaEngine.StopAll(ctx)
+	}
+	if c.beaconEngine != nil {
+		c.beaconEngine.StopAll(ctx)
+	}
+	if c.karmaEngine != nil {
+		c.karmaEngine.StopAll(ctx)
+	}
+	if c.navJamEngine != nil {
+		c.navJamEngine.StopAll(ctx)
+	}
 }
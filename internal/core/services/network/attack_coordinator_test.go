@@ -0,0 +1,39 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+)
+
+// regulatorySniffer reports fixed regulatory flags, or err.
+type regulatorySniffer struct {
+	ports.Sniffer
+	channels []domain.ChannelRegulatory
+	err      error
+}
+
+func (r *regulatorySniffer) ChannelRegulatory(ctx context.Context, iface string) ([]domain.ChannelRegulatory, error) {
+	return r.channels, r.err
+}
+
+func TestCheckTxAllowed(t *testing.T) {
+	ctx := context.Background()
+	sniffer := &regulatorySniffer{channels: []domain.ChannelRegulatory{
+		{Channel: 6},
+		{Channel: 52, DFS: true, NoIR: true},
+	}}
+	c := NewAttackCoordinator(nil, sniffer, nil)
+
+	assert.NoError(t, c.checkTxAllowed(ctx, "wlan0", 6))
+	assert.NoError(t, c.checkTxAllowed(ctx, "wlan0", 0), "no channel to check")
+	assert.ErrorIs(t, c.checkTxAllowed(ctx, "wlan0", 52), domain.ErrTxNotPermitted)
+	assert.ErrorIs(t, c.checkTxAllowed(ctx, "wlan0", 165), domain.ErrTxNotPermitted, "channel the interface does not list")
+
+	sniffer.err = errors.New("iw: command not found")
+	assert.ErrorIs(t, c.checkTxAllowed(ctx, "wlan0", 6), domain.ErrTxNotPermitted, "unknown flags fail closed")
+}
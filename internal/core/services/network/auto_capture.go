@@ -0,0 +1,288 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+var (
+	// ErrAutoCaptureRunning is returned when a run is started while another runs.
+	ErrAutoCaptureRunning = errors.New("auto-capture already running")
+	// ErrAutoCaptureIdle is returned when stopping while no run is in progress.
+	ErrAutoCaptureIdle = errors.New("auto-capture not running")
+)
+
+const (
+	// autoCapturePoll is how often the material of the current target is checked.
+	autoCapturePoll = 500 * time.Millisecond
+	// autoCaptureInterval spaces the frames of a burst.
+	autoCaptureInterval = 50 * time.Millisecond
+)
+
+// AutoCaptureService is the supervised "auto-pwn" recon mode: it locks a
+// capture interface on the channel of each WPA-PSK AP in scope in turn,
+// sends a small deauthentication burst and waits for a handshake or PMKID,
+// so an operator learns which networks yield crackable material without
+// attacking them one by one. Bursts go through the attack coordinator, so
+// rules of engagement, scope and PMF checks apply to every target.
+type AutoCaptureService struct {
+	service  *NetworkService
+	material ports.CaptureMaterial
+	poll     time.Duration
+
+	status domain.AutoCaptureStatus
+	cancel context.CancelFunc
+	mu     sync.RWMutex
+}
+
+// NewAutoCaptureService creates an auto-capture over the devices of service,
+// checking material with the handshake capture.
+func NewAutoCaptureService(service *NetworkService, material ports.CaptureMaterial) *AutoCaptureService {
+	return &AutoCaptureService{
+		service:  service,
+		material: material,
+		poll:     autoCapturePoll,
+		status:   domain.AutoCaptureStatus{Results: []domain.AutoCaptureResult{}},
+	}
+}
+
+// StartAutoCapture starts a run in the background. It keeps the values of the
+// caller's context, the operator, but not its cancellation, which usually
+// comes with the end of the HTTP request.
+func (a *AutoCaptureService) StartAutoCapture(ctx context.Context, config domain.AutoCaptureConfig) error {
+	config = config.WithDefaults()
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if a.service.sniffer == nil || a.material == nil {
+		return fmt.Errorf("auto-capture not available: no handshake capture")
+	}
+	// Fail fast rather than refusing every target
+	if err := a.service.attackCoordinator.checkROE(ctx, domain.AttackKindDeauth); err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	if err := a.begin(ctx, config, cancel); err != nil {
+		cancel()
+		return err
+	}
+	go a.run(runCtx, config)
+	return nil
+}
+
+// StopAutoCapture ends the current run. A burst already started completes.
+func (a *AutoCaptureService) StopAutoCapture(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.status.Running {
+		return ErrAutoCaptureIdle
+	}
+	a.cancel()
+	return nil
+}
+
+// GetAutoCaptureStatus returns the progress and results of the current or last run.
+func (a *AutoCaptureService) GetAutoCaptureStatus(ctx context.Context) domain.AutoCaptureStatus {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	status := a.status
+	status.Results = append([]domain.AutoCaptureResult{}, a.status.Results...)
+	return status
+}
+
+func (a *AutoCaptureService) begin(ctx context.Context, config domain.AutoCaptureConfig, cancel context.CancelFunc) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.status.Running {
+		return ErrAutoCaptureRunning
+	}
+	a.status = domain.AutoCaptureStatus{
+		Running:   true,
+		StartedAt: time.Now(),
+		Config:    config,
+		Results:   []domain.AutoCaptureResult{},
+	}
+	if user, ok := domain.UserFromContext(ctx); ok {
+		a.status.Operator = user.Username
+	}
+	a.cancel = cancel
+	return nil
+}
+
+func (a *AutoCaptureService) run(ctx context.Context, config domain.AutoCaptureConfig) {
+	defer func() {
+		a.mu.Lock()
+		a.status.Running = false
+		a.status.Current = ""
+		a.status.FinishedAt = time.Now()
+		a.cancel()
+		a.mu.Unlock()
+	}()
+
+	targets, err := a.selectTargets(ctx, config)
+	if err != nil {
+		a.mu.Lock()
+		a.status.Error = err.Error()
+		a.mu.Unlock()
+		return
+	}
+	a.mu.Lock()
+	a.status.Targets = len(targets)
+	a.mu.Unlock()
+	log.Printf("[AUTO-CAPTURE] Started over %d access points", len(targets))
+
+	for _, target := range targets {
+		if ctx.Err() != nil {
+			break
+		}
+		a.mu.Lock()
+		a.status.Current = target.MAC
+		a.mu.Unlock()
+
+		result := a.captureTarget(ctx, config, target)
+		log.Printf("[AUTO-CAPTURE] %s (%q, channel %d): %s %s", result.BSSID, result.SSID, result.Channel, result.Outcome, result.Detail)
+
+		a.mu.Lock()
+		a.status.Results = append(a.status.Results, result)
+		if result.Outcome.Yielded() {
+			a.status.Yielded++
+		}
+		a.mu.Unlock()
+	}
+
+	status := a.GetAutoCaptureStatus(ctx)
+	if a.service.auditService != nil {
+		a.service.auditService.Log(ctx, domain.ActionInfo, "auto-capture", fmt.Sprintf("Auto-capture finished: %d of %d networks yielded crackable material", status.Yielded, len(status.Results)))
+	}
+}
+
+// selectTargets returns the WPA-PSK APs in scope without material yet,
+// highest attack value first.
+func (a *AutoCaptureService) selectTargets(ctx context.Context, config domain.AutoCaptureConfig) ([]domain.Device, error) {
+	ranked, err := a.service.rankTargets(ctx, func(device domain.Device) bool {
+		switch {
+		case device.Channel == 0, !device.UsesPSK():
+			return false
+		case config.MinRSSI != 0 && device.RSSI < config.MinRSSI:
+			return false
+		}
+		return a.hasMaterial(device.MAC) == ""
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if config.MaxTargets > 0 && len(ranked) > config.MaxTargets {
+		ranked = ranked[:config.MaxTargets]
+	}
+	targets := make([]domain.Device, len(ranked))
+	for i, t := range ranked {
+		targets[i] = t.device
+	}
+	return targets, nil
+}
+
+// captureTarget holds a capture interface on the channel of target while a
+// burst is sent and material awaited.
+func (a *AutoCaptureService) captureTarget(ctx context.Context, config domain.AutoCaptureConfig, target domain.Device) domain.AutoCaptureResult {
+	started := time.Now()
+	result := domain.AutoCaptureResult{BSSID: target.MAC, SSID: target.SSID, Channel: target.Channel}
+
+	iface := config.Interface
+	if iface == "" {
+		interfaces, _ := a.service.sniffer.GetInterfaces(ctx)
+		iface = a.service.attackCoordinator.roles.Pick(domain.InterfaceRoleCapture, interfaces)
+	}
+	// The burst goes out on the interface held on the target's channel
+	err := a.lockChannel(ctx, iface, target, func(ctx context.Context) error {
+		_, err := a.service.attackCoordinator.StartDeauthAttack(ctx, domain.DeauthAttackConfig{
+			TargetMAC:      target.MAC,
+			AttackType:     domain.DeauthBroadcast,
+			PacketCount:    config.BurstPackets,
+			PacketInterval: autoCaptureInterval,
+			ReasonCode:     7,
+			Channel:        target.Channel,
+			Interface:      iface,
+		})
+		switch {
+		case errors.Is(err, domain.ErrPMFProtected):
+			// Clients may still associate on their own
+			result.Detail = "PMF required, listened without a burst"
+		case err != nil:
+			return err
+		}
+
+		result.Outcome = a.awaitMaterial(ctx, target.MAC, config.Wait)
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, domain.ErrNoROE), errors.Is(err, domain.ErrOutOfScope), errors.Is(err, domain.ErrTxNotPermitted):
+		result.Outcome = domain.CaptureSkipped
+		result.Detail = err.Error()
+	case err != nil:
+		result.Outcome = domain.CaptureFailed
+		result.Detail = err.Error()
+	case ctx.Err() != nil && !result.Outcome.Yielded():
+		result.Outcome = domain.CaptureSkipped
+		result.Detail = "run stopped"
+	}
+	result.Duration = time.Since(started)
+	return result
+}
+
+// lockChannel runs action with iface held on the channel of target. Handshake
+// capture outranks attacks and analyst pins when the sniffer arbitrates locks.
+func (a *AutoCaptureService) lockChannel(ctx context.Context, iface string, target domain.Device, action func(ctx context.Context) error) error {
+	if locker, ok := a.service.sniffer.(ports.PriorityChannelLocker); ok {
+		return locker.ExecuteWithPriority(ctx, iface, target.Channel, domain.LockPriorityHandshake, "handshake:"+target.MAC, action)
+	}
+	return a.service.sniffer.ExecuteWithLock(ctx, iface, target.Channel, func() error {
+		return action(ctx)
+	})
+}
+
+// awaitMaterial polls for a handshake or PMKID of bssid until wait elapses.
+func (a *AutoCaptureService) awaitMaterial(ctx context.Context, bssid string, wait time.Duration) domain.AutoCaptureOutcome {
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(a.poll)
+	defer ticker.Stop()
+
+	for {
+		if outcome := a.hasMaterial(bssid); outcome != "" {
+			return outcome
+		}
+		select {
+		case <-ctx.Done():
+			return domain.CaptureNothing
+		case <-deadline.C:
+			return domain.CaptureNothing
+		case <-ticker.C:
+		}
+	}
+}
+
+// hasMaterial returns the crackable material captured for bssid, empty if none.
+func (a *AutoCaptureService) hasMaterial(bssid string) domain.AutoCaptureOutcome {
+	if a.material == nil {
+		return ""
+	}
+	bssid = strings.ToLower(bssid)
+	switch {
+	case a.material.HasHandshake(bssid):
+		return domain.CaptureHandshake
+	case a.material.HasPMKID(bssid):
+		return domain.CapturePMKID
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package network
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/registry"
+	"github.com/lcalzada-xor/wmap/internal/core/services/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// lockingSniffer records the channels it was locked on.
+type lockingSniffer struct {
+	ports.Sniffer
+	mu       sync.Mutex
+	channels []int
+}
+
+func (s *lockingSniffer) GetInterfaces(ctx context.Context) ([]string, error) {
+	return []string{"wlan0mon"}, nil
+}
+
+func (s *lockingSniffer) GetInterfaceChannels(ctx context.Context, iface string) ([]int, error) {
+	return nil, nil
+}
+
+func (s *lockingSniffer) ExecuteWithLock(ctx context.Context, iface string, channel int, action func() error) error {
+	s.mu.Lock()
+	s.channels = append(s.channels, channel)
+	s.mu.Unlock()
+	return action()
+}
+
+// priorityLockingSniffer records the priority and holder of its locks.
+type priorityLockingSniffer struct {
+	lockingSniffer
+	priorities []domain.LockPriority
+	holders    []string
+}
+
+func (s *priorityLockingSniffer) ExecuteWithPriority(ctx context.Context, iface string, channel int, priority domain.LockPriority, holder string, action func(ctx context.Context) error) error {
+	s.mu.Lock()
+	s.channels = append(s.channels, channel)
+	s.priorities = append(s.priorities, priority)
+	s.holders = append(s.holders, holder)
+	s.mu.Unlock()
+	return action(ctx)
+}
+
+// capturedMaterial is a handshake capture double.
+type capturedMaterial struct {
+	mu         sync.Mutex
+	handshakes map[string]bool
+	pmkids     map[string]bool
+}
+
+func (m *capturedMaterial) HasHandshake(bssid string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.handshakes[bssid]
+}
+
+func (m *capturedMaterial) HasPMKID(bssid string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pmkids[bssid]
+}
+
+func TestAutoCapture(t *testing.T) {
+	ctx := context.Background()
+	reg := registry.NewDeviceRegistry(nil, nil)
+	sniffer := &lockingSniffer{}
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), nil, sniffer, nil)
+	mockDeauth := new(MockDeauthService)
+	svc.SetDeauthEngine(mockDeauth)
+
+	psk := &domain.RSNInfo{AKMSuites: []string{"PSK"}}
+	for _, ap := range []domain.Device{
+		{MAC: "aa:bb:cc:00:00:01", SSID: "Strong", RSSI: -40, Channel: 6, RSNInfo: psk},
+		{MAC: "aa:bb:cc:00:00:02", SSID: "Weak", RSSI: -70, Channel: 11, RSNInfo: psk},
+		{MAC: "aa:bb:cc:00:00:03", SSID: "Captured", RSSI: -50, Channel: 1, RSNInfo: psk},
+		{MAC: "aa:bb:cc:00:00:04", SSID: "WPA3", RSSI: -30, Channel: 36, RSNInfo: &domain.RSNInfo{AKMSuites: []string{"SAE"}}},
+		{MAC: "aa:bb:cc:00:00:05", SSID: "Open", RSSI: -30, Channel: 1},
+	} {
+		ap.Type = domain.DeviceTypeAP
+		reg.ProcessDevice(ctx, ap)
+	}
+
+	material := &capturedMaterial{
+		handshakes: map[string]bool{"aa:bb:cc:00:00:03": true},
+		pmkids:     map[string]bool{},
+	}
+	// The strong AP's clients reconnect after the burst; the weak one's never do
+	mockDeauth.On("StartAttack", mock.Anything, mock.MatchedBy(func(c domain.DeauthAttackConfig) bool {
+		return strings.EqualFold(c.TargetMAC, "aa:bb:cc:00:00:01")
+	})).Run(func(args mock.Arguments) {
+		material.mu.Lock()
+		material.pmkids["aa:bb:cc:00:00:01"] = true
+		material.mu.Unlock()
+	}).Return("job-1", nil)
+	mockDeauth.On("StartAttack", mock.Anything, mock.Anything).Return("job-2", nil)
+
+	auto := NewAutoCaptureService(svc, material)
+	auto.poll = 10 * time.Millisecond
+	require.NoError(t, auto.StartAutoCapture(ctx, domain.AutoCaptureConfig{Wait: time.Second}))
+	assert.ErrorIs(t, auto.StartAutoCapture(ctx, domain.AutoCaptureConfig{}), ErrAutoCaptureRunning)
+
+	require.Eventually(t, func() bool { return !auto.GetAutoCaptureStatus(ctx).Running }, 5*time.Second, 20*time.Millisecond)
+	status := auto.GetAutoCaptureStatus(ctx)
+	assert.Equal(t, 2, status.Targets, "only uncaptured WPA-PSK APs are targeted")
+	assert.Equal(t, 1, status.Yielded)
+	require.Len(t, status.Results, 2)
+	assert.Equal(t, domain.CapturePMKID, status.Results[0].Outcome, "strongest first")
+	assert.Equal(t, domain.CaptureNothing, status.Results[1].Outcome)
+	assert.Equal(t, []int{6, 11}, sniffer.channels)
+
+	sent := mockDeauth.Calls[0].Arguments.Get(1).(domain.DeauthAttackConfig)
+	assert.Equal(t, domain.DefaultAutoCaptureBurst, sent.PacketCount)
+	assert.Equal(t, "wlan0mon", sent.Interface, "burst sent on the locked interface")
+}
+
+func TestAutoCapture_RequiresROE(t *testing.T) {
+	reg := registry.NewDeviceRegistry(nil, nil)
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), nil, &lockingSniffer{}, nil)
+	svc.SetROEStore(&memoryROE{}, []byte("roe-key"))
+
+	auto := NewAutoCaptureService(svc, &capturedMaterial{})
+	assert.ErrorIs(t, auto.StartAutoCapture(context.Background(), domain.AutoCaptureConfig{}), domain.ErrNoROE)
+	assert.False(t, auto.GetAutoCaptureStatus(context.Background()).Running)
+}
+
+func TestAutoCapture_LocksAtHandshakePriority(t *testing.T) {
+	ctx := context.Background()
+	reg := registry.NewDeviceRegistry(nil, nil)
+	sniffer := &priorityLockingSniffer{}
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), nil, sniffer, nil)
+	mockDeauth := new(MockDeauthService)
+	mockDeauth.On("StartAttack", mock.Anything, mock.Anything).Return("job-1", nil)
+	svc.SetDeauthEngine(mockDeauth)
+	reg.ProcessDevice(ctx, domain.Device{MAC: "aa:bb:cc:00:00:01", Type: domain.DeviceTypeAP, Channel: 6,
+		RSNInfo: &domain.RSNInfo{AKMSuites: []string{"PSK"}}})
+
+	auto := NewAutoCaptureService(svc, &capturedMaterial{handshakes: map[string]bool{}, pmkids: map[string]bool{}})
+	auto.poll = 10 * time.Millisecond
+	require.NoError(t, auto.StartAutoCapture(ctx, domain.AutoCaptureConfig{Wait: time.Second}))
+	require.Eventually(t, func() bool { return !auto.GetAutoCaptureStatus(ctx).Running }, 5*time.Second, 20*time.Millisecond)
+
+	sniffer.mu.Lock()
+	defer sniffer.mu.Unlock()
+	assert.Equal(t, []domain.LockPriority{domain.LockPriorityHandshake}, sniffer.priorities)
+	assert.Equal(t, []string{"handshake:aa:bb:cc:00:00:01"}, sniffer.holders)
+	assert.Equal(t, []int{6}, sniffer.channels)
+}
@@ -0,0 +1,245 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// DefaultDeauthCorrelationWindow is how long reports of the same deauth
+	// source are collected from all sensors before the alert is raised.
+	DefaultDeauthCorrelationWindow = 2 * time.Second
+	// DefaultDeauthIncidentExpiry is the silence after which a source starts a new incident.
+	DefaultDeauthIncidentExpiry = 30 * time.Second
+
+	// deauthFloodFrames is the number of frames in an incident reported as a flood.
+	deauthFloodFrames = 10
+
+	// Log-distance path loss model used for the proximity estimate.
+	pathLossRefRSSI  = -40.0 // dBm at 1 m
+	pathLossExponent = 2.7   // Indoor, light obstruction
+)
+
+// deauthIncident aggregates the reports of one deauth source across sensors.
+type deauthIncident struct {
+	first     domain.Alert
+	last      time.Time
+	frames    int
+	targets   map[string]struct{}
+	sensors   map[string]*domain.SensorObservation
+	published bool
+}
+
+// DeauthCorrelator merges deauth and channel switch alerts raised by several
+// sensors (local interfaces and remote agents) for the same source into a
+// single alert enriched with per-sensor signal strength and an estimated
+// emitter position. Other alerts are passed through unchanged.
+type DeauthCorrelator struct {
+	window    time.Duration
+	expiry    time.Duration
+	publisher func(domain.Alert)
+
+	incidents map[string]*deauthIncident
+	mu        sync.Mutex
+}
+
+// NewDeauthCorrelator creates a correlator that waits window before raising an
+// incident and forgets a source after expiry without reports.
+func NewDeauthCorrelator(window, expiry time.Duration) *DeauthCorrelator {
+	if window <= 0 {
+		window = DefaultDeauthCorrelationWindow
+	}
+	if expiry <= window {
+		expiry = DefaultDeauthIncidentExpiry
+	}
+	return &DeauthCorrelator{
+		window:    window,
+		expiry:    expiry,
+		incidents: make(map[string]*deauthIncident),
+	}
+}
+
+// SetPublisher sets the callback receiving the alerts to raise.
+func (c *DeauthCorrelator) SetPublisher(publisher func(domain.Alert)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.publisher = publisher
+}
+
+// Report feeds an alert raised by a sensor.
+func (c *DeauthCorrelator) Report(alert domain.Alert) {
+	kind := correlatedKind(alert)
+	if kind == "" {
+		c.publish(alert)
+		return
+	}
+
+	now := time.Now()
+	source := kind + "|" + strings.ToLower(alert.DeviceMAC)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	inc, ok := c.incidents[source]
+	if !ok || now.Sub(inc.last) > c.expiry {
+		c.sweep(now)
+		inc = &deauthIncident{
+			first:   alert,
+			targets: make(map[string]struct{}),
+			sensors: make(map[string]*domain.SensorObservation),
+		}
+		c.incidents[source] = inc
+		time.AfterFunc(c.window, func() { c.flush(inc) })
+	}
+
+	inc.last = now
+	inc.frames++
+	if alert.TargetMAC != "" {
+		inc.targets[strings.ToLower(alert.TargetMAC)] = struct{}{}
+	}
+
+	sensor := alert.Sensor
+	if sensor == "" {
+		sensor = "local"
+	}
+	obs, ok := inc.sensors[sensor]
+	if !ok {
+		obs = &domain.SensorObservation{Sensor: sensor, RSSI: alert.RSSI}
+		inc.sensors[sensor] = obs
+	}
+	obs.Frames++
+	if alert.RSSI != 0 && (obs.RSSI == 0 || alert.RSSI > obs.RSSI) {
+		obs.RSSI = alert.RSSI
+	}
+	if alert.Latitude != 0 || alert.Longitude != 0 {
+		obs.Latitude, obs.Longitude = alert.Latitude, alert.Longitude
+	}
+}
+
+// flush raises the correlated alert once the aggregation window has elapsed.
+func (c *DeauthCorrelator) flush(inc *deauthIncident) {
+	c.mu.Lock()
+	if inc.published {
+		c.mu.Unlock()
+		return
+	}
+	inc.published = true
+	alert := inc.build()
+	c.mu.Unlock()
+
+	c.publish(alert)
+}
+
+// sweep drops incidents that have been silent longer than the expiry.
+// Caller must hold c.mu.
+func (c *DeauthCorrelator) sweep(now time.Time) {
+	for source, inc := range c.incidents {
+		if inc.published && now.Sub(inc.last) > c.expiry {
+			delete(c.incidents, source)
+		}
+	}
+}
+
+func (c *DeauthCorrelator) publish(alert domain.Alert) {
+	c.mu.Lock()
+	publisher := c.publisher
+	c.mu.Unlock()
+
+	if publisher != nil {
+		publisher(alert)
+	}
+}
+
+// build assembles the enriched alert. Caller must hold the correlator lock.
+func (inc *deauthIncident) build() domain.Alert {
+	alert := inc.first
+	alert.ID = fmt.Sprintf("alt_%d", time.Now().UnixNano())
+	alert.Severity = domain.SeverityMedium
+	isCSA := correlatedKind(alert) == "csa"
+	if inc.frames >= deauthFloodFrames && !isCSA {
+		alert.Subtype = "DEAUTH_FLOOD"
+		alert.Severity = domain.SeverityHigh
+	}
+
+	alert.Observations = make([]domain.SensorObservation, 0, len(inc.sensors))
+	for _, obs := range inc.sensors {
+		o := *obs
+		o.DistanceM = estimateDistance(o.RSSI)
+		alert.Observations = append(alert.Observations, o)
+	}
+	sort.Slice(alert.Observations, func(i, j int) bool {
+		if alert.Observations[i].RSSI != alert.Observations[j].RSSI {
+			return alert.Observations[i].RSSI > alert.Observations[j].RSSI
+		}
+		return alert.Observations[i].Sensor < alert.Observations[j].Sensor
+	})
+
+	nearest := alert.Observations[0]
+	alert.Sensor = nearest.Sensor
+	alert.RSSI = nearest.RSSI
+	alert.Latitude, alert.Longitude = estimatePosition(alert.Observations)
+
+	what := "Deauthentication"
+	if isCSA {
+		what = "Channel switch announcement"
+	}
+	alert.Message = fmt.Sprintf("%s from %s seen by %d sensor(s), nearest %s at ~%.0fm",
+		what, alert.DeviceMAC, len(alert.Observations), nearest.Sensor, nearest.DistanceM)
+	details := fmt.Sprintf("Frames: %d, Targets: %d", inc.frames, len(inc.targets))
+	if alert.Details != "" {
+		details = alert.Details + ", " + details
+	}
+	alert.Details = details
+	return alert
+}
+
+// correlatedKind returns the kind of incident an alert belongs to, or "" if
+// it is not correlated.
+func correlatedKind(alert domain.Alert) string {
+	if alert.DeviceMAC == "" {
+		return ""
+	}
+	switch alert.Subtype {
+	case "DEAUTH_DETECTED", "BROADCAST_DEAUTH", "DEAUTH_FLOOD":
+		return "deauth"
+	case "CSA_DETECTED":
+		return "csa"
+	}
+	return ""
+}
+
+// estimateDistance converts an RSSI to meters using the log-distance path loss
+// model. Unknown signals (0) yield 0.
+func estimateDistance(rssi int) float64 {
+	if rssi == 0 {
+		return 0
+	}
+	d := math.Pow(10, (pathLossRefRSSI-float64(rssi))/(10*pathLossExponent))
+	return math.Round(d*10) / 10
+}
+
+// estimatePosition returns the centroid of the positioned sensors weighted by
+// the inverse square of their estimated distance, or zero if none is positioned.
+func estimatePosition(observations []domain.SensorObservation) (lat, lon float64) {
+	var total float64
+	for _, o := range observations {
+		if o.Latitude == 0 && o.Longitude == 0 {
+			continue
+		}
+		d := math.Max(o.DistanceM, 1)
+		w := 1 / (d * d)
+		lat += o.Latitude * w
+		lon += o.Longitude * w
+		total += w
+	}
+	if total == 0 {
+		return 0, 0
+	}
+	return lat / total, lon / total
+}
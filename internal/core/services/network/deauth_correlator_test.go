@@ -0,0 +1,103 @@
+package network
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeauthCorrelator_Report(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		published []domain.Alert
+	)
+	c := NewDeauthCorrelator(50*time.Millisecond, time.Minute)
+	c.SetPublisher(func(a domain.Alert) {
+		mu.Lock()
+		defer mu.Unlock()
+		published = append(published, a)
+	})
+	snapshot := func() []domain.Alert {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]domain.Alert(nil), published...)
+	}
+
+	deauth := func(sensor string, rssi int, lat, lng float64) domain.Alert {
+		return domain.Alert{
+			Type:      domain.AlertAnomaly,
+			Subtype:   "BROADCAST_DEAUTH",
+			DeviceMAC: "AA:BB:CC:DD:EE:FF",
+			TargetMAC: "ff:ff:ff:ff:ff:ff",
+			Details:   "BSSID: aa:bb:cc:dd:ee:ff",
+			Sensor:    sensor,
+			RSSI:      rssi,
+			Latitude:  lat,
+			Longitude: lng,
+		}
+	}
+
+	// The same flood overheard by three sensors
+	for i := 0; i < 4; i++ {
+		c.Report(deauth("agent-a/wlan0", -45-i, 40.0, -3.0))
+		c.Report(deauth("agent-b/wlan0", -70, 40.001, -3.0))
+		c.Report(deauth("wlan1", -80, 0, 0))
+	}
+
+	// Unrelated alerts are raised immediately
+	c.Report(domain.Alert{Type: domain.AlertSSID, Message: "rule match"})
+	require.Len(t, snapshot(), 1)
+	assert.Equal(t, domain.AlertSSID, snapshot()[0].Type)
+
+	require.Eventually(t, func() bool { return len(snapshot()) == 2 }, time.Second, 10*time.Millisecond)
+
+	alert := snapshot()[1]
+	assert.Equal(t, "DEAUTH_FLOOD", alert.Subtype)
+	assert.Equal(t, domain.SeverityHigh, alert.Severity)
+	assert.Equal(t, "agent-a/wlan0", alert.Sensor, "strongest sensor is the nearest")
+	assert.Equal(t, -45, alert.RSSI)
+	assert.Contains(t, alert.Details, "Frames: 12")
+
+	require.Len(t, alert.Observations, 3)
+	assert.Equal(t, "agent-a/wlan0", alert.Observations[0].Sensor)
+	assert.Equal(t, 4, alert.Observations[0].Frames)
+	assert.Equal(t, "wlan1", alert.Observations[2].Sensor)
+	assert.Less(t, alert.Observations[0].DistanceM, alert.Observations[1].DistanceM)
+
+	// Position is pulled towards the nearest positioned sensor
+	assert.InDelta(t, 40.0, alert.Latitude, 0.0002)
+	assert.InDelta(t, -3.0, alert.Longitude, 0.0001)
+
+	// Further frames of the same incident do not raise duplicates
+	c.Report(deauth("agent-b/wlan0", -70, 40.001, -3.0))
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, snapshot(), 2)
+}
+
+func TestDeauthCorrelator_SingleFrame(t *testing.T) {
+	done := make(chan domain.Alert, 1)
+	c := NewDeauthCorrelator(10*time.Millisecond, time.Minute)
+	c.SetPublisher(func(a domain.Alert) { done <- a })
+
+	c.Report(domain.Alert{Type: domain.AlertAnomaly, Subtype: "DEAUTH_DETECTED", DeviceMAC: "00:11:22:33:44:55", RSSI: -60})
+
+	select {
+	case alert := <-done:
+		assert.Equal(t, "DEAUTH_DETECTED", alert.Subtype)
+		assert.Equal(t, domain.SeverityMedium, alert.Severity)
+		assert.Equal(t, "local", alert.Sensor)
+		assert.Zero(t, alert.Latitude, "no positioned sensor")
+	case <-time.After(time.Second):
+		t.Fatal("alert not raised")
+	}
+}
+
+func TestEstimateDistance(t *testing.T) {
+	assert.Equal(t, 1.0, estimateDistance(-40))
+	assert.Equal(t, 0.0, estimateDistance(0))
+	assert.Greater(t, estimateDistance(-80), estimateDistance(-60))
+}
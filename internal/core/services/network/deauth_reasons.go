@@ -0,0 +1,198 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// maxReasonBSSIDs bounds the BSSIDs whose reason codes are aggregated.
+	maxReasonBSSIDs = 1024
+	// maxReasonSources bounds the transmitters tracked per BSSID. Frames of
+	// further sources only count towards the BSSID totals.
+	maxReasonSources = 64
+
+	// toolReasonFrames is the frames from a source above which its
+	// distribution is examined.
+	toolReasonFrames = 20
+	// toolReasonShare is the share of a single reason marking a source as a tool.
+	toolReasonShare = 0.9
+	// apReasonFrames is the frames with an AP-only reason a station may send
+	// before it is reported.
+	apReasonFrames = 5
+)
+
+// toolReasons are the reason codes deauthentication tools default to.
+var toolReasons = map[int]bool{1: true, 6: true, 7: true}
+
+// reasonSource is the reason code distribution of one transmitter.
+type reasonSource struct {
+	frames     int
+	reasons    map[int]int
+	lastSeq    int
+	lastSensor string
+}
+
+// reasonAggregate is the reason code distribution of one BSSID.
+type reasonAggregate struct {
+	stats   domain.DeauthReasonStats
+	reasons map[int]int
+	sources map[string]*reasonSource
+}
+
+// DeauthReasonTracker aggregates the reason codes of the deauthentication
+// and disassociation frames reported by the sensors per BSSID, and flags the
+// distributions left by attack tools. A frame overheard by several sensors
+// is counted once.
+type DeauthReasonTracker struct {
+	bssids map[string]*reasonAggregate
+	mu     sync.Mutex
+}
+
+// NewDeauthReasonTracker creates an empty tracker.
+func NewDeauthReasonTracker() *DeauthReasonTracker {
+	return &DeauthReasonTracker{bssids: make(map[string]*reasonAggregate)}
+}
+
+// Report feeds an alert raised by a sensor. Only deauthentication frames
+// with a reason code and a BSSID are counted.
+func (t *DeauthReasonTracker) Report(alert domain.Alert) {
+	if alert.Subtype != "DEAUTH_DETECTED" && alert.Subtype != "BROADCAST_DEAUTH" {
+		return
+	}
+	if alert.ReasonCode == 0 || alert.BSSID == "" || alert.DeviceMAC == "" {
+		return
+	}
+	bssid := strings.ToLower(alert.BSSID)
+	source := strings.ToLower(alert.DeviceMAC)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	agg, ok := t.bssids[bssid]
+	if !ok {
+		if len(t.bssids) >= maxReasonBSSIDs {
+			return
+		}
+		agg = &reasonAggregate{
+			stats:   domain.DeauthReasonStats{BSSID: bssid, FirstSeen: alert.Timestamp},
+			reasons: make(map[int]int),
+			sources: make(map[string]*reasonSource),
+		}
+		t.bssids[bssid] = agg
+	}
+
+	src, ok := agg.sources[source]
+	if !ok && len(agg.sources) < maxReasonSources {
+		src = &reasonSource{reasons: make(map[int]int), lastSeq: -1}
+		agg.sources[source] = src
+	}
+	if src != nil {
+		if alert.Sequence == src.lastSeq && alert.Sensor != src.lastSensor {
+			return // Same frame, from another sensor
+		}
+		src.lastSeq, src.lastSensor = alert.Sequence, alert.Sensor
+		src.frames++
+		src.reasons[alert.ReasonCode]++
+	}
+
+	agg.stats.Frames++
+	agg.reasons[alert.ReasonCode]++
+	if alert.Timestamp.Before(agg.stats.FirstSeen) {
+		agg.stats.FirstSeen = alert.Timestamp
+	}
+	if alert.Timestamp.After(agg.stats.LastSeen) {
+		agg.stats.LastSeen = alert.Timestamp
+	}
+}
+
+// Stats returns the reason code distribution of bssid, or of every BSSID
+// when empty, busiest first.
+func (t *DeauthReasonTracker) Stats(bssid string) []domain.DeauthReasonStats {
+	bssid = strings.ToLower(bssid)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]domain.DeauthReasonStats, 0, len(t.bssids))
+	for key, agg := range t.bssids {
+		if bssid != "" && key != bssid {
+			continue
+		}
+		result = append(result, agg.snapshot())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Frames != result[j].Frames {
+			return result[i].Frames > result[j].Frames
+		}
+		return result[i].BSSID < result[j].BSSID
+	})
+	return result
+}
+
+// Clear forgets every reason code seen.
+func (t *DeauthReasonTracker) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.bssids = make(map[string]*reasonAggregate)
+}
+
+// snapshot returns the distribution of the aggregate with its anomalies.
+func (a *reasonAggregate) snapshot() domain.DeauthReasonStats {
+	stats := a.stats
+	stats.Reasons = reasonCounts(a.reasons)
+	stats.Sources = make([]domain.DeauthReasonSource, 0, len(a.sources))
+	for mac, src := range a.sources {
+		stats.Sources = append(stats.Sources, domain.DeauthReasonSource{
+			MAC:     mac,
+			Frames:  src.frames,
+			Reasons: reasonCounts(src.reasons),
+		})
+	}
+	sort.Slice(stats.Sources, func(i, j int) bool {
+		if stats.Sources[i].Frames != stats.Sources[j].Frames {
+			return stats.Sources[i].Frames > stats.Sources[j].Frames
+		}
+		return stats.Sources[i].MAC < stats.Sources[j].MAC
+	})
+
+	for _, src := range stats.Sources {
+		top := src.Reasons[0]
+		if src.Frames >= toolReasonFrames && toolReasons[top.Code] &&
+			float64(top.Count) >= toolReasonShare*float64(src.Frames) {
+			stats.Anomalies = append(stats.Anomalies, fmt.Sprintf(
+				"%s sent %d frames, %d%% with reason %d (%s), typical of deauthentication tools",
+				src.MAC, src.Frames, top.Count*100/src.Frames, top.Code, top.Name))
+		}
+		if src.MAC == stats.BSSID {
+			continue
+		}
+		// Class 2 and 3 frames are rejected by the AP, a station has no
+		// reason to send them
+		for _, r := range src.Reasons {
+			if (r.Code == 6 || r.Code == 7) && r.Count >= apReasonFrames {
+				stats.Anomalies = append(stats.Anomalies, fmt.Sprintf(
+					"%s is not the AP but sent %d frames with reason %d (%s)",
+					src.MAC, r.Count, r.Code, r.Name))
+			}
+		}
+	}
+	return stats
+}
+
+// reasonCounts returns the reason codes of a distribution, most frequent first.
+func reasonCounts(reasons map[int]int) []domain.DeauthReasonCount {
+	counts := make([]domain.DeauthReasonCount, 0, len(reasons))
+	for code, n := range reasons {
+		counts = append(counts, domain.DeauthReasonCount{Code: code, Name: domain.DeauthReasonName(code), Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Code < counts[j].Code
+	})
+	return counts
+}
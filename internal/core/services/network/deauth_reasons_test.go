@@ -0,0 +1,65 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeauthReasonTracker(t *testing.T) {
+	tracker := NewDeauthReasonTracker()
+	now := time.Now()
+	frame := func(source, sensor string, seq, reason int) domain.Alert {
+		return domain.Alert{
+			Type:       domain.AlertAnomaly,
+			Subtype:    "BROADCAST_DEAUTH",
+			DeviceMAC:  source,
+			TargetMAC:  "ff:ff:ff:ff:ff:ff",
+			BSSID:      "AA:BB:CC:DD:EE:FF",
+			Sensor:     sensor,
+			Sequence:   seq,
+			ReasonCode: reason,
+			Timestamp:  now.Add(time.Duration(seq) * time.Millisecond),
+		}
+	}
+
+	// A tool spoofing the AP floods with reason 7, overheard by two sensors
+	for i := 0; i < 30; i++ {
+		tracker.Report(frame("aa:bb:cc:dd:ee:ff", "wlan0", i, 7))
+		tracker.Report(frame("aa:bb:cc:dd:ee:ff", "agent-a/wlan0", i, 7))
+	}
+	// A station leaving, and one sending class 3 reasons
+	tracker.Report(frame("11:22:33:44:55:66", "wlan0", 100, 3))
+	for i := 0; i < 5; i++ {
+		tracker.Report(frame("22:33:44:55:66:77", "wlan0", 200+i, 7))
+	}
+	// Ignored: no reason code, other alerts
+	tracker.Report(frame("11:22:33:44:55:66", "wlan0", 101, 0))
+	tracker.Report(domain.Alert{Subtype: "CSA_DETECTED", DeviceMAC: "aa:bb:cc:dd:ee:ff", BSSID: "aa:bb:cc:dd:ee:ff", ReasonCode: 7})
+
+	stats := tracker.Stats("")
+	require.Len(t, stats, 1)
+	bss := stats[0]
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", bss.BSSID)
+	assert.Equal(t, 36, bss.Frames, "frames overheard by several sensors are counted once")
+	assert.Equal(t, now, bss.FirstSeen)
+	require.Len(t, bss.Reasons, 2)
+	assert.Equal(t, domain.DeauthReasonCount{Code: 7, Name: "Class 3 frame received from nonassociated station", Count: 35}, bss.Reasons[0])
+
+	require.Len(t, bss.Sources, 3)
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", bss.Sources[0].MAC)
+	assert.Equal(t, 30, bss.Sources[0].Frames)
+
+	require.Len(t, bss.Anomalies, 2)
+	assert.Contains(t, bss.Anomalies[0], "aa:bb:cc:dd:ee:ff sent 30 frames, 100% with reason 7")
+	assert.Contains(t, bss.Anomalies[1], "22:33:44:55:66:77 is not the AP")
+
+	assert.Len(t, tracker.Stats("aa:bb:cc:dd:ee:ff"), 1)
+	assert.Empty(t, tracker.Stats("00:00:00:00:00:01"))
+
+	tracker.Clear()
+	assert.Empty(t, tracker.Stats(""))
+}
@@ -0,0 +1,184 @@
+package network
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+const (
+	// DefaultMaxObservations bounds the in-memory observation history.
+	DefaultMaxObservations = 50000
+
+	// metersPerDegreeLat is the approximate length of one degree of latitude.
+	metersPerDegreeLat = 111320.0
+)
+
+// HeatmapService collects geo-tagged RSSI samples and bins them into a grid.
+type HeatmapService struct {
+	observations []domain.SignalObservation
+	maxSize      int
+	mu           sync.RWMutex
+}
+
+// NewHeatmapService creates a new heatmap service bounded to maxSize samples.
+func NewHeatmapService(maxSize int) *HeatmapService {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxObservations
+	}
+	return &HeatmapService{
+		observations: make([]domain.SignalObservation, 0, 1024),
+		maxSize:      maxSize,
+	}
+}
+
+// Record stores a sample if the device is a located AP with a known SSID.
+func (s *HeatmapService) Record(device domain.Device) {
+	if !device.IsAP() || device.SSID == "" || device.RSSI == 0 {
+		return
+	}
+	if device.Latitude == 0 && device.Longitude == 0 {
+		return
+	}
+
+	ts := device.LastPacketTime
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observations = append(s.observations, domain.SignalObservation{
+		SSID:      device.SSID,
+		BSSID:     device.MAC,
+		RSSI:      device.RSSI,
+		Latitude:  device.Latitude,
+		Longitude: device.Longitude,
+		Timestamp: ts,
+	})
+
+	// Drop oldest samples once the bound is exceeded
+	if len(s.observations) > s.maxSize {
+		s.observations = s.observations[len(s.observations)-s.maxSize:]
+	}
+}
+
+// Clear drops all recorded samples.
+func (s *HeatmapService) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.observations = s.observations[:0]
+}
+
+// Forget drops the samples recorded for a BSSID and returns how many there were.
+func (s *HeatmapService) Forget(bssid string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.observations[:0]
+	for _, o := range s.observations {
+		if o.BSSID != bssid {
+			kept = append(kept, o)
+		}
+	}
+	removed := len(s.observations) - len(kept)
+	s.observations = kept
+	return removed
+}
+
+type cellKey struct {
+	row, col int
+	ssid     string
+}
+
+type cellAccumulator struct {
+	max   int
+	sum   int
+	count int
+}
+
+// Aggregate bins the matching samples into a grid of square cells.
+func (s *HeatmapService) Aggregate(query domain.HeatmapQuery) (domain.Heatmap, error) {
+	if err := query.Validate(); err != nil {
+		return domain.Heatmap{}, err
+	}
+
+	s.mu.RLock()
+	samples := make([]domain.SignalObservation, 0, len(s.observations))
+	for _, o := range s.observations {
+		if query.SSID != "" && o.SSID != query.SSID {
+			continue
+		}
+		if !query.Since.IsZero() && o.Timestamp.Before(query.Since) {
+			continue
+		}
+		samples = append(samples, o)
+	}
+	s.mu.RUnlock()
+
+	heatmap := domain.Heatmap{
+		CellSizeMeters: query.CellSizeMeters,
+		Observations:   len(samples),
+		Cells:          []domain.HeatmapCell{},
+	}
+	if len(samples) == 0 {
+		return heatmap, nil
+	}
+
+	// Equirectangular projection anchored at the mean latitude of the samples
+	var latSum float64
+	for _, o := range samples {
+		latSum += o.Latitude
+	}
+	refLat := latSum / float64(len(samples))
+	degLat := query.CellSizeMeters / metersPerDegreeLat
+	degLng := query.CellSizeMeters / (metersPerDegreeLat * math.Max(math.Cos(refLat*math.Pi/180), 0.01))
+
+	cells := make(map[cellKey]*cellAccumulator)
+	for _, o := range samples {
+		key := cellKey{
+			row:  int(math.Floor(o.Latitude / degLat)),
+			col:  int(math.Floor(o.Longitude / degLng)),
+			ssid: o.SSID,
+		}
+		acc, ok := cells[key]
+		if !ok {
+			acc = &cellAccumulator{max: o.RSSI}
+			cells[key] = acc
+		}
+		if o.RSSI > acc.max {
+			acc.max = o.RSSI
+		}
+		acc.sum += o.RSSI
+		acc.count++
+	}
+
+	for key, acc := range cells {
+		heatmap.Cells = append(heatmap.Cells, domain.HeatmapCell{
+			Row:       key.row,
+			Col:       key.col,
+			Latitude:  (float64(key.row) + 0.5) * degLat,
+			Longitude: (float64(key.col) + 0.5) * degLng,
+			SSID:      key.ssid,
+			MaxRSSI:   acc.max,
+			AvgRSSI:   float64(acc.sum) / float64(acc.count),
+			Samples:   acc.count,
+		})
+	}
+
+	// Deterministic ordering for clients and exports
+	sort.Slice(heatmap.Cells, func(i, j int) bool {
+		a, b := heatmap.Cells[i], heatmap.Cells[j]
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		if a.Col != b.Col {
+			return a.Col < b.Col
+		}
+		return a.SSID < b.SSID
+	})
+
+	return heatmap, nil
+}
@@ -0,0 +1,76 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeatmapService_Aggregate(t *testing.T) {
+	svc := NewHeatmapService(100)
+	now := time.Now()
+
+	ap := func(ssid string, rssi int, lat, lng float64) domain.Device {
+		return domain.Device{
+			MAC:            "00:11:22:33:44:55",
+			Type:           domain.DeviceTypeAP,
+			SSID:           ssid,
+			RSSI:           rssi,
+			Latitude:       lat,
+			Longitude:      lng,
+			LastPacketTime: now,
+		}
+	}
+
+	// Two samples in the same ~25m cell, one far away
+	svc.Record(ap("Corp", -70, 40.41680, -3.70380))
+	svc.Record(ap("Corp", -50, 40.41681, -3.70381))
+	svc.Record(ap("Corp", -80, 40.42680, -3.70380))
+	svc.Record(ap("Guest", -60, 40.41680, -3.70380))
+
+	// Ignored: station, missing location, missing SSID
+	svc.Record(domain.Device{MAC: "aa:aa:aa:aa:aa:aa", Type: domain.DeviceTypeStation, RSSI: -40, Latitude: 40.4, Longitude: -3.7})
+	svc.Record(ap("Corp", -40, 0, 0))
+	svc.Record(ap("", -40, 40.4, -3.7))
+
+	t.Run("All SSIDs", func(t *testing.T) {
+		hm, err := svc.Aggregate(domain.HeatmapQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultHeatmapCellSize, hm.CellSizeMeters)
+		assert.Equal(t, 4, hm.Observations)
+		assert.Len(t, hm.Cells, 3)
+	})
+
+	t.Run("Filtered by SSID", func(t *testing.T) {
+		hm, err := svc.Aggregate(domain.HeatmapQuery{SSID: "Corp", CellSizeMeters: 25})
+		require.NoError(t, err)
+		require.Len(t, hm.Cells, 2)
+
+		var dense domain.HeatmapCell
+		for _, c := range hm.Cells {
+			if c.Samples == 2 {
+				dense = c
+			}
+		}
+		assert.Equal(t, -50, dense.MaxRSSI)
+		assert.InDelta(t, -60.0, dense.AvgRSSI, 0.001)
+	})
+
+	t.Run("Invalid cell size", func(t *testing.T) {
+		_, err := svc.Aggregate(domain.HeatmapQuery{CellSizeMeters: -5})
+		assert.ErrorIs(t, err, domain.ErrInvalidCellSize)
+	})
+
+	t.Run("Bounded history", func(t *testing.T) {
+		small := NewHeatmapService(2)
+		for i := 0; i < 5; i++ {
+			small.Record(ap("Corp", -50-i, 40.4168, -3.7038))
+		}
+		hm, err := small.Aggregate(domain.HeatmapQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, hm.Observations)
+	})
+}
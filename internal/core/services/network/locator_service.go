@@ -0,0 +1,236 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+const (
+	// trendWindow is the number of smoothed samples compared to derive the trend.
+	trendWindow = 5
+	// trendThreshold is the minimum smoothed change (dB) considered a movement.
+	trendThreshold = 2.0
+)
+
+// rssiTracker applies exponential smoothing to RSSI and derives a trend.
+type rssiTracker struct {
+	alpha    float64
+	smoothed float64
+	history  []float64
+	started  bool
+}
+
+func newRSSITracker(alpha float64) *rssiTracker {
+	return &rssiTracker{alpha: alpha}
+}
+
+// update feeds a raw sample and returns the smoothed value, the delta over the window and the trend.
+func (t *rssiTracker) update(rssi int) (float64, float64, domain.LocatorTrend) {
+	if !t.started {
+		t.smoothed = float64(rssi)
+		t.started = true
+	} else {
+		t.smoothed = t.alpha*float64(rssi) + (1-t.alpha)*t.smoothed
+	}
+
+	t.history = append(t.history, t.smoothed)
+	if len(t.history) > trendWindow {
+		t.history = t.history[1:]
+	}
+	if len(t.history) < trendWindow {
+		return t.smoothed, 0, domain.TrendUnknown
+	}
+
+	delta := t.smoothed - t.history[0]
+	switch {
+	case delta >= trendThreshold:
+		return t.smoothed, delta, domain.TrendApproaching
+	case delta <= -trendThreshold:
+		return t.smoothed, delta, domain.TrendReceding
+	default:
+		return t.smoothed, delta, domain.TrendStable
+	}
+}
+
+// LocatorService runs a single "hot/cold" session to physically track down a device.
+type LocatorService struct {
+	registry  ports.DeviceRegistry
+	sniffer   ports.Sniffer
+	audit     ports.AuditService
+	publisher func(domain.LocatorReading)
+	roles     domain.InterfaceRoles // The injection interface is locked instead of a hopping one
+
+	session *domain.LocatorSession
+	tracker *rssiTracker
+	timer   *time.Timer
+	mu      sync.Mutex
+}
+
+// NewLocatorService creates a new locator service.
+func NewLocatorService(registry ports.DeviceRegistry, sniffer ports.Sniffer, audit ports.AuditService) *LocatorService {
+	return &LocatorService{
+		registry: registry,
+		sniffer:  sniffer,
+		audit:    audit,
+	}
+}
+
+// SetPublisher sets the callback used to stream readings (e.g. over WebSocket).
+func (l *LocatorService) SetPublisher(publisher func(domain.LocatorReading)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.publisher = publisher
+}
+
+// SetInterfaceRoles sets the interface locked when none is given.
+func (l *LocatorService) SetInterfaceRoles(roles domain.InterfaceRoles) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.roles = roles
+}
+
+// Start locks the interface on the target's channel and begins tracking.
+func (l *LocatorService) Start(ctx context.Context, config domain.LocatorConfig) (domain.LocatorSession, error) {
+	if err := config.Validate(); err != nil {
+		return domain.LocatorSession{}, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.session != nil && l.session.Active {
+		return domain.LocatorSession{}, domain.ErrLocatorActive
+	}
+
+	// Channel Auto-detection
+	if config.Channel == 0 {
+		device, exists := l.registry.GetDevice(ctx, config.TargetMAC)
+		if !exists || device.Channel <= 0 {
+			return domain.LocatorSession{}, fmt.Errorf("channel is 0 and could not be detected for %s", config.TargetMAC)
+		}
+		config.Channel = device.Channel
+	}
+
+	// Interface Auto-detection
+	if config.Interface == "" && l.sniffer != nil {
+		interfaces, _ := l.sniffer.GetInterfaces(ctx)
+		config.Interface = l.roles.Pick(domain.InterfaceRoleInjection, interfaces)
+	}
+
+	if l.sniffer != nil && config.Interface != "" {
+		if err := l.sniffer.Lock(ctx, config.Interface, config.Channel); err != nil {
+			return domain.LocatorSession{}, fmt.Errorf("failed to lock %s on channel %d: %w", config.Interface, config.Channel, err)
+		}
+	}
+
+	l.session = &domain.LocatorSession{
+		ID:        uuid.New().String(),
+		Config:    config,
+		Active:    true,
+		StartTime: time.Now(),
+	}
+	l.tracker = newRSSITracker(config.Smoothing)
+
+	// Safety net: never hold the channel lock indefinitely
+	sessionID := l.session.ID
+	l.timer = time.AfterFunc(config.MaxDuration, func() {
+		l.stopSession(context.Background(), sessionID)
+	})
+
+	if l.audit != nil {
+		l.audit.Log(ctx, domain.ActionInfo, config.TargetMAC, fmt.Sprintf("Locator started on %s ch %d", config.Interface, config.Channel))
+	}
+
+	return *l.session, nil
+}
+
+// Stop ends the current session and releases the channel lock.
+func (l *LocatorService) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	if l.session == nil || !l.session.Active {
+		l.mu.Unlock()
+		return domain.ErrLocatorNotActive
+	}
+	id := l.session.ID
+	l.mu.Unlock()
+
+	l.stopSession(ctx, id)
+	return nil
+}
+
+// stopSession terminates the session if it is still the active one.
+func (l *LocatorService) stopSession(ctx context.Context, id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.session == nil || !l.session.Active || l.session.ID != id {
+		return
+	}
+
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+
+	if l.sniffer != nil && l.session.Config.Interface != "" {
+		_ = l.sniffer.Unlock(ctx, l.session.Config.Interface)
+	}
+
+	now := time.Now()
+	l.session.Active = false
+	l.session.EndTime = &now
+
+	if l.audit != nil {
+		l.audit.Log(ctx, domain.ActionInfo, l.session.Config.TargetMAC, fmt.Sprintf("Locator stopped after %d samples", l.session.Samples))
+	}
+}
+
+// Status returns the current (or last) session.
+func (l *LocatorService) Status() (domain.LocatorSession, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.session == nil {
+		return domain.LocatorSession{}, domain.ErrLocatorNotActive
+	}
+	return *l.session, nil
+}
+
+// Feed inspects a captured device and emits a reading if it is the tracked target.
+func (l *LocatorService) Feed(device domain.Device) {
+	l.mu.Lock()
+	if l.session == nil || !l.session.Active || !strings.EqualFold(device.MAC, l.session.Config.TargetMAC) {
+		l.mu.Unlock()
+		return
+	}
+	// Frames without radiotap signal report the -100 floor or 0
+	if device.RSSI == 0 || device.RSSI <= -100 {
+		l.mu.Unlock()
+		return
+	}
+
+	smoothed, delta, trend := l.tracker.update(device.RSSI)
+	reading := domain.LocatorReading{
+		TargetMAC:    l.session.Config.TargetMAC,
+		RSSI:         device.RSSI,
+		SmoothedRSSI: smoothed,
+		Delta:        delta,
+		Trend:        trend,
+		Channel:      l.session.Config.Channel,
+		Timestamp:    time.Now(),
+	}
+	l.session.Samples++
+	l.session.LastReading = &reading
+	publisher := l.publisher
+	l.mu.Unlock()
+
+	if publisher != nil {
+		publisher(reading)
+	}
+}
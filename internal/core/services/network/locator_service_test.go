@@ -0,0 +1,73 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/services/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSSITracker_Trend(t *testing.T) {
+	tracker := newRSSITracker(0.5)
+
+	// Warm-up: not enough history for a trend
+	_, _, trend := tracker.update(-80)
+	assert.Equal(t, domain.TrendUnknown, trend)
+
+	for _, rssi := range []int{-78, -74, -70, -65} {
+		_, _, trend = tracker.update(rssi)
+	}
+	assert.Equal(t, domain.TrendApproaching, trend)
+
+	for _, rssi := range []int{-70, -78, -85, -90, -92} {
+		_, _, trend = tracker.update(rssi)
+	}
+	assert.Equal(t, domain.TrendReceding, trend)
+
+	for i := 0; i < 20; i++ {
+		_, _, trend = tracker.update(-60)
+	}
+	assert.Equal(t, domain.TrendStable, trend)
+}
+
+func TestLocatorService_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	reg := registry.NewDeviceRegistry(nil, nil)
+	svc := NewLocatorService(reg, nil, nil)
+
+	var readings []domain.LocatorReading
+	svc.SetPublisher(func(r domain.LocatorReading) {
+		readings = append(readings, r)
+	})
+
+	t.Run("Channel required when target unknown", func(t *testing.T) {
+		_, err := svc.Start(ctx, domain.LocatorConfig{TargetMAC: "00:11:22:33:44:55"})
+		assert.Error(t, err)
+	})
+
+	session, err := svc.Start(ctx, domain.LocatorConfig{TargetMAC: "00:11:22:33:44:55", Channel: 6})
+	require.NoError(t, err)
+	assert.True(t, session.Active)
+
+	_, err = svc.Start(ctx, domain.LocatorConfig{TargetMAC: "00:11:22:33:44:66", Channel: 1})
+	assert.ErrorIs(t, err, domain.ErrLocatorActive)
+
+	svc.Feed(domain.Device{MAC: "00:11:22:33:44:55", RSSI: -60})
+	svc.Feed(domain.Device{MAC: "00:11:22:33:44:66", RSSI: -40})  // Not tracked
+	svc.Feed(domain.Device{MAC: "00:11:22:33:44:55", RSSI: -100}) // No signal info
+
+	require.Len(t, readings, 1)
+	assert.Equal(t, -60, readings[0].RSSI)
+	assert.Equal(t, 6, readings[0].Channel)
+
+	require.NoError(t, svc.Stop(ctx))
+	assert.ErrorIs(t, svc.Stop(ctx), domain.ErrLocatorNotActive)
+
+	status, err := svc.Status()
+	require.NoError(t, err)
+	assert.False(t, status.Active)
+	assert.Equal(t, 1, status.Samples)
+}
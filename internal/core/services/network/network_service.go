@@ -2,10 +2,19 @@ package network
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/adapters/attack/authflood"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/beaconspoof"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/csa"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/karma"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/navjam"
+	"github.com/lcalzada-xor/wmap/internal/adapters/attack/probeflood"
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
 	"github.com/lcalzada-xor/wmap/internal/core/services/persistence"
@@ -36,10 +45,25 @@ type NetworkService struct {
 	persistence  *persistence.PersistenceManager
 	sniffer      ports.Sniffer
 	auditService ports.AuditService
+	learner      ports.SignatureLearner // Optional, set when signature learning is available
+	inventory    ports.AssetInventory   // Optional, set when a CMDB is synchronized
+	portal       ports.PortalChecker    // Optional, set when a managed interface is configured
+	ticketer     ports.FindingTicketer  // Optional, set when findings are filed in an issue tracker
+	enricher     ports.AlertEnricher    // Optional, set when the CVE database is available
+	router       ports.WorkspaceRouter  // Optional, set when workspaces can be fed by routed sensors
 
 	// Sub-Services
 	statsService      *StatsService
 	attackCoordinator *AttackCoordinator
+	heatmapService    *HeatmapService
+	locatorService    *LocatorService
+	deauthCorrelator  *DeauthCorrelator
+	protectedMonitor  *ProtectedMonitor
+	alertEvidence     *AlertEvidence
+	deauthReasons     *DeauthReasonTracker
+
+	// Device retention of the active workspace, the cleanup default when zero
+	retention atomic.Int64
 
 	// Initialization state
 	mu sync.RWMutex
@@ -53,7 +77,7 @@ func NewNetworkService(
 	sniffer ports.Sniffer,
 	auditService ports.AuditService,
 ) *NetworkService {
-	return &NetworkService{
+	s := &NetworkService{
 		registry:          registry,
 		security:          security,
 		persistence:       persistence,
@@ -61,7 +85,31 @@ func NewNetworkService(
 		auditService:      auditService,
 		statsService:      NewStatsService(registry, security),
 		attackCoordinator: NewAttackCoordinator(registry, sniffer, auditService),
+		heatmapService:    NewHeatmapService(DefaultMaxObservations),
+		locatorService:    NewLocatorService(registry, sniffer, auditService),
+		deauthCorrelator:  NewDeauthCorrelator(DefaultDeauthCorrelationWindow, DefaultDeauthIncidentExpiry),
+		protectedMonitor:  NewProtectedMonitor(DefaultDeauthCorrelationWindow, DefaultDeauthIncidentExpiry),
+		alertEvidence:     NewAlertEvidence(DefaultEvidenceLead),
+		deauthReasons:     NewDeauthReasonTracker(),
+	}
+	if history, ok := sniffer.(ports.FrameHistory); ok {
+		s.alertEvidence.SetHistory(history)
+	}
+	if linker, ok := security.(ports.EvidenceLinker); ok {
+		// Engine alerts are stored, so their evidence is linked once saved
+		linker.OnAlert(func(alert domain.Alert) {
+			if s.alertEvidence.Wants(alert) {
+				go s.linkEvidence(linker, alert)
+			}
+			s.fileTicket(alert)
+		})
 	}
+	if persistence != nil {
+		s.attackCoordinator.SetHistoryStore(persistence)
+		s.attackCoordinator.SetScopeStore(persistence)
+		s.protectedMonitor.SetStore(persistence)
+	}
+	return s
 }
 
 // SetDeauthEngine injects the deauth engine dependency
@@ -79,6 +127,48 @@ func (s *NetworkService) SetAuthFloodEngine(engine *authflood.AuthFloodEngine) {
 	s.attackCoordinator.SetAuthFloodEngine(engine)
 }
 
+// SetProbeFloodEngine injects the Probe Flood engine dependency
+func (s *NetworkService) SetProbeFloodEngine(engine *probeflood.ProbeFloodEngine) {
+	s.attackCoordinator.SetProbeFloodEngine(engine)
+}
+
+// SetCSAEngine injects the Channel Switch Announcement engine dependency
+func (s *NetworkService) SetCSAEngine(engine *csa.CSAEngine) {
+	s.attackCoordinator.SetCSAEngine(engine)
+}
+
+// SetBeaconSpoofEngine injects the beacon spoofing engine dependency
+func (s *NetworkService) SetBeaconSpoofEngine(engine *beaconspoof.BeaconSpoofEngine) {
+	s.attackCoordinator.SetBeaconSpoofEngine(engine)
+}
+
+// SetKarmaEngine injects the Karma-lite responder engine dependency
+func (s *NetworkService) SetKarmaEngine(engine *karma.KarmaEngine) {
+	s.attackCoordinator.SetKarmaEngine(engine)
+}
+
+// SetNAVJamEngine injects the RTS/CTS virtual jamming engine dependency
+func (s *NetworkService) SetNAVJamEngine(engine *navjam.NAVJamEngine) {
+	s.attackCoordinator.SetNAVJamEngine(engine)
+}
+
+// SetInterfaceRoles sets the interfaces dedicated to injection and AP duties,
+// used by attacks and the locator when no interface is given
+func (s *NetworkService) SetInterfaceRoles(roles domain.InterfaceRoles) {
+	s.attackCoordinator.SetInterfaceRoles(roles)
+	s.locatorService.SetInterfaceRoles(roles)
+}
+
+// SetActiveAttackStore sets where running attacks are kept until they finish
+func (s *NetworkService) SetActiveAttackStore(store ports.ActiveAttackRepository) {
+	s.attackCoordinator.SetActiveStore(store)
+}
+
+// RecoverAttacks resumes or aborts the attacks left running by a previous run
+func (s *NetworkService) RecoverAttacks(ctx context.Context) {
+	s.attackCoordinator.RecoverAttacks(ctx)
+}
+
 // SetDeauthLogger sets the logger for the deauth engine
 func (s *NetworkService) SetDeauthLogger(logger func(string, string)) {
 	// Wrapper to access protected/private engine inside coordinator if needed,
@@ -93,6 +183,348 @@ func (s *NetworkService) SetDeauthLogger(logger func(string, string)) {
 	}
 }
 
+// SetLocatorPublisher sets the callback used to stream locator readings
+func (s *NetworkService) SetLocatorPublisher(publisher func(domain.LocatorReading)) {
+	s.locatorService.SetPublisher(publisher)
+}
+
+// SetAlertPublisher sets the callback used to raise sensor alerts (e.g. over WebSocket).
+// Alerts worth evidence are raised with their capture linked, alerts of
+// devices with CVEs with the top ones, and severe ones are filed in the issue
+// tracker, if any.
+func (s *NetworkService) SetAlertPublisher(publisher func(domain.Alert)) {
+	if publisher == nil {
+		s.deauthCorrelator.SetPublisher(nil)
+		s.protectedMonitor.SetPublisher(nil)
+		return
+	}
+	publish := func(alert domain.Alert) {
+		s.enrichAlert(&alert)
+		s.attachEvidence(&alert)
+		publisher(alert)
+		s.fileTicket(alert)
+	}
+	s.deauthCorrelator.SetPublisher(publish)
+	s.protectedMonitor.SetPublisher(publish)
+}
+
+// SetEvidenceStore sets the artifact store receiving the frames of attacks on
+// protected BSSIDs and around the alerts worth evidence.
+func (s *NetworkService) SetEvidenceStore(store ports.ArtifactManager) {
+	s.protectedMonitor.SetEvidenceStore(store)
+	s.alertEvidence.SetStore(store)
+}
+
+// SetCaptureContext sets the function returning the attribution recorded in
+// evidence captures.
+func (s *NetworkService) SetCaptureContext(fn domain.CaptureContextFunc) {
+	s.protectedMonitor.SetCaptureContext(fn)
+	s.alertEvidence.SetCaptureContext(fn)
+}
+
+// attachEvidence saves the frames around a sensor alert before it is raised,
+// so the published alert links to them.
+func (s *NetworkService) attachEvidence(alert *domain.Alert) {
+	id, err := s.alertEvidence.Capture(context.Background(), *alert)
+	if err != nil {
+		log.Printf("Warning: could not save evidence of %s alert: %v", alert.Subtype, err)
+		return
+	}
+	if id != "" {
+		alert.EvidenceID = id
+	}
+}
+
+// linkEvidence saves the frames around an alert of the security engine and
+// links them to the stored alert.
+func (s *NetworkService) linkEvidence(linker ports.EvidenceLinker, alert domain.Alert) {
+	s.attachEvidence(&alert)
+	if alert.EvidenceID != "" {
+		linker.AttachEvidence(alert.ID, alert.EvidenceID)
+	}
+}
+
+// SetTicketer sets the service filing severe alerts in an issue tracker.
+func (s *NetworkService) SetTicketer(ticketer ports.FindingTicketer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ticketer = ticketer
+}
+
+// SetAlertEnricher sets the enricher adding the CVEs of the device to sensor
+// alerts before they are raised.
+func (s *NetworkService) SetAlertEnricher(enricher ports.AlertEnricher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enricher = enricher
+}
+
+// enrichAlert adds the CVEs of the device to a sensor alert, if an enricher
+// is set.
+func (s *NetworkService) enrichAlert(alert *domain.Alert) {
+	s.mu.RLock()
+	enricher := s.enricher
+	s.mu.RUnlock()
+	if enricher != nil {
+		enricher.EnrichAlert(context.Background(), alert)
+	}
+}
+
+// fileTicket files an alert in the issue tracker, if any, in the background.
+func (s *NetworkService) fileTicket(alert domain.Alert) {
+	s.mu.RLock()
+	ticketer := s.ticketer
+	s.mu.RUnlock()
+	if ticketer != nil {
+		go ticketer.FileAlert(context.Background(), alert)
+	}
+}
+
+// SaveRecentFrames saves the frames captured in the last window on iface,
+// every adapter when empty, as a pcap artifact.
+func (s *NetworkService) SaveRecentFrames(ctx context.Context, iface string, window time.Duration) (domain.Artifact, error) {
+	return s.alertEvidence.SaveRecent(ctx, iface, window)
+}
+
+// AttackOn returns the ID and operator of the most recent running attack on target.
+func (s *NetworkService) AttackOn(target string) (id, operator string, ok bool) {
+	return s.attackCoordinator.AttackOn(target)
+}
+
+// ReportAlert handles an alert raised by a local or remote sensor. Deauth
+// alerts of the same source are correlated across sensors before being raised,
+// and their reason codes tallied per BSSID.
+func (s *NetworkService) ReportAlert(ctx context.Context, alert domain.Alert) error {
+	s.mu.RLock()
+	router := s.router
+	s.mu.RUnlock()
+	if router != nil {
+		alert.Workspace = router.WorkspaceOf(alert.Sensor)
+	}
+
+	s.protectedMonitor.Inspect(alert)
+	s.deauthReasons.Report(alert)
+	s.deauthCorrelator.Report(alert)
+	return nil
+}
+
+// DeauthReasonStats returns the reason codes of the deauthentication frames
+// seen per BSSID, restricted to bssid when not empty.
+func (s *NetworkService) DeauthReasonStats(ctx context.Context, bssid string) []domain.DeauthReasonStats {
+	return s.deauthReasons.Stats(bssid)
+}
+
+// AddProtectedBSSID protects a BSSID against deauthentication and channel switch attacks.
+func (s *NetworkService) AddProtectedBSSID(ctx context.Context, protected domain.ProtectedBSSID) (domain.ProtectedBSSID, error) {
+	return s.protectedMonitor.Add(ctx, protected)
+}
+
+// RemoveProtectedBSSID stops protecting a BSSID.
+func (s *NetworkService) RemoveProtectedBSSID(ctx context.Context, bssid string) error {
+	return s.protectedMonitor.Remove(ctx, bssid)
+}
+
+// SetSignatureLearner injects the engine recording signatures of labeled devices.
+func (s *NetworkService) SetSignatureLearner(learner ports.SignatureLearner) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.learner = learner
+}
+
+// SetWorkspaceRouter sets the router attributing the alerts of each sensor
+// to the workspace it feeds.
+func (s *NetworkService) SetWorkspaceRouter(router ports.WorkspaceRouter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.router = router
+}
+
+// SetInventory sets the trusted inventory devices are tagged from.
+func (s *NetworkService) SetInventory(inventory ports.AssetInventory) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inventory = inventory
+}
+
+// SetPortalChecker sets the checker of open networks' captive portals.
+func (s *NetworkService) SetPortalChecker(checker ports.PortalChecker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.portal = checker
+}
+
+// CheckCaptivePortal associates with an open AP in scope to find whether it
+// gives Internet access, sits behind a captive portal or has no egress. The
+// result is recorded on the AP, and free access raises OPEN-EGRESS.
+func (s *NetworkService) CheckCaptivePortal(ctx context.Context, bssid string) (domain.PortalCheck, error) {
+	s.mu.RLock()
+	checker := s.portal
+	s.mu.RUnlock()
+	if checker == nil {
+		return domain.PortalCheck{}, fmt.Errorf("captive portal checks not available: no managed interface configured")
+	}
+
+	device, ok := s.registry.GetDevice(ctx, bssid)
+	if !ok {
+		return domain.PortalCheck{}, domain.ErrDeviceNotFound
+	}
+	if !device.IsOpenNetwork() || device.SSID == "" {
+		return domain.PortalCheck{}, domain.ErrNotOpenNetwork
+	}
+	if err := s.attackCoordinator.checkROE(ctx, domain.AttackKindPortal); err != nil {
+		return domain.PortalCheck{}, err
+	}
+	if err := s.attackCoordinator.checkScope(ctx, domain.AttackKindPortal, bssid, device.SSID); err != nil {
+		return domain.PortalCheck{}, err
+	}
+
+	check, err := checker.CheckPortal(ctx, domain.PortalTarget{BSSID: device.MAC, SSID: device.SSID, Frequency: device.Frequency})
+	if err != nil {
+		return domain.PortalCheck{}, err
+	}
+	if s.auditService != nil {
+		s.auditService.Log(ctx, domain.ActionInfo, bssid, fmt.Sprintf("Captive portal check of %q via %s: %s", device.SSID, check.Interface, check.Result))
+	}
+
+	// Re-read: the AP kept being updated during the check
+	if current, ok := s.registry.GetDevice(ctx, bssid); ok {
+		device = current
+	}
+	device.Portal = &check
+	s.registry.LoadDevice(ctx, device)
+	s.security.Analyze(ctx, device)
+	if s.persistence != nil {
+		s.persistence.Persist(device)
+	}
+	return check, nil
+}
+
+// LabelDevice sets the true make and model of a device and records its
+// signature so devices sharing it are classified alike.
+func (s *NetworkService) LabelDevice(ctx context.Context, mac string, label domain.DeviceLabel) (domain.DeviceSignature, error) {
+	s.mu.RLock()
+	learner := s.learner
+	s.mu.RUnlock()
+	if learner == nil {
+		return domain.DeviceSignature{}, fmt.Errorf("signature learning not available")
+	}
+
+	device, ok := s.registry.GetDevice(ctx, mac)
+	if !ok {
+		return domain.DeviceSignature{}, domain.ErrDeviceNotFound
+	}
+	sig, err := learner.LearnSignature(ctx, device, label)
+	if err != nil {
+		return domain.DeviceSignature{}, err
+	}
+
+	device.Model = sig.Model
+	if sig.Vendor != "" {
+		device.Vendor = sig.Vendor
+	}
+	if sig.OS != "" {
+		device.OS = sig.OS
+	}
+	s.registry.LoadDevice(ctx, device)
+	if s.persistence != nil {
+		s.persistence.Persist(device)
+	}
+	return sig, nil
+}
+
+// AnnotateDevice attaches key/value findings, e.g. from an external analyzer,
+// to a device. Existing keys are overwritten.
+func (s *NetworkService) AnnotateDevice(ctx context.Context, mac string, annotations map[string]string) error {
+	device, ok := s.registry.GetDevice(ctx, mac)
+	if !ok {
+		return domain.ErrDeviceNotFound
+	}
+
+	merged := make(map[string]string, len(device.Annotations)+len(annotations))
+	for k, v := range device.Annotations {
+		merged[k] = v
+	}
+	for k, v := range annotations {
+		merged[k] = v
+	}
+	device.Annotations = merged
+
+	s.registry.LoadDevice(ctx, device)
+	if s.persistence != nil {
+		s.persistence.Persist(device)
+	}
+	return nil
+}
+
+// SetDeviceAsset records the business asset a device is. An empty asset
+// clears it.
+func (s *NetworkService) SetDeviceAsset(ctx context.Context, mac string, asset domain.AssetInfo) (domain.Device, error) {
+	if err := asset.Validate(); err != nil {
+		return domain.Device{}, err
+	}
+	device, ok := s.registry.GetDevice(ctx, mac)
+	if !ok {
+		return domain.Device{}, domain.ErrDeviceNotFound
+	}
+
+	device.Asset = nil
+	if !asset.IsEmpty() {
+		device.Asset = &asset
+	}
+	s.registry.LoadDevice(ctx, device)
+	if s.persistence != nil {
+		s.persistence.Persist(device)
+	}
+
+	if s.auditService != nil {
+		s.auditService.Log(ctx, domain.ActionDeviceAsset, mac, fmt.Sprintf("owner=%q asset_tag=%q criticality=%q",
+			asset.Owner, asset.AssetTag, asset.Criticality))
+	}
+	return device, nil
+}
+
+// ForgetDevice removes a device from the registry, the workspace storage and
+// the signal history, and unlinks the stations associated with it so it
+// leaves the graph entirely.
+func (s *NetworkService) ForgetDevice(ctx context.Context, mac string) (domain.DeviceForgetReport, error) {
+	report := domain.DeviceForgetReport{MAC: mac}
+	report.Registry, report.Unlinked = s.registry.RemoveDevice(ctx, mac)
+	report.Observations = s.heatmapService.Forget(mac)
+
+	if s.persistence != nil {
+		err := s.persistence.DeleteDevice(ctx, mac)
+		if err != nil && !errors.Is(err, domain.ErrDeviceNotFound) {
+			return report, err
+		}
+		report.Storage = err == nil
+	}
+	if !report.Registry && !report.Storage && report.Observations == 0 {
+		return report, domain.ErrDeviceNotFound
+	}
+
+	if s.auditService != nil {
+		s.auditService.Log(ctx, domain.ActionDeviceForget, mac, fmt.Sprintf("registry=%t storage=%t observations=%d unlinked=%d",
+			report.Registry, report.Storage, report.Observations, report.Unlinked))
+	}
+	return report, nil
+}
+
+// GetLearnedSignatures returns the signatures learned from labeled devices.
+func (s *NetworkService) GetLearnedSignatures(ctx context.Context) []domain.DeviceSignature {
+	s.mu.RLock()
+	learner := s.learner
+	s.mu.RUnlock()
+	if learner == nil {
+		return []domain.DeviceSignature{}
+	}
+	return learner.LearnedSignatures(ctx)
+}
+
+// GetProtectedBSSIDs returns all protected BSSIDs.
+func (s *NetworkService) GetProtectedBSSIDs(ctx context.Context) []domain.ProtectedBSSID {
+	return s.protectedMonitor.List(ctx)
+}
+
 // ProcessDevice handles a newly captured device packet.
 func (s *NetworkService) ProcessDevice(ctx context.Context, newDevice domain.Device) error {
 	packetsProcessed.Inc()
@@ -100,14 +532,36 @@ func (s *NetworkService) ProcessDevice(ctx context.Context, newDevice domain.Dev
 	// 1. Registry: Merge state and perform discovery
 	merged, _ := s.registry.ProcessDevice(ctx, newDevice)
 
+	// Tag the business asset the inventory lists the device as
+	s.mu.RLock()
+	inventory := s.inventory
+	s.mu.RUnlock()
+	if inventory != nil {
+		if entry, ok := inventory.Lookup(merged.MAC); ok && entry.Apply(&merged) {
+			s.registry.LoadDevice(ctx, merged)
+		}
+	}
+
 	// 2. Security: Perform analysis on the merged state
 	s.security.Analyze(ctx, merged)
+	s.applyRulePolicies(ctx, &merged)
 
-	// 3. Persistence: Queue for background write
+	// 3. Persistence: Queue for background write, routed by the sensor of
+	// this observation: a device heard at two sites is written to both
 	if s.persistence != nil {
-		s.persistence.Persist(merged)
+		observed := merged
+		if newDevice.Sensor != "" {
+			observed.Sensor = newDevice.Sensor
+		}
+		s.persistence.Persist(observed)
 	}
 
+	// Record the geo-tagged sample for coverage heatmaps
+	s.heatmapService.Record(newDevice)
+
+	// Stream raw signal to the locator if this is the tracked device
+	s.locatorService.Feed(newDevice)
+
 	// 4. Placeholder logic for APs (if station is connected to unknown AP)
 	if merged.ConnectedSSID != "" {
 		if _, ok := s.registry.GetDevice(ctx, merged.ConnectedSSID); !ok {
@@ -124,11 +578,51 @@ func (s *NetworkService) ProcessDevice(ctx context.Context, newDevice domain.Dev
 	return nil
 }
 
+// applyRulePolicies carries out the tag and record actions of the rules the
+// device matches. A device still around when its recording ends is
+// recorded again.
+func (s *NetworkService) applyRulePolicies(ctx context.Context, device *domain.Device) {
+	matcher, ok := s.security.(ports.RuleMatcher)
+	if !ok {
+		return
+	}
+
+	tagged := false
+	for _, rule := range matcher.MatchRules(*device) {
+		if rule.HasAction(domain.RuleActionTag) {
+			key := domain.TagAnnotationPrefix + rule.Tag
+			if device.Annotations[key] != rule.ID {
+				annotations := make(map[string]string, len(device.Annotations)+1)
+				for k, v := range device.Annotations {
+					annotations[k] = v
+				}
+				annotations[key] = rule.ID
+				device.Annotations = annotations
+				tagged = true
+			}
+		}
+		if rule.HasAction(domain.RuleActionRecord) {
+			recorder, ok := s.sniffer.(ports.DeviceRecorder)
+			if ok && recorder.RecordDevice(ctx, device.MAC, rule.RecordFor(), "rule "+rule.ID) {
+				log.Printf("Recording frames of %s for %v (rule %s)", device.MAC, rule.RecordFor(), rule.ID)
+			}
+		}
+	}
+	if tagged {
+		s.registry.LoadDevice(ctx, *device)
+	}
+}
+
 // GetGraph returns the graph projection for visualization.
 func (s *NetworkService) GetGraph(ctx context.Context) (domain.GraphData, error) {
 	return s.statsService.GetGraph(ctx)
 }
 
+// GetHeatmap aggregates recorded signal observations into a grid.
+func (s *NetworkService) GetHeatmap(ctx context.Context, query domain.HeatmapQuery) (domain.Heatmap, error) {
+	return s.heatmapService.Aggregate(query)
+}
+
 // AddRule delegates to the Security Engine.
 func (s *NetworkService) AddRule(ctx context.Context, rule domain.AlertRule) error {
 	s.security.AddRule(ctx, rule)
@@ -149,7 +643,14 @@ func (s *NetworkService) TriggerScan(ctx context.Context) error {
 	return s.sniffer.Scan(ctx, "")
 }
 
-// StartCleanupLoop manages the periodic removal of old devices.
+// SetDeviceRetention sets how long devices stay in memory unseen, overriding
+// the ttl of the cleanup loop; zero restores it.
+func (s *NetworkService) SetDeviceRetention(retention time.Duration) {
+	s.retention.Store(int64(retention))
+}
+
+// StartCleanupLoop manages the periodic removal of old devices, unseen for
+// ttl unless a device retention is set.
 func (s *NetworkService) StartCleanupLoop(ctx context.Context, ttl time.Duration, interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	go func() {
@@ -160,7 +661,11 @@ func (s *NetworkService) StartCleanupLoop(ctx context.Context, ttl time.Duration
 				return
 			case <-ticker.C:
 				cleanupRuns.Inc()
-				deleted := s.registry.PruneOldDevices(ctx, ttl)
+				retention := ttl
+				if r := time.Duration(s.retention.Load()); r > 0 {
+					retention = r
+				}
+				deleted := s.registry.PruneOldDevices(ctx, retention)
 				if deleted > 0 {
 					devicesActive.Set(float64(s.registry.GetActiveCount(ctx)))
 				}
@@ -189,6 +694,8 @@ func (s *NetworkService) IsPersistenceEnabled() bool {
 // ResetWorkspace wipes the current in-memory discovery state.
 func (s *NetworkService) ResetWorkspace(ctx context.Context) error {
 	s.registry.Clear(ctx)
+	s.heatmapService.Clear()
+	s.deauthReasons.Clear()
 	return nil
 }
 
@@ -239,6 +746,71 @@ func (s *NetworkService) GetInterfaceDetails(ctx context.Context) ([]domain.Inte
 	return []domain.InterfaceInfo{}, nil
 }
 
+// LockInterfaceChannel pins an interface to a channel for a limited time so an
+// analyst can watch a specific AP. The hopper resumes automatically on expiry.
+func (s *NetworkService) LockInterfaceChannel(ctx context.Context, iface string, channel int, duration time.Duration) (domain.ChannelLockState, error) {
+	locker, ok := s.sniffer.(ports.ManualChannelLocker)
+	if !ok {
+		return domain.ChannelLockState{}, fmt.Errorf("manual channel lock not supported by the active sniffer")
+	}
+	state, err := locker.LockChannelFor(ctx, iface, channel, duration)
+	if err == nil && s.auditService != nil {
+		s.auditService.Log(ctx, domain.ActionConfigChange, iface, fmt.Sprintf("Channel locked to %d for %v", channel, duration))
+	}
+	return state, err
+}
+
+// UnlockInterfaceChannel releases a manual channel lock early.
+func (s *NetworkService) UnlockInterfaceChannel(ctx context.Context, iface string) error {
+	locker, ok := s.sniffer.(ports.ManualChannelLocker)
+	if !ok {
+		return fmt.Errorf("manual channel lock not supported by the active sniffer")
+	}
+	return locker.ReleaseChannelLock(ctx, iface)
+}
+
+// GetChannelRegulatory returns the regulatory flags (DFS, no-IR, disabled) of an
+// interface's channels. Sniffers without hardware access report none.
+func (s *NetworkService) GetChannelRegulatory(ctx context.Context, iface string) ([]domain.ChannelRegulatory, error) {
+	provider, ok := s.sniffer.(ports.RegulatoryProvider)
+	if !ok {
+		return []domain.ChannelRegulatory{}, nil
+	}
+	return provider.ChannelRegulatory(ctx, iface)
+}
+
+// GetDwellPlan returns the per-channel dwell times the hopper currently uses on
+// an interface. Sniffers without a hopper report none.
+func (s *NetworkService) GetDwellPlan(ctx context.Context, iface string) ([]domain.ChannelDwell, error) {
+	planner, ok := s.sniffer.(ports.DwellPlanner)
+	if !ok {
+		return []domain.ChannelDwell{}, nil
+	}
+	return planner.DwellPlan(ctx, iface)
+}
+
+// DiagnoseInterfaces runs the monitor-mode preflight checks. Sniffers without
+// hardware access (e.g. mock mode) report no diagnostics.
+func (s *NetworkService) DiagnoseInterfaces(ctx context.Context, ifaces []string) ([]domain.InterfaceDiagnostics, error) {
+	diagnoser, ok := s.sniffer.(ports.InterfaceDiagnoser)
+	if !ok {
+		return []domain.InterfaceDiagnostics{}, nil
+	}
+	return diagnoser.DiagnoseInterfaces(ctx, ifaces)
+}
+
+// RunInjectionTest verifies that frames can be injected on iface.
+func (s *NetworkService) RunInjectionTest(ctx context.Context, iface string, config domain.InjectionTestConfig) (domain.InjectionTestResult, error) {
+	tester, ok := s.sniffer.(ports.InjectionTester)
+	if !ok {
+		return domain.InjectionTestResult{}, domain.ErrInjectionTestUnsupported
+	}
+	if s.auditService != nil {
+		s.auditService.Log(ctx, domain.ActionInfo, iface, "Started injection self-test")
+	}
+	return tester.RunInjectionTest(ctx, iface, config)
+}
+
 // Deauth Attack Methods - Delegated to Coordinator
 
 func (s *NetworkService) StartDeauthAttack(ctx context.Context, config domain.DeauthAttackConfig) (string, error) {
@@ -290,12 +862,153 @@ func (s *NetworkService) GetAuthFloodStatus(ctx context.Context, id string) (dom
 	return s.attackCoordinator.GetAuthFloodStatus(ctx, id)
 }
 
+// Probe Flood Attack Methods - Delegated to Coordinator
+
+func (s *NetworkService) StartProbeFloodAttack(ctx context.Context, config domain.ProbeFloodAttackConfig) (string, error) {
+	return s.attackCoordinator.StartProbeFloodAttack(ctx, config)
+}
+
+func (s *NetworkService) StopProbeFloodAttack(ctx context.Context, id string, force bool) error {
+	return s.attackCoordinator.StopProbeFloodAttack(ctx, id, force)
+}
+
+func (s *NetworkService) GetProbeFloodStatus(ctx context.Context, id string) (domain.ProbeFloodAttackStatus, error) {
+	return s.attackCoordinator.GetProbeFloodStatus(ctx, id)
+}
+
+// CSA Attack Methods - Delegated to Coordinator
+
+func (s *NetworkService) StartCSAAttack(ctx context.Context, config domain.CSAAttackConfig) (string, error) {
+	return s.attackCoordinator.StartCSAAttack(ctx, config)
+}
+
+func (s *NetworkService) StopCSAAttack(ctx context.Context, id string, force bool) error {
+	return s.attackCoordinator.StopCSAAttack(ctx, id, force)
+}
+
+func (s *NetworkService) GetCSAStatus(ctx context.Context, id string) (domain.CSAAttackStatus, error) {
+	return s.attackCoordinator.GetCSAStatus(ctx, id)
+}
+
+// Beacon Spoofing Methods - Delegated to Coordinator
+
+func (s *NetworkService) StartBeaconSpoof(ctx context.Context, config domain.BeaconSpoofConfig) (string, error) {
+	return s.attackCoordinator.StartBeaconSpoof(ctx, config)
+}
+
+func (s *NetworkService) StopBeaconSpoof(ctx context.Context, id string, force bool) error {
+	return s.attackCoordinator.StopBeaconSpoof(ctx, id, force)
+}
+
+func (s *NetworkService) GetBeaconSpoofStatus(ctx context.Context, id string) (domain.BeaconSpoofStatus, error) {
+	return s.attackCoordinator.GetBeaconSpoofStatus(ctx, id)
+}
+
+// Karma Responder Methods - Delegated to Coordinator
+
+func (s *NetworkService) StartKarma(ctx context.Context, config domain.KarmaConfig) (string, error) {
+	return s.attackCoordinator.StartKarma(ctx, config)
+}
+
+func (s *NetworkService) StopKarma(ctx context.Context, id string, force bool) error {
+	return s.attackCoordinator.StopKarma(ctx, id, force)
+}
+
+func (s *NetworkService) GetKarmaStatus(ctx context.Context, id string) (domain.KarmaStatus, error) {
+	return s.attackCoordinator.GetKarmaStatus(ctx, id)
+}
+
+// NAV Jamming Methods - Delegated to Coordinator
+
+func (s *NetworkService) StartNAVJam(ctx context.Context, config domain.NAVJamConfig) (string, error) {
+	return s.attackCoordinator.StartNAVJam(ctx, config)
+}
+
+func (s *NetworkService) StopNAVJam(ctx context.Context, id string, force bool) error {
+	return s.attackCoordinator.StopNAVJam(ctx, id, force)
+}
+
+func (s *NetworkService) GetNAVJamStatus(ctx context.Context, id string) (domain.NAVJamStatus, error) {
+	return s.attackCoordinator.GetNAVJamStatus(ctx, id)
+}
+
+// GetAttackHistory returns persisted attack outcomes with their effectiveness score.
+func (s *NetworkService) GetAttackHistory(ctx context.Context, limit int) ([]domain.AttackRecord, error) {
+	return s.attackCoordinator.GetAttackHistory(ctx, limit)
+}
+
+// GetScope returns the engagement scope of the current workspace.
+func (s *NetworkService) GetScope(ctx context.Context) (domain.EngagementScope, error) {
+	return s.attackCoordinator.GetScope(ctx)
+}
+
+// CheckScope refuses an attack of the kind on a target outside the
+// engagement scope, or without rules of engagement in force, as the local
+// engines do.
+func (s *NetworkService) CheckScope(ctx context.Context, kind domain.AttackKind, target string) error {
+	if err := s.attackCoordinator.checkROE(ctx, kind); err != nil {
+		return err
+	}
+	return s.attackCoordinator.checkScope(ctx, kind, target, "")
+}
+
+// SetROEStore sets where the rules of engagement required to start attacks
+// are kept, and the key sealing them.
+func (s *NetworkService) SetROEStore(store ports.ROERepository, key []byte) {
+	s.attackCoordinator.SetROEStore(store, key)
+}
+
+// GetROE returns the rules of engagement last signed for the workspace.
+func (s *NetworkService) GetROE(ctx context.Context) (*domain.RulesOfEngagement, error) {
+	return s.attackCoordinator.GetROE(ctx)
+}
+
+// ListROE returns every rules of engagement record of the workspace.
+func (s *NetworkService) ListROE(ctx context.Context) ([]domain.RulesOfEngagement, error) {
+	return s.attackCoordinator.ListROE(ctx)
+}
+
+// SignROE records rules of engagement for the current scope.
+func (s *NetworkService) SignROE(ctx context.Context, roe domain.RulesOfEngagement) (domain.RulesOfEngagement, error) {
+	return s.attackCoordinator.SignROE(ctx, roe)
+}
+
+// RevokeROE withdraws the rules of engagement in force.
+func (s *NetworkService) RevokeROE(ctx context.Context) error {
+	return s.attackCoordinator.RevokeROE(ctx)
+}
+
+// SetScope replaces the engagement scope enforced on active attacks.
+func (s *NetworkService) SetScope(ctx context.Context, scope domain.EngagementScope) error {
+	return s.attackCoordinator.SetScope(ctx, scope)
+}
+
+// Device Locator Methods - Delegated to LocatorService
+
+func (s *NetworkService) StartLocator(ctx context.Context, config domain.LocatorConfig) (domain.LocatorSession, error) {
+	return s.locatorService.Start(ctx, config)
+}
+
+func (s *NetworkService) StopLocator(ctx context.Context) error {
+	return s.locatorService.Stop(ctx)
+}
+
+func (s *NetworkService) GetLocatorStatus(ctx context.Context) (domain.LocatorSession, error) {
+	return s.locatorService.Status()
+}
+
 func (s *NetworkService) GetWPSEngine() ports.WPSAttackService {
 	return s.attackCoordinator.wpsEngine
 }
 
+// StopAttacks stops every running attack, leaving the service running.
+func (s *NetworkService) StopAttacks(ctx context.Context) {
+	s.attackCoordinator.StopAll(ctx)
+}
+
 // Close stops all active services and attacks.
 func (s *NetworkService) Close() error {
 	s.attackCoordinator.StopAll(context.Background())
+	_ = s.locatorService.Stop(context.Background())
 	return nil
 }
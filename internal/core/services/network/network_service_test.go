@@ -12,6 +12,7 @@ import (
 	"github.com/lcalzada-xor/wmap/internal/core/services/security"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // mockStorage implements ports.Storage for testing
@@ -411,6 +412,123 @@ func TestStartDeauthAttack_AuditLog(t *testing.T) {
 	mockDeauth.AssertExpectations(t)
 }
 
+// recordingHistory keeps the attack records saved by the coordinator.
+type recordingHistory struct {
+	records []domain.AttackRecord
+}
+
+func (h *recordingHistory) SaveAttackRecord(ctx context.Context, record domain.AttackRecord) error {
+	h.records = append(h.records, record)
+	return nil
+}
+
+func (h *recordingHistory) ListAttackRecords(ctx context.Context, limit int) ([]domain.AttackRecord, error) {
+	return h.records, nil
+}
+
+func TestStartDeauthAttack_TracksOperator(t *testing.T) {
+	reg := registry.NewDeviceRegistry(nil, nil)
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), persistence.NewPersistenceManager(nil, 100), nil, nil)
+	mockDeauth := new(MockDeauthService)
+	svc.SetDeauthEngine(mockDeauth)
+	history := &recordingHistory{}
+	svc.attackCoordinator.SetHistoryStore(history)
+
+	config := domain.DeauthAttackConfig{TargetMAC: "00:11:22:33:44:55", AttackType: domain.DeauthTargeted, ClientMAC: "aa:bb:cc:dd:ee:ff", Channel: 6}
+	mockDeauth.On("StartAttack", mock.Anything, config).Return("job-1", nil)
+
+	ctx := domain.ContextWithUser(context.Background(), &domain.User{Username: "alice", Role: domain.RoleOperator})
+	_, err := svc.StartDeauthAttack(ctx, config)
+	assert.NoError(t, err)
+
+	id, operator, ok := svc.AttackOn("00:11:22:33:44:55")
+	assert.True(t, ok)
+	assert.Equal(t, "job-1", id)
+	assert.Equal(t, "alice", operator)
+
+	// Attributed and forgotten once the engine records the attack as finished
+	svc.attackCoordinator.recordAttack(domain.AttackRecord{ID: "job-1"})
+	_, _, ok = svc.AttackOn("00:11:22:33:44:55")
+	assert.False(t, ok)
+	if assert.Len(t, history.records, 1) {
+		assert.Equal(t, "alice", history.records[0].Operator)
+	}
+}
+
+// memoryActiveStore keeps the running attacks in memory.
+type memoryActiveStore struct {
+	attacks map[string]domain.ActiveAttack
+}
+
+func (m *memoryActiveStore) SaveActiveAttack(ctx context.Context, attack domain.ActiveAttack) error {
+	m.attacks[attack.ID] = attack
+	return nil
+}
+
+func (m *memoryActiveStore) DeleteActiveAttack(ctx context.Context, id string) error {
+	delete(m.attacks, id)
+	return nil
+}
+
+func (m *memoryActiveStore) ListActiveAttacks(ctx context.Context) ([]domain.ActiveAttack, error) {
+	var attacks []domain.ActiveAttack
+	for _, attack := range m.attacks {
+		attacks = append(attacks, attack)
+	}
+	return attacks, nil
+}
+
+func TestRecoverAttacks(t *testing.T) {
+	reg := registry.NewDeviceRegistry(nil, nil)
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), nil, nil, nil)
+	mockDeauth := new(MockDeauthService)
+	svc.SetDeauthEngine(mockDeauth)
+	history := &recordingHistory{}
+	svc.attackCoordinator.SetHistoryStore(history)
+
+	endless := domain.NewActiveAttack(domain.AttackKindDeauth, "job-1", "00:11:22:33:44:55", "wlan0", 6,
+		domain.DeauthAttackConfig{TargetMAC: "00:11:22:33:44:55", AttackType: domain.DeauthTargeted, ClientMAC: "aa:bb:cc:dd:ee:ff", Channel: 6, Interface: "wlan0"})
+	endless.Operator = "alice"
+	wps := domain.NewActiveAttack(domain.AttackKindWPS, "job-2", "66:77:88:99:aa:bb", "wlan0", 11, domain.WPSAttackConfig{TargetBSSID: "66:77:88:99:aa:bb"})
+	store := &memoryActiveStore{attacks: map[string]domain.ActiveAttack{"job-1": endless, "job-2": wps}}
+	svc.SetActiveAttackStore(store)
+
+	mockDeauth.On("StartAttack", mock.Anything, mock.Anything).Return("job-3", nil)
+	svc.RecoverAttacks(context.Background())
+
+	// The endless deauth runs again on behalf of its operator
+	mockDeauth.AssertNumberOfCalls(t, "StartAttack", 1)
+	id, operator, ok := svc.AttackOn("00:11:22:33:44:55")
+	assert.True(t, ok)
+	assert.Equal(t, "job-3", id)
+	assert.Equal(t, "alice", operator)
+	assert.Len(t, store.attacks, 1)
+	assert.Contains(t, store.attacks, "job-3")
+
+	// The WPS attack cannot resume and is recorded as aborted
+	if assert.Len(t, history.records, 1) {
+		assert.Equal(t, "job-2", history.records[0].ID)
+		assert.Equal(t, domain.AttackStatusAborted, history.records[0].Status)
+	}
+}
+
+func TestStartDeauthAttack_RefusesPMFRequired(t *testing.T) {
+	reg := registry.NewDeviceRegistry(nil, nil)
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), nil, nil, nil)
+	mockDeauth := new(MockDeauthService)
+	svc.SetDeauthEngine(mockDeauth)
+
+	reg.ProcessDevice(context.Background(), domain.Device{
+		MAC: "AA:BB:CC:DD:EE:01", Type: "ap", Channel: 6,
+		RSNInfo: &domain.RSNInfo{Capabilities: domain.RSNCapabilities{MFPCapable: true, MFPRequired: true}},
+	})
+
+	_, err := svc.StartDeauthAttack(context.Background(), domain.DeauthAttackConfig{TargetMAC: "AA:BB:CC:DD:EE:01", AttackType: domain.DeauthBroadcast, Channel: 6})
+	assert.ErrorIs(t, err, domain.ErrPMFProtected)
+	assert.Equal(t, domain.CodePMFProtected, domain.ErrorCodeOf(err))
+	mockDeauth.AssertNotCalled(t, "StartAttack", mock.Anything, mock.Anything)
+}
+
 func TestStartDeauthAttack_SmartTargeting(t *testing.T) {
 	reg := registry.NewDeviceRegistry(nil, nil)
 	sec := security.NewSecurityEngine(reg)
@@ -459,6 +577,158 @@ func TestStartDeauthAttack_SmartTargeting(t *testing.T) {
 	mockDeauth.AssertExpectations(t)
 }
 
+// staticScope is an in-memory engagement scope store
+type staticScope struct {
+	scope domain.EngagementScope
+}
+
+func (s *staticScope) GetScope(ctx context.Context) (domain.EngagementScope, error) {
+	return s.scope, nil
+}
+
+func (s *staticScope) SaveScope(ctx context.Context, scope domain.EngagementScope) error {
+	s.scope = scope
+	return nil
+}
+
+func TestStartDeauthAttack_ScopeEnforcement(t *testing.T) {
+	reg := registry.NewDeviceRegistry(nil, nil)
+	sec := security.NewSecurityEngine(reg)
+	mockAudit := new(MockAuditService)
+	svc := NewNetworkService(reg, sec, nil, nil, mockAudit)
+	mockDeauth := new(MockDeauthService)
+	svc.SetDeauthEngine(mockDeauth)
+	svc.attackCoordinator.SetScopeStore(&staticScope{scope: domain.EngagementScope{SSIDs: []string{"CorpNet"}}})
+
+	inScope := "AA:BB:CC:DD:EE:01"
+	outOfScope := "AA:BB:CC:DD:EE:02"
+	reg.ProcessDevice(context.Background(), domain.Device{MAC: inScope, Type: "ap", SSID: "CorpNet", Channel: 6})
+	reg.ProcessDevice(context.Background(), domain.Device{MAC: outOfScope, Type: "ap", SSID: "Neighbour", Channel: 6})
+
+	// Out of scope: refused and audited, engine never called
+	mockAudit.On("Log", mock.Anything, domain.ActionScopeDenied, outOfScope, mock.Anything).Return(nil).Once()
+	_, err := svc.StartDeauthAttack(context.Background(), domain.DeauthAttackConfig{TargetMAC: outOfScope, AttackType: domain.DeauthBroadcast, Channel: 6})
+	assert.ErrorIs(t, err, domain.ErrOutOfScope)
+	mockDeauth.AssertNotCalled(t, "StartAttack", mock.Anything, mock.Anything)
+
+	// In scope through its SSID
+	mockAudit.On("Log", mock.Anything, domain.ActionDeauthStart, inScope, mock.Anything).Return(nil)
+	mockDeauth.On("StartAttack", mock.Anything, mock.Anything).Return("job-1", nil)
+	id, err := svc.StartDeauthAttack(context.Background(), domain.DeauthAttackConfig{TargetMAC: inScope, AttackType: domain.DeauthBroadcast, Channel: 6})
+	assert.NoError(t, err)
+	assert.Equal(t, "job-1", id)
+	mockAudit.AssertExpectations(t)
+}
+
+// memoryROE is an in-memory rules of engagement store
+type memoryROE struct {
+	records []domain.RulesOfEngagement
+}
+
+func (s *memoryROE) SaveROE(ctx context.Context, roe *domain.RulesOfEngagement) error {
+	if roe.ID == 0 {
+		roe.ID = uint(len(s.records) + 1)
+		s.records = append(s.records, *roe)
+	} else {
+		s.records[roe.ID-1] = *roe
+	}
+	return nil
+}
+
+func (s *memoryROE) LatestROE(ctx context.Context) (*domain.RulesOfEngagement, error) {
+	if len(s.records) == 0 {
+		return nil, nil
+	}
+	roe := s.records[len(s.records)-1]
+	return &roe, nil
+}
+
+func (s *memoryROE) ListROE(ctx context.Context) ([]domain.RulesOfEngagement, error) {
+	return s.records, nil
+}
+
+func TestStartDeauthAttack_RequiresROE(t *testing.T) {
+	reg := registry.NewDeviceRegistry(nil, nil)
+	mockAudit := new(MockAuditService)
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), nil, nil, mockAudit)
+	mockDeauth := new(MockDeauthService)
+	svc.SetDeauthEngine(mockDeauth)
+	scope := &staticScope{}
+	roes := &memoryROE{}
+	svc.attackCoordinator.SetScopeStore(scope)
+	svc.SetROEStore(roes, []byte("roe-key"))
+
+	target := "AA:BB:CC:DD:EE:01"
+	config := domain.DeauthAttackConfig{TargetMAC: target, AttackType: domain.DeauthBroadcast, Channel: 6}
+	operator := domain.ContextWithUser(context.Background(), &domain.User{Username: "alice", Role: domain.RoleOperator})
+	mockAudit.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockDeauth.On("StartAttack", mock.Anything, mock.Anything).Return("job-1", nil)
+
+	// Nothing signed yet
+	_, err := svc.StartDeauthAttack(operator, config)
+	assert.ErrorIs(t, err, domain.ErrNoROE)
+
+	_, err = svc.SignROE(context.Background(), domain.RulesOfEngagement{Acknowledgment: "Authorized", Start: time.Now(), End: time.Now().Add(time.Hour)})
+	assert.ErrorIs(t, err, domain.ErrInvalidROE, "unsigned by an operator")
+
+	roe, err := svc.SignROE(operator, domain.RulesOfEngagement{Acknowledgment: "Authorized by ACME", Start: time.Now().Add(-time.Minute), End: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", roe.Operator)
+	assert.True(t, roe.Intact([]byte("roe-key")))
+	assert.False(t, roe.Intact([]byte("other-key")), "sealed with the key")
+
+	_, err = svc.StartDeauthAttack(operator, config)
+	assert.NoError(t, err)
+
+	// Widening the scope calls for signing again
+	scope.scope = domain.EngagementScope{SSIDs: []string{"CorpNet"}}
+	_, err = svc.StartDeauthAttack(operator, config)
+	assert.ErrorIs(t, err, domain.ErrNoROE)
+	scope.scope = domain.EngagementScope{}
+
+	// Tampering breaks the digest
+	roes.records[0].End = roes.records[0].End.Add(24 * time.Hour)
+	_, err = svc.StartDeauthAttack(operator, config)
+	assert.ErrorIs(t, err, domain.ErrNoROE)
+
+	// Revoking stops the running attacks
+	mockDeauth.On("StopAll", mock.Anything).Return()
+	_, err = svc.SignROE(operator, domain.RulesOfEngagement{Acknowledgment: "Authorized by ACME", Start: time.Now(), End: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.NoError(t, svc.RevokeROE(operator))
+	mockDeauth.AssertNumberOfCalls(t, "StopAll", 1)
+	_, err = svc.StartDeauthAttack(operator, config)
+	assert.ErrorIs(t, err, domain.ErrNoROE)
+	mockDeauth.AssertNumberOfCalls(t, "StartAttack", 1)
+}
+
+func TestStartDeauthAttack_StoppedWhenROEEnds(t *testing.T) {
+	reg := registry.NewDeviceRegistry(nil, nil)
+	mockAudit := new(MockAuditService)
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), nil, nil, mockAudit)
+	mockDeauth := new(MockDeauthService)
+	svc.SetDeauthEngine(mockDeauth)
+	svc.attackCoordinator.SetScopeStore(&staticScope{})
+	svc.SetROEStore(&memoryROE{}, []byte("roe-key"))
+
+	operator := domain.ContextWithUser(context.Background(), &domain.User{Username: "alice", Role: domain.RoleOperator})
+	mockAudit.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockDeauth.On("StartAttack", mock.Anything, mock.Anything).Return("job-1", nil)
+	stopped := make(chan struct{}, 1)
+	mockDeauth.On("StopAll", mock.Anything).Run(func(mock.Arguments) { stopped <- struct{}{} }).Return()
+
+	_, err := svc.SignROE(operator, domain.RulesOfEngagement{Acknowledgment: "Authorized by ACME", Start: time.Now().Add(-time.Minute), End: time.Now().Add(100 * time.Millisecond)})
+	require.NoError(t, err)
+	_, err = svc.StartDeauthAttack(operator, domain.DeauthAttackConfig{TargetMAC: "AA:BB:CC:DD:EE:01", AttackType: domain.DeauthBroadcast, Channel: 6})
+	require.NoError(t, err)
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("attacks were not stopped when the window ended")
+	}
+}
+
 func TestStartDeauthAttack_AutoChannels(t *testing.T) {
 	reg := registry.NewDeviceRegistry(nil, nil)
 	sec := security.NewSecurityEngine(reg)
@@ -0,0 +1,388 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/lcalzada-xor/wmap/internal/adapters/sniffer/pcapng"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+const (
+	// spoofedSeqGap is the distance from the AP's beacons above which a frame
+	// claiming to come from the AP is considered injected.
+	spoofedSeqGap = 256
+	// maxEvidenceFrames bounds the frames kept per incident for the evidence capture.
+	maxEvidenceFrames = 500
+	// protectedRefresh bounds how long the cached protected BSSIDs are
+	// trusted, so the monitor follows workspace switches without a storage
+	// read per frame.
+	protectedRefresh = 10 * time.Second
+)
+
+// protectedIncident aggregates the frames of one attacker against a protected BSSID.
+type protectedIncident struct {
+	first     domain.Alert
+	protected domain.ProtectedBSSID
+	last      time.Time
+	frames    int
+	targets   map[string]struct{}
+	seqs      map[int]struct{}
+	minSeq    int
+	maxSeq    int
+	seqKnown  int // Frames whose sequence could be compared with the AP's beacons
+	spoofed   int // Frames far from the AP's beacon sequence
+	evidence  []domain.Alert
+	published bool
+}
+
+// ProtectedMonitor watches the deauthentication, disassociation and channel
+// switch frames reported by the sensors and raises a high-severity alert,
+// with an analysis of the attacker, when they target a protected BSSID.
+// The offending frames can be saved as a pcapng artifact for evidence.
+type ProtectedMonitor struct {
+	window     time.Duration
+	expiry     time.Duration
+	publisher  func(domain.Alert)
+	evidence   ports.ArtifactManager
+	captureCtx domain.CaptureContextFunc
+	store      ports.ProtectedBSSIDRepository // Optional, set when the list is kept per workspace
+
+	protected map[string]domain.ProtectedBSSID
+	loadedAt  time.Time
+	incidents map[string]*protectedIncident
+	mu        sync.Mutex
+}
+
+// NewProtectedMonitor creates a monitor that collects the frames of an attack
+// for window before raising it and forgets an attacker after expiry without frames.
+func NewProtectedMonitor(window, expiry time.Duration) *ProtectedMonitor {
+	if window <= 0 {
+		window = DefaultDeauthCorrelationWindow
+	}
+	if expiry <= window {
+		expiry = DefaultDeauthIncidentExpiry
+	}
+	return &ProtectedMonitor{
+		window:    window,
+		expiry:    expiry,
+		protected: make(map[string]domain.ProtectedBSSID),
+		incidents: make(map[string]*protectedIncident),
+	}
+}
+
+// SetPublisher sets the callback receiving the alerts to raise.
+func (m *ProtectedMonitor) SetPublisher(publisher func(domain.Alert)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.publisher = publisher
+}
+
+// SetEvidenceStore sets the artifact store evidence captures are saved to.
+func (m *ProtectedMonitor) SetEvidenceStore(store ports.ArtifactManager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evidence = store
+}
+
+// SetCaptureContext sets the function returning the attribution recorded in
+// the comments of evidence captures.
+func (m *ProtectedMonitor) SetCaptureContext(fn domain.CaptureContextFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.captureCtx = fn
+}
+
+// SetStore sets the storage holding the protected BSSIDs of the workspace.
+// The list then follows the active workspace.
+func (m *ProtectedMonitor) SetStore(store ports.ProtectedBSSIDRepository) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+	m.loadedAt = time.Time{}
+}
+
+// refresh reloads the protected BSSIDs from the store when the cache is
+// older than protectedRefresh, or always with force. Caller holds m.mu.
+func (m *ProtectedMonitor) refresh(ctx context.Context, force bool) error {
+	if m.store == nil {
+		return nil
+	}
+	if !force && time.Since(m.loadedAt) < protectedRefresh {
+		return nil
+	}
+
+	list, err := m.store.ListProtectedBSSIDs(ctx)
+	if err != nil {
+		return err
+	}
+	m.protected = make(map[string]domain.ProtectedBSSID, len(list))
+	for _, p := range list {
+		m.protected[p.BSSID] = p
+	}
+	m.loadedAt = time.Now()
+	return nil
+}
+
+// Add validates and protects a BSSID, returning the stored copy.
+func (m *ProtectedMonitor) Add(ctx context.Context, p domain.ProtectedBSSID) (domain.ProtectedBSSID, error) {
+	if err := p.Validate(); err != nil {
+		return domain.ProtectedBSSID{}, err
+	}
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.store != nil {
+		if err := m.store.SaveProtectedBSSID(ctx, p); err != nil {
+			return domain.ProtectedBSSID{}, err
+		}
+	}
+	m.protected[p.BSSID] = p
+	return p, nil
+}
+
+// Remove stops protecting a BSSID. It returns domain.ErrProtectedBSSIDNotFound
+// if it was not protected.
+func (m *ProtectedMonitor) Remove(ctx context.Context, bssid string) error {
+	bssid = strings.ToLower(bssid)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.refresh(ctx, true); err != nil {
+		return err
+	}
+	if _, ok := m.protected[bssid]; !ok {
+		return domain.ErrProtectedBSSIDNotFound
+	}
+	if m.store != nil {
+		if err := m.store.DeleteProtectedBSSID(ctx, bssid); err != nil {
+			return err
+		}
+	}
+	delete(m.protected, bssid)
+	return nil
+}
+
+// List returns the protected BSSIDs ordered by creation time.
+func (m *ProtectedMonitor) List(ctx context.Context) []domain.ProtectedBSSID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.refresh(ctx, true); err != nil {
+		log.Printf("Warning: could not load protected BSSIDs: %v", err)
+	}
+	result := make([]domain.ProtectedBSSID, 0, len(m.protected))
+	for _, p := range m.protected {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// Inspect checks an alert raised by a sensor against the protected BSSIDs.
+func (m *ProtectedMonitor) Inspect(alert domain.Alert) {
+	if correlatedKind(alert) == "" {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// On a load error keep the cached list and retry after the refresh interval
+	if err := m.refresh(context.Background(), false); err != nil {
+		m.loadedAt = time.Now()
+	}
+	protected, ok := m.match(alert)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	key := protected.BSSID + "|" + correlatedKind(alert) + "|" + strings.ToLower(alert.DeviceMAC)
+	inc, ok := m.incidents[key]
+	if !ok || now.Sub(inc.last) > m.expiry {
+		m.sweep(now)
+		inc = &protectedIncident{
+			first:     alert,
+			protected: protected,
+			targets:   make(map[string]struct{}),
+			seqs:      make(map[int]struct{}),
+			minSeq:    alert.Sequence,
+			maxSeq:    alert.Sequence,
+		}
+		m.incidents[key] = inc
+		time.AfterFunc(m.window, func() { m.flush(inc) })
+	}
+
+	inc.last = now
+	inc.frames++
+	if alert.TargetMAC != "" {
+		inc.targets[strings.ToLower(alert.TargetMAC)] = struct{}{}
+	}
+	if alert.Frame != nil {
+		inc.seqs[alert.Sequence] = struct{}{}
+		inc.minSeq = min(inc.minSeq, alert.Sequence)
+		inc.maxSeq = max(inc.maxSeq, alert.Sequence)
+	}
+	if alert.SeqGap > 0 {
+		inc.seqKnown++
+		if alert.SeqGap > spoofedSeqGap {
+			inc.spoofed++
+		}
+	}
+	if protected.CaptureEvidence && alert.Frame != nil && len(inc.evidence) < maxEvidenceFrames {
+		inc.evidence = append(inc.evidence, alert)
+	}
+}
+
+// match returns the protected BSSID an alert targets. Caller holds m.mu.
+func (m *ProtectedMonitor) match(alert domain.Alert) (domain.ProtectedBSSID, bool) {
+	for _, mac := range []string{alert.BSSID, alert.TargetMAC, alert.DeviceMAC} {
+		if p, ok := m.protected[strings.ToLower(mac)]; ok {
+			return p, true
+		}
+	}
+	return domain.ProtectedBSSID{}, false
+}
+
+// flush raises the alert of an incident once the collection window has elapsed.
+func (m *ProtectedMonitor) flush(inc *protectedIncident) {
+	m.mu.Lock()
+	if inc.published {
+		m.mu.Unlock()
+		return
+	}
+	inc.published = true
+	alert := inc.build()
+	frames := inc.evidence
+	inc.evidence = nil
+	store, publisher, captureCtx := m.evidence, m.publisher, m.captureCtx
+	m.mu.Unlock()
+
+	if len(frames) > 0 && store != nil {
+		comments := []string{"protected bssid: " + inc.protected.BSSID, alert.Message}
+		if captureCtx != nil {
+			comments = append(comments, captureCtx(inc.protected.BSSID).Comments()...)
+		}
+		if id, err := saveEvidence(store, inc.protected.BSSID, frames, comments); err != nil {
+			log.Printf("Warning: could not save evidence for protected BSSID %s: %v", inc.protected.BSSID, err)
+		} else {
+			alert.EvidenceID = id
+		}
+	}
+	if publisher != nil {
+		publisher(alert)
+	}
+}
+
+// sweep drops incidents that have been silent longer than the expiry.
+// Caller must hold m.mu.
+func (m *ProtectedMonitor) sweep(now time.Time) {
+	for key, inc := range m.incidents {
+		if inc.published && now.Sub(inc.last) > m.expiry {
+			delete(m.incidents, key)
+		}
+	}
+}
+
+// build assembles the alert of an incident. Caller must hold the monitor lock.
+func (inc *protectedIncident) build() domain.Alert {
+	alert := inc.first
+	alert.ID = fmt.Sprintf("alt_%d", time.Now().UnixNano())
+	alert.Type = domain.AlertAnomaly
+	alert.Subtype = "PROTECTED_BSSID_ATTACK"
+	alert.Severity = domain.SeverityHigh
+	alert.TargetMAC = inc.protected.BSSID
+	alert.BSSID = inc.protected.BSSID
+	alert.Frame = nil
+
+	name := inc.protected.BSSID
+	if inc.protected.Label != "" {
+		name = fmt.Sprintf("%s (%s)", inc.protected.Label, inc.protected.BSSID)
+	}
+	what := "Deauthentication/disassociation"
+	if correlatedKind(inc.first) == "csa" {
+		what = "Channel switch announcement"
+	}
+	alert.Message = fmt.Sprintf("%s targeting protected BSSID %s from %s", what, name, alert.DeviceMAC)
+
+	details := []string{
+		fmt.Sprintf("Frames: %d", inc.frames),
+		fmt.Sprintf("Targets: %d", len(inc.targets)),
+		"Attacker: " + inc.attacker(),
+	}
+	if len(inc.seqs) > 0 {
+		details = append(details, fmt.Sprintf("Sequence: %d-%d (%d distinct)", inc.minSeq, inc.maxSeq, len(inc.seqs)))
+	}
+	if inc.seqKnown > 0 {
+		details = append(details, fmt.Sprintf("Out of AP sequence: %d/%d", inc.spoofed, inc.seqKnown))
+	}
+	alert.Details = strings.Join(details, ", ")
+	return alert
+}
+
+// attacker describes the transmitter of the offending frames. Frames sent
+// with the AP's own address are spoofed when their sequence numbers do not
+// follow the AP's beacons.
+func (inc *protectedIncident) attacker() string {
+	source := strings.ToLower(inc.first.DeviceMAC)
+	switch {
+	case source != inc.protected.BSSID:
+		return "third-party transmitter " + source
+	case inc.spoofed > 0:
+		return "spoofing the AP address"
+	case inc.seqKnown > 0:
+		return "AP address, sequence consistent with the AP"
+	default:
+		return "AP address, unverified"
+	}
+}
+
+// saveEvidence writes the offending frames to a pcapng artifact, with the
+// incident described in the section comments, and returns its ID. Each
+// frame is commented with the sensor that reported it and geo-tagged with
+// its position when known.
+func saveEvidence(store ports.ArtifactManager, bssid string, frames []domain.Alert, comments []string) (string, error) {
+	var buf bytes.Buffer
+	w, err := pcapng.NewWriter(&buf, pcapng.Section{Application: "wmap", OS: runtime.GOOS, Comments: comments})
+	if err != nil {
+		return "", err
+	}
+	ifID, err := w.AddInterface(pcapng.Interface{LinkType: layers.LinkTypeIEEE80211Radio})
+	if err != nil {
+		return "", err
+	}
+	for _, f := range frames {
+		ci := gopacket.CaptureInfo{Timestamp: f.Timestamp, CaptureLength: len(f.Frame), Length: len(f.Frame)}
+		var opts pcapng.PacketOptions
+		if f.Sensor != "" {
+			opts.Comments = []string{"sensor: " + f.Sensor}
+		}
+		if f.Latitude != 0 || f.Longitude != 0 {
+			opts.GPS = &pcapng.GPS{Latitude: f.Latitude, Longitude: f.Longitude}
+		}
+		if err := w.WritePacket(ifID, ci, f.Frame, opts); err != nil {
+			return "", err
+		}
+	}
+
+	name := fmt.Sprintf("evidence_%s_%d.pcapng", strings.ReplaceAll(bssid, ":", ""), time.Now().Unix())
+	artifact, err := store.StoreArtifact(context.Background(), domain.ArtifactPcap, name, buf.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return artifact.ID, nil
+}
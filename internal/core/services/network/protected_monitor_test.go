@@ -0,0 +1,189 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket/pcapgo"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// evidenceStore records the artifacts stored by the monitor.
+type evidenceStore struct {
+	ports.ArtifactManager
+	mu     sync.Mutex
+	stored map[string][]byte
+}
+
+func (s *evidenceStore) StoreArtifact(ctx context.Context, kind domain.ArtifactKind, name string, data []byte) (domain.Artifact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stored[name] = data
+	return domain.Artifact{ID: "art-1", Kind: kind, Name: name}, nil
+}
+
+func TestProtectedMonitor_SpoofedDeauth(t *testing.T) {
+	alerts := make(chan domain.Alert, 4)
+	store := &evidenceStore{stored: make(map[string][]byte)}
+	m := NewProtectedMonitor(20*time.Millisecond, time.Minute)
+	m.SetPublisher(func(a domain.Alert) { alerts <- a })
+	m.SetEvidenceStore(store)
+	m.SetCaptureContext(func(bssid string) domain.CaptureContext {
+		return domain.CaptureContext{Workspace: "office"}
+	})
+
+	_, err := m.Add(context.Background(), domain.ProtectedBSSID{BSSID: "00:11:22:33:44:55", Label: "HQ", CaptureEvidence: true})
+	require.NoError(t, err)
+	_, err = m.Add(context.Background(), domain.ProtectedBSSID{BSSID: "bogus"})
+	assert.ErrorIs(t, err, domain.ErrInvalidMAC)
+
+	// Deauths forged with the AP's address, far from its beacon sequence
+	for i := 0; i < 5; i++ {
+		m.Inspect(domain.Alert{
+			Type:      domain.AlertAnomaly,
+			Subtype:   "DEAUTH_DETECTED",
+			DeviceMAC: "00:11:22:33:44:55",
+			TargetMAC: "aa:bb:cc:dd:ee:ff",
+			BSSID:     "00:11:22:33:44:55",
+			Sequence:  1000 + i,
+			SeqGap:    900,
+			Frame:     []byte{0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0, 0x00},
+			Timestamp: time.Now(),
+			Sensor:    "wlan0",
+			Latitude:  40.4,
+			Longitude: -3.7,
+		})
+	}
+	// Unprotected networks are ignored
+	m.Inspect(domain.Alert{Type: domain.AlertAnomaly, Subtype: "DEAUTH_DETECTED", DeviceMAC: "66:77:88:99:aa:bb", BSSID: "66:77:88:99:aa:bb"})
+
+	select {
+	case alert := <-alerts:
+		assert.Equal(t, "PROTECTED_BSSID_ATTACK", alert.Subtype)
+		assert.Equal(t, domain.SeverityHigh, alert.Severity)
+		assert.Equal(t, "00:11:22:33:44:55", alert.TargetMAC)
+		assert.Contains(t, alert.Message, "HQ")
+		assert.Contains(t, alert.Details, "Frames: 5")
+		assert.Contains(t, alert.Details, "spoofing the AP address")
+		assert.Contains(t, alert.Details, "Sequence: 1000-1004 (5 distinct)")
+		assert.Contains(t, alert.Details, "Out of AP sequence: 5/5")
+		assert.Equal(t, "art-1", alert.EvidenceID)
+		assert.Nil(t, alert.Frame)
+	case <-time.After(time.Second):
+		t.Fatal("alert not raised")
+	}
+
+	select {
+	case alert := <-alerts:
+		t.Fatalf("unexpected alert: %+v", alert)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	require.Len(t, store.stored, 1)
+	for name, data := range store.stored {
+		assert.Contains(t, name, "evidence_001122334455_")
+		assert.Contains(t, name, ".pcapng")
+		assert.True(t, bytes.Contains(data, []byte("protected bssid: 00:11:22:33:44:55")))
+		assert.True(t, bytes.Contains(data, []byte("workspace: office")))
+		assert.True(t, bytes.Contains(data, []byte("sensor: wlan0")))
+
+		r, err := pcapgo.NewNgReader(bytes.NewReader(data), pcapgo.DefaultNgReaderOptions)
+		require.NoError(t, err)
+		frames := 0
+		for {
+			if _, _, err := r.ReadPacketData(); err != nil {
+				break
+			}
+			frames++
+		}
+		assert.Equal(t, 5, frames)
+	}
+}
+
+func TestProtectedMonitor_ThirdPartyCSA(t *testing.T) {
+	alerts := make(chan domain.Alert, 1)
+	m := NewProtectedMonitor(10*time.Millisecond, time.Minute)
+	m.SetPublisher(func(a domain.Alert) { alerts <- a })
+	_, err := m.Add(context.Background(), domain.ProtectedBSSID{BSSID: "00:11:22:33:44:55"})
+	require.NoError(t, err)
+
+	m.Inspect(domain.Alert{Type: domain.AlertAnomaly, Subtype: "CSA_DETECTED", DeviceMAC: "de:ad:be:ef:00:01", BSSID: "00:11:22:33:44:55"})
+
+	select {
+	case alert := <-alerts:
+		assert.Contains(t, alert.Message, "Channel switch announcement")
+		assert.Contains(t, alert.Details, "third-party transmitter de:ad:be:ef:00:01")
+		assert.Empty(t, alert.EvidenceID, "evidence capture not requested")
+	case <-time.After(time.Second):
+		t.Fatal("alert not raised")
+	}
+
+	assert.NoError(t, m.Remove(context.Background(), "00:11:22:33:44:55"))
+	assert.ErrorIs(t, m.Remove(context.Background(), "00:11:22:33:44:55"), domain.ErrProtectedBSSIDNotFound)
+	assert.Empty(t, m.List(context.Background()))
+}
+
+// protectedStore is an in-memory protected BSSID repository of one workspace.
+type protectedStore struct {
+	protected map[string]domain.ProtectedBSSID
+}
+
+func (s *protectedStore) ListProtectedBSSIDs(ctx context.Context) ([]domain.ProtectedBSSID, error) {
+	var result []domain.ProtectedBSSID
+	for _, p := range s.protected {
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+func (s *protectedStore) SaveProtectedBSSID(ctx context.Context, protected domain.ProtectedBSSID) error {
+	s.protected[protected.BSSID] = protected
+	return nil
+}
+
+func (s *protectedStore) DeleteProtectedBSSID(ctx context.Context, bssid string) error {
+	delete(s.protected, bssid)
+	return nil
+}
+
+// switchingStore stands for the persistence manager, whose storage is the
+// active workspace's.
+type switchingStore struct {
+	ports.ProtectedBSSIDRepository
+}
+
+func TestProtectedMonitor_PersistedPerWorkspace(t *testing.T) {
+	ctx := context.Background()
+	office := &protectedStore{protected: map[string]domain.ProtectedBSSID{}}
+	lab := &protectedStore{protected: map[string]domain.ProtectedBSSID{}}
+	active := &switchingStore{office}
+
+	m := NewProtectedMonitor(10*time.Millisecond, time.Minute)
+	m.SetStore(active)
+	_, err := m.Add(ctx, domain.ProtectedBSSID{BSSID: "00:11:22:33:44:55", Label: "HQ"})
+	require.NoError(t, err)
+	assert.Contains(t, office.protected, "00:11:22:33:44:55", "saved to the active workspace")
+
+	// A restarted monitor reloads the list
+	restarted := NewProtectedMonitor(10*time.Millisecond, time.Minute)
+	restarted.SetStore(active)
+	require.Len(t, restarted.List(ctx), 1)
+	assert.Equal(t, "HQ", restarted.List(ctx)[0].Label)
+
+	// Switching workspace switches the list
+	active.ProtectedBSSIDRepository = lab
+	assert.Empty(t, m.List(ctx))
+	assert.ErrorIs(t, m.Remove(ctx, "00:11:22:33:44:55"), domain.ErrProtectedBSSIDNotFound)
+
+	active.ProtectedBSSIDRepository = office
+	require.NoError(t, m.Remove(ctx, "00:11:22:33:44:55"))
+	assert.Empty(t, office.protected)
+}
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	reg "github.com/lcalzada-xor/wmap/internal/core/services/registry"
+)
+
+// activeClientWindow is how recently a client must have been seen to count
+// towards the score of its AP: only those around can be deauthenticated.
+const activeClientWindow = 5 * time.Minute
+
+// scoredTarget is an AP with its score.
+type scoredTarget struct {
+	device domain.Device
+	score  domain.TargetScore
+}
+
+// PrioritizeTargets ranks the APs in the engagement scope by attack value,
+// best first. A limit of 0 returns them all.
+func (s *NetworkService) PrioritizeTargets(ctx context.Context, limit int) ([]domain.TargetScore, error) {
+	targets, err := s.rankTargets(ctx, func(domain.Device) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(targets) > limit {
+		targets = targets[:limit]
+	}
+
+	scores := make([]domain.TargetScore, len(targets))
+	for i, t := range targets {
+		scores[i] = t.score
+	}
+	return scores, nil
+}
+
+// rankTargets scores the APs in scope that eligible accepts, best first and
+// the strongest first among equals.
+func (s *NetworkService) rankTargets(ctx context.Context, eligible func(domain.Device) bool) ([]scoredTarget, error) {
+	scope, err := s.attackCoordinator.GetScope(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("engagement scope unavailable: %w", err)
+	}
+
+	devices := s.registry.GetAllDevices(ctx)
+	clients := reg.CountActiveClients(devices, activeClientWindow)
+
+	var targets []scoredTarget
+	for _, device := range devices {
+		if !device.IsAP() || !scope.Allows(device.MAC, device.SSID) || !eligible(device) {
+			continue
+		}
+		targets = append(targets, scoredTarget{device, domain.ScoreTarget(device, clients[device.MAC])})
+	}
+
+	sort.SliceStable(targets, func(i, j int) bool {
+		if targets[i].score.Score != targets[j].score.Score {
+			return targets[i].score.Score > targets[j].score.Score
+		}
+		return targets[i].device.RSSI > targets[j].device.RSSI
+	})
+	return targets, nil
+}
@@ -0,0 +1,42 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/services/registry"
+	"github.com/lcalzada-xor/wmap/internal/core/services/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrioritizeTargets(t *testing.T) {
+	ctx := context.Background()
+	reg := registry.NewDeviceRegistry(nil, nil)
+	svc := NewNetworkService(reg, security.NewSecurityEngine(reg), nil, nil, nil)
+	svc.attackCoordinator.SetScopeStore(&staticScope{scope: domain.EngagementScope{SSIDs: []string{"CorpNet", "Guest"}}})
+
+	psk := &domain.RSNInfo{AKMSuites: []string{"PSK"}}
+	for _, d := range []domain.Device{
+		{MAC: "aa:bb:cc:00:00:01", Type: domain.DeviceTypeAP, SSID: "Guest", RSSI: -40},
+		{MAC: "aa:bb:cc:00:00:02", Type: domain.DeviceTypeAP, SSID: "CorpNet", RSSI: -70, RSNInfo: psk},
+		{MAC: "aa:bb:cc:00:00:03", Type: domain.DeviceTypeAP, SSID: "Neighbor", RSSI: -30, RSNInfo: psk, WPSInfo: "Configured"},
+		{MAC: "11:22:33:00:00:01", Type: domain.DeviceTypeStation, ConnectionTarget: "aa:bb:cc:00:00:02", ConnectionState: domain.StateConnected},
+	} {
+		d.LastSeen = time.Now()
+		reg.ProcessDevice(ctx, d)
+	}
+
+	targets, err := svc.PrioritizeTargets(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, targets, 2, "APs out of scope are not ranked")
+	assert.Equal(t, "aa:bb:cc:00:00:02", targets[0].BSSID)
+	assert.Equal(t, 1, targets[0].Clients)
+	assert.Greater(t, targets[0].Score, targets[1].Score)
+
+	targets, err = svc.PrioritizeTargets(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, targets, 1)
+}
@@ -10,13 +10,19 @@ import (
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
 )
 
+// DefaultFlushInterval is the time between two writes of queued devices.
+const DefaultFlushInterval = 5 * time.Second
+
 // PersistenceManager handles background batch writing of devices to storage.
 type PersistenceManager struct {
 	storage     ports.Storage
+	router      ports.WorkspaceRouter // Optional, sends devices of routed sensors to other open workspaces
 	persistChan chan domain.Device
 	batchSize   int
 	interval    time.Duration
+	resetTicker chan struct{} // Signals an interval change to the flush loop
 	enabled     bool
+	forgotten   map[string]time.Time // Deleted devices, by MAC, so queued copies are not written back
 	mu          sync.RWMutex
 }
 
@@ -26,8 +32,10 @@ func NewPersistenceManager(storage ports.Storage, bufferSize int) *PersistenceMa
 		storage:     storage,
 		persistChan: make(chan domain.Device, bufferSize),
 		batchSize:   100,
-		interval:    5 * time.Second,
+		interval:    DefaultFlushInterval,
+		resetTicker: make(chan struct{}, 1),
 		enabled:     true, // Enabled by default
+		forgotten:   make(map[string]time.Time),
 	}
 }
 
@@ -68,9 +76,53 @@ func (p *PersistenceManager) SetStorage(storage ports.Storage) {
 	p.storage = storage
 }
 
+// SetRouter sets the router sending the devices heard by some sensors to
+// workspaces open besides the active one.
+func (p *PersistenceManager) SetRouter(router ports.WorkspaceRouter) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.router = router
+}
+
+// route returns the workspace a device goes to and its storage, an empty
+// name and the active storage without a route.
+func (p *PersistenceManager) route(device domain.Device) (string, ports.Storage) {
+	p.mu.RLock()
+	router, active := p.router, p.storage
+	p.mu.RUnlock()
+	if router != nil {
+		if name, store := router.Route(device.Sensor); name != "" {
+			return name, store
+		}
+	}
+	return "", active
+}
+
+// SetFlushInterval changes the time between two writes of queued devices.
+// Longer intervals save power on battery sensors; a full batch is still
+// written right away.
+func (p *PersistenceManager) SetFlushInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	p.mu.Lock()
+	p.interval = interval
+	p.mu.Unlock()
+	select {
+	case p.resetTicker <- struct{}{}:
+	default:
+	}
+}
+
+func (p *PersistenceManager) flushInterval() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.interval
+}
+
 // Start begins the persistence loop.
 func (p *PersistenceManager) Start(ctx context.Context) {
-	ticker := time.NewTicker(p.interval)
+	ticker := time.NewTicker(p.flushInterval())
 	buffer := make(map[string]domain.Device)
 
 	go func() {
@@ -81,11 +133,15 @@ func (p *PersistenceManager) Start(ctx context.Context) {
 				p.flushBuffer(buffer)
 				return
 			case dev := <-p.persistChan:
-				buffer[dev.MAC] = dev
+				// A device heard by sensors of two workspaces is written to both
+				workspace, _ := p.route(dev)
+				buffer[workspace+"|"+dev.MAC] = dev
 				if len(buffer) >= p.batchSize {
 					p.flushBuffer(buffer)
 					buffer = make(map[string]domain.Device)
 				}
+			case <-p.resetTicker:
+				ticker.Reset(p.flushInterval())
 			case <-ticker.C:
 				if len(buffer) > 0 {
 					p.flushBuffer(buffer)
@@ -97,14 +153,283 @@ func (p *PersistenceManager) Start(ctx context.Context) {
 }
 
 func (p *PersistenceManager) flushBuffer(buffer map[string]domain.Device) {
-	if len(buffer) == 0 || p.storage == nil {
+	if len(buffer) == 0 {
 		return
 	}
 	var devices []domain.Device
+	p.mu.Lock()
 	for _, d := range buffer {
+		if at, ok := p.forgotten[d.MAC]; ok && !d.LastSeen.After(at) {
+			continue
+		}
 		devices = append(devices, d)
 	}
-	if err := p.storage.SaveDevicesBatch(context.Background(), devices); err != nil {
-		fmt.Printf("[DB-ERR] Failed to batch save devices: %v\n", err)
+	// Queued copies are flushed within a couple of intervals
+	for mac, at := range p.forgotten {
+		if time.Since(at) > 2*p.interval {
+			delete(p.forgotten, mac)
+		}
+	}
+	p.mu.Unlock()
+	if len(devices) == 0 {
+		return
+	}
+
+	// Routed at flush time: a workspace closed since falls back to the active one
+	batches := make(map[ports.Storage][]domain.Device)
+	for _, d := range devices {
+		if _, store := p.route(d); store != nil {
+			batches[store] = append(batches[store], d)
+		}
+	}
+	for store, batch := range batches {
+		if err := store.SaveDevicesBatch(context.Background(), batch); err != nil {
+			fmt.Printf("[DB-ERR] Failed to batch save devices: %v\n", err)
+		}
+	}
+}
+
+// DeleteDevice removes a device from the active workspace. Copies of it
+// still queued are dropped unless the device was seen again since.
+func (p *PersistenceManager) DeleteDevice(ctx context.Context, mac string) error {
+	p.mu.Lock()
+	p.forgotten[mac] = time.Now()
+	store, ok := p.storage.(ports.DeviceEraser)
+	p.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("storage does not support deleting devices")
+	}
+	return store.DeleteDevice(ctx, mac)
+}
+
+// historyStore returns the current storage if it keeps attack history.
+func (p *PersistenceManager) historyStore() (ports.AttackHistoryRepository, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	store, ok := p.storage.(ports.AttackHistoryRepository)
+	if !ok {
+		return nil, fmt.Errorf("storage does not support attack history")
+	}
+	return store, nil
+}
+
+// SaveAttackRecord writes an attack outcome to the active workspace.
+func (p *PersistenceManager) SaveAttackRecord(ctx context.Context, record domain.AttackRecord) error {
+	store, err := p.historyStore()
+	if err != nil {
+		return err
+	}
+	return store.SaveAttackRecord(ctx, record)
+}
+
+// ListAttackRecords returns the attack history of the active workspace.
+func (p *PersistenceManager) ListAttackRecords(ctx context.Context, limit int) ([]domain.AttackRecord, error) {
+	store, err := p.historyStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.ListAttackRecords(ctx, limit)
+}
+
+// GetScope returns the engagement scope of the active workspace. Storage
+// without scope support cannot hold one, so the scope is empty.
+func (p *PersistenceManager) GetScope(ctx context.Context) (domain.EngagementScope, error) {
+	p.mu.RLock()
+	store, ok := p.storage.(ports.ScopeRepository)
+	p.mu.RUnlock()
+	if !ok {
+		return domain.EngagementScope{}, nil
+	}
+	return store.GetScope(ctx)
+}
+
+// SaveScope replaces the engagement scope of the active workspace.
+func (p *PersistenceManager) SaveScope(ctx context.Context, scope domain.EngagementScope) error {
+	p.mu.RLock()
+	store, ok := p.storage.(ports.ScopeRepository)
+	p.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("storage does not support engagement scope")
+	}
+	return store.SaveScope(ctx, scope)
+}
+
+// roeStore returns the current storage if it keeps rules of engagement.
+func (p *PersistenceManager) roeStore() (ports.ROERepository, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	store, ok := p.storage.(ports.ROERepository)
+	if !ok {
+		return nil, fmt.Errorf("storage does not support rules of engagement")
+	}
+	return store, nil
+}
+
+// SaveROE records rules of engagement signed for the active workspace.
+func (p *PersistenceManager) SaveROE(ctx context.Context, roe *domain.RulesOfEngagement) error {
+	store, err := p.roeStore()
+	if err != nil {
+		return err
+	}
+	return store.SaveROE(ctx, roe)
+}
+
+// LatestROE returns the rules of engagement last signed for the active
+// workspace, nil if none were.
+func (p *PersistenceManager) LatestROE(ctx context.Context) (*domain.RulesOfEngagement, error) {
+	store, err := p.roeStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.LatestROE(ctx)
+}
+
+// ListROE returns every rules of engagement record of the active workspace.
+func (p *PersistenceManager) ListROE(ctx context.Context) ([]domain.RulesOfEngagement, error) {
+	store, err := p.roeStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.ListROE(ctx)
+}
+
+// artifactStore returns the current storage if it keeps an artifact registry.
+func (p *PersistenceManager) artifactStore() (ports.ArtifactRepository, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	store, ok := p.storage.(ports.ArtifactRepository)
+	if !ok {
+		return nil, fmt.Errorf("storage does not support artifacts")
+	}
+	return store, nil
+}
+
+// SaveArtifact registers an artifact in the active workspace.
+func (p *PersistenceManager) SaveArtifact(ctx context.Context, artifact domain.Artifact) error {
+	store, err := p.artifactStore()
+	if err != nil {
+		return err
+	}
+	return store.SaveArtifact(ctx, artifact)
+}
+
+// GetArtifact returns an artifact of the active workspace.
+func (p *PersistenceManager) GetArtifact(ctx context.Context, id string) (*domain.Artifact, error) {
+	store, err := p.artifactStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.GetArtifact(ctx, id)
+}
+
+// ListArtifacts returns the artifacts of the active workspace.
+func (p *PersistenceManager) ListArtifacts(ctx context.Context, filter domain.ArtifactFilter) ([]domain.Artifact, error) {
+	store, err := p.artifactStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.ListArtifacts(ctx, filter)
+}
+
+// DeleteArtifact removes an artifact from the registry of the active workspace.
+func (p *PersistenceManager) DeleteArtifact(ctx context.Context, id string) error {
+	store, err := p.artifactStore()
+	if err != nil {
+		return err
+	}
+	return store.DeleteArtifact(ctx, id)
+}
+
+// GetBaseline returns the baseline monitoring configuration of the active
+// workspace, disabled if the storage cannot hold one.
+func (p *PersistenceManager) GetBaseline(ctx context.Context) (domain.BaselineConfig, error) {
+	p.mu.RLock()
+	store, ok := p.storage.(ports.BaselineRepository)
+	p.mu.RUnlock()
+	if !ok {
+		return domain.BaselineConfig{}, nil
+	}
+	return store.GetBaseline(ctx)
+}
+
+// SaveBaseline replaces the baseline monitoring configuration of the active workspace.
+func (p *PersistenceManager) SaveBaseline(ctx context.Context, config domain.BaselineConfig) error {
+	p.mu.RLock()
+	store, ok := p.storage.(ports.BaselineRepository)
+	p.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("storage does not support baseline monitoring")
+	}
+	return store.SaveBaseline(ctx, config)
+}
+
+// ListProtectedBSSIDs returns the protected BSSIDs of the active workspace,
+// none if the storage cannot hold them.
+func (p *PersistenceManager) ListProtectedBSSIDs(ctx context.Context) ([]domain.ProtectedBSSID, error) {
+	p.mu.RLock()
+	store, ok := p.storage.(ports.ProtectedBSSIDRepository)
+	p.mu.RUnlock()
+	if !ok {
+		return nil, nil
+	}
+	return store.ListProtectedBSSIDs(ctx)
+}
+
+// SaveProtectedBSSID adds or replaces a protected BSSID of the active workspace.
+func (p *PersistenceManager) SaveProtectedBSSID(ctx context.Context, protected domain.ProtectedBSSID) error {
+	p.mu.RLock()
+	store, ok := p.storage.(ports.ProtectedBSSIDRepository)
+	p.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("storage does not support protected BSSIDs")
+	}
+	return store.SaveProtectedBSSID(ctx, protected)
+}
+
+// DeleteProtectedBSSID stops protecting a BSSID in the active workspace.
+func (p *PersistenceManager) DeleteProtectedBSSID(ctx context.Context, bssid string) error {
+	p.mu.RLock()
+	store, ok := p.storage.(ports.ProtectedBSSIDRepository)
+	p.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("storage does not support protected BSSIDs")
+	}
+	return store.DeleteProtectedBSSID(ctx, bssid)
+}
+
+func (p *PersistenceManager) hookStore() (ports.HookRepository, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	store, ok := p.storage.(ports.HookRepository)
+	if !ok {
+		return nil, fmt.Errorf("storage does not support scripting hooks")
+	}
+	return store, nil
+}
+
+// ListHooks returns the scripting hooks of the active workspace.
+func (p *PersistenceManager) ListHooks(ctx context.Context) ([]domain.ScriptHook, error) {
+	store, err := p.hookStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.ListHooks(ctx)
+}
+
+// SaveHook creates or replaces a scripting hook of the active workspace.
+func (p *PersistenceManager) SaveHook(ctx context.Context, hook domain.ScriptHook) error {
+	store, err := p.hookStore()
+	if err != nil {
+		return err
+	}
+	return store.SaveHook(ctx, hook)
+}
+
+// DeleteHook removes a scripting hook from the active workspace.
+func (p *PersistenceManager) DeleteHook(ctx context.Context, id string) error {
+	store, err := p.hookStore()
+	if err != nil {
+		return err
 	}
+	return store.DeleteHook(ctx, id)
 }
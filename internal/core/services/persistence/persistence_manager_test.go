@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
 )
 
 // MockStorage implements ports.Storage for testing
@@ -55,6 +56,10 @@ func (m *MockStorage) UpdateVulnerabilityStatus(ctx context.Context, id string,
 	return nil
 }
 
+func (m *MockStorage) SetVulnerabilitySuppression(ctx context.Context, id string, suppression *domain.VulnerabilitySuppression) error {
+	return nil
+}
+
 func TestPersistenceManager_Persist_Batching(t *testing.T) {
 	mockStore := &MockStorage{}
 	// Create manager with small buffer for testing
@@ -122,3 +127,40 @@ func TestPersistenceManager_Persist_Timer(t *testing.T) {
 	}
 	mockStore.mu.Unlock()
 }
+
+// sensorRouter routes the devices of one sensor to a workspace
+type sensorRouter struct {
+	sensor string
+	store  ports.Storage
+}
+
+func (r sensorRouter) WorkspaceOf(sensor string) string {
+	name, _ := r.Route(sensor)
+	return name
+}
+
+func (r sensorRouter) Route(sensor string) (string, ports.Storage) {
+	if sensor == r.sensor {
+		return "site-b", r.store
+	}
+	return "", nil
+}
+
+func TestPersistenceManager_Router(t *testing.T) {
+	active, siteB := &MockStorage{}, &MockStorage{}
+	pm := NewPersistenceManager(active, 10)
+	pm.SetRouter(sensorRouter{sensor: "agent-b/wlan0", store: siteB})
+
+	pm.flushBuffer(map[string]domain.Device{
+		"a":  {MAC: "AA:AA:AA:AA:AA:01", Sensor: "wlan0"},
+		"b":  {MAC: "AA:AA:AA:AA:AA:02", Sensor: "agent-b/wlan0"},
+		"b2": {MAC: "AA:AA:AA:AA:AA:01", Sensor: "agent-b/wlan0"}, // Heard at both sites
+	})
+
+	if len(active.SavedDevices) != 1 || active.SavedDevices[0].MAC != "AA:AA:AA:AA:AA:01" {
+		t.Errorf("Expected the local device in the active workspace, got %v", active.SavedDevices)
+	}
+	if len(siteB.SavedDevices) != 2 {
+		t.Errorf("Expected 2 devices in the routed workspace, got %d", len(siteB.SavedDevices))
+	}
+}
@@ -0,0 +1,195 @@
+package power
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// BatteryInterval is the time between two battery readings.
+const BatteryInterval = time.Minute
+
+// Service runs the power save mode of battery and solar powered sensors:
+// it sleeps the radios between capture cycles, stops handshakes from
+// holding the hopper and slows down persistence flushes.
+type Service struct {
+	sleeper ports.RadioSleeper
+	hopper  ports.ReactiveHopper // Optional
+	flusher ports.FlushThrottler // Optional
+	battery ports.BatteryMonitor // Optional
+	audit   ports.AuditService   // Optional
+
+	mu       sync.RWMutex
+	config   domain.PowerSaveConfig
+	sleeping bool
+	status   *domain.BatteryStatus
+	err      string
+	low      bool // Battery last seen below the low threshold
+	changed  chan struct{}
+}
+
+// NewService creates a service with power save disabled.
+func NewService(sleeper ports.RadioSleeper, hopper ports.ReactiveHopper, flusher ports.FlushThrottler, battery ports.BatteryMonitor, audit ports.AuditService) *Service {
+	return &Service{
+		sleeper: sleeper,
+		hopper:  hopper,
+		flusher: flusher,
+		battery: battery,
+		audit:   audit,
+		config:  domain.PowerSaveConfig{LowBattery: domain.DefaultLowBattery},
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// PowerStatus returns the configuration, the radio state and the last
+// battery reading.
+func (s *Service) PowerStatus() domain.PowerStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status := domain.PowerStatus{PowerSave: s.config, Sleeping: s.sleeping, Error: s.err}
+	if s.status != nil {
+		battery := *s.status
+		status.Battery = &battery
+	}
+	return status
+}
+
+// SetPowerSave applies config and records the change.
+func (s *Service) SetPowerSave(ctx context.Context, config domain.PowerSaveConfig) (domain.PowerStatus, error) {
+	if err := s.Configure(config); err != nil {
+		return domain.PowerStatus{}, err
+	}
+	if s.audit != nil {
+		details := "disabled"
+		if config.Enabled {
+			details = fmt.Sprintf("enabled, %v on / %v off", config.On, config.Off)
+		}
+		s.audit.Log(ctx, domain.ActionConfigChange, "power_save", details)
+	}
+	return s.PowerStatus(), nil
+}
+
+// Configure applies config without recording it, e.g. from the command line.
+func (s *Service) Configure(config domain.PowerSaveConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.config = config
+	s.mu.Unlock()
+
+	if s.hopper != nil {
+		s.hopper.SetReactiveHopping(!config.Enabled)
+	}
+	if s.flusher != nil {
+		interval := time.Duration(0) // Default
+		if config.Enabled {
+			interval = config.FlushInterval
+		}
+		s.flusher.SetFlushInterval(interval)
+	}
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run sleeps and wakes the radios along the duty cycle and reads the
+// battery until ctx is done. The radios are awake when it returns.
+func (s *Service) Run(ctx context.Context) {
+	s.checkBattery(ctx)
+	lastBattery := time.Now()
+	for {
+		s.mu.RLock()
+		config := s.config
+		s.mu.RUnlock()
+
+		now := time.Now()
+		s.setSleeping(ctx, !config.IsOn(now))
+
+		wait := time.Until(lastBattery.Add(BatteryInterval))
+		if next := config.NextChange(now); !next.IsZero() && next.Sub(now) < wait {
+			wait = next.Sub(now)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.setSleeping(context.Background(), false)
+			return
+		case <-s.changed:
+			timer.Stop()
+		case <-timer.C:
+		}
+		if time.Since(lastBattery) >= BatteryInterval {
+			s.checkBattery(ctx)
+			lastBattery = time.Now()
+		}
+	}
+}
+
+// setSleeping switches the radios off or on if they are not already.
+func (s *Service) setSleeping(ctx context.Context, sleep bool) {
+	s.mu.RLock()
+	sleeping := s.sleeping
+	s.mu.RUnlock()
+	if sleep == sleeping {
+		return
+	}
+
+	var err error
+	if sleep {
+		err = s.sleeper.SleepRadios(ctx)
+	} else {
+		err = s.sleeper.WakeRadios(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.err = err.Error()
+		log.Printf("[POWER] Cannot switch radios (sleep=%v): %v", sleep, err)
+		return
+	}
+	s.err = ""
+	s.sleeping = sleep
+}
+
+// checkBattery reads the battery, reporting once when it runs low and once
+// when it recovers.
+func (s *Service) checkBattery(ctx context.Context) {
+	if s.battery == nil {
+		return
+	}
+	status, err := s.battery.Battery(ctx)
+	if err != nil {
+		return // No battery, e.g. mains powered
+	}
+
+	s.mu.Lock()
+	s.status = &status
+	threshold := s.config.LowBattery
+	low := threshold > 0 && status.Percent <= threshold && !status.Charging
+	changed := low != s.low
+	s.low = low
+	s.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	if low {
+		log.Printf("[POWER] Battery low: %d%% (%s)", status.Percent, status.Source)
+		if s.audit != nil {
+			s.audit.Log(ctx, domain.ActionInfo, "battery", fmt.Sprintf("battery low: %d%%", status.Percent))
+		}
+	} else {
+		log.Printf("[POWER] Battery recovered: %d%%", status.Percent)
+	}
+}
@@ -0,0 +1,118 @@
+package power
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSleeper struct {
+	mu            sync.Mutex
+	sleeps, wakes int
+}
+
+func (m *mockSleeper) SleepRadios(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sleeps++
+	return nil
+}
+
+func (m *mockSleeper) WakeRadios(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.wakes++
+	return nil
+}
+
+func (m *mockSleeper) counts() (int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sleeps, m.wakes
+}
+
+type mockHopper struct{ enabled bool }
+
+func (m *mockHopper) SetReactiveHopping(enabled bool) { m.enabled = enabled }
+
+type mockFlusher struct{ interval time.Duration }
+
+func (m *mockFlusher) SetFlushInterval(interval time.Duration) { m.interval = interval }
+
+type mockBattery struct {
+	status domain.BatteryStatus
+	err    error
+}
+
+func (m *mockBattery) Battery(ctx context.Context) (domain.BatteryStatus, error) {
+	return m.status, m.err
+}
+
+func TestService_SetPowerSave(t *testing.T) {
+	hopper := &mockHopper{enabled: true}
+	flusher := &mockFlusher{}
+	svc := NewService(&mockSleeper{}, hopper, flusher, nil, nil)
+
+	status, err := svc.SetPowerSave(context.Background(), domain.PowerSaveConfig{Enabled: true, On: 20 * time.Second, Off: 40 * time.Second})
+	require.NoError(t, err)
+	assert.True(t, status.PowerSave.Enabled)
+	assert.False(t, hopper.enabled)
+	assert.Equal(t, domain.DefaultPowerSaveFlush, flusher.interval)
+
+	_, err = svc.SetPowerSave(context.Background(), domain.PowerSaveConfig{})
+	require.NoError(t, err)
+	assert.True(t, hopper.enabled)
+	assert.Zero(t, flusher.interval)
+
+	_, err = svc.SetPowerSave(context.Background(), domain.PowerSaveConfig{Enabled: true, Off: time.Minute})
+	assert.True(t, errors.Is(err, domain.ErrInvalidDutyCycle))
+}
+
+func TestService_RunCycles(t *testing.T) {
+	sleeper := &mockSleeper{}
+	svc := NewService(sleeper, nil, nil, nil, nil)
+	require.NoError(t, svc.Configure(domain.PowerSaveConfig{Enabled: true, On: time.Second, Off: time.Second}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		sleeps, wakes := sleeper.counts()
+		return sleeps >= 1 && wakes >= 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	cancel()
+	<-done
+	sleeps, wakes := sleeper.counts()
+	assert.Equal(t, sleeps, wakes, "radios are awake once Run returns")
+	assert.False(t, svc.PowerStatus().Sleeping)
+}
+
+func TestService_Battery(t *testing.T) {
+	battery := &mockBattery{status: domain.BatteryStatus{Percent: 15, Source: "BAT0"}}
+	svc := NewService(&mockSleeper{}, nil, nil, battery, nil)
+
+	svc.checkBattery(context.Background())
+	status := svc.PowerStatus()
+	require.NotNil(t, status.Battery)
+	assert.Equal(t, 15, status.Battery.Percent)
+	assert.True(t, svc.low)
+
+	battery.status.Charging = true
+	svc.checkBattery(context.Background())
+	assert.False(t, svc.low)
+
+	battery.err = errors.New("no battery")
+	svc.checkBattery(context.Background())
+	assert.Equal(t, 15, svc.PowerStatus().Battery.Percent, "keeps the last reading")
+}
@@ -0,0 +1,105 @@
+package registry
+
+import (
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// clientCounts is the number of unique clients around an AP.
+type clientCounts struct {
+	associated int // Clients associated with the AP
+	probing    int // Clients probing for its SSID without being associated
+}
+
+// countClients counts the unique clients of every AP. Randomized MACs the
+// behavior engine correlated to the same device count once, so a phone
+// rotating its address does not inflate the numbers.
+func countClients(devices []domain.Device) map[string]clientCounts {
+	associated := make(map[string]map[string]bool) // AP MAC -> client identities
+	probing := make(map[string]map[string]bool)    // SSID -> client identities
+
+	for i := range devices {
+		d := &devices[i]
+		if d.Type == domain.DeviceTypeAP {
+			continue
+		}
+		id := clientIdentity(d)
+
+		if bssid := associatedBSSID(d); bssid != "" {
+			if associated[bssid] == nil {
+				associated[bssid] = make(map[string]bool)
+			}
+			associated[bssid][id] = true
+		}
+		for ssid := range d.ProbedSSIDs {
+			if probing[ssid] == nil {
+				probing[ssid] = make(map[string]bool)
+			}
+			probing[ssid][id] = true
+		}
+	}
+
+	counts := make(map[string]clientCounts)
+	for i := range devices {
+		ap := &devices[i]
+		if ap.Type != domain.DeviceTypeAP {
+			continue
+		}
+		c := clientCounts{associated: len(associated[ap.MAC])}
+		if ap.SSID != "" && ap.SSID != "<HIDDEN>" {
+			for id := range probing[ap.SSID] {
+				if !associated[ap.MAC][id] {
+					c.probing++
+				}
+			}
+		}
+		counts[ap.MAC] = c
+	}
+	return counts
+}
+
+// clientIdentity returns the correlated real MAC of a randomized client, or
+// its own MAC.
+func clientIdentity(d *domain.Device) string {
+	if d.Behavioral != nil && d.Behavioral.LinkedMAC != "" {
+		return d.Behavioral.LinkedMAC
+	}
+	return d.MAC
+}
+
+// associatedBSSID returns the AP a client is associated with. Clients still
+// authenticating or associating are not counted.
+func associatedBSSID(d *domain.Device) string {
+	if d.ConnectionTarget != "" {
+		if d.ConnectionState == domain.StateConnected || d.ConnectionState == domain.StateHandshake {
+			return d.ConnectionTarget
+		}
+		return ""
+	}
+	return d.ConnectedSSID // Legacy: devices without precise state yet
+}
+
+// CountActiveClients counts the unique clients associated with every AP
+// among devices, leaving out those not seen within window.
+func CountActiveClients(devices []domain.Device, window time.Duration) map[string]int {
+	clients := make(map[string]map[string]bool) // AP MAC -> client identities
+	for i := range devices {
+		d := &devices[i]
+		if d.Type == domain.DeviceTypeAP || !d.IsActive(window) {
+			continue
+		}
+		if bssid := associatedBSSID(d); bssid != "" {
+			if clients[bssid] == nil {
+				clients[bssid] = make(map[string]bool)
+			}
+			clients[bssid][clientIdentity(d)] = true
+		}
+	}
+
+	counts := make(map[string]int, len(clients))
+	for bssid, ids := range clients {
+		counts[bssid] = len(ids)
+	}
+	return counts
+}
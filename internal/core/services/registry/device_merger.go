@@ -19,12 +19,19 @@ func (dm *DeviceMerger) Merge(existing *domain.Device, newDevice domain.Device)
 	existing.LastPacketTime = newDevice.LastPacketTime
 	existing.LastSeen = newDevice.LastPacketTime
 	existing.RSSI = newDevice.RSSI
+	if len(newDevice.ChainRSSI) > 0 {
+		existing.ChainRSSI = newDevice.ChainRSSI
+	}
 	existing.Latitude = newDevice.Latitude
 	existing.Longitude = newDevice.Longitude
 
 	if newDevice.Vendor != "" {
 		existing.Vendor = newDevice.Vendor
 	}
+	// The first sensor is kept, each observation being routed by its own
+	if existing.Sensor == "" {
+		existing.Sensor = newDevice.Sensor
+	}
 
 	// APs take precedence over stations
 	if newDevice.Type != "" {
@@ -36,6 +43,13 @@ func (dm *DeviceMerger) Merge(existing *domain.Device, newDevice domain.Device)
 	if newDevice.Signature != "" {
 		existing.Signature = newDevice.Signature
 		existing.IETags = newDevice.IETags
+		existing.VendorIEs = newDevice.VendorIEs
+	}
+	if newDevice.BeaconInterval > 0 {
+		existing.BeaconInterval = newDevice.BeaconInterval
+	}
+	if len(newDevice.SupportedRates) > 0 {
+		existing.SupportedRates = newDevice.SupportedRates
 	}
 
 	if newDevice.Security != "" {
@@ -47,6 +61,23 @@ func (dm *DeviceMerger) Merge(existing *domain.Device, newDevice domain.Device)
 	if newDevice.Model != "" {
 		existing.Model = newDevice.Model
 	}
+	if len(newDevice.Annotations) > 0 {
+		// Copied rather than updated in place: snapshots handed out share the map
+		annotations := make(map[string]string, len(existing.Annotations)+len(newDevice.Annotations))
+		for k, v := range existing.Annotations {
+			annotations[k] = v
+		}
+		for k, v := range newDevice.Annotations {
+			annotations[k] = v
+		}
+		existing.Annotations = annotations
+	}
+	if newDevice.Asset != nil {
+		existing.Asset = newDevice.Asset
+	}
+	if newDevice.Portal != nil {
+		existing.Portal = newDevice.Portal
+	}
 	if newDevice.Frequency > 0 {
 		existing.Frequency = newDevice.Frequency
 	}
@@ -59,9 +90,33 @@ func (dm *DeviceMerger) Merge(existing *domain.Device, newDevice domain.Device)
 	if newDevice.MobilityDomain != nil {
 		existing.MobilityDomain = newDevice.MobilityDomain
 	}
+	if newDevice.BeaconFingerprint != nil {
+		existing.BeaconFingerprint = newDevice.BeaconFingerprint
+	}
+	if newDevice.OWETransition != nil {
+		existing.OWETransition = newDevice.OWETransition
+	}
 	if newDevice.LastANonce != "" {
 		existing.LastANonce = newDevice.LastANonce
 	}
+	if len(newDevice.KeyReinstallation) > 0 {
+		existing.KeyReinstallation = newDevice.KeyReinstallation
+	}
+	if newDevice.Uptime != nil {
+		existing.Uptime = newDevice.Uptime
+	}
+	if newDevice.WEPIVs != nil {
+		existing.WEPIVs = newDevice.WEPIVs
+	}
+	if newDevice.WeakNonces != nil {
+		merged := domain.WeakNonces{}
+		if existing.WeakNonces != nil {
+			merged = *existing.WeakNonces
+		}
+		merged.Zero = merged.Zero || newDevice.WeakNonces.Zero
+		merged.Evidence = append(append([]string(nil), merged.Evidence...), newDevice.WeakNonces.Evidence...)
+		existing.WeakNonces = &merged
+	}
 
 	if newDevice.Channel > 0 {
 		existing.Channel = newDevice.Channel
@@ -83,14 +138,19 @@ func (dm *DeviceMerger) Merge(existing *domain.Device, newDevice domain.Device)
 	existing.RetryCount += newDevice.RetryCount
 	if newDevice.ChannelWidth > 0 {
 		existing.ChannelWidth = newDevice.ChannelWidth
+		existing.CenterChannel = newDevice.CenterChannel
+	}
+	if newDevice.Country != "" {
+		existing.Country = newDevice.Country
+	}
+	if newDevice.MaxTxPower != 0 {
+		existing.MaxTxPower = newDevice.MaxTxPower
 	}
 
 	if existing.ProbedSSIDs == nil {
 		existing.ProbedSSIDs = make(map[string]time.Time)
 	}
-	for ssid, ts := range newDevice.ProbedSSIDs {
-		existing.ProbedSSIDs[ssid] = ts
-	}
+	existing.MergeProbes(newDevice.ProbedSSIDs, newDevice.ProbeCounts)
 
 	if len(newDevice.ObservedSSIDs) > 0 {
 		if existing.ObservedSSIDs == nil {
@@ -17,6 +17,7 @@ type deviceShard struct {
 	mu       sync.RWMutex
 	devices  map[string]domain.Device
 	profiles map[string]domain.BehavioralProfile
+	traffic  map[string]*rateWindow // Rolling traffic per MAC
 }
 
 // DeviceRegistry implements ports.DeviceRegistry.
@@ -32,6 +33,7 @@ type DeviceRegistry struct {
 	// MAC -> Last processed Signature
 	discoCacheMu sync.RWMutex
 	sigMatcher   ports.SignatureMatcher
+	hwMatcher    ports.APHardwareMatcher
 
 	// Vulnerability Persistence
 	VulnPersistence *security.VulnerabilityPersistenceService
@@ -58,6 +60,7 @@ func NewDeviceRegistry(sigMatcher ports.SignatureMatcher, vulnStore *security.Vu
 		r.shards[i] = &deviceShard{
 			devices:  make(map[string]domain.Device),
 			profiles: make(map[string]domain.BehavioralProfile),
+			traffic:  make(map[string]*rateWindow),
 		}
 	}
 	return r
@@ -79,9 +82,9 @@ func (r *DeviceRegistry) ProcessDevice(ctx context.Context, newDevice domain.Dev
 	existing, ok := shard.devices[newDevice.MAC]
 	if !ok {
 		// New Device initialization
-		if newDevice.ProbedSSIDs == nil {
-			newDevice.ProbedSSIDs = make(map[string]time.Time)
-		}
+		probes, counts := newDevice.ProbedSSIDs, newDevice.ProbeCounts
+		newDevice.ProbedSSIDs, newDevice.ProbeCounts = make(map[string]time.Time), nil
+		newDevice.MergeProbes(probes, counts)
 		if newDevice.FirstSeen.IsZero() {
 			newDevice.FirstSeen = newDevice.LastPacketTime
 		}
@@ -101,6 +104,9 @@ func (r *DeviceRegistry) ProcessDevice(ctx context.Context, newDevice domain.Dev
 		if raceExisting, raceOk := shard.devices[newDevice.MAC]; raceOk {
 			// Another thread beat us to it. Merge our data into the existing one.
 			r.merger.Merge(&raceExisting, newDevice)
+			now := time.Now()
+			shard.recordTraffic(newDevice, now)
+			shard.applyRates(&raceExisting, now)
 			shard.devices[newDevice.MAC] = raceExisting
 			// Strictly speaking, it's not "new" to the registry anymore, but we might want to return true/false based on discovery?
 			// Let's return false as it's an update now.
@@ -119,6 +125,10 @@ func (r *DeviceRegistry) ProcessDevice(ctx context.Context, newDevice domain.Dev
 			newDevice.Behavioral = &p
 		}
 
+		now := time.Now()
+		shard.recordTraffic(newDevice, now)
+		shard.applyRates(&newDevice, now)
+
 		shard.devices[newDevice.MAC] = newDevice
 		r.ssidManager.Update(ctx, newDevice.SSID, newDevice.Security)
 		r.ssidManager.Update(ctx, "", "") // Dummy to trigger internal SSID update for ProbedSSIDs?
@@ -144,7 +154,7 @@ func (r *DeviceRegistry) ProcessDevice(ctx context.Context, newDevice domain.Dev
 						deviceDesc = string(d.Type)
 					}
 					fmt.Printf("[VULN] Detected %d vulnerabilities for new device %s (%s)\n", len(vulns), d.MAC, deviceDesc)
-					if err := r.VulnPersistence.ProcessDetections(d.MAC, vulns); err != nil {
+					if err := r.VulnPersistence.ProcessSensorDetections(d.MAC, d.Sensor, vulns); err != nil {
 						fmt.Printf("[VULN] Error persisting vulnerabilities for %s: %v\n", d.MAC, err)
 					}
 				}
@@ -172,6 +182,10 @@ func (r *DeviceRegistry) ProcessDevice(ctx context.Context, newDevice domain.Dev
 		existing.Behavioral = &p
 	}
 
+	now := time.Now()
+	shard.recordTraffic(newDevice, now)
+	shard.applyRates(&existing, now)
+
 	shard.devices[newDevice.MAC] = existing
 
 	r.ssidManager.Update(ctx, existing.SSID, existing.Security)
@@ -183,7 +197,8 @@ func (r *DeviceRegistry) ProcessDevice(ctx context.Context, newDevice domain.Dev
 
 	// Vulnerability Detection for Updated Devices (All Types)
 	if r.VulnPersistence != nil && r.VulnDetector != nil {
-		go func(d domain.Device) {
+		// Attributed to the sensor of this observation, not the first one
+		go func(d domain.Device, sensor string) {
 			vulns := r.VulnDetector.DetectVulnerabilities(&d)
 			if len(vulns) > 0 {
 				deviceDesc := d.SSID
@@ -191,11 +206,11 @@ func (r *DeviceRegistry) ProcessDevice(ctx context.Context, newDevice domain.Dev
 					deviceDesc = string(d.Type)
 				}
 				fmt.Printf("[VULN] Detected %d vulnerabilities for device %s (%s)\n", len(vulns), d.MAC, deviceDesc)
-				if err := r.VulnPersistence.ProcessDetections(d.MAC, vulns); err != nil {
+				if err := r.VulnPersistence.ProcessSensorDetections(d.MAC, sensor, vulns); err != nil {
 					fmt.Printf("[VULN] Error persisting vulnerabilities for %s: %v\n", d.MAC, err)
 				}
 			}
-		}(existing)
+		}(existing, newDevice.Sensor)
 	}
 
 	return existing, shouldPerformDiscovery
@@ -230,7 +245,8 @@ func (r *DeviceRegistry) checkDiscoveryNeeded(existing, newDevice domain.Device)
 	r.discoCacheMu.RLock()
 	lastSig, cached := r.discoCache[existing.MAC]
 	r.discoCacheMu.RUnlock()
-	return !cached || lastSig != newDevice.Signature || existing.Model == ""
+	return !cached || lastSig != newDevice.Signature || existing.Model == "" ||
+		(existing.Hardware == nil && newDevice.BeaconFingerprint != nil)
 }
 
 // DELETED: func (r *DeviceRegistry) mergeDeviceData...
@@ -241,11 +257,15 @@ func (r *DeviceRegistry) GetDevice(ctx context.Context, mac string) (domain.Devi
 	shard.mu.RLock()
 	defer shard.mu.RUnlock()
 	d, ok := shard.devices[mac]
+	if ok {
+		shard.applyRates(&d, time.Now())
+	}
 	return d, ok
 }
 
 func (r *DeviceRegistry) GetAllDevices(ctx context.Context) []domain.Device {
 	var all []domain.Device
+	now := time.Now()
 	for _, shard := range r.shards {
 		shard.mu.RLock()
 		for _, d := range shard.devices {
@@ -258,12 +278,19 @@ func (r *DeviceRegistry) GetAllDevices(ctx context.Context) []domain.Device {
 					dCopy.ProbedSSIDs[k] = v
 				}
 			}
+			if d.ProbeCounts != nil {
+				dCopy.ProbeCounts = make(map[string]int, len(d.ProbeCounts))
+				for k, v := range d.ProbeCounts {
+					dCopy.ProbeCounts[k] = v
+				}
+			}
 			// IETags is a slice, might need copy if modified (append creates new slice usually, but modifying elements?)
 			// Typically IETags are just replaced, but safer to copy if we want true snapshot.
 			if d.IETags != nil {
 				dCopy.IETags = make([]int, len(d.IETags))
 				copy(dCopy.IETags, d.IETags)
 			}
+			shard.applyRates(&dCopy, now)
 
 			all = append(all, dCopy)
 		}
@@ -281,6 +308,7 @@ func (r *DeviceRegistry) PruneOldDevices(ctx context.Context, ttl time.Duration)
 		for mac, d := range shard.devices {
 			if d.LastSeen.Before(threshold) {
 				delete(shard.devices, mac)
+				delete(shard.traffic, mac)
 				deletedCount++
 			}
 		}
@@ -295,6 +323,43 @@ func (r *DeviceRegistry) PruneOldDevices(ctx context.Context, ttl time.Duration)
 	return deletedCount
 }
 
+// RemoveDevice drops a device with its profile and traffic, and clears the
+// connections of other devices to it so it leaves the graph entirely.
+func (r *DeviceRegistry) RemoveDevice(ctx context.Context, mac string) (bool, int) {
+	shard := r.getShard(mac)
+	shard.mu.Lock()
+	_, found := shard.devices[mac]
+	delete(shard.devices, mac)
+	delete(shard.profiles, mac)
+	delete(shard.traffic, mac)
+	shard.mu.Unlock()
+
+	r.discoCacheMu.Lock()
+	delete(r.discoCache, mac)
+	r.discoCacheMu.Unlock()
+
+	unlinked := 0
+	for _, shard := range r.shards {
+		shard.mu.Lock()
+		for key, d := range shard.devices {
+			if d.ConnectedSSID != mac && d.ConnectionTarget != mac {
+				continue
+			}
+			if d.ConnectedSSID == mac {
+				d.ConnectedSSID = ""
+			}
+			if d.ConnectionTarget == mac {
+				d.ConnectionTarget = ""
+				d.ConnectionState = domain.StateDisconnected
+			}
+			shard.devices[key] = d
+			unlinked++
+		}
+		shard.mu.Unlock()
+	}
+	return found, unlinked
+}
+
 // CleanupStaleConnections degrades connections to "disconnected" if silent for too long.
 func (r *DeviceRegistry) CleanupStaleConnections(ctx context.Context, timeout time.Duration) int {
 	threshold := time.Now().Add(-timeout)
@@ -333,6 +398,7 @@ func (r *DeviceRegistry) Clear(ctx context.Context) {
 		shard.mu.Lock()
 		shard.devices = make(map[string]domain.Device)
 		shard.profiles = make(map[string]domain.BehavioralProfile)
+		shard.traffic = make(map[string]*rateWindow)
 		shard.mu.Unlock()
 	}
 
@@ -407,14 +473,41 @@ func (r *DeviceRegistry) correlateMAC(newDevice domain.Device) (string, float64)
 	return bestMAC, maxScore
 }
 
+// SetHardwareMatcher sets the database identifying AP models from their
+// beacon fingerprints.
+func (r *DeviceRegistry) SetHardwareMatcher(m ports.APHardwareMatcher) {
+	r.hwMatcher = m
+}
+
 func (r *DeviceRegistry) performDiscovery(ctx context.Context, device *domain.Device) {
+	r.identifyHardware(ctx, device)
 	if r.sigMatcher == nil {
 		return
 	}
 	match := r.sigMatcher.MatchSignature(ctx, *device)
 	if match != nil && match.Confidence >= 0.6 {
 		device.Model = match.Signature.Model
-		device.OS = match.Signature.OS
-		device.Type = domain.DeviceType(match.Signature.DeviceType)
+		if match.Signature.OS != "" {
+			device.OS = match.Signature.OS
+		}
+		if match.Signature.DeviceType != "" {
+			device.Type = domain.DeviceType(match.Signature.DeviceType)
+		}
+	}
+}
+
+// identifyHardware names the model of an AP from its beacon fingerprint,
+// unless WPS already named it.
+func (r *DeviceRegistry) identifyHardware(ctx context.Context, device *domain.Device) {
+	if r.hwMatcher == nil || device.BeaconFingerprint == nil {
+		return
+	}
+	hw := r.hwMatcher.MatchHardware(ctx, *device)
+	if hw == nil {
+		return
+	}
+	device.Hardware = hw
+	if device.Model == "" {
+		device.Model = hw.Model
 	}
 }
@@ -292,3 +292,14 @@ func TestDeviceRegistry_MergeObservedSSIDs(t *testing.T) {
 	stored, _ = registry.GetDevice(context.Background(), mac)
 	assert.Len(t, stored.ObservedSSIDs, 2)
 }
+
+func TestDeviceRegistry_MergeKeepsFirstSensor(t *testing.T) {
+	registry := NewDeviceRegistry(nil, nil)
+	mac := "AA:BB:CC:DD:EE:01"
+
+	registry.ProcessDevice(context.Background(), domain.Device{MAC: mac, Sensor: "wlan0", LastPacketTime: time.Now()})
+	registry.ProcessDevice(context.Background(), domain.Device{MAC: mac, Sensor: "agent-b/wlan1", LastPacketTime: time.Now()})
+
+	stored, _ := registry.GetDevice(context.Background(), mac)
+	assert.Equal(t, "wlan0", stored.Sensor, "a second sensor must not move the device")
+}
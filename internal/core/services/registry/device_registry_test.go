@@ -143,6 +143,28 @@ func TestDeviceRegistry_PruneOldDevices(t *testing.T) {
 	assert.True(t, foundNew)
 }
 
+func TestDeviceRegistry_RemoveDevice(t *testing.T) {
+	registry := NewDeviceRegistry(nil, nil)
+	ctx := context.Background()
+
+	registry.ProcessDevice(ctx, domain.Device{MAC: "AP:01", Type: domain.DeviceTypeAP, LastPacketTime: time.Now()})
+	registry.ProcessDevice(ctx, domain.Device{MAC: "STA:01", ConnectedSSID: "AP:01", LastPacketTime: time.Now()})
+	registry.ProcessDevice(ctx, domain.Device{MAC: "STA:02", LastPacketTime: time.Now()})
+
+	found, unlinked := registry.RemoveDevice(ctx, "AP:01")
+	assert.True(t, found)
+	assert.Equal(t, 1, unlinked)
+
+	_, ok := registry.GetDevice(ctx, "AP:01")
+	assert.False(t, ok)
+	sta, ok := registry.GetDevice(ctx, "STA:01")
+	assert.True(t, ok)
+	assert.Empty(t, sta.ConnectedSSID)
+
+	found, _ = registry.RemoveDevice(ctx, "AP:01")
+	assert.False(t, found)
+}
+
 func TestDeviceRegistry_ProcessDevice_MergeHandshake(t *testing.T) {
 	registry := NewDeviceRegistry(nil, nil)
 	mac := "00:AA:BB:CC:DD:EE"
@@ -174,3 +196,81 @@ func TestDeviceRegistry_ProcessDevice_MergeHandshake(t *testing.T) {
 	stored, _ = registry.GetDevice(context.Background(), mac)
 	assert.True(t, stored.HasHandshake, "Should persist HasHandshake=true")
 }
+
+func TestDeviceRegistry_TrafficRates(t *testing.T) {
+	registry := NewDeviceRegistry(nil, nil)
+	ctx := context.Background()
+
+	mac := "AA:BB:CC:DD:EE:01"
+	registry.ProcessDevice(ctx, domain.Device{MAC: mac, LastPacketTime: time.Now(), DataTransmitted: 3000})
+	processed, _ := registry.ProcessDevice(ctx, domain.Device{MAC: mac, LastPacketTime: time.Now(), DataTransmitted: 3000, DataReceived: 600})
+
+	assert.Equal(t, int64(6000), processed.DataTransmitted)
+	assert.InDelta(t, 100.0, processed.TxRate, 0.001) // 6000 bytes over the last minute
+	assert.InDelta(t, 10.0, processed.RxRate, 0.001)
+
+	stored, _ := registry.GetDevice(ctx, mac)
+	assert.InDelta(t, 100.0, stored.TxRate, 0.001)
+
+	all := registry.GetAllDevices(ctx)
+	assert.Len(t, all, 1)
+	assert.InDelta(t, 10.0, all[0].RxRate, 0.001)
+}
+
+func TestRateWindow_Decays(t *testing.T) {
+	var w rateWindow
+	start := time.Unix(1700000000, 0)
+	w.add(start, 600, 0, 1, 0)
+	w.add(start.Add(30*time.Second), 600, 0, 1, 0)
+
+	tx, _ := w.rates(start.Add(30 * time.Second))
+	assert.InDelta(t, 20.0, tx, 0.001)
+
+	// The first second has left the window
+	tx, _ = w.rates(start.Add(70 * time.Second))
+	assert.InDelta(t, 10.0, tx, 0.001)
+
+	tx, _ = w.rates(start.Add(2 * time.Minute))
+	assert.Zero(t, tx)
+}
+
+type stubHardwareMatcher struct {
+	hw *domain.APHardware
+}
+
+func (m stubHardwareMatcher) MatchHardware(ctx context.Context, d domain.Device) *domain.APHardware {
+	return m.hw
+}
+
+func TestDeviceRegistry_IdentifiesHardware(t *testing.T) {
+	registry := NewDeviceRegistry(nil, nil)
+	registry.SetHardwareMatcher(stubHardwareMatcher{hw: &domain.APHardware{SignatureID: "acme_ap1", Model: "AP-1"}})
+	ctx := context.Background()
+
+	// No beacon fingerprint yet: nothing to identify
+	registry.ProcessDevice(ctx, domain.Device{MAC: "AA:BB:CC:DD:EE:01", Type: domain.DeviceTypeAP, LastPacketTime: time.Now()})
+	stored, _ := registry.GetDevice(ctx, "AA:BB:CC:DD:EE:01")
+	assert.Nil(t, stored.Hardware)
+
+	processed, _ := registry.ProcessDevice(ctx, domain.Device{
+		MAC:               "AA:BB:CC:DD:EE:01",
+		Type:              domain.DeviceTypeAP,
+		LastPacketTime:    time.Now(),
+		BeaconFingerprint: &domain.BeaconFingerprint{IEOrder: []int{0, 1, 3}},
+	})
+	if assert.NotNil(t, processed.Hardware) {
+		assert.Equal(t, "acme_ap1", processed.Hardware.SignatureID)
+	}
+	assert.Equal(t, "AP-1", processed.Model)
+
+	// A model named by WPS is kept
+	processed, _ = registry.ProcessDevice(ctx, domain.Device{
+		MAC:               "AA:BB:CC:DD:EE:02",
+		Type:              domain.DeviceTypeAP,
+		Model:             "WPS-Model",
+		LastPacketTime:    time.Now(),
+		BeaconFingerprint: &domain.BeaconFingerprint{IEOrder: []int{0, 1, 3}},
+	})
+	assert.NotNil(t, processed.Hardware)
+	assert.Equal(t, "WPS-Model", processed.Model)
+}
@@ -82,6 +82,9 @@ func (b *GraphBuilder) BuildGraph(ctx context.Context) domain.GraphData {
 		}
 	}
 
+	clients := countClients(devices)
+	cells := cellLinks(devices)
+
 	// Devices - Second pass for device nodes
 	for _, device := range devices {
 		group := domain.GraphGroup(device.Type)
@@ -109,6 +112,14 @@ func (b *GraphBuilder) BuildGraph(ctx context.Context) domain.GraphData {
 		// Passive Vulnerability Detection
 		vulns := b.vulnerabilityDetector.DetectVulnerabilities(&device)
 
+		// Link Quality: APs are graded by their whole cell
+		var link domain.LinkStats
+		if device.Type == domain.DeviceTypeAP {
+			link = cells[device.MAC]
+		} else if device.Link != nil {
+			link = *device.Link
+		}
+
 		nodes = append(nodes, domain.GraphNode{
 			NodeIdentity: domain.NodeIdentity{
 				ID:        "dev_" + device.MAC,
@@ -120,25 +131,31 @@ func (b *GraphBuilder) BuildGraph(ctx context.Context) domain.GraphData {
 				FirstSeen: device.FirstSeen,
 			},
 			RadioDetails: domain.RadioDetails{
-				RSSI:         device.RSSI,
-				Capabilities: device.Capabilities,
-				IsRandomized: device.IsRandomized,
-				HasHandshake: device.HasHandshake,
-				SSID:         device.SSID,
-				Channel:      device.Channel,
-				Security:     device.Security,
-				Standard:     device.Standard,
-				Frequency:    device.Frequency,
-				IsWiFi6:      device.IsWiFi6,
-				IsWiFi7:      device.IsWiFi7,
-				WPSInfo:      device.WPSInfo,
-				IETags:       device.IETags,
+				RSSI:          device.RSSI,
+				Capabilities:  device.Capabilities,
+				IsRandomized:  device.IsRandomized,
+				HasHandshake:  device.HasHandshake,
+				SSID:          device.SSID,
+				Channel:       device.Channel,
+				ChannelWidth:  device.ChannelWidth,
+				CenterChannel: device.CenterChannel,
+				Security:      device.Security,
+				Standard:      device.Standard,
+				Frequency:     device.Frequency,
+				IsWiFi6:       device.IsWiFi6,
+				IsWiFi7:       device.IsWiFi7,
+				WPSInfo:       device.WPSInfo,
+				IETags:        device.IETags,
 			},
 			TrafficStats: domain.TrafficStats{
 				DataTransmitted: device.DataTransmitted,
 				DataReceived:    device.DataReceived,
 				PacketsCount:    device.PacketsCount,
 				RetryCount:      device.RetryCount,
+				TxRate:          device.TxRate,
+				RxRate:          device.RxRate,
+				RetryRate:       link.RetryRate,
+				LinkQuality:     link.Quality,
 			},
 			NodeBehavioralData: domain.NodeBehavioralData{
 				ProbeFrequency: probeFreqStr,
@@ -149,6 +166,8 @@ func (b *GraphBuilder) BuildGraph(ctx context.Context) domain.GraphData {
 				OS:             device.OS,
 			},
 			Vulnerabilities: vulns,
+			ClientCount:     clients[device.MAC].associated,
+			ProbingClients:  clients[device.MAC].probing,
 		})
 
 		// SSID Edges (Logical Relation)
@@ -173,14 +192,20 @@ func (b *GraphBuilder) BuildGraph(ctx context.Context) domain.GraphData {
 			}
 		}
 
-		for ssid := range device.ProbedSSIDs {
+		// Probed-for Edges (Preferred Network List exposure)
+		for ssid, lastSeen := range device.ProbedSSIDs {
 			if ssid != device.SSID {
-				edges = append(edges, domain.GraphEdge{
+				edge := domain.GraphEdge{
 					From:   "dev_" + device.MAC,
 					To:     "ssid_" + ssid,
 					Dashed: true,
-					Type:   domain.TypeProbe,
-				})
+					Type:   domain.TypeProbedFor,
+					Count:  max(device.ProbeCounts[ssid], 1),
+				}
+				if !lastSeen.IsZero() {
+					edge.LastSeen = &lastSeen
+				}
+				edges = append(edges, edge)
 			}
 		}
 
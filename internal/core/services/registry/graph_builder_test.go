@@ -31,7 +31,10 @@ func (m *MockRegistryGraph) GetSSIDs(ctx context.Context) map[string]bool {
 	args := m.Called()
 	return args.Get(0).(map[string]bool)
 }
-func (m *MockRegistryGraph) GetActiveCount(ctx context.Context) int                     { return 0 }
+func (m *MockRegistryGraph) GetActiveCount(ctx context.Context) int { return 0 }
+func (m *MockRegistryGraph) RemoveDevice(ctx context.Context, mac string) (bool, int) {
+	return false, 0
+}
 func (m *MockRegistryGraph) PruneOldDevices(ctx context.Context, ttl time.Duration) int { return 0 }
 func (m *MockRegistryGraph) Clear(ctx context.Context)                                  {}
 func (m *MockRegistryGraph) GetSSIDSecurity(ctx context.Context, ssid string) (string, bool) {
@@ -124,3 +127,106 @@ func TestGraphBuilder_Edges(t *testing.T) {
 	}
 	assert.True(t, foundConnection, "Should have connection edge")
 }
+
+func TestGraphBuilder_ProbedForEdges(t *testing.T) {
+	mockReg := new(MockRegistryGraph)
+	builder := NewGraphBuilder(mockReg)
+
+	now := time.Now()
+	station := domain.Device{
+		MAC:         "S1",
+		Type:        domain.DeviceTypeStation,
+		ProbedSSIDs: map[string]time.Time{"HomeNet": now, "CafeNet": time.Time{}},
+		ProbeCounts: map[string]int{"HomeNet": 7},
+	}
+	mockReg.On("GetAllDevices").Return([]domain.Device{station})
+	mockReg.On("GetSSIDs").Return(map[string]bool{"HomeNet": true, "CafeNet": true})
+
+	graph := builder.BuildGraph(context.Background())
+
+	edges := make(map[string]domain.GraphEdge)
+	for _, edge := range graph.Edges {
+		if edge.Type == domain.TypeProbedFor {
+			edges[edge.To] = edge
+		}
+	}
+	assert.Len(t, edges, 2)
+	assert.Equal(t, 7, edges["ssid_HomeNet"].Count)
+	if assert.NotNil(t, edges["ssid_HomeNet"].LastSeen) {
+		assert.True(t, edges["ssid_HomeNet"].LastSeen.Equal(now))
+	}
+	assert.Equal(t, 1, edges["ssid_CafeNet"].Count, "Probes stored before counting count once")
+	assert.Nil(t, edges["ssid_CafeNet"].LastSeen)
+}
+
+func TestGraphBuilder_ClientCount(t *testing.T) {
+	mockReg := new(MockRegistryGraph)
+	builder := NewGraphBuilder(mockReg)
+
+	now := time.Now()
+	ap := domain.Device{MAC: "A1", Type: domain.DeviceTypeAP, SSID: "CorpNet"}
+	devices := []domain.Device{
+		ap,
+		// Associated client, also seen under a randomized MAC correlated to it
+		{MAC: "S1", Type: domain.DeviceTypeStation, ConnectionTarget: "A1", ConnectionState: domain.StateConnected},
+		{MAC: "R1", Type: domain.DeviceTypeStation, ConnectedSSID: "A1", IsRandomized: true,
+			Behavioral: &domain.BehavioralProfile{LinkedMAC: "S1"}},
+		// Legacy association without connection state
+		{MAC: "S2", Type: domain.DeviceTypeStation, ConnectedSSID: "A1"},
+		// Still authenticating: probing only
+		{MAC: "S3", Type: domain.DeviceTypeStation, ConnectionTarget: "A1", ConnectionState: domain.StateAuthenticating,
+			ProbedSSIDs: map[string]time.Time{"CorpNet": now}},
+		// Associated clients probing for the SSID are not counted twice
+		{MAC: "S4", Type: domain.DeviceTypeStation, ConnectionTarget: "A1", ConnectionState: domain.StateHandshake,
+			ProbedSSIDs: map[string]time.Time{"CorpNet": now}},
+		// Probing for another network
+		{MAC: "S5", Type: domain.DeviceTypeStation, ProbedSSIDs: map[string]time.Time{"Other": now}},
+	}
+
+	mockReg.On("GetAllDevices").Return(devices)
+	mockReg.On("GetSSIDs").Return(map[string]bool{"CorpNet": true, "Other": true})
+
+	graph := builder.BuildGraph(context.Background())
+
+	for _, n := range graph.Nodes {
+		switch n.ID {
+		case "dev_A1":
+			assert.Equal(t, 3, n.ClientCount) // S1 (+R1), S2, S4
+			assert.Equal(t, 1, n.ProbingClients)
+		case "dev_S1":
+			assert.Zero(t, n.ClientCount)
+		}
+	}
+}
+
+func TestGraphBuilder_CellLinkQuality(t *testing.T) {
+	mockReg := new(MockRegistryGraph)
+	builder := NewGraphBuilder(mockReg)
+
+	beacons := domain.NewLinkStats(30, 0)
+	noisy := domain.NewLinkStats(30, 15)
+	devices := []domain.Device{
+		{MAC: "A1", Type: domain.DeviceTypeAP, SSID: "CorpNet", Link: &beacons},
+		{MAC: "S1", Type: domain.DeviceTypeStation, ConnectionTarget: "A1", ConnectionState: domain.StateConnected, Link: &noisy},
+		{MAC: "S2", Type: domain.DeviceTypeStation},
+	}
+
+	mockReg.On("GetAllDevices").Return(devices)
+	mockReg.On("GetSSIDs").Return(map[string]bool{"CorpNet": true})
+
+	graph := builder.BuildGraph(context.Background())
+
+	for _, n := range graph.Nodes {
+		switch n.ID {
+		case "dev_A1":
+			// 15 retries out of 60 frames in the cell
+			assert.InDelta(t, 0.25, n.RetryRate, 0.001)
+			assert.Equal(t, domain.LinkPoor, n.LinkQuality)
+		case "dev_S1":
+			assert.InDelta(t, 0.5, n.RetryRate, 0.001)
+			assert.Equal(t, domain.LinkPoor, n.LinkQuality)
+		case "dev_S2":
+			assert.Empty(t, n.LinkQuality)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// throughputWindow is the period traffic rates are averaged over.
+const throughputWindow = 60 // seconds
+
+// trafficBucket holds the bytes and frames seen during one second.
+type trafficBucket struct {
+	second          int64
+	tx, rx          int64
+	frames, retries int
+}
+
+// rateWindow accumulates a device's traffic in one-second buckets over the
+// last minute, so rates decay to zero once the device goes quiet.
+type rateWindow struct {
+	buckets [throughputWindow]trafficBucket
+}
+
+func (w *rateWindow) add(now time.Time, tx, rx int64, frames, retries int) {
+	sec := now.Unix()
+	b := &w.buckets[sec%throughputWindow]
+	if b.second != sec {
+		*b = trafficBucket{second: sec}
+	}
+	b.tx += tx
+	b.rx += rx
+	b.frames += frames
+	b.retries += retries
+}
+
+// rates returns the average transmit and receive rates in bytes/sec.
+func (w *rateWindow) rates(now time.Time) (tx, rx float64) {
+	var txSum, rxSum int64
+	w.each(now, func(b trafficBucket) {
+		txSum += b.tx
+		rxSum += b.rx
+	})
+	return float64(txSum) / throughputWindow, float64(rxSum) / throughputWindow
+}
+
+// link returns the retry statistics of the window.
+func (w *rateWindow) link(now time.Time) domain.LinkStats {
+	var frames, retries int
+	w.each(now, func(b trafficBucket) {
+		frames += b.frames
+		retries += b.retries
+	})
+	return domain.NewLinkStats(frames, retries)
+}
+
+// each calls fn for every bucket inside the window.
+func (w *rateWindow) each(now time.Time, fn func(b trafficBucket)) {
+	sec := now.Unix()
+	for _, b := range w.buckets {
+		if age := sec - b.second; age >= 0 && age < throughputWindow {
+			fn(b)
+		}
+	}
+}
+
+// recordTraffic adds the traffic of an observation to the device's window.
+// The shard lock must be held.
+func (s *deviceShard) recordTraffic(device domain.Device, now time.Time) {
+	if device.DataTransmitted == 0 && device.DataReceived == 0 && device.PacketsCount == 0 {
+		return
+	}
+	w, ok := s.traffic[device.MAC]
+	if !ok {
+		w = &rateWindow{}
+		s.traffic[device.MAC] = w
+	}
+	w.add(now, device.DataTransmitted, device.DataReceived, device.PacketsCount, device.RetryCount)
+}
+
+// applyRates sets the device's current traffic rates and retry statistics.
+// The shard lock must be held.
+func (s *deviceShard) applyRates(device *domain.Device, now time.Time) {
+	device.TxRate, device.RxRate, device.Link = 0, 0, nil
+	if w, ok := s.traffic[device.MAC]; ok {
+		device.TxRate, device.RxRate = w.rates(now)
+		if link := w.link(now); link.Frames > 0 {
+			device.Link = &link
+		}
+	}
+}
+
+// cellLinks aggregates the retry statistics of every AP with those of its
+// associated clients. Downlink frames are attributed to the receiving
+// client, so an AP's own frames are mostly beacons that are never retried;
+// the cell tells how the channel really performs.
+func cellLinks(devices []domain.Device) map[string]domain.LinkStats {
+	cells := make(map[string]domain.LinkStats)
+	for i := range devices {
+		d := &devices[i]
+		if d.Link == nil {
+			continue
+		}
+		bssid := d.MAC
+		if d.Type != domain.DeviceTypeAP {
+			if bssid = associatedBSSID(d); bssid == "" {
+				continue
+			}
+		}
+		cells[bssid] = cells[bssid].Add(*d.Link)
+	}
+	return cells
+}
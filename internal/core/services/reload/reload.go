@@ -0,0 +1,133 @@
+package reload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// DefaultInterval is how often watched files are checked for changes.
+const DefaultInterval = 5 * time.Second
+
+// LoadFunc applies the content of a data file to a running service and
+// returns the number of entries loaded.
+type LoadFunc func(data []byte) (int, error)
+
+// source is a watched data file.
+type source struct {
+	name    string
+	path    string
+	load    LoadFunc
+	modTime time.Time
+	size    int64
+}
+
+// Service reloads data files such as device signatures and alert rules into
+// the running services, either when they change on disk or on demand, so
+// they take effect without restarting the sniffer.
+type Service struct {
+	sources []*source
+	mu      sync.Mutex
+}
+
+// NewService creates a reload service without sources.
+func NewService() *Service {
+	return &Service{}
+}
+
+// Register watches the file at path and applies it with load when it
+// changes. The file's current state is recorded, so it is only reloaded
+// after its next modification.
+func (s *Service) Register(name, path string, load LoadFunc) {
+	src := &source{name: name, path: path, load: load}
+	if info, err := os.Stat(path); err == nil {
+		src.modTime, src.size = info.ModTime(), info.Size()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sources = append(s.sources, src)
+}
+
+// Reload applies every registered file, changed or not.
+func (s *Service) Reload(ctx context.Context) []domain.ReloadResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := make([]domain.ReloadResult, 0, len(s.sources))
+	for _, src := range s.sources {
+		results = append(results, s.reload(src))
+	}
+	return results
+}
+
+// Watch checks the registered files every interval until ctx is done and
+// reloads those that changed.
+func (s *Service) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.poll()
+			}
+		}
+	}()
+}
+
+// poll reloads the files modified since they were last applied.
+func (s *Service) poll() []domain.ReloadResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var results []domain.ReloadResult
+	for _, src := range s.sources {
+		info, err := os.Stat(src.path)
+		if err != nil || (info.ModTime().Equal(src.modTime) && info.Size() == src.size) {
+			continue
+		}
+		results = append(results, s.reload(src))
+	}
+	return results
+}
+
+// reload applies a source's file. A file that cannot be read or parsed
+// leaves the running data untouched. Caller must hold s.mu.
+func (s *Service) reload(src *source) domain.ReloadResult {
+	result := domain.ReloadResult{Source: src.name, Path: src.path, ReloadedAt: time.Now()}
+
+	info, err := os.Stat(src.path)
+	if err == nil {
+		// Recorded before reading, so a write racing the load is picked up next time
+		src.modTime, src.size = info.ModTime(), info.Size()
+	}
+
+	var n int
+	data, err := os.ReadFile(src.path)
+	if errors.Is(err, os.ErrNotExist) {
+		err = fmt.Errorf("%s not found", src.path)
+	} else if err == nil {
+		n, err = src.load(data)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		log.Printf("[RELOAD] Could not reload %s: %v", src.name, err)
+		return result
+	}
+
+	result.Loaded = n
+	log.Printf("[RELOAD] Reloaded %d %s from %s", n, src.name, src.path)
+	return result
+}
@@ -0,0 +1,91 @@
+package reload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ReloadAndPoll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`["a"]`), 0600))
+
+	var loaded []string
+	svc := NewService()
+	svc.Register("rules", path, func(data []byte) (int, error) {
+		var items []string
+		if err := json.Unmarshal(data, &items); err != nil {
+			return 0, err
+		}
+		loaded = items
+		return len(items), nil
+	})
+
+	// Files are applied at start by their owners, so only changes are polled
+	assert.Empty(t, svc.poll())
+	assert.Nil(t, loaded)
+
+	results := svc.Reload(context.Background())
+	require.Len(t, results, 1)
+	assert.Equal(t, "rules", results[0].Source)
+	assert.Equal(t, 1, results[0].Loaded)
+	assert.Empty(t, results[0].Error)
+	assert.Equal(t, []string{"a"}, loaded)
+
+	require.NoError(t, os.WriteFile(path, []byte(`["a","b"]`), 0600))
+	results = svc.poll()
+	require.Len(t, results, 1)
+	assert.Equal(t, 2, results[0].Loaded)
+	assert.Equal(t, []string{"a", "b"}, loaded)
+	assert.Empty(t, svc.poll(), "unchanged file is not reloaded")
+
+	// A broken file is reported and keeps the running data
+	require.NoError(t, os.WriteFile(path, []byte(`["a",`), 0600))
+	results = svc.poll()
+	require.Len(t, results, 1)
+	assert.NotEmpty(t, results[0].Error)
+	assert.Equal(t, []string{"a", "b"}, loaded)
+}
+
+func TestService_ReloadMissingFile(t *testing.T) {
+	svc := NewService()
+	svc.Register("signatures", filepath.Join(t.TempDir(), "missing.json"), func([]byte) (int, error) {
+		return 0, errors.New("should not be called")
+	})
+
+	results := svc.Reload(context.Background())
+	require.Len(t, results, 1)
+	assert.Contains(t, results[0].Error, "not found")
+	assert.Empty(t, svc.poll())
+}
+
+func TestService_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sigs.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[]`), 0600))
+
+	reloaded := make(chan int, 1)
+	svc := NewService()
+	svc.Register("signatures", path, func(data []byte) (int, error) {
+		reloaded <- len(data)
+		return 0, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc.Watch(ctx, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{}]`), 0600))
+	select {
+	case n := <-reloaded:
+		assert.Equal(t, 4, n)
+	case <-time.After(2 * time.Second):
+		t.Fatal("changed file was not reloaded")
+	}
+}
@@ -0,0 +1,127 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// maxReportedActions bounds the attack history read for a report.
+const maxReportedActions = 10000
+
+// ActionsReportGenerator compiles the attacks launched during an engagement
+// from the persisted attack history.
+type ActionsReportGenerator struct {
+	history ports.AttackHistoryRepository
+	scope   ports.ScopeRepository
+}
+
+// NewActionsReportGenerator creates an actions report generator. The scope
+// repository is optional.
+func NewActionsReportGenerator(history ports.AttackHistoryRepository, scope ports.ScopeRepository) *ActionsReportGenerator {
+	return &ActionsReportGenerator{history: history, scope: scope}
+}
+
+// Generate lists the attacks started within the date range, oldest first,
+// with the engagement scope they were checked against.
+func (g *ActionsReportGenerator) Generate(
+	ctx context.Context,
+	dateRange domain.DateRange,
+	orgName, workspaceName, generatedBy string,
+) (*domain.ActionsReport, error) {
+	records, err := g.history.ListAttackRecords(ctx, maxReportedActions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attack history: %w", err)
+	}
+
+	actions := make([]domain.AttackRecord, 0, len(records))
+	for _, r := range records {
+		if inRange(r.StartTime, dateRange) {
+			actions = append(actions, r)
+		}
+	}
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].StartTime.Before(actions[j].StartTime)
+	})
+
+	report := &domain.ActionsReport{
+		Metadata: domain.ReportMetadata{
+			ID:               uuid.New().String(),
+			Type:             domain.ReportTypeActions,
+			Format:           domain.FormatPDF,
+			Title:            "Actions Taken",
+			GeneratedAt:      time.Now(),
+			GeneratedBy:      generatedBy,
+			ScanPeriod:       dateRange,
+			WorkspaceName:    workspaceName,
+			OrganizationName: orgName,
+		},
+		Summary: summarizeActions(actions),
+		Actions: actions,
+	}
+	if g.scope != nil {
+		if scope, err := g.scope.GetScope(ctx); err == nil {
+			report.Scope = scope
+		}
+	}
+	return report, nil
+}
+
+// inRange reports whether t falls in the range; zero bounds are open.
+func inRange(t time.Time, r domain.DateRange) bool {
+	if !r.Start.IsZero() && t.Before(r.Start) {
+		return false
+	}
+	if !r.End.IsZero() && t.After(r.End) {
+		return false
+	}
+	return true
+}
+
+// summarizeActions aggregates attacks sorted by start time.
+func summarizeActions(actions []domain.AttackRecord) domain.ActionsSummary {
+	summary := domain.ActionsSummary{
+		Total:     len(actions),
+		ByKind:    make(map[domain.AttackKind]int),
+		Operators: []string{},
+	}
+	operators := make(map[string]bool)
+	targets := make(map[string]bool)
+	for _, a := range actions {
+		summary.ByKind[a.Kind]++
+		if a.Operator != "" && !operators[a.Operator] {
+			operators[a.Operator] = true
+			summary.Operators = append(summary.Operators, a.Operator)
+		}
+		if a.Target != "" {
+			targets[strings.ToLower(a.Target)] = true
+		}
+		if a.HandshakeCaptured {
+			summary.HandshakesCaptured++
+		}
+		if a.CredentialsRecovered {
+			summary.CredentialsRecovered++
+		}
+		if a.DisconnectionConfirmed {
+			summary.DisconnectionsObserved++
+		}
+		if a.Status == string(domain.AttackFailed) {
+			summary.Failed++
+		}
+		if end := a.EndTime; end.After(summary.LastAction) {
+			summary.LastAction = end
+		}
+	}
+	summary.Targets = len(targets)
+	sort.Strings(summary.Operators)
+	if len(actions) > 0 {
+		summary.FirstAction = actions[0].StartTime
+	}
+	return summary
+}
@@ -0,0 +1,103 @@
+package reporting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+type mockAttackHistory struct {
+	records []domain.AttackRecord
+}
+
+func (m *mockAttackHistory) SaveAttackRecord(ctx context.Context, record domain.AttackRecord) error {
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *mockAttackHistory) ListAttackRecords(ctx context.Context, limit int) ([]domain.AttackRecord, error) {
+	return m.records, nil
+}
+
+type mockScope struct {
+	scope domain.EngagementScope
+}
+
+func (m *mockScope) GetScope(ctx context.Context) (domain.EngagementScope, error) {
+	return m.scope, nil
+}
+
+func (m *mockScope) SaveScope(ctx context.Context, scope domain.EngagementScope) error {
+	m.scope = scope
+	return nil
+}
+
+func TestActionsReportGenerator_Generate(t *testing.T) {
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+
+	history := &mockAttackHistory{records: []domain.AttackRecord{
+		// Newest first, as the repository lists them
+		{ID: "4", Kind: domain.AttackKindDeauth, Operator: "alice", Target: "AA:BB:CC:00:00:02", StartTime: day.AddDate(0, 0, 5), EndTime: day.AddDate(0, 0, 5)},
+		{ID: "3", Kind: domain.AttackKindWPS, Operator: "bob", Target: "AA:BB:CC:00:00:01", StartTime: at(5), EndTime: at(6), Status: string(domain.AttackFailed)},
+		{ID: "2", Kind: domain.AttackKindDeauth, Operator: "alice", Target: "aa:bb:cc:00:00:01", StartTime: at(3), EndTime: at(4), HandshakeCaptured: true, DisconnectionConfirmed: true},
+		{ID: "1", Kind: domain.AttackKindDeauth, Target: "AA:BB:CC:00:00:02", StartTime: at(1), EndTime: at(2)},
+	}}
+	scope := &mockScope{scope: domain.EngagementScope{BSSIDs: []string{"AA:BB:CC:00:00:01"}}}
+	gen := NewActionsReportGenerator(history, scope)
+
+	report, err := gen.Generate(context.Background(), domain.DateRange{Start: day, End: day.AddDate(0, 0, 1)}, "Acme", "office", "alice")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if report.Metadata.Type != domain.ReportTypeActions {
+		t.Errorf("Report type = %v, want %v", report.Metadata.Type, domain.ReportTypeActions)
+	}
+	if len(report.Actions) != 3 {
+		t.Fatalf("Actions = %d, want 3 within the range", len(report.Actions))
+	}
+	for i, id := range []string{"1", "2", "3"} {
+		if report.Actions[i].ID != id {
+			t.Errorf("Action %d = %s, want %s (oldest first)", i, report.Actions[i].ID, id)
+		}
+	}
+	if len(report.Scope.BSSIDs) != 1 {
+		t.Errorf("Scope = %+v, want the engagement scope", report.Scope)
+	}
+
+	s := report.Summary
+	if s.Total != 3 || s.Targets != 2 {
+		t.Errorf("Total = %d, Targets = %d, want 3 and 2", s.Total, s.Targets)
+	}
+	if s.ByKind[domain.AttackKindDeauth] != 2 || s.ByKind[domain.AttackKindWPS] != 1 {
+		t.Errorf("ByKind = %v", s.ByKind)
+	}
+	if len(s.Operators) != 2 || s.Operators[0] != "alice" || s.Operators[1] != "bob" {
+		t.Errorf("Operators = %v, want [alice bob]", s.Operators)
+	}
+	if s.HandshakesCaptured != 1 || s.DisconnectionsObserved != 1 || s.Failed != 1 {
+		t.Errorf("Summary = %+v", s)
+	}
+	if !s.FirstAction.Equal(at(1)) || !s.LastAction.Equal(at(6)) {
+		t.Errorf("Window = %v - %v, want %v - %v", s.FirstAction, s.LastAction, at(1), at(6))
+	}
+}
+
+func TestActionsReportGenerator_OpenRange(t *testing.T) {
+	history := &mockAttackHistory{records: []domain.AttackRecord{
+		{ID: "1", Kind: domain.AttackKindKarma, StartTime: time.Now()},
+	}}
+	report, err := NewActionsReportGenerator(history, nil).Generate(context.Background(), domain.DateRange{}, "", "", "")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(report.Actions) != 1 {
+		t.Errorf("Actions = %d, want 1 with an open range", len(report.Actions))
+	}
+	if !report.Scope.IsEmpty() {
+		t.Errorf("Scope = %+v, want empty without a scope repository", report.Scope)
+	}
+}
@@ -3,6 +3,7 @@ package reporting
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -48,6 +49,9 @@ func (g *ExecutiveReportGenerator) Generate(
 	// Filter by date range
 	vulns = g.filterByDateRange(vulns, dateRange)
 
+	// Accepted risks stay out of the scores, only counted
+	vulns, suppressed := g.withoutSuppressed(vulns)
+
 	// Get unique device count from vulnerabilities
 	deviceMACs := make(map[string]bool)
 	for _, v := range vulns {
@@ -57,6 +61,7 @@ func (g *ExecutiveReportGenerator) Generate(
 
 	// Calculate statistics
 	stats := g.calculateStats(vulns)
+	stats.Suppressed = suppressed
 
 	// Calculate risk score
 	riskScore := g.riskCalc.CalculateOverallRisk(vulns, deviceCount)
@@ -87,11 +92,87 @@ func (g *ExecutiveReportGenerator) Generate(
 		VulnStats:       stats,
 		TopRisks:        topRisks,
 		Recommendations: recommendations,
+		Assets:          g.assetExposure(ctx, vulns),
 	}
 
 	return report, nil
 }
 
+// withoutSuppressed removes the suppressed findings, returning how many.
+func (g *ExecutiveReportGenerator) withoutSuppressed(vulns []domain.VulnerabilityRecord) ([]domain.VulnerabilityRecord, int) {
+	reported := make([]domain.VulnerabilityRecord, 0, len(vulns))
+	for _, v := range vulns {
+		if !v.IsSuppressed() {
+			reported = append(reported, v)
+		}
+	}
+	return reported, len(vulns) - len(reported)
+}
+
+// assetExposure groups the active findings by the devices recorded as
+// business assets, most critical asset first.
+func (g *ExecutiveReportGenerator) assetExposure(ctx context.Context, vulns []domain.VulnerabilityRecord) []domain.AssetExposure {
+	byMAC := make(map[string]*domain.AssetExposure)
+	var exposures []*domain.AssetExposure
+	for _, v := range vulns {
+		if v.Status == domain.VulnStatusFixed || v.Status == domain.VulnStatusIgnored {
+			continue
+		}
+		exposure, seen := byMAC[v.DeviceMAC]
+		if !seen {
+			asset := g.lookupAsset(ctx, v.DeviceMAC)
+			if asset != nil {
+				exposure = &domain.AssetExposure{
+					MAC:         v.DeviceMAC,
+					Owner:       asset.Owner,
+					AssetTag:    asset.AssetTag,
+					Criticality: asset.Criticality,
+				}
+				exposures = append(exposures, exposure)
+			}
+			byMAC[v.DeviceMAC] = exposure
+		}
+		if exposure == nil {
+			continue
+		}
+		exposure.Vulnerabilities++
+		if int(v.Severity) > exposure.MaxSeverity {
+			exposure.MaxSeverity = int(v.Severity)
+			exposure.TopFinding = v.Name
+		}
+	}
+
+	sort.Slice(exposures, func(i, j int) bool {
+		a, b := exposures[i], exposures[j]
+		if a.Criticality.Rank() != b.Criticality.Rank() {
+			return a.Criticality.Rank() > b.Criticality.Rank()
+		}
+		if a.MaxSeverity != b.MaxSeverity {
+			return a.MaxSeverity > b.MaxSeverity
+		}
+		return a.MAC < b.MAC
+	})
+	result := make([]domain.AssetExposure, len(exposures))
+	for i, e := range exposures {
+		result[i] = *e
+	}
+	return result
+}
+
+// lookupAsset returns the business asset a device was recorded as, from the
+// registry or, for devices no longer active, the workspace storage.
+func (g *ExecutiveReportGenerator) lookupAsset(ctx context.Context, mac string) *domain.AssetInfo {
+	if g.deviceRegistry != nil {
+		if device, ok := g.deviceRegistry.GetDevice(ctx, mac); ok {
+			return device.Asset
+		}
+	}
+	if device, err := g.storage.GetDevice(ctx, mac); err == nil && device != nil {
+		return device.Asset
+	}
+	return nil
+}
+
 // calculateStats computes vulnerability statistics
 func (g *ExecutiveReportGenerator) calculateStats(vulns []domain.VulnerabilityRecord) domain.VulnerabilityStats {
 	stats := domain.VulnerabilityStats{
@@ -141,7 +222,8 @@ func (g *ExecutiveReportGenerator) inferCategory(vulnName string) string {
 	switch vulnName {
 	case "WEP", "TKIP", "KRACK", "WEAK-WPA", "TKIP-ONLY":
 		return "Protocol Weakness"
-	case "WPS-PIXIE", "WPS-ENABLED", "OPEN-NETWORK", "DEFAULT-SSID", "FT-PSK", "FT-OVER-DS":
+	case "WPS-PIXIE", "WPS-ENABLED", "OPEN-NETWORK", "OPEN-EGRESS", "DEFAULT-SSID", "FT-PSK", "FT-OVER-DS",
+		"OWE-TRANSITION-UNPAIRED", "OWE-TRANSITION-MISMATCH", "OWE-ONLY", "WPA3-ONLY":
 		return "Configuration"
 	case "PROBE-LEAKAGE", "MAC-RAND-FAIL", "LEGACY-WEP-SUPPORT", "LEGACY-TKIP-ONLY":
 		return "Client Security"
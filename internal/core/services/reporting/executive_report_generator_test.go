@@ -49,12 +49,18 @@ func (m *MockStorage) UpdateVulnerabilityStatus(ctx context.Context, id string,
 	return nil
 }
 
+func (m *MockStorage) SetVulnerabilitySuppression(ctx context.Context, id string, suppression *domain.VulnerabilitySuppression) error {
+	return nil
+}
+
 func (m *MockStorage) Close() error {
 	return nil
 }
 
 // MockDeviceRegistry implements ports.DeviceRegistry for testing
-type MockDeviceRegistry struct{}
+type MockDeviceRegistry struct {
+	devices map[string]domain.Device
+}
 
 func (m *MockDeviceRegistry) ProcessDevice(ctx context.Context, device domain.Device) (domain.Device, bool) {
 	return device, false
@@ -64,13 +70,18 @@ func (m *MockDeviceRegistry) LoadDevice(ctx context.Context, device domain.Devic
 }
 
 func (m *MockDeviceRegistry) GetDevice(ctx context.Context, mac string) (domain.Device, bool) {
-	return domain.Device{}, false
+	d, ok := m.devices[mac]
+	return d, ok
 }
 
 func (m *MockDeviceRegistry) GetAllDevices(ctx context.Context) []domain.Device {
 	return []domain.Device{}
 }
 
+func (m *MockDeviceRegistry) RemoveDevice(ctx context.Context, mac string) (bool, int) {
+	return false, 0
+}
+
 func (m *MockDeviceRegistry) PruneOldDevices(ctx context.Context, ttl time.Duration) int {
 	return 0
 }
@@ -291,6 +302,8 @@ func TestInferCategory(t *testing.T) {
 		{"DEFAULT-SSID", "Configuration"},
 		{"FT-PSK", "Configuration"},
 		{"FT-OVER-DS", "Configuration"},
+		{"OWE-TRANSITION-UNPAIRED", "Configuration"},
+		{"WPA3-ONLY", "Configuration"},
 		{"PROBE-LEAKAGE", "Client Security"},
 		{"MAC-RAND-FAIL", "Client Security"},
 		{"LEGACY-WEP-SUPPORT", "Client Security"},
@@ -437,3 +450,69 @@ func TestExecutiveReportGeneratorIntegration(t *testing.T) {
 		t.Errorf("Expected at least 3 recommendations, got %d", len(report.Recommendations))
 	}
 }
+
+func TestExecutiveReportGeneratorAssets(t *testing.T) {
+	mockStorage := &MockStorage{
+		vulnerabilities: []domain.VulnerabilityRecord{
+			{Name: "DEFAULT-SSID", Severity: domain.Severity(5), Status: domain.VulnStatusActive, DeviceMAC: "aa:00:00:00:00:01"},
+			{Name: "WPS-PIXIE", Severity: domain.Severity(9), Status: domain.VulnStatusActive, DeviceMAC: "aa:00:00:00:00:01"},
+			{Name: "OPEN-NETWORK", Severity: domain.Severity(8), Status: domain.VulnStatusActive, DeviceMAC: "aa:00:00:00:00:02"},
+			// Fixed findings and devices that are not assets are left out
+			{Name: "WEP", Severity: domain.Severity(10), Status: domain.VulnStatusFixed, DeviceMAC: "aa:00:00:00:00:02"},
+			{Name: "WEP", Severity: domain.Severity(10), Status: domain.VulnStatusActive, DeviceMAC: "aa:00:00:00:00:03"},
+		},
+	}
+	registry := &MockDeviceRegistry{devices: map[string]domain.Device{
+		"aa:00:00:00:00:01": {MAC: "aa:00:00:00:00:01", Asset: &domain.AssetInfo{Owner: "Facilities", AssetTag: "AP-LOBBY", Criticality: domain.CriticalityMedium}},
+		"aa:00:00:00:00:02": {MAC: "aa:00:00:00:00:02", Asset: &domain.AssetInfo{Owner: "Finance", AssetTag: "POS-01", Criticality: domain.CriticalityCritical}},
+		"aa:00:00:00:00:03": {MAC: "aa:00:00:00:00:03"},
+	}}
+
+	generator := NewExecutiveReportGenerator(mockStorage, registry)
+	report, err := generator.Generate(context.Background(), domain.DateRange{}, "Test Org")
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	if len(report.Assets) != 2 {
+		t.Fatalf("Expected 2 assets, got %d", len(report.Assets))
+	}
+	if report.Assets[0].AssetTag != "POS-01" || report.Assets[0].Vulnerabilities != 1 {
+		t.Errorf("Expected the critical asset with its active finding first, got %+v", report.Assets[0])
+	}
+	lobby := report.Assets[1]
+	if lobby.Vulnerabilities != 2 || lobby.MaxSeverity != 9 || lobby.TopFinding != "WPS-PIXIE" {
+		t.Errorf("Unexpected exposure for AP-LOBBY: %+v", lobby)
+	}
+}
+
+func TestExecutiveReportGeneratorSuppressed(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+	mockStorage := &MockStorage{
+		vulnerabilities: []domain.VulnerabilityRecord{
+			{Name: "WPS-PIXIE", Severity: domain.Severity(9), Status: domain.VulnStatusActive, DeviceMAC: "aa:00:00:00:00:01"},
+			{Name: "OPEN-NETWORK", Severity: domain.Severity(10), Status: domain.VulnStatusActive, DeviceMAC: "aa:00:00:00:00:02",
+				Suppression: &domain.VulnerabilitySuppression{Justification: "Guest SSID is open by design", SuppressedAt: time.Now()}},
+			{Name: "WEP", Severity: domain.Severity(10), Status: domain.VulnStatusActive, DeviceMAC: "aa:00:00:00:00:03",
+				Suppression: &domain.VulnerabilitySuppression{Justification: "Until the lab is rebuilt", ExpiresAt: &expired}},
+		},
+	}
+
+	generator := NewExecutiveReportGenerator(mockStorage, nil)
+	report, err := generator.Generate(context.Background(), domain.DateRange{}, "Test Org")
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+
+	if report.VulnStats.Total != 2 || report.VulnStats.Suppressed != 1 {
+		t.Errorf("Expected 2 reported findings and 1 suppressed, got %d and %d", report.VulnStats.Total, report.VulnStats.Suppressed)
+	}
+	if report.VulnStats.Critical != 2 {
+		t.Errorf("Expected the expired suppression to be reported as critical, got %d critical", report.VulnStats.Critical)
+	}
+	for _, risk := range report.TopRisks {
+		if risk.VulnName == "OPEN-NETWORK" {
+			t.Errorf("Suppressed finding listed among the top risks: %+v", risk)
+		}
+	}
+}
@@ -42,6 +42,76 @@ func (re *RecommendationEngine) GenerateRecommendations(
 	return recommendations
 }
 
+// RecommendationFor returns the remediation of a vulnerability affecting affectedCount devices
+func (re *RecommendationEngine) RecommendationFor(vulnName string, affectedCount int) domain.Recommendation {
+	return *re.getRecommendationForVuln(vulnName, affectedCount)
+}
+
+// RecommendationForAlert returns the remediation of an alert, by subtype
+func (re *RecommendationEngine) RecommendationForAlert(alert domain.Alert) domain.Recommendation {
+	switch alert.Subtype {
+	case "DEAUTH_FLOOD", "DEAUTH_DETECTED", "BROADCAST_DEAUTH", "PROTECTED_BSSID_ATTACK", "CSA_DETECTED":
+		return domain.Recommendation{
+			Priority:    "high",
+			Title:       "Enable Management Frame Protection",
+			Description: "Forged deauthentication or channel switch frames are disconnecting clients, a denial of service that also forces reconnections attackers capture.",
+			Actions: []string{
+				"Enable 802.11w (PMF) as required on WPA2 networks, it is mandatory with WPA3",
+				"Locate the transmitter from the sensor readings and remove it",
+				"Enable rogue containment or WIPS alerts on the wireless controller",
+			},
+			EstimatedEffort: "1-2 hours",
+			ImpactReduction: 80.0,
+		}
+	case "EVIL_TWIN_DETECTED", "EVIL_TWIN_SUSPECT", "KARMA_AP_DETECTED", "KARMA_DETECTION":
+		return domain.Recommendation{
+			Priority:    "critical",
+			Title:       "Remove the Rogue Access Point",
+			Description: "An access point is impersonating trusted networks to lure clients and intercept their traffic or credentials.",
+			Actions: []string{
+				"Locate the rogue AP from the sensor readings and remove it",
+				"Require server certificate validation on 802.1X clients",
+				"Remove open networks from client preferred network lists",
+				"Reset the credentials of clients that associated with the rogue AP",
+			},
+			EstimatedEffort: "2-4 hours",
+			ImpactReduction: 85.0,
+		}
+	case "WEAK_CRYPTO_ZERO_NONCE", "WEAK_CRYPTO_BAD_RNG":
+		return domain.Recommendation{
+			Priority:    "critical",
+			Title:       "Replace Firmware With a Broken Random Number Generator",
+			Description: "The device derives its keys from predictable nonces, so captured handshakes can be decrypted.",
+			Actions: []string{
+				"Update the device firmware or replace the device",
+				"Rotate the network passphrase after the update",
+			},
+			EstimatedEffort: "1-2 hours",
+			ImpactReduction: 90.0,
+		}
+	}
+	name := alert.Subtype
+	if name == "" {
+		name = string(alert.Type)
+	}
+	priority := string(alert.Severity)
+	if priority == "" {
+		priority = "medium"
+	}
+	return domain.Recommendation{
+		Priority:    priority,
+		Title:       fmt.Sprintf("Investigate %s Alert", name),
+		Description: alert.Message,
+		Actions: []string{
+			"Review the alert details and the device history",
+			"Confirm whether the activity is authorized",
+			"Contain the device if it is not",
+		},
+		EstimatedEffort: "Varies",
+		ImpactReduction: 50.0,
+	}
+}
+
 // getRecommendationForVuln returns a specific recommendation for a vulnerability type
 func (re *RecommendationEngine) getRecommendationForVuln(vulnName string, affectedCount int) *domain.Recommendation {
 	recommendations := map[string]domain.Recommendation{
@@ -58,6 +128,18 @@ func (re *RecommendationEngine) getRecommendationForVuln(vulnName string, affect
 			EstimatedEffort: "1-2 hours",
 			ImpactReduction: 90.0,
 		},
+		"OPEN-EGRESS": {
+			Priority:    "critical",
+			Title:       "Close Open Networks With Free Internet Access",
+			Description: fmt.Sprintf("%d open networks give Internet access to anyone in range without a login, making abuse untraceable.", affectedCount),
+			Actions: []string{
+				"Enable WPA2/WPA3 authentication, or WPA3 OWE for guest access",
+				"Put guest networks behind a captive portal with terms of use",
+				"Isolate guest traffic from internal networks",
+			},
+			EstimatedEffort: "1-2 hours",
+			ImpactReduction: 90.0,
+		},
 		"WPS-PIXIE": {
 			Priority:    "critical",
 			Title:       "Disable WPS on All Access Points",
@@ -123,6 +205,18 @@ func (re *RecommendationEngine) getRecommendationForVuln(vulnName string, affect
 			EstimatedEffort: "1-2 hours",
 			ImpactReduction: 85.0,
 		},
+		domain.VulnWeakPSK: {
+			Priority:    "critical",
+			Title:       "Replace Weak Pre-Shared Keys",
+			Description: fmt.Sprintf("Recovered the PSK of %d networks from captured handshakes using a list of common passwords.", affectedCount),
+			Actions: []string{
+				"Change the PSK to a random passphrase of at least 16 characters",
+				"Rotate the key on every device that stored the old one",
+				"Consider WPA3-SAE or 802.1X to resist offline dictionary attacks",
+			},
+			EstimatedEffort: "1-2 hours",
+			ImpactReduction: 90.0,
+		},
 		"TKIP-ONLY": {
 			Priority:    "medium",
 			Title:       "Enable AES Encryption",
@@ -0,0 +1,164 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// DefaultInterval is how often the schedule is checked for a window change.
+const DefaultInterval = 30 * time.Second
+
+// Service restricts capture to the windows of a monitoring schedule, so a
+// permanently installed sensor only monitors during the hours the site
+// allows. Outside them capture is paused and the interfaces are back in
+// managed mode; it resumes by itself when the next window opens.
+type Service struct {
+	controller ports.CaptureController
+	store      ports.ScheduleRepository // Optional, keeps the schedule across restarts
+	audit      ports.AuditService       // Optional
+	now        func() time.Time
+	wake       chan struct{}
+
+	transition sync.Mutex // Serializes pausing and resuming
+
+	mu     sync.RWMutex
+	status domain.MonitoringStatus
+}
+
+// NewService creates a disabled schedule. Capture is assumed to be running.
+func NewService(controller ports.CaptureController, store ports.ScheduleRepository, audit ports.AuditService) *Service {
+	return &Service{
+		controller: controller,
+		store:      store,
+		audit:      audit,
+		now:        time.Now,
+		wake:       make(chan struct{}, 1),
+		status:     domain.MonitoringStatus{Capturing: true, Since: time.Now()},
+	}
+}
+
+// Load restores the saved schedule.
+func (s *Service) Load(ctx context.Context) error {
+	if s.store == nil {
+		return nil
+	}
+	schedule, err := s.store.GetSchedule(ctx)
+	if err != nil {
+		return err
+	}
+	s.setSchedule(schedule)
+	return nil
+}
+
+// Override uses a schedule without saving it, such as one given on the
+// command line. It takes precedence over the saved schedule until changed.
+func (s *Service) Override(schedule domain.MonitoringSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	s.setSchedule(schedule)
+	return nil
+}
+
+// Status returns the schedule and whether capture is running.
+func (s *Service) Status() domain.MonitoringStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.status
+}
+
+// SetSchedule saves the schedule and applies it right away.
+func (s *Service) SetSchedule(ctx context.Context, schedule domain.MonitoringSchedule) (domain.MonitoringStatus, error) {
+	if err := schedule.Validate(); err != nil {
+		return domain.MonitoringStatus{}, err
+	}
+	if s.store != nil {
+		if err := s.store.SaveSchedule(ctx, schedule); err != nil {
+			return domain.MonitoringStatus{}, err
+		}
+	}
+	s.setSchedule(schedule)
+
+	if s.audit != nil {
+		details, _ := json.Marshal(schedule)
+		_ = s.audit.Log(ctx, domain.ActionConfigChange, "monitoring_schedule", string(details))
+	}
+
+	s.Apply(ctx)
+	return s.Status(), nil
+}
+
+func (s *Service) setSchedule(schedule domain.MonitoringSchedule) {
+	s.mu.Lock()
+	s.status.Schedule = schedule
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Apply pauses or resumes capture to match the schedule now. A failed change
+// is kept in the status and retried on the next check.
+func (s *Service) Apply(ctx context.Context) {
+	s.transition.Lock()
+	defer s.transition.Unlock()
+
+	current := s.Status()
+	want := current.Schedule.IsActive(s.now())
+	if want == current.Capturing {
+		return
+	}
+
+	var err error
+	if want {
+		err = s.controller.ResumeCapture(ctx)
+	} else {
+		err = s.controller.PauseCapture(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		if s.status.Error == "" {
+			log.Printf("[SCHEDULE] Changing capture state failed: %v", err)
+		}
+		s.status.Error = err.Error()
+		return
+	}
+	s.status.Capturing = want
+	s.status.Since = s.now()
+	s.status.Error = ""
+
+	message := "Monitoring window closed: capture paused"
+	if want {
+		message = "Monitoring window opened: capture resumed"
+	}
+	log.Printf("[SCHEDULE] %s", message)
+	if s.audit != nil {
+		_ = s.audit.Log(ctx, domain.ActionInfo, "monitoring_schedule", message)
+	}
+}
+
+// Run applies the schedule now, then on every interval and schedule change
+// until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.Apply(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockController struct {
+	paused, resumed int
+	err             error
+}
+
+func (c *mockController) PauseCapture(ctx context.Context) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.paused++
+	return nil
+}
+
+func (c *mockController) ResumeCapture(ctx context.Context) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.resumed++
+	return nil
+}
+
+type memoryScheduleStore struct {
+	schedule domain.MonitoringSchedule
+}
+
+func (s *memoryScheduleStore) GetSchedule(ctx context.Context) (domain.MonitoringSchedule, error) {
+	return s.schedule, nil
+}
+
+func (s *memoryScheduleStore) SaveSchedule(ctx context.Context, schedule domain.MonitoringSchedule) error {
+	s.schedule = schedule
+	return nil
+}
+
+func TestService_Apply(t *testing.T) {
+	ctx := context.Background()
+	controller := &mockController{}
+	store := &memoryScheduleStore{}
+	svc := NewService(controller, store, nil)
+
+	// Monday 2026-10-12, 21:00 UTC: outside office hours
+	now := time.Date(2026, 10, 12, 21, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	officeHours, err := domain.ParseMonitoringSchedule("mon-fri 08:00-20:00")
+	require.NoError(t, err)
+	officeHours.Timezone = "UTC"
+
+	status, err := svc.SetSchedule(ctx, officeHours)
+	require.NoError(t, err)
+	assert.False(t, status.Capturing)
+	assert.Equal(t, 1, controller.paused)
+	assert.Equal(t, officeHours, store.schedule)
+
+	// Nothing changes until the window opens
+	svc.Apply(ctx)
+	assert.Equal(t, 1, controller.paused)
+
+	now = time.Date(2026, 10, 13, 8, 0, 0, 0, time.UTC)
+	svc.Apply(ctx)
+	assert.True(t, svc.Status().Capturing)
+	assert.Equal(t, 1, controller.resumed)
+
+	// A failed change is reported and retried
+	now = time.Date(2026, 10, 13, 20, 0, 0, 0, time.UTC)
+	controller.err = errors.New("interface busy")
+	svc.Apply(ctx)
+	assert.True(t, svc.Status().Capturing)
+	assert.Equal(t, "interface busy", svc.Status().Error)
+
+	controller.err = nil
+	svc.Apply(ctx)
+	assert.False(t, svc.Status().Capturing)
+	assert.Empty(t, svc.Status().Error)
+
+	// Disabling the schedule resumes capture
+	status, err = svc.SetSchedule(ctx, domain.MonitoringSchedule{})
+	require.NoError(t, err)
+	assert.True(t, status.Capturing)
+	assert.Equal(t, 2, controller.resumed)
+
+	_, err = svc.SetSchedule(ctx, domain.MonitoringSchedule{Enabled: true})
+	assert.ErrorIs(t, err, domain.ErrEmptySchedule)
+}
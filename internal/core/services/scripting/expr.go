@@ -0,0 +1,704 @@
+// Package scripting evaluates the user-defined hooks run on every device
+// update. Hooks are written in a small expression language:
+//
+//	vendor == "Hikvision" && !randomized
+//	ssid matches "^Corp-" and rssi > -50
+//	"Guest" in probes || annotation("team") == "red"
+//	lower(model) contains "cam"
+//
+// Fields: mac, type, vendor, model, os, ssid, security, crypto, standard,
+// country, connection_target, channel, frequency, rssi, packets, retries,
+// tx_rate, rx_rate, randomized, wifi6, wifi7, handshake and probes (the list
+// of probed SSIDs). Operators: || (or), && (and), ! (not), ==, !=, <, <=, >,
+// >=, contains, startsWith, endsWith, matches (regular expression) and in.
+// Functions: lower(s), upper(s), len(s|list) and annotation(key).
+// Literals: numbers, "strings" or 'strings', true, false and ["lists"].
+package scripting
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// ErrSyntax is returned for expressions that cannot be compiled.
+var ErrSyntax = errors.New("syntax error")
+
+// value is the result of an expression: string, float64, bool or []string.
+type value interface{}
+
+// node is a compiled expression evaluated against a device.
+type node func(d *domain.Device) (value, error)
+
+// Expr is a compiled expression.
+type Expr struct {
+	source string
+	root   node
+}
+
+// Limits of the expressions compiled. The parser is recursive descent, so
+// nesting is bounded to keep its stack small whatever the input.
+const (
+	maxExprLength = 4096
+	maxExprDepth  = 32
+)
+
+// Compile parses an expression. The language is small enough that a
+// hand-written parser costs less than a dependency on an expression library,
+// and it is fuzzed (FuzzCompile).
+func Compile(source string) (*Expr, error) {
+	if len(source) > maxExprLength {
+		return nil, fmt.Errorf("%w: expression longer than %d bytes", ErrSyntax, maxExprLength)
+	}
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("%w: unexpected %q at %d", ErrSyntax, tok.text, tok.pos)
+	}
+	return &Expr{source: source, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string { return e.source }
+
+// Eval evaluates the expression against a device.
+func (e *Expr) Eval(d *domain.Device) (interface{}, error) {
+	return e.root(d)
+}
+
+// Match evaluates a boolean expression against a device.
+func (e *Expr) Match(d *domain.Device) (bool, error) {
+	v, err := e.root(d)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition is %s, not a boolean", typeName(v))
+	}
+	return b, nil
+}
+
+// fields resolves the identifiers of the language against a device.
+var fields = map[string]func(d *domain.Device) value{
+	"mac":               func(d *domain.Device) value { return d.MAC },
+	"type":              func(d *domain.Device) value { return string(d.Type) },
+	"vendor":            func(d *domain.Device) value { return d.Vendor },
+	"model":             func(d *domain.Device) value { return d.Model },
+	"os":                func(d *domain.Device) value { return d.OS },
+	"ssid":              func(d *domain.Device) value { return d.SSID },
+	"security":          func(d *domain.Device) value { return d.Security },
+	"crypto":            func(d *domain.Device) value { return d.Crypto },
+	"standard":          func(d *domain.Device) value { return d.Standard },
+	"country":           func(d *domain.Device) value { return d.Country },
+	"connection_target": func(d *domain.Device) value { return d.ConnectionTarget },
+	"channel":           func(d *domain.Device) value { return float64(d.Channel) },
+	"frequency":         func(d *domain.Device) value { return float64(d.Frequency) },
+	"rssi":              func(d *domain.Device) value { return float64(d.RSSI) },
+	"packets":           func(d *domain.Device) value { return float64(d.PacketsCount) },
+	"retries":           func(d *domain.Device) value { return float64(d.RetryCount) },
+	"tx_rate":           func(d *domain.Device) value { return d.TxRate },
+	"rx_rate":           func(d *domain.Device) value { return d.RxRate },
+	"randomized":        func(d *domain.Device) value { return d.IsRandomized },
+	"wifi6":             func(d *domain.Device) value { return d.IsWiFi6 },
+	"wifi7":             func(d *domain.Device) value { return d.IsWiFi7 },
+	"handshake":         func(d *domain.Device) value { return d.HasHandshake },
+	"probes": func(d *domain.Device) value {
+		probes := make([]string, 0, len(d.ProbedSSIDs))
+		for ssid := range d.ProbedSSIDs {
+			probes = append(probes, ssid)
+		}
+		return probes
+	},
+}
+
+// --- Lexer ---
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(src) && (unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i])) || src[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, src[start:i], start})
+		case unicode.IsDigit(c) || (c == '.' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1]))):
+			start := i
+			for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, src[start:i], start})
+		case c == '"' || c == '\'':
+			start := i
+			var sb strings.Builder
+			for i++; i < len(src) && rune(src[i]) != c; i++ {
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+				}
+				sb.WriteByte(src[i])
+			}
+			if i >= len(src) {
+				return nil, fmt.Errorf("%w: unterminated string at %d", ErrSyntax, start)
+			}
+			i++
+			tokens = append(tokens, token{tokString, sb.String(), start})
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", "[", "]", ",", "-"} {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("%w: unexpected character %q at %d", ErrSyntax, c, i)
+			}
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// --- Parser ---
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int // Nested negations, parentheses and calls
+}
+
+// enter descends into a nested expression, refusing more than maxExprDepth.
+// Each enter is paired with a leave.
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxExprDepth {
+		return fmt.Errorf("%w: expression nested deeper than %d", ErrSyntax, maxExprDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() { p.depth-- }
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of the given operators or keywords.
+func (p *parser) accept(texts ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp && tok.kind != tokIdent {
+		return "", false
+	}
+	for _, t := range texts {
+		if tok.text == t {
+			p.pos++
+			return t, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(text string) error {
+	if tok := p.next(); tok.kind != tokOp || tok.text != text {
+		return fmt.Errorf("%w: expected %q at %d", ErrSyntax, text, tok.pos)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logical(left, right, true)
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = logical(left, right, false)
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseNot()
+		p.leave()
+		if err != nil {
+			return nil, err
+		}
+		return func(d *domain.Device) (value, error) {
+			v, err := operand(d)
+			if err != nil {
+				return nil, err
+			}
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("cannot negate %s", typeName(v))
+			}
+			return !b, nil
+		}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<", "<=", ">", ">=", "contains", "startsWith", "endsWith", "matches", "in")
+	if !ok {
+		return left, nil
+	}
+	literal := p.peek()
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if op == "matches" {
+		var pattern *string
+		if literal.kind == tokString {
+			pattern = &literal.text
+		}
+		return matches(left, right, pattern)
+	}
+	return comparison(op, left, right), nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		return number(tok)
+	case tokString:
+		s := tok.text
+		return func(*domain.Device) (value, error) { return s, nil }, nil
+	case tokOp:
+		switch tok.text {
+		case "-":
+			num := p.next()
+			if num.kind != tokNumber {
+				return nil, fmt.Errorf("%w: expected number at %d", ErrSyntax, num.pos)
+			}
+			num.text = "-" + num.text
+			return number(num)
+		case "(":
+			if err := p.enter(); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseOr()
+			p.leave()
+			if err != nil {
+				return nil, err
+			}
+			return inner, p.expect(")")
+		case "[":
+			return p.parseList()
+		}
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			b := tok.text == "true"
+			return func(*domain.Device) (value, error) { return b, nil }, nil
+		}
+		if _, ok := p.accept("("); ok {
+			if err := p.enter(); err != nil {
+				return nil, err
+			}
+			defer p.leave()
+			return p.parseCall(tok)
+		}
+		field, ok := fields[tok.text]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q at %d", ErrSyntax, tok.text, tok.pos)
+		}
+		return func(d *domain.Device) (value, error) { return field(d), nil }, nil
+	}
+	if tok.kind == tokEOF {
+		return nil, fmt.Errorf("%w: unexpected end of expression", ErrSyntax)
+	}
+	return nil, fmt.Errorf("%w: unexpected %q at %d", ErrSyntax, tok.text, tok.pos)
+}
+
+func (p *parser) parseList() (node, error) {
+	var items []string
+	for {
+		if _, ok := p.accept("]"); ok {
+			break
+		}
+		if len(items) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		tok := p.next()
+		if tok.kind != tokString {
+			return nil, fmt.Errorf("%w: lists hold strings, got %q at %d", ErrSyntax, tok.text, tok.pos)
+		}
+		items = append(items, tok.text)
+	}
+	return func(*domain.Device) (value, error) { return items, nil }, nil
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	var args []node
+	for {
+		if _, ok := p.accept(")"); ok {
+			break
+		}
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: %s takes one argument", ErrSyntax, name.text)
+	}
+	arg := args[0]
+
+	switch name.text {
+	case "lower", "upper":
+		conv := strings.ToLower
+		if name.text == "upper" {
+			conv = strings.ToUpper
+		}
+		return func(d *domain.Device) (value, error) {
+			s, err := evalString(arg, d)
+			return conv(s), err
+		}, nil
+	case "len":
+		return func(d *domain.Device) (value, error) {
+			v, err := arg(d)
+			if err != nil {
+				return nil, err
+			}
+			switch x := v.(type) {
+			case string:
+				return float64(len(x)), nil
+			case []string:
+				return float64(len(x)), nil
+			}
+			return nil, fmt.Errorf("len of %s", typeName(v))
+		}, nil
+	case "annotation":
+		return func(d *domain.Device) (value, error) {
+			key, err := evalString(arg, d)
+			return d.Annotations[key], err
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown function %q at %d", ErrSyntax, name.text, name.pos)
+}
+
+// --- Evaluation ---
+
+func number(tok token) (node, error) {
+	f, err := strconv.ParseFloat(tok.text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid number %q at %d", ErrSyntax, tok.text, tok.pos)
+	}
+	return func(*domain.Device) (value, error) { return f, nil }, nil
+}
+
+func logical(left, right node, or bool) node {
+	return func(d *domain.Device) (value, error) {
+		for _, operand := range []node{left, right} {
+			v, err := operand(d)
+			if err != nil {
+				return nil, err
+			}
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("logical operand is %s, not a boolean", typeName(v))
+			}
+			if b == or {
+				return or, nil // Short-circuit
+			}
+		}
+		return !or, nil
+	}
+}
+
+func comparison(op string, left, right node) node {
+	return func(d *domain.Device) (value, error) {
+		l, err := left(d)
+		if err != nil {
+			return nil, err
+		}
+		r, err := right(d)
+		if err != nil {
+			return nil, err
+		}
+		return compare(op, l, r)
+	}
+}
+
+// matches compiles a literal pattern once; other patterns are compiled per evaluation.
+func matches(left, right node, literal *string) (node, error) {
+	var re *regexp.Regexp
+	if literal != nil {
+		var err error
+		if re, err = regexp.Compile(*literal); err != nil {
+			return nil, fmt.Errorf("%w: invalid pattern: %v", ErrSyntax, err)
+		}
+	}
+	return func(d *domain.Device) (value, error) {
+		s, err := evalString(left, d)
+		if err != nil {
+			return nil, err
+		}
+		pattern := re
+		if pattern == nil {
+			p, err := evalString(right, d)
+			if err != nil {
+				return nil, err
+			}
+			if pattern, err = regexp.Compile(p); err != nil {
+				return nil, err
+			}
+		}
+		return pattern.MatchString(s), nil
+	}, nil
+}
+
+func compare(op string, l, r value) (value, error) {
+	switch op {
+	case "==", "!=":
+		eq, err := equal(l, r)
+		if err != nil {
+			return nil, err
+		}
+		return eq == (op == "=="), nil
+	case "contains":
+		switch x := l.(type) {
+		case string:
+			if s, ok := r.(string); ok {
+				return strings.Contains(x, s), nil
+			}
+		case []string:
+			return inList(r, x)
+		}
+	case "in":
+		if list, ok := r.([]string); ok {
+			return inList(l, list)
+		}
+	case "startsWith", "endsWith":
+		ls, lok := l.(string)
+		rs, rok := r.(string)
+		if lok && rok {
+			if op == "startsWith" {
+				return strings.HasPrefix(ls, rs), nil
+			}
+			return strings.HasSuffix(ls, rs), nil
+		}
+	default: // Ordering
+		if lf, ok := l.(float64); ok {
+			if rf, ok := r.(float64); ok {
+				return order(op, lf < rf, lf == rf), nil
+			}
+		}
+		if ls, ok := l.(string); ok {
+			if rs, ok := r.(string); ok {
+				return order(op, ls < rs, ls == rs), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot apply %s to %s and %s", op, typeName(l), typeName(r))
+}
+
+func equal(l, r value) (bool, error) {
+	switch x := l.(type) {
+	case string:
+		if y, ok := r.(string); ok {
+			return x == y, nil
+		}
+	case float64:
+		if y, ok := r.(float64); ok {
+			return x == y, nil
+		}
+	case bool:
+		if y, ok := r.(bool); ok {
+			return x == y, nil
+		}
+	}
+	return false, fmt.Errorf("cannot compare %s and %s", typeName(l), typeName(r))
+}
+
+func order(op string, less, eq bool) bool {
+	switch op {
+	case "<":
+		return less
+	case "<=":
+		return less || eq
+	case ">":
+		return !less && !eq
+	default: // >=
+		return !less
+	}
+}
+
+func inList(v value, list []string) (value, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("lists hold strings, not %s", typeName(v))
+	}
+	for _, item := range list {
+		if item == s {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func evalString(n node, d *domain.Device) (string, error) {
+	v, err := n(d)
+	if err != nil {
+		return "", err
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("expected string, got %s", typeName(v))
+	}
+	return s, nil
+}
+
+func typeName(v value) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []string:
+		return "list"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// --- Templates ---
+
+// Template is a text with ${expression} placeholders.
+type Template struct {
+	parts []templatePart
+}
+
+type templatePart struct {
+	text string
+	expr *Expr
+}
+
+// CompileTemplate parses a template.
+func CompileTemplate(source string) (*Template, error) {
+	t := &Template{}
+	for source != "" {
+		start := strings.Index(source, "${")
+		if start < 0 {
+			t.parts = append(t.parts, templatePart{text: source})
+			break
+		}
+		end := strings.Index(source[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated placeholder", ErrSyntax)
+		}
+		expr, err := Compile(source[start+2 : start+end])
+		if err != nil {
+			return nil, err
+		}
+		t.parts = append(t.parts, templatePart{text: source[:start]}, templatePart{expr: expr})
+		source = source[start+end+1:]
+	}
+	return t, nil
+}
+
+// Render expands the placeholders for a device.
+func (t *Template) Render(d *domain.Device) (string, error) {
+	var sb strings.Builder
+	for _, part := range t.parts {
+		if part.expr == nil {
+			sb.WriteString(part.text)
+			continue
+		}
+		v, err := part.expr.Eval(d)
+		if err != nil {
+			return "", err
+		}
+		switch x := v.(type) {
+		case float64:
+			sb.WriteString(strconv.FormatFloat(x, 'f', -1, 64))
+		case []string:
+			sb.WriteString(strings.Join(x, ", "))
+		default:
+			fmt.Fprint(&sb, x)
+		}
+	}
+	return sb.String(), nil
+}
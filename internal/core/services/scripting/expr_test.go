@@ -0,0 +1,134 @@
+package scripting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDevice() *domain.Device {
+	return &domain.Device{
+		MAC:          "aa:bb:cc:dd:ee:ff",
+		Type:         domain.DeviceTypeStation,
+		Vendor:       "Hikvision",
+		Model:        "DS-2CD Cam",
+		SSID:         "Corp-Guest",
+		RSSI:         -42,
+		Channel:      6,
+		IsRandomized: false,
+		ProbedSSIDs:  map[string]time.Time{"Guest": time.Now(), "Home": time.Now()},
+		Annotations:  map[string]string{"team": "red"},
+	}
+}
+
+func TestExpr_Match(t *testing.T) {
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`vendor == "Hikvision" && !randomized`, true},
+		{`vendor != 'Hikvision'`, false},
+		{`ssid matches "^Corp-" and rssi > -50`, true},
+		{`rssi >= -42 && rssi <= -42 && rssi < 0`, true},
+		{`"Guest" in probes`, true},
+		{`probes contains "Office"`, false},
+		{`ssid in ["Corp-Guest", "Other"]`, true},
+		{`lower(model) contains "cam"`, true},
+		{`upper(vendor) startsWith "HIK" or false`, true},
+		{`ssid endsWith "Guest"`, true},
+		{`len(probes) == 2 && len(mac) == 17`, true},
+		{`annotation("team") == "red" && annotation("missing") == ""`, true},
+		{`not (channel == 6 || channel == 11)`, false},
+		{`type == "station" && wifi6 == false`, true},
+		{`ssid matches vendor`, false},
+	}
+	for _, tt := range tests {
+		expr, err := Compile(tt.expr)
+		require.NoError(t, err, tt.expr)
+		got, err := expr.Match(testDevice())
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, got, tt.expr)
+	}
+}
+
+func TestExpr_CompileErrors(t *testing.T) {
+	for _, src := range []string{
+		`vendor ==`,
+		`unknown_field == 1`,
+		`nope("x")`,
+		`lower("a", "b")`,
+		`ssid == "unterminated`,
+		`(vendor == "x"`,
+		`ssid matches "("`,
+		`vendor == "x" extra`,
+		`rssi @ 3`,
+		`[1, 2]`,
+		strings.Repeat("(", 100) + "true" + strings.Repeat(")", 100),
+		strings.Repeat("!", 100) + "true",
+		strings.Repeat("lower(", 100) + "ssid" + strings.Repeat(")", 100),
+		`ssid == "` + strings.Repeat("x", maxExprLength) + `"`,
+	} {
+		_, err := Compile(src)
+		assert.ErrorIs(t, err, ErrSyntax, src)
+	}
+
+	_, err := Compile(strings.Repeat("(", maxExprDepth) + "true" + strings.Repeat(")", maxExprDepth))
+	assert.NoError(t, err, "nesting up to the limit compiles")
+}
+
+// FuzzCompile checks that no expression panics the compiler, and that
+// compiled ones evaluate without panicking.
+func FuzzCompile(f *testing.F) {
+	f.Add(`vendor == "Hikvision" && !randomized`)
+	f.Add(`ssid matches "^Corp-" and rssi > -50`)
+	f.Add(`"Guest" in probes || annotation("team") == "red"`)
+	f.Add(`len(probes) >= 2 and lower(model) contains "cam"`)
+	f.Add(`ssid in ["a", 'b']`)
+
+	f.Fuzz(func(t *testing.T, src string) {
+		expr, err := Compile(src)
+		if err != nil {
+			return
+		}
+		expr.Eval(testDevice())
+	})
+}
+
+func TestExpr_TypeErrors(t *testing.T) {
+	for _, src := range []string{
+		`vendor`,         // Not a boolean
+		`rssi == "x"`,    // Mixed types
+		`!vendor`,        // Negating a string
+		`rssi && true`,   // Logical on a number
+		`len(rssi) == 1`, // len of a number
+		`rssi in probes`, // Number in a list
+	} {
+		expr, err := Compile(src)
+		require.NoError(t, err, src)
+		_, err = expr.Match(testDevice())
+		assert.Error(t, err, src)
+	}
+}
+
+func TestTemplate_Render(t *testing.T) {
+	tmpl, err := CompileTemplate(`${vendor} camera on ${ssid} (${rssi} dBm, ${len(probes)} probes)`)
+	require.NoError(t, err)
+	out, err := tmpl.Render(testDevice())
+	require.NoError(t, err)
+	assert.Equal(t, "Hikvision camera on Corp-Guest (-42 dBm, 2 probes)", out)
+
+	plain, err := CompileTemplate("iot")
+	require.NoError(t, err)
+	out, err = plain.Render(testDevice())
+	require.NoError(t, err)
+	assert.Equal(t, "iot", out)
+
+	_, err = CompileTemplate("${vendor")
+	assert.ErrorIs(t, err, ErrSyntax)
+	_, err = CompileTemplate("${nope}")
+	assert.ErrorIs(t, err, ErrSyntax)
+}
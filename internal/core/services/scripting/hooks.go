@@ -0,0 +1,322 @@
+package scripting
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+const (
+	// hookRefresh bounds how long cached hooks are trusted, so the engine
+	// follows workspace switches without a storage read per packet.
+	hookRefresh = 10 * time.Second
+	// hookAlertCooldown is the minimum time between two alerts of the same
+	// hook for the same device.
+	hookAlertCooldown = 10 * time.Minute
+)
+
+// compiledHook is a hook with its expressions compiled.
+type compiledHook struct {
+	hook      domain.ScriptHook
+	condition *Expr
+	templates []*Template // One per action
+}
+
+// HookEngine runs the scripting hooks of the active workspace on every device
+// update. Hooks can annotate the device, overwrite its vendor, model or OS,
+// and raise alerts. It is a security engine detector: changes to the device
+// are written back to the registry and alerts join the engine's history.
+type HookEngine struct {
+	store    ports.HookRepository
+	hooks    []compiledHook
+	loadedAt time.Time
+	failed   map[string]bool      // Hooks whose evaluation error was logged since the last refresh
+	alerted  map[string]time.Time // hook ID|MAC -> last alert
+	mu       sync.Mutex
+}
+
+// NewHookEngine creates an engine that stays idle until a store is set.
+func NewHookEngine() *HookEngine {
+	return &HookEngine{
+		failed:  make(map[string]bool),
+		alerted: make(map[string]time.Time),
+	}
+}
+
+// SetStore sets the storage holding the per-workspace hooks.
+func (e *HookEngine) SetStore(store ports.HookRepository) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.store = store
+	e.loadedAt = time.Time{}
+}
+
+func (e *HookEngine) Name() string { return "ScriptHooks" }
+
+// ListHooks returns the hooks of the active workspace.
+func (e *HookEngine) ListHooks(ctx context.Context) ([]domain.ScriptHook, error) {
+	store, err := e.storage()
+	if err != nil {
+		return nil, err
+	}
+	return store.ListHooks(ctx)
+}
+
+// CreateHook validates, compiles and stores a new hook.
+func (e *HookEngine) CreateHook(ctx context.Context, hook domain.ScriptHook) (domain.ScriptHook, error) {
+	hook.ID = uuid.New().String()
+	hook.CreatedAt = time.Now()
+	return e.save(ctx, hook)
+}
+
+// UpdateHook replaces an existing hook.
+func (e *HookEngine) UpdateHook(ctx context.Context, id string, hook domain.ScriptHook) (domain.ScriptHook, error) {
+	existing, err := e.find(ctx, id)
+	if err != nil {
+		return domain.ScriptHook{}, err
+	}
+	hook.ID = id
+	hook.CreatedAt = existing.CreatedAt
+	return e.save(ctx, hook)
+}
+
+// DeleteHook removes a hook by ID.
+func (e *HookEngine) DeleteHook(ctx context.Context, id string) error {
+	store, err := e.storage()
+	if err != nil {
+		return err
+	}
+	if err := store.DeleteHook(ctx, id); err != nil {
+		return err
+	}
+	e.invalidate()
+	return nil
+}
+
+// Analyze runs the enabled hooks against a device update.
+func (e *HookEngine) Analyze(device *domain.Device, registry ports.DeviceRegistry) []domain.Alert {
+	e.mu.Lock()
+	// On a load error keep the cached hooks and retry after the refresh interval
+	if err := e.refresh(context.Background()); err != nil {
+		e.loadedAt = time.Now()
+	}
+	hooks := e.hooks
+	e.mu.Unlock()
+
+	var alerts []domain.Alert
+	changed := false
+	for i := range hooks {
+		h := &hooks[i]
+		if !h.hook.Enabled {
+			continue
+		}
+		matched, err := h.condition.Match(device)
+		if err == nil && matched {
+			var fired []domain.Alert
+			var modified bool
+			fired, modified, err = e.run(h, device)
+			alerts = append(alerts, fired...)
+			changed = changed || modified
+		}
+		if err != nil {
+			e.reportError(h.hook, err)
+		}
+	}
+
+	if changed && registry != nil {
+		registry.LoadDevice(context.Background(), *device)
+	}
+	return alerts
+}
+
+// run applies the actions of a matching hook to the device.
+func (e *HookEngine) run(h *compiledHook, device *domain.Device) ([]domain.Alert, bool, error) {
+	var alerts []domain.Alert
+	changed := false
+	for i, action := range h.hook.Actions {
+		value, err := h.templates[i].Render(device)
+		if err != nil {
+			return alerts, changed, err
+		}
+
+		switch action.Type {
+		case domain.HookAnnotate:
+			if current, ok := device.Annotations[action.Key]; ok && current == value {
+				continue
+			}
+			// Copied rather than updated in place: the map is shared with the registry
+			annotations := make(map[string]string, len(device.Annotations)+1)
+			for k, v := range device.Annotations {
+				annotations[k] = v
+			}
+			annotations[action.Key] = value
+			device.Annotations = annotations
+			changed = true
+
+		case domain.HookSet:
+			field := map[string]*string{"vendor": &device.Vendor, "model": &device.Model, "os": &device.OS}[action.Key]
+			if field != nil && *field != value {
+				*field = value
+				changed = true
+			}
+
+		case domain.HookAlert:
+			if !e.shouldAlert(h.hook.ID, device.MAC) {
+				continue
+			}
+			alerts = append(alerts, hookAlert(h.hook, action, device.MAC, value))
+		}
+	}
+	return alerts, changed, nil
+}
+
+// shouldAlert enforces the per-device cooldown of a hook's alerts.
+func (e *HookEngine) shouldAlert(hookID, mac string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	key := hookID + "|" + mac
+	now := time.Now()
+	if last, ok := e.alerted[key]; ok && now.Sub(last) < hookAlertCooldown {
+		return false
+	}
+	e.alerted[key] = now
+	return true
+}
+
+func hookAlert(hook domain.ScriptHook, action domain.HookAction, mac, message string) domain.Alert {
+	severity := action.Severity
+	if severity == "" {
+		severity = domain.SeverityMedium
+	}
+	subtype := action.Subtype
+	if subtype == "" {
+		subtype = "SCRIPT_HOOK"
+	}
+	return domain.Alert{
+		ID:        fmt.Sprintf("alt_%d", time.Now().UnixNano()),
+		RuleID:    "hook:" + hook.ID,
+		Type:      domain.AlertAnomaly,
+		Subtype:   subtype,
+		Severity:  severity,
+		DeviceMAC: mac,
+		Message:   message,
+		Details:   "Hook: " + hook.Name,
+		Timestamp: time.Now(),
+	}
+}
+
+// reportError logs a hook evaluation error once per refresh.
+func (e *HookEngine) reportError(hook domain.ScriptHook, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.failed[hook.ID] {
+		return
+	}
+	e.failed[hook.ID] = true
+	log.Printf("[HOOKS] Hook %q failed: %v", hook.Name, err)
+}
+
+// save validates, compiles and stores a hook.
+func (e *HookEngine) save(ctx context.Context, hook domain.ScriptHook) (domain.ScriptHook, error) {
+	if err := hook.Validate(); err != nil {
+		return domain.ScriptHook{}, err
+	}
+	if _, err := compile(hook); err != nil {
+		return domain.ScriptHook{}, fmt.Errorf("%w: %v", domain.ErrInvalidHookScript, err)
+	}
+	store, err := e.storage()
+	if err != nil {
+		return domain.ScriptHook{}, err
+	}
+	hook.UpdatedAt = time.Now()
+	if err := store.SaveHook(ctx, hook); err != nil {
+		return domain.ScriptHook{}, err
+	}
+	e.invalidate()
+	return hook, nil
+}
+
+func (e *HookEngine) find(ctx context.Context, id string) (domain.ScriptHook, error) {
+	hooks, err := e.ListHooks(ctx)
+	if err != nil {
+		return domain.ScriptHook{}, err
+	}
+	for _, h := range hooks {
+		if h.ID == id {
+			return h, nil
+		}
+	}
+	return domain.ScriptHook{}, domain.ErrHookNotFound
+}
+
+func (e *HookEngine) storage() (ports.HookRepository, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.store == nil {
+		return nil, fmt.Errorf("hook storage not configured")
+	}
+	return e.store, nil
+}
+
+// invalidate forces the hooks to be reloaded on the next update.
+func (e *HookEngine) invalidate() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.loadedAt = time.Time{}
+}
+
+// refresh reloads and compiles the hooks of the active workspace when the
+// cache is stale. Caller must hold e.mu.
+func (e *HookEngine) refresh(ctx context.Context) error {
+	if e.store == nil || time.Since(e.loadedAt) < hookRefresh {
+		return nil
+	}
+	hooks, err := e.store.ListHooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	compiled := make([]compiledHook, 0, len(hooks))
+	for _, h := range hooks {
+		c, err := compile(h)
+		if err != nil {
+			log.Printf("[HOOKS] Skipping hook %q: %v", h.Name, err)
+			continue
+		}
+		compiled = append(compiled, c)
+	}
+	e.hooks = compiled
+	e.loadedAt = time.Now()
+	e.failed = make(map[string]bool)
+
+	now := time.Now()
+	for key, last := range e.alerted {
+		if now.Sub(last) >= hookAlertCooldown {
+			delete(e.alerted, key)
+		}
+	}
+	return nil
+}
+
+// compile compiles the condition and action templates of a hook.
+func compile(hook domain.ScriptHook) (compiledHook, error) {
+	condition, err := Compile(hook.Condition)
+	if err != nil {
+		return compiledHook{}, fmt.Errorf("condition: %w", err)
+	}
+	c := compiledHook{hook: hook, condition: condition}
+	for i, action := range hook.Actions {
+		tmpl, err := CompileTemplate(action.Value)
+		if err != nil {
+			return compiledHook{}, fmt.Errorf("action %d: %w", i, err)
+		}
+		c.templates = append(c.templates, tmpl)
+	}
+	return c, nil
+}
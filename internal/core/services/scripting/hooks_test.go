@@ -0,0 +1,137 @@
+package scripting
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryHookStore is an in-memory ports.HookRepository.
+type memoryHookStore struct {
+	mu    sync.Mutex
+	hooks []domain.ScriptHook
+}
+
+func (s *memoryHookStore) ListHooks(ctx context.Context) ([]domain.ScriptHook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]domain.ScriptHook(nil), s.hooks...), nil
+}
+
+func (s *memoryHookStore) SaveHook(ctx context.Context, hook domain.ScriptHook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.hooks {
+		if s.hooks[i].ID == hook.ID {
+			s.hooks[i] = hook
+			return nil
+		}
+	}
+	s.hooks = append(s.hooks, hook)
+	return nil
+}
+
+func (s *memoryHookStore) DeleteHook(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.hooks {
+		if s.hooks[i].ID == id {
+			s.hooks = append(s.hooks[:i], s.hooks[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrHookNotFound
+}
+
+func TestHookEngine_Actions(t *testing.T) {
+	engine := NewHookEngine()
+	engine.SetStore(&memoryHookStore{})
+	ctx := context.Background()
+
+	hook, err := engine.CreateHook(ctx, domain.ScriptHook{
+		Name:      "Cameras",
+		Condition: `vendor == "Hikvision"`,
+		Enabled:   true,
+		Actions: []domain.HookAction{
+			{Type: domain.HookAnnotate, Key: "class", Value: "camera"},
+			{Type: domain.HookSet, Key: "model", Value: "${vendor} IP camera"},
+			{Type: domain.HookAlert, Value: "Camera ${mac} on ${ssid}", Severity: domain.SeverityHigh, Subtype: "CAMERA"},
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, hook.ID)
+
+	device := &domain.Device{MAC: "aa:bb:cc:dd:ee:ff", Vendor: "Hikvision", SSID: "Lab"}
+	alerts := engine.Analyze(device, nil)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "CAMERA", alerts[0].Subtype)
+	assert.Equal(t, domain.SeverityHigh, alerts[0].Severity)
+	assert.Equal(t, "Camera aa:bb:cc:dd:ee:ff on Lab", alerts[0].Message)
+	assert.Equal(t, "hook:"+hook.ID, alerts[0].RuleID)
+	assert.Equal(t, "camera", device.Annotations["class"])
+	assert.Equal(t, "Hikvision IP camera", device.Model)
+
+	// Alerts of a hook are throttled per device
+	assert.Empty(t, engine.Analyze(device, nil))
+
+	other := &domain.Device{MAC: "11:22:33:44:55:66", Vendor: "Acme"}
+	assert.Empty(t, engine.Analyze(other, nil))
+	assert.Empty(t, other.Annotations)
+}
+
+func TestHookEngine_DisabledAndBrokenHooks(t *testing.T) {
+	engine := NewHookEngine()
+	store := &memoryHookStore{}
+	engine.SetStore(store)
+	ctx := context.Background()
+
+	_, err := engine.CreateHook(ctx, domain.ScriptHook{
+		Name: "Broken", Condition: `vendor ==`, Enabled: true,
+		Actions: []domain.HookAction{{Type: domain.HookAlert, Value: "x"}},
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidHookScript)
+
+	_, err = engine.CreateHook(ctx, domain.ScriptHook{
+		Name: "Bad field", Condition: `true`, Enabled: true,
+		Actions: []domain.HookAction{{Type: domain.HookSet, Key: "mac", Value: "x"}},
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidHookSetField)
+
+	hook, err := engine.CreateHook(ctx, domain.ScriptHook{
+		Name: "Off", Condition: `true`,
+		Actions: []domain.HookAction{{Type: domain.HookAlert, Value: "x"}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, engine.Analyze(&domain.Device{MAC: "aa:bb:cc:dd:ee:ff"}, nil))
+
+	// Enabling it through an update takes effect right away
+	hook.Enabled = true
+	_, err = engine.UpdateHook(ctx, hook.ID, hook)
+	require.NoError(t, err)
+	assert.Len(t, engine.Analyze(&domain.Device{MAC: "aa:bb:cc:dd:ee:ff"}, nil), 1)
+
+	// A runtime type error skips the hook
+	hook.Condition = `vendor`
+	_, err = engine.UpdateHook(ctx, hook.ID, hook)
+	require.NoError(t, err)
+	assert.Empty(t, engine.Analyze(&domain.Device{MAC: "11:22:33:44:55:66"}, nil))
+
+	_, err = engine.UpdateHook(ctx, "missing", hook)
+	assert.ErrorIs(t, err, domain.ErrHookNotFound)
+
+	require.NoError(t, engine.DeleteHook(ctx, hook.ID))
+	hooks, err := engine.ListHooks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, hooks)
+}
+
+func TestHookEngine_NoStore(t *testing.T) {
+	engine := NewHookEngine()
+	assert.Empty(t, engine.Analyze(&domain.Device{MAC: "aa:bb:cc:dd:ee:ff"}, nil))
+	_, err := engine.ListHooks(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,164 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// baselineRefresh bounds how long a cached configuration is trusted, so the
+// detector follows workspace switches without a storage read per packet.
+const baselineRefresh = 10 * time.Second
+
+// BaselineDetector raises a NEW_DEVICE alert, once per device, for any AP or
+// client first seen after the workspace's learning period and not listed in
+// the trusted inventory.
+type BaselineDetector struct {
+	store     ports.BaselineRepository
+	inventory ports.AssetInventory
+	config    domain.BaselineConfig
+	loadedAt  time.Time
+	alerted   map[string]struct{}
+	mu        sync.Mutex
+}
+
+// NewBaselineDetector creates a detector that stays disabled until a store is set.
+func NewBaselineDetector() *BaselineDetector {
+	return &BaselineDetector{
+		alerted: make(map[string]struct{}),
+	}
+}
+
+func (d *BaselineDetector) Name() string { return "BaselineDetector" }
+
+// SetStore sets the storage holding the per-workspace configuration.
+func (d *BaselineDetector) SetStore(store ports.BaselineRepository) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.store = store
+	d.loadedAt = time.Time{}
+}
+
+// SetInventory sets the trusted inventory of known devices.
+func (d *BaselineDetector) SetInventory(inventory ports.AssetInventory) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.inventory = inventory
+}
+
+// Config returns the configuration of the active workspace.
+func (d *BaselineDetector) Config(ctx context.Context) (domain.BaselineConfig, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.refresh(ctx, true); err != nil {
+		return domain.BaselineConfig{}, err
+	}
+	return d.config, nil
+}
+
+// SetConfig validates and stores a configuration. Enabling a disabled baseline
+// starts a new learning period; an enabled one keeps its start unless the
+// caller provides one.
+func (d *BaselineDetector) SetConfig(ctx context.Context, config domain.BaselineConfig) (domain.BaselineConfig, error) {
+	if err := config.Validate(); err != nil {
+		return domain.BaselineConfig{}, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.store == nil {
+		return domain.BaselineConfig{}, fmt.Errorf("baseline storage not configured")
+	}
+	if err := d.refresh(ctx, true); err != nil {
+		return domain.BaselineConfig{}, err
+	}
+
+	if config.Enabled && config.StartedAt.IsZero() {
+		if d.config.Enabled {
+			config.StartedAt = d.config.StartedAt
+		} else {
+			config.StartedAt = time.Now()
+		}
+	}
+	if err := d.store.SaveBaseline(ctx, config); err != nil {
+		return domain.BaselineConfig{}, err
+	}
+
+	if !config.StartedAt.Equal(d.config.StartedAt) {
+		d.alerted = make(map[string]struct{})
+	}
+	d.config = config
+	d.loadedAt = time.Now()
+	return config, nil
+}
+
+// refresh reloads the configuration if the cache is stale or force is set.
+// A baseline that started a new learning period forgets alerted devices.
+// Caller must hold d.mu.
+func (d *BaselineDetector) refresh(ctx context.Context, force bool) error {
+	if d.store == nil {
+		return nil
+	}
+	if !force && time.Since(d.loadedAt) < baselineRefresh {
+		return nil
+	}
+
+	config, err := d.store.GetBaseline(ctx)
+	if err != nil {
+		return err
+	}
+	if !config.StartedAt.Equal(d.config.StartedAt) {
+		d.alerted = make(map[string]struct{})
+	}
+	d.config = config
+	d.loadedAt = time.Now()
+	return nil
+}
+
+func (d *BaselineDetector) Analyze(device *domain.Device, _ ports.DeviceRegistry) []domain.Alert {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// On a load error keep the cached configuration and retry after the refresh interval
+	if err := d.refresh(context.Background(), false); err != nil {
+		d.loadedAt = time.Now()
+	}
+
+	if !d.config.IsNewDevice(*device) {
+		return nil
+	}
+	if _, ok := d.alerted[device.MAC]; ok {
+		return nil
+	}
+	if d.inventory != nil {
+		if _, ok := d.inventory.Lookup(device.MAC); ok {
+			return nil
+		}
+	}
+	d.alerted[device.MAC] = struct{}{}
+
+	kind := "client"
+	if device.IsAP() {
+		kind = "access point"
+	}
+	details := fmt.Sprintf("First seen %s, after baseline learned at %s",
+		device.FirstSeen.Format(time.RFC3339), d.config.LearnedAt().Format(time.RFC3339))
+	if device.SSID != "" {
+		details += fmt.Sprintf(", SSID %q", device.SSID)
+	}
+
+	return []domain.Alert{{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "NEW_DEVICE",
+		Severity:  domain.SeverityMedium,
+		Message:   "New " + kind + " not in baseline: " + device.MAC,
+		Details:   details,
+		DeviceMAC: device.MAC,
+		Timestamp: time.Now(),
+	}}
+}
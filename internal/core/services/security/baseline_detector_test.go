@@ -0,0 +1,105 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryBaselineStore struct {
+	config domain.BaselineConfig
+}
+
+func (s *memoryBaselineStore) GetBaseline(ctx context.Context) (domain.BaselineConfig, error) {
+	return s.config, nil
+}
+
+func (s *memoryBaselineStore) SaveBaseline(ctx context.Context, config domain.BaselineConfig) error {
+	s.config = config
+	return nil
+}
+
+func TestBaselineDetector(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryBaselineStore{}
+	detector := NewBaselineDetector()
+
+	device := func(mac string, dtype domain.DeviceType, firstSeen time.Time, randomized bool) *domain.Device {
+		return &domain.Device{MAC: mac, Type: dtype, FirstSeen: firstSeen, IsRandomized: randomized}
+	}
+	now := time.Now()
+
+	t.Run("Disabled without store", func(t *testing.T) {
+		assert.Empty(t, detector.Analyze(device("00:00:00:00:00:01", domain.DeviceTypeAP, now, false), nil))
+		_, err := detector.SetConfig(ctx, domain.BaselineConfig{Enabled: true, LearningPeriod: time.Hour})
+		assert.Error(t, err)
+	})
+
+	detector.SetStore(store)
+
+	t.Run("Invalid learning period", func(t *testing.T) {
+		_, err := detector.SetConfig(ctx, domain.BaselineConfig{Enabled: true})
+		assert.ErrorIs(t, err, domain.ErrInvalidLearningPeriod)
+	})
+
+	t.Run("Enabling starts learning", func(t *testing.T) {
+		config, err := detector.SetConfig(ctx, domain.BaselineConfig{Enabled: true, LearningPeriod: time.Hour})
+		require.NoError(t, err)
+		assert.WithinDuration(t, now, config.StartedAt, time.Second)
+		assert.True(t, config.IsLearning(time.Now()))
+		assert.Equal(t, config, store.config)
+
+		// Devices appearing during the learning period are part of the baseline
+		assert.Empty(t, detector.Analyze(device("00:00:00:00:00:02", domain.DeviceTypeAP, now, false), nil))
+
+		// Updating the sensitivity keeps the learning period running
+		updated, err := detector.SetConfig(ctx, domain.BaselineConfig{Enabled: true, LearningPeriod: time.Hour, APsOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, config.StartedAt, updated.StartedAt)
+	})
+
+	// Learned baseline: started two hours ago
+	_, err := detector.SetConfig(ctx, domain.BaselineConfig{
+		Enabled:          true,
+		LearningPeriod:   time.Hour,
+		StartedAt:        now.Add(-2 * time.Hour),
+		IgnoreRandomized: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("New devices alert once", func(t *testing.T) {
+		ap := device("00:11:22:33:44:55", domain.DeviceTypeAP, now, false)
+		alerts := detector.Analyze(ap, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "NEW_DEVICE", alerts[0].Subtype)
+		assert.Equal(t, ap.MAC, alerts[0].DeviceMAC)
+		assert.Empty(t, detector.Analyze(ap, nil))
+
+		assert.Len(t, detector.Analyze(device("00:11:22:33:44:66", domain.DeviceTypeStation, now, false), nil), 1)
+	})
+
+	t.Run("Baseline devices are ignored", func(t *testing.T) {
+		old := device("00:11:22:33:44:77", domain.DeviceTypeAP, now.Add(-90*time.Minute), false)
+		assert.Empty(t, detector.Analyze(old, nil))
+	})
+
+	t.Run("Randomized clients are ignored", func(t *testing.T) {
+		assert.Empty(t, detector.Analyze(device("da:11:22:33:44:88", domain.DeviceTypeStation, now, true), nil))
+	})
+
+	t.Run("Inventoried devices are known", func(t *testing.T) {
+		detector.SetInventory(domain.NewInventory([]domain.InventoryEntry{{MAC: "00-11-22-33-44-AA"}}))
+		defer detector.SetInventory(nil)
+		assert.Empty(t, detector.Analyze(device("00:11:22:33:44:aa", domain.DeviceTypeAP, now, false), nil))
+	})
+
+	t.Run("APs only", func(t *testing.T) {
+		_, err := detector.SetConfig(ctx, domain.BaselineConfig{Enabled: true, LearningPeriod: time.Hour, APsOnly: true})
+		require.NoError(t, err)
+		assert.Empty(t, detector.Analyze(device("00:11:22:33:44:99", domain.DeviceTypeStation, now, false), nil))
+	})
+}
@@ -26,6 +26,16 @@ func detectClientVulnerabilities(device *domain.Device) []domain.VulnerabilityTa
 		tags = append(tags, *vulnTag)
 	}
 
+	// 4. Key Reinstallation (KRACK)
+	if vulnTag := detectKeyReinstallation(device); vulnTag != nil {
+		tags = append(tags, *vulnTag)
+	}
+
+	// 5. Weak Handshake Nonces
+	if vulnTag := detectWeakNonces(device); vulnTag != nil {
+		tags = append(tags, *vulnTag)
+	}
+
 	return tags
 }
 
@@ -127,3 +137,21 @@ func detectLegacyProtocolSupport(device *domain.Device) *domain.VulnerabilityTag
 
 	return nil
 }
+
+// detectKeyReinstallation reports clients seen reusing packet numbers after
+// their AP retransmitted an EAPOL key message
+func detectKeyReinstallation(device *domain.Device) *domain.VulnerabilityTag {
+	if len(device.KeyReinstallation) == 0 {
+		return nil
+	}
+	return &domain.VulnerabilityTag{
+		Name:        "KRACK",
+		Severity:    domain.VulnSeverityHigh,
+		Confidence:  domain.ConfidenceHigh,
+		Evidence:    device.KeyReinstallation,
+		DetectedAt:  time.Now(),
+		Category:    "protocol",
+		Description: "Client reinstalls its key on retransmitted EAPOL messages, reusing nonces (CVE-2017-13077, CVE-2017-13080) - traffic can be decrypted and replayed",
+		Mitigation:  "Update the client's WPA supplicant or firmware",
+	}
+}
@@ -17,6 +17,11 @@ func detectConfigurationVulnerabilitiesEnhanced(device *domain.Device, vendorDB
 		tags = append(tags, *vulnTag)
 	}
 
+	// Open networks confirmed to give Internet access without a login
+	if vulnTag := detectOpenEgress(device); vulnTag != nil {
+		tags = append(tags, *vulnTag)
+	}
+
 	// 2. Default SSID Detection
 	if vendorDB != nil {
 		if vulnTag := detectDefaultSSID(device, vendorDB); vulnTag != nil {
@@ -57,6 +62,26 @@ func detectOpenNetwork(device *domain.Device) *domain.VulnerabilityTag {
 	return nil
 }
 
+// detectOpenEgress flags open networks where a captive portal check reached
+// the Internet without any login: anyone in range gets free, unattributed
+// access through them.
+func detectOpenEgress(device *domain.Device) *domain.VulnerabilityTag {
+	if device.Portal == nil || device.Portal.Result != domain.PortalOpenEgress || !device.IsOpenNetwork() {
+		return nil
+	}
+	return &domain.VulnerabilityTag{
+		Name:       "OPEN-EGRESS",
+		Severity:   domain.VulnSeverityCritical,
+		Confidence: domain.ConfidenceConfirmed,
+		Evidence: []string{fmt.Sprintf("Associated via %s and reached the Internet without a login at %s",
+			device.Portal.Interface, device.Portal.CheckedAt.Format(time.RFC3339))},
+		DetectedAt:  time.Now(),
+		Category:    "configuration",
+		Description: "Open network gives Internet access to anyone in range, without a captive portal or any other login",
+		Mitigation:  "Require WPA2/WPA3 authentication or put the network behind a captive portal",
+	}
+}
+
 // detectDefaultSSID identifies networks using default manufacturer SSIDs
 func detectDefaultSSID(device *domain.Device, vendorDB *VendorDatabase) *domain.VulnerabilityTag {
 	if device.Type != domain.DeviceTypeAP || device.SSID == "" {
@@ -142,3 +167,34 @@ func detectWeakCipherSuites(device *domain.Device) *domain.VulnerabilityTag {
 
 	return nil
 }
+
+// detectRegulatoryMismatch flags APs advertising a Country element that
+// differs from the local regulatory domain: they may use channels or power
+// levels that are not legal here.
+func detectRegulatoryMismatch(device *domain.Device, local string) *domain.VulnerabilityTag {
+	if local == "" || local == "00" || device.Country == "" || device.Country == "00" {
+		return nil
+	}
+	if !domain.IsValidRegDomain(device.Country) || device.Country == local {
+		return nil
+	}
+
+	evidence := []string{
+		fmt.Sprintf("Advertised country: %s", device.Country),
+		fmt.Sprintf("Local regulatory domain: %s", local),
+	}
+	if device.MaxTxPower != 0 {
+		evidence = append(evidence, fmt.Sprintf("Advertised max TX power: %d dBm on channel %d", device.MaxTxPower, device.Channel))
+	}
+
+	return &domain.VulnerabilityTag{
+		Name:        "REGDOMAIN-MISMATCH",
+		Severity:    domain.VulnSeverityLow,
+		Confidence:  domain.ConfidenceHigh,
+		Evidence:    evidence,
+		DetectedAt:  time.Now(),
+		Category:    "configuration",
+		Description: "AP advertises a regulatory country different from the local one and may transmit on illegal channels or power levels",
+		Mitigation:  "Set the AP country code to the country where it is deployed",
+	}
+}
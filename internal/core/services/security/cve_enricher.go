@@ -0,0 +1,125 @@
+package security
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// maxCachedCVEs bounds the CVE records kept in memory by the enricher.
+const maxCachedCVEs = 4096
+
+// cveRetryAfter is how long a CVE that could not be looked up is left
+// unscored before the database is asked again.
+const cveRetryAfter = 10 * time.Minute
+
+// CVEEnricher implements ports.AlertEnricher with the CVEs the vulnerability
+// detector mapped to the devices, scored from the CVE database.
+type CVEEnricher struct {
+	registry ports.DeviceRegistry
+	repo     ports.CVERepository
+
+	mu     sync.Mutex
+	cache  map[string]domain.AlertCVE // By CVE ID, CVSS 0 when not in the database
+	failed map[string]time.Time       // CVE IDs whose lookup failed, by time of failure
+}
+
+// NewCVEEnricher creates an enricher reading devices from registry and CVE
+// scores from repo.
+func NewCVEEnricher(registry ports.DeviceRegistry, repo ports.CVERepository) *CVEEnricher {
+	return &CVEEnricher{
+		registry: registry,
+		repo:     repo,
+		cache:    make(map[string]domain.AlertCVE),
+		failed:   make(map[string]time.Time),
+	}
+}
+
+// EnrichAlert sets the top CVEs, by CVSS, of the device raising the alert and,
+// when it names another one, of the BSSID it concerns.
+func (e *CVEEnricher) EnrichAlert(ctx context.Context, alert *domain.Alert) {
+	if len(alert.CVEs) > 0 {
+		return
+	}
+
+	seen := make(map[string]bool)
+	var cves []domain.AlertCVE
+	for _, mac := range []string{alert.DeviceMAC, alert.BSSID} {
+		if mac == "" {
+			continue
+		}
+		device, ok := e.registry.GetDevice(ctx, mac)
+		if !ok {
+			continue
+		}
+		for _, v := range device.Vulnerabilities {
+			if v.Category != "cve" || seen[v.Name] {
+				continue
+			}
+			seen[v.Name] = true
+			cves = append(cves, e.lookup(ctx, v.Name))
+		}
+	}
+	if len(cves) == 0 {
+		return
+	}
+
+	sort.Slice(cves, func(i, j int) bool {
+		if cves[i].CVSS != cves[j].CVSS {
+			return cves[i].CVSS > cves[j].CVSS
+		}
+		return cves[i].ID < cves[j].ID
+	})
+	if len(cves) > domain.MaxAlertCVEs {
+		cves = cves[:domain.MaxAlertCVEs]
+	}
+	alert.CVEs = cves
+}
+
+// lookup returns the score of a CVE, from the cache or the database. A
+// failed lookup is retried, and logged, once cveRetryAfter has passed.
+func (e *CVEEnricher) lookup(ctx context.Context, id string) domain.AlertCVE {
+	id = strings.ToUpper(id)
+	result := domain.AlertCVE{ID: id}
+	e.mu.Lock()
+	cached, ok := e.cache[id]
+	failedAt, failed := e.failed[id]
+	e.mu.Unlock()
+	if ok {
+		return cached
+	}
+	if failed && time.Since(failedAt) < cveRetryAfter {
+		return result
+	}
+
+	record, err := e.repo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Warning: could not look up %s: %v", id, err)
+		e.mu.Lock()
+		if len(e.failed) >= maxCachedCVEs {
+			e.failed = make(map[string]time.Time)
+		}
+		e.failed[id] = time.Now()
+		e.mu.Unlock()
+		return result
+	}
+	if record != nil {
+		result.CVSS = record.Severity
+		result.CVSSVector = record.CVSSVector
+	}
+
+	e.mu.Lock()
+	if len(e.cache) >= maxCachedCVEs {
+		e.cache = make(map[string]domain.AlertCVE)
+	}
+	e.cache[id] = result
+	delete(e.failed, id)
+	e.mu.Unlock()
+	return result
+}
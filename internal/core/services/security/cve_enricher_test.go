@@ -0,0 +1,84 @@
+package security_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cveScores serves CVE records by ID, counting the lookups.
+type cveScores struct {
+	ports.CVERepository
+	records map[string]domain.CVERecord
+	lookups int
+	err     error
+}
+
+func (r *cveScores) GetByID(ctx context.Context, id string) (*domain.CVERecord, error) {
+	r.lookups++
+	if r.err != nil {
+		return nil, r.err
+	}
+	record, ok := r.records[id]
+	if !ok {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func TestCVEEnricher_EnrichAlert(t *testing.T) {
+	cveTag := func(id string) domain.VulnerabilityTag {
+		return domain.VulnerabilityTag{Name: id, Category: "cve"}
+	}
+	ap := domain.Device{MAC: "aa:bb:cc:dd:ee:ff", Type: domain.DeviceTypeAP, Vulnerabilities: []domain.VulnerabilityTag{
+		cveTag("CVE-2019-15126"),
+		cveTag("CVE-2020-3111"),
+		{Name: "WPS-PIXIE", Category: "configuration"},
+		cveTag("CVE-2017-13077"),
+		cveTag("CVE-2023-0001"), // Not in the database
+	}}
+	repo := &cveScores{records: map[string]domain.CVERecord{
+		"CVE-2019-15126": {ID: "CVE-2019-15126", Severity: 3.1},
+		"CVE-2020-3111":  {ID: "CVE-2020-3111", Severity: 9.8, CVSSVector: "CVSS:3.1/AV:A/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+		"CVE-2017-13077": {ID: "CVE-2017-13077", Severity: 6.8},
+	}}
+	enricher := security.NewCVEEnricher(devicesRegistry{devices: map[string]domain.Device{ap.MAC: ap}}, repo)
+
+	// A station attacking the AP carries the AP's CVEs
+	alert := domain.Alert{Subtype: "DEAUTH_FLOOD", DeviceMAC: "11:22:33:44:55:66", BSSID: ap.MAC}
+	enricher.EnrichAlert(context.Background(), &alert)
+	require.Len(t, alert.CVEs, domain.MaxAlertCVEs)
+	assert.Equal(t, domain.AlertCVE{ID: "CVE-2020-3111", CVSS: 9.8, CVSSVector: "CVSS:3.1/AV:A/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}, alert.CVEs[0])
+	assert.Equal(t, "CVE-2017-13077", alert.CVEs[1].ID)
+	assert.Equal(t, "CVE-2019-15126 (CVSS 3.1)", alert.CVEs[2].String())
+
+	// Scores are looked up once
+	again := domain.Alert{Subtype: "EVIL_TWIN_DETECTED", DeviceMAC: ap.MAC}
+	enricher.EnrichAlert(context.Background(), &again)
+	assert.Equal(t, alert.CVEs, again.CVEs)
+	assert.Equal(t, 4, repo.lookups)
+
+	unknown := domain.Alert{Subtype: "NEW_DEVICE", DeviceMAC: "02:00:00:00:00:01"}
+	enricher.EnrichAlert(context.Background(), &unknown)
+	assert.Empty(t, unknown.CVEs)
+}
+
+func TestCVEEnricher_FailedLookupsNotRetried(t *testing.T) {
+	ap := domain.Device{MAC: "aa:bb:cc:dd:ee:ff", Vulnerabilities: []domain.VulnerabilityTag{{Name: "CVE-2020-3111", Category: "cve"}}}
+	repo := &cveScores{err: errors.New("database unreachable")}
+	enricher := security.NewCVEEnricher(devicesRegistry{devices: map[string]domain.Device{ap.MAC: ap}}, repo)
+
+	for i := 0; i < 3; i++ {
+		alert := domain.Alert{Subtype: "EVIL_TWIN_DETECTED", DeviceMAC: ap.MAC}
+		enricher.EnrichAlert(context.Background(), &alert)
+		require.Len(t, alert.CVEs, 1)
+		assert.Zero(t, alert.CVEs[0].CVSS)
+	}
+	assert.Equal(t, 1, repo.lookups, "failures are retried after a while, not on every alert")
+}
@@ -184,33 +184,26 @@ type RuleDetector struct {
 func (d *RuleDetector) Name() string { return "RuleDetector" }
 
 func (d *RuleDetector) Analyze(device *domain.Device, _ ports.DeviceRegistry) []domain.Alert {
-	d.engine.mu.RLock()
-	rules := make([]domain.AlertRule, len(d.engine.rules))
-	copy(rules, d.engine.rules)
-	d.engine.mu.RUnlock()
-
 	var alerts []domain.Alert
-	for _, rule := range rules {
-		if !rule.Enabled {
+	for _, rule := range d.engine.MatchRules(*device) {
+		if !rule.HasAction(domain.RuleActionAlert) {
 			continue
 		}
-
-		if d.matchRule(device, rule) {
-			alerts = append(alerts, domain.Alert{
-				Type:      rule.Type,
-				Subtype:   "RULE_MATCH",
-				RuleID:    rule.ID,
-				Severity:  domain.SeverityHigh,
-				Message:   "Security Rule Triggered: " + rule.Value,
-				DeviceMAC: device.MAC,
-				Timestamp: time.Now(),
-			})
-		}
+		alerts = append(alerts, domain.Alert{
+			Type:      rule.Type,
+			Subtype:   "RULE_MATCH",
+			RuleID:    rule.ID,
+			Severity:  rule.AlertSeverity(),
+			Message:   "Security Rule Triggered: " + rule.Value,
+			DeviceMAC: device.MAC,
+			Timestamp: time.Now(),
+		})
 	}
 	return alerts
 }
 
-func (d *RuleDetector) matchRule(device *domain.Device, rule domain.AlertRule) bool {
+// matchRule reports whether device satisfies the criteria of rule.
+func matchRule(device *domain.Device, rule domain.AlertRule) bool {
 	switch rule.Type {
 	case domain.AlertSSID:
 		if rule.Exact {
@@ -221,6 +214,8 @@ func (d *RuleDetector) matchRule(device *domain.Device, rule domain.AlertRule) b
 		return device.MAC == rule.Value
 	case domain.AlertVendor:
 		return device.Vendor == rule.Value
+	case domain.AlertOUI:
+		return rule.MatchesOUI(device.MAC)
 	case domain.AlertProbe:
 		for ssid := range device.ProbedSSIDs {
 			if rule.Exact {
@@ -0,0 +1,166 @@
+package security
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// DroneAlertThreshold is the minimum confidence reported as DRONE_DETECTED.
+const DroneAlertThreshold = 0.5
+
+// droneIndicator is a piece of evidence and how strongly it points to a drone.
+type droneIndicator struct {
+	weight float64
+	reason string
+}
+
+// Vendor specific IEs only drones broadcast
+var droneIEs = map[string]droneIndicator{
+	"fa:0b:bc": {0.95, "ASTM F3411 Remote ID broadcast"},
+	"26:37:12": {0.9, "DJI DroneID"},
+}
+
+// OUIs of drone makers' radios, for devices the vendor database does not resolve
+var droneOUIs = map[string]string{
+	"60:60:1f": "DJI",
+	"34:d2:62": "DJI",
+	"48:1c:b9": "DJI",
+	"90:03:b7": "Parrot",
+	"90:3a:e6": "Parrot",
+	"a0:14:3d": "Parrot",
+	"00:12:1c": "Parrot",
+	"00:26:7e": "Parrot",
+}
+
+// Vendor names resolved from the OUI database
+var droneVendors = []string{"DJI", "Parrot", "Autel", "Skydio", "Yuneec"}
+
+// SSID prefixes of drones' and controllers' own networks
+var droneSSIDPrefixes = []string{
+	"DJI-", "TELLO-", "MAVIC", "SPARK-", "PHANTOM", "BEBOP", "ANAFI", "DISCO-",
+	"SKYCONTROLLER", "AUTEL", "EVO_", "SKYDIO", "YUNEEC", "TYPHOON", "FIMI", "HUBSAN",
+}
+
+// DroneDetector flags drones and their controllers from their OUI, the SSID
+// patterns of their video links, the vendor IEs of Remote ID and DJI DroneID
+// and their beacon timing. Each indicator adds to the confidence of the
+// DRONE_DETECTED alert; beacon timing alone is never enough.
+type DroneDetector struct{}
+
+func (d *DroneDetector) Name() string { return "DroneDetector" }
+
+func (d *DroneDetector) Analyze(device *domain.Device, _ ports.DeviceRegistry) []domain.Alert {
+	indicators, maker := droneIndicators(device)
+	if len(indicators) == 0 {
+		return nil
+	}
+
+	// Independent indicators: the confidence is the chance any of them is right
+	miss := 1.0
+	reasons := make([]string, 0, len(indicators))
+	for _, ind := range indicators {
+		miss *= 1 - ind.weight
+		reasons = append(reasons, ind.reason)
+	}
+	confidence := math.Round((1-miss)*100) / 100
+	if confidence < DroneAlertThreshold {
+		return nil
+	}
+
+	if device.Behavioral == nil {
+		device.Behavioral = &domain.BehavioralProfile{}
+	}
+	if device.Behavioral.AnomalyDetails == nil {
+		device.Behavioral.AnomalyDetails = make(map[string]float64)
+	}
+	device.Behavioral.AnomalyDetails["DRONE_DETECTED"] = confidence
+
+	severity := domain.SeverityMedium
+	if confidence >= 0.8 {
+		severity = domain.SeverityHigh
+	}
+	what := "Drone or controller"
+	if maker != "" {
+		what = maker + " drone or controller"
+	}
+	return []domain.Alert{{
+		Type:       domain.AlertAnomaly,
+		Subtype:    "DRONE_DETECTED",
+		Severity:   severity,
+		Confidence: confidence,
+		Message:    fmt.Sprintf("%s detected (%.0f%% confidence)", what, confidence*100),
+		Details:    strings.Join(reasons, "; "),
+		DeviceMAC:  device.MAC,
+		Timestamp:  time.Now(),
+	}}
+}
+
+// droneIndicators collects the drone evidence of a device and the maker it
+// points to, if any.
+func droneIndicators(device *domain.Device) ([]droneIndicator, string) {
+	var indicators []droneIndicator
+	maker := ""
+
+	for _, oui := range device.VendorIEs {
+		if ind, ok := droneIEs[strings.ToLower(oui)]; ok {
+			indicators = append(indicators, ind)
+			if oui == "26:37:12" {
+				maker = "DJI"
+			}
+		}
+	}
+
+	if vendor := droneVendor(device); vendor != "" {
+		indicators = append(indicators, droneIndicator{0.6, "Radio made by " + vendor})
+		maker = vendor
+	}
+
+	ssids := []string{device.SSID}
+	for ssid := range device.ProbedSSIDs {
+		ssids = append(ssids, ssid)
+	}
+	for _, ssid := range ssids {
+		if prefix := droneSSIDPrefix(ssid); prefix != "" {
+			indicators = append(indicators, droneIndicator{0.5, fmt.Sprintf("SSID %q matches drone pattern %s*", ssid, prefix)})
+			break
+		}
+	}
+
+	// Video links beacon faster than the usual 100 TU. Only corroborates.
+	if len(indicators) > 0 && device.BeaconInterval > 0 && device.BeaconInterval < domain.DefaultBeaconInterval {
+		indicators = append(indicators, droneIndicator{0.1, fmt.Sprintf("Short beacon interval (%d TU)", device.BeaconInterval)})
+	}
+	return indicators, maker
+}
+
+// droneVendor returns the drone maker of the device's radio, if any.
+func droneVendor(device *domain.Device) string {
+	if len(device.MAC) >= 8 {
+		if vendor, ok := droneOUIs[strings.ToLower(device.MAC[:8])]; ok {
+			return vendor
+		}
+	}
+	vendor := strings.ToLower(device.Vendor)
+	for _, name := range droneVendors {
+		if strings.Contains(vendor, strings.ToLower(name)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// droneSSIDPrefix returns the drone SSID prefix ssid starts with, if any.
+func droneSSIDPrefix(ssid string) string {
+	upper := strings.ToUpper(ssid)
+	for _, prefix := range droneSSIDPrefixes {
+		if strings.HasPrefix(upper, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package security
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDroneDetector(t *testing.T) {
+	detector := &DroneDetector{}
+
+	t.Run("DJI OUI and SSID", func(t *testing.T) {
+		device := &domain.Device{MAC: "60:60:1f:12:34:56", Type: domain.DeviceTypeAP, SSID: "DJI-Mavic-3", BeaconInterval: 50}
+		alerts := detector.Analyze(device, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "DRONE_DETECTED", alerts[0].Subtype)
+		assert.Equal(t, domain.SeverityHigh, alerts[0].Severity)
+		assert.Equal(t, 0.82, alerts[0].Confidence) // 1 - 0.4*0.5*0.9
+		assert.Contains(t, alerts[0].Message, "DJI drone")
+		assert.Contains(t, alerts[0].Details, "Short beacon interval (50 TU)")
+		assert.Equal(t, 0.82, device.Behavioral.AnomalyDetails["DRONE_DETECTED"])
+	})
+
+	t.Run("Remote ID beacon", func(t *testing.T) {
+		device := &domain.Device{MAC: "02:aa:bb:cc:dd:ee", Type: domain.DeviceTypeAP, VendorIEs: []string{"00:50:f2", "fa:0b:bc"}}
+		alerts := detector.Analyze(device, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, 0.95, alerts[0].Confidence)
+		assert.Contains(t, alerts[0].Details, "Remote ID")
+	})
+
+	t.Run("Controller probing for a drone", func(t *testing.T) {
+		device := &domain.Device{MAC: "00:11:22:33:44:55", Type: domain.DeviceTypeStation, ProbedSSIDs: map[string]time.Time{"TELLO-AB12CD": time.Now()}}
+		alerts := detector.Analyze(device, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, domain.SeverityMedium, alerts[0].Severity)
+		assert.Equal(t, 0.5, alerts[0].Confidence)
+	})
+
+	t.Run("Vendor from the OUI database", func(t *testing.T) {
+		alerts := detector.Analyze(&domain.Device{MAC: "00:11:22:33:44:66", Vendor: "SZ DJI Technology Co.,Ltd"}, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, 0.6, alerts[0].Confidence)
+	})
+
+	t.Run("Beacon timing alone is not enough", func(t *testing.T) {
+		assert.Empty(t, detector.Analyze(&domain.Device{MAC: "00:11:22:33:44:77", Type: domain.DeviceTypeAP, SSID: "Office", BeaconInterval: 20}, nil))
+	})
+}
@@ -0,0 +1,136 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// GeofenceDetector raises alerts when tracked devices or newly discovered APs
+// are observed inside a protected zone.
+type GeofenceDetector struct {
+	zones map[string]domain.Geofence
+	store ports.GeofenceRepository // Optional, zones are kept in memory only without it
+	mu    sync.RWMutex
+}
+
+// NewGeofenceDetector creates a geofence detector holding the zones of
+// store, none if store is nil.
+func NewGeofenceDetector(store ports.GeofenceRepository) (*GeofenceDetector, error) {
+	d := &GeofenceDetector{
+		zones: make(map[string]domain.Geofence),
+		store: store,
+	}
+	if store == nil {
+		return d, nil
+	}
+	zones, err := store.ListGeofences(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load geofences: %w", err)
+	}
+	for _, zone := range zones {
+		d.zones[zone.ID] = zone
+	}
+	return d, nil
+}
+
+func (d *GeofenceDetector) Name() string { return "GeofenceDetector" }
+
+// AddZone validates and registers a zone, returning the stored copy.
+func (d *GeofenceDetector) AddZone(ctx context.Context, zone domain.Geofence) (domain.Geofence, error) {
+	if err := zone.Validate(); err != nil {
+		return domain.Geofence{}, err
+	}
+	if zone.ID == "" {
+		zone.ID = uuid.New().String()
+	}
+	if zone.CreatedAt.IsZero() {
+		zone.CreatedAt = time.Now()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.store != nil {
+		if err := d.store.SaveGeofence(ctx, zone); err != nil {
+			return domain.Geofence{}, err
+		}
+	}
+	d.zones[zone.ID] = zone
+	return zone, nil
+}
+
+// RemoveZone deletes a zone. It returns false if the zone does not exist.
+func (d *GeofenceDetector) RemoveZone(ctx context.Context, id string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.zones[id]; !ok {
+		return false, nil
+	}
+	if d.store != nil {
+		if err := d.store.DeleteGeofence(ctx, id); err != nil {
+			return false, err
+		}
+	}
+	delete(d.zones, id)
+	return true, nil
+}
+
+// Zones returns all registered zones ordered by creation time.
+func (d *GeofenceDetector) Zones() []domain.Geofence {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	result := make([]domain.Geofence, 0, len(d.zones))
+	for _, z := range d.zones {
+		result = append(result, z)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+func (d *GeofenceDetector) Analyze(device *domain.Device, _ ports.DeviceRegistry) []domain.Alert {
+	// Devices without a location fix cannot be placed in any zone
+	if device.Latitude == 0 && device.Longitude == 0 {
+		return nil
+	}
+
+	var alerts []domain.Alert
+	for _, zone := range d.Zones() {
+		if !zone.Enabled || !zone.Contains(device.Latitude, device.Longitude) {
+			continue
+		}
+
+		if zone.IsTracked(device.MAC) {
+			alerts = append(alerts, domain.Alert{
+				Type:      domain.AlertAnomaly,
+				Subtype:   "GEOFENCE_TRACKED_DEVICE",
+				Severity:  domain.SeverityHigh,
+				Message:   "Tracked device inside protected zone: " + zone.Name,
+				Details:   fmt.Sprintf("Zone %s (%s), RSSI %d dBm", zone.Name, zone.ID, device.RSSI),
+				DeviceMAC: device.MAC,
+				Timestamp: time.Now(),
+			})
+			continue
+		}
+
+		if zone.AlertOnNewAP && device.IsAP() && device.FirstSeen.After(zone.CreatedAt) {
+			alerts = append(alerts, domain.Alert{
+				Type:      domain.AlertAnomaly,
+				Subtype:   "GEOFENCE_NEW_AP",
+				Severity:  domain.SeverityMedium,
+				Message:   "New Access Point inside protected zone: " + zone.Name,
+				Details:   fmt.Sprintf("SSID %q first seen %s", device.SSID, device.FirstSeen.Format(time.RFC3339)),
+				DeviceMAC: device.MAC,
+				Timestamp: time.Now(),
+			})
+		}
+	}
+	return alerts
+}
@@ -0,0 +1,121 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeofenceDetector(t *testing.T) {
+	detector, err := NewGeofenceDetector(nil)
+	require.NoError(t, err)
+
+	zone, err := detector.AddZone(context.Background(), domain.Geofence{
+		Name:         "HQ",
+		Latitude:     40.4168,
+		Longitude:    -3.7038,
+		RadiusMeters: 200,
+		TrackedMACs:  []string{"AA:BB:CC:DD:EE:FF"},
+		AlertOnNewAP: true,
+		Enabled:      true,
+		CreatedAt:    time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, zone.ID)
+
+	t.Run("Tracked device inside zone", func(t *testing.T) {
+		device := &domain.Device{MAC: "aa:bb:cc:dd:ee:ff", Latitude: 40.4170, Longitude: -3.7040}
+		alerts := detector.Analyze(device, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "GEOFENCE_TRACKED_DEVICE", alerts[0].Subtype)
+	})
+
+	t.Run("Tracked device outside zone", func(t *testing.T) {
+		device := &domain.Device{MAC: "AA:BB:CC:DD:EE:FF", Latitude: 41.3874, Longitude: 2.1686}
+		assert.Empty(t, detector.Analyze(device, nil))
+	})
+
+	t.Run("New AP inside zone", func(t *testing.T) {
+		device := &domain.Device{MAC: "00:11:22:33:44:55", Type: domain.DeviceTypeAP, SSID: "Rogue", FirstSeen: time.Now(), Latitude: 40.4168, Longitude: -3.7038}
+		alerts := detector.Analyze(device, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "GEOFENCE_NEW_AP", alerts[0].Subtype)
+	})
+
+	t.Run("AP known before zone creation", func(t *testing.T) {
+		device := &domain.Device{MAC: "00:11:22:33:44:66", Type: domain.DeviceTypeAP, FirstSeen: time.Now().Add(-2 * time.Hour), Latitude: 40.4168, Longitude: -3.7038}
+		assert.Empty(t, detector.Analyze(device, nil))
+	})
+
+	t.Run("Device without location", func(t *testing.T) {
+		device := &domain.Device{MAC: "AA:BB:CC:DD:EE:FF"}
+		assert.Empty(t, detector.Analyze(device, nil))
+	})
+
+	t.Run("Invalid zone rejected", func(t *testing.T) {
+		_, err := detector.AddZone(context.Background(), domain.Geofence{Name: "Bad", RadiusMeters: 0})
+		assert.ErrorIs(t, err, domain.ErrInvalidGeofenceRadius)
+	})
+
+	t.Run("Remove zone", func(t *testing.T) {
+		found, err := detector.RemoveZone(context.Background(), zone.ID)
+		require.NoError(t, err)
+		assert.True(t, found)
+		found, err = detector.RemoveZone(context.Background(), zone.ID)
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Empty(t, detector.Zones())
+	})
+}
+
+// geofenceStore keeps zones in memory, as the database would.
+type geofenceStore struct {
+	zones map[string]domain.Geofence
+}
+
+func (s *geofenceStore) ListGeofences(ctx context.Context) ([]domain.Geofence, error) {
+	var zones []domain.Geofence
+	for _, z := range s.zones {
+		zones = append(zones, z)
+	}
+	return zones, nil
+}
+
+func (s *geofenceStore) SaveGeofence(ctx context.Context, zone domain.Geofence) error {
+	s.zones[zone.ID] = zone
+	return nil
+}
+
+func (s *geofenceStore) DeleteGeofence(ctx context.Context, id string) error {
+	delete(s.zones, id)
+	return nil
+}
+
+func TestGeofenceDetector_Persisted(t *testing.T) {
+	ctx := context.Background()
+	store := &geofenceStore{zones: make(map[string]domain.Geofence)}
+	detector, err := NewGeofenceDetector(store)
+	require.NoError(t, err)
+
+	hq, err := detector.AddZone(ctx, domain.Geofence{Name: "HQ", RadiusMeters: 200, Enabled: true})
+	require.NoError(t, err)
+	lab, err := detector.AddZone(ctx, domain.Geofence{Name: "Lab", RadiusMeters: 50, Enabled: true})
+	require.NoError(t, err)
+	_, err = detector.RemoveZone(ctx, lab.ID)
+	require.NoError(t, err)
+
+	// Zones survive a restart
+	restarted, err := NewGeofenceDetector(store)
+	require.NoError(t, err)
+	zones := restarted.Zones()
+	require.Len(t, zones, 1)
+	assert.Equal(t, hq.ID, zones[0].ID)
+
+	engine := NewSecurityEngine(new(MockRegistry))
+	require.NoError(t, engine.SetGeofenceStore(store))
+	assert.Len(t, engine.GetGeofences(ctx), 1)
+}
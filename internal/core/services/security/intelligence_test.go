@@ -25,6 +25,7 @@ func (m *MockRegistry) GetDevice(ctx context.Context, mac string) (domain.Device
 	return domain.Device{}, false
 }
 func (m *MockRegistry) GetAllDevices(ctx context.Context) []domain.Device          { return nil }
+func (m *MockRegistry) RemoveDevice(ctx context.Context, mac string) (bool, int)   { return false, 0 }
 func (m *MockRegistry) PruneOldDevices(ctx context.Context, ttl time.Duration) int { return 0 }
 func (m *MockRegistry) GetActiveCount(ctx context.Context) int                     { return 0 }
 func (m *MockRegistry) UpdateSSID(ctx context.Context, ssid, security string)      {}
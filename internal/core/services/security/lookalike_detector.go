@@ -0,0 +1,94 @@
+package security
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// DefaultLookalikeThreshold is the minimum similarity reported as a lookalike.
+const DefaultLookalikeThreshold = 0.8
+
+// LookalikeSSIDDetector flags APs whose SSID imitates a trusted one, e.g.
+// "C0rpWiFi" or "CorpWlFi" next to "CorpWiFi".
+type LookalikeSSIDDetector struct {
+	trusted   []string
+	threshold float64
+	mu        sync.RWMutex
+}
+
+// NewLookalikeSSIDDetector creates a detector with an empty trusted list.
+func NewLookalikeSSIDDetector(threshold float64) *LookalikeSSIDDetector {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultLookalikeThreshold
+	}
+	return &LookalikeSSIDDetector{threshold: threshold}
+}
+
+func (d *LookalikeSSIDDetector) Name() string { return "LookalikeSSIDDetector" }
+
+// SetTrusted replaces the trusted SSID list. Empty entries are ignored.
+func (d *LookalikeSSIDDetector) SetTrusted(ssids []string) {
+	trusted := make([]string, 0, len(ssids))
+	for _, ssid := range ssids {
+		if strings.TrimSpace(ssid) != "" {
+			trusted = append(trusted, ssid)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.trusted = trusted
+}
+
+// Trusted returns the trusted SSID list.
+func (d *LookalikeSSIDDetector) Trusted() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append([]string(nil), d.trusted...)
+}
+
+func (d *LookalikeSSIDDetector) Analyze(device *domain.Device, _ ports.DeviceRegistry) []domain.Alert {
+	if device.SSID == "" || !device.IsAP() {
+		return nil
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	bestSSID, bestScore := "", 0.0
+	for _, trusted := range d.trusted {
+		if device.SSID == trusted {
+			// Broadcasting a trusted SSID verbatim is the evil twin detector's concern
+			return nil
+		}
+		if score := domain.SSIDSimilarity(device.SSID, trusted); score > bestScore {
+			bestSSID, bestScore = trusted, score
+		}
+	}
+	if bestScore < d.threshold {
+		return nil
+	}
+
+	if device.Behavioral == nil {
+		device.Behavioral = &domain.BehavioralProfile{}
+	}
+	if device.Behavioral.AnomalyDetails == nil {
+		device.Behavioral.AnomalyDetails = make(map[string]float64)
+	}
+	device.Behavioral.AnomalyDetails["EVIL_TWIN_SUSPECT"] = bestScore
+
+	return []domain.Alert{{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "EVIL_TWIN_SUSPECT",
+		Severity:  domain.SeverityHigh,
+		Message:   fmt.Sprintf("Lookalike SSID %q imitates trusted %q", device.SSID, bestSSID),
+		Details:   fmt.Sprintf("Similarity: %.2f", bestScore),
+		DeviceMAC: device.MAC,
+		Timestamp: time.Now(),
+	}}
+}
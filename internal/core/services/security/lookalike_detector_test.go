@@ -0,0 +1,48 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookalikeSSIDDetector(t *testing.T) {
+	detector := NewLookalikeSSIDDetector(0)
+	detector.SetTrusted([]string{"CorpWiFi", " ", "Guest"})
+	assert.Equal(t, []string{"CorpWiFi", "Guest"}, detector.Trusted())
+
+	ap := func(ssid string) *domain.Device {
+		return &domain.Device{MAC: "00:11:22:33:44:55", Type: domain.DeviceTypeAP, SSID: ssid}
+	}
+
+	t.Run("Homoglyph lookalike", func(t *testing.T) {
+		device := ap("C0rpWiFi")
+		alerts := detector.Analyze(device, nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "EVIL_TWIN_SUSPECT", alerts[0].Subtype)
+		assert.Contains(t, alerts[0].Message, `"CorpWiFi"`)
+		assert.Equal(t, "Similarity: 1.00", alerts[0].Details)
+		assert.Equal(t, 1.0, device.Behavioral.AnomalyDetails["EVIL_TWIN_SUSPECT"])
+	})
+
+	t.Run("Typo lookalike", func(t *testing.T) {
+		alerts := detector.Analyze(ap("CorpWiFi5"), nil)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "Similarity: 0.89", alerts[0].Details)
+	})
+
+	t.Run("Trusted SSID itself", func(t *testing.T) {
+		assert.Empty(t, detector.Analyze(ap("CorpWiFi"), nil))
+	})
+
+	t.Run("Unrelated SSID", func(t *testing.T) {
+		assert.Empty(t, detector.Analyze(ap("HomeNet"), nil))
+	})
+
+	t.Run("Stations are ignored", func(t *testing.T) {
+		station := &domain.Device{MAC: "aa:bb:cc:dd:ee:ff", Type: domain.DeviceTypeStation, SSID: "C0rpWiFi"}
+		assert.Empty(t, detector.Analyze(station, nil))
+	})
+}
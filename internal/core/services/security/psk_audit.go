@@ -0,0 +1,301 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// ErrPSKAuditRunning is returned when an audit is started while another runs.
+var ErrPSKAuditRunning = errors.New("psk audit already running")
+
+// pskAuditSource tags credentials recovered by the audit.
+const pskAuditSource = "psk_audit"
+
+// WeakPasswords is the curated list run against each handshake: factory
+// defaults and the most common WPA passphrases (8+ characters).
+var WeakPasswords = []string{
+	"12345678", "123456789", "1234567890", "87654321", "11111111", "00000000",
+	"88888888", "12341234", "11223344", "123123123", "1q2w3e4r", "qwertyui",
+	"qwerty123", "qwertyuiop", "asdfghjk", "zxcvbnm1", "password", "password1",
+	"password123", "passw0rd", "iloveyou", "sunshine", "princess", "football",
+	"baseball", "welcome1", "welcome123", "letmein1", "admin123", "administrator",
+	"internet", "wireless", "wifi1234", "wifipassword", "changeme", "default1",
+	"guest123", "homewifi", "abcd1234", "abc12345", "a1b2c3d4", "superman",
+	"starwars", "computer", "michelle", "jennifer", "trustno1", "dragon12",
+	"monkey12", "master12", "12qwaszx", "1qaz2wsx", "zaq12wsx", "q1w2e3r4",
+}
+
+// VulnerabilityRecorder saves vulnerability detections for a device.
+type VulnerabilityRecorder interface {
+	ProcessDetections(mac string, vulns []domain.VulnerabilityTag) error
+}
+
+// pskCapture is a handshake or PMKID capture file saved by the handshake manager.
+type pskCapture struct {
+	path  string
+	bssid string
+	essid string
+}
+
+// PSKAuditService runs a weak-password list against every captured handshake
+// and reports networks whose PSK was recovered as critical vulnerabilities.
+type PSKAuditService struct {
+	dir         string
+	cracker     ports.PSKCracker
+	sealer      ports.SecretSealer
+	credentials ports.CredentialRepository
+	vulns       VulnerabilityRecorder
+	wordlist    []string
+
+	status domain.PSKAuditStatus
+	mu     sync.RWMutex
+}
+
+// NewPSKAuditService creates an audit over the capture files in dir.
+func NewPSKAuditService(dir string, cracker ports.PSKCracker, sealer ports.SecretSealer, credentials ports.CredentialRepository, vulns VulnerabilityRecorder) *PSKAuditService {
+	return &PSKAuditService{
+		dir:         dir,
+		cracker:     cracker,
+		sealer:      sealer,
+		credentials: credentials,
+		vulns:       vulns,
+		wordlist:    WeakPasswords,
+	}
+}
+
+// StartPSKAudit starts an audit in the background. It does not depend on the
+// caller's context, which usually ends with the HTTP request.
+func (s *PSKAuditService) StartPSKAudit(ctx context.Context) error {
+	if err := s.begin(); err != nil {
+		return err
+	}
+	go s.run(context.Background(), nil)
+	return nil
+}
+
+// RunPSKAudit runs an audit to completion, reporting progress from 0 to 100.
+// It backs the "psk_audit" background job.
+func (s *PSKAuditService) RunPSKAudit(ctx context.Context, progress func(percent float64, message string)) error {
+	if err := s.begin(); err != nil {
+		return err
+	}
+	return s.run(ctx, progress)
+}
+
+func (s *PSKAuditService) begin() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status.Running {
+		return ErrPSKAuditRunning
+	}
+	s.status = domain.PSKAuditStatus{Running: true, StartedAt: time.Now(), Recovered: []string{}}
+	return nil
+}
+
+// GetPSKAuditStatus returns the progress of the current or last audit.
+func (s *PSKAuditService) GetPSKAuditStatus(ctx context.Context) domain.PSKAuditStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status := s.status
+	status.Recovered = append([]string(nil), s.status.Recovered...)
+	status.Errors = append([]string(nil), s.status.Errors...)
+	return status
+}
+
+func (s *PSKAuditService) run(ctx context.Context, progress func(float64, string)) error {
+	defer func() {
+		s.mu.Lock()
+		s.status.Running = false
+		s.status.FinishedAt = time.Now()
+		s.mu.Unlock()
+	}()
+
+	captures, err := s.findCaptures()
+	if err != nil {
+		s.addError(fmt.Sprintf("list captures: %v", err))
+		return err
+	}
+	s.mu.Lock()
+	s.status.Captures = len(captures)
+	s.mu.Unlock()
+
+	recovered := make(map[string]bool)
+	for i, capture := range captures {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if progress != nil {
+			progress(float64(i)*100/float64(len(captures)), "Auditing "+filepath.Base(capture.path))
+		}
+		if recovered[capture.bssid] || s.alreadyRecovered(ctx, capture.bssid) {
+			recovered[capture.bssid] = true
+			continue
+		}
+
+		key, found, err := s.crack(ctx, capture)
+		s.mu.Lock()
+		s.status.Audited++
+		s.mu.Unlock()
+		if err != nil {
+			s.addError(fmt.Sprintf("%s: %v", filepath.Base(capture.path), err))
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		recovered[capture.bssid] = true
+		if err := s.report(ctx, capture, key); err != nil {
+			s.addError(fmt.Sprintf("%s: %v", capture.bssid, err))
+			continue
+		}
+		s.mu.Lock()
+		s.status.Recovered = append(s.status.Recovered, capture.bssid)
+		s.mu.Unlock()
+	}
+	return ctx.Err()
+}
+
+// crack runs the cracker on a capture, decrypting sealed captures into a
+// private temporary file that is removed afterwards.
+func (s *PSKAuditService) crack(ctx context.Context, capture pskCapture) (string, bool, error) {
+	if !strings.HasSuffix(capture.path, domain.SealedFileSuffix) {
+		return s.cracker.Crack(ctx, capture.path, capture.bssid, s.wordlist)
+	}
+
+	sealed, err := os.ReadFile(capture.path)
+	if err != nil {
+		return "", false, err
+	}
+	data, err := s.sealer.OpenBytes(sealed)
+	if err != nil {
+		return "", false, fmt.Errorf("decrypt capture: %w", err)
+	}
+	name := strings.TrimSuffix(filepath.Base(capture.path), domain.SealedFileSuffix)
+	tmp, err := os.CreateTemp("", "wmap-capture-*"+captureExt(name))
+	if err != nil {
+		return "", false, err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return s.cracker.Crack(ctx, tmp.Name(), capture.bssid, s.wordlist)
+}
+
+// report stores the sealed key and raises the WEAK-PSK vulnerability. The
+// plaintext key never leaves this function.
+func (s *PSKAuditService) report(ctx context.Context, capture pskCapture, key string) error {
+	sealed, err := s.sealer.Seal(key)
+	if err != nil {
+		return fmt.Errorf("seal key: %w", err)
+	}
+	if err := s.credentials.SaveCredential(ctx, domain.RecoveredCredential{
+		ID:           capture.bssid,
+		BSSID:        capture.bssid,
+		ESSID:        capture.essid,
+		Source:       pskAuditSource,
+		SealedSecret: sealed,
+		RecoveredAt:  time.Now(),
+	}); err != nil {
+		return fmt.Errorf("save credential: %w", err)
+	}
+
+	return s.vulns.ProcessDetections(capture.bssid, []domain.VulnerabilityTag{{
+		Name:        domain.VulnWeakPSK,
+		Severity:    domain.VulnSeverityCritical,
+		Confidence:  domain.ConfidenceConfirmed,
+		Description: "Pre-shared key recovered from a captured handshake using a list of common passwords",
+		Evidence: []string{
+			"Capture: " + filepath.Base(capture.path),
+			fmt.Sprintf("Key length: %d characters", len(key)),
+		},
+		Category:   "authentication",
+		Mitigation: "Replace the PSK with a long random passphrase, or move to WPA3-SAE or 802.1X",
+		DetectedAt: time.Now(),
+	}})
+}
+
+func (s *PSKAuditService) alreadyRecovered(ctx context.Context, bssid string) bool {
+	credential, err := s.credentials.GetCredential(ctx, bssid)
+	return err == nil && credential != nil
+}
+
+func (s *PSKAuditService) addError(msg string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.Errors = append(s.status.Errors, msg)
+}
+
+// findCaptures lists the capture files, named BSSID_ESSID_STATION.pcapng or
+// BSSID_ESSID_PMKID.pcapng with ':' replaced by '_', plain or sealed. Legacy
+// .pcap captures are included.
+func (s *PSKAuditService) findCaptures() ([]pskCapture, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var captures []pskCapture
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), domain.SealedFileSuffix)
+		if entry.IsDir() || captureExt(name) == "" {
+			continue
+		}
+		if capture, ok := parseCaptureName(name); ok {
+			capture.path = filepath.Join(s.dir, entry.Name())
+			captures = append(captures, capture)
+		}
+	}
+	sort.Slice(captures, func(i, j int) bool { return captures[i].path < captures[j].path })
+	return captures, nil
+}
+
+// parseCaptureName extracts the BSSID and (sanitized) ESSID from a capture file name.
+func parseCaptureName(name string) (pskCapture, bool) {
+	const macLen = 17
+	base := strings.TrimSuffix(name, captureExt(name))
+	if len(base) < macLen+2 || base[macLen] != '_' {
+		return pskCapture{}, false
+	}
+
+	bssid := strings.ReplaceAll(base[:macLen], "_", ":")
+	if !domain.IsValidMAC(bssid) {
+		return pskCapture{}, false
+	}
+
+	essid := base[macLen+1:]
+	if strings.HasSuffix(essid, "_PMKID") {
+		essid = strings.TrimSuffix(essid, "_PMKID")
+	} else if len(essid) > macLen && essid[len(essid)-macLen-1] == '_' {
+		essid = essid[:len(essid)-macLen-1]
+	}
+	return pskCapture{bssid: strings.ToLower(bssid), essid: essid}, true
+}
+
+// captureExt returns the capture extension of a file name, or "" for other files.
+func captureExt(name string) string {
+	for _, ext := range []string{".pcapng", ".pcap"} {
+		if strings.HasSuffix(name, ext) {
+			return ext
+		}
+	}
+	return ""
+}
@@ -0,0 +1,191 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCracker struct {
+	keys  map[string]string // bssid -> key in the wordlist
+	calls []string
+	mu    sync.Mutex
+}
+
+func (c *fakeCracker) Crack(ctx context.Context, capturePath, bssid string, candidates []string) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, filepath.Base(capturePath))
+	if bssid == "66:66:66:66:66:66" {
+		return "", false, errors.New("corrupt capture")
+	}
+	key, ok := c.keys[bssid]
+	return key, ok, nil
+}
+
+type reverseSealer struct{}
+
+func (reverseSealer) Seal(plaintext string) (string, error) {
+	return "sealed:" + reverse(plaintext), nil
+}
+
+func (reverseSealer) Open(sealed string) (string, error) {
+	if !strings.HasPrefix(sealed, "sealed:") {
+		return "", errors.New("not sealed")
+	}
+	return reverse(strings.TrimPrefix(sealed, "sealed:")), nil
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+func (reverseSealer) SealBytes(plaintext []byte) ([]byte, error) {
+	return append([]byte("sealed:"), plaintext...), nil
+}
+
+func (reverseSealer) OpenBytes(sealed []byte) ([]byte, error) {
+	return bytes.TrimPrefix(sealed, []byte("sealed:")), nil
+}
+
+type memoryCredentials struct {
+	saved map[string]domain.RecoveredCredential
+}
+
+func (m *memoryCredentials) SaveCredential(ctx context.Context, c domain.RecoveredCredential) error {
+	m.saved[c.BSSID] = c
+	return nil
+}
+
+func (m *memoryCredentials) GetCredential(ctx context.Context, bssid string) (*domain.RecoveredCredential, error) {
+	if c, ok := m.saved[bssid]; ok {
+		return &c, nil
+	}
+	return nil, nil
+}
+
+type recordedVulns struct {
+	tags map[string][]domain.VulnerabilityTag
+}
+
+func (r *recordedVulns) ProcessDetections(mac string, vulns []domain.VulnerabilityTag) error {
+	r.tags[mac] = append(r.tags[mac], vulns...)
+	return nil
+}
+
+func TestPSKAuditService(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"00_11_22_33_44_55_HomeNet_aa_bb_cc_dd_ee_ff.pcap",
+		"00_11_22_33_44_55_HomeNet_PMKID.pcap", // Same network, skipped once recovered
+		"11_22_33_44_55_66_Corp_Net_PMKID.pcapng",
+		"66_66_66_66_66_66_Broken_PMKID.pcap",
+		"notes.txt",
+		"garbage.pcap",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+
+	cracker := &fakeCracker{keys: map[string]string{"00:11:22:33:44:55": "password1"}}
+	credentials := &memoryCredentials{saved: map[string]domain.RecoveredCredential{}}
+	vulns := &recordedVulns{tags: map[string][]domain.VulnerabilityTag{}}
+	svc := NewPSKAuditService(dir, cracker, reverseSealer{}, credentials, vulns)
+
+	require.NoError(t, svc.StartPSKAudit(context.Background()))
+	require.Eventually(t, func() bool {
+		return !svc.GetPSKAuditStatus(context.Background()).Running
+	}, time.Second, 10*time.Millisecond)
+
+	status := svc.GetPSKAuditStatus(context.Background())
+	assert.Equal(t, 4, status.Captures)
+	assert.Equal(t, 3, status.Audited)
+	assert.Equal(t, []string{"00:11:22:33:44:55"}, status.Recovered)
+	require.Len(t, status.Errors, 1)
+	assert.Contains(t, status.Errors[0], "corrupt capture")
+
+	// The key is only stored sealed
+	cred := credentials.saved["00:11:22:33:44:55"]
+	assert.Equal(t, "sealed:1drowssap", cred.SealedSecret)
+	assert.Equal(t, "HomeNet", cred.ESSID)
+
+	// Reported as a critical vulnerability without the plaintext key
+	tags := vulns.tags["00:11:22:33:44:55"]
+	require.Len(t, tags, 1)
+	assert.Equal(t, domain.VulnWeakPSK, tags[0].Name)
+	assert.Equal(t, domain.VulnSeverityCritical, tags[0].Severity)
+	for _, evidence := range tags[0].Evidence {
+		assert.NotContains(t, evidence, "password1")
+	}
+
+	t.Run("Recovered networks are not audited again", func(t *testing.T) {
+		cracker.calls = nil
+		require.NoError(t, svc.StartPSKAudit(context.Background()))
+		require.Eventually(t, func() bool {
+			return !svc.GetPSKAuditStatus(context.Background()).Running
+		}, time.Second, 10*time.Millisecond)
+		assert.NotContains(t, cracker.calls, "00_11_22_33_44_55_HomeNet_aa_bb_cc_dd_ee_ff.pcap")
+		assert.Len(t, cracker.calls, 2)
+	})
+}
+
+type contentCracker struct {
+	content string
+	path    string
+}
+
+func (c *contentCracker) Crack(ctx context.Context, capturePath, bssid string, candidates []string) (string, bool, error) {
+	data, err := os.ReadFile(capturePath)
+	c.content, c.path = string(data), capturePath
+	return "", false, err
+}
+
+func TestPSKAuditSealedCaptures(t *testing.T) {
+	dir := t.TempDir()
+	name := "22_33_44_55_66_77_Vault_PMKID.pcap" + domain.SealedFileSuffix
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("sealed:pcap-bytes"), 0600))
+
+	cracker := &contentCracker{}
+	svc := NewPSKAuditService(dir, cracker, reverseSealer{}, &memoryCredentials{saved: map[string]domain.RecoveredCredential{}}, &recordedVulns{tags: map[string][]domain.VulnerabilityTag{}})
+
+	var progress []float64
+	require.NoError(t, svc.RunPSKAudit(context.Background(), func(percent float64, message string) {
+		progress = append(progress, percent)
+	}))
+
+	// The cracker gets a decrypted copy, removed once audited
+	assert.Equal(t, "pcap-bytes", cracker.content)
+	assert.NotEqual(t, dir, filepath.Dir(cracker.path))
+	_, err := os.Stat(cracker.path)
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, []float64{0}, progress)
+	assert.Equal(t, 1, svc.GetPSKAuditStatus(context.Background()).Audited)
+}
+
+func TestParseCaptureName(t *testing.T) {
+	capture, ok := parseCaptureName("00_11_22_33_44_55_My_WiFi_aa_bb_cc_dd_ee_ff.pcap")
+	require.True(t, ok)
+	assert.Equal(t, "00:11:22:33:44:55", capture.bssid)
+	assert.Equal(t, "My_WiFi", capture.essid)
+
+	capture, ok = parseCaptureName("AA_BB_CC_DD_EE_FF_unknown_PMKID.pcapng")
+	require.True(t, ok)
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", capture.bssid)
+	assert.Equal(t, "unknown", capture.essid)
+
+	_, ok = parseCaptureName("capture.pcap")
+	assert.False(t, ok)
+}
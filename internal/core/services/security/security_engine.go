@@ -2,7 +2,10 @@ package security
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
@@ -10,31 +13,49 @@ import (
 
 const MaxAlertsHistory = 1000
 
+// ErrGeofenceNotFound is returned when a zone ID is unknown.
+var ErrGeofenceNotFound = errors.New("geofence not found")
+
 // SecurityEngine analyzes network security state using pluggable detectors.
 type SecurityEngine struct {
 	Registry  ports.DeviceRegistry
 	detectors []Detector
 	rules     []domain.AlertRule
+	fileRules []domain.AlertRule // Loaded from the rules file, replaced on reload
+	wsRules   []domain.AlertRule // Of the active workspace, replaced when it changes
 	alerts    []domain.Alert
+	geofences *GeofenceDetector
+	baseline  *BaselineDetector
+	lookalike *LookalikeSSIDDetector
+	onAlert   func(domain.Alert)  // Optional, called with each new alert
+	enricher  ports.AlertEnricher // Optional, adds the CVEs of the device to alerts
 	mu        sync.RWMutex
 }
 
 // NewSecurityEngine creates a new security engine with default detectors.
 func NewSecurityEngine(registry ports.DeviceRegistry) *SecurityEngine {
 	engine := &SecurityEngine{
-		Registry: registry,
-		rules:    make([]domain.AlertRule, 0),
-		alerts:   make([]domain.Alert, 0),
+		Registry:  registry,
+		rules:     make([]domain.AlertRule, 0),
+		alerts:    make([]domain.Alert, 0),
+		baseline:  NewBaselineDetector(),
+		lookalike: NewLookalikeSSIDDetector(DefaultLookalikeThreshold),
 	}
 
+	engine.geofences, _ = NewGeofenceDetector(nil) // Cannot fail without a store
+
 	// Register default detectors
 	engine.detectors = []Detector{
 		&RetryRateDetector{},
 		&ClientKarmaDetector{},
 		&APKarmaDetector{},
 		&EvilTwinDetector{},
+		engine.lookalike,
 		&SpoofingDetector{},
+		&DroneDetector{},
 		&RuleDetector{engine: engine},
+		engine.geofences,
+		engine.baseline,
 	}
 
 	return engine
@@ -54,6 +75,124 @@ func (se *SecurityEngine) AddRule(ctx context.Context, rule domain.AlertRule) {
 	se.rules = append(se.rules, rule)
 }
 
+// SetFileRules replaces the rules loaded from the rules file. Rules added
+// through AddRule are kept. Nothing is replaced if any rule is invalid.
+func (se *SecurityEngine) SetFileRules(rules []domain.AlertRule) error {
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return fmt.Errorf("rule %d (%s): %w", i, rules[i].ID, err)
+		}
+	}
+
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	se.fileRules = append([]domain.AlertRule(nil), rules...)
+	return nil
+}
+
+// SetWorkspaceRules replaces the rules of the active workspace. Nothing is
+// replaced if any rule is invalid.
+func (se *SecurityEngine) SetWorkspaceRules(rules []domain.AlertRule) error {
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return fmt.Errorf("rule %d (%s): %w", i, rules[i].ID, err)
+		}
+	}
+
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	se.wsRules = append([]domain.AlertRule(nil), rules...)
+	return nil
+}
+
+// MatchRules returns the enabled rules, added, from the rules file or from
+// the active workspace, that device matches.
+func (se *SecurityEngine) MatchRules(device domain.Device) []domain.AlertRule {
+	se.mu.RLock()
+	rules := make([]domain.AlertRule, 0, len(se.rules)+len(se.fileRules)+len(se.wsRules))
+	rules = append(rules, se.rules...)
+	rules = append(rules, se.fileRules...)
+	rules = append(rules, se.wsRules...)
+	se.mu.RUnlock()
+
+	var matched []domain.AlertRule
+	for _, rule := range rules {
+		if rule.Enabled && matchRule(&device, rule) {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
+
+// AddGeofence registers a protected zone.
+func (se *SecurityEngine) AddGeofence(ctx context.Context, zone domain.Geofence) (domain.Geofence, error) {
+	return se.geofences.AddZone(ctx, zone)
+}
+
+// RemoveGeofence deletes a protected zone by ID.
+func (se *SecurityEngine) RemoveGeofence(ctx context.Context, id string) error {
+	found, err := se.geofences.RemoveZone(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrGeofenceNotFound
+	}
+	return nil
+}
+
+// GetGeofences returns all protected zones.
+func (se *SecurityEngine) GetGeofences(ctx context.Context) []domain.Geofence {
+	return se.geofences.Zones()
+}
+
+// SetTrustedSSIDs replaces the SSIDs that lookalike networks are compared against.
+func (se *SecurityEngine) SetTrustedSSIDs(ctx context.Context, ssids []string) {
+	se.lookalike.SetTrusted(ssids)
+}
+
+// GetTrustedSSIDs returns the trusted SSID list.
+func (se *SecurityEngine) GetTrustedSSIDs(ctx context.Context) []string {
+	return se.lookalike.Trusted()
+}
+
+// SetGeofenceStore loads the protected zones of store and keeps those added
+// later in it. Called at startup, before devices are analyzed.
+func (se *SecurityEngine) SetGeofenceStore(store ports.GeofenceRepository) error {
+	geofences, err := NewGeofenceDetector(store)
+	if err != nil {
+		return err
+	}
+	for i, detector := range se.detectors {
+		if detector == Detector(se.geofences) {
+			se.detectors[i] = geofences
+		}
+	}
+	se.geofences = geofences
+	return nil
+}
+
+// SetBaselineStore sets the storage holding the per-workspace baseline configuration.
+func (se *SecurityEngine) SetBaselineStore(store ports.BaselineRepository) {
+	se.baseline.SetStore(store)
+}
+
+// SetInventory sets the trusted inventory: devices it lists are never new
+// to the baseline.
+func (se *SecurityEngine) SetInventory(inventory ports.AssetInventory) {
+	se.baseline.SetInventory(inventory)
+}
+
+// GetBaseline returns the baseline monitoring configuration.
+func (se *SecurityEngine) GetBaseline(ctx context.Context) (domain.BaselineConfig, error) {
+	return se.baseline.Config(ctx)
+}
+
+// SetBaseline validates and stores the baseline monitoring configuration.
+func (se *SecurityEngine) SetBaseline(ctx context.Context, config domain.BaselineConfig) (domain.BaselineConfig, error) {
+	return se.baseline.SetConfig(ctx, config)
+}
+
 // GetAlerts returns all active alerts.
 func (se *SecurityEngine) GetAlerts(ctx context.Context) []domain.Alert {
 	se.mu.RLock()
@@ -63,6 +202,35 @@ func (se *SecurityEngine) GetAlerts(ctx context.Context) []domain.Alert {
 	return result
 }
 
+// OnAlert sets the hook called with each new alert, once it is stored.
+func (se *SecurityEngine) OnAlert(hook func(domain.Alert)) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	se.onAlert = hook
+}
+
+// SetAlertEnricher sets the enricher adding context, such as the device's
+// CVEs, to each alert before it is stored.
+func (se *SecurityEngine) SetAlertEnricher(enricher ports.AlertEnricher) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	se.enricher = enricher
+}
+
+// AttachEvidence links an artifact to a stored alert. It returns false if
+// the alert is no longer kept.
+func (se *SecurityEngine) AttachEvidence(alertID, evidenceID string) bool {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	for i := len(se.alerts) - 1; i >= 0; i-- {
+		if se.alerts[i].ID == alertID {
+			se.alerts[i].EvidenceID = evidenceID
+			return true
+		}
+	}
+	return false
+}
+
 // Analyze inspects a device for anomalies using all registered detectors.
 func (se *SecurityEngine) Analyze(ctx context.Context, device domain.Device) {
 	// Run all detectors
@@ -71,12 +239,13 @@ func (se *SecurityEngine) Analyze(ctx context.Context, device domain.Device) {
 		alerts := detector.Analyze(&device, se.Registry)
 		allAlerts = append(allAlerts, alerts...)
 	}
+	if len(allAlerts) == 0 {
+		return
+	}
 
-	// Add all alerts at once with a single lock
 	// Add all alerts at once with a single lock
 	se.mu.Lock()
-	defer se.mu.Unlock()
-
+	var raised []domain.Alert
 	for _, alert := range allAlerts {
 		// Basic deduplication: Check internal buffer for recent duplicate
 		// Optimization: Only check last 50 alerts to avoid O(N^2) on large history
@@ -100,7 +269,11 @@ func (se *SecurityEngine) Analyze(ctx context.Context, device domain.Device) {
 		}
 
 		if !isDuplicate {
+			if alert.ID == "" {
+				alert.ID = fmt.Sprintf("alt_%d", time.Now().UnixNano())
+			}
 			se.alerts = append(se.alerts, alert)
+			raised = append(raised, alert)
 		}
 	}
 
@@ -113,6 +286,40 @@ func (se *SecurityEngine) Analyze(ctx context.Context, device domain.Device) {
 		// For now simple re-slice is fine.
 		se.alerts = se.alerts[offset:]
 	}
+	hook := se.onAlert
+	enricher := se.enricher
+	se.mu.Unlock()
+
+	// Only new alerts are enriched, duplicates being dropped above
+	if enricher != nil && len(raised) > 0 {
+		for i := range raised {
+			enricher.EnrichAlert(ctx, &raised[i])
+		}
+		se.setCVEs(raised)
+	}
+
+	if hook != nil {
+		for _, alert := range raised {
+			hook(alert)
+		}
+	}
+}
+
+// setCVEs copies the CVEs of enriched alerts to the stored ones.
+func (se *SecurityEngine) setCVEs(alerts []domain.Alert) {
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	for _, alert := range alerts {
+		if len(alert.CVEs) == 0 {
+			continue
+		}
+		for i := len(se.alerts) - 1; i >= 0; i-- {
+			if se.alerts[i].ID == alert.ID {
+				se.alerts[i].CVEs = alert.CVEs
+				break
+			}
+		}
+	}
 }
 
 // AnalyzeNetwork is a placeholder for network-wide analysis.
@@ -8,6 +8,7 @@ import (
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockRegistry for SecurityEngine tests
@@ -32,6 +33,7 @@ func (m *MockRegistry) GetAllDevices(ctx context.Context) []domain.Device {
 	args := m.Called(ctx)
 	return args.Get(0).([]domain.Device)
 }
+func (m *MockRegistry) RemoveDevice(ctx context.Context, mac string) (bool, int)   { return false, 0 }
 func (m *MockRegistry) PruneOldDevices(ctx context.Context, ttl time.Duration) int { return 0 }
 func (m *MockRegistry) GetActiveCount(ctx context.Context) int                     { return 0 }
 func (m *MockRegistry) UpdateSSID(ctx context.Context, ssid, security string)      {}
@@ -141,3 +143,129 @@ func TestSecurityEngine_Intelligence(t *testing.T) {
 		assert.True(t, found, "Expected EVIL_TWIN_DETECTED alert")
 	})
 }
+
+func TestSecurityEngine_SetFileRules(t *testing.T) {
+	engine := NewSecurityEngine(new(MockRegistry))
+	ctx := context.Background()
+	detector := &RuleDetector{engine: engine}
+	device := domain.Device{MAC: "00:11:22:33:44:55", SSID: "HiddenLab", Vendor: "Acme"}
+
+	engine.AddRule(ctx, domain.AlertRule{ID: "api", Type: domain.AlertVendor, Value: "Acme", Enabled: true})
+	assert.NoError(t, engine.SetFileRules([]domain.AlertRule{
+		{ID: "file", Type: domain.AlertSSID, Value: "HiddenLab", Exact: true, Enabled: true},
+	}))
+	assert.Len(t, detector.Analyze(&device, nil), 2)
+
+	// A reload replaces the file rules but keeps those added through the API
+	assert.NoError(t, engine.SetFileRules(nil))
+	alerts := detector.Analyze(&device, nil)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, "api", alerts[0].RuleID)
+
+	// Invalid files leave the running rules untouched
+	assert.NoError(t, engine.SetFileRules([]domain.AlertRule{{ID: "a", Type: domain.AlertMAC, Value: device.MAC, Enabled: true}}))
+	assert.ErrorIs(t, engine.SetFileRules([]domain.AlertRule{{ID: "b", Type: domain.AlertSSID}}), domain.ErrEmptyRuleValue)
+	assert.Len(t, detector.Analyze(&device, nil), 2)
+}
+
+func TestSecurityEngine_SetWorkspaceRules(t *testing.T) {
+	engine := NewSecurityEngine(new(MockRegistry))
+	detector := &RuleDetector{engine: engine}
+	device := domain.Device{MAC: "00:11:22:33:44:55", SSID: "HiddenLab"}
+
+	assert.NoError(t, engine.SetFileRules([]domain.AlertRule{{ID: "file", Type: domain.AlertSSID, Value: "HiddenLab", Enabled: true}}))
+	assert.NoError(t, engine.SetWorkspaceRules([]domain.AlertRule{{ID: "ws", Type: domain.AlertMAC, Value: device.MAC, Enabled: true}}))
+	assert.Len(t, detector.Analyze(&device, nil), 2)
+
+	// Switching workspace replaces its rules only
+	assert.NoError(t, engine.SetWorkspaceRules(nil))
+	alerts := detector.Analyze(&device, nil)
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, "file", alerts[0].RuleID)
+}
+
+func TestSecurityEngine_RulePolicies(t *testing.T) {
+	engine := NewSecurityEngine(new(MockRegistry))
+	detector := &RuleDetector{engine: engine}
+	drone := domain.Device{MAC: "60:60:1f:aa:bb:cc", Vendor: "DJI"}
+
+	policy := domain.AlertRule{
+		ID:       "dji",
+		Type:     domain.AlertOUI,
+		Value:    "60-60-1F",
+		Enabled:  true,
+		Severity: domain.SeverityCritical,
+		Actions:  []domain.RuleAction{domain.RuleActionAlert, domain.RuleActionTag, domain.RuleActionRecord},
+		Tag:      "drone",
+	}
+	require.NoError(t, engine.SetFileRules([]domain.AlertRule{
+		policy,
+		{ID: "quiet", Type: domain.AlertOUI, Value: "60:60:1f", Enabled: true, Actions: []domain.RuleAction{domain.RuleActionTag}, Tag: "dji"},
+	}))
+
+	matched := engine.MatchRules(drone)
+	require.Len(t, matched, 2)
+	assert.Empty(t, engine.MatchRules(domain.Device{MAC: "00:11:22:33:44:55"}))
+
+	// Only rules with the alert action raise alerts, at their severity
+	alerts := detector.Analyze(&drone, nil)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "dji", alerts[0].RuleID)
+	assert.Equal(t, domain.SeverityCritical, alerts[0].Severity)
+	assert.Equal(t, domain.DefaultRecordDuration, matched[0].RecordFor())
+
+	for _, bad := range []struct {
+		rule domain.AlertRule
+		err  error
+	}{
+		{domain.AlertRule{Type: domain.AlertOUI, Value: "DJI"}, domain.ErrInvalidOUI},
+		{domain.AlertRule{Type: domain.AlertOUI, Value: "60:60:1f", Actions: []domain.RuleAction{"block"}}, domain.ErrInvalidRuleAction},
+		{domain.AlertRule{Type: domain.AlertOUI, Value: "60:60:1f", Actions: []domain.RuleAction{domain.RuleActionTag}}, domain.ErrEmptyRuleTag},
+		{domain.AlertRule{Type: domain.AlertVendor, Value: "DJI", Severity: "urgent"}, domain.ErrInvalidSeverity},
+	} {
+		assert.ErrorIs(t, engine.SetFileRules([]domain.AlertRule{bad.rule}), bad.err)
+	}
+}
+
+func TestSecurityEngine_AlertHookAndEvidence(t *testing.T) {
+	engine := NewSecurityEngine(new(MockRegistry))
+	var raised []domain.Alert
+	engine.OnAlert(func(alert domain.Alert) { raised = append(raised, alert) })
+
+	device := domain.Device{MAC: "00:11:22:33:44:55", PacketsCount: 100, RetryCount: 30}
+	engine.Analyze(context.Background(), device)
+	engine.Analyze(context.Background(), device)
+
+	// Duplicates are neither stored nor reported
+	require.Len(t, raised, 1)
+	require.NotEmpty(t, raised[0].ID)
+
+	assert.True(t, engine.AttachEvidence(raised[0].ID, "art-1"))
+	assert.False(t, engine.AttachEvidence("alt_unknown", "art-2"))
+	alerts := engine.GetAlerts(context.Background())
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "art-1", alerts[0].EvidenceID)
+}
+
+// countingEnricher adds one CVE to each alert, counting the alerts enriched.
+type countingEnricher struct{ enriched int }
+
+func (e *countingEnricher) EnrichAlert(ctx context.Context, alert *domain.Alert) {
+	e.enriched++
+	alert.CVEs = []domain.AlertCVE{{ID: "CVE-2017-13077", CVSS: 6.8}}
+}
+
+func TestSecurityEngine_EnrichesRaisedAlertsOnly(t *testing.T) {
+	engine := NewSecurityEngine(new(MockRegistry))
+	enricher := &countingEnricher{}
+	engine.SetAlertEnricher(enricher)
+
+	device := domain.Device{MAC: "00:11:22:33:44:55", PacketsCount: 100, RetryCount: 30}
+	engine.Analyze(context.Background(), device)
+	engine.Analyze(context.Background(), device)
+
+	assert.Equal(t, 1, enricher.enriched, "duplicates are not enriched")
+	alerts := engine.GetAlerts(context.Background())
+	require.Len(t, alerts, 1)
+	assert.Len(t, alerts[0].CVEs, 1, "the stored alert carries the CVEs")
+}
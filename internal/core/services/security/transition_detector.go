@@ -0,0 +1,111 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+// detectTransitionMisconfigurations checks the pairing of OWE transition
+// mode APs, whose open and OWE halves must point at each other, and flags
+// OWE-only and WPA3-only networks that legacy clients cannot join.
+func (vd *VulnerabilityDetector) detectTransitionMisconfigurations(device *domain.Device) []domain.VulnerabilityTag {
+	tags := []domain.VulnerabilityTag{}
+
+	switch {
+	case device.OWETransition != nil:
+		if vulnTag := vd.detectOWETransitionPair(device); vulnTag != nil {
+			tags = append(tags, *vulnTag)
+		}
+	case device.Security == domain.SecurityOWE:
+		tags = append(tags, domain.VulnerabilityTag{
+			Name:        "OWE-ONLY",
+			Severity:    domain.VulnSeverityInfo,
+			Confidence:  domain.ConfidenceConfirmed,
+			Evidence:    []string{"OWE AKM advertised without an OWE Transition Mode element"},
+			DetectedAt:  time.Now(),
+			Category:    "configuration",
+			Description: "OWE-only network without transition mode - clients lacking OWE support cannot connect",
+			Mitigation:  "Enable OWE transition mode with an open counterpart if legacy clients must connect",
+		})
+	}
+
+	if isWPA3Only(device) {
+		tags = append(tags, domain.VulnerabilityTag{
+			Name:        "WPA3-ONLY",
+			Severity:    domain.VulnSeverityInfo,
+			Confidence:  domain.ConfidenceConfirmed,
+			Evidence:    []string{fmt.Sprintf("AKMs advertised: %s", strings.Join(device.RSNInfo.AKMSuites, ", "))},
+			DetectedAt:  time.Now(),
+			Category:    "configuration",
+			Description: "WPA3-only network without transition mode - WPA2 clients cannot connect",
+			Mitigation:  "Enable WPA3 transition mode, or a separate WPA2 SSID, if WPA2 clients must connect",
+		})
+	}
+
+	return tags
+}
+
+// detectOWETransitionPair looks up the counterpart an OWE transition mode AP
+// points at. An open AP must point at an OWE one and the other way round;
+// a counterpart never heard or not pointing back leaves clients on the open
+// half unencrypted, or unable to find the OWE half.
+func (vd *VulnerabilityDetector) detectOWETransitionPair(device *domain.Device) *domain.VulnerabilityTag {
+	if vd.registry == nil {
+		return nil
+	}
+
+	wantSecurity := domain.SecurityOWE
+	if device.Security == domain.SecurityOWE {
+		wantSecurity = domain.SecurityOpen
+	}
+	counterpartMAC := device.OWETransition.BSSID
+
+	counterpart, found := vd.registry.GetDevice(context.Background(), counterpartMAC)
+	if !found {
+		return &domain.VulnerabilityTag{
+			Name:        "OWE-TRANSITION-UNPAIRED",
+			Severity:    domain.VulnSeverityLow,
+			Confidence:  domain.ConfidenceMedium,
+			Evidence:    []string{fmt.Sprintf("Points at %s %q, which was never heard", counterpartMAC, device.OWETransition.SSID)},
+			DetectedAt:  time.Now(),
+			Category:    "configuration",
+			Description: fmt.Sprintf("OWE transition AP missing %s counterpart", strings.ToLower(wantSecurity)),
+			Mitigation:  "Verify both the open and the OWE BSS of the transition pair are enabled",
+		}
+	}
+
+	var evidence []string
+	if counterpart.Security != wantSecurity {
+		evidence = append(evidence, fmt.Sprintf("Counterpart %s is %s, expected %s", counterpartMAC, counterpart.Security, wantSecurity))
+	}
+	if counterpart.OWETransition == nil {
+		evidence = append(evidence, fmt.Sprintf("Counterpart %s has no OWE Transition Mode element", counterpartMAC))
+	} else if !strings.EqualFold(counterpart.OWETransition.BSSID, device.MAC) {
+		evidence = append(evidence, fmt.Sprintf("Counterpart %s points at %s instead", counterpartMAC, counterpart.OWETransition.BSSID))
+	}
+	if len(evidence) == 0 {
+		return nil
+	}
+	return &domain.VulnerabilityTag{
+		Name:        "OWE-TRANSITION-MISMATCH",
+		Severity:    domain.VulnSeverityLow,
+		Confidence:  domain.ConfidenceConfirmed,
+		Evidence:    evidence,
+		DetectedAt:  time.Now(),
+		Category:    "configuration",
+		Description: "OWE transition pair misconfigured - clients may stay on the open BSS unencrypted",
+		Mitigation:  "Configure the open and the OWE BSS to advertise each other in their OWE Transition Mode elements",
+	}
+}
+
+// isWPA3Only reports an SAE AP offering no PSK AKM to fall back to.
+func isWPA3Only(device *domain.Device) bool {
+	if device.Security != domain.SecurityWPA3 || device.RSNInfo == nil {
+		return false
+	}
+	return !device.UsesPSK()
+}
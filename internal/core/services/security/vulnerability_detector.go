@@ -15,6 +15,7 @@ type VulnerabilityDetector struct {
 	registry   ports.DeviceRegistry
 	vendorDB   *VendorDatabase
 	cveMatcher ports.CVEMatcher
+	regDomain  string // Local regulatory domain, empty if unknown
 }
 
 // NewVulnerabilityDetector creates a new vulnerability detector.
@@ -34,6 +35,11 @@ func (vd *VulnerabilityDetector) SetCVEMatcher(matcher ports.CVEMatcher) {
 	vd.cveMatcher = matcher
 }
 
+// SetRegulatoryDomain sets the local regulatory domain APs are checked against
+func (vd *VulnerabilityDetector) SetRegulatoryDomain(cc string) {
+	vd.regDomain = cc
+}
+
 // DetectVulnerabilities performs passive vulnerability analysis on a device.
 func (vd *VulnerabilityDetector) DetectVulnerabilities(device *domain.Device) []domain.VulnerabilityTag {
 	tags := []domain.VulnerabilityTag{}
@@ -65,14 +71,49 @@ func (vd *VulnerabilityDetector) detectAPVulnerabilities(device *domain.Device)
 		tags = append(tags, detectConfigurationVulnerabilitiesEnhanced(device, vd.vendorDB)...)
 	}
 
-	// 4. CVE Detection
+	// 4. Regulatory mismatch
+	if vulnTag := detectRegulatoryMismatch(device, vd.regDomain); vulnTag != nil {
+		tags = append(tags, *vulnTag)
+	}
+
+	// 5. CVE Detection
 	if vd.cveMatcher != nil {
 		tags = append(tags, vd.detectCVEs(device)...)
 	}
 
+	// 6. Weak Handshake Nonces
+	if vulnTag := detectWeakNonces(device); vulnTag != nil {
+		tags = append(tags, *vulnTag)
+	}
+
+	// 7. OWE and WPA3 transition mode
+	tags = append(tags, vd.detectTransitionMisconfigurations(device)...)
+
 	return tags
 }
 
+// detectWeakNonces reports devices whose handshake nonces were zero,
+// patterned or reused, the sign of a broken random number generator
+func detectWeakNonces(device *domain.Device) *domain.VulnerabilityTag {
+	if device.WeakNonces == nil || len(device.WeakNonces.Evidence) == 0 {
+		return nil
+	}
+	severity := domain.VulnSeverityHigh
+	if device.WeakNonces.Zero {
+		severity = domain.VulnSeverityCritical
+	}
+	return &domain.VulnerabilityTag{
+		Name:        "BAD-RNG",
+		Severity:    severity,
+		Confidence:  domain.ConfidenceConfirmed,
+		Evidence:    device.WeakNonces.Evidence,
+		DetectedAt:  time.Now(),
+		Category:    "crypto",
+		Description: "Handshake nonces are predictable - session keys may be derived or replayed without the passphrase",
+		Mitigation:  "Update the firmware; its random number generator is broken",
+	}
+}
+
 // detectCVEs attempts to match device against CVE database
 func (vd *VulnerabilityDetector) detectCVEs(device *domain.Device) []domain.VulnerabilityTag {
 	if vd.cveMatcher == nil {
@@ -123,11 +164,16 @@ func (vd *VulnerabilityDetector) detectProtocolVulnerabilities(device *domain.De
 
 	// WEP Detection (Critical)
 	if strings.Contains(strings.ToUpper(device.Security), "WEP") {
+		evidence := []string{"WEP encryption detected in beacon/probe"}
+		if device.WEPIVs != nil {
+			evidence = append(evidence, fmt.Sprintf("%d unique IVs captured (%d weak), key recovery %s",
+				device.WEPIVs.UniqueIVs, device.WEPIVs.WeakIVs, device.WEPIVs.Crackability()))
+		}
 		tags = append(tags, domain.VulnerabilityTag{
 			Name:        "WEP",
 			Severity:    domain.VulnSeverityCritical,
 			Confidence:  domain.ConfidenceConfirmed,
-			Evidence:    []string{"WEP encryption detected in beacon/probe"},
+			Evidence:    evidence,
 			DetectedAt:  time.Now(),
 			Category:    "protocol",
 			Description: "WEP is fundamentally broken and can be cracked in minutes",
@@ -1,9 +1,11 @@
 package security_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
 	"github.com/lcalzada-xor/wmap/internal/core/services/security"
 	"github.com/stretchr/testify/assert"
 )
@@ -120,4 +122,113 @@ func TestVulnerabilityDetector_DetectVulnerabilities(t *testing.T) {
 		}
 		assert.True(t, found)
 	})
+	t.Run("Detects KRACK", func(t *testing.T) {
+		dev := &domain.Device{
+			Type:              domain.DeviceTypeStation,
+			KeyReinstallation: []string{"message 3 retransmitted", "data frame reused packet number 1"},
+		}
+		tags := vd.DetectVulnerabilities(dev)
+		assert.Len(t, tags, 1)
+		assert.Equal(t, "KRACK", tags[0].Name)
+		assert.Equal(t, dev.KeyReinstallation, tags[0].Evidence)
+	})
+	t.Run("Detects BAD-RNG", func(t *testing.T) {
+		dev := &domain.Device{
+			Type:       domain.DeviceTypeAP,
+			Security:   "WPA2",
+			WeakNonces: &domain.WeakNonces{Zero: true, Evidence: []string{"all-zero ANonce in message 1"}},
+		}
+		tags := vd.DetectVulnerabilities(dev)
+		found := false
+		for _, tag := range tags {
+			if tag.Name == "BAD-RNG" {
+				found = true
+				assert.Equal(t, domain.VulnSeverityCritical, tag.Severity)
+			}
+		}
+		assert.True(t, found)
+	})
+}
+
+func TestVulnerabilityDetector_RegulatoryMismatch(t *testing.T) {
+	hasMismatch := func(tags []domain.VulnerabilityTag) bool {
+		for _, tag := range tags {
+			if tag.Name == "REGDOMAIN-MISMATCH" {
+				return true
+			}
+		}
+		return false
+	}
+
+	vd := security.NewVulnerabilityDetector(nil)
+	foreign := &domain.Device{Type: domain.DeviceTypeAP, Security: "WPA2", Country: "US", Channel: 149, MaxTxPower: 30}
+	assert.False(t, hasMismatch(vd.DetectVulnerabilities(foreign)), "no local domain configured")
+
+	vd.SetRegulatoryDomain("ES")
+	assert.True(t, hasMismatch(vd.DetectVulnerabilities(foreign)))
+
+	local := &domain.Device{Type: domain.DeviceTypeAP, Security: "WPA2", Country: "ES"}
+	assert.False(t, hasMismatch(vd.DetectVulnerabilities(local)))
+
+	silent := &domain.Device{Type: domain.DeviceTypeAP, Security: "WPA2"}
+	assert.False(t, hasMismatch(vd.DetectVulnerabilities(silent)))
+}
+
+// devicesRegistry serves devices by MAC to detectors looking up others.
+type devicesRegistry struct {
+	ports.DeviceRegistry
+	devices map[string]domain.Device
+}
+
+func (r devicesRegistry) GetDevice(ctx context.Context, mac string) (domain.Device, bool) {
+	d, ok := r.devices[mac]
+	return d, ok
+}
+
+func TestVulnerabilityDetector_TransitionMisconfigurations(t *testing.T) {
+	findings := func(tags []domain.VulnerabilityTag) map[string]bool {
+		names := map[string]bool{}
+		for _, tag := range tags {
+			names[tag.Name] = true
+		}
+		return names
+	}
+
+	open := domain.Device{MAC: "02:00:00:00:00:01", Type: domain.DeviceTypeAP, SSID: "Guest", Security: domain.SecurityOpen,
+		OWETransition: &domain.OWETransition{BSSID: "02:00:00:00:00:02"}}
+	owe := domain.Device{MAC: "02:00:00:00:00:02", Type: domain.DeviceTypeAP, Security: domain.SecurityOWE,
+		RSNInfo:       &domain.RSNInfo{AKMSuites: []string{"OWE"}},
+		OWETransition: &domain.OWETransition{BSSID: "02:00:00:00:00:01", SSID: "Guest"}}
+
+	t.Run("paired transition APs", func(t *testing.T) {
+		vd := security.NewVulnerabilityDetector(devicesRegistry{devices: map[string]domain.Device{open.MAC: open, owe.MAC: owe}})
+		assert.False(t, findings(vd.DetectVulnerabilities(&owe))["OWE-TRANSITION-MISMATCH"])
+		assert.False(t, findings(vd.DetectVulnerabilities(&open))["OWE-TRANSITION-MISMATCH"])
+	})
+
+	t.Run("open counterpart never heard", func(t *testing.T) {
+		vd := security.NewVulnerabilityDetector(devicesRegistry{devices: map[string]domain.Device{owe.MAC: owe}})
+		tags := vd.DetectVulnerabilities(&owe)
+		assert.True(t, findings(tags)["OWE-TRANSITION-UNPAIRED"])
+	})
+
+	t.Run("counterpart not pointing back", func(t *testing.T) {
+		lone := open
+		lone.OWETransition = nil
+		vd := security.NewVulnerabilityDetector(devicesRegistry{devices: map[string]domain.Device{open.MAC: lone}})
+		assert.True(t, findings(vd.DetectVulnerabilities(&owe))["OWE-TRANSITION-MISMATCH"])
+	})
+
+	t.Run("OWE and WPA3 only networks", func(t *testing.T) {
+		vd := security.NewVulnerabilityDetector(nil)
+		oweOnly := owe
+		oweOnly.OWETransition = nil
+		assert.True(t, findings(vd.DetectVulnerabilities(&oweOnly))["OWE-ONLY"])
+
+		sae := &domain.Device{Type: domain.DeviceTypeAP, Security: domain.SecurityWPA3, RSNInfo: &domain.RSNInfo{AKMSuites: []string{"SAE"}}}
+		assert.True(t, findings(vd.DetectVulnerabilities(sae))["WPA3-ONLY"])
+
+		sae.RSNInfo.AKMSuites = append(sae.RSNInfo.AKMSuites, "PSK")
+		assert.False(t, findings(vd.DetectVulnerabilities(sae))["WPA3-ONLY"], "transition mode")
+	})
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
@@ -13,8 +14,12 @@ import (
 
 // VulnerabilityPersistenceService handles storage and retrieval of vulnerabilities.
 type VulnerabilityPersistenceService struct {
-	storage  ports.Storage
-	notifier ports.VulnerabilityNotifier
+	storage   ports.Storage
+	notifier  ports.VulnerabilityNotifier
+	notifiers []ports.VulnerabilityNotifier // Notified after notifier, e.g. issue trackers
+	sealer    ports.SecretSealer            // Encrypts credentials in confirmation evidence
+	audit     ports.AuditService            // Records suppressions, optional
+	router    ports.WorkspaceRouter         // Attributes findings to the workspace of their sensor, optional
 }
 
 // NewVulnerabilityPersistenceService creates a new service instance.
@@ -29,6 +34,29 @@ func (s *VulnerabilityPersistenceService) SetNotifier(notifier ports.Vulnerabili
 	s.notifier = notifier
 }
 
+// AddNotifier adds a notifier of new and confirmed vulnerabilities, after the
+// one set with SetNotifier.
+func (s *VulnerabilityPersistenceService) AddNotifier(notifier ports.VulnerabilityNotifier) {
+	s.notifiers = append(s.notifiers, notifier)
+}
+
+// SetSealer enables encryption at rest of the credentials (WPS PINs, PSKs)
+// found in confirmation evidence.
+func (s *VulnerabilityPersistenceService) SetSealer(sealer ports.SecretSealer) {
+	s.sealer = sealer
+}
+
+// SetAuditService records suppressions in the audit log.
+func (s *VulnerabilityPersistenceService) SetAuditService(audit ports.AuditService) {
+	s.audit = audit
+}
+
+// SetRouter attributes the findings on devices heard by routed sensors to
+// the open workspace they feed.
+func (s *VulnerabilityPersistenceService) SetRouter(router ports.WorkspaceRouter) {
+	s.router = router
+}
+
 // GenerateID creates a deterministic ID for a vulnerability.
 func (s *VulnerabilityPersistenceService) GenerateID(vuln domain.VulnerabilityTag, mac string) string {
 	raw := fmt.Sprintf("%s|%s|%s", mac, vuln.Name, vuln.Category)
@@ -38,11 +66,26 @@ func (s *VulnerabilityPersistenceService) GenerateID(vuln domain.VulnerabilityTa
 
 // ProcessDetections saves new detections and updates existing ones.
 func (s *VulnerabilityPersistenceService) ProcessDetections(mac string, vulns []domain.VulnerabilityTag) error {
+	return s.ProcessSensorDetections(mac, "", vulns)
+}
+
+// ProcessSensorDetections saves the detections on a device heard by sensor.
+// Those of a sensor routed to an open workspace are kept apart from the
+// findings of the same device elsewhere.
+func (s *VulnerabilityPersistenceService) ProcessSensorDetections(mac, sensor string, vulns []domain.VulnerabilityTag) error {
 	ctx := context.Background()
 	fmt.Printf("[VULN-PERSIST] Processing %d vulnerabilities for device %s\n", len(vulns), mac)
 
+	var workspace string
+	if s.router != nil {
+		workspace, _ = s.router.Route(sensor)
+	}
+
 	for _, v := range vulns {
 		id := s.GenerateID(v, mac)
+		if workspace != "" {
+			id = s.GenerateID(v, workspace+"|"+mac)
+		}
 		record := domain.VulnerabilityRecord{
 			ID:          id,
 			DeviceMAC:   mac,
@@ -54,6 +97,7 @@ func (s *VulnerabilityPersistenceService) ProcessDetections(mac string, vulns []
 			Status:      domain.VulnStatusActive,
 			Evidence:    v.Evidence,
 			Description: v.Description,
+			Workspace:   workspace,
 		}
 
 		// Check if exists to determine if we should notify
@@ -65,6 +109,9 @@ func (s *VulnerabilityPersistenceService) ProcessDetections(mac string, vulns []
 			if s.notifier != nil {
 				s.notifier.NotifyNewVulnerability(ctx, record)
 			}
+			for _, n := range s.notifiers {
+				n.NotifyNewVulnerability(ctx, record)
+			}
 		}
 
 		if err := s.storage.SaveVulnerability(ctx, record); err != nil {
@@ -86,6 +133,57 @@ func (s *VulnerabilityPersistenceService) UpdateStatus(id string, status domain.
 	return s.storage.UpdateVulnerabilityStatus(context.Background(), id, status, notes)
 }
 
+// Suppress accepts a finding as a known risk or false positive, on behalf of
+// the user in ctx, until expiresAt or for good when nil. The finding stays
+// stored and detected but is left out of reports and issue trackers.
+func (s *VulnerabilityPersistenceService) Suppress(ctx context.Context, id, justification string, expiresAt *time.Time) (*domain.VulnerabilityRecord, error) {
+	record, err := s.storage.GetVulnerability(ctx, id)
+	if err != nil {
+		return nil, domain.ErrVulnerabilityNotFound
+	}
+
+	suppression := domain.VulnerabilitySuppression{
+		Justification: justification,
+		SuppressedBy:  "system",
+		SuppressedAt:  time.Now().UTC(),
+		ExpiresAt:     expiresAt,
+	}
+	if err := suppression.Validate(suppression.SuppressedAt); err != nil {
+		return nil, err
+	}
+	if u, ok := domain.UserFromContext(ctx); ok {
+		suppression.SuppressedBy = u.Username
+	}
+	if err := s.storage.SetVulnerabilitySuppression(ctx, id, &suppression); err != nil {
+		return nil, err
+	}
+
+	if s.audit != nil {
+		details := fmt.Sprintf("%s on %s suppressed: %q", record.Name, record.DeviceMAC, suppression.Justification)
+		if expiresAt != nil {
+			details += " until " + expiresAt.UTC().Format(time.RFC3339)
+		}
+		s.audit.Log(ctx, domain.ActionVulnSuppress, id, details)
+	}
+	record.Suppression = &suppression
+	return record, nil
+}
+
+// Unsuppress lifts the suppression of a finding, which is reported again.
+func (s *VulnerabilityPersistenceService) Unsuppress(ctx context.Context, id string) error {
+	record, err := s.storage.GetVulnerability(ctx, id)
+	if err != nil {
+		return domain.ErrVulnerabilityNotFound
+	}
+	if err := s.storage.SetVulnerabilitySuppression(ctx, id, nil); err != nil {
+		return err
+	}
+	if s.audit != nil {
+		s.audit.Log(ctx, domain.ActionVulnRestore, id, fmt.Sprintf("%s on %s reported again", record.Name, record.DeviceMAC))
+	}
+	return nil
+}
+
 // ConfirmVulnerability updates a vulnerability record when confirmed via active attack
 func (s *VulnerabilityPersistenceService) ConfirmVulnerability(ctx context.Context, confirmation domain.VulnerabilityConfirmation) error {
 	fmt.Printf("[VULN-CONFIRM] Confirming vulnerability %s for device %s via %s\n",
@@ -118,6 +216,13 @@ func (s *VulnerabilityPersistenceService) ConfirmVulnerability(ctx context.Conte
 		return fmt.Errorf("vulnerability %s not found for device %s", confirmation.VulnerabilityName, confirmation.DeviceMAC)
 	}
 
+	// Credentials never reach the database in plaintext
+	evidence, err := s.sealEvidence(confirmation.Evidence)
+	if err != nil {
+		return fmt.Errorf("failed to seal confirmation evidence: %w", err)
+	}
+	confirmation.Evidence = evidence
+
 	// Update the vulnerability with confirmation details
 	targetVuln.ConfirmWithEvidence(confirmation)
 
@@ -134,6 +239,89 @@ func (s *VulnerabilityPersistenceService) ConfirmVulnerability(ctx context.Conte
 	if s.notifier != nil {
 		s.notifier.NotifyVulnerabilityConfirmed(ctx, *targetVuln)
 	}
+	for _, n := range s.notifiers {
+		n.NotifyVulnerabilityConfirmed(ctx, *targetVuln)
+	}
 
 	return nil
 }
+
+// SealStoredCredentials encrypts credentials that earlier versions stored in
+// plaintext evidence. It returns the number of records updated.
+func (s *VulnerabilityPersistenceService) SealStoredCredentials(ctx context.Context) (int, error) {
+	if s.sealer == nil {
+		return 0, nil
+	}
+	records, err := s.storage.GetVulnerabilities(ctx, domain.VulnerabilityFilter{})
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, record := range records {
+		changed := false
+		for i, entry := range record.Evidence {
+			key, value, source, ok := domain.ParseConfirmationEvidence(entry)
+			if !ok || !domain.IsCredentialEvidence(key) || value == "" || strings.HasPrefix(value, domain.SealedEvidencePrefix) {
+				continue
+			}
+			sealed, err := s.sealer.Seal(value)
+			if err != nil {
+				return updated, err
+			}
+			record.Evidence[i] = domain.FormatConfirmationEvidence(key, domain.SealedEvidencePrefix+sealed, source)
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		if err := s.storage.SaveVulnerability(ctx, record); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// RevealVulnerability returns a vulnerability with its sealed credentials decrypted.
+func (s *VulnerabilityPersistenceService) RevealVulnerability(ctx context.Context, id string) (*domain.VulnerabilityRecord, error) {
+	record, err := s.storage.GetVulnerability(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.sealer == nil {
+		return record, nil
+	}
+
+	for i, entry := range record.Evidence {
+		key, value, source, ok := domain.ParseConfirmationEvidence(entry)
+		if !ok || !strings.HasPrefix(value, domain.SealedEvidencePrefix) {
+			continue
+		}
+		plain, err := s.sealer.Open(strings.TrimPrefix(value, domain.SealedEvidencePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("open %s evidence: %w", key, err)
+		}
+		record.Evidence[i] = domain.FormatConfirmationEvidence(key, plain, source)
+	}
+	return record, nil
+}
+
+// sealEvidence returns a copy of the evidence with credential values sealed.
+func (s *VulnerabilityPersistenceService) sealEvidence(evidence map[string]string) (map[string]string, error) {
+	if s.sealer == nil {
+		return evidence, nil
+	}
+	sealed := make(map[string]string, len(evidence))
+	for key, value := range evidence {
+		if domain.IsCredentialEvidence(key) && value != "" {
+			ciphertext, err := s.sealer.Seal(value)
+			if err != nil {
+				return nil, err
+			}
+			value = domain.SealedEvidencePrefix + ciphertext
+		}
+		sealed[key] = value
+	}
+	return sealed, nil
+}
@@ -3,11 +3,12 @@ package security
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/core/domain"
-	//"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
 )
 
 // MockStorage for testing
@@ -16,6 +17,7 @@ type MockStorage struct {
 	GetVulnerabilitiesFunc func(ctx context.Context, filter domain.VulnerabilityFilter) ([]domain.VulnerabilityRecord, error)
 	GetVulnerabilityFunc   func(ctx context.Context, id string) (*domain.VulnerabilityRecord, error)
 	UpdateStatusFunc       func(ctx context.Context, id string, status domain.VulnerabilityStatus, notes string) error
+	SetSuppressionFunc     func(ctx context.Context, id string, suppression *domain.VulnerabilitySuppression) error
 
 	// unused methods for interface compliance
 	SaveDeviceFunc func(ctx context.Context, device domain.Device) error
@@ -49,6 +51,13 @@ func (m *MockStorage) UpdateVulnerabilityStatus(ctx context.Context, id string,
 	return nil
 }
 
+func (m *MockStorage) SetVulnerabilitySuppression(ctx context.Context, id string, suppression *domain.VulnerabilitySuppression) error {
+	if m.SetSuppressionFunc != nil {
+		return m.SetSuppressionFunc(ctx, id, suppression)
+	}
+	return nil
+}
+
 // Satisfy Full Storage Interface stubbing (minimal)
 func (m *MockStorage) SaveDevice(ctx context.Context, d domain.Device) error         { return nil }
 func (m *MockStorage) SaveDevicesBatch(ctx context.Context, d []domain.Device) error { return nil }
@@ -176,3 +185,209 @@ func TestUpdateStatus_ShouldPassNotes(t *testing.T) {
 		t.Errorf("Expected notes 'False positive', got '%s'", capturedNotes)
 	}
 }
+
+func TestConfirmVulnerability_SealsCredentials(t *testing.T) {
+	record := domain.VulnerabilityRecord{ID: "wps", DeviceMAC: "00:11:22:33:44:55", Name: "WPS-PIXIE"}
+	mockStorage := &MockStorage{}
+	mockStorage.GetVulnerabilitiesFunc = func(ctx context.Context, filter domain.VulnerabilityFilter) ([]domain.VulnerabilityRecord, error) {
+		return []domain.VulnerabilityRecord{record}, nil
+	}
+	mockStorage.SaveVulnerabilityFunc = func(ctx context.Context, r domain.VulnerabilityRecord) error {
+		record = r
+		return nil
+	}
+	mockStorage.GetVulnerabilityFunc = func(ctx context.Context, id string) (*domain.VulnerabilityRecord, error) {
+		r := record
+		r.Evidence = append([]string(nil), record.Evidence...)
+		return &r, nil
+	}
+
+	service := NewVulnerabilityPersistenceService(mockStorage)
+	service.SetSealer(reverseSealer{})
+
+	err := service.ConfirmVulnerability(context.Background(), domain.VulnerabilityConfirmation{
+		VulnerabilityName: "WPS-PIXIE",
+		DeviceMAC:         "00:11:22:33:44:55",
+		ConfirmedBy:       domain.ConfirmationSourceWPSAttack,
+		Evidence:          map[string]string{"pin": "12345670", "psk": "hunter22", "attack_id": "a1"},
+		ConfirmedAt:       time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Stored evidence holds no plaintext credential
+	for _, entry := range record.Evidence {
+		if strings.Contains(entry, "12345670") || strings.Contains(entry, "hunter22") {
+			t.Errorf("Credential stored in plaintext: %s", entry)
+		}
+	}
+	if !containsEntry(record.Evidence, "attack_id: a1 (confirmed by wps-attack)") {
+		t.Errorf("Expected non-credential evidence to stay readable, got %v", record.Evidence)
+	}
+
+	revealed, err := service.RevealVulnerability(context.Background(), "wps")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !containsEntry(revealed.Evidence, "pin: 12345670 (confirmed by wps-attack)") ||
+		!containsEntry(revealed.Evidence, "psk: hunter22 (confirmed by wps-attack)") {
+		t.Errorf("Expected revealed credentials, got %v", revealed.Evidence)
+	}
+}
+
+func TestSealStoredCredentials(t *testing.T) {
+	legacy := domain.VulnerabilityRecord{ID: "legacy", Evidence: []string{
+		"pin: 12345670 (confirmed by wps-attack)",
+		"psk: sealed:already (confirmed by wps-attack)",
+		"WPS enabled",
+	}}
+	var saved []domain.VulnerabilityRecord
+	mockStorage := &MockStorage{}
+	mockStorage.GetVulnerabilitiesFunc = func(ctx context.Context, filter domain.VulnerabilityFilter) ([]domain.VulnerabilityRecord, error) {
+		return []domain.VulnerabilityRecord{legacy, {ID: "clean", Evidence: []string{"Open network"}}}, nil
+	}
+	mockStorage.SaveVulnerabilityFunc = func(ctx context.Context, r domain.VulnerabilityRecord) error {
+		saved = append(saved, r)
+		return nil
+	}
+
+	service := NewVulnerabilityPersistenceService(mockStorage)
+	service.SetSealer(reverseSealer{})
+
+	n, err := service.SealStoredCredentials(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 1 || len(saved) != 1 {
+		t.Fatalf("Expected 1 record updated, got %d (%d saved)", n, len(saved))
+	}
+	want := []string{
+		"pin: sealed:sealed:07654321 (confirmed by wps-attack)",
+		"psk: sealed:already (confirmed by wps-attack)",
+		"WPS enabled",
+	}
+	for i, entry := range want {
+		if saved[0].Evidence[i] != entry {
+			t.Errorf("Evidence[%d] = %q, want %q", i, saved[0].Evidence[i], entry)
+		}
+	}
+}
+
+func containsEntry(entries []string, want string) bool {
+	for _, entry := range entries {
+		if entry == want {
+			return true
+		}
+	}
+	return false
+}
+
+// recordingAudit keeps the actions logged.
+type recordingAudit struct {
+	actions []domain.AuditAction
+	details []string
+}
+
+func (a *recordingAudit) Log(ctx context.Context, action domain.AuditAction, target, details string) error {
+	a.actions = append(a.actions, action)
+	a.details = append(a.details, details)
+	return nil
+}
+
+func (a *recordingAudit) GetLogs(ctx context.Context, limit int) ([]domain.AuditLog, error) {
+	return nil, nil
+}
+
+func TestSuppress_RecordsJustification(t *testing.T) {
+	var stored *domain.VulnerabilitySuppression
+	mockStorage := &MockStorage{}
+	mockStorage.GetVulnerabilityFunc = func(ctx context.Context, id string) (*domain.VulnerabilityRecord, error) {
+		if id != "guest" {
+			return nil, errors.New("record not found")
+		}
+		return &domain.VulnerabilityRecord{ID: id, Name: "OPEN-NETWORK", DeviceMAC: "AA:BB:CC:DD:EE:FF"}, nil
+	}
+	mockStorage.SetSuppressionFunc = func(ctx context.Context, id string, suppression *domain.VulnerabilitySuppression) error {
+		stored = suppression
+		return nil
+	}
+	audit := &recordingAudit{}
+	service := NewVulnerabilityPersistenceService(mockStorage)
+	service.SetAuditService(audit)
+	ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: "1", Username: "alice"})
+
+	if _, err := service.Suppress(ctx, "guest", "  ", nil); !errors.Is(err, domain.ErrEmptyJustification) {
+		t.Fatalf("Expected ErrEmptyJustification, got %v", err)
+	}
+	if _, err := service.Suppress(ctx, "missing", "Accepted", nil); !errors.Is(err, domain.ErrVulnerabilityNotFound) {
+		t.Fatalf("Expected ErrVulnerabilityNotFound, got %v", err)
+	}
+
+	record, err := service.Suppress(ctx, "guest", " Guest SSID is open by design ", nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stored == nil || stored.Justification != "Guest SSID is open by design" || stored.SuppressedBy != "alice" {
+		t.Fatalf("Unexpected suppression stored: %+v", stored)
+	}
+	if !record.IsSuppressed() {
+		t.Error("Expected the returned record to be suppressed")
+	}
+
+	if err := service.Unsuppress(ctx, "guest"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stored != nil {
+		t.Error("Expected the suppression to be lifted")
+	}
+	want := []domain.AuditAction{domain.ActionVulnSuppress, domain.ActionVulnRestore}
+	if len(audit.actions) != 2 || audit.actions[0] != want[0] || audit.actions[1] != want[1] {
+		t.Fatalf("Expected audit actions %v, got %v", want, audit.actions)
+	}
+	if !strings.Contains(audit.details[0], "Guest SSID is open by design") {
+		t.Errorf("Expected the justification in the audit log, got %q", audit.details[0])
+	}
+}
+
+// siteRouter routes one sensor to the site-b workspace
+type siteRouter struct{ store *MockStorage }
+
+func (r siteRouter) Route(sensor string) (string, ports.Storage) {
+	if sensor == "agent-b/wlan1" {
+		return "site-b", r.store
+	}
+	return "", nil
+}
+func (r siteRouter) WorkspaceOf(sensor string) string {
+	name, _ := r.Route(sensor)
+	return name
+}
+
+func TestProcessSensorDetections_Routed(t *testing.T) {
+	var saved []domain.VulnerabilityRecord
+	mockStorage := &MockStorage{SaveVulnerabilityFunc: func(ctx context.Context, record domain.VulnerabilityRecord) error {
+		saved = append(saved, record)
+		return nil
+	}}
+	service := NewVulnerabilityPersistenceService(mockStorage)
+	service.SetRouter(siteRouter{})
+
+	vuln := domain.VulnerabilityTag{Name: "WPS_PIXIE", Severity: 8, DetectedAt: time.Now()}
+	if err := service.ProcessSensorDetections("AA:BB:CC:DD:EE:FF", "wlan0", []domain.VulnerabilityTag{vuln}); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.ProcessSensorDetections("AA:BB:CC:DD:EE:FF", "agent-b/wlan1", []domain.VulnerabilityTag{vuln}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(saved) != 2 {
+		t.Fatalf("expected one finding per workspace, got %d", len(saved))
+	}
+	if saved[0].Workspace != "" || saved[1].Workspace != "site-b" {
+		t.Errorf("unexpected workspaces %q, %q", saved[0].Workspace, saved[1].Workspace)
+	}
+	if saved[0].ID == saved[1].ID {
+		t.Error("findings of different workspaces must not share an ID")
+	}
+}
@@ -0,0 +1,301 @@
+// Package ticketing opens an issue in an external tracker for each severe
+// finding, so remediation is followed up where the owners work.
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+	"github.com/lcalzada-xor/wmap/internal/core/services/reporting"
+)
+
+// DefaultMinSeverity is the least severe finding filed.
+const DefaultMinSeverity = domain.SeverityCritical
+
+// fileTimeout bounds the tracker calls filing one finding.
+const fileTimeout = time.Minute
+
+// At most maxCreated issues are opened per createWindow, so that findings
+// forged over the air, under as many MACs as wanted, cannot flood the
+// tracker. Findings over the limit are filed when detected again.
+const (
+	maxCreated   = 10
+	createWindow = time.Hour
+)
+
+var errCreateLimit = fmt.Errorf("more than %d issues opened in %s, filing deferred", maxCreated, createWindow)
+
+// Service files alerts and vulnerabilities at or above a severity as issues,
+// with the device's details and the remediation of the report generator.
+// Each finding is filed once: its fingerprint is set as a label on the issue
+// and the tracker is searched for it first, so restarts and other instances
+// do not open duplicates, even once the issue is closed. Vulnerabilities are
+// filed per device, alerts per type, as the MAC of an alert is whatever the
+// frames that raised it claim.
+type Service struct {
+	tracker     ports.IssueTracker
+	registry    ports.DeviceRegistry
+	advisor     *reporting.RecommendationEngine
+	minSeverity domain.AlertSeverity
+
+	mu      sync.Mutex
+	tickets map[string]domain.Ticket // By fingerprint
+	pending map[string]bool          // Fingerprints being filed
+	created []time.Time              // Issues opened within createWindow
+	lastErr string
+}
+
+// NewService creates a service filing critical findings in tracker. The
+// registry, if any, provides the details of the devices.
+func NewService(tracker ports.IssueTracker, registry ports.DeviceRegistry) *Service {
+	return &Service{
+		tracker:     tracker,
+		registry:    registry,
+		advisor:     reporting.NewRecommendationEngine(),
+		minSeverity: DefaultMinSeverity,
+		tickets:     make(map[string]domain.Ticket),
+		pending:     make(map[string]bool),
+	}
+}
+
+// SetMinSeverity sets the least severe finding filed.
+func (s *Service) SetMinSeverity(min domain.AlertSeverity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.minSeverity = min
+}
+
+// Status lists the issues filed, newest first, and the last filing error.
+func (s *Service) Status() domain.TicketStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := domain.TicketStatus{
+		Tracker:     s.tracker.Name(),
+		MinSeverity: s.minSeverity,
+		Tickets:     make([]domain.Ticket, 0, len(s.tickets)),
+		Error:       s.lastErr,
+	}
+	for _, t := range s.tickets {
+		status.Tickets = append(status.Tickets, t)
+	}
+	sort.Slice(status.Tickets, func(i, j int) bool {
+		return status.Tickets[i].CreatedAt.After(status.Tickets[j].CreatedAt)
+	})
+	return status
+}
+
+// FileAlert opens an issue for the type of the alert unless it is below the
+// minimum severity or one was already filed for the type. The issue
+// describes the first alert of the type.
+func (s *Service) FileAlert(ctx context.Context, alert domain.Alert) {
+	if !s.wants(alert.Severity) {
+		return
+	}
+	name := alert.Subtype
+	if name == "" {
+		name = string(alert.Type)
+	}
+	mac := alert.DeviceMAC
+	if mac == "" {
+		mac = alert.BSSID
+	}
+	draft := domain.IssueDraft{
+		Title:       fmt.Sprintf("[WMAP] %s: %s alerts", strings.ToUpper(string(alert.Severity)), name),
+		Summary:     alert.Message + "\n\nFurther alerts of this type are not filed separately, see the alert history.",
+		Severity:    string(alert.Severity),
+		Remediation: s.advisor.RecommendationForAlert(alert),
+	}
+	if alert.Details != "" {
+		draft.Evidence = append(draft.Evidence, alert.Details)
+	}
+	draft.Fields = append(draft.Fields, domain.IssueField{Name: "Alert", Value: name})
+	draft.Fields = append(draft.Fields, s.deviceFields(ctx, mac)...)
+	for _, f := range []domain.IssueField{
+		{Name: "Target", Value: alert.TargetMAC},
+		{Name: "BSSID", Value: alert.BSSID},
+		{Name: "Sensor", Value: alert.Sensor},
+		{Name: "Evidence capture", Value: alert.EvidenceID},
+	} {
+		if f.Value != "" {
+			draft.Fields = append(draft.Fields, f)
+		}
+	}
+	if len(alert.CVEs) > 0 {
+		cves := make([]string, len(alert.CVEs))
+		for i, c := range alert.CVEs {
+			cves[i] = c.String()
+		}
+		draft.Fields = append(draft.Fields, domain.IssueField{Name: "CVEs", Value: strings.Join(cves, ", ")})
+	}
+	if !alert.Timestamp.IsZero() {
+		draft.Fields = append(draft.Fields, domain.IssueField{Name: "Raised", Value: alert.Timestamp.UTC().Format(time.RFC3339)})
+	}
+	s.file(ctx, domain.FindingFingerprint(domain.FindingAlert, "", name), domain.FindingAlert, mac, name, draft)
+}
+
+// NotifyNewVulnerability files a new vulnerability in the background.
+func (s *Service) NotifyNewVulnerability(ctx context.Context, vuln domain.VulnerabilityRecord) {
+	go s.FileVulnerability(context.Background(), vuln)
+}
+
+// NotifyVulnerabilityConfirmed files a confirmed vulnerability in the
+// background, unless it was already filed when detected.
+func (s *Service) NotifyVulnerabilityConfirmed(ctx context.Context, vuln domain.VulnerabilityRecord) {
+	go s.FileVulnerability(context.Background(), vuln)
+}
+
+// FileVulnerability opens an issue for the vulnerability unless it is below
+// the minimum severity, ignored, suppressed, or one was already filed for it.
+func (s *Service) FileVulnerability(ctx context.Context, vuln domain.VulnerabilityRecord) {
+	if vuln.Status == domain.VulnStatusIgnored || vuln.IsSuppressed() || !s.wants(domain.VulnerabilityAlertSeverity(vuln.Severity)) {
+		return
+	}
+	draft := domain.IssueDraft{
+		Title:       fmt.Sprintf("[WMAP] %s: %s on %s", vuln.Severity, vuln.Name, vuln.DeviceMAC),
+		Summary:     vuln.Description,
+		Severity:    strings.ToLower(vuln.Severity.String()),
+		Evidence:    ticketEvidence(vuln.Evidence),
+		Remediation: s.advisor.RecommendationFor(vuln.Name, 1),
+	}
+	draft.Fields = append(draft.Fields,
+		domain.IssueField{Name: "Vulnerability", Value: vuln.Name},
+		domain.IssueField{Name: "Confidence", Value: fmt.Sprintf("%.0f%%", float64(vuln.Confidence)*100)},
+	)
+	draft.Fields = append(draft.Fields, s.deviceFields(ctx, vuln.DeviceMAC)...)
+	if !vuln.FirstSeen.IsZero() {
+		draft.Fields = append(draft.Fields, domain.IssueField{Name: "First seen", Value: vuln.FirstSeen.UTC().Format(time.RFC3339)})
+	}
+	s.file(ctx, domain.FindingFingerprint(domain.FindingVulnerability, vuln.DeviceMAC, vuln.Name), domain.FindingVulnerability, vuln.DeviceMAC, vuln.Name, draft)
+}
+
+// ticketEvidence leaves recovered credentials out of the issue, which
+// everyone with access to the project reads.
+func ticketEvidence(evidence []string) []string {
+	result := make([]string, 0, len(evidence))
+	for _, entry := range evidence {
+		if key, _, source, ok := domain.ParseConfirmationEvidence(entry); ok && domain.IsCredentialEvidence(key) {
+			entry = domain.FormatConfirmationEvidence(key, "[redacted]", source)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// wants reports whether findings of a severity are filed.
+func (s *Service) wants(severity domain.AlertSeverity) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return domain.SeverityAtLeast(severity, s.minSeverity)
+}
+
+// deviceFields describes the device as the registry knows it.
+func (s *Service) deviceFields(ctx context.Context, mac string) []domain.IssueField {
+	fields := []domain.IssueField{{Name: "Device", Value: mac}}
+	if s.registry == nil || mac == "" {
+		return fields
+	}
+	device, ok := s.registry.GetDevice(ctx, mac)
+	if !ok {
+		return fields
+	}
+	candidates := []domain.IssueField{
+		{Name: "Type", Value: string(device.Type)},
+		{Name: "Vendor", Value: device.Vendor},
+		{Name: "SSID", Value: device.SSID},
+		{Name: "Security", Value: device.Security},
+	}
+	if device.Channel != 0 {
+		candidates = append(candidates, domain.IssueField{Name: "Channel", Value: fmt.Sprint(device.Channel)})
+	}
+	if !device.LastSeen.IsZero() {
+		candidates = append(candidates, domain.IssueField{Name: "Last seen", Value: device.LastSeen.UTC().Format(time.RFC3339)})
+	}
+	if a := device.Asset; a != nil {
+		candidates = append(candidates,
+			domain.IssueField{Name: "Owner", Value: a.Owner},
+			domain.IssueField{Name: "Asset tag", Value: a.AssetTag},
+			domain.IssueField{Name: "Criticality", Value: string(a.Criticality)},
+		)
+	}
+	for _, f := range candidates {
+		if f.Value != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// file opens the issue of a finding unless one was filed, here or, as found
+// by its fingerprint label, in the tracker, or maxCreated were opened within
+// createWindow.
+func (s *Service) file(ctx context.Context, fingerprint, kind, mac, name string, draft domain.IssueDraft) {
+	s.mu.Lock()
+	if _, ok := s.tickets[fingerprint]; ok || s.pending[fingerprint] {
+		s.mu.Unlock()
+		return
+	}
+	s.pending[fingerprint] = true
+	s.mu.Unlock()
+
+	draft.Fingerprint = fingerprint
+	draft.Labels = []string{"wmap", "wmap-" + kind, domain.TicketLabel(fingerprint)}
+
+	ctx, cancel := context.WithTimeout(ctx, fileTimeout)
+	defer cancel()
+	ticket, found, err := s.tracker.FindIssue(ctx, fingerprint)
+	if err == nil && !found {
+		if err = s.reserveCreate(); err == nil {
+			ticket, err = s.tracker.CreateIssue(ctx, draft)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.pending, fingerprint)
+	if err != nil {
+		s.lastErr = err.Error()
+		if errors.Is(err, errCreateLimit) {
+			return
+		}
+		log.Printf("[TICKETS] Filing %s %s of %s in %s failed: %v", kind, name, mac, s.tracker.Name(), err)
+		return
+	}
+	ticket.Finding = kind
+	ticket.DeviceMAC = mac
+	if ticket.Title == "" {
+		ticket.Title = draft.Title
+	}
+	s.tickets[fingerprint] = ticket
+	s.lastErr = ""
+	if !found {
+		log.Printf("[TICKETS] Filed %s %s of %s as %s", kind, name, mac, ticket.Key)
+	}
+}
+
+// reserveCreate counts an issue about to be opened, unless maxCreated were
+// opened within createWindow.
+func (s *Service) reserveCreate() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := time.Now().Add(-createWindow)
+	recent := s.created[:0]
+	for _, t := range s.created {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	s.created = recent
+	if len(s.created) >= maxCreated {
+		return errCreateLimit
+	}
+	s.created = append(s.created, time.Now())
+	return nil
+}
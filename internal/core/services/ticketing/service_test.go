@@ -0,0 +1,156 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/services/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTracker keeps issues in memory, searchable by fingerprint.
+type fakeTracker struct {
+	mu      sync.Mutex
+	issues  map[string]domain.IssueDraft
+	created int
+	err     error
+}
+
+func newFakeTracker() *fakeTracker {
+	return &fakeTracker{issues: make(map[string]domain.IssueDraft)}
+}
+
+func (f *fakeTracker) Name() string { return "fake:SEC" }
+
+func (f *fakeTracker) FindIssue(ctx context.Context, fingerprint string) (domain.Ticket, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return domain.Ticket{}, false, f.err
+	}
+	if draft, ok := f.issues[fingerprint]; ok {
+		return domain.Ticket{Key: "SEC-1", Title: draft.Title, Fingerprint: fingerprint, Existing: true}, true, nil
+	}
+	return domain.Ticket{}, false, nil
+}
+
+func (f *fakeTracker) CreateIssue(ctx context.Context, draft domain.IssueDraft) (domain.Ticket, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created++
+	f.issues[draft.Fingerprint] = draft
+	return domain.Ticket{Key: fmt.Sprintf("SEC-%d", f.created), Fingerprint: draft.Fingerprint}, nil
+}
+
+func TestService_FileAlert(t *testing.T) {
+	ctx := context.Background()
+	reg := registry.NewDeviceRegistry(nil, nil)
+	reg.ProcessDevice(ctx, domain.Device{
+		MAC: "00:11:22:33:44:55", Type: domain.DeviceTypeAP, SSID: "Corp", Vendor: "Acme",
+		Asset: &domain.AssetInfo{Owner: "IT", AssetTag: "AP-7"},
+	})
+	tracker := newFakeTracker()
+	svc := NewService(tracker, reg)
+
+	evilTwin := domain.Alert{
+		Type:      domain.AlertAnomaly,
+		Subtype:   "EVIL_TWIN_DETECTED",
+		Severity:  domain.SeverityCritical,
+		Message:   "Evil Twin Detected: Security Mismatch",
+		DeviceMAC: "00:11:22:33:44:55",
+	}
+	svc.FileAlert(ctx, evilTwin)
+	svc.FileAlert(ctx, evilTwin)
+	spoofed := evilTwin
+	spoofed.DeviceMAC = "02:00:00:00:00:01"
+	svc.FileAlert(ctx, spoofed)
+	svc.FileAlert(ctx, domain.Alert{Subtype: "NEW_DEVICE", Severity: domain.SeverityLow, DeviceMAC: "00:11:22:33:44:55"})
+
+	require.Equal(t, 1, tracker.created, "filed once per type, lower severities are not filed")
+	var draft domain.IssueDraft
+	for _, d := range tracker.issues {
+		draft = d
+	}
+	assert.Equal(t, "Remove the Rogue Access Point", draft.Remediation.Title)
+	assert.Contains(t, draft.Fields, domain.IssueField{Name: "SSID", Value: "Corp"})
+	assert.Contains(t, draft.Fields, domain.IssueField{Name: "Owner", Value: "IT"})
+	assert.Contains(t, draft.Labels, domain.TicketLabel(draft.Fingerprint))
+
+	status := svc.Status()
+	require.Len(t, status.Tickets, 1)
+	assert.Equal(t, "SEC-1", status.Tickets[0].Key)
+	assert.Equal(t, domain.FindingAlert, status.Tickets[0].Finding)
+	assert.Equal(t, domain.SeverityCritical, status.MinSeverity)
+
+	// A restarted instance finds the issue in the tracker instead of filing it again
+	restarted := NewService(tracker, nil)
+	restarted.FileAlert(ctx, evilTwin)
+	assert.Equal(t, 1, tracker.created)
+	require.Len(t, restarted.Status().Tickets, 1)
+	assert.True(t, restarted.Status().Tickets[0].Existing)
+}
+
+func TestService_FileVulnerability(t *testing.T) {
+	ctx := context.Background()
+	tracker := newFakeTracker()
+	svc := NewService(tracker, nil)
+	min, err := domain.ParseAlertSeverity("High")
+	require.NoError(t, err)
+	svc.SetMinSeverity(min)
+
+	vuln := domain.VulnerabilityRecord{
+		DeviceMAC: "00:11:22:33:44:55",
+		Name:      "WPS-PIXIE",
+		Severity:  domain.VulnSeverityHigh,
+		Status:    domain.VulnStatusActive,
+		Evidence: []string{
+			"WPS 2.0 enabled",
+			domain.FormatConfirmationEvidence("pin", "12345670", domain.ConfirmationSourceWPSAttack),
+		},
+	}
+	ignored := vuln
+	ignored.Name = "WPS-ENABLED"
+	ignored.Status = domain.VulnStatusIgnored
+	svc.FileVulnerability(ctx, ignored)
+	suppressed := vuln
+	suppressed.Name = "OPEN-NETWORK"
+	suppressed.Suppression = &domain.VulnerabilitySuppression{Justification: "Guest SSID is open by design"}
+	svc.FileVulnerability(ctx, suppressed)
+	svc.FileVulnerability(ctx, vuln)
+
+	require.Equal(t, 1, tracker.created)
+	draft := tracker.issues[domain.FindingFingerprint(domain.FindingVulnerability, vuln.DeviceMAC, vuln.Name)]
+	assert.Equal(t, "Disable WPS on All Access Points", draft.Remediation.Title)
+	assert.Equal(t, "high", draft.Severity)
+	require.Len(t, draft.Evidence, 2)
+	assert.NotContains(t, draft.Evidence[1], "12345670", "credentials are not sent to the tracker")
+
+	tracker.err = fmt.Errorf("tracker down")
+	vuln.Name = "KRACK"
+	svc.FileVulnerability(ctx, vuln)
+	assert.Equal(t, "tracker down", svc.Status().Error)
+	tracker.err = nil
+	svc.FileVulnerability(ctx, vuln)
+	assert.Empty(t, svc.Status().Error, "retried on the next detection")
+	assert.Len(t, svc.Status().Tickets, 2)
+}
+
+func TestService_CreateLimit(t *testing.T) {
+	ctx := context.Background()
+	tracker := newFakeTracker()
+	svc := NewService(tracker, nil)
+
+	vuln := domain.VulnerabilityRecord{Name: "WEP", Severity: domain.VulnSeverityCritical, Status: domain.VulnStatusActive}
+	for i := 0; i <= maxCreated; i++ {
+		vuln.DeviceMAC = fmt.Sprintf("02:00:00:00:00:%02x", i)
+		svc.FileVulnerability(ctx, vuln)
+	}
+
+	assert.Equal(t, maxCreated, tracker.created, "forged findings cannot flood the tracker")
+	assert.Contains(t, svc.Status().Error, "deferred")
+	assert.Len(t, svc.Status().Tickets, maxCreated)
+}
@@ -1,26 +1,49 @@
 package workspace
 
 import (
-"context"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/lcalzada-xor/wmap/internal/adapters/storage"
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
 	"github.com/lcalzada-xor/wmap/internal/core/ports"
 	"github.com/lcalzada-xor/wmap/internal/core/services/persistence"
 )
 
+// Workspace errors
+var (
+	ErrWorkspaceNotFound    = errors.New("workspace not found")
+	ErrInvalidWorkspaceName = errors.New("invalid workspace name")
+	ErrWorkspaceOpen        = errors.New("workspace is open")
+)
+
+// routesFile holds the workspace routes, next to the workspace databases.
+const routesFile = "routes.json"
+
+// SettingsApplier puts the settings of the active workspace into effect.
+type SettingsApplier func(ctx context.Context, settings domain.WorkspaceSettings)
+
 // WorkspaceManager handles the lifecycle of user workspaces (database files).
 type WorkspaceManager struct {
 	baseDir          string
 	currentWorkspace string
 	currentStorage   ports.Storage
 
+	// Workspaces open besides the active one, fed by the sensors routed to them
+	open   map[string]*storage.SQLiteAdapter
+	routes []domain.WorkspaceRoute
+
 	persistence *persistence.PersistenceManager
 	registry    ports.DeviceRegistry
+	apply       SettingsApplier // Optional, called when the active settings change
 
 	mu sync.RWMutex
 }
@@ -32,11 +55,19 @@ func NewWorkspaceManager(baseDir string, persistence *persistence.PersistenceMan
 		return nil, fmt.Errorf("failed to create workspace directory: %w", err)
 	}
 
-	return &WorkspaceManager{
+	s := &WorkspaceManager{
 		baseDir:     baseDir,
+		open:        make(map[string]*storage.SQLiteAdapter),
 		persistence: persistence,
 		registry:    registry,
-	}, nil
+	}
+	if err := s.loadRoutes(); err != nil {
+		return nil, fmt.Errorf("failed to load workspace routes: %w", err)
+	}
+	if persistence != nil {
+		persistence.SetRouter(s)
+	}
+	return s, nil
 }
 
 // ListWorkspaces returns a list of available workspace names.
@@ -95,10 +126,15 @@ func (s *WorkspaceManager) LoadWorkspace(name string) error {
 
 	path := filepath.Join(s.baseDir, name+".db")
 
-	// Initialize new storage
-	newStore, err := storage.NewSQLiteAdapter(path)
-	if err != nil {
-		return fmt.Errorf("failed to open workspace storage: %w", err)
+	// Initialize new storage, taking it over if the workspace is already open
+	newStore, ok := s.open[name]
+	if ok {
+		delete(s.open, name)
+	} else {
+		var err error
+		if newStore, err = storage.NewSQLiteAdapter(path); err != nil {
+			return fmt.Errorf("failed to open workspace storage: %w", err)
+		}
 	}
 
 	// Close old storage
@@ -132,10 +168,125 @@ func (s *WorkspaceManager) LoadWorkspace(name string) error {
 		// We use LoadDevice to restore state without resetting timestamps.
 		s.registry.LoadDevice(context.Background(), d)
 	}
+	for openName, store := range s.open {
+		if err := s.hydrate(store); err != nil {
+			return fmt.Errorf("failed to read devices of open workspace '%s': %w", openName, err)
+		}
+	}
+
+	// 4. Apply the workspace settings
+	if s.apply != nil {
+		settings, err := newStore.GetWorkspaceSettings(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to read workspace settings: %w", err)
+		}
+		s.apply(context.Background(), settings)
+	}
 
 	return nil
 }
 
+// SetSettingsApplier sets the function putting the settings of the active
+// workspace into effect, on load and whenever they are saved.
+func (s *WorkspaceManager) SetSettingsApplier(apply SettingsApplier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apply = apply
+}
+
+// GetSettings returns the settings stored in a workspace, loaded or not.
+func (s *WorkspaceManager) GetSettings(ctx context.Context, name string) (domain.WorkspaceSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	repo, release, err := s.settingsRepository(name)
+	if err != nil {
+		return domain.WorkspaceSettings{}, err
+	}
+	defer release()
+	return repo.GetWorkspaceSettings(ctx)
+}
+
+// SaveSettings validates and stores the settings of a workspace, applying
+// them right away if it is the active one.
+func (s *WorkspaceManager) SaveSettings(ctx context.Context, name string, settings domain.WorkspaceSettings) (domain.WorkspaceSettings, error) {
+	if err := settings.Validate(); err != nil {
+		return domain.WorkspaceSettings{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, release, err := s.settingsRepository(name)
+	if err != nil {
+		return domain.WorkspaceSettings{}, err
+	}
+	defer release()
+
+	// Enabling the baseline starts its learning period, as through its own
+	// endpoint; an enabled one keeps its start unless the caller gives one
+	if settings.Baseline.Enabled && settings.Baseline.StartedAt.IsZero() {
+		previous, err := repo.GetWorkspaceSettings(ctx)
+		if err != nil {
+			return domain.WorkspaceSettings{}, err
+		}
+		settings.Baseline.StartedAt = time.Now()
+		if previous.Baseline.Enabled {
+			settings.Baseline.StartedAt = previous.Baseline.StartedAt
+		}
+	}
+
+	settings.UpdatedAt = time.Now()
+	if err := repo.SaveWorkspaceSettings(ctx, settings); err != nil {
+		return domain.WorkspaceSettings{}, err
+	}
+	if name == s.currentWorkspace && s.apply != nil {
+		s.apply(ctx, settings)
+	}
+	return settings, nil
+}
+
+// settingsRepository returns the settings of a workspace, as workspaceStore.
+// Caller holds s.mu.
+func (s *WorkspaceManager) settingsRepository(name string) (ports.WorkspaceSettingsRepository, func(), error) {
+	store, release, err := s.workspaceStore(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo, ok := store.(ports.WorkspaceSettingsRepository)
+	if !ok {
+		release()
+		return nil, nil, fmt.Errorf("workspace storage does not support settings")
+	}
+	return repo, release, nil
+}
+
+// workspaceStore returns the storage of a workspace: the open one if it is
+// active or open, else its database opened until release is called. Caller
+// holds s.mu.
+func (s *WorkspaceManager) workspaceStore(name string) (ports.Storage, func(), error) {
+	if name == "" || strings.Contains(name, "/") || strings.Contains(name, "\\") || strings.Contains(name, "..") {
+		return nil, nil, ErrInvalidWorkspaceName
+	}
+
+	if name == s.currentWorkspace && s.currentStorage != nil {
+		return s.currentStorage, func() {}, nil
+	}
+	if store, ok := s.open[name]; ok {
+		return store, func() {}, nil
+	}
+
+	path := filepath.Join(s.baseDir, name+".db")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil, ErrWorkspaceNotFound
+	}
+	store, err := storage.NewSQLiteAdapter(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open workspace storage: %w", err)
+	}
+	return store, func() { store.Close() }, nil
+}
+
 // DeleteWorkspace deletes a workspace database file.
 func (s *WorkspaceManager) DeleteWorkspace(name string) error {
 	s.mu.Lock()
@@ -150,6 +301,9 @@ func (s *WorkspaceManager) DeleteWorkspace(name string) error {
 	if name == s.currentWorkspace {
 		return fmt.Errorf("cannot delete the currently active workspace")
 	}
+	if _, ok := s.open[name]; ok {
+		return ErrWorkspaceOpen
+	}
 
 	path := filepath.Join(s.baseDir, name+".db")
 
@@ -166,12 +320,187 @@ func (s *WorkspaceManager) DeleteWorkspace(name string) error {
 	return nil
 }
 
-// Close closes the current workspace.
+// Close closes the current workspace and any other open one.
 func (s *WorkspaceManager) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	for name, store := range s.open {
+		store.Close()
+		delete(s.open, name)
+	}
 	if s.currentStorage != nil {
 		return s.currentStorage.Close()
 	}
 	return nil
 }
+
+// OpenWorkspace opens a workspace besides the active one, creating it if
+// needed, so the sensors routed to it feed it at the same time. Its devices
+// join the live view.
+func (s *WorkspaceManager) OpenWorkspace(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name == "" || strings.Contains(name, "/") || strings.Contains(name, "\\") || strings.Contains(name, "..") {
+		return ErrInvalidWorkspaceName
+	}
+	if _, ok := s.open[name]; ok || name == s.currentWorkspace {
+		return nil
+	}
+
+	store, err := storage.NewSQLiteAdapter(filepath.Join(s.baseDir, name+".db"))
+	if err != nil {
+		return fmt.Errorf("failed to open workspace storage: %w", err)
+	}
+	if err := s.hydrate(store); err != nil {
+		store.Close()
+		return fmt.Errorf("accessed DB but failed to read devices: %w", err)
+	}
+	s.open[name] = store
+	return nil
+}
+
+// CloseWorkspace closes a workspace opened besides the active one. Devices
+// of the sensors routed to it go to the active workspace again.
+func (s *WorkspaceManager) CloseWorkspace(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, ok := s.open[name]
+	if !ok {
+		return ErrWorkspaceNotFound
+	}
+	delete(s.open, name)
+	return store.Close()
+}
+
+// OpenWorkspaces returns the names of the workspaces open besides the active one.
+func (s *WorkspaceManager) OpenWorkspaces() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.open))
+	for name := range s.open {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Routes returns the rules sending the devices of sensors to workspaces.
+func (s *WorkspaceManager) Routes() []domain.WorkspaceRoute {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]domain.WorkspaceRoute{}, s.routes...)
+}
+
+// SetRoutes validates and stores the rules sending the devices of sensors
+// to workspaces, the first matching rule winning.
+func (s *WorkspaceManager) SetRoutes(routes []domain.WorkspaceRoute) error {
+	for i, r := range routes {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("route %d: %w", i, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(routes, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.WriteFile(filepath.Join(s.baseDir, routesFile), data, 0600); err != nil {
+		return fmt.Errorf("failed to save workspace routes: %w", err)
+	}
+	s.routes = append([]domain.WorkspaceRoute(nil), routes...)
+	return nil
+}
+
+// Route returns the open workspace receiving the devices heard by sensor and
+// its storage, or an empty name for the active workspace. Routes to a
+// workspace that is not open fall back to the active one.
+func (s *WorkspaceManager) Route(sensor string) (string, ports.Storage) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	name := domain.RouteWorkspace(s.routes, sensor)
+	store, ok := s.open[name]
+	if !ok {
+		return "", nil
+	}
+	return name, store
+}
+
+// WorkspaceOf returns the workspace recording what sensor hears: the open
+// workspace it is routed to, else the active one.
+func (s *WorkspaceManager) WorkspaceOf(sensor string) string {
+	name, _ := s.Route(sensor)
+	if name == "" {
+		return s.GetCurrentWorkspace()
+	}
+	return name
+}
+
+// WorkspaceOfDevice returns the workspace recording what the sensor that
+// first heard mac hears, the active one for devices not in the registry.
+func (s *WorkspaceManager) WorkspaceOfDevice(ctx context.Context, mac string) string {
+	if device, ok := s.registry.GetDevice(ctx, mac); ok {
+		return s.WorkspaceOf(device.Sensor)
+	}
+	return s.GetCurrentWorkspace()
+}
+
+// Devices returns the devices of a workspace. Those of the active workspace
+// are taken live from the registry, which holds them all, and the others
+// read from their database: open workspaces get every observation of the
+// sensors routed to them, while the registry only knows the first sensor of
+// each device.
+func (s *WorkspaceManager) Devices(ctx context.Context, name string) ([]domain.Device, error) {
+	s.mu.RLock()
+	live := name == s.currentWorkspace
+	s.mu.RUnlock()
+
+	if live {
+		var devices []domain.Device
+		for _, d := range s.registry.GetAllDevices(ctx) {
+			if s.WorkspaceOf(d.Sensor) == name {
+				devices = append(devices, d)
+			}
+		}
+		return devices, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	store, release, err := s.workspaceStore(name)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return store.GetAllDevices(ctx)
+}
+
+// loadRoutes reads the stored workspace routes, none if never saved.
+func (s *WorkspaceManager) loadRoutes() error {
+	data, err := os.ReadFile(filepath.Join(s.baseDir, routesFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.routes)
+}
+
+// hydrate loads the devices of a workspace into the registry.
+func (s *WorkspaceManager) hydrate(store ports.Storage) error {
+	devices, err := store.GetAllDevices(context.Background())
+	if err != nil {
+		return err
+	}
+	for _, d := range devices {
+		s.registry.LoadDevice(context.Background(), d)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/services/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceManager_Settings(t *testing.T) {
+	mgr, err := NewWorkspaceManager(t.TempDir(), nil, registry.NewDeviceRegistry(nil, nil))
+	require.NoError(t, err)
+	defer mgr.Close()
+	ctx := context.Background()
+
+	var applied []domain.WorkspaceSettings
+	mgr.SetSettingsApplier(func(ctx context.Context, settings domain.WorkspaceSettings) {
+		applied = append(applied, settings)
+	})
+
+	require.NoError(t, mgr.CreateWorkspace("site-a"))
+	require.NoError(t, mgr.CreateWorkspace("site-b")) // Now the active one
+	require.Len(t, applied, 2)
+
+	// Settings of an inactive workspace are stored, not applied
+	saved, err := mgr.SaveSettings(ctx, "site-a", domain.WorkspaceSettings{DeviceRetention: time.Hour})
+	require.NoError(t, err)
+	assert.False(t, saved.UpdatedAt.IsZero())
+	assert.Len(t, applied, 2)
+
+	got, err := mgr.GetSettings(ctx, "site-a")
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, got.DeviceRetention)
+
+	// The active workspace applies them right away
+	_, err = mgr.SaveSettings(ctx, "site-b", domain.WorkspaceSettings{DeviceRetention: 2 * time.Hour})
+	require.NoError(t, err)
+	require.Len(t, applied, 3)
+	assert.Equal(t, 2*time.Hour, applied[2].DeviceRetention)
+
+	// And loading a workspace applies its own
+	require.NoError(t, mgr.LoadWorkspace("site-a"))
+	assert.Equal(t, time.Hour, applied[len(applied)-1].DeviceRetention)
+
+	// Enabling the baseline starts learning, saving again keeps the start
+	saved, err = mgr.SaveSettings(ctx, "site-a", domain.WorkspaceSettings{Baseline: domain.BaselineConfig{Enabled: true, LearningPeriod: time.Hour}})
+	require.NoError(t, err)
+	assert.False(t, saved.Baseline.StartedAt.IsZero())
+	again, err := mgr.SaveSettings(ctx, "site-a", domain.WorkspaceSettings{Baseline: domain.BaselineConfig{Enabled: true, LearningPeriod: time.Hour}})
+	require.NoError(t, err)
+	assert.True(t, saved.Baseline.StartedAt.Equal(again.Baseline.StartedAt))
+
+	_, err = mgr.GetSettings(ctx, "missing")
+	assert.ErrorIs(t, err, ErrWorkspaceNotFound)
+	_, err = mgr.SaveSettings(ctx, "site-a", domain.WorkspaceSettings{DeviceRetention: -time.Hour})
+	assert.ErrorIs(t, err, domain.ErrInvalidRetention)
+}
+
+func TestWorkspaceManager_OpenWorkspaces(t *testing.T) {
+	dir := t.TempDir()
+	reg := registry.NewDeviceRegistry(nil, nil)
+	mgr, err := NewWorkspaceManager(dir, nil, reg)
+	require.NoError(t, err)
+	defer mgr.Close()
+	ctx := context.Background()
+
+	require.NoError(t, mgr.CreateWorkspace("site-a"))
+	require.NoError(t, mgr.OpenWorkspace("site-b"))
+	assert.Equal(t, []string{"site-b"}, mgr.OpenWorkspaces())
+	assert.ErrorIs(t, mgr.DeleteWorkspace("site-b"), ErrWorkspaceOpen)
+
+	require.NoError(t, mgr.SetRoutes([]domain.WorkspaceRoute{
+		{Agent: "agent-b", Workspace: "site-b"},
+		{Interface: "wlan2", Workspace: "site-c"}, // Not open
+	}))
+	name, store := mgr.Route("agent-b/wlan0")
+	assert.Equal(t, "site-b", name)
+	require.NotNil(t, store)
+	name, _ = mgr.Route("wlan2")
+	assert.Empty(t, name, "routes to closed workspaces fall back to the active one")
+	name, _ = mgr.Route("wlan0")
+	assert.Empty(t, name)
+
+	// Devices of an open workspace join the live view, also after a switch
+	require.NoError(t, store.SaveDevicesBatch(ctx, []domain.Device{{MAC: "AA:BB:CC:00:00:01", LastSeen: time.Now()}}))
+	require.NoError(t, mgr.LoadWorkspace("site-a"))
+	_, found := reg.GetDevice(ctx, "AA:BB:CC:00:00:01")
+	assert.True(t, found)
+
+	// Routes survive a restart
+	reopened, err := NewWorkspaceManager(dir, nil, reg)
+	require.NoError(t, err)
+	assert.Len(t, reopened.Routes(), 2)
+
+	require.NoError(t, mgr.CloseWorkspace("site-b"))
+	assert.Empty(t, mgr.OpenWorkspaces())
+	name, _ = mgr.Route("agent-b/wlan0")
+	assert.Empty(t, name)
+	assert.ErrorIs(t, mgr.CloseWorkspace("site-b"), ErrWorkspaceNotFound)
+	assert.ErrorIs(t, mgr.SetRoutes([]domain.WorkspaceRoute{{Workspace: "x"}}), domain.ErrEmptyRouteSensor)
+}
+
+func TestWorkspaceManager_Devices(t *testing.T) {
+	dir := t.TempDir()
+	reg := registry.NewDeviceRegistry(nil, nil)
+	mgr, err := NewWorkspaceManager(dir, nil, reg)
+	require.NoError(t, err)
+	defer mgr.Close()
+	ctx := context.Background()
+
+	require.NoError(t, mgr.CreateWorkspace("archive"))
+	require.NoError(t, mgr.CreateWorkspace("site-a"))
+	require.NoError(t, mgr.LoadWorkspace("archive"))
+	require.NoError(t, mgr.LoadWorkspace("site-a"))
+	require.NoError(t, mgr.OpenWorkspace("site-b"))
+	require.NoError(t, mgr.SetRoutes([]domain.WorkspaceRoute{{Agent: "agent-b", Workspace: "site-b"}}))
+
+	reg.ProcessDevice(ctx, domain.Device{MAC: "AA:BB:CC:00:00:01", Sensor: "wlan0", LastPacketTime: time.Now()})
+	reg.ProcessDevice(ctx, domain.Device{MAC: "AA:BB:CC:00:00:02", Sensor: "agent-b/", LastPacketTime: time.Now()})
+	assert.Equal(t, "site-b", mgr.WorkspaceOf("agent-b/"))
+	assert.Equal(t, "site-a", mgr.WorkspaceOf("wlan0"))
+	assert.Equal(t, "site-b", mgr.WorkspaceOfDevice(ctx, "AA:BB:CC:00:00:02"))
+	assert.Equal(t, "site-a", mgr.WorkspaceOfDevice(ctx, "AA:BB:CC:00:00:99"), "unknown devices go to the active workspace")
+
+	// Open workspaces are read from their database, written per observation
+	_, store := mgr.Route("agent-b/")
+	require.NotNil(t, store)
+	require.NoError(t, store.SaveDevice(ctx, domain.Device{MAC: "AA:BB:CC:00:00:02", Sensor: "agent-b/"}))
+
+	devices, err := mgr.Devices(ctx, "site-a")
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "AA:BB:CC:00:00:01", devices[0].MAC)
+
+	devices, err = mgr.Devices(ctx, "site-b")
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, "AA:BB:CC:00:00:02", devices[0].MAC)
+
+	// Closed workspaces are read from their database
+	devices, err = mgr.Devices(ctx, "archive")
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+	_, err = mgr.Devices(ctx, "missing")
+	assert.ErrorIs(t, err, ErrWorkspaceNotFound)
+}
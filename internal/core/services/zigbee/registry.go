@@ -0,0 +1,210 @@
+package zigbee
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/lcalzada-xor/wmap/internal/core/ports"
+)
+
+// RetryInterval is how long to wait before reopening a failed sniffer, such
+// as an unplugged dongle.
+const RetryInterval = 10 * time.Second
+
+// broadcastPAN is the PAN ID of frames not addressed to a particular network.
+const broadcastPAN = 0xFFFF
+
+// roleRank orders roles by how much they tell: a node seen as coordinator is
+// never downgraded by a later frame.
+var roleRank = map[domain.ZigbeeRole]int{
+	domain.ZigbeeRoleUnknown:     0,
+	domain.ZigbeeRoleEndDevice:   1,
+	domain.ZigbeeRoleRouter:      2,
+	domain.ZigbeeRoleCoordinator: 3,
+}
+
+// Registry keeps the 802.15.4 networks and devices seen by a sniffer
+// dongle, as a group of its own next to the Wi-Fi device registry.
+type Registry struct {
+	sniffer ports.ZigbeeSniffer
+
+	mu       sync.RWMutex
+	networks map[uint16]*domain.ZigbeeNetwork
+	devices  map[string]*domain.ZigbeeDevice // By PAN ID and address
+	received int                             // Frames observed
+}
+
+// NewRegistry creates an empty registry fed by sniffer.
+func NewRegistry(sniffer ports.ZigbeeSniffer) *Registry {
+	return &Registry{
+		sniffer:  sniffer,
+		networks: make(map[uint16]*domain.ZigbeeNetwork),
+		devices:  make(map[string]*domain.ZigbeeDevice),
+	}
+}
+
+// Run feeds the registry from the sniffer until ctx is done, reopening it
+// when it fails. A dongle failing again before any frame is logged once.
+func (r *Registry) Run(ctx context.Context) {
+	logged := -1
+	for {
+		err := r.sniffer.Run(ctx, r.Observe)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = errors.New("serial port closed")
+		}
+		r.mu.RLock()
+		received := r.received
+		r.mu.RUnlock()
+		if received != logged {
+			log.Printf("[ZIGBEE] Sniffer stopped: %v, retrying every %v", err, RetryInterval)
+			logged = received
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(RetryInterval):
+		}
+	}
+}
+
+// Observe records the transmitter, receiver and network of a frame.
+func (r *Registry) Observe(frame domain.ZigbeeFrame) {
+	if frame.Timestamp.IsZero() {
+		frame.Timestamp = time.Now()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received++
+
+	if frame.SrcAddr != "" && frame.SrcPAN != broadcastPAN {
+		device := r.device(frame.SrcPAN, frame.SrcAddr, frame)
+		device.Channel = frame.Channel
+		device.RSSI = frame.RSSI
+		device.LQI = frame.LQI
+		device.Frames++
+		device.LastSeen = frame.Timestamp
+		device.Encrypted = device.Encrypted || frame.Encrypted
+		promote(device, roleOf(frame))
+		r.network(frame.SrcPAN, frame)
+	}
+	if frame.DstAddr != "" && frame.DstAddr != domain.ZigbeeBroadcastAddress && frame.DstPAN != broadcastPAN {
+		r.device(frame.DstPAN, frame.DstAddr, frame)
+		r.network(frame.DstPAN, frame)
+	}
+
+	if frame.Beacon != nil && frame.SrcAddr != "" {
+		network := r.network(frame.SrcPAN, frame)
+		// Any parent may open the network; only the coordinator closes it
+		if frame.Beacon.PermitJoining || frame.Beacon.PANCoordinator {
+			network.PermitJoining = frame.Beacon.PermitJoining
+		}
+		if frame.Beacon.ExtendedPANID != "" {
+			network.ExtendedPANID = frame.Beacon.ExtendedPANID
+			network.StackProfile = frame.Beacon.StackProfile
+		}
+		if frame.Beacon.PANCoordinator {
+			network.Coordinator = frame.SrcAddr
+		}
+	}
+}
+
+// ZigbeeSurvey returns the networks, most recently seen first, and their
+// devices.
+func (r *Registry) ZigbeeSurvey() domain.ZigbeeSurvey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	perNetwork := make(map[uint16]int)
+	survey := domain.ZigbeeSurvey{
+		Networks: make([]domain.ZigbeeNetwork, 0, len(r.networks)),
+		Devices:  make([]domain.ZigbeeDevice, 0, len(r.devices)),
+	}
+	for _, d := range r.devices {
+		survey.Devices = append(survey.Devices, *d)
+		perNetwork[d.PANID]++
+	}
+	for _, n := range r.networks {
+		network := *n
+		network.Devices = perNetwork[n.PANID]
+		survey.Networks = append(survey.Networks, network)
+	}
+	sort.Slice(survey.Networks, func(i, j int) bool {
+		return survey.Networks[i].LastSeen.After(survey.Networks[j].LastSeen)
+	})
+	sort.Slice(survey.Devices, func(i, j int) bool {
+		a, b := survey.Devices[i], survey.Devices[j]
+		if a.PANID != b.PANID {
+			return a.PANID < b.PANID
+		}
+		return a.Address < b.Address
+	})
+	return survey
+}
+
+// device returns the device at address in pan, adding it if new.
+func (r *Registry) device(pan uint16, address string, frame domain.ZigbeeFrame) *domain.ZigbeeDevice {
+	key := fmt.Sprintf("%04x/%s", pan, address)
+	device, ok := r.devices[key]
+	if !ok {
+		device = &domain.ZigbeeDevice{
+			Address:   address,
+			PANID:     pan,
+			Role:      domain.ZigbeeRoleUnknown,
+			Channel:   frame.Channel,
+			FirstSeen: frame.Timestamp,
+			LastSeen:  frame.Timestamp,
+		}
+		if address == domain.ZigbeeCoordinatorAddress {
+			device.Role = domain.ZigbeeRoleCoordinator
+		}
+		r.devices[key] = device
+	}
+	return device
+}
+
+// network returns the network of pan, adding it if new, and marks it seen.
+func (r *Registry) network(pan uint16, frame domain.ZigbeeFrame) *domain.ZigbeeNetwork {
+	network, ok := r.networks[pan]
+	if !ok {
+		network = &domain.ZigbeeNetwork{PANID: pan, FirstSeen: frame.Timestamp}
+		r.networks[pan] = network
+	}
+	network.Channel = frame.Channel
+	if frame.Timestamp.After(network.LastSeen) {
+		network.LastSeen = frame.Timestamp
+	}
+	if network.Coordinator == "" && frame.SrcAddr == domain.ZigbeeCoordinatorAddress && frame.SrcPAN == pan {
+		network.Coordinator = frame.SrcAddr
+	}
+	return network
+}
+
+// roleOf returns what a frame tells of its transmitter's role.
+func roleOf(frame domain.ZigbeeFrame) domain.ZigbeeRole {
+	switch {
+	case frame.SrcAddr == domain.ZigbeeCoordinatorAddress:
+		return domain.ZigbeeRoleCoordinator
+	case frame.Beacon != nil && frame.Beacon.PANCoordinator:
+		return domain.ZigbeeRoleCoordinator
+	case frame.Beacon != nil:
+		return domain.ZigbeeRoleRouter // Only routers answer beacon requests
+	case frame.DataRequest:
+		return domain.ZigbeeRoleEndDevice // Sleeping devices poll their parent
+	}
+	return domain.ZigbeeRoleUnknown
+}
+
+func promote(device *domain.ZigbeeDevice, role domain.ZigbeeRole) {
+	if roleRank[role] > roleRank[device.Role] {
+		device.Role = role
+	}
+}
@@ -0,0 +1,55 @@
+package zigbee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Observe(t *testing.T) {
+	r := NewRegistry(nil)
+	now := time.Now()
+
+	r.Observe(domain.ZigbeeFrame{
+		Type: domain.ZigbeeFrameBeacon, Channel: 15, RSSI: -50, Timestamp: now,
+		SrcPAN: 0x1a62, SrcAddr: "0x0000",
+		Beacon: &domain.ZigbeeBeacon{PANCoordinator: true, PermitJoining: true, StackProfile: 2, ExtendedPANID: "01:02:03:04:05:06:07:08"},
+	})
+	r.Observe(domain.ZigbeeFrame{
+		Type: domain.ZigbeeFrameBeacon, Channel: 15, RSSI: -70, Timestamp: now,
+		SrcPAN: 0x1a62, SrcAddr: "0x4f21", Beacon: &domain.ZigbeeBeacon{},
+	})
+	r.Observe(domain.ZigbeeFrame{
+		Type: domain.ZigbeeFrameCommand, DataRequest: true, Channel: 15, RSSI: -80, Timestamp: now.Add(time.Second),
+		SrcPAN: 0x1a62, SrcAddr: "00:12:4b:05:04:03:02:01", DstPAN: 0x1a62, DstAddr: "0x4f21",
+	})
+	r.Observe(domain.ZigbeeFrame{
+		Type: domain.ZigbeeFrameData, Encrypted: true, Channel: 15, Timestamp: now.Add(2 * time.Second),
+		SrcPAN: 0x1a62, SrcAddr: "0x4f21", DstPAN: 0x1a62, DstAddr: domain.ZigbeeBroadcastAddress,
+	})
+	r.Observe(domain.ZigbeeFrame{Type: domain.ZigbeeFrameAck, Channel: 15, Timestamp: now}) // No addresses
+
+	survey := r.ZigbeeSurvey()
+	require.Len(t, survey.Networks, 1)
+	network := survey.Networks[0]
+	assert.Equal(t, uint16(0x1a62), network.PANID)
+	assert.Equal(t, "01:02:03:04:05:06:07:08", network.ExtendedPANID)
+	assert.Equal(t, "0x0000", network.Coordinator)
+	assert.Equal(t, 2, network.StackProfile)
+	assert.True(t, network.PermitJoining)
+	assert.Equal(t, 3, network.Devices)
+
+	roles := make(map[string]domain.ZigbeeDevice)
+	for _, d := range survey.Devices {
+		roles[d.Address] = d
+	}
+	assert.Equal(t, domain.ZigbeeRoleCoordinator, roles["0x0000"].Role)
+	assert.Equal(t, domain.ZigbeeRoleRouter, roles["0x4f21"].Role)
+	assert.True(t, roles["0x4f21"].Encrypted)
+	assert.Equal(t, 2, roles["0x4f21"].Frames)
+	assert.Equal(t, domain.ZigbeeRoleEndDevice, roles["00:12:4b:05:04:03:02:01"].Role)
+	assert.Equal(t, -80, roles["00:12:4b:05:04:03:02:01"].RSSI)
+}
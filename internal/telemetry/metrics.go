@@ -57,6 +57,15 @@ var (
 		[]string{"interface", "type"},
 	)
 
+	// BatteryLevel reports the charge of the sensor's battery, when it has one
+	BatteryLevel = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "wmap",
+			Name:      "battery_percent",
+			Help:      "Remaining charge of the sensor battery in percent",
+		},
+	)
+
 	// Ensure metrics are only registered once
 	once sync.Once
 )
@@ -72,5 +81,6 @@ func InitMetrics() {
 		prometheus.DefaultRegisterer.Register(PacketsDropped)
 		prometheus.DefaultRegisterer.Register(InjectionsTotal)
 		prometheus.DefaultRegisterer.Register(InjectionErrors)
+		prometheus.DefaultRegisterer.Register(BatteryLevel)
 	})
 }
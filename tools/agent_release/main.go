@@ -0,0 +1,87 @@
+// Command agent_release signs wmap-agent releases for self-update.
+//
+// Generate the release key pair once; the public key goes to the agents'
+// -release-key flag and the private key stays off the server:
+//
+//	agent_release -keygen -key release.key
+//
+// Sign a build and write the release file the server advertises with
+// -agent-release:
+//
+//	agent_release -key release.key -binary wmap-agent -version v1.4.0 \
+//	    -url https://example.com/wmap-agent-v1.4.0 > release.json
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/lcalzada-xor/wmap/internal/core/domain"
+)
+
+func main() {
+	keyPath := flag.String("key", "release.key", "Path to the base64 Ed25519 private key")
+	keygen := flag.Bool("keygen", false, "Generate a key pair, write the private key and print the public key")
+	binary := flag.String("binary", "", "Agent binary to sign")
+	version := flag.String("version", "", "Version the binary was built with (-X main.version)")
+	url := flag.String("url", "", "URL the agents download the binary from")
+	flag.Parse()
+
+	if *keygen {
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			log.Fatalf("Failed to generate key: %v", err)
+		}
+		if err := os.WriteFile(*keyPath, []byte(base64.StdEncoding.EncodeToString(private)+"\n"), 0600); err != nil {
+			log.Fatalf("Failed to write key: %v", err)
+		}
+		fmt.Println(base64.StdEncoding.EncodeToString(public))
+		return
+	}
+
+	if *binary == "" || *version == "" || *url == "" {
+		log.Fatalf("-binary, -version and -url are required")
+	}
+	data, err := os.ReadFile(*keyPath)
+	if err != nil {
+		log.Fatalf("Failed to read key: %v", err)
+	}
+	private, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(private) != ed25519.PrivateKeySize {
+		log.Fatalf("%s is not a base64 Ed25519 private key", *keyPath)
+	}
+
+	f, err := os.Open(*binary)
+	if err != nil {
+		log.Fatalf("Failed to open binary: %v", err)
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		log.Fatalf("Failed to read binary: %v", err)
+	}
+
+	release := domain.AgentUpdate{
+		Version: *version,
+		URL:     *url,
+		SHA256:  hex.EncodeToString(hash.Sum(nil)),
+	}
+	release.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(ed25519.PrivateKey(private), release.SignedMessage()))
+	if err := release.Validate(); err != nil {
+		log.Fatalf("Invalid release: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(release)
+}